@@ -15,9 +15,12 @@
 package main
 
 import (
+	"context"
+
 	"github.com/nitrictech/nitric/cloud/aws/runtime"
 	"github.com/nitrictech/nitric/cloud/aws/runtime/env"
 	"github.com/nitrictech/nitric/cloud/aws/runtime/resource"
+	"github.com/nitrictech/nitric/cloud/common/runtime/telemetry"
 	"github.com/nitrictech/nitric/core/pkg/logger"
 	"github.com/nitrictech/nitric/core/pkg/server"
 )
@@ -37,6 +40,10 @@ func main() {
 		return
 	}
 
+	if err := telemetry.Setup(context.Background()); err != nil {
+		logger.Fatalf("could not set up telemetry: %v", err)
+	}
+
 	m, err := runtime.NewAwsRuntimeServer(resolver)
 	if err != nil {
 		logger.Fatalf("there was an error initializing the AWS runtime server: %v", err)
@@ -33,7 +33,9 @@ import (
 	"github.com/nitrictech/nitric/cloud/common/deploy/provider"
 	"github.com/nitrictech/nitric/cloud/common/deploy/pulumix"
 	"github.com/nitrictech/nitric/cloud/common/deploy/tags"
+	deploymentspb "github.com/nitrictech/nitric/core/pkg/proto/deployments/v1"
 	resourcespb "github.com/nitrictech/nitric/core/pkg/proto/resources/v1"
+	topicspb "github.com/nitrictech/nitric/core/pkg/proto/topics/v1"
 	"github.com/pulumi/pulumi-aws/sdk/v5/go/aws/apigatewayv2"
 	"github.com/pulumi/pulumi-aws/sdk/v5/go/aws/batch"
 	"github.com/pulumi/pulumi-aws/sdk/v5/go/aws/dynamodb"
@@ -94,10 +96,16 @@ type NitricAwsPulumiProvider struct {
 	BucketNotifications   map[string]*s3.BucketNotification
 	Topics                map[string]*topic
 	Queues                map[string]*sqs.Queue
-	Websockets            map[string]*apigatewayv2.Api
-	KeyValueStores        map[string]*dynamodb.Table
-	JobDefinitions        map[string]*batch.JobDefinition
-	Schedules             map[string]*scheduler.Schedule
+	// Whether each queue is a FIFO queue, dead-letter targets must be the same type as the queues that use them
+	FifoQueues map[string]bool
+	// Queue policies allowing SNS to move undeliverable topic messages to dead-letter queues
+	DeadLetterQueuePolicies map[string]*sqs.QueuePolicy
+	// The retry policy of each service's subscriptions, lambda configures retries per function rather than per subscription
+	SubscriberRetryPolicies map[string]*topicspb.SubscriptionRetryPolicy
+	Websockets              map[string]*apigatewayv2.Api
+	KeyValueStores          map[string]*dynamodb.Table
+	JobDefinitions          map[string]*batch.JobDefinition
+	Schedules               map[string]*scheduler.Schedule
 
 	provider.NitricDefaultOrder
 
@@ -134,6 +142,33 @@ func (a *NitricAwsPulumiProvider) Init(attributes map[string]interface{}) error
 }
 
 func (a *NitricAwsPulumiProvider) Pre(ctx *pulumi.Context, resources []*pulumix.NitricPulumiResource[any]) error {
+	err := ValidateSubscriberRetryPolicies(
+		lo.FilterMap(resources, func(item *pulumix.NitricPulumiResource[any], idx int) (*deploymentspb.Topic, bool) {
+			if config, ok := item.Config.(*deploymentspb.Resource_Topic); ok {
+				return config.Topic, true
+			}
+
+			return nil, false
+		}),
+		lo.FilterMap(resources, func(item *pulumix.NitricPulumiResource[any], idx int) (*deploymentspb.Schedule, bool) {
+			if config, ok := item.Config.(*deploymentspb.Resource_Schedule); ok {
+				return config.Schedule, true
+			}
+
+			return nil, false
+		}),
+		lo.FilterMap(resources, func(item *pulumix.NitricPulumiResource[any], idx int) (*deploymentspb.Bucket, bool) {
+			if config, ok := item.Config.(*deploymentspb.Resource_Bucket); ok {
+				return config.Bucket, true
+			}
+
+			return nil, false
+		}),
+	)
+	if err != nil {
+		return err
+	}
+
 	// make our random stackId
 	stackRandId, err := random.NewRandomString(ctx, fmt.Sprintf("%s-stack-name", ctx.Stack()), &random.RandomStringArgs{
 		Special: pulumi.Bool(false),
@@ -300,21 +335,24 @@ func (a *NitricAwsPulumiProvider) Result(ctx *pulumi.Context) (pulumi.StringOutp
 
 func NewNitricAwsProvider() *NitricAwsPulumiProvider {
 	return &NitricAwsPulumiProvider{
-		Lambdas:               make(map[string]*lambda.Function),
-		LambdaRoles:           make(map[string]*iam.Role),
-		BatchRoles:            make(map[string]*iam.Role),
-		Apis:                  make(map[string]*apigatewayv2.Api),
-		HttpProxies:           make(map[string]*apigatewayv2.Api),
-		Secrets:               make(map[string]*secretsmanager.Secret),
-		Schedules:             make(map[string]*scheduler.Schedule),
-		Buckets:               make(map[string]*s3.Bucket),
-		BucketNotifications:   make(map[string]*s3.BucketNotification),
-		Websockets:            make(map[string]*apigatewayv2.Api),
-		Topics:                make(map[string]*topic),
-		Queues:                make(map[string]*sqs.Queue),
-		KeyValueStores:        make(map[string]*dynamodb.Table),
-		DatabaseMigrationJobs: make(map[string]*codebuild.Project),
-		SqlDatabases:          make(map[string]*RdsDatabase),
-		JobDefinitions:        make(map[string]*batch.JobDefinition),
+		Lambdas:                 make(map[string]*lambda.Function),
+		LambdaRoles:             make(map[string]*iam.Role),
+		BatchRoles:              make(map[string]*iam.Role),
+		Apis:                    make(map[string]*apigatewayv2.Api),
+		HttpProxies:             make(map[string]*apigatewayv2.Api),
+		Secrets:                 make(map[string]*secretsmanager.Secret),
+		Schedules:               make(map[string]*scheduler.Schedule),
+		Buckets:                 make(map[string]*s3.Bucket),
+		BucketNotifications:     make(map[string]*s3.BucketNotification),
+		Websockets:              make(map[string]*apigatewayv2.Api),
+		Topics:                  make(map[string]*topic),
+		Queues:                  make(map[string]*sqs.Queue),
+		FifoQueues:              make(map[string]bool),
+		DeadLetterQueuePolicies: make(map[string]*sqs.QueuePolicy),
+		SubscriberRetryPolicies: make(map[string]*topicspb.SubscriptionRetryPolicy),
+		KeyValueStores:          make(map[string]*dynamodb.Table),
+		DatabaseMigrationJobs:   make(map[string]*codebuild.Project),
+		SqlDatabases:            make(map[string]*RdsDatabase),
+		JobDefinitions:          make(map[string]*batch.JobDefinition),
 	}
 }
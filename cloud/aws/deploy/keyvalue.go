@@ -17,12 +17,20 @@
 package deploy
 
 import (
+	"encoding/json"
+	"fmt"
+	"sort"
+
 	"github.com/nitrictech/nitric/cloud/common/deploy/resources"
 	"github.com/nitrictech/nitric/cloud/common/deploy/tags"
 	deploymentspb "github.com/nitrictech/nitric/core/pkg/proto/deployments/v1"
 	v1 "github.com/nitrictech/nitric/core/pkg/proto/deployments/v1"
+	kvstorepb "github.com/nitrictech/nitric/core/pkg/proto/kvstore/v1"
 	"github.com/pulumi/pulumi-aws/sdk/v5/go/aws/dynamodb"
+	"github.com/pulumi/pulumi-aws/sdk/v5/go/aws/iam"
+	"github.com/pulumi/pulumi-aws/sdk/v5/go/aws/lambda"
 	"github.com/pulumi/pulumi/sdk/v3/go/pulumi"
+	"github.com/samber/lo"
 )
 
 type DynamodbKeyValueStore struct {
@@ -41,7 +49,7 @@ func (n *NitricAwsPulumiProvider) KeyValueStore(ctx *pulumi.Context, parent pulu
 	var err error
 	opts := []pulumi.ResourceOption{pulumi.Parent(parent)}
 
-	n.KeyValueStores[name], err = dynamodb.NewTable(ctx, name, &dynamodb.TableArgs{
+	tableArgs := &dynamodb.TableArgs{
 		Attributes: dynamodb.TableAttributeArray{
 			&dynamodb.TableAttributeArgs{
 				Name: pulumi.String("_pk"),
@@ -56,7 +64,109 @@ func (n *NitricAwsPulumiProvider) KeyValueStore(ctx *pulumi.Context, parent pulu
 		RangeKey:    pulumi.String("_sk"),
 		BillingMode: pulumi.String("PAY_PER_REQUEST"),
 		Tags:        pulumi.ToStringMap(tags.Tags(n.StackId, name, resources.Collection)),
-	}, opts...)
+		// values set with a ttl store their expiry time, in epoch seconds, in the _ttl attribute
+		Ttl: &dynamodb.TableTtlArgs{
+			AttributeName: pulumi.String("_ttl"),
+			Enabled:       pulumi.Bool(true),
+		},
+	}
+
+	if len(keyvalue.GetListeners()) > 0 {
+		// listeners only need to know which key changed, they can retrieve its current value
+		tableArgs.StreamEnabled = pulumi.Bool(true)
+		tableArgs.StreamViewType = pulumi.String("KEYS_ONLY")
+	}
+
+	n.KeyValueStores[name], err = dynamodb.NewTable(ctx, name, tableArgs, opts...)
+	if err != nil {
+		return err
+	}
+
+	return n.keyValueStreamListeners(ctx, name, n.KeyValueStores[name], keyvalue.GetListeners(), opts)
+}
+
+// keyValueStreamListeners - invokes the lambda of each listening service with the table's stream records,
+// filtered to the event types and key prefixes the service listens for
+func (n *NitricAwsPulumiProvider) keyValueStreamListeners(ctx *pulumi.Context, name string, table *dynamodb.Table, listeners []*deploymentspb.KeyValueStoreListener, opts []pulumi.ResourceOption) error {
+	filtersByService := map[string]lambda.EventSourceMappingFilterCriteriaFilterArray{}
+
+	for _, listener := range listeners {
+		serviceName := listener.GetService()
+		if _, ok := n.Lambdas[serviceName]; !ok {
+			return fmt.Errorf("invalid service %s given for key value store listener", serviceName)
+		}
+
+		pattern, err := streamFilterPattern(listener.GetConfig())
+		if err != nil {
+			return err
+		}
+
+		filtersByService[serviceName] = append(filtersByService[serviceName], lambda.EventSourceMappingFilterCriteriaFilterArgs{
+			Pattern: pulumi.String(pattern),
+		})
+	}
+
+	serviceNames := lo.Keys(filtersByService)
+	sort.Strings(serviceNames)
+
+	for _, serviceName := range serviceNames {
+		policy, err := iam.NewRolePolicy(ctx, fmt.Sprintf("%s-%s-stream", name, serviceName), &iam.RolePolicyArgs{
+			Role: n.LambdaRoles[serviceName].ID(),
+			Policy: pulumi.Sprintf(`{
+				"Version": "2012-10-17",
+				"Statement": [{
+					"Effect": "Allow",
+					"Action": ["dynamodb:DescribeStream", "dynamodb:GetRecords", "dynamodb:GetShardIterator", "dynamodb:ListStreams"],
+					"Resource": "%s"
+				}]
+			}`, table.StreamArn),
+		}, opts...)
+		if err != nil {
+			return fmt.Errorf("unable to create stream access policy for service %s: %w", serviceName, err)
+		}
+
+		_, err = lambda.NewEventSourceMapping(ctx, fmt.Sprintf("%s-%s", name, serviceName), &lambda.EventSourceMappingArgs{
+			EventSourceArn:   table.StreamArn,
+			FunctionName:     n.Lambdas[serviceName].Arn,
+			StartingPosition: pulumi.String("LATEST"),
+			FilterCriteria: &lambda.EventSourceMappingFilterCriteriaArgs{
+				Filters: filtersByService[serviceName],
+			},
+		}, append([]pulumi.ResourceOption{pulumi.DependsOn([]pulumi.Resource{policy})}, opts...)...)
+		if err != nil {
+			return fmt.Errorf("unable to create stream event source mapping for service %s: %w", serviceName, err)
+		}
+	}
+
+	return nil
+}
+
+// streamFilterPattern - the lambda event filter pattern matching the stream records of a listener's event type and key prefix
+func streamFilterPattern(config *kvstorepb.RegistrationRequest) (string, error) {
+	eventNames := []string{"INSERT", "MODIFY"}
+	if config.GetEventType() == kvstorepb.KeyValueEventType_Delete {
+		eventNames = []string{"REMOVE"}
+	}
+
+	pattern := map[string]interface{}{
+		"eventName": eventNames,
+	}
+
+	prefix := config.GetKeyPrefixFilter()
+	if prefix != "" && prefix != "*" {
+		pattern["dynamodb"] = map[string]interface{}{
+			"Keys": map[string]interface{}{
+				"_pk": map[string]interface{}{
+					"S": []interface{}{map[string]string{"prefix": prefix}},
+				},
+			},
+		}
+	}
+
+	patternJson, err := json.Marshal(pattern)
+	if err != nil {
+		return "", fmt.Errorf("unable to create stream filter pattern: %w", err)
+	}
 
-	return err
+	return string(patternJson), nil
 }
@@ -64,15 +64,20 @@ var awsActionsMap map[resourcespb.Action][]string = map[resourcespb.Action][]str
 	resourcespb.Action_KeyValueStoreWrite: {
 		"dynamodb:UpdateItem",
 		"dynamodb:PutItem",
+		"dynamodb:BatchWriteItem",
 	},
 	resourcespb.Action_KeyValueStoreDelete: {
 		"dynamodb:DeleteItem",
+		"dynamodb:BatchWriteItem",
 	},
 	resourcespb.Action_SecretAccess: {
 		"secretsmanager:GetSecretValue",
+		"secretsmanager:ListSecretVersionIds",
 	},
 	resourcespb.Action_SecretPut: {
 		"secretsmanager:PutSecretValue",
+		"secretsmanager:DescribeSecret",
+		"secretsmanager:UpdateSecretVersionStage",
 	},
 	resourcespb.Action_WebsocketManage: {
 		"execute-api:ManageConnections",
@@ -86,6 +91,7 @@ var awsActionsMap map[resourcespb.Action][]string = map[resourcespb.Action][]str
 	resourcespb.Action_QueueDequeue: {
 		"sqs:ReceiveMessage",
 		"sqs:DeleteMessage",
+		"sqs:ChangeMessageVisibility",
 		"sqs:GetQueueAttributes",
 		"sqs:GetQueueUrl",
 		"sqs:ListQueueTags",
@@ -17,9 +17,16 @@
 package deploy
 
 import (
+	"encoding/json"
+	"fmt"
+
 	"github.com/nitrictech/nitric/cloud/common/deploy/resources"
 	common "github.com/nitrictech/nitric/cloud/common/deploy/tags"
 	deploymentspb "github.com/nitrictech/nitric/core/pkg/proto/deployments/v1"
+	queuespb "github.com/nitrictech/nitric/core/pkg/proto/queues/v1"
+	resourcespb "github.com/nitrictech/nitric/core/pkg/proto/resources/v1"
+	"github.com/pulumi/pulumi-aws/sdk/v5/go/aws/iam"
+	"github.com/pulumi/pulumi-aws/sdk/v5/go/aws/lambda"
 	"github.com/pulumi/pulumi-aws/sdk/v5/go/aws/sqs"
 	"github.com/pulumi/pulumi/sdk/v3/go/pulumi"
 )
@@ -28,14 +35,196 @@ import (
 func (a *NitricAwsPulumiProvider) Queue(ctx *pulumi.Context, parent pulumi.Resource, name string, config *deploymentspb.Queue) error {
 	opts := []pulumi.ResourceOption{pulumi.Parent(parent)}
 
-	queue, err := sqs.NewQueue(ctx, name, &sqs.QueueArgs{
+	queueArgs := &sqs.QueueArgs{
 		Tags: pulumi.ToStringMap(common.Tags(a.StackId, name, resources.Queue)),
-	}, opts...)
+	}
+
+	// SQS requires FIFO queue names to end with the .fifo suffix
+	if config.Fifo {
+		queueArgs.FifoQueue = pulumi.Bool(true)
+		queueArgs.Name = pulumi.Sprintf("%s-%s.fifo", name, a.StackId)
+	}
+
+	if policy := config.GetDeadLetterPolicy(); policy != nil {
+		if err := validateDeadLetterPolicy(name, policy); err != nil {
+			return err
+		}
+
+		target, ok := a.Queues[policy.TargetQueue]
+		if !ok {
+			return fmt.Errorf("dead-letter target queue %s for queue %s not found", policy.TargetQueue, name)
+		}
+
+		if err := validateDeadLetterTarget(name, config.Fifo, policy.TargetQueue, a.FifoQueues[policy.TargetQueue]); err != nil {
+			return err
+		}
+
+		queueArgs.RedrivePolicy = target.Arn.ApplyT(func(arn string) (string, error) {
+			redrivePolicy, err := json.Marshal(map[string]interface{}{
+				"deadLetterTargetArn": arn,
+				"maxReceiveCount":     policy.MaxReceiveCount,
+			})
+
+			return string(redrivePolicy), err
+		}).(pulumi.StringOutput)
+	}
+
+	if len(config.GetListeners()) > 0 {
+		visibilityTimeout, err := a.listenerVisibilityTimeout(config.GetListeners())
+		if err != nil {
+			return err
+		}
+
+		queueArgs.VisibilityTimeoutSeconds = visibilityTimeout
+	}
+
+	queue, err := sqs.NewQueue(ctx, name, queueArgs, opts...)
 	if err != nil {
 		return err
 	}
 
 	a.Queues[name] = queue
+	a.FifoQueues[name] = config.Fifo
+
+	return a.queueListeners(ctx, name, queue, config, opts)
+}
+
+// The default timeout of lambda functions, in seconds
+const defaultLambdaTimeout = 3
+
+// SQS limits the visibility timeout of a queue to 12 hours
+const maxVisibilityTimeoutSeconds = 12 * 60 * 60
+
+// listenerVisibilityTimeout - SQS event source mappings require the queue's visibility timeout to be at least the timeout of the function,
+// AWS recommends six times the function timeout so messages aren't redelivered while their batch is retried
+func (a *NitricAwsPulumiProvider) listenerVisibilityTimeout(listeners []*deploymentspb.QueueListener) (pulumi.IntOutput, error) {
+	timeouts := []interface{}{}
+
+	for _, listener := range listeners {
+		function, ok := a.Lambdas[listener.GetService()]
+		if !ok {
+			return pulumi.IntOutput{}, fmt.Errorf("invalid service %s given for queue listener", listener.GetService())
+		}
+
+		timeouts = append(timeouts, function.Timeout)
+	}
+
+	return pulumi.All(timeouts...).ApplyT(func(all []interface{}) int {
+		maxTimeout := defaultLambdaTimeout
+
+		for _, timeout := range all {
+			if t, ok := timeout.(*int); ok && t != nil && *t > maxTimeout {
+				maxTimeout = *t
+			}
+		}
+
+		return min(maxTimeout*6, maxVisibilityTimeoutSeconds)
+	}).(pulumi.IntOutput), nil
+}
+
+// queueListeners - invokes the lambda of each listening service with batches of the queue's messages,
+// only the messages the service fails to handle are returned to the queue
+func (a *NitricAwsPulumiProvider) queueListeners(ctx *pulumi.Context, name string, queue *sqs.Queue, config *deploymentspb.Queue, opts []pulumi.ResourceOption) error {
+	listenedBy := map[string]bool{}
+
+	for _, listener := range config.GetListeners() {
+		serviceName := listener.GetService()
+		if listenedBy[serviceName] {
+			return fmt.Errorf("service %s has more than one listener for queue %s", serviceName, name)
+		}
+		listenedBy[serviceName] = true
+
+		if err := validateQueueListener(name, config.Fifo, listener.GetConfig()); err != nil {
+			return err
+		}
+
+		policy, err := iam.NewRolePolicy(ctx, fmt.Sprintf("%s-%s-listener", name, serviceName), &iam.RolePolicyArgs{
+			Role: a.LambdaRoles[serviceName].ID(),
+			Policy: pulumi.Sprintf(`{
+				"Version": "2012-10-17",
+				"Statement": [{
+					"Effect": "Allow",
+					"Action": ["sqs:ReceiveMessage", "sqs:DeleteMessage", "sqs:ChangeMessageVisibility", "sqs:GetQueueAttributes"],
+					"Resource": "%s"
+				}]
+			}`, queue.Arn),
+		}, opts...)
+		if err != nil {
+			return fmt.Errorf("unable to create queue access policy for service %s: %w", serviceName, err)
+		}
+
+		batchSize := int(listener.GetConfig().GetBatchSize())
+		if batchSize == 0 {
+			batchSize = defaultListenerBatchSize
+		}
+
+		mappingArgs := &lambda.EventSourceMappingArgs{
+			EventSourceArn:        queue.Arn,
+			FunctionName:          a.Lambdas[serviceName].Arn,
+			BatchSize:             pulumi.Int(batchSize),
+			FunctionResponseTypes: pulumi.ToStringArray([]string{"ReportBatchItemFailures"}),
+		}
+
+		// batches of more than 10 messages require a batching window
+		if batchSize > defaultListenerBatchSize {
+			mappingArgs.MaximumBatchingWindowInSeconds = pulumi.Int(1)
+		}
+
+		if concurrency := listener.GetConfig().GetConcurrency(); concurrency > 0 {
+			mappingArgs.ScalingConfig = &lambda.EventSourceMappingScalingConfigArgs{
+				MaximumConcurrency: pulumi.Int(int(concurrency)),
+			}
+		}
+
+		_, err = lambda.NewEventSourceMapping(ctx, fmt.Sprintf("%s-%s", name, serviceName), mappingArgs, append([]pulumi.ResourceOption{pulumi.DependsOn([]pulumi.Resource{policy})}, opts...)...)
+		if err != nil {
+			return fmt.Errorf("unable to create queue event source mapping for service %s: %w", serviceName, err)
+		}
+	}
+
+	return nil
+}
+
+// The batch size of queue listeners that don't set one, also the largest batch that doesn't require a batching window
+const defaultListenerBatchSize = 10
+
+// validateQueueListener - SQS event source mappings accept batches of up to 10 messages from FIFO queues and 10000 from standard queues,
+// their maximum concurrency must be between 2 and 1000
+func validateQueueListener(name string, fifo bool, config *queuespb.RegistrationRequest) error {
+	maxBatchSize := int32(10000)
+	if fifo {
+		maxBatchSize = 10
+	}
+
+	if config.GetBatchSize() < 0 || config.GetBatchSize() > maxBatchSize {
+		return fmt.Errorf("batch size of listeners for queue %s must be between 1 and %d, got %d", name, maxBatchSize, config.GetBatchSize())
+	}
+
+	if config.GetConcurrency() != 0 && (config.GetConcurrency() < 2 || config.GetConcurrency() > 1000) {
+		return fmt.Errorf("concurrency of listeners for queue %s must be between 2 and 1000, got %d", name, config.GetConcurrency())
+	}
+
+	return nil
+}
+
+// validateDeadLetterPolicy - SQS redrive policies accept a max receive count between 1 and 1000
+func validateDeadLetterPolicy(name string, policy *resourcespb.QueueDeadLetterPolicy) error {
+	if policy.TargetQueue == name {
+		return fmt.Errorf("queue %s can't be its own dead-letter target", name)
+	}
+
+	if policy.MaxReceiveCount < 1 || policy.MaxReceiveCount > 1000 {
+		return fmt.Errorf("max receive count for queue %s must be between 1 and 1000, got %d", name, policy.MaxReceiveCount)
+	}
+
+	return nil
+}
+
+// validateDeadLetterTarget - SQS requires a FIFO queue's dead-letter queue to also be FIFO, and a standard queue's to be standard
+func validateDeadLetterTarget(name string, fifo bool, targetName string, targetFifo bool) error {
+	if fifo != targetFifo {
+		return fmt.Errorf("queue %s and its dead-letter target queue %s must both be FIFO queues or both be standard queues", name, targetName)
+	}
 
 	return nil
 }
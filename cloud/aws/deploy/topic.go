@@ -19,17 +19,23 @@ package deploy
 import (
 	"encoding/json"
 	"fmt"
+	"strings"
 
 	"github.com/nitrictech/nitric/cloud/common/deploy/resources"
 	common "github.com/nitrictech/nitric/cloud/common/deploy/tags"
 	deploymentspb "github.com/nitrictech/nitric/core/pkg/proto/deployments/v1"
+	topicspb "github.com/nitrictech/nitric/core/pkg/proto/topics/v1"
+	"github.com/nitrictech/nitric/core/pkg/workers/topics"
 	"github.com/pkg/errors"
 	"github.com/pulumi/pulumi-aws/sdk/v5/go/aws/iam"
 	"github.com/pulumi/pulumi-aws/sdk/v5/go/aws/lambda"
 	awslambda "github.com/pulumi/pulumi-aws/sdk/v5/go/aws/lambda"
 	"github.com/pulumi/pulumi-aws/sdk/v5/go/aws/sfn"
 	"github.com/pulumi/pulumi-aws/sdk/v5/go/aws/sns"
+	"github.com/pulumi/pulumi-aws/sdk/v5/go/aws/sqs"
 	"github.com/pulumi/pulumi/sdk/v3/go/pulumi"
+	"github.com/samber/lo"
+	"google.golang.org/protobuf/proto"
 )
 
 type topic struct {
@@ -37,11 +43,273 @@ type topic struct {
 	sfn *sfn.StateMachine
 }
 
-func createSubscription(ctx *pulumi.Context, parent pulumi.Resource, name string, topic *sns.Topic, target *lambda.Function) error {
+// SNS filter policies can match at most 5 message attributes
+const maxFilterPolicyAttributes = 5
+
+// SnsFilterPolicy - converts a nitric attribute filter to an SNS subscription filter policy, returns an empty policy if the filter has no conditions
+func SnsFilterPolicy(filter *topicspb.AttributeFilter) (string, error) {
+	if err := topics.ValidateFilter(filter); err != nil {
+		return "", err
+	}
+
+	if len(filter.GetConditions()) == 0 {
+		return "", nil
+	}
+
+	if len(filter.GetConditions()) > maxFilterPolicyAttributes {
+		return "", fmt.Errorf("filters can match at most %d attributes on aws", maxFilterPolicyAttributes)
+	}
+
+	policy := map[string][]interface{}{}
+	for _, condition := range filter.GetConditions() {
+		switch match := condition.GetMatch().(type) {
+		case *topicspb.AttributeCondition_AnyOf:
+			policy[condition.GetKey()] = lo.ToAnySlice(match.AnyOf.GetValues())
+		case *topicspb.AttributeCondition_NoneOf:
+			policy[condition.GetKey()] = []interface{}{map[string]interface{}{"anything-but": match.NoneOf.GetValues()}}
+		case *topicspb.AttributeCondition_Prefix:
+			policy[condition.GetKey()] = []interface{}{map[string]interface{}{"prefix": match.Prefix}}
+		case *topicspb.AttributeCondition_Exists:
+			policy[condition.GetKey()] = []interface{}{map[string]interface{}{"exists": match.Exists}}
+		}
+	}
+
+	policyJson, err := json.Marshal(policy)
+	if err != nil {
+		return "", err
+	}
+
+	return string(policyJson), nil
+}
+
+// Lambda retries failed asynchronous invocations at most twice, so messages are delivered to a subscriber at most 3 times
+const maxSubscriberAttempts = 3
+
+// ValidateRetryPolicy - returns an error if the retry policy of a subscription can't be applied on aws
+func ValidateRetryPolicy(policy *topicspb.SubscriptionRetryPolicy) error {
+	if err := topics.ValidateRetryPolicy(policy); err != nil {
+		return err
+	}
+
+	if policy.GetMaxAttempts() > maxSubscriberAttempts {
+		return fmt.Errorf("max attempts must be at most %d on aws, got %d", maxSubscriberAttempts, policy.GetMaxAttempts())
+	}
+
+	return nil
+}
+
+// ValidateSubscriberRetryPolicies - returns an error if the retry policies of the stack's subscriptions can't be applied on aws.
+// Lambda applies a retry policy to every asynchronous invocation of a function, not just those of its subscriptions,
+// so all subscriptions of a service must share the same policy and a service with a policy can't also handle schedules or bucket notifications
+func ValidateSubscriberRetryPolicies(topics []*deploymentspb.Topic, schedules []*deploymentspb.Schedule, buckets []*deploymentspb.Bucket) error {
+	policies := map[string]*topicspb.SubscriptionRetryPolicy{}
+
+	for _, topic := range topics {
+		for _, sub := range topic.GetSubscriptions() {
+			policy := sub.GetRetryPolicy()
+			if proto.Size(policy) == 0 {
+				policy = nil
+			}
+
+			if existing, ok := policies[sub.GetService()]; ok && !proto.Equal(existing, policy) {
+				return fmt.Errorf("all subscriptions of service %s must have the same retry policy on aws", sub.GetService())
+			}
+
+			policies[sub.GetService()] = policy
+		}
+	}
+
+	for _, schedule := range schedules {
+		if policies[schedule.GetTarget().GetService()] != nil {
+			return fmt.Errorf("service %s can't handle schedules and have subscriptions with a retry policy on aws", schedule.GetTarget().GetService())
+		}
+	}
+
+	for _, bucket := range buckets {
+		for _, listener := range bucket.GetListeners() {
+			if policies[listener.GetService()] != nil {
+				return fmt.Errorf("service %s can't handle bucket notifications and have subscriptions with a retry policy on aws", listener.GetService())
+			}
+		}
+	}
+
+	return nil
+}
+
+// deadLetterQueueArn - returns the ARN of the queue that a subscription dead-letters messages to,
+// SNS and lambda destinations can't send messages to FIFO queues
+func (a *NitricAwsPulumiProvider) deadLetterQueueArn(name string) (pulumi.StringOutput, error) {
+	queue, ok := a.Queues[name]
+	if !ok {
+		return pulumi.StringOutput{}, fmt.Errorf("dead-letter queue %s not found", name)
+	}
+
+	return pulumi.All(queue.Arn, queue.FifoQueue).ApplyT(func(all []interface{}) (string, error) {
+		if fifo, ok := all[1].(*bool); ok && fifo != nil && *fifo {
+			return "", fmt.Errorf("FIFO queue %s can't be used as the dead-letter queue of a subscription", name)
+		}
+
+		return all[0].(string), nil
+	}).(pulumi.StringOutput), nil
+}
+
+// allowSnsDeadLetters - allows SNS to move messages it fails to deliver to the queue, the policy is shared by every topic in the account
+func (a *NitricAwsPulumiProvider) allowSnsDeadLetters(ctx *pulumi.Context, parent pulumi.Resource, name string) error {
+	if _, ok := a.DeadLetterQueuePolicies[name]; ok {
+		return nil
+	}
+
+	queue := a.Queues[name]
+
+	policy := queue.Arn.ApplyT(func(arn string) (string, error) {
+		// arn:aws:sqs:<region>:<account>:<name>
+		account := strings.Split(arn, ":")[4]
+
+		policy, err := json.Marshal(map[string]interface{}{
+			"Version": "2012-10-17",
+			"Statement": []map[string]interface{}{
+				{
+					"Effect": "Allow",
+					"Principal": map[string]interface{}{
+						"Service": "sns.amazonaws.com",
+					},
+					"Action":   "sqs:SendMessage",
+					"Resource": arn,
+					"Condition": map[string]interface{}{
+						"StringEquals": map[string]string{
+							"aws:SourceAccount": account,
+						},
+					},
+				},
+			},
+		})
+
+		return string(policy), err
+	})
+
+	queuePolicy, err := sqs.NewQueuePolicy(ctx, fmt.Sprintf("%s-sns-dead-letter", name), &sqs.QueuePolicyArgs{
+		QueueUrl: queue.Url,
+		Policy:   policy,
+	}, pulumi.Parent(parent))
+	if err != nil {
+		return err
+	}
+
+	a.DeadLetterQueuePolicies[name] = queuePolicy
+
+	return nil
+}
+
+// subscriberRetryPolicy - configures the retries of the asynchronous invocations of a subscriber's lambda and the queue that failed messages are sent to.
+// The policies are validated by ValidateSubscriberRetryPolicies, so the policy of a service's first subscription applies to all of them.
+// Retries follow lambda's backoff schedule, backoff bounds can't be configured on aws
+func (a *NitricAwsPulumiProvider) subscriberRetryPolicy(ctx *pulumi.Context, parent pulumi.Resource, serviceName string, policy *topicspb.SubscriptionRetryPolicy) error {
+	if _, ok := a.SubscriberRetryPolicies[serviceName]; ok {
+		return nil
+	}
+
+	a.SubscriberRetryPolicies[serviceName] = policy
+
+	if proto.Size(policy) == 0 {
+		return nil
+	}
+
+	opts := []pulumi.ResourceOption{pulumi.Parent(parent)}
+
+	invokeConfigArgs := &lambda.FunctionEventInvokeConfigArgs{
+		FunctionName: a.Lambdas[serviceName].Name,
+	}
+
+	if policy.MaxAttempts > 0 {
+		invokeConfigArgs.MaximumRetryAttempts = pulumi.Int(int(policy.MaxAttempts - 1))
+	}
+
+	if policy.DeadLetterQueue != "" {
+		queueArn, err := a.deadLetterQueueArn(policy.DeadLetterQueue)
+		if err != nil {
+			return err
+		}
+
+		// lambda sends failed invocations to the destination using the function's role
+		rolePolicy, err := iam.NewRolePolicy(ctx, fmt.Sprintf("%s-dead-letter", serviceName), &iam.RolePolicyArgs{
+			Role: a.LambdaRoles[serviceName].ID(),
+			Policy: pulumi.Sprintf(`{
+				"Version": "2012-10-17",
+				"Statement": [{
+					"Effect": "Allow",
+					"Action": ["sqs:SendMessage"],
+					"Resource": "%s"
+				}]
+			}`, queueArn),
+		}, opts...)
+		if err != nil {
+			return err
+		}
+
+		invokeConfigArgs.DestinationConfig = &lambda.FunctionEventInvokeConfigDestinationConfigArgs{
+			OnFailure: &lambda.FunctionEventInvokeConfigDestinationConfigOnFailureArgs{
+				Destination: queueArn,
+			},
+		}
+
+		opts = append(opts, pulumi.DependsOn([]pulumi.Resource{rolePolicy}))
+	}
+
+	_, err := lambda.NewFunctionEventInvokeConfig(ctx, serviceName, invokeConfigArgs, opts...)
+
+	return err
+}
+
+func (a *NitricAwsPulumiProvider) createSubscription(ctx *pulumi.Context, parent pulumi.Resource, name string, topic *sns.Topic, target *lambda.Function, sub *deploymentspb.SubscriptionTarget) error {
 	var err error
 
 	opts := []pulumi.ResourceOption{pulumi.Parent(parent)}
 
+	filterPolicy, err := SnsFilterPolicy(sub.GetFilter())
+	if err != nil {
+		return fmt.Errorf("invalid filter for subscription %s: %w", name, err)
+	}
+
+	if err := ValidateRetryPolicy(sub.GetRetryPolicy()); err != nil {
+		return fmt.Errorf("invalid retry policy for subscription %s: %w", name, err)
+	}
+
+	subscriptionArgs := &sns.TopicSubscriptionArgs{
+		Endpoint: target.Arn,
+		Protocol: pulumi.String("lambda"),
+		Topic:    topic.ID(),
+	}
+
+	if filterPolicy != "" {
+		// attributes are published as SNS message attributes, rather than in the message body
+		subscriptionArgs.FilterPolicy = pulumi.String(filterPolicy)
+		subscriptionArgs.FilterPolicyScope = pulumi.String("MessageAttributes")
+	}
+
+	if deadLetterQueue := sub.GetRetryPolicy().GetDeadLetterQueue(); deadLetterQueue != "" {
+		queueArn, err := a.deadLetterQueueArn(deadLetterQueue)
+		if err != nil {
+			return err
+		}
+
+		if err := a.allowSnsDeadLetters(ctx, parent, deadLetterQueue); err != nil {
+			return err
+		}
+
+		// messages that SNS can't deliver to the lambda are moved to the dead-letter queue as well
+		subscriptionArgs.RedrivePolicy = queueArn.ApplyT(func(arn string) (string, error) {
+			redrivePolicy, err := json.Marshal(map[string]string{
+				"deadLetterTargetArn": arn,
+			})
+
+			return string(redrivePolicy), err
+		}).(pulumi.StringOutput)
+	}
+
+	if err := a.subscriberRetryPolicy(ctx, parent, sub.GetService(), sub.GetRetryPolicy()); err != nil {
+		return err
+	}
+
 	_, err = awslambda.NewPermission(ctx, name+"Permission", &awslambda.PermissionArgs{
 		SourceArn: topic.Arn,
 		Function:  target.Name,
@@ -52,11 +320,7 @@ func createSubscription(ctx *pulumi.Context, parent pulumi.Resource, name string
 		return err
 	}
 
-	_, err = sns.NewTopicSubscription(ctx, name+"Subscription", &sns.TopicSubscriptionArgs{
-		Endpoint: target.Arn,
-		Protocol: pulumi.String("lambda"),
-		Topic:    topic.ID(),
-	}, opts...)
+	_, err = sns.NewTopicSubscription(ctx, name+"Subscription", subscriptionArgs, opts...)
 	if err != nil {
 		return err
 	}
@@ -143,8 +407,9 @@ func (a *NitricAwsPulumiProvider) Topic(ctx *pulumi.Context, parent pulumi.Resou
 					"Type":     "Task",
 					"Resource": "arn:aws:states:::sns:publish",
 					"Parameters": map[string]string{
-						"TopicArn":  arn,
-						"Message.$": "$.message",
+						"TopicArn":            arn,
+						"Message.$":           "$.message",
+						"MessageAttributes.$": "$.attributes",
 					},
 					"End": true,
 				},
@@ -172,7 +437,7 @@ func (a *NitricAwsPulumiProvider) Topic(ctx *pulumi.Context, parent pulumi.Resou
 			return fmt.Errorf("unable to find lambda %s for subscription", sub.GetService())
 		}
 
-		err := createSubscription(ctx, parent, name, a.Topics[name].sns, targetLambda)
+		err := a.createSubscription(ctx, parent, name, a.Topics[name].sns, targetLambda, sub)
 		if err != nil {
 			return err
 		}
@@ -0,0 +1,141 @@
+// Copyright 2021 Nitric Technologies Pty Ltd.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package deploy_test
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/ginkgo/extensions/table"
+	. "github.com/onsi/gomega"
+
+	"github.com/nitrictech/nitric/cloud/aws/deploy"
+	deploymentspb "github.com/nitrictech/nitric/core/pkg/proto/deployments/v1"
+	topicspb "github.com/nitrictech/nitric/core/pkg/proto/topics/v1"
+)
+
+func filterOf(conditions ...*topicspb.AttributeCondition) *topicspb.AttributeFilter {
+	return &topicspb.AttributeFilter{Conditions: conditions}
+}
+
+func anyOf(key string, values ...string) *topicspb.AttributeCondition {
+	return &topicspb.AttributeCondition{
+		Key:   key,
+		Match: &topicspb.AttributeCondition_AnyOf{AnyOf: &topicspb.AttributeValues{Values: values}},
+	}
+}
+
+func noneOf(key string, values ...string) *topicspb.AttributeCondition {
+	return &topicspb.AttributeCondition{
+		Key:   key,
+		Match: &topicspb.AttributeCondition_NoneOf{NoneOf: &topicspb.AttributeValues{Values: values}},
+	}
+}
+
+func prefix(key string, prefix string) *topicspb.AttributeCondition {
+	return &topicspb.AttributeCondition{
+		Key:   key,
+		Match: &topicspb.AttributeCondition_Prefix{Prefix: prefix},
+	}
+}
+
+func exists(key string, exists bool) *topicspb.AttributeCondition {
+	return &topicspb.AttributeCondition{
+		Key:   key,
+		Match: &topicspb.AttributeCondition_Exists{Exists: exists},
+	}
+}
+
+var _ = Describe("SnsFilterPolicy", func() {
+	It("should return an empty policy for filters without conditions", func() {
+		policy, err := deploy.SnsFilterPolicy(nil)
+		Expect(err).ShouldNot(HaveOccurred())
+		Expect(policy).To(BeEmpty())
+
+		policy, err = deploy.SnsFilterPolicy(filterOf())
+		Expect(err).ShouldNot(HaveOccurred())
+		Expect(policy).To(BeEmpty())
+	})
+
+	// SNS policies only match messages with the attributes they name, except for "exists": false,
+	// which agrees with MatchesFilter for absent attributes
+	DescribeTable("should convert filters to equivalent policies",
+		func(filter *topicspb.AttributeFilter, expected string) {
+			policy, err := deploy.SnsFilterPolicy(filter)
+
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(policy).To(MatchJSON(expected))
+		},
+		Entry("exact match", filterOf(anyOf("type", "order")), `{"type": ["order"]}`),
+		Entry("multiple values", filterOf(anyOf("type", "order", "refund")), `{"type": ["order", "refund"]}`),
+		Entry("none of", filterOf(noneOf("type", "test", "debug")), `{"type": [{"anything-but": ["test", "debug"]}]}`),
+		Entry("prefix match", filterOf(prefix("region", "eu-")), `{"region": [{"prefix": "eu-"}]}`),
+		Entry("exists", filterOf(exists("priority", true)), `{"priority": [{"exists": true}]}`),
+		Entry("not exists", filterOf(exists("priority", false)), `{"priority": [{"exists": false}]}`),
+		Entry("multiple conditions", filterOf(anyOf("type", "order"), prefix("region", "eu-")), `{"type": ["order"], "region": [{"prefix": "eu-"}]}`),
+	)
+
+	DescribeTable("should reject filters that can't be applied",
+		func(filter *topicspb.AttributeFilter) {
+			_, err := deploy.SnsFilterPolicy(filter)
+
+			Expect(err).Should(HaveOccurred())
+		},
+		Entry("invalid filter", filterOf(anyOf("type"))),
+		Entry("more than 5 attributes", filterOf(exists("a", true), exists("b", true), exists("c", true), exists("d", true), exists("e", true), exists("f", true))),
+	)
+})
+
+func subscribedTopic(subscriptions ...*deploymentspb.SubscriptionTarget) *deploymentspb.Topic {
+	return &deploymentspb.Topic{Subscriptions: subscriptions}
+}
+
+func subscription(service string, maxAttempts int32) *deploymentspb.SubscriptionTarget {
+	target := &deploymentspb.SubscriptionTarget{Target: &deploymentspb.SubscriptionTarget_Service{Service: service}}
+	if maxAttempts > 0 {
+		target.RetryPolicy = &topicspb.SubscriptionRetryPolicy{MaxAttempts: maxAttempts}
+	}
+
+	return target
+}
+
+func scheduleOf(service string) *deploymentspb.Schedule {
+	return &deploymentspb.Schedule{Target: &deploymentspb.ScheduleTarget{Target: &deploymentspb.ScheduleTarget_Service{Service: service}}}
+}
+
+func bucketOf(service string) *deploymentspb.Bucket {
+	return &deploymentspb.Bucket{Listeners: []*deploymentspb.BucketListener{{Target: &deploymentspb.BucketListener_Service{Service: service}}}}
+}
+
+var _ = Describe("ValidateSubscriberRetryPolicies", func() {
+	DescribeTable("should accept policies that only apply to a service's subscriptions",
+		func(topics []*deploymentspb.Topic, schedules []*deploymentspb.Schedule, buckets []*deploymentspb.Bucket) {
+			Expect(deploy.ValidateSubscriberRetryPolicies(topics, schedules, buckets)).To(Succeed())
+		},
+		Entry("no policies", []*deploymentspb.Topic{subscribedTopic(subscription("a", 0), subscription("b", 0))}, []*deploymentspb.Schedule{scheduleOf("a")}, []*deploymentspb.Bucket{bucketOf("b")}),
+		Entry("the same policy across topics", []*deploymentspb.Topic{subscribedTopic(subscription("a", 2)), subscribedTopic(subscription("a", 2))}, nil, nil),
+		Entry("different policies for different services", []*deploymentspb.Topic{subscribedTopic(subscription("a", 2), subscription("b", 3))}, nil, nil),
+		Entry("schedules and bucket notifications of other services", []*deploymentspb.Topic{subscribedTopic(subscription("a", 2))}, []*deploymentspb.Schedule{scheduleOf("b")}, []*deploymentspb.Bucket{bucketOf("b")}),
+		Entry("an empty policy alongside no policy", []*deploymentspb.Topic{subscribedTopic(subscription("a", 0), &deploymentspb.SubscriptionTarget{Target: &deploymentspb.SubscriptionTarget_Service{Service: "a"}, RetryPolicy: &topicspb.SubscriptionRetryPolicy{}})}, []*deploymentspb.Schedule{scheduleOf("a")}, nil),
+	)
+
+	DescribeTable("should reject policies that would apply to other invocations",
+		func(topics []*deploymentspb.Topic, schedules []*deploymentspb.Schedule, buckets []*deploymentspb.Bucket) {
+			Expect(deploy.ValidateSubscriberRetryPolicies(topics, schedules, buckets)).ToNot(Succeed())
+		},
+		Entry("different policies for the same service", []*deploymentspb.Topic{subscribedTopic(subscription("a", 2)), subscribedTopic(subscription("a", 3))}, nil, nil),
+		Entry("a policy alongside no policy for the same service", []*deploymentspb.Topic{subscribedTopic(subscription("a", 0), subscription("a", 2))}, nil, nil),
+		Entry("a service that also handles schedules", []*deploymentspb.Topic{subscribedTopic(subscription("a", 2))}, []*deploymentspb.Schedule{scheduleOf("a")}, nil),
+		Entry("a service that also handles bucket notifications", []*deploymentspb.Topic{subscribedTopic(subscription("a", 2))}, nil, []*deploymentspb.Bucket{bucketOf("a")}),
+	)
+})
@@ -13,6 +13,11 @@ resource "aws_dynamodb_table" "table" {
   hash_key  = "_pk"
   range_key = "_sk"
   billing_mode = "PAY_PER_REQUEST"
+  # values set with a ttl store their expiry time in the _ttl attribute
+  ttl {
+    attribute_name = "_ttl"
+    enabled        = true
+  }
   tags = {
     "x-nitric-${var.stack_id}-name" = var.kvstore_name
     "x-nitric-${var.stack_id}-type" = "kvstore"
@@ -1,9 +1,40 @@
 
 # Deploy an SQS queue
 resource "aws_sqs_queue" "queue" {
-  name = var.queue_name
+  name       = var.fifo ? "${var.queue_name}.fifo" : var.queue_name
+  fifo_queue = var.fifo
+  redrive_policy = var.dead_letter_queue_arn == null ? null : jsonencode({
+    deadLetterTargetArn = var.dead_letter_queue_arn
+    maxReceiveCount     = var.max_receive_count
+  })
   tags = {
     "x-nitric-${var.stack_id}-name" = var.queue_name
     "x-nitric-${var.stack_id}-type" = "queue"
   }
-}
\ No newline at end of file
+}
+
+# Allow SNS to move messages it fails to deliver to topic subscribers to the queue
+resource "aws_sqs_queue_policy" "sns_dead_letters" {
+  count     = var.allow_sns_dead_letters ? 1 : 0
+  queue_url = aws_sqs_queue.queue.id
+
+  policy = jsonencode({
+    Version = "2012-10-17"
+    Statement = [
+      {
+        Effect = "Allow"
+        Principal = {
+          Service = "sns.amazonaws.com"
+        }
+        Action   = "sqs:SendMessage"
+        Resource = aws_sqs_queue.queue.arn
+        Condition = {
+          StringEquals = {
+            # arn:aws:sqs:<region>:<account>:<name>
+            "aws:SourceAccount" = split(":", aws_sqs_queue.queue.arn)[4]
+          }
+        }
+      }
+    ]
+  })
+}
@@ -7,3 +7,27 @@ variable "stack_id" {
   description = "The ID of the Nitric stack"
   type        = string
 }
+
+variable "dead_letter_queue_arn" {
+  description = "The ARN of the queue that messages are moved to once they exceed the max receive count"
+  type        = string
+  default     = null
+}
+
+variable "max_receive_count" {
+  description = "The number of times a message can be received before it is moved to the dead-letter queue"
+  type        = number
+  default     = null
+}
+
+variable "fifo" {
+  description = "Deliver messages in order within each message group and deduplicate them"
+  type        = bool
+  default     = false
+}
+
+variable "allow_sns_dead_letters" {
+  description = "Allow SNS subscriptions in the same account to move messages they fail to deliver to the queue"
+  type        = bool
+  default     = false
+}
@@ -119,3 +119,39 @@ resource "aws_lambda_function" "function" {
     "x-nitric-${var.stack_id}-type" = "service",
   }
 }
+
+# Allow failed asynchronous invocations to be sent to their destination, lambda uses the function's role
+resource "aws_iam_role_policy" "async-failure-destination" {
+  count = var.async_failure_destination_arn != null ? 1 : 0
+  name  = "async-failure-destination"
+  role  = aws_iam_role.role.name
+
+  policy = jsonencode({
+    Version = "2012-10-17"
+    Statement = [
+      {
+        Effect   = "Allow"
+        Action   = "sqs:SendMessage"
+        Resource = var.async_failure_destination_arn
+      }
+    ]
+  })
+}
+
+# Configure the retries of asynchronous invocations, such as topic subscriptions, and where they're sent once retries are exhausted
+resource "aws_lambda_function_event_invoke_config" "async" {
+  count                  = var.async_max_retry_attempts != null || var.async_failure_destination_arn != null ? 1 : 0
+  function_name          = aws_lambda_function.function.function_name
+  maximum_retry_attempts = var.async_max_retry_attempts
+
+  dynamic "destination_config" {
+    for_each = var.async_failure_destination_arn != null ? ["1"] : []
+    content {
+      on_failure {
+        destination = var.async_failure_destination_arn
+      }
+    }
+  }
+
+  depends_on = [aws_iam_role_policy.async-failure-destination]
+}
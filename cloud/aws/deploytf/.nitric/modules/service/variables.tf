@@ -51,3 +51,14 @@ variable "security_group_ids" {
 }
 
 
+variable "async_max_retry_attempts" {
+  description = "The number of times lambda retries failed asynchronous invocations, such as topic subscriptions"
+  type        = number
+  default     = null
+}
+
+variable "async_failure_destination_arn" {
+  description = "The ARN of the SQS queue that asynchronous invocations are sent to once their retries are exhausted"
+  type        = string
+  default     = null
+}
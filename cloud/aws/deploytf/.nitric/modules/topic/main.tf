@@ -26,6 +26,14 @@ resource "aws_sns_topic_subscription" "subscription" {
   topic_arn = aws_sns_topic.topic.arn
   protocol  = "lambda"
   endpoint  = each.value
+
+  # Attributes are published as SNS message attributes, rather than in the message body
+  filter_policy       = lookup(var.lambda_subscriber_filter_policies, each.key, null)
+  filter_policy_scope = contains(keys(var.lambda_subscriber_filter_policies), each.key) ? "MessageAttributes" : null
+
+  redrive_policy = contains(keys(var.lambda_subscriber_dead_letter_queues), each.key) ? jsonencode({
+    deadLetterTargetArn = var.lambda_subscriber_dead_letter_queues[each.key]
+  }) : null
 }
 
 resource "aws_lambda_permission" "sns" {
@@ -91,6 +99,7 @@ resource "aws_sfn_state_machine" "publish_to_topic" {
         Parameters = {
           TopicArn = aws_sns_topic.topic.arn,
           "Message.$" : "$.message",
+          "MessageAttributes.$" : "$.attributes",
         },
         End = true
       }
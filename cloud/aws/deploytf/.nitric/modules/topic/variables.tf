@@ -12,3 +12,15 @@ variable "lambda_subscribers" {
   description = "A list of lambda ARNs to subscribe to the topic"
   type        = map(string)
 }
+
+variable "lambda_subscriber_filter_policies" {
+  description = "SNS filter policies of the lambda subscribers that only receive some messages"
+  type        = map(string)
+  default     = {}
+}
+
+variable "lambda_subscriber_dead_letter_queues" {
+  description = "ARNs of the SQS queues that messages SNS fails to deliver to the lambda subscribers are moved to"
+  type        = map(string)
+  default     = {}
+}
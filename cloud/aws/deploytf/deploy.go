@@ -40,6 +40,7 @@ import (
 	"github.com/nitrictech/nitric/cloud/common/deploy/provider"
 	deploymentspb "github.com/nitrictech/nitric/core/pkg/proto/deployments/v1"
 	resourcespb "github.com/nitrictech/nitric/core/pkg/proto/resources/v1"
+	topicspb "github.com/nitrictech/nitric/core/pkg/proto/topics/v1"
 	"github.com/samber/lo"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
@@ -63,6 +64,10 @@ type NitricAwsTerraformProvider struct {
 	Queues         map[string]queue.Queue
 	KeyValueStores map[string]keyvalue.Keyvalue
 	Websockets     map[string]websocket.Websocket
+	// Whether each queue is a FIFO queue, dead-letter targets must be the same type as the queues that use them
+	FifoQueues map[string]bool
+	// The retry policy of each service's subscriptions, lambda configures retries per function rather than per subscription
+	SubscriberRetryPolicies map[string]*topicspb.SubscriptionRetryPolicy
 
 	provider.NitricDefaultOrder
 }
@@ -104,6 +109,11 @@ func (a *NitricAwsTerraformProvider) RequiredProviders() map[string]interface{}
 }
 
 func (a *NitricAwsTerraformProvider) Pre(stack cdktf.TerraformStack, resources []*deploymentspb.Resource) error {
+	err := validateSubscriberRetryPolicies(resources)
+	if err != nil {
+		return err
+	}
+
 	tfRegion := cdktf.NewTerraformVariable(stack, jsii.String("region"), &cdktf.TerraformVariableConfig{
 		Type:        jsii.String("string"),
 		Default:     jsii.String(a.Region),
@@ -160,14 +170,16 @@ func (a *NitricAwsTerraformProvider) Post(stack cdktf.TerraformStack) error {
 
 func NewNitricAwsProvider() *NitricAwsTerraformProvider {
 	return &NitricAwsTerraformProvider{
-		Apis:           make(map[string]api.Api),
-		Buckets:        make(map[string]bucket.Bucket),
-		Services:       make(map[string]service.Service),
-		Topics:         make(map[string]topic.Topic),
-		Schedules:      make(map[string]schedule.Schedule),
-		Secrets:        make(map[string]secret.Secret),
-		Queues:         make(map[string]queue.Queue),
-		KeyValueStores: make(map[string]keyvalue.Keyvalue),
-		Websockets:     make(map[string]websocket.Websocket),
+		Apis:                    make(map[string]api.Api),
+		Buckets:                 make(map[string]bucket.Bucket),
+		Services:                make(map[string]service.Service),
+		Topics:                  make(map[string]topic.Topic),
+		Schedules:               make(map[string]schedule.Schedule),
+		Secrets:                 make(map[string]secret.Secret),
+		Queues:                  make(map[string]queue.Queue),
+		FifoQueues:              make(map[string]bool),
+		KeyValueStores:          make(map[string]keyvalue.Keyvalue),
+		Websockets:              make(map[string]websocket.Websocket),
+		SubscriberRetryPolicies: make(map[string]*topicspb.SubscriptionRetryPolicy),
 	}
 }
@@ -14,14 +14,20 @@ import (
 // Source at ./.nitric/modules/queue
 type Queue interface {
 	cdktf.TerraformModule
+	AllowSnsDeadLetters() *bool
+	SetAllowSnsDeadLetters(val *bool)
 	// Experimental.
 	CdktfStack() cdktf.TerraformStack
 	// Experimental.
 	ConstructNodeMetadata() *map[string]interface{}
+	DeadLetterQueueArn() *string
+	SetDeadLetterQueueArn(val *string)
 	// Experimental.
 	DependsOn() *[]*string
 	// Experimental.
 	SetDependsOn(val *[]*string)
+	Fifo() *bool
+	SetFifo(val *bool)
 	// Experimental.
 	ForEach() cdktf.ITerraformIterator
 	// Experimental.
@@ -32,6 +38,8 @@ type Queue interface {
 	FriendlyUniqueId() *string
 	// The tree node.
 	Node() constructs.Node
+	MaxReceiveCount() *float64
+	SetMaxReceiveCount(val *float64)
 	// Experimental.
 	Providers() *[]interface{}
 	QueueArnOutput() *string
@@ -78,6 +86,16 @@ type jsiiProxy_Queue struct {
 	internal.Type__cdktfTerraformModule
 }
 
+func (j *jsiiProxy_Queue) AllowSnsDeadLetters() *bool {
+	var returns *bool
+	_jsii_.Get(
+		j,
+		"allowSnsDeadLetters",
+		&returns,
+	)
+	return returns
+}
+
 func (j *jsiiProxy_Queue) CdktfStack() cdktf.TerraformStack {
 	var returns cdktf.TerraformStack
 	_jsii_.Get(
@@ -98,6 +116,16 @@ func (j *jsiiProxy_Queue) ConstructNodeMetadata() *map[string]interface{} {
 	return returns
 }
 
+func (j *jsiiProxy_Queue) DeadLetterQueueArn() *string {
+	var returns *string
+	_jsii_.Get(
+		j,
+		"deadLetterQueueArn",
+		&returns,
+	)
+	return returns
+}
+
 func (j *jsiiProxy_Queue) DependsOn() *[]*string {
 	var returns *[]*string
 	_jsii_.Get(
@@ -108,6 +136,16 @@ func (j *jsiiProxy_Queue) DependsOn() *[]*string {
 	return returns
 }
 
+func (j *jsiiProxy_Queue) Fifo() *bool {
+	var returns *bool
+	_jsii_.Get(
+		j,
+		"fifo",
+		&returns,
+	)
+	return returns
+}
+
 func (j *jsiiProxy_Queue) ForEach() cdktf.ITerraformIterator {
 	var returns cdktf.ITerraformIterator
 	_jsii_.Get(
@@ -148,6 +186,16 @@ func (j *jsiiProxy_Queue) Node() constructs.Node {
 	return returns
 }
 
+func (j *jsiiProxy_Queue) MaxReceiveCount() *float64 {
+	var returns *float64
+	_jsii_.Get(
+		j,
+		"maxReceiveCount",
+		&returns,
+	)
+	return returns
+}
+
 func (j *jsiiProxy_Queue) Providers() *[]interface{} {
 	var returns *[]interface{}
 	_jsii_.Get(
@@ -256,6 +304,22 @@ func NewQueue_Override(q Queue, scope constructs.Construct, id *string, config *
 	)
 }
 
+func (j *jsiiProxy_Queue)SetAllowSnsDeadLetters(val *bool) {
+	_jsii_.Set(
+		j,
+		"allowSnsDeadLetters",
+		val,
+	)
+}
+
+func (j *jsiiProxy_Queue)SetDeadLetterQueueArn(val *string) {
+	_jsii_.Set(
+		j,
+		"deadLetterQueueArn",
+		val,
+	)
+}
+
 func (j *jsiiProxy_Queue)SetDependsOn(val *[]*string) {
 	_jsii_.Set(
 		j,
@@ -264,6 +328,14 @@ func (j *jsiiProxy_Queue)SetDependsOn(val *[]*string) {
 	)
 }
 
+func (j *jsiiProxy_Queue)SetFifo(val *bool) {
+	_jsii_.Set(
+		j,
+		"fifo",
+		val,
+	)
+}
+
 func (j *jsiiProxy_Queue)SetForEach(val cdktf.ITerraformIterator) {
 	_jsii_.Set(
 		j,
@@ -272,6 +344,14 @@ func (j *jsiiProxy_Queue)SetForEach(val cdktf.ITerraformIterator) {
 	)
 }
 
+func (j *jsiiProxy_Queue)SetMaxReceiveCount(val *float64) {
+	_jsii_.Set(
+		j,
+		"maxReceiveCount",
+		val,
+	)
+}
+
 func (j *jsiiProxy_Queue)SetQueueName(val *string) {
 	if err := j.validateSetQueueNameParameters(val); err != nil {
 		panic(err)
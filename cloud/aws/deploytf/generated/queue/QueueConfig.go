@@ -17,5 +17,13 @@ type QueueConfig struct {
 	QueueName *string `field:"required" json:"queueName" yaml:"queueName"`
 	// The ID of the Nitric stack.
 	StackId *string `field:"required" json:"stackId" yaml:"stackId"`
+	// The ARN of the queue that messages are moved to once they exceed the max receive count.
+	DeadLetterQueueArn *string `field:"optional" json:"deadLetterQueueArn" yaml:"deadLetterQueueArn"`
+	// The number of times a message can be received before it is moved to the dead-letter queue.
+	MaxReceiveCount *float64 `field:"optional" json:"maxReceiveCount" yaml:"maxReceiveCount"`
+	// Deliver messages in order within each message group and deduplicate them.
+	Fifo *bool `field:"optional" json:"fifo" yaml:"fifo"`
+	// Allow SNS subscriptions in the same account to move messages they fail to deliver to the queue..
+	AllowSnsDeadLetters *bool `field:"optional" json:"allowSnsDeadLetters" yaml:"allowSnsDeadLetters"`
 }
 
@@ -14,6 +14,10 @@ import (
 // Source at ./.nitric/modules/service
 type Service interface {
 	cdktf.TerraformModule
+	AsyncFailureDestinationArn() *string
+	SetAsyncFailureDestinationArn(val *string)
+	AsyncMaxRetryAttempts() *float64
+	SetAsyncMaxRetryAttempts(val *float64)
 	// Experimental.
 	CdktfStack() cdktf.TerraformStack
 	// Experimental.
@@ -96,6 +100,26 @@ type jsiiProxy_Service struct {
 	internal.Type__cdktfTerraformModule
 }
 
+func (j *jsiiProxy_Service) AsyncFailureDestinationArn() *string {
+	var returns *string
+	_jsii_.Get(
+		j,
+		"asyncFailureDestinationArn",
+		&returns,
+	)
+	return returns
+}
+
+func (j *jsiiProxy_Service) AsyncMaxRetryAttempts() *float64 {
+	var returns *float64
+	_jsii_.Get(
+		j,
+		"asyncMaxRetryAttempts",
+		&returns,
+	)
+	return returns
+}
+
 func (j *jsiiProxy_Service) CdktfStack() cdktf.TerraformStack {
 	var returns cdktf.TerraformStack
 	_jsii_.Get(
@@ -384,6 +408,22 @@ func NewService_Override(s Service, scope constructs.Construct, id *string, conf
 	)
 }
 
+func (j *jsiiProxy_Service)SetAsyncFailureDestinationArn(val *string) {
+	_jsii_.Set(
+		j,
+		"asyncFailureDestinationArn",
+		val,
+	)
+}
+
+func (j *jsiiProxy_Service)SetAsyncMaxRetryAttempts(val *float64) {
+	_jsii_.Set(
+		j,
+		"asyncMaxRetryAttempts",
+		val,
+	)
+}
+
 func (j *jsiiProxy_Service)SetDependsOn(val *[]*string) {
 	_jsii_.Set(
 		j,
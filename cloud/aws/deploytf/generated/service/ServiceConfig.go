@@ -31,5 +31,9 @@ type ServiceConfig struct {
 	SubnetIds *[]*string `field:"optional" json:"subnetIds" yaml:"subnetIds"`
 	// The timeout for the lambda function in seconds 15.
 	Timeout *float64 `field:"optional" json:"timeout" yaml:"timeout"`
+	// The ARN of the SQS queue that asynchronous invocations are sent to once their retries are exhausted..
+	AsyncFailureDestinationArn *string `field:"optional" json:"asyncFailureDestinationArn" yaml:"asyncFailureDestinationArn"`
+	// The number of times lambda retries failed asynchronous invocations, such as topic subscriptions..
+	AsyncMaxRetryAttempts *float64 `field:"optional" json:"asyncMaxRetryAttempts" yaml:"asyncMaxRetryAttempts"`
 }
 
@@ -30,6 +30,10 @@ type Topic interface {
 	Fqn() *string
 	// Experimental.
 	FriendlyUniqueId() *string
+	LambdaSubscriberDeadLetterQueues() *map[string]*string
+	SetLambdaSubscriberDeadLetterQueues(val *map[string]*string)
+	LambdaSubscriberFilterPolicies() *map[string]*string
+	SetLambdaSubscriberFilterPolicies(val *map[string]*string)
 	LambdaSubscribers() *map[string]*string
 	SetLambdaSubscribers(val *map[string]*string)
 	// The tree node.
@@ -141,6 +145,26 @@ func (j *jsiiProxy_Topic) FriendlyUniqueId() *string {
 	return returns
 }
 
+func (j *jsiiProxy_Topic) LambdaSubscriberDeadLetterQueues() *map[string]*string {
+	var returns *map[string]*string
+	_jsii_.Get(
+		j,
+		"lambdaSubscriberDeadLetterQueues",
+		&returns,
+	)
+	return returns
+}
+
+func (j *jsiiProxy_Topic) LambdaSubscriberFilterPolicies() *map[string]*string {
+	var returns *map[string]*string
+	_jsii_.Get(
+		j,
+		"lambdaSubscriberFilterPolicies",
+		&returns,
+	)
+	return returns
+}
+
 func (j *jsiiProxy_Topic) LambdaSubscribers() *map[string]*string {
 	var returns *map[string]*string
 	_jsii_.Get(
@@ -295,6 +319,22 @@ func (j *jsiiProxy_Topic)SetForEach(val cdktf.ITerraformIterator) {
 	)
 }
 
+func (j *jsiiProxy_Topic)SetLambdaSubscriberDeadLetterQueues(val *map[string]*string) {
+	_jsii_.Set(
+		j,
+		"lambdaSubscriberDeadLetterQueues",
+		val,
+	)
+}
+
+func (j *jsiiProxy_Topic)SetLambdaSubscriberFilterPolicies(val *map[string]*string) {
+	_jsii_.Set(
+		j,
+		"lambdaSubscriberFilterPolicies",
+		val,
+	)
+}
+
 func (j *jsiiProxy_Topic)SetLambdaSubscribers(val *map[string]*string) {
 	if err := j.validateSetLambdaSubscribersParameters(val); err != nil {
 		panic(err)
@@ -19,5 +19,9 @@ type TopicConfig struct {
 	StackId *string `field:"required" json:"stackId" yaml:"stackId"`
 	// The name of the topic.
 	TopicName *string `field:"required" json:"topicName" yaml:"topicName"`
+	// SNS filter policies of the lambda subscribers that only receive some messages.
+	LambdaSubscriberFilterPolicies *map[string]*string `field:"optional" json:"lambdaSubscriberFilterPolicies" yaml:"lambdaSubscriberFilterPolicies"`
+	// ARNs of the SQS queues that messages SNS fails to deliver to the lambda subscribers are moved to..
+	LambdaSubscriberDeadLetterQueues *map[string]*string `field:"optional" json:"lambdaSubscriberDeadLetterQueues" yaml:"lambdaSubscriberDeadLetterQueues"`
 }
 
@@ -22,6 +22,8 @@ func init() {
 			_jsii_.MemberProperty{JsiiProperty: "friendlyUniqueId", GoGetter: "FriendlyUniqueId"},
 			_jsii_.MemberMethod{JsiiMethod: "getString", GoMethod: "GetString"},
 			_jsii_.MemberMethod{JsiiMethod: "interpolationForOutput", GoMethod: "InterpolationForOutput"},
+			_jsii_.MemberProperty{JsiiProperty: "lambdaSubscriberDeadLetterQueues", GoGetter: "LambdaSubscriberDeadLetterQueues"},
+			_jsii_.MemberProperty{JsiiProperty: "lambdaSubscriberFilterPolicies", GoGetter: "LambdaSubscriberFilterPolicies"},
 			_jsii_.MemberProperty{JsiiProperty: "lambdaSubscribers", GoGetter: "LambdaSubscribers"},
 			_jsii_.MemberProperty{JsiiProperty: "node", GoGetter: "Node"},
 			_jsii_.MemberMethod{JsiiMethod: "overrideLogicalId", GoMethod: "OverrideLogicalId"},
@@ -15,6 +15,8 @@
 package deploytf
 
 import (
+	"fmt"
+
 	"github.com/aws/jsii-runtime-go"
 	"github.com/hashicorp/terraform-cdk-go/cdktf"
 	"github.com/nitrictech/nitric/cloud/aws/deploytf/generated/keyvalue"
@@ -23,6 +25,10 @@ import (
 
 // // KeyValueStore - Deploy a Key Value tioStore
 func (a *NitricAwsTerraformProvider) KeyValueStore(stack cdktf.TerraformStack, name string, config *deploymentspb.KeyValueStore) error {
+	if len(config.GetListeners()) > 0 {
+		return fmt.Errorf("key value store %s has listeners, which aren't supported by the terraform provider yet", name)
+	}
+
 	a.KeyValueStores[name] = keyvalue.NewKeyvalue(stack, jsii.Sprintf("kvstore_%s", name), &keyvalue.KeyvalueConfig{
 		KvstoreName: jsii.String(name),
 		StackId:     a.Stack.StackIdOutput(),
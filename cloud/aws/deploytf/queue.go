@@ -15,18 +15,69 @@
 package deploytf
 
 import (
+	"fmt"
+
 	"github.com/aws/jsii-runtime-go"
 	"github.com/hashicorp/terraform-cdk-go/cdktf"
 	"github.com/nitrictech/nitric/cloud/aws/deploytf/generated/queue"
 	deploymentspb "github.com/nitrictech/nitric/core/pkg/proto/deployments/v1"
+	resourcespb "github.com/nitrictech/nitric/core/pkg/proto/resources/v1"
 )
 
 // // Queue - Deploy a Queue
 func (a *NitricAwsTerraformProvider) Queue(stack cdktf.TerraformStack, name string, config *deploymentspb.Queue) error {
-	a.Queues[name] = queue.NewQueue(stack, jsii.Sprintf("queue_%s", name), &queue.QueueConfig{
+	if len(config.GetListeners()) > 0 {
+		return fmt.Errorf("queue %s has listeners, which aren't supported by the terraform provider yet", name)
+	}
+
+	queueConfig := &queue.QueueConfig{
 		QueueName: jsii.String(name),
 		StackId:   a.Stack.StackIdOutput(),
-	})
+		Fifo:      jsii.Bool(config.Fifo),
+	}
+
+	if policy := config.GetDeadLetterPolicy(); policy != nil {
+		if err := validateDeadLetterPolicy(name, policy); err != nil {
+			return err
+		}
+
+		target, ok := a.Queues[policy.TargetQueue]
+		if !ok {
+			return fmt.Errorf("dead-letter target queue %s for queue %s not found", policy.TargetQueue, name)
+		}
+
+		if err := validateDeadLetterTarget(name, config.Fifo, policy.TargetQueue, a.FifoQueues[policy.TargetQueue]); err != nil {
+			return err
+		}
+
+		queueConfig.DeadLetterQueueArn = target.QueueArnOutput()
+		queueConfig.MaxReceiveCount = jsii.Number(policy.MaxReceiveCount)
+	}
+
+	a.FifoQueues[name] = config.Fifo
+	a.Queues[name] = queue.NewQueue(stack, jsii.Sprintf("queue_%s", name), queueConfig)
+
+	return nil
+}
+
+// validateDeadLetterPolicy - SQS redrive policies accept a max receive count between 1 and 1000
+func validateDeadLetterPolicy(name string, policy *resourcespb.QueueDeadLetterPolicy) error {
+	if policy.TargetQueue == name {
+		return fmt.Errorf("queue %s can't be its own dead-letter target", name)
+	}
+
+	if policy.MaxReceiveCount < 1 || policy.MaxReceiveCount > 1000 {
+		return fmt.Errorf("max receive count for queue %s must be between 1 and 1000, got %d", name, policy.MaxReceiveCount)
+	}
+
+	return nil
+}
+
+// validateDeadLetterTarget - SQS requires a FIFO queue's dead-letter queue to also be FIFO, and a standard queue's to be standard
+func validateDeadLetterTarget(name string, fifo bool, targetName string, targetFifo bool) error {
+	if fifo != targetFifo {
+		return fmt.Errorf("queue %s and its dead-letter target queue %s must both be FIFO queues or both be standard queues", name, targetName)
+	}
 
 	return nil
 }
@@ -15,25 +15,117 @@
 package deploytf
 
 import (
+	"fmt"
+
 	"github.com/aws/jsii-runtime-go"
 	"github.com/hashicorp/terraform-cdk-go/cdktf"
+	"github.com/nitrictech/nitric/cloud/aws/deploy"
 	"github.com/nitrictech/nitric/cloud/aws/deploytf/generated/topic"
 	deploymentspb "github.com/nitrictech/nitric/core/pkg/proto/deployments/v1"
+	topicspb "github.com/nitrictech/nitric/core/pkg/proto/topics/v1"
+	"github.com/samber/lo"
 )
 
+// deadLetterQueueArn - returns the ARN of the queue that a subscription dead-letters messages to,
+// SNS and lambda destinations can't send messages to FIFO queues
+func (a *NitricAwsTerraformProvider) deadLetterQueueArn(name string) (*string, error) {
+	queue, ok := a.Queues[name]
+	if !ok {
+		return nil, fmt.Errorf("dead-letter queue %s not found", name)
+	}
+
+	if queue.Fifo() != nil && *queue.Fifo() {
+		return nil, fmt.Errorf("FIFO queue %s can't be used as the dead-letter queue of a subscription", name)
+	}
+
+	return queue.QueueArnOutput(), nil
+}
+
+// validateSubscriberRetryPolicies - returns an error if the retry policies of the stack's subscriptions can't be applied on aws
+func validateSubscriberRetryPolicies(resources []*deploymentspb.Resource) error {
+	return deploy.ValidateSubscriberRetryPolicies(
+		lo.FilterMap(resources, func(item *deploymentspb.Resource, idx int) (*deploymentspb.Topic, bool) {
+			return item.GetTopic(), item.GetTopic() != nil
+		}),
+		lo.FilterMap(resources, func(item *deploymentspb.Resource, idx int) (*deploymentspb.Schedule, bool) {
+			return item.GetSchedule(), item.GetSchedule() != nil
+		}),
+		lo.FilterMap(resources, func(item *deploymentspb.Resource, idx int) (*deploymentspb.Bucket, bool) {
+			return item.GetBucket(), item.GetBucket() != nil
+		}),
+	)
+}
+
+// subscriberRetryPolicy - configures the retries of the asynchronous invocations of a subscriber's lambda and the queue that failed messages are sent to.
+// The policies are validated by deploy.ValidateSubscriberRetryPolicies, so the policy of a service's first subscription applies to all of them
+func (a *NitricAwsTerraformProvider) subscriberRetryPolicy(serviceName string, policy *topicspb.SubscriptionRetryPolicy) error {
+	if _, ok := a.SubscriberRetryPolicies[serviceName]; ok {
+		return nil
+	}
+
+	a.SubscriberRetryPolicies[serviceName] = policy
+
+	if policy.GetMaxAttempts() > 0 {
+		a.Services[serviceName].SetAsyncMaxRetryAttempts(jsii.Number(policy.GetMaxAttempts() - 1))
+	}
+
+	if policy.GetDeadLetterQueue() != "" {
+		queueArn, err := a.deadLetterQueueArn(policy.GetDeadLetterQueue())
+		if err != nil {
+			return err
+		}
+
+		a.Services[serviceName].SetAsyncFailureDestinationArn(queueArn)
+	}
+
+	return nil
+}
+
 func (a *NitricAwsTerraformProvider) Topic(stack cdktf.TerraformStack, name string, config *deploymentspb.Topic) error {
 	lambdaSubscriberArns := map[string]*string{}
+	lambdaSubscriberFilterPolicies := map[string]*string{}
+	lambdaSubscriberDeadLetterQueues := map[string]*string{}
 
 	for _, subscriber := range config.Subscriptions {
 		// subscriber.GetService()
 		lambdaService := a.Services[subscriber.GetService()]
 		lambdaSubscriberArns[subscriber.GetService()] = lambdaService.LambdaArnOutput()
+
+		filterPolicy, err := deploy.SnsFilterPolicy(subscriber.GetFilter())
+		if err != nil {
+			return fmt.Errorf("invalid filter for subscription of service %s to topic %s: %w", subscriber.GetService(), name, err)
+		}
+
+		if filterPolicy != "" {
+			lambdaSubscriberFilterPolicies[subscriber.GetService()] = jsii.String(filterPolicy)
+		}
+
+		if err := deploy.ValidateRetryPolicy(subscriber.GetRetryPolicy()); err != nil {
+			return fmt.Errorf("invalid retry policy for subscription of service %s to topic %s: %w", subscriber.GetService(), name, err)
+		}
+
+		if err := a.subscriberRetryPolicy(subscriber.GetService(), subscriber.GetRetryPolicy()); err != nil {
+			return err
+		}
+
+		if deadLetterQueue := subscriber.GetRetryPolicy().GetDeadLetterQueue(); deadLetterQueue != "" {
+			queueArn, err := a.deadLetterQueueArn(deadLetterQueue)
+			if err != nil {
+				return err
+			}
+
+			// messages that SNS can't deliver to the lambda are moved to the dead-letter queue as well
+			a.Queues[deadLetterQueue].SetAllowSnsDeadLetters(jsii.Bool(true))
+			lambdaSubscriberDeadLetterQueues[subscriber.GetService()] = queueArn
+		}
 	}
 
 	a.Topics[name] = topic.NewTopic(stack, jsii.Sprintf("topic_%s", name), &topic.TopicConfig{
-		StackId:           a.Stack.StackIdOutput(),
-		TopicName:         jsii.String(name),
-		LambdaSubscribers: &lambdaSubscriberArns,
+		StackId:                          a.Stack.StackIdOutput(),
+		TopicName:                        jsii.String(name),
+		LambdaSubscribers:                &lambdaSubscriberArns,
+		LambdaSubscriberFilterPolicies:   &lambdaSubscriberFilterPolicies,
+		LambdaSubscriberDeadLetterQueues: &lambdaSubscriberDeadLetterQueues,
 	})
 
 	return nil
@@ -35,6 +35,26 @@ func (m *MockSecretsManagerAPI) EXPECT() *MockSecretsManagerAPIMockRecorder {
 	return m.recorder
 }
 
+// DescribeSecret mocks base method.
+func (m *MockSecretsManagerAPI) DescribeSecret(arg0 context.Context, arg1 *secretsmanager.DescribeSecretInput, arg2 ...func(*secretsmanager.Options)) (*secretsmanager.DescribeSecretOutput, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{arg0, arg1}
+	for _, a := range arg2 {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "DescribeSecret", varargs...)
+	ret0, _ := ret[0].(*secretsmanager.DescribeSecretOutput)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DescribeSecret indicates an expected call of DescribeSecret.
+func (mr *MockSecretsManagerAPIMockRecorder) DescribeSecret(arg0, arg1 interface{}, arg2 ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{arg0, arg1}, arg2...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DescribeSecret", reflect.TypeOf((*MockSecretsManagerAPI)(nil).DescribeSecret), varargs...)
+}
+
 // GetSecretValue mocks base method.
 func (m *MockSecretsManagerAPI) GetSecretValue(arg0 context.Context, arg1 *secretsmanager.GetSecretValueInput, arg2 ...func(*secretsmanager.Options)) (*secretsmanager.GetSecretValueOutput, error) {
 	m.ctrl.T.Helper()
@@ -55,6 +75,26 @@ func (mr *MockSecretsManagerAPIMockRecorder) GetSecretValue(arg0, arg1 interface
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSecretValue", reflect.TypeOf((*MockSecretsManagerAPI)(nil).GetSecretValue), varargs...)
 }
 
+// ListSecretVersionIds mocks base method.
+func (m *MockSecretsManagerAPI) ListSecretVersionIds(arg0 context.Context, arg1 *secretsmanager.ListSecretVersionIdsInput, arg2 ...func(*secretsmanager.Options)) (*secretsmanager.ListSecretVersionIdsOutput, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{arg0, arg1}
+	for _, a := range arg2 {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "ListSecretVersionIds", varargs...)
+	ret0, _ := ret[0].(*secretsmanager.ListSecretVersionIdsOutput)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListSecretVersionIds indicates an expected call of ListSecretVersionIds.
+func (mr *MockSecretsManagerAPIMockRecorder) ListSecretVersionIds(arg0, arg1 interface{}, arg2 ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{arg0, arg1}, arg2...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListSecretVersionIds", reflect.TypeOf((*MockSecretsManagerAPI)(nil).ListSecretVersionIds), varargs...)
+}
+
 // PutSecretValue mocks base method.
 func (m *MockSecretsManagerAPI) PutSecretValue(arg0 context.Context, arg1 *secretsmanager.PutSecretValueInput, arg2 ...func(*secretsmanager.Options)) (*secretsmanager.PutSecretValueOutput, error) {
 	m.ctrl.T.Helper()
@@ -74,3 +114,23 @@ func (mr *MockSecretsManagerAPIMockRecorder) PutSecretValue(arg0, arg1 interface
 	varargs := append([]interface{}{arg0, arg1}, arg2...)
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PutSecretValue", reflect.TypeOf((*MockSecretsManagerAPI)(nil).PutSecretValue), varargs...)
 }
+
+// UpdateSecretVersionStage mocks base method.
+func (m *MockSecretsManagerAPI) UpdateSecretVersionStage(arg0 context.Context, arg1 *secretsmanager.UpdateSecretVersionStageInput, arg2 ...func(*secretsmanager.Options)) (*secretsmanager.UpdateSecretVersionStageOutput, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{arg0, arg1}
+	for _, a := range arg2 {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "UpdateSecretVersionStage", varargs...)
+	ret0, _ := ret[0].(*secretsmanager.UpdateSecretVersionStageOutput)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateSecretVersionStage indicates an expected call of UpdateSecretVersionStage.
+func (mr *MockSecretsManagerAPIMockRecorder) UpdateSecretVersionStage(arg0, arg1 interface{}, arg2 ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{arg0, arg1}, arg2...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateSecretVersionStage", reflect.TypeOf((*MockSecretsManagerAPI)(nil).UpdateSecretVersionStage), varargs...)
+}
@@ -32,6 +32,8 @@ const (
 	healthcheck
 	// cloudwatch
 	schedule
+	dynamodbStream
+	sqsEvent
 	xforwardHeader string = "x-forwarded-for"
 )
 
@@ -47,6 +49,8 @@ type Record struct {
 	ResponseElements map[string]string
 	S3               events.S3Entity
 	SNS              events.SNSEntity
+	DynamoDB         events.DynamoDBStreamRecord
+	SQS              events.SQSMessage
 }
 
 type nitricScheduleEvent struct {
@@ -74,6 +78,10 @@ func (e *Event) Type() eventType {
 		return sns
 	} else if len(e.Records) > 0 && e.Records[0].EventSource == "aws:s3" {
 		return s3
+	} else if len(e.Records) > 0 && e.Records[0].EventSource == "aws:dynamodb" {
+		return dynamodbStream
+	} else if len(e.Records) > 0 && e.Records[0].EventSource == "aws:sqs" {
+		return sqsEvent
 	} else if e.Schedule != "" {
 		return schedule
 	}
@@ -125,6 +133,43 @@ func (e *Event) UnmarshalJSON(data []byte) error {
 			})
 		}
 
+	case dynamodbStream:
+		dynamodbEvent := &events.DynamoDBEvent{}
+		err = json.Unmarshal(data, dynamodbEvent)
+		if err != nil {
+			return err
+		}
+
+		e.Records = make([]Record, 0)
+
+		for _, dynamodbRecord := range dynamodbEvent.Records {
+			e.Records = append(e.Records, Record{
+				EventSource:      dynamodbRecord.EventSource,
+				EventSourceArn:   dynamodbRecord.EventSourceArn,
+				EventName:        dynamodbRecord.EventName,
+				ResponseElements: map[string]string{},
+				DynamoDB:         dynamodbRecord.Change,
+			})
+		}
+
+	case sqsEvent:
+		sqsMessages := &events.SQSEvent{}
+		err = json.Unmarshal(data, sqsMessages)
+		if err != nil {
+			return err
+		}
+
+		e.Records = make([]Record, 0)
+
+		for _, sqsRecord := range sqsMessages.Records {
+			e.Records = append(e.Records, Record{
+				EventSource:      sqsRecord.EventSource,
+				EventSourceArn:   sqsRecord.EventSourceARN,
+				ResponseElements: map[string]string{},
+				SQS:              sqsRecord,
+			})
+		}
+
 	case httpEvent:
 		apiEvent := events.APIGatewayV2HTTPRequest{}
 		err = json.Unmarshal(data, &apiEvent)
@@ -206,6 +251,10 @@ func (e *Event) getEventType(data []byte) eventType {
 		return s3
 	case "aws:sns":
 		return sns
+	case "aws:dynamodb":
+		return dynamodbStream
+	case "aws:sqs":
+		return sqsEvent
 	}
 
 	return unknown
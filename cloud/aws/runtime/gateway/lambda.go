@@ -46,6 +46,8 @@ func (s *LambdaGateway) Start(opts *gateway.GatewayStartOpts) error {
 		Subscriptions:      opts.TopicsListenerPlugin,
 		StorageListeners:   opts.StorageListenerPlugin,
 		WebsocketListeners: opts.WebsocketListenerPlugin,
+		KeyValueListeners:  opts.KeyValueListenerPlugin,
+		QueueListeners:     opts.QueueListenerPlugin,
 	}
 
 	// Begin polling lambda for incoming requests...
@@ -16,11 +16,13 @@ package gateway_test
 
 import (
 	"context"
+	"encoding/base64"
 	"encoding/json"
 	"fmt"
 	"os"
 
 	"github.com/aws/aws-lambda-go/events"
+	"github.com/aws/aws-sdk-go-v2/aws"
 	"github.com/golang/mock/gomock"
 	"google.golang.org/protobuf/proto"
 	"google.golang.org/protobuf/types/known/structpb"
@@ -33,12 +35,16 @@ import (
 	"github.com/nitrictech/nitric/cloud/aws/runtime/resource"
 	commonenv "github.com/nitrictech/nitric/cloud/common/runtime/env"
 	mock_apis "github.com/nitrictech/nitric/core/mocks/workers/apis"
+	mock_keyvalue "github.com/nitrictech/nitric/core/mocks/workers/keyvalue"
+	mock_queues "github.com/nitrictech/nitric/core/mocks/workers/queues"
 	mock_storage "github.com/nitrictech/nitric/core/mocks/workers/storage"
 	mock_topics "github.com/nitrictech/nitric/core/mocks/workers/topics"
 	mock_websockets "github.com/nitrictech/nitric/core/mocks/workers/websockets"
 	"github.com/nitrictech/nitric/core/pkg/env"
 	coreGateway "github.com/nitrictech/nitric/core/pkg/gateway"
 	apispb "github.com/nitrictech/nitric/core/pkg/proto/apis/v1"
+	kvstorepb "github.com/nitrictech/nitric/core/pkg/proto/kvstore/v1"
+	queuespb "github.com/nitrictech/nitric/core/pkg/proto/queues/v1"
 	storagepb "github.com/nitrictech/nitric/core/pkg/proto/storage/v1"
 	topicspb "github.com/nitrictech/nitric/core/pkg/proto/topics/v1"
 	websocketspb "github.com/nitrictech/nitric/core/pkg/proto/websockets/v1"
@@ -137,7 +143,7 @@ var _ = Describe("Lambda", func() {
 				// mockHandler.EXPECT().HandlesTrigger(gomock.Any()).Return(true)
 
 				By("Handling a single HTTP request")
-				mockManager.EXPECT().HandleRequest(gomock.Any(), EqProto(&apispb.ServerMessage{
+				mockManager.EXPECT().HandleRequest(gomock.Any(), gomock.Any(), EqProto(&apispb.ServerMessage{
 					Content: &apispb.ServerMessage_HttpRequest{
 						HttpRequest: &apispb.HttpRequest{
 							Method: "GET",
@@ -217,7 +223,7 @@ var _ = Describe("Lambda", func() {
 				}, nil)
 
 				By("Handling a single HTTP request")
-				mockManager.EXPECT().HandleRequest(&websocketspb.ServerMessage{
+				mockManager.EXPECT().HandleRequest(gomock.Any(), &websocketspb.ServerMessage{
 					Content: &websocketspb.ServerMessage_WebsocketEventRequest{
 						WebsocketEventRequest: &websocketspb.WebsocketEventRequest{
 							SocketName:   "test-api",
@@ -283,7 +289,10 @@ var _ = Describe("Lambda", func() {
 							EventSubscriptionArn: "some:arbitrary:subscription:arn:MySubscription",
 							SNS: events.SNSEntity{
 								TopicArn: fmt.Sprintf("arn:aws:sns:us-east-1:12345678910:arn:%s", topicName),
-								Message:  string(messageBytes),
+								Message:  base64.StdEncoding.EncodeToString(messageBytes),
+								MessageAttributes: map[string]interface{}{
+									"x-nitric-encoding": map[string]interface{}{"Type": "String", "Value": "protobuf"},
+								},
 							},
 						},
 					},
@@ -304,7 +313,7 @@ var _ = Describe("Lambda", func() {
 				mockManager.EXPECT().WorkerCount().Return(1)
 
 				By("Handling a single event")
-				mockManager.EXPECT().HandleRequest(EqProto(&topicspb.ServerMessage{
+				mockManager.EXPECT().HandleRequest(gomock.Any(), EqProto(&topicspb.ServerMessage{
 					Content: &topicspb.ServerMessage_MessageRequest{
 						MessageRequest: &topicspb.MessageRequest{
 							TopicName: "MyTopic",
@@ -328,6 +337,76 @@ var _ = Describe("Lambda", func() {
 				Expect(err).To(BeNil())
 			})
 		})
+
+		When("The Lambda Gateway receives SNS events published by other producers", func() {
+			ctrl := gomock.NewController(GinkgoT())
+			mockResolver := mock_provider.NewMockAwsResourceResolver(ctrl)
+			mockManager := mock_topics.NewMockSubscriptionRequestHandler(ctrl)
+
+			runtime := MockLambdaRuntime{
+				eventQueue: []interface{}{&events.SNSEvent{
+					Records: []events.SNSEventRecord{
+						{
+							EventSource:          "aws:sns",
+							EventSubscriptionArn: "some:arbitrary:subscription:arn:MySubscription",
+							SNS: events.SNSEntity{
+								TopicArn: "arn:aws:sns:us-east-1:12345678910:arn:MyTopic",
+								Message:  `{"order": 12345678901234567890}`,
+								MessageAttributes: map[string]interface{}{
+									"content-type": map[string]interface{}{
+										"Type":  "String",
+										"Value": "application/json",
+									},
+								},
+							},
+						},
+					},
+				}},
+			}
+
+			client := gateway.New(mockResolver, gateway.WithRuntime(runtime.Start))
+
+			It("The gateway should deliver the message as a bytes payload", func() {
+				defer ctrl.Finish()
+
+				mockResolver.EXPECT().GetResources(gomock.Any(), resource.AwsResource_Topic).Return(map[string]resource.ResolvedResource{
+					"MyTopic": {
+						ARN: "arn:aws:sns:us-east-1:12345678910:arn:MyTopic",
+					},
+				}, nil)
+
+				By("Delivering the original message with its content type")
+				mockManager.EXPECT().HandleRequest(gomock.Any(), EqProto(&topicspb.ServerMessage{
+					Content: &topicspb.ServerMessage_MessageRequest{
+						MessageRequest: &topicspb.MessageRequest{
+							TopicName: "MyTopic",
+							Message: &topicspb.TopicMessage{
+								Content: &topicspb.TopicMessage_BytesPayload{
+									BytesPayload: &topicspb.BytesPayload{
+										Data:        []byte(`{"order": 12345678901234567890}`),
+										ContentType: "application/json",
+									},
+								},
+								Attributes: map[string]string{
+									"content-type": "application/json",
+								},
+							},
+						},
+					},
+				})).Return(&topicspb.ClientMessage{
+					Content: &topicspb.ClientMessage_MessageResponse{
+						MessageResponse: &topicspb.MessageResponse{
+							Success: true,
+						},
+					},
+				}, nil)
+
+				err := client.Start(&coreGateway.GatewayStartOpts{
+					TopicsListenerPlugin: mockManager,
+				})
+				Expect(err).To(BeNil())
+			})
+		})
 	})
 
 	Context("S3 Events", func() {
@@ -379,7 +458,7 @@ var _ = Describe("Lambda", func() {
 				// mockHandler.EXPECT().HandlesTrigger(gomock.Any()).Return(true)
 
 				By("Handling a single Notification request")
-				mockManager.EXPECT().HandleRequest(&storagepb.ServerMessage{
+				mockManager.EXPECT().HandleRequest(gomock.Any(), &storagepb.ServerMessage{
 					Content: &storagepb.ServerMessage_BlobEventRequest{
 						BlobEventRequest: &storagepb.BlobEventRequest{
 							BucketName: "images",
@@ -452,7 +531,7 @@ var _ = Describe("Lambda", func() {
 				mockManager.EXPECT().WorkerCount().Return(1)
 
 				By("Handling a single Notification request")
-				mockManager.EXPECT().HandleRequest(&storagepb.ServerMessage{
+				mockManager.EXPECT().HandleRequest(gomock.Any(), &storagepb.ServerMessage{
 					Content: &storagepb.ServerMessage_BlobEventRequest{
 						BlobEventRequest: &storagepb.BlobEventRequest{
 							BucketName: "images",
@@ -479,4 +558,143 @@ var _ = Describe("Lambda", func() {
 			})
 		})
 	})
+
+	Context("DynamoDB Stream Events", func() {
+		When("The Lambda Gateway receives DynamoDB MODIFY records", func() {
+			ctrl := gomock.NewController(GinkgoT())
+			mockResolver := mock_provider.NewMockAwsResourceResolver(ctrl)
+
+			mockManager := mock_keyvalue.NewMockStoreRequestHandler(ctrl)
+
+			runtime := MockLambdaRuntime{
+				// Setup mock events for our runtime to process...
+				eventQueue: []interface{}{&events.DynamoDBEvent{
+					Records: []events.DynamoDBEventRecord{
+						{
+							EventSource:    "aws:dynamodb",
+							EventName:      "MODIFY",
+							EventSourceArn: "arn:aws:dynamodb:us-east-1:12345678910:table/customers-abc123/stream/2024-01-01T00:00:00.000",
+							Change: events.DynamoDBStreamRecord{
+								Keys: map[string]events.DynamoDBAttributeValue{
+									"_pk": events.NewStringAttribute("customer-1"),
+									"_sk": events.NewStringAttribute("customers#"),
+								},
+							},
+						},
+					},
+				}},
+			}
+
+			client := gateway.New(mockResolver, gateway.WithRuntime(runtime.Start))
+
+			It("The gateway should translate into a standard NitricRequest", func() {
+				By("The key value store existing")
+				mockResolver.EXPECT().GetResources(gomock.Any(), resource.AwsResource_Collection).Return(map[string]resource.ResolvedResource{
+					"customers": {ARN: "arn:aws:dynamodb:us-east-1:12345678910:table/customers-abc123"},
+				}, nil)
+
+				By("Handling a single value event")
+				mockManager.EXPECT().HandleRequest(gomock.Any(), EqProto(&kvstorepb.ServerMessage{
+					Content: &kvstorepb.ServerMessage_KeyValueEventRequest{
+						KeyValueEventRequest: &kvstorepb.KeyValueEventRequest{
+							StoreName: "customers",
+							Event: &kvstorepb.KeyValueEventRequest_KeyValueEvent{
+								KeyValueEvent: &kvstorepb.KeyValueEvent{
+									Key:  "customer-1",
+									Type: kvstorepb.KeyValueEventType_Set,
+								},
+							},
+						},
+					},
+				})).Return(&kvstorepb.ClientMessage{
+					Content: &kvstorepb.ClientMessage_KeyValueEventResponse{
+						KeyValueEventResponse: &kvstorepb.KeyValueEventResponse{
+							Success: true,
+						},
+					},
+				}, nil)
+
+				err := client.Start(&coreGateway.GatewayStartOpts{
+					KeyValueListenerPlugin: mockManager,
+				})
+				Expect(err).To(BeNil())
+
+				ctrl.Finish()
+			})
+		})
+	})
+
+	Context("SQS Events", func() {
+		When("The Lambda Gateway receives SQS messages", func() {
+			ctrl := gomock.NewController(GinkgoT())
+			mockResolver := mock_provider.NewMockAwsResourceResolver(ctrl)
+
+			mockManager := mock_queues.NewMockQueueRequestHandler(ctrl)
+
+			content, _ := structpb.NewStruct(map[string]interface{}{
+				"Test": "Test",
+			})
+
+			message := &queuespb.QueueMessage{
+				Content: &queuespb.QueueMessage_StructPayload{
+					StructPayload: content,
+				},
+			}
+
+			messageBytes, _ := proto.Marshal(message)
+
+			runtime := MockLambdaRuntime{
+				// Setup mock events for our runtime to process...
+				eventQueue: []interface{}{&events.SQSEvent{
+					Records: []events.SQSMessage{
+						{
+							MessageId:      "test-message",
+							EventSource:    "aws:sqs",
+							EventSourceARN: "arn:aws:sqs:us-east-1:12345678910:orders-abc123",
+							Body:           base64.StdEncoding.EncodeToString(messageBytes),
+							Attributes: map[string]string{
+								"ApproximateReceiveCount": "2",
+							},
+							MessageAttributes: map[string]events.SQSMessageAttribute{
+								"x-nitric-encoding": {DataType: "String", StringValue: aws.String("protobuf")},
+							},
+						},
+					},
+				}},
+			}
+
+			client := gateway.New(mockResolver, gateway.WithRuntime(runtime.Start))
+
+			It("The gateway should translate into a standard NitricRequest", func() {
+				By("The queue existing")
+				mockResolver.EXPECT().GetResources(gomock.Any(), resource.AwsResource_Queue).Return(map[string]resource.ResolvedResource{
+					"orders": {ARN: "arn:aws:sqs:us-east-1:12345678910:orders-abc123"},
+				}, nil)
+
+				By("Handling a single message with its delivery attempt")
+				mockManager.EXPECT().HandleRequest(gomock.Any(), EqProto(&queuespb.ServerMessage{
+					Content: &queuespb.ServerMessage_MessageRequest{
+						MessageRequest: &queuespb.MessageRequest{
+							QueueName:       "orders",
+							Message:         message,
+							DeliveryAttempt: 2,
+						},
+					},
+				})).Return(&queuespb.ClientMessage{
+					Content: &queuespb.ClientMessage_MessageResponse{
+						MessageResponse: &queuespb.MessageResponse{
+							Success: true,
+						},
+					},
+				}, nil)
+
+				err := client.Start(&coreGateway.GatewayStartOpts{
+					QueueListenerPlugin: mockManager,
+				})
+				Expect(err).To(BeNil())
+
+				ctrl.Finish()
+			})
+		})
+	})
 })
@@ -20,24 +20,32 @@ import (
 	"encoding/json"
 	"fmt"
 	"net/url"
+	"strconv"
 	"strings"
 
 	"github.com/aws/aws-lambda-go/events"
+	"github.com/nitrictech/nitric/cloud/aws/runtime/queue"
 	"github.com/nitrictech/nitric/cloud/aws/runtime/resource"
+	"github.com/nitrictech/nitric/cloud/common/runtime/messages"
 	"github.com/nitrictech/nitric/core/pkg/logger"
 	apispb "github.com/nitrictech/nitric/core/pkg/proto/apis/v1"
+	kvstorepb "github.com/nitrictech/nitric/core/pkg/proto/kvstore/v1"
+	queuespb "github.com/nitrictech/nitric/core/pkg/proto/queues/v1"
 	schedulespb "github.com/nitrictech/nitric/core/pkg/proto/schedules/v1"
 	storagepb "github.com/nitrictech/nitric/core/pkg/proto/storage/v1"
 	topicspb "github.com/nitrictech/nitric/core/pkg/proto/topics/v1"
 	websocketspb "github.com/nitrictech/nitric/core/pkg/proto/websockets/v1"
+	"github.com/nitrictech/nitric/core/pkg/workers"
 	"github.com/nitrictech/nitric/core/pkg/workers/apis"
 	"github.com/nitrictech/nitric/core/pkg/workers/http"
+	"github.com/nitrictech/nitric/core/pkg/workers/keyvalue"
+	"github.com/nitrictech/nitric/core/pkg/workers/queues"
 	"github.com/nitrictech/nitric/core/pkg/workers/schedules"
 	"github.com/nitrictech/nitric/core/pkg/workers/storage"
 	"github.com/nitrictech/nitric/core/pkg/workers/topics"
 	"github.com/nitrictech/nitric/core/pkg/workers/websockets"
 	"github.com/valyala/fasthttp"
-	"google.golang.org/protobuf/proto"
+	"go.opentelemetry.io/otel/propagation"
 )
 
 type Handlers struct {
@@ -47,6 +55,8 @@ type Handlers struct {
 	Subscriptions      topics.SubscriptionRequestHandler
 	StorageListeners   storage.BucketRequestHandler
 	WebsocketListeners websockets.WebsocketRequestHandler
+	KeyValueListeners  keyvalue.StoreRequestHandler
+	QueueListeners     queues.QueueRequestHandler
 }
 
 type LambdaEventRouter func(ctx context.Context, resolver resource.AwsResourceResolver, handlers *Handlers, evt json.RawMessage) (interface{}, error)
@@ -88,6 +98,38 @@ func getBucketNameForArn(ctx context.Context, resolver resource.AwsResourceResol
 	return "", fmt.Errorf("could not find bucket for arn %s", bucketArn)
 }
 
+// getStoreNameForStreamArn returns the name of the key/value store whose table a dynamodb stream belongs to
+func getStoreNameForStreamArn(ctx context.Context, resolver resource.AwsResourceResolver, streamArn string) (string, error) {
+	stores, err := resolver.GetResources(ctx, resource.AwsResource_Collection)
+	if err != nil {
+		return "", fmt.Errorf("error retrieving key value stores: %w", err)
+	}
+
+	// stream arns are the table arn followed by /stream/<timestamp>
+	for name, store := range stores {
+		if strings.HasPrefix(streamArn, store.ARN+"/stream/") {
+			return name, nil
+		}
+	}
+
+	return "", fmt.Errorf("could not find key value store for stream arn %s", streamArn)
+}
+
+func getQueueNameForArn(ctx context.Context, resolver resource.AwsResourceResolver, queueArn string) (string, error) {
+	queues, err := resolver.GetResources(ctx, resource.AwsResource_Queue)
+	if err != nil {
+		return "", fmt.Errorf("error retrieving queues: %w", err)
+	}
+
+	for name, queue := range queues {
+		if queue.ARN == queueArn {
+			return name, nil
+		}
+	}
+
+	return "", fmt.Errorf("could not find queue for arn %s", queueArn)
+}
+
 // handleSnsEvents translates AWS SNS events to Nitric topic events and forwards them to be handled by registered workers.
 func handleSnsEvents(ctx context.Context, resolver resource.AwsResourceResolver, subscriptions topics.SubscriptionRequestHandler, records []Record) (interface{}, error) {
 	for _, snsRecord := range records {
@@ -95,9 +137,14 @@ func handleSnsEvents(ctx context.Context, resolver resource.AwsResourceResolver,
 		attrs := map[string]string{}
 
 		for k, v := range snsRecord.SNS.MessageAttributes {
-			sv, ok := v.(string)
-			if ok {
-				attrs[k] = sv
+			switch attr := v.(type) {
+			case string:
+				attrs[k] = attr
+			case map[string]interface{}:
+				// lambda delivers attributes as objects with their type and value
+				if sv, ok := attr["Value"].(string); ok {
+					attrs[k] = sv
+				}
 			}
 		}
 
@@ -107,29 +154,22 @@ func handleSnsEvents(ctx context.Context, resolver resource.AwsResourceResolver,
 			continue
 		}
 
-		messageBytes, err := base64.StdEncoding.DecodeString(messageString)
-		if err != nil {
-			logger.Errorf("unable decode SNS payload: %v", err)
-			continue
-		}
-
-		var message topicspb.TopicMessage
-
-		if err := proto.Unmarshal(messageBytes, &message); err != nil {
-			logger.Errorf("unable to unmarshal nitric message from SNS trigger: %v", err)
-			continue
-		}
+		// messages published to the topic by other producers are delivered as bytes payloads
+		message := messages.DecodeBase64TopicMessage(messageString, attrs)
 
 		request := &topicspb.ServerMessage{
 			Content: &topicspb.ServerMessage_MessageRequest{
 				MessageRequest: &topicspb.MessageRequest{
 					TopicName: tName,
-					Message:   &message,
+					Message:   message,
 				},
 			},
 		}
 
-		resp, err := subscriptions.HandleRequest(request)
+		// continue the publisher's trace, its trace context is published as message attributes
+		recordCtx := workers.ExtractTraceContext(ctx, propagation.MapCarrier(attrs))
+
+		resp, err := subscriptions.HandleRequest(recordCtx, request)
 		if err != nil {
 			return nil, err
 		}
@@ -157,7 +197,7 @@ func handleScheduleEvent(ctx context.Context, schedules schedules.ScheduleReques
 		},
 	}
 
-	_, err := schedules.HandleRequest(request)
+	_, err := schedules.HandleRequest(ctx, request)
 	if err != nil {
 		return nil, err
 	}
@@ -197,7 +237,7 @@ func handleHttpProxyRequest(ctx context.Context, httpmanager http.HttpRequestHan
 		request.Header.Add("Cookie", cookie)
 	}
 
-	resp, err := httpmanager.HandleRequest(request)
+	resp, err := httpmanager.HandleRequest(ctx, request)
 	if err != nil {
 		return nil, err
 	}
@@ -276,7 +316,8 @@ func handleApiGatewayRequest(ctx context.Context, nitricName string, apismanager
 		},
 	}
 
-	resp, err := apismanager.HandleRequest(nitricName, req)
+	// lambda responses must be complete, so streamed response bodies are buffered by HandleRequest
+	resp, err := apismanager.HandleRequest(ctx, nitricName, req)
 	if err != nil {
 		return events.APIGatewayProxyResponse{
 			StatusCode:      500,
@@ -358,7 +399,7 @@ func handleWebsocketEvent(ctx context.Context, resolver resource.AwsResourceReso
 		Content: wsEvent,
 	}
 
-	resp, err := websockets.HandleRequest(req)
+	resp, err := websockets.HandleRequest(ctx, req)
 	if err != nil {
 		return events.APIGatewayProxyResponse{
 			StatusCode:      500,
@@ -417,7 +458,7 @@ func handleS3Event(ctx context.Context, resolver resource.AwsResourceResolver, s
 			},
 		}
 
-		resp, err := storageListeners.HandleRequest(msg)
+		resp, err := storageListeners.HandleRequest(ctx, msg)
 		if err != nil {
 			return nil, err
 		}
@@ -430,6 +471,127 @@ func handleS3Event(ctx context.Context, resolver resource.AwsResourceResolver, s
 	return nil, nil
 }
 
+// Converts a DynamoDB stream record event name to the corresponding nitric key/value event type
+func dynamodbEventNameToNitricKeyValueEventType(eventName string) (*kvstorepb.KeyValueEventType, error) {
+	switch eventName {
+	case "INSERT", "MODIFY":
+		return kvstorepb.KeyValueEventType_Set.Enum(), nil
+	case "REMOVE":
+		return kvstorepb.KeyValueEventType_Delete.Enum(), nil
+	}
+	return nil, fmt.Errorf("unsupported key value event type, expected INSERT, MODIFY or REMOVE, got %s", eventName)
+}
+
+// handleDynamoDBStreamEvent translates DynamoDB stream records to Nitric key/value events and forwards them to be handled by registered workers.
+// Returning an error causes lambda to retry the batch of records.
+func handleDynamoDBStreamEvent(ctx context.Context, resolver resource.AwsResourceResolver, keyValueListeners keyvalue.StoreRequestHandler, records []Record) (interface{}, error) {
+	for _, dynamodbRecord := range records {
+		storeName, err := getStoreNameForStreamArn(ctx, resolver, dynamodbRecord.EventSourceArn)
+		if err != nil {
+			logger.Errorf("unable to find nitric key value store: %s", err.Error())
+			return nil, fmt.Errorf("unable to find nitric key value store: %w", err)
+		}
+
+		eventType, err := dynamodbEventNameToNitricKeyValueEventType(dynamodbRecord.EventName)
+		if err != nil {
+			return nil, err
+		}
+
+		key, ok := dynamodbRecord.DynamoDB.Keys["_pk"]
+		if !ok || key.DataType() != events.DataTypeString {
+			return nil, fmt.Errorf("stream record for store %s is missing its key", storeName)
+		}
+
+		msg := &kvstorepb.ServerMessage{
+			Content: &kvstorepb.ServerMessage_KeyValueEventRequest{
+				KeyValueEventRequest: &kvstorepb.KeyValueEventRequest{
+					StoreName: storeName,
+					Event: &kvstorepb.KeyValueEventRequest_KeyValueEvent{
+						KeyValueEvent: &kvstorepb.KeyValueEvent{
+							Key:  key.String(),
+							Type: *eventType,
+						},
+					},
+				},
+			},
+		}
+
+		resp, err := keyValueListeners.HandleRequest(ctx, msg)
+		if err != nil {
+			return nil, err
+		}
+
+		if !resp.GetKeyValueEventResponse().Success {
+			return nil, fmt.Errorf("failed to process key value event")
+		}
+	}
+
+	return nil, nil
+}
+
+// handleSqsEvents translates SQS messages delivered by an event source mapping to Nitric queue messages and forwards them to registered listeners.
+//
+//	Messages that fail are reported as batch item failures, so only those are returned to the queue and redelivered.
+//	The messages after a failure on a FIFO queue also fail, so messages in the same group aren't handled out of order.
+func handleSqsEvents(ctx context.Context, resolver resource.AwsResourceResolver, queueListeners queues.QueueRequestHandler, records []Record) (interface{}, error) {
+	failures := []events.SQSBatchItemFailure{}
+
+	for _, sqsRecord := range records {
+		if len(failures) > 0 && strings.HasSuffix(sqsRecord.EventSourceArn, ".fifo") {
+			failures = append(failures, events.SQSBatchItemFailure{ItemIdentifier: sqsRecord.SQS.MessageId})
+			continue
+		}
+
+		if err := handleSqsRecord(ctx, resolver, queueListeners, sqsRecord); err != nil {
+			logger.Errorf("error handling message %s: %v", sqsRecord.SQS.MessageId, err)
+			failures = append(failures, events.SQSBatchItemFailure{ItemIdentifier: sqsRecord.SQS.MessageId})
+		}
+	}
+
+	return events.SQSEventResponse{
+		BatchItemFailures: failures,
+	}, nil
+}
+
+// handleSqsRecord forwards a single SQS message to a listener for its queue, returning an error if it wasn't handled successfully
+func handleSqsRecord(ctx context.Context, resolver resource.AwsResourceResolver, queueListeners queues.QueueRequestHandler, sqsRecord Record) error {
+	queueName, err := getQueueNameForArn(ctx, resolver, sqsRecord.EventSourceArn)
+	if err != nil {
+		return fmt.Errorf("unable to find nitric queue: %w", err)
+	}
+
+	attributes := map[string]string{}
+	for name, attr := range sqsRecord.SQS.MessageAttributes {
+		if attr.StringValue != nil {
+			attributes[name] = *attr.StringValue
+		}
+	}
+
+	message := queue.DecodeMessage(sqsRecord.SQS.Body, attributes)
+
+	// the receive count is informational, it's left as unknown if it can't be parsed
+	receiveCount, _ := strconv.ParseInt(sqsRecord.SQS.Attributes["ApproximateReceiveCount"], 10, 32)
+
+	resp, err := queueListeners.HandleRequest(ctx, &queuespb.ServerMessage{
+		Content: &queuespb.ServerMessage_MessageRequest{
+			MessageRequest: &queuespb.MessageRequest{
+				QueueName:       queueName,
+				Message:         message,
+				DeliveryAttempt: int32(receiveCount),
+			},
+		},
+	})
+	if err != nil {
+		return err
+	}
+
+	if !resp.GetMessageResponse().GetSuccess() {
+		return fmt.Errorf("message processing failed")
+	}
+
+	return nil
+}
+
 // isRejectedConnection returns true if the client message was a rejection response to a connection request.
 func isRejectedConnection(resp *websocketspb.ClientMessage) bool {
 	eventResponse := resp.GetWebsocketEventResponse()
@@ -468,6 +630,10 @@ func StandardEventRouter(ctx context.Context, resolver resource.AwsResourceResol
 		return handleSnsEvents(ctx, resolver, handlers.Subscriptions, event.Records)
 	case s3:
 		return handleS3Event(ctx, resolver, handlers.StorageListeners, event.Records)
+	case dynamodbStream:
+		return handleDynamoDBStreamEvent(ctx, resolver, handlers.KeyValueListeners, event.Records)
+	case sqsEvent:
+		return handleSqsEvents(ctx, resolver, handlers.QueueListeners, event.Records)
 	case schedule:
 		return handleScheduleEvent(ctx, handlers.Schedules, event.nitricScheduleEvent)
 	default:
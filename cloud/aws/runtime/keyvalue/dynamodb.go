@@ -18,14 +18,18 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"strconv"
 	"strings"
+	"time"
 
 	"github.com/aws/aws-sdk-go-v2/aws"
 	"github.com/aws/aws-sdk-go-v2/config"
 	"github.com/aws/aws-sdk-go-v2/feature/dynamodb/attributevalue"
 	"github.com/aws/aws-sdk-go-v2/feature/dynamodb/expression"
 	"github.com/aws/aws-sdk-go-v2/service/dynamodb"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
 	"github.com/aws/smithy-go"
+	"github.com/google/uuid"
 	"go.opentelemetry.io/contrib/instrumentation/github.com/aws/aws-sdk-go-v2/otelaws"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/protobuf/types/known/structpb"
@@ -41,10 +45,17 @@ import (
 const (
 	AttribPk         = "_pk"
 	AttribSk         = "_sk"
+	AttribTtl        = "_ttl"
+	AttribVersion    = "_v"
 	deleteQueryLimit = int32(1000)
 	maxBatchWrite    = 25
+	maxBatchGet      = 100
+	maxTransactItems = 100
 )
 
+// reservedAttributes are stored alongside the content of a value, so they can't be used as top level content keys
+var reservedAttributes = []string{AttribPk, AttribSk, AttribTtl, AttribVersion}
+
 // DynamoKeyValueService - an AWS DynamoDB implementation of the Nitric Document Service
 type DynamoKeyValueService struct {
 	client   dynamodbiface.DynamoDBAPI
@@ -113,7 +124,8 @@ func (s *DynamoKeyValueService) GetValue(ctx context.Context, req *kvstorepb.KvS
 		)
 	}
 
-	if result.Item == nil {
+	// dynamodb can take some time to delete expired items, so they're filtered out until it does
+	if result.Item == nil || itemExpired(result.Item) {
 		return nil, newErr(
 			codes.NotFound,
 			fmt.Sprintf("key %s not found in store %s", req.Ref.Key, req.Ref.Store),
@@ -133,6 +145,8 @@ func (s *DynamoKeyValueService) GetValue(ctx context.Context, req *kvstorepb.KvS
 
 	delete(itemMap, AttribPk)
 	delete(itemMap, AttribSk)
+	delete(itemMap, AttribTtl)
+	delete(itemMap, AttribVersion)
 
 	documentContent, err := structpb.NewStruct(itemMap)
 	if err != nil {
@@ -147,6 +161,7 @@ func (s *DynamoKeyValueService) GetValue(ctx context.Context, req *kvstorepb.KvS
 		Value: &kvstorepb.Value{
 			Ref:     req.Ref,
 			Content: documentContent,
+			Version: itemVersion(result.Item),
 		},
 	}, nil
 }
@@ -171,8 +186,44 @@ func (s *DynamoKeyValueService) SetValue(ctx context.Context, req *kvstorepb.KvS
 		)
 	}
 
+	if err := validateContent(req.Content); err != nil {
+		return nil, newErr(
+			codes.InvalidArgument,
+			"invalid content",
+			err,
+		)
+	}
+
+	if err := document.ValidateTtl(req.Ttl); err != nil {
+		return nil, newErr(
+			codes.InvalidArgument,
+			"invalid ttl",
+			err,
+		)
+	}
+
+	if err := document.ValidatePrecondition(req.Precondition, false); err != nil {
+		return nil, newErr(
+			codes.InvalidArgument,
+			"invalid precondition",
+			err,
+		)
+	}
+
+	condition, err := conditionExpression(req.Precondition)
+	if err != nil {
+		return nil, newErr(
+			codes.Internal,
+			"unable to build precondition expression",
+			err,
+		)
+	}
+
 	// Construct DynamoDB attribute value object
 	itemMap := createItemMap(req.Content.AsMap(), req.Ref)
+	if req.Ttl != nil {
+		itemMap[AttribTtl] = document.ExpiresAt(req.Ttl).Unix()
+	}
 	itemAttributeMap, err := attributevalue.MarshalMap(itemMap)
 	if err != nil {
 		return nil, newErr(
@@ -196,8 +247,22 @@ func (s *DynamoKeyValueService) SetValue(ctx context.Context, req *kvstorepb.KvS
 		TableName: tableName,
 	}
 
+	if condition != nil {
+		input.ConditionExpression = condition.Condition()
+		input.ExpressionAttributeNames = condition.Names()
+		input.ExpressionAttributeValues = condition.Values()
+	}
+
 	_, err = s.client.PutItem(ctx, input)
 	if err != nil {
+		if isConditionFailedErr(err) {
+			return nil, newErr(
+				codes.FailedPrecondition,
+				fmt.Sprintf("precondition failed for key %s in store %s", req.Ref.Key, req.Ref.Store),
+				err,
+			)
+		}
+
 		if isDynamoAccessDeniedErr(err) {
 			return nil, newErr(
 				codes.PermissionDenied,
@@ -213,7 +278,9 @@ func (s *DynamoKeyValueService) SetValue(ctx context.Context, req *kvstorepb.KvS
 		)
 	}
 
-	return &kvstorepb.KvStoreSetValueResponse{}, nil
+	return &kvstorepb.KvStoreSetValueResponse{
+		Version: itemMap[AttribVersion].(string),
+	}, nil
 }
 
 // Delete a document from the DynamoDB table
@@ -228,6 +295,23 @@ func (s *DynamoKeyValueService) DeleteKey(ctx context.Context, req *kvstorepb.Kv
 		)
 	}
 
+	if err := document.ValidatePrecondition(req.Precondition, true); err != nil {
+		return nil, newErr(
+			codes.InvalidArgument,
+			"invalid precondition",
+			err,
+		)
+	}
+
+	condition, err := conditionExpression(req.Precondition)
+	if err != nil {
+		return nil, newErr(
+			codes.Internal,
+			"unable to build precondition expression",
+			err,
+		)
+	}
+
 	keyMap := createKeyMap(req.Ref)
 	attributeMap, err := attributevalue.MarshalMap(keyMap)
 	if err != nil {
@@ -252,8 +336,22 @@ func (s *DynamoKeyValueService) DeleteKey(ctx context.Context, req *kvstorepb.Kv
 		TableName: tableName,
 	}
 
+	if condition != nil {
+		deleteInput.ConditionExpression = condition.Condition()
+		deleteInput.ExpressionAttributeNames = condition.Names()
+		deleteInput.ExpressionAttributeValues = condition.Values()
+	}
+
 	_, err = s.client.DeleteItem(ctx, deleteInput)
 	if err != nil {
+		if isConditionFailedErr(err) {
+			return nil, newErr(
+				codes.FailedPrecondition,
+				fmt.Sprintf("precondition failed for key %s in store %s", req.Ref.Key, req.Ref.Store),
+				err,
+			)
+		}
+
 		if isDynamoAccessDeniedErr(err) {
 			return nil, newErr(
 				codes.PermissionDenied,
@@ -272,6 +370,8 @@ func (s *DynamoKeyValueService) DeleteKey(ctx context.Context, req *kvstorepb.Kv
 	return &kvstorepb.KvStoreDeleteKeyResponse{}, nil
 }
 
+// ScanKeys scans the DynamoDB table of a store, scans are unordered so reverse ordering isn't supported.
+// Continuation tokens resume the scan from the item of the key they were returned with.
 func (s *DynamoKeyValueService) ScanKeys(req *kvstorepb.KvStoreScanKeysRequest, stream kvstorepb.KvStore_ScanKeysServer) error {
 	newErr := grpc_errors.ErrorsWithScope("DynamoDocService.Keys")
 
@@ -283,6 +383,24 @@ func (s *DynamoKeyValueService) ScanKeys(req *kvstorepb.KvStoreScanKeysRequest,
 		)
 	}
 
+	if err := document.ValidateScan(req); err != nil {
+		return newErr(
+			codes.InvalidArgument,
+			"invalid scan",
+			err,
+		)
+	}
+
+	if req.Reverse {
+		return newErr(
+			codes.Unimplemented,
+			"reverse ordering isn't supported by dynamodb scans",
+			nil,
+		)
+	}
+
+	startAfter, _ := document.ScanStartAfter(req.ContinuationToken)
+
 	tableName, err := s.getTableName(context.TODO(), req.Store.Name)
 	if err != nil {
 		return newErr(
@@ -292,9 +410,18 @@ func (s *DynamoKeyValueService) ScanKeys(req *kvstorepb.KvStoreScanKeysRequest,
 		)
 	}
 
-	projection := expression.NamesList(expression.Name(AttribPk))
-	filter := expression.Name(AttribPk).BeginsWith(req.Prefix)
-	expr, err := expression.NewBuilder().WithFilter(filter).WithProjection(projection).Build()
+	filter := expression.Name(AttribPk).BeginsWith(req.Prefix).And(
+		// exclude expired items that dynamodb hasn't deleted yet
+		expression.Or(
+			expression.Name(AttribTtl).AttributeNotExists(),
+			expression.Name(AttribTtl).GreaterThan(expression.Value(time.Now().Unix())),
+		),
+	)
+	builder := expression.NewBuilder().WithFilter(filter)
+	if !req.IncludeValues {
+		builder = builder.WithProjection(expression.NamesList(expression.Name(AttribPk)))
+	}
+	expr, err := builder.Build()
 	if err != nil {
 		return newErr(
 			codes.Internal,
@@ -311,10 +438,17 @@ func (s *DynamoKeyValueService) ScanKeys(req *kvstorepb.KvStoreScanKeysRequest,
 		ExpressionAttributeValues: expr.Values(),
 	}
 
-	// Get all keys from the table
+	if startAfter != "" {
+		input.ExclusiveStartKey = map[string]types.AttributeValue{
+			AttribPk: &types.AttributeValueMemberS{Value: startAfter},
+			AttribSk: &types.AttributeValueMemberS{Value: req.Store.Name + "#"},
+		}
+	}
+
+	sent := 0
 	paginator := dynamodb.NewScanPaginator(s.client, input)
 	for paginator.HasMorePages() {
-		page, err := paginator.NextPage(context.Background())
+		page, err := paginator.NextPage(stream.Context())
 		if err != nil {
 			if isDynamoAccessDeniedErr(err) {
 				return newErr(
@@ -332,9 +466,8 @@ func (s *DynamoKeyValueService) ScanKeys(req *kvstorepb.KvStoreScanKeysRequest,
 		}
 
 		for _, item := range page.Items {
-			var itemMap map[string]interface{}
-			err = attributevalue.UnmarshalMap(item, &itemMap)
-			if err != nil {
+			var key string
+			if err := attributevalue.Unmarshal(item[AttribPk], &key); err != nil {
 				return newErr(
 					codes.Internal,
 					"error unmarshalling key attributes",
@@ -342,21 +475,503 @@ func (s *DynamoKeyValueService) ScanKeys(req *kvstorepb.KvStoreScanKeysRequest,
 				)
 			}
 
-			if err := stream.Send(&kvstorepb.KvStoreScanKeysResponse{
-				Key: itemMap[AttribPk].(string),
-			}); err != nil {
+			resp := &kvstorepb.KvStoreScanKeysResponse{
+				Key:               key,
+				ContinuationToken: document.ScanContinuationToken(key),
+			}
+
+			if req.IncludeValues {
+				content, err := contentFromItem(item)
+				if err != nil {
+					return newErr(
+						codes.Internal,
+						"error unmarshalling value",
+						err,
+					)
+				}
+
+				resp.Value = &kvstorepb.Value{
+					Ref:     &kvstorepb.ValueRef{Store: req.Store.Name, Key: key},
+					Content: content,
+					Version: itemVersion(item),
+				}
+			}
+
+			if err := stream.Send(resp); err != nil {
 				return newErr(
 					codes.Internal,
 					"failed to send response",
 					err,
 				)
 			}
+
+			sent++
+			if req.Limit > 0 && sent >= int(req.Limit) {
+				return nil
+			}
+		}
+	}
+
+	return nil
+}
+
+// BatchGet retrieves up to 100 values from the DynamoDB tables with a single BatchGetItem request
+func (s *DynamoKeyValueService) BatchGet(ctx context.Context, req *kvstorepb.KvStoreBatchGetRequest) (*kvstorepb.KvStoreBatchGetResponse, error) {
+	newErr := grpc_errors.ErrorsWithScope("DynamoDocService.BatchGet")
+
+	if err := document.ValidateBatchSize(len(req.Refs), maxBatchGet); err != nil {
+		return nil, newErr(
+			codes.InvalidArgument,
+			"invalid batch",
+			err,
+		)
+	}
+
+	resp := &kvstorepb.KvStoreBatchGetResponse{}
+	pending := newBatchRefs()
+	requestItems := map[string]types.KeysAndAttributes{}
+
+	for _, ref := range req.Refs {
+		tableName, key, err := s.resolveKey(ctx, newErr, ref)
+		if err != nil {
+			resp.Errors = append(resp.Errors, document.ItemError(ref, err))
+			continue
+		}
+
+		if err := pending.add(*tableName, ref, newErr); err != nil {
+			resp.Errors = append(resp.Errors, document.ItemError(ref, err))
+			continue
+		}
+
+		keys := requestItems[*tableName]
+		keys.Keys = append(keys.Keys, key)
+		requestItems[*tableName] = keys
+	}
+
+	if len(requestItems) == 0 {
+		return resp, nil
+	}
+
+	result, err := s.client.BatchGetItem(ctx, &dynamodb.BatchGetItemInput{
+		RequestItems: requestItems,
+	})
+	if err != nil {
+		if isDynamoAccessDeniedErr(err) {
+			err = newErr(
+				codes.PermissionDenied,
+				"unable to get values, this may be due to a missing permissions request in your code.",
+				err,
+			)
+		} else {
+			err = newErr(
+				codes.Internal,
+				"error retrieving values",
+				err,
+			)
+		}
+
+		for _, ref := range pending.remaining() {
+			resp.Errors = append(resp.Errors, document.ItemError(ref, err))
+		}
+
+		return resp, nil
+	}
+
+	for tableName, items := range result.Responses {
+		for _, item := range items {
+			// expired items are left pending, so they're reported as not found
+			if itemExpired(item) {
+				continue
+			}
+
+			ref := pending.take(tableName, item)
+			if ref == nil {
+				continue
+			}
+
+			content, err := contentFromItem(item)
+			if err != nil {
+				resp.Errors = append(resp.Errors, document.ItemError(ref, newErr(
+					codes.Internal,
+					"error unmarshalling item",
+					err,
+				)))
+				continue
+			}
+
+			resp.Values = append(resp.Values, &kvstorepb.Value{
+				Ref:     ref,
+				Content: content,
+				Version: itemVersion(item),
+			})
+		}
+	}
+
+	for tableName, keys := range result.UnprocessedKeys {
+		for _, key := range keys.Keys {
+			if ref := pending.take(tableName, key); ref != nil {
+				resp.Errors = append(resp.Errors, document.ItemError(ref, newErr(
+					codes.Unavailable,
+					"value was not retrieved, the table's provisioned throughput was exceeded",
+					nil,
+				)))
+			}
+		}
+	}
+
+	// any keys left weren't returned by dynamodb, so they don't exist
+	for _, ref := range pending.remaining() {
+		resp.Errors = append(resp.Errors, document.ItemError(ref, newErr(
+			codes.NotFound,
+			fmt.Sprintf("key %s not found in store %s", ref.Key, ref.Store),
+			nil,
+		)))
+	}
+
+	return resp, nil
+}
+
+// BatchSet stores up to 25 values in the DynamoDB tables with a single BatchWriteItem request
+func (s *DynamoKeyValueService) BatchSet(ctx context.Context, req *kvstorepb.KvStoreBatchSetRequest) (*kvstorepb.KvStoreBatchSetResponse, error) {
+	newErr := grpc_errors.ErrorsWithScope("DynamoDocService.BatchSet")
+
+	if err := document.ValidateBatchSize(len(req.Values), maxBatchWrite); err != nil {
+		return nil, newErr(
+			codes.InvalidArgument,
+			"invalid batch",
+			err,
+		)
+	}
+
+	resp := &kvstorepb.KvStoreBatchSetResponse{}
+	pending := newBatchRefs()
+	requestItems := map[string][]types.WriteRequest{}
+
+	for _, value := range req.Values {
+		tableName, item, err := s.resolveItem(ctx, newErr, value)
+		if err != nil {
+			resp.Errors = append(resp.Errors, document.ItemError(value.Ref, err))
+			continue
+		}
+
+		if err := pending.add(*tableName, value.Ref, newErr); err != nil {
+			resp.Errors = append(resp.Errors, document.ItemError(value.Ref, err))
+			continue
+		}
+
+		requestItems[*tableName] = append(requestItems[*tableName], types.WriteRequest{
+			PutRequest: &types.PutRequest{
+				Item: item,
+			},
+		})
+	}
+
+	resp.Errors = append(resp.Errors, s.batchWrite(ctx, newErr, requestItems, pending)...)
+
+	return resp, nil
+}
+
+// BatchDelete deletes up to 25 values from the DynamoDB tables with a single BatchWriteItem request
+func (s *DynamoKeyValueService) BatchDelete(ctx context.Context, req *kvstorepb.KvStoreBatchDeleteRequest) (*kvstorepb.KvStoreBatchDeleteResponse, error) {
+	newErr := grpc_errors.ErrorsWithScope("DynamoDocService.BatchDelete")
+
+	if err := document.ValidateBatchSize(len(req.Refs), maxBatchWrite); err != nil {
+		return nil, newErr(
+			codes.InvalidArgument,
+			"invalid batch",
+			err,
+		)
+	}
+
+	resp := &kvstorepb.KvStoreBatchDeleteResponse{}
+	pending := newBatchRefs()
+	requestItems := map[string][]types.WriteRequest{}
+
+	for _, ref := range req.Refs {
+		tableName, key, err := s.resolveKey(ctx, newErr, ref)
+		if err != nil {
+			resp.Errors = append(resp.Errors, document.ItemError(ref, err))
+			continue
+		}
+
+		if err := pending.add(*tableName, ref, newErr); err != nil {
+			resp.Errors = append(resp.Errors, document.ItemError(ref, err))
+			continue
+		}
+
+		requestItems[*tableName] = append(requestItems[*tableName], types.WriteRequest{
+			DeleteRequest: &types.DeleteRequest{
+				Key: key,
+			},
+		})
+	}
+
+	resp.Errors = append(resp.Errors, s.batchWrite(ctx, newErr, requestItems, pending)...)
+
+	return resp, nil
+}
+
+// Transact applies up to 100 operations atomically with a single TransactWriteItems request
+func (s *DynamoKeyValueService) Transact(ctx context.Context, req *kvstorepb.KvStoreTransactRequest) (*kvstorepb.KvStoreTransactResponse, error) {
+	newErr := grpc_errors.ErrorsWithScope("DynamoDocService.Transact")
+
+	if err := document.ValidateTransaction(req.Operations, maxTransactItems); err != nil {
+		return nil, newErr(
+			codes.InvalidArgument,
+			"invalid transaction",
+			err,
+		)
+	}
+
+	transactItems := make([]types.TransactWriteItem, 0, len(req.Operations))
+	for _, op := range req.Operations {
+		if set := op.GetSet(); set != nil {
+			tableName, item, err := s.resolveItem(ctx, newErr, set)
+			if err != nil {
+				return nil, err
+			}
+
+			transactItems = append(transactItems, types.TransactWriteItem{
+				Put: &types.Put{
+					TableName: tableName,
+					Item:      item,
+				},
+			})
+		} else {
+			tableName, key, err := s.resolveKey(ctx, newErr, op.GetDelete())
+			if err != nil {
+				return nil, err
+			}
+
+			transactItems = append(transactItems, types.TransactWriteItem{
+				Delete: &types.Delete{
+					TableName: tableName,
+					Key:       key,
+				},
+			})
+		}
+	}
+
+	_, err := s.client.TransactWriteItems(ctx, &dynamodb.TransactWriteItemsInput{
+		TransactItems: transactItems,
+	})
+	if err != nil {
+		if isDynamoAccessDeniedErr(err) {
+			return nil, newErr(
+				codes.PermissionDenied,
+				"unable to apply transaction, this may be due to a missing permissions request in your code.",
+				err,
+			)
 		}
+
+		var cancelledErr *types.TransactionCanceledException
+		if errors.As(err, &cancelledErr) {
+			return nil, newErr(
+				codes.Aborted,
+				"transaction was cancelled, no operations were applied",
+				err,
+			)
+		}
+
+		return nil, newErr(
+			codes.Internal,
+			"unable to apply transaction",
+			err,
+		)
+	}
+
+	return &kvstorepb.KvStoreTransactResponse{}, nil
+}
+
+// batchWrite submits the write requests of a batch, returning an error for each item that wasn't written
+func (s *DynamoKeyValueService) batchWrite(ctx context.Context, newErr grpc_errors.ScopedErrorFactory, requestItems map[string][]types.WriteRequest, pending *batchRefs) []*kvstorepb.KvStoreItemError {
+	itemErrors := []*kvstorepb.KvStoreItemError{}
+
+	if len(requestItems) == 0 {
+		return itemErrors
 	}
 
+	result, err := s.client.BatchWriteItem(ctx, &dynamodb.BatchWriteItemInput{
+		RequestItems: requestItems,
+	})
+	if err != nil {
+		if isDynamoAccessDeniedErr(err) {
+			err = newErr(
+				codes.PermissionDenied,
+				"unable to write values, this may be due to a missing permissions request in your code.",
+				err,
+			)
+		} else {
+			err = newErr(
+				codes.Internal,
+				"error writing values",
+				err,
+			)
+		}
+
+		for _, ref := range pending.remaining() {
+			itemErrors = append(itemErrors, document.ItemError(ref, err))
+		}
+
+		return itemErrors
+	}
+
+	for tableName, writeRequests := range result.UnprocessedItems {
+		for _, writeRequest := range writeRequests {
+			var item map[string]types.AttributeValue
+			if writeRequest.PutRequest != nil {
+				item = writeRequest.PutRequest.Item
+			} else if writeRequest.DeleteRequest != nil {
+				item = writeRequest.DeleteRequest.Key
+			}
+
+			if ref := pending.take(tableName, item); ref != nil {
+				itemErrors = append(itemErrors, document.ItemError(ref, newErr(
+					codes.Unavailable,
+					"value was not written, the table's provisioned throughput was exceeded",
+					nil,
+				)))
+			}
+		}
+	}
+
+	return itemErrors
+}
+
+// resolveKey returns the table and key attributes of a value
+func (s *DynamoKeyValueService) resolveKey(ctx context.Context, newErr grpc_errors.ScopedErrorFactory, ref *kvstorepb.ValueRef) (*string, map[string]types.AttributeValue, error) {
+	if err := document.ValidateValueRef(ref); err != nil {
+		return nil, nil, newErr(
+			codes.InvalidArgument,
+			"invalid key",
+			err,
+		)
+	}
+
+	key, err := attributevalue.MarshalMap(createKeyMap(ref))
+	if err != nil {
+		return nil, nil, newErr(
+			codes.InvalidArgument,
+			fmt.Sprintf("failed to marshal keys: %v", ref),
+			err,
+		)
+	}
+
+	tableName, err := s.getTableName(ctx, ref.Store)
+	if err != nil {
+		return nil, nil, newErr(
+			codes.NotFound,
+			"unable to find table",
+			err,
+		)
+	}
+
+	return tableName, key, nil
+}
+
+// resolveItem returns the table and item attributes of a value, including its key attributes
+func (s *DynamoKeyValueService) resolveItem(ctx context.Context, newErr grpc_errors.ScopedErrorFactory, value *kvstorepb.Value) (*string, map[string]types.AttributeValue, error) {
+	if err := document.ValidateValueRef(value.GetRef()); err != nil {
+		return nil, nil, newErr(
+			codes.InvalidArgument,
+			"invalid key",
+			err,
+		)
+	}
+
+	if value.Content == nil {
+		return nil, nil, newErr(
+			codes.InvalidArgument,
+			"document content must not be nil",
+			nil,
+		)
+	}
+
+	if err := validateContent(value.Content); err != nil {
+		return nil, nil, newErr(
+			codes.InvalidArgument,
+			"invalid content",
+			err,
+		)
+	}
+
+	item, err := attributevalue.MarshalMap(createItemMap(value.Content.AsMap(), value.Ref))
+	if err != nil {
+		return nil, nil, newErr(
+			codes.InvalidArgument,
+			"failed to marshal content",
+			err,
+		)
+	}
+
+	tableName, err := s.getTableName(ctx, value.Ref.Store)
+	if err != nil {
+		return nil, nil, newErr(
+			codes.NotFound,
+			"unable to find table",
+			err,
+		)
+	}
+
+	return tableName, item, nil
+}
+
+// batchRefs tracks the refs of a batch request that haven't been resolved, in the order they were requested
+type batchRefs struct {
+	ids  []string
+	refs map[string]*kvstorepb.ValueRef
+}
+
+func newBatchRefs() *batchRefs {
+	return &batchRefs{
+		ids:  []string{},
+		refs: map[string]*kvstorepb.ValueRef{},
+	}
+}
+
+// add a ref to the batch, dynamodb rejects batches that include the same key more than once
+func (b *batchRefs) add(tableName string, ref *kvstorepb.ValueRef, newErr grpc_errors.ScopedErrorFactory) error {
+	id := tableName + "/" + ref.Key
+	if _, ok := b.refs[id]; ok {
+		return newErr(
+			codes.InvalidArgument,
+			fmt.Sprintf("key %s in store %s is included more than once", ref.Key, ref.Store),
+			nil,
+		)
+	}
+
+	b.ids = append(b.ids, id)
+	b.refs[id] = ref
+
 	return nil
 }
 
+// take removes and returns the ref matching the key attributes of an item, or nil if it isn't pending
+func (b *batchRefs) take(tableName string, item map[string]types.AttributeValue) *kvstorepb.ValueRef {
+	var key string
+	if err := attributevalue.Unmarshal(item[AttribPk], &key); err != nil {
+		return nil
+	}
+
+	id := tableName + "/" + key
+	ref := b.refs[id]
+	delete(b.refs, id)
+
+	return ref
+}
+
+// remaining returns the refs that haven't been taken
+func (b *batchRefs) remaining() []*kvstorepb.ValueRef {
+	refs := []*kvstorepb.ValueRef{}
+	for _, id := range b.ids {
+		if ref, ok := b.refs[id]; ok {
+			refs = append(refs, ref)
+		}
+	}
+
+	return refs
+}
+
 // New creates a new AWS DynamoDB implementation of a DocumentServiceServer
 func New(resolver resource.AwsResourceResolver) (*DynamoKeyValueService, error) {
 	awsRegion := env.AWS_REGION.String()
@@ -396,6 +1011,17 @@ func createKeyMap(ref *kvstorepb.ValueRef) map[string]string {
 	return keyMap
 }
 
+// validateContent ensures the content of a value doesn't overwrite the attributes stored alongside it
+func validateContent(content *structpb.Struct) error {
+	for _, attrib := range reservedAttributes {
+		if _, ok := content.GetFields()[attrib]; ok {
+			return fmt.Errorf("content key %s is reserved", attrib)
+		}
+	}
+
+	return nil
+}
+
 func createItemMap(source map[string]interface{}, ref *kvstorepb.ValueRef) map[string]interface{} {
 	// Copy map
 	newMap := make(map[string]interface{})
@@ -408,6 +1034,8 @@ func createItemMap(source map[string]interface{}, ref *kvstorepb.ValueRef) map[s
 	// Add key attributes
 	newMap[AttribPk] = keyMap[AttribPk]
 	newMap[AttribSk] = keyMap[AttribSk]
+	// every write creates a new version of the value
+	newMap[AttribVersion] = uuid.NewString()
 
 	return newMap
 }
@@ -427,3 +1055,81 @@ func (s *DynamoKeyValueService) getTableName(ctx context.Context, store string)
 
 	return nil, fmt.Errorf("store %s does not exist", store)
 }
+
+// contentFromItem converts a dynamodb item to the value content, excluding its key attributes
+func contentFromItem(item map[string]types.AttributeValue) (*structpb.Struct, error) {
+	var itemMap map[string]interface{}
+	if err := attributevalue.UnmarshalMap(item, &itemMap); err != nil {
+		return nil, err
+	}
+
+	delete(itemMap, AttribPk)
+	delete(itemMap, AttribSk)
+	delete(itemMap, AttribTtl)
+	delete(itemMap, AttribVersion)
+
+	return structpb.NewStruct(itemMap)
+}
+
+// itemVersion returns the version of a dynamodb item, items written before versions were introduced don't have one
+func itemVersion(item map[string]types.AttributeValue) string {
+	if version, ok := item[AttribVersion].(*types.AttributeValueMemberS); ok {
+		return version.Value
+	}
+
+	return ""
+}
+
+// conditionExpression builds the condition for a write with a precondition, expired items are treated as not existing.
+// Returns nil if there is no precondition.
+func conditionExpression(precondition *kvstorepb.KvStorePrecondition) (*expression.Expression, error) {
+	now := expression.Value(time.Now().Unix())
+	unexpired := expression.Or(
+		expression.Name(AttribTtl).AttributeNotExists(),
+		expression.Name(AttribTtl).GreaterThan(now),
+	)
+
+	var condition expression.ConditionBuilder
+	switch c := precondition.GetCondition().(type) {
+	case *kvstorepb.KvStorePrecondition_Version:
+		condition = expression.Name(AttribVersion).Equal(expression.Value(c.Version)).And(unexpired)
+	case *kvstorepb.KvStorePrecondition_Exists:
+		if c.Exists {
+			condition = expression.Name(AttribPk).AttributeExists().And(unexpired)
+		} else {
+			condition = expression.Or(
+				expression.Name(AttribPk).AttributeNotExists(),
+				expression.Name(AttribTtl).LessThanEqual(now),
+			)
+		}
+	default:
+		return nil, nil
+	}
+
+	expr, err := expression.NewBuilder().WithCondition(condition).Build()
+	if err != nil {
+		return nil, err
+	}
+
+	return &expr, nil
+}
+
+func isConditionFailedErr(err error) bool {
+	var conditionErr *types.ConditionalCheckFailedException
+	return errors.As(err, &conditionErr)
+}
+
+// itemExpired returns true if the ttl of a dynamodb item has elapsed
+func itemExpired(item map[string]types.AttributeValue) bool {
+	ttl, ok := item[AttribTtl].(*types.AttributeValueMemberN)
+	if !ok {
+		return false
+	}
+
+	expiresAt, err := strconv.ParseInt(ttl.Value, 10, 64)
+	if err != nil {
+		return false
+	}
+
+	return expiresAt <= time.Now().Unix()
+}
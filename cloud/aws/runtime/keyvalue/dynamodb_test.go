@@ -0,0 +1,86 @@
+// Copyright 2021 Nitric Technologies Pty Ltd.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package keyvalue
+
+import (
+	"context"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/ginkgo/extensions/table"
+	. "github.com/onsi/gomega"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/structpb"
+
+	kvstorepb "github.com/nitrictech/nitric/core/pkg/proto/kvstore/v1"
+)
+
+var _ = Describe("DynamoKeyValueService", func() {
+	ref := &kvstorepb.ValueRef{Store: "test-store", Key: "key"}
+
+	// the content is rejected before the table is resolved, so no client is needed
+	plugin := &DynamoKeyValueService{}
+
+	contentWith := func(attrib string) *structpb.Struct {
+		content, err := structpb.NewStruct(map[string]interface{}{"name": "test", attrib: "value"})
+		Expect(err).ShouldNot(HaveOccurred())
+
+		return content
+	}
+
+	DescribeTable("SetValue with reserved content keys",
+		func(attrib string) {
+			_, err := plugin.SetValue(context.TODO(), &kvstorepb.KvStoreSetValueRequest{
+				Ref:     ref,
+				Content: contentWith(attrib),
+			})
+
+			Expect(status.Code(err)).To(Equal(codes.InvalidArgument))
+		},
+		Entry("the partition key", AttribPk),
+		Entry("the sort key", AttribSk),
+		Entry("the ttl", AttribTtl),
+		Entry("the version", AttribVersion),
+	)
+
+	Context("BatchSet", func() {
+		When("a value uses a reserved content key", func() {
+			It("should return an invalid argument error for that value", func() {
+				resp, err := plugin.BatchSet(context.TODO(), &kvstorepb.KvStoreBatchSetRequest{
+					Values: []*kvstorepb.Value{{Ref: ref, Content: contentWith(AttribTtl)}},
+				})
+				Expect(err).ShouldNot(HaveOccurred())
+
+				Expect(resp.Errors).To(HaveLen(1))
+				Expect(resp.Errors[0].Ref).To(Equal(ref))
+				Expect(codes.Code(resp.Errors[0].Code)).To(Equal(codes.InvalidArgument))
+			})
+		})
+	})
+
+	Context("Transact", func() {
+		When("a set operation uses a reserved content key", func() {
+			It("should return an invalid argument error", func() {
+				_, err := plugin.Transact(context.TODO(), &kvstorepb.KvStoreTransactRequest{
+					Operations: []*kvstorepb.KvStoreTransactOperation{
+						{Operation: &kvstorepb.KvStoreTransactOperation_Set{Set: &kvstorepb.Value{Ref: ref, Content: contentWith(AttribVersion)}}},
+					},
+				})
+
+				Expect(status.Code(err)).To(Equal(codes.InvalidArgument))
+			})
+		})
+	})
+})
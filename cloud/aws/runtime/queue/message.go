@@ -0,0 +1,82 @@
+// Copyright 2021 Nitric Pty Ltd.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package queue
+
+import (
+	"encoding/json"
+	"strings"
+
+	"github.com/nitrictech/nitric/cloud/common/runtime/messages"
+	queuespb "github.com/nitrictech/nitric/core/pkg/proto/queues/v1"
+)
+
+// snsMessageAttribute - a message attribute as it appears in SNS notifications
+type snsMessageAttribute struct {
+	Type  string `json:"Type"`
+	Value string `json:"Value"`
+}
+
+// snsEnvelope - the JSON notification SNS sends to a subscription's dead-letter queue
+type snsEnvelope struct {
+	Type              string                         `json:"Type"`
+	Message           string                         `json:"Message"`
+	MessageAttributes map[string]snsMessageAttribute `json:"MessageAttributes"`
+}
+
+func (e snsEnvelope) attributes() map[string]string {
+	attributes := map[string]string{}
+	for name, attr := range e.MessageAttributes {
+		attributes[name] = attr.Value
+	}
+
+	return attributes
+}
+
+// invocationRecord - the record lambda sends to a function's on-failure destination once its asynchronous retries are exhausted
+type invocationRecord struct {
+	RequestPayload struct {
+		Records []struct {
+			Sns snsEnvelope `json:"Sns"`
+		} `json:"Records"`
+	} `json:"requestPayload"`
+}
+
+// unwrapDeadLetter returns the published message, and the attributes it was published with, of a topic message dead-lettered
+// by SNS or by a subscriber's lambda. Other message bodies are returned unchanged along with the SQS message attributes
+func unwrapDeadLetter(body string, attributes map[string]string) (string, map[string]string) {
+	if !strings.HasPrefix(body, "{") {
+		return body, attributes
+	}
+
+	var record invocationRecord
+	if err := json.Unmarshal([]byte(body), &record); err == nil && len(record.RequestPayload.Records) > 0 && record.RequestPayload.Records[0].Sns.Message != "" {
+		envelope := record.RequestPayload.Records[0].Sns
+		return envelope.Message, envelope.attributes()
+	}
+
+	var envelope snsEnvelope
+	if err := json.Unmarshal([]byte(body), &envelope); err == nil && envelope.Type == "Notification" {
+		return envelope.Message, envelope.attributes()
+	}
+
+	return body, attributes
+}
+
+// DecodeMessage - decodes the body of an SQS message, topic messages are wire compatible with queue messages
+// so messages dead-lettered by topic subscriptions are decoded as queue messages.
+// Bodies sent to the queue by other producers are decoded as bytes payloads along with the message attributes
+func DecodeMessage(body string, attributes map[string]string) *queuespb.QueueMessage {
+	return messages.DecodeBase64QueueMessage(unwrapDeadLetter(body, attributes))
+}
@@ -19,7 +19,9 @@ import (
 	"encoding/base64"
 	"errors"
 	"fmt"
+	"strconv"
 	"strings"
+	"time"
 
 	"github.com/aws/aws-sdk-go-v2/aws"
 	"github.com/aws/aws-sdk-go-v2/config"
@@ -29,16 +31,28 @@ import (
 	"github.com/google/uuid"
 	"go.opentelemetry.io/contrib/instrumentation/github.com/aws/aws-sdk-go-v2/otelaws"
 	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/durationpb"
 
 	"github.com/nitrictech/nitric/cloud/aws/ifaces/sqsiface"
 	"github.com/nitrictech/nitric/cloud/aws/runtime/env"
 	"github.com/nitrictech/nitric/cloud/aws/runtime/resource"
+	"github.com/nitrictech/nitric/cloud/common/runtime/messages"
 	grpc_errors "github.com/nitrictech/nitric/core/pkg/grpc/errors"
 
 	queuespb "github.com/nitrictech/nitric/core/pkg/proto/queues/v1"
 )
 
+// SQS limits the visibility timeout of a message to 12 hours
+const maxVisibilityTimeout = 12 * time.Hour
+
+// SQS limits message group ids and deduplication ids to 128 characters
+const maxFifoIdLength = 128
+
+// The number of times a message has been received from the queue, including the current receive
+const approximateReceiveCountAttribute = types.QueueAttributeName("ApproximateReceiveCount")
+
 type SQSQueueService struct {
 	provider resource.AwsResourceResolver
 	client   sqsiface.SQSAPI
@@ -74,6 +88,32 @@ func (s *SQSQueueService) getUrlForQueueName(ctx context.Context, queue string)
 	return out.QueueUrl, nil
 }
 
+// SQS FIFO queue names must end with the .fifo suffix, the queue url ends with the queue name
+func isFifoQueueUrl(url string) bool {
+	return strings.HasSuffix(url, ".fifo")
+}
+
+// validateMessageOptions - check the message's FIFO options are supported by the queue type
+func validateMessageOptions(message *queuespb.QueueMessage, fifo bool) error {
+	if !fifo {
+		if message.GroupKey != "" || message.DeduplicationId != "" {
+			return fmt.Errorf("message group keys and deduplication ids are only supported by FIFO queues")
+		}
+
+		return nil
+	}
+
+	if message.GroupKey == "" {
+		return fmt.Errorf("FIFO queues require a message group key")
+	}
+
+	if len(message.GroupKey) > maxFifoIdLength || len(message.DeduplicationId) > maxFifoIdLength {
+		return fmt.Errorf("message group keys and deduplication ids can't be longer than %d characters", maxFifoIdLength)
+	}
+
+	return nil
+}
+
 func isSQSAccessDeniedErr(err error) bool {
 	var opErr *smithy.OperationError
 	if errors.As(err, &opErr) {
@@ -89,10 +129,20 @@ func (s *SQSQueueService) Enqueue(ctx context.Context, req *queuespb.QueueEnqueu
 
 	if url, err := s.getUrlForQueueName(ctx, req.QueueName); err == nil {
 		entries := make([]types.SendMessageBatchRequestEntry, 0)
+		invalidTasks := make([]*queuespb.FailedEnqueueMessage, 0)
+		fifo := isFifoQueueUrl(*url)
 
 		for _, sendTaskReq := range req.Messages {
 			t := sendTaskReq
 
+			if err := validateMessageOptions(t, fifo); err != nil {
+				invalidTasks = append(invalidTasks, &queuespb.FailedEnqueueMessage{
+					Message: t,
+					Details: err.Error(),
+				})
+				continue
+			}
+
 			// generate a unique Id for each task
 			id := uuid.New()
 			requestIdMap[id.String()] = t
@@ -100,10 +150,27 @@ func (s *SQSQueueService) Enqueue(ctx context.Context, req *queuespb.QueueEnqueu
 			if bytes, err := proto.Marshal(t); err == nil {
 				msgString := base64.StdEncoding.EncodeToString(bytes)
 
-				entries = append(entries, types.SendMessageBatchRequestEntry{
+				entry := types.SendMessageBatchRequestEntry{
 					Id:          aws.String(id.String()),
 					MessageBody: aws.String(msgString),
-				})
+					// marks the message as enqueued by nitric, so it isn't delivered as data sent by another producer
+					MessageAttributes: map[string]types.MessageAttributeValue{
+						messages.EncodingAttribute: {DataType: aws.String("String"), StringValue: aws.String(messages.NitricEncoding)},
+					},
+				}
+
+				if fifo {
+					// messages without a deduplication id are never deduplicated
+					deduplicationId := t.DeduplicationId
+					if deduplicationId == "" {
+						deduplicationId = id.String()
+					}
+
+					entry.MessageGroupId = aws.String(t.GroupKey)
+					entry.MessageDeduplicationId = aws.String(deduplicationId)
+				}
+
+				entries = append(entries, entry)
 			} else {
 				return nil, newErr(
 					codes.Internal,
@@ -113,12 +180,19 @@ func (s *SQSQueueService) Enqueue(ctx context.Context, req *queuespb.QueueEnqueu
 			}
 		}
 
+		// SQS rejects empty batches
+		if len(entries) == 0 {
+			return &queuespb.QueueEnqueueResponse{
+				FailedMessages: invalidTasks,
+			}, nil
+		}
+
 		if out, err := s.client.SendMessageBatch(ctx, &sqs.SendMessageBatchInput{
 			Entries:  entries,
 			QueueUrl: url,
 		}); err == nil {
 			// process out Failed messages to return to the user...
-			failedTasks := make([]*queuespb.FailedEnqueueMessage, 0, len(out.Failed))
+			failedTasks := append(make([]*queuespb.FailedEnqueueMessage, 0, len(invalidTasks)+len(out.Failed)), invalidTasks...)
 			for _, failed := range out.Failed {
 				for id, e := range requestIdMap {
 					if id == *failed.Id {
@@ -162,15 +236,32 @@ func (s *SQSQueueService) Enqueue(ctx context.Context, req *queuespb.QueueEnqueu
 func (s *SQSQueueService) Dequeue(ctx context.Context, req *queuespb.QueueDequeueRequest) (*queuespb.QueueDequeueResponse, error) {
 	newErr := grpc_errors.ErrorsWithScope("SQSQueueService.Dequeue")
 
+	var visibilityTimeout int32
+	if req.VisibilityTimeout != nil {
+		seconds, err := visibilityTimeoutSeconds(req.VisibilityTimeout)
+		if err != nil || seconds == 0 {
+			return nil, newErr(
+				codes.InvalidArgument,
+				fmt.Sprintf("visibility timeout must be between 1 second and %s", maxVisibilityTimeout),
+				err,
+			)
+		}
+		visibilityTimeout = seconds
+	}
+
 	if url, err := s.getUrlForQueueName(ctx, req.QueueName); err == nil {
 		req := sqs.ReceiveMessageInput{
 			MaxNumberOfMessages: req.Depth,
+			// the receive count is a message system attribute, SQS only returns it when requested
+			AttributeNames: []types.QueueAttributeName{
+				approximateReceiveCountAttribute,
+			},
 			MessageAttributeNames: []string{
 				string(types.QueueAttributeNameAll),
 			},
 			QueueUrl: url,
-			// VisibilityTimeout:       nil,
-			// WaitTimeSeconds:         nil,
+			// zero uses the queue's default visibility timeout
+			VisibilityTimeout: visibilityTimeout,
 		}
 
 		res, err := s.client.ReceiveMessage(ctx, &req)
@@ -192,29 +283,22 @@ func (s *SQSQueueService) Dequeue(ctx context.Context, req *queuespb.QueueDequeu
 
 		tasks := make([]*queuespb.DequeuedMessage, 0, len(res.Messages))
 		for _, m := range res.Messages {
-			var queueMessage queuespb.QueueMessage
-
-			msgBytes, err := base64.StdEncoding.DecodeString(*m.Body)
-			if err != nil {
-				return nil, newErr(
-					codes.Internal,
-					"failed unmarshalling body",
-					err,
-				)
+			attributes := map[string]string{}
+			for name, attr := range m.MessageAttributes {
+				if attr.StringValue != nil {
+					attributes[name] = *attr.StringValue
+				}
 			}
 
-			err = proto.Unmarshal(msgBytes, &queueMessage)
-			if err != nil {
-				return nil, newErr(
-					codes.Internal,
-					"failed unmarshalling body",
-					err,
-				)
-			}
+			queueMessage := DecodeMessage(*m.Body, attributes)
+
+			// the receive count is informational, it's left as unknown if it can't be parsed
+			receiveCount, _ := strconv.ParseInt(m.Attributes[string(approximateReceiveCountAttribute)], 10, 32)
 
 			tasks = append(tasks, &queuespb.DequeuedMessage{
-				LeaseId: *m.ReceiptHandle,
-				Message: &queueMessage,
+				LeaseId:         *m.ReceiptHandle,
+				Message:         queueMessage,
+				DeliveryAttempt: int32(receiveCount),
 			})
 		}
 
@@ -258,6 +342,101 @@ func (s *SQSQueueService) Complete(ctx context.Context, req *queuespb.QueueCompl
 	}
 }
 
+// Extends the lease of a previously popped queue item, by changing its visibility timeout
+func (s *SQSQueueService) ExtendLease(ctx context.Context, req *queuespb.QueueExtendLeaseRequest) (*queuespb.QueueExtendLeaseResponse, error) {
+	newErr := grpc_errors.ErrorsWithScope("SQSQueueService.ExtendLease")
+
+	visibilityTimeout, err := visibilityTimeoutSeconds(req.VisibilityTimeout)
+	if err != nil || visibilityTimeout == 0 {
+		return nil, newErr(
+			codes.InvalidArgument,
+			fmt.Sprintf("visibility timeout must be between 1 second and %s", maxVisibilityTimeout),
+			err,
+		)
+	}
+
+	if err := s.changeVisibility(ctx, req.QueueName, req.LeaseId, visibilityTimeout); err != nil {
+		return nil, newErr(
+			status.Code(err),
+			"failed to extend lease",
+			err,
+		)
+	}
+
+	return &queuespb.QueueExtendLeaseResponse{
+		LeaseId: req.LeaseId,
+	}, nil
+}
+
+// Releases a previously popped queue item, making it visible again once the delay has passed
+func (s *SQSQueueService) Release(ctx context.Context, req *queuespb.QueueReleaseRequest) (*queuespb.QueueReleaseResponse, error) {
+	newErr := grpc_errors.ErrorsWithScope("SQSQueueService.Release")
+
+	var delay int32
+	if req.Delay != nil {
+		seconds, err := visibilityTimeoutSeconds(req.Delay)
+		if err != nil {
+			return nil, newErr(
+				codes.InvalidArgument,
+				fmt.Sprintf("delay must be between 0 and %s", maxVisibilityTimeout),
+				err,
+			)
+		}
+		delay = seconds
+	}
+
+	if err := s.changeVisibility(ctx, req.QueueName, req.LeaseId, delay); err != nil {
+		return nil, newErr(
+			status.Code(err),
+			"failed to release message",
+			err,
+		)
+	}
+
+	return &queuespb.QueueReleaseResponse{}, nil
+}
+
+// changeVisibility sets how long from now a leased message stays hidden from other consumers
+func (s *SQSQueueService) changeVisibility(ctx context.Context, queueName string, leaseId string, visibilityTimeout int32) error {
+	url, err := s.getUrlForQueueName(ctx, queueName)
+	if err != nil {
+		return status.Errorf(codes.NotFound, "unable to find queue: %v", err)
+	}
+
+	_, err = s.client.ChangeMessageVisibility(ctx, &sqs.ChangeMessageVisibilityInput{
+		QueueUrl:          url,
+		ReceiptHandle:     aws.String(leaseId),
+		VisibilityTimeout: visibilityTimeout,
+	})
+	if err != nil {
+		if isSQSAccessDeniedErr(err) {
+			return status.Errorf(codes.PermissionDenied, "have you requested access to this queue? %v", err)
+		}
+
+		var apiErr smithy.APIError
+		if errors.As(err, &apiErr) && (apiErr.ErrorCode() == "ReceiptHandleIsInvalid" || apiErr.ErrorCode() == "MessageNotInflight") {
+			return status.Errorf(codes.NotFound, "lease not found, it may have expired: %v", err)
+		}
+
+		return status.Errorf(codes.Internal, "%v", err)
+	}
+
+	return nil
+}
+
+// visibilityTimeoutSeconds converts a duration to the whole seconds SQS expects, within its limits
+func visibilityTimeoutSeconds(duration *durationpb.Duration) (int32, error) {
+	if err := duration.CheckValid(); err != nil {
+		return 0, err
+	}
+
+	if duration.AsDuration() < 0 || duration.AsDuration() > maxVisibilityTimeout {
+		return 0, fmt.Errorf("duration %s is out of range", duration.AsDuration())
+	}
+
+	return int32(duration.AsDuration().Seconds()), nil
+}
+
 func New(provider resource.AwsResourceResolver) (queuespb.QueuesServer, error) {
 	awsRegion := env.AWS_REGION.String()
 
@@ -19,13 +19,17 @@ import (
 	"encoding/base64"
 	"errors"
 	"fmt"
+	"time"
 
 	"github.com/aws/aws-sdk-go-v2/aws"
 	"github.com/aws/aws-sdk-go-v2/service/sqs"
 	"github.com/aws/aws-sdk-go-v2/service/sqs/types"
 	"github.com/aws/smithy-go"
 	"github.com/golang/mock/gomock"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/durationpb"
 	"google.golang.org/protobuf/types/known/structpb"
 
 	. "github.com/onsi/ginkgo"
@@ -141,6 +145,128 @@ var _ = Describe("Sqs", func() {
 			})
 		})
 
+		When("Sending to a FIFO queue", func() {
+			It("Should send the task with its group key and deduplication id", func() {
+				ctrl := gomock.NewController(GinkgoT())
+				sqsMock := mocks_sqs.NewMockSQSAPI(ctrl)
+				providerMock := mock_provider.NewMockAwsResourceResolver(ctrl)
+				plugin := NewWithClient(providerMock, sqsMock)
+
+				queueUrl := aws.String("https://example.com/test-queue.fifo")
+
+				By("The queue being available")
+				providerMock.EXPECT().GetResources(gomock.Any(), resource.AwsResource_Queue).Return(map[string]resource.ResolvedResource{
+					"test-queue": {
+						ARN: "arn:aws:sqs:us-east-2:444455556666:test-queue.fifo",
+					},
+				}, nil)
+
+				sqsMock.EXPECT().GetQueueUrl(gomock.Any(), gomock.Any()).Times(1).Return(&sqs.GetQueueUrlOutput{
+					QueueUrl: queueUrl,
+				}, nil)
+
+				By("Calling SendMessageBatch with the group key and deduplication id")
+				sqsMock.EXPECT().SendMessageBatch(gomock.Any(), gomock.Any()).DoAndReturn(func(ctx context.Context, input *sqs.SendMessageBatchInput, opts ...func(*sqs.Options)) (*sqs.SendMessageBatchOutput, error) {
+					Expect(input.Entries).To(HaveLen(1))
+					Expect(*input.Entries[0].MessageGroupId).To(Equal("test-group"))
+					Expect(*input.Entries[0].MessageDeduplicationId).To(Equal("test-dedup"))
+
+					return &sqs.SendMessageBatchOutput{}, nil
+				})
+
+				resp, err := plugin.Enqueue(context.TODO(), &queuepb.QueueEnqueueRequest{
+					QueueName: "test-queue",
+					Messages: []*queuespb.QueueMessage{
+						{
+							Content:         testStruct.Content,
+							GroupKey:        "test-group",
+							DeduplicationId: "test-dedup",
+						},
+					},
+				})
+
+				By("Not returning an error")
+				Expect(err).ShouldNot(HaveOccurred())
+				Expect(resp.FailedMessages).To(BeEmpty())
+				ctrl.Finish()
+			})
+
+			It("Should fail messages without a group key", func() {
+				ctrl := gomock.NewController(GinkgoT())
+				sqsMock := mocks_sqs.NewMockSQSAPI(ctrl)
+				providerMock := mock_provider.NewMockAwsResourceResolver(ctrl)
+				plugin := NewWithClient(providerMock, sqsMock)
+
+				providerMock.EXPECT().GetResources(gomock.Any(), resource.AwsResource_Queue).Return(map[string]resource.ResolvedResource{
+					"test-queue": {
+						ARN: "arn:aws:sqs:us-east-2:444455556666:test-queue.fifo",
+					},
+				}, nil)
+
+				sqsMock.EXPECT().GetQueueUrl(gomock.Any(), gomock.Any()).Times(1).Return(&sqs.GetQueueUrlOutput{
+					QueueUrl: aws.String("https://example.com/test-queue.fifo"),
+				}, nil)
+
+				By("Not calling SendMessageBatch")
+				sqsMock.EXPECT().SendMessageBatch(gomock.Any(), gomock.Any()).Times(0)
+
+				resp, err := plugin.Enqueue(context.TODO(), &queuepb.QueueEnqueueRequest{
+					QueueName: "test-queue",
+					Messages:  []*queuespb.QueueMessage{testStruct},
+				})
+
+				By("Returning the message as failed")
+				Expect(err).ShouldNot(HaveOccurred())
+				Expect(resp.FailedMessages).To(HaveLen(1))
+				Expect(resp.FailedMessages[0].Details).To(ContainSubstring("require a message group key"))
+				ctrl.Finish()
+			})
+		})
+
+		When("Sending FIFO options to a standard queue", func() {
+			It("Should fail the messages with FIFO options", func() {
+				ctrl := gomock.NewController(GinkgoT())
+				sqsMock := mocks_sqs.NewMockSQSAPI(ctrl)
+				providerMock := mock_provider.NewMockAwsResourceResolver(ctrl)
+				plugin := NewWithClient(providerMock, sqsMock)
+
+				providerMock.EXPECT().GetResources(gomock.Any(), resource.AwsResource_Queue).Return(map[string]resource.ResolvedResource{
+					"test-queue": {
+						ARN: "arn:aws:sqs:us-east-2:444455556666:test-queue",
+					},
+				}, nil)
+
+				sqsMock.EXPECT().GetQueueUrl(gomock.Any(), gomock.Any()).Times(1).Return(&sqs.GetQueueUrlOutput{
+					QueueUrl: aws.String("https://example.com/test-queue"),
+				}, nil)
+
+				By("Only sending the valid messages")
+				sqsMock.EXPECT().SendMessageBatch(gomock.Any(), gomock.Any()).DoAndReturn(func(ctx context.Context, input *sqs.SendMessageBatchInput, opts ...func(*sqs.Options)) (*sqs.SendMessageBatchOutput, error) {
+					Expect(input.Entries).To(HaveLen(1))
+					Expect(input.Entries[0].MessageGroupId).To(BeNil())
+
+					return &sqs.SendMessageBatchOutput{}, nil
+				})
+
+				resp, err := plugin.Enqueue(context.TODO(), &queuepb.QueueEnqueueRequest{
+					QueueName: "test-queue",
+					Messages: []*queuespb.QueueMessage{
+						testStruct,
+						{
+							Content:  testStruct.Content,
+							GroupKey: "test-group",
+						},
+					},
+				})
+
+				By("Returning the message with FIFO options as failed")
+				Expect(err).ShouldNot(HaveOccurred())
+				Expect(resp.FailedMessages).To(HaveLen(1))
+				Expect(resp.FailedMessages[0].Message.GroupKey).To(Equal("test-group"))
+				ctrl.Finish()
+			})
+		})
+
 		When("Permission to access the queue is missing", func() {
 			It("Should return an error", func() {
 				ctrl := gomock.NewController(GinkgoT())
@@ -254,6 +380,9 @@ var _ = Describe("Sqs", func() {
 					By("Calling ReceiveMessage with the expected inputs")
 					sqsMock.EXPECT().ReceiveMessage(gomock.Any(), &sqs.ReceiveMessageInput{
 						MaxNumberOfMessages: int32(10),
+						AttributeNames: []types.QueueAttributeName{
+							"ApproximateReceiveCount",
+						},
 						MessageAttributeNames: []string{
 							string(types.QueueAttributeNameAll),
 						},
@@ -263,6 +392,12 @@ var _ = Describe("Sqs", func() {
 							{
 								ReceiptHandle: aws.String("mockreceipthandle"),
 								Body:          aws.String(testPayloadB64),
+								Attributes: map[string]string{
+									"ApproximateReceiveCount": "2",
+								},
+								MessageAttributes: map[string]types.MessageAttributeValue{
+									"x-nitric-encoding": {DataType: aws.String("String"), StringValue: aws.String("protobuf")},
+								},
 							},
 						},
 					}, nil)
@@ -276,12 +411,130 @@ var _ = Describe("Sqs", func() {
 					Expect(err).ShouldNot(HaveOccurred())
 					Expect(response.Messages).To(HaveLen(1))
 					Expect(response.Messages[0].LeaseId).To(BeEquivalentTo("mockreceipthandle"))
+					Expect(response.Messages[0].DeliveryAttempt).To(Equal(int32(2)))
+					Expect(response.Messages[0].Message.GetStructPayload().AsMap()).To(BeEquivalentTo(testStruct.GetStructPayload().AsMap()))
+
+					ctrl.Finish()
+				})
+			})
+
+			When("There is a topic message dead-lettered by a subscriber on the queue", func() {
+				It("Should receive the published message", func() {
+					ctrl := gomock.NewController(GinkgoT())
+					sqsMock := mocks_sqs.NewMockSQSAPI(ctrl)
+					providerMock := mock_provider.NewMockAwsResourceResolver(ctrl)
+					plugin := NewWithClient(providerMock, sqsMock)
+
+					queueUrl := aws.String("https://example.com/test-queue")
+
+					providerMock.EXPECT().GetResources(gomock.Any(), resource.AwsResource_Queue).Return(map[string]resource.ResolvedResource{
+						"mock-queue": {
+							ARN: "arn:aws:sqs:us-east-2:444455556666:mock-queue",
+						},
+					}, nil)
+
+					sqsMock.EXPECT().GetQueueUrl(gomock.Any(), gomock.Any()).Return(&sqs.GetQueueUrlOutput{
+						QueueUrl: queueUrl,
+					}, nil)
+
+					invocationRecord := fmt.Sprintf(`{
+						"version": "1.0",
+						"requestContext": {"condition": "RetriesExhausted", "approximateInvokeCount": 3},
+						"requestPayload": {"Records": [{"EventSource": "aws:sns", "Sns": {"Type": "Notification", "Message": "%s", "MessageAttributes": {"x-nitric-encoding": {"Type": "String", "Value": "protobuf"}}}}]}
+					}`, testPayloadB64)
+
+					By("Receiving the lambda invocation record from SQS")
+					sqsMock.EXPECT().ReceiveMessage(gomock.Any(), gomock.Any()).Times(1).Return(&sqs.ReceiveMessageOutput{
+						Messages: []types.Message{
+							{
+								ReceiptHandle: aws.String("mockreceipthandle"),
+								Body:          aws.String(invocationRecord),
+							},
+						},
+					}, nil)
+
+					response, err := plugin.Dequeue(context.TODO(), &queuepb.QueueDequeueRequest{
+						QueueName: "mock-queue",
+						Depth:     10,
+					})
+
+					By("Returning the published message")
+					Expect(err).ShouldNot(HaveOccurred())
+					Expect(response.Messages).To(HaveLen(1))
 					Expect(response.Messages[0].Message.GetStructPayload().AsMap()).To(BeEquivalentTo(testStruct.GetStructPayload().AsMap()))
 
 					ctrl.Finish()
 				})
 			})
 
+			When("There are messages with bytes payloads on the queue", func() {
+				It("Should receive the bytes payloads", func() {
+					ctrl := gomock.NewController(GinkgoT())
+					sqsMock := mocks_sqs.NewMockSQSAPI(ctrl)
+					providerMock := mock_provider.NewMockAwsResourceResolver(ctrl)
+					plugin := NewWithClient(providerMock, sqsMock)
+
+					queueUrl := aws.String("https://example.com/test-queue")
+
+					providerMock.EXPECT().GetResources(gomock.Any(), resource.AwsResource_Queue).Return(map[string]resource.ResolvedResource{
+						"mock-queue": {
+							ARN: "arn:aws:sqs:us-east-2:444455556666:mock-queue",
+						},
+					}, nil)
+
+					sqsMock.EXPECT().GetQueueUrl(gomock.Any(), gomock.Any()).Return(&sqs.GetQueueUrlOutput{
+						QueueUrl: queueUrl,
+					}, nil)
+
+					nitricMessage, err := proto.Marshal(&queuepb.QueueMessage{
+						Content: &queuepb.QueueMessage_BytesPayload{
+							BytesPayload: &queuepb.BytesPayload{
+								Data:        []byte{0x00, 0xff},
+								ContentType: "application/octet-stream",
+							},
+						},
+					})
+					Expect(err).ShouldNot(HaveOccurred())
+
+					By("Receiving a message enqueued by nitric and a message sent by another producer")
+					sqsMock.EXPECT().ReceiveMessage(gomock.Any(), gomock.Any()).Times(1).Return(&sqs.ReceiveMessageOutput{
+						Messages: []types.Message{
+							{
+								ReceiptHandle: aws.String("nitricreceipthandle"),
+								Body:          aws.String(base64.StdEncoding.EncodeToString(nitricMessage)),
+								MessageAttributes: map[string]types.MessageAttributeValue{
+									"x-nitric-encoding": {DataType: aws.String("String"), StringValue: aws.String("protobuf")},
+								},
+							},
+							{
+								ReceiptHandle: aws.String("otherreceipthandle"),
+								Body:          aws.String("test"),
+								MessageAttributes: map[string]types.MessageAttributeValue{
+									"content-type": {DataType: aws.String("String"), StringValue: aws.String("text/csv")},
+								},
+							},
+						},
+					}, nil)
+
+					response, err := plugin.Dequeue(context.TODO(), &queuepb.QueueDequeueRequest{
+						QueueName: "mock-queue",
+						Depth:     10,
+					})
+					Expect(err).ShouldNot(HaveOccurred())
+					Expect(response.Messages).To(HaveLen(2))
+
+					By("Returning the bytes payload enqueued by nitric")
+					Expect(response.Messages[0].Message.GetBytesPayload().GetData()).To(Equal([]byte{0x00, 0xff}))
+					Expect(response.Messages[0].Message.GetBytesPayload().GetContentType()).To(Equal("application/octet-stream"))
+
+					By("Returning the original body of the other message with its content type")
+					Expect(response.Messages[1].Message.GetBytesPayload().GetData()).To(Equal([]byte("test")))
+					Expect(response.Messages[1].Message.GetBytesPayload().GetContentType()).To(Equal("text/csv"))
+
+					ctrl.Finish()
+				})
+			})
+
 			When("There are no messages on the queue", func() {
 				It("Should receive no messages", func() {
 					ctrl := gomock.NewController(GinkgoT())
@@ -306,6 +559,9 @@ var _ = Describe("Sqs", func() {
 					By("Calling ReceiveMessage with the expected inputs")
 					sqsMock.EXPECT().ReceiveMessage(gomock.Any(), &sqs.ReceiveMessageInput{
 						MaxNumberOfMessages: int32(10),
+						AttributeNames: []types.QueueAttributeName{
+							"ApproximateReceiveCount",
+						},
 						MessageAttributeNames: []string{
 							string(types.QueueAttributeNameAll),
 						},
@@ -358,6 +614,9 @@ var _ = Describe("Sqs", func() {
 					By("Calling ReceiveMessage with the expected inputs")
 					sqsMock.EXPECT().ReceiveMessage(gomock.Any(), &sqs.ReceiveMessageInput{
 						MaxNumberOfMessages: int32(10),
+						AttributeNames: []types.QueueAttributeName{
+							"ApproximateReceiveCount",
+						},
 						MessageAttributeNames: []string{
 							string(types.QueueAttributeNameAll),
 						},
@@ -463,5 +722,106 @@ var _ = Describe("Sqs", func() {
 				})
 			})
 		})
+
+		Context("ExtendLease", func() {
+			When("The visibility of the message is changed", func() {
+				It("Should return the same lease id", func() {
+					ctrl := gomock.NewController(GinkgoT())
+					sqsMock := mocks_sqs.NewMockSQSAPI(ctrl)
+					providerMock := mock_provider.NewMockAwsResourceResolver(ctrl)
+					plugin := NewWithClient(providerMock, sqsMock)
+
+					queueUrl := aws.String("https://example.com/test-queue")
+
+					providerMock.EXPECT().GetResources(gomock.Any(), resource.AwsResource_Queue).Return(map[string]resource.ResolvedResource{
+						"test-queue": {
+							ARN: "arn:aws:sqs:us-east-2:444455556666:test-queue",
+						},
+					}, nil)
+
+					sqsMock.EXPECT().GetQueueUrl(gomock.Any(), gomock.Any()).Times(1).Return(&sqs.GetQueueUrlOutput{
+						QueueUrl: queueUrl,
+					}, nil)
+
+					By("Calling SQS with the new visibility timeout in seconds")
+					sqsMock.EXPECT().ChangeMessageVisibility(gomock.Any(), &sqs.ChangeMessageVisibilityInput{
+						QueueUrl:          queueUrl,
+						ReceiptHandle:     aws.String("lease-id"),
+						VisibilityTimeout: 120,
+					}).Times(1).Return(&sqs.ChangeMessageVisibilityOutput{}, nil)
+
+					resp, err := plugin.ExtendLease(context.TODO(), &queuepb.QueueExtendLeaseRequest{
+						QueueName:         "test-queue",
+						LeaseId:           "lease-id",
+						VisibilityTimeout: durationpb.New(2 * time.Minute),
+					})
+
+					By("Not returning an error")
+					Expect(err).ShouldNot(HaveOccurred())
+					Expect(resp.LeaseId).To(Equal("lease-id"))
+
+					ctrl.Finish()
+				})
+			})
+
+			When("The visibility timeout exceeds the SQS limit", func() {
+				It("Should return an invalid argument error", func() {
+					ctrl := gomock.NewController(GinkgoT())
+					sqsMock := mocks_sqs.NewMockSQSAPI(ctrl)
+					providerMock := mock_provider.NewMockAwsResourceResolver(ctrl)
+					plugin := NewWithClient(providerMock, sqsMock)
+
+					_, err := plugin.ExtendLease(context.TODO(), &queuepb.QueueExtendLeaseRequest{
+						QueueName:         "test-queue",
+						LeaseId:           "lease-id",
+						VisibilityTimeout: durationpb.New(13 * time.Hour),
+					})
+
+					Expect(status.Code(err)).To(Equal(codes.InvalidArgument))
+
+					ctrl.Finish()
+				})
+			})
+		})
+
+		Context("Release", func() {
+			When("No delay is provided", func() {
+				It("Should make the message visible immediately", func() {
+					ctrl := gomock.NewController(GinkgoT())
+					sqsMock := mocks_sqs.NewMockSQSAPI(ctrl)
+					providerMock := mock_provider.NewMockAwsResourceResolver(ctrl)
+					plugin := NewWithClient(providerMock, sqsMock)
+
+					queueUrl := aws.String("https://example.com/test-queue")
+
+					providerMock.EXPECT().GetResources(gomock.Any(), resource.AwsResource_Queue).Return(map[string]resource.ResolvedResource{
+						"test-queue": {
+							ARN: "arn:aws:sqs:us-east-2:444455556666:test-queue",
+						},
+					}, nil)
+
+					sqsMock.EXPECT().GetQueueUrl(gomock.Any(), gomock.Any()).Times(1).Return(&sqs.GetQueueUrlOutput{
+						QueueUrl: queueUrl,
+					}, nil)
+
+					By("Calling SQS with a visibility timeout of zero")
+					sqsMock.EXPECT().ChangeMessageVisibility(gomock.Any(), &sqs.ChangeMessageVisibilityInput{
+						QueueUrl:          queueUrl,
+						ReceiptHandle:     aws.String("lease-id"),
+						VisibilityTimeout: 0,
+					}).Times(1).Return(&sqs.ChangeMessageVisibilityOutput{}, nil)
+
+					_, err := plugin.Release(context.TODO(), &queuepb.QueueReleaseRequest{
+						QueueName: "test-queue",
+						LeaseId:   "lease-id",
+					})
+
+					By("Not returning an error")
+					Expect(err).ShouldNot(HaveOccurred())
+
+					ctrl.Finish()
+				})
+			})
+		})
 	})
 })
@@ -18,14 +18,17 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"slices"
 	"strings"
 
 	"github.com/aws/aws-sdk-go-v2/aws"
 	"github.com/aws/aws-sdk-go-v2/config"
 	"github.com/aws/aws-sdk-go-v2/service/secretsmanager"
+	"github.com/aws/aws-sdk-go-v2/service/secretsmanager/types"
 	"github.com/aws/smithy-go"
 	"go.opentelemetry.io/contrib/instrumentation/github.com/aws/aws-sdk-go-v2/otelaws"
 	"google.golang.org/grpc/codes"
+	"google.golang.org/protobuf/types/known/timestamppb"
 
 	"github.com/nitrictech/nitric/cloud/aws/ifaces/secretsmanageriface"
 	"github.com/nitrictech/nitric/cloud/aws/runtime/env"
@@ -77,6 +80,28 @@ func isSecretsManagerAccessDeniedErr(err error) bool {
 	return false
 }
 
+// Secrets Manager has no per version state, so nitric tracks the state of a version with staging labels.
+// A version without any staging labels is deprecated and will be deleted by Secrets Manager.
+// Disabling a version is soft, its label is only enforced by Access and the value can still be read from Secrets Manager directly.
+// Each disabled version holds a label until it's enabled or destroyed, and Secrets Manager allows at most 20 labels per secret.
+const (
+	currentVersionStage = "AWSCURRENT"
+	disabledStagePrefix = "nitric-disabled-"
+)
+
+// versionState - determines the state of a secret version from its staging labels
+func versionState(versionId string, stages []string) secretpb.SecretVersionState {
+	if len(stages) == 0 {
+		return secretpb.SecretVersionState_Destroyed
+	}
+
+	if slices.Contains(stages, disabledStagePrefix+versionId) {
+		return secretpb.SecretVersionState_Disabled
+	}
+
+	return secretpb.SecretVersionState_Enabled
+}
+
 // Put - Store a new secret value
 func (s *SecretsManagerSecretService) Put(ctx context.Context, req *secretpb.SecretPutRequest) (*secretpb.SecretPutResponse, error) {
 	newErr := grpc_errors.ErrorsWithScope("SecretManagerSecretService.Put")
@@ -173,6 +198,14 @@ func (s *SecretsManagerSecretService) Access(ctx context.Context, req *secretpb.
 		)
 	}
 
+	if state := versionState(*result.VersionId, result.VersionStages); state != secretpb.SecretVersionState_Enabled {
+		return nil, newErr(
+			codes.FailedPrecondition,
+			"secret version is not enabled",
+			fmt.Errorf("secret version %s is %s", *result.VersionId, strings.ToLower(state.String())),
+		)
+	}
+
 	returnValue := result.SecretBinary
 
 	if returnValue == nil && result.SecretString != nil {
@@ -188,6 +221,249 @@ func (s *SecretsManagerSecretService) Access(ctx context.Context, req *secretpb.
 	}, nil
 }
 
+// ListVersions - Lists the versions of a secret, newest first
+func (s *SecretsManagerSecretService) ListVersions(ctx context.Context, req *secretpb.SecretListVersionsRequest) (*secretpb.SecretListVersionsResponse, error) {
+	newErr := grpc_errors.ErrorsWithScope("SecretManagerSecretService.ListVersions")
+
+	if len(req.GetSecret().GetName()) == 0 {
+		return nil, newErr(
+			codes.InvalidArgument,
+			"secret name cannot be blank or empty",
+			nil,
+		)
+	}
+
+	secretArn, err := s.getSecretArn(ctx, req.Secret.Name)
+	if err != nil {
+		return nil, newErr(codes.NotFound, "secret not found", err)
+	}
+
+	versions := []types.SecretVersionsListEntry{}
+	input := &secretsmanager.ListSecretVersionIdsInput{
+		SecretId:          aws.String(secretArn),
+		IncludeDeprecated: aws.Bool(true),
+	}
+
+	for {
+		result, err := s.client.ListSecretVersionIds(ctx, input)
+		if err != nil {
+			if isSecretsManagerAccessDeniedErr(err) {
+				return nil, newErr(
+					codes.PermissionDenied,
+					"unable to list secret versions, this may be due to a missing permissions request in your code.",
+					err,
+				)
+			}
+
+			return nil, newErr(codes.Unknown, "failed to list secret versions", err)
+		}
+
+		versions = append(versions, result.Versions...)
+
+		if result.NextToken == nil {
+			break
+		}
+		input.NextToken = result.NextToken
+	}
+
+	slices.SortFunc(versions, func(a, b types.SecretVersionsListEntry) int {
+		return aws.ToTime(b.CreatedDate).Compare(aws.ToTime(a.CreatedDate))
+	})
+
+	details := make([]*secretpb.SecretVersionDetails, 0, len(versions))
+	for _, version := range versions {
+		details = append(details, &secretpb.SecretVersionDetails{
+			SecretVersion: &secretpb.SecretVersion{
+				Secret:  req.Secret,
+				Version: aws.ToString(version.VersionId),
+			},
+			CreateTime: timestamppb.New(aws.ToTime(version.CreatedDate)),
+			State:      versionState(aws.ToString(version.VersionId), version.VersionStages),
+		})
+	}
+
+	return &secretpb.SecretListVersionsResponse{
+		Versions: details,
+	}, nil
+}
+
+// versionStages - returns the ARN of a secret and the staging labels attached to one of its versions
+func (s *SecretsManagerSecretService) versionStages(ctx context.Context, newErr grpc_errors.ScopedErrorFactory, sv *secretpb.SecretVersion) (string, []string, error) {
+	if len(sv.GetSecret().GetName()) == 0 {
+		return "", nil, newErr(
+			codes.InvalidArgument,
+			"secret name cannot be blank or empty",
+			nil,
+		)
+	}
+
+	if len(sv.GetVersion()) == 0 {
+		return "", nil, newErr(
+			codes.InvalidArgument,
+			"secret version cannot be blank or empty",
+			nil,
+		)
+	}
+
+	secretArn, err := s.getSecretArn(ctx, sv.Secret.Name)
+	if err != nil {
+		return "", nil, newErr(codes.NotFound, "secret not found", err)
+	}
+
+	result, err := s.client.DescribeSecret(ctx, &secretsmanager.DescribeSecretInput{
+		SecretId: aws.String(secretArn),
+	})
+	if err != nil {
+		if isSecretsManagerAccessDeniedErr(err) {
+			return "", nil, newErr(
+				codes.PermissionDenied,
+				"unable to describe secret, this may be due to a missing permissions request in your code.",
+				err,
+			)
+		}
+
+		return "", nil, newErr(codes.Unknown, "failed to describe secret", err)
+	}
+
+	// deprecated versions aren't included, they're either destroyed or have been deleted
+	stages, ok := result.VersionIdsToStages[sv.Version]
+	if !ok {
+		return "", nil, newErr(
+			codes.NotFound,
+			"secret version not found",
+			fmt.Errorf("secret version %s does not exist or has been destroyed", sv.Version),
+		)
+	}
+
+	return secretArn, stages, nil
+}
+
+// updateVersionStages - moves staging labels to or from a secret version
+func (s *SecretsManagerSecretService) updateVersionStages(ctx context.Context, newErr grpc_errors.ScopedErrorFactory, secretArn string, versionId string, attach []string, remove []string) error {
+	inputs := []*secretsmanager.UpdateSecretVersionStageInput{}
+
+	// labels are attached first so the version is never left without labels and deprecated
+	for _, stage := range attach {
+		inputs = append(inputs, &secretsmanager.UpdateSecretVersionStageInput{
+			SecretId:        aws.String(secretArn),
+			VersionStage:    aws.String(stage),
+			MoveToVersionId: aws.String(versionId),
+		})
+	}
+
+	for _, stage := range remove {
+		inputs = append(inputs, &secretsmanager.UpdateSecretVersionStageInput{
+			SecretId:            aws.String(secretArn),
+			VersionStage:        aws.String(stage),
+			RemoveFromVersionId: aws.String(versionId),
+		})
+	}
+
+	for _, input := range inputs {
+		_, err := s.client.UpdateSecretVersionStage(ctx, input)
+		if err != nil {
+			if isSecretsManagerAccessDeniedErr(err) {
+				return newErr(
+					codes.PermissionDenied,
+					"unable to update secret version, this may be due to a missing permissions request in your code.",
+					err,
+				)
+			}
+
+			return newErr(codes.Unknown, "failed to update secret version", err)
+		}
+	}
+
+	return nil
+}
+
+// DisableVersion - Disables a version of a secret by attaching the disabled label, the current version can't be disabled
+func (s *SecretsManagerSecretService) DisableVersion(ctx context.Context, req *secretpb.SecretDisableVersionRequest) (*secretpb.SecretDisableVersionResponse, error) {
+	newErr := grpc_errors.ErrorsWithScope("SecretManagerSecretService.DisableVersion")
+
+	secretArn, stages, err := s.versionStages(ctx, newErr, req.SecretVersion)
+	if err != nil {
+		return nil, err
+	}
+
+	if slices.Contains(stages, currentVersionStage) {
+		return nil, newErr(
+			codes.FailedPrecondition,
+			"the current secret version can't be disabled, put a new value first",
+			nil,
+		)
+	}
+
+	disabledStage := disabledStagePrefix + req.SecretVersion.Version
+	if slices.Contains(stages, disabledStage) {
+		return &secretpb.SecretDisableVersionResponse{}, nil
+	}
+
+	err = s.updateVersionStages(ctx, newErr, secretArn, req.SecretVersion.Version, []string{disabledStage}, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return &secretpb.SecretDisableVersionResponse{}, nil
+}
+
+// EnableVersion - Enables a disabled version of a secret by removing the disabled label
+func (s *SecretsManagerSecretService) EnableVersion(ctx context.Context, req *secretpb.SecretEnableVersionRequest) (*secretpb.SecretEnableVersionResponse, error) {
+	newErr := grpc_errors.ErrorsWithScope("SecretManagerSecretService.EnableVersion")
+
+	secretArn, stages, err := s.versionStages(ctx, newErr, req.SecretVersion)
+	if err != nil {
+		return nil, err
+	}
+
+	disabledStage := disabledStagePrefix + req.SecretVersion.Version
+	if !slices.Contains(stages, disabledStage) {
+		return &secretpb.SecretEnableVersionResponse{}, nil
+	}
+
+	// versions that were superseded while disabled only have the disabled label, removing it would deprecate them
+	if len(stages) == 1 {
+		return nil, newErr(
+			codes.FailedPrecondition,
+			"the secret version has been superseded and can't be enabled, put its value again instead",
+			nil,
+		)
+	}
+
+	err = s.updateVersionStages(ctx, newErr, secretArn, req.SecretVersion.Version, nil, []string{disabledStage})
+	if err != nil {
+		return nil, err
+	}
+
+	return &secretpb.SecretEnableVersionResponse{}, nil
+}
+
+// DestroyVersion - Deprecates a version of a secret by removing its staging labels, Secrets Manager deletes deprecated versions.
+// Deprecated versions can't be accessed through nitric, but remain in Secrets Manager until it deletes them
+func (s *SecretsManagerSecretService) DestroyVersion(ctx context.Context, req *secretpb.SecretDestroyVersionRequest) (*secretpb.SecretDestroyVersionResponse, error) {
+	newErr := grpc_errors.ErrorsWithScope("SecretManagerSecretService.DestroyVersion")
+
+	secretArn, stages, err := s.versionStages(ctx, newErr, req.SecretVersion)
+	if err != nil {
+		return nil, err
+	}
+
+	if slices.Contains(stages, currentVersionStage) {
+		return nil, newErr(
+			codes.FailedPrecondition,
+			"the current secret version can't be destroyed, put a new value first",
+			nil,
+		)
+	}
+
+	err = s.updateVersionStages(ctx, newErr, secretArn, req.SecretVersion.Version, nil, stages)
+	if err != nil {
+		return nil, err
+	}
+
+	return &secretpb.SecretDestroyVersionResponse{}, nil
+}
+
 // Gets a new Secrets Manager Client
 func New(resolver resource.AwsResourceResolver) (*SecretsManagerSecretService, error) {
 	awsRegion := env.AWS_REGION.String()
@@ -17,11 +17,15 @@ package secret
 import (
 	"context"
 	"errors"
+	"time"
 
 	"github.com/aws/aws-sdk-go-v2/aws"
 	"github.com/aws/aws-sdk-go-v2/service/secretsmanager"
+	"github.com/aws/aws-sdk-go-v2/service/secretsmanager/types"
 	"github.com/aws/smithy-go"
 	"github.com/golang/mock/gomock"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/gomega"
@@ -243,10 +247,11 @@ var _ = Describe("Secrets Manager Plugin", func() {
 							VersionId: aws.String("Version-Id"),
 						},
 					).Return(&secretsmanager.GetSecretValueOutput{
-						ARN:          aws.String(testARN),
-						Name:         aws.String("Test"),
-						VersionId:    aws.String(testVersionID),
-						SecretBinary: testSecretVal,
+						ARN:           aws.String(testARN),
+						Name:          aws.String("Test"),
+						VersionId:     aws.String(testVersionID),
+						SecretBinary:  testSecretVal,
+						VersionStages: []string{"AWSCURRENT"},
 					}, nil).Times(1)
 
 					response, err := secretPlugin.Access(context.TODO(), &secretpb.SecretAccessRequest{
@@ -315,10 +320,11 @@ var _ = Describe("Secrets Manager Plugin", func() {
 							SecretId: aws.String(testARN),
 						},
 					).Return(&secretsmanager.GetSecretValueOutput{
-						ARN:          aws.String(testARN),
-						Name:         aws.String("Test"),
-						VersionId:    aws.String(testVersionID),
-						SecretBinary: testSecretVal,
+						ARN:           aws.String(testARN),
+						Name:          aws.String("Test"),
+						VersionId:     aws.String(testVersionID),
+						SecretBinary:  testSecretVal,
+						VersionStages: []string{"AWSCURRENT"},
 					}, nil).Times(1)
 
 					response, err := secretPlugin.Access(context.TODO(), &secretpb.SecretAccessRequest{
@@ -418,6 +424,248 @@ var _ = Describe("Secrets Manager Plugin", func() {
 					Expect(response).Should(BeNil())
 				})
 			})
+			When("Accessing a disabled secret version", func() {
+				ctrl := gomock.NewController(GinkgoT())
+				mockProvider := mock_provider.NewMockAwsResourceResolver(ctrl)
+				mockSecretClient := mocks.NewMockSecretsManagerAPI(ctrl)
+				secretPlugin := &SecretsManagerSecretService{
+					client:   mockSecretClient,
+					resolver: mockProvider,
+				}
+				It("Should not return the secret", func() {
+					defer ctrl.Finish()
+
+					By("the secret existing")
+					mockProvider.EXPECT().GetResources(gomock.Any(), resource.AwsResource_Secret).Return(map[string]resource.ResolvedResource{
+						"Test": {ARN: testARN},
+					}, nil)
+
+					By("the version having the disabled staging label")
+					mockSecretClient.EXPECT().GetSecretValue(gomock.Any(), gomock.Any()).Return(&secretsmanager.GetSecretValueOutput{
+						ARN:           aws.String(testARN),
+						Name:          aws.String("Test"),
+						VersionId:     aws.String(testVersionID),
+						SecretBinary:  testSecretVal,
+						VersionStages: []string{"nitric-disabled-" + testVersionID},
+					}, nil).Times(1)
+
+					response, err := secretPlugin.Access(context.TODO(), &secretpb.SecretAccessRequest{
+						SecretVersion: &secretpb.SecretVersion{
+							Secret:  testSecret,
+							Version: testVersionID,
+						},
+					})
+					By("returning a failed precondition error")
+					Expect(status.Code(err)).To(Equal(codes.FailedPrecondition))
+					By("returning a nil response")
+					Expect(response).Should(BeNil())
+				})
+			})
+		})
+	})
+
+	When("ListVersions", func() {
+		When("The secret has versions", func() {
+			ctrl := gomock.NewController(GinkgoT())
+			mockProvider := mock_provider.NewMockAwsResourceResolver(ctrl)
+			mockSecretClient := mocks.NewMockSecretsManagerAPI(ctrl)
+			secretPlugin := &SecretsManagerSecretService{
+				client:   mockSecretClient,
+				resolver: mockProvider,
+			}
+			It("Should return every version newest first", func() {
+				defer ctrl.Finish()
+
+				mockProvider.EXPECT().GetResources(gomock.Any(), resource.AwsResource_Secret).Return(map[string]resource.ResolvedResource{
+					"Test": {ARN: testARN},
+				}, nil)
+
+				By("listing the versions including deprecated versions across pages")
+				gomock.InOrder(
+					mockSecretClient.EXPECT().ListSecretVersionIds(gomock.Any(), &secretsmanager.ListSecretVersionIdsInput{
+						SecretId:          aws.String(testARN),
+						IncludeDeprecated: aws.Bool(true),
+					}).Return(&secretsmanager.ListSecretVersionIdsOutput{
+						Versions: []types.SecretVersionsListEntry{{
+							VersionId:     aws.String("first"),
+							CreatedDate:   aws.Time(time.Unix(100, 0)),
+							VersionStages: []string{},
+						}},
+						NextToken: aws.String("page-2"),
+					}, nil),
+					mockSecretClient.EXPECT().ListSecretVersionIds(gomock.Any(), &secretsmanager.ListSecretVersionIdsInput{
+						SecretId:          aws.String(testARN),
+						IncludeDeprecated: aws.Bool(true),
+						NextToken:         aws.String("page-2"),
+					}).Return(&secretsmanager.ListSecretVersionIdsOutput{
+						Versions: []types.SecretVersionsListEntry{{
+							VersionId:     aws.String("second"),
+							CreatedDate:   aws.Time(time.Unix(300, 0)),
+							VersionStages: []string{"nitric-disabled-second"},
+						}, {
+							VersionId:     aws.String("third"),
+							CreatedDate:   aws.Time(time.Unix(200, 0)),
+							VersionStages: []string{"AWSCURRENT"},
+						}},
+					}, nil),
+				)
+
+				response, err := secretPlugin.ListVersions(context.TODO(), &secretpb.SecretListVersionsRequest{
+					Secret: testSecret,
+				})
+				Expect(err).ShouldNot(HaveOccurred())
+
+				By("ordering the versions by their created date")
+				Expect(response.Versions).To(HaveLen(3))
+				Expect(response.Versions[0].SecretVersion.Version).To(Equal("second"))
+				Expect(response.Versions[0].State).To(Equal(secretpb.SecretVersionState_Disabled))
+				Expect(response.Versions[1].SecretVersion.Version).To(Equal("third"))
+				Expect(response.Versions[1].State).To(Equal(secretpb.SecretVersionState_Enabled))
+				Expect(response.Versions[2].SecretVersion.Version).To(Equal("first"))
+				Expect(response.Versions[2].State).To(Equal(secretpb.SecretVersionState_Destroyed))
+			})
+		})
+	})
+
+	When("DisableVersion", func() {
+		When("The version is the current version", func() {
+			ctrl := gomock.NewController(GinkgoT())
+			mockProvider := mock_provider.NewMockAwsResourceResolver(ctrl)
+			mockSecretClient := mocks.NewMockSecretsManagerAPI(ctrl)
+			secretPlugin := &SecretsManagerSecretService{
+				client:   mockSecretClient,
+				resolver: mockProvider,
+			}
+			It("Should return a failed precondition error", func() {
+				defer ctrl.Finish()
+
+				mockProvider.EXPECT().GetResources(gomock.Any(), resource.AwsResource_Secret).Return(map[string]resource.ResolvedResource{
+					"Test": {ARN: testARN},
+				}, nil)
+
+				mockSecretClient.EXPECT().DescribeSecret(gomock.Any(), gomock.Any()).Return(&secretsmanager.DescribeSecretOutput{
+					VersionIdsToStages: map[string][]string{
+						testVersionID: {"AWSCURRENT"},
+					},
+				}, nil)
+
+				_, err := secretPlugin.DisableVersion(context.TODO(), &secretpb.SecretDisableVersionRequest{
+					SecretVersion: &secretpb.SecretVersion{
+						Secret:  testSecret,
+						Version: testVersionID,
+					},
+				})
+				Expect(status.Code(err)).To(Equal(codes.FailedPrecondition))
+			})
+		})
+
+		When("The version is a previous version", func() {
+			ctrl := gomock.NewController(GinkgoT())
+			mockProvider := mock_provider.NewMockAwsResourceResolver(ctrl)
+			mockSecretClient := mocks.NewMockSecretsManagerAPI(ctrl)
+			secretPlugin := &SecretsManagerSecretService{
+				client:   mockSecretClient,
+				resolver: mockProvider,
+			}
+			It("Should attach the disabled label", func() {
+				defer ctrl.Finish()
+
+				mockProvider.EXPECT().GetResources(gomock.Any(), resource.AwsResource_Secret).Return(map[string]resource.ResolvedResource{
+					"Test": {ARN: testARN},
+				}, nil)
+
+				mockSecretClient.EXPECT().DescribeSecret(gomock.Any(), gomock.Any()).Return(&secretsmanager.DescribeSecretOutput{
+					VersionIdsToStages: map[string][]string{
+						testVersionID: {"AWSPREVIOUS"},
+					},
+				}, nil)
+
+				By("attaching the disabled label without removing the existing labels")
+				mockSecretClient.EXPECT().UpdateSecretVersionStage(gomock.Any(), &secretsmanager.UpdateSecretVersionStageInput{
+					SecretId:        aws.String(testARN),
+					VersionStage:    aws.String("nitric-disabled-" + testVersionID),
+					MoveToVersionId: aws.String(testVersionID),
+				}).Return(&secretsmanager.UpdateSecretVersionStageOutput{}, nil).Times(1)
+
+				_, err := secretPlugin.DisableVersion(context.TODO(), &secretpb.SecretDisableVersionRequest{
+					SecretVersion: &secretpb.SecretVersion{
+						Secret:  testSecret,
+						Version: testVersionID,
+					},
+				})
+				Expect(err).ShouldNot(HaveOccurred())
+			})
+		})
+	})
+
+	When("EnableVersion", func() {
+		When("The version is disabled", func() {
+			ctrl := gomock.NewController(GinkgoT())
+			mockProvider := mock_provider.NewMockAwsResourceResolver(ctrl)
+			mockSecretClient := mocks.NewMockSecretsManagerAPI(ctrl)
+			secretPlugin := &SecretsManagerSecretService{
+				client:   mockSecretClient,
+				resolver: mockProvider,
+			}
+			It("Should remove the disabled label", func() {
+				defer ctrl.Finish()
+
+				mockProvider.EXPECT().GetResources(gomock.Any(), resource.AwsResource_Secret).Return(map[string]resource.ResolvedResource{
+					"Test": {ARN: testARN},
+				}, nil)
+
+				mockSecretClient.EXPECT().DescribeSecret(gomock.Any(), gomock.Any()).Return(&secretsmanager.DescribeSecretOutput{
+					VersionIdsToStages: map[string][]string{
+						testVersionID: {"AWSPREVIOUS", "nitric-disabled-" + testVersionID},
+					},
+				}, nil)
+
+				By("not attaching any other label")
+				mockSecretClient.EXPECT().UpdateSecretVersionStage(gomock.Any(), &secretsmanager.UpdateSecretVersionStageInput{
+					SecretId:            aws.String(testARN),
+					VersionStage:        aws.String("nitric-disabled-" + testVersionID),
+					RemoveFromVersionId: aws.String(testVersionID),
+				}).Return(&secretsmanager.UpdateSecretVersionStageOutput{}, nil).Times(1)
+
+				_, err := secretPlugin.EnableVersion(context.TODO(), &secretpb.SecretEnableVersionRequest{
+					SecretVersion: &secretpb.SecretVersion{
+						Secret:  testSecret,
+						Version: testVersionID,
+					},
+				})
+				Expect(err).ShouldNot(HaveOccurred())
+			})
+		})
+
+		When("The version only has the disabled label", func() {
+			ctrl := gomock.NewController(GinkgoT())
+			mockProvider := mock_provider.NewMockAwsResourceResolver(ctrl)
+			mockSecretClient := mocks.NewMockSecretsManagerAPI(ctrl)
+			secretPlugin := &SecretsManagerSecretService{
+				client:   mockSecretClient,
+				resolver: mockProvider,
+			}
+			It("Should return a failed precondition error", func() {
+				defer ctrl.Finish()
+
+				mockProvider.EXPECT().GetResources(gomock.Any(), resource.AwsResource_Secret).Return(map[string]resource.ResolvedResource{
+					"Test": {ARN: testARN},
+				}, nil)
+
+				mockSecretClient.EXPECT().DescribeSecret(gomock.Any(), gomock.Any()).Return(&secretsmanager.DescribeSecretOutput{
+					VersionIdsToStages: map[string][]string{
+						testVersionID: {"nitric-disabled-" + testVersionID},
+					},
+				}, nil)
+
+				_, err := secretPlugin.EnableVersion(context.TODO(), &secretpb.SecretEnableVersionRequest{
+					SecretVersion: &secretpb.SecretVersion{
+						Secret:  testSecret,
+						Version: testVersionID,
+					},
+				})
+				Expect(status.Code(err)).To(Equal(codes.FailedPrecondition))
+			})
 		})
 	})
 })
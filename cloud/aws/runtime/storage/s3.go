@@ -26,14 +26,18 @@ import (
 	"github.com/aws/aws-sdk-go-v2/aws"
 	"github.com/aws/aws-sdk-go-v2/config"
 	"github.com/aws/aws-sdk-go-v2/service/s3"
+	"github.com/aws/aws-sdk-go-v2/service/s3/types"
 	"github.com/aws/smithy-go"
 	"go.opentelemetry.io/contrib/instrumentation/github.com/aws/aws-sdk-go-v2/otelaws"
 	"google.golang.org/grpc/codes"
+	"google.golang.org/protobuf/types/known/timestamppb"
 
 	"github.com/nitrictech/nitric/cloud/aws/ifaces/s3iface"
 	"github.com/nitrictech/nitric/cloud/aws/runtime/env"
 	"github.com/nitrictech/nitric/cloud/aws/runtime/resource"
+	base_storage "github.com/nitrictech/nitric/cloud/common/runtime/storage"
 	grpc_errors "github.com/nitrictech/nitric/core/pkg/grpc/errors"
+	"github.com/nitrictech/nitric/core/pkg/logger"
 	storagepb "github.com/nitrictech/nitric/core/pkg/proto/storage/v1"
 )
 
@@ -43,6 +47,9 @@ const (
 	ErrCodeAccessDenied = "AccessDenied"
 )
 
+// multipartPartSize - the size of each part of a streamed upload, S3 requires at least 5MiB for all but the last part
+const multipartPartSize = 8 * 1024 * 1024
+
 // S3StorageService - an AWS S3 implementation of the Nitric Storage Service
 type S3StorageService struct {
 	s3Client      s3iface.S3API
@@ -130,13 +137,18 @@ func (s *S3StorageService) Write(ctx context.Context, req *storagepb.StorageWrit
 	newErr := grpc_errors.ErrorsWithScope("S3StorageService.Write")
 
 	if b, err := s.getS3BucketName(ctx, req.BucketName); err == nil {
-		contentType := http.DetectContentType(req.Body)
+		contentType := req.ContentType
+		if contentType == "" {
+			contentType = http.DetectContentType(req.Body)
+		}
 
 		if _, err := s.s3Client.PutObject(ctx, &s3.PutObjectInput{
-			Bucket:      b,
-			Body:        bytes.NewReader(req.Body),
-			ContentType: &contentType,
-			Key:         aws.String(req.Key),
+			Bucket:       b,
+			Body:         bytes.NewReader(req.Body),
+			ContentType:  &contentType,
+			CacheControl: optionalString(req.CacheControl),
+			Metadata:     req.Metadata,
+			Key:          aws.String(req.Key),
 		}); err != nil {
 			if isS3AccessDeniedErr(err) {
 				return nil, newErr(
@@ -197,6 +209,198 @@ func (s *S3StorageService) Delete(ctx context.Context, req *storagepb.StorageDel
 	return &storagepb.StorageDeleteResponse{}, nil
 }
 
+// ReadStream streams the contents of a file in a bucket, optionally limited to a byte range
+func (s *S3StorageService) ReadStream(req *storagepb.StorageReadStreamRequest, stream storagepb.Storage_ReadStreamServer) error {
+	newErr := grpc_errors.ErrorsWithScope("S3StorageService.ReadStream")
+	ctx := stream.Context()
+
+	if err := base_storage.ValidateRange(req); err != nil {
+		return newErr(codes.InvalidArgument, "invalid range", err)
+	}
+
+	b, err := s.getS3BucketName(ctx, req.BucketName)
+	if err != nil {
+		return newErr(codes.NotFound, "error finding S3 bucket", err)
+	}
+
+	input := &s3.GetObjectInput{
+		Bucket: b,
+		Key:    aws.String(req.Key),
+	}
+
+	if req.Offset > 0 || req.Length > 0 {
+		input.Range = aws.String(byteRange(req.Offset, req.Length))
+	}
+
+	resp, err := s.s3Client.GetObject(ctx, input)
+	if err != nil {
+		if isS3AccessDeniedErr(err) {
+			return newErr(
+				codes.PermissionDenied,
+				"unable to read file, this may be due to a missing permissions request in your code.",
+				err,
+			)
+		}
+
+		var apiErr smithy.APIError
+		if errors.As(err, &apiErr) && apiErr.ErrorCode() == "InvalidRange" {
+			return newErr(codes.OutOfRange, "range is not satisfiable for this file", err)
+		}
+
+		return newErr(
+			codes.Unknown,
+			"error reading file",
+			err,
+		)
+	}
+	defer resp.Body.Close()
+
+	if err := base_storage.SendChunks(stream, resp.Body); err != nil {
+		return newErr(codes.Unknown, "error streaming file", err)
+	}
+
+	return nil
+}
+
+// byteRange formats an HTTP range header for the given offset and length, a length of 0 reads to the end of the file
+func byteRange(offset int64, length int64) string {
+	if length == 0 {
+		return fmt.Sprintf("bytes=%d-", offset)
+	}
+
+	return fmt.Sprintf("bytes=%d-%d", offset, offset+length-1)
+}
+
+// WriteStream writes a streamed file to a bucket, using a multipart upload when it's larger than a single part
+func (s *S3StorageService) WriteStream(stream storagepb.Storage_WriteStreamServer) error {
+	newErr := grpc_errors.ErrorsWithScope("S3StorageService.WriteStream")
+	ctx := stream.Context()
+
+	header, body, err := base_storage.ReceiveWriteStream(stream)
+	if err != nil {
+		return newErr(codes.InvalidArgument, "invalid write stream", err)
+	}
+
+	b, err := s.getS3BucketName(ctx, header.BucketName)
+	if err != nil {
+		return newErr(codes.NotFound, "error finding S3 bucket", err)
+	}
+
+	part := make([]byte, multipartPartSize)
+
+	n, err := io.ReadFull(body, part)
+	if err != nil && !errors.Is(err, io.EOF) && !errors.Is(err, io.ErrUnexpectedEOF) {
+		return newErr(codes.Unknown, "error receiving file", err)
+	}
+
+	contentType := header.ContentType
+	if contentType == "" {
+		contentType = http.DetectContentType(part[:n])
+	}
+
+	// files that fit in a single part don't need the overhead of a multipart upload
+	if n < multipartPartSize {
+		if _, err := s.s3Client.PutObject(ctx, &s3.PutObjectInput{
+			Bucket:       b,
+			Body:         bytes.NewReader(part[:n]),
+			ContentType:  &contentType,
+			CacheControl: optionalString(header.CacheControl),
+			Metadata:     header.Metadata,
+			Key:          aws.String(header.Key),
+		}); err != nil {
+			return newWriteErr(newErr, err)
+		}
+
+		return stream.SendAndClose(&storagepb.StorageWriteStreamResponse{
+			Size: body.Size(),
+		})
+	}
+
+	upload, err := s.s3Client.CreateMultipartUpload(ctx, &s3.CreateMultipartUploadInput{
+		Bucket:       b,
+		ContentType:  &contentType,
+		CacheControl: optionalString(header.CacheControl),
+		Metadata:     header.Metadata,
+		Key:          aws.String(header.Key),
+	})
+	if err != nil {
+		return newWriteErr(newErr, err)
+	}
+
+	if err := s.uploadParts(ctx, upload, part, body); err != nil {
+		// abort with a fresh context, the stream's context may have been cancelled
+		if _, abortErr := s.s3Client.AbortMultipartUpload(context.Background(), &s3.AbortMultipartUploadInput{
+			Bucket:   upload.Bucket,
+			Key:      upload.Key,
+			UploadId: upload.UploadId,
+		}); abortErr != nil {
+			logger.Errorf("unable to abort multipart upload %s: %v", aws.ToString(upload.UploadId), abortErr)
+		}
+
+		return newWriteErr(newErr, err)
+	}
+
+	return stream.SendAndClose(&storagepb.StorageWriteStreamResponse{
+		Size: body.Size(),
+	})
+}
+
+// uploadParts uploads the remainder of a multipart upload, starting with an already buffered first part
+func (s *S3StorageService) uploadParts(ctx context.Context, upload *s3.CreateMultipartUploadOutput, part []byte, body io.Reader) error {
+	completed := []types.CompletedPart{}
+	n := len(part)
+
+	for partNumber := int32(1); n > 0; partNumber++ {
+		resp, err := s.s3Client.UploadPart(ctx, &s3.UploadPartInput{
+			Bucket:     upload.Bucket,
+			Key:        upload.Key,
+			UploadId:   upload.UploadId,
+			PartNumber: aws.Int32(partNumber),
+			Body:       bytes.NewReader(part[:n]),
+		})
+		if err != nil {
+			return err
+		}
+
+		completed = append(completed, types.CompletedPart{
+			ETag:       resp.ETag,
+			PartNumber: aws.Int32(partNumber),
+		})
+
+		n, err = io.ReadFull(body, part)
+		if err != nil && !errors.Is(err, io.EOF) && !errors.Is(err, io.ErrUnexpectedEOF) {
+			return err
+		}
+	}
+
+	_, err := s.s3Client.CompleteMultipartUpload(ctx, &s3.CompleteMultipartUploadInput{
+		Bucket:   upload.Bucket,
+		Key:      upload.Key,
+		UploadId: upload.UploadId,
+		MultipartUpload: &types.CompletedMultipartUpload{
+			Parts: completed,
+		},
+	})
+
+	return err
+}
+
+func newWriteErr(newErr grpc_errors.ScopedErrorFactory, err error) error {
+	if isS3AccessDeniedErr(err) {
+		return newErr(
+			codes.PermissionDenied,
+			"unable to write file, this may be due to a missing permissions request in your code.",
+			err,
+		)
+	}
+
+	return newErr(
+		codes.Unknown,
+		"error writing file",
+		err,
+	)
+}
+
 // PreSignUrl generates a signed URL which can be used to perform direct operations on a file
 // useful for large file uploads/downloads so they can bypass application code and work directly with S3
 func (s *S3StorageService) PreSignUrl(ctx context.Context, req *storagepb.StoragePreSignUrlRequest) (*storagepb.StoragePreSignUrlResponse, error) {
@@ -246,21 +450,38 @@ func (s *S3StorageService) PreSignUrl(ctx context.Context, req *storagepb.Storag
 	}
 }
 
-// ListFiles lists all files in a bucket
+// ListFiles lists the files in a bucket, a page at a time when a limit is requested
 func (s *S3StorageService) ListBlobs(ctx context.Context, req *storagepb.StorageListBlobsRequest) (*storagepb.StorageListBlobsResponse, error) {
 	newErr := grpc_errors.ErrorsWithScope("S3StorageService.ListFiles")
 
-	var prefix *string = nil
-	if req.Prefix != "" {
-		// Only apply if prefix isn't default
-		prefix = &req.Prefix
+	b, err := s.getS3BucketName(ctx, req.BucketName)
+	if err != nil {
+		return nil, newErr(
+			codes.NotFound,
+			"error finding S3 bucket",
+			err,
+		)
 	}
 
-	if b, err := s.getS3BucketName(ctx, req.BucketName); err == nil {
-		objects, err := s.s3Client.ListObjectsV2(ctx, &s3.ListObjectsV2Input{
-			Bucket: b,
-			Prefix: prefix,
-		})
+	input := &s3.ListObjectsV2Input{
+		Bucket: b,
+		// Only apply if values aren't default
+		Prefix:            optionalString(req.Prefix),
+		Delimiter:         optionalString(req.Delimiter),
+		ContinuationToken: optionalString(req.PageToken),
+	}
+
+	if req.Limit > 0 {
+		input.MaxKeys = aws.Int32(req.Limit)
+	}
+
+	resp := &storagepb.StorageListBlobsResponse{
+		Blobs:    []*storagepb.Blob{},
+		Prefixes: []string{},
+	}
+
+	for {
+		objects, err := s.s3Client.ListObjectsV2(ctx, input)
 		if err != nil {
 			if isS3AccessDeniedErr(err) {
 				return nil, newErr(
@@ -277,23 +498,118 @@ func (s *S3StorageService) ListBlobs(ctx context.Context, req *storagepb.Storage
 			)
 		}
 
-		files := make([]*storagepb.Blob, 0, len(objects.Contents))
 		for _, o := range objects.Contents {
-			files = append(files, &storagepb.Blob{
-				Key: *o.Key,
-			})
+			blob := &storagepb.Blob{
+				Key:  aws.ToString(o.Key),
+				Size: aws.ToInt64(o.Size),
+				Etag: strings.Trim(aws.ToString(o.ETag), `"`),
+			}
+
+			if o.LastModified != nil {
+				blob.LastModified = timestamppb.New(*o.LastModified)
+			}
+
+			// listings don't include content types or metadata, so each object is fetched individually
+			if req.IncludeMetadata {
+				head, err := s.s3Client.HeadObject(ctx, &s3.HeadObjectInput{
+					Bucket: b,
+					Key:    o.Key,
+				})
+				if err != nil {
+					return nil, newErr(
+						codes.Unknown,
+						"error retrieving file metadata",
+						err,
+					)
+				}
+
+				blob = blobFromHead(blob.Key, head)
+			}
+
+			resp.Blobs = append(resp.Blobs, blob)
+		}
+
+		for _, p := range objects.CommonPrefixes {
+			resp.Prefixes = append(resp.Prefixes, aws.ToString(p.Prefix))
+		}
+
+		// a limit returns a single page, otherwise every remaining page is listed
+		if req.Limit > 0 || !aws.ToBool(objects.IsTruncated) {
+			if aws.ToBool(objects.IsTruncated) {
+				resp.NextPageToken = aws.ToString(objects.NextContinuationToken)
+			}
+
+			return resp, nil
+		}
+
+		input.ContinuationToken = objects.NextContinuationToken
+	}
+}
+
+// Stat returns the details of a file in a bucket, without its contents
+func (s *S3StorageService) Stat(ctx context.Context, req *storagepb.StorageStatRequest) (*storagepb.StorageStatResponse, error) {
+	newErr := grpc_errors.ErrorsWithScope("S3StorageService.Stat")
+
+	b, err := s.getS3BucketName(ctx, req.BucketName)
+	if err != nil {
+		return nil, newErr(codes.NotFound, "error finding S3 bucket", err)
+	}
+
+	head, err := s.s3Client.HeadObject(ctx, &s3.HeadObjectInput{
+		Bucket: b,
+		Key:    aws.String(req.Key),
+	})
+	if err != nil {
+		if isS3AccessDeniedErr(err) {
+			return nil, newErr(
+				codes.PermissionDenied,
+				"unable to read file details, this may be due to a missing permissions request in your code.",
+				err,
+			)
+		}
+
+		var notFound *types.NotFound
+		if errors.As(err, &notFound) {
+			return nil, newErr(codes.NotFound, fmt.Sprintf("file %s not found", req.Key), err)
 		}
 
-		return &storagepb.StorageListBlobsResponse{
-			Blobs: files,
-		}, nil
-	} else {
 		return nil, newErr(
-			codes.NotFound,
-			"error finding S3 bucket",
+			codes.Unknown,
+			"error reading file details",
 			err,
 		)
 	}
+
+	return &storagepb.StorageStatResponse{
+		Blob: blobFromHead(req.Key, head),
+	}, nil
+}
+
+// blobFromHead converts the result of a HeadObject request to a blob
+func blobFromHead(key string, head *s3.HeadObjectOutput) *storagepb.Blob {
+	blob := &storagepb.Blob{
+		Key:          key,
+		Size:         aws.ToInt64(head.ContentLength),
+		Etag:         strings.Trim(aws.ToString(head.ETag), `"`),
+		ContentType:  aws.ToString(head.ContentType),
+		CacheControl: aws.ToString(head.CacheControl),
+		Metadata:     head.Metadata,
+	}
+
+	if head.LastModified != nil {
+		blob.LastModified = timestamppb.New(*head.LastModified)
+	}
+
+	return blob
+}
+
+// optionalString returns nil for empty strings, so default values aren't sent to S3
+func optionalString(value string) *string {
+	if value == "" {
+		return nil
+	}
+
+	return aws.String(value)
 }
 
 func (s *S3StorageService) Exists(ctx context.Context, req *storagepb.StorageExistsRequest) (*storagepb.StorageExistsResponse, error) {
@@ -30,12 +30,15 @@ import (
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/gomega"
 	"github.com/pkg/errors"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 	"google.golang.org/protobuf/types/known/durationpb"
 
 	mock_provider "github.com/nitrictech/nitric/cloud/aws/mocks/provider"
 	mock_s3iface "github.com/nitrictech/nitric/cloud/aws/mocks/s3"
 	"github.com/nitrictech/nitric/cloud/aws/runtime/resource"
 	s3_service "github.com/nitrictech/nitric/cloud/aws/runtime/storage"
+	"github.com/nitrictech/nitric/cloud/common/runtime/storage/storagetest"
 	storagepb "github.com/nitrictech/nitric/core/pkg/proto/storage/v1"
 )
 
@@ -70,6 +73,41 @@ var _ = Describe("S3", func() {
 				})
 			})
 
+			When("Creating an object with a content type and metadata", func() {
+				ctrl := gomock.NewController(GinkgoT())
+
+				mockStorageClient := mock_s3iface.NewMockS3API(ctrl)
+				mockPSStorageClient := mock_s3iface.NewMockPreSignAPI(ctrl)
+				mockProvider := mock_provider.NewMockAwsResourceResolver(ctrl)
+				storagePlugin, _ := s3_service.NewWithClient(mockProvider, mockStorageClient, mockPSStorageClient)
+
+				It("Should store the object with the provided details", func() {
+					By("the bucket existing")
+					mockProvider.EXPECT().GetResources(gomock.Any(), resource.AwsResource_Bucket).Return(map[string]resource.ResolvedResource{
+						"my-bucket": {ARN: "arn:aws:s3:::my-bucket"},
+					}, nil)
+
+					By("writing the item with the provided details")
+					mockStorageClient.EXPECT().PutObject(gomock.Any(), gomock.Any()).DoAndReturn(func(ctx context.Context, input *s3.PutObjectInput, opts ...func(*s3.Options)) (*s3.PutObjectOutput, error) {
+						Expect(*input.ContentType).To(Equal("text/css"))
+						Expect(*input.CacheControl).To(Equal("max-age=60"))
+						Expect(input.Metadata).To(Equal(map[string]string{"owner": "test"}))
+						return &s3.PutObjectOutput{}, nil
+					})
+
+					_, err := storagePlugin.Write(context.TODO(), &storagepb.StorageWriteRequest{
+						BucketName:   "my-bucket",
+						Key:          "styles.css",
+						Body:         []byte("body {}"),
+						ContentType:  "text/css",
+						CacheControl: "max-age=60",
+						Metadata:     map[string]string{"owner": "test"},
+					})
+					By("Not returning an error")
+					Expect(err).ShouldNot(HaveOccurred())
+				})
+			})
+
 			When("Creating an object in a non-existent bucket", func() {
 				ctrl := gomock.NewController(GinkgoT())
 				mockStorageClient := mock_s3iface.NewMockS3API(ctrl)
@@ -353,6 +391,135 @@ var _ = Describe("S3", func() {
 		})
 	})
 
+	When("ListFiles with a delimiter and limit", func() {
+		ctrl := gomock.NewController(GinkgoT())
+		mockStorageClient := mock_s3iface.NewMockS3API(ctrl)
+		mockPSStorageClient := mock_s3iface.NewMockPreSignAPI(ctrl)
+		mockProvider := mock_provider.NewMockAwsResourceResolver(ctrl)
+		storagePlugin, _ := s3_service.NewWithClient(mockProvider, mockStorageClient, mockPSStorageClient)
+
+		It("should return a single page of files and prefixes", func() {
+			By("the bucket existing")
+			mockProvider.EXPECT().GetResources(gomock.Any(), resource.AwsResource_Bucket).Return(map[string]resource.ResolvedResource{
+				"test-bucket": {ARN: "arn:aws:s3:::test-bucket-aaa111"},
+			}, nil)
+
+			lastModified := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+
+			By("s3 returning a truncated page")
+			mockStorageClient.EXPECT().ListObjectsV2(gomock.Any(), &s3.ListObjectsV2Input{
+				Bucket:            aws.String("test-bucket-aaa111"),
+				Delimiter:         aws.String("/"),
+				MaxKeys:           aws.Int32(2),
+				ContinuationToken: aws.String("page-1"),
+			}).Return(&s3.ListObjectsV2Output{
+				Contents: []types.Object{{
+					Key:          aws.String("test.txt"),
+					Size:         aws.Int64(4),
+					ETag:         aws.String(`"abc123"`),
+					LastModified: &lastModified,
+				}},
+				CommonPrefixes: []types.CommonPrefix{{
+					Prefix: aws.String("images/"),
+				}},
+				IsTruncated:           aws.Bool(true),
+				NextContinuationToken: aws.String("page-2"),
+			}, nil)
+
+			resp, err := storagePlugin.ListBlobs(context.TODO(), &storagepb.StorageListBlobsRequest{
+				BucketName: "test-bucket",
+				Delimiter:  "/",
+				Limit:      2,
+				PageToken:  "page-1",
+			})
+
+			By("not returning an error")
+			Expect(err).ShouldNot(HaveOccurred())
+
+			By("returning the file details")
+			Expect(resp.Blobs).To(HaveLen(1))
+			Expect(resp.Blobs[0].Size).To(Equal(int64(4)))
+			Expect(resp.Blobs[0].Etag).To(Equal("abc123"))
+			Expect(resp.Blobs[0].LastModified.AsTime()).To(Equal(lastModified))
+
+			By("returning the common prefixes")
+			Expect(resp.Prefixes).To(Equal([]string{"images/"}))
+
+			By("returning the next page token")
+			Expect(resp.NextPageToken).To(Equal("page-2"))
+		})
+	})
+
+	When("Stat", func() {
+		When("The file exists", func() {
+			ctrl := gomock.NewController(GinkgoT())
+			mockStorageClient := mock_s3iface.NewMockS3API(ctrl)
+			mockPSStorageClient := mock_s3iface.NewMockPreSignAPI(ctrl)
+			mockProvider := mock_provider.NewMockAwsResourceResolver(ctrl)
+			storagePlugin, _ := s3_service.NewWithClient(mockProvider, mockStorageClient, mockPSStorageClient)
+
+			It("should return the file details", func() {
+				By("the bucket existing")
+				mockProvider.EXPECT().GetResources(gomock.Any(), resource.AwsResource_Bucket).Return(map[string]resource.ResolvedResource{
+					"test-bucket": {ARN: "arn:aws:s3:::test-bucket-aaa111"},
+				}, nil)
+
+				By("the file existing")
+				mockStorageClient.EXPECT().HeadObject(gomock.Any(), &s3.HeadObjectInput{
+					Bucket: aws.String("test-bucket-aaa111"),
+					Key:    aws.String("test.png"),
+				}).Return(&s3.HeadObjectOutput{
+					ContentLength: aws.Int64(1024),
+					ContentType:   aws.String("image/png"),
+					CacheControl:  aws.String("max-age=60"),
+					ETag:          aws.String(`"abc123"`),
+					Metadata:      map[string]string{"owner": "test"},
+				}, nil)
+
+				resp, err := storagePlugin.Stat(context.TODO(), &storagepb.StorageStatRequest{
+					BucketName: "test-bucket",
+					Key:        "test.png",
+				})
+
+				By("not returning an error")
+				Expect(err).ShouldNot(HaveOccurred())
+
+				By("returning the file details")
+				Expect(resp.Blob.Key).To(Equal("test.png"))
+				Expect(resp.Blob.Size).To(Equal(int64(1024)))
+				Expect(resp.Blob.ContentType).To(Equal("image/png"))
+				Expect(resp.Blob.CacheControl).To(Equal("max-age=60"))
+				Expect(resp.Blob.Etag).To(Equal("abc123"))
+				Expect(resp.Blob.Metadata).To(Equal(map[string]string{"owner": "test"}))
+			})
+		})
+
+		When("The file doesn't exist", func() {
+			ctrl := gomock.NewController(GinkgoT())
+			mockStorageClient := mock_s3iface.NewMockS3API(ctrl)
+			mockPSStorageClient := mock_s3iface.NewMockPreSignAPI(ctrl)
+			mockProvider := mock_provider.NewMockAwsResourceResolver(ctrl)
+			storagePlugin, _ := s3_service.NewWithClient(mockProvider, mockStorageClient, mockPSStorageClient)
+
+			It("should return a not found error", func() {
+				By("the bucket existing")
+				mockProvider.EXPECT().GetResources(gomock.Any(), resource.AwsResource_Bucket).Return(map[string]resource.ResolvedResource{
+					"test-bucket": {ARN: "arn:aws:s3:::test-bucket-aaa111"},
+				}, nil)
+
+				By("s3 returning not found")
+				mockStorageClient.EXPECT().HeadObject(gomock.Any(), gomock.Any()).Return(nil, &types.NotFound{})
+
+				_, err := storagePlugin.Stat(context.TODO(), &storagepb.StorageStatRequest{
+					BucketName: "test-bucket",
+					Key:        "missing.png",
+				})
+
+				Expect(status.Code(err)).To(Equal(codes.NotFound))
+			})
+		})
+	})
+
 	When("Exists", func() {
 		When("The bucket exists", func() {
 			When("The s3 backend is available", func() {
@@ -416,4 +583,163 @@ var _ = Describe("S3", func() {
 			})
 		})
 	})
+
+	When("ReadStream", func() {
+		When("A range of an existing object is requested", func() {
+			ctrl := gomock.NewController(GinkgoT())
+			mockStorageClient := mock_s3iface.NewMockS3API(ctrl)
+			mockPSStorageClient := mock_s3iface.NewMockPreSignAPI(ctrl)
+			mockProvider := mock_provider.NewMockAwsResourceResolver(ctrl)
+			storagePlugin, _ := s3_service.NewWithClient(mockProvider, mockStorageClient, mockPSStorageClient)
+
+			It("Should stream the requested range of the object", func() {
+				By("the bucket existing")
+				mockProvider.EXPECT().GetResources(gomock.Any(), resource.AwsResource_Bucket).Return(map[string]resource.ResolvedResource{
+					"test-bucket": {ARN: "arn:aws:s3:::test-bucket"},
+				}, nil)
+
+				By("requesting the range from S3")
+				mockStorageClient.EXPECT().GetObject(gomock.Any(), &s3.GetObjectInput{
+					Bucket: aws.String("test-bucket"),
+					Key:    aws.String("test-key"),
+					Range:  aws.String("bytes=2-5"),
+				}).Return(&s3.GetObjectOutput{
+					Body: io.NopCloser(bytes.NewReader([]byte("st d"))),
+				}, nil)
+
+				stream := &storagetest.ReadStream{}
+				err := storagePlugin.ReadStream(&storagepb.StorageReadStreamRequest{
+					BucketName: "test-bucket",
+					Key:        "test-key",
+					Offset:     2,
+					Length:     4,
+				}, stream)
+
+				By("Not returning an error")
+				Expect(err).ShouldNot(HaveOccurred())
+
+				By("Streaming the range")
+				Expect(stream.Body.String()).To(Equal("st d"))
+			})
+		})
+
+		When("A negative offset is requested", func() {
+			ctrl := gomock.NewController(GinkgoT())
+			mockStorageClient := mock_s3iface.NewMockS3API(ctrl)
+			mockPSStorageClient := mock_s3iface.NewMockPreSignAPI(ctrl)
+			mockProvider := mock_provider.NewMockAwsResourceResolver(ctrl)
+			storagePlugin, _ := s3_service.NewWithClient(mockProvider, mockStorageClient, mockPSStorageClient)
+
+			It("Should return an invalid argument error", func() {
+				err := storagePlugin.ReadStream(&storagepb.StorageReadStreamRequest{
+					BucketName: "test-bucket",
+					Key:        "test-key",
+					Offset:     -1,
+				}, &storagetest.ReadStream{})
+
+				Expect(status.Code(err)).To(Equal(codes.InvalidArgument))
+			})
+		})
+	})
+
+	When("WriteStream", func() {
+		When("The object fits in a single part", func() {
+			ctrl := gomock.NewController(GinkgoT())
+			mockStorageClient := mock_s3iface.NewMockS3API(ctrl)
+			mockPSStorageClient := mock_s3iface.NewMockPreSignAPI(ctrl)
+			mockProvider := mock_provider.NewMockAwsResourceResolver(ctrl)
+			storagePlugin, _ := s3_service.NewWithClient(mockProvider, mockStorageClient, mockPSStorageClient)
+
+			It("Should store the object with a single put", func() {
+				By("the bucket existing")
+				mockProvider.EXPECT().GetResources(gomock.Any(), resource.AwsResource_Bucket).Return(map[string]resource.ResolvedResource{
+					"my-bucket": {ARN: "arn:aws:s3:::my-bucket"},
+				}, nil)
+
+				var written []byte
+				mockStorageClient.EXPECT().PutObject(gomock.Any(), gomock.Any()).DoAndReturn(func(ctx context.Context, input *s3.PutObjectInput, opts ...func(*s3.Options)) (*s3.PutObjectOutput, error) {
+					written, _ = io.ReadAll(input.Body)
+					return &s3.PutObjectOutput{}, nil
+				})
+
+				stream := storagetest.NewWriteStream("my-bucket", "test-item", []byte("Te"), []byte("st"))
+				err := storagePlugin.WriteStream(stream)
+
+				By("Not returning an error")
+				Expect(err).ShouldNot(HaveOccurred())
+
+				By("Writing the chunks in order")
+				Expect(written).To(Equal([]byte("Test")))
+
+				By("Returning the size of the object")
+				Expect(stream.Response.Size).To(Equal(int64(4)))
+			})
+		})
+
+		When("The object is larger than a single part", func() {
+			ctrl := gomock.NewController(GinkgoT())
+			mockStorageClient := mock_s3iface.NewMockS3API(ctrl)
+			mockPSStorageClient := mock_s3iface.NewMockPreSignAPI(ctrl)
+			mockProvider := mock_provider.NewMockAwsResourceResolver(ctrl)
+			storagePlugin, _ := s3_service.NewWithClient(mockProvider, mockStorageClient, mockPSStorageClient)
+
+			It("Should store the object with a multipart upload", func() {
+				By("the bucket existing")
+				mockProvider.EXPECT().GetResources(gomock.Any(), resource.AwsResource_Bucket).Return(map[string]resource.ResolvedResource{
+					"my-bucket": {ARN: "arn:aws:s3:::my-bucket"},
+				}, nil)
+
+				mockStorageClient.EXPECT().CreateMultipartUpload(gomock.Any(), gomock.Any()).Return(&s3.CreateMultipartUploadOutput{
+					Bucket:   aws.String("my-bucket"),
+					Key:      aws.String("test-item"),
+					UploadId: aws.String("upload-id"),
+				}, nil)
+
+				By("uploading each part")
+				mockStorageClient.EXPECT().UploadPart(gomock.Any(), gomock.Any()).Times(2).DoAndReturn(func(ctx context.Context, input *s3.UploadPartInput, opts ...func(*s3.Options)) (*s3.UploadPartOutput, error) {
+					return &s3.UploadPartOutput{
+						ETag: aws.String(fmt.Sprintf("etag-%d", *input.PartNumber)),
+					}, nil
+				})
+
+				By("completing the upload with every part")
+				mockStorageClient.EXPECT().CompleteMultipartUpload(gomock.Any(), gomock.Any()).DoAndReturn(func(ctx context.Context, input *s3.CompleteMultipartUploadInput, opts ...func(*s3.Options)) (*s3.CompleteMultipartUploadOutput, error) {
+					Expect(input.MultipartUpload.Parts).To(HaveLen(2))
+					Expect(*input.UploadId).To(Equal("upload-id"))
+					return &s3.CompleteMultipartUploadOutput{}, nil
+				})
+
+				chunk := make([]byte, 1024*1024)
+				chunks := [][]byte{}
+				for i := 0; i < 9; i++ {
+					chunks = append(chunks, chunk)
+				}
+
+				stream := storagetest.NewWriteStream("my-bucket", "test-item", chunks...)
+				err := storagePlugin.WriteStream(stream)
+
+				By("Not returning an error")
+				Expect(err).ShouldNot(HaveOccurred())
+
+				By("Returning the size of the object")
+				Expect(stream.Response.Size).To(Equal(int64(9 * 1024 * 1024)))
+			})
+		})
+
+		When("The stream doesn't start with a header", func() {
+			ctrl := gomock.NewController(GinkgoT())
+			mockStorageClient := mock_s3iface.NewMockS3API(ctrl)
+			mockPSStorageClient := mock_s3iface.NewMockPreSignAPI(ctrl)
+			mockProvider := mock_provider.NewMockAwsResourceResolver(ctrl)
+			storagePlugin, _ := s3_service.NewWithClient(mockProvider, mockStorageClient, mockPSStorageClient)
+
+			It("Should return an invalid argument error", func() {
+				stream := storagetest.NewHeaderlessWriteStream([]byte("Test"))
+
+				err := storagePlugin.WriteStream(stream)
+
+				Expect(status.Code(err)).To(Equal(codes.InvalidArgument))
+			})
+		})
+	})
 })
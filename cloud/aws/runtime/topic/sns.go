@@ -20,6 +20,8 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"slices"
+	"strconv"
 	"strings"
 	"time"
 
@@ -29,6 +31,7 @@ import (
 	"github.com/aws/aws-sdk-go-v2/service/sns"
 	"github.com/aws/aws-sdk-go-v2/service/sns/types"
 	"github.com/aws/smithy-go"
+	"github.com/samber/lo"
 	"go.opentelemetry.io/contrib/instrumentation/github.com/aws/aws-sdk-go-v2/otelaws"
 	"go.opentelemetry.io/contrib/propagators/aws/xray"
 	"go.opentelemetry.io/otel/propagation"
@@ -39,11 +42,21 @@ import (
 	"github.com/nitrictech/nitric/cloud/aws/ifaces/snsiface"
 	"github.com/nitrictech/nitric/cloud/aws/runtime/env"
 	"github.com/nitrictech/nitric/cloud/aws/runtime/resource"
+	"github.com/nitrictech/nitric/cloud/common/runtime/messages"
 	grpc_errors "github.com/nitrictech/nitric/core/pkg/grpc/errors"
 	"github.com/nitrictech/nitric/core/pkg/help"
 	topicpb "github.com/nitrictech/nitric/core/pkg/proto/topics/v1"
 )
 
+// SNS allows up to 10 attributes per message, some of them are reserved for the trace context
+const maxMessageAttributes = 10
+
+// SNS publishes at most 10 messages per batch
+const maxPublishBatchSize = 10
+
+// W3C trace context continues the trace in subscribers, the x-ray format is kept for AWS X-Ray
+var tracePropagator = propagation.NewCompositeTextMapPropagator(propagation.TraceContext{}, xray.Propagator{})
+
 type SnsEventService struct {
 	client    snsiface.SNSAPI
 	sfnClient sfniface.SFNAPI
@@ -68,26 +81,48 @@ func (s *SnsEventService) getStateMachines(ctx context.Context) (map[string]reso
 	return s.resolver.GetResources(ctx, resource.AwsResource_StateMachine)
 }
 
-func (s *SnsEventService) publish(ctx context.Context, topic string, message string) error {
-	topics, err := s.getTopics(ctx)
-	if err != nil {
-		return fmt.Errorf("error finding topics: %w", err)
+// messageAttributes returns the SNS attributes of a message, its own attributes along with the trace context
+func messageAttributes(ctx context.Context, message *topicpb.TopicMessage) (map[string]types.MessageAttributeValue, error) {
+	reserved := append([]string{messages.EncodingAttribute}, tracePropagator.Fields()...)
+
+	if len(message.GetAttributes()) > maxMessageAttributes-len(reserved) {
+		return nil, fmt.Errorf("messages support at most %d attributes", maxMessageAttributes-len(reserved))
 	}
 
-	snsTopic, ok := topics[topic]
+	// marks the message as published by nitric, so it isn't delivered as data published by another producer
+	attrs := map[string]types.MessageAttributeValue{
+		messages.EncodingAttribute: {DataType: aws.String("String"), StringValue: aws.String(messages.NitricEncoding)},
+	}
+	for k, v := range message.GetAttributes() {
+		if slices.Contains(reserved, k) {
+			return nil, fmt.Errorf("attribute %s is reserved", k)
+		}
 
-	if !ok {
-		return fmt.Errorf("could not resolve topic ARN from topic name")
+		attrs[k] = types.MessageAttributeValue{DataType: aws.String("String"), StringValue: aws.String(v)}
 	}
 
 	mc := propagation.MapCarrier{}
-	xray.Propagator{}.Inject(ctx, mc)
+	tracePropagator.Inject(ctx, mc)
 
-	attrs := map[string]types.MessageAttributeValue{}
 	for k, v := range mc {
 		attrs[k] = types.MessageAttributeValue{DataType: aws.String("String"), StringValue: aws.String(v)}
 	}
 
+	return attrs, nil
+}
+
+func (s *SnsEventService) publish(ctx context.Context, topic string, message string, attrs map[string]types.MessageAttributeValue) (string, error) {
+	topics, err := s.getTopics(ctx)
+	if err != nil {
+		return "", fmt.Errorf("error finding topics: %w", err)
+	}
+
+	snsTopic, ok := topics[topic]
+
+	if !ok {
+		return "", fmt.Errorf("could not resolve topic ARN from topic name")
+	}
+
 	publishInput := &sns.PublishInput{
 		TopicArn: aws.String(snsTopic.ARN),
 		Message:  &message,
@@ -97,12 +132,15 @@ func (s *SnsEventService) publish(ctx context.Context, topic string, message str
 		MessageAttributes: attrs,
 	}
 
-	_, err = s.client.Publish(ctx, publishInput)
+	out, err := s.client.Publish(ctx, publishInput)
+	if err != nil {
+		return "", err
+	}
 
-	return err
+	return aws.ToString(out.MessageId), nil
 }
 
-func (s *SnsEventService) publishDelayed(ctx context.Context, topic string, delay time.Duration, message string) error {
+func (s *SnsEventService) publishDelayed(ctx context.Context, topic string, delay time.Duration, message string, attrs map[string]types.MessageAttributeValue) error {
 	stepFunctions, err := s.getStateMachines(ctx)
 	if err != nil {
 		return fmt.Errorf("error getting state machines: %w", err)
@@ -117,8 +155,9 @@ func (s *SnsEventService) publishDelayed(ctx context.Context, topic string, dela
 	xray.Propagator{}.Inject(ctx, mc)
 
 	input, err := json.Marshal(map[string]interface{}{
-		"seconds": int(delay / time.Second),
-		"message": message,
+		"seconds":    int(delay / time.Second),
+		"message":    message,
+		"attributes": attrs,
 	})
 	if err != nil {
 		return err
@@ -147,10 +186,18 @@ func (s *SnsEventService) Publish(ctx context.Context, req *topicpb.TopicPublish
 	}
 	message := base64.StdEncoding.EncodeToString(messageBytes)
 
+	// attributes are also set on the SNS message, so subscription filter policies can match them
+	attrs, err := messageAttributes(ctx, req.Message)
+	if err != nil {
+		return nil, newErr(codes.InvalidArgument, "invalid message attributes", err)
+	}
+
+	// delayed messages are published by the state machine, so they don't have an ID yet
+	messageId := ""
 	if req.Delay != nil && req.Delay.AsDuration() > 0 {
-		err = s.publishDelayed(ctx, req.TopicName, req.Delay.AsDuration(), message)
+		err = s.publishDelayed(ctx, req.TopicName, req.Delay.AsDuration(), message, attrs)
 	} else {
-		err = s.publish(ctx, req.TopicName, message)
+		messageId, err = s.publish(ctx, req.TopicName, message, attrs)
 	}
 
 	if err != nil {
@@ -165,7 +212,100 @@ func (s *SnsEventService) Publish(ctx context.Context, req *topicpb.TopicPublish
 		return nil, newErr(codes.Internal, "error publishing message", err)
 	}
 
-	return &topicpb.TopicPublishResponse{}, nil
+	return &topicpb.TopicPublishResponse{
+		MessageId: messageId,
+	}, nil
+}
+
+func failedPublishResult(err string) *topicpb.TopicPublishResult {
+	return &topicpb.TopicPublishResult{
+		Result: &topicpb.TopicPublishResult_Error{
+			Error: err,
+		},
+	}
+}
+
+// PublishBatch publishes messages to a given topic, in batches of up to 10 messages
+func (s *SnsEventService) PublishBatch(ctx context.Context, req *topicpb.TopicPublishBatchRequest) (*topicpb.TopicPublishBatchResponse, error) {
+	newErr := grpc_errors.ErrorsWithScope("SnsEventService.PublishBatch")
+
+	topics, err := s.getTopics(ctx)
+	if err != nil {
+		return nil, newErr(codes.Internal, "error finding topics", err)
+	}
+
+	snsTopic, ok := topics[req.TopicName]
+	if !ok {
+		return nil, newErr(codes.NotFound, fmt.Sprintf("topic %s does not exist", req.TopicName), nil)
+	}
+
+	results := make([]*topicpb.TopicPublishResult, len(req.Messages))
+	entries := []types.PublishBatchRequestEntry{}
+
+	for i, message := range req.Messages {
+		messageBytes, err := proto.Marshal(message)
+		if err != nil {
+			return nil, newErr(
+				codes.Unknown,
+				fmt.Sprintf("unable to serialize message. %s", help.BugInNitricHelpText()),
+				err,
+			)
+		}
+
+		attrs, err := messageAttributes(ctx, message)
+		if err != nil {
+			results[i] = failedPublishResult(fmt.Sprintf("invalid message attributes: %s", err.Error()))
+			continue
+		}
+
+		// entry ids are the index of the message in the request, so results can be returned in order
+		entries = append(entries, types.PublishBatchRequestEntry{
+			Id:                aws.String(strconv.Itoa(i)),
+			Message:           aws.String(base64.StdEncoding.EncodeToString(messageBytes)),
+			MessageAttributes: attrs,
+		})
+	}
+
+	for _, batch := range lo.Chunk(entries, maxPublishBatchSize) {
+		out, err := s.client.PublishBatch(ctx, &sns.PublishBatchInput{
+			TopicArn:                   aws.String(snsTopic.ARN),
+			PublishBatchRequestEntries: batch,
+		})
+		if err != nil {
+			if isSNSAccessDeniedErr(err) {
+				return nil, newErr(
+					codes.PermissionDenied,
+					"unable to publish to topic, this may be due to a missing permissions request in your code.",
+					err,
+				)
+			}
+
+			for _, entry := range batch {
+				i, _ := strconv.Atoi(aws.ToString(entry.Id))
+				results[i] = failedPublishResult(err.Error())
+			}
+
+			continue
+		}
+
+		for _, successful := range out.Successful {
+			i, _ := strconv.Atoi(aws.ToString(successful.Id))
+			results[i] = &topicpb.TopicPublishResult{
+				Result: &topicpb.TopicPublishResult_MessageId{
+					MessageId: aws.ToString(successful.MessageId),
+				},
+			}
+		}
+
+		for _, failed := range out.Failed {
+			i, _ := strconv.Atoi(aws.ToString(failed.Id))
+			results[i] = failedPublishResult(aws.ToString(failed.Message))
+		}
+	}
+
+	return &topicpb.TopicPublishBatchResponse{
+		Results: results,
+	}, nil
 }
 
 // Create new SNS event service plugin
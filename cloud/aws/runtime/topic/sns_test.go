@@ -166,8 +166,9 @@ var _ = Describe("Sns", func() {
 				}, nil)
 
 				input, _ := json.Marshal(map[string]interface{}{
-					"seconds": 1,
-					"message": stringData,
+					"seconds":    1,
+					"message":    stringData,
+					"attributes": map[string]types.MessageAttributeValue{},
 				})
 
 				By("Publishing the message to the topic")
@@ -15,8 +15,11 @@
 package main
 
 import (
+	"context"
+
 	"github.com/nitrictech/nitric/cloud/azure/runtime"
 	"github.com/nitrictech/nitric/cloud/azure/runtime/resource"
+	"github.com/nitrictech/nitric/cloud/common/runtime/telemetry"
 	"github.com/nitrictech/nitric/core/pkg/logger"
 	"github.com/nitrictech/nitric/core/pkg/server"
 )
@@ -27,6 +30,10 @@ func main() {
 		logger.Fatalf("could not create core azure resource resolver: %v", err)
 	}
 
+	if err := telemetry.Setup(context.Background()); err != nil {
+		logger.Fatalf("could not set up telemetry: %v", err)
+	}
+
 	m, err := runtime.NewAzureRuntimeServer(resourceResolver)
 	if err != nil {
 		logger.Fatalf("There was an error initializing the nitric server: %v", err)
@@ -19,6 +19,7 @@ import (
 	"strings"
 
 	"github.com/nitrictech/nitric/cloud/common/deploy/resources"
+	commonapi "github.com/nitrictech/nitric/cloud/common/deploy/resources/api"
 	"github.com/nitrictech/nitric/core/pkg/logger"
 	deploymentspb "github.com/nitrictech/nitric/core/pkg/proto/deployments/v1"
 
@@ -90,6 +91,9 @@ func (p *NitricAzurePulumiProvider) Api(ctx *pulumi.Context, parent pulumi.Resou
 		return nil
 	}
 
+	// wildcard routes are described with greedy path params, translate them to API Management's wildcard syntax
+	openapiDoc.Paths = commonapi.TranslateGreedyPathParams(openapiDoc.Paths, commonapi.AzureGreedyPathParam)
+
 	managedIdentities := p.ContainerEnv.ManagedUser.ID().ToStringOutput().ApplyT(func(id string) apimanagement.UserIdentityPropertiesMapOutput {
 		return apimanagement.UserIdentityPropertiesMap{
 			id: nil,
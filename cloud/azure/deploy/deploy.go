@@ -22,6 +22,7 @@ import (
 
 	_ "embed"
 
+	"github.com/nitrictech/nitric/cloud/azure/runtime/queue"
 	"github.com/nitrictech/nitric/cloud/common/deploy"
 	"github.com/nitrictech/nitric/cloud/common/deploy/provider"
 	"github.com/nitrictech/nitric/cloud/common/deploy/pulumix"
@@ -71,7 +72,10 @@ type NitricAzurePulumiProvider struct {
 	HttpProxies map[string]ApiResources
 	Buckets     map[string]*storage.BlobContainer
 
-	Queues map[string]*storage.Queue
+	Queues                  map[string]*storage.Queue
+	queueDeadLetterPolicies queue.DeadLetterPolicies
+	// Blob containers that Event Grid writes the undeliverable events of subscriptions to, by the name of their dead-letter queue
+	DeadLetterContainers map[string]*storage.BlobContainer
 
 	Principals map[resourcespb.ResourceType]map[string]*ServicePrincipal
 
@@ -387,7 +391,17 @@ func (a *NitricAzurePulumiProvider) Pre(ctx *pulumi.Context, nitricResources []*
 		}
 	}
 
-	a.ContainerEnv, err = a.newContainerEnv(ctx, a.StackId, map[string]string{})
+	a.queueDeadLetterPolicies, err = queueDeadLetterPolicies(nitricResources)
+	if err != nil {
+		return err
+	}
+
+	containerEnv, err := queueDeadLetterPoliciesEnv(a.queueDeadLetterPolicies)
+	if err != nil {
+		return err
+	}
+
+	a.ContainerEnv, err = a.newContainerEnv(ctx, a.StackId, containerEnv)
 	if err != nil {
 		return err
 	}
@@ -452,14 +466,15 @@ func NewNitricAzurePulumiProvider() *NitricAzurePulumiProvider {
 	principalsMap[resourcespb.ResourceType_Service] = map[string]*ServicePrincipal{}
 
 	return &NitricAzurePulumiProvider{
-		Apis:           make(map[string]ApiResources),
-		HttpProxies:    make(map[string]ApiResources),
-		Buckets:        make(map[string]*storage.BlobContainer),
-		Queues:         make(map[string]*storage.Queue),
-		ContainerApps:  make(map[string]*ContainerApp),
-		Topics:         make(map[string]*eventgrid.Topic),
-		SqlMigrations:  make(map[string]*containerinstance.ContainerGroup),
-		Principals:     principalsMap,
-		KeyValueStores: make(map[string]*storage.Table),
+		Apis:                 make(map[string]ApiResources),
+		HttpProxies:          make(map[string]ApiResources),
+		Buckets:              make(map[string]*storage.BlobContainer),
+		Queues:               make(map[string]*storage.Queue),
+		DeadLetterContainers: make(map[string]*storage.BlobContainer),
+		ContainerApps:        make(map[string]*ContainerApp),
+		Topics:               make(map[string]*eventgrid.Topic),
+		SqlMigrations:        make(map[string]*containerinstance.ContainerGroup),
+		Principals:           principalsMap,
+		KeyValueStores:       make(map[string]*storage.Table),
 	}
 }
@@ -15,7 +15,11 @@
 package deploy
 
 import (
+	"fmt"
+
 	deploymentspb "github.com/nitrictech/nitric/core/pkg/proto/deployments/v1"
+	resourcespb "github.com/nitrictech/nitric/core/pkg/proto/resources/v1"
+	"github.com/pulumi/pulumi-azure-native-sdk/authorization"
 	"github.com/pulumi/pulumi-azure-native-sdk/storage"
 	"github.com/pulumi/pulumi/sdk/v3/go/pulumi"
 )
@@ -29,6 +33,49 @@ func (p *NitricAzurePulumiProvider) KeyValueStore(ctx *pulumi.Context, parent pu
 		ResourceGroupName: p.ResourceGroup.Name,
 		TableName:         pulumi.String(name),
 	}, opts...)
+	if err != nil {
+		return err
+	}
+
+	// azure storage tables have no change feed, listening services poll the store's table for changes instead so they need to be able to read it
+	readRole, ok := p.Roles.RoleDefinitions[resourcespb.Action_KeyValueStoreRead]
+	if !ok {
+		return fmt.Errorf("key value store read role not found")
+	}
+
+	services := map[string]bool{}
+	for _, listener := range config.GetListeners() {
+		if services[listener.GetService()] {
+			continue
+		}
+		services[listener.GetService()] = true
+
+		sp, ok := p.Principals[resourcespb.ResourceType_Service][listener.GetService()]
+		if !ok {
+			return fmt.Errorf("principal %s of type %s not found", listener.GetService(), resourcespb.ResourceType_Service)
+		}
+
+		scope, err := p.scopeFromResource(&deploymentspb.Resource{
+			Id: &resourcespb.ResourceIdentifier{
+				Name: name,
+				Type: resourcespb.ResourceType_KeyValueStore,
+			},
+		})
+		if err != nil {
+			return err
+		}
+
+		_, err = authorization.NewRoleAssignment(ctx, fmt.Sprintf("%s-%s-listener", name, listener.GetService()), &authorization.RoleAssignmentArgs{
+			PrincipalId:      sp.ServicePrincipalId,
+			PrincipalType:    pulumi.String("ServicePrincipal"),
+			RoleDefinitionId: readRole.ID(),
+			Scope:            scope.scope,
+			Condition:        scope.condition,
+		}, opts...)
+		if err != nil {
+			return fmt.Errorf("there was an error creating the listener role assignment: %w", err)
+		}
+	}
 
-	return err
+	return nil
 }
@@ -23,6 +23,7 @@ import (
 
 	"github.com/pulumi/pulumi-azure-native-sdk/authorization"
 	"github.com/pulumi/pulumi/sdk/v3/go/pulumi"
+	"github.com/samber/lo"
 
 	deploymentspb "github.com/nitrictech/nitric/core/pkg/proto/deployments/v1"
 	resourcespb "github.com/nitrictech/nitric/core/pkg/proto/resources/v1"
@@ -177,8 +178,46 @@ func (p *NitricAzurePulumiProvider) Policy(ctx *pulumi.Context, parent pulumi.Re
 					return fmt.Errorf("there was an error creating the role assignment: %w", err)
 				}
 			}
+
+			if err := p.deadLetterRoleAssignment(ctx, resource, principal, sp, policy.Actions, opts...); err != nil {
+				return err
+			}
 		}
 	}
 
 	return nil
 }
+
+// deadLetterRoleAssignment - allow principals that dequeue from a queue to move messages to its dead-letter target queue
+func (p *NitricAzurePulumiProvider) deadLetterRoleAssignment(ctx *pulumi.Context, resource *deploymentspb.Resource, principal *deploymentspb.Resource, sp *ServicePrincipal, actions []resourcespb.Action, opts ...pulumi.ResourceOption) error {
+	if resource.Id.Type != resourcespb.ResourceType_Queue || !lo.Contains(actions, resourcespb.Action_QueueDequeue) {
+		return nil
+	}
+
+	deadLetterPolicy, ok := p.queueDeadLetterPolicies[resource.Id.Name]
+	if !ok {
+		return nil
+	}
+
+	scope, err := p.scopeFromResource(&deploymentspb.Resource{
+		Id: &resourcespb.ResourceIdentifier{
+			Name: deadLetterPolicy.TargetQueue,
+			Type: resourcespb.ResourceType_Queue,
+		},
+	})
+	if err != nil {
+		return err
+	}
+
+	_, err = authorization.NewRoleAssignment(ctx, fmt.Sprintf("%s-%s-deadletter", principal.Id.Name, resource.Id.Name), &authorization.RoleAssignmentArgs{
+		PrincipalId:      sp.ServicePrincipalId,
+		PrincipalType:    pulumi.String("ServicePrincipal"),
+		RoleDefinitionId: p.Roles.RoleDefinitions[resourcespb.Action_QueueEnqueue].ID(),
+		Scope:            scope.scope,
+	}, opts...)
+	if err != nil {
+		return fmt.Errorf("there was an error creating the dead-letter role assignment: %w", err)
+	}
+
+	return nil
+}
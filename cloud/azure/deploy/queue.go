@@ -1,21 +1,146 @@
 package deploy
 
 import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/nitrictech/nitric/cloud/azure/runtime/queue"
+	"github.com/nitrictech/nitric/cloud/common/deploy/pulumix"
 	deploymentspb "github.com/nitrictech/nitric/core/pkg/proto/deployments/v1"
+	resourcespb "github.com/nitrictech/nitric/core/pkg/proto/resources/v1"
+	"github.com/pulumi/pulumi-azure-native-sdk/authorization"
 	"github.com/pulumi/pulumi-azure-native-sdk/storage"
 	"github.com/pulumi/pulumi/sdk/v3/go/pulumi"
 )
 
+// queueDeadLetterPolicies - collect the dead-letter policies of the stack's queues.
+// Azure Storage Queues don't support dead-lettering, so the policies are applied by the runtime when messages are dequeued.
+func queueDeadLetterPolicies(nitricResources []*pulumix.NitricPulumiResource[any]) (queue.DeadLetterPolicies, error) {
+	policies := queue.DeadLetterPolicies{}
+	queues := map[string]bool{}
+
+	for _, res := range nitricResources {
+		if res.Id.GetType() == resourcespb.ResourceType_Queue {
+			queues[res.Id.Name] = true
+		}
+	}
+
+	for _, res := range nitricResources {
+		config, ok := res.Config.(*deploymentspb.Resource_Queue)
+		if !ok || config.Queue.GetDeadLetterPolicy() == nil {
+			continue
+		}
+
+		policy := config.Queue.DeadLetterPolicy
+
+		if policy.TargetQueue == res.Id.Name {
+			return nil, fmt.Errorf("queue %s can't be its own dead-letter target", res.Id.Name)
+		}
+
+		if !queues[policy.TargetQueue] {
+			return nil, fmt.Errorf("dead-letter target queue %s for queue %s not found", policy.TargetQueue, res.Id.Name)
+		}
+
+		if policy.MaxReceiveCount < 1 {
+			return nil, fmt.Errorf("max receive count for queue %s must be at least 1, got %d", res.Id.Name, policy.MaxReceiveCount)
+		}
+
+		policies[res.Id.Name] = queue.DeadLetterPolicy{
+			TargetQueue:     policy.TargetQueue,
+			MaxDequeueCount: int64(policy.MaxReceiveCount),
+		}
+	}
+
+	return policies, nil
+}
+
+// queueDeadLetterPoliciesEnv - the environment variables that provide the dead-letter policies to the runtime
+func queueDeadLetterPoliciesEnv(policies queue.DeadLetterPolicies) (map[string]string, error) {
+	if len(policies) == 0 {
+		return map[string]string{}, nil
+	}
+
+	policiesJson, err := json.Marshal(policies)
+	if err != nil {
+		return nil, err
+	}
+
+	return map[string]string{
+		"AZURE_QUEUE_DEAD_LETTER_POLICIES": string(policiesJson),
+	}, nil
+}
+
 func (a *NitricAzurePulumiProvider) Queue(ctx *pulumi.Context, parent pulumi.Resource, name string, config *deploymentspb.Queue) error {
 	var err error
 	opts := []pulumi.ResourceOption{pulumi.Parent(parent)}
 
+	// Storage Queues don't guarantee ordering or deduplicate messages
+	if config.Fifo {
+		return fmt.Errorf("queue %s is FIFO, FIFO queues are not supported by the azure provider", name)
+	}
+
 	a.Queues[name], err = storage.NewQueue(ctx, ResourceName(ctx, name, StorageQueueRT), &storage.QueueArgs{
 		AccountName:       a.StorageAccount.Name,
 		ResourceGroupName: a.ResourceGroup.Name,
 	}, opts...)
+	if err != nil {
+		return err
+	}
+
+	// storage queues can't push messages, listening services poll the queue instead so they need to be able to dequeue from it
+	dequeueRole, ok := a.Roles.RoleDefinitions[resourcespb.Action_QueueDequeue]
+	if !ok {
+		return fmt.Errorf("queue dequeue role not found")
+	}
+
+	services := map[string]bool{}
+	for _, listener := range config.GetListeners() {
+		if services[listener.GetService()] {
+			return fmt.Errorf("service %s has more than one listener on queue %s", listener.GetService(), name)
+		}
+		services[listener.GetService()] = true
+
+		sp, ok := a.Principals[resourcespb.ResourceType_Service][listener.GetService()]
+		if !ok {
+			return fmt.Errorf("principal %s of type %s not found", listener.GetService(), resourcespb.ResourceType_Service)
+		}
+
+		queueResource := &deploymentspb.Resource{
+			Id: &resourcespb.ResourceIdentifier{
+				Name: name,
+				Type: resourcespb.ResourceType_Queue,
+			},
+		}
+
+		scope, err := a.scopeFromResource(queueResource)
+		if err != nil {
+			return err
+		}
+
+		_, err = authorization.NewRoleAssignment(ctx, fmt.Sprintf("%s-%s-listener", name, listener.GetService()), &authorization.RoleAssignmentArgs{
+			PrincipalId:      sp.ServicePrincipalId,
+			PrincipalType:    pulumi.String("ServicePrincipal"),
+			RoleDefinitionId: dequeueRole.ID(),
+			Scope:            scope.scope,
+		}, opts...)
+		if err != nil {
+			return fmt.Errorf("there was an error creating the listener role assignment: %w", err)
+		}
+
+		servicePrincipal := &deploymentspb.Resource{
+			Id: &resourcespb.ResourceIdentifier{
+				Name: listener.GetService(),
+				Type: resourcespb.ResourceType_Service,
+			},
+		}
+
+		err = a.deadLetterRoleAssignment(ctx, queueResource, servicePrincipal, sp, []resourcespb.Action{resourcespb.Action_QueueDequeue}, opts...)
+		if err != nil {
+			return err
+		}
+	}
 
-	return err
+	return nil
 }
 
 // Copyright 2021 Nitric Technologies Pty Ltd.
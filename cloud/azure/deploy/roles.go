@@ -84,7 +84,8 @@ var roleDefinitions = map[resourcespb.Action]RoleDefinition{
 				DataActions: pulumi.StringArray{
 					pulumi.String("Microsoft.Storage/storageAccounts/queueServices/queues/messages/read"),
 					pulumi.String("Microsoft.Storage/storageAccounts/queueServices/queues/messages/delete"),
-					// pulumi.String("Microsoft.Storage/storageAccounts/queueServices/queues/messages/update"),
+					// updating a message's visibility is required to extend or release its lease
+					pulumi.String("Microsoft.Storage/storageAccounts/queueServices/queues/messages/write"),
 				},
 				NotActions: pulumi.StringArray{},
 			},
@@ -211,6 +212,7 @@ var roleDefinitions = map[resourcespb.Action]RoleDefinition{
 				Actions: pulumi.StringArray{},
 				DataActions: pulumi.StringArray{
 					pulumi.String("Microsoft.KeyVault/vaults/secrets/getSecret/action"),
+					pulumi.String("Microsoft.KeyVault/vaults/secrets/readMetadata/action"),
 				},
 				NotActions: pulumi.StringArray{},
 			},
@@ -228,6 +230,8 @@ var roleDefinitions = map[resourcespb.Action]RoleDefinition{
 				},
 				DataActions: pulumi.StringArray{
 					pulumi.String("Microsoft.KeyVault/vaults/secrets/setSecret/action"),
+					pulumi.String("Microsoft.KeyVault/vaults/secrets/readMetadata/action"),
+					pulumi.String("Microsoft.KeyVault/vaults/secrets/update/action"),
 				},
 				NotActions: pulumi.StringArray{},
 			},
@@ -291,10 +291,15 @@ func (p *NitricAzurePulumiProvider) Service(ctx *pulumi.Context, parent pulumi.R
 
 	//	If this instance contains a schedule set the minimum instances to 1
 	// schedules rely on the Dapr Runtime to trigger the function, without a running instance the Dapr Runtime will not execute, so the schedule won't trigger.
+	// queue listeners are polled by the membrane, so they also need a running instance.
 	_, schedulesFound := lo.Find(p.resources, func(item *pulumix.NitricPulumiResource[any]) bool {
 		switch t := item.Config.(type) {
 		case *deploymentspb.Schedule:
 			return t.Target.GetService() == name
+		case *deploymentspb.Resource_Queue:
+			return lo.ContainsBy(t.Queue.GetListeners(), func(listener *deploymentspb.QueueListener) bool {
+				return listener.GetService() == name
+			})
 		}
 
 		return false
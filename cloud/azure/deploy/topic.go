@@ -16,12 +16,16 @@ package deploy
 
 import (
 	"fmt"
+	"strings"
 
 	nitricresources "github.com/nitrictech/nitric/cloud/common/deploy/resources"
 	"github.com/nitrictech/nitric/cloud/common/deploy/tags"
 	deploymentspb "github.com/nitrictech/nitric/core/pkg/proto/deployments/v1"
+	topicspb "github.com/nitrictech/nitric/core/pkg/proto/topics/v1"
+	"github.com/nitrictech/nitric/core/pkg/workers/topics"
 	"github.com/pulumi/pulumi-azure-native-sdk/eventgrid"
 	"github.com/pulumi/pulumi-azure-native-sdk/resources"
+	"github.com/pulumi/pulumi-azure-native-sdk/storage"
 	pulumiEventgrid "github.com/pulumi/pulumi-azure/sdk/v4/go/azure/eventgrid"
 	"github.com/pulumi/pulumi/sdk/v3/go/pulumi"
 )
@@ -40,6 +44,144 @@ type AzureEventGridTopicArgs struct {
 	ResourceGroup *resources.ResourceGroup
 }
 
+// Event Grid subscriptions support at most 25 advanced filters, with at most 25 values across all of them
+const (
+	maxAdvancedFilters      = 25
+	maxAdvancedFilterValues = 25
+)
+
+// eventGridAdvancedFilter - converts a nitric attribute filter to an Event Grid advanced filter, returns nil if the filter has no conditions
+//
+//	Event Grid compares strings case-insensitively, the membrane filters messages again so matching is consistent with other providers.
+func eventGridAdvancedFilter(filter *topicspb.AttributeFilter) (*pulumiEventgrid.EventSubscriptionAdvancedFilterArgs, error) {
+	if err := topics.ValidateFilter(filter); err != nil {
+		return nil, err
+	}
+
+	if len(filter.GetConditions()) == 0 {
+		return nil, nil
+	}
+
+	advancedFilter := &pulumiEventgrid.EventSubscriptionAdvancedFilterArgs{}
+	stringIns := pulumiEventgrid.EventSubscriptionAdvancedFilterStringInArray{}
+	stringNotIns := pulumiEventgrid.EventSubscriptionAdvancedFilterStringNotInArray{}
+	stringBeginsWiths := pulumiEventgrid.EventSubscriptionAdvancedFilterStringBeginsWithArray{}
+	isNotNulls := pulumiEventgrid.EventSubscriptionAdvancedFilterIsNotNullArray{}
+	isNullOrUndefineds := pulumiEventgrid.EventSubscriptionAdvancedFilterIsNullOrUndefinedArray{}
+	filterCount := 0
+	valueCount := 0
+
+	for _, condition := range filter.GetConditions() {
+		// keys are paths into the event data, where the message attributes are published
+		if strings.Contains(condition.GetKey(), ".") {
+			return nil, fmt.Errorf("attribute %s can't be filtered on azure, filtered attribute keys must not contain '.'", condition.GetKey())
+		}
+		key := pulumi.Sprintf("data.attributes.%s", condition.GetKey())
+
+		switch match := condition.GetMatch().(type) {
+		case *topicspb.AttributeCondition_AnyOf:
+			stringIns = append(stringIns, pulumiEventgrid.EventSubscriptionAdvancedFilterStringInArgs{
+				Key:    key,
+				Values: pulumi.ToStringArray(match.AnyOf.GetValues()),
+			})
+			filterCount += 1
+			valueCount += len(match.AnyOf.GetValues())
+		case *topicspb.AttributeCondition_NoneOf:
+			// messages without the attribute would otherwise match
+			isNotNulls = append(isNotNulls, pulumiEventgrid.EventSubscriptionAdvancedFilterIsNotNullArgs{
+				Key: key,
+			})
+			stringNotIns = append(stringNotIns, pulumiEventgrid.EventSubscriptionAdvancedFilterStringNotInArgs{
+				Key:    key,
+				Values: pulumi.ToStringArray(match.NoneOf.GetValues()),
+			})
+			filterCount += 2
+			valueCount += len(match.NoneOf.GetValues())
+		case *topicspb.AttributeCondition_Prefix:
+			stringBeginsWiths = append(stringBeginsWiths, pulumiEventgrid.EventSubscriptionAdvancedFilterStringBeginsWithArgs{
+				Key:    key,
+				Values: pulumi.ToStringArray([]string{match.Prefix}),
+			})
+			filterCount += 1
+			valueCount += 1
+		case *topicspb.AttributeCondition_Exists:
+			if match.Exists {
+				isNotNulls = append(isNotNulls, pulumiEventgrid.EventSubscriptionAdvancedFilterIsNotNullArgs{
+					Key: key,
+				})
+			} else {
+				isNullOrUndefineds = append(isNullOrUndefineds, pulumiEventgrid.EventSubscriptionAdvancedFilterIsNullOrUndefinedArgs{
+					Key: key,
+				})
+			}
+			filterCount += 1
+		}
+	}
+
+	if filterCount > maxAdvancedFilters || valueCount > maxAdvancedFilterValues {
+		return nil, fmt.Errorf("filters are limited to %d conditions and %d values on azure", maxAdvancedFilters, maxAdvancedFilterValues)
+	}
+
+	if len(stringIns) > 0 {
+		advancedFilter.StringIns = stringIns
+	}
+	if len(stringNotIns) > 0 {
+		advancedFilter.StringNotIns = stringNotIns
+	}
+	if len(stringBeginsWiths) > 0 {
+		advancedFilter.StringBeginsWiths = stringBeginsWiths
+	}
+	if len(isNotNulls) > 0 {
+		advancedFilter.IsNotNulls = isNotNulls
+	}
+	if len(isNullOrUndefineds) > 0 {
+		advancedFilter.IsNullOrUndefineds = isNullOrUndefineds
+	}
+
+	return advancedFilter, nil
+}
+
+// Event Grid delivers an event at most 30 times
+const maxEventDeliveryAttempts = 30
+
+// validateRetryPolicy - returns an error if the retry policy of a subscription can't be applied on azure,
+// Event Grid retries with its own backoff schedule so backoff bounds aren't applied
+func validateRetryPolicy(policy *topicspb.SubscriptionRetryPolicy) error {
+	if err := topics.ValidateRetryPolicy(policy); err != nil {
+		return err
+	}
+
+	if policy.GetMaxAttempts() > maxEventDeliveryAttempts {
+		return fmt.Errorf("max attempts must be at most %d on azure, got %d", maxEventDeliveryAttempts, policy.GetMaxAttempts())
+	}
+
+	return nil
+}
+
+// deadLetterContainer - returns the blob container that Event Grid writes undeliverable events to for subscriptions dead-lettering to the queue,
+// Event Grid can only dead-letter events to blob storage, so they aren't added to the queue itself
+func (p *NitricAzurePulumiProvider) deadLetterContainer(ctx *pulumi.Context, parent pulumi.Resource, queueName string) (*storage.BlobContainer, error) {
+	if container, ok := p.DeadLetterContainers[queueName]; ok {
+		return container, nil
+	}
+
+	if _, ok := p.Queues[queueName]; !ok {
+		return nil, fmt.Errorf("dead-letter queue %s not found", queueName)
+	}
+
+	container, err := storage.NewBlobContainer(ctx, ResourceName(ctx, queueName+"-deadletter", StorageContainerRT), &storage.BlobContainerArgs{
+		ResourceGroupName: p.ResourceGroup.Name,
+		AccountName:       p.StorageAccount.Name,
+	}, pulumi.Parent(parent))
+	if err != nil {
+		return nil, err
+	}
+
+	p.DeadLetterContainers[queueName] = container
+
+	return container, nil
+}
+
 func (p *NitricAzurePulumiProvider) newEventGridTopicSubscription(ctx *pulumi.Context, parent pulumi.Resource, topicName string, config *deploymentspb.SubscriptionTarget) error {
 	opts := []pulumi.ResourceOption{pulumi.Parent(parent)}
 
@@ -60,7 +202,22 @@ func (p *NitricAzurePulumiProvider) newEventGridTopicSubscription(ctx *pulumi.Co
 
 	subName := topicName + "-" + config.GetService()
 
-	_, err = pulumiEventgrid.NewEventSubscription(ctx, ResourceName(ctx, subName, EventSubscriptionRT), &pulumiEventgrid.EventSubscriptionArgs{
+	advancedFilter, err := eventGridAdvancedFilter(config.GetFilter())
+	if err != nil {
+		return fmt.Errorf("invalid filter for subscription of service %s to topic %s: %w", config.GetService(), topicName, err)
+	}
+
+	retryPolicy := config.GetRetryPolicy()
+	if err := validateRetryPolicy(retryPolicy); err != nil {
+		return fmt.Errorf("invalid retry policy for subscription of service %s to topic %s: %w", config.GetService(), topicName, err)
+	}
+
+	maxDeliveryAttempts := int32(maxEventDeliveryAttempts)
+	if retryPolicy.GetMaxAttempts() > 0 {
+		maxDeliveryAttempts = retryPolicy.GetMaxAttempts()
+	}
+
+	subscriptionArgs := &pulumiEventgrid.EventSubscriptionArgs{
 		Scope: topic.ID(),
 		WebhookEndpoint: pulumiEventgrid.EventSubscriptionWebhookEndpointArgs{
 			Url: pulumi.Sprintf("%s/%s/x-nitric-topic/%s", hostUrl, target.EventToken, topicName),
@@ -70,10 +227,28 @@ func (p *NitricAzurePulumiProvider) newEventGridTopicSubscription(ctx *pulumi.Co
 			ActiveDirectoryTenantId:   target.Sp.TenantID,
 		},
 		RetryPolicy: pulumiEventgrid.EventSubscriptionRetryPolicyArgs{
-			MaxDeliveryAttempts: pulumi.Int(30),
+			MaxDeliveryAttempts: pulumi.Int(int(maxDeliveryAttempts)),
 			EventTimeToLive:     pulumi.Int(5),
 		},
-	}, opts...)
+	}
+
+	if advancedFilter != nil {
+		subscriptionArgs.AdvancedFilter = advancedFilter
+	}
+
+	if deadLetterQueue := retryPolicy.GetDeadLetterQueue(); deadLetterQueue != "" {
+		container, err := p.deadLetterContainer(ctx, parent, deadLetterQueue)
+		if err != nil {
+			return fmt.Errorf("invalid retry policy for subscription of service %s to topic %s: %w", config.GetService(), topicName, err)
+		}
+
+		subscriptionArgs.StorageBlobDeadLetterDestination = pulumiEventgrid.EventSubscriptionStorageBlobDeadLetterDestinationArgs{
+			StorageAccountId:         p.StorageAccount.ID(),
+			StorageBlobContainerName: container.Name,
+		}
+	}
+
+	_, err = pulumiEventgrid.NewEventSubscription(ctx, ResourceName(ctx, subName, EventSubscriptionRT), subscriptionArgs, opts...)
 
 	return err
 }
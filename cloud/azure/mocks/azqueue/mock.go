@@ -243,3 +243,18 @@ func (mr *MockAzqueueMessageIdUrlIfaceMockRecorder) Delete(arg0, arg1 interface{
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockAzqueueMessageIdUrlIface)(nil).Delete), arg0, arg1)
 }
+
+// Update mocks base method.
+func (m *MockAzqueueMessageIdUrlIface) Update(arg0 context.Context, arg1 azqueue.PopReceipt, arg2 time.Duration, arg3 string) (*azqueue.UpdatedMessageResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Update", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].(*azqueue.UpdatedMessageResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Update indicates an expected call of Update.
+func (mr *MockAzqueueMessageIdUrlIfaceMockRecorder) Update(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockAzqueueMessageIdUrlIface)(nil).Update), arg0, arg1, arg2, arg3)
+}
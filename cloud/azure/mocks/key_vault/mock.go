@@ -50,6 +50,21 @@ func (mr *MockKeyVaultClientMockRecorder) GetSecret(arg0, arg1, arg2, arg3 inter
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSecret", reflect.TypeOf((*MockKeyVaultClient)(nil).GetSecret), arg0, arg1, arg2, arg3)
 }
 
+// GetSecretVersions mocks base method.
+func (m *MockKeyVaultClient) GetSecretVersions(arg0 context.Context, arg1, arg2 string, arg3 *int32) (keyvault.SecretListResultPage, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetSecretVersions", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].(keyvault.SecretListResultPage)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetSecretVersions indicates an expected call of GetSecretVersions.
+func (mr *MockKeyVaultClientMockRecorder) GetSecretVersions(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSecretVersions", reflect.TypeOf((*MockKeyVaultClient)(nil).GetSecretVersions), arg0, arg1, arg2, arg3)
+}
+
 // SetSecret mocks base method.
 func (m *MockKeyVaultClient) SetSecret(arg0 context.Context, arg1, arg2 string, arg3 keyvault.SecretSetParameters) (keyvault.SecretBundle, error) {
 	m.ctrl.T.Helper()
@@ -64,3 +79,18 @@ func (mr *MockKeyVaultClientMockRecorder) SetSecret(arg0, arg1, arg2, arg3 inter
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetSecret", reflect.TypeOf((*MockKeyVaultClient)(nil).SetSecret), arg0, arg1, arg2, arg3)
 }
+
+// UpdateSecret mocks base method.
+func (m *MockKeyVaultClient) UpdateSecret(arg0 context.Context, arg1, arg2, arg3 string, arg4 keyvault.SecretUpdateParameters) (keyvault.SecretBundle, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateSecret", arg0, arg1, arg2, arg3, arg4)
+	ret0, _ := ret[0].(keyvault.SecretBundle)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateSecret indicates an expected call of UpdateSecret.
+func (mr *MockKeyVaultClientMockRecorder) UpdateSecret(arg0, arg1, arg2, arg3, arg4 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateSecret", reflect.TypeOf((*MockKeyVaultClient)(nil).UpdateSecret), arg0, arg1, arg2, arg3, arg4)
+}
@@ -31,6 +31,11 @@ var (
 	AZURE_STORAGE_QUEUE_ENDPOINT = env.GetEnv("AZURE_STORAGE_ACCOUNT_QUEUE_ENDPOINT", "")
 )
 
+var AZURE_QUEUE_DEAD_LETTER_POLICIES = env.GetEnv("AZURE_QUEUE_DEAD_LETTER_POLICIES", "")
+
+// AZURE_KEYVALUE_POLL_INTERVAL is how often, in seconds, key value stores with listeners are checked for changes
+var AZURE_KEYVALUE_POLL_INTERVAL = env.GetEnv("AZURE_KEYVALUE_POLL_INTERVAL", "10")
+
 // mongoDBConnectionString := utils.GetEnv(mongoDBConnectionStringEnvVarName, "")
 
 // 	if mongoDBConnectionString == "" {
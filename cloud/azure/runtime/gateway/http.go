@@ -15,26 +15,31 @@
 package http_service
 
 import (
-	"encoding/base64"
+	"bytes"
 	"encoding/json"
 	"errors"
 	"fmt"
 	"os"
+	"strconv"
 	"strings"
 
 	"github.com/Azure/azure-sdk-for-go/profiles/latest/eventgrid/eventgrid"
 	"github.com/fasthttp/router"
 	"github.com/mitchellh/mapstructure"
 	"github.com/valyala/fasthttp"
+	"go.opentelemetry.io/otel/propagation"
 	"google.golang.org/protobuf/proto"
 
 	"github.com/nitrictech/nitric/cloud/azure/runtime/resource"
+	"github.com/nitrictech/nitric/cloud/azure/runtime/topic"
 	base_http "github.com/nitrictech/nitric/cloud/common/runtime/gateway"
+	"github.com/nitrictech/nitric/cloud/common/runtime/messages"
 	"github.com/nitrictech/nitric/core/pkg/gateway"
 	"github.com/nitrictech/nitric/core/pkg/logger"
 	schedulespb "github.com/nitrictech/nitric/core/pkg/proto/schedules/v1"
 	storagepb "github.com/nitrictech/nitric/core/pkg/proto/storage/v1"
 	topicspb "github.com/nitrictech/nitric/core/pkg/proto/topics/v1"
+	"github.com/nitrictech/nitric/core/pkg/workers"
 )
 
 type azMiddleware struct {
@@ -43,36 +48,73 @@ type azMiddleware struct {
 
 func extractEvents(ctx *fasthttp.RequestCtx) ([]eventgrid.Event, error) {
 	var eventgridEvents []eventgrid.Event
-	bytes := ctx.Request.Body()
-	if err := json.Unmarshal(bytes, &eventgridEvents); err != nil {
+
+	// numbers are decoded as json.Number, so event data is re-encoded without losing precision
+	decoder := json.NewDecoder(bytes.NewReader(ctx.Request.Body()))
+	decoder.UseNumber()
+	if err := decoder.Decode(&eventgridEvents); err != nil {
 		return nil, errors.New("invalid event grid types")
 	}
 
 	return eventgridEvents, nil
 }
 
-func extractMessage(event eventgrid.Event) (*topicspb.TopicMessage, error) {
-	var payloadBytes []byte
-	var err error
-	if stringData, ok := event.Data.(string); ok {
-		// byte payloads are automatically base64 encoded when publishing to event grid
-		payloadBytes, err = base64.StdEncoding.DecodeString(stringData)
+// extractMessage returns the topic message of an event and the trace context it was published with, if any,
+// events published to the topic by other producers are delivered as bytes payloads
+func extractMessage(event eventgrid.Event) (*topicspb.TopicMessage, map[string]string, error) {
+	if event.DataVersion != nil && *event.DataVersion == topic.EventGridDataVersion {
+		// the data has been decoded as generic JSON, so it's re-encoded to decode it as a message
+		dataBytes, err := json.Marshal(event.Data)
 		if err != nil {
-			return nil, err
+			return nil, nil, err
+		}
+
+		var eventGridMessage topic.EventGridMessage
+		if err := json.Unmarshal(dataBytes, &eventGridMessage); err != nil {
+			return nil, nil, fmt.Errorf("invalid event data: %w", err)
+		}
+
+		var message topicspb.TopicMessage
+		if err := proto.Unmarshal(eventGridMessage.Payload, &message); err != nil {
+			return nil, nil, err
 		}
-	} else if byteData, ok := event.Data.([]byte); ok {
-		payloadBytes = byteData
-	} else {
-		return nil, fmt.Errorf("invalid event data type: %T", event.Data)
+
+		return &message, eventGridMessage.TraceContext, nil
 	}
 
-	var message topicspb.TopicMessage
+	switch data := event.Data.(type) {
+	case string:
+		// byte payloads are automatically base64 encoded when publishing to event grid
+		return messages.DecodeBase64TopicMessage(data, nil), nil, nil
+	case []byte:
+		return messages.DecodeTopicMessage(data, nil), nil, nil
+	default:
+		// the data has been decoded as generic JSON, so it's re-encoded to deliver it unchanged
+		dataBytes, err := json.Marshal(event.Data)
+		if err != nil {
+			return nil, nil, fmt.Errorf("invalid event data: %w", err)
+		}
+
+		return &topicspb.TopicMessage{
+			Content: &topicspb.TopicMessage_BytesPayload{
+				BytesPayload: &topicspb.BytesPayload{
+					Data:        dataBytes,
+					ContentType: "application/json",
+				},
+			},
+		}, nil, nil
+	}
+}
 
-	if err = proto.Unmarshal(payloadBytes, &message); err != nil {
-		return nil, err
+// deliveryAttempt returns the number of times event grid has delivered the event, including this delivery, or 0 if it isn't known
+func deliveryAttempt(ctx *fasthttp.RequestCtx) int32 {
+	// aeg-delivery-count is the number of previous delivery attempts
+	deliveryCount, err := strconv.Atoi(string(ctx.Request.Header.Peek("aeg-delivery-count")))
+	if err != nil {
+		return 0
 	}
 
-	return &message, nil
+	return int32(deliveryCount) + 1
 }
 
 func (a *azMiddleware) handleSubscriptionValidation(ctx *fasthttp.RequestCtx, events []eventgrid.Event) {
@@ -93,6 +135,9 @@ func (a *azMiddleware) handleSubscriptionValidation(ctx *fasthttp.RequestCtx, ev
 
 func (a *azMiddleware) handleSubscription(opts *gateway.GatewayStartOpts) fasthttp.RequestHandler {
 	return func(ctx *fasthttp.RequestCtx) {
+		reqCtx, cancel := base_http.RequestContext()
+		defer cancel()
+
 		if strings.ToUpper(string(ctx.Request.Header.Method())) == "OPTIONS" {
 			ctx.SuccessString("text/plain", "success")
 			return
@@ -112,7 +157,7 @@ func (a *azMiddleware) handleSubscription(opts *gateway.GatewayStartOpts) fastht
 				return
 			}
 
-			message, err := extractMessage(event)
+			message, traceContext, err := extractMessage(event)
 			if err != nil {
 				fmt.Println("error extracting message", err)
 				ctx.Error(err.Error(), 500)
@@ -124,13 +169,17 @@ func (a *azMiddleware) handleSubscription(opts *gateway.GatewayStartOpts) fastht
 			evt := &topicspb.ServerMessage{
 				Content: &topicspb.ServerMessage_MessageRequest{
 					MessageRequest: &topicspb.MessageRequest{
-						TopicName: topicName,
-						Message:   message,
+						TopicName:       topicName,
+						Message:         message,
+						DeliveryAttempt: deliveryAttempt(ctx),
 					},
 				},
 			}
 
-			resp, err := opts.TopicsListenerPlugin.HandleRequest(evt)
+			// continue the publisher's trace
+			eventCtx := workers.ExtractTraceContext(reqCtx, propagation.MapCarrier(traceContext))
+
+			resp, err := opts.TopicsListenerPlugin.HandleRequest(eventCtx, evt)
 			if err != nil {
 				logger.Errorf("error handling event from topic %s: %s", topicName, err.Error())
 				ctx.Error("failed handling event, error returned from subscriber function", 500)
@@ -149,6 +198,9 @@ func (a *azMiddleware) handleSubscription(opts *gateway.GatewayStartOpts) fastht
 
 func (a *azMiddleware) handleSchedule(opts *gateway.GatewayStartOpts) fasthttp.RequestHandler {
 	return func(ctx *fasthttp.RequestCtx) {
+		reqCtx, cancel := base_http.RequestContext()
+		defer cancel()
+
 		if strings.ToUpper(string(ctx.Request.Header.Method())) == "OPTIONS" {
 			ctx.SuccessString("text/plain", "success")
 			return
@@ -164,7 +216,7 @@ func (a *azMiddleware) handleSchedule(opts *gateway.GatewayStartOpts) fasthttp.R
 			},
 		}
 
-		_, err := opts.SchedulesPlugin.HandleRequest(evt)
+		_, err := opts.SchedulesPlugin.HandleRequest(reqCtx, evt)
 		if err != nil {
 			ctx.Error(fmt.Sprintf("failed handling schedule %s", scheduleName), 500)
 		}
@@ -187,6 +239,9 @@ func notificationEventToEventType(eventType *string) (*storagepb.BlobEventType,
 
 func (a *azMiddleware) handleBucketNotification(opts *gateway.GatewayStartOpts) fasthttp.RequestHandler {
 	return func(ctx *fasthttp.RequestCtx) {
+		reqCtx, cancel := base_http.RequestContext()
+		defer cancel()
+
 		logger.Debug("handling a bucket notification")
 		if strings.ToUpper(string(ctx.Request.Header.Method())) == "OPTIONS" {
 			ctx.SuccessString("text/plain", "success")
@@ -249,7 +304,7 @@ func (a *azMiddleware) handleBucketNotification(opts *gateway.GatewayStartOpts)
 				},
 			}
 
-			resp, err := opts.StorageListenerPlugin.HandleRequest(evt)
+			resp, err := opts.StorageListenerPlugin.HandleRequest(reqCtx, evt)
 			if err != nil {
 				logger.Errorf("error handling event: %s", err)
 				ctx.Error("error handling event", 500)
@@ -16,6 +16,7 @@ package http_service
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
 	"fmt"
 	"io"
@@ -25,6 +26,7 @@ import (
 
 	"github.com/Azure/azure-sdk-for-go/profiles/latest/eventgrid/eventgrid"
 	"github.com/golang/mock/gomock"
+	"go.opentelemetry.io/otel/trace"
 	"google.golang.org/protobuf/proto"
 	"google.golang.org/protobuf/types/known/structpb"
 
@@ -33,6 +35,7 @@ import (
 
 	mock_provider "github.com/nitrictech/nitric/cloud/azure/mocks/provider"
 	"github.com/nitrictech/nitric/cloud/azure/runtime/resource"
+	"github.com/nitrictech/nitric/cloud/azure/runtime/topic"
 	mock_apis "github.com/nitrictech/nitric/core/mocks/workers/apis"
 	mock_http "github.com/nitrictech/nitric/core/mocks/workers/http"
 	mock_topics "github.com/nitrictech/nitric/core/mocks/workers/topics"
@@ -112,16 +115,11 @@ var _ = Describe("Http", func() {
 					},
 				}
 
-				mockManager.EXPECT().HandleRequest("test", test.ProtoEq(mockRequest)).Return(&apispb.ClientMessage{
-					Id: "TODO",
-					Content: &apispb.ClientMessage_HttpResponse{
-						HttpResponse: &apispb.HttpResponse{
-							Status:  200,
-							Body:    []byte("Test"),
-							Headers: map[string]*apispb.HeaderValue{},
-						},
-					},
-				}, nil)
+				mockManager.EXPECT().HandleStreamingRequest(gomock.Any(), "test", test.ProtoEq(mockRequest), gomock.Any()).Return(&apispb.HttpResponse{
+					Status:  200,
+					Body:    []byte("Test"),
+					Headers: map[string]*apispb.HeaderValue{},
+				}, io.NopCloser(bytes.NewReader(nil)), nil)
 
 				By("Generating a HTTP trigger")
 				request, _ := http.NewRequest("POST", fmt.Sprintf("%s/x-nitric-api/test/test/", gatewayUrl), bytes.NewReader(payload[:]))
@@ -206,14 +204,15 @@ var _ = Describe("Http", func() {
 				mockRequest := &topicspb.ServerMessage{
 					Content: &topicspb.ServerMessage_MessageRequest{
 						MessageRequest: &topicspb.MessageRequest{
-							TopicName: testTopic,
-							Message:   messagePayload,
+							TopicName:       testTopic,
+							Message:         messagePayload,
+							DeliveryAttempt: 2,
 						},
 					},
 				}
 
 				By("Handling exactly 1 request")
-				mockManager.EXPECT().HandleRequest(test.ProtoEq(mockRequest)).Return(&topicspb.ClientMessage{
+				mockManager.EXPECT().HandleRequest(gomock.Any(), test.ProtoEq(mockRequest)).Return(&topicspb.ClientMessage{
 					Content: &topicspb.ClientMessage_MessageResponse{
 						MessageResponse: &topicspb.MessageResponse{
 							Success: true,
@@ -222,11 +221,15 @@ var _ = Describe("Http", func() {
 				}, nil)
 
 				testID := "1234"
+				dataVersion := topic.EventGridDataVersion
 				evt := []eventgrid.Event{
 					{
-						ID:    &testID,
-						Topic: &testTopic,
-						Data:  messagePayloadBytes,
+						ID:          &testID,
+						Topic:       &testTopic,
+						DataVersion: &dataVersion,
+						Data: topic.EventGridMessage{
+							Payload: messagePayloadBytes,
+						},
 					},
 				}
 
@@ -235,8 +238,99 @@ var _ = Describe("Http", func() {
 				request, err := http.NewRequest("POST", fmt.Sprintf("%s/%s/x-nitric-topic/test", gatewayUrl, testEvtToken), bytes.NewReader(requestBody))
 				Expect(err).To(BeNil())
 				request.Header.Add("aeg-event-type", "Notification")
+				request.Header.Add("aeg-delivery-count", "1")
 				_, _ = http.DefaultClient.Do(request)
 			})
+
+			It("Should continue the publisher's trace", func() {
+				structPayload, _ := structpb.NewStruct(map[string]interface{}{
+					"testing": "test",
+				})
+
+				messagePayloadBytes, _ := proto.Marshal(&topicspb.TopicMessage{
+					Content: &topicspb.TopicMessage_StructPayload{
+						StructPayload: structPayload,
+					},
+				})
+
+				traceId := "4bf92f3577b34da6a3ce929d0e0e4736"
+				var capturedTraceId string
+
+				By("Handling exactly 1 request")
+				mockManager.EXPECT().HandleRequest(gomock.Any(), gomock.Any()).Times(1).DoAndReturn(func(ctx context.Context, request *topicspb.ServerMessage) (*topicspb.ClientMessage, error) {
+					capturedTraceId = trace.SpanContextFromContext(ctx).TraceID().String()
+
+					return &topicspb.ClientMessage{
+						Content: &topicspb.ClientMessage_MessageResponse{
+							MessageResponse: &topicspb.MessageResponse{
+								Success: true,
+							},
+						},
+					}, nil
+				})
+
+				testID := "5678"
+				testTopic := "test"
+				dataVersion := topic.EventGridDataVersion
+				evt := []eventgrid.Event{
+					{
+						ID:          &testID,
+						Topic:       &testTopic,
+						DataVersion: &dataVersion,
+						Data: topic.EventGridMessage{
+							Payload: messagePayloadBytes,
+							TraceContext: map[string]string{
+								"traceparent": fmt.Sprintf("00-%s-00f067aa0ba902b7-01", traceId),
+							},
+						},
+					},
+				}
+
+				requestBody, err := json.Marshal(evt)
+				Expect(err).To(BeNil())
+				request, err := http.NewRequest("POST", fmt.Sprintf("%s/%s/x-nitric-topic/test", gatewayUrl, testEvtToken), bytes.NewReader(requestBody))
+				Expect(err).To(BeNil())
+				request.Header.Add("aeg-event-type", "Notification")
+				resp, err := http.DefaultClient.Do(request)
+
+				By("Not returning an error")
+				Expect(err).To(BeNil())
+				Expect(resp.StatusCode).To(Equal(200))
+
+				By("Passing the publisher's trace context to the subscribers")
+				Expect(capturedTraceId).To(Equal(traceId))
+			})
+
+			It("Should deliver events from other producers as bytes payloads", func() {
+				var capturedMessage *topicspb.TopicMessage
+
+				By("Handling exactly 1 request")
+				mockManager.EXPECT().HandleRequest(gomock.Any(), gomock.Any()).Times(1).DoAndReturn(func(ctx context.Context, request *topicspb.ServerMessage) (*topicspb.ClientMessage, error) {
+					capturedMessage = request.GetMessageRequest().GetMessage()
+
+					return &topicspb.ClientMessage{
+						Content: &topicspb.ClientMessage_MessageResponse{
+							MessageResponse: &topicspb.MessageResponse{
+								Success: true,
+							},
+						},
+					}, nil
+				})
+
+				requestBody := []byte(`[{"id": "9012", "topic": "test", "dataVersion": "1.0", "data": {"order":12345678901234567890}}]`)
+				request, err := http.NewRequest("POST", fmt.Sprintf("%s/%s/x-nitric-topic/test", gatewayUrl, testEvtToken), bytes.NewReader(requestBody))
+				Expect(err).To(BeNil())
+				request.Header.Add("aeg-event-type", "Notification")
+				resp, err := http.DefaultClient.Do(request)
+
+				By("Not returning an error")
+				Expect(err).To(BeNil())
+				Expect(resp.StatusCode).To(Equal(200))
+
+				By("Passing through the JSON event data without losing precision")
+				Expect(capturedMessage.GetBytesPayload().GetContentType()).To(Equal("application/json"))
+				Expect(string(capturedMessage.GetBytesPayload().GetData())).To(Equal(`{"order":12345678901234567890}`))
+			})
 		})
 	})
 })
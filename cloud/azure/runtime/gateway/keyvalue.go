@@ -0,0 +1,68 @@
+// Copyright 2021 Nitric Technologies Pty Ltd.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package http_service
+
+import (
+	"context"
+
+	"github.com/nitrictech/nitric/cloud/azure/runtime/keyvalue"
+	"github.com/nitrictech/nitric/core/pkg/gateway"
+)
+
+// keyValuePollingGateway polls key value stores for changes while the wrapped gateway is running,
+// since azure storage tables can't push change events to the gateway's routes
+type keyValuePollingGateway struct {
+	gateway.GatewayService
+	poller *keyvalue.ChangePoller
+	cancel context.CancelFunc
+}
+
+func (k *keyValuePollingGateway) Start(opts *gateway.GatewayStartOpts) error {
+	ctx, cancel := context.WithCancel(context.Background())
+	k.cancel = cancel
+
+	go k.poller.Run(ctx, opts)
+
+	return k.GatewayService.Start(opts)
+}
+
+// StopAccepting stops polling for changes, so no new events are delivered to draining listeners
+func (k *keyValuePollingGateway) StopAccepting() error {
+	if k.cancel != nil {
+		k.cancel()
+	}
+
+	if stopper, ok := k.GatewayService.(gateway.AcceptStopper); ok {
+		return stopper.StopAccepting()
+	}
+
+	return nil
+}
+
+func (k *keyValuePollingGateway) Stop() error {
+	if k.cancel != nil {
+		k.cancel()
+	}
+
+	return k.GatewayService.Stop()
+}
+
+// WithKeyValuePolling delivers key value events found by the poller to listeners while the gateway is running
+func WithKeyValuePolling(gw gateway.GatewayService, poller *keyvalue.ChangePoller) gateway.GatewayService {
+	return &keyValuePollingGateway{
+		GatewayService: gw,
+		poller:         poller,
+	}
+}
@@ -19,6 +19,8 @@ import (
 	"encoding/json"
 	"fmt"
 	"net/http"
+	"strings"
+	"sync"
 	"time"
 
 	"github.com/Azure/azure-sdk-for-go/sdk/azcore"
@@ -27,6 +29,7 @@ import (
 	"github.com/nitrictech/nitric/cloud/azure/runtime/env"
 	document "github.com/nitrictech/nitric/core/pkg/decorators/keyvalue"
 	grpc_errors "github.com/nitrictech/nitric/core/pkg/grpc/errors"
+	"github.com/nitrictech/nitric/core/pkg/logger"
 	kvstorepb "github.com/nitrictech/nitric/core/pkg/proto/kvstore/v1"
 	"github.com/pkg/errors"
 	"google.golang.org/grpc/codes"
@@ -34,6 +37,9 @@ import (
 	"google.golang.org/protobuf/types/known/structpb"
 )
 
+// Azure Storage Table transactions are limited to 100 entities, which must all be in the same partition
+const maxBatchSize = 100
+
 // AzureStorageTableKeyValueService - an Azure Storage Table implementation of the Nitric Key/Value Service
 type AzureStorageTableKeyValueService struct {
 	clientFactory AzureStorageClientFactory
@@ -45,7 +51,13 @@ var _ kvstorepb.KvStoreServer = (*AzureStorageTableKeyValueService)(nil)
 type AztableEntity struct {
 	aztables.Entity
 
+	// ETag of the entity when it was read, set by the table service
+	ETag string `json:"odata.etag,omitempty"`
+
 	Content aztables.EDMBinary
+
+	// ExpiresAt is set for values with a ttl, the table service doesn't expire entities so they're filtered out on read
+	ExpiresAt *aztables.EDMDateTime `json:",omitempty"`
 }
 
 // Get a value from the Azure Storage table
@@ -108,6 +120,16 @@ func (s *AzureStorageTableKeyValueService) GetValue(ctx context.Context, req *kv
 		)
 	}
 
+	if entity.expired() {
+		deleteExpired(ctx, client, entity, response.ETag)
+
+		return nil, newErr(
+			codes.NotFound,
+			fmt.Sprintf("key %s not found in store %s", req.Ref.Key, req.Ref.Store),
+			nil,
+		)
+	}
+
 	var structContent structpb.Struct
 	err = proto.Unmarshal(entity.Content, &structContent)
 	if err != nil {
@@ -122,6 +144,7 @@ func (s *AzureStorageTableKeyValueService) GetValue(ctx context.Context, req *kv
 		Value: &kvstorepb.Value{
 			Ref:     req.Ref,
 			Content: &structContent,
+			Version: string(response.ETag),
 		},
 	}, nil
 }
@@ -146,6 +169,22 @@ func (s *AzureStorageTableKeyValueService) SetValue(ctx context.Context, req *kv
 		)
 	}
 
+	if err := document.ValidateTtl(req.Ttl); err != nil {
+		return nil, newErr(
+			codes.InvalidArgument,
+			"invalid ttl",
+			err,
+		)
+	}
+
+	if err := document.ValidatePrecondition(req.Precondition, false); err != nil {
+		return nil, newErr(
+			codes.InvalidArgument,
+			"invalid precondition",
+			err,
+		)
+	}
+
 	content, err := proto.Marshal(req.Content)
 	if err != nil {
 		return nil, newErr(
@@ -164,6 +203,11 @@ func (s *AzureStorageTableKeyValueService) SetValue(ctx context.Context, req *kv
 		Content: content,
 	}
 
+	if req.Ttl != nil {
+		expiresAt := aztables.EDMDateTime(document.ExpiresAt(req.Ttl))
+		entity.ExpiresAt = &expiresAt
+	}
+
 	entityJson, err := json.Marshal(entity)
 	if err != nil {
 		return nil, newErr(
@@ -173,10 +217,16 @@ func (s *AzureStorageTableKeyValueService) SetValue(ctx context.Context, req *kv
 		)
 	}
 
-	_, err = client.UpsertEntity(ctx, entityJson, &aztables.UpsertEntityOptions{
-		UpdateMode: aztables.UpdateModeReplace,
-	})
+	etag, err := writeEntity(ctx, client, req.Ref, entityJson, req.Precondition)
 	if err != nil {
+		if errors.Is(err, errPreconditionFailed) {
+			return nil, newErr(
+				codes.FailedPrecondition,
+				fmt.Sprintf("precondition failed for key %s in store %s", req.Ref.Key, req.Ref.Store),
+				err,
+			)
+		}
+
 		var respErr *azcore.ResponseError
 		if errors.As(err, &respErr) {
 			switch respErr.StatusCode {
@@ -197,7 +247,9 @@ func (s *AzureStorageTableKeyValueService) SetValue(ctx context.Context, req *kv
 		)
 	}
 
-	return &kvstorepb.KvStoreSetValueResponse{}, nil
+	return &kvstorepb.KvStoreSetValueResponse{
+		Version: string(etag),
+	}, nil
 }
 
 // Delete a key/value pair from the Azure Storage table
@@ -220,8 +272,24 @@ func (s *AzureStorageTableKeyValueService) DeleteKey(ctx context.Context, req *k
 		)
 	}
 
-	_, err = client.DeleteEntity(ctx, req.Ref.Store, req.Ref.Key, nil)
+	if err := document.ValidatePrecondition(req.Precondition, true); err != nil {
+		return nil, newErr(
+			codes.InvalidArgument,
+			"invalid precondition",
+			err,
+		)
+	}
+
+	err = deleteEntity(ctx, client, req.Ref, req.Precondition)
 	if err != nil {
+		if errors.Is(err, errPreconditionFailed) {
+			return nil, newErr(
+				codes.FailedPrecondition,
+				fmt.Sprintf("precondition failed for key %s in store %s", req.Ref.Key, req.Ref.Store),
+				err,
+			)
+		}
+
 		var respErr *azcore.ResponseError
 		if errors.As(err, &respErr) {
 			switch respErr.StatusCode {
@@ -248,7 +316,8 @@ func (s *AzureStorageTableKeyValueService) DeleteKey(ctx context.Context, req *k
 	return &kvstorepb.KvStoreDeleteKeyResponse{}, nil
 }
 
-// Return all keys in the Azure Storage table for a key/value store
+// Return the keys in the Azure Storage table for a key/value store, entities are always listed in ascending key order
+// so reverse ordering isn't supported.
 func (s *AzureStorageTableKeyValueService) ScanKeys(req *kvstorepb.KvStoreScanKeysRequest, stream kvstorepb.KvStore_ScanKeysServer) error {
 	newErr := grpc_errors.ErrorsWithScope("AzureStorageTableKeyValueService.Keys")
 	storeName := req.GetStore().GetName()
@@ -261,6 +330,24 @@ func (s *AzureStorageTableKeyValueService) ScanKeys(req *kvstorepb.KvStoreScanKe
 		)
 	}
 
+	if err := document.ValidateScan(req); err != nil {
+		return newErr(
+			codes.InvalidArgument,
+			"invalid scan",
+			err,
+		)
+	}
+
+	if req.Reverse {
+		return newErr(
+			codes.Unimplemented,
+			"reverse ordering isn't supported by azure storage tables",
+			nil,
+		)
+	}
+
+	startAfter, _ := document.ScanStartAfter(req.ContinuationToken)
+
 	client, err := s.clientFactory(storeName)
 	if err != nil {
 		return newErr(
@@ -271,16 +358,30 @@ func (s *AzureStorageTableKeyValueService) ScanKeys(req *kvstorepb.KvStoreScanKe
 	}
 
 	// ge "GreaterThanOrEqual" is used for string prefix filtering (https://learn.microsoft.com/en-us/rest/api/storageservices/querying-tables-and-entities#filtering-on-string-properties)
-	keyFilter := fmt.Sprintf("PartitionKey eq '%s' and RowKey ge '%s'", storeName, req.GetPrefix())
+	keyFilter := fmt.Sprintf("PartitionKey eq '%s' and RowKey ge '%s'", storeName, odataString(req.GetPrefix()))
+	if startAfter != "" {
+		keyFilter += fmt.Sprintf(" and RowKey gt '%s'", odataString(startAfter))
+	}
 
-	pager := client.NewListEntitiesPager(
-		&aztables.ListEntitiesOptions{
-			Filter: &keyFilter,
-		},
-	)
+	options := &aztables.ListEntitiesOptions{
+		Filter: &keyFilter,
+	}
+
+	if !req.IncludeValues {
+		// only the expiry time is needed to filter out expired keys
+		selectKeys := "PartitionKey,RowKey,ExpiresAt"
+		options.Select = &selectKeys
+	}
+
+	if req.Limit > 0 {
+		options.Top = &req.Limit
+	}
+
+	sent := 0
+	pager := client.NewListEntitiesPager(options)
 
 	for pager.More() {
-		response, err := pager.NextPage(context.TODO())
+		response, err := pager.NextPage(stream.Context())
 		if err != nil {
 			var respErr *azcore.ResponseError
 			if errors.As(err, &respErr) {
@@ -312,21 +413,536 @@ func (s *AzureStorageTableKeyValueService) ScanKeys(req *kvstorepb.KvStoreScanKe
 				)
 			}
 
-			if err := stream.Send(&kvstorepb.KvStoreScanKeysResponse{
-				Key: entity.RowKey,
-			}); err != nil {
+			// keys with the prefix are contiguous, so the scan is complete once it has passed them
+			if !strings.HasPrefix(entity.RowKey, req.GetPrefix()) {
+				return nil
+			}
+
+			if entity.expired() {
+				deleteExpired(stream.Context(), client, entity, azcore.ETag(entity.ETag))
+				continue
+			}
+
+			resp := &kvstorepb.KvStoreScanKeysResponse{
+				Key:               entity.RowKey,
+				ContinuationToken: document.ScanContinuationToken(entity.RowKey),
+			}
+
+			if req.IncludeValues {
+				var content structpb.Struct
+				if err := proto.Unmarshal(entity.Content, &content); err != nil {
+					return newErr(
+						codes.Internal,
+						"Unable to convert value to pb struct",
+						err,
+					)
+				}
+
+				resp.Value = &kvstorepb.Value{
+					Ref:     &kvstorepb.ValueRef{Store: storeName, Key: entity.RowKey},
+					Content: &content,
+					Version: entity.ETag,
+				}
+			}
+
+			if err := stream.Send(resp); err != nil {
 				return newErr(
 					codes.Internal,
 					"failed to send response",
 					err,
 				)
 			}
+
+			sent++
+			if req.Limit > 0 && sent >= int(req.Limit) {
+				return nil
+			}
+		}
+	}
+
+	return nil
+}
+
+// odataString escapes a value for use in a string literal of an OData filter
+func odataString(value string) string {
+	return strings.ReplaceAll(value, "'", "''")
+}
+
+// Get multiple values from the Azure Storage tables, the table service doesn't support batch reads so they're retrieved concurrently
+func (s *AzureStorageTableKeyValueService) BatchGet(ctx context.Context, req *kvstorepb.KvStoreBatchGetRequest) (*kvstorepb.KvStoreBatchGetResponse, error) {
+	newErr := grpc_errors.ErrorsWithScope("AzureStorageTableKeyValueService.BatchGet")
+
+	if err := document.ValidateBatchSize(len(req.Refs), maxBatchSize); err != nil {
+		return nil, newErr(
+			codes.InvalidArgument,
+			"invalid batch",
+			err,
+		)
+	}
+
+	values := make([]*kvstorepb.Value, len(req.Refs))
+	errs := make([]error, len(req.Refs))
+
+	concurrently(len(req.Refs), func(i int) {
+		if err := document.ValidateValueRef(req.Refs[i]); err != nil {
+			errs[i] = newErr(codes.InvalidArgument, "invalid key", err)
+			return
+		}
+
+		resp, err := s.GetValue(ctx, &kvstorepb.KvStoreGetValueRequest{Ref: req.Refs[i]})
+		if err != nil {
+			errs[i] = err
+			return
+		}
+
+		values[i] = resp.Value
+	})
+
+	resp := &kvstorepb.KvStoreBatchGetResponse{}
+	for i, ref := range req.Refs {
+		if errs[i] != nil {
+			resp.Errors = append(resp.Errors, document.ItemError(ref, errs[i]))
+			continue
+		}
+
+		resp.Values = append(resp.Values, values[i])
+	}
+
+	return resp, nil
+}
+
+// Set multiple values in the Azure Storage tables, the values of each store are upserted in a single transaction
+func (s *AzureStorageTableKeyValueService) BatchSet(ctx context.Context, req *kvstorepb.KvStoreBatchSetRequest) (*kvstorepb.KvStoreBatchSetResponse, error) {
+	newErr := grpc_errors.ErrorsWithScope("AzureStorageTableKeyValueService.BatchSet")
+
+	if err := document.ValidateBatchSize(len(req.Values), maxBatchSize); err != nil {
+		return nil, newErr(
+			codes.InvalidArgument,
+			"invalid batch",
+			err,
+		)
+	}
+
+	resp := &kvstorepb.KvStoreBatchSetResponse{}
+
+	// a transaction can only contain entities from a single partition, which is the store
+	stores := []string{}
+	refs := map[string][]*kvstorepb.ValueRef{}
+	actions := map[string][]aztables.TransactionAction{}
+	included := map[string]bool{}
+
+	for _, value := range req.Values {
+		if err := document.ValidateValueRef(value.Ref); err != nil {
+			resp.Errors = append(resp.Errors, document.ItemError(value.Ref, newErr(codes.InvalidArgument, "invalid key", err)))
+			continue
+		}
+
+		if value.Content == nil {
+			resp.Errors = append(resp.Errors, document.ItemError(value.Ref, newErr(codes.InvalidArgument, "value content must not be nil", nil)))
+			continue
+		}
+
+		// the table service rejects transactions that include the same entity more than once
+		id := value.Ref.Store + "/" + value.Ref.Key
+		if included[id] {
+			resp.Errors = append(resp.Errors, document.ItemError(value.Ref, newErr(
+				codes.InvalidArgument,
+				fmt.Sprintf("key %s in store %s is included more than once", value.Ref.Key, value.Ref.Store),
+				nil,
+			)))
+			continue
+		}
+		included[id] = true
+
+		entityJson, err := marshalEntity(value.Ref, value.Content)
+		if err != nil {
+			resp.Errors = append(resp.Errors, document.ItemError(value.Ref, newErr(codes.Internal, "unable to marshal content", err)))
+			continue
+		}
+
+		if _, ok := refs[value.Ref.Store]; !ok {
+			stores = append(stores, value.Ref.Store)
+		}
+
+		refs[value.Ref.Store] = append(refs[value.Ref.Store], value.Ref)
+		actions[value.Ref.Store] = append(actions[value.Ref.Store], aztables.TransactionAction{
+			ActionType: aztables.TransactionTypeInsertReplace,
+			Entity:     entityJson,
+		})
+	}
+
+	for _, store := range stores {
+		if err := s.submitTransaction(ctx, newErr, store, actions[store]); err != nil {
+			for _, ref := range refs[store] {
+				resp.Errors = append(resp.Errors, document.ItemError(ref, err))
+			}
+		}
+	}
+
+	return resp, nil
+}
+
+// Delete multiple key/value pairs from the Azure Storage tables, they're deleted concurrently since a
+// transaction would fail entirely if any of the keys didn't exist
+func (s *AzureStorageTableKeyValueService) BatchDelete(ctx context.Context, req *kvstorepb.KvStoreBatchDeleteRequest) (*kvstorepb.KvStoreBatchDeleteResponse, error) {
+	newErr := grpc_errors.ErrorsWithScope("AzureStorageTableKeyValueService.BatchDelete")
+
+	if err := document.ValidateBatchSize(len(req.Refs), maxBatchSize); err != nil {
+		return nil, newErr(
+			codes.InvalidArgument,
+			"invalid batch",
+			err,
+		)
+	}
+
+	errs := make([]error, len(req.Refs))
+
+	concurrently(len(req.Refs), func(i int) {
+		if err := document.ValidateValueRef(req.Refs[i]); err != nil {
+			errs[i] = newErr(codes.InvalidArgument, "invalid key", err)
+			return
+		}
+
+		_, errs[i] = s.DeleteKey(ctx, &kvstorepb.KvStoreDeleteKeyRequest{Ref: req.Refs[i]})
+	})
+
+	resp := &kvstorepb.KvStoreBatchDeleteResponse{}
+	for i, ref := range req.Refs {
+		if errs[i] != nil {
+			resp.Errors = append(resp.Errors, document.ItemError(ref, errs[i]))
+		}
+	}
+
+	return resp, nil
+}
+
+// Apply multiple operations atomically with an Azure Storage Table transaction, all operations must be on the same store.
+// Deleting a key that doesn't exist is a no-op, as it is for DeleteKey.
+func (s *AzureStorageTableKeyValueService) Transact(ctx context.Context, req *kvstorepb.KvStoreTransactRequest) (*kvstorepb.KvStoreTransactResponse, error) {
+	newErr := grpc_errors.ErrorsWithScope("AzureStorageTableKeyValueService.Transact")
+
+	if err := document.ValidateTransaction(req.Operations, maxBatchSize); err != nil {
+		return nil, newErr(
+			codes.InvalidArgument,
+			"invalid transaction",
+			err,
+		)
+	}
+
+	store := ""
+	actions := make([]aztables.TransactionAction, 0, len(req.Operations))
+	deletes := []*kvstorepb.ValueRef{}
+
+	for _, op := range req.Operations {
+		ref, _ := document.TransactOperationRef(op)
+		if store == "" {
+			store = ref.Store
+		} else if ref.Store != store {
+			return nil, newErr(
+				codes.InvalidArgument,
+				fmt.Sprintf("all operations of a transaction must be on the same store, found %s and %s", store, ref.Store),
+				nil,
+			)
+		}
+
+		if set := op.GetSet(); set != nil {
+			entityJson, err := marshalEntity(set.Ref, set.Content)
+			if err != nil {
+				return nil, newErr(
+					codes.Internal,
+					"unable to marshal content",
+					err,
+				)
+			}
+
+			actions = append(actions, aztables.TransactionAction{
+				ActionType: aztables.TransactionTypeInsertReplace,
+				Entity:     entityJson,
+			})
+		} else {
+			deletes = append(deletes, ref)
+		}
+	}
+
+	deleteActions, err := s.existingDeleteActions(ctx, newErr, store, deletes)
+	if err != nil {
+		return nil, err
+	}
+	actions = append(actions, deleteActions...)
+
+	// every operation deleted a key that doesn't exist
+	if len(actions) == 0 {
+		return &kvstorepb.KvStoreTransactResponse{}, nil
+	}
+
+	if err := s.submitTransaction(ctx, newErr, store, actions); err != nil {
+		return nil, err
+	}
+
+	return &kvstorepb.KvStoreTransactResponse{}, nil
+}
+
+// existingDeleteActions creates transaction actions deleting the keys that exist in a store. Azure aborts a transaction
+// that deletes an entity that doesn't exist, so deletes of missing keys are left out of the transaction.
+func (s *AzureStorageTableKeyValueService) existingDeleteActions(ctx context.Context, newErr grpc_errors.ScopedErrorFactory, store string, refs []*kvstorepb.ValueRef) ([]aztables.TransactionAction, error) {
+	if len(refs) == 0 {
+		return nil, nil
+	}
+
+	client, err := s.clientFactory(store)
+	if err != nil {
+		return nil, newErr(
+			codes.Internal,
+			"unable to create client",
+			err,
+		)
+	}
+
+	exists := make([]bool, len(refs))
+	errs := make([]error, len(refs))
+
+	concurrently(len(refs), func(i int) {
+		var etag azcore.ETag
+		// expired entities that haven't been removed yet still exist in the table, so they're deleted too
+		etag, _, errs[i] = currentEntity(ctx, client, refs[i])
+		exists[i] = etag != ""
+	})
+
+	actions := []aztables.TransactionAction{}
+	for i, ref := range refs {
+		if errs[i] != nil {
+			if responseStatus(errs[i]) == http.StatusForbidden {
+				return nil, newErr(
+					codes.PermissionDenied,
+					"unable to apply transaction, this may be due to a missing permissions request in your code.",
+					errs[i],
+				)
+			}
+
+			return nil, newErr(
+				codes.Internal,
+				fmt.Sprintf("unable to read key %s in store %s", ref.Key, ref.Store),
+				errs[i],
+			)
 		}
+
+		if !exists[i] {
+			continue
+		}
+
+		entityJson, err := json.Marshal(aztables.Entity{
+			PartitionKey: ref.Store,
+			RowKey:       ref.Key,
+		})
+		if err != nil {
+			return nil, newErr(
+				codes.Internal,
+				"unable to convert struct to json",
+				err,
+			)
+		}
+
+		actions = append(actions, aztables.TransactionAction{
+			ActionType: aztables.TransactionTypeDelete,
+			Entity:     entityJson,
+		})
+	}
+
+	return actions, nil
+}
+
+// submitTransaction applies the actions to a store's table atomically
+func (s *AzureStorageTableKeyValueService) submitTransaction(ctx context.Context, newErr grpc_errors.ScopedErrorFactory, store string, actions []aztables.TransactionAction) error {
+	client, err := s.clientFactory(store)
+	if err != nil {
+		return newErr(
+			codes.Internal,
+			"unable to create client",
+			err,
+		)
+	}
+
+	_, err = client.SubmitTransaction(ctx, actions, nil)
+	if err != nil {
+		var respErr *azcore.ResponseError
+		if errors.As(err, &respErr) && respErr.StatusCode == http.StatusForbidden {
+			return newErr(
+				codes.PermissionDenied,
+				"unable to apply transaction, this may be due to a missing permissions request in your code.",
+				err,
+			)
+		}
+
+		return newErr(
+			codes.Aborted,
+			"transaction failed, no operations were applied",
+			err,
+		)
 	}
 
 	return nil
 }
 
+// marshalEntity converts a value to the json of its table entity
+func marshalEntity(ref *kvstorepb.ValueRef, content *structpb.Struct) ([]byte, error) {
+	contentBytes, err := proto.Marshal(content)
+	if err != nil {
+		return nil, err
+	}
+
+	return json.Marshal(AztableEntity{
+		Entity: aztables.Entity{
+			PartitionKey: ref.Store,
+			RowKey:       ref.Key,
+			Timestamp:    aztables.EDMDateTime(time.Now()),
+		},
+		Content: contentBytes,
+	})
+}
+
+// errPreconditionFailed is returned when the current entity doesn't meet the precondition of a write
+var errPreconditionFailed = errors.New("precondition failed")
+
+// writeEntity stores an entity, applying the precondition with the ETag of the current entity. Returns the entity's new ETag.
+func writeEntity(ctx context.Context, client *aztables.Client, ref *kvstorepb.ValueRef, entityJson []byte, precondition *kvstorepb.KvStorePrecondition) (azcore.ETag, error) {
+	switch c := precondition.GetCondition().(type) {
+	case *kvstorepb.KvStorePrecondition_Version:
+		return replaceEntity(ctx, client, entityJson, azcore.ETag(c.Version))
+	case *kvstorepb.KvStorePrecondition_Exists:
+		etag, live, err := currentEntity(ctx, client, ref)
+		if err != nil {
+			return "", err
+		}
+
+		if c.Exists != live {
+			return "", errPreconditionFailed
+		}
+
+		// replace the current entity, including expired entities that haven't been deleted yet
+		if etag != "" {
+			return replaceEntity(ctx, client, entityJson, etag)
+		}
+
+		resp, err := client.AddEntity(ctx, entityJson, nil)
+		if responseStatus(err) == http.StatusConflict {
+			return "", errPreconditionFailed
+		}
+
+		return resp.ETag, err
+	default:
+		resp, err := client.UpsertEntity(ctx, entityJson, &aztables.UpsertEntityOptions{
+			UpdateMode: aztables.UpdateModeReplace,
+		})
+
+		return resp.ETag, err
+	}
+}
+
+// replaceEntity replaces an entity only if its ETag matches
+func replaceEntity(ctx context.Context, client *aztables.Client, entityJson []byte, etag azcore.ETag) (azcore.ETag, error) {
+	resp, err := client.UpdateEntity(ctx, entityJson, &aztables.UpdateEntityOptions{
+		IfMatch:    &etag,
+		UpdateMode: aztables.UpdateModeReplace,
+	})
+	if status := responseStatus(err); status == http.StatusPreconditionFailed || status == http.StatusNotFound {
+		return "", errPreconditionFailed
+	}
+
+	return resp.ETag, err
+}
+
+// deleteEntity deletes an entity, applying the precondition with the ETag of the current entity
+func deleteEntity(ctx context.Context, client *aztables.Client, ref *kvstorepb.ValueRef, precondition *kvstorepb.KvStorePrecondition) error {
+	var etag azcore.ETag
+	switch c := precondition.GetCondition().(type) {
+	case nil:
+		_, err := client.DeleteEntity(ctx, ref.Store, ref.Key, nil)
+		return err
+	case *kvstorepb.KvStorePrecondition_Version:
+		etag = azcore.ETag(c.Version)
+	case *kvstorepb.KvStorePrecondition_Exists:
+		currentEtag, live, err := currentEntity(ctx, client, ref)
+		if err != nil {
+			return err
+		}
+
+		if !live {
+			return errPreconditionFailed
+		}
+
+		etag = currentEtag
+	}
+
+	_, err := client.DeleteEntity(ctx, ref.Store, ref.Key, &aztables.DeleteEntityOptions{
+		IfMatch: &etag,
+	})
+	if status := responseStatus(err); status == http.StatusPreconditionFailed || status == http.StatusNotFound {
+		return errPreconditionFailed
+	}
+
+	return err
+}
+
+// currentEntity returns the ETag of an entity, or an empty ETag if it doesn't exist, and whether it exists and hasn't expired
+func currentEntity(ctx context.Context, client *aztables.Client, ref *kvstorepb.ValueRef) (azcore.ETag, bool, error) {
+	response, err := client.GetEntity(ctx, ref.Store, ref.Key, nil)
+	if err != nil {
+		if responseStatus(err) == http.StatusNotFound {
+			return "", false, nil
+		}
+
+		return "", false, err
+	}
+
+	var entity AztableEntity
+	if err := json.Unmarshal(response.Value, &entity); err != nil {
+		return "", false, err
+	}
+
+	return response.ETag, !entity.expired(), nil
+}
+
+// responseStatus returns the HTTP status code of an error returned by the table service, or 0 if it isn't a response error
+func responseStatus(err error) int {
+	var respErr *azcore.ResponseError
+	if errors.As(err, &respErr) {
+		return respErr.StatusCode
+	}
+
+	return 0
+}
+
+// expired returns true if the entity's ttl has elapsed
+func (e AztableEntity) expired() bool {
+	return e.ExpiresAt != nil && !time.Time(*e.ExpiresAt).After(time.Now())
+}
+
+// deleteExpired removes an expired entity from its table, unless it has been overwritten since it was read
+func deleteExpired(ctx context.Context, client *aztables.Client, entity AztableEntity, etag azcore.ETag) {
+	opts := &aztables.DeleteEntityOptions{}
+	if etag != "" {
+		opts.IfMatch = &etag
+	}
+
+	if _, err := client.DeleteEntity(ctx, entity.PartitionKey, entity.RowKey, opts); err != nil {
+		logger.Debugf("unable to delete expired key %s from store %s: %v", entity.RowKey, entity.PartitionKey, err)
+	}
+}
+
+// concurrently calls fn with each index up to count, returning once all calls have completed
+func concurrently(count int, fn func(i int)) {
+	var wg sync.WaitGroup
+	for i := 0; i < count; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			fn(i)
+		}(i)
+	}
+
+	wg.Wait()
+}
+
 type AzureStorageClientFactory func(tableName string) (*aztables.Client, error)
 
 func newStorageTablesClientFactory(creds *azidentity.DefaultAzureCredential, storageAccountName string) AzureStorageClientFactory {
@@ -0,0 +1,199 @@
+// Copyright 2021 Nitric Technologies Pty Ltd.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package keyvalue
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/Azure/azure-sdk-for-go/sdk/data/aztables"
+	"github.com/nitrictech/nitric/core/pkg/gateway"
+	"github.com/nitrictech/nitric/core/pkg/logger"
+	kvstorepb "github.com/nitrictech/nitric/core/pkg/proto/kvstore/v1"
+	"github.com/nitrictech/nitric/core/pkg/workers"
+	kvworkers "github.com/nitrictech/nitric/core/pkg/workers/keyvalue"
+)
+
+// keyLister lists the ETag of every unexpired key in a store
+type keyLister func(ctx context.Context, storeName string) (map[string]string, error)
+
+// ChangePoller delivers key value events for Azure Storage Tables, which have no change feed.
+//
+//	the tables of stores with registered listeners are listed periodically and compared to their previous listing,
+//	so events are delivered after the next poll rather than as they occur, and changes between polls are coalesced.
+type ChangePoller struct {
+	listKeys keyLister
+	interval time.Duration
+	// ETags of the keys in each store as of the last poll
+	snapshots map[string]map[string]string
+}
+
+// Run polls the stores with registered listeners until the context is done
+func (p *ChangePoller) Run(ctx context.Context, opts *gateway.GatewayStartOpts) {
+	ticker := time.NewTicker(p.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			for _, storeName := range listenedStores(opts) {
+				if err := p.poll(ctx, storeName, opts.KeyValueListenerPlugin); err != nil {
+					logger.Errorf("unable to poll key value store %s for changes: %v", storeName, err)
+				}
+			}
+		}
+	}
+}
+
+// listenedStores returns the names of the stores with registered listeners
+func listenedStores(opts *gateway.GatewayStartOpts) []string {
+	if opts.Status == nil || opts.KeyValueListenerPlugin == nil {
+		return nil
+	}
+
+	stores := []string{}
+	seen := map[string]bool{}
+	for _, worker := range opts.Status.Workers()[workers.TriggerKeyValue] {
+		if !seen[worker.Name] {
+			seen[worker.Name] = true
+			stores = append(stores, worker.Name)
+		}
+	}
+
+	return stores
+}
+
+// poll compares the current keys of a store to its last snapshot, delivering an event for each changed key.
+// the first poll of a store only records its snapshot, keys that fail to be handled are retried on the next poll.
+func (p *ChangePoller) poll(ctx context.Context, storeName string, handler kvworkers.StoreRequestHandler) error {
+	current, err := p.listKeys(ctx, storeName)
+	if err != nil {
+		return err
+	}
+
+	previous, ok := p.snapshots[storeName]
+	if !ok {
+		p.snapshots[storeName] = current
+		return nil
+	}
+
+	next := map[string]string{}
+	for key, etag := range current {
+		next[key] = etag
+		if previous[key] != etag && !p.deliver(ctx, handler, storeName, key, kvstorepb.KeyValueEventType_Set) {
+			// keep the previous version so the change is detected again
+			if prevEtag, existed := previous[key]; existed {
+				next[key] = prevEtag
+			} else {
+				delete(next, key)
+			}
+		}
+	}
+
+	for key, etag := range previous {
+		if _, exists := current[key]; !exists && !p.deliver(ctx, handler, storeName, key, kvstorepb.KeyValueEventType_Delete) {
+			next[key] = etag
+		}
+	}
+
+	p.snapshots[storeName] = next
+
+	return nil
+}
+
+// deliver sends an event to the store's listeners, returning false if it should be retried
+func (p *ChangePoller) deliver(ctx context.Context, handler kvworkers.StoreRequestHandler, storeName string, key string, eventType kvstorepb.KeyValueEventType) bool {
+	resp, err := handler.HandleRequest(ctx, &kvstorepb.ServerMessage{
+		Content: &kvstorepb.ServerMessage_KeyValueEventRequest{
+			KeyValueEventRequest: &kvstorepb.KeyValueEventRequest{
+				StoreName: storeName,
+				Event: &kvstorepb.KeyValueEventRequest_KeyValueEvent{
+					KeyValueEvent: &kvstorepb.KeyValueEvent{
+						Key:  key,
+						Type: eventType,
+					},
+				},
+			},
+		},
+	})
+	if errors.Is(err, kvworkers.ErrNoListener) {
+		// events for keys that don't match any listener's prefix are expected, since every key in the store is polled
+		logger.Debugf("no listener for %s event for key %s in store %s", eventType, key, storeName)
+		return true
+	}
+
+	if err != nil {
+		logger.Errorf("unable to deliver %s event for key %s in store %s, retrying on the next poll: %v", eventType, key, storeName, err)
+		return false
+	}
+
+	if !resp.GetKeyValueEventResponse().GetSuccess() {
+		logger.Errorf("listener failed handling %s event for key %s in store %s, retrying on the next poll", eventType, key, storeName)
+		return false
+	}
+
+	return true
+}
+
+// listETags returns the ETag of every unexpired key in a store
+func (s *AzureStorageTableKeyValueService) listETags(ctx context.Context, storeName string) (map[string]string, error) {
+	client, err := s.clientFactory(storeName)
+	if err != nil {
+		return nil, err
+	}
+
+	keyFilter := fmt.Sprintf("PartitionKey eq '%s'", odataString(storeName))
+	selectKeys := "PartitionKey,RowKey,ExpiresAt"
+
+	pager := client.NewListEntitiesPager(&aztables.ListEntitiesOptions{
+		Filter: &keyFilter,
+		Select: &selectKeys,
+	})
+
+	etags := map[string]string{}
+	for pager.More() {
+		response, err := pager.NextPage(ctx)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, entityBytes := range response.Entities {
+			var entity AztableEntity
+			if err := json.Unmarshal(entityBytes, &entity); err != nil {
+				return nil, err
+			}
+
+			if !entity.expired() {
+				etags[entity.RowKey] = entity.ETag
+			}
+		}
+	}
+
+	return etags, nil
+}
+
+// NewChangePoller creates a poller for the tables of the key value service's stores
+func (s *AzureStorageTableKeyValueService) NewChangePoller(interval time.Duration) *ChangePoller {
+	return &ChangePoller{
+		listKeys:  s.listETags,
+		interval:  interval,
+		snapshots: map[string]map[string]string{},
+	}
+}
@@ -0,0 +1,269 @@
+// Copyright 2021 Nitric Technologies Pty Ltd.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package keyvalue
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	mock_keyvalue "github.com/nitrictech/nitric/core/mocks/workers/keyvalue"
+	"github.com/nitrictech/nitric/core/pkg/gateway"
+	kvstorepb "github.com/nitrictech/nitric/core/pkg/proto/kvstore/v1"
+	"github.com/nitrictech/nitric/core/pkg/workers"
+	kvworkers "github.com/nitrictech/nitric/core/pkg/workers/keyvalue"
+)
+
+// fakeTables returns the configured ETags of each store's keys
+type fakeTables struct {
+	lock  sync.Mutex
+	keys  map[string]map[string]string
+	err   error
+	polls int
+}
+
+func (f *fakeTables) list(ctx context.Context, storeName string) (map[string]string, error) {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+
+	f.polls++
+
+	if f.err != nil {
+		return nil, f.err
+	}
+
+	etags := map[string]string{}
+	for key, etag := range f.keys[storeName] {
+		etags[key] = etag
+	}
+
+	return etags, nil
+}
+
+func (f *fakeTables) set(storeName string, keys map[string]string) {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+
+	f.keys[storeName] = keys
+}
+
+func (f *fakeTables) pollCount() int {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+
+	return f.polls
+}
+
+type fakeStatus struct {
+	workers map[string][]workers.WorkerDescription
+}
+
+func (f *fakeStatus) Ready() error {
+	return nil
+}
+
+func (f *fakeStatus) Workers() map[string][]workers.WorkerDescription {
+	return f.workers
+}
+
+// eventMatcher matches event requests for a key and event type
+type eventMatcher struct {
+	key       string
+	eventType kvstorepb.KeyValueEventType
+}
+
+func (e eventMatcher) Matches(x interface{}) bool {
+	msg, ok := x.(*kvstorepb.ServerMessage)
+	if !ok {
+		return false
+	}
+
+	event := msg.GetKeyValueEventRequest().GetKeyValueEvent()
+
+	return event.GetKey() == e.key && event.GetType() == e.eventType
+}
+
+func (e eventMatcher) String() string {
+	return fmt.Sprintf("is a %s event for key %s", e.eventType, e.key)
+}
+
+func isEvent(key string, eventType kvstorepb.KeyValueEventType) gomock.Matcher {
+	return eventMatcher{key: key, eventType: eventType}
+}
+
+func eventResponse(success bool) *kvstorepb.ClientMessage {
+	return &kvstorepb.ClientMessage{
+		Content: &kvstorepb.ClientMessage_KeyValueEventResponse{
+			KeyValueEventResponse: &kvstorepb.KeyValueEventResponse{
+				Success: success,
+			},
+		},
+	}
+}
+
+var _ = Describe("ChangePoller", func() {
+	var (
+		ctrl    *gomock.Controller
+		handler *mock_keyvalue.MockStoreRequestHandler
+		tables  *fakeTables
+		poller  *ChangePoller
+	)
+
+	BeforeEach(func() {
+		ctrl = gomock.NewController(GinkgoT())
+		handler = mock_keyvalue.NewMockStoreRequestHandler(ctrl)
+		tables = &fakeTables{keys: map[string]map[string]string{}}
+		poller = &ChangePoller{
+			listKeys:  tables.list,
+			interval:  10 * time.Millisecond,
+			snapshots: map[string]map[string]string{},
+		}
+	})
+
+	AfterEach(func() {
+		ctrl.Finish()
+	})
+
+	When("a store is polled for the first time", func() {
+		It("should record its keys without delivering events", func() {
+			tables.set("store", map[string]string{"a": "1"})
+
+			err := poller.poll(context.TODO(), "store", handler)
+
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(poller.snapshots["store"]).To(Equal(map[string]string{"a": "1"}))
+		})
+	})
+
+	When("keys change between polls", func() {
+		BeforeEach(func() {
+			tables.set("store", map[string]string{"a": "1", "b": "1"})
+			Expect(poller.poll(context.TODO(), "store", handler)).To(Succeed())
+		})
+
+		It("should deliver set events for new and updated keys and delete events for removed keys", func() {
+			tables.set("store", map[string]string{"a": "2", "c": "1"})
+
+			handler.EXPECT().HandleRequest(gomock.Any(), isEvent("a", kvstorepb.KeyValueEventType_Set)).Return(eventResponse(true), nil)
+			handler.EXPECT().HandleRequest(gomock.Any(), isEvent("c", kvstorepb.KeyValueEventType_Set)).Return(eventResponse(true), nil)
+			handler.EXPECT().HandleRequest(gomock.Any(), isEvent("b", kvstorepb.KeyValueEventType_Delete)).Return(eventResponse(true), nil)
+
+			err := poller.poll(context.TODO(), "store", handler)
+
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(poller.snapshots["store"]).To(Equal(map[string]string{"a": "2", "c": "1"}))
+		})
+
+		It("should not retry events that have no listener", func() {
+			tables.set("store", map[string]string{"a": "2", "b": "1"})
+
+			noListener := fmt.Errorf("%w for store store", kvworkers.ErrNoListener)
+			handler.EXPECT().HandleRequest(gomock.Any(), isEvent("a", kvstorepb.KeyValueEventType_Set)).Return(nil, noListener)
+
+			Expect(poller.poll(context.TODO(), "store", handler)).To(Succeed())
+			Expect(poller.snapshots["store"]).To(Equal(map[string]string{"a": "2", "b": "1"}))
+
+			// no further events are expected by the mock
+			Expect(poller.poll(context.TODO(), "store", handler)).To(Succeed())
+		})
+
+		It("should retry events that fail to be delivered on the next poll", func() {
+			tables.set("store", map[string]string{"a": "2"})
+
+			gomock.InOrder(
+				handler.EXPECT().HandleRequest(gomock.Any(), isEvent("a", kvstorepb.KeyValueEventType_Set)).Return(nil, fmt.Errorf("worker is draining")),
+				handler.EXPECT().HandleRequest(gomock.Any(), isEvent("a", kvstorepb.KeyValueEventType_Set)).Return(eventResponse(true), nil),
+			)
+			gomock.InOrder(
+				handler.EXPECT().HandleRequest(gomock.Any(), isEvent("b", kvstorepb.KeyValueEventType_Delete)).Return(eventResponse(false), nil),
+				handler.EXPECT().HandleRequest(gomock.Any(), isEvent("b", kvstorepb.KeyValueEventType_Delete)).Return(eventResponse(true), nil),
+			)
+
+			Expect(poller.poll(context.TODO(), "store", handler)).To(Succeed())
+			Expect(poller.snapshots["store"]).To(Equal(map[string]string{"a": "1", "b": "1"}))
+
+			Expect(poller.poll(context.TODO(), "store", handler)).To(Succeed())
+			Expect(poller.snapshots["store"]).To(Equal(map[string]string{"a": "2"}))
+		})
+
+		It("should retry set events for new keys that fail to be delivered", func() {
+			tables.set("store", map[string]string{"a": "1", "b": "1", "c": "1"})
+
+			gomock.InOrder(
+				handler.EXPECT().HandleRequest(gomock.Any(), isEvent("c", kvstorepb.KeyValueEventType_Set)).Return(eventResponse(false), nil),
+				handler.EXPECT().HandleRequest(gomock.Any(), isEvent("c", kvstorepb.KeyValueEventType_Set)).Return(eventResponse(true), nil),
+			)
+
+			Expect(poller.poll(context.TODO(), "store", handler)).To(Succeed())
+			Expect(poller.snapshots["store"]).ToNot(HaveKey("c"))
+
+			Expect(poller.poll(context.TODO(), "store", handler)).To(Succeed())
+			Expect(poller.snapshots["store"]).To(HaveKeyWithValue("c", "1"))
+		})
+	})
+
+	When("the store can't be listed", func() {
+		It("should return the error and keep the previous snapshot", func() {
+			poller.snapshots["store"] = map[string]string{"a": "1"}
+			tables.err = fmt.Errorf("forbidden")
+
+			err := poller.poll(context.TODO(), "store", handler)
+
+			Expect(err).Should(HaveOccurred())
+			Expect(poller.snapshots["store"]).To(Equal(map[string]string{"a": "1"}))
+		})
+	})
+
+	Context("Run", func() {
+		It("should poll the stores with listeners until the context is done", func() {
+			ctx, cancel := context.WithCancel(context.Background())
+			done := make(chan struct{})
+
+			go func() {
+				defer close(done)
+				poller.Run(ctx, &gateway.GatewayStartOpts{
+					KeyValueListenerPlugin: handler,
+					Status: &fakeStatus{
+						workers: map[string][]workers.WorkerDescription{
+							workers.TriggerKeyValue: {{Name: "store"}, {Name: "store"}},
+						},
+					},
+				})
+			}()
+
+			Eventually(tables.pollCount).Should(BeNumerically(">=", 2))
+
+			cancel()
+			Eventually(done).Should(BeClosed())
+		})
+
+		It("should not poll without listeners", func() {
+			ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+			defer cancel()
+
+			poller.Run(ctx, &gateway.GatewayStartOpts{
+				KeyValueListenerPlugin: handler,
+				Status:                 &fakeStatus{workers: map[string][]workers.WorkerDescription{}},
+			})
+
+			Expect(tables.pollCount()).To(Equal(0))
+		})
+	})
+})
@@ -18,31 +18,44 @@ import (
 	"context"
 	"encoding/base64"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"log"
+	"net/http"
 	"net/url"
+	"strings"
 	"time"
 
 	"github.com/Azure/azure-storage-queue-go/azqueue"
 	"github.com/Azure/go-autorest/autorest/adal"
 	"github.com/Azure/go-autorest/autorest/azure"
 	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/durationpb"
 
 	"github.com/nitrictech/nitric/cloud/azure/runtime/env"
 	azqueueserviceiface "github.com/nitrictech/nitric/cloud/azure/runtime/queue/iface"
 	azureutils "github.com/nitrictech/nitric/cloud/azure/runtime/utils"
+	"github.com/nitrictech/nitric/cloud/common/runtime/messages"
 	grpc_errors "github.com/nitrictech/nitric/core/pkg/grpc/errors"
 	"github.com/nitrictech/nitric/core/pkg/logger"
 
 	queuespb "github.com/nitrictech/nitric/core/pkg/proto/queues/v1"
 )
 
+// nitricMessagePrefix marks the text of messages enqueued by nitric, storage queue messages don't have attributes to mark them with
+const nitricMessagePrefix = messages.EncodingAttribute + "=" + messages.NitricEncoding + ":"
+
 // Set to 30 seconds,
 const defaultVisibilityTimeout = 30 * time.Second
 
+// Azure Storage Queues limit the visibility timeout of a message to 7 days
+const maxVisibilityTimeout = 7 * 24 * time.Hour
+
 type AzqueueQueueService struct {
-	client azqueueserviceiface.AzqueueServiceUrlIface
+	client             azqueueserviceiface.AzqueueServiceUrlIface
+	deadLetterPolicies DeadLetterPolicies
 }
 
 var _ queuespb.QueuesServer = &AzqueueQueueService{}
@@ -66,6 +79,17 @@ func (s *AzqueueQueueService) getMessageIdUrl(queue string, messageId azqueue.Me
 // 	Payload map[string]interface{}
 // }
 
+// decodeMessage decodes the text of a storage queue message, the text of messages enqueued by nitric starts with the nitricMessagePrefix
+func decodeMessage(text string) *queuespb.QueueMessage {
+	if encoded, ok := strings.CutPrefix(text, nitricMessagePrefix); ok {
+		return messages.DecodeBase64QueueMessage(encoded, map[string]string{
+			messages.EncodingAttribute: messages.NitricEncoding,
+		})
+	}
+
+	return messages.DecodeBase64QueueMessage(text, nil)
+}
+
 func (s *AzqueueQueueService) send(ctx context.Context, queueName string, req *queuespb.QueueMessage) error {
 	newErr := grpc_errors.ErrorsWithScope("AzqueueQueueService.Enqueue")
 
@@ -73,7 +97,7 @@ func (s *AzqueueQueueService) send(ctx context.Context, queueName string, req *q
 
 	// Send the tasks to the queue
 	if taskBytes, err := proto.Marshal(req); err == nil {
-		taskPayload := base64.StdEncoding.EncodeToString(taskBytes)
+		taskPayload := nitricMessagePrefix + base64.StdEncoding.EncodeToString(taskBytes)
 		if _, err := messages.Enqueue(ctx, taskPayload, 0, 0); err != nil {
 			return newErr(
 				codes.Internal,
@@ -96,6 +120,15 @@ func (s *AzqueueQueueService) Enqueue(ctx context.Context, req *queuespb.QueueEn
 	failedRequests := make([]*queuespb.FailedEnqueueMessage, 0)
 
 	for _, r := range req.Messages {
+		// Storage Queues have no ordering or deduplication, queues that require them can't be deployed to azure
+		if r.GroupKey != "" || r.DeduplicationId != "" {
+			failedRequests = append(failedRequests, &queuespb.FailedEnqueueMessage{
+				Message: r,
+				Details: "message group keys and deduplication ids are only supported by FIFO queues",
+			})
+			continue
+		}
+
 		// t := task
 		// Azure Storage Queues don't support batches, so each task must be sent individually.
 		err := s.send(ctx, req.QueueName, r)
@@ -119,8 +152,11 @@ type AzureQueueItemLease struct {
 	// The ID of the queue item
 	// note: this is an id generated by Azure, it's not the user provided unique id.
 	ID string
-	// lease id, a new popReceipt is generated each time an item is dequeued.
+	// lease id, a new popReceipt is generated each time an item is dequeued or updated.
 	PopReceipt string
+	// The encoded contents of the queue item, updating an item's visibility also replaces its contents
+	// so they're needed to extend or release the lease.
+	Text string `json:",omitempty"`
 }
 
 // String - convert the item lease struct to a string, to be returned as a NitricTask LeaseID
@@ -143,9 +179,21 @@ func leaseFromString(leaseID string) (*AzureQueueItemLease, error) {
 func (s *AzqueueQueueService) Dequeue(ctx context.Context, req *queuespb.QueueDequeueRequest) (*queuespb.QueueDequeueResponse, error) {
 	newErr := grpc_errors.ErrorsWithScope("AzqueueQueueService.Dequeue")
 
-	messages := s.getMessagesUrl(req.QueueName)
+	visibilityTimeout := defaultVisibilityTimeout
+	if req.VisibilityTimeout != nil {
+		if err := validateVisibilityTimeout(req.VisibilityTimeout); err != nil || req.VisibilityTimeout.AsDuration() < time.Second {
+			return nil, newErr(
+				codes.InvalidArgument,
+				fmt.Sprintf("visibility timeout must be between 1 second and %s", maxVisibilityTimeout),
+				err,
+			)
+		}
+		visibilityTimeout = req.VisibilityTimeout.AsDuration()
+	}
+
+	messagesUrl := s.getMessagesUrl(req.QueueName)
 
-	dequeueResp, err := messages.Dequeue(ctx, req.Depth, defaultVisibilityTimeout)
+	dequeueResp, err := messagesUrl.Dequeue(ctx, req.Depth, visibilityTimeout)
 	if err != nil {
 		return nil, newErr(
 			codes.Internal,
@@ -164,32 +212,24 @@ func (s *AzqueueQueueService) Dequeue(ctx context.Context, req *queuespb.QueueDe
 	tasks := []*queuespb.DequeuedMessage{}
 	for i := int32(0); i < dequeueResp.NumMessages(); i++ {
 		m := dequeueResp.Message(i)
-		var queueMessage queuespb.QueueMessage
 
-		fmt.Printf("deserializing payload: %s", m.Text)
+		if policy, ok := s.deadLetterPolicies[req.QueueName]; ok && m.DequeueCount > policy.MaxDequeueCount {
+			err := s.deadLetter(ctx, req.QueueName, policy, m)
+			if err == nil {
+				continue
+			}
 
-		// bytePayload := []byte(m.Text)
-		bytePayload, err := base64.StdEncoding.DecodeString(m.Text)
-		if err != nil {
-			return nil, newErr(
-				codes.Internal,
-				"failed to decode queue item payload",
-				err,
-			)
+			// the message is still delivered, dead-lettering is retried on its next delivery
+			logger.Errorf("failed to move message %s to dead-letter queue %s: %s", m.ID, policy.TargetQueue, err.Error())
 		}
 
-		err = proto.Unmarshal(bytePayload, &queueMessage)
-		if err != nil {
-			// This item could have its visibility timeout reset and be requeued.
-			// However, that risks the unprocessable items being reprocessed immediately,
-			// causing a loop where the receiver frequently attempts to receive the same item.
-			logger.Errorf("failed to deserialize queue item payload: %s", err.Error())
-			continue
-		}
+		// messages sent to the queue by other producers are delivered as bytes payloads
+		queueMessage := decodeMessage(m.Text)
 
 		lease := AzureQueueItemLease{
 			ID:         m.ID.String(),
 			PopReceipt: m.PopReceipt.String(),
+			Text:       m.Text,
 		}
 		leaseID, err := lease.String()
 		// This should never happen, it's a fatal error
@@ -202,8 +242,9 @@ func (s *AzqueueQueueService) Dequeue(ctx context.Context, req *queuespb.QueueDe
 		}
 
 		tasks = append(tasks, &queuespb.DequeuedMessage{
-			LeaseId: leaseID,
-			Message: &queueMessage,
+			LeaseId:         leaseID,
+			Message:         queueMessage,
+			DeliveryAttempt: int32(m.DequeueCount),
 		})
 	}
 
@@ -239,6 +280,103 @@ func (s *AzqueueQueueService) Complete(ctx context.Context, req *queuespb.QueueC
 	return &queuespb.QueueCompleteResponse{}, nil
 }
 
+// ExtendLease - Extends the lease of a previously popped queue item, its pop receipt changes so a new lease id is returned
+func (s *AzqueueQueueService) ExtendLease(ctx context.Context, req *queuespb.QueueExtendLeaseRequest) (*queuespb.QueueExtendLeaseResponse, error) {
+	newErr := grpc_errors.ErrorsWithScope("AzqueueQueueService.ExtendLease")
+
+	if err := validateVisibilityTimeout(req.VisibilityTimeout); err != nil || req.VisibilityTimeout.AsDuration() < time.Second {
+		return nil, newErr(
+			codes.InvalidArgument,
+			fmt.Sprintf("visibility timeout must be between 1 second and %s", maxVisibilityTimeout),
+			err,
+		)
+	}
+
+	leaseID, err := s.updateVisibility(ctx, req.QueueName, req.LeaseId, req.VisibilityTimeout.AsDuration())
+	if err != nil {
+		return nil, newErr(
+			status.Code(err),
+			"failed to extend lease",
+			err,
+		)
+	}
+
+	return &queuespb.QueueExtendLeaseResponse{
+		LeaseId: leaseID,
+	}, nil
+}
+
+// Release - Returns a previously popped queue item to the queue, it becomes visible again once the delay has passed
+func (s *AzqueueQueueService) Release(ctx context.Context, req *queuespb.QueueReleaseRequest) (*queuespb.QueueReleaseResponse, error) {
+	newErr := grpc_errors.ErrorsWithScope("AzqueueQueueService.Release")
+
+	if req.Delay != nil {
+		if err := validateVisibilityTimeout(req.Delay); err != nil {
+			return nil, newErr(
+				codes.InvalidArgument,
+				fmt.Sprintf("delay must be between 0 and %s", maxVisibilityTimeout),
+				err,
+			)
+		}
+	}
+
+	if _, err := s.updateVisibility(ctx, req.QueueName, req.LeaseId, req.Delay.AsDuration()); err != nil {
+		return nil, newErr(
+			status.Code(err),
+			"failed to release message",
+			err,
+		)
+	}
+
+	return &queuespb.QueueReleaseResponse{}, nil
+}
+
+// updateVisibility sets how long from now a leased queue item stays hidden, returning the lease id for its new pop receipt
+func (s *AzqueueQueueService) updateVisibility(ctx context.Context, queueName string, leaseID string, visibilityTimeout time.Duration) (string, error) {
+	lease, err := leaseFromString(leaseID)
+	if err != nil {
+		return "", status.Errorf(codes.InvalidArgument, "failed to unmarshal lease id value: %v", err)
+	}
+
+	// the contents are replaced by the update, leases without them would clear the item
+	if lease.Text == "" {
+		return "", status.Errorf(codes.InvalidArgument, "lease id doesn't contain the message contents, it may be from an earlier version")
+	}
+
+	task := s.getMessageIdUrl(queueName, azqueue.MessageID(lease.ID))
+	resp, err := task.Update(ctx, azqueue.PopReceipt(lease.PopReceipt), visibilityTimeout, lease.Text)
+	if err != nil {
+		var storageErr azqueue.StorageError
+		if errors.As(err, &storageErr) && storageErr.Response() != nil && storageErr.Response().StatusCode == http.StatusNotFound {
+			return "", status.Errorf(codes.NotFound, "lease not found, it may have expired: %v", err)
+		}
+
+		return "", status.Errorf(codes.Internal, "%v", err)
+	}
+
+	lease.PopReceipt = resp.PopReceipt.String()
+
+	newLeaseID, err := lease.String()
+	if err != nil {
+		return "", status.Errorf(codes.Internal, "failed to construct queue item lease id: %v", err)
+	}
+
+	return newLeaseID, nil
+}
+
+// validateVisibilityTimeout returns an error if a duration isn't within the visibility timeout limits of Azure Storage Queues
+func validateVisibilityTimeout(duration *durationpb.Duration) error {
+	if err := duration.CheckValid(); err != nil {
+		return err
+	}
+
+	if duration.AsDuration() < 0 || duration.AsDuration() > maxVisibilityTimeout {
+		return fmt.Errorf("duration %s is out of range", duration.AsDuration())
+	}
+
+	return nil
+}
+
 const expiryBuffer = 2 * time.Minute
 
 func tokenRefresherFromSpt(spt *adal.ServicePrincipalToken) azqueue.TokenRefresher {
@@ -276,11 +414,17 @@ func New() (*AzqueueQueueService, error) {
 		return nil, err
 	}
 
+	deadLetterPolicies, err := ParseDeadLetterPolicies(env.AZURE_QUEUE_DEAD_LETTER_POLICIES.String())
+	if err != nil {
+		return nil, err
+	}
+
 	pipeline := azqueue.NewPipeline(cTkn, azqueue.PipelineOptions{})
 	client := azqueue.NewServiceURL(*accountURL, pipeline)
 
 	return &AzqueueQueueService{
-		client: azqueueserviceiface.AdaptServiceUrl(client),
+		client:             azqueueserviceiface.AdaptServiceUrl(client),
+		deadLetterPolicies: deadLetterPolicies,
 	}, nil
 }
 
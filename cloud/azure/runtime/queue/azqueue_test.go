@@ -23,6 +23,7 @@ import (
 	azqueue "github.com/Azure/azure-storage-queue-go/azqueue"
 	"github.com/golang/mock/gomock"
 	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/durationpb"
 	"google.golang.org/protobuf/types/known/structpb"
 
 	. "github.com/onsi/ginkgo"
@@ -43,7 +44,7 @@ var _ = Describe("Azqueue", func() {
 	}
 
 	testPayloadBytes, err := proto.Marshal(testStruct)
-	testB64Payload := base64.StdEncoding.EncodeToString(testPayloadBytes)
+	testB64Payload := nitricMessagePrefix + base64.StdEncoding.EncodeToString(testPayloadBytes)
 	Expect(err).To(BeNil())
 
 	Context("Send", func() {
@@ -137,6 +138,39 @@ var _ = Describe("Azqueue", func() {
 				crtl.Finish()
 			})
 		})
+
+		When("Sending a task with FIFO options", func() {
+			crtl := gomock.NewController(GinkgoT())
+			mockAzqueue := mock_azqueue.NewMockAzqueueServiceUrlIface(crtl)
+
+			queuePlugin := &AzqueueQueueService{
+				client: mockAzqueue,
+			}
+
+			It("should return the task as failed without sending it", func() {
+				By("Not retrieving the Queue URL")
+				mockAzqueue.EXPECT().NewQueueURL(gomock.Any()).Times(0)
+
+				resp, err := queuePlugin.Enqueue(context.TODO(), &queuepb.QueueEnqueueRequest{
+					QueueName: "test-queue",
+					Messages: []*queuepb.QueueMessage{
+						{
+							Content:  testStruct.Content,
+							GroupKey: "test-group",
+						},
+					},
+				})
+
+				By("Not returning an error")
+				Expect(err).ToNot(HaveOccurred())
+
+				By("Returning the failed task")
+				Expect(resp.FailedMessages).To(HaveLen(1))
+				Expect(resp.FailedMessages[0].Details).To(ContainSubstring("only supported by FIFO queues"))
+
+				crtl.Finish()
+			})
+		})
 	})
 
 	Context("Receive", func() {
@@ -173,7 +207,7 @@ var _ = Describe("Azqueue", func() {
 					// ExpirationTime:  time.Time{},
 					PopReceipt:      "popreceipt",
 					NextVisibleTime: time.Time{},
-					DequeueCount:    0,
+					DequeueCount:    3,
 					Text:            testB64Payload,
 				})
 
@@ -189,6 +223,64 @@ var _ = Describe("Azqueue", func() {
 				Expect(len(resp.Messages)).To(Equal(1))
 				Expect(resp.Messages[0].Message.GetStructPayload().AsMap()).To(Equal(map[string]interface{}{"Test": "Test"}))
 
+				By("Returning the delivery attempt")
+				Expect(resp.Messages[0].DeliveryAttempt).To(Equal(int32(3)))
+
+				crtl.Finish()
+			})
+		})
+
+		When("a message exceeds the max dequeue count of the queue's dead-letter policy", func() {
+			crtl := gomock.NewController(GinkgoT())
+			mockAzqueue := mock_azqueue.NewMockAzqueueServiceUrlIface(crtl)
+			mockQueue := mock_azqueue.NewMockAzqueueQueueUrlIface(crtl)
+			mockDeadLetterQueue := mock_azqueue.NewMockAzqueueQueueUrlIface(crtl)
+			mockMessages := mock_azqueue.NewMockAzqueueMessageUrlIface(crtl)
+			mockDeadLetterMessages := mock_azqueue.NewMockAzqueueMessageUrlIface(crtl)
+			mockDequeueResp := mock_azqueue.NewMockDequeueMessagesResponseIface(crtl)
+			mockMessageId := mock_azqueue.NewMockAzqueueMessageIdUrlIface(crtl)
+
+			queuePlugin := &AzqueueQueueService{
+				client: mockAzqueue,
+				deadLetterPolicies: DeadLetterPolicies{
+					"test-queue": {TargetQueue: "test-dlq", MaxDequeueCount: 2},
+				},
+			}
+
+			It("should move the message to the dead-letter queue", func() {
+				By("Dequeuing from the requested queue")
+				mockAzqueue.EXPECT().NewQueueURL("test-queue").Times(2).Return(mockQueue)
+				mockQueue.EXPECT().NewMessageURL().Times(2).Return(mockMessages)
+				mockMessages.EXPECT().Dequeue(gomock.Any(), int32(1), 30*time.Second).Times(1).Return(mockDequeueResp, nil)
+
+				mockDequeueResp.EXPECT().NumMessages().AnyTimes().Return(int32(1))
+				mockDequeueResp.EXPECT().Message(int32(0)).Times(1).Return(&azqueue.DequeuedMessage{
+					ID:           "testid",
+					PopReceipt:   "popreceipt",
+					DequeueCount: 3,
+					Text:         testB64Payload,
+				})
+
+				By("Enqueuing the message on the dead-letter queue")
+				mockAzqueue.EXPECT().NewQueueURL("test-dlq").Times(1).Return(mockDeadLetterQueue)
+				mockDeadLetterQueue.EXPECT().NewMessageURL().Times(1).Return(mockDeadLetterMessages)
+				mockDeadLetterMessages.EXPECT().Enqueue(gomock.Any(), testB64Payload, time.Duration(0), time.Duration(0)).Times(1).Return(nil, nil)
+
+				By("Deleting the message from the source queue")
+				mockMessages.EXPECT().NewMessageIDURL(azqueue.MessageID("testid")).Times(1).Return(mockMessageId)
+				mockMessageId.EXPECT().Delete(gomock.Any(), azqueue.PopReceipt("popreceipt")).Times(1).Return(nil, nil)
+
+				resp, err := queuePlugin.Dequeue(context.TODO(), &queuepb.QueueDequeueRequest{
+					QueueName: "test-queue",
+					Depth:     1,
+				})
+
+				By("Not returning an error")
+				Expect(err).ToNot(HaveOccurred())
+
+				By("Not returning the dead-lettered message")
+				Expect(resp.Messages).To(BeEmpty())
+
 				crtl.Finish()
 			})
 		})
@@ -315,4 +407,95 @@ var _ = Describe("Azqueue", func() {
 			})
 		})
 	})
+
+	Context("ExtendLease", func() {
+		When("Azure returns a successfully response", func() {
+			crtl := gomock.NewController(GinkgoT())
+			mockAzqueue := mock_azqueue.NewMockAzqueueServiceUrlIface(crtl)
+			mockQueue := mock_azqueue.NewMockAzqueueQueueUrlIface(crtl)
+			mockMessages := mock_azqueue.NewMockAzqueueMessageUrlIface(crtl)
+			mockMessageId := mock_azqueue.NewMockAzqueueMessageIdUrlIface(crtl)
+
+			queuePlugin := &AzqueueQueueService{
+				client: mockAzqueue,
+			}
+
+			It("should return a lease id with the new pop receipt", func() {
+				mockAzqueue.EXPECT().NewQueueURL("test-queue").Times(1).Return(mockQueue)
+				mockQueue.EXPECT().NewMessageURL().Times(1).Return(mockMessages)
+
+				lease := AzureQueueItemLease{
+					ID:         "testid",
+					PopReceipt: "testreceipt",
+					Text:       testB64Payload,
+				}
+				leaseStr, _ := lease.String()
+
+				By("Updating the visibility of the message without changing its contents")
+				mockMessages.EXPECT().NewMessageIDURL(azqueue.MessageID("testid")).Times(1).Return(mockMessageId)
+				mockMessageId.EXPECT().Update(gomock.Any(), azqueue.PopReceipt("testreceipt"), 2*time.Minute, testB64Payload).Times(1).Return(&azqueue.UpdatedMessageResponse{
+					PopReceipt: "newreceipt",
+				}, nil)
+
+				resp, err := queuePlugin.ExtendLease(context.TODO(), &queuepb.QueueExtendLeaseRequest{
+					QueueName:         "test-queue",
+					LeaseId:           leaseStr,
+					VisibilityTimeout: durationpb.New(2 * time.Minute),
+				})
+
+				By("Not returning an error")
+				Expect(err).ToNot(HaveOccurred())
+
+				By("Returning the new lease id")
+				newLease, err := leaseFromString(resp.LeaseId)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(newLease.PopReceipt).To(Equal("newreceipt"))
+				Expect(newLease.Text).To(Equal(testB64Payload))
+
+				crtl.Finish()
+			})
+		})
+	})
+
+	Context("Release", func() {
+		When("A delay is provided", func() {
+			crtl := gomock.NewController(GinkgoT())
+			mockAzqueue := mock_azqueue.NewMockAzqueueServiceUrlIface(crtl)
+			mockQueue := mock_azqueue.NewMockAzqueueQueueUrlIface(crtl)
+			mockMessages := mock_azqueue.NewMockAzqueueMessageUrlIface(crtl)
+			mockMessageId := mock_azqueue.NewMockAzqueueMessageIdUrlIface(crtl)
+
+			queuePlugin := &AzqueueQueueService{
+				client: mockAzqueue,
+			}
+
+			It("should hide the message until the delay has passed", func() {
+				mockAzqueue.EXPECT().NewQueueURL("test-queue").Times(1).Return(mockQueue)
+				mockQueue.EXPECT().NewMessageURL().Times(1).Return(mockMessages)
+
+				lease := AzureQueueItemLease{
+					ID:         "testid",
+					PopReceipt: "testreceipt",
+					Text:       testB64Payload,
+				}
+				leaseStr, _ := lease.String()
+
+				mockMessages.EXPECT().NewMessageIDURL(azqueue.MessageID("testid")).Times(1).Return(mockMessageId)
+				mockMessageId.EXPECT().Update(gomock.Any(), azqueue.PopReceipt("testreceipt"), 10*time.Second, testB64Payload).Times(1).Return(&azqueue.UpdatedMessageResponse{
+					PopReceipt: "newreceipt",
+				}, nil)
+
+				_, err := queuePlugin.Release(context.TODO(), &queuepb.QueueReleaseRequest{
+					QueueName: "test-queue",
+					LeaseId:   leaseStr,
+					Delay:     durationpb.New(10 * time.Second),
+				})
+
+				By("Not returning an error")
+				Expect(err).ToNot(HaveOccurred())
+
+				crtl.Finish()
+			})
+		})
+	})
 })
@@ -0,0 +1,60 @@
+// Copyright 2021 Nitric Technologies Pty Ltd.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package queue
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/Azure/azure-storage-queue-go/azqueue"
+)
+
+// DeadLetterPolicy - moves messages to the target queue once they've been dequeued more than MaxDequeueCount times
+//
+//	Azure Storage Queues don't support dead-lettering, so the policies are provided by the deployment and applied during Dequeue.
+type DeadLetterPolicy struct {
+	TargetQueue     string `json:"targetQueue"`
+	MaxDequeueCount int64  `json:"maxDequeueCount"`
+}
+
+// DeadLetterPolicies - dead-letter policies keyed by the name of the source queue
+type DeadLetterPolicies map[string]DeadLetterPolicy
+
+// ParseDeadLetterPolicies - parse dead-letter policies from their JSON representation, an empty string has no policies
+func ParseDeadLetterPolicies(policiesJson string) (DeadLetterPolicies, error) {
+	policies := DeadLetterPolicies{}
+
+	if policiesJson == "" {
+		return policies, nil
+	}
+
+	if err := json.Unmarshal([]byte(policiesJson), &policies); err != nil {
+		return nil, fmt.Errorf("invalid queue dead-letter policies: %w", err)
+	}
+
+	return policies, nil
+}
+
+// deadLetter - move a message to the dead-letter target queue, removing it from the source queue
+func (s *AzqueueQueueService) deadLetter(ctx context.Context, queueName string, policy DeadLetterPolicy, m *azqueue.DequeuedMessage) error {
+	if _, err := s.getMessagesUrl(policy.TargetQueue).Enqueue(ctx, m.Text, 0, 0); err != nil {
+		return err
+	}
+
+	_, err := s.getMessageIdUrl(queueName, m.ID).Delete(ctx, m.PopReceipt)
+
+	return err
+}
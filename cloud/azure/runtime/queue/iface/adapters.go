@@ -79,6 +79,10 @@ func (c messageIdUrl) Delete(ctx context.Context, popReceipt azqueue.PopReceipt)
 	return c.c.Delete(ctx, popReceipt)
 }
 
+func (c messageIdUrl) Update(ctx context.Context, popReceipt azqueue.PopReceipt, visibilityTimeout time.Duration, message string) (*azqueue.UpdatedMessageResponse, error) {
+	return c.c.Update(ctx, popReceipt, visibilityTimeout, message)
+}
+
 func (c dequeueMessagesResponse) NumMessages() int32 {
 	return c.c.NumMessages()
 }
@@ -37,6 +37,7 @@ type AzqueueMessageUrlIface interface {
 
 type AzqueueMessageIdUrlIface interface {
 	Delete(ctx context.Context, popReceipt azqueue.PopReceipt) (*azqueue.MessageIDDeleteResponse, error)
+	Update(ctx context.Context, popReceipt azqueue.PopReceipt, visibilityTimeout time.Duration, message string) (*azqueue.UpdatedMessageResponse, error)
 }
 
 type DequeueMessagesResponseIface interface {
@@ -17,12 +17,15 @@ package secret
 import (
 	"context"
 	"fmt"
+	"slices"
 	"strings"
+	"time"
 
 	"github.com/Azure/azure-sdk-for-go/services/keyvault/v7.1/keyvault"
 	"github.com/Azure/go-autorest/autorest"
 	"github.com/Azure/go-autorest/autorest/azure"
 	"google.golang.org/grpc/codes"
+	"google.golang.org/protobuf/types/known/timestamppb"
 
 	"github.com/nitrictech/nitric/cloud/azure/runtime/env"
 	azureutils "github.com/nitrictech/nitric/cloud/azure/runtime/utils"
@@ -33,6 +36,8 @@ import (
 type KeyVaultClient interface {
 	SetSecret(ctx context.Context, vaultBaseURL string, secretName string, parameters keyvault.SecretSetParameters) (result keyvault.SecretBundle, err error)
 	GetSecret(ctx context.Context, vaultBaseURL string, secretName string, secretVersion string) (result keyvault.SecretBundle, err error)
+	GetSecretVersions(ctx context.Context, vaultBaseURL string, secretName string, maxresults *int32) (result keyvault.SecretListResultPage, err error)
+	UpdateSecret(ctx context.Context, vaultBaseURL string, secretName string, secretVersion string, parameters keyvault.SecretUpdateParameters) (result keyvault.SecretBundle, err error)
 }
 
 // KeyVaultSecretService - Nitric Secret Service implementation for Azure Key Vault
@@ -50,13 +55,109 @@ func versionIdFromUrl(versionUrl string) string {
 	return urlParts[len(urlParts)-1]
 }
 
+// vaultBaseUrl - returns the base url of the key vault, e.g. https://myvault.vault.azure.net
+func (s *KeyVaultSecretService) vaultBaseUrl() string {
+	return fmt.Sprintf("https://%s.vault.azure.net", s.vaultName)
+}
+
+// isSecretDisabledErr - returns true if key vault refused an operation because the secret version is disabled
+func isSecretDisabledErr(err error) bool {
+	return strings.Contains(err.Error(), "SecretDisabled")
+}
+
+// versionState - determines the state of a secret version from its attributes
+func versionState(item keyvault.SecretItem) secretpb.SecretVersionState {
+	if item.Attributes != nil && item.Attributes.Enabled != nil && !*item.Attributes.Enabled {
+		return secretpb.SecretVersionState_Disabled
+	}
+
+	return secretpb.SecretVersionState_Enabled
+}
+
+// createdTime - returns the time a secret version was created
+func createdTime(item keyvault.SecretItem) time.Time {
+	if item.Attributes == nil || item.Attributes.Created == nil {
+		return time.Time{}
+	}
+
+	return time.Time(*item.Attributes.Created)
+}
+
+// listVersions - returns every version of a secret
+func (s *KeyVaultSecretService) listVersions(ctx context.Context, secretName string) ([]keyvault.SecretItem, error) {
+	page, err := s.client.GetSecretVersions(ctx, s.vaultBaseUrl(), secretName, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	items := []keyvault.SecretItem{}
+	for page.NotDone() {
+		items = append(items, page.Values()...)
+
+		if err := page.NextWithContext(ctx); err != nil {
+			return nil, err
+		}
+	}
+
+	return items, nil
+}
+
+// findVersion - returns a version of a secret, versions are listed because disabled versions can't be retrieved directly
+func (s *KeyVaultSecretService) findVersion(ctx context.Context, newErr grpc_errors.ScopedErrorFactory, sv *secretpb.SecretVersion) (*keyvault.SecretItem, error) {
+	if len(sv.GetSecret().GetName()) == 0 || len(sv.GetVersion()) == 0 {
+		return nil, newErr(
+			codes.InvalidArgument,
+			"invalid secret version",
+			fmt.Errorf("provide non-blank secret name and version"),
+		)
+	}
+
+	items, err := s.listVersions(ctx, sv.Secret.Name)
+	if err != nil {
+		return nil, newErr(
+			codes.Internal,
+			"failed to list secret versions",
+			err,
+		)
+	}
+
+	for _, item := range items {
+		if item.ID != nil && versionIdFromUrl(*item.ID) == sv.Version {
+			return &item, nil
+		}
+	}
+
+	return nil, newErr(
+		codes.NotFound,
+		"secret version not found",
+		fmt.Errorf("secret %s has no version %s", sv.Secret.Name, sv.Version),
+	)
+}
+
+// updateVersion - enables or disables a secret version
+func (s *KeyVaultSecretService) updateVersion(ctx context.Context, sv *secretpb.SecretVersion, enabled bool) error {
+	_, err := s.client.UpdateSecret(
+		ctx,
+		s.vaultBaseUrl(),
+		sv.Secret.Name,
+		sv.Version,
+		keyvault.SecretUpdateParameters{
+			SecretAttributes: &keyvault.SecretAttributes{
+				Enabled: &enabled,
+			},
+		},
+	)
+
+	return err
+}
+
 func (s *KeyVaultSecretService) Put(ctx context.Context, req *secretpb.SecretPutRequest) (*secretpb.SecretPutResponse, error) {
 	newErr := grpc_errors.ErrorsWithScope("KeyVaultSecretService.Put")
 	stringVal := string(req.Value[:])
 
 	result, err := s.client.SetSecret(
 		ctx,
-		fmt.Sprintf("https://%s.vault.azure.net", s.vaultName),
+		s.vaultBaseUrl(),
 		req.Secret.Name,
 		keyvault.SecretSetParameters{
 			Value: &stringVal,
@@ -90,11 +191,19 @@ func (s *KeyVaultSecretService) Access(ctx context.Context, req *secretpb.Secret
 	}
 	result, err := s.client.GetSecret(
 		ctx,
-		fmt.Sprintf("https://%s.vault.azure.net", s.vaultName), // https://myvault.vault.azure.net.
+		s.vaultBaseUrl(), // https://myvault.vault.azure.net.
 		req.SecretVersion.Secret.Name,
 		version,
 	)
 	if err != nil {
+		if isSecretDisabledErr(err) {
+			return nil, newErr(
+				codes.FailedPrecondition,
+				"secret version is not enabled",
+				err,
+			)
+		}
+
 		return nil, newErr(
 			codes.Internal,
 			"failed to access secret",
@@ -115,6 +224,98 @@ func (s *KeyVaultSecretService) Access(ctx context.Context, req *secretpb.Secret
 	}, nil
 }
 
+// ListVersions - Lists the versions of a secret, newest first
+func (s *KeyVaultSecretService) ListVersions(ctx context.Context, req *secretpb.SecretListVersionsRequest) (*secretpb.SecretListVersionsResponse, error) {
+	newErr := grpc_errors.ErrorsWithScope("KeyVaultSecretService.ListVersions")
+
+	if len(req.GetSecret().GetName()) == 0 {
+		return nil, newErr(
+			codes.InvalidArgument,
+			"invalid secret",
+			fmt.Errorf("provide non-blank secret name"),
+		)
+	}
+
+	items, err := s.listVersions(ctx, req.Secret.Name)
+	if err != nil {
+		return nil, newErr(
+			codes.Internal,
+			"failed to list secret versions",
+			err,
+		)
+	}
+
+	slices.SortFunc(items, func(a, b keyvault.SecretItem) int {
+		return createdTime(b).Compare(createdTime(a))
+	})
+
+	versions := make([]*secretpb.SecretVersionDetails, 0, len(items))
+	for _, item := range items {
+		versions = append(versions, &secretpb.SecretVersionDetails{
+			SecretVersion: &secretpb.SecretVersion{
+				Secret:  req.Secret,
+				Version: versionIdFromUrl(*item.ID),
+			},
+			CreateTime: timestamppb.New(createdTime(item)),
+			State:      versionState(item),
+		})
+	}
+
+	return &secretpb.SecretListVersionsResponse{
+		Versions: versions,
+	}, nil
+}
+
+// DisableVersion - Disables a version of a secret
+func (s *KeyVaultSecretService) DisableVersion(ctx context.Context, req *secretpb.SecretDisableVersionRequest) (*secretpb.SecretDisableVersionResponse, error) {
+	newErr := grpc_errors.ErrorsWithScope("KeyVaultSecretService.DisableVersion")
+
+	if _, err := s.findVersion(ctx, newErr, req.SecretVersion); err != nil {
+		return nil, err
+	}
+
+	if err := s.updateVersion(ctx, req.SecretVersion, false); err != nil {
+		return nil, newErr(
+			codes.Internal,
+			"failed to disable secret version",
+			err,
+		)
+	}
+
+	return &secretpb.SecretDisableVersionResponse{}, nil
+}
+
+// EnableVersion - Enables a disabled version of a secret
+func (s *KeyVaultSecretService) EnableVersion(ctx context.Context, req *secretpb.SecretEnableVersionRequest) (*secretpb.SecretEnableVersionResponse, error) {
+	newErr := grpc_errors.ErrorsWithScope("KeyVaultSecretService.EnableVersion")
+
+	if _, err := s.findVersion(ctx, newErr, req.SecretVersion); err != nil {
+		return nil, err
+	}
+
+	if err := s.updateVersion(ctx, req.SecretVersion, true); err != nil {
+		return nil, newErr(
+			codes.Internal,
+			"failed to enable secret version",
+			err,
+		)
+	}
+
+	return &secretpb.SecretEnableVersionResponse{}, nil
+}
+
+// DestroyVersion - Key Vault can only delete every version of a secret together, so single versions can't be destroyed.
+// Use DisableVersion to prevent a version being accessed instead
+func (s *KeyVaultSecretService) DestroyVersion(ctx context.Context, req *secretpb.SecretDestroyVersionRequest) (*secretpb.SecretDestroyVersionResponse, error) {
+	newErr := grpc_errors.ErrorsWithScope("KeyVaultSecretService.DestroyVersion")
+
+	return nil, newErr(
+		codes.Unimplemented,
+		"key vault can't destroy a single secret version, disable the version instead",
+		nil,
+	)
+}
+
 // New - Creates a new Nitric secret service with Azure Key Vault Provider
 func New() (*KeyVaultSecretService, error) {
 	vaultName := env.KVAULT_NAME.String()
@@ -17,9 +17,13 @@ package secret
 import (
 	"context"
 	"fmt"
+	"time"
 
 	"github.com/Azure/azure-sdk-for-go/services/keyvault/v7.1/keyvault"
+	"github.com/Azure/go-autorest/autorest/date"
 	"github.com/golang/mock/gomock"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/gomega"
@@ -162,4 +166,111 @@ var _ = Describe("Key Vault", func() {
 			})
 		})
 	})
+
+	When("ListVersions", func() {
+		When("The secret has versions", func() {
+			ctrl := gomock.NewController(GinkgoT())
+			mockSecretClient := mocks.NewMockKeyVaultClient(ctrl)
+			secretPlugin := NewWithClient(mockSecretClient)
+			It("Should return the versions newest first", func() {
+				defer ctrl.Finish()
+
+				enabled := true
+				disabled := false
+				olderID := "https://localvault.vault.azure.net/secret/secret-name/older"
+				newerID := "https://localvault.vault.azure.net/secret/secret-name/newer"
+				older := date.UnixTime(time.Unix(100, 0))
+				newer := date.UnixTime(time.Unix(200, 0))
+
+				By("listing the secret versions from key vault")
+				mockSecretClient.EXPECT().GetSecretVersions(
+					context.TODO(),
+					"https://localvault.vault.azure.net",
+					secretName,
+					nil,
+				).Return(keyvault.NewSecretListResultPage(keyvault.SecretListResult{
+					Value: &[]keyvault.SecretItem{{
+						ID:         &olderID,
+						Attributes: &keyvault.SecretAttributes{Enabled: &disabled, Created: &older},
+					}, {
+						ID:         &newerID,
+						Attributes: &keyvault.SecretAttributes{Enabled: &enabled, Created: &newer},
+					}},
+				}, func(context.Context, keyvault.SecretListResult) (keyvault.SecretListResult, error) {
+					return keyvault.SecretListResult{}, nil
+				}), nil).Times(1)
+
+				response, err := secretPlugin.ListVersions(context.TODO(), &secretpb.SecretListVersionsRequest{
+					Secret: testSecret,
+				})
+				By("Not returning an error")
+				Expect(err).ShouldNot(HaveOccurred())
+
+				By("Returning the versions ordered by their created time")
+				Expect(response.Versions).To(HaveLen(2))
+				Expect(response.Versions[0].SecretVersion.Version).To(Equal("newer"))
+				Expect(response.Versions[0].State).To(Equal(secretpb.SecretVersionState_Enabled))
+				Expect(response.Versions[1].SecretVersion.Version).To(Equal("older"))
+				Expect(response.Versions[1].State).To(Equal(secretpb.SecretVersionState_Disabled))
+			})
+		})
+	})
+
+	When("DestroyVersion", func() {
+		ctrl := gomock.NewController(GinkgoT())
+		mockSecretClient := mocks.NewMockKeyVaultClient(ctrl)
+		secretPlugin := NewWithClient(mockSecretClient)
+		It("Should return an unimplemented error without changing the secret version", func() {
+			defer ctrl.Finish()
+
+			_, err := secretPlugin.DestroyVersion(context.TODO(), &secretpb.SecretDestroyVersionRequest{
+				SecretVersion: testSecretVersion,
+			})
+			By("Returning an error")
+			Expect(status.Code(err)).To(Equal(codes.Unimplemented))
+		})
+	})
+
+	When("EnableVersion", func() {
+		When("The secret version exists", func() {
+			ctrl := gomock.NewController(GinkgoT())
+			mockSecretClient := mocks.NewMockKeyVaultClient(ctrl)
+			secretPlugin := NewWithClient(mockSecretClient)
+			It("Should enable the secret version", func() {
+				defer ctrl.Finish()
+
+				disabled := false
+				mockSecretClient.EXPECT().GetSecretVersions(
+					context.TODO(),
+					"https://localvault.vault.azure.net",
+					secretName,
+					nil,
+				).Return(keyvault.NewSecretListResultPage(keyvault.SecretListResult{
+					Value: &[]keyvault.SecretItem{{
+						ID:         &secretID,
+						Attributes: &keyvault.SecretAttributes{Enabled: &disabled},
+					}},
+				}, func(context.Context, keyvault.SecretListResult) (keyvault.SecretListResult, error) {
+					return keyvault.SecretListResult{}, nil
+				}), nil).Times(1)
+
+				enabled := true
+				mockSecretClient.EXPECT().UpdateSecret(
+					context.TODO(),
+					"https://localvault.vault.azure.net",
+					secretName,
+					secretVersion,
+					keyvault.SecretUpdateParameters{
+						SecretAttributes: &keyvault.SecretAttributes{Enabled: &enabled},
+					},
+				).Return(keyvault.SecretBundle{}, nil).Times(1)
+
+				_, err := secretPlugin.EnableVersion(context.TODO(), &secretpb.SecretEnableVersionRequest{
+					SecretVersion: testSecretVersion,
+				})
+				By("Not returning an error")
+				Expect(err).ShouldNot(HaveOccurred())
+			})
+		})
+	})
 })
@@ -15,7 +15,11 @@
 package runtime
 
 import (
+	"fmt"
+	"time"
+
 	"github.com/nitrictech/nitric/cloud/azure/runtime/api"
+	"github.com/nitrictech/nitric/cloud/azure/runtime/env"
 	az_gateway "github.com/nitrictech/nitric/cloud/azure/runtime/gateway"
 	"github.com/nitrictech/nitric/cloud/azure/runtime/keyvalue"
 	"github.com/nitrictech/nitric/cloud/azure/runtime/queue"
@@ -25,6 +29,7 @@ import (
 	az_storage "github.com/nitrictech/nitric/cloud/azure/runtime/storage"
 	"github.com/nitrictech/nitric/cloud/azure/runtime/topic"
 	"github.com/nitrictech/nitric/core/pkg/server"
+	queueworkers "github.com/nitrictech/nitric/core/pkg/workers/queues"
 )
 
 func NewAzureRuntimeServer(resourcesPlugin resource.AzResourceResolver, opts ...server.ServerOption) (*server.NitricServer, error) {
@@ -34,6 +39,14 @@ func NewAzureRuntimeServer(resourcesPlugin resource.AzResourceResolver, opts ...
 	storagePlugin, _ := az_storage.New()
 	queuesPlugin, _ := queue.New()
 	gatewayPlugin, _ := az_gateway.New(resourcesPlugin)
+	if keyValuePlugin != nil {
+		pollInterval, err := env.AZURE_KEYVALUE_POLL_INTERVAL.Int()
+		if err != nil || pollInterval <= 0 {
+			return nil, fmt.Errorf("invalid AZURE_KEYVALUE_POLL_INTERVAL %q, expected a positive number of seconds", env.AZURE_KEYVALUE_POLL_INTERVAL.String())
+		}
+
+		gatewayPlugin = az_gateway.WithKeyValuePolling(gatewayPlugin, keyValuePlugin.NewChangePoller(time.Duration(pollInterval)*time.Second))
+	}
 	apiPlugin := api.NewAzureApiGatewayProvider(resourcesPlugin)
 
 	sqlPlugin, _ := sql_service.New()
@@ -49,6 +62,11 @@ func NewAzureRuntimeServer(resourcesPlugin resource.AzResourceResolver, opts ...
 		server.WithSqlPlugin(sqlPlugin),
 	}
 
+	if queuesPlugin != nil {
+		// Storage Queues can't push messages, so listeners are served by polling their queues
+		defaultAzureOpts = append(defaultAzureOpts, server.WithQueueListenerPlugin(queueworkers.NewPolling(queuesPlugin)))
+	}
+
 	// append overrides
 	defaultAzureOpts = append(defaultAzureOpts, opts...)
 
@@ -17,24 +17,32 @@ package storage
 import (
 	"bytes"
 	"context"
+	"encoding/base64"
+	"errors"
 	"fmt"
 	"io"
 	"net/url"
+	"strings"
 	"time"
 
 	"github.com/Azure/azure-storage-blob-go/azblob"
 	"github.com/Azure/go-autorest/autorest/adal"
 	"github.com/Azure/go-autorest/autorest/azure"
 	"google.golang.org/grpc/codes"
+	"google.golang.org/protobuf/types/known/timestamppb"
 
 	"github.com/nitrictech/nitric/cloud/azure/runtime/env"
 	azblob_service_iface "github.com/nitrictech/nitric/cloud/azure/runtime/storage/iface"
 	azureutils "github.com/nitrictech/nitric/cloud/azure/runtime/utils"
+	base_storage "github.com/nitrictech/nitric/cloud/common/runtime/storage"
 	grpc_errors "github.com/nitrictech/nitric/core/pkg/grpc/errors"
 	"github.com/nitrictech/nitric/core/pkg/logger"
 	storagepb "github.com/nitrictech/nitric/core/pkg/proto/storage/v1"
 )
 
+// stageBlockSize - the size of each block staged when streaming a blob
+const stageBlockSize = 4 * 1024 * 1024
+
 // AzblobStorageService - Nitric storage plugin implementation for Azure Storage
 type AzblobStorageService struct {
 	client azblob_service_iface.AzblobServiceUrlIface
@@ -95,8 +103,11 @@ func (a *AzblobStorageService) Write(ctx context.Context, req *storagepb.Storage
 	if _, err := blob.Upload(
 		ctx,
 		bytes.NewReader(req.Body),
-		azblob.BlobHTTPHeaders{},
-		azblob.Metadata{},
+		azblob.BlobHTTPHeaders{
+			ContentType:  req.ContentType,
+			CacheControl: req.CacheControl,
+		},
+		toAzblobMetadata(req.Metadata),
 		azblob.BlobAccessConditions{},
 		azblob.DefaultAccessTier,
 		nil,
@@ -112,6 +123,133 @@ func (a *AzblobStorageService) Write(ctx context.Context, req *storagepb.Storage
 	return &storagepb.StorageWriteResponse{}, nil
 }
 
+func (a *AzblobStorageService) ReadStream(req *storagepb.StorageReadStreamRequest, stream storagepb.Storage_ReadStreamServer) error {
+	newErr := grpc_errors.ErrorsWithScope("AzblobStorageService.ReadStream")
+
+	if err := base_storage.ValidateRange(req); err != nil {
+		return newErr(codes.InvalidArgument, "invalid range", err)
+	}
+
+	blob := a.getBlobUrl(req.BucketName, req.Key)
+
+	// a count of zero (azblob.CountToEnd) reads to the end of the blob
+	r, err := blob.Download(
+		stream.Context(),
+		req.Offset,
+		req.Length,
+		azblob.BlobAccessConditions{},
+		false,
+		azblob.ClientProvidedKeyOptions{},
+	)
+	if err != nil {
+		var storageErr azblob.StorageError
+		if errors.As(err, &storageErr) && storageErr.ServiceCode() == azblob.ServiceCodeInvalidRange {
+			return newErr(
+				codes.OutOfRange,
+				"Range is not satisfiable for this blob",
+				err,
+			)
+		}
+
+		return newErr(
+			codes.Internal,
+			"Unable to download blob",
+			err,
+		)
+	}
+
+	data := r.Body(azblob.RetryReaderOptions{MaxRetryRequests: 20})
+	defer data.Close()
+
+	if err := base_storage.SendChunks(stream, data); err != nil {
+		return newErr(
+			codes.Internal,
+			"Error streaming blob",
+			err,
+		)
+	}
+
+	return nil
+}
+
+// WriteStream - stages each block of the stream, then commits them as the blob's content.
+// Blocks that are never committed are discarded by the storage service.
+func (a *AzblobStorageService) WriteStream(stream storagepb.Storage_WriteStreamServer) error {
+	newErr := grpc_errors.ErrorsWithScope("AzblobStorageService.WriteStream")
+	ctx := stream.Context()
+
+	header, body, err := base_storage.ReceiveWriteStream(stream)
+	if err != nil {
+		return newErr(codes.InvalidArgument, "invalid write stream", err)
+	}
+
+	blob := a.getBlobUrl(header.BucketName, header.Key)
+
+	block := make([]byte, stageBlockSize)
+	blockIds := []string{}
+
+	for {
+		n, err := io.ReadFull(body, block)
+		if err != nil && !errors.Is(err, io.EOF) && !errors.Is(err, io.ErrUnexpectedEOF) {
+			return newErr(
+				codes.Internal,
+				"Unable to receive blob data",
+				err,
+			)
+		}
+
+		if n > 0 {
+			// block ids must be the same length for every block of a blob
+			blockId := base64.StdEncoding.EncodeToString([]byte(fmt.Sprintf("%08d", len(blockIds))))
+
+			if _, err := blob.StageBlock(
+				ctx,
+				blockId,
+				bytes.NewReader(block[:n]),
+				azblob.LeaseAccessConditions{},
+				nil,
+				azblob.ClientProvidedKeyOptions{},
+			); err != nil {
+				return newErr(
+					codes.Internal,
+					"Unable to stage blob data",
+					err,
+				)
+			}
+
+			blockIds = append(blockIds, blockId)
+		}
+
+		if n < stageBlockSize {
+			break
+		}
+	}
+
+	if _, err := blob.CommitBlockList(
+		ctx,
+		blockIds,
+		azblob.BlobHTTPHeaders{
+			ContentType:  header.ContentType,
+			CacheControl: header.CacheControl,
+		},
+		toAzblobMetadata(header.Metadata),
+		azblob.BlobAccessConditions{},
+		azblob.DefaultAccessTier,
+		nil,
+		azblob.ClientProvidedKeyOptions{},
+	); err != nil {
+		return newErr(
+			codes.Internal,
+			"Unable to write blob data",
+			err,
+		)
+	}
+
+	return stream.SendAndClose(&storagepb.StorageWriteStreamResponse{
+		Size: body.Size(),
+	})
+}
+
 func (a *AzblobStorageService) Delete(ctx context.Context, req *storagepb.StorageDeleteRequest) (*storagepb.StorageDeleteResponse, error) {
 	newErr := grpc_errors.ErrorsWithScope("AzblobStorageService.Delete")
 
@@ -180,34 +318,135 @@ func (s *AzblobStorageService) ListBlobs(ctx context.Context, req *storagepb.Sto
 	newErr := grpc_errors.ErrorsWithScope("AzblobStorageService.ListFiles")
 
 	cUrl := s.getContainerUrl(req.BucketName)
-	files := make([]*storagepb.Blob, 0)
+	resp := &storagepb.StorageListBlobsResponse{
+		Blobs:    []*storagepb.Blob{},
+		Prefixes: []string{},
+	}
+
+	options := azblob.ListBlobsSegmentOptions{
+		Prefix: req.Prefix,
+		Details: azblob.BlobListingDetails{
+			Metadata: req.IncludeMetadata,
+		},
+		MaxResults: req.Limit,
+	}
+
+	marker := azblob.Marker{}
+	if req.PageToken != "" {
+		marker.Val = &req.PageToken
+	}
 
 	// List the blob(s) in our container; since a container may hold millions of blobs, this is done 1 segment at a time.
-	for marker := (azblob.Marker{}); marker.NotDone(); { // The parens around Marker{} are required to avoid compiler error.
+	for marker.NotDone() {
+		var blobItems []azblob.BlobItemInternal
+		var blobPrefixes []azblob.BlobPrefix
+
 		// Get a result segment starting with the blob indicated by the current Marker.
-		listBlob, err := cUrl.ListBlobsFlatSegment(ctx, marker, azblob.ListBlobsSegmentOptions{
-			Prefix: req.Prefix,
-		})
-		if err != nil {
-			return nil, newErr(codes.Internal, "error listing files", err)
+		if req.Delimiter != "" {
+			listBlob, err := cUrl.ListBlobsHierarchySegment(ctx, marker, req.Delimiter, options)
+			if err != nil {
+				return nil, newErr(codes.Internal, "error listing files", err)
+			}
+
+			marker = listBlob.NextMarker
+			blobItems = listBlob.Segment.BlobItems
+			blobPrefixes = listBlob.Segment.BlobPrefixes
+		} else {
+			listBlob, err := cUrl.ListBlobsFlatSegment(ctx, marker, options)
+			if err != nil {
+				return nil, newErr(codes.Internal, "error listing files", err)
+			}
+
+			// IMPORTANT: ListBlobs returns the start of the next segment; you MUST use this to get
+			// the next segment (after processing the current result segment).
+			marker = listBlob.NextMarker
+			blobItems = listBlob.Segment.BlobItems
 		}
-		// IMPORTANT: ListBlobs returns the start of the next segment; you MUST use this to get
-		// the next segment (after processing the current result segment).
-		marker = listBlob.NextMarker
 
 		// Process the blobs returned in this result segment (if the segment is empty, the loop body won't execute)
-		for _, blobInfo := range listBlob.Segment.BlobItems {
-			files = append(files, &storagepb.Blob{
-				Key: blobInfo.Name,
-			})
+		for _, blobInfo := range blobItems {
+			resp.Blobs = append(resp.Blobs, blobFromItem(blobInfo))
+		}
+
+		for _, prefix := range blobPrefixes {
+			resp.Prefixes = append(resp.Prefixes, prefix.Name)
+		}
+
+		// a limit returns a single segment, otherwise every remaining segment is listed
+		if req.Limit > 0 {
+			if marker.NotDone() && marker.Val != nil {
+				resp.NextPageToken = *marker.Val
+			}
+
+			break
+		}
+	}
+
+	return resp, nil
+}
+
+func (s *AzblobStorageService) Stat(ctx context.Context, req *storagepb.StorageStatRequest) (*storagepb.StorageStatResponse, error) {
+	newErr := grpc_errors.ErrorsWithScope("AzblobStorageService.Stat")
+
+	bUrl := s.getBlobUrl(req.BucketName, req.Key)
+
+	props, err := bUrl.GetProperties(ctx, azblob.BlobAccessConditions{}, azblob.ClientProvidedKeyOptions{})
+	if err != nil {
+		var storageErr azblob.StorageError
+		if errors.As(err, &storageErr) && storageErr.ServiceCode() == azblob.ServiceCodeBlobNotFound {
+			return nil, newErr(codes.NotFound, fmt.Sprintf("blob %s not found", req.Key), err)
 		}
+
+		return nil, newErr(codes.Internal, "error getting blob properties", err)
 	}
 
-	return &storagepb.StorageListBlobsResponse{
-		Blobs: files,
+	return &storagepb.StorageStatResponse{
+		Blob: &storagepb.Blob{
+			Key:          req.Key,
+			Size:         props.ContentLength(),
+			Etag:         strings.Trim(string(props.ETag()), `"`),
+			LastModified: timestamppb.New(props.LastModified()),
+			ContentType:  props.ContentType(),
+			CacheControl: props.CacheControl(),
+			Metadata:     props.NewMetadata(),
+		},
 	}, nil
 }
 
+// blobFromItem converts a listed blob item to a blob
+func blobFromItem(item azblob.BlobItemInternal) *storagepb.Blob {
+	blob := &storagepb.Blob{
+		Key:          item.Name,
+		Etag:         strings.Trim(string(item.Properties.Etag), `"`),
+		LastModified: timestamppb.New(item.Properties.LastModified),
+		Metadata:     item.Metadata,
+	}
+
+	if item.Properties.ContentLength != nil {
+		blob.Size = *item.Properties.ContentLength
+	}
+
+	if item.Properties.ContentType != nil {
+		blob.ContentType = *item.Properties.ContentType
+	}
+
+	if item.Properties.CacheControl != nil {
+		blob.CacheControl = *item.Properties.CacheControl
+	}
+
+	return blob
+}
+
+// toAzblobMetadata copies user metadata into the metadata of a blob
+func toAzblobMetadata(metadata map[string]string) azblob.Metadata {
+	azMetadata := azblob.Metadata{}
+	for k, v := range metadata {
+		azMetadata[k] = v
+	}
+
+	return azMetadata
+}
+
 func (s *AzblobStorageService) Exists(ctx context.Context, req *storagepb.StorageExistsRequest) (*storagepb.StorageExistsResponse, error) {
 	newErr := grpc_errors.ErrorsWithScope("AzblobStorageService.Exists")
 
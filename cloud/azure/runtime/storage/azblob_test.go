@@ -25,12 +25,15 @@ import (
 
 	"github.com/Azure/azure-storage-blob-go/azblob"
 	"github.com/golang/mock/gomock"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 	"google.golang.org/protobuf/types/known/durationpb"
 
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/gomega"
 
 	mock_azblob "github.com/nitrictech/nitric/cloud/azure/mocks/azblob"
+	"github.com/nitrictech/nitric/cloud/common/runtime/storage/storagetest"
 	storagepb "github.com/nitrictech/nitric/core/pkg/proto/storage/v1"
 )
 
@@ -452,6 +455,116 @@ var _ = Describe("Azblob", func() {
 		})
 	})
 
+	Context("ListFiles with a delimiter and limit", func() {
+		When("Azure returns a successful response", func() {
+			ctrl := gomock.NewController(GinkgoT())
+			mockAzblob := mock_azblob.NewMockAzblobServiceUrlIface(ctrl)
+			mockContainer := mock_azblob.NewMockAzblobContainerUrlIface(ctrl)
+
+			storagePlugin := &AzblobStorageService{
+				client: mockAzblob,
+			}
+
+			It("should return a single page of files and prefixes", func() {
+				By("Retrieving the Container URL for the requested bucket")
+				mockAzblob.EXPECT().NewContainerURL("my-bucket").Times(1).Return(mockContainer)
+
+				pageToken := "page-1"
+				nextMarker := "page-2"
+				contentLength := int64(4)
+				lastModified := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+
+				By("The container returning a segment of the hierarchy")
+				mockContainer.EXPECT().ListBlobsHierarchySegment(gomock.Any(), azblob.Marker{Val: &pageToken}, "/", azblob.ListBlobsSegmentOptions{
+					MaxResults: 2,
+				}).Times(1).Return(&azblob.ListBlobsHierarchySegmentResponse{
+					NextMarker: azblob.Marker{
+						Val: &nextMarker,
+					},
+					Segment: azblob.BlobHierarchyListSegment{
+						BlobItems: []azblob.BlobItemInternal{
+							{
+								Name: "test.txt",
+								Properties: azblob.BlobPropertiesInternal{
+									Etag:          azblob.ETag(`"abc123"`),
+									LastModified:  lastModified,
+									ContentLength: &contentLength,
+								},
+							},
+						},
+						BlobPrefixes: []azblob.BlobPrefix{
+							{Name: "images/"},
+						},
+					},
+				}, nil)
+
+				resp, err := storagePlugin.ListBlobs(context.TODO(), &storagepb.StorageListBlobsRequest{
+					BucketName: "my-bucket",
+					Delimiter:  "/",
+					Limit:      2,
+					PageToken:  pageToken,
+				})
+
+				By("Not returning an error")
+				Expect(err).ShouldNot(HaveOccurred())
+
+				By("Returning the file details")
+				Expect(resp.Blobs).To(HaveLen(1))
+				Expect(resp.Blobs[0].Key).To(Equal("test.txt"))
+				Expect(resp.Blobs[0].Size).To(Equal(int64(4)))
+				Expect(resp.Blobs[0].Etag).To(Equal("abc123"))
+				Expect(resp.Blobs[0].LastModified.AsTime()).To(Equal(lastModified))
+
+				By("Returning the common prefixes")
+				Expect(resp.Prefixes).To(Equal([]string{"images/"}))
+
+				By("Returning the next page token")
+				Expect(resp.NextPageToken).To(Equal("page-2"))
+
+				ctrl.Finish()
+			})
+		})
+	})
+
+	Context("Stat", func() {
+		When("The file does not exist", func() {
+			ctrl := gomock.NewController(GinkgoT())
+			mockAzblob := mock_azblob.NewMockAzblobServiceUrlIface(ctrl)
+			mockContainer := mock_azblob.NewMockAzblobContainerUrlIface(ctrl)
+			mockBlob := mock_azblob.NewMockAzblobBlockBlobUrlIface(ctrl)
+			mockError := mock_azblob.NewMockStorageError(ctrl)
+
+			storagePlugin := &AzblobStorageService{
+				client: mockAzblob,
+			}
+
+			It("should return a not found error", func() {
+				By("Retrieving the Container URL for the requested bucket")
+				mockAzblob.EXPECT().NewContainerURL("my-bucket").Times(1).Return(mockContainer)
+
+				By("Retrieving the Blob URL for the requested file")
+				mockContainer.EXPECT().NewBlockBlobURL("test-file").Times(1).Return(mockBlob)
+
+				By("Producing a service code of azblob.ServiceCodeBlobNotFound")
+				mockError.EXPECT().ServiceCode().Times(1).Return(azblob.ServiceCodeBlobNotFound)
+				mockError.EXPECT().Error().AnyTimes().Return("blob not found")
+
+				By("Calling GetProperties on the file")
+				mockBlob.EXPECT().GetProperties(gomock.Any(), gomock.Any(), gomock.Any()).Times(1).Return(nil, mockError)
+
+				_, err := storagePlugin.Stat(context.TODO(), &storagepb.StorageStatRequest{
+					BucketName: "my-bucket",
+					Key:        "test-file",
+				})
+
+				By("Returning a not found error")
+				Expect(status.Code(err)).To(Equal(codes.NotFound))
+
+				ctrl.Finish()
+			})
+		})
+	})
+
 	Context("Exists", func() {
 		When("Azure returns a successful response", func() {
 			Context("and the file exists", func() {
@@ -574,4 +687,116 @@ var _ = Describe("Azblob", func() {
 			})
 		})
 	})
+
+	Context("ReadStream", func() {
+		When("A range of the blob is requested", func() {
+			crtl := gomock.NewController(GinkgoT())
+			mockAzblob := mock_azblob.NewMockAzblobServiceUrlIface(crtl)
+			mockContainer := mock_azblob.NewMockAzblobContainerUrlIface(crtl)
+			mockBlob := mock_azblob.NewMockAzblobBlockBlobUrlIface(crtl)
+			mockDown := mock_azblob.NewMockAzblobDownloadResponse(crtl)
+
+			storagePlugin := &AzblobStorageService{
+				client: mockAzblob,
+			}
+
+			It("should stream the requested range", func() {
+				By("Retrieving the Container URL for the requested bucket")
+				mockAzblob.EXPECT().NewContainerURL("my-bucket").Times(1).Return(mockContainer)
+
+				By("Retrieving the blob url of the requested object")
+				mockContainer.EXPECT().NewBlockBlobURL("my-blob").Times(1).Return(mockBlob)
+
+				By("Downloading only the requested range")
+				mockBlob.EXPECT().Download(
+					gomock.Any(),
+					int64(5),
+					int64(8),
+					azblob.BlobAccessConditions{},
+					false,
+					azblob.ClientProvidedKeyOptions{},
+				).Times(1).Return(mockDown, nil)
+
+				By("Reading from the download response")
+				mockDown.EXPECT().Body(gomock.Any()).Times(1).Return(io.NopCloser(strings.NewReader("contents")))
+
+				stream := &storagetest.ReadStream{}
+				err := storagePlugin.ReadStream(&storagepb.StorageReadStreamRequest{
+					BucketName: "my-bucket",
+					Key:        "my-blob",
+					Offset:     5,
+					Length:     8,
+				}, stream)
+
+				By("Not returning an error")
+				Expect(err).ToNot(HaveOccurred())
+
+				By("Streaming the range")
+				Expect(stream.Body.String()).To(Equal("contents"))
+
+				crtl.Finish()
+			})
+		})
+	})
+
+	Context("WriteStream", func() {
+		When("Azure returns a successful response", func() {
+			crtl := gomock.NewController(GinkgoT())
+			mockAzblob := mock_azblob.NewMockAzblobServiceUrlIface(crtl)
+			mockContainer := mock_azblob.NewMockAzblobContainerUrlIface(crtl)
+			mockBlob := mock_azblob.NewMockAzblobBlockBlobUrlIface(crtl)
+
+			storagePlugin := &AzblobStorageService{
+				client: mockAzblob,
+			}
+
+			It("should stage and commit the streamed blocks", func() {
+				By("Retrieving the Container URL for the requested bucket")
+				mockAzblob.EXPECT().NewContainerURL("my-bucket").Times(1).Return(mockContainer)
+
+				By("Retrieving the blob url of the requested object")
+				mockContainer.EXPECT().NewBlockBlobURL("my-blob").Times(1).Return(mockBlob)
+
+				By("Staging a block for each block sized section of the stream")
+				stagedIds := []string{}
+				mockBlob.EXPECT().StageBlock(
+					gomock.Any(),
+					gomock.Any(),
+					gomock.Any(),
+					azblob.LeaseAccessConditions{},
+					nil,
+					azblob.ClientProvidedKeyOptions{},
+				).Times(2).DoAndReturn(func(ctx context.Context, id string, r io.ReadSeeker, lac azblob.LeaseAccessConditions, md5 []byte, cpk azblob.ClientProvidedKeyOptions) (*azblob.BlockBlobStageBlockResponse, error) {
+					stagedIds = append(stagedIds, id)
+					return &azblob.BlockBlobStageBlockResponse{}, nil
+				})
+
+				By("Committing the staged blocks in order")
+				mockBlob.EXPECT().CommitBlockList(
+					gomock.Any(),
+					gomock.Any(),
+					azblob.BlobHTTPHeaders{},
+					azblob.Metadata{},
+					azblob.BlobAccessConditions{},
+					azblob.DefaultAccessTier,
+					nil,
+					azblob.ClientProvidedKeyOptions{},
+				).Times(1).DoAndReturn(func(ctx context.Context, ids []string, h azblob.BlobHTTPHeaders, m azblob.Metadata, bac azblob.BlobAccessConditions, att azblob.AccessTierType, btm azblob.BlobTagsMap, cpk azblob.ClientProvidedKeyOptions) (*azblob.BlockBlobCommitBlockListResponse, error) {
+					Expect(ids).To(Equal(stagedIds))
+					return &azblob.BlockBlobCommitBlockListResponse{}, nil
+				})
+
+				stream := storagetest.NewWriteStream("my-bucket", "my-blob", make([]byte, stageBlockSize), []byte("more"))
+				err := storagePlugin.WriteStream(stream)
+
+				By("Not returning an error")
+				Expect(err).ToNot(HaveOccurred())
+
+				By("Returning the size of the blob")
+				Expect(stream.Response.Size).To(Equal(int64(stageBlockSize + 4)))
+
+				crtl.Finish()
+			})
+		})
+	})
 })
@@ -16,6 +16,7 @@ package topic
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
 	"fmt"
 	"time"
@@ -31,8 +32,23 @@ import (
 	"github.com/nitrictech/nitric/cloud/azure/runtime/resource"
 	grpc_errors "github.com/nitrictech/nitric/core/pkg/grpc/errors"
 	topicpb "github.com/nitrictech/nitric/core/pkg/proto/topics/v1"
+	"github.com/nitrictech/nitric/core/pkg/workers"
 )
 
+// EventGridDataVersion is the data version of events with an EventGridMessage as their data,
+// events with other data versions only contain the serialized topic message.
+const EventGridDataVersion = "2.0"
+
+// EventGridMessage is the data of events published to Event Grid topics
+type EventGridMessage struct {
+	// The serialized topic message
+	Payload []byte `json:"payload"`
+	// The W3C trace context of the publisher, so subscribers can continue the trace
+	TraceContext map[string]string `json:"traceContext,omitempty"`
+	// The attributes of the topic message, so subscription advanced filters can match them
+	Attributes map[string]string `json:"attributes,omitempty"`
+}
+
 type EventGridEventService struct {
 	client   eventgridapi.BaseClientAPI
 	provider resource.AzResourceResolver
@@ -40,8 +56,8 @@ type EventGridEventService struct {
 
 var _ topicpb.TopicsServer = &EventGridEventService{}
 
-func (s *EventGridEventService) nitricEventToAzureEvent(topic string, payload *topicpb.TopicMessage) (*eventgrid.Event, error) {
-	dataVersion := "1.0"
+func (s *EventGridEventService) nitricEventToAzureEvent(ctx context.Context, topic string, payload *topicpb.TopicMessage) (*eventgrid.Event, error) {
+	dataVersion := EventGridDataVersion
 	eventType := "nitric"
 
 	uid := uuid.New()
@@ -53,8 +69,12 @@ func (s *EventGridEventService) nitricEventToAzureEvent(topic string, payload *t
 	}
 
 	return &eventgrid.Event{
-		ID:          &id,
-		Data:        msgBytes,
+		ID: &id,
+		Data: EventGridMessage{
+			Payload:      msgBytes,
+			TraceContext: workers.InjectTraceContext(ctx),
+			Attributes:   payload.GetAttributes(),
+		},
 		EventType:   &eventType,
 		Subject:     &topic,
 		EventTime:   &date.Time{Time: time.Now()},
@@ -89,7 +109,7 @@ func (s *EventGridEventService) Publish(ctx context.Context, req *topicpb.TopicP
 
 	topicHostName := fmt.Sprintf("%s.%s-1.eventgrid.azure.net", t.Name, t.Location)
 
-	eventToPublish, err := s.nitricEventToAzureEvent(topicHostName, req.Message)
+	eventToPublish, err := s.nitricEventToAzureEvent(ctx, topicHostName, req.Message)
 	if err != nil {
 		return nil, newErr(
 			codes.Internal,
@@ -115,7 +135,135 @@ func (s *EventGridEventService) Publish(ctx context.Context, req *topicpb.TopicP
 		)
 	}
 
-	return &topicpb.TopicPublishResponse{}, nil
+	return &topicpb.TopicPublishResponse{
+		MessageId: *eventToPublish.ID,
+	}, nil
+}
+
+func failedPublishResult(err string) *topicpb.TopicPublishResult {
+	return &topicpb.TopicPublishResult{
+		Result: &topicpb.TopicPublishResult_Error{
+			Error: err,
+		},
+	}
+}
+
+// Event Grid rejects publish requests larger than 1MB
+const maxPublishRequestSize = 1024 * 1024
+
+// batchEvents splits events into batches that fit within Event Grid's publish request size limit, preserving their order.
+// it returns the indexes of the events in each batch, and the error of each event too large to be published.
+func batchEvents(events []eventgrid.Event, maxRequestSize int) ([][]int, map[int]error) {
+	batches := [][]int{}
+	oversized := map[int]error{}
+
+	// events are published as a JSON array, so each event adds its size and a separator to the request
+	batch := []int{}
+	batchSize := 2
+	for i, event := range events {
+		eventJson, err := json.Marshal(event)
+		if err != nil {
+			oversized[i] = err
+			continue
+		}
+
+		eventSize := len(eventJson) + 1
+		if eventSize+2 > maxRequestSize {
+			oversized[i] = fmt.Errorf("message is %d bytes as an event, events are limited to %d bytes", len(eventJson), maxRequestSize-2)
+			continue
+		}
+
+		if batchSize+eventSize > maxRequestSize {
+			batches = append(batches, batch)
+			batch = []int{}
+			batchSize = 2
+		}
+
+		batch = append(batch, i)
+		batchSize += eventSize
+	}
+
+	if len(batch) > 0 {
+		batches = append(batches, batch)
+	}
+
+	return batches, oversized
+}
+
+// PublishBatch publishes messages to a given topic as arrays of events, split to fit within Event Grid's request size limit.
+// Event Grid accepts or rejects each array as a whole, so every message in the same request has the same result.
+func (s *EventGridEventService) PublishBatch(ctx context.Context, req *topicpb.TopicPublishBatchRequest) (*topicpb.TopicPublishBatchResponse, error) {
+	newErr := grpc_errors.ErrorsWithScope("EventGrid.PublishBatch")
+
+	topics, err := s.provider.GetResources(ctx, resource.AzResource_Topic)
+	if err != nil {
+		return nil, newErr(
+			codes.NotFound,
+			fmt.Sprintf("unable to find topic %s", req.TopicName),
+			err,
+		)
+	}
+
+	t, ok := topics[req.TopicName]
+	if !ok {
+		return nil, newErr(
+			codes.NotFound,
+			fmt.Sprintf("topic %s does not exist", req.TopicName),
+			err,
+		)
+	}
+
+	topicHostName := fmt.Sprintf("%s.%s-1.eventgrid.azure.net", t.Name, t.Location)
+
+	eventsToPublish := []eventgrid.Event{}
+	for _, message := range req.Messages {
+		event, err := s.nitricEventToAzureEvent(ctx, topicHostName, message)
+		if err != nil {
+			return nil, newErr(
+				codes.Internal,
+				"error marshalling event",
+				err,
+			)
+		}
+
+		eventsToPublish = append(eventsToPublish, *event)
+	}
+
+	results := make([]*topicpb.TopicPublishResult, len(eventsToPublish))
+
+	batches, oversized := batchEvents(eventsToPublish, maxPublishRequestSize)
+	for i, err := range oversized {
+		results[i] = failedPublishResult(err.Error())
+	}
+
+	for _, batch := range batches {
+		events := make([]eventgrid.Event, 0, len(batch))
+		for _, i := range batch {
+			events = append(events, eventsToPublish[i])
+		}
+
+		result, err := s.client.PublishEvents(ctx, topicHostName, events)
+		if err == nil && (result.StatusCode < 200 || result.StatusCode >= 300) {
+			err = fmt.Errorf("returned non 200 status code: %s", result.Status)
+		}
+
+		for _, i := range batch {
+			if err != nil {
+				results[i] = failedPublishResult(err.Error())
+				continue
+			}
+
+			results[i] = &topicpb.TopicPublishResult{
+				Result: &topicpb.TopicPublishResult_MessageId{
+					MessageId: *eventsToPublish[i].ID,
+				},
+			}
+		}
+	}
+
+	return &topicpb.TopicPublishBatchResponse{
+		Results: results,
+	}, nil
 }
 
 func New(provider resource.AzResourceResolver) (*EventGridEventService, error) {
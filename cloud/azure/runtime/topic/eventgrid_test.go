@@ -18,11 +18,14 @@ import (
 	"context"
 	"fmt"
 	"net/http"
+	"strings"
 
+	"github.com/Azure/azure-sdk-for-go/services/eventgrid/2018-01-01/eventgrid"
 	"github.com/Azure/go-autorest/autorest"
 	"github.com/golang/mock/gomock"
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/gomega"
+	"google.golang.org/protobuf/types/known/structpb"
 
 	mock_eventgrid "github.com/nitrictech/nitric/cloud/azure/mocks/mock_event_grid"
 	mock_provider "github.com/nitrictech/nitric/cloud/azure/mocks/provider"
@@ -128,5 +131,194 @@ var _ = Describe("Event Grid Plugin", func() {
 				ctrl.Finish()
 			})
 		})
+
+		When("The message has attributes", func() {
+			ctrl := gomock.NewController(GinkgoT())
+			eventgridClient := mock_eventgrid.NewMockBaseClientAPI(ctrl)
+			mockProvider := mock_provider.NewMockAzResourceResolver(ctrl)
+			eventgridPlugin, _ := eventgrid_service.NewWithClient(mockProvider, eventgridClient)
+
+			It("should publish the attributes in the event data", func() {
+				By("the az provider returning topics")
+				mockProvider.EXPECT().GetResources(gomock.Any(), resource.AzResource_Topic).Return(getTopicResourcesResponse, nil)
+
+				By("the eventgrid client publishing to the returned topic")
+				var publishedEvents []eventgrid.Event
+				eventgridClient.EXPECT().PublishEvents(gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(func(ctx context.Context, topicHostname string, events []eventgrid.Event) (autorest.Response, error) {
+					publishedEvents = events
+
+					return autorest.Response{
+						Response: &http.Response{
+							StatusCode: 202,
+						},
+					}, nil
+				}).Times(1)
+
+				_, err := eventgridPlugin.Publish(context.TODO(), &topicpb.TopicPublishRequest{
+					TopicName: "Test",
+					Message: &topicpb.TopicMessage{
+						Content:    eventPayload.Content,
+						Attributes: map[string]string{"region": "us"},
+					},
+				})
+				Expect(err).ShouldNot(HaveOccurred())
+
+				By("setting the message attributes on the event data")
+				Expect(publishedEvents).To(HaveLen(1))
+				Expect(publishedEvents[0].Data.(eventgrid_service.EventGridMessage).Attributes).To(HaveKeyWithValue("region", "us"))
+
+				ctrl.Finish()
+			})
+		})
+	})
+
+	When("Publishing a batch of messages", func() {
+		eventPayload := &topicpb.TopicMessage{}
+
+		When("To a topic that does exist", func() {
+			ctrl := gomock.NewController(GinkgoT())
+			eventgridClient := mock_eventgrid.NewMockBaseClientAPI(ctrl)
+			mockProvider := mock_provider.NewMockAzResourceResolver(ctrl)
+			eventgridPlugin, _ := eventgrid_service.NewWithClient(mockProvider, eventgridClient)
+
+			It("should publish the messages as a single array of events", func() {
+				By("the az provider returning topics")
+				mockProvider.EXPECT().GetResources(gomock.Any(), resource.AzResource_Topic).Return(getTopicResourcesResponse, nil)
+
+				By("the eventgrid client publishing every event together")
+				var publishedEvents []eventgrid.Event
+				eventgridClient.EXPECT().PublishEvents(gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(func(ctx context.Context, topicHostname string, events []eventgrid.Event) (autorest.Response, error) {
+					publishedEvents = events
+
+					return autorest.Response{
+						Response: &http.Response{
+							StatusCode: 202,
+						},
+					}, nil
+				}).Times(1)
+
+				resp, err := eventgridPlugin.PublishBatch(context.TODO(), &topicpb.TopicPublishBatchRequest{
+					TopicName: "Test",
+					Messages:  []*topicpb.TopicMessage{eventPayload, eventPayload},
+				})
+				Expect(err).ShouldNot(HaveOccurred())
+				Expect(publishedEvents).To(HaveLen(2))
+
+				By("returning the event ids as message ids")
+				Expect(resp.Results).To(HaveLen(2))
+				Expect(resp.Results[0].GetMessageId()).To(Equal(*publishedEvents[0].ID))
+				Expect(resp.Results[1].GetMessageId()).To(Equal(*publishedEvents[1].ID))
+
+				ctrl.Finish()
+			})
+		})
+
+		When("The messages are larger than a single publish request", func() {
+			ctrl := gomock.NewController(GinkgoT())
+			eventgridClient := mock_eventgrid.NewMockBaseClientAPI(ctrl)
+			mockProvider := mock_provider.NewMockAzResourceResolver(ctrl)
+			eventgridPlugin, _ := eventgrid_service.NewWithClient(mockProvider, eventgridClient)
+
+			largeMessage := func(size int) *topicpb.TopicMessage {
+				payload, _ := structpb.NewStruct(map[string]interface{}{
+					"data": strings.Repeat("a", size),
+				})
+
+				return &topicpb.TopicMessage{
+					Content: &topicpb.TopicMessage_StructPayload{
+						StructPayload: payload,
+					},
+				}
+			}
+
+			It("should publish the messages across multiple requests", func() {
+				By("the az provider returning topics")
+				mockProvider.EXPECT().GetResources(gomock.Any(), resource.AzResource_Topic).Return(getTopicResourcesResponse, nil)
+
+				By("the eventgrid client publishing each request")
+				publishedBatches := [][]eventgrid.Event{}
+				eventgridClient.EXPECT().PublishEvents(gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(func(ctx context.Context, topicHostname string, events []eventgrid.Event) (autorest.Response, error) {
+					publishedBatches = append(publishedBatches, events)
+
+					return autorest.Response{
+						Response: &http.Response{
+							StatusCode: 202,
+						},
+					}, nil
+				}).Times(2)
+
+				resp, err := eventgridPlugin.PublishBatch(context.TODO(), &topicpb.TopicPublishBatchRequest{
+					TopicName: "Test",
+					Messages:  []*topicpb.TopicMessage{largeMessage(600 * 1024), largeMessage(600 * 1024)},
+				})
+				Expect(err).ShouldNot(HaveOccurred())
+				Expect(publishedBatches).To(HaveLen(2))
+				Expect(publishedBatches[0]).To(HaveLen(1))
+				Expect(publishedBatches[1]).To(HaveLen(1))
+
+				By("returning the event ids in the order of the messages")
+				Expect(resp.Results).To(HaveLen(2))
+				Expect(resp.Results[0].GetMessageId()).To(Equal(*publishedBatches[0][0].ID))
+				Expect(resp.Results[1].GetMessageId()).To(Equal(*publishedBatches[1][0].ID))
+
+				ctrl.Finish()
+			})
+
+			It("should fail messages too large to be published on their own", func() {
+				By("the az provider returning topics")
+				mockProvider.EXPECT().GetResources(gomock.Any(), resource.AzResource_Topic).Return(getTopicResourcesResponse, nil)
+
+				By("the eventgrid client publishing only the smaller message")
+				eventgridClient.EXPECT().PublishEvents(gomock.Any(), gomock.Any(), gomock.Len(1)).Return(autorest.Response{
+					Response: &http.Response{
+						StatusCode: 202,
+					},
+				}, nil).Times(1)
+
+				resp, err := eventgridPlugin.PublishBatch(context.TODO(), &topicpb.TopicPublishBatchRequest{
+					TopicName: "Test",
+					Messages:  []*topicpb.TopicMessage{largeMessage(2 * 1024 * 1024), eventPayload},
+				})
+				Expect(err).ShouldNot(HaveOccurred())
+
+				Expect(resp.Results).To(HaveLen(2))
+				Expect(resp.Results[0].GetError()).To(ContainSubstring("limited"))
+				Expect(resp.Results[1].GetMessageId()).ToNot(BeEmpty())
+
+				ctrl.Finish()
+			})
+		})
+
+		When("Event Grid rejects the events", func() {
+			ctrl := gomock.NewController(GinkgoT())
+			eventgridClient := mock_eventgrid.NewMockBaseClientAPI(ctrl)
+			mockProvider := mock_provider.NewMockAzResourceResolver(ctrl)
+			eventgridPlugin, _ := eventgrid_service.NewWithClient(mockProvider, eventgridClient)
+
+			It("should return a failure for every message", func() {
+				By("the az provider returning topics")
+				mockProvider.EXPECT().GetResources(gomock.Any(), resource.AzResource_Topic).Return(getTopicResourcesResponse, nil)
+
+				By("the eventgrid client failing to publish")
+				eventgridClient.EXPECT().PublishEvents(gomock.Any(), gomock.Any(), gomock.Any()).Return(autorest.Response{
+					Response: &http.Response{
+						StatusCode: 413,
+						Status:     "413 Payload Too Large",
+					},
+				}, nil).Times(1)
+
+				resp, err := eventgridPlugin.PublishBatch(context.TODO(), &topicpb.TopicPublishBatchRequest{
+					TopicName: "Test",
+					Messages:  []*topicpb.TopicMessage{eventPayload, eventPayload},
+				})
+				Expect(err).ShouldNot(HaveOccurred())
+
+				Expect(resp.Results).To(HaveLen(2))
+				Expect(resp.Results[0].GetError()).To(ContainSubstring("413"))
+				Expect(resp.Results[1].GetError()).To(ContainSubstring("413"))
+
+				ctrl.Finish()
+			})
+		})
 	})
 })
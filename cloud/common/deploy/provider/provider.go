@@ -101,8 +101,47 @@ func (*NitricDefaultOrder) Order(resources []*deploymentspb.Resource) []*deploym
 
 	sorted := []*deploymentspb.Resource{}
 	for _, resourceType := range typeOrder {
+		if resourceType == resourcespb.ResourceType_Queue {
+			sorted = append(sorted, deadLetterTargetsFirst(just(resources, resourceType))...)
+			continue
+		}
+
 		sorted = append(sorted, just(resources, resourceType)...)
 	}
 
 	return sorted
 }
+
+// deadLetterTargetsFirst - order queues so that dead-letter target queues are deployed before the queues that reference them
+func deadLetterTargetsFirst(queues []*deploymentspb.Resource) []*deploymentspb.Resource {
+	sorted := []*deploymentspb.Resource{}
+	remaining := queues
+
+	for len(remaining) > 0 {
+		ready := []*deploymentspb.Resource{}
+		waiting := []*deploymentspb.Resource{}
+
+		for _, queue := range remaining {
+			target := queue.GetQueue().GetDeadLetterPolicy().GetTargetQueue()
+			pending := lo.ContainsBy(remaining, func(other *deploymentspb.Resource) bool {
+				return other.Id.Name == target
+			})
+
+			if !pending {
+				ready = append(ready, queue)
+			} else {
+				waiting = append(waiting, queue)
+			}
+		}
+
+		// queues that target each other can't be ordered, leave them as they are
+		if len(ready) == 0 {
+			return append(sorted, waiting...)
+		}
+
+		sorted = append(sorted, ready...)
+		remaining = waiting
+	}
+
+	return sorted
+}
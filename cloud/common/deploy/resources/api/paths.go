@@ -0,0 +1,47 @@
+// Copyright 2021 Nitric Technologies Pty Ltd.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package api
+
+import (
+	"fmt"
+	"regexp"
+)
+
+// greedyPathParam matches the greedy path params generated for wildcard routes, e.g. /files/{path+}
+var greedyPathParam = regexp.MustCompile(`\{([^{}/]+)\+\}`)
+
+// TranslateGreedyPathParams rewrites greedy path params in OpenAPI paths into a provider's wildcard syntax,
+// so the API gateway matches the same requests as the nitric route a worker registered.
+func TranslateGreedyPathParams[M ~map[string]V, V any](paths M, format func(name string) string) M {
+	translated := make(M, len(paths))
+
+	for path, item := range paths {
+		translated[greedyPathParam.ReplaceAllStringFunc(path, func(param string) string {
+			return format(greedyPathParam.FindStringSubmatch(param)[1])
+		})] = item
+	}
+
+	return translated
+}
+
+// GoogleGreedyPathParam formats a greedy path param as a Google API Gateway multi-segment wildcard, e.g. {path=**}
+func GoogleGreedyPathParam(name string) string {
+	return fmt.Sprintf("{%s=**}", name)
+}
+
+// AzureGreedyPathParam formats a greedy path param as an Azure API Management wildcard template, e.g. {*path}
+func AzureGreedyPathParam(name string) string {
+	return fmt.Sprintf("{*%s}", name)
+}
@@ -45,3 +45,11 @@ func GetResourceTypeKey(stackID string) string {
 	}
 	return fmt.Sprintf("x-nitric-%s-type", stackID)
 }
+
+// GetQueueFifoKey returns the key used to identify queues deployed with FIFO ordering from their tags.
+func GetQueueFifoKey(stackID string) string {
+	if stackID == "" {
+		panic("blank stack ID, resource mapping isn't possible")
+	}
+	return fmt.Sprintf("x-nitric-%s-fifo", stackID)
+}
@@ -34,5 +34,15 @@ var _ = Describe("Tags", func() {
 				Expect(resourcesTags["x-nitric-testing-type"]).To(Equal("stack"))
 			})
 		})
+
+		When("Calling GetQueueFifoKey", func() {
+			It("should return a stack specific key", func() {
+				Expect(tags.GetQueueFifoKey("testing")).To(Equal("x-nitric-testing-fifo"))
+			})
+
+			It("should panic on a blank stack ID", func() {
+				Expect(func() { tags.GetQueueFifoKey("") }).To(Panic())
+			})
+		})
 	})
 })
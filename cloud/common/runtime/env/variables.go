@@ -30,3 +30,9 @@ var NITRIC_JOB_NAME = env.GetEnv("NITRIC_JOB_NAME", "")
 
 // Job data as JSON
 var NITRIC_JOB_DATA = env.GetEnv("NITRIC_JOB_DATA", "{}")
+
+// Expose debug endpoints on the gateway, e.g. listing registered workers
+var NITRIC_ENABLE_DEBUG_ENDPOINTS = env.GetEnv("NITRIC_ENABLE_DEBUG_ENDPOINTS", "false")
+
+// Maximum time in seconds to wait for a worker to handle a gateway request, 0 disables the timeout
+var NITRIC_REQUEST_TIMEOUT = env.GetEnv("NITRIC_REQUEST_TIMEOUT", "0")
@@ -16,19 +16,24 @@ package base_http
 
 import (
 	"context"
+	"encoding/json"
+	"errors"
 	"fmt"
+	"io"
 	"strings"
+	"sync/atomic"
 	"time"
 
 	fasthttprouter "github.com/fasthttp/router"
 	"github.com/valyala/fasthttp"
-	"go.opentelemetry.io/otel"
-	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/propagation"
 
 	"github.com/nitrictech/nitric/cloud/common/runtime/env"
+	"github.com/nitrictech/nitric/cloud/common/runtime/telemetry"
 	"github.com/nitrictech/nitric/core/pkg/gateway"
 	"github.com/nitrictech/nitric/core/pkg/logger"
 	apispb "github.com/nitrictech/nitric/core/pkg/proto/apis/v1"
+	"github.com/nitrictech/nitric/core/pkg/workers"
 )
 
 type (
@@ -40,11 +45,19 @@ type (
 type RouterRegistrationCallback func(*fasthttprouter.Router, *gateway.GatewayStartOpts)
 
 const (
-	DefaultTopicRoute              = "/x-nitric-topic/{name}"
-	DefaultScheduleRoute           = "/x-nitric-schedule/{name}"
-	DefaultBucketNotificationRoute = "/x-nitric-notification/bucket/{name}"
+	DefaultTopicRoute                = "/x-nitric-topic/{name}"
+	DefaultScheduleRoute             = "/x-nitric-schedule/{name}"
+	DefaultBucketNotificationRoute   = "/x-nitric-notification/bucket/{name}"
+	DefaultKeyValueNotificationRoute = "/x-nitric-notification/keyvalue/{name}"
+	DefaultQueueRoute                = "/x-nitric-queue/{name}"
+	DefaultLivenessRoute             = "/x-nitric-health/live"
+	DefaultReadinessRoute            = "/x-nitric-health/ready"
+	DefaultDebugWorkersRoute         = "/x-nitric-debug/workers"
 )
 
+// API request bodies larger than this are streamed to workers
+const maxBufferedRequestBodySize = fasthttp.DefaultMaxRequestBodySize
+
 type HttpGatewayOptions struct {
 	RouteRegistrationHook RouterRegistrationCallback
 }
@@ -54,8 +67,12 @@ type HttpGateway struct {
 	server  *fasthttp.Server
 	gateway.UnimplementedGatewayPlugin
 	routeRegistrationHook RouterRegistrationCallback
+	// set once the gateway stops accepting new requests
+	refusing atomic.Bool
 }
 
+var _ gateway.AcceptStopper = &HttpGateway{}
+
 func HttpHeadersToMap(rh *fasthttp.RequestHeader) map[string][]string {
 	headerCopy := make(map[string][]string)
 
@@ -73,6 +90,23 @@ func HttpHeadersToMap(rh *fasthttp.RequestHeader) map[string][]string {
 	return headerCopy
 }
 
+// RequestContext returns a context for forwarding a gateway request to a worker, cancel it when the handler returns.
+//
+//	The context isn't derived from the fasthttp request context, that's cancelled as soon as the server starts shutting down,
+//	so in-flight requests can complete while workers drain. If NITRIC_REQUEST_TIMEOUT is set it's cancelled when the timeout is exceeded.
+func RequestContext() (context.Context, context.CancelFunc) {
+	timeout, err := env.NITRIC_REQUEST_TIMEOUT.Int()
+	if err != nil {
+		logger.Warnf("invalid NITRIC_REQUEST_TIMEOUT %s, requests will not time out: %v", env.NITRIC_REQUEST_TIMEOUT.String(), err)
+	}
+
+	if err != nil || timeout <= 0 {
+		return context.WithCancel(context.Background())
+	}
+
+	return context.WithTimeout(context.Background(), time.Duration(timeout)*time.Second)
+}
+
 func (s *HttpGateway) newApiHandler(opts *gateway.GatewayStartOpts, apiNameParam string, originalPathParam string) func(ctx *fasthttp.RequestCtx) {
 	return func(rc *fasthttp.RequestCtx) {
 		// The API name is captured in the path using a path rewrite at the cloud API Gateway layer, and used to route the request to the correct workers
@@ -103,48 +137,92 @@ func (s *HttpGateway) newApiHandler(opts *gateway.GatewayStartOpts, apiNameParam
 			query[k].Value = append(query[k].Value, string(val))
 		})
 
+		httpRequest := &apispb.HttpRequest{
+			Method:      string(rc.Request.Header.Method()),
+			Path:        originalPath,
+			Headers:     headers,
+			QueryParams: query,
+		}
+
+		// large or chunked request bodies are streamed to the worker, rather than buffered in memory
+		var requestBody io.Reader
+		contentLength := rc.Request.Header.ContentLength()
+		if contentLength < 0 || contentLength > maxBufferedRequestBodySize {
+			requestBody = rc.RequestBodyStream()
+		} else {
+			httpRequest.Body = rc.Request.Body()
+		}
+
 		httpTrigger := &apispb.ServerMessage{
 			Content: &apispb.ServerMessage_HttpRequest{
-				HttpRequest: &apispb.HttpRequest{
-					Method:      string(rc.Request.Header.Method()),
-					Path:        originalPath,
-					Headers:     headers,
-					QueryParams: query,
-					Body:        rc.Request.Body(),
-				},
+				HttpRequest: httpRequest,
 			},
 		}
 
-		resp, err := opts.ApiPlugin.HandleRequest(apiName, httpTrigger)
+		ctx, cancel := RequestContext()
+		// continue the caller's trace
+		ctx = workers.ExtractTraceContext(ctx, propagation.HeaderCarrier(headerMap))
+
+		http, responseBody, err := opts.ApiPlugin.HandleStreamingRequest(ctx, apiName, httpTrigger, requestBody)
 		if err != nil {
+			cancel()
+
+			if errors.Is(err, context.DeadlineExceeded) {
+				rc.Error("Worker timed out handling request", 504)
+				return
+			}
+
 			rc.Error("Unable to get worker to handle request", 500)
 			return
 		}
 
-		if http := resp.GetHttpResponse(); http != nil {
-			// Copy headers across
-			for k, v := range http.Headers {
-				for _, val := range v.Value {
-					rc.Response.Header.Add(k, val)
-				}
+		// Copy headers across
+		for k, v := range http.Headers {
+			for _, val := range v.Value {
+				rc.Response.Header.Add(k, val)
 			}
+		}
 
-			// Avoid content length header duplication
-			rc.Response.Header.Del("Content-Length")
-			rc.Response.SetStatusCode(int(http.Status))
-			rc.Response.SetBody(resp.GetHttpResponse().Body)
+		// Avoid content length header duplication
+		rc.Response.Header.Del("Content-Length")
+		rc.Response.SetStatusCode(int(http.Status))
 
+		if http.StreamedBody {
+			// the body is written after this handler returns, fasthttp closes the stream once it's complete or the client disconnects
+			rc.Response.SetBodyStream(&cancelOnClose{ReadCloser: responseBody, cancel: cancel}, -1)
 			return
 		}
 
-		rc.Error("received invalid response type from worker", 500)
+		rc.Response.SetBody(http.Body)
+		_ = responseBody.Close()
+		cancel()
 	}
 }
 
+// cancelOnClose releases a request context once a streamed response body is closed
+type cancelOnClose struct {
+	io.ReadCloser
+	cancel context.CancelFunc
+}
+
+func (c *cancelOnClose) Close() error {
+	defer c.cancel()
+	return c.ReadCloser.Close()
+}
+
 func (s *HttpGateway) newHttpProxyHandler(opts *gateway.GatewayStartOpts) func(ctx *fasthttp.RequestCtx) {
 	return func(rc *fasthttp.RequestCtx) {
 		logger.Debugf("handling HTTP request: %s", rc.Request.URI())
-		resp, err := opts.HttpPlugin.HandleRequest(&rc.Request)
+		ctx, cancel := RequestContext()
+		defer cancel()
+
+		// continue the caller's trace
+		ctx = workers.ExtractTraceContext(ctx, propagation.HeaderCarrier(HttpHeadersToMap(&rc.Request.Header)))
+
+		// the proxied request is copied, which requires the full body rather than a stream
+		_ = rc.Request.Body()
+
+		resp, err := opts.HttpPlugin.HandleRequest(ctx, &rc.Request)
 		if err != nil {
 			logger.Errorf("error handling request: %s", err)
 			rc.Error("Internal Server Error", 500)
@@ -156,12 +234,71 @@ func (s *HttpGateway) newHttpProxyHandler(opts *gateway.GatewayStartOpts) func(c
 	}
 }
 
+// newLivenessHandler reports that the gateway is able to serve requests
+func (s *HttpGateway) newLivenessHandler() func(ctx *fasthttp.RequestCtx) {
+	return func(rc *fasthttp.RequestCtx) {
+		rc.SetStatusCode(200)
+		rc.SetBodyString("ok")
+	}
+}
+
+// newReadinessHandler reports whether the membrane is ready to handle triggers
+func (s *HttpGateway) newReadinessHandler(opts *gateway.GatewayStartOpts) func(ctx *fasthttp.RequestCtx) {
+	return func(rc *fasthttp.RequestCtx) {
+		if opts.Status != nil {
+			if err := opts.Status.Ready(); err != nil {
+				rc.Error(err.Error(), 503)
+				return
+			}
+		}
+
+		rc.SetStatusCode(200)
+		rc.SetBodyString("ok")
+	}
+}
+
+// newDebugWorkersHandler lists the workers registered with the membrane and the number of requests they're handling
+func (s *HttpGateway) newDebugWorkersHandler(opts *gateway.GatewayStartOpts) func(ctx *fasthttp.RequestCtx) {
+	return func(rc *fasthttp.RequestCtx) {
+		if opts.Status == nil {
+			rc.Error("worker status unavailable", 404)
+			return
+		}
+
+		body, err := json.Marshal(opts.Status.Workers())
+		if err != nil {
+			rc.Error(fmt.Sprintf("unable to describe workers: %v", err), 500)
+			return
+		}
+
+		rc.SetContentType("application/json")
+		rc.SetStatusCode(200)
+		rc.SetBody(body)
+	}
+}
+
 func (s *HttpGateway) newDaprConfigHandler() func(ctx *fasthttp.RequestCtx) {
 	return func(rc *fasthttp.RequestCtx) {
 		rc.Error("No config available", 404)
 	}
 }
 
+// refuseWhenStopping responds to new requests with 503 once the gateway stops accepting requests,
+// health checks are still served so orchestrators can see the membrane is shutting down
+func (s *HttpGateway) refuseWhenStopping(handler fasthttp.RequestHandler) fasthttp.RequestHandler {
+	return func(rc *fasthttp.RequestCtx) {
+		path := string(rc.Path())
+
+		if s.refusing.Load() && path != DefaultLivenessRoute && path != DefaultReadinessRoute {
+			rc.SetConnectionClose()
+			rc.Error("gateway is shutting down", 503)
+			return
+		}
+
+		handler(rc)
+	}
+}
+
 // Start the HTTP server and listen for requests, then route them to the appropriate handler(s
 func (s *HttpGateway) Start(opts *gateway.GatewayStartOpts) error {
 	r := fasthttprouter.New()
@@ -174,6 +311,14 @@ func (s *HttpGateway) Start(opts *gateway.GatewayStartOpts) error {
 	// Handle Dapr config request
 	r.ANY("/dapr/config", s.newDaprConfigHandler())
 
+	// Health checks for orchestrators, served under the reserved prefix so they never shadow user routes
+	r.GET(DefaultLivenessRoute, s.newLivenessHandler())
+	r.GET(DefaultReadinessRoute, s.newReadinessHandler(opts))
+
+	if debug, _ := env.NITRIC_ENABLE_DEBUG_ENDPOINTS.Bool(); debug {
+		r.GET(DefaultDebugWorkersRoute, s.newDebugWorkersHandler(opts))
+	}
+
 	// if opts.ApiPlugin.WorkerCount() > 0 {
 	// Capture the API Name to allow for accurate worker routing.
 	// also capture the original path so it can be passed to the worker without the name prefix.
@@ -192,25 +337,34 @@ func (s *HttpGateway) Start(opts *gateway.GatewayStartOpts) error {
 	s.server = &fasthttp.Server{
 		IdleTimeout:     time.Second * 1,
 		CloseOnShutdown: true,
-		Handler:         r.Handler,
+		Handler:         s.refuseWhenStopping(r.Handler),
 		ReadBufferSize:  8192,
+		// allows large API request bodies to be streamed to workers
+		StreamRequestBody: true,
 	}
 
 	return s.server.ListenAndServe(s.address)
 }
 
+// StopAccepting refuses new requests, requests already in flight are unaffected
+func (s *HttpGateway) StopAccepting() error {
+	s.refusing.Store(true)
+
+	return nil
+}
+
 // Stop the HTTP gateway server
+//
+//	new connections are refused immediately, then Stop waits for in-flight requests to complete before flushing telemetry
 func (s *HttpGateway) Stop() error {
-	tp, ok := otel.GetTracerProvider().(*sdktrace.TracerProvider)
-	if ok {
-		_ = tp.ForceFlush(context.TODO())
-		_ = tp.Shutdown(context.TODO())
-	}
-
+	var err error
 	if s.server != nil {
-		return s.server.Shutdown()
+		err = s.server.Shutdown()
 	}
-	return nil
+
+	_ = telemetry.Shutdown(context.TODO())
+
+	return err
 }
 
 // Create new HTTP gateway
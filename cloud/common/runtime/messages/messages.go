@@ -0,0 +1,148 @@
+// Copyright 2021 Nitric Technologies Pty Ltd.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package messages
+
+import (
+	"encoding/base64"
+
+	"google.golang.org/protobuf/proto"
+
+	queuespb "github.com/nitrictech/nitric/core/pkg/proto/queues/v1"
+	topicspb "github.com/nitrictech/nitric/core/pkg/proto/topics/v1"
+)
+
+// DefaultContentType is the content type of raw data delivered without one
+const DefaultContentType = "application/octet-stream"
+
+// DefaultTextContentType is the content type of raw text delivered without one
+const DefaultTextContentType = "text/plain"
+
+// ContentTypeAttribute is the message attribute producers outside of nitric can set to describe the content type of their data
+const ContentTypeAttribute = "content-type"
+
+// EncodingAttribute is the message attribute nitric sets on the messages it publishes, so they're never mistaken for data published by other producers
+const EncodingAttribute = "x-nitric-encoding"
+
+// NitricEncoding is the value of the EncodingAttribute of protobuf encoded topic and queue messages published by nitric
+const NitricEncoding = "protobuf"
+
+// IsNitricEncoded returns true if the message attributes mark the message as published by nitric
+func IsNitricEncoded(attributes map[string]string) bool {
+	return attributes[EncodingAttribute] == NitricEncoding
+}
+
+func contentType(attributes map[string]string, defaultContentType string) string {
+	if contentType, ok := attributes[ContentTypeAttribute]; ok && contentType != "" {
+		return contentType
+	}
+
+	return defaultContentType
+}
+
+// unmarshalTopicMessage returns false if the data isn't a valid topic message
+func unmarshalTopicMessage(data []byte) (*topicspb.TopicMessage, bool) {
+	var message topicspb.TopicMessage
+	if err := proto.Unmarshal(data, &message); err != nil {
+		return nil, false
+	}
+
+	return &message, true
+}
+
+func rawTopicMessage(data []byte, attributes map[string]string, contentType string) *topicspb.TopicMessage {
+	return &topicspb.TopicMessage{
+		Content: &topicspb.TopicMessage_BytesPayload{
+			BytesPayload: &topicspb.BytesPayload{
+				Data:        data,
+				ContentType: contentType,
+			},
+		},
+		Attributes: attributes,
+	}
+}
+
+// DecodeTopicMessage decodes a message published to a topic by nitric, identified by its EncodingAttribute,
+// data published to the topic by other producers is delivered as a bytes payload along with the provider message attributes
+func DecodeTopicMessage(data []byte, attributes map[string]string) *topicspb.TopicMessage {
+	if IsNitricEncoded(attributes) {
+		if message, ok := unmarshalTopicMessage(data); ok {
+			return message
+		}
+	}
+
+	return rawTopicMessage(data, attributes, contentType(attributes, DefaultContentType))
+}
+
+// DecodeBase64TopicMessage decodes a base64 encoded message published to a topic by nitric, identified by its EncodingAttribute,
+// text published to the topic by other producers is delivered as a bytes payload containing the original text
+func DecodeBase64TopicMessage(text string, attributes map[string]string) *topicspb.TopicMessage {
+	if IsNitricEncoded(attributes) {
+		if data, err := base64.StdEncoding.DecodeString(text); err == nil {
+			if message, ok := unmarshalTopicMessage(data); ok {
+				return message
+			}
+		}
+	}
+
+	return rawTopicMessage([]byte(text), attributes, contentType(attributes, DefaultTextContentType))
+}
+
+// unmarshalQueueMessage returns false if the data isn't a valid queue message
+func unmarshalQueueMessage(data []byte) (*queuespb.QueueMessage, bool) {
+	var message queuespb.QueueMessage
+	if err := proto.Unmarshal(data, &message); err != nil {
+		return nil, false
+	}
+
+	return &message, true
+}
+
+func rawQueueMessage(data []byte, attributes map[string]string, contentType string) *queuespb.QueueMessage {
+	return &queuespb.QueueMessage{
+		Content: &queuespb.QueueMessage_BytesPayload{
+			BytesPayload: &queuespb.BytesPayload{
+				Data:        data,
+				ContentType: contentType,
+			},
+		},
+		Attributes: attributes,
+	}
+}
+
+// DecodeQueueMessage decodes a message enqueued by nitric, identified by its EncodingAttribute,
+// data sent to the queue by other producers is delivered as a bytes payload along with the provider message attributes
+func DecodeQueueMessage(data []byte, attributes map[string]string) *queuespb.QueueMessage {
+	if IsNitricEncoded(attributes) {
+		if message, ok := unmarshalQueueMessage(data); ok {
+			return message
+		}
+	}
+
+	return rawQueueMessage(data, attributes, contentType(attributes, DefaultContentType))
+}
+
+// DecodeBase64QueueMessage decodes a base64 encoded message enqueued by nitric, identified by its EncodingAttribute,
+// text sent to the queue by other producers is delivered as a bytes payload containing the original text
+func DecodeBase64QueueMessage(text string, attributes map[string]string) *queuespb.QueueMessage {
+	if IsNitricEncoded(attributes) {
+		if data, err := base64.StdEncoding.DecodeString(text); err == nil {
+			if message, ok := unmarshalQueueMessage(data); ok {
+				return message
+			}
+		}
+	}
+
+	return rawQueueMessage([]byte(text), attributes, contentType(attributes, DefaultTextContentType))
+}
@@ -0,0 +1,107 @@
+// Copyright 2021 Nitric Technologies Pty Ltd.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package messages
+
+import (
+	"encoding/base64"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/ginkgo/extensions/table"
+	. "github.com/onsi/gomega"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/structpb"
+
+	queuespb "github.com/nitrictech/nitric/core/pkg/proto/queues/v1"
+	topicspb "github.com/nitrictech/nitric/core/pkg/proto/topics/v1"
+)
+
+var _ = Describe("Messages", func() {
+	content, _ := structpb.NewStruct(map[string]interface{}{"test": "test"})
+
+	topicMessage := &topicspb.TopicMessage{
+		Content:    &topicspb.TopicMessage_StructPayload{StructPayload: content},
+		Attributes: map[string]string{"region": "us"},
+	}
+	topicBytes, _ := proto.Marshal(topicMessage)
+
+	queueMessage := &queuespb.QueueMessage{
+		Content: &queuespb.QueueMessage_StructPayload{StructPayload: content},
+	}
+	queueBytes, _ := proto.Marshal(queueMessage)
+
+	nitricAttributes := map[string]string{EncodingAttribute: NitricEncoding}
+
+	// foreign binary data that happens to be a valid, but meaningless, protobuf encoding
+	foreignData := []byte{0x0a, 0x00}
+
+	rawTopicPayload := func(data []byte, contentType string, attributes map[string]string) *topicspb.TopicMessage {
+		return &topicspb.TopicMessage{
+			Content: &topicspb.TopicMessage_BytesPayload{
+				BytesPayload: &topicspb.BytesPayload{Data: data, ContentType: contentType},
+			},
+			Attributes: attributes,
+		}
+	}
+
+	rawQueuePayload := func(data []byte, contentType string, attributes map[string]string) *queuespb.QueueMessage {
+		return &queuespb.QueueMessage{
+			Content: &queuespb.QueueMessage_BytesPayload{
+				BytesPayload: &queuespb.BytesPayload{Data: data, ContentType: contentType},
+			},
+			Attributes: attributes,
+		}
+	}
+
+	DescribeTable("DecodeTopicMessage",
+		func(data []byte, attributes map[string]string, expected *topicspb.TopicMessage) {
+			Expect(proto.Equal(DecodeTopicMessage(data, attributes), expected)).To(BeTrue())
+		},
+		Entry("a nitric message", topicBytes, nitricAttributes, topicMessage),
+		Entry("a nitric encoding without the encoding attribute", topicBytes, nil, rawTopicPayload(topicBytes, DefaultContentType, nil)),
+		Entry("foreign binary data", foreignData, map[string]string{"region": "us"}, rawTopicPayload(foreignData, DefaultContentType, map[string]string{"region": "us"})),
+		Entry("foreign binary data with a content type", foreignData, map[string]string{ContentTypeAttribute: "image/png"}, rawTopicPayload(foreignData, "image/png", map[string]string{ContentTypeAttribute: "image/png"})),
+		Entry("foreign binary data with a blank content type", foreignData, map[string]string{ContentTypeAttribute: ""}, rawTopicPayload(foreignData, DefaultContentType, map[string]string{ContentTypeAttribute: ""})),
+		Entry("invalid data marked as a nitric message", []byte{0xff}, nitricAttributes, rawTopicPayload([]byte{0xff}, DefaultContentType, nitricAttributes)),
+	)
+
+	DescribeTable("DecodeBase64TopicMessage",
+		func(text string, attributes map[string]string, expected *topicspb.TopicMessage) {
+			Expect(proto.Equal(DecodeBase64TopicMessage(text, attributes), expected)).To(BeTrue())
+		},
+		Entry("a nitric message", base64.StdEncoding.EncodeToString(topicBytes), nitricAttributes, topicMessage),
+		Entry("base64 text", "Cg==", nil, rawTopicPayload([]byte("Cg=="), DefaultTextContentType, nil)),
+		Entry("plain text with a content type", "a,b", map[string]string{ContentTypeAttribute: "text/csv"}, rawTopicPayload([]byte("a,b"), "text/csv", map[string]string{ContentTypeAttribute: "text/csv"})),
+		Entry("invalid base64 marked as a nitric message", "not base64", nitricAttributes, rawTopicPayload([]byte("not base64"), DefaultTextContentType, nitricAttributes)),
+	)
+
+	DescribeTable("DecodeQueueMessage",
+		func(data []byte, attributes map[string]string, expected *queuespb.QueueMessage) {
+			Expect(proto.Equal(DecodeQueueMessage(data, attributes), expected)).To(BeTrue())
+		},
+		Entry("a nitric message", queueBytes, nitricAttributes, queueMessage),
+		Entry("a nitric encoding without the encoding attribute", queueBytes, nil, rawQueuePayload(queueBytes, DefaultContentType, nil)),
+		Entry("foreign binary data", foreignData, nil, rawQueuePayload(foreignData, DefaultContentType, nil)),
+		Entry("foreign binary data with a content type", foreignData, map[string]string{ContentTypeAttribute: "image/png"}, rawQueuePayload(foreignData, "image/png", map[string]string{ContentTypeAttribute: "image/png"})),
+	)
+
+	DescribeTable("DecodeBase64QueueMessage",
+		func(text string, attributes map[string]string, expected *queuespb.QueueMessage) {
+			Expect(proto.Equal(DecodeBase64QueueMessage(text, attributes), expected)).To(BeTrue())
+		},
+		Entry("a nitric message", base64.StdEncoding.EncodeToString(queueBytes), nitricAttributes, queueMessage),
+		Entry("base64 text", "Cg==", nil, rawQueuePayload([]byte("Cg=="), DefaultTextContentType, nil)),
+		Entry("plain text with a content type", "{}", map[string]string{ContentTypeAttribute: "application/json"}, rawQueuePayload([]byte("{}"), "application/json", map[string]string{ContentTypeAttribute: "application/json"})),
+	)
+})
@@ -0,0 +1,105 @@
+// Copyright 2021 Nitric Technologies Pty Ltd.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package storagetest provides storage streams for testing provider implementations of the streaming storage RPCs
+package storagetest
+
+import (
+	"bytes"
+	"context"
+	"io"
+
+	"google.golang.org/grpc"
+
+	storagepb "github.com/nitrictech/nitric/core/pkg/proto/storage/v1"
+)
+
+// ReadStream collects the chunks sent on a read stream
+type ReadStream struct {
+	grpc.ServerStream
+	Body bytes.Buffer
+}
+
+var _ storagepb.Storage_ReadStreamServer = &ReadStream{}
+
+func (r *ReadStream) Context() context.Context {
+	return context.TODO()
+}
+
+func (r *ReadStream) Send(resp *storagepb.StorageReadStreamResponse) error {
+	r.Body.Write(resp.Chunk)
+	return nil
+}
+
+// WriteStream replays a header followed by chunks on a write stream
+type WriteStream struct {
+	grpc.ServerStream
+	messages []*storagepb.StorageWriteStreamRequest
+	// the response sent once the stream has been written
+	Response *storagepb.StorageWriteStreamResponse
+}
+
+var _ storagepb.Storage_WriteStreamServer = &WriteStream{}
+
+// NewWriteStream creates a write stream for the given blob, that sends each chunk in order
+func NewWriteStream(bucket string, key string, chunks ...[]byte) *WriteStream {
+	header := &storagepb.StorageWriteStreamRequest{
+		Content: &storagepb.StorageWriteStreamRequest_Header{
+			Header: &storagepb.StorageWriteStreamHeader{
+				BucketName: bucket,
+				Key:        key,
+			},
+		},
+	}
+
+	stream := NewHeaderlessWriteStream(chunks...)
+	stream.messages = append([]*storagepb.StorageWriteStreamRequest{header}, stream.messages...)
+
+	return stream
+}
+
+// NewHeaderlessWriteStream creates an invalid write stream, that sends each chunk without sending a header first
+func NewHeaderlessWriteStream(chunks ...[]byte) *WriteStream {
+	messages := []*storagepb.StorageWriteStreamRequest{}
+
+	for _, chunk := range chunks {
+		messages = append(messages, &storagepb.StorageWriteStreamRequest{
+			Content: &storagepb.StorageWriteStreamRequest_Chunk{
+				Chunk: chunk,
+			},
+		})
+	}
+
+	return &WriteStream{messages: messages}
+}
+
+func (w *WriteStream) Context() context.Context {
+	return context.TODO()
+}
+
+func (w *WriteStream) Recv() (*storagepb.StorageWriteStreamRequest, error) {
+	if len(w.messages) == 0 {
+		return nil, io.EOF
+	}
+
+	msg := w.messages[0]
+	w.messages = w.messages[1:]
+
+	return msg, nil
+}
+
+func (w *WriteStream) SendAndClose(resp *storagepb.StorageWriteStreamResponse) error {
+	w.Response = resp
+	return nil
+}
@@ -0,0 +1,127 @@
+// Copyright 2021 Nitric Technologies Pty Ltd.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package storage
+
+import (
+	"errors"
+	"fmt"
+	"io"
+
+	storagepb "github.com/nitrictech/nitric/core/pkg/proto/storage/v1"
+)
+
+// ChunkSize - the maximum number of bytes sent in each message of a read stream
+const ChunkSize = 64 * 1024
+
+// ValidateRange - check the byte range of a read stream request, a length of 0 reads to the end of the blob
+func ValidateRange(req *storagepb.StorageReadStreamRequest) error {
+	if req.Offset < 0 {
+		return fmt.Errorf("offset must not be negative, got %d", req.Offset)
+	}
+
+	if req.Length < 0 {
+		return fmt.Errorf("length must not be negative, got %d", req.Length)
+	}
+
+	return nil
+}
+
+// SendChunks - send the contents of a reader to a read stream, in chunks of up to ChunkSize bytes
+func SendChunks(stream storagepb.Storage_ReadStreamServer, r io.Reader) error {
+	buf := make([]byte, ChunkSize)
+
+	for {
+		n, err := io.ReadFull(r, buf)
+		if n > 0 {
+			if sendErr := stream.Send(&storagepb.StorageReadStreamResponse{
+				Chunk: buf[:n],
+			}); sendErr != nil {
+				return sendErr
+			}
+		}
+
+		if errors.Is(err, io.EOF) || errors.Is(err, io.ErrUnexpectedEOF) {
+			return nil
+		}
+
+		if err != nil {
+			return err
+		}
+	}
+}
+
+// ChunkReader - reads the chunks of a write stream as a contiguous body
+type ChunkReader struct {
+	stream storagepb.Storage_WriteStreamServer
+	buf    []byte
+	size   int64
+	done   bool
+}
+
+var _ io.Reader = &ChunkReader{}
+
+func (r *ChunkReader) Read(p []byte) (int, error) {
+	for len(r.buf) == 0 {
+		if r.done {
+			return 0, io.EOF
+		}
+
+		msg, err := r.stream.Recv()
+		if errors.Is(err, io.EOF) {
+			r.done = true
+			return 0, io.EOF
+		}
+
+		if err != nil {
+			return 0, err
+		}
+
+		if msg.GetHeader() != nil {
+			return 0, fmt.Errorf("unexpected header, the header must only be sent as the first message of the stream")
+		}
+
+		r.buf = msg.GetChunk()
+	}
+
+	n := copy(p, r.buf)
+	r.buf = r.buf[n:]
+	r.size += int64(n)
+
+	return n, nil
+}
+
+// Size - the number of bytes read so far
+func (r *ChunkReader) Size() int64 {
+	return r.size
+}
+
+// ReceiveWriteStream - read the header of a write stream, returning it along with a reader over the chunks that follow
+func ReceiveWriteStream(stream storagepb.Storage_WriteStreamServer) (*storagepb.StorageWriteStreamHeader, *ChunkReader, error) {
+	msg, err := stream.Recv()
+	if err != nil {
+		return nil, nil, err
+	}
+
+	header := msg.GetHeader()
+	if header == nil {
+		return nil, nil, fmt.Errorf("the first message of a write stream must be a header")
+	}
+
+	if header.BucketName == "" || header.Key == "" {
+		return nil, nil, fmt.Errorf("the write stream header must include a bucket name and key")
+	}
+
+	return header, &ChunkReader{stream: stream}, nil
+}
@@ -0,0 +1,80 @@
+// Copyright 2021 Nitric Technologies Pty Ltd.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package telemetry
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/exporters/otlp/otlpmetric/otlpmetricgrpc"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracegrpc"
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+
+	"github.com/nitrictech/nitric/cloud/common/runtime/env"
+)
+
+// Setup registers global trace and meter providers that export to the OpenTelemetry collector bundled with telemetry enabled images.
+//
+//	Nothing is registered unless NITRIC_TRACE_SAMPLE_PERCENT is greater than 0. Both exporters use the standard
+//	OTEL_EXPORTER_OTLP_* configuration, defaulting to the collector's OTLP gRPC receiver on localhost.
+func Setup(ctx context.Context) error {
+	samplePercent, err := env.NITRIC_TRACE_SAMPLE_PERCENT.Int()
+	if err != nil {
+		return fmt.Errorf("invalid NITRIC_TRACE_SAMPLE_PERCENT: %w", err)
+	}
+
+	if samplePercent <= 0 {
+		return nil
+	}
+
+	traceExporter, err := otlptracegrpc.New(ctx, otlptracegrpc.WithInsecure())
+	if err != nil {
+		return fmt.Errorf("unable to create trace exporter: %w", err)
+	}
+
+	metricExporter, err := otlpmetricgrpc.New(ctx, otlpmetricgrpc.WithInsecure())
+	if err != nil {
+		return fmt.Errorf("unable to create metric exporter: %w", err)
+	}
+
+	otel.SetTracerProvider(sdktrace.NewTracerProvider(
+		sdktrace.WithBatcher(traceExporter),
+		sdktrace.WithSampler(sdktrace.ParentBased(sdktrace.TraceIDRatioBased(float64(samplePercent)/100))),
+	))
+
+	otel.SetMeterProvider(sdkmetric.NewMeterProvider(
+		sdkmetric.WithReader(sdkmetric.NewPeriodicReader(metricExporter)),
+	))
+
+	return nil
+}
+
+// Shutdown flushes and stops the global trace and meter providers registered by Setup
+func Shutdown(ctx context.Context) error {
+	var errs []error
+
+	if tp, ok := otel.GetTracerProvider().(*sdktrace.TracerProvider); ok {
+		errs = append(errs, tp.ForceFlush(ctx), tp.Shutdown(ctx))
+	}
+
+	if mp, ok := otel.GetMeterProvider().(*sdkmetric.MeterProvider); ok {
+		errs = append(errs, mp.ForceFlush(ctx), mp.Shutdown(ctx))
+	}
+
+	return errors.Join(errs...)
+}
@@ -15,6 +15,9 @@
 package main
 
 import (
+	"context"
+
+	"github.com/nitrictech/nitric/cloud/common/runtime/telemetry"
 	"github.com/nitrictech/nitric/cloud/gcp/runtime"
 	"github.com/nitrictech/nitric/cloud/gcp/runtime/resource"
 	"github.com/nitrictech/nitric/core/pkg/logger"
@@ -28,6 +31,10 @@ func main() {
 		return
 	}
 
+	if err := telemetry.Setup(context.Background()); err != nil {
+		logger.Fatalf("could not set up telemetry: %v", err)
+	}
+
 	m, err := runtime.NewGcpRuntimeServer(resourceResolver)
 	if err != nil {
 		logger.Fatalf("there was an error initializing the nitric server: %v", err)
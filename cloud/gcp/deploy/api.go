@@ -24,6 +24,7 @@ import (
 	"strings"
 
 	"github.com/nitrictech/nitric/cloud/common/deploy/resources"
+	commonapi "github.com/nitrictech/nitric/cloud/common/deploy/resources/api"
 	"github.com/nitrictech/nitric/core/pkg/help"
 	deploymentspb "github.com/nitrictech/nitric/core/pkg/proto/deployments/v1"
 
@@ -100,6 +101,9 @@ func (p *NitricGcpPulumiProvider) Api(ctx *pulumi.Context, parent pulumi.Resourc
 		return err
 	}
 
+	// wildcard routes are described with greedy path params, translate them to API Gateway's wildcard syntax
+	v2doc.Paths = commonapi.TranslateGreedyPathParams(v2doc.Paths, commonapi.GoogleGreedyPathParam)
+
 	// Get service targets for IAM binding
 	services := p.CloudRunServices
 
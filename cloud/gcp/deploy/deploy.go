@@ -88,6 +88,8 @@ type NitricGcpPulumiProvider struct {
 	QueueSubscriptions     map[string]*pubsub.Subscription
 	Secrets                map[string]*secretmanager.Secret
 	DatabaseMigrationBuild map[string]*cloudrunv2.Job
+	// Queues whose subscription pushes messages to a listener, they can't also be dequeued from
+	PushQueues map[string]bool
 
 	BatchServiceAccounts map[string]*GcpIamServiceAccount
 	masterDb             *sql.DatabaseInstance
@@ -436,6 +438,7 @@ func NewNitricGcpProvider() *NitricGcpPulumiProvider {
 		Topics:                 make(map[string]*pubsub.Topic),
 		Queues:                 make(map[string]*pubsub.Topic),
 		QueueSubscriptions:     make(map[string]*pubsub.Subscription),
+		PushQueues:             make(map[string]bool),
 		Secrets:                make(map[string]*secretmanager.Secret),
 		DatabaseMigrationBuild: make(map[string]*cloudrunv2.Job),
 	}
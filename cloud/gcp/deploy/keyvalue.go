@@ -15,11 +15,115 @@
 package deploy
 
 import (
+	"encoding/base64"
+	"fmt"
+	"strings"
+
+	"github.com/nitrictech/nitric/cloud/common/deploy/resources"
+	common "github.com/nitrictech/nitric/cloud/common/deploy/tags"
 	deploymentspb "github.com/nitrictech/nitric/core/pkg/proto/deployments/v1"
+	kvstorepb "github.com/nitrictech/nitric/core/pkg/proto/kvstore/v1"
+	"github.com/pkg/errors"
+	"github.com/pulumi/pulumi-gcp/sdk/v8/go/gcp/eventarc"
+	"github.com/pulumi/pulumi-gcp/sdk/v8/go/gcp/firestore"
+	"github.com/pulumi/pulumi-gcp/sdk/v8/go/gcp/projects"
 	"github.com/pulumi/pulumi/sdk/v3/go/pulumi"
 )
 
 func (p *NitricGcpPulumiProvider) KeyValueStore(ctx *pulumi.Context, parent pulumi.Resource, name string, config *deploymentspb.KeyValueStore) error {
-	// keyvalue stores are created at runtime in GCP, so we don't need to do anything here.
+	// keyvalue stores are created at runtime in GCP, only the ttl policy of the store's collection is deployed.
+	// values set with a ttl store their expiry time in the _ttl field, firestore deletes them after it has passed.
+	_, err := firestore.NewField(ctx, fmt.Sprintf("%s-ttl", name), &firestore.FieldArgs{
+		Project:    pulumi.String(p.GcpConfig.ProjectId),
+		Database:   pulumi.String("(default)"),
+		Collection: pulumi.String(name),
+		Field:      pulumi.String("_ttl"),
+		TtlConfig:  &firestore.FieldTtlConfigArgs{},
+	}, pulumi.Parent(parent))
+	if err != nil {
+		return err
+	}
+
+	for _, listener := range config.GetListeners() {
+		if err := p.newFirestoreTrigger(ctx, parent, name, listener); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// newFirestoreTrigger delivers the firestore document events of a store's collection to the listening service through eventarc.
+// eventarc path patterns can't match a partial document id, so the key prefix filter is encoded in the destination path and applied by the service's gateway.
+func (p *NitricGcpPulumiProvider) newFirestoreTrigger(ctx *pulumi.Context, parent pulumi.Resource, storeName string, listener *deploymentspb.KeyValueStoreListener) error {
+	if listener == nil || listener.Config == nil {
+		return fmt.Errorf("invalid config provided for key value store listener")
+	}
+
+	targetService, ok := p.CloudRunServices[listener.GetService()]
+	if !ok {
+		return fmt.Errorf("unable to find target service for key value store listener: %s", listener.GetService())
+	}
+
+	prefix := listener.Config.KeyPrefixFilter
+	if prefix == "*" {
+		prefix = ""
+	}
+
+	// eventarc delivers events to the service as its invoker, which must be permitted to receive them
+	_, err := projects.NewIAMMember(ctx, fmt.Sprintf("%s-%s-event-receiver", storeName, listener.GetService()), &projects.IAMMemberArgs{
+		Project: pulumi.String(p.GcpConfig.ProjectId),
+		Member:  pulumi.Sprintf("serviceAccount:%s", targetService.Invoker.Email),
+		Role:    pulumi.String("roles/eventarc.eventReceiver"),
+	}, pulumi.Parent(parent))
+	if err != nil {
+		return errors.WithMessage(err, "event receiver membership "+storeName)
+	}
+
+	for _, eventType := range keyValueEventTypeToFirestoreEventTypes(listener.Config.EventType) {
+		name := fmt.Sprintf("%s-%s-%s", storeName, listener.GetService(), eventType[strings.LastIndex(eventType, ".")+1:])
+
+		_, err := eventarc.NewTrigger(ctx, name, &eventarc.TriggerArgs{
+			Location: pulumi.String(p.Region),
+			MatchingCriterias: eventarc.TriggerMatchingCriteriaArray{
+				eventarc.TriggerMatchingCriteriaArgs{
+					Attribute: pulumi.String("type"),
+					Value:     pulumi.String(eventType),
+				},
+				eventarc.TriggerMatchingCriteriaArgs{
+					Attribute: pulumi.String("database"),
+					Value:     pulumi.String("(default)"),
+				},
+				eventarc.TriggerMatchingCriteriaArgs{
+					Attribute: pulumi.String("document"),
+					Operator:  pulumi.String("match-path-pattern"),
+					Value:     pulumi.Sprintf("%s/{key}", storeName),
+				},
+			},
+			EventDataContentType: pulumi.String("application/protobuf"),
+			Destination: eventarc.TriggerDestinationArgs{
+				CloudRunService: eventarc.TriggerDestinationCloudRunServiceArgs{
+					Service: targetService.Service.Name,
+					Region:  pulumi.String(p.Region),
+					Path:    pulumi.Sprintf("/x-nitric-notification/keyvalue/%s/%s", storeName, base64.RawURLEncoding.EncodeToString([]byte(prefix))),
+				},
+			},
+			ServiceAccount: targetService.Invoker.Email,
+			Labels:         pulumi.ToStringMap(common.Tags(p.StackId, name, resources.Collection)),
+		}, p.WithDefaultResourceOptions(pulumi.Parent(parent))...)
+		if err != nil {
+			return errors.WithMessage(err, "eventarc trigger "+name)
+		}
+	}
+
 	return nil
 }
+
+func keyValueEventTypeToFirestoreEventTypes(eventType kvstorepb.KeyValueEventType) []string {
+	switch eventType {
+	case kvstorepb.KeyValueEventType_Delete:
+		return []string{"google.cloud.firestore.document.v1.deleted"}
+	default:
+		return []string{"google.cloud.firestore.document.v1.created", "google.cloud.firestore.document.v1.updated"}
+	}
+}
@@ -294,6 +294,11 @@ func (p *NitricGcpPulumiProvider) Policy(ctx *pulumi.Context, parent pulumi.Reso
 				}
 
 				if needSubConsume {
+					// a queue's only subscription is a push subscription once it has a listener, pulling from it would take messages from the listener
+					if p.PushQueues[resource.Id.Name] {
+						return fmt.Errorf("queue %s has a listener, so it can't also be dequeued from on gcp", resource.Id.Name)
+					}
+
 					subscription := p.QueueSubscriptions[resource.Id.Name]
 					subRolePolicy, err := NewCustomRole(ctx, name+"subscription", []string{"pubsub.subscriptions.consume"}, opts...)
 					if err != nil {
@@ -22,8 +22,9 @@ import (
 type Project struct {
 	pulumi.ResourceState
 
-	Name     string
-	Services []*projects.Service
+	Name          string
+	ProjectNumber string
+	Services      []*projects.Service
 }
 
 type ProjectArgs struct {
@@ -67,6 +68,8 @@ var requiredServices = []string{
 	"vpcaccess.googleapis.com",
 	// Enable Cloud Build API
 	"cloudbuild.googleapis.com",
+	// Enable Eventarc API
+	"eventarc.googleapis.com",
 	// Enable Batch API
 	"batch.googleapis.com",
 }
@@ -74,8 +77,9 @@ var requiredServices = []string{
 // Creates a new GCP Project
 func NewProject(ctx *pulumi.Context, name string, args *ProjectArgs, opts ...pulumi.ResourceOption) (*Project, error) {
 	res := &Project{
-		Name:     name,
-		Services: []*projects.Service{},
+		Name:          name,
+		ProjectNumber: args.ProjectNumber,
+		Services:      []*projects.Service{},
 	}
 
 	err := ctx.RegisterComponentResource("ntiricgcp:project:GcpProject", name, res, opts...)
@@ -21,6 +21,8 @@ import (
 
 	"github.com/nitrictech/nitric/cloud/common/deploy/resources"
 	deploymentspb "github.com/nitrictech/nitric/core/pkg/proto/deployments/v1"
+	resourcespb "github.com/nitrictech/nitric/core/pkg/proto/resources/v1"
+	"github.com/samber/lo"
 
 	common "github.com/nitrictech/nitric/cloud/common/deploy/tags"
 	"github.com/pulumi/pulumi-gcp/sdk/v8/go/gcp/pubsub"
@@ -33,23 +35,119 @@ func (p *NitricGcpPulumiProvider) Queue(ctx *pulumi.Context, parent pulumi.Resou
 
 	resourceLabels := common.Tags(p.StackId, name, resources.Queue)
 
+	topicLabels := lo.Assign(resourceLabels)
+	if config.Fifo {
+		// The runtime publishes with ordering keys to topics labelled as FIFO
+		topicLabels[common.GetQueueFifoKey(p.StackId)] = "true"
+	}
+
 	p.Queues[name], err = pubsub.NewTopic(ctx, fmt.Sprintf("%s-queue", name), &pubsub.TopicArgs{
-		Labels: pulumi.ToStringMap(resourceLabels),
+		Labels: pulumi.ToStringMap(topicLabels),
 	}, p.WithDefaultResourceOptions(opts...)...)
 	if err != nil {
 		return err
 	}
 
-	p.QueueSubscriptions[name], err = pubsub.NewSubscription(ctx, fmt.Sprintf("%s-nitricqueue", name), &pubsub.SubscriptionArgs{
+	subscriptionArgs := &pubsub.SubscriptionArgs{
 		Topic:  p.Queues[name].Name,
 		Labels: pulumi.ToStringMap(resourceLabels),
 		ExpirationPolicy: &pubsub.SubscriptionExpirationPolicyArgs{
 			Ttl: pulumi.String(""),
 		},
+		EnableMessageOrdering: pulumi.Bool(config.Fifo),
+	}
+
+	if listeners := config.GetListeners(); len(listeners) > 0 {
+		// the subscription pushes each message to a single endpoint, so messages can't be shared between services
+		if len(listeners) > 1 {
+			return fmt.Errorf("queue %s has %d listeners, queues can only have one listener on gcp", name, len(listeners))
+		}
+
+		targetService, ok := p.CloudRunServices[listeners[0].GetService()]
+		if !ok {
+			return fmt.Errorf("invalid service %s given for queue listener", listeners[0].GetService())
+		}
+
+		// pushed messages aren't batched, the service limits its concurrency as the messages arrive
+		subscriptionArgs.AckDeadlineSeconds = pulumi.Int(300)
+		subscriptionArgs.RetryPolicy = pubsub.SubscriptionRetryPolicyArgs{
+			MinimumBackoff: pulumi.String("15s"),
+			MaximumBackoff: pulumi.String("600s"),
+		}
+		subscriptionArgs.PushConfig = pubsub.SubscriptionPushConfigArgs{
+			OidcToken: pubsub.SubscriptionPushConfigOidcTokenArgs{
+				ServiceAccountEmail: targetService.Invoker.Email,
+			},
+			PushEndpoint: pulumi.Sprintf("%s/x-nitric-queue/%s?token=%s", targetService.Url, name, targetService.EventToken),
+		}
+
+		p.PushQueues[name] = true
+	}
+
+	policy := config.GetDeadLetterPolicy()
+	if policy != nil {
+		if err := validateDeadLetterPolicy(name, policy); err != nil {
+			return err
+		}
+
+		target, ok := p.Queues[policy.TargetQueue]
+		if !ok {
+			return fmt.Errorf("dead-letter target queue %s for queue %s not found", policy.TargetQueue, name)
+		}
+
+		subscriptionArgs.DeadLetterPolicy = &pubsub.SubscriptionDeadLetterPolicyArgs{
+			DeadLetterTopic:     target.ID(),
+			MaxDeliveryAttempts: pulumi.Int(int(policy.MaxReceiveCount)),
+		}
+	}
+
+	p.QueueSubscriptions[name], err = pubsub.NewSubscription(ctx, fmt.Sprintf("%s-nitricqueue", name), subscriptionArgs, p.WithDefaultResourceOptions(opts...)...)
+	if err != nil {
+		return err
+	}
+
+	if policy != nil {
+		err = p.allowDeadLetters(ctx, name, p.QueueSubscriptions[name], policy.TargetQueue, opts...)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// allowDeadLetters - The Pub/Sub service agent forwards undeliverable messages, so it needs to publish to the dead-letter topic
+// and acknowledge the forwarded messages on the subscription
+func (p *NitricGcpPulumiProvider) allowDeadLetters(ctx *pulumi.Context, name string, subscription *pubsub.Subscription, deadLetterQueue string, opts ...pulumi.ResourceOption) error {
+	serviceAgent := pulumi.Sprintf("serviceAccount:service-%s@gcp-sa-pubsub.iam.gserviceaccount.com", p.Project.ProjectNumber)
+
+	_, err := pubsub.NewTopicIAMMember(ctx, fmt.Sprintf("%s-deadletter-publisher", name), &pubsub.TopicIAMMemberArgs{
+		Topic:  p.Queues[deadLetterQueue].Name,
+		Role:   pulumi.String("roles/pubsub.publisher"),
+		Member: serviceAgent,
 	}, p.WithDefaultResourceOptions(opts...)...)
 	if err != nil {
 		return err
 	}
 
+	_, err = pubsub.NewSubscriptionIAMMember(ctx, fmt.Sprintf("%s-deadletter-subscriber", name), &pubsub.SubscriptionIAMMemberArgs{
+		Subscription: subscription.Name,
+		Role:         pulumi.String("roles/pubsub.subscriber"),
+		Member:       serviceAgent,
+	}, p.WithDefaultResourceOptions(opts...)...)
+
+	return err
+}
+
+// validateDeadLetterPolicy - Pub/Sub dead-letter policies accept between 5 and 100 delivery attempts
+func validateDeadLetterPolicy(name string, policy *resourcespb.QueueDeadLetterPolicy) error {
+	if policy.TargetQueue == name {
+		return fmt.Errorf("queue %s can't be its own dead-letter target", name)
+	}
+
+	if policy.MaxReceiveCount < 5 || policy.MaxReceiveCount > 100 {
+		return fmt.Errorf("max receive count for queue %s must be between 5 and 100, got %d", name, policy.MaxReceiveCount)
+	}
+
 	return nil
 }
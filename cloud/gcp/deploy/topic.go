@@ -18,20 +18,135 @@ package deploy
 
 import (
 	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
 
 	"github.com/nitrictech/nitric/cloud/common/deploy/resources"
 
 	common "github.com/nitrictech/nitric/cloud/common/deploy/tags"
 	deploymentspb "github.com/nitrictech/nitric/core/pkg/proto/deployments/v1"
+	topicspb "github.com/nitrictech/nitric/core/pkg/proto/topics/v1"
+	"github.com/nitrictech/nitric/core/pkg/workers/topics"
 	"github.com/pkg/errors"
 	"github.com/pulumi/pulumi-gcp/sdk/v8/go/gcp/pubsub"
 	"github.com/pulumi/pulumi/sdk/v3/go/pulumi"
+	"github.com/samber/lo"
 )
 
 func GetSubName(serviceName string, topicName string) string {
 	return fmt.Sprintf("%s-%s-sub", serviceName, topicName)
 }
 
+// pubsub subscription filters are limited to 256 bytes
+const maxSubscriptionFilterLength = 256
+
+// attribute keys are referenced as identifiers in pubsub filters
+var filterAttributeKeyPattern = regexp.MustCompile(`^[a-zA-Z_][a-zA-Z0-9_]*$`)
+
+// PubsubFilter - converts a nitric attribute filter to a pubsub subscription filter, returns an empty filter if the filter has no conditions
+func PubsubFilter(filter *topicspb.AttributeFilter) (string, error) {
+	if err := topics.ValidateFilter(filter); err != nil {
+		return "", err
+	}
+
+	expressions := []string{}
+	for _, condition := range filter.GetConditions() {
+		key := condition.GetKey()
+		if !filterAttributeKeyPattern.MatchString(key) {
+			return "", fmt.Errorf("attribute %s can't be filtered on gcp, filtered attribute keys may only contain letters, numbers and underscores", key)
+		}
+
+		switch match := condition.GetMatch().(type) {
+		case *topicspb.AttributeCondition_AnyOf:
+			values := lo.Map(match.AnyOf.GetValues(), func(value string, _ int) string {
+				return fmt.Sprintf("attributes.%s = %s", key, strconv.Quote(value))
+			})
+			expressions = append(expressions, fmt.Sprintf("(%s)", strings.Join(values, " OR ")))
+		case *topicspb.AttributeCondition_NoneOf:
+			// inequality alone also matches messages without the attribute
+			values := lo.Map(match.NoneOf.GetValues(), func(value string, _ int) string {
+				return fmt.Sprintf("attributes.%s != %s", key, strconv.Quote(value))
+			})
+			expressions = append(expressions, fmt.Sprintf("attributes:%s", key), strings.Join(values, " AND "))
+		case *topicspb.AttributeCondition_Prefix:
+			expressions = append(expressions, fmt.Sprintf("hasPrefix(attributes.%s, %s)", key, strconv.Quote(match.Prefix)))
+		case *topicspb.AttributeCondition_Exists:
+			if match.Exists {
+				expressions = append(expressions, fmt.Sprintf("attributes:%s", key))
+			} else {
+				expressions = append(expressions, fmt.Sprintf("NOT attributes:%s", key))
+			}
+		}
+	}
+
+	pubsubFilter := strings.Join(expressions, " AND ")
+	if len(pubsubFilter) > maxSubscriptionFilterLength {
+		return "", fmt.Errorf("filter is %d bytes on gcp, filters are limited to %d bytes", len(pubsubFilter), maxSubscriptionFilterLength)
+	}
+
+	return pubsubFilter, nil
+}
+
+// The backoff pubsub applies between redeliveries when a subscription doesn't configure its own
+const (
+	defaultMinimumBackoff = 15 * time.Second
+	defaultMaximumBackoff = 600 * time.Second
+)
+
+// ValidateRetryPolicy - returns an error if the retry policy of a subscription can't be applied on gcp
+func ValidateRetryPolicy(policy *topicspb.SubscriptionRetryPolicy) error {
+	if err := topics.ValidateRetryPolicy(policy); err != nil {
+		return err
+	}
+
+	// pubsub redelivers messages until they're acknowledged, only dead-letter policies limit the delivery attempts
+	if policy.GetMaxAttempts() > 0 {
+		if policy.GetDeadLetterQueue() == "" {
+			return fmt.Errorf("max attempts require a dead-letter queue on gcp")
+		}
+
+		if policy.GetMaxAttempts() < 5 || policy.GetMaxAttempts() > 100 {
+			return fmt.Errorf("max attempts must be between 5 and 100 on gcp, got %d", policy.GetMaxAttempts())
+		}
+	}
+
+	if policy.GetMinBackoff().AsDuration() > defaultMaximumBackoff || policy.GetMaxBackoff().AsDuration() > defaultMaximumBackoff {
+		return fmt.Errorf("backoff must be at most %s on gcp", defaultMaximumBackoff)
+	}
+
+	return nil
+}
+
+// SubscriptionBackoff - returns the minimum and maximum backoff of a subscription's retry policy as pubsub durations
+func SubscriptionBackoff(policy *topicspb.SubscriptionRetryPolicy) (string, string) {
+	minimumBackoff := defaultMinimumBackoff
+	if policy.GetMinBackoff() != nil {
+		minimumBackoff = policy.GetMinBackoff().AsDuration()
+	}
+
+	maximumBackoff := defaultMaximumBackoff
+	if policy.GetMaxBackoff() != nil {
+		maximumBackoff = policy.GetMaxBackoff().AsDuration()
+	}
+
+	// only one bound may be configured, so the default of the other bound can't be allowed to invert them
+	if minimumBackoff > maximumBackoff {
+		if policy.GetMinBackoff() != nil {
+			maximumBackoff = minimumBackoff
+		} else {
+			minimumBackoff = maximumBackoff
+		}
+	}
+
+	formatBackoff := func(backoff time.Duration) string {
+		return strconv.FormatFloat(backoff.Seconds(), 'f', -1, 64) + "s"
+	}
+
+	return formatBackoff(minimumBackoff), formatBackoff(maximumBackoff)
+}
+
 func (p *NitricGcpPulumiProvider) Topic(ctx *pulumi.Context, parent pulumi.Resource, name string, config *deploymentspb.Topic) error {
 	var err error
 	opts := append([]pulumi.ResourceOption{}, pulumi.Parent(parent))
@@ -46,12 +161,24 @@ func (p *NitricGcpPulumiProvider) Topic(ctx *pulumi.Context, parent pulumi.Resou
 	for _, sub := range config.Subscriptions {
 		targetService := p.CloudRunServices[sub.GetService()]
 
-		_, err := pubsub.NewSubscription(ctx, GetSubName(targetService.Name, name), &pubsub.SubscriptionArgs{
+		filter, err := PubsubFilter(sub.GetFilter())
+		if err != nil {
+			return fmt.Errorf("invalid filter for subscription of service %s to topic %s: %w", sub.GetService(), name, err)
+		}
+
+		retryPolicy := sub.GetRetryPolicy()
+		if err := ValidateRetryPolicy(retryPolicy); err != nil {
+			return fmt.Errorf("invalid retry policy for subscription of service %s to topic %s: %w", sub.GetService(), name, err)
+		}
+
+		minimumBackoff, maximumBackoff := SubscriptionBackoff(retryPolicy)
+
+		subscriptionArgs := &pubsub.SubscriptionArgs{
 			Topic:              p.Topics[name].Name, // The GCP topic name
 			AckDeadlineSeconds: pulumi.Int(300),
 			RetryPolicy: pubsub.SubscriptionRetryPolicyArgs{
-				MinimumBackoff: pulumi.String("15s"),
-				MaximumBackoff: pulumi.String("600s"),
+				MinimumBackoff: pulumi.String(minimumBackoff),
+				MaximumBackoff: pulumi.String(maximumBackoff),
 			},
 			PushConfig: pubsub.SubscriptionPushConfigArgs{
 				OidcToken: pubsub.SubscriptionPushConfigOidcTokenArgs{
@@ -63,10 +190,42 @@ func (p *NitricGcpPulumiProvider) Topic(ctx *pulumi.Context, parent pulumi.Resou
 			ExpirationPolicy: &pubsub.SubscriptionExpirationPolicyArgs{
 				Ttl: pulumi.String(""),
 			},
-		}, p.WithDefaultResourceOptions(opts...)...)
+		}
+
+		// subscriptions without a filter are left unchanged from those created before filters were supported
+		if filter != "" {
+			subscriptionArgs.Filter = pulumi.String(filter)
+		}
+
+		deadLetterQueue := retryPolicy.GetDeadLetterQueue()
+		if deadLetterQueue != "" {
+			target, ok := p.Queues[deadLetterQueue]
+			if !ok {
+				return fmt.Errorf("dead-letter queue %s for subscription of service %s to topic %s not found", deadLetterQueue, sub.GetService(), name)
+			}
+
+			deadLetterPolicy := &pubsub.SubscriptionDeadLetterPolicyArgs{
+				DeadLetterTopic: target.ID(),
+			}
+
+			if retryPolicy.GetMaxAttempts() > 0 {
+				deadLetterPolicy.MaxDeliveryAttempts = pulumi.Int(int(retryPolicy.GetMaxAttempts()))
+			}
+
+			subscriptionArgs.DeadLetterPolicy = deadLetterPolicy
+		}
+
+		subscription, err := pubsub.NewSubscription(ctx, GetSubName(targetService.Name, name), subscriptionArgs, p.WithDefaultResourceOptions(opts...)...)
 		if err != nil {
 			return errors.WithMessage(err, "subscription "+name+"-sub")
 		}
+
+		if deadLetterQueue != "" {
+			err = p.allowDeadLetters(ctx, GetSubName(targetService.Name, name), subscription, deadLetterQueue, opts...)
+			if err != nil {
+				return err
+			}
+		}
 	}
 
 	return nil
@@ -0,0 +1,100 @@
+// Copyright 2021 Nitric Technologies Pty Ltd.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package deploy_test
+
+import (
+	"strings"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/ginkgo/extensions/table"
+	. "github.com/onsi/gomega"
+
+	"github.com/nitrictech/nitric/cloud/gcp/deploy"
+	topicspb "github.com/nitrictech/nitric/core/pkg/proto/topics/v1"
+)
+
+func filterOf(conditions ...*topicspb.AttributeCondition) *topicspb.AttributeFilter {
+	return &topicspb.AttributeFilter{Conditions: conditions}
+}
+
+func anyOf(key string, values ...string) *topicspb.AttributeCondition {
+	return &topicspb.AttributeCondition{
+		Key:   key,
+		Match: &topicspb.AttributeCondition_AnyOf{AnyOf: &topicspb.AttributeValues{Values: values}},
+	}
+}
+
+func noneOf(key string, values ...string) *topicspb.AttributeCondition {
+	return &topicspb.AttributeCondition{
+		Key:   key,
+		Match: &topicspb.AttributeCondition_NoneOf{NoneOf: &topicspb.AttributeValues{Values: values}},
+	}
+}
+
+func prefix(key string, prefix string) *topicspb.AttributeCondition {
+	return &topicspb.AttributeCondition{
+		Key:   key,
+		Match: &topicspb.AttributeCondition_Prefix{Prefix: prefix},
+	}
+}
+
+func exists(key string, exists bool) *topicspb.AttributeCondition {
+	return &topicspb.AttributeCondition{
+		Key:   key,
+		Match: &topicspb.AttributeCondition_Exists{Exists: exists},
+	}
+}
+
+var _ = Describe("PubsubFilter", func() {
+	It("should return an empty filter for filters without conditions", func() {
+		filter, err := deploy.PubsubFilter(nil)
+		Expect(err).ShouldNot(HaveOccurred())
+		Expect(filter).To(BeEmpty())
+
+		filter, err = deploy.PubsubFilter(filterOf())
+		Expect(err).ShouldNot(HaveOccurred())
+		Expect(filter).To(BeEmpty())
+	})
+
+	// inequality in pubsub filters also matches messages without the attribute, so none of requires the attribute
+	// to agree with MatchesFilter
+	DescribeTable("should convert filters to equivalent subscription filters",
+		func(filter *topicspb.AttributeFilter, expected string) {
+			pubsubFilter, err := deploy.PubsubFilter(filter)
+
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(pubsubFilter).To(Equal(expected))
+		},
+		Entry("exact match", filterOf(anyOf("type", "order")), `(attributes.type = "order")`),
+		Entry("multiple values", filterOf(anyOf("type", "order", "refund")), `(attributes.type = "order" OR attributes.type = "refund")`),
+		Entry("none of", filterOf(noneOf("type", "test", "debug")), `attributes:type AND attributes.type != "test" AND attributes.type != "debug"`),
+		Entry("prefix match", filterOf(prefix("region", "eu-")), `hasPrefix(attributes.region, "eu-")`),
+		Entry("exists", filterOf(exists("priority", true)), `attributes:priority`),
+		Entry("not exists", filterOf(exists("priority", false)), `NOT attributes:priority`),
+		Entry("multiple conditions", filterOf(anyOf("type", "order"), prefix("region", "eu-")), `(attributes.type = "order") AND hasPrefix(attributes.region, "eu-")`),
+		Entry("quoted values", filterOf(anyOf("type", `say "hi"`)), `(attributes.type = "say \"hi\"")`),
+	)
+
+	DescribeTable("should reject filters that can't be applied",
+		func(filter *topicspb.AttributeFilter) {
+			_, err := deploy.PubsubFilter(filter)
+
+			Expect(err).Should(HaveOccurred())
+		},
+		Entry("invalid filter", filterOf(anyOf("type"))),
+		Entry("attribute keys that aren't identifiers", filterOf(anyOf("event-type", "order"))),
+		Entry("filters longer than 256 bytes", filterOf(anyOf("type", strings.Repeat("a", 250)))),
+	)
+})
@@ -0,0 +1,20 @@
+# The Pub/Sub service agent forwards undeliverable messages, so it needs to publish to the dead-letter topic
+# and acknowledge the forwarded messages on the subscription
+data "google_project" "project" {
+}
+
+locals {
+  service_agent = "serviceAccount:service-${data.google_project.project.number}@gcp-sa-pubsub.iam.gserviceaccount.com"
+}
+
+resource "google_pubsub_topic_iam_member" "dead_letter_publisher" {
+  topic  = var.dead_letter_topic_id
+  role   = "roles/pubsub.publisher"
+  member = local.service_agent
+}
+
+resource "google_pubsub_subscription_iam_member" "dead_letter_subscriber" {
+  subscription = var.subscription_name
+  role         = "roles/pubsub.subscriber"
+  member       = local.service_agent
+}
@@ -0,0 +1,9 @@
+variable "dead_letter_topic_id" {
+  description = "The ID of the topic that undeliverable messages are forwarded to"
+  type        = string
+}
+
+variable "subscription_name" {
+  description = "The name of the subscription that messages are forwarded from"
+  type        = string
+}
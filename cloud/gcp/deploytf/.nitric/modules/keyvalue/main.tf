@@ -0,0 +1,8 @@
+# Enable TTL on the store's collection, the collection itself is created at runtime
+resource "google_firestore_field" "ttl" {
+  database   = "(default)"
+  collection = var.kvstore_name
+  field      = "_ttl"
+
+  ttl_config {}
+}
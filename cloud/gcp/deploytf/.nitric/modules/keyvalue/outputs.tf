@@ -0,0 +1,4 @@
+output "kv_arn" {
+  description = "The ID of the TTL field of the key value store"
+  value       = google_firestore_field.ttl.id
+}
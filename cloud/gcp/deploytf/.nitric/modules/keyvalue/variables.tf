@@ -0,0 +1,9 @@
+variable "kvstore_name" {
+  description = "The name of the key value store"
+  type        = string
+}
+
+variable "stack_id" {
+  description = "The ID of the Nitric stack"
+  type        = string
+}
@@ -2,10 +2,11 @@
 # Deploy a PubSub topic to serve as the queue
 resource "google_pubsub_topic" "queue" {
   name = "queue"
-  labels = {
+  # The runtime publishes with ordering keys to topics labelled as FIFO
+  labels = merge({
     "x-nitric-${var.stack_id}-name" = var.queue_name
     "x-nitric-${var.stack_id}-type" = "queue"
-  }
+  }, var.fifo ? { "x-nitric-${var.stack_id}-fifo" = "true" } : {})
 }
 
 # Create a pull subscription for the topic to emulate a queue
@@ -17,8 +18,26 @@ resource "google_pubsub_subscription" "queue_subscription" {
     ttl = ""
   }
 
+  enable_message_ordering = var.fifo
+
+  dynamic "dead_letter_policy" {
+    for_each = var.dead_letter_topic_id == null ? [] : [1]
+    content {
+      dead_letter_topic     = var.dead_letter_topic_id
+      max_delivery_attempts = var.max_delivery_attempts
+    }
+  }
+
   labels = {
     "x-nitric-${var.stack_id}-name" = var.queue_name
     "x-nitric-${var.stack_id}-type" = "queue"
   }
-}
\ No newline at end of file
+}
+
+module "dead_letter" {
+  count  = var.dead_letter_topic_id == null ? 0 : 1
+  source = "../dead_letter"
+
+  dead_letter_topic_id = var.dead_letter_topic_id
+  subscription_name    = google_pubsub_subscription.queue_subscription.name
+}
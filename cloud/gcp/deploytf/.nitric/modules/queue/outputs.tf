@@ -1,4 +1,9 @@
 output "name" {
   description = "The name of the deployed queue."
   value       = google_pubsub_subscription.queue_subscription.name
+}
+
+output "topic_id" {
+  description = "The ID of the topic backing the queue"
+  value       = google_pubsub_topic.queue.id
 }
\ No newline at end of file
@@ -6,4 +6,22 @@ variable "queue_name" {
 variable "stack_id" {
   description = "The ID of the Nitric stack"
   type        = string
+}
+
+variable "dead_letter_topic_id" {
+  description = "The ID of the topic that messages are forwarded to once they exceed the max delivery attempts"
+  type        = string
+  default     = null
+}
+
+variable "max_delivery_attempts" {
+  description = "The number of delivery attempts before a message is forwarded to the dead-letter topic"
+  type        = number
+  default     = null
+}
+
+variable "fifo" {
+  description = "Deliver messages in order within each message group"
+  type        = bool
+  default     = false
 }
\ No newline at end of file
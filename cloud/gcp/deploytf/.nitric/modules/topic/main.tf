@@ -13,10 +13,19 @@ resource "google_pubsub_subscription" "topic_subscriptions" {
   name = "${var.subscriber_services[count.index].name}"
   topic = google_pubsub_topic.topic.name
   ack_deadline_seconds = 300
+  filter = var.subscriber_services[count.index].filter
 
   retry_policy {
-    minimum_backoff = "15s"
-    maximum_backoff = "600s"
+    minimum_backoff = var.subscriber_services[count.index].minimum_backoff
+    maximum_backoff = var.subscriber_services[count.index].maximum_backoff
+  }
+
+  dynamic "dead_letter_policy" {
+    for_each = var.subscriber_services[count.index].dead_letter_queue == "" ? [] : [1]
+    content {
+      dead_letter_topic     = var.subscriber_services[count.index].dead_letter_topic_id
+      max_delivery_attempts = var.subscriber_services[count.index].max_delivery_attempts == 0 ? null : var.subscriber_services[count.index].max_delivery_attempts
+    }
   }
 
   push_config {
@@ -29,4 +38,21 @@ resource "google_pubsub_subscription" "topic_subscriptions" {
   expiration_policy {
     ttl = ""
   }
-}
\ No newline at end of file
+}
+
+locals {
+  dead_letter_subscriptions = {
+    for index, subscriber in var.subscriber_services : subscriber.name => {
+      topic_id     = subscriber.dead_letter_topic_id
+      subscription = google_pubsub_subscription.topic_subscriptions[index].name
+    } if subscriber.dead_letter_queue != ""
+  }
+}
+
+module "dead_letter" {
+  for_each = local.dead_letter_subscriptions
+  source   = "../dead_letter"
+
+  dead_letter_topic_id = each.value.topic_id
+  subscription_name    = each.value.subscription
+}
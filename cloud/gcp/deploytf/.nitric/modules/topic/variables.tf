@@ -15,5 +15,11 @@ variable "subscriber_services" {
     url                   = string
     invoker_service_account_email = string
     event_token           = string
+    filter                = string
+    minimum_backoff       = string
+    maximum_backoff       = string
+    dead_letter_queue     = string
+    dead_letter_topic_id  = string
+    max_delivery_attempts = number
   }))
 }
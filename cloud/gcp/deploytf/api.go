@@ -24,6 +24,7 @@ import (
 	"github.com/getkin/kin-openapi/openapi2conv"
 	"github.com/getkin/kin-openapi/openapi3"
 	"github.com/hashicorp/terraform-cdk-go/cdktf"
+	commonapi "github.com/nitrictech/nitric/cloud/common/deploy/resources/api"
 	"github.com/nitrictech/nitric/cloud/common/deploy/utils"
 	"github.com/nitrictech/nitric/cloud/gcp/deploytf/generated/api"
 	"github.com/nitrictech/nitric/cloud/gcp/deploytf/generated/service"
@@ -108,6 +109,9 @@ func (n *NitricGcpTerraformProvider) Api(stack cdktf.TerraformStack, name string
 		return err
 	}
 
+	// wildcard routes are described with greedy path params, translate them to API Gateway's wildcard syntax
+	v2doc.Paths = commonapi.TranslateGreedyPathParams(v2doc.Paths, commonapi.GoogleGreedyPathParam)
+
 	// Get service targets for IAM binding
 	services := map[string]service.Service{}
 
@@ -18,10 +18,14 @@ type Queue interface {
 	CdktfStack() cdktf.TerraformStack
 	// Experimental.
 	ConstructNodeMetadata() *map[string]interface{}
+	DeadLetterTopicId() *string
+	SetDeadLetterTopicId(val *string)
 	// Experimental.
 	DependsOn() *[]*string
 	// Experimental.
 	SetDependsOn(val *[]*string)
+	Fifo() *bool
+	SetFifo(val *bool)
 	// Experimental.
 	ForEach() cdktf.ITerraformIterator
 	// Experimental.
@@ -30,6 +34,8 @@ type Queue interface {
 	Fqn() *string
 	// Experimental.
 	FriendlyUniqueId() *string
+	MaxDeliveryAttempts() *float64
+	SetMaxDeliveryAttempts(val *float64)
 	NameOutput() *string
 	// The tree node.
 	Node() constructs.Node
@@ -45,6 +51,7 @@ type Queue interface {
 	Source() *string
 	StackId() *string
 	SetStackId(val *string)
+	TopicIdOutput() *string
 	// Experimental.
 	Version() *string
 	// Experimental.
@@ -98,6 +105,16 @@ func (j *jsiiProxy_Queue) ConstructNodeMetadata() *map[string]interface{} {
 	return returns
 }
 
+func (j *jsiiProxy_Queue) DeadLetterTopicId() *string {
+	var returns *string
+	_jsii_.Get(
+		j,
+		"deadLetterTopicId",
+		&returns,
+	)
+	return returns
+}
+
 func (j *jsiiProxy_Queue) DependsOn() *[]*string {
 	var returns *[]*string
 	_jsii_.Get(
@@ -108,6 +125,16 @@ func (j *jsiiProxy_Queue) DependsOn() *[]*string {
 	return returns
 }
 
+func (j *jsiiProxy_Queue) Fifo() *bool {
+	var returns *bool
+	_jsii_.Get(
+		j,
+		"fifo",
+		&returns,
+	)
+	return returns
+}
+
 func (j *jsiiProxy_Queue) ForEach() cdktf.ITerraformIterator {
 	var returns cdktf.ITerraformIterator
 	_jsii_.Get(
@@ -138,6 +165,16 @@ func (j *jsiiProxy_Queue) FriendlyUniqueId() *string {
 	return returns
 }
 
+func (j *jsiiProxy_Queue) MaxDeliveryAttempts() *float64 {
+	var returns *float64
+	_jsii_.Get(
+		j,
+		"maxDeliveryAttempts",
+		&returns,
+	)
+	return returns
+}
+
 func (j *jsiiProxy_Queue) NameOutput() *string {
 	var returns *string
 	_jsii_.Get(
@@ -218,6 +255,16 @@ func (j *jsiiProxy_Queue) StackId() *string {
 	return returns
 }
 
+func (j *jsiiProxy_Queue) TopicIdOutput() *string {
+	var returns *string
+	_jsii_.Get(
+		j,
+		"topicIdOutput",
+		&returns,
+	)
+	return returns
+}
+
 func (j *jsiiProxy_Queue) Version() *string {
 	var returns *string
 	_jsii_.Get(
@@ -256,6 +303,14 @@ func NewQueue_Override(q Queue, scope constructs.Construct, id *string, config *
 	)
 }
 
+func (j *jsiiProxy_Queue)SetDeadLetterTopicId(val *string) {
+	_jsii_.Set(
+		j,
+		"deadLetterTopicId",
+		val,
+	)
+}
+
 func (j *jsiiProxy_Queue)SetDependsOn(val *[]*string) {
 	_jsii_.Set(
 		j,
@@ -264,6 +319,14 @@ func (j *jsiiProxy_Queue)SetDependsOn(val *[]*string) {
 	)
 }
 
+func (j *jsiiProxy_Queue)SetFifo(val *bool) {
+	_jsii_.Set(
+		j,
+		"fifo",
+		val,
+	)
+}
+
 func (j *jsiiProxy_Queue)SetForEach(val cdktf.ITerraformIterator) {
 	_jsii_.Set(
 		j,
@@ -272,6 +335,14 @@ func (j *jsiiProxy_Queue)SetForEach(val cdktf.ITerraformIterator) {
 	)
 }
 
+func (j *jsiiProxy_Queue)SetMaxDeliveryAttempts(val *float64) {
+	_jsii_.Set(
+		j,
+		"maxDeliveryAttempts",
+		val,
+	)
+}
+
 func (j *jsiiProxy_Queue)SetQueueName(val *string) {
 	if err := j.validateSetQueueNameParameters(val); err != nil {
 		panic(err)
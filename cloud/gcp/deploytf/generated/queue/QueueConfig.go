@@ -17,5 +17,11 @@ type QueueConfig struct {
 	QueueName *string `field:"required" json:"queueName" yaml:"queueName"`
 	// The ID of the Nitric stack.
 	StackId *string `field:"required" json:"stackId" yaml:"stackId"`
+	// The ID of the topic that messages are forwarded to once they exceed the max delivery attempts.
+	DeadLetterTopicId *string `field:"optional" json:"deadLetterTopicId" yaml:"deadLetterTopicId"`
+	// The number of delivery attempts before a message is forwarded to the dead-letter topic.
+	MaxDeliveryAttempts *float64 `field:"optional" json:"maxDeliveryAttempts" yaml:"maxDeliveryAttempts"`
+	// Deliver messages in order within each message group.
+	Fifo *bool `field:"optional" json:"fifo" yaml:"fifo"`
 }
 
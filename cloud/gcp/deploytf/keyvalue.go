@@ -15,11 +15,24 @@
 package deploytf
 
 import (
+	"fmt"
+
+	"github.com/aws/jsii-runtime-go"
 	"github.com/hashicorp/terraform-cdk-go/cdktf"
+	"github.com/nitrictech/nitric/cloud/gcp/deploytf/generated/keyvalue"
 	deploymentspb "github.com/nitrictech/nitric/core/pkg/proto/deployments/v1"
 )
 
 func (a *NitricGcpTerraformProvider) KeyValueStore(stack cdktf.TerraformStack, name string, config *deploymentspb.KeyValueStore) error {
-	// NoOp - Key Value Store are created at runtime on GCP
+	if len(config.GetListeners()) > 0 {
+		return fmt.Errorf("key value store %s has listeners, which aren't supported by the terraform provider yet", name)
+	}
+
+	// Key Value Stores are created at runtime on GCP, only the ttl policy of the store's collection is deployed
+	a.KeyValueStores[name] = keyvalue.NewKeyvalue(stack, jsii.Sprintf("kvstore_%s", name), &keyvalue.KeyvalueConfig{
+		KvstoreName: jsii.String(name),
+		StackId:     a.Stack.StackIdOutput(),
+	})
+
 	return nil
 }
@@ -15,18 +15,55 @@
 package deploytf
 
 import (
+	"fmt"
+
 	"github.com/aws/jsii-runtime-go"
 	"github.com/hashicorp/terraform-cdk-go/cdktf"
 	"github.com/nitrictech/nitric/cloud/gcp/deploytf/generated/queue"
 	deploymentspb "github.com/nitrictech/nitric/core/pkg/proto/deployments/v1"
+	resourcespb "github.com/nitrictech/nitric/core/pkg/proto/resources/v1"
 )
 
 // // Queue - Deploy a Queue
 func (a *NitricGcpTerraformProvider) Queue(stack cdktf.TerraformStack, name string, config *deploymentspb.Queue) error {
-	a.Queues[name] = queue.NewQueue(stack, jsii.Sprintf("queue_%s", name), &queue.QueueConfig{
+	if len(config.GetListeners()) > 0 {
+		return fmt.Errorf("queue %s has listeners, which aren't supported by the terraform provider yet", name)
+	}
+
+	queueConfig := &queue.QueueConfig{
 		QueueName: jsii.String(name),
 		StackId:   a.Stack.StackIdOutput(),
-	})
+		Fifo:      jsii.Bool(config.Fifo),
+	}
+
+	if policy := config.GetDeadLetterPolicy(); policy != nil {
+		if err := validateDeadLetterPolicy(name, policy); err != nil {
+			return err
+		}
+
+		target, ok := a.Queues[policy.TargetQueue]
+		if !ok {
+			return fmt.Errorf("dead-letter target queue %s for queue %s not found", policy.TargetQueue, name)
+		}
+
+		queueConfig.DeadLetterTopicId = target.TopicIdOutput()
+		queueConfig.MaxDeliveryAttempts = jsii.Number(policy.MaxReceiveCount)
+	}
+
+	a.Queues[name] = queue.NewQueue(stack, jsii.Sprintf("queue_%s", name), queueConfig)
+
+	return nil
+}
+
+// validateDeadLetterPolicy - Pub/Sub dead-letter policies accept between 5 and 100 delivery attempts
+func validateDeadLetterPolicy(name string, policy *resourcespb.QueueDeadLetterPolicy) error {
+	if policy.TargetQueue == name {
+		return fmt.Errorf("queue %s can't be its own dead-letter target", name)
+	}
+
+	if policy.MaxReceiveCount < 5 || policy.MaxReceiveCount > 100 {
+		return fmt.Errorf("max receive count for queue %s must be between 5 and 100, got %d", name, policy.MaxReceiveCount)
+	}
 
 	return nil
 }
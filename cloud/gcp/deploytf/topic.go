@@ -15,8 +15,11 @@
 package deploytf
 
 import (
+	"fmt"
+
 	"github.com/aws/jsii-runtime-go"
 	"github.com/hashicorp/terraform-cdk-go/cdktf"
+	"github.com/nitrictech/nitric/cloud/gcp/deploy"
 	"github.com/nitrictech/nitric/cloud/gcp/deploytf/generated/topic"
 	deploymentspb "github.com/nitrictech/nitric/core/pkg/proto/deployments/v1"
 )
@@ -27,6 +30,16 @@ type SubscriberService struct {
 	Url                        string `json:"url"`
 	InvokerServiceAccountEmail string `json:"invoker_service_account_email"`
 	EventToken                 string `json:"event_token"`
+	// The pubsub filter of the subscription, empty when the service receives all messages
+	Filter string `json:"filter"`
+	// The backoff between redeliveries of messages the service fails to process
+	MinimumBackoff string `json:"minimum_backoff"`
+	MaximumBackoff string `json:"maximum_backoff"`
+	// The nitric queue that undeliverable messages are moved to, empty when messages are redelivered until they're processed
+	DeadLetterQueue   string `json:"dead_letter_queue"`
+	DeadLetterTopicId string `json:"dead_letter_topic_id"`
+	// The number of delivery attempts before messages are moved to the dead-letter queue, 0 for the pubsub default
+	MaxDeliveryAttempts int32 `json:"max_delivery_attempts"`
 }
 
 func (a *NitricGcpTerraformProvider) Topic(stack cdktf.TerraformStack, name string, config *deploymentspb.Topic) error {
@@ -42,11 +55,39 @@ func (a *NitricGcpTerraformProvider) Topic(stack cdktf.TerraformStack, name stri
 	for _, subscriber := range config.Subscriptions {
 		subscriberSvc := a.Services[subscriber.GetService()]
 
+		filter, err := deploy.PubsubFilter(subscriber.GetFilter())
+		if err != nil {
+			return fmt.Errorf("invalid filter for subscription of service %s to topic %s: %w", subscriber.GetService(), name, err)
+		}
+
+		retryPolicy := subscriber.GetRetryPolicy()
+		if err := deploy.ValidateRetryPolicy(retryPolicy); err != nil {
+			return fmt.Errorf("invalid retry policy for subscription of service %s to topic %s: %w", subscriber.GetService(), name, err)
+		}
+
+		minimumBackoff, maximumBackoff := deploy.SubscriptionBackoff(retryPolicy)
+
+		deadLetterTopicId := ""
+		if deadLetterQueue := retryPolicy.GetDeadLetterQueue(); deadLetterQueue != "" {
+			target, ok := a.Queues[deadLetterQueue]
+			if !ok {
+				return fmt.Errorf("dead-letter queue %s for subscription of service %s to topic %s not found", deadLetterQueue, subscriber.GetService(), name)
+			}
+
+			deadLetterTopicId = *target.TopicIdOutput()
+		}
+
 		subscriberInput = append(subscriberInput, &SubscriberService{
 			Name:                       subscriber.GetService(),
 			Url:                        *serviceEndpoints[subscriber.GetService()],
 			InvokerServiceAccountEmail: *subscriberSvc.InvokerServiceAccountEmailOutput(),
 			EventToken:                 *subscriberSvc.EventTokenOutput(),
+			Filter:                     filter,
+			MinimumBackoff:             minimumBackoff,
+			MaximumBackoff:             maximumBackoff,
+			DeadLetterQueue:            retryPolicy.GetDeadLetterQueue(),
+			DeadLetterTopicId:          deadLetterTopicId,
+			MaxDeliveryAttempts:        retryPolicy.GetMaxAttempts(),
 		})
 	}
 
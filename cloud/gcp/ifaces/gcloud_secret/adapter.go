@@ -49,3 +49,19 @@ func (r *realClient) UpdateSecret(ctx context.Context, req *secretmanagerpb.Upda
 func (r *realClient) ListSecrets(ctx context.Context, req *secretmanagerpb.ListSecretsRequest, co ...gax.CallOption) SecretIterator {
 	return r.Client.ListSecrets(ctx, req, co...)
 }
+
+func (r *realClient) ListSecretVersions(ctx context.Context, req *secretmanagerpb.ListSecretVersionsRequest, co ...gax.CallOption) SecretVersionIterator {
+	return r.Client.ListSecretVersions(ctx, req, co...)
+}
+
+func (r *realClient) DisableSecretVersion(ctx context.Context, req *secretmanagerpb.DisableSecretVersionRequest, co ...gax.CallOption) (*secretmanagerpb.SecretVersion, error) {
+	return r.Client.DisableSecretVersion(ctx, req, co...)
+}
+
+func (r *realClient) EnableSecretVersion(ctx context.Context, req *secretmanagerpb.EnableSecretVersionRequest, co ...gax.CallOption) (*secretmanagerpb.SecretVersion, error) {
+	return r.Client.EnableSecretVersion(ctx, req, co...)
+}
+
+func (r *realClient) DestroySecretVersion(ctx context.Context, req *secretmanagerpb.DestroySecretVersionRequest, co ...gax.CallOption) (*secretmanagerpb.SecretVersion, error) {
+	return r.Client.DestroySecretVersion(ctx, req, co...)
+}
@@ -73,6 +73,17 @@ func (o objectHandle) NewReader(ctx context.Context) (Reader, error) {
 	return reader{newReader}, err
 }
 
+func (o objectHandle) NewRangeReader(ctx context.Context, offset int64, length int64) (Reader, error) {
+	newReader, err := o.ObjectHandle.NewRangeReader(ctx, offset, length)
+	return reader{newReader}, err
+}
+
+func (w writer) SetObjectAttrs(contentType string, cacheControl string, metadata map[string]string) {
+	w.Writer.ContentType = contentType
+	w.Writer.CacheControl = cacheControl
+	w.Writer.Metadata = metadata
+}
+
 func (o objectHandle) Delete(ctx context.Context) error {
 	return o.ObjectHandle.Delete(ctx)
 }
@@ -19,10 +19,13 @@ import (
 	"io"
 
 	"cloud.google.com/go/storage"
+	"google.golang.org/api/iterator"
 )
 
 type Writer interface {
 	io.WriteCloser
+	// SetObjectAttrs sets the attributes of the object being written, it must be called before the first write
+	SetObjectAttrs(contentType string, cacheControl string, metadata map[string]string)
 }
 
 type Reader interface {
@@ -32,6 +35,7 @@ type Reader interface {
 type ObjectHandle interface {
 	NewWriter(context.Context) Writer
 	NewReader(context.Context) (Reader, error)
+	NewRangeReader(ctx context.Context, offset int64, length int64) (Reader, error)
 	Delete(ctx context.Context) error
 	Attrs(ctx context.Context) (*storage.ObjectAttrs, error)
 }
@@ -42,6 +46,7 @@ type BucketIterator interface {
 
 type ObjectIterator interface {
 	Next() (*storage.ObjectAttrs, error)
+	PageInfo() *iterator.PageInfo
 }
 
 type BucketHandle interface {
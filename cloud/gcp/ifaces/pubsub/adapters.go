@@ -98,6 +98,15 @@ func (t topic) Labels(ctx context.Context) (map[string]string, error) {
 	return config.Labels, nil
 }
 
+// EnableOrdering - messages published with an ordering key are delivered in order
+func (t topic) EnableOrdering() {
+	t.Topic.EnableMessageOrdering = true
+}
+
+func (t topic) ResumePublish(orderingKey string) {
+	t.Topic.ResumePublish(orderingKey)
+}
+
 func (s subscription) ID() string {
 	return s.Subscription.ID()
 }
@@ -38,6 +38,8 @@ type Topic interface {
 	Subscriptions(ctx context.Context) SubscriptionIterator
 	ID() string
 	Labels(ctx context.Context) (map[string]string, error)
+	EnableOrdering()
+	ResumePublish(orderingKey string)
 }
 
 type SubscriptionIterator interface {
@@ -67,4 +69,5 @@ type SubscriberClient interface {
 	Close() error
 	Pull(ctx context.Context, req *pubsubpb.PullRequest, opts ...gax.CallOption) (*pubsubpb.PullResponse, error)
 	Acknowledge(ctx context.Context, req *pubsubpb.AcknowledgeRequest, opts ...gax.CallOption) error
+	ModifyAckDeadline(ctx context.Context, req *pubsubpb.ModifyAckDeadlineRequest, opts ...gax.CallOption) error
 }
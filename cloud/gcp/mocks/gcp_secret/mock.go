@@ -1,5 +1,5 @@
 // Code generated by MockGen. DO NOT EDIT.
-// Source: github.com/nitrictech/nitric/cloud/gcp/ifaces/gcloud_secret (interfaces: SecretManagerClient,SecretIterator)
+// Source: github.com/nitrictech/nitric/cloud/gcp/ifaces/gcloud_secret (interfaces: SecretManagerClient,SecretIterator,SecretVersionIterator)
 
 // Package mock_gcloud_secret is a generated GoMock package.
 package mock_gcloud_secret
@@ -77,6 +77,85 @@ func (mr *MockSecretManagerClientMockRecorder) AddSecretVersion(arg0, arg1 inter
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddSecretVersion", reflect.TypeOf((*MockSecretManagerClient)(nil).AddSecretVersion), varargs...)
 }
 
+// DestroySecretVersion mocks base method.
+func (m *MockSecretManagerClient) DestroySecretVersion(arg0 context.Context, arg1 *secretmanagerpb.DestroySecretVersionRequest, arg2 ...gax.CallOption) (*secretmanagerpb.SecretVersion, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{arg0, arg1}
+	for _, a := range arg2 {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "DestroySecretVersion", varargs...)
+	ret0, _ := ret[0].(*secretmanagerpb.SecretVersion)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DestroySecretVersion indicates an expected call of DestroySecretVersion.
+func (mr *MockSecretManagerClientMockRecorder) DestroySecretVersion(arg0, arg1 interface{}, arg2 ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{arg0, arg1}, arg2...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DestroySecretVersion", reflect.TypeOf((*MockSecretManagerClient)(nil).DestroySecretVersion), varargs...)
+}
+
+// DisableSecretVersion mocks base method.
+func (m *MockSecretManagerClient) DisableSecretVersion(arg0 context.Context, arg1 *secretmanagerpb.DisableSecretVersionRequest, arg2 ...gax.CallOption) (*secretmanagerpb.SecretVersion, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{arg0, arg1}
+	for _, a := range arg2 {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "DisableSecretVersion", varargs...)
+	ret0, _ := ret[0].(*secretmanagerpb.SecretVersion)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DisableSecretVersion indicates an expected call of DisableSecretVersion.
+func (mr *MockSecretManagerClientMockRecorder) DisableSecretVersion(arg0, arg1 interface{}, arg2 ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{arg0, arg1}, arg2...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DisableSecretVersion", reflect.TypeOf((*MockSecretManagerClient)(nil).DisableSecretVersion), varargs...)
+}
+
+// EnableSecretVersion mocks base method.
+func (m *MockSecretManagerClient) EnableSecretVersion(arg0 context.Context, arg1 *secretmanagerpb.EnableSecretVersionRequest, arg2 ...gax.CallOption) (*secretmanagerpb.SecretVersion, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{arg0, arg1}
+	for _, a := range arg2 {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "EnableSecretVersion", varargs...)
+	ret0, _ := ret[0].(*secretmanagerpb.SecretVersion)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// EnableSecretVersion indicates an expected call of EnableSecretVersion.
+func (mr *MockSecretManagerClientMockRecorder) EnableSecretVersion(arg0, arg1 interface{}, arg2 ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{arg0, arg1}, arg2...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "EnableSecretVersion", reflect.TypeOf((*MockSecretManagerClient)(nil).EnableSecretVersion), varargs...)
+}
+
+// ListSecretVersions mocks base method.
+func (m *MockSecretManagerClient) ListSecretVersions(arg0 context.Context, arg1 *secretmanagerpb.ListSecretVersionsRequest, arg2 ...gax.CallOption) ifaces_gcloud_secret.SecretVersionIterator {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{arg0, arg1}
+	for _, a := range arg2 {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "ListSecretVersions", varargs...)
+	ret0, _ := ret[0].(ifaces_gcloud_secret.SecretVersionIterator)
+	return ret0
+}
+
+// ListSecretVersions indicates an expected call of ListSecretVersions.
+func (mr *MockSecretManagerClientMockRecorder) ListSecretVersions(arg0, arg1 interface{}, arg2 ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{arg0, arg1}, arg2...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListSecretVersions", reflect.TypeOf((*MockSecretManagerClient)(nil).ListSecretVersions), varargs...)
+}
+
 // ListSecrets mocks base method.
 func (m *MockSecretManagerClient) ListSecrets(arg0 context.Context, arg1 *secretmanagerpb.ListSecretsRequest, arg2 ...gax.CallOption) ifaces_gcloud_secret.SecretIterator {
 	m.ctrl.T.Helper()
@@ -153,3 +232,41 @@ func (mr *MockSecretIteratorMockRecorder) Next() *gomock.Call {
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Next", reflect.TypeOf((*MockSecretIterator)(nil).Next))
 }
+
+// MockSecretVersionIterator is a mock of SecretVersionIterator interface.
+type MockSecretVersionIterator struct {
+	ctrl     *gomock.Controller
+	recorder *MockSecretVersionIteratorMockRecorder
+}
+
+// MockSecretVersionIteratorMockRecorder is the mock recorder for MockSecretVersionIterator.
+type MockSecretVersionIteratorMockRecorder struct {
+	mock *MockSecretVersionIterator
+}
+
+// NewMockSecretVersionIterator creates a new mock instance.
+func NewMockSecretVersionIterator(ctrl *gomock.Controller) *MockSecretVersionIterator {
+	mock := &MockSecretVersionIterator{ctrl: ctrl}
+	mock.recorder = &MockSecretVersionIteratorMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockSecretVersionIterator) EXPECT() *MockSecretVersionIteratorMockRecorder {
+	return m.recorder
+}
+
+// Next mocks base method.
+func (m *MockSecretVersionIterator) Next() (*secretmanagerpb.SecretVersion, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Next")
+	ret0, _ := ret[0].(*secretmanagerpb.SecretVersion)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Next indicates an expected call of Next.
+func (mr *MockSecretVersionIteratorMockRecorder) Next() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Next", reflect.TypeOf((*MockSecretVersionIterator)(nil).Next))
+}
@@ -11,6 +11,7 @@ import (
 	storage "cloud.google.com/go/storage"
 	gomock "github.com/golang/mock/gomock"
 	ifaces_gcloud_storage "github.com/nitrictech/nitric/cloud/gcp/ifaces/gcloud_storage"
+	iterator "google.golang.org/api/iterator"
 )
 
 // MockReader is a mock of Reader interface.
@@ -102,6 +103,18 @@ func (mr *MockWriterMockRecorder) Close() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Close", reflect.TypeOf((*MockWriter)(nil).Close))
 }
 
+// SetObjectAttrs mocks base method.
+func (m *MockWriter) SetObjectAttrs(arg0, arg1 string, arg2 map[string]string) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "SetObjectAttrs", arg0, arg1, arg2)
+}
+
+// SetObjectAttrs indicates an expected call of SetObjectAttrs.
+func (mr *MockWriterMockRecorder) SetObjectAttrs(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetObjectAttrs", reflect.TypeOf((*MockWriter)(nil).SetObjectAttrs), arg0, arg1, arg2)
+}
+
 // Write mocks base method.
 func (m *MockWriter) Write(arg0 []byte) (int, error) {
 	m.ctrl.T.Helper()
@@ -169,6 +182,21 @@ func (mr *MockObjectHandleMockRecorder) Delete(arg0 interface{}) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockObjectHandle)(nil).Delete), arg0)
 }
 
+// NewRangeReader mocks base method.
+func (m *MockObjectHandle) NewRangeReader(arg0 context.Context, arg1, arg2 int64) (ifaces_gcloud_storage.Reader, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "NewRangeReader", arg0, arg1, arg2)
+	ret0, _ := ret[0].(ifaces_gcloud_storage.Reader)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// NewRangeReader indicates an expected call of NewRangeReader.
+func (mr *MockObjectHandleMockRecorder) NewRangeReader(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "NewRangeReader", reflect.TypeOf((*MockObjectHandle)(nil).NewRangeReader), arg0, arg1, arg2)
+}
+
 // NewReader mocks base method.
 func (m *MockObjectHandle) NewReader(arg0 context.Context) (ifaces_gcloud_storage.Reader, error) {
 	m.ctrl.T.Helper()
@@ -390,3 +418,17 @@ func (mr *MockObjectIteratorMockRecorder) Next() *gomock.Call {
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Next", reflect.TypeOf((*MockObjectIterator)(nil).Next))
 }
+
+// PageInfo mocks base method.
+func (m *MockObjectIterator) PageInfo() *iterator.PageInfo {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PageInfo")
+	ret0, _ := ret[0].(*iterator.PageInfo)
+	return ret0
+}
+
+// PageInfo indicates an expected call of PageInfo.
+func (mr *MockObjectIteratorMockRecorder) PageInfo() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PageInfo", reflect.TypeOf((*MockObjectIterator)(nil).PageInfo))
+}
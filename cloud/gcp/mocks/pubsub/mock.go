@@ -1,5 +1,5 @@
 // Code generated by MockGen. DO NOT EDIT.
-// Source: github.com/nitrictech/nitric/cloud/gcp/ifaces/pubsub (interfaces: PubsubClient,TopicIterator,Topic,PublishResult)
+// Source: github.com/nitrictech/nitric/cloud/gcp/ifaces/pubsub (interfaces: PubsubClient,TopicIterator,Topic,SubscriptionIterator,Subscription,PublishResult,SubscriberClient)
 
 // Package mock_pubsub is a generated GoMock package.
 package mock_pubsub
@@ -8,7 +8,9 @@ import (
 	context "context"
 	reflect "reflect"
 
+	pubsubpb "cloud.google.com/go/pubsub/apiv1/pubsubpb"
 	gomock "github.com/golang/mock/gomock"
+	gax "github.com/googleapis/gax-go/v2"
 	ifaces_pubsub "github.com/nitrictech/nitric/cloud/gcp/ifaces/pubsub"
 )
 
@@ -124,6 +126,18 @@ func (m *MockTopic) EXPECT() *MockTopicMockRecorder {
 	return m.recorder
 }
 
+// EnableOrdering mocks base method.
+func (m *MockTopic) EnableOrdering() {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "EnableOrdering")
+}
+
+// EnableOrdering indicates an expected call of EnableOrdering.
+func (mr *MockTopicMockRecorder) EnableOrdering() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "EnableOrdering", reflect.TypeOf((*MockTopic)(nil).EnableOrdering))
+}
+
 // Exists mocks base method.
 func (m *MockTopic) Exists(arg0 context.Context) (bool, error) {
 	m.ctrl.T.Helper()
@@ -182,6 +196,18 @@ func (mr *MockTopicMockRecorder) Publish(arg0, arg1 interface{}) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Publish", reflect.TypeOf((*MockTopic)(nil).Publish), arg0, arg1)
 }
 
+// ResumePublish mocks base method.
+func (m *MockTopic) ResumePublish(arg0 string) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "ResumePublish", arg0)
+}
+
+// ResumePublish indicates an expected call of ResumePublish.
+func (mr *MockTopicMockRecorder) ResumePublish(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ResumePublish", reflect.TypeOf((*MockTopic)(nil).ResumePublish), arg0)
+}
+
 // String mocks base method.
 func (m *MockTopic) String() string {
 	m.ctrl.T.Helper()
@@ -210,6 +236,110 @@ func (mr *MockTopicMockRecorder) Subscriptions(arg0 interface{}) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Subscriptions", reflect.TypeOf((*MockTopic)(nil).Subscriptions), arg0)
 }
 
+// MockSubscriptionIterator is a mock of SubscriptionIterator interface.
+type MockSubscriptionIterator struct {
+	ctrl     *gomock.Controller
+	recorder *MockSubscriptionIteratorMockRecorder
+}
+
+// MockSubscriptionIteratorMockRecorder is the mock recorder for MockSubscriptionIterator.
+type MockSubscriptionIteratorMockRecorder struct {
+	mock *MockSubscriptionIterator
+}
+
+// NewMockSubscriptionIterator creates a new mock instance.
+func NewMockSubscriptionIterator(ctrl *gomock.Controller) *MockSubscriptionIterator {
+	mock := &MockSubscriptionIterator{ctrl: ctrl}
+	mock.recorder = &MockSubscriptionIteratorMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockSubscriptionIterator) EXPECT() *MockSubscriptionIteratorMockRecorder {
+	return m.recorder
+}
+
+// Next mocks base method.
+func (m *MockSubscriptionIterator) Next() (ifaces_pubsub.Subscription, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Next")
+	ret0, _ := ret[0].(ifaces_pubsub.Subscription)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Next indicates an expected call of Next.
+func (mr *MockSubscriptionIteratorMockRecorder) Next() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Next", reflect.TypeOf((*MockSubscriptionIterator)(nil).Next))
+}
+
+// MockSubscription is a mock of Subscription interface.
+type MockSubscription struct {
+	ctrl     *gomock.Controller
+	recorder *MockSubscriptionMockRecorder
+}
+
+// MockSubscriptionMockRecorder is the mock recorder for MockSubscription.
+type MockSubscriptionMockRecorder struct {
+	mock *MockSubscription
+}
+
+// NewMockSubscription creates a new mock instance.
+func NewMockSubscription(ctrl *gomock.Controller) *MockSubscription {
+	mock := &MockSubscription{ctrl: ctrl}
+	mock.recorder = &MockSubscriptionMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockSubscription) EXPECT() *MockSubscriptionMockRecorder {
+	return m.recorder
+}
+
+// ID mocks base method.
+func (m *MockSubscription) ID() string {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ID")
+	ret0, _ := ret[0].(string)
+	return ret0
+}
+
+// ID indicates an expected call of ID.
+func (mr *MockSubscriptionMockRecorder) ID() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ID", reflect.TypeOf((*MockSubscription)(nil).ID))
+}
+
+// Labels mocks base method.
+func (m *MockSubscription) Labels(arg0 context.Context) (map[string]string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Labels", arg0)
+	ret0, _ := ret[0].(map[string]string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Labels indicates an expected call of Labels.
+func (mr *MockSubscriptionMockRecorder) Labels(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Labels", reflect.TypeOf((*MockSubscription)(nil).Labels), arg0)
+}
+
+// String mocks base method.
+func (m *MockSubscription) String() string {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "String")
+	ret0, _ := ret[0].(string)
+	return ret0
+}
+
+// String indicates an expected call of String.
+func (mr *MockSubscriptionMockRecorder) String() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "String", reflect.TypeOf((*MockSubscription)(nil).String))
+}
+
 // MockPublishResult is a mock of PublishResult interface.
 type MockPublishResult struct {
 	ctrl     *gomock.Controller
@@ -247,3 +377,98 @@ func (mr *MockPublishResultMockRecorder) Get(arg0 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*MockPublishResult)(nil).Get), arg0)
 }
+
+// MockSubscriberClient is a mock of SubscriberClient interface.
+type MockSubscriberClient struct {
+	ctrl     *gomock.Controller
+	recorder *MockSubscriberClientMockRecorder
+}
+
+// MockSubscriberClientMockRecorder is the mock recorder for MockSubscriberClient.
+type MockSubscriberClientMockRecorder struct {
+	mock *MockSubscriberClient
+}
+
+// NewMockSubscriberClient creates a new mock instance.
+func NewMockSubscriberClient(ctrl *gomock.Controller) *MockSubscriberClient {
+	mock := &MockSubscriberClient{ctrl: ctrl}
+	mock.recorder = &MockSubscriberClientMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockSubscriberClient) EXPECT() *MockSubscriberClientMockRecorder {
+	return m.recorder
+}
+
+// Acknowledge mocks base method.
+func (m *MockSubscriberClient) Acknowledge(arg0 context.Context, arg1 *pubsubpb.AcknowledgeRequest, arg2 ...gax.CallOption) error {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{arg0, arg1}
+	for _, a := range arg2 {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "Acknowledge", varargs...)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Acknowledge indicates an expected call of Acknowledge.
+func (mr *MockSubscriberClientMockRecorder) Acknowledge(arg0, arg1 interface{}, arg2 ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{arg0, arg1}, arg2...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Acknowledge", reflect.TypeOf((*MockSubscriberClient)(nil).Acknowledge), varargs...)
+}
+
+// Close mocks base method.
+func (m *MockSubscriberClient) Close() error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Close")
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Close indicates an expected call of Close.
+func (mr *MockSubscriberClientMockRecorder) Close() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Close", reflect.TypeOf((*MockSubscriberClient)(nil).Close))
+}
+
+// ModifyAckDeadline mocks base method.
+func (m *MockSubscriberClient) ModifyAckDeadline(arg0 context.Context, arg1 *pubsubpb.ModifyAckDeadlineRequest, arg2 ...gax.CallOption) error {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{arg0, arg1}
+	for _, a := range arg2 {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "ModifyAckDeadline", varargs...)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ModifyAckDeadline indicates an expected call of ModifyAckDeadline.
+func (mr *MockSubscriberClientMockRecorder) ModifyAckDeadline(arg0, arg1 interface{}, arg2 ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{arg0, arg1}, arg2...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ModifyAckDeadline", reflect.TypeOf((*MockSubscriberClient)(nil).ModifyAckDeadline), varargs...)
+}
+
+// Pull mocks base method.
+func (m *MockSubscriberClient) Pull(arg0 context.Context, arg1 *pubsubpb.PullRequest, arg2 ...gax.CallOption) (*pubsubpb.PullResponse, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{arg0, arg1}
+	for _, a := range arg2 {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "Pull", varargs...)
+	ret0, _ := ret[0].(*pubsubpb.PullResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Pull indicates an expected call of Pull.
+func (mr *MockSubscriberClientMockRecorder) Pull(arg0, arg1 interface{}, arg2 ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{arg0, arg1}, arg2...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Pull", reflect.TypeOf((*MockSubscriberClient)(nil).Pull), varargs...)
+}
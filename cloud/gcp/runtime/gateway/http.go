@@ -16,24 +16,35 @@
 package gateway
 
 import (
+	"context"
+	"encoding/base64"
 	"encoding/json"
 	"fmt"
 	"os"
+	"strings"
+	"time"
 
 	"github.com/fasthttp/router"
 	"github.com/valyala/fasthttp"
+	"go.opentelemetry.io/otel/propagation"
 
 	base_http "github.com/nitrictech/nitric/cloud/common/runtime/gateway"
+	"github.com/nitrictech/nitric/cloud/common/runtime/messages"
 	"github.com/nitrictech/nitric/cloud/gcp/runtime/resource"
 	"github.com/nitrictech/nitric/core/pkg/gateway"
 	"github.com/nitrictech/nitric/core/pkg/logger"
+	kvstorepb "github.com/nitrictech/nitric/core/pkg/proto/kvstore/v1"
+	queuespb "github.com/nitrictech/nitric/core/pkg/proto/queues/v1"
 	schedulespb "github.com/nitrictech/nitric/core/pkg/proto/schedules/v1"
 	storagepb "github.com/nitrictech/nitric/core/pkg/proto/storage/v1"
-	topicpb "github.com/nitrictech/nitric/core/pkg/proto/topics/v1"
 	topicspb "github.com/nitrictech/nitric/core/pkg/proto/topics/v1"
-	"google.golang.org/protobuf/proto"
+	"github.com/nitrictech/nitric/core/pkg/workers"
 )
 
+// pubsubAckDeadline matches the ack deadline of the push subscriptions created during deployment,
+// pubsub will redeliver the message once it passes, so there's no value in waiting on a worker any longer
+const pubsubAckDeadline = 300 * time.Second
+
 type gcpMiddleware struct {
 	provider resource.GcpResourceResolver
 }
@@ -45,6 +56,8 @@ type PubSubMessage struct {
 		ID         string            `json:"id"`
 	} `json:"message"`
 	Subscription string `json:"subscription"`
+	// only set by subscriptions with a dead-letter policy
+	DeliveryAttempt int32 `json:"deliveryAttempt,omitempty"`
 }
 
 func eventAuthorised(ctx *fasthttp.RequestCtx) bool {
@@ -75,12 +88,8 @@ func (g *gcpMiddleware) handleSubscription(opts *gateway.GatewayStartOpts) fasth
 				ctx.Error("Can not handle event for empty topic", 400)
 			}
 
-			var message topicpb.TopicMessage
-			err := proto.Unmarshal(pubsubEvent.Message.Data, &message)
-			if err != nil {
-				ctx.Error("could not unmarshal event data", 500)
-				return
-			}
+			// messages published to the topic by other producers are delivered as bytes payloads
+			message := messages.DecodeTopicMessage(pubsubEvent.Message.Data, pubsubEvent.Message.Attributes)
 
 			// event := &faaspb.TriggerRequest{
 			// 	Context: &faaspb.TriggerRequest_Topic{
@@ -95,7 +104,9 @@ func (g *gcpMiddleware) handleSubscription(opts *gateway.GatewayStartOpts) fasth
 				Content: &topicspb.ServerMessage_MessageRequest{
 					MessageRequest: &topicspb.MessageRequest{
 						TopicName: topicName,
-						Message:   &message,
+						Message:   message,
+						// pubsub only counts deliveries for subscriptions with a dead-letter policy, otherwise this is 0
+						DeliveryAttempt: pubsubEvent.DeliveryAttempt,
 					},
 				},
 			}
@@ -107,18 +118,13 @@ func (g *gcpMiddleware) handleSubscription(opts *gateway.GatewayStartOpts) fasth
 			// 	ctx.Error("Could not find handle for event", 500)
 			// }
 
-			// traceKey := propagator.CloudTraceFormatPropagator{}.Fields()[0]
-			// traceCtx := context.TODO()
+			reqCtx, cancel := context.WithTimeout(context.Background(), pubsubAckDeadline)
+			defer cancel()
 
-			// if pubsubEvent.Message.Attributes[traceKey] != "" {
-			// 	var mc propagation.MapCarrier = pubsubEvent.Message.Attributes
-			// 	traceCtx = propagator.CloudTraceFormatPropagator{}.Extract(traceCtx, mc)
-			// } else {
-			// 	var hc propagation.HeaderCarrier = base_http.HttpHeadersToMap(&ctx.Request.Header)
-			// 	traceCtx = propagator.CloudTraceFormatPropagator{}.Extract(traceCtx, hc)
-			// }
+			// continue the publisher's trace, its trace context is published as message attributes
+			reqCtx = workers.ExtractTraceContext(reqCtx, propagation.MapCarrier(pubsubEvent.Message.Attributes))
 
-			response, err := opts.TopicsListenerPlugin.HandleRequest(event)
+			response, err := opts.TopicsListenerPlugin.HandleRequest(reqCtx, event)
 			if err != nil {
 				ctx.Error(fmt.Sprintf("Error handling event %v", err), 500)
 				return
@@ -134,6 +140,59 @@ func (g *gcpMiddleware) handleSubscription(opts *gateway.GatewayStartOpts) fasth
 	}
 }
 
+// handleQueueMessage delivers messages pushed by a queue's subscription to its listener,
+// the message is acknowledged when handled successfully and redelivered by pubsub otherwise
+func (g *gcpMiddleware) handleQueueMessage(opts *gateway.GatewayStartOpts) fasthttp.RequestHandler {
+	return func(ctx *fasthttp.RequestCtx) {
+		if !eventAuthorised(ctx) {
+			ctx.Error("Unauthorized", 401)
+			return
+		}
+
+		var pubsubEvent PubSubMessage
+		if err := json.Unmarshal(ctx.Request.Body(), &pubsubEvent); err != nil || pubsubEvent.Subscription == "" {
+			ctx.Error("Expected a pubsub push message", 400)
+			return
+		}
+
+		queueName := ctx.UserValue("name").(string)
+		if queueName == "" {
+			ctx.Error("Can not handle message for empty queue", 400)
+			return
+		}
+
+		// messages sent to the queue by other producers are delivered as bytes payloads
+		message := messages.DecodeQueueMessage(pubsubEvent.Message.Data, pubsubEvent.Message.Attributes)
+
+		reqCtx, cancel := context.WithTimeout(context.Background(), pubsubAckDeadline)
+		defer cancel()
+
+		// continue the producer's trace, its trace context is published as message attributes
+		reqCtx = workers.ExtractTraceContext(reqCtx, propagation.MapCarrier(pubsubEvent.Message.Attributes))
+
+		response, err := opts.QueueListenerPlugin.HandleRequest(reqCtx, &queuespb.ServerMessage{
+			Content: &queuespb.ServerMessage_MessageRequest{
+				MessageRequest: &queuespb.MessageRequest{
+					QueueName:       queueName,
+					Message:         message,
+					DeliveryAttempt: pubsubEvent.DeliveryAttempt,
+				},
+			},
+		})
+		if err != nil {
+			ctx.Error(fmt.Sprintf("Error handling message %v", err), 500)
+			return
+		}
+
+		if !response.GetMessageResponse().GetSuccess() {
+			ctx.Error("Message handler returned success false", 500)
+			return
+		}
+
+		ctx.SuccessString("text/plain", "success")
+	}
+}
+
 func (g *gcpMiddleware) handleSchedule(opts *gateway.GatewayStartOpts) fasthttp.RequestHandler {
 	return func(ctx *fasthttp.RequestCtx) {
 		if !eventAuthorised(ctx) {
@@ -146,7 +205,10 @@ func (g *gcpMiddleware) handleSchedule(opts *gateway.GatewayStartOpts) fasthttp.
 			ctx.Error("Can not handle event for empty schedule", 400)
 		}
 
-		_, err := opts.SchedulesPlugin.HandleRequest(&schedulespb.ServerMessage{
+		reqCtx, cancel := base_http.RequestContext()
+		defer cancel()
+
+		_, err := opts.SchedulesPlugin.HandleRequest(reqCtx, &schedulespb.ServerMessage{
 			Content: &schedulespb.ServerMessage_IntervalRequest{
 				IntervalRequest: &schedulespb.IntervalRequest{
 					ScheduleName: scheduleName,
@@ -196,7 +258,10 @@ func (g *gcpMiddleware) handleBucketNotification(opts *gateway.GatewayStartOpts)
 				return
 			}
 
-			resp, err := opts.StorageListenerPlugin.HandleRequest(&storagepb.ServerMessage{
+			reqCtx, cancel := context.WithTimeout(context.Background(), pubsubAckDeadline)
+			defer cancel()
+
+			resp, err := opts.StorageListenerPlugin.HandleRequest(reqCtx, &storagepb.ServerMessage{
 				Content: &storagepb.ServerMessage_BlobEventRequest{
 					BlobEventRequest: &storagepb.BlobEventRequest{
 						BucketName: bucketName,
@@ -224,10 +289,89 @@ func (g *gcpMiddleware) handleBucketNotification(opts *gateway.GatewayStartOpts)
 	}
 }
 
+// Converts the firestore document event type to our abstract event type
+func documentEventToEventType(eventType string) (*kvstorepb.KeyValueEventType, error) {
+	switch eventType {
+	case "google.cloud.firestore.document.v1.created", "google.cloud.firestore.document.v1.updated":
+		return kvstorepb.KeyValueEventType_Set.Enum(), nil
+	case "google.cloud.firestore.document.v1.deleted":
+		return kvstorepb.KeyValueEventType_Delete.Enum(), nil
+	default:
+		return nil, fmt.Errorf("unsupported key value notification event type %s", eventType)
+	}
+}
+
+// handleKeyValueNotification handles firestore document events delivered by eventarc.
+// eventarc can't add the event token to its requests, they're authenticated by the invoker permission of the cloud run service instead.
+func (g *gcpMiddleware) handleKeyValueNotification(opts *gateway.GatewayStartOpts) fasthttp.RequestHandler {
+	return func(ctx *fasthttp.RequestCtx) {
+		storeName := ctx.UserValue("name").(string)
+
+		prefix := []byte{}
+		if encodedPrefix, ok := ctx.UserValue("prefix").(string); ok {
+			var err error
+			prefix, err = base64.RawURLEncoding.DecodeString(encodedPrefix)
+			if err != nil {
+				ctx.Error("invalid key prefix filter", 400)
+				return
+			}
+		}
+
+		eventType, err := documentEventToEventType(string(ctx.Request.Header.Peek("ce-type")))
+		if err != nil {
+			ctx.Error(err.Error(), 400)
+			return
+		}
+
+		// Subject is in the form: "documents/store-name/key"
+		key, ok := strings.CutPrefix(string(ctx.Request.Header.Peek("ce-subject")), "documents/"+storeName+"/")
+		if !ok || key == "" {
+			ctx.Error("invalid document subject", 400)
+			return
+		}
+
+		// eventarc delivers the events of every key in the store, only keys matching the listener's prefix are forwarded
+		if !strings.HasPrefix(key, string(prefix)) {
+			ctx.SuccessString("text/plain", "success")
+			return
+		}
+
+		reqCtx, cancel := base_http.RequestContext()
+		defer cancel()
+
+		resp, err := opts.KeyValueListenerPlugin.HandleRequest(reqCtx, &kvstorepb.ServerMessage{
+			Content: &kvstorepb.ServerMessage_KeyValueEventRequest{
+				KeyValueEventRequest: &kvstorepb.KeyValueEventRequest{
+					StoreName: storeName,
+					Event: &kvstorepb.KeyValueEventRequest_KeyValueEvent{
+						KeyValueEvent: &kvstorepb.KeyValueEvent{
+							Key:  key,
+							Type: *eventType,
+						},
+					},
+				},
+			},
+		})
+		if err != nil {
+			ctx.Error(fmt.Sprintf("Error handling event %v", err), 500)
+			return
+		}
+
+		if !resp.GetKeyValueEventResponse().Success {
+			ctx.Error("Error handling event", 500)
+			return
+		}
+
+		ctx.SuccessString("text/plain", "success")
+	}
+}
+
 func (g *gcpMiddleware) router(r *router.Router, opts *gateway.GatewayStartOpts) {
 	r.ANY(base_http.DefaultTopicRoute, g.handleSubscription(opts))
+	r.ANY(base_http.DefaultQueueRoute, g.handleQueueMessage(opts))
 	r.ANY(base_http.DefaultScheduleRoute, g.handleSchedule(opts))
 	r.ANY(base_http.DefaultBucketNotificationRoute, g.handleBucketNotification(opts))
+	r.ANY(base_http.DefaultKeyValueNotificationRoute+"/{prefix?}", g.handleKeyValueNotification(opts))
 }
 
 // New - Create a New cloudrun gateway plugin
@@ -34,9 +34,11 @@ import (
 	mock_provider "github.com/nitrictech/nitric/cloud/gcp/mocks/provider"
 	cloudrun_service "github.com/nitrictech/nitric/cloud/gcp/runtime/gateway"
 	mock_apis "github.com/nitrictech/nitric/core/mocks/workers/apis"
+	mock_queues "github.com/nitrictech/nitric/core/mocks/workers/queues"
 	mock_topics "github.com/nitrictech/nitric/core/mocks/workers/topics"
 	"github.com/nitrictech/nitric/core/pkg/gateway"
 	apispb "github.com/nitrictech/nitric/core/pkg/proto/apis/v1"
+	queuespb "github.com/nitrictech/nitric/core/pkg/proto/queues/v1"
 	topicspb "github.com/nitrictech/nitric/core/pkg/proto/topics/v1"
 )
 
@@ -50,6 +52,7 @@ var _ = Describe("Http", func() {
 
 	mockApiRequestHandler := mock_apis.NewMockApiRequestHandler(ctrl)
 	mockTopicRequestHandler := mock_topics.NewMockSubscriptionRequestHandler(ctrl)
+	mockQueueRequestHandler := mock_queues.NewMockQueueRequestHandler(ctrl)
 
 	// Set this to loopback to ensure its not public in our CI/Testing environments
 	BeforeSuite(func() {
@@ -67,6 +70,7 @@ var _ = Describe("Http", func() {
 		_ = gw.Start(&gateway.GatewayStartOpts{
 			ApiPlugin:            mockApiRequestHandler,
 			TopicsListenerPlugin: mockTopicRequestHandler,
+			QueueListenerPlugin:  mockQueueRequestHandler,
 		})
 	}(httpPlugin)
 
@@ -74,6 +78,18 @@ var _ = Describe("Http", func() {
 	time.Sleep(500 * time.Millisecond)
 
 	When("Invoking the GCP HTTP Gateway", func() {
+		When("with a readiness check", func() {
+			It("Should report the gateway as ready", func() {
+				resp, err := http.Get(fmt.Sprintf("%s/x-nitric-health/ready", gatewayUrl))
+
+				By("Not returning an error")
+				Expect(err).To(BeNil())
+
+				By("Returning a 200 response")
+				Expect(resp.StatusCode).To(Equal(200))
+			})
+		})
+
 		When("with a HTTP request", func() {
 			It("Should be handled successfully", func() {
 				payload := []byte("Test")
@@ -82,20 +98,15 @@ var _ = Describe("Http", func() {
 				var capturedApiName string
 
 				By("Handling exactly 1 request")
-				mockApiRequestHandler.EXPECT().HandleRequest(gomock.Any(), gomock.Any()).Times(1).DoAndReturn(func(arg0 interface{}, arg1 interface{}) (*apispb.ClientMessage, error) {
+				mockApiRequestHandler.EXPECT().HandleStreamingRequest(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Times(1).DoAndReturn(func(ctx interface{}, arg0 interface{}, arg1 interface{}, arg2 interface{}) (*apispb.HttpResponse, io.ReadCloser, error) {
 					capturedApiName = arg0.(string)
 					capturedRequest = arg1.(*apispb.ServerMessage)
 					// apiName string, request *apispb.ServerMessage
 
-					return &apispb.ClientMessage{
-						Id: "test",
-						Content: &apispb.ClientMessage_HttpResponse{
-							HttpResponse: &apispb.HttpResponse{
-								Status: 200,
-								Body:   []byte("success"),
-							},
-						},
-					}, nil
+					return &apispb.HttpResponse{
+						Status: 200,
+						Body:   []byte("success"),
+					}, io.NopCloser(bytes.NewReader(nil)), nil
 				})
 
 				request, err := http.NewRequest("POST", fmt.Sprintf("%s/x-nitric-api/%s/test", gatewayUrl, "test-api"), bytes.NewReader(payload))
@@ -163,10 +174,12 @@ var _ = Describe("Http", func() {
 
 			b64Event := base64.StdEncoding.EncodeToString(messageBytes)
 			payloadBytes, _ := json.Marshal(&map[string]interface{}{
-				"subscription": "test",
+				"subscription":    "test",
+				"deliveryAttempt": 3,
 				"message": map[string]interface{}{
 					"attributes": map[string]string{
-						"x-nitric-topic": "test",
+						"x-nitric-topic":    "test",
+						"x-nitric-encoding": "protobuf",
 					},
 					"id":   "test",
 					"data": b64Event,
@@ -177,7 +190,7 @@ var _ = Describe("Http", func() {
 				var capturedRequest *topicspb.ServerMessage
 
 				By("Handling exactly 1 request")
-				mockTopicRequestHandler.EXPECT().HandleRequest(gomock.Any()).Times(1).DoAndReturn(func(arg0 interface{}) (*topicspb.ClientMessage, error) {
+				mockTopicRequestHandler.EXPECT().HandleRequest(gomock.Any(), gomock.Any()).Times(1).DoAndReturn(func(ctx interface{}, arg0 interface{}) (*topicspb.ClientMessage, error) {
 					capturedRequest = arg0.(*topicspb.ServerMessage)
 
 					return &topicspb.ClientMessage{
@@ -206,6 +219,9 @@ var _ = Describe("Http", func() {
 				By("Passing through the published message data")
 				Expect(capturedMessageBytes).To(Equal(messageBytes))
 
+				By("Passing through the delivery attempt")
+				Expect(capturedRequest.GetMessageRequest().GetDeliveryAttempt()).To(Equal(int32(3)))
+
 				By("The request returns a successful status")
 				Expect(resp.StatusCode).To(Equal(200))
 
@@ -213,5 +229,115 @@ var _ = Describe("Http", func() {
 				Expect(string(responseBody)).To(Equal("success"))
 			})
 		})
+
+		When("From a subscription with data published by another producer", func() {
+			rawData := []byte(`{"order": 12345678901234567890}`)
+			payloadBytes, _ := json.Marshal(&map[string]interface{}{
+				"subscription": "test",
+				"message": map[string]interface{}{
+					"attributes": map[string]string{
+						"content-type": "application/json",
+					},
+					"id":   "test",
+					"data": base64.StdEncoding.EncodeToString(rawData),
+				},
+			})
+
+			It("Should deliver the data as a bytes payload", func() {
+				var capturedRequest *topicspb.ServerMessage
+
+				By("Handling exactly 1 request")
+				mockTopicRequestHandler.EXPECT().HandleRequest(gomock.Any(), gomock.Any()).Times(1).DoAndReturn(func(ctx interface{}, arg0 interface{}) (*topicspb.ClientMessage, error) {
+					capturedRequest = arg0.(*topicspb.ServerMessage)
+
+					return &topicspb.ClientMessage{
+						Id: "test",
+						Content: &topicspb.ClientMessage_MessageResponse{
+							MessageResponse: &topicspb.MessageResponse{
+								Success: true,
+							},
+						},
+					}, nil
+				})
+
+				request, err := http.NewRequest("POST", fmt.Sprintf("%s/x-nitric-topic/test", gatewayUrl), bytes.NewReader(payloadBytes))
+				Expect(err).To(BeNil())
+				request.Header.Add("Content-Type", "application/json")
+				resp, err := http.DefaultClient.Do(request)
+				Expect(err).To(BeNil())
+
+				By("The request returns a successful status")
+				Expect(resp.StatusCode).To(Equal(200))
+
+				By("Passing through the original data and its content type")
+				payload := capturedRequest.GetMessageRequest().GetMessage().GetBytesPayload()
+				Expect(payload.GetData()).To(Equal(rawData))
+				Expect(payload.GetContentType()).To(Equal("application/json"))
+			})
+		})
+
+		When("From a queue subscription with a QueueMessage", func() {
+			content, _ := structpb.NewStruct(map[string]interface{}{
+				"Test": "Test",
+			})
+
+			message := queuespb.QueueMessage{
+				Content: &queuespb.QueueMessage_StructPayload{
+					StructPayload: content,
+				},
+			}
+
+			messageBytes, err := proto.Marshal(&message)
+			Expect(err).To(BeNil())
+
+			payloadBytes, _ := json.Marshal(&map[string]interface{}{
+				"subscription":    "test",
+				"deliveryAttempt": 2,
+				"message": map[string]interface{}{
+					"attributes": map[string]string{
+						"x-nitric-encoding": "protobuf",
+					},
+					"id":   "test",
+					"data": base64.StdEncoding.EncodeToString(messageBytes),
+				},
+			})
+
+			It("Should deliver the message to the queue listener", func() {
+				var capturedRequest *queuespb.ServerMessage
+
+				By("Handling exactly 1 request")
+				mockQueueRequestHandler.EXPECT().HandleRequest(gomock.Any(), gomock.Any()).Times(1).DoAndReturn(func(ctx interface{}, arg0 interface{}) (*queuespb.ClientMessage, error) {
+					capturedRequest = arg0.(*queuespb.ServerMessage)
+
+					return &queuespb.ClientMessage{
+						Id: "test",
+						Content: &queuespb.ClientMessage_MessageResponse{
+							MessageResponse: &queuespb.MessageResponse{
+								Success: true,
+							},
+						},
+					}, nil
+				})
+
+				request, err := http.NewRequest("POST", fmt.Sprintf("%s/x-nitric-queue/test-queue", gatewayUrl), bytes.NewReader(payloadBytes))
+				Expect(err).To(BeNil())
+				request.Header.Add("Content-Type", "application/json")
+				resp, err := http.DefaultClient.Do(request)
+				Expect(err).To(BeNil())
+
+				By("Passing through the queue name and delivery attempt")
+				Expect(capturedRequest.GetMessageRequest().GetQueueName()).To(Equal("test-queue"))
+				Expect(capturedRequest.GetMessageRequest().GetDeliveryAttempt()).To(Equal(int32(2)))
+
+				capturedMessageBytes, err := proto.Marshal(capturedRequest.GetMessageRequest().GetMessage())
+				Expect(err).To(BeNil())
+
+				By("Passing through the enqueued message data")
+				Expect(capturedMessageBytes).To(Equal(messageBytes))
+
+				By("The request returns a successful status")
+				Expect(resp.StatusCode).To(Equal(200))
+			})
+		})
 	})
 })
@@ -18,7 +18,10 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"strconv"
 	"strings"
+	"time"
+	"unicode/utf8"
 
 	"github.com/nitrictech/nitric/core/pkg/decorators/keyvalue"
 	grpc_errors "github.com/nitrictech/nitric/core/pkg/grpc/errors"
@@ -34,6 +37,13 @@ import (
 	"google.golang.org/grpc/status"
 )
 
+const (
+	// firestore limits write batches and transactions to 500 documents
+	maxBatchSize = 500
+	// TtlField - the expiry time of a value, stores have a firestore ttl policy on this field
+	TtlField = "_ttl"
+)
+
 type FirestoreDocService struct {
 	client *firestore.Client
 }
@@ -77,7 +87,18 @@ func (s *FirestoreDocService) GetValue(ctx context.Context, req *v1.KvStoreGetVa
 		)
 	}
 
-	documentContent, err := structpb.NewStruct(value.Data())
+	// firestore can take some time to delete expired documents, so they're filtered out until it does
+	data := value.Data()
+	if documentExpired(data) {
+		return nil, newErr(
+			codes.NotFound,
+			fmt.Sprintf("key %s not found in store %s", req.Ref.Key, req.Ref.Store),
+			nil,
+		)
+	}
+	delete(data, TtlField)
+
+	documentContent, err := structpb.NewStruct(data)
 	if err != nil {
 		return nil, newErr(
 			codes.Internal,
@@ -90,6 +111,7 @@ func (s *FirestoreDocService) GetValue(ctx context.Context, req *v1.KvStoreGetVa
 		Value: &v1.Value{
 			Ref:     req.Ref,
 			Content: documentContent,
+			Version: documentVersion(value.UpdateTime),
 		},
 	}, nil
 }
@@ -113,9 +135,54 @@ func (s *FirestoreDocService) SetValue(ctx context.Context, req *v1.KvStoreSetVa
 		)
 	}
 
+	if err := keyvalue.ValidateTtl(req.Ttl); err != nil {
+		return nil, newErr(
+			codes.InvalidArgument,
+			"invalid ttl",
+			err,
+		)
+	}
+
+	if err := keyvalue.ValidatePrecondition(req.Precondition, false); err != nil {
+		return nil, newErr(
+			codes.InvalidArgument,
+			"invalid precondition",
+			err,
+		)
+	}
+
+	content := req.Content.AsMap()
+	if req.Ttl != nil {
+		content[TtlField] = keyvalue.ExpiresAt(req.Ttl)
+	}
+
 	doc := s.getDocRef(req.Ref)
 
-	if _, err := doc.Set(ctx, req.Content.AsMap()); err != nil {
+	var result *firestore.WriteResult
+	var err error
+
+	switch c := req.Precondition.GetCondition().(type) {
+	case nil:
+		result, err = doc.Set(ctx, content)
+	case *v1.KvStorePrecondition_Exists:
+		if c.Exists {
+			result, err = replaceDocument(ctx, doc, content, "")
+		} else {
+			result, err = createDocument(ctx, doc, content)
+		}
+	case *v1.KvStorePrecondition_Version:
+		result, err = replaceDocument(ctx, doc, content, c.Version)
+	}
+
+	if err != nil {
+		if status.Code(err) == codes.FailedPrecondition {
+			return nil, newErr(
+				codes.FailedPrecondition,
+				fmt.Sprintf("precondition failed for key %s in store %s", req.Ref.Key, req.Ref.Store),
+				err,
+			)
+		}
+
 		if status.Code(err) == codes.PermissionDenied {
 			return nil, newErr(
 				codes.PermissionDenied,
@@ -131,7 +198,9 @@ func (s *FirestoreDocService) SetValue(ctx context.Context, req *v1.KvStoreSetVa
 		)
 	}
 
-	return &v1.KvStoreSetValueResponse{}, nil
+	return &v1.KvStoreSetValueResponse{
+		Version: documentVersion(result.UpdateTime),
+	}, nil
 }
 
 func (s *FirestoreDocService) DeleteKey(ctx context.Context, req *v1.KvStoreDeleteKeyRequest) (*v1.KvStoreDeleteKeyResponse, error) {
@@ -145,10 +214,43 @@ func (s *FirestoreDocService) DeleteKey(ctx context.Context, req *v1.KvStoreDele
 		)
 	}
 
+	if err := keyvalue.ValidatePrecondition(req.Precondition, true); err != nil {
+		return nil, newErr(
+			codes.InvalidArgument,
+			"invalid precondition",
+			err,
+		)
+	}
+
 	doc := s.getDocRef(req.Ref)
 
 	// Delete document
-	if _, err := doc.Delete(ctx); err != nil {
+	var err error
+	switch c := req.Precondition.GetCondition().(type) {
+	case nil:
+		_, err = doc.Delete(ctx)
+	case *v1.KvStorePrecondition_Version:
+		var updateTime time.Time
+		if updateTime, err = parseDocumentVersion(c.Version); err == nil {
+			_, err = doc.Delete(ctx, firestore.LastUpdateTime(updateTime))
+		}
+	case *v1.KvStorePrecondition_Exists:
+		var snapshot *firestore.DocumentSnapshot
+		if snapshot, err = currentDocument(ctx, doc); err == nil {
+			_, err = doc.Delete(ctx, firestore.LastUpdateTime(snapshot.UpdateTime))
+		}
+	}
+
+	if err != nil {
+		// firestore returns not found when deleting a document that doesn't exist with a precondition
+		if req.Precondition != nil && (status.Code(err) == codes.FailedPrecondition || status.Code(err) == codes.NotFound) {
+			return nil, newErr(
+				codes.FailedPrecondition,
+				fmt.Sprintf("precondition failed for key %s in store %s", req.Ref.Key, req.Ref.Store),
+				err,
+			)
+		}
+
 		if status.Code(err) == codes.PermissionDenied {
 			return nil, newErr(
 				codes.PermissionDenied,
@@ -179,7 +281,44 @@ func (s *FirestoreDocService) ScanKeys(req *v1.KvStoreScanKeysRequest, stream v1
 		)
 	}
 
-	iter := s.getCollectionRef(storeName).DocumentRefs(stream.Context())
+	if err := keyvalue.ValidateScan(req); err != nil {
+		return newErr(
+			codes.InvalidArgument,
+			"invalid scan",
+			err,
+		)
+	}
+
+	startAfter, _ := keyvalue.ScanStartAfter(req.ContinuationToken)
+
+	query := s.getCollectionRef(storeName).Query
+	if !req.IncludeValues {
+		// only the expiry time is needed to filter out expired keys
+		query = query.Select(TtlField)
+	}
+
+	// Range filters on the document ID require a DocumentRef rather than a prefix string,
+	// so the query is started from the prefix with a cursor and the results are filtered as they're returned
+	if req.Reverse {
+		query = query.OrderBy(firestore.DocumentID, firestore.Desc)
+		if startAfter != "" {
+			query = query.StartAfter(startAfter)
+		} else if req.Prefix != "" {
+			// the largest code point sorts after every key with the prefix
+			query = query.StartAt(req.Prefix + string(utf8.MaxRune))
+		}
+	} else {
+		query = query.OrderBy(firestore.DocumentID, firestore.Asc)
+		if startAfter != "" && startAfter >= req.Prefix {
+			query = query.StartAfter(startAfter)
+		} else if req.Prefix != "" {
+			query = query.StartAt(req.Prefix)
+		}
+	}
+
+	sent := 0
+	iter := query.Documents(stream.Context())
+	defer iter.Stop()
 
 	for {
 		doc, err := iter.Next()
@@ -194,28 +333,396 @@ func (s *FirestoreDocService) ScanKeys(req *v1.KvStoreScanKeysRequest, stream v1
 			)
 		}
 
-		// Range queries don't appear to be supported when querying based on document ID.
-		// e.g. Where(firestore.DocumentID, "<=", req.Prefix)
-		// since prefix is a string not a DocumentRef.
-		// Instead we filter the results as they're returned
-		if !strings.HasPrefix(doc.ID, req.Prefix) {
+		key := doc.Ref.ID
+		if !strings.HasPrefix(key, req.Prefix) {
+			// keys with the prefix are contiguous, so the scan is complete once it has passed them
+			if (req.Reverse && key < req.Prefix) || (!req.Reverse && key > req.Prefix) {
+				break
+			}
+
+			continue
+		}
+
+		// firestore can take some time to delete expired documents, so they're filtered out until it does
+		data := doc.Data()
+		if documentExpired(data) {
 			continue
 		}
 
-		if err := stream.Send(&v1.KvStoreScanKeysResponse{
-			Key: doc.ID,
-		}); err != nil {
+		resp := &v1.KvStoreScanKeysResponse{
+			Key:               key,
+			ContinuationToken: keyvalue.ScanContinuationToken(key),
+		}
+
+		if req.IncludeValues {
+			delete(data, TtlField)
+
+			content, err := structpb.NewStruct(data)
+			if err != nil {
+				return newErr(
+					codes.Internal,
+					"error converting returned document to struct",
+					err,
+				)
+			}
+
+			resp.Value = &v1.Value{
+				Ref:     &v1.ValueRef{Store: storeName, Key: key},
+				Content: content,
+				Version: documentVersion(doc.UpdateTime),
+			}
+		}
+
+		if err := stream.Send(resp); err != nil {
 			return newErr(
 				codes.Internal,
 				"failed to send response",
 				err,
 			)
 		}
+
+		sent++
+		if req.Limit > 0 && sent >= int(req.Limit) {
+			break
+		}
 	}
 
 	return nil
 }
 
+func (s *FirestoreDocService) BatchGet(ctx context.Context, req *v1.KvStoreBatchGetRequest) (*v1.KvStoreBatchGetResponse, error) {
+	newErr := grpc_errors.ErrorsWithScope("FirestoreDocService.BatchGet")
+
+	if err := keyvalue.ValidateBatchSize(len(req.Refs), maxBatchSize); err != nil {
+		return nil, newErr(
+			codes.InvalidArgument,
+			"invalid batch",
+			err,
+		)
+	}
+
+	resp := &v1.KvStoreBatchGetResponse{}
+	refs := []*v1.ValueRef{}
+	docs := []*firestore.DocumentRef{}
+
+	for _, ref := range req.Refs {
+		if err := keyvalue.ValidateValueRef(ref); err != nil {
+			resp.Errors = append(resp.Errors, keyvalue.ItemError(ref, newErr(codes.InvalidArgument, "invalid key", err)))
+			continue
+		}
+
+		refs = append(refs, ref)
+		docs = append(docs, s.getDocRef(ref))
+	}
+
+	if len(docs) == 0 {
+		return resp, nil
+	}
+
+	// snapshots are returned in the same order as the document refs
+	snapshots, err := s.client.GetAll(ctx, docs)
+	if err != nil {
+		err = firestoreErr(newErr, "unable to retrieve values", err)
+		for _, ref := range refs {
+			resp.Errors = append(resp.Errors, keyvalue.ItemError(ref, err))
+		}
+
+		return resp, nil
+	}
+
+	for i, snapshot := range snapshots {
+		ref := refs[i]
+
+		data := snapshot.Data()
+		if !snapshot.Exists() || documentExpired(data) {
+			resp.Errors = append(resp.Errors, keyvalue.ItemError(ref, newErr(
+				codes.NotFound,
+				fmt.Sprintf("key %s not found in store %s", ref.Key, ref.Store),
+				nil,
+			)))
+			continue
+		}
+
+		delete(data, TtlField)
+
+		content, err := structpb.NewStruct(data)
+		if err != nil {
+			resp.Errors = append(resp.Errors, keyvalue.ItemError(ref, newErr(
+				codes.Internal,
+				"error converting returned document to struct",
+				err,
+			)))
+			continue
+		}
+
+		resp.Values = append(resp.Values, &v1.Value{
+			Ref:     ref,
+			Content: content,
+			Version: documentVersion(snapshot.UpdateTime),
+		})
+	}
+
+	return resp, nil
+}
+
+func (s *FirestoreDocService) BatchSet(ctx context.Context, req *v1.KvStoreBatchSetRequest) (*v1.KvStoreBatchSetResponse, error) {
+	newErr := grpc_errors.ErrorsWithScope("FirestoreDocService.BatchSet")
+
+	if err := keyvalue.ValidateBatchSize(len(req.Values), maxBatchSize); err != nil {
+		return nil, newErr(
+			codes.InvalidArgument,
+			"invalid batch",
+			err,
+		)
+	}
+
+	resp := &v1.KvStoreBatchSetResponse{}
+	writer := s.client.BulkWriter(ctx)
+	refs := []*v1.ValueRef{}
+	jobs := []*firestore.BulkWriterJob{}
+
+	for _, value := range req.Values {
+		if err := keyvalue.ValidateValueRef(value.Ref); err != nil {
+			resp.Errors = append(resp.Errors, keyvalue.ItemError(value.Ref, newErr(codes.InvalidArgument, "invalid key", err)))
+			continue
+		}
+
+		if value.Content == nil {
+			resp.Errors = append(resp.Errors, keyvalue.ItemError(value.Ref, newErr(codes.InvalidArgument, "provide non-nil value", nil)))
+			continue
+		}
+
+		job, err := writer.Set(s.getDocRef(value.Ref), value.Content.AsMap())
+		if err != nil {
+			resp.Errors = append(resp.Errors, keyvalue.ItemError(value.Ref, newErr(codes.Internal, "error updating value", err)))
+			continue
+		}
+
+		refs = append(refs, value.Ref)
+		jobs = append(jobs, job)
+	}
+
+	resp.Errors = append(resp.Errors, bulkWriteErrors(newErr, writer, refs, jobs)...)
+
+	return resp, nil
+}
+
+func (s *FirestoreDocService) BatchDelete(ctx context.Context, req *v1.KvStoreBatchDeleteRequest) (*v1.KvStoreBatchDeleteResponse, error) {
+	newErr := grpc_errors.ErrorsWithScope("FirestoreDocService.BatchDelete")
+
+	if err := keyvalue.ValidateBatchSize(len(req.Refs), maxBatchSize); err != nil {
+		return nil, newErr(
+			codes.InvalidArgument,
+			"invalid batch",
+			err,
+		)
+	}
+
+	resp := &v1.KvStoreBatchDeleteResponse{}
+	writer := s.client.BulkWriter(ctx)
+	refs := []*v1.ValueRef{}
+	jobs := []*firestore.BulkWriterJob{}
+
+	for _, ref := range req.Refs {
+		if err := keyvalue.ValidateValueRef(ref); err != nil {
+			resp.Errors = append(resp.Errors, keyvalue.ItemError(ref, newErr(codes.InvalidArgument, "invalid key", err)))
+			continue
+		}
+
+		job, err := writer.Delete(s.getDocRef(ref))
+		if err != nil {
+			resp.Errors = append(resp.Errors, keyvalue.ItemError(ref, newErr(codes.Internal, "error deleting value", err)))
+			continue
+		}
+
+		refs = append(refs, ref)
+		jobs = append(jobs, job)
+	}
+
+	resp.Errors = append(resp.Errors, bulkWriteErrors(newErr, writer, refs, jobs)...)
+
+	return resp, nil
+}
+
+// Transact applies all operations in a single firestore transaction
+func (s *FirestoreDocService) Transact(ctx context.Context, req *v1.KvStoreTransactRequest) (*v1.KvStoreTransactResponse, error) {
+	newErr := grpc_errors.ErrorsWithScope("FirestoreDocService.Transact")
+
+	if err := keyvalue.ValidateTransaction(req.Operations, maxBatchSize); err != nil {
+		return nil, newErr(
+			codes.InvalidArgument,
+			"invalid transaction",
+			err,
+		)
+	}
+
+	err := s.client.RunTransaction(ctx, func(ctx context.Context, tx *firestore.Transaction) error {
+		for _, op := range req.Operations {
+			var err error
+			if set := op.GetSet(); set != nil {
+				err = tx.Set(s.getDocRef(set.Ref), set.Content.AsMap())
+			} else {
+				err = tx.Delete(s.getDocRef(op.GetDelete()))
+			}
+
+			if err != nil {
+				return err
+			}
+		}
+
+		return nil
+	})
+	if err != nil {
+		if status.Code(err) == codes.PermissionDenied {
+			return nil, newErr(
+				codes.PermissionDenied,
+				"permission denied, have you requested access to this key value store?",
+				err,
+			)
+		}
+
+		return nil, newErr(
+			codes.Aborted,
+			"transaction failed, no operations were applied",
+			err,
+		)
+	}
+
+	return &v1.KvStoreTransactResponse{}, nil
+}
+
+// bulkWriteErrors waits for the jobs of a bulk writer to complete, returning an error for each ref that wasn't written
+func bulkWriteErrors(newErr grpc_errors.ScopedErrorFactory, writer *firestore.BulkWriter, refs []*v1.ValueRef, jobs []*firestore.BulkWriterJob) []*v1.KvStoreItemError {
+	writer.End()
+
+	itemErrors := []*v1.KvStoreItemError{}
+	for i, job := range jobs {
+		if _, err := job.Results(); err != nil {
+			itemErrors = append(itemErrors, keyvalue.ItemError(refs[i], firestoreErr(newErr, "error writing value", err)))
+		}
+	}
+
+	return itemErrors
+}
+
+// documentVersion returns the version of a document, which is the time it was last updated
+func documentVersion(updateTime time.Time) string {
+	return strconv.FormatInt(updateTime.UnixNano(), 10)
+}
+
+// parseDocumentVersion returns the update time of a document version,
+// versions that can't be parsed fail the precondition since they can never match.
+func parseDocumentVersion(version string) (time.Time, error) {
+	nanos, err := strconv.ParseInt(version, 10, 64)
+	if err != nil {
+		return time.Time{}, status.Errorf(codes.FailedPrecondition, "invalid version %s", version)
+	}
+
+	return time.Unix(0, nanos), nil
+}
+
+// currentDocument returns the snapshot of a document, failing the precondition if it doesn't exist or has expired
+func currentDocument(ctx context.Context, doc *firestore.DocumentRef) (*firestore.DocumentSnapshot, error) {
+	snapshot, err := doc.Get(ctx)
+	if err != nil {
+		if status.Code(err) == codes.NotFound {
+			return nil, status.Error(codes.FailedPrecondition, "document does not exist")
+		}
+
+		return nil, err
+	}
+
+	if documentExpired(snapshot.Data()) {
+		return nil, status.Error(codes.FailedPrecondition, "document does not exist")
+	}
+
+	return snapshot, nil
+}
+
+// createDocument creates a document, failing the precondition if it already exists and hasn't expired
+func createDocument(ctx context.Context, doc *firestore.DocumentRef, content map[string]interface{}) (*firestore.WriteResult, error) {
+	result, err := doc.Create(ctx, content)
+	if status.Code(err) != codes.AlreadyExists {
+		return result, err
+	}
+
+	// expired documents are replaced, as long as they haven't been updated since they were read
+	snapshot, err := doc.Get(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if !documentExpired(snapshot.Data()) {
+		return nil, status.Error(codes.FailedPrecondition, "document already exists")
+	}
+
+	return updateDocument(ctx, doc, snapshot, content)
+}
+
+// replaceDocument replaces the content of an existing document, failing the precondition if it doesn't exist,
+// has expired or, when a version is provided, has a different version
+func replaceDocument(ctx context.Context, doc *firestore.DocumentRef, content map[string]interface{}, version string) (*firestore.WriteResult, error) {
+	snapshot, err := currentDocument(ctx, doc)
+	if err != nil {
+		return nil, err
+	}
+
+	if version != "" && documentVersion(snapshot.UpdateTime) != version {
+		return nil, status.Error(codes.FailedPrecondition, "document version does not match")
+	}
+
+	return updateDocument(ctx, doc, snapshot, content)
+}
+
+// updateDocument replaces the fields of a document with an update precondition on the snapshot's update time,
+// so the update fails if the document has changed since the snapshot was read
+func updateDocument(ctx context.Context, doc *firestore.DocumentRef, snapshot *firestore.DocumentSnapshot, content map[string]interface{}) (*firestore.WriteResult, error) {
+	updates := []firestore.Update{}
+	for field, value := range content {
+		updates = append(updates, firestore.Update{FieldPath: firestore.FieldPath{field}, Value: value})
+	}
+
+	for field := range snapshot.Data() {
+		if _, ok := content[field]; !ok {
+			updates = append(updates, firestore.Update{FieldPath: firestore.FieldPath{field}, Value: firestore.Delete})
+		}
+	}
+
+	// both the document and the new content are empty, so there is nothing to update
+	if len(updates) == 0 {
+		return &firestore.WriteResult{UpdateTime: snapshot.UpdateTime}, nil
+	}
+
+	return doc.Update(ctx, updates, firestore.LastUpdateTime(snapshot.UpdateTime))
+}
+
+// documentExpired returns true if the ttl of a document has elapsed
+func documentExpired(data map[string]interface{}) bool {
+	expiresAt, ok := data[TtlField].(time.Time)
+	if !ok {
+		return false
+	}
+
+	return !expiresAt.After(time.Now())
+}
+
+// firestoreErr converts an error returned by firestore, surfacing missing permissions
+func firestoreErr(newErr grpc_errors.ScopedErrorFactory, msg string, err error) error {
+	if status.Code(err) == codes.PermissionDenied {
+		return newErr(
+			codes.PermissionDenied,
+			"permission denied, have you requested access to this key value store?",
+			err,
+		)
+	}
+
+	return newErr(
+		codes.Internal,
+		msg,
+		err,
+	)
+}
+
 func New() (v1.KvStoreServer, error) {
 	ctx := context.Background()
 
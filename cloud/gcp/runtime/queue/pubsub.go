@@ -19,14 +19,17 @@ import (
 	"errors"
 	"fmt"
 	"sync"
+	"time"
 
 	"github.com/nitrictech/nitric/cloud/common/deploy/resources"
 	"github.com/nitrictech/nitric/cloud/common/deploy/tags"
+	"github.com/nitrictech/nitric/cloud/common/runtime/messages"
 	"github.com/nitrictech/nitric/cloud/gcp/runtime/env"
 	"google.golang.org/grpc/codes"
 	grpccodes "google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
 	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/durationpb"
 
 	"cloud.google.com/go/pubsub"
 	pubsubbase "cloud.google.com/go/pubsub/apiv1"
@@ -39,11 +42,19 @@ import (
 
 	ifaces_pubsub "github.com/nitrictech/nitric/cloud/gcp/ifaces/pubsub"
 	grpc_errors "github.com/nitrictech/nitric/core/pkg/grpc/errors"
-	"github.com/nitrictech/nitric/core/pkg/logger"
 
 	queuespb "github.com/nitrictech/nitric/core/pkg/proto/queues/v1"
 )
 
+// Pub/Sub limits the ack deadline of a subscription to between 10 seconds and 10 minutes
+const (
+	minAckDeadline = 10 * time.Second
+	maxAckDeadline = 10 * time.Minute
+)
+
+// Pub/Sub limits ordering keys to 1024 bytes
+const maxOrderingKeyLength = 1024
+
 type PubsubQueueService struct {
 	queuespb.UnimplementedQueuesServer
 	// queue.UnimplementedQueuePlugin
@@ -51,6 +62,7 @@ type PubsubQueueService struct {
 	newSubscriberClient func(ctx context.Context, opts ...option.ClientOption) (ifaces_pubsub.SubscriberClient, error)
 	projectId           string
 	cache               map[string]ifaces_pubsub.Topic
+	fifoQueues          map[string]bool
 	cacheLock           sync.Mutex
 }
 
@@ -64,6 +76,7 @@ func (s *PubsubQueueService) getPubsubTopicFromName(queue string) (ifaces_pubsub
 	if s.cache == nil {
 		topics := s.client.Topics(context.Background())
 		s.cache = make(map[string]ifaces_pubsub.Topic)
+		s.fifoQueues = make(map[string]bool)
 		stackID := env.GetNitricStackID()
 		for {
 			t, err := topics.Next()
@@ -82,6 +95,12 @@ func (s *PubsubQueueService) getPubsubTopicFromName(queue string) (ifaces_pubsub
 			resType, hasType := labels[tags.GetResourceTypeKey(stackID)]
 
 			if name, ok := labels[tags.GetResourceNameKey(stackID)]; ok && hasType && resType == "queue" {
+				// ordering keys can only be published to topics with message ordering enabled
+				if labels[tags.GetQueueFifoKey(stackID)] == "true" {
+					t.EnableOrdering()
+					s.fifoQueues[name] = true
+				}
+
 				s.cache[name] = t
 			}
 		}
@@ -94,6 +113,36 @@ func (s *PubsubQueueService) getPubsubTopicFromName(queue string) (ifaces_pubsub
 	return nil, fmt.Errorf("queue not found")
 }
 
+// isFifoQueue - returns true if the queue was deployed with message ordering, the queue must already be cached
+func (s *PubsubQueueService) isFifoQueue(queue string) bool {
+	s.cacheLock.Lock()
+	defer s.cacheLock.Unlock()
+
+	return s.fifoQueues[queue]
+}
+
+// validateMessageOptions - check the message's FIFO options are supported by the queue type
+func validateMessageOptions(message *queuespb.QueueMessage, fifo bool) error {
+	// Pub/Sub has no publish deduplication, so deduplication ids can't be honoured by any queue
+	if message.DeduplicationId != "" {
+		return fmt.Errorf("deduplication ids are not supported by Pub/Sub queues")
+	}
+
+	if !fifo && message.GroupKey != "" {
+		return fmt.Errorf("message group keys are only supported by FIFO queues")
+	}
+
+	if fifo && message.GroupKey == "" {
+		return fmt.Errorf("FIFO queues require a message group key")
+	}
+
+	if len(message.GroupKey) > maxOrderingKeyLength {
+		return fmt.Errorf("message group keys can't be longer than %d bytes", maxOrderingKeyLength)
+	}
+
+	return nil
+}
+
 // Retrieves the Nitric "Queue Subscription" for the specified queue (PubSub Topic).
 //
 // GCP PubSub requires a Subscription in order to Pull messages from a Topic.
@@ -152,16 +201,30 @@ func (s *PubsubQueueService) Enqueue(ctx context.Context, req *queuespb.QueueEnq
 	failedTasks := make([]*queuespb.FailedEnqueueMessage, 0)
 	publishedTasks := make([]*queuespb.QueueMessage, 0)
 
-	attributes := propagation.MapCarrier{}
+	attributes := propagation.MapCarrier{
+		messages.EncodingAttribute: messages.NitricEncoding,
+	}
 
 	propagator.CloudTraceFormatPropagator{}.Inject(ctx, attributes)
 
+	fifo := s.isFifoQueue(req.QueueName)
+
 	for _, task := range req.Messages {
 		t := task
+
+		if err := validateMessageOptions(t, fifo); err != nil {
+			failedTasks = append(failedTasks, &queuespb.FailedEnqueueMessage{
+				Message: t,
+				Details: err.Error(),
+			})
+			continue
+		}
+
 		if taskBytes, err := proto.Marshal(t); err == nil {
 			msg := ifaces_pubsub.AdaptPubsubMessage(&pubsub.Message{
-				Data:       taskBytes,
-				Attributes: attributes,
+				Data:        taskBytes,
+				Attributes:  attributes,
+				OrderingKey: t.GroupKey,
 			})
 
 			results = append(results, topic.Publish(ctx, msg))
@@ -177,6 +240,11 @@ func (s *PubsubQueueService) Enqueue(ctx context.Context, req *queuespb.QueueEnq
 	for idx, result := range results {
 		// Iterate over the results to check for successful publishing...
 		if _, err := result.Get(ctx); err != nil {
+			// publishing for an ordering key is paused after a failure until it's resumed
+			if publishedTasks[idx].GroupKey != "" {
+				topic.ResumePublish(publishedTasks[idx].GroupKey)
+			}
+
 			// Add this to our failures list in our results...
 			failedTasks = append(failedTasks, &queuespb.FailedEnqueueMessage{
 				Message: publishedTasks[idx],
@@ -193,6 +261,19 @@ func (s *PubsubQueueService) Enqueue(ctx context.Context, req *queuespb.QueueEnq
 func (s *PubsubQueueService) Dequeue(ctx context.Context, req *queuespb.QueueDequeueRequest) (*queuespb.QueueDequeueResponse, error) {
 	newErr := grpc_errors.ErrorsWithScope("PubsubQueueService.Dequeue")
 
+	var ackDeadline int32
+	if req.VisibilityTimeout != nil {
+		seconds, err := leaseDeadlineSeconds(req.VisibilityTimeout)
+		if err != nil {
+			return nil, newErr(
+				codes.InvalidArgument,
+				"invalid visibility timeout",
+				err,
+			)
+		}
+		ackDeadline = seconds
+	}
+
 	// Find the generic pull subscription for the provided topic (queue)
 	queueSubscription, err := s.getQueueSubscription(ctx, req.QueueName)
 	if err != nil {
@@ -248,23 +329,38 @@ func (s *PubsubQueueService) Dequeue(ctx context.Context, req *queuespb.QueueDeq
 	// Convert the PubSub messages into Nitric tasks
 	var tasks []*queuespb.DequeuedMessage
 	for _, m := range res.ReceivedMessages {
-		// var nitricTask queuespb.ReceivedTask
-		var queueMessage queuespb.QueueMessage
-		err := proto.Unmarshal(m.Message.Data, &queueMessage)
-		if err != nil {
-			// This item could be immediately requeued.
-			// However, that risks the unprocessable items being reprocessed immediately,
-			// causing a loop where the receiver frequently attempts to receive the same item.
-			logger.Errorf("failed to deserialize queue item payload: %s", err.Error())
-			continue
-		}
+		// messages sent to the queue by other producers are delivered as bytes payloads
+		queueMessage := messages.DecodeQueueMessage(m.Message.Data, m.Message.Attributes)
 
 		tasks = append(tasks, &queuespb.DequeuedMessage{
-			Message: &queueMessage,
+			Message: queueMessage,
 			LeaseId: m.AckId,
+			// only populated by Pub/Sub when the subscription has a dead-letter policy
+			DeliveryAttempt: m.DeliveryAttempt,
 		})
 	}
 
+	// pulled messages have the subscription's ack deadline, it's modified afterwards when a different visibility timeout is requested
+	if ackDeadline > 0 && len(tasks) > 0 {
+		ackIds := make([]string, 0, len(tasks))
+		for _, task := range tasks {
+			ackIds = append(ackIds, task.LeaseId)
+		}
+
+		err = client.ModifyAckDeadline(ctx, &pubsubpb.ModifyAckDeadlineRequest{
+			Subscription:       queueSubscription.String(),
+			AckIds:             ackIds,
+			AckDeadlineSeconds: ackDeadline,
+		})
+		if err != nil {
+			return nil, newErr(
+				codes.Internal,
+				"failed to set visibility timeout of pulled messages",
+				err,
+			)
+		}
+	}
+
 	return &queuespb.QueueDequeueResponse{
 		Messages: tasks,
 	}, nil
@@ -321,6 +417,112 @@ func (s *PubsubQueueService) Complete(ctx context.Context, req *queuespb.QueueCo
 	return &queuespb.QueueCompleteResponse{}, nil
 }
 
+func (s *PubsubQueueService) ExtendLease(ctx context.Context, req *queuespb.QueueExtendLeaseRequest) (*queuespb.QueueExtendLeaseResponse, error) {
+	newErr := grpc_errors.ErrorsWithScope("PubsubQueueService.ExtendLease")
+
+	ackDeadline, err := leaseDeadlineSeconds(req.VisibilityTimeout)
+	if err != nil {
+		return nil, newErr(
+			codes.InvalidArgument,
+			"invalid visibility timeout",
+			err,
+		)
+	}
+
+	if err := s.modifyAckDeadline(ctx, req.QueueName, req.LeaseId, ackDeadline); err != nil {
+		return nil, newErr(
+			status.Code(err),
+			"failed to extend lease",
+			err,
+		)
+	}
+
+	return &queuespb.QueueExtendLeaseResponse{
+		LeaseId: req.LeaseId,
+	}, nil
+}
+
+// Release - negatively acknowledges a message, an ack deadline of zero makes it available for redelivery immediately
+func (s *PubsubQueueService) Release(ctx context.Context, req *queuespb.QueueReleaseRequest) (*queuespb.QueueReleaseResponse, error) {
+	newErr := grpc_errors.ErrorsWithScope("PubsubQueueService.Release")
+
+	var ackDeadline int32
+	if req.Delay != nil {
+		seconds, err := ackDeadlineSeconds(req.Delay)
+		if err != nil {
+			return nil, newErr(
+				codes.InvalidArgument,
+				fmt.Sprintf("delay must be between 0 and %s", maxAckDeadline),
+				err,
+			)
+		}
+		ackDeadline = seconds
+	}
+
+	if err := s.modifyAckDeadline(ctx, req.QueueName, req.LeaseId, ackDeadline); err != nil {
+		return nil, newErr(
+			status.Code(err),
+			"failed to release message",
+			err,
+		)
+	}
+
+	return &queuespb.QueueReleaseResponse{}, nil
+}
+
+// modifyAckDeadline sets how long from now a leased message stays hidden from other consumers
+func (s *PubsubQueueService) modifyAckDeadline(ctx context.Context, queueName string, ackId string, ackDeadline int32) error {
+	queueSubscription, err := s.getQueueSubscription(ctx, queueName)
+	if err != nil {
+		return status.Errorf(codes.NotFound, "could not find queue subscription: %v", err)
+	}
+
+	client, err := s.newSubscriberClient(ctx)
+	if err != nil {
+		return status.Errorf(codes.Internal, "failed to create subscriber client: %v", err)
+	}
+	defer client.Close()
+
+	err = client.ModifyAckDeadline(ctx, &pubsubpb.ModifyAckDeadlineRequest{
+		Subscription:       queueSubscription.String(),
+		AckIds:             []string{ackId},
+		AckDeadlineSeconds: ackDeadline,
+	})
+	if err != nil {
+		errStatus, _ := status.FromError(err)
+		if errStatus.Code() == grpccodes.PermissionDenied {
+			return status.Errorf(codes.PermissionDenied, "permission denied, have you requested access to the queue? %v", err)
+		}
+
+		return status.Errorf(codes.Internal, "%v", err)
+	}
+
+	return nil
+}
+
+// ackDeadlineSeconds converts a duration to the whole seconds Pub/Sub expects, within its limits
+func ackDeadlineSeconds(duration *durationpb.Duration) (int32, error) {
+	if err := duration.CheckValid(); err != nil {
+		return 0, err
+	}
+
+	if duration.AsDuration() < 0 || duration.AsDuration() > maxAckDeadline {
+		return 0, fmt.Errorf("duration %s is out of range", duration.AsDuration())
+	}
+
+	return int32(duration.AsDuration().Seconds()), nil
+}
+
+// leaseDeadlineSeconds converts a visibility timeout to seconds, leases are held for the same range Pub/Sub allows for a subscription's ack deadline
+func leaseDeadlineSeconds(visibilityTimeout *durationpb.Duration) (int32, error) {
+	seconds, err := ackDeadlineSeconds(visibilityTimeout)
+	if err != nil || time.Duration(seconds)*time.Second < minAckDeadline {
+		return 0, fmt.Errorf("visibility timeout must be between %s and %s", minAckDeadline, maxAckDeadline)
+	}
+
+	return seconds, nil
+}
+
 // adaptNewClient - Adapts the pubsubbase.NewSubscriberClient func to one that implements the SubscriberClient
 // interface. This is used to enable substitution of the base pubsub client, primarily for mocking support.
 func adaptNewClient(f func(context.Context, ...option.ClientOption) (*pubsubbase.SubscriberClient, error)) func(ctx context.Context, opts ...option.ClientOption) (ifaces_pubsub.SubscriberClient, error) {
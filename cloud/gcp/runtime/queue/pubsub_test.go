@@ -15,19 +15,65 @@
 package queue_test
 
 import (
-	// "encoding/json"
-	// "fmt"
+	"context"
+	"os"
+	"time"
 
+	"cloud.google.com/go/pubsub/apiv1/pubsubpb"
+	"github.com/golang/mock/gomock"
 	. "github.com/onsi/ginkgo"
-	// . "github.com/onsi/gomega"
-	// "golang.org/x/net/context"
-	// "google.golang.org/api/option"
-	// "github.com/nitrictech/nitric/core/pkg/plugins/queue"
-	// ifaces_pubsub "github.com/nitrictech/nitric/cloud/gcp/ifaces/pubsub"
-	// mock_pubsub "github.com/nitrictech/nitric/cloud/gcp/mocks/pubsub"
-	// pubsub_queue_service "github.com/nitrictech/nitric/cloud/gcp/runtime/queue"
+	. "github.com/onsi/gomega"
+	"google.golang.org/api/iterator"
+	"google.golang.org/api/option"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/durationpb"
+
+	ifaces_pubsub "github.com/nitrictech/nitric/cloud/gcp/ifaces/pubsub"
+	mock_pubsub "github.com/nitrictech/nitric/cloud/gcp/mocks/pubsub"
+	pubsub_queue_service "github.com/nitrictech/nitric/cloud/gcp/runtime/queue"
+	queuespb "github.com/nitrictech/nitric/core/pkg/proto/queues/v1"
 )
 
+// newLeaseTestService creates a queue service for a single queue named test-queue, returning the subscriber client used to modify its leases
+func newLeaseTestService(ctrl *gomock.Controller) (*pubsub_queue_service.PubsubQueueService, *mock_pubsub.MockSubscriberClient) {
+	pubsubClient := mock_pubsub.NewMockPubsubClient(ctrl)
+	topicIterator := mock_pubsub.NewMockTopicIterator(ctrl)
+	topic := mock_pubsub.NewMockTopic(ctrl)
+	subscriptionIterator := mock_pubsub.NewMockSubscriptionIterator(ctrl)
+	subscription := mock_pubsub.NewMockSubscription(ctrl)
+	subscriberClient := mock_pubsub.NewMockSubscriberClient(ctrl)
+
+	labels := map[string]string{
+		"x-nitric-test-stack-name": "test-queue",
+		"x-nitric-test-stack-type": "queue",
+	}
+
+	pubsubClient.EXPECT().Topics(gomock.Any()).Return(topicIterator).AnyTimes()
+	topicIterator.EXPECT().Next().Return(topic, nil).MaxTimes(1)
+	topicIterator.EXPECT().Next().Return(nil, iterator.Done).AnyTimes()
+	topic.EXPECT().Labels(gomock.Any()).Return(labels, nil).AnyTimes()
+	topic.EXPECT().Subscriptions(gomock.Any()).Return(subscriptionIterator).AnyTimes()
+	subscriptionIterator.EXPECT().Next().Return(subscription, nil).AnyTimes()
+	subscription.EXPECT().Labels(gomock.Any()).Return(labels, nil).AnyTimes()
+	subscription.EXPECT().String().Return("projects/test/subscriptions/test-queue").AnyTimes()
+	subscriberClient.EXPECT().Close().AnyTimes()
+
+	service := pubsub_queue_service.NewWithClients(pubsubClient, func(ctx context.Context, opts ...option.ClientOption) (ifaces_pubsub.SubscriberClient, error) {
+		return subscriberClient, nil
+	})
+
+	return service, subscriberClient
+}
+
+func expectAckDeadline(subscriberClient *mock_pubsub.MockSubscriberClient, seconds int32, err error) {
+	subscriberClient.EXPECT().ModifyAckDeadline(gomock.Any(), &pubsubpb.ModifyAckDeadlineRequest{
+		Subscription:       "projects/test/subscriptions/test-queue",
+		AckIds:             []string{"lease-id"},
+		AckDeadlineSeconds: seconds,
+	}).Return(err)
+}
+
 var _ = Describe("Pubsub", func() {
 	Context("Send", func() {
 		// When("Publishing to a queue that exists", func() {
@@ -228,4 +274,170 @@ var _ = Describe("Pubsub", func() {
 	// 		})
 	// 	})
 	// })
+
+	Context("ExtendLease", func() {
+		var (
+			ctrl             *gomock.Controller
+			queueService     *pubsub_queue_service.PubsubQueueService
+			subscriberClient *mock_pubsub.MockSubscriberClient
+		)
+
+		BeforeEach(func() {
+			_ = os.Setenv("NITRIC_STACK_ID", "test-stack")
+			ctrl = gomock.NewController(GinkgoT())
+			queueService, subscriberClient = newLeaseTestService(ctrl)
+		})
+
+		AfterEach(func() {
+			ctrl.Finish()
+		})
+
+		extendLease := func(visibilityTimeout time.Duration) (*queuespb.QueueExtendLeaseResponse, error) {
+			return queueService.ExtendLease(context.TODO(), &queuespb.QueueExtendLeaseRequest{
+				QueueName:         "test-queue",
+				LeaseId:           "lease-id",
+				VisibilityTimeout: durationpb.New(visibilityTimeout),
+			})
+		}
+
+		It("should set the message's ack deadline to the visibility timeout", func() {
+			expectAckDeadline(subscriberClient, 30, nil)
+
+			resp, err := extendLease(30 * time.Second)
+
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(resp.LeaseId).To(Equal("lease-id"))
+		})
+
+		It("should accept visibility timeouts at the limits of Pub/Sub's ack deadline", func() {
+			expectAckDeadline(subscriberClient, 10, nil)
+			expectAckDeadline(subscriberClient, 600, nil)
+
+			_, err := extendLease(10 * time.Second)
+			Expect(err).ShouldNot(HaveOccurred())
+
+			_, err = extendLease(10 * time.Minute)
+			Expect(err).ShouldNot(HaveOccurred())
+		})
+
+		It("should reject visibility timeouts shorter than 10 seconds", func() {
+			_, err := extendLease(time.Second)
+
+			Expect(status.Code(err)).To(Equal(codes.InvalidArgument))
+		})
+
+		It("should reject visibility timeouts longer than 10 minutes", func() {
+			_, err := extendLease(11 * time.Minute)
+
+			Expect(status.Code(err)).To(Equal(codes.InvalidArgument))
+		})
+
+		It("should reject a missing visibility timeout", func() {
+			_, err := queueService.ExtendLease(context.TODO(), &queuespb.QueueExtendLeaseRequest{
+				QueueName: "test-queue",
+				LeaseId:   "lease-id",
+			})
+
+			Expect(status.Code(err)).To(Equal(codes.InvalidArgument))
+		})
+
+		It("should return permission denied when the queue hasn't been requested", func() {
+			expectAckDeadline(subscriberClient, 30, status.Error(codes.PermissionDenied, "denied"))
+
+			_, err := extendLease(30 * time.Second)
+
+			Expect(status.Code(err)).To(Equal(codes.PermissionDenied))
+		})
+
+		It("should return not found for unknown queues", func() {
+			_, err := queueService.ExtendLease(context.TODO(), &queuespb.QueueExtendLeaseRequest{
+				QueueName:         "unknown-queue",
+				LeaseId:           "lease-id",
+				VisibilityTimeout: durationpb.New(30 * time.Second),
+			})
+
+			Expect(status.Code(err)).To(Equal(codes.NotFound))
+		})
+	})
+
+	Context("Release", func() {
+		var (
+			ctrl             *gomock.Controller
+			queueService     *pubsub_queue_service.PubsubQueueService
+			subscriberClient *mock_pubsub.MockSubscriberClient
+		)
+
+		BeforeEach(func() {
+			_ = os.Setenv("NITRIC_STACK_ID", "test-stack")
+			ctrl = gomock.NewController(GinkgoT())
+			queueService, subscriberClient = newLeaseTestService(ctrl)
+		})
+
+		AfterEach(func() {
+			ctrl.Finish()
+		})
+
+		It("should make the message available immediately without a delay", func() {
+			expectAckDeadline(subscriberClient, 0, nil)
+
+			_, err := queueService.Release(context.TODO(), &queuespb.QueueReleaseRequest{
+				QueueName: "test-queue",
+				LeaseId:   "lease-id",
+			})
+
+			Expect(err).ShouldNot(HaveOccurred())
+		})
+
+		It("should make the message available after the delay", func() {
+			expectAckDeadline(subscriberClient, 5, nil)
+
+			_, err := queueService.Release(context.TODO(), &queuespb.QueueReleaseRequest{
+				QueueName: "test-queue",
+				LeaseId:   "lease-id",
+				Delay:     durationpb.New(5 * time.Second),
+			})
+
+			Expect(err).ShouldNot(HaveOccurred())
+		})
+
+		It("should reject delays longer than 10 minutes", func() {
+			_, err := queueService.Release(context.TODO(), &queuespb.QueueReleaseRequest{
+				QueueName: "test-queue",
+				LeaseId:   "lease-id",
+				Delay:     durationpb.New(11 * time.Minute),
+			})
+
+			Expect(status.Code(err)).To(Equal(codes.InvalidArgument))
+		})
+
+		It("should return the error when the lease can't be modified", func() {
+			expectAckDeadline(subscriberClient, 0, status.Error(codes.Unavailable, "unavailable"))
+
+			_, err := queueService.Release(context.TODO(), &queuespb.QueueReleaseRequest{
+				QueueName: "test-queue",
+				LeaseId:   "lease-id",
+			})
+
+			Expect(err).Should(HaveOccurred())
+		})
+	})
+
+	Context("Dequeue", func() {
+		It("should reject the same visibility timeouts as ExtendLease", func() {
+			_ = os.Setenv("NITRIC_STACK_ID", "test-stack")
+			ctrl := gomock.NewController(GinkgoT())
+			defer ctrl.Finish()
+			queueService, _ := newLeaseTestService(ctrl)
+
+			for _, visibilityTimeout := range []time.Duration{time.Second, 11 * time.Minute} {
+				_, err := queueService.Dequeue(context.TODO(), &queuespb.QueueDequeueRequest{
+					QueueName:         "test-queue",
+					Depth:             1,
+					VisibilityTimeout: durationpb.New(visibilityTimeout),
+				})
+
+				Expect(status.Code(err)).To(Equal(codes.InvalidArgument))
+			}
+		})
+	})
 })
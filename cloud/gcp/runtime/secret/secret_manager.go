@@ -204,6 +204,15 @@ func (s *SecretManagerSecretService) Access(ctx context.Context, req *secretpb.S
 				"permission denied, have you requested access to this secret?", err)
 		}
 
+		// disabled and destroyed versions can't be accessed
+		if errStatus.Code() == grpccodes.FailedPrecondition {
+			return nil, newErr(
+				codes.FailedPrecondition,
+				"secret version is not enabled",
+				err,
+			)
+		}
+
 		return nil, newErr(
 			codes.Internal,
 			"failed to access secret version",
@@ -218,6 +227,156 @@ func (s *SecretManagerSecretService) Access(ctx context.Context, req *secretpb.S
 	}, nil
 }
 
+// secretVersionStates - maps secret manager version states to nitric version states
+var secretVersionStates = map[secretmanagerpb.SecretVersion_State]secretpb.SecretVersionState{
+	secretmanagerpb.SecretVersion_ENABLED:   secretpb.SecretVersionState_Enabled,
+	secretmanagerpb.SecretVersion_DISABLED:  secretpb.SecretVersionState_Disabled,
+	secretmanagerpb.SecretVersion_DESTROYED: secretpb.SecretVersionState_Destroyed,
+}
+
+// versionStateErr - converts a secret manager error from changing the state of a version to a nitric error
+func versionStateErr(newErr grpc_errors.ScopedErrorFactory, action string, err error) error {
+	switch status.Code(err) {
+	case grpccodes.PermissionDenied:
+		return newErr(
+			codes.PermissionDenied,
+			"permission denied, have you requested access to this secret?", err)
+	case grpccodes.NotFound:
+		return newErr(codes.NotFound, "secret version not found", err)
+	case grpccodes.FailedPrecondition:
+		// e.g. enabling a destroyed version
+		return newErr(codes.FailedPrecondition, fmt.Sprintf("unable to %s secret version", action), err)
+	}
+
+	return newErr(
+		codes.Internal,
+		fmt.Sprintf("failed to %s secret version", action),
+		err,
+	)
+}
+
+// ListVersions - Lists the versions of a secret, newest first
+func (s *SecretManagerSecretService) ListVersions(ctx context.Context, req *secretpb.SecretListVersionsRequest) (*secretpb.SecretListVersionsResponse, error) {
+	newErr := grpc_errors.ErrorsWithScope("SecretManagerSecretService.ListVersions")
+
+	if len(req.GetSecret().GetName()) == 0 {
+		return nil, newErr(
+			codes.InvalidArgument,
+			"invalid secret",
+			fmt.Errorf("provide non-blank secret name"),
+		)
+	}
+
+	parentSec, err := s.getSecret(ctx, req.Secret)
+	if err != nil {
+		return nil, newErr(
+			codes.NotFound,
+			"secret not found",
+			err,
+		)
+	}
+
+	iter := s.client.ListSecretVersions(ctx, &secretmanagerpb.ListSecretVersionsRequest{
+		Parent: parentSec,
+	})
+
+	versions := []*secretpb.SecretVersionDetails{}
+	for {
+		version, err := iter.Next()
+		if errors.Is(err, iterator.Done) {
+			break
+		}
+
+		if err != nil {
+			return nil, versionStateErr(newErr, "list", err)
+		}
+
+		nameParts := strings.Split(version.Name, "/")
+
+		versions = append(versions, &secretpb.SecretVersionDetails{
+			SecretVersion: &secretpb.SecretVersion{
+				Secret:  req.Secret,
+				Version: nameParts[len(nameParts)-1],
+			},
+			CreateTime: version.CreateTime,
+			State:      secretVersionStates[version.State],
+		})
+	}
+
+	return &secretpb.SecretListVersionsResponse{
+		Versions: versions,
+	}, nil
+}
+
+// DisableVersion - Disables a version of a secret
+func (s *SecretManagerSecretService) DisableVersion(ctx context.Context, req *secretpb.SecretDisableVersionRequest) (*secretpb.SecretDisableVersionResponse, error) {
+	newErr := grpc_errors.ErrorsWithScope("SecretManagerSecretService.DisableVersion")
+
+	fullName, err := s.buildSecretVersionName(ctx, req.SecretVersion)
+	if err != nil {
+		return nil, newErr(
+			codes.InvalidArgument,
+			"invalid secret version",
+			err,
+		)
+	}
+
+	_, err = s.client.DisableSecretVersion(ctx, &secretmanagerpb.DisableSecretVersionRequest{
+		Name: fullName,
+	})
+	if err != nil {
+		return nil, versionStateErr(newErr, "disable", err)
+	}
+
+	return &secretpb.SecretDisableVersionResponse{}, nil
+}
+
+// EnableVersion - Enables a disabled version of a secret
+func (s *SecretManagerSecretService) EnableVersion(ctx context.Context, req *secretpb.SecretEnableVersionRequest) (*secretpb.SecretEnableVersionResponse, error) {
+	newErr := grpc_errors.ErrorsWithScope("SecretManagerSecretService.EnableVersion")
+
+	fullName, err := s.buildSecretVersionName(ctx, req.SecretVersion)
+	if err != nil {
+		return nil, newErr(
+			codes.InvalidArgument,
+			"invalid secret version",
+			err,
+		)
+	}
+
+	_, err = s.client.EnableSecretVersion(ctx, &secretmanagerpb.EnableSecretVersionRequest{
+		Name: fullName,
+	})
+	if err != nil {
+		return nil, versionStateErr(newErr, "enable", err)
+	}
+
+	return &secretpb.SecretEnableVersionResponse{}, nil
+}
+
+// DestroyVersion - Destroys the value of a version of a secret
+func (s *SecretManagerSecretService) DestroyVersion(ctx context.Context, req *secretpb.SecretDestroyVersionRequest) (*secretpb.SecretDestroyVersionResponse, error) {
+	newErr := grpc_errors.ErrorsWithScope("SecretManagerSecretService.DestroyVersion")
+
+	fullName, err := s.buildSecretVersionName(ctx, req.SecretVersion)
+	if err != nil {
+		return nil, newErr(
+			codes.InvalidArgument,
+			"invalid secret version",
+			err,
+		)
+	}
+
+	_, err = s.client.DestroySecretVersion(ctx, &secretmanagerpb.DestroySecretVersionRequest{
+		Name: fullName,
+	})
+	if err != nil {
+		return nil, versionStateErr(newErr, "destroy", err)
+	}
+
+	return &secretpb.SecretDestroyVersionResponse{}, nil
+}
+
 // New - Creates a new Nitric secret service with GCP Secret Manager provider
 func New() (*SecretManagerSecretService, error) {
 	ctx := context.Background()
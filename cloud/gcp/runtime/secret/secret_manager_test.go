@@ -23,6 +23,7 @@ import (
 	"github.com/golang/mock/gomock"
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/gomega"
+	"google.golang.org/api/iterator"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
 
@@ -355,4 +356,132 @@ var _ = Describe("Secret Manager", func() {
 			})
 		})
 	})
+
+	When("ListVersions", func() {
+		When("Given the Secret Manager backend is available", func() {
+			When("The secret has versions", func() {
+				crtl := gomock.NewController(GinkgoT())
+				mockSecretClient := mocks.NewMockSecretManagerClient(crtl)
+				mockVersionIterator := mocks.NewMockSecretVersionIterator(crtl)
+				secretPlugin := &SecretManagerSecretService{
+					client:    mockSecretClient,
+					projectId: "my-project",
+					cache:     map[string]string{"test-id": "projects/my-project/secrets/test-id"},
+				}
+
+				It("Should return the versions with their state", func() {
+					defer crtl.Finish()
+
+					By("calling SecretManagerService.ListSecretVersions with the secret as the parent")
+					mockSecretClient.EXPECT().ListSecretVersions(
+						gomock.Any(),
+						&secretmanagerpb.ListSecretVersionsRequest{
+							Parent: "projects/my-project/secrets/test-id",
+						},
+					).Return(mockVersionIterator).Times(1)
+
+					By("iterating over the secret versions")
+					gomock.InOrder(
+						mockVersionIterator.EXPECT().Next().Return(&secretmanagerpb.SecretVersion{
+							Name:  "projects/my-project/secrets/test-id/versions/2",
+							State: secretmanagerpb.SecretVersion_ENABLED,
+						}, nil),
+						mockVersionIterator.EXPECT().Next().Return(&secretmanagerpb.SecretVersion{
+							Name:  "projects/my-project/secrets/test-id/versions/1",
+							State: secretmanagerpb.SecretVersion_DISABLED,
+						}, nil),
+						mockVersionIterator.EXPECT().Next().Return(nil, iterator.Done),
+					)
+
+					response, err := secretPlugin.ListVersions(context.TODO(), &secretpb.SecretListVersionsRequest{
+						Secret: &secretpb.Secret{
+							Name: "test-id",
+						},
+					})
+
+					By("Not returning an error")
+					Expect(err).ShouldNot(HaveOccurred())
+
+					By("Returning the versions newest first")
+					Expect(response.Versions).To(HaveLen(2))
+					Expect(response.Versions[0].SecretVersion.Version).To(Equal("2"))
+					Expect(response.Versions[0].State).To(Equal(secretpb.SecretVersionState_Enabled))
+					Expect(response.Versions[1].SecretVersion.Version).To(Equal("1"))
+					Expect(response.Versions[1].State).To(Equal(secretpb.SecretVersionState_Disabled))
+				})
+			})
+		})
+	})
+
+	When("DisableVersion", func() {
+		When("Given the Secret Manager backend is available", func() {
+			When("The secret version exists", func() {
+				crtl := gomock.NewController(GinkgoT())
+				mockSecretClient := mocks.NewMockSecretManagerClient(crtl)
+				secretPlugin := &SecretManagerSecretService{
+					client:    mockSecretClient,
+					projectId: "my-project",
+					cache:     map[string]string{"test-id": "projects/my-project/secrets/test-id"},
+				}
+
+				It("Should disable the secret version", func() {
+					defer crtl.Finish()
+
+					By("calling SecretManagerService.DisableSecretVersion with the full version name")
+					mockSecretClient.EXPECT().DisableSecretVersion(
+						gomock.Any(),
+						&secretmanagerpb.DisableSecretVersionRequest{
+							Name: "projects/my-project/secrets/test-id/versions/1",
+						},
+					).Return(&secretmanagerpb.SecretVersion{}, nil).Times(1)
+
+					_, err := secretPlugin.DisableVersion(context.TODO(), &secretpb.SecretDisableVersionRequest{
+						SecretVersion: &secretpb.SecretVersion{
+							Secret: &secretpb.Secret{
+								Name: "test-id",
+							},
+							Version: "1",
+						},
+					})
+
+					By("Not returning an error")
+					Expect(err).ShouldNot(HaveOccurred())
+				})
+			})
+
+			When("The secret version is destroyed", func() {
+				crtl := gomock.NewController(GinkgoT())
+				mockSecretClient := mocks.NewMockSecretManagerClient(crtl)
+				secretPlugin := &SecretManagerSecretService{
+					client:    mockSecretClient,
+					projectId: "my-project",
+					cache:     map[string]string{"test-id": "projects/my-project/secrets/test-id"},
+				}
+
+				It("Should return a failed precondition error", func() {
+					defer crtl.Finish()
+
+					mockSecretClient.EXPECT().DisableSecretVersion(
+						gomock.Any(),
+						gomock.Any(),
+					).Return(nil, status.Error(codes.FailedPrecondition, "version is destroyed")).Times(1)
+
+					response, err := secretPlugin.DisableVersion(context.TODO(), &secretpb.SecretDisableVersionRequest{
+						SecretVersion: &secretpb.SecretVersion{
+							Secret: &secretpb.Secret{
+								Name: "test-id",
+							},
+							Version: "1",
+						},
+					})
+
+					By("returning an error")
+					Expect(status.Code(err)).To(Equal(codes.FailedPrecondition))
+
+					By("returning a nil response")
+					Expect(response).Should(BeNil())
+				})
+			})
+		})
+	})
 })
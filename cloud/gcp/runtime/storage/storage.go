@@ -32,7 +32,9 @@ import (
 	"google.golang.org/api/iterator"
 	"google.golang.org/api/option"
 	"google.golang.org/grpc/codes"
+	"google.golang.org/protobuf/types/known/timestamppb"
 
+	base_storage "github.com/nitrictech/nitric/cloud/common/runtime/storage"
 	ifaces_gcloud_storage "github.com/nitrictech/nitric/cloud/gcp/ifaces/gcloud_storage"
 	grpc_errors "github.com/nitrictech/nitric/core/pkg/grpc/errors"
 	storagePb "github.com/nitrictech/nitric/core/pkg/proto/storage/v1"
@@ -154,6 +156,11 @@ func (s *StorageStorageService) Write(ctx context.Context, req *storagePb.Storag
 
 	writer := bucketHandle.Object(req.Key).NewWriter(ctx)
 
+	// the content type is detected from the written bytes when not provided
+	if req.ContentType != "" || req.CacheControl != "" || len(req.Metadata) > 0 {
+		writer.SetObjectAttrs(req.ContentType, req.CacheControl, req.Metadata)
+	}
+
 	if _, err := writer.Write(req.Body); err != nil {
 		if isPermissionDenied(err) {
 			return nil, newErr(
@@ -189,6 +196,140 @@ func (s *StorageStorageService) Write(ctx context.Context, req *storagePb.Storag
 	return &storagePb.StorageWriteResponse{}, nil
 }
 
+/**
+ * Streams a previously stored object, or a range of it, from a Google Cloud Storage Bucket
+ */
+func (s *StorageStorageService) ReadStream(req *storagePb.StorageReadStreamRequest, stream storagePb.Storage_ReadStreamServer) error {
+	newErr := grpc_errors.ErrorsWithScope("StorageStorageService.ReadStream")
+
+	if err := base_storage.ValidateRange(req); err != nil {
+		return newErr(codes.InvalidArgument, "invalid range", err)
+	}
+
+	bucketHandle, err := s.getBucketByName(req.BucketName)
+	if err != nil {
+		return newErr(
+			codes.NotFound,
+			"unable to locate bucket",
+			err,
+		)
+	}
+
+	// a negative length reads to the end of the object
+	length := req.Length
+	if length == 0 {
+		length = -1
+	}
+
+	reader, err := bucketHandle.Object(req.Key).NewRangeReader(stream.Context(), req.Offset, length)
+	if err != nil {
+		if isPermissionDenied(err) {
+			return newErr(
+				codes.PermissionDenied,
+				"unable to read file, have you requested access to this bucket?",
+				err,
+			)
+		}
+
+		var ee *googleapi.Error
+		if errors.As(err, &ee) && ee.Code == http.StatusRequestedRangeNotSatisfiable {
+			return newErr(
+				codes.OutOfRange,
+				"range is not satisfiable for this object",
+				err,
+			)
+		}
+
+		return newErr(
+			codes.Internal,
+			"unable to get reader for object",
+			err,
+		)
+	}
+	defer reader.Close()
+
+	if err := base_storage.SendChunks(stream, reader); err != nil {
+		return newErr(
+			codes.Internal,
+			"error streaming object",
+			err,
+		)
+	}
+
+	return nil
+}
+
+/**
+ * Stores a streamed Item in a Google Cloud Storage Bucket using a resumable upload
+ */
+func (s *StorageStorageService) WriteStream(stream storagePb.Storage_WriteStreamServer) error {
+	newErr := grpc_errors.ErrorsWithScope("StorageStorageService.WriteStream")
+
+	header, body, err := base_storage.ReceiveWriteStream(stream)
+	if err != nil {
+		return newErr(codes.InvalidArgument, "invalid write stream", err)
+	}
+
+	bucketHandle, err := s.getBucketByName(header.BucketName)
+	if err != nil {
+		return newErr(
+			codes.NotFound,
+			"unable to locate bucket",
+			err,
+		)
+	}
+
+	// cancelling the writer's context abandons the upload, so a failed stream never replaces the object
+	ctx, cancel := context.WithCancel(stream.Context())
+	defer cancel()
+
+	// object writers upload in chunks with a resumable session, so the object is never buffered in full
+	writer := bucketHandle.Object(header.Key).NewWriter(ctx)
+
+	if header.ContentType != "" || header.CacheControl != "" || len(header.Metadata) > 0 {
+		writer.SetObjectAttrs(header.ContentType, header.CacheControl, header.Metadata)
+	}
+
+	if _, err := io.Copy(writer, body); err != nil {
+		cancel()
+		_ = writer.Close()
+
+		if isPermissionDenied(err) {
+			return newErr(
+				codes.PermissionDenied,
+				"unable to write to file, have you requested access to this bucket?",
+				err,
+			)
+		}
+
+		return newErr(
+			codes.Internal,
+			"unable to write object",
+			err,
+		)
+	}
+
+	if err := writer.Close(); err != nil {
+		if isPermissionDenied(err) {
+			return newErr(
+				codes.PermissionDenied,
+				"unable to write to file, have you requested access to this bucket?",
+				err,
+			)
+		}
+
+		return newErr(
+			codes.Internal,
+			"error closing object write",
+			err,
+		)
+	}
+
+	return stream.SendAndClose(&storagePb.StorageWriteStreamResponse{
+		Size: body.Size(),
+	})
+}
+
 /**
  * Delete an Item in a Google Cloud Storage Bucket
  */
@@ -279,29 +420,94 @@ func (s *StorageStorageService) ListBlobs(ctx context.Context, req *storagePb.St
 	iter := bucketHandle.Objects(ctx, &storage.Query{
 		Projection: storage.ProjectionNoACL,
 		Prefix:     req.Prefix,
+		Delimiter:  req.Delimiter,
 	})
 
-	fis := make([]*storagePb.Blob, 0)
-	for {
+	if req.Limit > 0 || req.PageToken != "" {
+		iter.PageInfo().MaxSize = int(req.Limit)
+		iter.PageInfo().Token = req.PageToken
+	}
+
+	resp := &storagePb.StorageListBlobsResponse{
+		Blobs:    []*storagePb.Blob{},
+		Prefixes: []string{},
+	}
+
+	for count := 0; req.Limit == 0 || count < int(req.Limit); count++ {
 		obj, err := iter.Next()
 
 		if errors.Is(err, iterator.Done) {
-			break
+			return resp, nil
 		}
 		if err != nil {
 			return nil, newErr(codes.Internal, "error occurred iterating objects", err)
 		}
 
-		fis = append(fis, &storagePb.Blob{
-			Key: obj.Name,
-		})
+		// results grouped by the delimiter only have a prefix
+		if obj.Prefix != "" {
+			resp.Prefixes = append(resp.Prefixes, obj.Prefix)
+			continue
+		}
+
+		resp.Blobs = append(resp.Blobs, blobFromAttrs(obj))
 	}
 
-	return &storagePb.StorageListBlobsResponse{
-		Blobs: fis,
+	// the page has been consumed, so the iterator's token is the start of the next page
+	resp.NextPageToken = iter.PageInfo().Token
+
+	return resp, nil
+}
+
+/**
+ * Retrieves the details of an object in a Google Cloud Storage Bucket, without its contents
+ */
+func (s *StorageStorageService) Stat(ctx context.Context, req *storagePb.StorageStatRequest) (*storagePb.StorageStatResponse, error) {
+	newErr := grpc_errors.ErrorsWithScope("StorageStorageService.Stat")
+
+	bucketHandle, err := s.getBucketByName(req.BucketName)
+	if err != nil {
+		return nil, newErr(
+			codes.NotFound,
+			"unable to locate bucket",
+			err,
+		)
+	}
+
+	attrs, err := bucketHandle.Object(req.Key).Attrs(ctx)
+	if err != nil {
+		if errors.Is(err, storage.ErrObjectNotExist) {
+			return nil, newErr(codes.NotFound, fmt.Sprintf("object %s not found", req.Key), err)
+		}
+
+		if isPermissionDenied(err) {
+			return nil, newErr(
+				codes.PermissionDenied,
+				"unable to read file details, have you requested access to this bucket?",
+				err,
+			)
+		}
+
+		return nil, newErr(codes.Internal, "error calling object.Attrs", err)
+	}
+
+	return &storagePb.StorageStatResponse{
+		Blob: blobFromAttrs(attrs),
 	}, nil
 }
 
+// blobFromAttrs converts the attributes of an object to a blob
+func blobFromAttrs(attrs *storage.ObjectAttrs) *storagePb.Blob {
+	return &storagePb.Blob{
+		Key:          attrs.Name,
+		Size:         attrs.Size,
+		Etag:         attrs.Etag,
+		LastModified: timestamppb.New(attrs.Updated),
+		ContentType:  attrs.ContentType,
+		CacheControl: attrs.CacheControl,
+		Metadata:     attrs.Metadata,
+	}
+}
+
 func (s *StorageStorageService) Exists(ctx context.Context, req *storagePb.StorageExistsRequest) (*storagePb.StorageExistsResponse, error) {
 	newErr := grpc_errors.ErrorsWithScope("StorageStorageService.Exists")
 
@@ -20,6 +20,7 @@ import (
 	"fmt"
 	"io"
 	"os"
+	"strings"
 	"time"
 
 	"cloud.google.com/go/storage"
@@ -28,8 +29,11 @@ import (
 	. "github.com/onsi/gomega"
 	"google.golang.org/api/googleapi"
 	"google.golang.org/api/iterator"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 	"google.golang.org/protobuf/types/known/durationpb"
 
+	"github.com/nitrictech/nitric/cloud/common/runtime/storage/storagetest"
 	storage_mock "github.com/nitrictech/nitric/cloud/gcp/mocks/gcp_storage"
 	storage_service "github.com/nitrictech/nitric/cloud/gcp/runtime/storage"
 	storagePb "github.com/nitrictech/nitric/core/pkg/proto/storage/v1"
@@ -684,6 +688,185 @@ var _ = Describe("Storage", func() {
 		})
 	})
 
+	Context("ListFiles with a delimiter and limit", func() {
+		When("GCloud Storage Backend is available", func() {
+			ctrl := gomock.NewController(GinkgoT())
+			mockStorageClient := storage_mock.NewMockStorageClient(ctrl)
+			mockBucketIterator := storage_mock.NewMockBucketIterator(ctrl)
+			mockBucket := storage_mock.NewMockBucketHandle(ctrl)
+			mockObjectIterator := storage_mock.NewMockObjectIterator(ctrl)
+			storagePlugin, _ := storage_service.NewWithClient(mockStorageClient)
+
+			It("should return a single page of files and prefixes", func() {
+				By("the bucket existing")
+				gomock.InOrder(
+					mockBucketIterator.EXPECT().Next().Return(&storage.BucketAttrs{
+						Labels: map[string]string{
+							"x-nitric-test-stack-name": "test-bucket",
+							"x-nitric-test-stack-type": "bucket",
+						},
+						Name: "my-bucket-1234",
+					}, nil),
+					mockBucketIterator.EXPECT().Next().Return(nil, iterator.Done),
+				)
+				mockStorageClient.EXPECT().Buckets(gomock.Any(), gomock.Any()).Return(mockBucketIterator)
+				mockStorageClient.EXPECT().Bucket("my-bucket-1234").Return(mockBucket)
+
+				By("querying with the delimiter")
+				mockBucket.EXPECT().Objects(gomock.Any(), &storage.Query{
+					Projection: storage.ProjectionNoACL,
+					Delimiter:  "/",
+				}).Return(mockObjectIterator)
+
+				By("starting from the requested page")
+				pageInfo := &iterator.PageInfo{}
+				mockObjectIterator.EXPECT().PageInfo().AnyTimes().Return(pageInfo)
+
+				updated := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+				gomock.InOrder(
+					mockObjectIterator.EXPECT().Next().DoAndReturn(func() (*storage.ObjectAttrs, error) {
+						Expect(pageInfo.MaxSize).To(Equal(2))
+						Expect(pageInfo.Token).To(Equal("page-1"))
+
+						// the iterator replaces the token with the next page's once a page is fetched
+						pageInfo.Token = "page-2"
+
+						return &storage.ObjectAttrs{
+							Name:        "test.txt",
+							Size:        4,
+							Etag:        "abc123",
+							Updated:     updated,
+							ContentType: "text/plain",
+							Metadata:    map[string]string{"owner": "test"},
+						}, nil
+					}),
+					mockObjectIterator.EXPECT().Next().Return(&storage.ObjectAttrs{
+						Prefix: "images/",
+					}, nil),
+				)
+
+				resp, err := storagePlugin.ListBlobs(context.TODO(), &storagePb.StorageListBlobsRequest{
+					BucketName: "test-bucket",
+					Delimiter:  "/",
+					Limit:      2,
+					PageToken:  "page-1",
+				})
+
+				By("not returning an error")
+				Expect(err).ShouldNot(HaveOccurred())
+
+				By("returning the file details")
+				Expect(resp.Blobs).To(HaveLen(1))
+				Expect(resp.Blobs[0].Key).To(Equal("test.txt"))
+				Expect(resp.Blobs[0].Size).To(Equal(int64(4)))
+				Expect(resp.Blobs[0].LastModified.AsTime()).To(Equal(updated))
+				Expect(resp.Blobs[0].Metadata).To(Equal(map[string]string{"owner": "test"}))
+
+				By("returning the common prefixes")
+				Expect(resp.Prefixes).To(Equal([]string{"images/"}))
+
+				By("returning the next page token")
+				Expect(resp.NextPageToken).To(Equal("page-2"))
+
+				ctrl.Finish()
+			})
+		})
+	})
+
+	Context("Stat", func() {
+		When("The item exists", func() {
+			ctrl := gomock.NewController(GinkgoT())
+			mockStorageClient := storage_mock.NewMockStorageClient(ctrl)
+			mockBucketIterator := storage_mock.NewMockBucketIterator(ctrl)
+			mockBucket := storage_mock.NewMockBucketHandle(ctrl)
+			mockObject := storage_mock.NewMockObjectHandle(ctrl)
+			storagePlugin, _ := storage_service.NewWithClient(mockStorageClient)
+
+			It("should return the object details", func() {
+				By("the bucket existing")
+				gomock.InOrder(
+					mockBucketIterator.EXPECT().Next().Return(&storage.BucketAttrs{
+						Labels: map[string]string{
+							"x-nitric-test-stack-name": "test-bucket",
+							"x-nitric-test-stack-type": "bucket",
+						},
+						Name: "my-bucket-1234",
+					}, nil),
+					mockBucketIterator.EXPECT().Next().Return(nil, iterator.Done),
+				)
+				mockStorageClient.EXPECT().Buckets(gomock.Any(), gomock.Any()).Return(mockBucketIterator)
+				mockStorageClient.EXPECT().Bucket("my-bucket-1234").Return(mockBucket)
+
+				By("the object reference being valid")
+				mockBucket.EXPECT().Object("test-key").Return(mockObject)
+
+				By("returning object attributes")
+				mockObject.EXPECT().Attrs(gomock.Any()).Times(1).Return(&storage.ObjectAttrs{
+					Name:         "test-key",
+					Size:         1024,
+					ContentType:  "image/png",
+					CacheControl: "max-age=60",
+				}, nil)
+
+				resp, err := storagePlugin.Stat(context.TODO(), &storagePb.StorageStatRequest{
+					BucketName: "test-bucket",
+					Key:        "test-key",
+				})
+
+				By("Not returning an error")
+				Expect(err).ShouldNot(HaveOccurred())
+
+				By("Returning the object details")
+				Expect(resp.Blob.Key).To(Equal("test-key"))
+				Expect(resp.Blob.Size).To(Equal(int64(1024)))
+				Expect(resp.Blob.ContentType).To(Equal("image/png"))
+				Expect(resp.Blob.CacheControl).To(Equal("max-age=60"))
+
+				ctrl.Finish()
+			})
+		})
+
+		When("The item doesn't exist", func() {
+			ctrl := gomock.NewController(GinkgoT())
+			mockStorageClient := storage_mock.NewMockStorageClient(ctrl)
+			mockBucketIterator := storage_mock.NewMockBucketIterator(ctrl)
+			mockBucket := storage_mock.NewMockBucketHandle(ctrl)
+			mockObject := storage_mock.NewMockObjectHandle(ctrl)
+			storagePlugin, _ := storage_service.NewWithClient(mockStorageClient)
+
+			It("should return a not found error", func() {
+				By("the bucket existing")
+				gomock.InOrder(
+					mockBucketIterator.EXPECT().Next().Return(&storage.BucketAttrs{
+						Labels: map[string]string{
+							"x-nitric-test-stack-name": "test-bucket",
+							"x-nitric-test-stack-type": "bucket",
+						},
+						Name: "my-bucket-1234",
+					}, nil),
+					mockBucketIterator.EXPECT().Next().Return(nil, iterator.Done),
+				)
+				mockStorageClient.EXPECT().Buckets(gomock.Any(), gomock.Any()).Return(mockBucketIterator)
+				mockStorageClient.EXPECT().Bucket("my-bucket-1234").Return(mockBucket)
+
+				By("the object reference being valid")
+				mockBucket.EXPECT().Object("test-key").Return(mockObject)
+
+				By("the object not existing")
+				mockObject.EXPECT().Attrs(gomock.Any()).Times(1).Return(nil, storage.ErrObjectNotExist)
+
+				_, err := storagePlugin.Stat(context.TODO(), &storagePb.StorageStatRequest{
+					BucketName: "test-bucket",
+					Key:        "test-key",
+				})
+
+				Expect(status.Code(err)).To(Equal(codes.NotFound))
+
+				ctrl.Finish()
+			})
+		})
+	})
+
 	Context("Exists", func() {
 		When("The bucket exists", func() {
 			When("The item exists", func() {
@@ -819,4 +1002,110 @@ var _ = Describe("Storage", func() {
 			})
 		})
 	})
+
+	Context("ReadStream", func() {
+		When("GCloud Storage Backend is available", func() {
+			When("Reading to the end of an object from an offset", func() {
+				ctrl := gomock.NewController(GinkgoT())
+				mockStorageClient := storage_mock.NewMockStorageClient(ctrl)
+				mockBucketIterator := storage_mock.NewMockBucketIterator(ctrl)
+				mockBucket := storage_mock.NewMockBucketHandle(ctrl)
+				mockObject := storage_mock.NewMockObjectHandle(ctrl)
+				mockStorageServer, _ := storage_service.NewWithClient(mockStorageClient)
+
+				It("Should stream the object from the offset", func() {
+					By("The bucket existing")
+					gomock.InOrder(
+						mockBucketIterator.EXPECT().Next().Return(&storage.BucketAttrs{
+							Labels: map[string]string{
+								"x-nitric-test-stack-name": "my-bucket",
+								"x-nitric-test-stack-type": "bucket",
+							},
+							Name: "my-bucket-1234",
+						}, nil),
+						mockBucketIterator.EXPECT().Next().Return(nil, iterator.Done),
+					)
+					mockStorageClient.EXPECT().Buckets(gomock.Any(), gomock.Any()).Return(mockBucketIterator)
+					mockStorageClient.EXPECT().Bucket("my-bucket-1234").Return(mockBucket)
+
+					By("The object reference being correct")
+					mockBucket.EXPECT().Object("test-file").Return(mockObject)
+
+					By("Reading from the offset to the end of the object")
+					mockObject.EXPECT().NewRangeReader(gomock.Any(), int64(2), int64(-1)).Return(io.NopCloser(strings.NewReader("st")), nil)
+
+					stream := &storagetest.ReadStream{}
+					err := mockStorageServer.ReadStream(&storagePb.StorageReadStreamRequest{
+						BucketName: "my-bucket",
+						Key:        "test-file",
+						Offset:     2,
+					}, stream)
+
+					By("Not returning an error")
+					Expect(err).ShouldNot(HaveOccurred())
+
+					By("Streaming the object")
+					Expect(stream.Body.String()).To(Equal("st"))
+
+					ctrl.Finish()
+				})
+			})
+		})
+	})
+
+	Context("WriteStream", func() {
+		When("GCloud Storage Backend is available", func() {
+			When("Writing to a bucket that exists", func() {
+				ctrl := gomock.NewController(GinkgoT())
+				mockStorageClient := storage_mock.NewMockStorageClient(ctrl)
+				mockBucketIterator := storage_mock.NewMockBucketIterator(ctrl)
+				mockBucket := storage_mock.NewMockBucketHandle(ctrl)
+				mockObject := storage_mock.NewMockObjectHandle(ctrl)
+				mockWriter := storage_mock.NewMockWriter(ctrl)
+				mockStorageServer, _ := storage_service.NewWithClient(mockStorageClient)
+
+				It("Should store the chunks in order", func() {
+					By("The bucket existing")
+					gomock.InOrder(
+						mockBucketIterator.EXPECT().Next().Return(&storage.BucketAttrs{
+							Labels: map[string]string{
+								"x-nitric-test-stack-name": "my-bucket",
+								"x-nitric-test-stack-type": "bucket",
+							},
+							Name: "my-bucket-1234",
+						}, nil),
+						mockBucketIterator.EXPECT().Next().Return(nil, iterator.Done),
+					)
+					mockStorageClient.EXPECT().Buckets(gomock.Any(), gomock.Any()).Return(mockBucketIterator)
+					mockStorageClient.EXPECT().Bucket("my-bucket-1234").Return(mockBucket)
+
+					By("The object reference being correct")
+					mockBucket.EXPECT().Object("test-file").Return(mockObject)
+
+					By("The writer being called on the object handle")
+					mockObject.EXPECT().NewWriter(gomock.Any()).Return(mockWriter)
+
+					By("The bytes being written")
+					written := []byte{}
+					mockWriter.EXPECT().Write(gomock.Any()).AnyTimes().DoAndReturn(func(p []byte) (int, error) {
+						written = append(written, p...)
+						return len(p), nil
+					})
+					mockWriter.EXPECT().Close().Times(1)
+
+					stream := storagetest.NewWriteStream("my-bucket", "test-file", []byte("Te"), []byte("st"))
+					err := mockStorageServer.WriteStream(stream)
+
+					By("Not returning an error")
+					Expect(err).ShouldNot(HaveOccurred())
+					Expect(written).To(Equal([]byte("Test")))
+
+					By("Returning the size of the object")
+					Expect(stream.Response.Size).To(Equal(int64(4)))
+
+					ctrl.Finish()
+				})
+			})
+		})
+	})
 })
@@ -19,6 +19,7 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"slices"
 	"sync"
 	"time"
 
@@ -28,6 +29,7 @@ import (
 	"google.golang.org/protobuf/proto"
 
 	"github.com/nitrictech/nitric/cloud/common/deploy/tags"
+	"github.com/nitrictech/nitric/cloud/common/runtime/messages"
 
 	cloudtasks "cloud.google.com/go/cloudtasks/apiv2"
 	tasks "cloud.google.com/go/cloudtasks/apiv2/cloudtaskspb"
@@ -47,6 +49,12 @@ import (
 	topicpb "github.com/nitrictech/nitric/core/pkg/proto/topics/v1"
 )
 
+// the attribute identifying the source topic of a message
+const topicAttribute = "x-nitric-topic"
+
+// W3C trace context continues the trace in subscribers, the cloud trace format is kept for Cloud Trace
+var tracePropagator = propagation.NewCompositeTextMapPropagator(propagation.TraceContext{}, propagator.CloudTraceFormatPropagator{})
+
 type PubsubEventService struct {
 	resource.GcpResourceResolver
 	client      ifaces_pubsub.PubsubClient
@@ -102,16 +110,37 @@ type httpPubsubMessages struct {
 	Messages []httpPubsubMessage `json:"messages"`
 }
 
-func (s *PubsubEventService) publish(ctx context.Context, topic string, pubsubMsg *pubsub.Message) error {
+// messageAttributes returns the pubsub attributes of a message, its own attributes along with the source topic and trace context
+func messageAttributes(ctx context.Context, topic string, message *topicpb.TopicMessage) (map[string]string, error) {
+	// allows ctx to include the name of the source topic.
+	attributes := propagation.MapCarrier{
+		topicAttribute:             topic,
+		messages.EncodingAttribute: messages.NitricEncoding,
+	}
+
+	// the message's own attributes are also set on the pubsub message, so subscription filters can match them
+	reserved := append([]string{topicAttribute, messages.EncodingAttribute}, tracePropagator.Fields()...)
+	for k, v := range message.GetAttributes() {
+		if slices.Contains(reserved, k) {
+			return nil, fmt.Errorf("attribute %s is reserved", k)
+		}
+
+		attributes[k] = v
+	}
+
+	tracePropagator.Inject(ctx, attributes)
+
+	return attributes, nil
+}
+
+func (s *PubsubEventService) publish(ctx context.Context, topic string, pubsubMsg *pubsub.Message) (string, error) {
 	msg := ifaces_pubsub.AdaptPubsubMessage(pubsubMsg)
 	pubsubTopic, err := s.getPubsubTopicFromName(topic)
 	if err != nil {
-		return err
+		return "", err
 	}
 
-	_, err = pubsubTopic.Publish(ctx, msg).Get(ctx)
-
-	return err
+	return pubsubTopic.Publish(ctx, msg).Get(ctx)
 }
 
 func (s *PubsubEventService) publishDelayed(ctx context.Context, topic string, delay time.Duration, pubsubMsg *pubsub.Message) error {
@@ -176,22 +205,22 @@ func (s *PubsubEventService) Publish(ctx context.Context, req *topicpb.TopicPubl
 		)
 	}
 
-	// allows ctx to include the name of the source topic.
-	attributes := propagation.MapCarrier{
-		"x-nitric-topic": req.TopicName,
+	attributes, err := messageAttributes(ctx, req.TopicName, req.Message)
+	if err != nil {
+		return nil, newErr(codes.InvalidArgument, "invalid message attributes", err)
 	}
 
-	propagator.CloudTraceFormatPropagator{}.Inject(ctx, attributes)
-
 	pubsubMsg := &pubsub.Message{
 		Attributes: attributes,
 		Data:       messageBytes,
 	}
 
+	// delayed messages are published by cloud tasks, so they don't have an ID yet
+	messageId := ""
 	if delay > 0 {
 		err = s.publishDelayed(ctx, req.TopicName, delay, pubsubMsg)
 	} else {
-		err = s.publish(ctx, req.TopicName, pubsubMsg)
+		messageId, err = s.publish(ctx, req.TopicName, pubsubMsg)
 	}
 
 	if err != nil {
@@ -210,7 +239,82 @@ func (s *PubsubEventService) Publish(ctx context.Context, req *topicpb.TopicPubl
 		)
 	}
 
-	return &topicpb.TopicPublishResponse{}, nil
+	return &topicpb.TopicPublishResponse{
+		MessageId: messageId,
+	}, nil
+}
+
+func failedPublishResult(err string) *topicpb.TopicPublishResult {
+	return &topicpb.TopicPublishResult{
+		Result: &topicpb.TopicPublishResult_Error{
+			Error: err,
+		},
+	}
+}
+
+// PublishBatch publishes messages to a given topic, the pubsub client batches them before they're sent
+func (s *PubsubEventService) PublishBatch(ctx context.Context, req *topicpb.TopicPublishBatchRequest) (*topicpb.TopicPublishBatchResponse, error) {
+	newErr := grpc_errors.ErrorsWithScope("PubsubEventService.PublishBatch")
+
+	pubsubTopic, err := s.getPubsubTopicFromName(req.TopicName)
+	if err != nil {
+		return nil, newErr(codes.NotFound, fmt.Sprintf("unable to find topic %s", req.TopicName), err)
+	}
+
+	results := make([]*topicpb.TopicPublishResult, len(req.Messages))
+	publishResults := make([]ifaces_pubsub.PublishResult, len(req.Messages))
+
+	// publish every message before waiting for any of them, so they can be sent together
+	for i, message := range req.Messages {
+		messageBytes, err := proto.Marshal(message)
+		if err != nil {
+			return nil, newErr(
+				codes.Unknown,
+				fmt.Sprintf("unable to serialize message. %s", help.BugInNitricHelpText()),
+				err,
+			)
+		}
+
+		attributes, err := messageAttributes(ctx, req.TopicName, message)
+		if err != nil {
+			results[i] = failedPublishResult(fmt.Sprintf("invalid message attributes: %s", err.Error()))
+			continue
+		}
+
+		publishResults[i] = pubsubTopic.Publish(ctx, ifaces_pubsub.AdaptPubsubMessage(&pubsub.Message{
+			Attributes: attributes,
+			Data:       messageBytes,
+		}))
+	}
+
+	for i, publishResult := range publishResults {
+		if publishResult == nil {
+			continue
+		}
+
+		messageId, err := publishResult.Get(ctx)
+		if err != nil {
+			// report each failure against its own message, as earlier messages may already have been published
+			errStatus, _ := status.FromError(err)
+			if errStatus.Code() == grpccodes.PermissionDenied {
+				results[i] = failedPublishResult(fmt.Sprintf("permission denied, have you requested access to this topic?: %s", err.Error()))
+				continue
+			}
+
+			results[i] = failedPublishResult(err.Error())
+			continue
+		}
+
+		results[i] = &topicpb.TopicPublishResult{
+			Result: &topicpb.TopicPublishResult_MessageId{
+				MessageId: messageId,
+			},
+		}
+	}
+
+	return &topicpb.TopicPublishBatchResponse{
+		Results: results,
+	}, nil
 }
 
 func New(provider resource.GcpResourceResolver) (topicpb.TopicsServer, error) {
@@ -31,6 +31,7 @@ import (
 	"google.golang.org/protobuf/types/known/durationpb"
 	"google.golang.org/protobuf/types/known/structpb"
 
+	ifaces_pubsub "github.com/nitrictech/nitric/cloud/gcp/ifaces/pubsub"
 	mock_cloudtasks "github.com/nitrictech/nitric/cloud/gcp/mocks/cloudtasks"
 	mock_core "github.com/nitrictech/nitric/cloud/gcp/mocks/provider"
 	mock_pubsub "github.com/nitrictech/nitric/cloud/gcp/mocks/pubsub"
@@ -85,6 +86,72 @@ var _ = Describe("Pubsub Plugin", func() {
 			})
 		})
 
+		When("The message has attributes", func() {
+			ctrl := gomock.NewController(GinkgoT())
+			pubsubClient := mock_pubsub.NewMockPubsubClient(ctrl)
+			mockTopic := mock_pubsub.NewMockTopic(ctrl)
+			mockIterator := mock_pubsub.NewMockTopicIterator(ctrl)
+			mockPublishResult := mock_pubsub.NewMockPublishResult(ctrl)
+			pubsubPlugin, _ := pubsub_service.NewWithClient(nil, pubsubClient, nil)
+
+			It("should publish the attributes with the message", func() {
+				By("the publish being successful")
+				mockPublishResult.EXPECT().Get(gomock.Any()).Return("mock-server", nil)
+
+				By("the topic existing")
+				pubsubClient.EXPECT().Topics(gomock.Any()).Return(mockIterator)
+				gomock.InOrder(
+					mockIterator.EXPECT().Next().Return(mockTopic, nil),
+					mockIterator.EXPECT().Next().Return(nil, iterator.Done),
+				)
+
+				var publishedAttributes map[string]string
+				mockTopic.EXPECT().Publish(gomock.Any(), gomock.Any()).DoAndReturn(func(ctx context.Context, msg ifaces_pubsub.Message) ifaces_pubsub.PublishResult {
+					publishedAttributes = msg.Attributes()
+					return mockPublishResult
+				})
+
+				mockTopic.EXPECT().Labels(gomock.Any()).Return(map[string]string{
+					"x-nitric-test-stack-name": "Test",
+					"x-nitric-test-stack-type": "topic",
+				}, nil)
+
+				_, err := pubsubPlugin.Publish(context.TODO(), &topicpb.TopicPublishRequest{
+					TopicName: "Test",
+					Message: &topicpb.TopicMessage{
+						Content:    message.Content,
+						Attributes: map[string]string{"region": "us"},
+					},
+					Delay: durationpb.New(0),
+				})
+				Expect(err).ShouldNot(HaveOccurred())
+
+				By("setting the message attributes on the pubsub message")
+				Expect(publishedAttributes).To(HaveKeyWithValue("region", "us"))
+				Expect(publishedAttributes).To(HaveKeyWithValue("x-nitric-topic", "Test"))
+				Expect(publishedAttributes).To(HaveKeyWithValue("x-nitric-encoding", "protobuf"))
+			})
+		})
+
+		When("The message has a reserved attribute", func() {
+			ctrl := gomock.NewController(GinkgoT())
+			pubsubClient := mock_pubsub.NewMockPubsubClient(ctrl)
+			pubsubPlugin, _ := pubsub_service.NewWithClient(nil, pubsubClient, nil)
+
+			It("should return an invalid argument error", func() {
+				_, err := pubsubPlugin.Publish(context.TODO(), &topicpb.TopicPublishRequest{
+					TopicName: "Test",
+					Message: &topicpb.TopicMessage{
+						Content:    message.Content,
+						Attributes: map[string]string{"x-nitric-topic": "Other"},
+					},
+					Delay: durationpb.New(0),
+				})
+				Expect(err).Should(HaveOccurred())
+				Expect(status.Code(err)).To(Equal(codes.InvalidArgument))
+			})
+		})
+
 		When("There are insufficient permissions", func() {
 			ctrl := gomock.NewController(GinkgoT())
 			pubsubClient := mock_pubsub.NewMockPubsubClient(ctrl)
@@ -121,6 +188,103 @@ var _ = Describe("Pubsub Plugin", func() {
 		})
 	})
 
+	When("Publishing a batch of messages", func() {
+		payload, _ := structpb.NewStruct(map[string]interface{}{"Test": "test"})
+
+		When("To a topic that does exist", func() {
+			ctrl := gomock.NewController(GinkgoT())
+			pubsubClient := mock_pubsub.NewMockPubsubClient(ctrl)
+			mockTopic := mock_pubsub.NewMockTopic(ctrl)
+			mockIterator := mock_pubsub.NewMockTopicIterator(ctrl)
+			mockPublishResult := mock_pubsub.NewMockPublishResult(ctrl)
+			pubsubPlugin, _ := pubsub_service.NewWithClient(nil, pubsubClient, nil)
+
+			It("should return a result for each message", func() {
+				By("the topic existing")
+				pubsubClient.EXPECT().Topics(gomock.Any()).Return(mockIterator)
+				gomock.InOrder(
+					mockIterator.EXPECT().Next().Return(mockTopic, nil),
+					mockIterator.EXPECT().Next().Return(nil, iterator.Done),
+				)
+				mockTopic.EXPECT().Labels(gomock.Any()).Return(map[string]string{
+					"x-nitric-test-stack-name": "Test",
+					"x-nitric-test-stack-type": "topic",
+				}, nil)
+
+				By("only the valid message being published")
+				mockTopic.EXPECT().Publish(gomock.Any(), gomock.Any()).Return(mockPublishResult).Times(1)
+				mockPublishResult.EXPECT().Get(gomock.Any()).Return("mock-message-id", nil).Times(1)
+
+				resp, err := pubsubPlugin.PublishBatch(context.TODO(), &topicpb.TopicPublishBatchRequest{
+					TopicName: "Test",
+					Messages: []*topicpb.TopicMessage{
+						{
+							Content: &topicpb.TopicMessage_StructPayload{StructPayload: payload},
+						},
+						{
+							Content:    &topicpb.TopicMessage_StructPayload{StructPayload: payload},
+							Attributes: map[string]string{"x-nitric-topic": "Other"},
+						},
+					},
+				})
+				Expect(err).ShouldNot(HaveOccurred())
+
+				By("returning the results in the order of the messages")
+				Expect(resp.Results).To(HaveLen(2))
+				Expect(resp.Results[0].GetMessageId()).To(Equal("mock-message-id"))
+				Expect(resp.Results[1].GetError()).To(ContainSubstring("reserved"))
+			})
+		})
+
+		When("Some messages are denied", func() {
+			ctrl := gomock.NewController(GinkgoT())
+			pubsubClient := mock_pubsub.NewMockPubsubClient(ctrl)
+			mockTopic := mock_pubsub.NewMockTopic(ctrl)
+			mockIterator := mock_pubsub.NewMockTopicIterator(ctrl)
+			publishedResult := mock_pubsub.NewMockPublishResult(ctrl)
+			deniedResult := mock_pubsub.NewMockPublishResult(ctrl)
+			pubsubPlugin, _ := pubsub_service.NewWithClient(nil, pubsubClient, nil)
+
+			It("should return the results of the messages that were published", func() {
+				By("the topic existing")
+				pubsubClient.EXPECT().Topics(gomock.Any()).Return(mockIterator)
+				gomock.InOrder(
+					mockIterator.EXPECT().Next().Return(mockTopic, nil),
+					mockIterator.EXPECT().Next().Return(nil, iterator.Done),
+				)
+				mockTopic.EXPECT().Labels(gomock.Any()).Return(map[string]string{
+					"x-nitric-test-stack-name": "Test",
+					"x-nitric-test-stack-type": "topic",
+				}, nil)
+
+				By("the second message being denied")
+				gomock.InOrder(
+					mockTopic.EXPECT().Publish(gomock.Any(), gomock.Any()).Return(publishedResult),
+					mockTopic.EXPECT().Publish(gomock.Any(), gomock.Any()).Return(deniedResult),
+				)
+				publishedResult.EXPECT().Get(gomock.Any()).Return("mock-message-id", nil).Times(1)
+				deniedResult.EXPECT().Get(gomock.Any()).Return("", status.Error(codes.PermissionDenied, "insufficient permissions")).Times(1)
+
+				resp, err := pubsubPlugin.PublishBatch(context.TODO(), &topicpb.TopicPublishBatchRequest{
+					TopicName: "Test",
+					Messages: []*topicpb.TopicMessage{
+						{
+							Content: &topicpb.TopicMessage_StructPayload{StructPayload: payload},
+						},
+						{
+							Content: &topicpb.TopicMessage_StructPayload{StructPayload: payload},
+						},
+					},
+				})
+				Expect(err).ShouldNot(HaveOccurred())
+
+				Expect(resp.Results).To(HaveLen(2))
+				Expect(resp.Results[0].GetMessageId()).To(Equal("mock-message-id"))
+				Expect(resp.Results[1].GetError()).To(ContainSubstring("permission denied"))
+			})
+		})
+	})
+
 	When("Publishing Delayed Messages", func() {
 		payload, _ := structpb.NewStruct(map[string]interface{}{"Test": "test"})
 		message := &topicpb.TopicMessage{
@@ -174,7 +338,7 @@ var _ = Describe("Pubsub Plugin", func() {
 						},
 						HttpMethod: tasks.HttpMethod_POST,
 						Url:        fmt.Sprintf("https://pubsub.googleapis.com/v1/%s:publish", "test"),
-						Body:       []byte("{\"messages\":[{\"attributes\":{\"x-cloud-trace-context\":\"00000000000000000000000000000000/0;o=0\",\"x-nitric-topic\":\"Test\"},\"data\":\"ChAKDgoEVGVzdBIGGgR0ZXN0\"}]}"),
+						Body:       []byte("{\"messages\":[{\"attributes\":{\"x-cloud-trace-context\":\"00000000000000000000000000000000/0;o=0\",\"x-nitric-encoding\":\"protobuf\",\"x-nitric-topic\":\"Test\"},\"data\":\"ChAKDgoEVGVzdBIGGgR0ZXN0\"}]}"),
 					}
 
 					if !proto.Equal(actual.Task.GetHttpRequest(), httpRequest) {
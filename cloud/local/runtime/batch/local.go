@@ -0,0 +1,70 @@
+// Copyright 2021 Nitric Technologies Pty Ltd.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package batch
+
+import (
+	"context"
+
+	"google.golang.org/grpc/codes"
+
+	grpc_errors "github.com/nitrictech/nitric/core/pkg/grpc/errors"
+	"github.com/nitrictech/nitric/core/pkg/logger"
+	batchpb "github.com/nitrictech/nitric/core/pkg/proto/batch/v1"
+	"github.com/nitrictech/nitric/core/pkg/workers/jobs"
+)
+
+// LocalBatchService - runs submitted jobs on job handlers registered with this membrane
+type LocalBatchService struct {
+	batchpb.UnimplementedBatchServer
+	handlers jobs.JobRequestHandler
+}
+
+var _ batchpb.BatchServer = &LocalBatchService{}
+
+func (s *LocalBatchService) SubmitJob(ctx context.Context, req *batchpb.JobSubmitRequest) (*batchpb.JobSubmitResponse, error) {
+	newErr := grpc_errors.ErrorsWithScope("LocalBatchService.SubmitJob")
+
+	if req.JobName == "" {
+		return nil, newErr(codes.InvalidArgument, "job name must not be empty", nil)
+	}
+
+	go func() {
+		resp, err := s.handlers.HandleJobRequest(context.Background(), &batchpb.ServerMessage{
+			Content: &batchpb.ServerMessage_JobRequest{
+				JobRequest: &batchpb.JobRequest{
+					JobName: req.JobName,
+					Data:    req.Data,
+				},
+			},
+		})
+		if err != nil {
+			logger.Errorf("job %s could not be run: %v", req.JobName, err)
+			return
+		}
+
+		if !resp.GetJobResponse().GetSuccess() {
+			logger.Errorf("job %s failed", req.JobName)
+		}
+	}()
+
+	return &batchpb.JobSubmitResponse{}, nil
+}
+
+// New - create a new batch service that runs jobs on the given job handler
+func New(handlers jobs.JobRequestHandler) *LocalBatchService {
+	return &LocalBatchService{
+		handlers: handlers,
+	}
+}
@@ -0,0 +1,29 @@
+// Copyright 2021 Nitric Technologies Pty Ltd.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package env
+
+import "github.com/nitrictech/nitric/core/pkg/env"
+
+// NITRIC_LOCAL_DATA_DIR - Directory used to persist buckets, key value stores and secrets between runs
+var NITRIC_LOCAL_DATA_DIR = env.GetEnv("NITRIC_LOCAL_DATA_DIR", "./.nitric/run")
+
+// NITRIC_LOCAL_GATEWAY_URL - Externally reachable URL of the local gateway, used when generating pre-signed URLs
+var NITRIC_LOCAL_GATEWAY_URL = env.GetEnv("NITRIC_LOCAL_GATEWAY_URL", "")
+
+// NITRIC_LOCAL_QUEUE_LEASE_SECONDS - The time a dequeued message is leased to a consumer before it becomes visible again
+var NITRIC_LOCAL_QUEUE_LEASE_SECONDS = env.GetEnv("NITRIC_LOCAL_QUEUE_LEASE_SECONDS", "30")
+
+// NITRIC_DATABASE_BASE_URL - Base connection string for a locally running database server
+var NITRIC_DATABASE_BASE_URL = env.GetEnv("NITRIC_DATABASE_BASE_URL", "")
@@ -0,0 +1,229 @@
+// Copyright 2021 Nitric Technologies Pty Ltd.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// The local HTTP gateway plugin
+package gateway
+
+import (
+	"bytes"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/fasthttp/router"
+	"github.com/valyala/fasthttp"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/structpb"
+
+	base_http "github.com/nitrictech/nitric/cloud/common/runtime/gateway"
+	"github.com/nitrictech/nitric/cloud/local/runtime/storage"
+	"github.com/nitrictech/nitric/core/pkg/gateway"
+	"github.com/nitrictech/nitric/core/pkg/logger"
+	schedulespb "github.com/nitrictech/nitric/core/pkg/proto/schedules/v1"
+	storagepb "github.com/nitrictech/nitric/core/pkg/proto/storage/v1"
+	topicspb "github.com/nitrictech/nitric/core/pkg/proto/topics/v1"
+)
+
+type localMiddleware struct {
+	storage *storage.FilesystemStorageService
+}
+
+// jsonContentTypes - request bodies with these content types are delivered as JSON, form encoded bodies are included as it's the default of curl
+var jsonContentTypes = []string{"", "application/json", "application/x-www-form-urlencoded"}
+
+// subscriptionMessage - converts a request body to a topic message, bodies with other content types are delivered as bytes payloads
+func subscriptionMessage(ctx *fasthttp.RequestCtx) (*topicspb.TopicMessage, error) {
+	contentType := string(ctx.Request.Header.ContentType())
+	mediaType, _, _ := strings.Cut(contentType, ";")
+
+	if !slices.Contains(jsonContentTypes, strings.TrimSpace(mediaType)) {
+		return &topicspb.TopicMessage{
+			Content: &topicspb.TopicMessage_BytesPayload{
+				BytesPayload: &topicspb.BytesPayload{
+					// the request body is only valid until the handler returns
+					Data:        bytes.Clone(ctx.Request.Body()),
+					ContentType: contentType,
+				},
+			},
+		}, nil
+	}
+
+	payload := &structpb.Struct{}
+	if len(ctx.Request.Body()) > 0 {
+		if err := payload.UnmarshalJSON(ctx.Request.Body()); err != nil {
+			return nil, err
+		}
+	}
+
+	return &topicspb.TopicMessage{
+		Content: &topicspb.TopicMessage_StructPayload{
+			StructPayload: payload,
+		},
+	}, nil
+}
+
+// handleSubscription - deliver a payload directly to the subscribers of a topic
+func (l *localMiddleware) handleSubscription(opts *gateway.GatewayStartOpts) fasthttp.RequestHandler {
+	return func(ctx *fasthttp.RequestCtx) {
+		topicName := ctx.UserValue("name").(string)
+
+		message, err := subscriptionMessage(ctx)
+		if err != nil {
+			ctx.Error("message body must be a JSON object", 400)
+			return
+		}
+
+		reqCtx, cancel := base_http.RequestContext()
+		defer cancel()
+
+		response, err := opts.TopicsListenerPlugin.HandleRequest(reqCtx, &topicspb.ServerMessage{
+			Content: &topicspb.ServerMessage_MessageRequest{
+				MessageRequest: &topicspb.MessageRequest{
+					TopicName: topicName,
+					// messages are delivered once without retries
+					DeliveryAttempt: 1,
+					Message:         message,
+				},
+			},
+		})
+		if err != nil {
+			ctx.Error(fmt.Sprintf("Error handling event %v", err), 500)
+			return
+		}
+
+		if !response.GetMessageResponse().GetSuccess() {
+			ctx.Error("Event handler returned success false", 500)
+			return
+		}
+
+		ctx.SuccessString("text/plain", "success")
+	}
+}
+
+// handleSchedule - run a schedule on demand, schedules aren't triggered on their cadence by the local runtime
+func (l *localMiddleware) handleSchedule(opts *gateway.GatewayStartOpts) fasthttp.RequestHandler {
+	return func(ctx *fasthttp.RequestCtx) {
+		scheduleName := ctx.UserValue("name").(string)
+
+		reqCtx, cancel := base_http.RequestContext()
+		defer cancel()
+
+		_, err := opts.SchedulesPlugin.HandleRequest(reqCtx, &schedulespb.ServerMessage{
+			Content: &schedulespb.ServerMessage_IntervalRequest{
+				IntervalRequest: &schedulespb.IntervalRequest{
+					ScheduleName: scheduleName,
+				},
+			},
+		})
+		if err != nil {
+			logger.Errorf("could not handle trigger for schedule %s: %s", scheduleName, err.Error())
+			ctx.Error("could not handle trigger", 500)
+			return
+		}
+
+		ctx.SuccessString("text/plain", "success")
+	}
+}
+
+func statusToHttpCode(err error) int {
+	switch status.Code(err) {
+	case codes.NotFound:
+		return fasthttp.StatusNotFound
+	case codes.InvalidArgument:
+		return fasthttp.StatusBadRequest
+	default:
+		return fasthttp.StatusInternalServerError
+	}
+}
+
+// handlePreSignedUrl - serve reads and writes for URLs generated by the storage service
+func (l *localMiddleware) handlePreSignedUrl(ctx *fasthttp.RequestCtx) {
+	bucketName := ctx.UserValue("bucket").(string)
+	key := strings.TrimPrefix(ctx.UserValue("key").(string), "/")
+
+	operation := storagepb.StoragePreSignUrlRequest_READ
+	if ctx.IsPut() {
+		operation = storagepb.StoragePreSignUrlRequest_WRITE
+	}
+
+	expires := string(ctx.QueryArgs().Peek("expires"))
+	signature := string(ctx.QueryArgs().Peek("signature"))
+
+	if err := l.storage.VerifyPreSignedUrl(operation, bucketName, key, expires, signature); err != nil {
+		ctx.Error(err.Error(), fasthttp.StatusForbidden)
+		return
+	}
+
+	if operation == storagepb.StoragePreSignUrlRequest_WRITE {
+		_, err := l.storage.Write(ctx, &storagepb.StorageWriteRequest{
+			BucketName:   bucketName,
+			Key:          key,
+			Body:         ctx.Request.Body(),
+			ContentType:  string(ctx.Request.Header.ContentType()),
+			CacheControl: string(ctx.Request.Header.Peek(fasthttp.HeaderCacheControl)),
+		})
+		if err != nil {
+			ctx.Error(err.Error(), statusToHttpCode(err))
+			return
+		}
+
+		ctx.SetStatusCode(fasthttp.StatusOK)
+		return
+	}
+
+	stat, err := l.storage.Stat(ctx, &storagepb.StorageStatRequest{
+		BucketName: bucketName,
+		Key:        key,
+	})
+	if err != nil {
+		ctx.Error(err.Error(), statusToHttpCode(err))
+		return
+	}
+
+	resp, err := l.storage.Read(ctx, &storagepb.StorageReadRequest{
+		BucketName: bucketName,
+		Key:        key,
+	})
+	if err != nil {
+		ctx.Error(err.Error(), statusToHttpCode(err))
+		return
+	}
+
+	// serve the blob with its stored attributes, as the cloud object stores do
+	ctx.SetContentType(stat.Blob.ContentType)
+	if stat.Blob.CacheControl != "" {
+		ctx.Response.Header.Set(fasthttp.HeaderCacheControl, stat.Blob.CacheControl)
+	}
+	ctx.Response.Header.Set(fasthttp.HeaderETag, fmt.Sprintf("%q", stat.Blob.Etag))
+	ctx.SetBody(resp.Body)
+}
+
+func (l *localMiddleware) router(r *router.Router, opts *gateway.GatewayStartOpts) {
+	r.POST(base_http.DefaultTopicRoute, l.handleSubscription(opts))
+	r.POST(base_http.DefaultScheduleRoute, l.handleSchedule(opts))
+	r.GET(fmt.Sprintf("%s/{bucket}/{key:*}", storage.PreSignPathPrefix), l.handlePreSignedUrl)
+	r.PUT(fmt.Sprintf("%s/{bucket}/{key:*}", storage.PreSignPathPrefix), l.handlePreSignedUrl)
+}
+
+// New - Create a new local gateway plugin, serving pre-signed URLs for the given storage service
+func New(storageService *storage.FilesystemStorageService) (gateway.GatewayService, error) {
+	mw := &localMiddleware{
+		storage: storageService,
+	}
+
+	return base_http.NewHttpGateway(&base_http.HttpGatewayOptions{
+		RouteRegistrationHook: mw.router,
+	})
+}
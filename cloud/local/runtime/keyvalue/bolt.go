@@ -0,0 +1,759 @@
+// Copyright 2021 Nitric Technologies Pty Ltd.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package keyvalue
+
+import (
+	"bytes"
+	"context"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"time"
+
+	bolt "go.etcd.io/bbolt"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/types/known/structpb"
+
+	document "github.com/nitrictech/nitric/core/pkg/decorators/keyvalue"
+	grpc_errors "github.com/nitrictech/nitric/core/pkg/grpc/errors"
+	"github.com/nitrictech/nitric/core/pkg/logger"
+	kvstorepb "github.com/nitrictech/nitric/core/pkg/proto/kvstore/v1"
+	"github.com/nitrictech/nitric/core/pkg/workers/keyvalue"
+)
+
+// BoltKeyValueService - an embedded bbolt implementation of the Nitric KvStore Service
+//
+//	each store is a top level bolt bucket, values are stored as protojson encoded structs.
+//	the expiry times and versions of values are kept in a separate bucket per store.
+type BoltKeyValueService struct {
+	db        *bolt.DB
+	listeners keyvalue.StoreRequestHandler
+}
+
+var _ kvstorepb.KvStoreServer = (*BoltKeyValueService)(nil)
+
+func (s *BoltKeyValueService) GetValue(ctx context.Context, req *kvstorepb.KvStoreGetValueRequest) (*kvstorepb.KvStoreGetValueResponse, error) {
+	newErr := grpc_errors.ErrorsWithScope("BoltKeyValueService.GetValue")
+
+	if err := document.ValidateValueRef(req.Ref); err != nil {
+		return nil, newErr(
+			codes.InvalidArgument,
+			"invalid key",
+			err,
+		)
+	}
+
+	var raw []byte
+	var version string
+	err := s.db.View(func(tx *bolt.Tx) error {
+		raw, version = getValue(tx, req.Ref)
+		// bolt values are only valid for the life of the transaction
+		raw = bytes.Clone(raw)
+
+		return nil
+	})
+	if err != nil {
+		return nil, newErr(
+			codes.Internal,
+			fmt.Sprintf("error retrieving value with key %s from store %s", req.Ref.Key, req.Ref.Store),
+			err,
+		)
+	}
+
+	if raw == nil {
+		return nil, newErr(
+			codes.NotFound,
+			fmt.Sprintf("key %s not found in store %s", req.Ref.Key, req.Ref.Store),
+			nil,
+		)
+	}
+
+	content := &structpb.Struct{}
+	if err := protojson.Unmarshal(raw, content); err != nil {
+		return nil, newErr(
+			codes.Internal,
+			"error unmarshalling value",
+			err,
+		)
+	}
+
+	return &kvstorepb.KvStoreGetValueResponse{
+		Value: &kvstorepb.Value{
+			Ref:     req.Ref,
+			Content: content,
+			Version: version,
+		},
+	}, nil
+}
+
+func (s *BoltKeyValueService) SetValue(ctx context.Context, req *kvstorepb.KvStoreSetValueRequest) (*kvstorepb.KvStoreSetValueResponse, error) {
+	newErr := grpc_errors.ErrorsWithScope("BoltKeyValueService.SetValue")
+
+	if err := document.ValidateValueRef(req.Ref); err != nil {
+		return nil, newErr(
+			codes.InvalidArgument,
+			"invalid key",
+			err,
+		)
+	}
+
+	if req.Content == nil {
+		return nil, newErr(
+			codes.InvalidArgument,
+			"document content must not be nil",
+			nil,
+		)
+	}
+
+	if err := document.ValidateTtl(req.Ttl); err != nil {
+		return nil, newErr(
+			codes.InvalidArgument,
+			"invalid ttl",
+			err,
+		)
+	}
+
+	if err := document.ValidatePrecondition(req.Precondition, false); err != nil {
+		return nil, newErr(
+			codes.InvalidArgument,
+			"invalid precondition",
+			err,
+		)
+	}
+
+	raw, err := protojson.Marshal(req.Content)
+	if err != nil {
+		return nil, newErr(
+			codes.InvalidArgument,
+			"failed to marshal content",
+			err,
+		)
+	}
+
+	var version string
+	err = s.db.Update(func(tx *bolt.Tx) error {
+		if err := checkPrecondition(tx, req.Ref, req.Precondition); err != nil {
+			return err
+		}
+
+		version, err = s.putValue(tx, req.Ref, raw, document.ExpiresAt(req.Ttl))
+
+		return err
+	})
+	if err != nil {
+		if errors.Is(err, errPreconditionFailed) {
+			return nil, newErr(
+				codes.FailedPrecondition,
+				fmt.Sprintf("precondition failed for key %s in store %s", req.Ref.Key, req.Ref.Store),
+				nil,
+			)
+		}
+
+		return nil, newErr(
+			codes.Internal,
+			"unable to set value",
+			err,
+		)
+	}
+
+	return &kvstorepb.KvStoreSetValueResponse{
+		Version: version,
+	}, nil
+}
+
+func (s *BoltKeyValueService) DeleteKey(ctx context.Context, req *kvstorepb.KvStoreDeleteKeyRequest) (*kvstorepb.KvStoreDeleteKeyResponse, error) {
+	newErr := grpc_errors.ErrorsWithScope("BoltKeyValueService.DeleteKey")
+
+	if err := document.ValidateValueRef(req.Ref); err != nil {
+		return nil, newErr(
+			codes.InvalidArgument,
+			"invalid key",
+			err,
+		)
+	}
+
+	if err := document.ValidatePrecondition(req.Precondition, true); err != nil {
+		return nil, newErr(
+			codes.InvalidArgument,
+			"invalid precondition",
+			err,
+		)
+	}
+
+	err := s.db.Update(func(tx *bolt.Tx) error {
+		if err := checkPrecondition(tx, req.Ref, req.Precondition); err != nil {
+			return err
+		}
+
+		return s.deleteValue(tx, req.Ref)
+	})
+	if err != nil {
+		if errors.Is(err, errPreconditionFailed) {
+			return nil, newErr(
+				codes.FailedPrecondition,
+				fmt.Sprintf("precondition failed for key %s in store %s", req.Ref.Key, req.Ref.Store),
+				nil,
+			)
+		}
+
+		return nil, newErr(
+			codes.Internal,
+			fmt.Sprintf("error deleting %v item %v", req.Ref.Store, req.Ref.Key),
+			err,
+		)
+	}
+
+	return &kvstorepb.KvStoreDeleteKeyResponse{}, nil
+}
+
+// scanEntry is a key, and its value if requested, collected by a key scan
+type scanEntry struct {
+	key     string
+	raw     []byte
+	version string
+}
+
+func (s *BoltKeyValueService) ScanKeys(req *kvstorepb.KvStoreScanKeysRequest, stream kvstorepb.KvStore_ScanKeysServer) error {
+	newErr := grpc_errors.ErrorsWithScope("BoltKeyValueService.ScanKeys")
+
+	if req.Store.GetName() == "" {
+		return newErr(
+			codes.InvalidArgument,
+			"store name is required",
+			nil,
+		)
+	}
+
+	if err := document.ValidateScan(req); err != nil {
+		return newErr(
+			codes.InvalidArgument,
+			"invalid scan",
+			err,
+		)
+	}
+
+	startAfter, _ := document.ScanStartAfter(req.ContinuationToken)
+
+	// collect the entries first so the read transaction isn't held open while streaming to the client
+	entries := []scanEntry{}
+	err := s.db.View(func(tx *bolt.Tx) error {
+		store := tx.Bucket([]byte(req.Store.Name))
+		if store == nil {
+			return nil
+		}
+
+		now := time.Now()
+		metas := tx.Bucket(metaBucket(req.Store.Name))
+
+		prefix := []byte(req.Prefix)
+		k, v, next := scanStart(store.Cursor(), prefix, []byte(startAfter), req.Reverse)
+		for ; k != nil && bytes.HasPrefix(k, prefix); k, v = next() {
+			meta := readMeta(metas, k)
+			if meta.expired(now) {
+				continue
+			}
+
+			entry := scanEntry{key: string(k)}
+			if req.IncludeValues {
+				// bolt values are only valid for the life of the transaction
+				entry.raw = bytes.Clone(v)
+				entry.version = meta.versionString()
+			}
+			entries = append(entries, entry)
+
+			if req.Limit > 0 && len(entries) >= int(req.Limit) {
+				break
+			}
+		}
+
+		return nil
+	})
+	if err != nil {
+		return newErr(
+			codes.Internal,
+			"unable to retrieve keys",
+			err,
+		)
+	}
+
+	for _, entry := range entries {
+		resp := &kvstorepb.KvStoreScanKeysResponse{
+			Key:               entry.key,
+			ContinuationToken: document.ScanContinuationToken(entry.key),
+		}
+
+		if req.IncludeValues {
+			content := &structpb.Struct{}
+			if err := protojson.Unmarshal(entry.raw, content); err != nil {
+				return newErr(
+					codes.Internal,
+					"error unmarshalling value",
+					err,
+				)
+			}
+
+			resp.Value = &kvstorepb.Value{
+				Ref:     &kvstorepb.ValueRef{Store: req.Store.Name, Key: entry.key},
+				Content: content,
+				Version: entry.version,
+			}
+		}
+
+		if err := stream.Send(resp); err != nil {
+			return newErr(
+				codes.Internal,
+				"failed to send response",
+				err,
+			)
+		}
+	}
+
+	return nil
+}
+
+func (s *BoltKeyValueService) BatchGet(ctx context.Context, req *kvstorepb.KvStoreBatchGetRequest) (*kvstorepb.KvStoreBatchGetResponse, error) {
+	newErr := grpc_errors.ErrorsWithScope("BoltKeyValueService.BatchGet")
+
+	if err := document.ValidateBatchSize(len(req.Refs), 0); err != nil {
+		return nil, newErr(
+			codes.InvalidArgument,
+			"invalid batch",
+			err,
+		)
+	}
+
+	resp := &kvstorepb.KvStoreBatchGetResponse{}
+
+	// bolt values are only valid for the life of the transaction, they're decoded before it ends
+	err := s.db.View(func(tx *bolt.Tx) error {
+		for _, ref := range req.Refs {
+			if err := document.ValidateValueRef(ref); err != nil {
+				resp.Errors = append(resp.Errors, document.ItemError(ref, newErr(codes.InvalidArgument, "invalid key", err)))
+				continue
+			}
+
+			raw, version := getValue(tx, ref)
+			if raw == nil {
+				resp.Errors = append(resp.Errors, document.ItemError(ref, newErr(
+					codes.NotFound,
+					fmt.Sprintf("key %s not found in store %s", ref.Key, ref.Store),
+					nil,
+				)))
+				continue
+			}
+
+			content := &structpb.Struct{}
+			if err := protojson.Unmarshal(raw, content); err != nil {
+				resp.Errors = append(resp.Errors, document.ItemError(ref, newErr(codes.Internal, "error unmarshalling value", err)))
+				continue
+			}
+
+			resp.Values = append(resp.Values, &kvstorepb.Value{
+				Ref:     ref,
+				Content: content,
+				Version: version,
+			})
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, newErr(
+			codes.Internal,
+			"unable to retrieve values",
+			err,
+		)
+	}
+
+	return resp, nil
+}
+
+func (s *BoltKeyValueService) BatchSet(ctx context.Context, req *kvstorepb.KvStoreBatchSetRequest) (*kvstorepb.KvStoreBatchSetResponse, error) {
+	newErr := grpc_errors.ErrorsWithScope("BoltKeyValueService.BatchSet")
+
+	if err := document.ValidateBatchSize(len(req.Values), 0); err != nil {
+		return nil, newErr(
+			codes.InvalidArgument,
+			"invalid batch",
+			err,
+		)
+	}
+
+	resp := &kvstorepb.KvStoreBatchSetResponse{}
+
+	err := s.db.Update(func(tx *bolt.Tx) error {
+		for _, value := range req.Values {
+			if err := document.ValidateValueRef(value.Ref); err != nil {
+				resp.Errors = append(resp.Errors, document.ItemError(value.Ref, newErr(codes.InvalidArgument, "invalid key", err)))
+				continue
+			}
+
+			if value.Content == nil {
+				resp.Errors = append(resp.Errors, document.ItemError(value.Ref, newErr(codes.InvalidArgument, "document content must not be nil", nil)))
+				continue
+			}
+
+			raw, err := protojson.Marshal(value.Content)
+			if err != nil {
+				resp.Errors = append(resp.Errors, document.ItemError(value.Ref, newErr(codes.InvalidArgument, "failed to marshal content", err)))
+				continue
+			}
+
+			if _, err := s.putValue(tx, value.Ref, raw, time.Time{}); err != nil {
+				resp.Errors = append(resp.Errors, document.ItemError(value.Ref, newErr(codes.Internal, "unable to set value", err)))
+			}
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, newErr(
+			codes.Internal,
+			"unable to set values",
+			err,
+		)
+	}
+
+	return resp, nil
+}
+
+func (s *BoltKeyValueService) BatchDelete(ctx context.Context, req *kvstorepb.KvStoreBatchDeleteRequest) (*kvstorepb.KvStoreBatchDeleteResponse, error) {
+	newErr := grpc_errors.ErrorsWithScope("BoltKeyValueService.BatchDelete")
+
+	if err := document.ValidateBatchSize(len(req.Refs), 0); err != nil {
+		return nil, newErr(
+			codes.InvalidArgument,
+			"invalid batch",
+			err,
+		)
+	}
+
+	resp := &kvstorepb.KvStoreBatchDeleteResponse{}
+
+	err := s.db.Update(func(tx *bolt.Tx) error {
+		for _, ref := range req.Refs {
+			if err := document.ValidateValueRef(ref); err != nil {
+				resp.Errors = append(resp.Errors, document.ItemError(ref, newErr(codes.InvalidArgument, "invalid key", err)))
+				continue
+			}
+
+			if err := s.deleteValue(tx, ref); err != nil {
+				resp.Errors = append(resp.Errors, document.ItemError(ref, newErr(
+					codes.Internal,
+					fmt.Sprintf("error deleting %v item %v", ref.Store, ref.Key),
+					err,
+				)))
+			}
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, newErr(
+			codes.Internal,
+			"unable to delete values",
+			err,
+		)
+	}
+
+	return resp, nil
+}
+
+// Transact applies all operations in a single bolt transaction, which is rolled back if any of them fail
+func (s *BoltKeyValueService) Transact(ctx context.Context, req *kvstorepb.KvStoreTransactRequest) (*kvstorepb.KvStoreTransactResponse, error) {
+	newErr := grpc_errors.ErrorsWithScope("BoltKeyValueService.Transact")
+
+	if err := document.ValidateTransaction(req.Operations, 0); err != nil {
+		return nil, newErr(
+			codes.InvalidArgument,
+			"invalid transaction",
+			err,
+		)
+	}
+
+	err := s.db.Update(func(tx *bolt.Tx) error {
+		for _, op := range req.Operations {
+			if set := op.GetSet(); set != nil {
+				raw, err := protojson.Marshal(set.Content)
+				if err != nil {
+					return fmt.Errorf("failed to marshal content: %w", err)
+				}
+
+				if _, err := s.putValue(tx, set.Ref, raw, time.Time{}); err != nil {
+					return err
+				}
+			} else if err := s.deleteValue(tx, op.GetDelete()); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, newErr(
+			codes.Aborted,
+			"transaction failed, no operations were applied",
+			err,
+		)
+	}
+
+	return &kvstorepb.KvStoreTransactResponse{}, nil
+}
+
+// scanStart positions a cursor at the first key of a scan, after startAfter if it isn't blank,
+// and returns the function that advances the cursor in the scan's direction
+func scanStart(cursor *bolt.Cursor, prefix []byte, startAfter []byte, reverse bool) ([]byte, []byte, func() ([]byte, []byte)) {
+	if !reverse {
+		seek := prefix
+		if bytes.Compare(startAfter, prefix) > 0 {
+			seek = startAfter
+		}
+
+		k, v := cursor.Seek(seek)
+		if len(startAfter) > 0 && bytes.Equal(k, startAfter) {
+			k, v = cursor.Next()
+		}
+
+		return k, v, cursor.Next
+	}
+
+	// reverse scans start from the last key before the end of the prefix range, or before startAfter
+	upper := prefixEnd(prefix)
+	if len(startAfter) > 0 && (upper == nil || bytes.Compare(startAfter, upper) < 0) {
+		upper = startAfter
+	}
+
+	if upper == nil {
+		k, v := cursor.Last()
+		return k, v, cursor.Prev
+	}
+
+	if k, _ := cursor.Seek(upper); k == nil {
+		k, v := cursor.Last()
+		return k, v, cursor.Prev
+	}
+
+	k, v := cursor.Prev()
+	return k, v, cursor.Prev
+}
+
+// prefixEnd returns the first key after every key with the prefix, nil if there isn't one
+func prefixEnd(prefix []byte) []byte {
+	end := bytes.Clone(prefix)
+	for i := len(end) - 1; i >= 0; i-- {
+		if end[i] < 0xff {
+			end[i]++
+			return end[:i+1]
+		}
+	}
+
+	return nil
+}
+
+// errPreconditionFailed is returned when the current value of a key doesn't meet the precondition of a write
+var errPreconditionFailed = errors.New("precondition failed")
+
+// metaBucket returns the name of the bucket holding the expiry times and versions of a store's values
+func metaBucket(store string) []byte {
+	return []byte("nitric-meta:" + store)
+}
+
+// valueMeta is the expiry time and version of a value
+type valueMeta struct {
+	// expiresAt is in unix nanoseconds, 0 if the value never expires
+	expiresAt int64
+	version   uint64
+}
+
+func readMeta(metas *bolt.Bucket, key []byte) valueMeta {
+	if metas == nil {
+		return valueMeta{}
+	}
+
+	raw := metas.Get(key)
+	if len(raw) != 16 {
+		return valueMeta{}
+	}
+
+	return valueMeta{
+		expiresAt: int64(binary.BigEndian.Uint64(raw[:8])),
+		version:   binary.BigEndian.Uint64(raw[8:]),
+	}
+}
+
+func (m valueMeta) encode() []byte {
+	return binary.BigEndian.AppendUint64(binary.BigEndian.AppendUint64(nil, uint64(m.expiresAt)), m.version)
+}
+
+func (m valueMeta) expired(now time.Time) bool {
+	return m.expiresAt != 0 && m.expiresAt <= now.UnixNano()
+}
+
+func (m valueMeta) versionString() string {
+	return strconv.FormatUint(m.version, 10)
+}
+
+// getValue returns the encoded content and version of a value, or nil if it doesn't exist or has expired
+func getValue(tx *bolt.Tx, ref *kvstorepb.ValueRef) ([]byte, string) {
+	store := tx.Bucket([]byte(ref.Store))
+	if store == nil {
+		return nil, ""
+	}
+
+	raw := store.Get([]byte(ref.Key))
+	if raw == nil {
+		return nil, ""
+	}
+
+	meta := readMeta(tx.Bucket(metaBucket(ref.Store)), []byte(ref.Key))
+	if meta.expired(time.Now()) {
+		return nil, ""
+	}
+
+	return raw, meta.versionString()
+}
+
+// putValue stores the encoded content of a value with a new version, replacing its expiry time.
+// A zero expiresAt never expires. Returns the new version of the value.
+func (s *BoltKeyValueService) putValue(tx *bolt.Tx, ref *kvstorepb.ValueRef, raw []byte, expiresAt time.Time) (string, error) {
+	store, err := tx.CreateBucketIfNotExists([]byte(ref.Store))
+	if err != nil {
+		return "", err
+	}
+
+	if err := store.Put([]byte(ref.Key), raw); err != nil {
+		return "", err
+	}
+
+	metas, err := tx.CreateBucketIfNotExists(metaBucket(ref.Store))
+	if err != nil {
+		return "", err
+	}
+
+	version, err := metas.NextSequence()
+	if err != nil {
+		return "", err
+	}
+
+	meta := valueMeta{version: version}
+	if !expiresAt.IsZero() {
+		meta.expiresAt = expiresAt.UnixNano()
+	}
+
+	if err := metas.Put([]byte(ref.Key), meta.encode()); err != nil {
+		return "", err
+	}
+
+	s.notifyOnCommit(tx, ref, kvstorepb.KeyValueEventType_Set)
+
+	return meta.versionString(), nil
+}
+
+func (s *BoltKeyValueService) deleteValue(tx *bolt.Tx, ref *kvstorepb.ValueRef) error {
+	store := tx.Bucket([]byte(ref.Store))
+	if store == nil || store.Get([]byte(ref.Key)) == nil {
+		return nil
+	}
+
+	if metas := tx.Bucket(metaBucket(ref.Store)); metas != nil {
+		if err := metas.Delete([]byte(ref.Key)); err != nil {
+			return err
+		}
+	}
+
+	if err := store.Delete([]byte(ref.Key)); err != nil {
+		return err
+	}
+
+	s.notifyOnCommit(tx, ref, kvstorepb.KeyValueEventType_Delete)
+
+	return nil
+}
+
+// notifyOnCommit - deliver a value event to any registered store listeners once the transaction has been committed,
+// asynchronously as the cloud providers do
+func (s *BoltKeyValueService) notifyOnCommit(tx *bolt.Tx, ref *kvstorepb.ValueRef, eventType kvstorepb.KeyValueEventType) {
+	if s.listeners == nil {
+		return
+	}
+
+	store, key := ref.Store, ref.Key
+
+	tx.OnCommit(func() {
+		go func() {
+			_, err := s.listeners.HandleRequest(context.Background(), &kvstorepb.ServerMessage{
+				Content: &kvstorepb.ServerMessage_KeyValueEventRequest{
+					KeyValueEventRequest: &kvstorepb.KeyValueEventRequest{
+						StoreName: store,
+						Event: &kvstorepb.KeyValueEventRequest_KeyValueEvent{
+							KeyValueEvent: &kvstorepb.KeyValueEvent{
+								Key:  key,
+								Type: eventType,
+							},
+						},
+					},
+				},
+			})
+			if err != nil {
+				logger.Debugf("value event %s for %s/%s not delivered: %v", eventType, store, key, err)
+			}
+		}()
+	})
+}
+
+// checkPrecondition returns errPreconditionFailed if the current value of a key doesn't meet the precondition
+func checkPrecondition(tx *bolt.Tx, ref *kvstorepb.ValueRef, precondition *kvstorepb.KvStorePrecondition) error {
+	raw, version := getValue(tx, ref)
+
+	switch c := precondition.GetCondition().(type) {
+	case *kvstorepb.KvStorePrecondition_Version:
+		if raw == nil || version != c.Version {
+			return errPreconditionFailed
+		}
+	case *kvstorepb.KvStorePrecondition_Exists:
+		if (raw != nil) != c.Exists {
+			return errPreconditionFailed
+		}
+	}
+
+	return nil
+}
+
+// Close the underlying database
+func (s *BoltKeyValueService) Close() error {
+	return s.db.Close()
+}
+
+// New creates a new bbolt implementation of the KvStoreServer, persisted in the given file.
+// Value events are delivered to the given listeners, if any.
+func New(path string, listeners keyvalue.StoreRequestHandler) (*BoltKeyValueService, error) {
+	if err := os.MkdirAll(filepath.Dir(path), 0o750); err != nil {
+		return nil, fmt.Errorf("unable to create key value directory: %w", err)
+	}
+
+	db, err := bolt.Open(path, 0o600, &bolt.Options{Timeout: 5 * time.Second})
+	if err != nil {
+		return nil, fmt.Errorf("unable to open key value database %s: %w", path, err)
+	}
+
+	return &BoltKeyValueService{
+		db:        db,
+		listeners: listeners,
+	}, nil
+}
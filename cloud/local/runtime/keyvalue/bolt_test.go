@@ -0,0 +1,210 @@
+// Copyright 2021 Nitric Technologies Pty Ltd.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package keyvalue
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/durationpb"
+	"google.golang.org/protobuf/types/known/structpb"
+
+	kvstorepb "github.com/nitrictech/nitric/core/pkg/proto/kvstore/v1"
+)
+
+// scanStream collects the responses of a key scan
+type scanStream struct {
+	grpc.ServerStream
+	responses []*kvstorepb.KvStoreScanKeysResponse
+}
+
+func (s *scanStream) Send(resp *kvstorepb.KvStoreScanKeysResponse) error {
+	s.responses = append(s.responses, resp)
+	return nil
+}
+
+func (s *scanStream) keys() []string {
+	keys := []string{}
+	for _, resp := range s.responses {
+		keys = append(keys, resp.Key)
+	}
+
+	return keys
+}
+
+var _ = Describe("BoltKeyValueService", func() {
+	content, _ := structpb.NewStruct(map[string]interface{}{"Test": "Test"})
+
+	var (
+		dir    string
+		plugin *BoltKeyValueService
+	)
+
+	ref := func(key string) *kvstorepb.ValueRef {
+		return &kvstorepb.ValueRef{Store: "test-store", Key: key}
+	}
+
+	set := func(key string) string {
+		resp, err := plugin.SetValue(context.TODO(), &kvstorepb.KvStoreSetValueRequest{Ref: ref(key), Content: content})
+		Expect(err).ShouldNot(HaveOccurred())
+
+		return resp.Version
+	}
+
+	BeforeEach(func() {
+		var err error
+		dir, err = os.MkdirTemp("", "nitric-kv-*")
+		Expect(err).ShouldNot(HaveOccurred())
+
+		plugin, err = New(filepath.Join(dir, "kv.db"), nil)
+		Expect(err).ShouldNot(HaveOccurred())
+	})
+
+	AfterEach(func() {
+		Expect(plugin.Close()).To(Succeed())
+		Expect(os.RemoveAll(dir)).To(Succeed())
+	})
+
+	Context("SetValue", func() {
+		When("the value has a ttl", func() {
+			It("should no longer be returned once it has expired", func() {
+				_, err := plugin.SetValue(context.TODO(), &kvstorepb.KvStoreSetValueRequest{
+					Ref:     ref("expiring"),
+					Content: content,
+					Ttl:     durationpb.New(50 * time.Millisecond),
+				})
+				Expect(err).ShouldNot(HaveOccurred())
+				set("lasting")
+
+				_, err = plugin.GetValue(context.TODO(), &kvstorepb.KvStoreGetValueRequest{Ref: ref("expiring")})
+				Expect(err).ShouldNot(HaveOccurred())
+
+				Eventually(func() codes.Code {
+					_, err := plugin.GetValue(context.TODO(), &kvstorepb.KvStoreGetValueRequest{Ref: ref("expiring")})
+					return status.Code(err)
+				}).Should(Equal(codes.NotFound))
+
+				stream := &scanStream{}
+				Expect(plugin.ScanKeys(&kvstorepb.KvStoreScanKeysRequest{Store: &kvstorepb.Store{Name: "test-store"}}, stream)).To(Succeed())
+				Expect(stream.keys()).To(Equal([]string{"lasting"}))
+			})
+		})
+
+		When("the version precondition isn't met", func() {
+			It("should return a failed precondition error and keep the current value", func() {
+				version := set("key")
+				newVersion := set("key")
+
+				_, err := plugin.SetValue(context.TODO(), &kvstorepb.KvStoreSetValueRequest{
+					Ref:     ref("key"),
+					Content: content,
+					Precondition: &kvstorepb.KvStorePrecondition{
+						Condition: &kvstorepb.KvStorePrecondition_Version{Version: version},
+					},
+				})
+				Expect(status.Code(err)).To(Equal(codes.FailedPrecondition))
+
+				resp, err := plugin.GetValue(context.TODO(), &kvstorepb.KvStoreGetValueRequest{Ref: ref("key")})
+				Expect(err).ShouldNot(HaveOccurred())
+				Expect(resp.Value.Version).To(Equal(newVersion))
+			})
+		})
+	})
+
+	Context("ScanKeys", func() {
+		When("scanning with a limit", func() {
+			It("should page through the keys with continuation tokens", func() {
+				for _, key := range []string{"a", "b", "c", "d", "e"} {
+					set(key)
+				}
+
+				pages := [][]string{}
+				token := ""
+				for {
+					stream := &scanStream{}
+					Expect(plugin.ScanKeys(&kvstorepb.KvStoreScanKeysRequest{
+						Store:             &kvstorepb.Store{Name: "test-store"},
+						Limit:             2,
+						ContinuationToken: token,
+					}, stream)).To(Succeed())
+
+					if len(stream.responses) == 0 {
+						break
+					}
+
+					pages = append(pages, stream.keys())
+					token = stream.responses[len(stream.responses)-1].ContinuationToken
+				}
+
+				Expect(pages).To(Equal([][]string{{"a", "b"}, {"c", "d"}, {"e"}}))
+			})
+
+			It("should page through the keys in reverse", func() {
+				for _, key := range []string{"a", "b", "c"} {
+					set(key)
+				}
+
+				first := &scanStream{}
+				Expect(plugin.ScanKeys(&kvstorepb.KvStoreScanKeysRequest{
+					Store:   &kvstorepb.Store{Name: "test-store"},
+					Limit:   2,
+					Reverse: true,
+				}, first)).To(Succeed())
+				Expect(first.keys()).To(Equal([]string{"c", "b"}))
+
+				second := &scanStream{}
+				Expect(plugin.ScanKeys(&kvstorepb.KvStoreScanKeysRequest{
+					Store:             &kvstorepb.Store{Name: "test-store"},
+					Limit:             2,
+					Reverse:           true,
+					ContinuationToken: first.responses[1].ContinuationToken,
+				}, second)).To(Succeed())
+				Expect(second.keys()).To(Equal([]string{"a"}))
+			})
+		})
+	})
+
+	Context("Transact", func() {
+		When("an operation fails", func() {
+			It("should not apply any of the operations", func() {
+				set("deleted")
+
+				_, err := plugin.Transact(context.TODO(), &kvstorepb.KvStoreTransactRequest{
+					Operations: []*kvstorepb.KvStoreTransactOperation{
+						{Operation: &kvstorepb.KvStoreTransactOperation_Delete{Delete: ref("deleted")}},
+						{Operation: &kvstorepb.KvStoreTransactOperation_Set{Set: &kvstorepb.Value{Ref: ref("set"), Content: content}}},
+						// bolt rejects keys this long, failing the transaction after the other operations
+						{Operation: &kvstorepb.KvStoreTransactOperation_Set{Set: &kvstorepb.Value{Ref: ref(strings.Repeat("k", 40000)), Content: content}}},
+					},
+				})
+				Expect(status.Code(err)).To(Equal(codes.Aborted))
+
+				_, err = plugin.GetValue(context.TODO(), &kvstorepb.KvStoreGetValueRequest{Ref: ref("deleted")})
+				Expect(err).ShouldNot(HaveOccurred())
+
+				_, err = plugin.GetValue(context.TODO(), &kvstorepb.KvStoreGetValueRequest{Ref: ref("set")})
+				Expect(status.Code(err)).To(Equal(codes.NotFound))
+			})
+		})
+	})
+})
@@ -0,0 +1,297 @@
+// Copyright 2021 Nitric Technologies Pty Ltd.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package queue
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"google.golang.org/grpc/codes"
+
+	grpc_errors "github.com/nitrictech/nitric/core/pkg/grpc/errors"
+	queuespb "github.com/nitrictech/nitric/core/pkg/proto/queues/v1"
+)
+
+// queuedMessage - a message along with the number of times it has been delivered
+type queuedMessage struct {
+	message  *queuespb.QueueMessage
+	attempts int32
+}
+
+type leasedMessage struct {
+	message *queuedMessage
+	expires time.Time
+}
+
+// Messages with the same deduplication id are only enqueued once within this window, matching SQS FIFO queues
+const deduplicationWindow = 5 * time.Minute
+
+type localQueue struct {
+	messages []*queuedMessage
+	leases   map[string]*leasedMessage
+	// the time each deduplication id was last enqueued
+	deduplicationIds map[string]time.Time
+}
+
+// isDuplicate - returns true if the deduplication id was enqueued within the deduplication window, otherwise records it
+func (q *localQueue) isDuplicate(deduplicationId string, now time.Time) bool {
+	for id, enqueued := range q.deduplicationIds {
+		if now.Sub(enqueued) >= deduplicationWindow {
+			delete(q.deduplicationIds, id)
+		}
+	}
+
+	if deduplicationId == "" {
+		return false
+	}
+
+	if _, ok := q.deduplicationIds[deduplicationId]; ok {
+		return true
+	}
+
+	q.deduplicationIds[deduplicationId] = now
+
+	return false
+}
+
+// reclaimExpiredLeases - return messages with expired leases to the front of the queue, making them visible again
+func (q *localQueue) reclaimExpiredLeases(now time.Time) {
+	reclaimed := []*queuedMessage{}
+
+	for leaseId, lease := range q.leases {
+		if now.After(lease.expires) {
+			reclaimed = append(reclaimed, lease.message)
+			delete(q.leases, leaseId)
+		}
+	}
+
+	q.messages = append(reclaimed, q.messages...)
+}
+
+// MemoryQueueService - an in-memory implementation of the Nitric Queues Service
+//
+//	dequeued messages are leased to the consumer, if they're not completed before the lease expires they're redelivered.
+type MemoryQueueService struct {
+	queues        map[string]*localQueue
+	leaseDuration time.Duration
+	lock          sync.Mutex
+}
+
+var _ queuespb.QueuesServer = &MemoryQueueService{}
+
+func (s *MemoryQueueService) getQueue(name string) *localQueue {
+	queue, ok := s.queues[name]
+	if !ok {
+		queue = &localQueue{
+			messages:         []*queuedMessage{},
+			leases:           map[string]*leasedMessage{},
+			deduplicationIds: map[string]time.Time{},
+		}
+		s.queues[name] = queue
+	}
+
+	return queue
+}
+
+func (s *MemoryQueueService) Enqueue(ctx context.Context, req *queuespb.QueueEnqueueRequest) (*queuespb.QueueEnqueueResponse, error) {
+	newErr := grpc_errors.ErrorsWithScope("MemoryQueueService.Enqueue")
+
+	if req.QueueName == "" {
+		return nil, newErr(codes.InvalidArgument, "queue name must not be empty", nil)
+	}
+
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	now := time.Now()
+	queue := s.getQueue(req.QueueName)
+	failedMessages := []*queuespb.FailedEnqueueMessage{}
+
+	for _, message := range req.Messages {
+		if message.GetContent() == nil {
+			failedMessages = append(failedMessages, &queuespb.FailedEnqueueMessage{
+				Message: message,
+				Details: "message content must not be empty",
+			})
+			continue
+		}
+
+		// duplicates are accepted but not enqueued again, local queues are always delivered in order
+		if queue.isDuplicate(message.DeduplicationId, now) {
+			continue
+		}
+
+		queue.messages = append(queue.messages, &queuedMessage{message: message})
+	}
+
+	return &queuespb.QueueEnqueueResponse{
+		FailedMessages: failedMessages,
+	}, nil
+}
+
+func (s *MemoryQueueService) Dequeue(ctx context.Context, req *queuespb.QueueDequeueRequest) (*queuespb.QueueDequeueResponse, error) {
+	newErr := grpc_errors.ErrorsWithScope("MemoryQueueService.Dequeue")
+
+	if req.QueueName == "" {
+		return nil, newErr(codes.InvalidArgument, "queue name must not be empty", nil)
+	}
+
+	if req.Depth < 1 {
+		return nil, newErr(codes.InvalidArgument, "depth cannot be less than 1", nil)
+	}
+
+	leaseDuration := s.leaseDuration
+	if req.VisibilityTimeout != nil {
+		if err := req.VisibilityTimeout.CheckValid(); err != nil || req.VisibilityTimeout.AsDuration() <= 0 {
+			return nil, newErr(codes.InvalidArgument, "visibility timeout must be a positive duration", err)
+		}
+		leaseDuration = req.VisibilityTimeout.AsDuration()
+	}
+
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	now := time.Now()
+	queue := s.getQueue(req.QueueName)
+	queue.reclaimExpiredLeases(now)
+
+	count := min(int(req.Depth), len(queue.messages))
+	dequeued := make([]*queuespb.DequeuedMessage, 0, count)
+
+	for _, message := range queue.messages[:count] {
+		message.attempts++
+
+		leaseId := uuid.New().String()
+		queue.leases[leaseId] = &leasedMessage{
+			message: message,
+			expires: now.Add(leaseDuration),
+		}
+
+		dequeued = append(dequeued, &queuespb.DequeuedMessage{
+			LeaseId:         leaseId,
+			Message:         message.message,
+			DeliveryAttempt: message.attempts,
+		})
+	}
+
+	queue.messages = queue.messages[count:]
+
+	return &queuespb.QueueDequeueResponse{
+		Messages: dequeued,
+	}, nil
+}
+
+func (s *MemoryQueueService) Complete(ctx context.Context, req *queuespb.QueueCompleteRequest) (*queuespb.QueueCompleteResponse, error) {
+	newErr := grpc_errors.ErrorsWithScope("MemoryQueueService.Complete")
+
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	queue := s.getQueue(req.QueueName)
+	queue.reclaimExpiredLeases(time.Now())
+
+	if _, ok := queue.leases[req.LeaseId]; !ok {
+		return nil, newErr(
+			codes.NotFound,
+			fmt.Sprintf("lease %s not found on queue %s, it may have expired", req.LeaseId, req.QueueName),
+			nil,
+		)
+	}
+
+	delete(queue.leases, req.LeaseId)
+
+	return &queuespb.QueueCompleteResponse{}, nil
+}
+
+func (s *MemoryQueueService) ExtendLease(ctx context.Context, req *queuespb.QueueExtendLeaseRequest) (*queuespb.QueueExtendLeaseResponse, error) {
+	newErr := grpc_errors.ErrorsWithScope("MemoryQueueService.ExtendLease")
+
+	if err := req.GetVisibilityTimeout().CheckValid(); err != nil || req.VisibilityTimeout.AsDuration() <= 0 {
+		return nil, newErr(codes.InvalidArgument, "visibility timeout must be a positive duration", err)
+	}
+
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	now := time.Now()
+	queue := s.getQueue(req.QueueName)
+	queue.reclaimExpiredLeases(now)
+
+	lease, ok := queue.leases[req.LeaseId]
+	if !ok {
+		return nil, newErr(
+			codes.NotFound,
+			fmt.Sprintf("lease %s not found on queue %s, it may have expired", req.LeaseId, req.QueueName),
+			nil,
+		)
+	}
+
+	lease.expires = now.Add(req.VisibilityTimeout.AsDuration())
+
+	return &queuespb.QueueExtendLeaseResponse{
+		LeaseId: req.LeaseId,
+	}, nil
+}
+
+func (s *MemoryQueueService) Release(ctx context.Context, req *queuespb.QueueReleaseRequest) (*queuespb.QueueReleaseResponse, error) {
+	newErr := grpc_errors.ErrorsWithScope("MemoryQueueService.Release")
+
+	if req.Delay != nil {
+		if err := req.Delay.CheckValid(); err != nil || req.Delay.AsDuration() < 0 {
+			return nil, newErr(codes.InvalidArgument, "delay must not be negative", err)
+		}
+	}
+
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	now := time.Now()
+	queue := s.getQueue(req.QueueName)
+	queue.reclaimExpiredLeases(now)
+
+	lease, ok := queue.leases[req.LeaseId]
+	if !ok {
+		return nil, newErr(
+			codes.NotFound,
+			fmt.Sprintf("lease %s not found on queue %s, it may have expired", req.LeaseId, req.QueueName),
+			nil,
+		)
+	}
+
+	delete(queue.leases, req.LeaseId)
+
+	if req.Delay.AsDuration() > 0 {
+		// a delayed message is held under a lease no consumer knows, so it's reclaimed once the delay has passed
+		queue.leases[uuid.New().String()] = &leasedMessage{
+			message: lease.message,
+			expires: now.Add(req.Delay.AsDuration()),
+		}
+	} else {
+		queue.messages = append([]*queuedMessage{lease.message}, queue.messages...)
+	}
+
+	return &queuespb.QueueReleaseResponse{}, nil
+}
+
+// New - create a new in-memory queue service, dequeued messages are leased for leaseDuration
+func New(leaseDuration time.Duration) *MemoryQueueService {
+	return &MemoryQueueService{
+		queues:        map[string]*localQueue{},
+		leaseDuration: leaseDuration,
+	}
+}
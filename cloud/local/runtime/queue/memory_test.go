@@ -0,0 +1,280 @@
+// Copyright 2021 Nitric Technologies Pty Ltd.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package queue
+
+import (
+	"context"
+	"time"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/durationpb"
+	"google.golang.org/protobuf/types/known/structpb"
+
+	queuespb "github.com/nitrictech/nitric/core/pkg/proto/queues/v1"
+)
+
+var _ = Describe("MemoryQueueService", func() {
+	payload, _ := structpb.NewStruct(map[string]interface{}{"Test": "Test"})
+	message := &queuespb.QueueMessage{
+		Content: &queuespb.QueueMessage_StructPayload{
+			StructPayload: payload,
+		},
+	}
+
+	Context("Enqueue", func() {
+		When("messages share a deduplication id", func() {
+			It("should only enqueue the first message", func() {
+				plugin := New(time.Minute)
+
+				deduplicated := &queuespb.QueueMessage{
+					Content:         message.Content,
+					GroupKey:        "test-group",
+					DeduplicationId: "test-dedup",
+				}
+
+				resp, err := plugin.Enqueue(context.TODO(), &queuespb.QueueEnqueueRequest{
+					QueueName: "test-queue",
+					Messages:  []*queuespb.QueueMessage{deduplicated, deduplicated, message},
+				})
+				Expect(err).ShouldNot(HaveOccurred())
+				Expect(resp.FailedMessages).To(BeEmpty())
+
+				dequeued, err := plugin.Dequeue(context.TODO(), &queuespb.QueueDequeueRequest{
+					QueueName: "test-queue",
+					Depth:     10,
+				})
+				Expect(err).ShouldNot(HaveOccurred())
+				Expect(dequeued.Messages).To(HaveLen(2))
+				Expect(dequeued.Messages[0].Message.DeduplicationId).To(Equal("test-dedup"))
+				Expect(dequeued.Messages[1].Message.DeduplicationId).To(BeEmpty())
+			})
+		})
+	})
+
+	Context("Dequeue", func() {
+		When("messages have been enqueued", func() {
+			It("should return up to depth messages in order", func() {
+				plugin := New(time.Minute)
+
+				_, err := plugin.Enqueue(context.TODO(), &queuespb.QueueEnqueueRequest{
+					QueueName: "test-queue",
+					Messages:  []*queuespb.QueueMessage{message, message, message},
+				})
+				Expect(err).ShouldNot(HaveOccurred())
+
+				resp, err := plugin.Dequeue(context.TODO(), &queuespb.QueueDequeueRequest{
+					QueueName: "test-queue",
+					Depth:     2,
+				})
+				Expect(err).ShouldNot(HaveOccurred())
+				Expect(resp.Messages).To(HaveLen(2))
+				Expect(resp.Messages[0].LeaseId).ToNot(Equal(resp.Messages[1].LeaseId))
+
+				resp, err = plugin.Dequeue(context.TODO(), &queuespb.QueueDequeueRequest{
+					QueueName: "test-queue",
+					Depth:     2,
+				})
+				Expect(err).ShouldNot(HaveOccurred())
+				Expect(resp.Messages).To(HaveLen(1))
+			})
+		})
+
+		When("a lease expires before the message is completed", func() {
+			It("should redeliver the message", func() {
+				plugin := New(time.Millisecond)
+
+				_, err := plugin.Enqueue(context.TODO(), &queuespb.QueueEnqueueRequest{
+					QueueName: "test-queue",
+					Messages:  []*queuespb.QueueMessage{message},
+				})
+				Expect(err).ShouldNot(HaveOccurred())
+
+				first, err := plugin.Dequeue(context.TODO(), &queuespb.QueueDequeueRequest{QueueName: "test-queue", Depth: 1})
+				Expect(err).ShouldNot(HaveOccurred())
+				Expect(first.Messages).To(HaveLen(1))
+
+				time.Sleep(5 * time.Millisecond)
+
+				second, err := plugin.Dequeue(context.TODO(), &queuespb.QueueDequeueRequest{QueueName: "test-queue", Depth: 1})
+				Expect(err).ShouldNot(HaveOccurred())
+				Expect(second.Messages).To(HaveLen(1))
+
+				By("counting each delivery attempt")
+				Expect(first.Messages[0].DeliveryAttempt).To(Equal(int32(1)))
+				Expect(second.Messages[0].DeliveryAttempt).To(Equal(int32(2)))
+
+				By("rejecting completion with the expired lease")
+				_, err = plugin.Complete(context.TODO(), &queuespb.QueueCompleteRequest{
+					QueueName: "test-queue",
+					LeaseId:   first.Messages[0].LeaseId,
+				})
+				Expect(status.Code(err)).To(Equal(codes.NotFound))
+			})
+		})
+
+		When("depth is less than 1", func() {
+			It("should return an invalid argument error", func() {
+				plugin := New(time.Minute)
+
+				_, err := plugin.Dequeue(context.TODO(), &queuespb.QueueDequeueRequest{QueueName: "test-queue", Depth: 0})
+				Expect(status.Code(err)).To(Equal(codes.InvalidArgument))
+			})
+		})
+	})
+
+	Context("Complete", func() {
+		When("the lease is valid", func() {
+			It("should remove the message from the queue", func() {
+				plugin := New(time.Millisecond)
+
+				_, err := plugin.Enqueue(context.TODO(), &queuespb.QueueEnqueueRequest{
+					QueueName: "test-queue",
+					Messages:  []*queuespb.QueueMessage{message},
+				})
+				Expect(err).ShouldNot(HaveOccurred())
+
+				resp, err := plugin.Dequeue(context.TODO(), &queuespb.QueueDequeueRequest{QueueName: "test-queue", Depth: 1})
+				Expect(err).ShouldNot(HaveOccurred())
+
+				_, err = plugin.Complete(context.TODO(), &queuespb.QueueCompleteRequest{
+					QueueName: "test-queue",
+					LeaseId:   resp.Messages[0].LeaseId,
+				})
+				Expect(err).ShouldNot(HaveOccurred())
+
+				time.Sleep(5 * time.Millisecond)
+
+				resp, err = plugin.Dequeue(context.TODO(), &queuespb.QueueDequeueRequest{QueueName: "test-queue", Depth: 1})
+				Expect(err).ShouldNot(HaveOccurred())
+				Expect(resp.Messages).To(BeEmpty())
+			})
+		})
+	})
+
+	Context("ExtendLease", func() {
+		When("the lease is valid", func() {
+			It("should delay redelivery of the message", func() {
+				plugin := New(time.Millisecond)
+
+				_, err := plugin.Enqueue(context.TODO(), &queuespb.QueueEnqueueRequest{
+					QueueName: "test-queue",
+					Messages:  []*queuespb.QueueMessage{message},
+				})
+				Expect(err).ShouldNot(HaveOccurred())
+
+				resp, err := plugin.Dequeue(context.TODO(), &queuespb.QueueDequeueRequest{QueueName: "test-queue", Depth: 1})
+				Expect(err).ShouldNot(HaveOccurred())
+
+				extended, err := plugin.ExtendLease(context.TODO(), &queuespb.QueueExtendLeaseRequest{
+					QueueName:         "test-queue",
+					LeaseId:           resp.Messages[0].LeaseId,
+					VisibilityTimeout: durationpb.New(time.Minute),
+				})
+				Expect(err).ShouldNot(HaveOccurred())
+
+				time.Sleep(5 * time.Millisecond)
+
+				resp, err = plugin.Dequeue(context.TODO(), &queuespb.QueueDequeueRequest{QueueName: "test-queue", Depth: 1})
+				Expect(err).ShouldNot(HaveOccurred())
+				Expect(resp.Messages).To(BeEmpty())
+
+				By("allowing completion with the extended lease")
+				_, err = plugin.Complete(context.TODO(), &queuespb.QueueCompleteRequest{
+					QueueName: "test-queue",
+					LeaseId:   extended.LeaseId,
+				})
+				Expect(err).ShouldNot(HaveOccurred())
+			})
+		})
+
+		When("the visibility timeout is missing", func() {
+			It("should return an invalid argument error", func() {
+				plugin := New(time.Minute)
+
+				_, err := plugin.ExtendLease(context.TODO(), &queuespb.QueueExtendLeaseRequest{QueueName: "test-queue", LeaseId: "test"})
+				Expect(status.Code(err)).To(Equal(codes.InvalidArgument))
+			})
+		})
+	})
+
+	Context("Release", func() {
+		When("no delay is provided", func() {
+			It("should redeliver the message immediately", func() {
+				plugin := New(time.Minute)
+
+				_, err := plugin.Enqueue(context.TODO(), &queuespb.QueueEnqueueRequest{
+					QueueName: "test-queue",
+					Messages:  []*queuespb.QueueMessage{message},
+				})
+				Expect(err).ShouldNot(HaveOccurred())
+
+				first, err := plugin.Dequeue(context.TODO(), &queuespb.QueueDequeueRequest{QueueName: "test-queue", Depth: 1})
+				Expect(err).ShouldNot(HaveOccurred())
+
+				_, err = plugin.Release(context.TODO(), &queuespb.QueueReleaseRequest{
+					QueueName: "test-queue",
+					LeaseId:   first.Messages[0].LeaseId,
+				})
+				Expect(err).ShouldNot(HaveOccurred())
+
+				second, err := plugin.Dequeue(context.TODO(), &queuespb.QueueDequeueRequest{QueueName: "test-queue", Depth: 1})
+				Expect(err).ShouldNot(HaveOccurred())
+				Expect(second.Messages).To(HaveLen(1))
+
+				By("rejecting completion with the released lease")
+				_, err = plugin.Complete(context.TODO(), &queuespb.QueueCompleteRequest{
+					QueueName: "test-queue",
+					LeaseId:   first.Messages[0].LeaseId,
+				})
+				Expect(status.Code(err)).To(Equal(codes.NotFound))
+			})
+		})
+
+		When("a delay is provided", func() {
+			It("should redeliver the message once the delay has passed", func() {
+				plugin := New(time.Minute)
+
+				_, err := plugin.Enqueue(context.TODO(), &queuespb.QueueEnqueueRequest{
+					QueueName: "test-queue",
+					Messages:  []*queuespb.QueueMessage{message},
+				})
+				Expect(err).ShouldNot(HaveOccurred())
+
+				resp, err := plugin.Dequeue(context.TODO(), &queuespb.QueueDequeueRequest{QueueName: "test-queue", Depth: 1})
+				Expect(err).ShouldNot(HaveOccurred())
+
+				_, err = plugin.Release(context.TODO(), &queuespb.QueueReleaseRequest{
+					QueueName: "test-queue",
+					LeaseId:   resp.Messages[0].LeaseId,
+					Delay:     durationpb.New(5 * time.Millisecond),
+				})
+				Expect(err).ShouldNot(HaveOccurred())
+
+				resp, err = plugin.Dequeue(context.TODO(), &queuespb.QueueDequeueRequest{QueueName: "test-queue", Depth: 1})
+				Expect(err).ShouldNot(HaveOccurred())
+				Expect(resp.Messages).To(BeEmpty())
+
+				time.Sleep(10 * time.Millisecond)
+
+				resp, err = plugin.Dequeue(context.TODO(), &queuespb.QueueDequeueRequest{QueueName: "test-queue", Depth: 1})
+				Expect(err).ShouldNot(HaveOccurred())
+				Expect(resp.Messages).To(HaveLen(1))
+			})
+		})
+	})
+})
@@ -0,0 +1,367 @@
+// Copyright 2021 Nitric Technologies Pty Ltd.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package secret
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	grpc_errors "github.com/nitrictech/nitric/core/pkg/grpc/errors"
+	secretpb "github.com/nitrictech/nitric/core/pkg/proto/secrets/v1"
+)
+
+const latestFile = "latest"
+
+// Suffixes of the files of versions in each state
+var versionSuffixes = map[secretpb.SecretVersionState]string{
+	secretpb.SecretVersionState_Enabled:   "",
+	secretpb.SecretVersionState_Disabled:  ".disabled",
+	secretpb.SecretVersionState_Destroyed: ".destroyed",
+}
+
+// FileSecretService - a local filesystem implementation of the Nitric Secret Manager
+//
+//	each secret is a directory under the root containing one file per version,
+//	versions are numbered sequentially and a pointer file tracks the latest version.
+//	Disabled versions are renamed with a suffix, destroyed versions are replaced with an empty marker file.
+type FileSecretService struct {
+	root string
+	lock sync.Mutex
+}
+
+var _ secretpb.SecretManagerServer = &FileSecretService{}
+
+func (s *FileSecretService) secretDir(name string) (string, error) {
+	if name == "" || strings.ContainsAny(name, `/\`) || name == "." || name == ".." {
+		return "", fmt.Errorf("invalid secret name %q", name)
+	}
+
+	return filepath.Join(s.root, name), nil
+}
+
+func (s *FileSecretService) latestVersion(dir string) (int, error) {
+	latest, err := os.ReadFile(filepath.Join(dir, latestFile))
+	if err != nil {
+		if errors.Is(err, fs.ErrNotExist) {
+			return 0, nil
+		}
+
+		return 0, err
+	}
+
+	return strconv.Atoi(strings.TrimSpace(string(latest)))
+}
+
+// Put - Store a new secret value as the next version of the secret
+func (s *FileSecretService) Put(ctx context.Context, req *secretpb.SecretPutRequest) (*secretpb.SecretPutResponse, error) {
+	newErr := grpc_errors.ErrorsWithScope("FileSecretService.Put")
+
+	dir, err := s.secretDir(req.GetSecret().GetName())
+	if err != nil {
+		return nil, newErr(codes.InvalidArgument, "invalid secret", err)
+	}
+
+	if len(req.Value) == 0 {
+		return nil, newErr(codes.InvalidArgument, "secret value cannot be empty", nil)
+	}
+
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	if err := os.MkdirAll(dir, 0o700); err != nil {
+		return nil, newErr(codes.Internal, "unable to create secret directory", err)
+	}
+
+	latest, err := s.latestVersion(dir)
+	if err != nil {
+		return nil, newErr(codes.Internal, "unable to determine latest secret version", err)
+	}
+
+	version := strconv.Itoa(latest + 1)
+
+	if err := os.WriteFile(filepath.Join(dir, version), req.Value, 0o600); err != nil {
+		return nil, newErr(codes.Internal, "unable to put secret", err)
+	}
+
+	if err := os.WriteFile(filepath.Join(dir, latestFile), []byte(version), 0o600); err != nil {
+		return nil, newErr(codes.Internal, "unable to update latest secret version", err)
+	}
+
+	return &secretpb.SecretPutResponse{
+		SecretVersion: &secretpb.SecretVersion{
+			Secret: &secretpb.Secret{
+				Name: req.Secret.Name,
+			},
+			Version: version,
+		},
+	}, nil
+}
+
+// Access - Retrieve a secret value
+func (s *FileSecretService) Access(ctx context.Context, req *secretpb.SecretAccessRequest) (*secretpb.SecretAccessResponse, error) {
+	newErr := grpc_errors.ErrorsWithScope("FileSecretService.Access")
+
+	dir, err := s.secretDir(req.GetSecretVersion().GetSecret().GetName())
+	if err != nil {
+		return nil, newErr(codes.InvalidArgument, "invalid secret", err)
+	}
+
+	version := req.GetSecretVersion().GetVersion()
+	if version == "" {
+		return nil, newErr(codes.InvalidArgument, "secret version cannot be blank or empty", nil)
+	}
+
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	if strings.ToLower(version) == latestFile {
+		latest, err := s.latestVersion(dir)
+		if err != nil {
+			return nil, newErr(codes.Internal, "unable to determine latest secret version", err)
+		}
+
+		if latest == 0 {
+			return nil, newErr(codes.NotFound, fmt.Sprintf("secret %s has no versions", req.SecretVersion.Secret.Name), nil)
+		}
+
+		version = strconv.Itoa(latest)
+	} else if _, err := strconv.Atoi(version); err != nil {
+		return nil, newErr(codes.InvalidArgument, fmt.Sprintf("invalid secret version %q", version), err)
+	}
+
+	state, err := s.versionState(dir, version)
+	if err != nil {
+		if errors.Is(err, fs.ErrNotExist) {
+			return nil, newErr(codes.NotFound, "secret version not found", err)
+		}
+
+		return nil, newErr(codes.Internal, "failed to retrieve secret value", err)
+	}
+
+	if state != secretpb.SecretVersionState_Enabled {
+		return nil, newErr(codes.FailedPrecondition, fmt.Sprintf("secret version %s is %s", version, strings.ToLower(state.String())), nil)
+	}
+
+	value, err := os.ReadFile(filepath.Join(dir, version))
+	if err != nil {
+		return nil, newErr(codes.Internal, "failed to retrieve secret value", err)
+	}
+
+	return &secretpb.SecretAccessResponse{
+		SecretVersion: &secretpb.SecretVersion{
+			Secret:  req.SecretVersion.Secret,
+			Version: version,
+		},
+		Value: value,
+	}, nil
+}
+
+// versionState - returns the state of a version from the name of its file, returning fs.ErrNotExist if the version doesn't exist
+func (s *FileSecretService) versionState(dir string, version string) (secretpb.SecretVersionState, error) {
+	for state, suffix := range versionSuffixes {
+		_, err := os.Stat(filepath.Join(dir, version+suffix))
+		if err == nil {
+			return state, nil
+		}
+
+		if !errors.Is(err, fs.ErrNotExist) {
+			return 0, err
+		}
+	}
+
+	return 0, fs.ErrNotExist
+}
+
+// ListVersions - List the versions of a secret, newest first
+func (s *FileSecretService) ListVersions(ctx context.Context, req *secretpb.SecretListVersionsRequest) (*secretpb.SecretListVersionsResponse, error) {
+	newErr := grpc_errors.ErrorsWithScope("FileSecretService.ListVersions")
+
+	dir, err := s.secretDir(req.GetSecret().GetName())
+	if err != nil {
+		return nil, newErr(codes.InvalidArgument, "invalid secret", err)
+	}
+
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	entries, err := os.ReadDir(dir)
+	if err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return nil, newErr(codes.Internal, "unable to list secret versions", err)
+	}
+
+	versions := []*secretpb.SecretVersionDetails{}
+	for _, entry := range entries {
+		version, state := entry.Name(), secretpb.SecretVersionState_Enabled
+
+		if trimmed, ok := strings.CutSuffix(version, versionSuffixes[secretpb.SecretVersionState_Disabled]); ok {
+			version, state = trimmed, secretpb.SecretVersionState_Disabled
+		} else if trimmed, ok := strings.CutSuffix(version, versionSuffixes[secretpb.SecretVersionState_Destroyed]); ok {
+			version, state = trimmed, secretpb.SecretVersionState_Destroyed
+		}
+
+		// skips the latest version pointer
+		if _, err := strconv.Atoi(version); err != nil {
+			continue
+		}
+
+		info, err := entry.Info()
+		if err != nil {
+			return nil, newErr(codes.Internal, "unable to list secret versions", err)
+		}
+
+		versions = append(versions, &secretpb.SecretVersionDetails{
+			SecretVersion: &secretpb.SecretVersion{
+				Secret:  req.Secret,
+				Version: version,
+			},
+			CreateTime: timestamppb.New(info.ModTime()),
+			State:      state,
+		})
+	}
+
+	sort.Slice(versions, func(i, j int) bool {
+		a, _ := strconv.Atoi(versions[i].SecretVersion.Version)
+		b, _ := strconv.Atoi(versions[j].SecretVersion.Version)
+
+		return a > b
+	})
+
+	return &secretpb.SecretListVersionsResponse{
+		Versions: versions,
+	}, nil
+}
+
+// changeVersionState - moves a version of a secret between states, returning an error if the version isn't in one of the expected states
+func (s *FileSecretService) changeVersionState(secretVersion *secretpb.SecretVersion, to secretpb.SecretVersionState, from ...secretpb.SecretVersionState) (codes.Code, error) {
+	dir, err := s.secretDir(secretVersion.GetSecret().GetName())
+	if err != nil {
+		return codes.InvalidArgument, err
+	}
+
+	version := secretVersion.GetVersion()
+	if _, err := strconv.Atoi(version); err != nil {
+		return codes.InvalidArgument, fmt.Errorf("invalid secret version %q", version)
+	}
+
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	state, err := s.versionState(dir, version)
+	if err != nil {
+		if errors.Is(err, fs.ErrNotExist) {
+			return codes.NotFound, fmt.Errorf("secret version not found")
+		}
+
+		return codes.Internal, err
+	}
+
+	if state == to {
+		return codes.OK, nil
+	}
+
+	if !slices.Contains(from, state) {
+		return codes.FailedPrecondition, fmt.Errorf("secret version %s is %s", version, strings.ToLower(state.String()))
+	}
+
+	current := filepath.Join(dir, version+versionSuffixes[state])
+
+	if to == secretpb.SecretVersionState_Destroyed {
+		info, err := os.Stat(current)
+		if err != nil {
+			return codes.Internal, err
+		}
+
+		marker := filepath.Join(dir, version+versionSuffixes[to])
+		if err := os.WriteFile(marker, []byte{}, 0o600); err != nil {
+			return codes.Internal, err
+		}
+
+		// the marker keeps the creation time of the version for listing
+		if err := os.Chtimes(marker, info.ModTime(), info.ModTime()); err != nil {
+			return codes.Internal, err
+		}
+
+		if err := os.Remove(current); err != nil {
+			return codes.Internal, err
+		}
+
+		return codes.OK, nil
+	}
+
+	if err := os.Rename(current, filepath.Join(dir, version+versionSuffixes[to])); err != nil {
+		return codes.Internal, err
+	}
+
+	return codes.OK, nil
+}
+
+// DisableVersion - Disable a version of a secret so it can't be accessed
+func (s *FileSecretService) DisableVersion(ctx context.Context, req *secretpb.SecretDisableVersionRequest) (*secretpb.SecretDisableVersionResponse, error) {
+	newErr := grpc_errors.ErrorsWithScope("FileSecretService.DisableVersion")
+
+	code, err := s.changeVersionState(req.GetSecretVersion(), secretpb.SecretVersionState_Disabled, secretpb.SecretVersionState_Enabled)
+	if err != nil {
+		return nil, newErr(code, "unable to disable secret version", err)
+	}
+
+	return &secretpb.SecretDisableVersionResponse{}, nil
+}
+
+// EnableVersion - Enable a disabled version of a secret
+func (s *FileSecretService) EnableVersion(ctx context.Context, req *secretpb.SecretEnableVersionRequest) (*secretpb.SecretEnableVersionResponse, error) {
+	newErr := grpc_errors.ErrorsWithScope("FileSecretService.EnableVersion")
+
+	code, err := s.changeVersionState(req.GetSecretVersion(), secretpb.SecretVersionState_Enabled, secretpb.SecretVersionState_Disabled)
+	if err != nil {
+		return nil, newErr(code, "unable to enable secret version", err)
+	}
+
+	return &secretpb.SecretEnableVersionResponse{}, nil
+}
+
+// DestroyVersion - Delete the value of a version of a secret
+func (s *FileSecretService) DestroyVersion(ctx context.Context, req *secretpb.SecretDestroyVersionRequest) (*secretpb.SecretDestroyVersionResponse, error) {
+	newErr := grpc_errors.ErrorsWithScope("FileSecretService.DestroyVersion")
+
+	code, err := s.changeVersionState(req.GetSecretVersion(), secretpb.SecretVersionState_Destroyed, secretpb.SecretVersionState_Enabled, secretpb.SecretVersionState_Disabled)
+	if err != nil {
+		return nil, newErr(code, "unable to destroy secret version", err)
+	}
+
+	return &secretpb.SecretDestroyVersionResponse{}, nil
+}
+
+// New - create a new file backed secret manager, storing secrets under root
+func New(root string) (*FileSecretService, error) {
+	if err := os.MkdirAll(root, 0o700); err != nil {
+		return nil, fmt.Errorf("unable to create secrets directory %s: %w", root, err)
+	}
+
+	return &FileSecretService{
+		root: root,
+	}, nil
+}
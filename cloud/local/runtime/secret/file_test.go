@@ -0,0 +1,205 @@
+// Copyright 2021 Nitric Technologies Pty Ltd.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package secret
+
+import (
+	"context"
+	"os"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	secretpb "github.com/nitrictech/nitric/core/pkg/proto/secrets/v1"
+)
+
+var _ = Describe("FileSecretService", func() {
+	testSecret := &secretpb.Secret{Name: "test-secret"}
+
+	var root string
+
+	BeforeEach(func() {
+		var err error
+		root, err = os.MkdirTemp("", "nitric-secrets-*")
+		Expect(err).ShouldNot(HaveOccurred())
+	})
+
+	AfterEach(func() {
+		Expect(os.RemoveAll(root)).To(Succeed())
+	})
+
+	Context("Put", func() {
+		When("putting a new value", func() {
+			It("should create sequential versions", func() {
+				plugin, err := New(root)
+				Expect(err).ShouldNot(HaveOccurred())
+
+				first, err := plugin.Put(context.TODO(), &secretpb.SecretPutRequest{Secret: testSecret, Value: []byte("one")})
+				Expect(err).ShouldNot(HaveOccurred())
+				Expect(first.SecretVersion.Version).To(Equal("1"))
+
+				second, err := plugin.Put(context.TODO(), &secretpb.SecretPutRequest{Secret: testSecret, Value: []byte("two")})
+				Expect(err).ShouldNot(HaveOccurred())
+				Expect(second.SecretVersion.Version).To(Equal("2"))
+			})
+		})
+
+		When("the secret name would escape the secrets directory", func() {
+			It("should return an invalid argument error", func() {
+				plugin, err := New(root)
+				Expect(err).ShouldNot(HaveOccurred())
+
+				_, err = plugin.Put(context.TODO(), &secretpb.SecretPutRequest{Secret: &secretpb.Secret{Name: "../escape"}, Value: []byte("one")})
+				Expect(status.Code(err)).To(Equal(codes.InvalidArgument))
+			})
+		})
+	})
+
+	Context("Access", func() {
+		When("accessing the latest version", func() {
+			It("should return the most recently put value", func() {
+				plugin, err := New(root)
+				Expect(err).ShouldNot(HaveOccurred())
+
+				_, err = plugin.Put(context.TODO(), &secretpb.SecretPutRequest{Secret: testSecret, Value: []byte("one")})
+				Expect(err).ShouldNot(HaveOccurred())
+				_, err = plugin.Put(context.TODO(), &secretpb.SecretPutRequest{Secret: testSecret, Value: []byte("two")})
+				Expect(err).ShouldNot(HaveOccurred())
+
+				resp, err := plugin.Access(context.TODO(), &secretpb.SecretAccessRequest{
+					SecretVersion: &secretpb.SecretVersion{Secret: testSecret, Version: "latest"},
+				})
+				Expect(err).ShouldNot(HaveOccurred())
+				Expect(resp.Value).To(Equal([]byte("two")))
+				Expect(resp.SecretVersion.Version).To(Equal("2"))
+			})
+		})
+
+		When("accessing a pinned version", func() {
+			It("should return that version's value", func() {
+				plugin, err := New(root)
+				Expect(err).ShouldNot(HaveOccurred())
+
+				_, err = plugin.Put(context.TODO(), &secretpb.SecretPutRequest{Secret: testSecret, Value: []byte("one")})
+				Expect(err).ShouldNot(HaveOccurred())
+				_, err = plugin.Put(context.TODO(), &secretpb.SecretPutRequest{Secret: testSecret, Value: []byte("two")})
+				Expect(err).ShouldNot(HaveOccurred())
+
+				resp, err := plugin.Access(context.TODO(), &secretpb.SecretAccessRequest{
+					SecretVersion: &secretpb.SecretVersion{Secret: testSecret, Version: "1"},
+				})
+				Expect(err).ShouldNot(HaveOccurred())
+				Expect(resp.Value).To(Equal([]byte("one")))
+			})
+		})
+
+		When("the secret has no versions", func() {
+			It("should return a not found error", func() {
+				plugin, err := New(root)
+				Expect(err).ShouldNot(HaveOccurred())
+
+				_, err = plugin.Access(context.TODO(), &secretpb.SecretAccessRequest{
+					SecretVersion: &secretpb.SecretVersion{Secret: testSecret, Version: "latest"},
+				})
+				Expect(status.Code(err)).To(Equal(codes.NotFound))
+			})
+		})
+	})
+
+	Context("Version lifecycle", func() {
+		var plugin *FileSecretService
+
+		BeforeEach(func() {
+			var err error
+			plugin, err = New(root)
+			Expect(err).ShouldNot(HaveOccurred())
+
+			for _, value := range []string{"one", "two", "three"} {
+				_, err = plugin.Put(context.TODO(), &secretpb.SecretPutRequest{Secret: testSecret, Value: []byte(value)})
+				Expect(err).ShouldNot(HaveOccurred())
+			}
+		})
+
+		When("a version is disabled", func() {
+			It("should not be accessible until it's enabled again", func() {
+				version := &secretpb.SecretVersion{Secret: testSecret, Version: "2"}
+
+				_, err := plugin.DisableVersion(context.TODO(), &secretpb.SecretDisableVersionRequest{SecretVersion: version})
+				Expect(err).ShouldNot(HaveOccurred())
+
+				_, err = plugin.Access(context.TODO(), &secretpb.SecretAccessRequest{SecretVersion: version})
+				Expect(status.Code(err)).To(Equal(codes.FailedPrecondition))
+
+				_, err = plugin.EnableVersion(context.TODO(), &secretpb.SecretEnableVersionRequest{SecretVersion: version})
+				Expect(err).ShouldNot(HaveOccurred())
+
+				resp, err := plugin.Access(context.TODO(), &secretpb.SecretAccessRequest{SecretVersion: version})
+				Expect(err).ShouldNot(HaveOccurred())
+				Expect(resp.Value).To(Equal([]byte("two")))
+			})
+		})
+
+		When("a version is destroyed", func() {
+			It("should not be accessible or enabled again", func() {
+				version := &secretpb.SecretVersion{Secret: testSecret, Version: "1"}
+
+				_, err := plugin.DestroyVersion(context.TODO(), &secretpb.SecretDestroyVersionRequest{SecretVersion: version})
+				Expect(err).ShouldNot(HaveOccurred())
+
+				_, err = plugin.Access(context.TODO(), &secretpb.SecretAccessRequest{SecretVersion: version})
+				Expect(status.Code(err)).To(Equal(codes.FailedPrecondition))
+
+				_, err = plugin.EnableVersion(context.TODO(), &secretpb.SecretEnableVersionRequest{SecretVersion: version})
+				Expect(status.Code(err)).To(Equal(codes.FailedPrecondition))
+			})
+		})
+
+		When("listing the versions", func() {
+			It("should return every version newest first with its state", func() {
+				_, err := plugin.DisableVersion(context.TODO(), &secretpb.SecretDisableVersionRequest{
+					SecretVersion: &secretpb.SecretVersion{Secret: testSecret, Version: "2"},
+				})
+				Expect(err).ShouldNot(HaveOccurred())
+
+				_, err = plugin.DestroyVersion(context.TODO(), &secretpb.SecretDestroyVersionRequest{
+					SecretVersion: &secretpb.SecretVersion{Secret: testSecret, Version: "1"},
+				})
+				Expect(err).ShouldNot(HaveOccurred())
+
+				resp, err := plugin.ListVersions(context.TODO(), &secretpb.SecretListVersionsRequest{Secret: testSecret})
+				Expect(err).ShouldNot(HaveOccurred())
+				Expect(resp.Versions).To(HaveLen(3))
+
+				Expect(resp.Versions[0].SecretVersion.Version).To(Equal("3"))
+				Expect(resp.Versions[0].State).To(Equal(secretpb.SecretVersionState_Enabled))
+				Expect(resp.Versions[1].SecretVersion.Version).To(Equal("2"))
+				Expect(resp.Versions[1].State).To(Equal(secretpb.SecretVersionState_Disabled))
+				Expect(resp.Versions[2].SecretVersion.Version).To(Equal("1"))
+				Expect(resp.Versions[2].State).To(Equal(secretpb.SecretVersionState_Destroyed))
+				Expect(resp.Versions[2].CreateTime).ToNot(BeNil())
+			})
+		})
+
+		When("the version doesn't exist", func() {
+			It("should return a not found error", func() {
+				_, err := plugin.DisableVersion(context.TODO(), &secretpb.SecretDisableVersionRequest{
+					SecretVersion: &secretpb.SecretVersion{Secret: testSecret, Version: "4"},
+				})
+				Expect(status.Code(err)).To(Equal(codes.NotFound))
+			})
+		})
+	})
+})
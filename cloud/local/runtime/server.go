@@ -0,0 +1,127 @@
+// Copyright 2021 Nitric Technologies Pty Ltd.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package runtime
+
+import (
+	"fmt"
+	"path/filepath"
+	"strings"
+	"time"
+
+	commonenv "github.com/nitrictech/nitric/cloud/common/runtime/env"
+	"github.com/nitrictech/nitric/cloud/common/runtime/gateway/jobs"
+	"github.com/nitrictech/nitric/cloud/local/runtime/batch"
+	"github.com/nitrictech/nitric/cloud/local/runtime/env"
+	local_gateway "github.com/nitrictech/nitric/cloud/local/runtime/gateway"
+	"github.com/nitrictech/nitric/cloud/local/runtime/keyvalue"
+	"github.com/nitrictech/nitric/cloud/local/runtime/queue"
+	"github.com/nitrictech/nitric/cloud/local/runtime/secret"
+	sql_service "github.com/nitrictech/nitric/cloud/local/runtime/sql"
+	local_storage "github.com/nitrictech/nitric/cloud/local/runtime/storage"
+	"github.com/nitrictech/nitric/cloud/local/runtime/topic"
+	websocketspb "github.com/nitrictech/nitric/core/pkg/proto/websockets/v1"
+	"github.com/nitrictech/nitric/core/pkg/server"
+	jobworkers "github.com/nitrictech/nitric/core/pkg/workers/jobs"
+	kvworkers "github.com/nitrictech/nitric/core/pkg/workers/keyvalue"
+	queueworkers "github.com/nitrictech/nitric/core/pkg/workers/queues"
+	"github.com/nitrictech/nitric/core/pkg/workers/storage"
+	"github.com/nitrictech/nitric/core/pkg/workers/topics"
+)
+
+// gatewayUrl - the URL the local gateway can be reached on, used to build pre-signed URLs
+func gatewayUrl() string {
+	if url := env.NITRIC_LOCAL_GATEWAY_URL.String(); url != "" {
+		return url
+	}
+
+	address := commonenv.GATEWAY_ADDRESS.String()
+	if strings.HasPrefix(address, ":") {
+		address = "localhost" + address
+	}
+
+	return "http://" + address
+}
+
+// NewLocalRuntimeServer - create a nitric server backed entirely by local, in-process plugins.
+//
+//	Messages, blob events, value events and jobs are delivered directly to the workers registered with this server,
+//	so the same listener instances are shared by the resource plugins and the gateway.
+func NewLocalRuntimeServer(opts ...server.ServerOption) (*server.NitricServer, error) {
+	dataDir := env.NITRIC_LOCAL_DATA_DIR.String()
+
+	leaseSeconds, err := env.NITRIC_LOCAL_QUEUE_LEASE_SECONDS.Int()
+	if err != nil {
+		return nil, fmt.Errorf("invalid NITRIC_LOCAL_QUEUE_LEASE_SECONDS: %w", err)
+	}
+
+	subscriberPlugin := topics.New()
+	bucketListenerPlugin := storage.New()
+	keyValueListenerPlugin := kvworkers.New()
+	jobHandlerPlugin := jobworkers.New()
+
+	storagePlugin, err := local_storage.New(filepath.Join(dataDir, "buckets"), gatewayUrl(), bucketListenerPlugin)
+	if err != nil {
+		return nil, err
+	}
+
+	keyValuePlugin, err := keyvalue.New(filepath.Join(dataDir, "kv.db"), keyValueListenerPlugin)
+	if err != nil {
+		return nil, err
+	}
+
+	secretPlugin, err := secret.New(filepath.Join(dataDir, "secrets"))
+	if err != nil {
+		return nil, err
+	}
+
+	topicsPlugin := topic.New(subscriberPlugin)
+	queuesPlugin := queue.New(time.Duration(leaseSeconds) * time.Second)
+	queueListenerPlugin := queueworkers.NewPolling(queuesPlugin)
+	batchPlugin := batch.New(jobHandlerPlugin)
+	sqlPlugin := sql_service.New()
+
+	gatewayPlugin, err := local_gateway.New(storagePlugin)
+	if err != nil {
+		return nil, err
+	}
+
+	if commonenv.NITRIC_JOB_NAME.String() != "" {
+		// swap out the gateway if we're executing a job
+		gatewayPlugin = jobs.NewDefaultBatchGateway()
+	}
+
+	defaultLocalOpts := []server.ServerOption{
+		server.WithGatewayPlugin(gatewayPlugin),
+		server.WithKeyValuePlugin(keyValuePlugin),
+		server.WithSecretManagerPlugin(secretPlugin),
+		server.WithStoragePlugin(storagePlugin),
+		server.WithTopicsPlugin(topicsPlugin),
+		server.WithQueuesPlugin(queuesPlugin),
+		server.WithBatchPlugin(batchPlugin),
+		server.WithSqlPlugin(sqlPlugin),
+		// websockets require a cloud API gateway to manage connections
+		server.WithWebsocketPlugin(&websocketspb.UnimplementedWebsocketServer{}),
+		server.WithTopicsListenerPlugin(subscriberPlugin),
+		server.WithStorageListenerPlugin(bucketListenerPlugin),
+		server.WithKeyValueListenerPlugin(keyValueListenerPlugin),
+		server.WithQueueListenerPlugin(queueListenerPlugin),
+		server.WithJobHandlerPlugin(jobHandlerPlugin),
+	}
+
+	// append overrides
+	defaultLocalOpts = append(defaultLocalOpts, opts...)
+
+	return server.New(defaultLocalOpts...)
+}
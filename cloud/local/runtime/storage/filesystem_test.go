@@ -0,0 +1,310 @@
+// Copyright 2021 Nitric Technologies Pty Ltd.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package storage
+
+import (
+	"context"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/nitrictech/nitric/cloud/common/runtime/storage/storagetest"
+	storagepb "github.com/nitrictech/nitric/core/pkg/proto/storage/v1"
+)
+
+var _ = Describe("FilesystemStorageService", func() {
+	var root string
+
+	BeforeEach(func() {
+		var err error
+		root, err = os.MkdirTemp("", "nitric-buckets-*")
+		Expect(err).ShouldNot(HaveOccurred())
+	})
+
+	AfterEach(func() {
+		Expect(os.RemoveAll(root)).To(Succeed())
+	})
+
+	Context("Write and Read", func() {
+		When("writing a nested key", func() {
+			It("should be readable and listed", func() {
+				plugin, err := New(root, "http://localhost:9001", nil)
+				Expect(err).ShouldNot(HaveOccurred())
+
+				_, err = plugin.Write(context.TODO(), &storagepb.StorageWriteRequest{
+					BucketName: "test-bucket",
+					Key:        "images/test.png",
+					Body:       []byte("test"),
+				})
+				Expect(err).ShouldNot(HaveOccurred())
+
+				resp, err := plugin.Read(context.TODO(), &storagepb.StorageReadRequest{
+					BucketName: "test-bucket",
+					Key:        "images/test.png",
+				})
+				Expect(err).ShouldNot(HaveOccurred())
+				Expect(resp.Body).To(Equal([]byte("test")))
+
+				list, err := plugin.ListBlobs(context.TODO(), &storagepb.StorageListBlobsRequest{
+					BucketName: "test-bucket",
+					Prefix:     "images/",
+				})
+				Expect(err).ShouldNot(HaveOccurred())
+				Expect(list.Blobs).To(HaveLen(1))
+				Expect(list.Blobs[0].Key).To(Equal("images/test.png"))
+			})
+		})
+
+		When("reading a key that doesn't exist", func() {
+			It("should return a not found error", func() {
+				plugin, err := New(root, "http://localhost:9001", nil)
+				Expect(err).ShouldNot(HaveOccurred())
+
+				_, err = plugin.Read(context.TODO(), &storagepb.StorageReadRequest{
+					BucketName: "test-bucket",
+					Key:        "missing",
+				})
+				Expect(status.Code(err)).To(Equal(codes.NotFound))
+			})
+		})
+
+		When("the key would escape the bucket directory", func() {
+			It("should return an invalid argument error", func() {
+				plugin, err := New(root, "http://localhost:9001", nil)
+				Expect(err).ShouldNot(HaveOccurred())
+
+				_, err = plugin.Write(context.TODO(), &storagepb.StorageWriteRequest{
+					BucketName: "test-bucket",
+					Key:        "../other-bucket/test",
+					Body:       []byte("test"),
+				})
+				Expect(status.Code(err)).To(Equal(codes.InvalidArgument))
+			})
+		})
+	})
+
+	Context("Stat", func() {
+		When("a blob was written with attributes", func() {
+			It("should return the attributes", func() {
+				plugin, err := New(root, "http://localhost:9001", nil)
+				Expect(err).ShouldNot(HaveOccurred())
+
+				_, err = plugin.Write(context.TODO(), &storagepb.StorageWriteRequest{
+					BucketName:   "test-bucket",
+					Key:          "styles.css",
+					Body:         []byte("body {}"),
+					ContentType:  "text/css",
+					CacheControl: "max-age=60",
+					Metadata:     map[string]string{"owner": "test"},
+				})
+				Expect(err).ShouldNot(HaveOccurred())
+
+				resp, err := plugin.Stat(context.TODO(), &storagepb.StorageStatRequest{
+					BucketName: "test-bucket",
+					Key:        "styles.css",
+				})
+				Expect(err).ShouldNot(HaveOccurred())
+				Expect(resp.Blob.Size).To(Equal(int64(7)))
+				Expect(resp.Blob.ContentType).To(Equal("text/css"))
+				Expect(resp.Blob.CacheControl).To(Equal("max-age=60"))
+				Expect(resp.Blob.Metadata).To(Equal(map[string]string{"owner": "test"}))
+				Expect(resp.Blob.Etag).ToNot(BeEmpty())
+			})
+		})
+
+		When("a blob was written without a content type", func() {
+			It("should detect the content type", func() {
+				plugin, err := New(root, "http://localhost:9001", nil)
+				Expect(err).ShouldNot(HaveOccurred())
+
+				_, err = plugin.Write(context.TODO(), &storagepb.StorageWriteRequest{
+					BucketName: "test-bucket",
+					Key:        "test.txt",
+					Body:       []byte("test"),
+				})
+				Expect(err).ShouldNot(HaveOccurred())
+
+				resp, err := plugin.Stat(context.TODO(), &storagepb.StorageStatRequest{
+					BucketName: "test-bucket",
+					Key:        "test.txt",
+				})
+				Expect(err).ShouldNot(HaveOccurred())
+				Expect(resp.Blob.ContentType).To(Equal("text/plain; charset=utf-8"))
+			})
+		})
+
+		When("the blob doesn't exist", func() {
+			It("should return a not found error", func() {
+				plugin, err := New(root, "http://localhost:9001", nil)
+				Expect(err).ShouldNot(HaveOccurred())
+
+				_, err = plugin.Stat(context.TODO(), &storagepb.StorageStatRequest{
+					BucketName: "test-bucket",
+					Key:        "missing",
+				})
+				Expect(status.Code(err)).To(Equal(codes.NotFound))
+			})
+		})
+	})
+
+	Context("ListBlobs", func() {
+		When("listing with a delimiter and limit", func() {
+			It("should page through the blobs and prefixes in key order", func() {
+				plugin, err := New(root, "http://localhost:9001", nil)
+				Expect(err).ShouldNot(HaveOccurred())
+
+				for _, key := range []string{"a.txt", "images/one.png", "images/two.png", "z.txt"} {
+					_, err = plugin.Write(context.TODO(), &storagepb.StorageWriteRequest{
+						BucketName:  "test-bucket",
+						Key:         key,
+						Body:        []byte("test"),
+						ContentType: "text/plain",
+					})
+					Expect(err).ShouldNot(HaveOccurred())
+				}
+
+				first, err := plugin.ListBlobs(context.TODO(), &storagepb.StorageListBlobsRequest{
+					BucketName: "test-bucket",
+					Delimiter:  "/",
+					Limit:      2,
+				})
+				Expect(err).ShouldNot(HaveOccurred())
+				Expect(first.Blobs).To(HaveLen(1))
+				Expect(first.Blobs[0].Key).To(Equal("a.txt"))
+				Expect(first.Blobs[0].Size).To(Equal(int64(4)))
+				Expect(first.Prefixes).To(Equal([]string{"images/"}))
+				Expect(first.NextPageToken).To(Equal("images/"))
+
+				second, err := plugin.ListBlobs(context.TODO(), &storagepb.StorageListBlobsRequest{
+					BucketName:      "test-bucket",
+					Delimiter:       "/",
+					Limit:           2,
+					PageToken:       first.NextPageToken,
+					IncludeMetadata: true,
+				})
+				Expect(err).ShouldNot(HaveOccurred())
+				Expect(second.Blobs).To(HaveLen(1))
+				Expect(second.Blobs[0].Key).To(Equal("z.txt"))
+				Expect(second.Blobs[0].ContentType).To(Equal("text/plain"))
+				Expect(second.Prefixes).To(BeEmpty())
+				Expect(second.NextPageToken).To(BeEmpty())
+			})
+		})
+
+		When("the bucket name would escape the root directory", func() {
+			It("should return an invalid argument error", func() {
+				plugin, err := New(filepath.Join(root, "buckets"), "http://localhost:9001", nil)
+				Expect(err).ShouldNot(HaveOccurred())
+
+				Expect(os.WriteFile(filepath.Join(root, "outside.txt"), []byte("test"), 0o600)).To(Succeed())
+
+				for _, bucket := range []string{"..", "."} {
+					_, err = plugin.ListBlobs(context.TODO(), &storagepb.StorageListBlobsRequest{
+						BucketName: bucket,
+					})
+					Expect(status.Code(err)).To(Equal(codes.InvalidArgument))
+				}
+			})
+		})
+	})
+
+	Context("WriteStream and ReadStream", func() {
+		When("streaming a blob in chunks", func() {
+			It("should be readable in full and by range", func() {
+				plugin, err := New(root, "http://localhost:9001", nil)
+				Expect(err).ShouldNot(HaveOccurred())
+
+				writeStream := storagetest.NewWriteStream("test-bucket", "streamed.txt", []byte("hello "), []byte("world"))
+				Expect(plugin.WriteStream(writeStream)).To(Succeed())
+				Expect(writeStream.Response.Size).To(Equal(int64(11)))
+
+				full := &storagetest.ReadStream{}
+				Expect(plugin.ReadStream(&storagepb.StorageReadStreamRequest{
+					BucketName: "test-bucket",
+					Key:        "streamed.txt",
+				}, full)).To(Succeed())
+				Expect(full.Body.String()).To(Equal("hello world"))
+
+				ranged := &storagetest.ReadStream{}
+				Expect(plugin.ReadStream(&storagepb.StorageReadStreamRequest{
+					BucketName: "test-bucket",
+					Key:        "streamed.txt",
+					Offset:     6,
+					Length:     3,
+				}, ranged)).To(Succeed())
+				Expect(ranged.Body.String()).To(Equal("wor"))
+			})
+		})
+
+		When("the offset is beyond the end of the blob", func() {
+			It("should return an out of range error", func() {
+				plugin, err := New(root, "http://localhost:9001", nil)
+				Expect(err).ShouldNot(HaveOccurred())
+
+				Expect(plugin.WriteStream(storagetest.NewWriteStream("test-bucket", "small.txt", []byte("test")))).To(Succeed())
+
+				err = plugin.ReadStream(&storagepb.StorageReadStreamRequest{
+					BucketName: "test-bucket",
+					Key:        "small.txt",
+					Offset:     10,
+				}, &storagetest.ReadStream{})
+				Expect(status.Code(err)).To(Equal(codes.OutOfRange))
+			})
+		})
+
+		When("the stream doesn't start with a header", func() {
+			It("should return an invalid argument error", func() {
+				plugin, err := New(root, "http://localhost:9001", nil)
+				Expect(err).ShouldNot(HaveOccurred())
+
+				stream := storagetest.NewHeaderlessWriteStream([]byte("test"))
+
+				Expect(status.Code(plugin.WriteStream(stream))).To(Equal(codes.InvalidArgument))
+			})
+		})
+	})
+
+	Context("PreSignUrl", func() {
+		When("generating a read URL", func() {
+			It("should produce a signature that verifies for the same operation only", func() {
+				plugin, err := New(root, "http://localhost:9001/", nil)
+				Expect(err).ShouldNot(HaveOccurred())
+
+				resp, err := plugin.PreSignUrl(context.TODO(), &storagepb.StoragePreSignUrlRequest{
+					BucketName: "test-bucket",
+					Key:        "test file.txt",
+					Operation:  storagepb.StoragePreSignUrlRequest_READ,
+				})
+				Expect(err).ShouldNot(HaveOccurred())
+				Expect(strings.HasPrefix(resp.Url, "http://localhost:9001/x-nitric-storage/test-bucket/test%20file.txt?")).To(BeTrue())
+
+				signedUrl, err := url.Parse(resp.Url)
+				Expect(err).ShouldNot(HaveOccurred())
+
+				expires := signedUrl.Query().Get("expires")
+				signature := signedUrl.Query().Get("signature")
+
+				Expect(plugin.VerifyPreSignedUrl(storagepb.StoragePreSignUrlRequest_READ, "test-bucket", "test file.txt", expires, signature)).To(Succeed())
+				Expect(plugin.VerifyPreSignedUrl(storagepb.StoragePreSignUrlRequest_WRITE, "test-bucket", "test file.txt", expires, signature)).ToNot(Succeed())
+			})
+		})
+	})
+})
@@ -0,0 +1,121 @@
+// Copyright 2021 Nitric Technologies Pty Ltd.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package topic
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"google.golang.org/grpc/codes"
+
+	grpc_errors "github.com/nitrictech/nitric/core/pkg/grpc/errors"
+	"github.com/nitrictech/nitric/core/pkg/logger"
+	topicspb "github.com/nitrictech/nitric/core/pkg/proto/topics/v1"
+	"github.com/nitrictech/nitric/core/pkg/workers/topics"
+)
+
+// LocalTopicService - delivers published messages directly to subscribers registered with this membrane
+type LocalTopicService struct {
+	subscribers topics.SubscriptionRequestHandler
+}
+
+var _ topicspb.TopicsServer = &LocalTopicService{}
+
+func (s *LocalTopicService) deliver(topicName string, message *topicspb.TopicMessage) {
+	resp, err := s.subscribers.HandleRequest(context.Background(), &topicspb.ServerMessage{
+		Content: &topicspb.ServerMessage_MessageRequest{
+			MessageRequest: &topicspb.MessageRequest{
+				TopicName: topicName,
+				Message:   message,
+				// messages are delivered once without retries
+				DeliveryAttempt: 1,
+			},
+		},
+	})
+	if err != nil {
+		logger.Debugf("message published to topic %s not delivered: %v", topicName, err)
+		return
+	}
+
+	if !resp.GetMessageResponse().GetSuccess() {
+		logger.Errorf("subscriber for topic %s failed to handle message", topicName)
+	}
+}
+
+func (s *LocalTopicService) Publish(ctx context.Context, req *topicspb.TopicPublishRequest) (*topicspb.TopicPublishResponse, error) {
+	newErr := grpc_errors.ErrorsWithScope("LocalTopicService.Publish")
+
+	if req.TopicName == "" {
+		return nil, newErr(codes.InvalidArgument, "topic name must not be empty", nil)
+	}
+
+	if req.Message.GetContent() == nil {
+		return nil, newErr(codes.InvalidArgument, "message content must not be empty", nil)
+	}
+
+	// delivery is asynchronous, matching the semantics of the cloud topic services
+	delay := req.Delay.AsDuration()
+	if delay > 0 {
+		time.AfterFunc(delay, func() {
+			s.deliver(req.TopicName, req.Message)
+		})
+	} else {
+		go s.deliver(req.TopicName, req.Message)
+	}
+
+	return &topicspb.TopicPublishResponse{
+		MessageId: uuid.New().String(),
+	}, nil
+}
+
+func (s *LocalTopicService) PublishBatch(ctx context.Context, req *topicspb.TopicPublishBatchRequest) (*topicspb.TopicPublishBatchResponse, error) {
+	newErr := grpc_errors.ErrorsWithScope("LocalTopicService.PublishBatch")
+
+	if req.TopicName == "" {
+		return nil, newErr(codes.InvalidArgument, "topic name must not be empty", nil)
+	}
+
+	results := make([]*topicspb.TopicPublishResult, len(req.Messages))
+	for i, message := range req.Messages {
+		if message.GetContent() == nil {
+			results[i] = &topicspb.TopicPublishResult{
+				Result: &topicspb.TopicPublishResult_Error{
+					Error: "message content must not be empty",
+				},
+			}
+			continue
+		}
+
+		go s.deliver(req.TopicName, message)
+
+		results[i] = &topicspb.TopicPublishResult{
+			Result: &topicspb.TopicPublishResult_MessageId{
+				MessageId: uuid.New().String(),
+			},
+		}
+	}
+
+	return &topicspb.TopicPublishBatchResponse{
+		Results: results,
+	}, nil
+}
+
+// New - create a new topic service that delivers to the given subscription handler
+func New(subscribers topics.SubscriptionRequestHandler) *LocalTopicService {
+	return &LocalTopicService{
+		subscribers: subscribers,
+	}
+}
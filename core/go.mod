@@ -24,6 +24,9 @@ require (
 require (
 	github.com/valyala/fasthttp v1.55.0
 	github.com/yoheimuta/protolint v0.47.6
+	go.opentelemetry.io/otel v1.29.0
+	go.opentelemetry.io/otel/metric v1.29.0
+	go.opentelemetry.io/otel/trace v1.29.0
 	golang.org/x/sync v0.10.0
 )
 
@@ -80,6 +83,8 @@ require (
 	github.com/gertd/go-pluralize v0.2.0 // indirect
 	github.com/ghostiam/protogetter v0.3.6 // indirect
 	github.com/go-critic/go-critic v0.11.4 // indirect
+	github.com/go-logr/logr v1.4.2 // indirect
+	github.com/go-logr/stdr v1.2.2 // indirect
 	github.com/go-task/slim-sprig v0.0.0-20230315185526-52ccab3ef572 // indirect
 	github.com/go-toolsmith/astcast v1.1.0 // indirect
 	github.com/go-toolsmith/astcopy v1.1.0 // indirect
@@ -5,6 +5,7 @@
 package mock_http
 
 import (
+	context "context"
 	reflect "reflect"
 
 	gomock "github.com/golang/mock/gomock"
@@ -36,18 +37,18 @@ func (m *MockHttpRequestHandler) EXPECT() *MockHttpRequestHandlerMockRecorder {
 }
 
 // HandleRequest mocks base method.
-func (m *MockHttpRequestHandler) HandleRequest(arg0 *fasthttp.Request) (*fasthttp.Response, error) {
+func (m *MockHttpRequestHandler) HandleRequest(arg0 context.Context, arg1 *fasthttp.Request) (*fasthttp.Response, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "HandleRequest", arg0)
+	ret := m.ctrl.Call(m, "HandleRequest", arg0, arg1)
 	ret0, _ := ret[0].(*fasthttp.Response)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // HandleRequest indicates an expected call of HandleRequest.
-func (mr *MockHttpRequestHandlerMockRecorder) HandleRequest(arg0 interface{}) *gomock.Call {
+func (mr *MockHttpRequestHandlerMockRecorder) HandleRequest(arg0, arg1 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "HandleRequest", reflect.TypeOf((*MockHttpRequestHandler)(nil).HandleRequest), arg0)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "HandleRequest", reflect.TypeOf((*MockHttpRequestHandler)(nil).HandleRequest), arg0, arg1)
 }
 
 // Proxy mocks base method.
@@ -0,0 +1,79 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: github.com/nitrictech/nitric/core/pkg/workers/keyvalue (interfaces: StoreRequestHandler)
+
+// Package mock_keyvalue is a generated GoMock package.
+package mock_keyvalue
+
+import (
+	context "context"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+	kvstorepb "github.com/nitrictech/nitric/core/pkg/proto/kvstore/v1"
+)
+
+// MockStoreRequestHandler is a mock of StoreRequestHandler interface.
+type MockStoreRequestHandler struct {
+	ctrl     *gomock.Controller
+	recorder *MockStoreRequestHandlerMockRecorder
+}
+
+// MockStoreRequestHandlerMockRecorder is the mock recorder for MockStoreRequestHandler.
+type MockStoreRequestHandlerMockRecorder struct {
+	mock *MockStoreRequestHandler
+}
+
+// NewMockStoreRequestHandler creates a new mock instance.
+func NewMockStoreRequestHandler(ctrl *gomock.Controller) *MockStoreRequestHandler {
+	mock := &MockStoreRequestHandler{ctrl: ctrl}
+	mock.recorder = &MockStoreRequestHandlerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockStoreRequestHandler) EXPECT() *MockStoreRequestHandlerMockRecorder {
+	return m.recorder
+}
+
+// HandleRequest mocks base method.
+func (m *MockStoreRequestHandler) HandleRequest(arg0 context.Context, arg1 *kvstorepb.ServerMessage) (*kvstorepb.ClientMessage, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "HandleRequest", arg0, arg1)
+	ret0, _ := ret[0].(*kvstorepb.ClientMessage)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// HandleRequest indicates an expected call of HandleRequest.
+func (mr *MockStoreRequestHandlerMockRecorder) HandleRequest(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "HandleRequest", reflect.TypeOf((*MockStoreRequestHandler)(nil).HandleRequest), arg0, arg1)
+}
+
+// Listen mocks base method.
+func (m *MockStoreRequestHandler) Listen(arg0 kvstorepb.KeyValueListener_ListenServer) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Listen", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Listen indicates an expected call of Listen.
+func (mr *MockStoreRequestHandlerMockRecorder) Listen(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Listen", reflect.TypeOf((*MockStoreRequestHandler)(nil).Listen), arg0)
+}
+
+// WorkerCount mocks base method.
+func (m *MockStoreRequestHandler) WorkerCount() int {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "WorkerCount")
+	ret0, _ := ret[0].(int)
+	return ret0
+}
+
+// WorkerCount indicates an expected call of WorkerCount.
+func (mr *MockStoreRequestHandlerMockRecorder) WorkerCount() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WorkerCount", reflect.TypeOf((*MockStoreRequestHandler)(nil).WorkerCount))
+}
@@ -0,0 +1,79 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: github.com/nitrictech/nitric/core/pkg/workers/queues (interfaces: QueueRequestHandler)
+
+// Package mock_queues is a generated GoMock package.
+package mock_queues
+
+import (
+	context "context"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+	queuespb "github.com/nitrictech/nitric/core/pkg/proto/queues/v1"
+)
+
+// MockQueueRequestHandler is a mock of QueueRequestHandler interface.
+type MockQueueRequestHandler struct {
+	ctrl     *gomock.Controller
+	recorder *MockQueueRequestHandlerMockRecorder
+}
+
+// MockQueueRequestHandlerMockRecorder is the mock recorder for MockQueueRequestHandler.
+type MockQueueRequestHandlerMockRecorder struct {
+	mock *MockQueueRequestHandler
+}
+
+// NewMockQueueRequestHandler creates a new mock instance.
+func NewMockQueueRequestHandler(ctrl *gomock.Controller) *MockQueueRequestHandler {
+	mock := &MockQueueRequestHandler{ctrl: ctrl}
+	mock.recorder = &MockQueueRequestHandlerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockQueueRequestHandler) EXPECT() *MockQueueRequestHandlerMockRecorder {
+	return m.recorder
+}
+
+// HandleRequest mocks base method.
+func (m *MockQueueRequestHandler) HandleRequest(arg0 context.Context, arg1 *queuespb.ServerMessage) (*queuespb.ClientMessage, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "HandleRequest", arg0, arg1)
+	ret0, _ := ret[0].(*queuespb.ClientMessage)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// HandleRequest indicates an expected call of HandleRequest.
+func (mr *MockQueueRequestHandlerMockRecorder) HandleRequest(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "HandleRequest", reflect.TypeOf((*MockQueueRequestHandler)(nil).HandleRequest), arg0, arg1)
+}
+
+// Listen mocks base method.
+func (m *MockQueueRequestHandler) Listen(arg0 queuespb.QueueListener_ListenServer) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Listen", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Listen indicates an expected call of Listen.
+func (mr *MockQueueRequestHandlerMockRecorder) Listen(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Listen", reflect.TypeOf((*MockQueueRequestHandler)(nil).Listen), arg0)
+}
+
+// WorkerCount mocks base method.
+func (m *MockQueueRequestHandler) WorkerCount() int {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "WorkerCount")
+	ret0, _ := ret[0].(int)
+	return ret0
+}
+
+// WorkerCount indicates an expected call of WorkerCount.
+func (mr *MockQueueRequestHandlerMockRecorder) WorkerCount() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WorkerCount", reflect.TypeOf((*MockQueueRequestHandler)(nil).WorkerCount))
+}
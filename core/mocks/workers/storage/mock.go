@@ -5,6 +5,7 @@
 package mock_storage
 
 import (
+	context "context"
 	reflect "reflect"
 
 	gomock "github.com/golang/mock/gomock"
@@ -35,18 +36,18 @@ func (m *MockBucketRequestHandler) EXPECT() *MockBucketRequestHandlerMockRecorde
 }
 
 // HandleRequest mocks base method.
-func (m *MockBucketRequestHandler) HandleRequest(arg0 *storagepb.ServerMessage) (*storagepb.ClientMessage, error) {
+func (m *MockBucketRequestHandler) HandleRequest(arg0 context.Context, arg1 *storagepb.ServerMessage) (*storagepb.ClientMessage, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "HandleRequest", arg0)
+	ret := m.ctrl.Call(m, "HandleRequest", arg0, arg1)
 	ret0, _ := ret[0].(*storagepb.ClientMessage)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // HandleRequest indicates an expected call of HandleRequest.
-func (mr *MockBucketRequestHandlerMockRecorder) HandleRequest(arg0 interface{}) *gomock.Call {
+func (mr *MockBucketRequestHandlerMockRecorder) HandleRequest(arg0, arg1 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "HandleRequest", reflect.TypeOf((*MockBucketRequestHandler)(nil).HandleRequest), arg0)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "HandleRequest", reflect.TypeOf((*MockBucketRequestHandler)(nil).HandleRequest), arg0, arg1)
 }
 
 // Listen mocks base method.
@@ -15,7 +15,13 @@
 package keyvalue
 
 import (
+	"encoding/base64"
 	"fmt"
+	"time"
+
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/durationpb"
+	"google.golang.org/protobuf/types/known/structpb"
 
 	v1 "github.com/nitrictech/nitric/core/pkg/proto/kvstore/v1"
 )
@@ -45,6 +51,161 @@ func ValidateValueRef(key *v1.ValueRef) error {
 	return nil
 }
 
+// ValidateTtl - validates the time-to-live of a value, a nil ttl means the value never expires
+func ValidateTtl(ttl *durationpb.Duration) error {
+	if ttl == nil {
+		return nil
+	}
+
+	if err := ttl.CheckValid(); err != nil {
+		return fmt.Errorf("invalid ttl: %w", err)
+	}
+
+	if ttl.AsDuration() <= 0 {
+		return fmt.Errorf("provide a positive ttl")
+	}
+
+	return nil
+}
+
+// ExpiresAt - returns when a value set now with the given time-to-live expires, the zero time if it never expires
+func ExpiresAt(ttl *durationpb.Duration) time.Time {
+	if ttl == nil {
+		return time.Time{}
+	}
+
+	return time.Now().Add(ttl.AsDuration())
+}
+
+// ValidatePrecondition - validates the precondition of a write, a nil precondition is always met.
+// Deletes don't support the precondition that a key doesn't have a value.
+func ValidatePrecondition(precondition *v1.KvStorePrecondition, isDelete bool) error {
+	switch c := precondition.GetCondition().(type) {
+	case nil:
+		return nil
+	case *v1.KvStorePrecondition_Version:
+		if c.Version == "" {
+			return fmt.Errorf("provide non-blank precondition version")
+		}
+	case *v1.KvStorePrecondition_Exists:
+		if isDelete && !c.Exists {
+			return fmt.Errorf("deleting a key only if it doesn't have a value isn't supported")
+		}
+	}
+
+	return nil
+}
+
+// ValidateScan - validates the paging options of a key scan
+func ValidateScan(req *v1.KvStoreScanKeysRequest) error {
+	if req.Limit < 0 {
+		return fmt.Errorf("provide a non-negative limit")
+	}
+
+	if _, err := ScanStartAfter(req.ContinuationToken); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// ScanContinuationToken - returns the token that resumes a key scan after the given key
+func ScanContinuationToken(key string) string {
+	return base64.RawURLEncoding.EncodeToString([]byte(key))
+}
+
+// ScanStartAfter - returns the key a key scan resumes after from its continuation token, blank if there is no token
+func ScanStartAfter(token string) (string, error) {
+	if token == "" {
+		return "", nil
+	}
+
+	key, err := base64.RawURLEncoding.DecodeString(token)
+	if err != nil || len(key) == 0 {
+		return "", fmt.Errorf("invalid continuation token")
+	}
+
+	return string(key), nil
+}
+
+// ValidateBatchSize - validates the number of items in a batch or transaction is within a provider's limit.
+// A limit of 0 permits any number of items.
+func ValidateBatchSize(size int, limit int) error {
+	if size == 0 {
+		return fmt.Errorf("provide at least one item")
+	}
+
+	if limit > 0 && size > limit {
+		return fmt.Errorf("%d items exceeds the limit of %d items per request", size, limit)
+	}
+
+	return nil
+}
+
+// ValidateTransaction - validates the operations of a transaction, each key may only be written once per transaction
+func ValidateTransaction(operations []*v1.KvStoreTransactOperation, limit int) error {
+	if err := ValidateBatchSize(len(operations), limit); err != nil {
+		return err
+	}
+
+	written := map[string]bool{}
+	for i, op := range operations {
+		ref, err := TransactOperationRef(op)
+		if err != nil {
+			return fmt.Errorf("invalid operation %d, %w", i, err)
+		}
+
+		if err := ValidateValueRef(ref); err != nil {
+			return fmt.Errorf("invalid operation %d, %w", i, err)
+		}
+
+		if set := op.GetSet(); set != nil && set.Content == nil {
+			return fmt.Errorf("invalid operation %d, provide non-nil content", i)
+		}
+
+		id := ref.Store + "/" + ref.Key
+		if written[id] {
+			return fmt.Errorf("invalid operation %d, key %s in store %s is written more than once", i, ref.Key, ref.Store)
+		}
+		written[id] = true
+	}
+
+	return nil
+}
+
+// TransactOperationRef - returns the ValueRef written by a transaction operation
+func TransactOperationRef(op *v1.KvStoreTransactOperation) (*v1.ValueRef, error) {
+	switch o := op.GetOperation().(type) {
+	case *v1.KvStoreTransactOperation_Set:
+		return o.Set.GetRef(), nil
+	case *v1.KvStoreTransactOperation_Delete:
+		return o.Delete, nil
+	default:
+		return nil, fmt.Errorf("provide a set or delete operation")
+	}
+}
+
+// ItemError - converts the error returned for a single item of a batch operation to an item error,
+// the cause of grpc errors is included in the message.
+func ItemError(ref *v1.ValueRef, err error) *v1.KvStoreItemError {
+	st := status.Convert(err)
+
+	message := st.Message()
+	for _, detail := range st.Details() {
+		if details, ok := detail.(*structpb.Struct); ok {
+			if cause, ok := details.AsMap()["cause"].(string); ok {
+				message = fmt.Sprintf("%s - %s", message, cause)
+			}
+		}
+	}
+
+	return &v1.KvStoreItemError{
+		Ref:     ref,
+		Code:    int32(st.Code()),
+		Message: message,
+	}
+}
+
 // ValidateCollection - validates a collection key, used for operations on a single document/collection e.g. Get, Set, Delete
 // func ValidateCollection(collection *v1.Collection) error {
 // 	if collection == nil {
@@ -14,7 +14,15 @@
 package keyvalue_test
 
 import (
+	"fmt"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/protobuf/types/known/durationpb"
+	"google.golang.org/protobuf/types/known/structpb"
+
 	document "github.com/nitrictech/nitric/core/pkg/decorators/keyvalue"
+	grpc_errors "github.com/nitrictech/nitric/core/pkg/grpc/errors"
 	kvstorepb "github.com/nitrictech/nitric/core/pkg/proto/kvstore/v1"
 
 	. "github.com/onsi/ginkgo"
@@ -47,4 +55,170 @@ var _ = Describe("Document Plugin", func() {
 			})
 		})
 	})
+	When("ValidateTtl", func() {
+		When("The ttl is nil", func() {
+			It("should not return error", func() {
+				Expect(document.ValidateTtl(nil)).To(BeNil())
+			})
+		})
+		When("The ttl is positive", func() {
+			It("should not return error", func() {
+				Expect(document.ValidateTtl(durationpb.New(time.Minute))).To(BeNil())
+			})
+		})
+		When("The ttl is negative", func() {
+			It("should return error", func() {
+				err := document.ValidateTtl(durationpb.New(-time.Minute))
+				Expect(err.Error()).To(ContainSubstring("provide a positive ttl"))
+			})
+		})
+	})
+
+	When("ExpiresAt", func() {
+		When("The ttl is nil", func() {
+			It("should never expire", func() {
+				Expect(document.ExpiresAt(nil).IsZero()).To(BeTrue())
+			})
+		})
+		When("The ttl is set", func() {
+			It("should expire after the ttl", func() {
+				expiresAt := document.ExpiresAt(durationpb.New(time.Hour))
+				Expect(expiresAt).To(BeTemporally("~", time.Now().Add(time.Hour), time.Second))
+			})
+		})
+	})
+
+	When("ValidatePrecondition", func() {
+		When("There is no precondition", func() {
+			It("should not return error", func() {
+				Expect(document.ValidatePrecondition(nil, false)).To(BeNil())
+			})
+		})
+		When("The version is blank", func() {
+			It("should return error", func() {
+				err := document.ValidatePrecondition(&kvstorepb.KvStorePrecondition{
+					Condition: &kvstorepb.KvStorePrecondition_Version{},
+				}, false)
+				Expect(err.Error()).To(ContainSubstring("provide non-blank precondition version"))
+			})
+		})
+		When("Setting a key only if it doesn't exist", func() {
+			It("should not return error", func() {
+				Expect(document.ValidatePrecondition(&kvstorepb.KvStorePrecondition{
+					Condition: &kvstorepb.KvStorePrecondition_Exists{Exists: false},
+				}, false)).To(BeNil())
+			})
+		})
+		When("Deleting a key only if it doesn't exist", func() {
+			It("should return error", func() {
+				err := document.ValidatePrecondition(&kvstorepb.KvStorePrecondition{
+					Condition: &kvstorepb.KvStorePrecondition_Exists{Exists: false},
+				}, true)
+				Expect(err.Error()).To(ContainSubstring("isn't supported"))
+			})
+		})
+	})
+
+	When("ValidateScan", func() {
+		When("The limit is negative", func() {
+			It("should return error", func() {
+				err := document.ValidateScan(&kvstorepb.KvStoreScanKeysRequest{Limit: -1})
+				Expect(err.Error()).To(ContainSubstring("provide a non-negative limit"))
+			})
+		})
+		When("The continuation token is invalid", func() {
+			It("should return error", func() {
+				err := document.ValidateScan(&kvstorepb.KvStoreScanKeysRequest{ContinuationToken: "not a token!"})
+				Expect(err.Error()).To(ContainSubstring("invalid continuation token"))
+			})
+		})
+		When("The continuation token was returned by a previous scan", func() {
+			It("should resume after its key", func() {
+				token := document.ScanContinuationToken("customer/1")
+				Expect(document.ValidateScan(&kvstorepb.KvStoreScanKeysRequest{Limit: 10, ContinuationToken: token})).To(BeNil())
+
+				key, err := document.ScanStartAfter(token)
+				Expect(err).To(BeNil())
+				Expect(key).To(Equal("customer/1"))
+			})
+		})
+	})
+
+	When("ValidateBatchSize", func() {
+		When("The batch is empty", func() {
+			It("should return error", func() {
+				err := document.ValidateBatchSize(0, 25)
+				Expect(err.Error()).To(ContainSubstring("provide at least one item"))
+			})
+		})
+		When("The batch exceeds the limit", func() {
+			It("should return error", func() {
+				err := document.ValidateBatchSize(26, 25)
+				Expect(err.Error()).To(ContainSubstring("26 items exceeds the limit of 25 items per request"))
+			})
+		})
+		When("There is no limit", func() {
+			It("should not return error", func() {
+				Expect(document.ValidateBatchSize(1000, 0)).To(BeNil())
+			})
+		})
+	})
+
+	When("ValidateTransaction", func() {
+		content, _ := structpb.NewStruct(map[string]interface{}{"test": "test"})
+		set := func(key string) *kvstorepb.KvStoreTransactOperation {
+			return &kvstorepb.KvStoreTransactOperation{
+				Operation: &kvstorepb.KvStoreTransactOperation_Set{
+					Set: &kvstorepb.Value{
+						Ref:     &kvstorepb.ValueRef{Store: "users", Key: key},
+						Content: content,
+					},
+				},
+			}
+		}
+		del := func(key string) *kvstorepb.KvStoreTransactOperation {
+			return &kvstorepb.KvStoreTransactOperation{
+				Operation: &kvstorepb.KvStoreTransactOperation_Delete{
+					Delete: &kvstorepb.ValueRef{Store: "users", Key: key},
+				},
+			}
+		}
+
+		When("The operations are valid", func() {
+			It("should not return error", func() {
+				Expect(document.ValidateTransaction([]*kvstorepb.KvStoreTransactOperation{set("a"), del("b")}, 100)).To(BeNil())
+			})
+		})
+		When("An operation is empty", func() {
+			It("should return error", func() {
+				err := document.ValidateTransaction([]*kvstorepb.KvStoreTransactOperation{set("a"), {}}, 100)
+				Expect(err.Error()).To(ContainSubstring("invalid operation 1, provide a set or delete operation"))
+			})
+		})
+		When("A key is written more than once", func() {
+			It("should return error", func() {
+				err := document.ValidateTransaction([]*kvstorepb.KvStoreTransactOperation{set("a"), del("a")}, 100)
+				Expect(err.Error()).To(ContainSubstring("key a in store users is written more than once"))
+			})
+		})
+		When("The operations exceed the limit", func() {
+			It("should return error", func() {
+				err := document.ValidateTransaction([]*kvstorepb.KvStoreTransactOperation{set("a"), set("b")}, 1)
+				Expect(err.Error()).To(ContainSubstring("exceeds the limit of 1 items"))
+			})
+		})
+	})
+
+	When("ItemError", func() {
+		It("should include the code, message and cause", func() {
+			ref := &kvstorepb.ValueRef{Store: "users", Key: "a"}
+			newErr := grpc_errors.ErrorsWithScope("Test")
+
+			itemErr := document.ItemError(ref, newErr(codes.NotFound, "not found", fmt.Errorf("missing")))
+
+			Expect(itemErr.Ref).To(Equal(ref))
+			Expect(itemErr.Code).To(Equal(int32(codes.NotFound)))
+			Expect(itemErr.Message).To(Equal("Test not found - missing"))
+		})
+	})
 })
@@ -0,0 +1,277 @@
+// Copyright 2021 Nitric Technologies Pty Ltd.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package decorators
+
+import (
+	"context"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/nitrictech/nitric/core/pkg/logger"
+	secretspb "github.com/nitrictech/nitric/core/pkg/proto/secrets/v1"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const latestVersion = "latest"
+
+// the maximum number of secret versions cached at once, the oldest values are evicted first
+const maxCachedSecrets = 1000
+
+type cachedSecret struct {
+	response  *secretspb.SecretAccessResponse
+	fetchedAt time.Time
+	// set while the value is being refreshed in the background
+	refreshing bool
+}
+
+// secretFetches - tracks the in-flight fetches of a secret, so fetches don't cache a value that changed while they were in flight
+type secretFetches struct {
+	inflight int
+	// incremented when the secret changes while fetches are in flight
+	generation int
+}
+
+// SecretServerCache - caches secret values to avoid calling the provider on every access.
+// Values of pinned versions never change so they're cached until the version is disabled or destroyed,
+// the value of the latest version is cached for the TTL and refreshed in the background before it expires.
+type SecretServerCache struct {
+	inner      secretspb.SecretManagerServer
+	ttl        time.Duration
+	maxEntries int
+	now        func() time.Time
+
+	lock    sync.Mutex
+	entries map[string]*cachedSecret
+	// only holds secrets with fetches in flight
+	fetches map[string]*secretFetches
+}
+
+var _ secretspb.SecretManagerServer = &SecretServerCache{}
+
+func cacheKey(secretName string, version string) string {
+	return secretName + "/" + version
+}
+
+func isLatestVersion(version string) bool {
+	return strings.ToLower(version) == latestVersion
+}
+
+// expired - only the latest version of a secret expires, the lock must be held by the caller
+func (s *SecretServerCache) expired(key string, entry *cachedSecret) bool {
+	return strings.HasSuffix(key, "/"+latestVersion) && s.now().Sub(entry.fetchedAt) >= s.ttl
+}
+
+// invalidate - removes cached values for a secret, the latest version is always removed along with any of the provided versions
+func (s *SecretServerCache) invalidate(secretName string, versions ...string) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	if fetches, ok := s.fetches[secretName]; ok {
+		fetches.generation++
+	}
+
+	delete(s.entries, cacheKey(secretName, latestVersion))
+
+	for _, version := range versions {
+		delete(s.entries, cacheKey(secretName, version))
+	}
+}
+
+// store - caches an entry, evicting expired entries and then the oldest entries to stay within the max entries.
+// the lock must be held by the caller
+func (s *SecretServerCache) store(key string, entry *cachedSecret) {
+	if _, ok := s.entries[key]; !ok && len(s.entries) >= s.maxEntries {
+		for k, e := range s.entries {
+			if s.expired(k, e) {
+				delete(s.entries, k)
+			}
+		}
+
+		for len(s.entries) > 0 && len(s.entries) >= s.maxEntries {
+			oldestKey := ""
+			for k, e := range s.entries {
+				if oldestKey == "" || e.fetchedAt.Before(s.entries[oldestKey].fetchedAt) {
+					oldestKey = k
+				}
+			}
+
+			delete(s.entries, oldestKey)
+		}
+	}
+
+	s.entries[key] = entry
+}
+
+// fetch - accesses a secret from the inner server and caches the result if the secret hasn't changed in the meantime
+func (s *SecretServerCache) fetch(ctx context.Context, key string, req *secretspb.SecretAccessRequest) (*secretspb.SecretAccessResponse, error) {
+	secretName := req.SecretVersion.Secret.Name
+
+	s.lock.Lock()
+	fetches, ok := s.fetches[secretName]
+	if !ok {
+		fetches = &secretFetches{}
+		s.fetches[secretName] = fetches
+	}
+	fetches.inflight++
+	generation := fetches.generation
+	s.lock.Unlock()
+
+	resp, err := s.inner.Access(ctx, req)
+
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	fetches.inflight--
+	if fetches.inflight == 0 {
+		delete(s.fetches, secretName)
+	}
+
+	if err != nil {
+		if entry, ok := s.entries[key]; ok {
+			entry.refreshing = false
+
+			// the latest version was disabled or destroyed elsewhere, so the cached value must no longer be served
+			if code := status.Code(err); code == codes.FailedPrecondition || code == codes.NotFound {
+				delete(s.entries, key)
+			}
+		}
+
+		return nil, err
+	}
+
+	if generation != fetches.generation {
+		return resp, nil
+	}
+
+	entry := &cachedSecret{
+		response:  resp,
+		fetchedAt: s.now(),
+	}
+
+	// the resolved version is also cached, so accessing it by id doesn't call the provider
+	s.store(cacheKey(secretName, resp.GetSecretVersion().GetVersion()), entry)
+
+	if isLatestVersion(req.SecretVersion.Version) {
+		s.store(key, entry)
+	}
+
+	return resp, nil
+}
+
+// refresh - updates the cached value of the latest version of a secret without blocking the caller
+func (s *SecretServerCache) refresh(ctx context.Context, key string, req *secretspb.SecretAccessRequest) {
+	go func() {
+		_, err := s.fetch(ctx, key, req)
+		if err != nil {
+			logger.Warnf("failed to refresh cached secret %s: %v", req.SecretVersion.Secret.Name, err)
+		}
+	}()
+}
+
+func (s *SecretServerCache) Access(ctx context.Context, req *secretspb.SecretAccessRequest) (*secretspb.SecretAccessResponse, error) {
+	secretName := req.SecretVersion.Secret.Name
+	latest := isLatestVersion(req.SecretVersion.Version)
+
+	key := cacheKey(secretName, req.SecretVersion.Version)
+	if latest {
+		key = cacheKey(secretName, latestVersion)
+	}
+
+	s.lock.Lock()
+	entry, ok := s.entries[key]
+
+	if ok && latest {
+		age := s.now().Sub(entry.fetchedAt)
+
+		if age >= s.ttl {
+			delete(s.entries, key)
+			ok = false
+		} else if age >= s.ttl/2 && !entry.refreshing {
+			// refresh ahead of expiry, so frequently accessed secrets are never fetched by the caller
+			entry.refreshing = true
+			s.refresh(context.WithoutCancel(ctx), key, req)
+		}
+	}
+	s.lock.Unlock()
+
+	if ok {
+		return entry.response, nil
+	}
+
+	return s.fetch(ctx, key, req)
+}
+
+func (s *SecretServerCache) Put(ctx context.Context, req *secretspb.SecretPutRequest) (*secretspb.SecretPutResponse, error) {
+	resp, err := s.inner.Put(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	s.invalidate(req.Secret.Name)
+
+	return resp, nil
+}
+
+func (s *SecretServerCache) ListVersions(ctx context.Context, req *secretspb.SecretListVersionsRequest) (*secretspb.SecretListVersionsResponse, error) {
+	return s.inner.ListVersions(ctx, req)
+}
+
+func (s *SecretServerCache) DisableVersion(ctx context.Context, req *secretspb.SecretDisableVersionRequest) (*secretspb.SecretDisableVersionResponse, error) {
+	resp, err := s.inner.DisableVersion(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	s.invalidate(req.SecretVersion.Secret.Name, req.SecretVersion.Version)
+
+	return resp, nil
+}
+
+func (s *SecretServerCache) EnableVersion(ctx context.Context, req *secretspb.SecretEnableVersionRequest) (*secretspb.SecretEnableVersionResponse, error) {
+	resp, err := s.inner.EnableVersion(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	// enabling a version can change which version is the latest on some providers
+	s.invalidate(req.SecretVersion.Secret.Name)
+
+	return resp, nil
+}
+
+func (s *SecretServerCache) DestroyVersion(ctx context.Context, req *secretspb.SecretDestroyVersionRequest) (*secretspb.SecretDestroyVersionResponse, error) {
+	resp, err := s.inner.DestroyVersion(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	s.invalidate(req.SecretVersion.Secret.Name, req.SecretVersion.Version)
+
+	return resp, nil
+}
+
+// SecretsServerWithCache - caches secret values accessed through the inner server, the latest version of a secret is cached for the ttl
+func SecretsServerWithCache(inner secretspb.SecretManagerServer, ttl time.Duration) *SecretServerCache {
+	return &SecretServerCache{
+		inner:      inner,
+		ttl:        ttl,
+		maxEntries: maxCachedSecrets,
+		now:        time.Now,
+		entries:    map[string]*cachedSecret{},
+		fetches:    map[string]*secretFetches{},
+	}
+}
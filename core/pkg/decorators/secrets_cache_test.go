@@ -0,0 +1,353 @@
+// Copyright 2021 Nitric Technologies Pty Ltd.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package decorators
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	secretspb "github.com/nitrictech/nitric/core/pkg/proto/secrets/v1"
+)
+
+// fakeSecrets is a secret store with a single secret, counting how often its values are accessed
+type fakeSecrets struct {
+	secretspb.UnimplementedSecretManagerServer
+
+	lock      sync.Mutex
+	versions  []string
+	values    map[string]string
+	disabled  map[string]bool
+	accesses  int
+	accessing chan struct{}
+}
+
+func newFakeSecrets(values ...string) *fakeSecrets {
+	f := &fakeSecrets{
+		values:   map[string]string{},
+		disabled: map[string]bool{},
+	}
+
+	for _, value := range values {
+		f.put(value)
+	}
+
+	return f
+}
+
+func (f *fakeSecrets) put(value string) string {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+
+	version := fmt.Sprintf("v%d", len(f.versions)+1)
+	f.versions = append(f.versions, version)
+	f.values[version] = value
+
+	return version
+}
+
+func (f *fakeSecrets) disable(version string) {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+
+	f.disabled[version] = true
+}
+
+func (f *fakeSecrets) accessCount() int {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+
+	return f.accesses
+}
+
+func (f *fakeSecrets) Access(ctx context.Context, req *secretspb.SecretAccessRequest) (*secretspb.SecretAccessResponse, error) {
+	f.lock.Lock()
+	accessing := f.accessing
+	f.lock.Unlock()
+
+	// blocks the access until the test allows it to continue
+	if accessing != nil {
+		<-accessing
+	}
+
+	f.lock.Lock()
+	defer f.lock.Unlock()
+
+	f.accesses++
+
+	version := req.SecretVersion.Version
+	if isLatestVersion(version) {
+		version = f.versions[len(f.versions)-1]
+	}
+
+	value, ok := f.values[version]
+	if !ok {
+		return nil, status.Error(codes.NotFound, "secret version not found")
+	}
+
+	if f.disabled[version] {
+		return nil, status.Error(codes.FailedPrecondition, "secret version is not enabled")
+	}
+
+	return &secretspb.SecretAccessResponse{
+		SecretVersion: &secretspb.SecretVersion{
+			Secret:  req.SecretVersion.Secret,
+			Version: version,
+		},
+		Value: []byte(value),
+	}, nil
+}
+
+func (f *fakeSecrets) Put(ctx context.Context, req *secretspb.SecretPutRequest) (*secretspb.SecretPutResponse, error) {
+	version := f.put(string(req.Value))
+
+	return &secretspb.SecretPutResponse{
+		SecretVersion: &secretspb.SecretVersion{
+			Secret:  req.Secret,
+			Version: version,
+		},
+	}, nil
+}
+
+func (f *fakeSecrets) DisableVersion(ctx context.Context, req *secretspb.SecretDisableVersionRequest) (*secretspb.SecretDisableVersionResponse, error) {
+	f.disable(req.SecretVersion.Version)
+
+	return &secretspb.SecretDisableVersionResponse{}, nil
+}
+
+func (f *fakeSecrets) DestroyVersion(ctx context.Context, req *secretspb.SecretDestroyVersionRequest) (*secretspb.SecretDestroyVersionResponse, error) {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+
+	delete(f.values, req.SecretVersion.Version)
+
+	return &secretspb.SecretDestroyVersionResponse{}, nil
+}
+
+type fakeClock struct {
+	lock sync.Mutex
+	time time.Time
+}
+
+func (c *fakeClock) now() time.Time {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	return c.time
+}
+
+func (c *fakeClock) advance(d time.Duration) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	c.time = c.time.Add(d)
+}
+
+var _ = Describe("SecretServerCache", func() {
+	const ttl = 10 * time.Second
+
+	secret := &secretspb.Secret{Name: "secret"}
+
+	var (
+		inner *fakeSecrets
+		clock *fakeClock
+		cache *SecretServerCache
+	)
+
+	access := func(version string) (string, error) {
+		resp, err := cache.Access(context.TODO(), &secretspb.SecretAccessRequest{
+			SecretVersion: &secretspb.SecretVersion{Secret: secret, Version: version},
+		})
+		if err != nil {
+			return "", err
+		}
+
+		return string(resp.Value), nil
+	}
+
+	BeforeEach(func() {
+		inner = newFakeSecrets("first")
+		clock = &fakeClock{time: time.Unix(0, 0)}
+		cache = SecretsServerWithCache(inner, ttl)
+		cache.now = clock.now
+	})
+
+	When("a secret is accessed within the ttl", func() {
+		It("should only access the provider once", func() {
+			Expect(access("latest")).To(Equal("first"))
+			Expect(access("latest")).To(Equal("first"))
+			Expect(access("v1")).To(Equal("first"))
+
+			Expect(inner.accessCount()).To(Equal(1))
+		})
+	})
+
+	When("the ttl has passed", func() {
+		It("should access the provider again", func() {
+			Expect(access("latest")).To(Equal("first"))
+			inner.put("second")
+
+			clock.advance(ttl)
+
+			Expect(access("latest")).To(Equal("second"))
+			Expect(inner.accessCount()).To(Equal(2))
+		})
+
+		It("should keep serving pinned versions from the cache", func() {
+			Expect(access("v1")).To(Equal("first"))
+
+			clock.advance(10 * ttl)
+
+			Expect(access("v1")).To(Equal("first"))
+			Consistently(inner.accessCount, 50*time.Millisecond).Should(Equal(1))
+		})
+	})
+
+	When("more than half of the ttl has passed", func() {
+		It("should serve the cached value while refreshing it in the background", func() {
+			Expect(access("latest")).To(Equal("first"))
+			inner.put("second")
+
+			clock.advance(ttl / 2)
+
+			Expect(access("latest")).To(Equal("first"))
+			Eventually(inner.accessCount).Should(Equal(2))
+			Eventually(func() (string, error) { return access("latest") }).Should(Equal("second"))
+			Expect(inner.accessCount()).To(Equal(2))
+		})
+
+		It("should drop the latest version when the refresh finds it disabled", func() {
+			Expect(access("latest")).To(Equal("first"))
+			inner.disable("v1")
+
+			clock.advance(ttl / 2)
+
+			Expect(access("latest")).To(Equal("first"))
+			Eventually(func() error {
+				_, err := access("latest")
+				return err
+			}).Should(HaveOccurred())
+		})
+	})
+
+	When("the secret is changed through the cache", func() {
+		BeforeEach(func() {
+			Expect(access("latest")).To(Equal("first"))
+		})
+
+		It("should invalidate the latest version on Put", func() {
+			_, err := cache.Put(context.TODO(), &secretspb.SecretPutRequest{Secret: secret, Value: []byte("second")})
+			Expect(err).ShouldNot(HaveOccurred())
+
+			Expect(access("latest")).To(Equal("second"))
+		})
+
+		It("should invalidate the version on DisableVersion", func() {
+			_, err := cache.DisableVersion(context.TODO(), &secretspb.SecretDisableVersionRequest{
+				SecretVersion: &secretspb.SecretVersion{Secret: secret, Version: "v1"},
+			})
+			Expect(err).ShouldNot(HaveOccurred())
+
+			_, err = access("v1")
+			Expect(status.Code(err)).To(Equal(codes.FailedPrecondition))
+			_, err = access("latest")
+			Expect(status.Code(err)).To(Equal(codes.FailedPrecondition))
+		})
+
+		It("should invalidate the version on DestroyVersion", func() {
+			_, err := cache.DestroyVersion(context.TODO(), &secretspb.SecretDestroyVersionRequest{
+				SecretVersion: &secretspb.SecretVersion{Secret: secret, Version: "v1"},
+			})
+			Expect(err).ShouldNot(HaveOccurred())
+
+			_, err = access("v1")
+			Expect(status.Code(err)).To(Equal(codes.NotFound))
+		})
+	})
+
+	When("the secret changes while it's being fetched", func() {
+		It("should not cache the fetched value", func() {
+			inner.accessing = make(chan struct{})
+
+			fetched := make(chan string)
+			go func() {
+				defer GinkgoRecover()
+
+				value, err := access("latest")
+				Expect(err).ShouldNot(HaveOccurred())
+				fetched <- value
+			}()
+
+			Eventually(func() int {
+				cache.lock.Lock()
+				defer cache.lock.Unlock()
+
+				return len(cache.fetches)
+			}).Should(Equal(1))
+
+			_, err := cache.Put(context.TODO(), &secretspb.SecretPutRequest{Secret: secret, Value: []byte("second")})
+			Expect(err).ShouldNot(HaveOccurred())
+
+			close(inner.accessing)
+			Eventually(fetched).Should(Receive())
+
+			Expect(access("latest")).To(Equal("second"))
+			Expect(cache.fetches).To(BeEmpty())
+		})
+	})
+
+	When("more versions are accessed than can be cached", func() {
+		It("should evict the oldest versions", func() {
+			cache.maxEntries = 2
+			inner.put("second")
+			inner.put("third")
+
+			for _, version := range []string{"v1", "v2", "v3"} {
+				_, err := access(version)
+				Expect(err).ShouldNot(HaveOccurred())
+				clock.advance(time.Second)
+			}
+
+			Expect(cache.entries).To(HaveLen(2))
+			Expect(cache.entries).ToNot(HaveKey(cacheKey("secret", "v1")))
+			Expect(cache.fetches).To(BeEmpty())
+		})
+
+		It("should evict the expired latest version before pinned versions", func() {
+			cache.maxEntries = 3
+			inner.put("second")
+
+			Expect(access("v1")).To(Equal("first"))
+			Expect(access("latest")).To(Equal("second"))
+
+			clock.advance(ttl)
+
+			Expect(access("v1")).To(Equal("first"))
+			inner.put("third")
+			Expect(access("v3")).To(Equal("third"))
+
+			Expect(cache.entries).To(HaveKey(cacheKey("secret", "v1")))
+			Expect(cache.entries).To(HaveKey(cacheKey("secret", "v2")))
+			Expect(cache.entries).To(HaveKey(cacheKey("secret", "v3")))
+			Expect(cache.entries).ToNot(HaveKey(cacheKey("secret", latestVersion)))
+		})
+	})
+})
@@ -17,6 +17,7 @@ package decorators
 import (
 	"context"
 	"fmt"
+	"strings"
 
 	secretspb "github.com/nitrictech/nitric/core/pkg/proto/secrets/v1"
 	"google.golang.org/grpc/codes"
@@ -65,6 +66,44 @@ func validateAccessRequest(req *secretspb.SecretAccessRequest) error {
 	return nil
 }
 
+// validateSecretVersion - validates a reference to a specific version of a secret, the latest version alias isn't allowed
+func validateSecretVersion(secretVersion *secretspb.SecretVersion) error {
+	if secretVersion == nil {
+		return fmt.Errorf("secret version cannot be nil")
+	}
+
+	if secretVersion.Secret == nil {
+		return fmt.Errorf("secret cannot be nil")
+	}
+
+	if len(secretVersion.Secret.Name) == 0 {
+		return fmt.Errorf("secret name cannot be blank")
+	}
+
+	if len(secretVersion.Version) == 0 {
+		return status.Errorf(codes.InvalidArgument, "secret version cannot be blank")
+	}
+
+	// the version the alias refers to changes as versions are put, so a specific version must be provided
+	if strings.ToLower(secretVersion.Version) == "latest" {
+		return status.Errorf(codes.InvalidArgument, "secret version must be a specific version, not latest")
+	}
+
+	return nil
+}
+
+func validateListVersionsRequest(req *secretspb.SecretListVersionsRequest) error {
+	if req.Secret == nil {
+		return fmt.Errorf("secret cannot be nil")
+	}
+
+	if len(req.Secret.GetName()) == 0 {
+		return fmt.Errorf("secret name cannot be blank")
+	}
+
+	return nil
+}
+
 func (s *SecretServerValidator) Put(ctx context.Context, req *secretspb.SecretPutRequest) (*secretspb.SecretPutResponse, error) {
 	if err := validatePutRequest(req); err != nil {
 		return nil, err
@@ -81,6 +120,38 @@ func (s *SecretServerValidator) Access(ctx context.Context, req *secretspb.Secre
 	return s.inner.Access(ctx, req)
 }
 
+func (s *SecretServerValidator) ListVersions(ctx context.Context, req *secretspb.SecretListVersionsRequest) (*secretspb.SecretListVersionsResponse, error) {
+	if err := validateListVersionsRequest(req); err != nil {
+		return nil, err
+	}
+
+	return s.inner.ListVersions(ctx, req)
+}
+
+func (s *SecretServerValidator) DisableVersion(ctx context.Context, req *secretspb.SecretDisableVersionRequest) (*secretspb.SecretDisableVersionResponse, error) {
+	if err := validateSecretVersion(req.SecretVersion); err != nil {
+		return nil, err
+	}
+
+	return s.inner.DisableVersion(ctx, req)
+}
+
+func (s *SecretServerValidator) EnableVersion(ctx context.Context, req *secretspb.SecretEnableVersionRequest) (*secretspb.SecretEnableVersionResponse, error) {
+	if err := validateSecretVersion(req.SecretVersion); err != nil {
+		return nil, err
+	}
+
+	return s.inner.EnableVersion(ctx, req)
+}
+
+func (s *SecretServerValidator) DestroyVersion(ctx context.Context, req *secretspb.SecretDestroyVersionRequest) (*secretspb.SecretDestroyVersionResponse, error) {
+	if err := validateSecretVersion(req.SecretVersion); err != nil {
+		return nil, err
+	}
+
+	return s.inner.DestroyVersion(ctx, req)
+}
+
 func SecretsServerWithValidation(inner secretspb.SecretManagerServer) *SecretServerValidator {
 	return &SecretServerValidator{
 		inner: inner,
@@ -21,6 +21,14 @@ var (
 	WORKER_TIMEOUT  = GetEnv("WORKER_TIMEOUT", "10")
 	SERVICE_ADDRESS = GetEnv("SERVICE_ADDRESS", "127.0.0.1:")
 	LOG_LEVEL       = GetEnv("LOG_LEVEL", "INFO")
+	// The strategy used to balance requests between workers registered for the same trigger, see workers.LoadBalancerFromString
+	WORKER_LOAD_BALANCER = GetEnv("WORKER_LOAD_BALANCER", "round-robin")
+	// Seconds to wait for in-flight requests to finish when shutting down
+	SHUTDOWN_GRACE_PERIOD = GetEnv("SHUTDOWN_GRACE_PERIOD", "10")
+	// Seconds to wait for the user process to exit once in-flight requests have finished, before it's killed
+	PROCESS_STOP_TIMEOUT = GetEnv("PROCESS_STOP_TIMEOUT", "10")
+	// Seconds to cache the latest version of a secret for, secret values aren't cached when 0
+	SECRET_CACHE_TTL = GetEnv("SECRET_CACHE_TTL", "0")
 	// The execution type of the nitric execution unit, can either be job or service
 	EXECUTION_TYPE = GetEnv("NITRIC_EXECUTION_TYPE", "service")
 )
@@ -17,9 +17,12 @@ package gateway
 import (
 	"fmt"
 
+	"github.com/nitrictech/nitric/core/pkg/workers"
 	apigateways "github.com/nitrictech/nitric/core/pkg/workers/apis"
 	"github.com/nitrictech/nitric/core/pkg/workers/http"
 	"github.com/nitrictech/nitric/core/pkg/workers/jobs"
+	"github.com/nitrictech/nitric/core/pkg/workers/keyvalue"
+	"github.com/nitrictech/nitric/core/pkg/workers/queues"
 	"github.com/nitrictech/nitric/core/pkg/workers/schedules"
 	"github.com/nitrictech/nitric/core/pkg/workers/storage"
 	"github.com/nitrictech/nitric/core/pkg/workers/topics"
@@ -34,6 +37,18 @@ type GatewayStartOpts struct {
 	StorageListenerPlugin   storage.BucketRequestHandler
 	WebsocketListenerPlugin websockets.WebsocketRequestHandler
 	JobHandlerPlugin        jobs.JobRequestHandler
+	KeyValueListenerPlugin  keyvalue.StoreRequestHandler
+	QueueListenerPlugin     queues.QueueRequestHandler
+	// Status of the membrane, used to serve health checks
+	Status StatusReporter
+}
+
+// StatusReporter provides the state of the membrane to gateways
+type StatusReporter interface {
+	// Ready returns nil if the membrane is ready to handle triggers, otherwise an error describing why it isn't
+	Ready() error
+	// Workers lists the registered workers by the type of trigger they handle, e.g. apis or topics
+	Workers() map[string][]workers.WorkerDescription
 }
 
 // GatewayService - The interface for a Nitric Gateway, which acts as provider specific adapter for all incoming requests.
@@ -44,6 +59,12 @@ type GatewayService interface {
 	Stop() error
 }
 
+// AcceptStopper is implemented by gateways that can stop accepting new triggers without waiting for triggers in flight.
+// The server calls StopAccepting when it begins shutting down, before in-flight requests are drained.
+type AcceptStopper interface {
+	StopAccepting() error
+}
+
 type UnimplementedGatewayPlugin struct {
 	GatewayService
 }
@@ -15,6 +15,7 @@
 package pm
 
 import (
+	"context"
 	"fmt"
 	"os"
 	"os/exec"
@@ -27,6 +28,9 @@ import (
 type process struct {
 	Command []string
 	cmd     *exec.Cmd
+	// closed once the process has exited, waitErr is the result of waiting on the process
+	exited  chan struct{}
+	waitErr error
 }
 
 type pMgr struct {
@@ -39,7 +43,10 @@ type ProcessManager interface {
 	StartPreProcesses(...string) error
 	StartUserProcess(...string) error
 	Monitor() error
-	StopAll()
+	// UserProcessAlive reports whether the user process is running, it's always true if there is no user command
+	UserProcessAlive() bool
+	// StopAll sends SIGTERM to all processes, processes still running when the context is done are killed
+	StopAll(ctx context.Context)
 }
 
 func NewProcessManager(userCommand []string, preCommands [][]string) ProcessManager {
@@ -60,6 +67,24 @@ func (pm *pMgr) StartUserProcess(env ...string) error {
 	return pm.userProcess.start(env...)
 }
 
+func (pm *pMgr) UserProcessAlive() bool {
+	if len(pm.userProcess.Command) == 0 {
+		return true
+	}
+
+	if pm.userProcess.exited == nil {
+		// not started yet
+		return false
+	}
+
+	select {
+	case <-pm.userProcess.exited:
+		return false
+	default:
+		return true
+	}
+}
+
 func (pm *pMgr) StartPreProcesses(env ...string) error {
 	for i := range pm.preProcesses {
 		if err := pm.preProcesses[i].start(env...); err != nil {
@@ -70,14 +95,14 @@ func (pm *pMgr) StartPreProcesses(env ...string) error {
 	return nil
 }
 
-func (pm *pMgr) StopAll() {
-	err := pm.userProcess.stop()
+func (pm *pMgr) StopAll(ctx context.Context) {
+	err := pm.userProcess.stop(ctx)
 	if err != nil {
 		fmt.Println(err)
 	}
 
 	for _, p := range pm.preProcesses {
-		err := p.stop()
+		err := p.stop(ctx)
 		if err != nil {
 			fmt.Println(err)
 		}
@@ -86,13 +111,14 @@ func (pm *pMgr) StopAll() {
 
 func (pm *pMgr) Monitor() error {
 	for _, p := range append(pm.preProcesses, pm.userProcess) {
-		if p.cmd == nil {
+		if p.exited == nil {
 			continue
 		}
 
-		go func(c *exec.Cmd) {
-			pm.monitorErrChan <- c.Wait()
-		}(p.cmd)
+		go func(p *process) {
+			<-p.exited
+			pm.monitorErrChan <- p.waitErr
+		}(p)
 	}
 
 	return <-pm.monitorErrChan
@@ -115,11 +141,23 @@ func (p *process) start(env ...string) error {
 
 	logger.Debugf("Starting: %s", p.Command[0])
 
-	return errors.WithMessagef(p.cmd.Start(), "there was an error starting the process %s", p.Command[0])
+	if err := p.cmd.Start(); err != nil {
+		return errors.WithMessagef(err, "there was an error starting the process %s", p.Command[0])
+	}
+
+	// processes can only be waited on once, so the result is shared by Monitor and stop
+	p.exited = make(chan struct{})
+	go func() {
+		p.waitErr = p.cmd.Wait()
+		close(p.exited)
+	}()
+
+	return nil
 }
 
-func (p *process) stop() error {
-	if p == nil || p.cmd == nil {
+// stop sends SIGTERM to the process and waits for it to exit, the process is killed if it's still running when the context is done
+func (p *process) stop(ctx context.Context) error {
+	if p == nil || p.cmd == nil || p.exited == nil {
 		return nil
 	}
 
@@ -139,5 +177,19 @@ func (p *process) stop() error {
 		}
 	}
 
+	select {
+	case <-p.exited:
+		return nil
+	case <-ctx.Done():
+		logger.Warnf("process %s did not exit within the process stop timeout, killing it", p.Command[0])
+	}
+
+	err = p.cmd.Process.Kill()
+	if err != nil && !errors.Is(err, os.ErrProcessDone) {
+		return err
+	}
+
+	<-p.exited
+
 	return nil
 }
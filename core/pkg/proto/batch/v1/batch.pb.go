@@ -176,6 +176,7 @@ type JobData struct {
 	// Types that are assignable to Data:
 	//
 	//	*JobData_Struct
+	//	*JobData_Bytes
 	Data isJobData_Data `protobuf_oneof:"data"`
 }
 
@@ -225,6 +226,13 @@ func (x *JobData) GetStruct() *structpb.Struct {
 	return nil
 }
 
+func (x *JobData) GetBytes() *BytesPayload {
+	if x, ok := x.GetData().(*JobData_Bytes); ok {
+		return x.Bytes
+	}
+	return nil
+}
+
 type isJobData_Data interface {
 	isJobData_Data()
 }
@@ -233,8 +241,71 @@ type JobData_Struct struct {
 	Struct *structpb.Struct `protobuf:"bytes,1,opt,name=struct,proto3,oneof"`
 }
 
+type JobData_Bytes struct {
+	Bytes *BytesPayload `protobuf:"bytes,2,opt,name=bytes,proto3,oneof"`
+}
+
 func (*JobData_Struct) isJobData_Data() {}
 
+func (*JobData_Bytes) isJobData_Data() {}
+
+// Raw job data, for binary data or data that can't be represented as JSON without losing precision
+type BytesPayload struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Data []byte `protobuf:"bytes,1,opt,name=data,proto3" json:"data,omitempty"`
+	// The MIME type of the data, e.g. application/octet-stream
+	ContentType string `protobuf:"bytes,2,opt,name=content_type,json=contentType,proto3" json:"content_type,omitempty"`
+}
+
+func (x *BytesPayload) Reset() {
+	*x = BytesPayload{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_nitric_proto_batch_v1_batch_proto_msgTypes[3]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *BytesPayload) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BytesPayload) ProtoMessage() {}
+
+func (x *BytesPayload) ProtoReflect() protoreflect.Message {
+	mi := &file_nitric_proto_batch_v1_batch_proto_msgTypes[3]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BytesPayload.ProtoReflect.Descriptor instead.
+func (*BytesPayload) Descriptor() ([]byte, []int) {
+	return file_nitric_proto_batch_v1_batch_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *BytesPayload) GetData() []byte {
+	if x != nil {
+		return x.Data
+	}
+	return nil
+}
+
+func (x *BytesPayload) GetContentType() string {
+	if x != nil {
+		return x.ContentType
+	}
+	return ""
+}
+
 type JobResponse struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
@@ -247,7 +318,7 @@ type JobResponse struct {
 func (x *JobResponse) Reset() {
 	*x = JobResponse{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_nitric_proto_batch_v1_batch_proto_msgTypes[3]
+		mi := &file_nitric_proto_batch_v1_batch_proto_msgTypes[4]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -260,7 +331,7 @@ func (x *JobResponse) String() string {
 func (*JobResponse) ProtoMessage() {}
 
 func (x *JobResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_nitric_proto_batch_v1_batch_proto_msgTypes[3]
+	mi := &file_nitric_proto_batch_v1_batch_proto_msgTypes[4]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -273,7 +344,7 @@ func (x *JobResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use JobResponse.ProtoReflect.Descriptor instead.
 func (*JobResponse) Descriptor() ([]byte, []int) {
-	return file_nitric_proto_batch_v1_batch_proto_rawDescGZIP(), []int{3}
+	return file_nitric_proto_batch_v1_batch_proto_rawDescGZIP(), []int{4}
 }
 
 func (x *JobResponse) GetSuccess() bool {
@@ -296,7 +367,7 @@ type RegistrationRequest struct {
 func (x *RegistrationRequest) Reset() {
 	*x = RegistrationRequest{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_nitric_proto_batch_v1_batch_proto_msgTypes[4]
+		mi := &file_nitric_proto_batch_v1_batch_proto_msgTypes[5]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -309,7 +380,7 @@ func (x *RegistrationRequest) String() string {
 func (*RegistrationRequest) ProtoMessage() {}
 
 func (x *RegistrationRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_nitric_proto_batch_v1_batch_proto_msgTypes[4]
+	mi := &file_nitric_proto_batch_v1_batch_proto_msgTypes[5]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -322,7 +393,7 @@ func (x *RegistrationRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use RegistrationRequest.ProtoReflect.Descriptor instead.
 func (*RegistrationRequest) Descriptor() ([]byte, []int) {
-	return file_nitric_proto_batch_v1_batch_proto_rawDescGZIP(), []int{4}
+	return file_nitric_proto_batch_v1_batch_proto_rawDescGZIP(), []int{5}
 }
 
 func (x *RegistrationRequest) GetJobName() string {
@@ -348,7 +419,7 @@ type RegistrationResponse struct {
 func (x *RegistrationResponse) Reset() {
 	*x = RegistrationResponse{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_nitric_proto_batch_v1_batch_proto_msgTypes[5]
+		mi := &file_nitric_proto_batch_v1_batch_proto_msgTypes[6]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -361,7 +432,7 @@ func (x *RegistrationResponse) String() string {
 func (*RegistrationResponse) ProtoMessage() {}
 
 func (x *RegistrationResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_nitric_proto_batch_v1_batch_proto_msgTypes[5]
+	mi := &file_nitric_proto_batch_v1_batch_proto_msgTypes[6]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -374,7 +445,7 @@ func (x *RegistrationResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use RegistrationResponse.ProtoReflect.Descriptor instead.
 func (*RegistrationResponse) Descriptor() ([]byte, []int) {
-	return file_nitric_proto_batch_v1_batch_proto_rawDescGZIP(), []int{5}
+	return file_nitric_proto_batch_v1_batch_proto_rawDescGZIP(), []int{6}
 }
 
 type JobResourceRequirements struct {
@@ -393,7 +464,7 @@ type JobResourceRequirements struct {
 func (x *JobResourceRequirements) Reset() {
 	*x = JobResourceRequirements{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_nitric_proto_batch_v1_batch_proto_msgTypes[6]
+		mi := &file_nitric_proto_batch_v1_batch_proto_msgTypes[7]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -406,7 +477,7 @@ func (x *JobResourceRequirements) String() string {
 func (*JobResourceRequirements) ProtoMessage() {}
 
 func (x *JobResourceRequirements) ProtoReflect() protoreflect.Message {
-	mi := &file_nitric_proto_batch_v1_batch_proto_msgTypes[6]
+	mi := &file_nitric_proto_batch_v1_batch_proto_msgTypes[7]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -419,7 +490,7 @@ func (x *JobResourceRequirements) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use JobResourceRequirements.ProtoReflect.Descriptor instead.
 func (*JobResourceRequirements) Descriptor() ([]byte, []int) {
-	return file_nitric_proto_batch_v1_batch_proto_rawDescGZIP(), []int{6}
+	return file_nitric_proto_batch_v1_batch_proto_rawDescGZIP(), []int{7}
 }
 
 func (x *JobResourceRequirements) GetCpus() float32 {
@@ -455,13 +526,16 @@ type ServerMessage struct {
 	//
 	//	*ServerMessage_RegistrationResponse
 	//	*ServerMessage_JobRequest
+	//	*ServerMessage_CancellationRequest
 	Content isServerMessage_Content `protobuf_oneof:"content"`
+	// W3C trace context (e.g. traceparent and tracestate) of the trigger, used to continue its trace in the service
+	TraceContext map[string]string `protobuf:"bytes,10,rep,name=trace_context,json=traceContext,proto3" json:"trace_context,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
 }
 
 func (x *ServerMessage) Reset() {
 	*x = ServerMessage{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_nitric_proto_batch_v1_batch_proto_msgTypes[7]
+		mi := &file_nitric_proto_batch_v1_batch_proto_msgTypes[8]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -474,7 +548,7 @@ func (x *ServerMessage) String() string {
 func (*ServerMessage) ProtoMessage() {}
 
 func (x *ServerMessage) ProtoReflect() protoreflect.Message {
-	mi := &file_nitric_proto_batch_v1_batch_proto_msgTypes[7]
+	mi := &file_nitric_proto_batch_v1_batch_proto_msgTypes[8]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -487,7 +561,7 @@ func (x *ServerMessage) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ServerMessage.ProtoReflect.Descriptor instead.
 func (*ServerMessage) Descriptor() ([]byte, []int) {
-	return file_nitric_proto_batch_v1_batch_proto_rawDescGZIP(), []int{7}
+	return file_nitric_proto_batch_v1_batch_proto_rawDescGZIP(), []int{8}
 }
 
 func (x *ServerMessage) GetId() string {
@@ -518,6 +592,20 @@ func (x *ServerMessage) GetJobRequest() *JobRequest {
 	return nil
 }
 
+func (x *ServerMessage) GetCancellationRequest() *CancellationRequest {
+	if x, ok := x.GetContent().(*ServerMessage_CancellationRequest); ok {
+		return x.CancellationRequest
+	}
+	return nil
+}
+
+func (x *ServerMessage) GetTraceContext() map[string]string {
+	if x != nil {
+		return x.TraceContext
+	}
+	return nil
+}
+
 type isServerMessage_Content interface {
 	isServerMessage_Content()
 }
@@ -531,10 +619,67 @@ type ServerMessage_JobRequest struct {
 	JobRequest *JobRequest `protobuf:"bytes,3,opt,name=job_request,json=jobRequest,proto3,oneof"`
 }
 
+type ServerMessage_CancellationRequest struct {
+	// Notifies the worker that a previous request with the same ID has been cancelled,
+	// typically because its deadline was exceeded. The worker should abort processing.
+	CancellationRequest *CancellationRequest `protobuf:"bytes,4,opt,name=cancellation_request,json=cancellationRequest,proto3,oneof"`
+}
+
 func (*ServerMessage_RegistrationResponse) isServerMessage_Content() {}
 
 func (*ServerMessage_JobRequest) isServerMessage_Content() {}
 
+func (*ServerMessage_CancellationRequest) isServerMessage_Content() {}
+
+// Notification that an in-flight request was cancelled by the server
+type CancellationRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// The reason the request was cancelled
+	Reason string `protobuf:"bytes,1,opt,name=reason,proto3" json:"reason,omitempty"`
+}
+
+func (x *CancellationRequest) Reset() {
+	*x = CancellationRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_nitric_proto_batch_v1_batch_proto_msgTypes[9]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *CancellationRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CancellationRequest) ProtoMessage() {}
+
+func (x *CancellationRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_nitric_proto_batch_v1_batch_proto_msgTypes[9]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CancellationRequest.ProtoReflect.Descriptor instead.
+func (*CancellationRequest) Descriptor() ([]byte, []int) {
+	return file_nitric_proto_batch_v1_batch_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *CancellationRequest) GetReason() string {
+	if x != nil {
+		return x.Reason
+	}
+	return ""
+}
+
 type JobSubmitRequest struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
@@ -549,7 +694,7 @@ type JobSubmitRequest struct {
 func (x *JobSubmitRequest) Reset() {
 	*x = JobSubmitRequest{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_nitric_proto_batch_v1_batch_proto_msgTypes[8]
+		mi := &file_nitric_proto_batch_v1_batch_proto_msgTypes[10]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -562,7 +707,7 @@ func (x *JobSubmitRequest) String() string {
 func (*JobSubmitRequest) ProtoMessage() {}
 
 func (x *JobSubmitRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_nitric_proto_batch_v1_batch_proto_msgTypes[8]
+	mi := &file_nitric_proto_batch_v1_batch_proto_msgTypes[10]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -575,7 +720,7 @@ func (x *JobSubmitRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use JobSubmitRequest.ProtoReflect.Descriptor instead.
 func (*JobSubmitRequest) Descriptor() ([]byte, []int) {
-	return file_nitric_proto_batch_v1_batch_proto_rawDescGZIP(), []int{8}
+	return file_nitric_proto_batch_v1_batch_proto_rawDescGZIP(), []int{10}
 }
 
 func (x *JobSubmitRequest) GetJobName() string {
@@ -601,7 +746,7 @@ type JobSubmitResponse struct {
 func (x *JobSubmitResponse) Reset() {
 	*x = JobSubmitResponse{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_nitric_proto_batch_v1_batch_proto_msgTypes[9]
+		mi := &file_nitric_proto_batch_v1_batch_proto_msgTypes[11]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -614,7 +759,7 @@ func (x *JobSubmitResponse) String() string {
 func (*JobSubmitResponse) ProtoMessage() {}
 
 func (x *JobSubmitResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_nitric_proto_batch_v1_batch_proto_msgTypes[9]
+	mi := &file_nitric_proto_batch_v1_batch_proto_msgTypes[11]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -627,7 +772,7 @@ func (x *JobSubmitResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use JobSubmitResponse.ProtoReflect.Descriptor instead.
 func (*JobSubmitResponse) Descriptor() ([]byte, []int) {
-	return file_nitric_proto_batch_v1_batch_proto_rawDescGZIP(), []int{9}
+	return file_nitric_proto_batch_v1_batch_proto_rawDescGZIP(), []int{11}
 }
 
 var File_nitric_proto_batch_v1_batch_proto protoreflect.FileDescriptor
@@ -657,74 +802,101 @@ var file_nitric_proto_batch_v1_batch_proto_rawDesc = []byte{
 	0x07, 0x6a, 0x6f, 0x62, 0x4e, 0x61, 0x6d, 0x65, 0x12, 0x32, 0x0a, 0x04, 0x64, 0x61, 0x74, 0x61,
 	0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1e, 0x2e, 0x6e, 0x69, 0x74, 0x72, 0x69, 0x63, 0x2e,
 	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x62, 0x61, 0x74, 0x63, 0x68, 0x2e, 0x76, 0x31, 0x2e, 0x4a,
-	0x6f, 0x62, 0x44, 0x61, 0x74, 0x61, 0x52, 0x04, 0x64, 0x61, 0x74, 0x61, 0x22, 0x44, 0x0a, 0x07,
-	0x4a, 0x6f, 0x62, 0x44, 0x61, 0x74, 0x61, 0x12, 0x31, 0x0a, 0x06, 0x73, 0x74, 0x72, 0x75, 0x63,
-	0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x17, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65,
-	0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x53, 0x74, 0x72, 0x75, 0x63, 0x74,
-	0x48, 0x00, 0x52, 0x06, 0x73, 0x74, 0x72, 0x75, 0x63, 0x74, 0x42, 0x06, 0x0a, 0x04, 0x64, 0x61,
-	0x74, 0x61, 0x22, 0x27, 0x0a, 0x0b, 0x4a, 0x6f, 0x62, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73,
-	0x65, 0x12, 0x18, 0x0a, 0x07, 0x73, 0x75, 0x63, 0x63, 0x65, 0x73, 0x73, 0x18, 0x01, 0x20, 0x01,
-	0x28, 0x08, 0x52, 0x07, 0x73, 0x75, 0x63, 0x63, 0x65, 0x73, 0x73, 0x22, 0x84, 0x01, 0x0a, 0x13,
-	0x52, 0x65, 0x67, 0x69, 0x73, 0x74, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x65, 0x71, 0x75,
-	0x65, 0x73, 0x74, 0x12, 0x19, 0x0a, 0x08, 0x6a, 0x6f, 0x62, 0x5f, 0x6e, 0x61, 0x6d, 0x65, 0x18,
-	0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x6a, 0x6f, 0x62, 0x4e, 0x61, 0x6d, 0x65, 0x12, 0x52,
-	0x0a, 0x0c, 0x72, 0x65, 0x71, 0x75, 0x69, 0x72, 0x65, 0x6d, 0x65, 0x6e, 0x74, 0x73, 0x18, 0x02,
-	0x20, 0x01, 0x28, 0x0b, 0x32, 0x2e, 0x2e, 0x6e, 0x69, 0x74, 0x72, 0x69, 0x63, 0x2e, 0x70, 0x72,
-	0x6f, 0x74, 0x6f, 0x2e, 0x62, 0x61, 0x74, 0x63, 0x68, 0x2e, 0x76, 0x31, 0x2e, 0x4a, 0x6f, 0x62,
-	0x52, 0x65, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x52, 0x65, 0x71, 0x75, 0x69, 0x72, 0x65, 0x6d,
-	0x65, 0x6e, 0x74, 0x73, 0x52, 0x0c, 0x72, 0x65, 0x71, 0x75, 0x69, 0x72, 0x65, 0x6d, 0x65, 0x6e,
-	0x74, 0x73, 0x22, 0x16, 0x0a, 0x14, 0x52, 0x65, 0x67, 0x69, 0x73, 0x74, 0x72, 0x61, 0x74, 0x69,
-	0x6f, 0x6e, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x59, 0x0a, 0x17, 0x4a, 0x6f,
-	0x62, 0x52, 0x65, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x52, 0x65, 0x71, 0x75, 0x69, 0x72, 0x65,
-	0x6d, 0x65, 0x6e, 0x74, 0x73, 0x12, 0x12, 0x0a, 0x04, 0x63, 0x70, 0x75, 0x73, 0x18, 0x01, 0x20,
-	0x01, 0x28, 0x02, 0x52, 0x04, 0x63, 0x70, 0x75, 0x73, 0x12, 0x16, 0x0a, 0x06, 0x6d, 0x65, 0x6d,
-	0x6f, 0x72, 0x79, 0x18, 0x02, 0x20, 0x01, 0x28, 0x03, 0x52, 0x06, 0x6d, 0x65, 0x6d, 0x6f, 0x72,
-	0x79, 0x12, 0x12, 0x0a, 0x04, 0x67, 0x70, 0x75, 0x73, 0x18, 0x03, 0x20, 0x01, 0x28, 0x03, 0x52,
-	0x04, 0x67, 0x70, 0x75, 0x73, 0x22, 0xd4, 0x01, 0x0a, 0x0d, 0x53, 0x65, 0x72, 0x76, 0x65, 0x72,
-	0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20,
-	0x01, 0x28, 0x09, 0x52, 0x02, 0x69, 0x64, 0x12, 0x62, 0x0a, 0x15, 0x72, 0x65, 0x67, 0x69, 0x73,
-	0x74, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x5f, 0x72, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65,
-	0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x2b, 0x2e, 0x6e, 0x69, 0x74, 0x72, 0x69, 0x63, 0x2e,
-	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x62, 0x61, 0x74, 0x63, 0x68, 0x2e, 0x76, 0x31, 0x2e, 0x52,
-	0x65, 0x67, 0x69, 0x73, 0x74, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x65, 0x73, 0x70, 0x6f,
-	0x6e, 0x73, 0x65, 0x48, 0x00, 0x52, 0x14, 0x72, 0x65, 0x67, 0x69, 0x73, 0x74, 0x72, 0x61, 0x74,
-	0x69, 0x6f, 0x6e, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x44, 0x0a, 0x0b, 0x6a,
-	0x6f, 0x62, 0x5f, 0x72, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b,
-	0x32, 0x21, 0x2e, 0x6e, 0x69, 0x74, 0x72, 0x69, 0x63, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e,
-	0x62, 0x61, 0x74, 0x63, 0x68, 0x2e, 0x76, 0x31, 0x2e, 0x4a, 0x6f, 0x62, 0x52, 0x65, 0x71, 0x75,
-	0x65, 0x73, 0x74, 0x48, 0x00, 0x52, 0x0a, 0x6a, 0x6f, 0x62, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
-	0x74, 0x42, 0x09, 0x0a, 0x07, 0x63, 0x6f, 0x6e, 0x74, 0x65, 0x6e, 0x74, 0x22, 0x61, 0x0a, 0x10,
-	0x4a, 0x6f, 0x62, 0x53, 0x75, 0x62, 0x6d, 0x69, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
-	0x12, 0x19, 0x0a, 0x08, 0x6a, 0x6f, 0x62, 0x5f, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01,
-	0x28, 0x09, 0x52, 0x07, 0x6a, 0x6f, 0x62, 0x4e, 0x61, 0x6d, 0x65, 0x12, 0x32, 0x0a, 0x04, 0x64,
-	0x61, 0x74, 0x61, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1e, 0x2e, 0x6e, 0x69, 0x74, 0x72,
+	0x6f, 0x62, 0x44, 0x61, 0x74, 0x61, 0x52, 0x04, 0x64, 0x61, 0x74, 0x61, 0x22, 0x81, 0x01, 0x0a,
+	0x07, 0x4a, 0x6f, 0x62, 0x44, 0x61, 0x74, 0x61, 0x12, 0x31, 0x0a, 0x06, 0x73, 0x74, 0x72, 0x75,
+	0x63, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x17, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c,
+	0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x53, 0x74, 0x72, 0x75, 0x63,
+	0x74, 0x48, 0x00, 0x52, 0x06, 0x73, 0x74, 0x72, 0x75, 0x63, 0x74, 0x12, 0x3b, 0x0a, 0x05, 0x62,
+	0x79, 0x74, 0x65, 0x73, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x23, 0x2e, 0x6e, 0x69, 0x74,
+	0x72, 0x69, 0x63, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x62, 0x61, 0x74, 0x63, 0x68, 0x2e,
+	0x76, 0x31, 0x2e, 0x42, 0x79, 0x74, 0x65, 0x73, 0x50, 0x61, 0x79, 0x6c, 0x6f, 0x61, 0x64, 0x48,
+	0x00, 0x52, 0x05, 0x62, 0x79, 0x74, 0x65, 0x73, 0x42, 0x06, 0x0a, 0x04, 0x64, 0x61, 0x74, 0x61,
+	0x22, 0x45, 0x0a, 0x0c, 0x42, 0x79, 0x74, 0x65, 0x73, 0x50, 0x61, 0x79, 0x6c, 0x6f, 0x61, 0x64,
+	0x12, 0x12, 0x0a, 0x04, 0x64, 0x61, 0x74, 0x61, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x04,
+	0x64, 0x61, 0x74, 0x61, 0x12, 0x21, 0x0a, 0x0c, 0x63, 0x6f, 0x6e, 0x74, 0x65, 0x6e, 0x74, 0x5f,
+	0x74, 0x79, 0x70, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0b, 0x63, 0x6f, 0x6e, 0x74,
+	0x65, 0x6e, 0x74, 0x54, 0x79, 0x70, 0x65, 0x22, 0x27, 0x0a, 0x0b, 0x4a, 0x6f, 0x62, 0x52, 0x65,
+	0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x18, 0x0a, 0x07, 0x73, 0x75, 0x63, 0x63, 0x65, 0x73,
+	0x73, 0x18, 0x01, 0x20, 0x01, 0x28, 0x08, 0x52, 0x07, 0x73, 0x75, 0x63, 0x63, 0x65, 0x73, 0x73,
+	0x22, 0x84, 0x01, 0x0a, 0x13, 0x52, 0x65, 0x67, 0x69, 0x73, 0x74, 0x72, 0x61, 0x74, 0x69, 0x6f,
+	0x6e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x19, 0x0a, 0x08, 0x6a, 0x6f, 0x62, 0x5f,
+	0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x6a, 0x6f, 0x62, 0x4e,
+	0x61, 0x6d, 0x65, 0x12, 0x52, 0x0a, 0x0c, 0x72, 0x65, 0x71, 0x75, 0x69, 0x72, 0x65, 0x6d, 0x65,
+	0x6e, 0x74, 0x73, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x2e, 0x2e, 0x6e, 0x69, 0x74, 0x72,
 	0x69, 0x63, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x62, 0x61, 0x74, 0x63, 0x68, 0x2e, 0x76,
-	0x31, 0x2e, 0x4a, 0x6f, 0x62, 0x44, 0x61, 0x74, 0x61, 0x52, 0x04, 0x64, 0x61, 0x74, 0x61, 0x22,
-	0x13, 0x0a, 0x11, 0x4a, 0x6f, 0x62, 0x53, 0x75, 0x62, 0x6d, 0x69, 0x74, 0x52, 0x65, 0x73, 0x70,
-	0x6f, 0x6e, 0x73, 0x65, 0x32, 0x62, 0x0a, 0x03, 0x4a, 0x6f, 0x62, 0x12, 0x5b, 0x0a, 0x09, 0x48,
-	0x61, 0x6e, 0x64, 0x6c, 0x65, 0x4a, 0x6f, 0x62, 0x12, 0x24, 0x2e, 0x6e, 0x69, 0x74, 0x72, 0x69,
-	0x63, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x62, 0x61, 0x74, 0x63, 0x68, 0x2e, 0x76, 0x31,
-	0x2e, 0x43, 0x6c, 0x69, 0x65, 0x6e, 0x74, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x1a, 0x24,
+	0x31, 0x2e, 0x4a, 0x6f, 0x62, 0x52, 0x65, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x52, 0x65, 0x71,
+	0x75, 0x69, 0x72, 0x65, 0x6d, 0x65, 0x6e, 0x74, 0x73, 0x52, 0x0c, 0x72, 0x65, 0x71, 0x75, 0x69,
+	0x72, 0x65, 0x6d, 0x65, 0x6e, 0x74, 0x73, 0x22, 0x16, 0x0a, 0x14, 0x52, 0x65, 0x67, 0x69, 0x73,
+	0x74, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22,
+	0x59, 0x0a, 0x17, 0x4a, 0x6f, 0x62, 0x52, 0x65, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x52, 0x65,
+	0x71, 0x75, 0x69, 0x72, 0x65, 0x6d, 0x65, 0x6e, 0x74, 0x73, 0x12, 0x12, 0x0a, 0x04, 0x63, 0x70,
+	0x75, 0x73, 0x18, 0x01, 0x20, 0x01, 0x28, 0x02, 0x52, 0x04, 0x63, 0x70, 0x75, 0x73, 0x12, 0x16,
+	0x0a, 0x06, 0x6d, 0x65, 0x6d, 0x6f, 0x72, 0x79, 0x18, 0x02, 0x20, 0x01, 0x28, 0x03, 0x52, 0x06,
+	0x6d, 0x65, 0x6d, 0x6f, 0x72, 0x79, 0x12, 0x12, 0x0a, 0x04, 0x67, 0x70, 0x75, 0x73, 0x18, 0x03,
+	0x20, 0x01, 0x28, 0x03, 0x52, 0x04, 0x67, 0x70, 0x75, 0x73, 0x22, 0xd3, 0x03, 0x0a, 0x0d, 0x53,
+	0x65, 0x72, 0x76, 0x65, 0x72, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x12, 0x0e, 0x0a, 0x02,
+	0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x02, 0x69, 0x64, 0x12, 0x62, 0x0a, 0x15,
+	0x72, 0x65, 0x67, 0x69, 0x73, 0x74, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x5f, 0x72, 0x65, 0x73,
+	0x70, 0x6f, 0x6e, 0x73, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x2b, 0x2e, 0x6e, 0x69,
+	0x74, 0x72, 0x69, 0x63, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x62, 0x61, 0x74, 0x63, 0x68,
+	0x2e, 0x76, 0x31, 0x2e, 0x52, 0x65, 0x67, 0x69, 0x73, 0x74, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e,
+	0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x48, 0x00, 0x52, 0x14, 0x72, 0x65, 0x67, 0x69,
+	0x73, 0x74, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65,
+	0x12, 0x44, 0x0a, 0x0b, 0x6a, 0x6f, 0x62, 0x5f, 0x72, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x18,
+	0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x21, 0x2e, 0x6e, 0x69, 0x74, 0x72, 0x69, 0x63, 0x2e, 0x70,
+	0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x62, 0x61, 0x74, 0x63, 0x68, 0x2e, 0x76, 0x31, 0x2e, 0x4a, 0x6f,
+	0x62, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x48, 0x00, 0x52, 0x0a, 0x6a, 0x6f, 0x62, 0x52,
+	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x5f, 0x0a, 0x14, 0x63, 0x61, 0x6e, 0x63, 0x65, 0x6c,
+	0x6c, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x5f, 0x72, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x18, 0x04,
+	0x20, 0x01, 0x28, 0x0b, 0x32, 0x2a, 0x2e, 0x6e, 0x69, 0x74, 0x72, 0x69, 0x63, 0x2e, 0x70, 0x72,
+	0x6f, 0x74, 0x6f, 0x2e, 0x62, 0x61, 0x74, 0x63, 0x68, 0x2e, 0x76, 0x31, 0x2e, 0x43, 0x61, 0x6e,
+	0x63, 0x65, 0x6c, 0x6c, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
+	0x48, 0x00, 0x52, 0x13, 0x63, 0x61, 0x6e, 0x63, 0x65, 0x6c, 0x6c, 0x61, 0x74, 0x69, 0x6f, 0x6e,
+	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x5b, 0x0a, 0x0d, 0x74, 0x72, 0x61, 0x63, 0x65,
+	0x5f, 0x63, 0x6f, 0x6e, 0x74, 0x65, 0x78, 0x74, 0x18, 0x0a, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x36,
 	0x2e, 0x6e, 0x69, 0x74, 0x72, 0x69, 0x63, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x62, 0x61,
 	0x74, 0x63, 0x68, 0x2e, 0x76, 0x31, 0x2e, 0x53, 0x65, 0x72, 0x76, 0x65, 0x72, 0x4d, 0x65, 0x73,
-	0x73, 0x61, 0x67, 0x65, 0x28, 0x01, 0x30, 0x01, 0x32, 0x67, 0x0a, 0x05, 0x42, 0x61, 0x74, 0x63,
-	0x68, 0x12, 0x5e, 0x0a, 0x09, 0x53, 0x75, 0x62, 0x6d, 0x69, 0x74, 0x4a, 0x6f, 0x62, 0x12, 0x27,
-	0x2e, 0x6e, 0x69, 0x74, 0x72, 0x69, 0x63, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x62, 0x61,
-	0x74, 0x63, 0x68, 0x2e, 0x76, 0x31, 0x2e, 0x4a, 0x6f, 0x62, 0x53, 0x75, 0x62, 0x6d, 0x69, 0x74,
-	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x28, 0x2e, 0x6e, 0x69, 0x74, 0x72, 0x69, 0x63,
-	0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x62, 0x61, 0x74, 0x63, 0x68, 0x2e, 0x76, 0x31, 0x2e,
-	0x4a, 0x6f, 0x62, 0x53, 0x75, 0x62, 0x6d, 0x69, 0x74, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73,
-	0x65, 0x42, 0x98, 0x01, 0x0a, 0x18, 0x69, 0x6f, 0x2e, 0x6e, 0x69, 0x74, 0x72, 0x69, 0x63, 0x2e,
-	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x62, 0x61, 0x74, 0x63, 0x68, 0x2e, 0x76, 0x31, 0x42, 0x0c,
-	0x42, 0x61, 0x74, 0x63, 0x68, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x50, 0x01, 0x5a, 0x3c,
-	0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x6e, 0x69, 0x74, 0x72, 0x69,
-	0x63, 0x74, 0x65, 0x63, 0x68, 0x2f, 0x6e, 0x69, 0x74, 0x72, 0x69, 0x63, 0x2f, 0x63, 0x6f, 0x72,
-	0x65, 0x2f, 0x70, 0x6b, 0x67, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2f, 0x62, 0x61, 0x74, 0x63,
-	0x68, 0x2f, 0x76, 0x31, 0x3b, 0x62, 0x61, 0x74, 0x63, 0x68, 0x70, 0x62, 0xaa, 0x02, 0x15, 0x4e,
-	0x69, 0x74, 0x72, 0x69, 0x63, 0x2e, 0x50, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x42, 0x61, 0x74, 0x63,
-	0x68, 0x2e, 0x76, 0x31, 0xca, 0x02, 0x15, 0x4e, 0x69, 0x74, 0x72, 0x69, 0x63, 0x5c, 0x50, 0x72,
-	0x6f, 0x74, 0x6f, 0x5c, 0x42, 0x61, 0x74, 0x63, 0x68, 0x5c, 0x56, 0x31, 0x62, 0x06, 0x70, 0x72,
-	0x6f, 0x74, 0x6f, 0x33,
+	0x73, 0x61, 0x67, 0x65, 0x2e, 0x54, 0x72, 0x61, 0x63, 0x65, 0x43, 0x6f, 0x6e, 0x74, 0x65, 0x78,
+	0x74, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x52, 0x0c, 0x74, 0x72, 0x61, 0x63, 0x65, 0x43, 0x6f, 0x6e,
+	0x74, 0x65, 0x78, 0x74, 0x1a, 0x3f, 0x0a, 0x11, 0x54, 0x72, 0x61, 0x63, 0x65, 0x43, 0x6f, 0x6e,
+	0x74, 0x65, 0x78, 0x74, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x14, 0x0a, 0x05, 0x76,
+	0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75,
+	0x65, 0x3a, 0x02, 0x38, 0x01, 0x42, 0x09, 0x0a, 0x07, 0x63, 0x6f, 0x6e, 0x74, 0x65, 0x6e, 0x74,
+	0x22, 0x2d, 0x0a, 0x13, 0x43, 0x61, 0x6e, 0x63, 0x65, 0x6c, 0x6c, 0x61, 0x74, 0x69, 0x6f, 0x6e,
+	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x16, 0x0a, 0x06, 0x72, 0x65, 0x61, 0x73, 0x6f,
+	0x6e, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x72, 0x65, 0x61, 0x73, 0x6f, 0x6e, 0x22,
+	0x61, 0x0a, 0x10, 0x4a, 0x6f, 0x62, 0x53, 0x75, 0x62, 0x6d, 0x69, 0x74, 0x52, 0x65, 0x71, 0x75,
+	0x65, 0x73, 0x74, 0x12, 0x19, 0x0a, 0x08, 0x6a, 0x6f, 0x62, 0x5f, 0x6e, 0x61, 0x6d, 0x65, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x6a, 0x6f, 0x62, 0x4e, 0x61, 0x6d, 0x65, 0x12, 0x32,
+	0x0a, 0x04, 0x64, 0x61, 0x74, 0x61, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1e, 0x2e, 0x6e,
+	0x69, 0x74, 0x72, 0x69, 0x63, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x62, 0x61, 0x74, 0x63,
+	0x68, 0x2e, 0x76, 0x31, 0x2e, 0x4a, 0x6f, 0x62, 0x44, 0x61, 0x74, 0x61, 0x52, 0x04, 0x64, 0x61,
+	0x74, 0x61, 0x22, 0x13, 0x0a, 0x11, 0x4a, 0x6f, 0x62, 0x53, 0x75, 0x62, 0x6d, 0x69, 0x74, 0x52,
+	0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x32, 0x62, 0x0a, 0x03, 0x4a, 0x6f, 0x62, 0x12, 0x5b,
+	0x0a, 0x09, 0x48, 0x61, 0x6e, 0x64, 0x6c, 0x65, 0x4a, 0x6f, 0x62, 0x12, 0x24, 0x2e, 0x6e, 0x69,
+	0x74, 0x72, 0x69, 0x63, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x62, 0x61, 0x74, 0x63, 0x68,
+	0x2e, 0x76, 0x31, 0x2e, 0x43, 0x6c, 0x69, 0x65, 0x6e, 0x74, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67,
+	0x65, 0x1a, 0x24, 0x2e, 0x6e, 0x69, 0x74, 0x72, 0x69, 0x63, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f,
+	0x2e, 0x62, 0x61, 0x74, 0x63, 0x68, 0x2e, 0x76, 0x31, 0x2e, 0x53, 0x65, 0x72, 0x76, 0x65, 0x72,
+	0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x28, 0x01, 0x30, 0x01, 0x32, 0x67, 0x0a, 0x05, 0x42,
+	0x61, 0x74, 0x63, 0x68, 0x12, 0x5e, 0x0a, 0x09, 0x53, 0x75, 0x62, 0x6d, 0x69, 0x74, 0x4a, 0x6f,
+	0x62, 0x12, 0x27, 0x2e, 0x6e, 0x69, 0x74, 0x72, 0x69, 0x63, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f,
+	0x2e, 0x62, 0x61, 0x74, 0x63, 0x68, 0x2e, 0x76, 0x31, 0x2e, 0x4a, 0x6f, 0x62, 0x53, 0x75, 0x62,
+	0x6d, 0x69, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x28, 0x2e, 0x6e, 0x69, 0x74,
+	0x72, 0x69, 0x63, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x62, 0x61, 0x74, 0x63, 0x68, 0x2e,
+	0x76, 0x31, 0x2e, 0x4a, 0x6f, 0x62, 0x53, 0x75, 0x62, 0x6d, 0x69, 0x74, 0x52, 0x65, 0x73, 0x70,
+	0x6f, 0x6e, 0x73, 0x65, 0x42, 0x98, 0x01, 0x0a, 0x18, 0x69, 0x6f, 0x2e, 0x6e, 0x69, 0x74, 0x72,
+	0x69, 0x63, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x62, 0x61, 0x74, 0x63, 0x68, 0x2e, 0x76,
+	0x31, 0x42, 0x0c, 0x42, 0x61, 0x74, 0x63, 0x68, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x50,
+	0x01, 0x5a, 0x3c, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x6e, 0x69,
+	0x74, 0x72, 0x69, 0x63, 0x74, 0x65, 0x63, 0x68, 0x2f, 0x6e, 0x69, 0x74, 0x72, 0x69, 0x63, 0x2f,
+	0x63, 0x6f, 0x72, 0x65, 0x2f, 0x70, 0x6b, 0x67, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2f, 0x62,
+	0x61, 0x74, 0x63, 0x68, 0x2f, 0x76, 0x31, 0x3b, 0x62, 0x61, 0x74, 0x63, 0x68, 0x70, 0x62, 0xaa,
+	0x02, 0x15, 0x4e, 0x69, 0x74, 0x72, 0x69, 0x63, 0x2e, 0x50, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x42,
+	0x61, 0x74, 0x63, 0x68, 0x2e, 0x76, 0x31, 0xca, 0x02, 0x15, 0x4e, 0x69, 0x74, 0x72, 0x69, 0x63,
+	0x5c, 0x50, 0x72, 0x6f, 0x74, 0x6f, 0x5c, 0x42, 0x61, 0x74, 0x63, 0x68, 0x5c, 0x56, 0x31, 0x62,
+	0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
 }
 
 var (
@@ -739,38 +911,44 @@ func file_nitric_proto_batch_v1_batch_proto_rawDescGZIP() []byte {
 	return file_nitric_proto_batch_v1_batch_proto_rawDescData
 }
 
-var file_nitric_proto_batch_v1_batch_proto_msgTypes = make([]protoimpl.MessageInfo, 10)
+var file_nitric_proto_batch_v1_batch_proto_msgTypes = make([]protoimpl.MessageInfo, 13)
 var file_nitric_proto_batch_v1_batch_proto_goTypes = []interface{}{
 	(*ClientMessage)(nil),           // 0: nitric.proto.batch.v1.ClientMessage
 	(*JobRequest)(nil),              // 1: nitric.proto.batch.v1.JobRequest
 	(*JobData)(nil),                 // 2: nitric.proto.batch.v1.JobData
-	(*JobResponse)(nil),             // 3: nitric.proto.batch.v1.JobResponse
-	(*RegistrationRequest)(nil),     // 4: nitric.proto.batch.v1.RegistrationRequest
-	(*RegistrationResponse)(nil),    // 5: nitric.proto.batch.v1.RegistrationResponse
-	(*JobResourceRequirements)(nil), // 6: nitric.proto.batch.v1.JobResourceRequirements
-	(*ServerMessage)(nil),           // 7: nitric.proto.batch.v1.ServerMessage
-	(*JobSubmitRequest)(nil),        // 8: nitric.proto.batch.v1.JobSubmitRequest
-	(*JobSubmitResponse)(nil),       // 9: nitric.proto.batch.v1.JobSubmitResponse
-	(*structpb.Struct)(nil),         // 10: google.protobuf.Struct
+	(*BytesPayload)(nil),            // 3: nitric.proto.batch.v1.BytesPayload
+	(*JobResponse)(nil),             // 4: nitric.proto.batch.v1.JobResponse
+	(*RegistrationRequest)(nil),     // 5: nitric.proto.batch.v1.RegistrationRequest
+	(*RegistrationResponse)(nil),    // 6: nitric.proto.batch.v1.RegistrationResponse
+	(*JobResourceRequirements)(nil), // 7: nitric.proto.batch.v1.JobResourceRequirements
+	(*ServerMessage)(nil),           // 8: nitric.proto.batch.v1.ServerMessage
+	(*CancellationRequest)(nil),     // 9: nitric.proto.batch.v1.CancellationRequest
+	(*JobSubmitRequest)(nil),        // 10: nitric.proto.batch.v1.JobSubmitRequest
+	(*JobSubmitResponse)(nil),       // 11: nitric.proto.batch.v1.JobSubmitResponse
+	nil,                             // 12: nitric.proto.batch.v1.ServerMessage.TraceContextEntry
+	(*structpb.Struct)(nil),         // 13: google.protobuf.Struct
 }
 var file_nitric_proto_batch_v1_batch_proto_depIdxs = []int32{
-	4,  // 0: nitric.proto.batch.v1.ClientMessage.registration_request:type_name -> nitric.proto.batch.v1.RegistrationRequest
-	3,  // 1: nitric.proto.batch.v1.ClientMessage.job_response:type_name -> nitric.proto.batch.v1.JobResponse
+	5,  // 0: nitric.proto.batch.v1.ClientMessage.registration_request:type_name -> nitric.proto.batch.v1.RegistrationRequest
+	4,  // 1: nitric.proto.batch.v1.ClientMessage.job_response:type_name -> nitric.proto.batch.v1.JobResponse
 	2,  // 2: nitric.proto.batch.v1.JobRequest.data:type_name -> nitric.proto.batch.v1.JobData
-	10, // 3: nitric.proto.batch.v1.JobData.struct:type_name -> google.protobuf.Struct
-	6,  // 4: nitric.proto.batch.v1.RegistrationRequest.requirements:type_name -> nitric.proto.batch.v1.JobResourceRequirements
-	5,  // 5: nitric.proto.batch.v1.ServerMessage.registration_response:type_name -> nitric.proto.batch.v1.RegistrationResponse
-	1,  // 6: nitric.proto.batch.v1.ServerMessage.job_request:type_name -> nitric.proto.batch.v1.JobRequest
-	2,  // 7: nitric.proto.batch.v1.JobSubmitRequest.data:type_name -> nitric.proto.batch.v1.JobData
-	0,  // 8: nitric.proto.batch.v1.Job.HandleJob:input_type -> nitric.proto.batch.v1.ClientMessage
-	8,  // 9: nitric.proto.batch.v1.Batch.SubmitJob:input_type -> nitric.proto.batch.v1.JobSubmitRequest
-	7,  // 10: nitric.proto.batch.v1.Job.HandleJob:output_type -> nitric.proto.batch.v1.ServerMessage
-	9,  // 11: nitric.proto.batch.v1.Batch.SubmitJob:output_type -> nitric.proto.batch.v1.JobSubmitResponse
-	10, // [10:12] is the sub-list for method output_type
-	8,  // [8:10] is the sub-list for method input_type
-	8,  // [8:8] is the sub-list for extension type_name
-	8,  // [8:8] is the sub-list for extension extendee
-	0,  // [0:8] is the sub-list for field type_name
+	13, // 3: nitric.proto.batch.v1.JobData.struct:type_name -> google.protobuf.Struct
+	3,  // 4: nitric.proto.batch.v1.JobData.bytes:type_name -> nitric.proto.batch.v1.BytesPayload
+	7,  // 5: nitric.proto.batch.v1.RegistrationRequest.requirements:type_name -> nitric.proto.batch.v1.JobResourceRequirements
+	6,  // 6: nitric.proto.batch.v1.ServerMessage.registration_response:type_name -> nitric.proto.batch.v1.RegistrationResponse
+	1,  // 7: nitric.proto.batch.v1.ServerMessage.job_request:type_name -> nitric.proto.batch.v1.JobRequest
+	9,  // 8: nitric.proto.batch.v1.ServerMessage.cancellation_request:type_name -> nitric.proto.batch.v1.CancellationRequest
+	12, // 9: nitric.proto.batch.v1.ServerMessage.trace_context:type_name -> nitric.proto.batch.v1.ServerMessage.TraceContextEntry
+	2,  // 10: nitric.proto.batch.v1.JobSubmitRequest.data:type_name -> nitric.proto.batch.v1.JobData
+	0,  // 11: nitric.proto.batch.v1.Job.HandleJob:input_type -> nitric.proto.batch.v1.ClientMessage
+	10, // 12: nitric.proto.batch.v1.Batch.SubmitJob:input_type -> nitric.proto.batch.v1.JobSubmitRequest
+	8,  // 13: nitric.proto.batch.v1.Job.HandleJob:output_type -> nitric.proto.batch.v1.ServerMessage
+	11, // 14: nitric.proto.batch.v1.Batch.SubmitJob:output_type -> nitric.proto.batch.v1.JobSubmitResponse
+	13, // [13:15] is the sub-list for method output_type
+	11, // [11:13] is the sub-list for method input_type
+	11, // [11:11] is the sub-list for extension type_name
+	11, // [11:11] is the sub-list for extension extendee
+	0,  // [0:11] is the sub-list for field type_name
 }
 
 func init() { file_nitric_proto_batch_v1_batch_proto_init() }
@@ -816,7 +994,7 @@ func file_nitric_proto_batch_v1_batch_proto_init() {
 			}
 		}
 		file_nitric_proto_batch_v1_batch_proto_msgTypes[3].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*JobResponse); i {
+			switch v := v.(*BytesPayload); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -828,7 +1006,7 @@ func file_nitric_proto_batch_v1_batch_proto_init() {
 			}
 		}
 		file_nitric_proto_batch_v1_batch_proto_msgTypes[4].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*RegistrationRequest); i {
+			switch v := v.(*JobResponse); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -840,7 +1018,7 @@ func file_nitric_proto_batch_v1_batch_proto_init() {
 			}
 		}
 		file_nitric_proto_batch_v1_batch_proto_msgTypes[5].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*RegistrationResponse); i {
+			switch v := v.(*RegistrationRequest); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -852,7 +1030,7 @@ func file_nitric_proto_batch_v1_batch_proto_init() {
 			}
 		}
 		file_nitric_proto_batch_v1_batch_proto_msgTypes[6].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*JobResourceRequirements); i {
+			switch v := v.(*RegistrationResponse); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -864,7 +1042,7 @@ func file_nitric_proto_batch_v1_batch_proto_init() {
 			}
 		}
 		file_nitric_proto_batch_v1_batch_proto_msgTypes[7].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*ServerMessage); i {
+			switch v := v.(*JobResourceRequirements); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -876,7 +1054,7 @@ func file_nitric_proto_batch_v1_batch_proto_init() {
 			}
 		}
 		file_nitric_proto_batch_v1_batch_proto_msgTypes[8].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*JobSubmitRequest); i {
+			switch v := v.(*ServerMessage); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -888,6 +1066,30 @@ func file_nitric_proto_batch_v1_batch_proto_init() {
 			}
 		}
 		file_nitric_proto_batch_v1_batch_proto_msgTypes[9].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*CancellationRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_nitric_proto_batch_v1_batch_proto_msgTypes[10].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*JobSubmitRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_nitric_proto_batch_v1_batch_proto_msgTypes[11].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*JobSubmitResponse); i {
 			case 0:
 				return &v.state
@@ -906,10 +1108,12 @@ func file_nitric_proto_batch_v1_batch_proto_init() {
 	}
 	file_nitric_proto_batch_v1_batch_proto_msgTypes[2].OneofWrappers = []interface{}{
 		(*JobData_Struct)(nil),
+		(*JobData_Bytes)(nil),
 	}
-	file_nitric_proto_batch_v1_batch_proto_msgTypes[7].OneofWrappers = []interface{}{
+	file_nitric_proto_batch_v1_batch_proto_msgTypes[8].OneofWrappers = []interface{}{
 		(*ServerMessage_RegistrationResponse)(nil),
 		(*ServerMessage_JobRequest)(nil),
+		(*ServerMessage_CancellationRequest)(nil),
 	}
 	type x struct{}
 	out := protoimpl.TypeBuilder{
@@ -917,7 +1121,7 @@ func file_nitric_proto_batch_v1_batch_proto_init() {
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: file_nitric_proto_batch_v1_batch_proto_rawDesc,
 			NumEnums:      0,
-			NumMessages:   10,
+			NumMessages:   13,
 			NumExtensions: 0,
 			NumServices:   2,
 		},
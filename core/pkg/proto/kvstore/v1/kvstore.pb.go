@@ -9,6 +9,7 @@ package kvstorepb
 import (
 	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
 	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	durationpb "google.golang.org/protobuf/types/known/durationpb"
 	structpb "google.golang.org/protobuf/types/known/structpb"
 	reflect "reflect"
 	sync "sync"
@@ -21,18 +22,71 @@ const (
 	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
 )
 
-// Provides a Key/Value Store
-type Store struct {
+type KeyValueEventType int32
+
+const (
+	// A value was created or overwritten
+	KeyValueEventType_Set KeyValueEventType = 0
+	// A key and its value were deleted, including when the value expired
+	KeyValueEventType_Delete KeyValueEventType = 1
+)
+
+// Enum value maps for KeyValueEventType.
+var (
+	KeyValueEventType_name = map[int32]string{
+		0: "Set",
+		1: "Delete",
+	}
+	KeyValueEventType_value = map[string]int32{
+		"Set":    0,
+		"Delete": 1,
+	}
+)
+
+func (x KeyValueEventType) Enum() *KeyValueEventType {
+	p := new(KeyValueEventType)
+	*p = x
+	return p
+}
+
+func (x KeyValueEventType) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (KeyValueEventType) Descriptor() protoreflect.EnumDescriptor {
+	return file_nitric_proto_kvstore_v1_kvstore_proto_enumTypes[0].Descriptor()
+}
+
+func (KeyValueEventType) Type() protoreflect.EnumType {
+	return &file_nitric_proto_kvstore_v1_kvstore_proto_enumTypes[0]
+}
+
+func (x KeyValueEventType) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use KeyValueEventType.Descriptor instead.
+func (KeyValueEventType) EnumDescriptor() ([]byte, []int) {
+	return file_nitric_proto_kvstore_v1_kvstore_proto_rawDescGZIP(), []int{0}
+}
+
+// ClientMessages are sent from the service to the nitric server
+type ClientMessage struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	// The store name
-	Name string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	// globally unique ID of the request/response pair
+	Id string `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	// Types that are assignable to Content:
+	//
+	//	*ClientMessage_RegistrationRequest
+	//	*ClientMessage_KeyValueEventResponse
+	Content isClientMessage_Content `protobuf_oneof:"content"`
 }
 
-func (x *Store) Reset() {
-	*x = Store{}
+func (x *ClientMessage) Reset() {
+	*x = ClientMessage{}
 	if protoimpl.UnsafeEnabled {
 		mi := &file_nitric_proto_kvstore_v1_kvstore_proto_msgTypes[0]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -40,13 +94,13 @@ func (x *Store) Reset() {
 	}
 }
 
-func (x *Store) String() string {
+func (x *ClientMessage) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*Store) ProtoMessage() {}
+func (*ClientMessage) ProtoMessage() {}
 
-func (x *Store) ProtoReflect() protoreflect.Message {
+func (x *ClientMessage) ProtoReflect() protoreflect.Message {
 	mi := &file_nitric_proto_kvstore_v1_kvstore_proto_msgTypes[0]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -58,32 +112,77 @@ func (x *Store) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use Store.ProtoReflect.Descriptor instead.
-func (*Store) Descriptor() ([]byte, []int) {
+// Deprecated: Use ClientMessage.ProtoReflect.Descriptor instead.
+func (*ClientMessage) Descriptor() ([]byte, []int) {
 	return file_nitric_proto_kvstore_v1_kvstore_proto_rawDescGZIP(), []int{0}
 }
 
-func (x *Store) GetName() string {
+func (x *ClientMessage) GetId() string {
 	if x != nil {
-		return x.Name
+		return x.Id
 	}
 	return ""
 }
 
-// ValueRef provides a unique identifier for a value
-type ValueRef struct {
+func (m *ClientMessage) GetContent() isClientMessage_Content {
+	if m != nil {
+		return m.Content
+	}
+	return nil
+}
+
+func (x *ClientMessage) GetRegistrationRequest() *RegistrationRequest {
+	if x, ok := x.GetContent().(*ClientMessage_RegistrationRequest); ok {
+		return x.RegistrationRequest
+	}
+	return nil
+}
+
+func (x *ClientMessage) GetKeyValueEventResponse() *KeyValueEventResponse {
+	if x, ok := x.GetContent().(*ClientMessage_KeyValueEventResponse); ok {
+		return x.KeyValueEventResponse
+	}
+	return nil
+}
+
+type isClientMessage_Content interface {
+	isClientMessage_Content()
+}
+
+type ClientMessage_RegistrationRequest struct {
+	// Watch for changes on a store
+	RegistrationRequest *RegistrationRequest `protobuf:"bytes,2,opt,name=registration_request,json=registrationRequest,proto3,oneof"`
+}
+
+type ClientMessage_KeyValueEventResponse struct {
+	// Response to a value event (change to a key's value)
+	KeyValueEventResponse *KeyValueEventResponse `protobuf:"bytes,3,opt,name=key_value_event_response,json=keyValueEventResponse,proto3,oneof"`
+}
+
+func (*ClientMessage_RegistrationRequest) isClientMessage_Content() {}
+
+func (*ClientMessage_KeyValueEventResponse) isClientMessage_Content() {}
+
+// ServerMessages are sent from the nitric server to the service
+type ServerMessage struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	// The key/value store name
-	Store string `protobuf:"bytes,1,opt,name=store,proto3" json:"store,omitempty"`
-	// The item's unique key within the store
-	Key string `protobuf:"bytes,2,opt,name=key,proto3" json:"key,omitempty"`
+	// globally unique ID of the request/response pair
+	Id string `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	// Types that are assignable to Content:
+	//
+	//	*ServerMessage_RegistrationResponse
+	//	*ServerMessage_KeyValueEventRequest
+	//	*ServerMessage_CancellationRequest
+	Content isServerMessage_Content `protobuf_oneof:"content"`
+	// W3C trace context (e.g. traceparent and tracestate) of the trigger, used to continue its trace in the service
+	TraceContext map[string]string `protobuf:"bytes,10,rep,name=trace_context,json=traceContext,proto3" json:"trace_context,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
 }
 
-func (x *ValueRef) Reset() {
-	*x = ValueRef{}
+func (x *ServerMessage) Reset() {
+	*x = ServerMessage{}
 	if protoimpl.UnsafeEnabled {
 		mi := &file_nitric_proto_kvstore_v1_kvstore_proto_msgTypes[1]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -91,13 +190,13 @@ func (x *ValueRef) Reset() {
 	}
 }
 
-func (x *ValueRef) String() string {
+func (x *ServerMessage) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*ValueRef) ProtoMessage() {}
+func (*ServerMessage) ProtoMessage() {}
 
-func (x *ValueRef) ProtoReflect() protoreflect.Message {
+func (x *ServerMessage) ProtoReflect() protoreflect.Message {
 	mi := &file_nitric_proto_kvstore_v1_kvstore_proto_msgTypes[1]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -109,39 +208,91 @@ func (x *ValueRef) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use ValueRef.ProtoReflect.Descriptor instead.
-func (*ValueRef) Descriptor() ([]byte, []int) {
+// Deprecated: Use ServerMessage.ProtoReflect.Descriptor instead.
+func (*ServerMessage) Descriptor() ([]byte, []int) {
 	return file_nitric_proto_kvstore_v1_kvstore_proto_rawDescGZIP(), []int{1}
 }
 
-func (x *ValueRef) GetStore() string {
+func (x *ServerMessage) GetId() string {
 	if x != nil {
-		return x.Store
+		return x.Id
 	}
 	return ""
 }
 
-func (x *ValueRef) GetKey() string {
+func (m *ServerMessage) GetContent() isServerMessage_Content {
+	if m != nil {
+		return m.Content
+	}
+	return nil
+}
+
+func (x *ServerMessage) GetRegistrationResponse() *RegistrationResponse {
+	if x, ok := x.GetContent().(*ServerMessage_RegistrationResponse); ok {
+		return x.RegistrationResponse
+	}
+	return nil
+}
+
+func (x *ServerMessage) GetKeyValueEventRequest() *KeyValueEventRequest {
+	if x, ok := x.GetContent().(*ServerMessage_KeyValueEventRequest); ok {
+		return x.KeyValueEventRequest
+	}
+	return nil
+}
+
+func (x *ServerMessage) GetCancellationRequest() *CancellationRequest {
+	if x, ok := x.GetContent().(*ServerMessage_CancellationRequest); ok {
+		return x.CancellationRequest
+	}
+	return nil
+}
+
+func (x *ServerMessage) GetTraceContext() map[string]string {
 	if x != nil {
-		return x.Key
+		return x.TraceContext
 	}
-	return ""
+	return nil
 }
 
-// Value provides a return value type
-type Value struct {
+type isServerMessage_Content interface {
+	isServerMessage_Content()
+}
+
+type ServerMessage_RegistrationResponse struct {
+	// Watch for changes on a store
+	RegistrationResponse *RegistrationResponse `protobuf:"bytes,2,opt,name=registration_response,json=registrationResponse,proto3,oneof"`
+}
+
+type ServerMessage_KeyValueEventRequest struct {
+	// Event for a key in a store
+	KeyValueEventRequest *KeyValueEventRequest `protobuf:"bytes,3,opt,name=key_value_event_request,json=keyValueEventRequest,proto3,oneof"`
+}
+
+type ServerMessage_CancellationRequest struct {
+	// Notifies the worker that a previous request with the same ID has been cancelled,
+	// typically because its deadline was exceeded. The worker should abort processing.
+	CancellationRequest *CancellationRequest `protobuf:"bytes,4,opt,name=cancellation_request,json=cancellationRequest,proto3,oneof"`
+}
+
+func (*ServerMessage_RegistrationResponse) isServerMessage_Content() {}
+
+func (*ServerMessage_KeyValueEventRequest) isServerMessage_Content() {}
+
+func (*ServerMessage_CancellationRequest) isServerMessage_Content() {}
+
+// Notification that an in-flight request was cancelled by the server
+type CancellationRequest struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	// ValueRef of the key/value pair, which includes the store and key
-	Ref *ValueRef `protobuf:"bytes,1,opt,name=ref,proto3" json:"ref,omitempty"`
-	// The content (JSON object)
-	Content *structpb.Struct `protobuf:"bytes,2,opt,name=content,proto3" json:"content,omitempty"`
+	// The reason the request was cancelled
+	Reason string `protobuf:"bytes,1,opt,name=reason,proto3" json:"reason,omitempty"`
 }
 
-func (x *Value) Reset() {
-	*x = Value{}
+func (x *CancellationRequest) Reset() {
+	*x = CancellationRequest{}
 	if protoimpl.UnsafeEnabled {
 		mi := &file_nitric_proto_kvstore_v1_kvstore_proto_msgTypes[2]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -149,13 +300,13 @@ func (x *Value) Reset() {
 	}
 }
 
-func (x *Value) String() string {
+func (x *CancellationRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*Value) ProtoMessage() {}
+func (*CancellationRequest) ProtoMessage() {}
 
-func (x *Value) ProtoReflect() protoreflect.Message {
+func (x *CancellationRequest) ProtoReflect() protoreflect.Message {
 	mi := &file_nitric_proto_kvstore_v1_kvstore_proto_msgTypes[2]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -167,36 +318,32 @@ func (x *Value) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use Value.ProtoReflect.Descriptor instead.
-func (*Value) Descriptor() ([]byte, []int) {
+// Deprecated: Use CancellationRequest.ProtoReflect.Descriptor instead.
+func (*CancellationRequest) Descriptor() ([]byte, []int) {
 	return file_nitric_proto_kvstore_v1_kvstore_proto_rawDescGZIP(), []int{2}
 }
 
-func (x *Value) GetRef() *ValueRef {
-	if x != nil {
-		return x.Ref
-	}
-	return nil
-}
-
-func (x *Value) GetContent() *structpb.Struct {
+func (x *CancellationRequest) GetReason() string {
 	if x != nil {
-		return x.Content
+		return x.Reason
 	}
-	return nil
+	return ""
 }
 
-type KvStoreGetValueRequest struct {
+type KeyValueEventRequest struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	// ValueRef of the key/value pair to get, which includes the store and key
-	Ref *ValueRef `protobuf:"bytes,1,opt,name=ref,proto3" json:"ref,omitempty"`
+	StoreName string `protobuf:"bytes,1,opt,name=store_name,json=storeName,proto3" json:"store_name,omitempty"`
+	// Types that are assignable to Event:
+	//
+	//	*KeyValueEventRequest_KeyValueEvent
+	Event isKeyValueEventRequest_Event `protobuf_oneof:"event"`
 }
 
-func (x *KvStoreGetValueRequest) Reset() {
-	*x = KvStoreGetValueRequest{}
+func (x *KeyValueEventRequest) Reset() {
+	*x = KeyValueEventRequest{}
 	if protoimpl.UnsafeEnabled {
 		mi := &file_nitric_proto_kvstore_v1_kvstore_proto_msgTypes[3]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -204,13 +351,13 @@ func (x *KvStoreGetValueRequest) Reset() {
 	}
 }
 
-func (x *KvStoreGetValueRequest) String() string {
+func (x *KeyValueEventRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*KvStoreGetValueRequest) ProtoMessage() {}
+func (*KeyValueEventRequest) ProtoMessage() {}
 
-func (x *KvStoreGetValueRequest) ProtoReflect() protoreflect.Message {
+func (x *KeyValueEventRequest) ProtoReflect() protoreflect.Message {
 	mi := &file_nitric_proto_kvstore_v1_kvstore_proto_msgTypes[3]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -222,29 +369,55 @@ func (x *KvStoreGetValueRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use KvStoreGetValueRequest.ProtoReflect.Descriptor instead.
-func (*KvStoreGetValueRequest) Descriptor() ([]byte, []int) {
+// Deprecated: Use KeyValueEventRequest.ProtoReflect.Descriptor instead.
+func (*KeyValueEventRequest) Descriptor() ([]byte, []int) {
 	return file_nitric_proto_kvstore_v1_kvstore_proto_rawDescGZIP(), []int{3}
 }
 
-func (x *KvStoreGetValueRequest) GetRef() *ValueRef {
+func (x *KeyValueEventRequest) GetStoreName() string {
 	if x != nil {
-		return x.Ref
+		return x.StoreName
+	}
+	return ""
+}
+
+func (m *KeyValueEventRequest) GetEvent() isKeyValueEventRequest_Event {
+	if m != nil {
+		return m.Event
 	}
 	return nil
 }
 
-type KvStoreGetValueResponse struct {
+func (x *KeyValueEventRequest) GetKeyValueEvent() *KeyValueEvent {
+	if x, ok := x.GetEvent().(*KeyValueEventRequest_KeyValueEvent); ok {
+		return x.KeyValueEvent
+	}
+	return nil
+}
+
+type isKeyValueEventRequest_Event interface {
+	isKeyValueEventRequest_Event()
+}
+
+type KeyValueEventRequest_KeyValueEvent struct {
+	KeyValueEvent *KeyValueEvent `protobuf:"bytes,10,opt,name=key_value_event,json=keyValueEvent,proto3,oneof"`
+}
+
+func (*KeyValueEventRequest_KeyValueEvent) isKeyValueEventRequest_Event() {}
+
+type KeyValueEvent struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	// The retrieved value
-	Value *Value `protobuf:"bytes,1,opt,name=value,proto3" json:"value,omitempty"`
+	// The key of the value the event is for
+	Key string `protobuf:"bytes,1,opt,name=key,proto3" json:"key,omitempty"`
+	// The type of event that occurred
+	Type KeyValueEventType `protobuf:"varint,2,opt,name=type,proto3,enum=nitric.proto.kvstore.v1.KeyValueEventType" json:"type,omitempty"`
 }
 
-func (x *KvStoreGetValueResponse) Reset() {
-	*x = KvStoreGetValueResponse{}
+func (x *KeyValueEvent) Reset() {
+	*x = KeyValueEvent{}
 	if protoimpl.UnsafeEnabled {
 		mi := &file_nitric_proto_kvstore_v1_kvstore_proto_msgTypes[4]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -252,13 +425,13 @@ func (x *KvStoreGetValueResponse) Reset() {
 	}
 }
 
-func (x *KvStoreGetValueResponse) String() string {
+func (x *KeyValueEvent) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*KvStoreGetValueResponse) ProtoMessage() {}
+func (*KeyValueEvent) ProtoMessage() {}
 
-func (x *KvStoreGetValueResponse) ProtoReflect() protoreflect.Message {
+func (x *KeyValueEvent) ProtoReflect() protoreflect.Message {
 	mi := &file_nitric_proto_kvstore_v1_kvstore_proto_msgTypes[4]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -270,31 +443,35 @@ func (x *KvStoreGetValueResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use KvStoreGetValueResponse.ProtoReflect.Descriptor instead.
-func (*KvStoreGetValueResponse) Descriptor() ([]byte, []int) {
+// Deprecated: Use KeyValueEvent.ProtoReflect.Descriptor instead.
+func (*KeyValueEvent) Descriptor() ([]byte, []int) {
 	return file_nitric_proto_kvstore_v1_kvstore_proto_rawDescGZIP(), []int{4}
 }
 
-func (x *KvStoreGetValueResponse) GetValue() *Value {
+func (x *KeyValueEvent) GetKey() string {
 	if x != nil {
-		return x.Value
+		return x.Key
 	}
-	return nil
+	return ""
 }
 
-type KvStoreSetValueRequest struct {
+func (x *KeyValueEvent) GetType() KeyValueEventType {
+	if x != nil {
+		return x.Type
+	}
+	return KeyValueEventType_Set
+}
+
+type KeyValueEventResponse struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	// ValueRef of the key/value pair to set, which includes the store and key
-	Ref *ValueRef `protobuf:"bytes,1,opt,name=ref,proto3" json:"ref,omitempty"`
-	// The value content to store (JSON object)
-	Content *structpb.Struct `protobuf:"bytes,3,opt,name=content,proto3" json:"content,omitempty"`
+	Success bool `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
 }
 
-func (x *KvStoreSetValueRequest) Reset() {
-	*x = KvStoreSetValueRequest{}
+func (x *KeyValueEventResponse) Reset() {
+	*x = KeyValueEventResponse{}
 	if protoimpl.UnsafeEnabled {
 		mi := &file_nitric_proto_kvstore_v1_kvstore_proto_msgTypes[5]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -302,13 +479,13 @@ func (x *KvStoreSetValueRequest) Reset() {
 	}
 }
 
-func (x *KvStoreSetValueRequest) String() string {
+func (x *KeyValueEventResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*KvStoreSetValueRequest) ProtoMessage() {}
+func (*KeyValueEventResponse) ProtoMessage() {}
 
-func (x *KvStoreSetValueRequest) ProtoReflect() protoreflect.Message {
+func (x *KeyValueEventResponse) ProtoReflect() protoreflect.Message {
 	mi := &file_nitric_proto_kvstore_v1_kvstore_proto_msgTypes[5]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -320,33 +497,33 @@ func (x *KvStoreSetValueRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use KvStoreSetValueRequest.ProtoReflect.Descriptor instead.
-func (*KvStoreSetValueRequest) Descriptor() ([]byte, []int) {
+// Deprecated: Use KeyValueEventResponse.ProtoReflect.Descriptor instead.
+func (*KeyValueEventResponse) Descriptor() ([]byte, []int) {
 	return file_nitric_proto_kvstore_v1_kvstore_proto_rawDescGZIP(), []int{5}
 }
 
-func (x *KvStoreSetValueRequest) GetRef() *ValueRef {
-	if x != nil {
-		return x.Ref
-	}
-	return nil
-}
-
-func (x *KvStoreSetValueRequest) GetContent() *structpb.Struct {
+func (x *KeyValueEventResponse) GetSuccess() bool {
 	if x != nil {
-		return x.Content
+		return x.Success
 	}
-	return nil
+	return false
 }
 
-type KvStoreSetValueResponse struct {
+type RegistrationRequest struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
+
+	// Name of the store to watch
+	StoreName string `protobuf:"bytes,1,opt,name=store_name,json=storeName,proto3" json:"store_name,omitempty"`
+	// Event type to listen for
+	EventType KeyValueEventType `protobuf:"varint,2,opt,name=event_type,json=eventType,proto3,enum=nitric.proto.kvstore.v1.KeyValueEventType" json:"event_type,omitempty"`
+	// A key prefix to filter events by
+	KeyPrefixFilter string `protobuf:"bytes,3,opt,name=key_prefix_filter,json=keyPrefixFilter,proto3" json:"key_prefix_filter,omitempty"`
 }
 
-func (x *KvStoreSetValueResponse) Reset() {
-	*x = KvStoreSetValueResponse{}
+func (x *RegistrationRequest) Reset() {
+	*x = RegistrationRequest{}
 	if protoimpl.UnsafeEnabled {
 		mi := &file_nitric_proto_kvstore_v1_kvstore_proto_msgTypes[6]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -354,13 +531,13 @@ func (x *KvStoreSetValueResponse) Reset() {
 	}
 }
 
-func (x *KvStoreSetValueResponse) String() string {
+func (x *RegistrationRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*KvStoreSetValueResponse) ProtoMessage() {}
+func (*RegistrationRequest) ProtoMessage() {}
 
-func (x *KvStoreSetValueResponse) ProtoReflect() protoreflect.Message {
+func (x *RegistrationRequest) ProtoReflect() protoreflect.Message {
 	mi := &file_nitric_proto_kvstore_v1_kvstore_proto_msgTypes[6]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -372,22 +549,43 @@ func (x *KvStoreSetValueResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use KvStoreSetValueResponse.ProtoReflect.Descriptor instead.
-func (*KvStoreSetValueResponse) Descriptor() ([]byte, []int) {
+// Deprecated: Use RegistrationRequest.ProtoReflect.Descriptor instead.
+func (*RegistrationRequest) Descriptor() ([]byte, []int) {
 	return file_nitric_proto_kvstore_v1_kvstore_proto_rawDescGZIP(), []int{6}
 }
 
-type KvStoreDeleteKeyRequest struct {
+func (x *RegistrationRequest) GetStoreName() string {
+	if x != nil {
+		return x.StoreName
+	}
+	return ""
+}
+
+func (x *RegistrationRequest) GetEventType() KeyValueEventType {
+	if x != nil {
+		return x.EventType
+	}
+	return KeyValueEventType_Set
+}
+
+func (x *RegistrationRequest) GetKeyPrefixFilter() string {
+	if x != nil {
+		return x.KeyPrefixFilter
+	}
+	return ""
+}
+
+type RegistrationResponse struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	// ValueRef of the key/value pair to delete, which includes the store and key
-	Ref *ValueRef `protobuf:"bytes,1,opt,name=ref,proto3" json:"ref,omitempty"`
+	// The ID of the registration
+	Id string `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
 }
 
-func (x *KvStoreDeleteKeyRequest) Reset() {
-	*x = KvStoreDeleteKeyRequest{}
+func (x *RegistrationResponse) Reset() {
+	*x = RegistrationResponse{}
 	if protoimpl.UnsafeEnabled {
 		mi := &file_nitric_proto_kvstore_v1_kvstore_proto_msgTypes[7]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -395,13 +593,13 @@ func (x *KvStoreDeleteKeyRequest) Reset() {
 	}
 }
 
-func (x *KvStoreDeleteKeyRequest) String() string {
+func (x *RegistrationResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*KvStoreDeleteKeyRequest) ProtoMessage() {}
+func (*RegistrationResponse) ProtoMessage() {}
 
-func (x *KvStoreDeleteKeyRequest) ProtoReflect() protoreflect.Message {
+func (x *RegistrationResponse) ProtoReflect() protoreflect.Message {
 	mi := &file_nitric_proto_kvstore_v1_kvstore_proto_msgTypes[7]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -413,26 +611,30 @@ func (x *KvStoreDeleteKeyRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use KvStoreDeleteKeyRequest.ProtoReflect.Descriptor instead.
-func (*KvStoreDeleteKeyRequest) Descriptor() ([]byte, []int) {
+// Deprecated: Use RegistrationResponse.ProtoReflect.Descriptor instead.
+func (*RegistrationResponse) Descriptor() ([]byte, []int) {
 	return file_nitric_proto_kvstore_v1_kvstore_proto_rawDescGZIP(), []int{7}
 }
 
-func (x *KvStoreDeleteKeyRequest) GetRef() *ValueRef {
+func (x *RegistrationResponse) GetId() string {
 	if x != nil {
-		return x.Ref
+		return x.Id
 	}
-	return nil
+	return ""
 }
 
-type KvStoreDeleteKeyResponse struct {
+// Provides a Key/Value Store
+type Store struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
+
+	// The store name
+	Name string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
 }
 
-func (x *KvStoreDeleteKeyResponse) Reset() {
-	*x = KvStoreDeleteKeyResponse{}
+func (x *Store) Reset() {
+	*x = Store{}
 	if protoimpl.UnsafeEnabled {
 		mi := &file_nitric_proto_kvstore_v1_kvstore_proto_msgTypes[8]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -440,13 +642,13 @@ func (x *KvStoreDeleteKeyResponse) Reset() {
 	}
 }
 
-func (x *KvStoreDeleteKeyResponse) String() string {
+func (x *Store) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*KvStoreDeleteKeyResponse) ProtoMessage() {}
+func (*Store) ProtoMessage() {}
 
-func (x *KvStoreDeleteKeyResponse) ProtoReflect() protoreflect.Message {
+func (x *Store) ProtoReflect() protoreflect.Message {
 	mi := &file_nitric_proto_kvstore_v1_kvstore_proto_msgTypes[8]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -458,24 +660,32 @@ func (x *KvStoreDeleteKeyResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use KvStoreDeleteKeyResponse.ProtoReflect.Descriptor instead.
-func (*KvStoreDeleteKeyResponse) Descriptor() ([]byte, []int) {
+// Deprecated: Use Store.ProtoReflect.Descriptor instead.
+func (*Store) Descriptor() ([]byte, []int) {
 	return file_nitric_proto_kvstore_v1_kvstore_proto_rawDescGZIP(), []int{8}
 }
 
-type KvStoreScanKeysRequest struct {
-	state         protoimpl.MessageState
-	sizeCache     protoimpl.SizeCache
-	unknownFields protoimpl.UnknownFields
-
-	// The store to iterate over
-	Store *Store `protobuf:"bytes,1,opt,name=store,proto3" json:"store,omitempty"`
-	// The prefix to filter keys by
-	Prefix string `protobuf:"bytes,2,opt,name=prefix,proto3" json:"prefix,omitempty"`
+func (x *Store) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
 }
 
-func (x *KvStoreScanKeysRequest) Reset() {
-	*x = KvStoreScanKeysRequest{}
+// ValueRef provides a unique identifier for a value
+type ValueRef struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// The key/value store name
+	Store string `protobuf:"bytes,1,opt,name=store,proto3" json:"store,omitempty"`
+	// The item's unique key within the store
+	Key string `protobuf:"bytes,2,opt,name=key,proto3" json:"key,omitempty"`
+}
+
+func (x *ValueRef) Reset() {
+	*x = ValueRef{}
 	if protoimpl.UnsafeEnabled {
 		mi := &file_nitric_proto_kvstore_v1_kvstore_proto_msgTypes[9]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -483,13 +693,13 @@ func (x *KvStoreScanKeysRequest) Reset() {
 	}
 }
 
-func (x *KvStoreScanKeysRequest) String() string {
+func (x *ValueRef) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*KvStoreScanKeysRequest) ProtoMessage() {}
+func (*ValueRef) ProtoMessage() {}
 
-func (x *KvStoreScanKeysRequest) ProtoReflect() protoreflect.Message {
+func (x *ValueRef) ProtoReflect() protoreflect.Message {
 	mi := &file_nitric_proto_kvstore_v1_kvstore_proto_msgTypes[9]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -501,51 +711,1171 @@ func (x *KvStoreScanKeysRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use KvStoreScanKeysRequest.ProtoReflect.Descriptor instead.
-func (*KvStoreScanKeysRequest) Descriptor() ([]byte, []int) {
+// Deprecated: Use ValueRef.ProtoReflect.Descriptor instead.
+func (*ValueRef) Descriptor() ([]byte, []int) {
 	return file_nitric_proto_kvstore_v1_kvstore_proto_rawDescGZIP(), []int{9}
 }
 
-func (x *KvStoreScanKeysRequest) GetStore() *Store {
+func (x *ValueRef) GetStore() string {
 	if x != nil {
 		return x.Store
 	}
+	return ""
+}
+
+func (x *ValueRef) GetKey() string {
+	if x != nil {
+		return x.Key
+	}
+	return ""
+}
+
+// Value provides a return value type
+type Value struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// ValueRef of the key/value pair, which includes the store and key
+	Ref *ValueRef `protobuf:"bytes,1,opt,name=ref,proto3" json:"ref,omitempty"`
+	// The content (JSON object)
+	Content *structpb.Struct `protobuf:"bytes,2,opt,name=content,proto3" json:"content,omitempty"`
+	// Opaque version of the value, which changes every time it is set.
+	// Used as a precondition to only write a value if it hasn't changed since it was read.
+	Version string `protobuf:"bytes,3,opt,name=version,proto3" json:"version,omitempty"`
+}
+
+func (x *Value) Reset() {
+	*x = Value{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_nitric_proto_kvstore_v1_kvstore_proto_msgTypes[10]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Value) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Value) ProtoMessage() {}
+
+func (x *Value) ProtoReflect() protoreflect.Message {
+	mi := &file_nitric_proto_kvstore_v1_kvstore_proto_msgTypes[10]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Value.ProtoReflect.Descriptor instead.
+func (*Value) Descriptor() ([]byte, []int) {
+	return file_nitric_proto_kvstore_v1_kvstore_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *Value) GetRef() *ValueRef {
+	if x != nil {
+		return x.Ref
+	}
 	return nil
 }
 
-func (x *KvStoreScanKeysRequest) GetPrefix() string {
+func (x *Value) GetContent() *structpb.Struct {
 	if x != nil {
-		return x.Prefix
+		return x.Content
+	}
+	return nil
+}
+
+func (x *Value) GetVersion() string {
+	if x != nil {
+		return x.Version
+	}
+	return ""
+}
+
+// Condition that must be met for a write to be applied, otherwise it fails with FAILED_PRECONDITION
+type KvStorePrecondition struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Types that are assignable to Condition:
+	//
+	//	*KvStorePrecondition_Version
+	//	*KvStorePrecondition_Exists
+	Condition isKvStorePrecondition_Condition `protobuf_oneof:"condition"`
+}
+
+func (x *KvStorePrecondition) Reset() {
+	*x = KvStorePrecondition{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_nitric_proto_kvstore_v1_kvstore_proto_msgTypes[11]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *KvStorePrecondition) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*KvStorePrecondition) ProtoMessage() {}
+
+func (x *KvStorePrecondition) ProtoReflect() protoreflect.Message {
+	mi := &file_nitric_proto_kvstore_v1_kvstore_proto_msgTypes[11]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use KvStorePrecondition.ProtoReflect.Descriptor instead.
+func (*KvStorePrecondition) Descriptor() ([]byte, []int) {
+	return file_nitric_proto_kvstore_v1_kvstore_proto_rawDescGZIP(), []int{11}
+}
+
+func (m *KvStorePrecondition) GetCondition() isKvStorePrecondition_Condition {
+	if m != nil {
+		return m.Condition
+	}
+	return nil
+}
+
+func (x *KvStorePrecondition) GetVersion() string {
+	if x, ok := x.GetCondition().(*KvStorePrecondition_Version); ok {
+		return x.Version
 	}
 	return ""
 }
 
-type KvStoreScanKeysResponse struct {
+func (x *KvStorePrecondition) GetExists() bool {
+	if x, ok := x.GetCondition().(*KvStorePrecondition_Exists); ok {
+		return x.Exists
+	}
+	return false
+}
+
+type isKvStorePrecondition_Condition interface {
+	isKvStorePrecondition_Condition()
+}
+
+type KvStorePrecondition_Version struct {
+	// Only write if the key's current value has this version
+	Version string `protobuf:"bytes,1,opt,name=version,proto3,oneof"`
+}
+
+type KvStorePrecondition_Exists struct {
+	// Only write if the key has a value (true) or doesn't have a value (false).
+	// Deleting a key only if it doesn't have a value isn't supported.
+	Exists bool `protobuf:"varint,2,opt,name=exists,proto3,oneof"`
+}
+
+func (*KvStorePrecondition_Version) isKvStorePrecondition_Condition() {}
+
+func (*KvStorePrecondition_Exists) isKvStorePrecondition_Condition() {}
+
+type KvStoreGetValueRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// ValueRef of the key/value pair to get, which includes the store and key
+	Ref *ValueRef `protobuf:"bytes,1,opt,name=ref,proto3" json:"ref,omitempty"`
+}
+
+func (x *KvStoreGetValueRequest) Reset() {
+	*x = KvStoreGetValueRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_nitric_proto_kvstore_v1_kvstore_proto_msgTypes[12]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *KvStoreGetValueRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*KvStoreGetValueRequest) ProtoMessage() {}
+
+func (x *KvStoreGetValueRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_nitric_proto_kvstore_v1_kvstore_proto_msgTypes[12]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use KvStoreGetValueRequest.ProtoReflect.Descriptor instead.
+func (*KvStoreGetValueRequest) Descriptor() ([]byte, []int) {
+	return file_nitric_proto_kvstore_v1_kvstore_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *KvStoreGetValueRequest) GetRef() *ValueRef {
+	if x != nil {
+		return x.Ref
+	}
+	return nil
+}
+
+type KvStoreGetValueResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// The retrieved value
+	Value *Value `protobuf:"bytes,1,opt,name=value,proto3" json:"value,omitempty"`
+}
+
+func (x *KvStoreGetValueResponse) Reset() {
+	*x = KvStoreGetValueResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_nitric_proto_kvstore_v1_kvstore_proto_msgTypes[13]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *KvStoreGetValueResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*KvStoreGetValueResponse) ProtoMessage() {}
+
+func (x *KvStoreGetValueResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_nitric_proto_kvstore_v1_kvstore_proto_msgTypes[13]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use KvStoreGetValueResponse.ProtoReflect.Descriptor instead.
+func (*KvStoreGetValueResponse) Descriptor() ([]byte, []int) {
+	return file_nitric_proto_kvstore_v1_kvstore_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *KvStoreGetValueResponse) GetValue() *Value {
+	if x != nil {
+		return x.Value
+	}
+	return nil
+}
+
+type KvStoreSetValueRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// ValueRef of the key/value pair to set, which includes the store and key
+	Ref *ValueRef `protobuf:"bytes,1,opt,name=ref,proto3" json:"ref,omitempty"`
+	// The value content to store (JSON object)
+	Content *structpb.Struct `protobuf:"bytes,3,opt,name=content,proto3" json:"content,omitempty"`
+	// Optional time-to-live, after which the value expires and is no longer returned.
+	// Values without a ttl never expire, overwriting a value replaces its ttl.
+	Ttl *durationpb.Duration `protobuf:"bytes,4,opt,name=ttl,proto3" json:"ttl,omitempty"`
+	// Optional condition the key's current value must meet for it to be set
+	Precondition *KvStorePrecondition `protobuf:"bytes,5,opt,name=precondition,proto3" json:"precondition,omitempty"`
+}
+
+func (x *KvStoreSetValueRequest) Reset() {
+	*x = KvStoreSetValueRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_nitric_proto_kvstore_v1_kvstore_proto_msgTypes[14]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *KvStoreSetValueRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*KvStoreSetValueRequest) ProtoMessage() {}
+
+func (x *KvStoreSetValueRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_nitric_proto_kvstore_v1_kvstore_proto_msgTypes[14]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use KvStoreSetValueRequest.ProtoReflect.Descriptor instead.
+func (*KvStoreSetValueRequest) Descriptor() ([]byte, []int) {
+	return file_nitric_proto_kvstore_v1_kvstore_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *KvStoreSetValueRequest) GetRef() *ValueRef {
+	if x != nil {
+		return x.Ref
+	}
+	return nil
+}
+
+func (x *KvStoreSetValueRequest) GetContent() *structpb.Struct {
+	if x != nil {
+		return x.Content
+	}
+	return nil
+}
+
+func (x *KvStoreSetValueRequest) GetTtl() *durationpb.Duration {
+	if x != nil {
+		return x.Ttl
+	}
+	return nil
+}
+
+func (x *KvStoreSetValueRequest) GetPrecondition() *KvStorePrecondition {
+	if x != nil {
+		return x.Precondition
+	}
+	return nil
+}
+
+type KvStoreSetValueResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// The version of the value that was set
+	Version string `protobuf:"bytes,1,opt,name=version,proto3" json:"version,omitempty"`
+}
+
+func (x *KvStoreSetValueResponse) Reset() {
+	*x = KvStoreSetValueResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_nitric_proto_kvstore_v1_kvstore_proto_msgTypes[15]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *KvStoreSetValueResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*KvStoreSetValueResponse) ProtoMessage() {}
+
+func (x *KvStoreSetValueResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_nitric_proto_kvstore_v1_kvstore_proto_msgTypes[15]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use KvStoreSetValueResponse.ProtoReflect.Descriptor instead.
+func (*KvStoreSetValueResponse) Descriptor() ([]byte, []int) {
+	return file_nitric_proto_kvstore_v1_kvstore_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *KvStoreSetValueResponse) GetVersion() string {
+	if x != nil {
+		return x.Version
+	}
+	return ""
+}
+
+type KvStoreDeleteKeyRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// ValueRef of the key/value pair to delete, which includes the store and key
+	Ref *ValueRef `protobuf:"bytes,1,opt,name=ref,proto3" json:"ref,omitempty"`
+	// Optional condition the key's current value must meet for it to be deleted
+	Precondition *KvStorePrecondition `protobuf:"bytes,2,opt,name=precondition,proto3" json:"precondition,omitempty"`
+}
+
+func (x *KvStoreDeleteKeyRequest) Reset() {
+	*x = KvStoreDeleteKeyRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_nitric_proto_kvstore_v1_kvstore_proto_msgTypes[16]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *KvStoreDeleteKeyRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*KvStoreDeleteKeyRequest) ProtoMessage() {}
+
+func (x *KvStoreDeleteKeyRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_nitric_proto_kvstore_v1_kvstore_proto_msgTypes[16]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use KvStoreDeleteKeyRequest.ProtoReflect.Descriptor instead.
+func (*KvStoreDeleteKeyRequest) Descriptor() ([]byte, []int) {
+	return file_nitric_proto_kvstore_v1_kvstore_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *KvStoreDeleteKeyRequest) GetRef() *ValueRef {
+	if x != nil {
+		return x.Ref
+	}
+	return nil
+}
+
+func (x *KvStoreDeleteKeyRequest) GetPrecondition() *KvStorePrecondition {
+	if x != nil {
+		return x.Precondition
+	}
+	return nil
+}
+
+type KvStoreDeleteKeyResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+}
+
+func (x *KvStoreDeleteKeyResponse) Reset() {
+	*x = KvStoreDeleteKeyResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_nitric_proto_kvstore_v1_kvstore_proto_msgTypes[17]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *KvStoreDeleteKeyResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*KvStoreDeleteKeyResponse) ProtoMessage() {}
+
+func (x *KvStoreDeleteKeyResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_nitric_proto_kvstore_v1_kvstore_proto_msgTypes[17]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use KvStoreDeleteKeyResponse.ProtoReflect.Descriptor instead.
+func (*KvStoreDeleteKeyResponse) Descriptor() ([]byte, []int) {
+	return file_nitric_proto_kvstore_v1_kvstore_proto_rawDescGZIP(), []int{17}
+}
+
+type KvStoreScanKeysRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// The store to iterate over
+	Store *Store `protobuf:"bytes,1,opt,name=store,proto3" json:"store,omitempty"`
+	// The prefix to filter keys by
+	Prefix string `protobuf:"bytes,2,opt,name=prefix,proto3" json:"prefix,omitempty"`
+	// Maximum number of keys to return, 0 returns all of them
+	Limit int32 `protobuf:"varint,3,opt,name=limit,proto3" json:"limit,omitempty"`
+	// Token to resume a previous scan after the key it was returned with
+	ContinuationToken string `protobuf:"bytes,4,opt,name=continuation_token,json=continuationToken,proto3" json:"continuation_token,omitempty"`
+	// Return the value of each key with it
+	IncludeValues bool `protobuf:"varint,5,opt,name=include_values,json=includeValues,proto3" json:"include_values,omitempty"`
+	// Return keys in descending order, not supported by all providers
+	Reverse bool `protobuf:"varint,6,opt,name=reverse,proto3" json:"reverse,omitempty"`
+}
+
+func (x *KvStoreScanKeysRequest) Reset() {
+	*x = KvStoreScanKeysRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_nitric_proto_kvstore_v1_kvstore_proto_msgTypes[18]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *KvStoreScanKeysRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*KvStoreScanKeysRequest) ProtoMessage() {}
+
+func (x *KvStoreScanKeysRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_nitric_proto_kvstore_v1_kvstore_proto_msgTypes[18]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use KvStoreScanKeysRequest.ProtoReflect.Descriptor instead.
+func (*KvStoreScanKeysRequest) Descriptor() ([]byte, []int) {
+	return file_nitric_proto_kvstore_v1_kvstore_proto_rawDescGZIP(), []int{18}
+}
+
+func (x *KvStoreScanKeysRequest) GetStore() *Store {
+	if x != nil {
+		return x.Store
+	}
+	return nil
+}
+
+func (x *KvStoreScanKeysRequest) GetPrefix() string {
+	if x != nil {
+		return x.Prefix
+	}
+	return ""
+}
+
+func (x *KvStoreScanKeysRequest) GetLimit() int32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+func (x *KvStoreScanKeysRequest) GetContinuationToken() string {
+	if x != nil {
+		return x.ContinuationToken
+	}
+	return ""
+}
+
+func (x *KvStoreScanKeysRequest) GetIncludeValues() bool {
+	if x != nil {
+		return x.IncludeValues
+	}
+	return false
+}
+
+func (x *KvStoreScanKeysRequest) GetReverse() bool {
+	if x != nil {
+		return x.Reverse
+	}
+	return false
+}
+
+type KvStoreScanKeysResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// The key of the key/value pair
+	Key string `protobuf:"bytes,1,opt,name=key,proto3" json:"key,omitempty"`
+	// The value of the key/value pair, only populated when include_values is requested
+	Value *Value `protobuf:"bytes,2,opt,name=value,proto3" json:"value,omitempty"`
+	// Token to resume the scan after this key
+	ContinuationToken string `protobuf:"bytes,3,opt,name=continuation_token,json=continuationToken,proto3" json:"continuation_token,omitempty"`
+}
+
+func (x *KvStoreScanKeysResponse) Reset() {
+	*x = KvStoreScanKeysResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_nitric_proto_kvstore_v1_kvstore_proto_msgTypes[19]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *KvStoreScanKeysResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*KvStoreScanKeysResponse) ProtoMessage() {}
+
+func (x *KvStoreScanKeysResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_nitric_proto_kvstore_v1_kvstore_proto_msgTypes[19]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use KvStoreScanKeysResponse.ProtoReflect.Descriptor instead.
+func (*KvStoreScanKeysResponse) Descriptor() ([]byte, []int) {
+	return file_nitric_proto_kvstore_v1_kvstore_proto_rawDescGZIP(), []int{19}
+}
+
+func (x *KvStoreScanKeysResponse) GetKey() string {
+	if x != nil {
+		return x.Key
+	}
+	return ""
+}
+
+func (x *KvStoreScanKeysResponse) GetValue() *Value {
+	if x != nil {
+		return x.Value
+	}
+	return nil
+}
+
+func (x *KvStoreScanKeysResponse) GetContinuationToken() string {
+	if x != nil {
+		return x.ContinuationToken
+	}
+	return ""
+}
+
+// Failure of a single item in a batch operation
+type KvStoreItemError struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// ValueRef of the key/value pair that failed
+	Ref *ValueRef `protobuf:"bytes,1,opt,name=ref,proto3" json:"ref,omitempty"`
+	// gRPC status code of the failure
+	Code int32 `protobuf:"varint,2,opt,name=code,proto3" json:"code,omitempty"`
+	// Description of the failure
+	Message string `protobuf:"bytes,3,opt,name=message,proto3" json:"message,omitempty"`
+}
+
+func (x *KvStoreItemError) Reset() {
+	*x = KvStoreItemError{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_nitric_proto_kvstore_v1_kvstore_proto_msgTypes[20]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *KvStoreItemError) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*KvStoreItemError) ProtoMessage() {}
+
+func (x *KvStoreItemError) ProtoReflect() protoreflect.Message {
+	mi := &file_nitric_proto_kvstore_v1_kvstore_proto_msgTypes[20]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use KvStoreItemError.ProtoReflect.Descriptor instead.
+func (*KvStoreItemError) Descriptor() ([]byte, []int) {
+	return file_nitric_proto_kvstore_v1_kvstore_proto_rawDescGZIP(), []int{20}
+}
+
+func (x *KvStoreItemError) GetRef() *ValueRef {
+	if x != nil {
+		return x.Ref
+	}
+	return nil
+}
+
+func (x *KvStoreItemError) GetCode() int32 {
+	if x != nil {
+		return x.Code
+	}
+	return 0
+}
+
+func (x *KvStoreItemError) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+type KvStoreBatchGetRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// ValueRefs of the key/value pairs to get
+	Refs []*ValueRef `protobuf:"bytes,1,rep,name=refs,proto3" json:"refs,omitempty"`
+}
+
+func (x *KvStoreBatchGetRequest) Reset() {
+	*x = KvStoreBatchGetRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_nitric_proto_kvstore_v1_kvstore_proto_msgTypes[21]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *KvStoreBatchGetRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*KvStoreBatchGetRequest) ProtoMessage() {}
+
+func (x *KvStoreBatchGetRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_nitric_proto_kvstore_v1_kvstore_proto_msgTypes[21]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use KvStoreBatchGetRequest.ProtoReflect.Descriptor instead.
+func (*KvStoreBatchGetRequest) Descriptor() ([]byte, []int) {
+	return file_nitric_proto_kvstore_v1_kvstore_proto_rawDescGZIP(), []int{21}
+}
+
+func (x *KvStoreBatchGetRequest) GetRefs() []*ValueRef {
+	if x != nil {
+		return x.Refs
+	}
+	return nil
+}
+
+type KvStoreBatchGetResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// The retrieved values
+	Values []*Value `protobuf:"bytes,1,rep,name=values,proto3" json:"values,omitempty"`
+	// Keys that couldn't be retrieved, including keys that don't exist
+	Errors []*KvStoreItemError `protobuf:"bytes,2,rep,name=errors,proto3" json:"errors,omitempty"`
+}
+
+func (x *KvStoreBatchGetResponse) Reset() {
+	*x = KvStoreBatchGetResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_nitric_proto_kvstore_v1_kvstore_proto_msgTypes[22]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *KvStoreBatchGetResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*KvStoreBatchGetResponse) ProtoMessage() {}
+
+func (x *KvStoreBatchGetResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_nitric_proto_kvstore_v1_kvstore_proto_msgTypes[22]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use KvStoreBatchGetResponse.ProtoReflect.Descriptor instead.
+func (*KvStoreBatchGetResponse) Descriptor() ([]byte, []int) {
+	return file_nitric_proto_kvstore_v1_kvstore_proto_rawDescGZIP(), []int{22}
+}
+
+func (x *KvStoreBatchGetResponse) GetValues() []*Value {
+	if x != nil {
+		return x.Values
+	}
+	return nil
+}
+
+func (x *KvStoreBatchGetResponse) GetErrors() []*KvStoreItemError {
+	if x != nil {
+		return x.Errors
+	}
+	return nil
+}
+
+type KvStoreBatchSetRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// The values to store, including their store and key
+	Values []*Value `protobuf:"bytes,1,rep,name=values,proto3" json:"values,omitempty"`
+}
+
+func (x *KvStoreBatchSetRequest) Reset() {
+	*x = KvStoreBatchSetRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_nitric_proto_kvstore_v1_kvstore_proto_msgTypes[23]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *KvStoreBatchSetRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*KvStoreBatchSetRequest) ProtoMessage() {}
+
+func (x *KvStoreBatchSetRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_nitric_proto_kvstore_v1_kvstore_proto_msgTypes[23]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use KvStoreBatchSetRequest.ProtoReflect.Descriptor instead.
+func (*KvStoreBatchSetRequest) Descriptor() ([]byte, []int) {
+	return file_nitric_proto_kvstore_v1_kvstore_proto_rawDescGZIP(), []int{23}
+}
+
+func (x *KvStoreBatchSetRequest) GetValues() []*Value {
+	if x != nil {
+		return x.Values
+	}
+	return nil
+}
+
+type KvStoreBatchSetResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Values that couldn't be stored
+	Errors []*KvStoreItemError `protobuf:"bytes,1,rep,name=errors,proto3" json:"errors,omitempty"`
+}
+
+func (x *KvStoreBatchSetResponse) Reset() {
+	*x = KvStoreBatchSetResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_nitric_proto_kvstore_v1_kvstore_proto_msgTypes[24]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *KvStoreBatchSetResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*KvStoreBatchSetResponse) ProtoMessage() {}
+
+func (x *KvStoreBatchSetResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_nitric_proto_kvstore_v1_kvstore_proto_msgTypes[24]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use KvStoreBatchSetResponse.ProtoReflect.Descriptor instead.
+func (*KvStoreBatchSetResponse) Descriptor() ([]byte, []int) {
+	return file_nitric_proto_kvstore_v1_kvstore_proto_rawDescGZIP(), []int{24}
+}
+
+func (x *KvStoreBatchSetResponse) GetErrors() []*KvStoreItemError {
+	if x != nil {
+		return x.Errors
+	}
+	return nil
+}
+
+type KvStoreBatchDeleteRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// ValueRefs of the key/value pairs to delete
+	Refs []*ValueRef `protobuf:"bytes,1,rep,name=refs,proto3" json:"refs,omitempty"`
+}
+
+func (x *KvStoreBatchDeleteRequest) Reset() {
+	*x = KvStoreBatchDeleteRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_nitric_proto_kvstore_v1_kvstore_proto_msgTypes[25]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *KvStoreBatchDeleteRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*KvStoreBatchDeleteRequest) ProtoMessage() {}
+
+func (x *KvStoreBatchDeleteRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_nitric_proto_kvstore_v1_kvstore_proto_msgTypes[25]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use KvStoreBatchDeleteRequest.ProtoReflect.Descriptor instead.
+func (*KvStoreBatchDeleteRequest) Descriptor() ([]byte, []int) {
+	return file_nitric_proto_kvstore_v1_kvstore_proto_rawDescGZIP(), []int{25}
+}
+
+func (x *KvStoreBatchDeleteRequest) GetRefs() []*ValueRef {
+	if x != nil {
+		return x.Refs
+	}
+	return nil
+}
+
+type KvStoreBatchDeleteResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Keys that couldn't be deleted
+	Errors []*KvStoreItemError `protobuf:"bytes,1,rep,name=errors,proto3" json:"errors,omitempty"`
+}
+
+func (x *KvStoreBatchDeleteResponse) Reset() {
+	*x = KvStoreBatchDeleteResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_nitric_proto_kvstore_v1_kvstore_proto_msgTypes[26]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *KvStoreBatchDeleteResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*KvStoreBatchDeleteResponse) ProtoMessage() {}
+
+func (x *KvStoreBatchDeleteResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_nitric_proto_kvstore_v1_kvstore_proto_msgTypes[26]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use KvStoreBatchDeleteResponse.ProtoReflect.Descriptor instead.
+func (*KvStoreBatchDeleteResponse) Descriptor() ([]byte, []int) {
+	return file_nitric_proto_kvstore_v1_kvstore_proto_rawDescGZIP(), []int{26}
+}
+
+func (x *KvStoreBatchDeleteResponse) GetErrors() []*KvStoreItemError {
+	if x != nil {
+		return x.Errors
+	}
+	return nil
+}
+
+// A single write within a transaction
+type KvStoreTransactOperation struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Types that are assignable to Operation:
+	//
+	//	*KvStoreTransactOperation_Set
+	//	*KvStoreTransactOperation_Delete
+	Operation isKvStoreTransactOperation_Operation `protobuf_oneof:"operation"`
+}
+
+func (x *KvStoreTransactOperation) Reset() {
+	*x = KvStoreTransactOperation{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_nitric_proto_kvstore_v1_kvstore_proto_msgTypes[27]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *KvStoreTransactOperation) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*KvStoreTransactOperation) ProtoMessage() {}
+
+func (x *KvStoreTransactOperation) ProtoReflect() protoreflect.Message {
+	mi := &file_nitric_proto_kvstore_v1_kvstore_proto_msgTypes[27]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use KvStoreTransactOperation.ProtoReflect.Descriptor instead.
+func (*KvStoreTransactOperation) Descriptor() ([]byte, []int) {
+	return file_nitric_proto_kvstore_v1_kvstore_proto_rawDescGZIP(), []int{27}
+}
+
+func (m *KvStoreTransactOperation) GetOperation() isKvStoreTransactOperation_Operation {
+	if m != nil {
+		return m.Operation
+	}
+	return nil
+}
+
+func (x *KvStoreTransactOperation) GetSet() *Value {
+	if x, ok := x.GetOperation().(*KvStoreTransactOperation_Set); ok {
+		return x.Set
+	}
+	return nil
+}
+
+func (x *KvStoreTransactOperation) GetDelete() *ValueRef {
+	if x, ok := x.GetOperation().(*KvStoreTransactOperation_Delete); ok {
+		return x.Delete
+	}
+	return nil
+}
+
+type isKvStoreTransactOperation_Operation interface {
+	isKvStoreTransactOperation_Operation()
+}
+
+type KvStoreTransactOperation_Set struct {
+	// Create a new or overwrite an existing value
+	Set *Value `protobuf:"bytes,1,opt,name=set,proto3,oneof"`
+}
+
+type KvStoreTransactOperation_Delete struct {
+	// Delete a key and its value
+	Delete *ValueRef `protobuf:"bytes,2,opt,name=delete,proto3,oneof"`
+}
+
+func (*KvStoreTransactOperation_Set) isKvStoreTransactOperation_Operation() {}
+
+func (*KvStoreTransactOperation_Delete) isKvStoreTransactOperation_Operation() {}
+
+type KvStoreTransactRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// The operations to apply, each key may only be written once per transaction
+	Operations []*KvStoreTransactOperation `protobuf:"bytes,1,rep,name=operations,proto3" json:"operations,omitempty"`
+}
+
+func (x *KvStoreTransactRequest) Reset() {
+	*x = KvStoreTransactRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_nitric_proto_kvstore_v1_kvstore_proto_msgTypes[28]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *KvStoreTransactRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*KvStoreTransactRequest) ProtoMessage() {}
+
+func (x *KvStoreTransactRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_nitric_proto_kvstore_v1_kvstore_proto_msgTypes[28]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use KvStoreTransactRequest.ProtoReflect.Descriptor instead.
+func (*KvStoreTransactRequest) Descriptor() ([]byte, []int) {
+	return file_nitric_proto_kvstore_v1_kvstore_proto_rawDescGZIP(), []int{28}
+}
+
+func (x *KvStoreTransactRequest) GetOperations() []*KvStoreTransactOperation {
+	if x != nil {
+		return x.Operations
+	}
+	return nil
+}
+
+type KvStoreTransactResponse struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
-
-	// The key of the key/value pair
-	Key string `protobuf:"bytes,1,opt,name=key,proto3" json:"key,omitempty"`
 }
 
-func (x *KvStoreScanKeysResponse) Reset() {
-	*x = KvStoreScanKeysResponse{}
+func (x *KvStoreTransactResponse) Reset() {
+	*x = KvStoreTransactResponse{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_nitric_proto_kvstore_v1_kvstore_proto_msgTypes[10]
+		mi := &file_nitric_proto_kvstore_v1_kvstore_proto_msgTypes[29]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *KvStoreScanKeysResponse) String() string {
+func (x *KvStoreTransactResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*KvStoreScanKeysResponse) ProtoMessage() {}
+func (*KvStoreTransactResponse) ProtoMessage() {}
 
-func (x *KvStoreScanKeysResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_nitric_proto_kvstore_v1_kvstore_proto_msgTypes[10]
+func (x *KvStoreTransactResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_nitric_proto_kvstore_v1_kvstore_proto_msgTypes[29]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -556,16 +1886,9 @@ func (x *KvStoreScanKeysResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use KvStoreScanKeysResponse.ProtoReflect.Descriptor instead.
-func (*KvStoreScanKeysResponse) Descriptor() ([]byte, []int) {
-	return file_nitric_proto_kvstore_v1_kvstore_proto_rawDescGZIP(), []int{10}
-}
-
-func (x *KvStoreScanKeysResponse) GetKey() string {
-	if x != nil {
-		return x.Key
-	}
-	return ""
+// Deprecated: Use KvStoreTransactResponse.ProtoReflect.Descriptor instead.
+func (*KvStoreTransactResponse) Descriptor() ([]byte, []int) {
+	return file_nitric_proto_kvstore_v1_kvstore_proto_rawDescGZIP(), []int{29}
 }
 
 var File_nitric_proto_kvstore_v1_kvstore_proto protoreflect.FileDescriptor
@@ -575,96 +1898,318 @@ var file_nitric_proto_kvstore_v1_kvstore_proto_rawDesc = []byte{
 	0x76, 0x73, 0x74, 0x6f, 0x72, 0x65, 0x2f, 0x76, 0x31, 0x2f, 0x6b, 0x76, 0x73, 0x74, 0x6f, 0x72,
 	0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x12, 0x17, 0x6e, 0x69, 0x74, 0x72, 0x69, 0x63, 0x2e,
 	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x6b, 0x76, 0x73, 0x74, 0x6f, 0x72, 0x65, 0x2e, 0x76, 0x31,
+	0x1a, 0x1e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75,
+	0x66, 0x2f, 0x64, 0x75, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f,
 	0x1a, 0x1c, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75,
-	0x66, 0x2f, 0x73, 0x74, 0x72, 0x75, 0x63, 0x74, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x22, 0x1b,
-	0x0a, 0x05, 0x53, 0x74, 0x6f, 0x72, 0x65, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18,
-	0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x22, 0x32, 0x0a, 0x08, 0x56,
-	0x61, 0x6c, 0x75, 0x65, 0x52, 0x65, 0x66, 0x12, 0x14, 0x0a, 0x05, 0x73, 0x74, 0x6f, 0x72, 0x65,
-	0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x73, 0x74, 0x6f, 0x72, 0x65, 0x12, 0x10, 0x0a,
-	0x03, 0x6b, 0x65, 0x79, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x22,
-	0x6f, 0x0a, 0x05, 0x56, 0x61, 0x6c, 0x75, 0x65, 0x12, 0x33, 0x0a, 0x03, 0x72, 0x65, 0x66, 0x18,
-	0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x21, 0x2e, 0x6e, 0x69, 0x74, 0x72, 0x69, 0x63, 0x2e, 0x70,
+	0x66, 0x2f, 0x73, 0x74, 0x72, 0x75, 0x63, 0x74, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x22, 0xf8,
+	0x01, 0x0a, 0x0d, 0x43, 0x6c, 0x69, 0x65, 0x6e, 0x74, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65,
+	0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x02, 0x69, 0x64,
+	0x12, 0x61, 0x0a, 0x14, 0x72, 0x65, 0x67, 0x69, 0x73, 0x74, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e,
+	0x5f, 0x72, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x2c,
+	0x2e, 0x6e, 0x69, 0x74, 0x72, 0x69, 0x63, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x6b, 0x76,
+	0x73, 0x74, 0x6f, 0x72, 0x65, 0x2e, 0x76, 0x31, 0x2e, 0x52, 0x65, 0x67, 0x69, 0x73, 0x74, 0x72,
+	0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x48, 0x00, 0x52, 0x13,
+	0x72, 0x65, 0x67, 0x69, 0x73, 0x74, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x65, 0x71, 0x75,
+	0x65, 0x73, 0x74, 0x12, 0x69, 0x0a, 0x18, 0x6b, 0x65, 0x79, 0x5f, 0x76, 0x61, 0x6c, 0x75, 0x65,
+	0x5f, 0x65, 0x76, 0x65, 0x6e, 0x74, 0x5f, 0x72, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x18,
+	0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x2e, 0x2e, 0x6e, 0x69, 0x74, 0x72, 0x69, 0x63, 0x2e, 0x70,
 	0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x6b, 0x76, 0x73, 0x74, 0x6f, 0x72, 0x65, 0x2e, 0x76, 0x31, 0x2e,
-	0x56, 0x61, 0x6c, 0x75, 0x65, 0x52, 0x65, 0x66, 0x52, 0x03, 0x72, 0x65, 0x66, 0x12, 0x31, 0x0a,
-	0x07, 0x63, 0x6f, 0x6e, 0x74, 0x65, 0x6e, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x17,
-	0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66,
-	0x2e, 0x53, 0x74, 0x72, 0x75, 0x63, 0x74, 0x52, 0x07, 0x63, 0x6f, 0x6e, 0x74, 0x65, 0x6e, 0x74,
-	0x22, 0x4d, 0x0a, 0x16, 0x4b, 0x76, 0x53, 0x74, 0x6f, 0x72, 0x65, 0x47, 0x65, 0x74, 0x56, 0x61,
-	0x6c, 0x75, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x33, 0x0a, 0x03, 0x72, 0x65,
-	0x66, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x21, 0x2e, 0x6e, 0x69, 0x74, 0x72, 0x69, 0x63,
-	0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x6b, 0x76, 0x73, 0x74, 0x6f, 0x72, 0x65, 0x2e, 0x76,
-	0x31, 0x2e, 0x56, 0x61, 0x6c, 0x75, 0x65, 0x52, 0x65, 0x66, 0x52, 0x03, 0x72, 0x65, 0x66, 0x22,
-	0x4f, 0x0a, 0x17, 0x4b, 0x76, 0x53, 0x74, 0x6f, 0x72, 0x65, 0x47, 0x65, 0x74, 0x56, 0x61, 0x6c,
-	0x75, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x34, 0x0a, 0x05, 0x76, 0x61,
-	0x6c, 0x75, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1e, 0x2e, 0x6e, 0x69, 0x74, 0x72,
-	0x69, 0x63, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x6b, 0x76, 0x73, 0x74, 0x6f, 0x72, 0x65,
-	0x2e, 0x76, 0x31, 0x2e, 0x56, 0x61, 0x6c, 0x75, 0x65, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65,
-	0x22, 0x80, 0x01, 0x0a, 0x16, 0x4b, 0x76, 0x53, 0x74, 0x6f, 0x72, 0x65, 0x53, 0x65, 0x74, 0x56,
-	0x61, 0x6c, 0x75, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x33, 0x0a, 0x03, 0x72,
-	0x65, 0x66, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x21, 0x2e, 0x6e, 0x69, 0x74, 0x72, 0x69,
-	0x63, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x6b, 0x76, 0x73, 0x74, 0x6f, 0x72, 0x65, 0x2e,
-	0x76, 0x31, 0x2e, 0x56, 0x61, 0x6c, 0x75, 0x65, 0x52, 0x65, 0x66, 0x52, 0x03, 0x72, 0x65, 0x66,
-	0x12, 0x31, 0x0a, 0x07, 0x63, 0x6f, 0x6e, 0x74, 0x65, 0x6e, 0x74, 0x18, 0x03, 0x20, 0x01, 0x28,
-	0x0b, 0x32, 0x17, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f,
-	0x62, 0x75, 0x66, 0x2e, 0x53, 0x74, 0x72, 0x75, 0x63, 0x74, 0x52, 0x07, 0x63, 0x6f, 0x6e, 0x74,
-	0x65, 0x6e, 0x74, 0x22, 0x19, 0x0a, 0x17, 0x4b, 0x76, 0x53, 0x74, 0x6f, 0x72, 0x65, 0x53, 0x65,
-	0x74, 0x56, 0x61, 0x6c, 0x75, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x4e,
-	0x0a, 0x17, 0x4b, 0x76, 0x53, 0x74, 0x6f, 0x72, 0x65, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x4b,
-	0x65, 0x79, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x33, 0x0a, 0x03, 0x72, 0x65, 0x66,
-	0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x21, 0x2e, 0x6e, 0x69, 0x74, 0x72, 0x69, 0x63, 0x2e,
-	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x6b, 0x76, 0x73, 0x74, 0x6f, 0x72, 0x65, 0x2e, 0x76, 0x31,
-	0x2e, 0x56, 0x61, 0x6c, 0x75, 0x65, 0x52, 0x65, 0x66, 0x52, 0x03, 0x72, 0x65, 0x66, 0x22, 0x1a,
-	0x0a, 0x18, 0x4b, 0x76, 0x53, 0x74, 0x6f, 0x72, 0x65, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x4b,
-	0x65, 0x79, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x66, 0x0a, 0x16, 0x4b, 0x76,
-	0x53, 0x74, 0x6f, 0x72, 0x65, 0x53, 0x63, 0x61, 0x6e, 0x4b, 0x65, 0x79, 0x73, 0x52, 0x65, 0x71,
-	0x75, 0x65, 0x73, 0x74, 0x12, 0x34, 0x0a, 0x05, 0x73, 0x74, 0x6f, 0x72, 0x65, 0x18, 0x01, 0x20,
-	0x01, 0x28, 0x0b, 0x32, 0x1e, 0x2e, 0x6e, 0x69, 0x74, 0x72, 0x69, 0x63, 0x2e, 0x70, 0x72, 0x6f,
-	0x74, 0x6f, 0x2e, 0x6b, 0x76, 0x73, 0x74, 0x6f, 0x72, 0x65, 0x2e, 0x76, 0x31, 0x2e, 0x53, 0x74,
-	0x6f, 0x72, 0x65, 0x52, 0x05, 0x73, 0x74, 0x6f, 0x72, 0x65, 0x12, 0x16, 0x0a, 0x06, 0x70, 0x72,
-	0x65, 0x66, 0x69, 0x78, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x70, 0x72, 0x65, 0x66,
-	0x69, 0x78, 0x22, 0x2b, 0x0a, 0x17, 0x4b, 0x76, 0x53, 0x74, 0x6f, 0x72, 0x65, 0x53, 0x63, 0x61,
-	0x6e, 0x4b, 0x65, 0x79, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x10, 0x0a,
-	0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x32,
-	0xca, 0x03, 0x0a, 0x07, 0x4b, 0x76, 0x53, 0x74, 0x6f, 0x72, 0x65, 0x12, 0x6d, 0x0a, 0x08, 0x47,
-	0x65, 0x74, 0x56, 0x61, 0x6c, 0x75, 0x65, 0x12, 0x2f, 0x2e, 0x6e, 0x69, 0x74, 0x72, 0x69, 0x63,
+	0x4b, 0x65, 0x79, 0x56, 0x61, 0x6c, 0x75, 0x65, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x52, 0x65, 0x73,
+	0x70, 0x6f, 0x6e, 0x73, 0x65, 0x48, 0x00, 0x52, 0x15, 0x6b, 0x65, 0x79, 0x56, 0x61, 0x6c, 0x75,
+	0x65, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x42, 0x09,
+	0x0a, 0x07, 0x63, 0x6f, 0x6e, 0x74, 0x65, 0x6e, 0x74, 0x22, 0xfb, 0x03, 0x0a, 0x0d, 0x53, 0x65,
+	0x72, 0x76, 0x65, 0x72, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x12, 0x0e, 0x0a, 0x02, 0x69,
+	0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x02, 0x69, 0x64, 0x12, 0x64, 0x0a, 0x15, 0x72,
+	0x65, 0x67, 0x69, 0x73, 0x74, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x5f, 0x72, 0x65, 0x73, 0x70,
+	0x6f, 0x6e, 0x73, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x2d, 0x2e, 0x6e, 0x69, 0x74,
+	0x72, 0x69, 0x63, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x6b, 0x76, 0x73, 0x74, 0x6f, 0x72,
+	0x65, 0x2e, 0x76, 0x31, 0x2e, 0x52, 0x65, 0x67, 0x69, 0x73, 0x74, 0x72, 0x61, 0x74, 0x69, 0x6f,
+	0x6e, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x48, 0x00, 0x52, 0x14, 0x72, 0x65, 0x67,
+	0x69, 0x73, 0x74, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73,
+	0x65, 0x12, 0x66, 0x0a, 0x17, 0x6b, 0x65, 0x79, 0x5f, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x5f, 0x65,
+	0x76, 0x65, 0x6e, 0x74, 0x5f, 0x72, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x18, 0x03, 0x20, 0x01,
+	0x28, 0x0b, 0x32, 0x2d, 0x2e, 0x6e, 0x69, 0x74, 0x72, 0x69, 0x63, 0x2e, 0x70, 0x72, 0x6f, 0x74,
+	0x6f, 0x2e, 0x6b, 0x76, 0x73, 0x74, 0x6f, 0x72, 0x65, 0x2e, 0x76, 0x31, 0x2e, 0x4b, 0x65, 0x79,
+	0x56, 0x61, 0x6c, 0x75, 0x65, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
+	0x74, 0x48, 0x00, 0x52, 0x14, 0x6b, 0x65, 0x79, 0x56, 0x61, 0x6c, 0x75, 0x65, 0x45, 0x76, 0x65,
+	0x6e, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x61, 0x0a, 0x14, 0x63, 0x61, 0x6e,
+	0x63, 0x65, 0x6c, 0x6c, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x5f, 0x72, 0x65, 0x71, 0x75, 0x65, 0x73,
+	0x74, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x2c, 0x2e, 0x6e, 0x69, 0x74, 0x72, 0x69, 0x63,
 	0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x6b, 0x76, 0x73, 0x74, 0x6f, 0x72, 0x65, 0x2e, 0x76,
-	0x31, 0x2e, 0x4b, 0x76, 0x53, 0x74, 0x6f, 0x72, 0x65, 0x47, 0x65, 0x74, 0x56, 0x61, 0x6c, 0x75,
-	0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x30, 0x2e, 0x6e, 0x69, 0x74, 0x72, 0x69,
-	0x63, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x6b, 0x76, 0x73, 0x74, 0x6f, 0x72, 0x65, 0x2e,
-	0x76, 0x31, 0x2e, 0x4b, 0x76, 0x53, 0x74, 0x6f, 0x72, 0x65, 0x47, 0x65, 0x74, 0x56, 0x61, 0x6c,
-	0x75, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x6d, 0x0a, 0x08, 0x53, 0x65,
-	0x74, 0x56, 0x61, 0x6c, 0x75, 0x65, 0x12, 0x2f, 0x2e, 0x6e, 0x69, 0x74, 0x72, 0x69, 0x63, 0x2e,
-	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x6b, 0x76, 0x73, 0x74, 0x6f, 0x72, 0x65, 0x2e, 0x76, 0x31,
-	0x2e, 0x4b, 0x76, 0x53, 0x74, 0x6f, 0x72, 0x65, 0x53, 0x65, 0x74, 0x56, 0x61, 0x6c, 0x75, 0x65,
-	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x30, 0x2e, 0x6e, 0x69, 0x74, 0x72, 0x69, 0x63,
-	0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x6b, 0x76, 0x73, 0x74, 0x6f, 0x72, 0x65, 0x2e, 0x76,
-	0x31, 0x2e, 0x4b, 0x76, 0x53, 0x74, 0x6f, 0x72, 0x65, 0x53, 0x65, 0x74, 0x56, 0x61, 0x6c, 0x75,
-	0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x70, 0x0a, 0x09, 0x44, 0x65, 0x6c,
-	0x65, 0x74, 0x65, 0x4b, 0x65, 0x79, 0x12, 0x30, 0x2e, 0x6e, 0x69, 0x74, 0x72, 0x69, 0x63, 0x2e,
-	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x6b, 0x76, 0x73, 0x74, 0x6f, 0x72, 0x65, 0x2e, 0x76, 0x31,
-	0x2e, 0x4b, 0x76, 0x53, 0x74, 0x6f, 0x72, 0x65, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x4b, 0x65,
-	0x79, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x31, 0x2e, 0x6e, 0x69, 0x74, 0x72, 0x69,
-	0x63, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x6b, 0x76, 0x73, 0x74, 0x6f, 0x72, 0x65, 0x2e,
-	0x76, 0x31, 0x2e, 0x4b, 0x76, 0x53, 0x74, 0x6f, 0x72, 0x65, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65,
-	0x4b, 0x65, 0x79, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x6f, 0x0a, 0x08, 0x53,
-	0x63, 0x61, 0x6e, 0x4b, 0x65, 0x79, 0x73, 0x12, 0x2f, 0x2e, 0x6e, 0x69, 0x74, 0x72, 0x69, 0x63,
+	0x31, 0x2e, 0x43, 0x61, 0x6e, 0x63, 0x65, 0x6c, 0x6c, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x65,
+	0x71, 0x75, 0x65, 0x73, 0x74, 0x48, 0x00, 0x52, 0x13, 0x63, 0x61, 0x6e, 0x63, 0x65, 0x6c, 0x6c,
+	0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x5d, 0x0a, 0x0d,
+	0x74, 0x72, 0x61, 0x63, 0x65, 0x5f, 0x63, 0x6f, 0x6e, 0x74, 0x65, 0x78, 0x74, 0x18, 0x0a, 0x20,
+	0x03, 0x28, 0x0b, 0x32, 0x38, 0x2e, 0x6e, 0x69, 0x74, 0x72, 0x69, 0x63, 0x2e, 0x70, 0x72, 0x6f,
+	0x74, 0x6f, 0x2e, 0x6b, 0x76, 0x73, 0x74, 0x6f, 0x72, 0x65, 0x2e, 0x76, 0x31, 0x2e, 0x53, 0x65,
+	0x72, 0x76, 0x65, 0x72, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x2e, 0x54, 0x72, 0x61, 0x63,
+	0x65, 0x43, 0x6f, 0x6e, 0x74, 0x65, 0x78, 0x74, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x52, 0x0c, 0x74,
+	0x72, 0x61, 0x63, 0x65, 0x43, 0x6f, 0x6e, 0x74, 0x65, 0x78, 0x74, 0x1a, 0x3f, 0x0a, 0x11, 0x54,
+	0x72, 0x61, 0x63, 0x65, 0x43, 0x6f, 0x6e, 0x74, 0x65, 0x78, 0x74, 0x45, 0x6e, 0x74, 0x72, 0x79,
+	0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6b,
+	0x65, 0x79, 0x12, 0x14, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38, 0x01, 0x42, 0x09, 0x0a, 0x07,
+	0x63, 0x6f, 0x6e, 0x74, 0x65, 0x6e, 0x74, 0x22, 0x2d, 0x0a, 0x13, 0x43, 0x61, 0x6e, 0x63, 0x65,
+	0x6c, 0x6c, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x16,
+	0x0a, 0x06, 0x72, 0x65, 0x61, 0x73, 0x6f, 0x6e, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06,
+	0x72, 0x65, 0x61, 0x73, 0x6f, 0x6e, 0x22, 0x90, 0x01, 0x0a, 0x14, 0x4b, 0x65, 0x79, 0x56, 0x61,
+	0x6c, 0x75, 0x65, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12,
+	0x1d, 0x0a, 0x0a, 0x73, 0x74, 0x6f, 0x72, 0x65, 0x5f, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x09, 0x73, 0x74, 0x6f, 0x72, 0x65, 0x4e, 0x61, 0x6d, 0x65, 0x12, 0x50,
+	0x0a, 0x0f, 0x6b, 0x65, 0x79, 0x5f, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x5f, 0x65, 0x76, 0x65, 0x6e,
+	0x74, 0x18, 0x0a, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x26, 0x2e, 0x6e, 0x69, 0x74, 0x72, 0x69, 0x63,
 	0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x6b, 0x76, 0x73, 0x74, 0x6f, 0x72, 0x65, 0x2e, 0x76,
-	0x31, 0x2e, 0x4b, 0x76, 0x53, 0x74, 0x6f, 0x72, 0x65, 0x53, 0x63, 0x61, 0x6e, 0x4b, 0x65, 0x79,
-	0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x30, 0x2e, 0x6e, 0x69, 0x74, 0x72, 0x69,
+	0x31, 0x2e, 0x4b, 0x65, 0x79, 0x56, 0x61, 0x6c, 0x75, 0x65, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x48,
+	0x00, 0x52, 0x0d, 0x6b, 0x65, 0x79, 0x56, 0x61, 0x6c, 0x75, 0x65, 0x45, 0x76, 0x65, 0x6e, 0x74,
+	0x42, 0x07, 0x0a, 0x05, 0x65, 0x76, 0x65, 0x6e, 0x74, 0x22, 0x61, 0x0a, 0x0d, 0x4b, 0x65, 0x79,
+	0x56, 0x61, 0x6c, 0x75, 0x65, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65,
+	0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x3e, 0x0a, 0x04,
+	0x74, 0x79, 0x70, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x2a, 0x2e, 0x6e, 0x69, 0x74,
+	0x72, 0x69, 0x63, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x6b, 0x76, 0x73, 0x74, 0x6f, 0x72,
+	0x65, 0x2e, 0x76, 0x31, 0x2e, 0x4b, 0x65, 0x79, 0x56, 0x61, 0x6c, 0x75, 0x65, 0x45, 0x76, 0x65,
+	0x6e, 0x74, 0x54, 0x79, 0x70, 0x65, 0x52, 0x04, 0x74, 0x79, 0x70, 0x65, 0x22, 0x31, 0x0a, 0x15,
+	0x4b, 0x65, 0x79, 0x56, 0x61, 0x6c, 0x75, 0x65, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x52, 0x65, 0x73,
+	0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x18, 0x0a, 0x07, 0x73, 0x75, 0x63, 0x63, 0x65, 0x73, 0x73,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x08, 0x52, 0x07, 0x73, 0x75, 0x63, 0x63, 0x65, 0x73, 0x73, 0x22,
+	0xab, 0x01, 0x0a, 0x13, 0x52, 0x65, 0x67, 0x69, 0x73, 0x74, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e,
+	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x1d, 0x0a, 0x0a, 0x73, 0x74, 0x6f, 0x72, 0x65,
+	0x5f, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x73, 0x74, 0x6f,
+	0x72, 0x65, 0x4e, 0x61, 0x6d, 0x65, 0x12, 0x49, 0x0a, 0x0a, 0x65, 0x76, 0x65, 0x6e, 0x74, 0x5f,
+	0x74, 0x79, 0x70, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x2a, 0x2e, 0x6e, 0x69, 0x74,
+	0x72, 0x69, 0x63, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x6b, 0x76, 0x73, 0x74, 0x6f, 0x72,
+	0x65, 0x2e, 0x76, 0x31, 0x2e, 0x4b, 0x65, 0x79, 0x56, 0x61, 0x6c, 0x75, 0x65, 0x45, 0x76, 0x65,
+	0x6e, 0x74, 0x54, 0x79, 0x70, 0x65, 0x52, 0x09, 0x65, 0x76, 0x65, 0x6e, 0x74, 0x54, 0x79, 0x70,
+	0x65, 0x12, 0x2a, 0x0a, 0x11, 0x6b, 0x65, 0x79, 0x5f, 0x70, 0x72, 0x65, 0x66, 0x69, 0x78, 0x5f,
+	0x66, 0x69, 0x6c, 0x74, 0x65, 0x72, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0f, 0x6b, 0x65,
+	0x79, 0x50, 0x72, 0x65, 0x66, 0x69, 0x78, 0x46, 0x69, 0x6c, 0x74, 0x65, 0x72, 0x22, 0x26, 0x0a,
+	0x14, 0x52, 0x65, 0x67, 0x69, 0x73, 0x74, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x65, 0x73,
+	0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x02, 0x69, 0x64, 0x22, 0x1b, 0x0a, 0x05, 0x53, 0x74, 0x6f, 0x72, 0x65, 0x12, 0x12,
+	0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61,
+	0x6d, 0x65, 0x22, 0x32, 0x0a, 0x08, 0x56, 0x61, 0x6c, 0x75, 0x65, 0x52, 0x65, 0x66, 0x12, 0x14,
+	0x0a, 0x05, 0x73, 0x74, 0x6f, 0x72, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x73,
+	0x74, 0x6f, 0x72, 0x65, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x02, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x22, 0x89, 0x01, 0x0a, 0x05, 0x56, 0x61, 0x6c, 0x75, 0x65,
+	0x12, 0x33, 0x0a, 0x03, 0x72, 0x65, 0x66, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x21, 0x2e,
+	0x6e, 0x69, 0x74, 0x72, 0x69, 0x63, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x6b, 0x76, 0x73,
+	0x74, 0x6f, 0x72, 0x65, 0x2e, 0x76, 0x31, 0x2e, 0x56, 0x61, 0x6c, 0x75, 0x65, 0x52, 0x65, 0x66,
+	0x52, 0x03, 0x72, 0x65, 0x66, 0x12, 0x31, 0x0a, 0x07, 0x63, 0x6f, 0x6e, 0x74, 0x65, 0x6e, 0x74,
+	0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x17, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e,
+	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x53, 0x74, 0x72, 0x75, 0x63, 0x74, 0x52,
+	0x07, 0x63, 0x6f, 0x6e, 0x74, 0x65, 0x6e, 0x74, 0x12, 0x18, 0x0a, 0x07, 0x76, 0x65, 0x72, 0x73,
+	0x69, 0x6f, 0x6e, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x76, 0x65, 0x72, 0x73, 0x69,
+	0x6f, 0x6e, 0x22, 0x58, 0x0a, 0x13, 0x4b, 0x76, 0x53, 0x74, 0x6f, 0x72, 0x65, 0x50, 0x72, 0x65,
+	0x63, 0x6f, 0x6e, 0x64, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x1a, 0x0a, 0x07, 0x76, 0x65, 0x72,
+	0x73, 0x69, 0x6f, 0x6e, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x48, 0x00, 0x52, 0x07, 0x76, 0x65,
+	0x72, 0x73, 0x69, 0x6f, 0x6e, 0x12, 0x18, 0x0a, 0x06, 0x65, 0x78, 0x69, 0x73, 0x74, 0x73, 0x18,
+	0x02, 0x20, 0x01, 0x28, 0x08, 0x48, 0x00, 0x52, 0x06, 0x65, 0x78, 0x69, 0x73, 0x74, 0x73, 0x42,
+	0x0b, 0x0a, 0x09, 0x63, 0x6f, 0x6e, 0x64, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x22, 0x4d, 0x0a, 0x16,
+	0x4b, 0x76, 0x53, 0x74, 0x6f, 0x72, 0x65, 0x47, 0x65, 0x74, 0x56, 0x61, 0x6c, 0x75, 0x65, 0x52,
+	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x33, 0x0a, 0x03, 0x72, 0x65, 0x66, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x0b, 0x32, 0x21, 0x2e, 0x6e, 0x69, 0x74, 0x72, 0x69, 0x63, 0x2e, 0x70, 0x72, 0x6f,
+	0x74, 0x6f, 0x2e, 0x6b, 0x76, 0x73, 0x74, 0x6f, 0x72, 0x65, 0x2e, 0x76, 0x31, 0x2e, 0x56, 0x61,
+	0x6c, 0x75, 0x65, 0x52, 0x65, 0x66, 0x52, 0x03, 0x72, 0x65, 0x66, 0x22, 0x4f, 0x0a, 0x17, 0x4b,
+	0x76, 0x53, 0x74, 0x6f, 0x72, 0x65, 0x47, 0x65, 0x74, 0x56, 0x61, 0x6c, 0x75, 0x65, 0x52, 0x65,
+	0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x34, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1e, 0x2e, 0x6e, 0x69, 0x74, 0x72, 0x69, 0x63, 0x2e, 0x70,
+	0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x6b, 0x76, 0x73, 0x74, 0x6f, 0x72, 0x65, 0x2e, 0x76, 0x31, 0x2e,
+	0x56, 0x61, 0x6c, 0x75, 0x65, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x22, 0xff, 0x01, 0x0a,
+	0x16, 0x4b, 0x76, 0x53, 0x74, 0x6f, 0x72, 0x65, 0x53, 0x65, 0x74, 0x56, 0x61, 0x6c, 0x75, 0x65,
+	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x33, 0x0a, 0x03, 0x72, 0x65, 0x66, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x0b, 0x32, 0x21, 0x2e, 0x6e, 0x69, 0x74, 0x72, 0x69, 0x63, 0x2e, 0x70, 0x72,
+	0x6f, 0x74, 0x6f, 0x2e, 0x6b, 0x76, 0x73, 0x74, 0x6f, 0x72, 0x65, 0x2e, 0x76, 0x31, 0x2e, 0x56,
+	0x61, 0x6c, 0x75, 0x65, 0x52, 0x65, 0x66, 0x52, 0x03, 0x72, 0x65, 0x66, 0x12, 0x31, 0x0a, 0x07,
+	0x63, 0x6f, 0x6e, 0x74, 0x65, 0x6e, 0x74, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x17, 0x2e,
+	0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e,
+	0x53, 0x74, 0x72, 0x75, 0x63, 0x74, 0x52, 0x07, 0x63, 0x6f, 0x6e, 0x74, 0x65, 0x6e, 0x74, 0x12,
+	0x2b, 0x0a, 0x03, 0x74, 0x74, 0x6c, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x19, 0x2e, 0x67,
+	0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x44,
+	0x75, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x03, 0x74, 0x74, 0x6c, 0x12, 0x50, 0x0a, 0x0c,
+	0x70, 0x72, 0x65, 0x63, 0x6f, 0x6e, 0x64, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x05, 0x20, 0x01,
+	0x28, 0x0b, 0x32, 0x2c, 0x2e, 0x6e, 0x69, 0x74, 0x72, 0x69, 0x63, 0x2e, 0x70, 0x72, 0x6f, 0x74,
+	0x6f, 0x2e, 0x6b, 0x76, 0x73, 0x74, 0x6f, 0x72, 0x65, 0x2e, 0x76, 0x31, 0x2e, 0x4b, 0x76, 0x53,
+	0x74, 0x6f, 0x72, 0x65, 0x50, 0x72, 0x65, 0x63, 0x6f, 0x6e, 0x64, 0x69, 0x74, 0x69, 0x6f, 0x6e,
+	0x52, 0x0c, 0x70, 0x72, 0x65, 0x63, 0x6f, 0x6e, 0x64, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x22, 0x33,
+	0x0a, 0x17, 0x4b, 0x76, 0x53, 0x74, 0x6f, 0x72, 0x65, 0x53, 0x65, 0x74, 0x56, 0x61, 0x6c, 0x75,
+	0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x18, 0x0a, 0x07, 0x76, 0x65, 0x72,
+	0x73, 0x69, 0x6f, 0x6e, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x76, 0x65, 0x72, 0x73,
+	0x69, 0x6f, 0x6e, 0x22, 0xa0, 0x01, 0x0a, 0x17, 0x4b, 0x76, 0x53, 0x74, 0x6f, 0x72, 0x65, 0x44,
+	0x65, 0x6c, 0x65, 0x74, 0x65, 0x4b, 0x65, 0x79, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12,
+	0x33, 0x0a, 0x03, 0x72, 0x65, 0x66, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x21, 0x2e, 0x6e,
+	0x69, 0x74, 0x72, 0x69, 0x63, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x6b, 0x76, 0x73, 0x74,
+	0x6f, 0x72, 0x65, 0x2e, 0x76, 0x31, 0x2e, 0x56, 0x61, 0x6c, 0x75, 0x65, 0x52, 0x65, 0x66, 0x52,
+	0x03, 0x72, 0x65, 0x66, 0x12, 0x50, 0x0a, 0x0c, 0x70, 0x72, 0x65, 0x63, 0x6f, 0x6e, 0x64, 0x69,
+	0x74, 0x69, 0x6f, 0x6e, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x2c, 0x2e, 0x6e, 0x69, 0x74,
+	0x72, 0x69, 0x63, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x6b, 0x76, 0x73, 0x74, 0x6f, 0x72,
+	0x65, 0x2e, 0x76, 0x31, 0x2e, 0x4b, 0x76, 0x53, 0x74, 0x6f, 0x72, 0x65, 0x50, 0x72, 0x65, 0x63,
+	0x6f, 0x6e, 0x64, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x0c, 0x70, 0x72, 0x65, 0x63, 0x6f, 0x6e,
+	0x64, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x22, 0x1a, 0x0a, 0x18, 0x4b, 0x76, 0x53, 0x74, 0x6f, 0x72,
+	0x65, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x4b, 0x65, 0x79, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e,
+	0x73, 0x65, 0x22, 0xec, 0x01, 0x0a, 0x16, 0x4b, 0x76, 0x53, 0x74, 0x6f, 0x72, 0x65, 0x53, 0x63,
+	0x61, 0x6e, 0x4b, 0x65, 0x79, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x34, 0x0a,
+	0x05, 0x73, 0x74, 0x6f, 0x72, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1e, 0x2e, 0x6e,
+	0x69, 0x74, 0x72, 0x69, 0x63, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x6b, 0x76, 0x73, 0x74,
+	0x6f, 0x72, 0x65, 0x2e, 0x76, 0x31, 0x2e, 0x53, 0x74, 0x6f, 0x72, 0x65, 0x52, 0x05, 0x73, 0x74,
+	0x6f, 0x72, 0x65, 0x12, 0x16, 0x0a, 0x06, 0x70, 0x72, 0x65, 0x66, 0x69, 0x78, 0x18, 0x02, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x06, 0x70, 0x72, 0x65, 0x66, 0x69, 0x78, 0x12, 0x14, 0x0a, 0x05, 0x6c,
+	0x69, 0x6d, 0x69, 0x74, 0x18, 0x03, 0x20, 0x01, 0x28, 0x05, 0x52, 0x05, 0x6c, 0x69, 0x6d, 0x69,
+	0x74, 0x12, 0x2d, 0x0a, 0x12, 0x63, 0x6f, 0x6e, 0x74, 0x69, 0x6e, 0x75, 0x61, 0x74, 0x69, 0x6f,
+	0x6e, 0x5f, 0x74, 0x6f, 0x6b, 0x65, 0x6e, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x11, 0x63,
+	0x6f, 0x6e, 0x74, 0x69, 0x6e, 0x75, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x54, 0x6f, 0x6b, 0x65, 0x6e,
+	0x12, 0x25, 0x0a, 0x0e, 0x69, 0x6e, 0x63, 0x6c, 0x75, 0x64, 0x65, 0x5f, 0x76, 0x61, 0x6c, 0x75,
+	0x65, 0x73, 0x18, 0x05, 0x20, 0x01, 0x28, 0x08, 0x52, 0x0d, 0x69, 0x6e, 0x63, 0x6c, 0x75, 0x64,
+	0x65, 0x56, 0x61, 0x6c, 0x75, 0x65, 0x73, 0x12, 0x18, 0x0a, 0x07, 0x72, 0x65, 0x76, 0x65, 0x72,
+	0x73, 0x65, 0x18, 0x06, 0x20, 0x01, 0x28, 0x08, 0x52, 0x07, 0x72, 0x65, 0x76, 0x65, 0x72, 0x73,
+	0x65, 0x22, 0x90, 0x01, 0x0a, 0x17, 0x4b, 0x76, 0x53, 0x74, 0x6f, 0x72, 0x65, 0x53, 0x63, 0x61,
+	0x6e, 0x4b, 0x65, 0x79, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x10, 0x0a,
+	0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12,
+	0x34, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1e,
+	0x2e, 0x6e, 0x69, 0x74, 0x72, 0x69, 0x63, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x6b, 0x76,
+	0x73, 0x74, 0x6f, 0x72, 0x65, 0x2e, 0x76, 0x31, 0x2e, 0x56, 0x61, 0x6c, 0x75, 0x65, 0x52, 0x05,
+	0x76, 0x61, 0x6c, 0x75, 0x65, 0x12, 0x2d, 0x0a, 0x12, 0x63, 0x6f, 0x6e, 0x74, 0x69, 0x6e, 0x75,
+	0x61, 0x74, 0x69, 0x6f, 0x6e, 0x5f, 0x74, 0x6f, 0x6b, 0x65, 0x6e, 0x18, 0x03, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x11, 0x63, 0x6f, 0x6e, 0x74, 0x69, 0x6e, 0x75, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x54,
+	0x6f, 0x6b, 0x65, 0x6e, 0x22, 0x75, 0x0a, 0x10, 0x4b, 0x76, 0x53, 0x74, 0x6f, 0x72, 0x65, 0x49,
+	0x74, 0x65, 0x6d, 0x45, 0x72, 0x72, 0x6f, 0x72, 0x12, 0x33, 0x0a, 0x03, 0x72, 0x65, 0x66, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x21, 0x2e, 0x6e, 0x69, 0x74, 0x72, 0x69, 0x63, 0x2e, 0x70,
+	0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x6b, 0x76, 0x73, 0x74, 0x6f, 0x72, 0x65, 0x2e, 0x76, 0x31, 0x2e,
+	0x56, 0x61, 0x6c, 0x75, 0x65, 0x52, 0x65, 0x66, 0x52, 0x03, 0x72, 0x65, 0x66, 0x12, 0x12, 0x0a,
+	0x04, 0x63, 0x6f, 0x64, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x05, 0x52, 0x04, 0x63, 0x6f, 0x64,
+	0x65, 0x12, 0x18, 0x0a, 0x07, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x18, 0x03, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x07, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x22, 0x4f, 0x0a, 0x16, 0x4b,
+	0x76, 0x53, 0x74, 0x6f, 0x72, 0x65, 0x42, 0x61, 0x74, 0x63, 0x68, 0x47, 0x65, 0x74, 0x52, 0x65,
+	0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x35, 0x0a, 0x04, 0x72, 0x65, 0x66, 0x73, 0x18, 0x01, 0x20,
+	0x03, 0x28, 0x0b, 0x32, 0x21, 0x2e, 0x6e, 0x69, 0x74, 0x72, 0x69, 0x63, 0x2e, 0x70, 0x72, 0x6f,
+	0x74, 0x6f, 0x2e, 0x6b, 0x76, 0x73, 0x74, 0x6f, 0x72, 0x65, 0x2e, 0x76, 0x31, 0x2e, 0x56, 0x61,
+	0x6c, 0x75, 0x65, 0x52, 0x65, 0x66, 0x52, 0x04, 0x72, 0x65, 0x66, 0x73, 0x22, 0x94, 0x01, 0x0a,
+	0x17, 0x4b, 0x76, 0x53, 0x74, 0x6f, 0x72, 0x65, 0x42, 0x61, 0x74, 0x63, 0x68, 0x47, 0x65, 0x74,
+	0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x36, 0x0a, 0x06, 0x76, 0x61, 0x6c, 0x75,
+	0x65, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x1e, 0x2e, 0x6e, 0x69, 0x74, 0x72, 0x69,
 	0x63, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x6b, 0x76, 0x73, 0x74, 0x6f, 0x72, 0x65, 0x2e,
-	0x76, 0x31, 0x2e, 0x4b, 0x76, 0x53, 0x74, 0x6f, 0x72, 0x65, 0x53, 0x63, 0x61, 0x6e, 0x4b, 0x65,
-	0x79, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x30, 0x01, 0x42, 0xa4, 0x01, 0x0a,
-	0x1a, 0x69, 0x6f, 0x2e, 0x6e, 0x69, 0x74, 0x72, 0x69, 0x63, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f,
-	0x2e, 0x6b, 0x76, 0x73, 0x74, 0x6f, 0x72, 0x65, 0x2e, 0x76, 0x31, 0x42, 0x0e, 0x4b, 0x76, 0x53,
-	0x74, 0x6f, 0x72, 0x65, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x50, 0x01, 0x5a, 0x40, 0x67,
-	0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x6e, 0x69, 0x74, 0x72, 0x69, 0x63,
-	0x74, 0x65, 0x63, 0x68, 0x2f, 0x6e, 0x69, 0x74, 0x72, 0x69, 0x63, 0x2f, 0x63, 0x6f, 0x72, 0x65,
-	0x2f, 0x70, 0x6b, 0x67, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2f, 0x6b, 0x76, 0x73, 0x74, 0x6f,
-	0x72, 0x65, 0x2f, 0x76, 0x31, 0x3b, 0x6b, 0x76, 0x73, 0x74, 0x6f, 0x72, 0x65, 0x70, 0x62, 0xaa,
-	0x02, 0x17, 0x4e, 0x69, 0x74, 0x72, 0x69, 0x63, 0x2e, 0x50, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x4b,
-	0x76, 0x53, 0x74, 0x6f, 0x72, 0x65, 0x2e, 0x76, 0x31, 0xca, 0x02, 0x17, 0x4e, 0x69, 0x74, 0x72,
-	0x69, 0x63, 0x5c, 0x50, 0x72, 0x6f, 0x74, 0x6f, 0x5c, 0x4b, 0x76, 0x53, 0x74, 0x6f, 0x72, 0x65,
-	0x5c, 0x56, 0x31, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+	0x76, 0x31, 0x2e, 0x56, 0x61, 0x6c, 0x75, 0x65, 0x52, 0x06, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x73,
+	0x12, 0x41, 0x0a, 0x06, 0x65, 0x72, 0x72, 0x6f, 0x72, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x0b,
+	0x32, 0x29, 0x2e, 0x6e, 0x69, 0x74, 0x72, 0x69, 0x63, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e,
+	0x6b, 0x76, 0x73, 0x74, 0x6f, 0x72, 0x65, 0x2e, 0x76, 0x31, 0x2e, 0x4b, 0x76, 0x53, 0x74, 0x6f,
+	0x72, 0x65, 0x49, 0x74, 0x65, 0x6d, 0x45, 0x72, 0x72, 0x6f, 0x72, 0x52, 0x06, 0x65, 0x72, 0x72,
+	0x6f, 0x72, 0x73, 0x22, 0x50, 0x0a, 0x16, 0x4b, 0x76, 0x53, 0x74, 0x6f, 0x72, 0x65, 0x42, 0x61,
+	0x74, 0x63, 0x68, 0x53, 0x65, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x36, 0x0a,
+	0x06, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x1e, 0x2e,
+	0x6e, 0x69, 0x74, 0x72, 0x69, 0x63, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x6b, 0x76, 0x73,
+	0x74, 0x6f, 0x72, 0x65, 0x2e, 0x76, 0x31, 0x2e, 0x56, 0x61, 0x6c, 0x75, 0x65, 0x52, 0x06, 0x76,
+	0x61, 0x6c, 0x75, 0x65, 0x73, 0x22, 0x5c, 0x0a, 0x17, 0x4b, 0x76, 0x53, 0x74, 0x6f, 0x72, 0x65,
+	0x42, 0x61, 0x74, 0x63, 0x68, 0x53, 0x65, 0x74, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65,
+	0x12, 0x41, 0x0a, 0x06, 0x65, 0x72, 0x72, 0x6f, 0x72, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b,
+	0x32, 0x29, 0x2e, 0x6e, 0x69, 0x74, 0x72, 0x69, 0x63, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e,
+	0x6b, 0x76, 0x73, 0x74, 0x6f, 0x72, 0x65, 0x2e, 0x76, 0x31, 0x2e, 0x4b, 0x76, 0x53, 0x74, 0x6f,
+	0x72, 0x65, 0x49, 0x74, 0x65, 0x6d, 0x45, 0x72, 0x72, 0x6f, 0x72, 0x52, 0x06, 0x65, 0x72, 0x72,
+	0x6f, 0x72, 0x73, 0x22, 0x52, 0x0a, 0x19, 0x4b, 0x76, 0x53, 0x74, 0x6f, 0x72, 0x65, 0x42, 0x61,
+	0x74, 0x63, 0x68, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
+	0x12, 0x35, 0x0a, 0x04, 0x72, 0x65, 0x66, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x21,
+	0x2e, 0x6e, 0x69, 0x74, 0x72, 0x69, 0x63, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x6b, 0x76,
+	0x73, 0x74, 0x6f, 0x72, 0x65, 0x2e, 0x76, 0x31, 0x2e, 0x56, 0x61, 0x6c, 0x75, 0x65, 0x52, 0x65,
+	0x66, 0x52, 0x04, 0x72, 0x65, 0x66, 0x73, 0x22, 0x5f, 0x0a, 0x1a, 0x4b, 0x76, 0x53, 0x74, 0x6f,
+	0x72, 0x65, 0x42, 0x61, 0x74, 0x63, 0x68, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x52, 0x65, 0x73,
+	0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x41, 0x0a, 0x06, 0x65, 0x72, 0x72, 0x6f, 0x72, 0x73, 0x18,
+	0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x29, 0x2e, 0x6e, 0x69, 0x74, 0x72, 0x69, 0x63, 0x2e, 0x70,
+	0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x6b, 0x76, 0x73, 0x74, 0x6f, 0x72, 0x65, 0x2e, 0x76, 0x31, 0x2e,
+	0x4b, 0x76, 0x53, 0x74, 0x6f, 0x72, 0x65, 0x49, 0x74, 0x65, 0x6d, 0x45, 0x72, 0x72, 0x6f, 0x72,
+	0x52, 0x06, 0x65, 0x72, 0x72, 0x6f, 0x72, 0x73, 0x22, 0x98, 0x01, 0x0a, 0x18, 0x4b, 0x76, 0x53,
+	0x74, 0x6f, 0x72, 0x65, 0x54, 0x72, 0x61, 0x6e, 0x73, 0x61, 0x63, 0x74, 0x4f, 0x70, 0x65, 0x72,
+	0x61, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x32, 0x0a, 0x03, 0x73, 0x65, 0x74, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x0b, 0x32, 0x1e, 0x2e, 0x6e, 0x69, 0x74, 0x72, 0x69, 0x63, 0x2e, 0x70, 0x72, 0x6f, 0x74,
+	0x6f, 0x2e, 0x6b, 0x76, 0x73, 0x74, 0x6f, 0x72, 0x65, 0x2e, 0x76, 0x31, 0x2e, 0x56, 0x61, 0x6c,
+	0x75, 0x65, 0x48, 0x00, 0x52, 0x03, 0x73, 0x65, 0x74, 0x12, 0x3b, 0x0a, 0x06, 0x64, 0x65, 0x6c,
+	0x65, 0x74, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x21, 0x2e, 0x6e, 0x69, 0x74, 0x72,
+	0x69, 0x63, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x6b, 0x76, 0x73, 0x74, 0x6f, 0x72, 0x65,
+	0x2e, 0x76, 0x31, 0x2e, 0x56, 0x61, 0x6c, 0x75, 0x65, 0x52, 0x65, 0x66, 0x48, 0x00, 0x52, 0x06,
+	0x64, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x42, 0x0b, 0x0a, 0x09, 0x6f, 0x70, 0x65, 0x72, 0x61, 0x74,
+	0x69, 0x6f, 0x6e, 0x22, 0x6b, 0x0a, 0x16, 0x4b, 0x76, 0x53, 0x74, 0x6f, 0x72, 0x65, 0x54, 0x72,
+	0x61, 0x6e, 0x73, 0x61, 0x63, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x51, 0x0a,
+	0x0a, 0x6f, 0x70, 0x65, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28,
+	0x0b, 0x32, 0x31, 0x2e, 0x6e, 0x69, 0x74, 0x72, 0x69, 0x63, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f,
+	0x2e, 0x6b, 0x76, 0x73, 0x74, 0x6f, 0x72, 0x65, 0x2e, 0x76, 0x31, 0x2e, 0x4b, 0x76, 0x53, 0x74,
+	0x6f, 0x72, 0x65, 0x54, 0x72, 0x61, 0x6e, 0x73, 0x61, 0x63, 0x74, 0x4f, 0x70, 0x65, 0x72, 0x61,
+	0x74, 0x69, 0x6f, 0x6e, 0x52, 0x0a, 0x6f, 0x70, 0x65, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x73,
+	0x22, 0x19, 0x0a, 0x17, 0x4b, 0x76, 0x53, 0x74, 0x6f, 0x72, 0x65, 0x54, 0x72, 0x61, 0x6e, 0x73,
+	0x61, 0x63, 0x74, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x2a, 0x28, 0x0a, 0x11, 0x4b,
+	0x65, 0x79, 0x56, 0x61, 0x6c, 0x75, 0x65, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x54, 0x79, 0x70, 0x65,
+	0x12, 0x07, 0x0a, 0x03, 0x53, 0x65, 0x74, 0x10, 0x00, 0x12, 0x0a, 0x0a, 0x06, 0x44, 0x65, 0x6c,
+	0x65, 0x74, 0x65, 0x10, 0x01, 0x32, 0x8f, 0x07, 0x0a, 0x07, 0x4b, 0x76, 0x53, 0x74, 0x6f, 0x72,
+	0x65, 0x12, 0x6d, 0x0a, 0x08, 0x47, 0x65, 0x74, 0x56, 0x61, 0x6c, 0x75, 0x65, 0x12, 0x2f, 0x2e,
+	0x6e, 0x69, 0x74, 0x72, 0x69, 0x63, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x6b, 0x76, 0x73,
+	0x74, 0x6f, 0x72, 0x65, 0x2e, 0x76, 0x31, 0x2e, 0x4b, 0x76, 0x53, 0x74, 0x6f, 0x72, 0x65, 0x47,
+	0x65, 0x74, 0x56, 0x61, 0x6c, 0x75, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x30,
+	0x2e, 0x6e, 0x69, 0x74, 0x72, 0x69, 0x63, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x6b, 0x76,
+	0x73, 0x74, 0x6f, 0x72, 0x65, 0x2e, 0x76, 0x31, 0x2e, 0x4b, 0x76, 0x53, 0x74, 0x6f, 0x72, 0x65,
+	0x47, 0x65, 0x74, 0x56, 0x61, 0x6c, 0x75, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65,
+	0x12, 0x6d, 0x0a, 0x08, 0x53, 0x65, 0x74, 0x56, 0x61, 0x6c, 0x75, 0x65, 0x12, 0x2f, 0x2e, 0x6e,
+	0x69, 0x74, 0x72, 0x69, 0x63, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x6b, 0x76, 0x73, 0x74,
+	0x6f, 0x72, 0x65, 0x2e, 0x76, 0x31, 0x2e, 0x4b, 0x76, 0x53, 0x74, 0x6f, 0x72, 0x65, 0x53, 0x65,
+	0x74, 0x56, 0x61, 0x6c, 0x75, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x30, 0x2e,
+	0x6e, 0x69, 0x74, 0x72, 0x69, 0x63, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x6b, 0x76, 0x73,
+	0x74, 0x6f, 0x72, 0x65, 0x2e, 0x76, 0x31, 0x2e, 0x4b, 0x76, 0x53, 0x74, 0x6f, 0x72, 0x65, 0x53,
+	0x65, 0x74, 0x56, 0x61, 0x6c, 0x75, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12,
+	0x70, 0x0a, 0x09, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x4b, 0x65, 0x79, 0x12, 0x30, 0x2e, 0x6e,
+	0x69, 0x74, 0x72, 0x69, 0x63, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x6b, 0x76, 0x73, 0x74,
+	0x6f, 0x72, 0x65, 0x2e, 0x76, 0x31, 0x2e, 0x4b, 0x76, 0x53, 0x74, 0x6f, 0x72, 0x65, 0x44, 0x65,
+	0x6c, 0x65, 0x74, 0x65, 0x4b, 0x65, 0x79, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x31,
+	0x2e, 0x6e, 0x69, 0x74, 0x72, 0x69, 0x63, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x6b, 0x76,
+	0x73, 0x74, 0x6f, 0x72, 0x65, 0x2e, 0x76, 0x31, 0x2e, 0x4b, 0x76, 0x53, 0x74, 0x6f, 0x72, 0x65,
+	0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x4b, 0x65, 0x79, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73,
+	0x65, 0x12, 0x6f, 0x0a, 0x08, 0x53, 0x63, 0x61, 0x6e, 0x4b, 0x65, 0x79, 0x73, 0x12, 0x2f, 0x2e,
+	0x6e, 0x69, 0x74, 0x72, 0x69, 0x63, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x6b, 0x76, 0x73,
+	0x74, 0x6f, 0x72, 0x65, 0x2e, 0x76, 0x31, 0x2e, 0x4b, 0x76, 0x53, 0x74, 0x6f, 0x72, 0x65, 0x53,
+	0x63, 0x61, 0x6e, 0x4b, 0x65, 0x79, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x30,
+	0x2e, 0x6e, 0x69, 0x74, 0x72, 0x69, 0x63, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x6b, 0x76,
+	0x73, 0x74, 0x6f, 0x72, 0x65, 0x2e, 0x76, 0x31, 0x2e, 0x4b, 0x76, 0x53, 0x74, 0x6f, 0x72, 0x65,
+	0x53, 0x63, 0x61, 0x6e, 0x4b, 0x65, 0x79, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65,
+	0x30, 0x01, 0x12, 0x6d, 0x0a, 0x08, 0x42, 0x61, 0x74, 0x63, 0x68, 0x47, 0x65, 0x74, 0x12, 0x2f,
+	0x2e, 0x6e, 0x69, 0x74, 0x72, 0x69, 0x63, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x6b, 0x76,
+	0x73, 0x74, 0x6f, 0x72, 0x65, 0x2e, 0x76, 0x31, 0x2e, 0x4b, 0x76, 0x53, 0x74, 0x6f, 0x72, 0x65,
+	0x42, 0x61, 0x74, 0x63, 0x68, 0x47, 0x65, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a,
+	0x30, 0x2e, 0x6e, 0x69, 0x74, 0x72, 0x69, 0x63, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x6b,
+	0x76, 0x73, 0x74, 0x6f, 0x72, 0x65, 0x2e, 0x76, 0x31, 0x2e, 0x4b, 0x76, 0x53, 0x74, 0x6f, 0x72,
+	0x65, 0x42, 0x61, 0x74, 0x63, 0x68, 0x47, 0x65, 0x74, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73,
+	0x65, 0x12, 0x6d, 0x0a, 0x08, 0x42, 0x61, 0x74, 0x63, 0x68, 0x53, 0x65, 0x74, 0x12, 0x2f, 0x2e,
+	0x6e, 0x69, 0x74, 0x72, 0x69, 0x63, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x6b, 0x76, 0x73,
+	0x74, 0x6f, 0x72, 0x65, 0x2e, 0x76, 0x31, 0x2e, 0x4b, 0x76, 0x53, 0x74, 0x6f, 0x72, 0x65, 0x42,
+	0x61, 0x74, 0x63, 0x68, 0x53, 0x65, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x30,
+	0x2e, 0x6e, 0x69, 0x74, 0x72, 0x69, 0x63, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x6b, 0x76,
+	0x73, 0x74, 0x6f, 0x72, 0x65, 0x2e, 0x76, 0x31, 0x2e, 0x4b, 0x76, 0x53, 0x74, 0x6f, 0x72, 0x65,
+	0x42, 0x61, 0x74, 0x63, 0x68, 0x53, 0x65, 0x74, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65,
+	0x12, 0x76, 0x0a, 0x0b, 0x42, 0x61, 0x74, 0x63, 0x68, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x12,
+	0x32, 0x2e, 0x6e, 0x69, 0x74, 0x72, 0x69, 0x63, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x6b,
+	0x76, 0x73, 0x74, 0x6f, 0x72, 0x65, 0x2e, 0x76, 0x31, 0x2e, 0x4b, 0x76, 0x53, 0x74, 0x6f, 0x72,
+	0x65, 0x42, 0x61, 0x74, 0x63, 0x68, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x52, 0x65, 0x71, 0x75,
+	0x65, 0x73, 0x74, 0x1a, 0x33, 0x2e, 0x6e, 0x69, 0x74, 0x72, 0x69, 0x63, 0x2e, 0x70, 0x72, 0x6f,
+	0x74, 0x6f, 0x2e, 0x6b, 0x76, 0x73, 0x74, 0x6f, 0x72, 0x65, 0x2e, 0x76, 0x31, 0x2e, 0x4b, 0x76,
+	0x53, 0x74, 0x6f, 0x72, 0x65, 0x42, 0x61, 0x74, 0x63, 0x68, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65,
+	0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x6d, 0x0a, 0x08, 0x54, 0x72, 0x61, 0x6e,
+	0x73, 0x61, 0x63, 0x74, 0x12, 0x2f, 0x2e, 0x6e, 0x69, 0x74, 0x72, 0x69, 0x63, 0x2e, 0x70, 0x72,
+	0x6f, 0x74, 0x6f, 0x2e, 0x6b, 0x76, 0x73, 0x74, 0x6f, 0x72, 0x65, 0x2e, 0x76, 0x31, 0x2e, 0x4b,
+	0x76, 0x53, 0x74, 0x6f, 0x72, 0x65, 0x54, 0x72, 0x61, 0x6e, 0x73, 0x61, 0x63, 0x74, 0x52, 0x65,
+	0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x30, 0x2e, 0x6e, 0x69, 0x74, 0x72, 0x69, 0x63, 0x2e, 0x70,
+	0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x6b, 0x76, 0x73, 0x74, 0x6f, 0x72, 0x65, 0x2e, 0x76, 0x31, 0x2e,
+	0x4b, 0x76, 0x53, 0x74, 0x6f, 0x72, 0x65, 0x54, 0x72, 0x61, 0x6e, 0x73, 0x61, 0x63, 0x74, 0x52,
+	0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x32, 0x70, 0x0a, 0x10, 0x4b, 0x65, 0x79, 0x56, 0x61,
+	0x6c, 0x75, 0x65, 0x4c, 0x69, 0x73, 0x74, 0x65, 0x6e, 0x65, 0x72, 0x12, 0x5c, 0x0a, 0x06, 0x4c,
+	0x69, 0x73, 0x74, 0x65, 0x6e, 0x12, 0x26, 0x2e, 0x6e, 0x69, 0x74, 0x72, 0x69, 0x63, 0x2e, 0x70,
+	0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x6b, 0x76, 0x73, 0x74, 0x6f, 0x72, 0x65, 0x2e, 0x76, 0x31, 0x2e,
+	0x43, 0x6c, 0x69, 0x65, 0x6e, 0x74, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x1a, 0x26, 0x2e,
+	0x6e, 0x69, 0x74, 0x72, 0x69, 0x63, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x6b, 0x76, 0x73,
+	0x74, 0x6f, 0x72, 0x65, 0x2e, 0x76, 0x31, 0x2e, 0x53, 0x65, 0x72, 0x76, 0x65, 0x72, 0x4d, 0x65,
+	0x73, 0x73, 0x61, 0x67, 0x65, 0x28, 0x01, 0x30, 0x01, 0x42, 0xa4, 0x01, 0x0a, 0x1a, 0x69, 0x6f,
+	0x2e, 0x6e, 0x69, 0x74, 0x72, 0x69, 0x63, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x6b, 0x76,
+	0x73, 0x74, 0x6f, 0x72, 0x65, 0x2e, 0x76, 0x31, 0x42, 0x0e, 0x4b, 0x76, 0x53, 0x74, 0x6f, 0x72,
+	0x65, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x50, 0x01, 0x5a, 0x40, 0x67, 0x69, 0x74, 0x68,
+	0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x6e, 0x69, 0x74, 0x72, 0x69, 0x63, 0x74, 0x65, 0x63,
+	0x68, 0x2f, 0x6e, 0x69, 0x74, 0x72, 0x69, 0x63, 0x2f, 0x63, 0x6f, 0x72, 0x65, 0x2f, 0x70, 0x6b,
+	0x67, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2f, 0x6b, 0x76, 0x73, 0x74, 0x6f, 0x72, 0x65, 0x2f,
+	0x76, 0x31, 0x3b, 0x6b, 0x76, 0x73, 0x74, 0x6f, 0x72, 0x65, 0x70, 0x62, 0xaa, 0x02, 0x17, 0x4e,
+	0x69, 0x74, 0x72, 0x69, 0x63, 0x2e, 0x50, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x4b, 0x76, 0x53, 0x74,
+	0x6f, 0x72, 0x65, 0x2e, 0x76, 0x31, 0xca, 0x02, 0x17, 0x4e, 0x69, 0x74, 0x72, 0x69, 0x63, 0x5c,
+	0x50, 0x72, 0x6f, 0x74, 0x6f, 0x5c, 0x4b, 0x76, 0x53, 0x74, 0x6f, 0x72, 0x65, 0x5c, 0x56, 0x31,
+	0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
 }
 
 var (
@@ -679,43 +2224,100 @@ func file_nitric_proto_kvstore_v1_kvstore_proto_rawDescGZIP() []byte {
 	return file_nitric_proto_kvstore_v1_kvstore_proto_rawDescData
 }
 
-var file_nitric_proto_kvstore_v1_kvstore_proto_msgTypes = make([]protoimpl.MessageInfo, 11)
+var file_nitric_proto_kvstore_v1_kvstore_proto_enumTypes = make([]protoimpl.EnumInfo, 1)
+var file_nitric_proto_kvstore_v1_kvstore_proto_msgTypes = make([]protoimpl.MessageInfo, 31)
 var file_nitric_proto_kvstore_v1_kvstore_proto_goTypes = []interface{}{
-	(*Store)(nil),                    // 0: nitric.proto.kvstore.v1.Store
-	(*ValueRef)(nil),                 // 1: nitric.proto.kvstore.v1.ValueRef
-	(*Value)(nil),                    // 2: nitric.proto.kvstore.v1.Value
-	(*KvStoreGetValueRequest)(nil),   // 3: nitric.proto.kvstore.v1.KvStoreGetValueRequest
-	(*KvStoreGetValueResponse)(nil),  // 4: nitric.proto.kvstore.v1.KvStoreGetValueResponse
-	(*KvStoreSetValueRequest)(nil),   // 5: nitric.proto.kvstore.v1.KvStoreSetValueRequest
-	(*KvStoreSetValueResponse)(nil),  // 6: nitric.proto.kvstore.v1.KvStoreSetValueResponse
-	(*KvStoreDeleteKeyRequest)(nil),  // 7: nitric.proto.kvstore.v1.KvStoreDeleteKeyRequest
-	(*KvStoreDeleteKeyResponse)(nil), // 8: nitric.proto.kvstore.v1.KvStoreDeleteKeyResponse
-	(*KvStoreScanKeysRequest)(nil),   // 9: nitric.proto.kvstore.v1.KvStoreScanKeysRequest
-	(*KvStoreScanKeysResponse)(nil),  // 10: nitric.proto.kvstore.v1.KvStoreScanKeysResponse
-	(*structpb.Struct)(nil),          // 11: google.protobuf.Struct
+	(KeyValueEventType)(0),             // 0: nitric.proto.kvstore.v1.KeyValueEventType
+	(*ClientMessage)(nil),              // 1: nitric.proto.kvstore.v1.ClientMessage
+	(*ServerMessage)(nil),              // 2: nitric.proto.kvstore.v1.ServerMessage
+	(*CancellationRequest)(nil),        // 3: nitric.proto.kvstore.v1.CancellationRequest
+	(*KeyValueEventRequest)(nil),       // 4: nitric.proto.kvstore.v1.KeyValueEventRequest
+	(*KeyValueEvent)(nil),              // 5: nitric.proto.kvstore.v1.KeyValueEvent
+	(*KeyValueEventResponse)(nil),      // 6: nitric.proto.kvstore.v1.KeyValueEventResponse
+	(*RegistrationRequest)(nil),        // 7: nitric.proto.kvstore.v1.RegistrationRequest
+	(*RegistrationResponse)(nil),       // 8: nitric.proto.kvstore.v1.RegistrationResponse
+	(*Store)(nil),                      // 9: nitric.proto.kvstore.v1.Store
+	(*ValueRef)(nil),                   // 10: nitric.proto.kvstore.v1.ValueRef
+	(*Value)(nil),                      // 11: nitric.proto.kvstore.v1.Value
+	(*KvStorePrecondition)(nil),        // 12: nitric.proto.kvstore.v1.KvStorePrecondition
+	(*KvStoreGetValueRequest)(nil),     // 13: nitric.proto.kvstore.v1.KvStoreGetValueRequest
+	(*KvStoreGetValueResponse)(nil),    // 14: nitric.proto.kvstore.v1.KvStoreGetValueResponse
+	(*KvStoreSetValueRequest)(nil),     // 15: nitric.proto.kvstore.v1.KvStoreSetValueRequest
+	(*KvStoreSetValueResponse)(nil),    // 16: nitric.proto.kvstore.v1.KvStoreSetValueResponse
+	(*KvStoreDeleteKeyRequest)(nil),    // 17: nitric.proto.kvstore.v1.KvStoreDeleteKeyRequest
+	(*KvStoreDeleteKeyResponse)(nil),   // 18: nitric.proto.kvstore.v1.KvStoreDeleteKeyResponse
+	(*KvStoreScanKeysRequest)(nil),     // 19: nitric.proto.kvstore.v1.KvStoreScanKeysRequest
+	(*KvStoreScanKeysResponse)(nil),    // 20: nitric.proto.kvstore.v1.KvStoreScanKeysResponse
+	(*KvStoreItemError)(nil),           // 21: nitric.proto.kvstore.v1.KvStoreItemError
+	(*KvStoreBatchGetRequest)(nil),     // 22: nitric.proto.kvstore.v1.KvStoreBatchGetRequest
+	(*KvStoreBatchGetResponse)(nil),    // 23: nitric.proto.kvstore.v1.KvStoreBatchGetResponse
+	(*KvStoreBatchSetRequest)(nil),     // 24: nitric.proto.kvstore.v1.KvStoreBatchSetRequest
+	(*KvStoreBatchSetResponse)(nil),    // 25: nitric.proto.kvstore.v1.KvStoreBatchSetResponse
+	(*KvStoreBatchDeleteRequest)(nil),  // 26: nitric.proto.kvstore.v1.KvStoreBatchDeleteRequest
+	(*KvStoreBatchDeleteResponse)(nil), // 27: nitric.proto.kvstore.v1.KvStoreBatchDeleteResponse
+	(*KvStoreTransactOperation)(nil),   // 28: nitric.proto.kvstore.v1.KvStoreTransactOperation
+	(*KvStoreTransactRequest)(nil),     // 29: nitric.proto.kvstore.v1.KvStoreTransactRequest
+	(*KvStoreTransactResponse)(nil),    // 30: nitric.proto.kvstore.v1.KvStoreTransactResponse
+	nil,                                // 31: nitric.proto.kvstore.v1.ServerMessage.TraceContextEntry
+	(*structpb.Struct)(nil),            // 32: google.protobuf.Struct
+	(*durationpb.Duration)(nil),        // 33: google.protobuf.Duration
 }
 var file_nitric_proto_kvstore_v1_kvstore_proto_depIdxs = []int32{
-	1,  // 0: nitric.proto.kvstore.v1.Value.ref:type_name -> nitric.proto.kvstore.v1.ValueRef
-	11, // 1: nitric.proto.kvstore.v1.Value.content:type_name -> google.protobuf.Struct
-	1,  // 2: nitric.proto.kvstore.v1.KvStoreGetValueRequest.ref:type_name -> nitric.proto.kvstore.v1.ValueRef
-	2,  // 3: nitric.proto.kvstore.v1.KvStoreGetValueResponse.value:type_name -> nitric.proto.kvstore.v1.Value
-	1,  // 4: nitric.proto.kvstore.v1.KvStoreSetValueRequest.ref:type_name -> nitric.proto.kvstore.v1.ValueRef
-	11, // 5: nitric.proto.kvstore.v1.KvStoreSetValueRequest.content:type_name -> google.protobuf.Struct
-	1,  // 6: nitric.proto.kvstore.v1.KvStoreDeleteKeyRequest.ref:type_name -> nitric.proto.kvstore.v1.ValueRef
-	0,  // 7: nitric.proto.kvstore.v1.KvStoreScanKeysRequest.store:type_name -> nitric.proto.kvstore.v1.Store
-	3,  // 8: nitric.proto.kvstore.v1.KvStore.GetValue:input_type -> nitric.proto.kvstore.v1.KvStoreGetValueRequest
-	5,  // 9: nitric.proto.kvstore.v1.KvStore.SetValue:input_type -> nitric.proto.kvstore.v1.KvStoreSetValueRequest
-	7,  // 10: nitric.proto.kvstore.v1.KvStore.DeleteKey:input_type -> nitric.proto.kvstore.v1.KvStoreDeleteKeyRequest
-	9,  // 11: nitric.proto.kvstore.v1.KvStore.ScanKeys:input_type -> nitric.proto.kvstore.v1.KvStoreScanKeysRequest
-	4,  // 12: nitric.proto.kvstore.v1.KvStore.GetValue:output_type -> nitric.proto.kvstore.v1.KvStoreGetValueResponse
-	6,  // 13: nitric.proto.kvstore.v1.KvStore.SetValue:output_type -> nitric.proto.kvstore.v1.KvStoreSetValueResponse
-	8,  // 14: nitric.proto.kvstore.v1.KvStore.DeleteKey:output_type -> nitric.proto.kvstore.v1.KvStoreDeleteKeyResponse
-	10, // 15: nitric.proto.kvstore.v1.KvStore.ScanKeys:output_type -> nitric.proto.kvstore.v1.KvStoreScanKeysResponse
-	12, // [12:16] is the sub-list for method output_type
-	8,  // [8:12] is the sub-list for method input_type
-	8,  // [8:8] is the sub-list for extension type_name
-	8,  // [8:8] is the sub-list for extension extendee
-	0,  // [0:8] is the sub-list for field type_name
+	7,  // 0: nitric.proto.kvstore.v1.ClientMessage.registration_request:type_name -> nitric.proto.kvstore.v1.RegistrationRequest
+	6,  // 1: nitric.proto.kvstore.v1.ClientMessage.key_value_event_response:type_name -> nitric.proto.kvstore.v1.KeyValueEventResponse
+	8,  // 2: nitric.proto.kvstore.v1.ServerMessage.registration_response:type_name -> nitric.proto.kvstore.v1.RegistrationResponse
+	4,  // 3: nitric.proto.kvstore.v1.ServerMessage.key_value_event_request:type_name -> nitric.proto.kvstore.v1.KeyValueEventRequest
+	3,  // 4: nitric.proto.kvstore.v1.ServerMessage.cancellation_request:type_name -> nitric.proto.kvstore.v1.CancellationRequest
+	31, // 5: nitric.proto.kvstore.v1.ServerMessage.trace_context:type_name -> nitric.proto.kvstore.v1.ServerMessage.TraceContextEntry
+	5,  // 6: nitric.proto.kvstore.v1.KeyValueEventRequest.key_value_event:type_name -> nitric.proto.kvstore.v1.KeyValueEvent
+	0,  // 7: nitric.proto.kvstore.v1.KeyValueEvent.type:type_name -> nitric.proto.kvstore.v1.KeyValueEventType
+	0,  // 8: nitric.proto.kvstore.v1.RegistrationRequest.event_type:type_name -> nitric.proto.kvstore.v1.KeyValueEventType
+	10, // 9: nitric.proto.kvstore.v1.Value.ref:type_name -> nitric.proto.kvstore.v1.ValueRef
+	32, // 10: nitric.proto.kvstore.v1.Value.content:type_name -> google.protobuf.Struct
+	10, // 11: nitric.proto.kvstore.v1.KvStoreGetValueRequest.ref:type_name -> nitric.proto.kvstore.v1.ValueRef
+	11, // 12: nitric.proto.kvstore.v1.KvStoreGetValueResponse.value:type_name -> nitric.proto.kvstore.v1.Value
+	10, // 13: nitric.proto.kvstore.v1.KvStoreSetValueRequest.ref:type_name -> nitric.proto.kvstore.v1.ValueRef
+	32, // 14: nitric.proto.kvstore.v1.KvStoreSetValueRequest.content:type_name -> google.protobuf.Struct
+	33, // 15: nitric.proto.kvstore.v1.KvStoreSetValueRequest.ttl:type_name -> google.protobuf.Duration
+	12, // 16: nitric.proto.kvstore.v1.KvStoreSetValueRequest.precondition:type_name -> nitric.proto.kvstore.v1.KvStorePrecondition
+	10, // 17: nitric.proto.kvstore.v1.KvStoreDeleteKeyRequest.ref:type_name -> nitric.proto.kvstore.v1.ValueRef
+	12, // 18: nitric.proto.kvstore.v1.KvStoreDeleteKeyRequest.precondition:type_name -> nitric.proto.kvstore.v1.KvStorePrecondition
+	9,  // 19: nitric.proto.kvstore.v1.KvStoreScanKeysRequest.store:type_name -> nitric.proto.kvstore.v1.Store
+	11, // 20: nitric.proto.kvstore.v1.KvStoreScanKeysResponse.value:type_name -> nitric.proto.kvstore.v1.Value
+	10, // 21: nitric.proto.kvstore.v1.KvStoreItemError.ref:type_name -> nitric.proto.kvstore.v1.ValueRef
+	10, // 22: nitric.proto.kvstore.v1.KvStoreBatchGetRequest.refs:type_name -> nitric.proto.kvstore.v1.ValueRef
+	11, // 23: nitric.proto.kvstore.v1.KvStoreBatchGetResponse.values:type_name -> nitric.proto.kvstore.v1.Value
+	21, // 24: nitric.proto.kvstore.v1.KvStoreBatchGetResponse.errors:type_name -> nitric.proto.kvstore.v1.KvStoreItemError
+	11, // 25: nitric.proto.kvstore.v1.KvStoreBatchSetRequest.values:type_name -> nitric.proto.kvstore.v1.Value
+	21, // 26: nitric.proto.kvstore.v1.KvStoreBatchSetResponse.errors:type_name -> nitric.proto.kvstore.v1.KvStoreItemError
+	10, // 27: nitric.proto.kvstore.v1.KvStoreBatchDeleteRequest.refs:type_name -> nitric.proto.kvstore.v1.ValueRef
+	21, // 28: nitric.proto.kvstore.v1.KvStoreBatchDeleteResponse.errors:type_name -> nitric.proto.kvstore.v1.KvStoreItemError
+	11, // 29: nitric.proto.kvstore.v1.KvStoreTransactOperation.set:type_name -> nitric.proto.kvstore.v1.Value
+	10, // 30: nitric.proto.kvstore.v1.KvStoreTransactOperation.delete:type_name -> nitric.proto.kvstore.v1.ValueRef
+	28, // 31: nitric.proto.kvstore.v1.KvStoreTransactRequest.operations:type_name -> nitric.proto.kvstore.v1.KvStoreTransactOperation
+	13, // 32: nitric.proto.kvstore.v1.KvStore.GetValue:input_type -> nitric.proto.kvstore.v1.KvStoreGetValueRequest
+	15, // 33: nitric.proto.kvstore.v1.KvStore.SetValue:input_type -> nitric.proto.kvstore.v1.KvStoreSetValueRequest
+	17, // 34: nitric.proto.kvstore.v1.KvStore.DeleteKey:input_type -> nitric.proto.kvstore.v1.KvStoreDeleteKeyRequest
+	19, // 35: nitric.proto.kvstore.v1.KvStore.ScanKeys:input_type -> nitric.proto.kvstore.v1.KvStoreScanKeysRequest
+	22, // 36: nitric.proto.kvstore.v1.KvStore.BatchGet:input_type -> nitric.proto.kvstore.v1.KvStoreBatchGetRequest
+	24, // 37: nitric.proto.kvstore.v1.KvStore.BatchSet:input_type -> nitric.proto.kvstore.v1.KvStoreBatchSetRequest
+	26, // 38: nitric.proto.kvstore.v1.KvStore.BatchDelete:input_type -> nitric.proto.kvstore.v1.KvStoreBatchDeleteRequest
+	29, // 39: nitric.proto.kvstore.v1.KvStore.Transact:input_type -> nitric.proto.kvstore.v1.KvStoreTransactRequest
+	1,  // 40: nitric.proto.kvstore.v1.KeyValueListener.Listen:input_type -> nitric.proto.kvstore.v1.ClientMessage
+	14, // 41: nitric.proto.kvstore.v1.KvStore.GetValue:output_type -> nitric.proto.kvstore.v1.KvStoreGetValueResponse
+	16, // 42: nitric.proto.kvstore.v1.KvStore.SetValue:output_type -> nitric.proto.kvstore.v1.KvStoreSetValueResponse
+	18, // 43: nitric.proto.kvstore.v1.KvStore.DeleteKey:output_type -> nitric.proto.kvstore.v1.KvStoreDeleteKeyResponse
+	20, // 44: nitric.proto.kvstore.v1.KvStore.ScanKeys:output_type -> nitric.proto.kvstore.v1.KvStoreScanKeysResponse
+	23, // 45: nitric.proto.kvstore.v1.KvStore.BatchGet:output_type -> nitric.proto.kvstore.v1.KvStoreBatchGetResponse
+	25, // 46: nitric.proto.kvstore.v1.KvStore.BatchSet:output_type -> nitric.proto.kvstore.v1.KvStoreBatchSetResponse
+	27, // 47: nitric.proto.kvstore.v1.KvStore.BatchDelete:output_type -> nitric.proto.kvstore.v1.KvStoreBatchDeleteResponse
+	30, // 48: nitric.proto.kvstore.v1.KvStore.Transact:output_type -> nitric.proto.kvstore.v1.KvStoreTransactResponse
+	2,  // 49: nitric.proto.kvstore.v1.KeyValueListener.Listen:output_type -> nitric.proto.kvstore.v1.ServerMessage
+	41, // [41:50] is the sub-list for method output_type
+	32, // [32:41] is the sub-list for method input_type
+	32, // [32:32] is the sub-list for extension type_name
+	32, // [32:32] is the sub-list for extension extendee
+	0,  // [0:32] is the sub-list for field type_name
 }
 
 func init() { file_nitric_proto_kvstore_v1_kvstore_proto_init() }
@@ -725,7 +2327,7 @@ func file_nitric_proto_kvstore_v1_kvstore_proto_init() {
 	}
 	if !protoimpl.UnsafeEnabled {
 		file_nitric_proto_kvstore_v1_kvstore_proto_msgTypes[0].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*Store); i {
+			switch v := v.(*ClientMessage); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -737,7 +2339,7 @@ func file_nitric_proto_kvstore_v1_kvstore_proto_init() {
 			}
 		}
 		file_nitric_proto_kvstore_v1_kvstore_proto_msgTypes[1].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*ValueRef); i {
+			switch v := v.(*ServerMessage); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -749,7 +2351,7 @@ func file_nitric_proto_kvstore_v1_kvstore_proto_init() {
 			}
 		}
 		file_nitric_proto_kvstore_v1_kvstore_proto_msgTypes[2].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*Value); i {
+			switch v := v.(*CancellationRequest); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -761,7 +2363,7 @@ func file_nitric_proto_kvstore_v1_kvstore_proto_init() {
 			}
 		}
 		file_nitric_proto_kvstore_v1_kvstore_proto_msgTypes[3].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*KvStoreGetValueRequest); i {
+			switch v := v.(*KeyValueEventRequest); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -773,7 +2375,7 @@ func file_nitric_proto_kvstore_v1_kvstore_proto_init() {
 			}
 		}
 		file_nitric_proto_kvstore_v1_kvstore_proto_msgTypes[4].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*KvStoreGetValueResponse); i {
+			switch v := v.(*KeyValueEvent); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -785,7 +2387,7 @@ func file_nitric_proto_kvstore_v1_kvstore_proto_init() {
 			}
 		}
 		file_nitric_proto_kvstore_v1_kvstore_proto_msgTypes[5].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*KvStoreSetValueRequest); i {
+			switch v := v.(*KeyValueEventResponse); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -797,7 +2399,7 @@ func file_nitric_proto_kvstore_v1_kvstore_proto_init() {
 			}
 		}
 		file_nitric_proto_kvstore_v1_kvstore_proto_msgTypes[6].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*KvStoreSetValueResponse); i {
+			switch v := v.(*RegistrationRequest); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -809,7 +2411,7 @@ func file_nitric_proto_kvstore_v1_kvstore_proto_init() {
 			}
 		}
 		file_nitric_proto_kvstore_v1_kvstore_proto_msgTypes[7].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*KvStoreDeleteKeyRequest); i {
+			switch v := v.(*RegistrationResponse); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -821,7 +2423,7 @@ func file_nitric_proto_kvstore_v1_kvstore_proto_init() {
 			}
 		}
 		file_nitric_proto_kvstore_v1_kvstore_proto_msgTypes[8].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*KvStoreDeleteKeyResponse); i {
+			switch v := v.(*Store); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -833,7 +2435,7 @@ func file_nitric_proto_kvstore_v1_kvstore_proto_init() {
 			}
 		}
 		file_nitric_proto_kvstore_v1_kvstore_proto_msgTypes[9].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*KvStoreScanKeysRequest); i {
+			switch v := v.(*ValueRef); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -845,6 +2447,114 @@ func file_nitric_proto_kvstore_v1_kvstore_proto_init() {
 			}
 		}
 		file_nitric_proto_kvstore_v1_kvstore_proto_msgTypes[10].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Value); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_nitric_proto_kvstore_v1_kvstore_proto_msgTypes[11].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*KvStorePrecondition); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_nitric_proto_kvstore_v1_kvstore_proto_msgTypes[12].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*KvStoreGetValueRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_nitric_proto_kvstore_v1_kvstore_proto_msgTypes[13].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*KvStoreGetValueResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_nitric_proto_kvstore_v1_kvstore_proto_msgTypes[14].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*KvStoreSetValueRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_nitric_proto_kvstore_v1_kvstore_proto_msgTypes[15].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*KvStoreSetValueResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_nitric_proto_kvstore_v1_kvstore_proto_msgTypes[16].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*KvStoreDeleteKeyRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_nitric_proto_kvstore_v1_kvstore_proto_msgTypes[17].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*KvStoreDeleteKeyResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_nitric_proto_kvstore_v1_kvstore_proto_msgTypes[18].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*KvStoreScanKeysRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_nitric_proto_kvstore_v1_kvstore_proto_msgTypes[19].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*KvStoreScanKeysResponse); i {
 			case 0:
 				return &v.state
@@ -856,19 +2566,160 @@ func file_nitric_proto_kvstore_v1_kvstore_proto_init() {
 				return nil
 			}
 		}
+		file_nitric_proto_kvstore_v1_kvstore_proto_msgTypes[20].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*KvStoreItemError); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_nitric_proto_kvstore_v1_kvstore_proto_msgTypes[21].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*KvStoreBatchGetRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_nitric_proto_kvstore_v1_kvstore_proto_msgTypes[22].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*KvStoreBatchGetResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_nitric_proto_kvstore_v1_kvstore_proto_msgTypes[23].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*KvStoreBatchSetRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_nitric_proto_kvstore_v1_kvstore_proto_msgTypes[24].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*KvStoreBatchSetResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_nitric_proto_kvstore_v1_kvstore_proto_msgTypes[25].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*KvStoreBatchDeleteRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_nitric_proto_kvstore_v1_kvstore_proto_msgTypes[26].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*KvStoreBatchDeleteResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_nitric_proto_kvstore_v1_kvstore_proto_msgTypes[27].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*KvStoreTransactOperation); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_nitric_proto_kvstore_v1_kvstore_proto_msgTypes[28].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*KvStoreTransactRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_nitric_proto_kvstore_v1_kvstore_proto_msgTypes[29].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*KvStoreTransactResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+	}
+	file_nitric_proto_kvstore_v1_kvstore_proto_msgTypes[0].OneofWrappers = []interface{}{
+		(*ClientMessage_RegistrationRequest)(nil),
+		(*ClientMessage_KeyValueEventResponse)(nil),
+	}
+	file_nitric_proto_kvstore_v1_kvstore_proto_msgTypes[1].OneofWrappers = []interface{}{
+		(*ServerMessage_RegistrationResponse)(nil),
+		(*ServerMessage_KeyValueEventRequest)(nil),
+		(*ServerMessage_CancellationRequest)(nil),
+	}
+	file_nitric_proto_kvstore_v1_kvstore_proto_msgTypes[3].OneofWrappers = []interface{}{
+		(*KeyValueEventRequest_KeyValueEvent)(nil),
+	}
+	file_nitric_proto_kvstore_v1_kvstore_proto_msgTypes[11].OneofWrappers = []interface{}{
+		(*KvStorePrecondition_Version)(nil),
+		(*KvStorePrecondition_Exists)(nil),
+	}
+	file_nitric_proto_kvstore_v1_kvstore_proto_msgTypes[27].OneofWrappers = []interface{}{
+		(*KvStoreTransactOperation_Set)(nil),
+		(*KvStoreTransactOperation_Delete)(nil),
 	}
 	type x struct{}
 	out := protoimpl.TypeBuilder{
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: file_nitric_proto_kvstore_v1_kvstore_proto_rawDesc,
-			NumEnums:      0,
-			NumMessages:   11,
+			NumEnums:      1,
+			NumMessages:   31,
 			NumExtensions: 0,
-			NumServices:   1,
+			NumServices:   2,
 		},
 		GoTypes:           file_nitric_proto_kvstore_v1_kvstore_proto_goTypes,
 		DependencyIndexes: file_nitric_proto_kvstore_v1_kvstore_proto_depIdxs,
+		EnumInfos:         file_nitric_proto_kvstore_v1_kvstore_proto_enumTypes,
 		MessageInfos:      file_nitric_proto_kvstore_v1_kvstore_proto_msgTypes,
 	}.Build()
 	File_nitric_proto_kvstore_v1_kvstore_proto = out.File
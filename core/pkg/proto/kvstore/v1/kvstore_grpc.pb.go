@@ -30,6 +30,14 @@ type KvStoreClient interface {
 	DeleteKey(ctx context.Context, in *KvStoreDeleteKeyRequest, opts ...grpc.CallOption) (*KvStoreDeleteKeyResponse, error)
 	// Iterate over all keys in a store
 	ScanKeys(ctx context.Context, in *KvStoreScanKeysRequest, opts ...grpc.CallOption) (KvStore_ScanKeysClient, error)
+	// Get multiple values, keys that couldn't be retrieved are reported individually
+	BatchGet(ctx context.Context, in *KvStoreBatchGetRequest, opts ...grpc.CallOption) (*KvStoreBatchGetResponse, error)
+	// Create or overwrite multiple values, values that couldn't be stored are reported individually
+	BatchSet(ctx context.Context, in *KvStoreBatchSetRequest, opts ...grpc.CallOption) (*KvStoreBatchSetResponse, error)
+	// Delete multiple keys and their values, keys that couldn't be deleted are reported individually
+	BatchDelete(ctx context.Context, in *KvStoreBatchDeleteRequest, opts ...grpc.CallOption) (*KvStoreBatchDeleteResponse, error)
+	// Apply multiple set and delete operations atomically, either all of them succeed or none are applied
+	Transact(ctx context.Context, in *KvStoreTransactRequest, opts ...grpc.CallOption) (*KvStoreTransactResponse, error)
 }
 
 type kvStoreClient struct {
@@ -99,6 +107,42 @@ func (x *kvStoreScanKeysClient) Recv() (*KvStoreScanKeysResponse, error) {
 	return m, nil
 }
 
+func (c *kvStoreClient) BatchGet(ctx context.Context, in *KvStoreBatchGetRequest, opts ...grpc.CallOption) (*KvStoreBatchGetResponse, error) {
+	out := new(KvStoreBatchGetResponse)
+	err := c.cc.Invoke(ctx, "/nitric.proto.kvstore.v1.KvStore/BatchGet", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *kvStoreClient) BatchSet(ctx context.Context, in *KvStoreBatchSetRequest, opts ...grpc.CallOption) (*KvStoreBatchSetResponse, error) {
+	out := new(KvStoreBatchSetResponse)
+	err := c.cc.Invoke(ctx, "/nitric.proto.kvstore.v1.KvStore/BatchSet", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *kvStoreClient) BatchDelete(ctx context.Context, in *KvStoreBatchDeleteRequest, opts ...grpc.CallOption) (*KvStoreBatchDeleteResponse, error) {
+	out := new(KvStoreBatchDeleteResponse)
+	err := c.cc.Invoke(ctx, "/nitric.proto.kvstore.v1.KvStore/BatchDelete", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *kvStoreClient) Transact(ctx context.Context, in *KvStoreTransactRequest, opts ...grpc.CallOption) (*KvStoreTransactResponse, error) {
+	out := new(KvStoreTransactResponse)
+	err := c.cc.Invoke(ctx, "/nitric.proto.kvstore.v1.KvStore/Transact", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // KvStoreServer is the server API for KvStore service.
 // All implementations should embed UnimplementedKvStoreServer
 // for forward compatibility
@@ -111,6 +155,14 @@ type KvStoreServer interface {
 	DeleteKey(context.Context, *KvStoreDeleteKeyRequest) (*KvStoreDeleteKeyResponse, error)
 	// Iterate over all keys in a store
 	ScanKeys(*KvStoreScanKeysRequest, KvStore_ScanKeysServer) error
+	// Get multiple values, keys that couldn't be retrieved are reported individually
+	BatchGet(context.Context, *KvStoreBatchGetRequest) (*KvStoreBatchGetResponse, error)
+	// Create or overwrite multiple values, values that couldn't be stored are reported individually
+	BatchSet(context.Context, *KvStoreBatchSetRequest) (*KvStoreBatchSetResponse, error)
+	// Delete multiple keys and their values, keys that couldn't be deleted are reported individually
+	BatchDelete(context.Context, *KvStoreBatchDeleteRequest) (*KvStoreBatchDeleteResponse, error)
+	// Apply multiple set and delete operations atomically, either all of them succeed or none are applied
+	Transact(context.Context, *KvStoreTransactRequest) (*KvStoreTransactResponse, error)
 }
 
 // UnimplementedKvStoreServer should be embedded to have forward compatible implementations.
@@ -129,6 +181,18 @@ func (UnimplementedKvStoreServer) DeleteKey(context.Context, *KvStoreDeleteKeyRe
 func (UnimplementedKvStoreServer) ScanKeys(*KvStoreScanKeysRequest, KvStore_ScanKeysServer) error {
 	return status.Errorf(codes.Unimplemented, "method ScanKeys not implemented")
 }
+func (UnimplementedKvStoreServer) BatchGet(context.Context, *KvStoreBatchGetRequest) (*KvStoreBatchGetResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method BatchGet not implemented")
+}
+func (UnimplementedKvStoreServer) BatchSet(context.Context, *KvStoreBatchSetRequest) (*KvStoreBatchSetResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method BatchSet not implemented")
+}
+func (UnimplementedKvStoreServer) BatchDelete(context.Context, *KvStoreBatchDeleteRequest) (*KvStoreBatchDeleteResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method BatchDelete not implemented")
+}
+func (UnimplementedKvStoreServer) Transact(context.Context, *KvStoreTransactRequest) (*KvStoreTransactResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Transact not implemented")
+}
 
 // UnsafeKvStoreServer may be embedded to opt out of forward compatibility for this service.
 // Use of this interface is not recommended, as added methods to KvStoreServer will
@@ -216,6 +280,78 @@ func (x *kvStoreScanKeysServer) Send(m *KvStoreScanKeysResponse) error {
 	return x.ServerStream.SendMsg(m)
 }
 
+func _KvStore_BatchGet_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(KvStoreBatchGetRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(KvStoreServer).BatchGet(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/nitric.proto.kvstore.v1.KvStore/BatchGet",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(KvStoreServer).BatchGet(ctx, req.(*KvStoreBatchGetRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _KvStore_BatchSet_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(KvStoreBatchSetRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(KvStoreServer).BatchSet(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/nitric.proto.kvstore.v1.KvStore/BatchSet",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(KvStoreServer).BatchSet(ctx, req.(*KvStoreBatchSetRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _KvStore_BatchDelete_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(KvStoreBatchDeleteRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(KvStoreServer).BatchDelete(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/nitric.proto.kvstore.v1.KvStore/BatchDelete",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(KvStoreServer).BatchDelete(ctx, req.(*KvStoreBatchDeleteRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _KvStore_Transact_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(KvStoreTransactRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(KvStoreServer).Transact(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/nitric.proto.kvstore.v1.KvStore/Transact",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(KvStoreServer).Transact(ctx, req.(*KvStoreTransactRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // KvStore_ServiceDesc is the grpc.ServiceDesc for KvStore service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -235,6 +371,22 @@ var KvStore_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "DeleteKey",
 			Handler:    _KvStore_DeleteKey_Handler,
 		},
+		{
+			MethodName: "BatchGet",
+			Handler:    _KvStore_BatchGet_Handler,
+		},
+		{
+			MethodName: "BatchSet",
+			Handler:    _KvStore_BatchSet_Handler,
+		},
+		{
+			MethodName: "BatchDelete",
+			Handler:    _KvStore_BatchDelete_Handler,
+		},
+		{
+			MethodName: "Transact",
+			Handler:    _KvStore_Transact_Handler,
+		},
 	},
 	Streams: []grpc.StreamDesc{
 		{
@@ -245,3 +397,121 @@ var KvStore_ServiceDesc = grpc.ServiceDesc{
 	},
 	Metadata: "nitric/proto/kvstore/v1/kvstore.proto",
 }
+
+// KeyValueListenerClient is the client API for KeyValueListener service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type KeyValueListenerClient interface {
+	// Listen for changes on a store
+	Listen(ctx context.Context, opts ...grpc.CallOption) (KeyValueListener_ListenClient, error)
+}
+
+type keyValueListenerClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewKeyValueListenerClient(cc grpc.ClientConnInterface) KeyValueListenerClient {
+	return &keyValueListenerClient{cc}
+}
+
+func (c *keyValueListenerClient) Listen(ctx context.Context, opts ...grpc.CallOption) (KeyValueListener_ListenClient, error) {
+	stream, err := c.cc.NewStream(ctx, &KeyValueListener_ServiceDesc.Streams[0], "/nitric.proto.kvstore.v1.KeyValueListener/Listen", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &keyValueListenerListenClient{stream}
+	return x, nil
+}
+
+type KeyValueListener_ListenClient interface {
+	Send(*ClientMessage) error
+	Recv() (*ServerMessage, error)
+	grpc.ClientStream
+}
+
+type keyValueListenerListenClient struct {
+	grpc.ClientStream
+}
+
+func (x *keyValueListenerListenClient) Send(m *ClientMessage) error {
+	return x.ClientStream.SendMsg(m)
+}
+
+func (x *keyValueListenerListenClient) Recv() (*ServerMessage, error) {
+	m := new(ServerMessage)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+// KeyValueListenerServer is the server API for KeyValueListener service.
+// All implementations should embed UnimplementedKeyValueListenerServer
+// for forward compatibility
+type KeyValueListenerServer interface {
+	// Listen for changes on a store
+	Listen(KeyValueListener_ListenServer) error
+}
+
+// UnimplementedKeyValueListenerServer should be embedded to have forward compatible implementations.
+type UnimplementedKeyValueListenerServer struct {
+}
+
+func (UnimplementedKeyValueListenerServer) Listen(KeyValueListener_ListenServer) error {
+	return status.Errorf(codes.Unimplemented, "method Listen not implemented")
+}
+
+// UnsafeKeyValueListenerServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to KeyValueListenerServer will
+// result in compilation errors.
+type UnsafeKeyValueListenerServer interface {
+	mustEmbedUnimplementedKeyValueListenerServer()
+}
+
+func RegisterKeyValueListenerServer(s grpc.ServiceRegistrar, srv KeyValueListenerServer) {
+	s.RegisterService(&KeyValueListener_ServiceDesc, srv)
+}
+
+func _KeyValueListener_Listen_Handler(srv interface{}, stream grpc.ServerStream) error {
+	return srv.(KeyValueListenerServer).Listen(&keyValueListenerListenServer{stream})
+}
+
+type KeyValueListener_ListenServer interface {
+	Send(*ServerMessage) error
+	Recv() (*ClientMessage, error)
+	grpc.ServerStream
+}
+
+type keyValueListenerListenServer struct {
+	grpc.ServerStream
+}
+
+func (x *keyValueListenerListenServer) Send(m *ServerMessage) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func (x *keyValueListenerListenServer) Recv() (*ClientMessage, error) {
+	m := new(ClientMessage)
+	if err := x.ServerStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+// KeyValueListener_ServiceDesc is the grpc.ServiceDesc for KeyValueListener service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var KeyValueListener_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "nitric.proto.kvstore.v1.KeyValueListener",
+	HandlerType: (*KeyValueListenerServer)(nil),
+	Methods:     []grpc.MethodDesc{},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "Listen",
+			Handler:       _KeyValueListener_Listen_Handler,
+			ServerStreams: true,
+			ClientStreams: true,
+		},
+	},
+	Metadata: "nitric/proto/kvstore/v1/kvstore.proto",
+}
@@ -9,6 +9,7 @@ package queuespb
 import (
 	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
 	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	durationpb "google.golang.org/protobuf/types/known/durationpb"
 	structpb "google.golang.org/protobuf/types/known/structpb"
 	reflect "reflect"
 	sync "sync"
@@ -21,6 +22,484 @@ const (
 	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
 )
 
+// ClientMessages are sent from the service to the nitric server
+type ClientMessage struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// globally unique ID of the request/response pair
+	Id string `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	// Types that are assignable to Content:
+	//
+	//	*ClientMessage_RegistrationRequest
+	//	*ClientMessage_MessageResponse
+	Content isClientMessage_Content `protobuf_oneof:"content"`
+}
+
+func (x *ClientMessage) Reset() {
+	*x = ClientMessage{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_nitric_proto_queues_v1_queues_proto_msgTypes[0]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ClientMessage) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ClientMessage) ProtoMessage() {}
+
+func (x *ClientMessage) ProtoReflect() protoreflect.Message {
+	mi := &file_nitric_proto_queues_v1_queues_proto_msgTypes[0]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ClientMessage.ProtoReflect.Descriptor instead.
+func (*ClientMessage) Descriptor() ([]byte, []int) {
+	return file_nitric_proto_queues_v1_queues_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *ClientMessage) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (m *ClientMessage) GetContent() isClientMessage_Content {
+	if m != nil {
+		return m.Content
+	}
+	return nil
+}
+
+func (x *ClientMessage) GetRegistrationRequest() *RegistrationRequest {
+	if x, ok := x.GetContent().(*ClientMessage_RegistrationRequest); ok {
+		return x.RegistrationRequest
+	}
+	return nil
+}
+
+func (x *ClientMessage) GetMessageResponse() *MessageResponse {
+	if x, ok := x.GetContent().(*ClientMessage_MessageResponse); ok {
+		return x.MessageResponse
+	}
+	return nil
+}
+
+type isClientMessage_Content interface {
+	isClientMessage_Content()
+}
+
+type ClientMessage_RegistrationRequest struct {
+	// Register a listener for a queue
+	RegistrationRequest *RegistrationRequest `protobuf:"bytes,2,opt,name=registration_request,json=registrationRequest,proto3,oneof"`
+}
+
+type ClientMessage_MessageResponse struct {
+	// Response to a queue message request
+	MessageResponse *MessageResponse `protobuf:"bytes,3,opt,name=message_response,json=messageResponse,proto3,oneof"`
+}
+
+func (*ClientMessage_RegistrationRequest) isClientMessage_Content() {}
+
+func (*ClientMessage_MessageResponse) isClientMessage_Content() {}
+
+// ServerMessages are sent from the nitric server to the service
+type ServerMessage struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// globally unique ID of the request/response pair
+	Id string `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	// Types that are assignable to Content:
+	//
+	//	*ServerMessage_RegistrationResponse
+	//	*ServerMessage_MessageRequest
+	//	*ServerMessage_CancellationRequest
+	Content isServerMessage_Content `protobuf_oneof:"content"`
+	// W3C trace context (e.g. traceparent and tracestate) of the trigger, used to continue its trace in the service
+	TraceContext map[string]string `protobuf:"bytes,10,rep,name=trace_context,json=traceContext,proto3" json:"trace_context,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+}
+
+func (x *ServerMessage) Reset() {
+	*x = ServerMessage{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_nitric_proto_queues_v1_queues_proto_msgTypes[1]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ServerMessage) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ServerMessage) ProtoMessage() {}
+
+func (x *ServerMessage) ProtoReflect() protoreflect.Message {
+	mi := &file_nitric_proto_queues_v1_queues_proto_msgTypes[1]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ServerMessage.ProtoReflect.Descriptor instead.
+func (*ServerMessage) Descriptor() ([]byte, []int) {
+	return file_nitric_proto_queues_v1_queues_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *ServerMessage) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (m *ServerMessage) GetContent() isServerMessage_Content {
+	if m != nil {
+		return m.Content
+	}
+	return nil
+}
+
+func (x *ServerMessage) GetRegistrationResponse() *RegistrationResponse {
+	if x, ok := x.GetContent().(*ServerMessage_RegistrationResponse); ok {
+		return x.RegistrationResponse
+	}
+	return nil
+}
+
+func (x *ServerMessage) GetMessageRequest() *MessageRequest {
+	if x, ok := x.GetContent().(*ServerMessage_MessageRequest); ok {
+		return x.MessageRequest
+	}
+	return nil
+}
+
+func (x *ServerMessage) GetCancellationRequest() *CancellationRequest {
+	if x, ok := x.GetContent().(*ServerMessage_CancellationRequest); ok {
+		return x.CancellationRequest
+	}
+	return nil
+}
+
+func (x *ServerMessage) GetTraceContext() map[string]string {
+	if x != nil {
+		return x.TraceContext
+	}
+	return nil
+}
+
+type isServerMessage_Content interface {
+	isServerMessage_Content()
+}
+
+type ServerMessage_RegistrationResponse struct {
+	// Response to a queue listener registration request
+	RegistrationResponse *RegistrationResponse `protobuf:"bytes,2,opt,name=registration_response,json=registrationResponse,proto3,oneof"`
+}
+
+type ServerMessage_MessageRequest struct {
+	// A message dequeued for the listener
+	MessageRequest *MessageRequest `protobuf:"bytes,3,opt,name=message_request,json=messageRequest,proto3,oneof"`
+}
+
+type ServerMessage_CancellationRequest struct {
+	// Notifies the worker that a previous request with the same ID has been cancelled,
+	// typically because its deadline was exceeded. The worker should abort processing.
+	CancellationRequest *CancellationRequest `protobuf:"bytes,4,opt,name=cancellation_request,json=cancellationRequest,proto3,oneof"`
+}
+
+func (*ServerMessage_RegistrationResponse) isServerMessage_Content() {}
+
+func (*ServerMessage_MessageRequest) isServerMessage_Content() {}
+
+func (*ServerMessage_CancellationRequest) isServerMessage_Content() {}
+
+// Notification that an in-flight request was cancelled by the server
+type CancellationRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// The reason the request was cancelled
+	Reason string `protobuf:"bytes,1,opt,name=reason,proto3" json:"reason,omitempty"`
+}
+
+func (x *CancellationRequest) Reset() {
+	*x = CancellationRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_nitric_proto_queues_v1_queues_proto_msgTypes[2]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *CancellationRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CancellationRequest) ProtoMessage() {}
+
+func (x *CancellationRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_nitric_proto_queues_v1_queues_proto_msgTypes[2]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CancellationRequest.ProtoReflect.Descriptor instead.
+func (*CancellationRequest) Descriptor() ([]byte, []int) {
+	return file_nitric_proto_queues_v1_queues_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *CancellationRequest) GetReason() string {
+	if x != nil {
+		return x.Reason
+	}
+	return ""
+}
+
+type RegistrationRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// The Nitric name of the queue to listen to
+	QueueName string `protobuf:"bytes,1,opt,name=queue_name,json=queueName,proto3" json:"queue_name,omitempty"`
+	// The maximum number of messages dequeued or delivered together, defaults to 10
+	BatchSize int32 `protobuf:"varint,2,opt,name=batch_size,json=batchSize,proto3" json:"batch_size,omitempty"`
+	// The maximum number of messages the listener handles at once, defaults to the batch size
+	Concurrency int32 `protobuf:"varint,3,opt,name=concurrency,proto3" json:"concurrency,omitempty"`
+}
+
+func (x *RegistrationRequest) Reset() {
+	*x = RegistrationRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_nitric_proto_queues_v1_queues_proto_msgTypes[3]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *RegistrationRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RegistrationRequest) ProtoMessage() {}
+
+func (x *RegistrationRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_nitric_proto_queues_v1_queues_proto_msgTypes[3]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RegistrationRequest.ProtoReflect.Descriptor instead.
+func (*RegistrationRequest) Descriptor() ([]byte, []int) {
+	return file_nitric_proto_queues_v1_queues_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *RegistrationRequest) GetQueueName() string {
+	if x != nil {
+		return x.QueueName
+	}
+	return ""
+}
+
+func (x *RegistrationRequest) GetBatchSize() int32 {
+	if x != nil {
+		return x.BatchSize
+	}
+	return 0
+}
+
+func (x *RegistrationRequest) GetConcurrency() int32 {
+	if x != nil {
+		return x.Concurrency
+	}
+	return 0
+}
+
+type RegistrationResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+}
+
+func (x *RegistrationResponse) Reset() {
+	*x = RegistrationResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_nitric_proto_queues_v1_queues_proto_msgTypes[4]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *RegistrationResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RegistrationResponse) ProtoMessage() {}
+
+func (x *RegistrationResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_nitric_proto_queues_v1_queues_proto_msgTypes[4]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RegistrationResponse.ProtoReflect.Descriptor instead.
+func (*RegistrationResponse) Descriptor() ([]byte, []int) {
+	return file_nitric_proto_queues_v1_queues_proto_rawDescGZIP(), []int{4}
+}
+
+type MessageRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// The Nitric name of the queue the message was dequeued from
+	QueueName string `protobuf:"bytes,1,opt,name=queue_name,json=queueName,proto3" json:"queue_name,omitempty"`
+	// The dequeued message
+	Message *QueueMessage `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
+	// Number of times the message has been delivered, including this delivery, 0 when the provider can't determine it
+	DeliveryAttempt int32 `protobuf:"varint,3,opt,name=delivery_attempt,json=deliveryAttempt,proto3" json:"delivery_attempt,omitempty"`
+}
+
+func (x *MessageRequest) Reset() {
+	*x = MessageRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_nitric_proto_queues_v1_queues_proto_msgTypes[5]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *MessageRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MessageRequest) ProtoMessage() {}
+
+func (x *MessageRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_nitric_proto_queues_v1_queues_proto_msgTypes[5]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MessageRequest.ProtoReflect.Descriptor instead.
+func (*MessageRequest) Descriptor() ([]byte, []int) {
+	return file_nitric_proto_queues_v1_queues_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *MessageRequest) GetQueueName() string {
+	if x != nil {
+		return x.QueueName
+	}
+	return ""
+}
+
+func (x *MessageRequest) GetMessage() *QueueMessage {
+	if x != nil {
+		return x.Message
+	}
+	return nil
+}
+
+func (x *MessageRequest) GetDeliveryAttempt() int32 {
+	if x != nil {
+		return x.DeliveryAttempt
+	}
+	return 0
+}
+
+type MessageResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Completes the message when true, otherwise the message is released for redelivery
+	Success bool `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+}
+
+func (x *MessageResponse) Reset() {
+	*x = MessageResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_nitric_proto_queues_v1_queues_proto_msgTypes[6]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *MessageResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MessageResponse) ProtoMessage() {}
+
+func (x *MessageResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_nitric_proto_queues_v1_queues_proto_msgTypes[6]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MessageResponse.ProtoReflect.Descriptor instead.
+func (*MessageResponse) Descriptor() ([]byte, []int) {
+	return file_nitric_proto_queues_v1_queues_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *MessageResponse) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
 type QueueEnqueueRequest struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
@@ -36,7 +515,7 @@ type QueueEnqueueRequest struct {
 func (x *QueueEnqueueRequest) Reset() {
 	*x = QueueEnqueueRequest{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_nitric_proto_queues_v1_queues_proto_msgTypes[0]
+		mi := &file_nitric_proto_queues_v1_queues_proto_msgTypes[7]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -49,7 +528,7 @@ func (x *QueueEnqueueRequest) String() string {
 func (*QueueEnqueueRequest) ProtoMessage() {}
 
 func (x *QueueEnqueueRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_nitric_proto_queues_v1_queues_proto_msgTypes[0]
+	mi := &file_nitric_proto_queues_v1_queues_proto_msgTypes[7]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -62,7 +541,7 @@ func (x *QueueEnqueueRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use QueueEnqueueRequest.ProtoReflect.Descriptor instead.
 func (*QueueEnqueueRequest) Descriptor() ([]byte, []int) {
-	return file_nitric_proto_queues_v1_queues_proto_rawDescGZIP(), []int{0}
+	return file_nitric_proto_queues_v1_queues_proto_rawDescGZIP(), []int{7}
 }
 
 func (x *QueueEnqueueRequest) GetQueueName() string {
@@ -92,7 +571,7 @@ type QueueEnqueueResponse struct {
 func (x *QueueEnqueueResponse) Reset() {
 	*x = QueueEnqueueResponse{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_nitric_proto_queues_v1_queues_proto_msgTypes[1]
+		mi := &file_nitric_proto_queues_v1_queues_proto_msgTypes[8]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -105,7 +584,7 @@ func (x *QueueEnqueueResponse) String() string {
 func (*QueueEnqueueResponse) ProtoMessage() {}
 
 func (x *QueueEnqueueResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_nitric_proto_queues_v1_queues_proto_msgTypes[1]
+	mi := &file_nitric_proto_queues_v1_queues_proto_msgTypes[8]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -118,7 +597,7 @@ func (x *QueueEnqueueResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use QueueEnqueueResponse.ProtoReflect.Descriptor instead.
 func (*QueueEnqueueResponse) Descriptor() ([]byte, []int) {
-	return file_nitric_proto_queues_v1_queues_proto_rawDescGZIP(), []int{1}
+	return file_nitric_proto_queues_v1_queues_proto_rawDescGZIP(), []int{8}
 }
 
 func (x *QueueEnqueueResponse) GetFailedMessages() []*FailedEnqueueMessage {
@@ -138,12 +617,14 @@ type QueueDequeueRequest struct {
 	QueueName string `protobuf:"bytes,1,opt,name=queue_name,json=queueName,proto3" json:"queue_name,omitempty"`
 	// The max number of messages to pop off the queue, may be capped by provider specific limitations
 	Depth int32 `protobuf:"varint,2,opt,name=depth,proto3" json:"depth,omitempty"`
+	// How long the messages are leased for before they're redelivered, the provider's default is used when not set
+	VisibilityTimeout *durationpb.Duration `protobuf:"bytes,3,opt,name=visibility_timeout,json=visibilityTimeout,proto3" json:"visibility_timeout,omitempty"`
 }
 
 func (x *QueueDequeueRequest) Reset() {
 	*x = QueueDequeueRequest{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_nitric_proto_queues_v1_queues_proto_msgTypes[2]
+		mi := &file_nitric_proto_queues_v1_queues_proto_msgTypes[9]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -156,7 +637,7 @@ func (x *QueueDequeueRequest) String() string {
 func (*QueueDequeueRequest) ProtoMessage() {}
 
 func (x *QueueDequeueRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_nitric_proto_queues_v1_queues_proto_msgTypes[2]
+	mi := &file_nitric_proto_queues_v1_queues_proto_msgTypes[9]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -169,7 +650,7 @@ func (x *QueueDequeueRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use QueueDequeueRequest.ProtoReflect.Descriptor instead.
 func (*QueueDequeueRequest) Descriptor() ([]byte, []int) {
-	return file_nitric_proto_queues_v1_queues_proto_rawDescGZIP(), []int{2}
+	return file_nitric_proto_queues_v1_queues_proto_rawDescGZIP(), []int{9}
 }
 
 func (x *QueueDequeueRequest) GetQueueName() string {
@@ -186,6 +667,13 @@ func (x *QueueDequeueRequest) GetDepth() int32 {
 	return 0
 }
 
+func (x *QueueDequeueRequest) GetVisibilityTimeout() *durationpb.Duration {
+	if x != nil {
+		return x.VisibilityTimeout
+	}
+	return nil
+}
+
 type QueueDequeueResponse struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
@@ -198,7 +686,7 @@ type QueueDequeueResponse struct {
 func (x *QueueDequeueResponse) Reset() {
 	*x = QueueDequeueResponse{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_nitric_proto_queues_v1_queues_proto_msgTypes[3]
+		mi := &file_nitric_proto_queues_v1_queues_proto_msgTypes[10]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -211,7 +699,7 @@ func (x *QueueDequeueResponse) String() string {
 func (*QueueDequeueResponse) ProtoMessage() {}
 
 func (x *QueueDequeueResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_nitric_proto_queues_v1_queues_proto_msgTypes[3]
+	mi := &file_nitric_proto_queues_v1_queues_proto_msgTypes[10]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -224,7 +712,7 @@ func (x *QueueDequeueResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use QueueDequeueResponse.ProtoReflect.Descriptor instead.
 func (*QueueDequeueResponse) Descriptor() ([]byte, []int) {
-	return file_nitric_proto_queues_v1_queues_proto_rawDescGZIP(), []int{3}
+	return file_nitric_proto_queues_v1_queues_proto_rawDescGZIP(), []int{10}
 }
 
 func (x *QueueDequeueResponse) GetMessages() []*DequeuedMessage {
@@ -234,7 +722,220 @@ func (x *QueueDequeueResponse) GetMessages() []*DequeuedMessage {
 	return nil
 }
 
-type QueueCompleteRequest struct {
+type QueueCompleteRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// The nitric name for the queue
+	//
+	//	this will automatically be resolved to the provider specific queue identifier.
+	QueueName string `protobuf:"bytes,1,opt,name=queue_name,json=queueName,proto3" json:"queue_name,omitempty"`
+	// Lease id of the message to be completed
+	LeaseId string `protobuf:"bytes,2,opt,name=lease_id,json=leaseId,proto3" json:"lease_id,omitempty"`
+}
+
+func (x *QueueCompleteRequest) Reset() {
+	*x = QueueCompleteRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_nitric_proto_queues_v1_queues_proto_msgTypes[11]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *QueueCompleteRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*QueueCompleteRequest) ProtoMessage() {}
+
+func (x *QueueCompleteRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_nitric_proto_queues_v1_queues_proto_msgTypes[11]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use QueueCompleteRequest.ProtoReflect.Descriptor instead.
+func (*QueueCompleteRequest) Descriptor() ([]byte, []int) {
+	return file_nitric_proto_queues_v1_queues_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *QueueCompleteRequest) GetQueueName() string {
+	if x != nil {
+		return x.QueueName
+	}
+	return ""
+}
+
+func (x *QueueCompleteRequest) GetLeaseId() string {
+	if x != nil {
+		return x.LeaseId
+	}
+	return ""
+}
+
+type QueueCompleteResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+}
+
+func (x *QueueCompleteResponse) Reset() {
+	*x = QueueCompleteResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_nitric_proto_queues_v1_queues_proto_msgTypes[12]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *QueueCompleteResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*QueueCompleteResponse) ProtoMessage() {}
+
+func (x *QueueCompleteResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_nitric_proto_queues_v1_queues_proto_msgTypes[12]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use QueueCompleteResponse.ProtoReflect.Descriptor instead.
+func (*QueueCompleteResponse) Descriptor() ([]byte, []int) {
+	return file_nitric_proto_queues_v1_queues_proto_rawDescGZIP(), []int{12}
+}
+
+type QueueExtendLeaseRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// The nitric name for the queue
+	//
+	//	this will automatically be resolved to the provider specific queue identifier.
+	QueueName string `protobuf:"bytes,1,opt,name=queue_name,json=queueName,proto3" json:"queue_name,omitempty"`
+	// Lease id of the message to extend the lease of
+	LeaseId string `protobuf:"bytes,2,opt,name=lease_id,json=leaseId,proto3" json:"lease_id,omitempty"`
+	// How long from now the message is leased for before it's redelivered
+	VisibilityTimeout *durationpb.Duration `protobuf:"bytes,3,opt,name=visibility_timeout,json=visibilityTimeout,proto3" json:"visibility_timeout,omitempty"`
+}
+
+func (x *QueueExtendLeaseRequest) Reset() {
+	*x = QueueExtendLeaseRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_nitric_proto_queues_v1_queues_proto_msgTypes[13]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *QueueExtendLeaseRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*QueueExtendLeaseRequest) ProtoMessage() {}
+
+func (x *QueueExtendLeaseRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_nitric_proto_queues_v1_queues_proto_msgTypes[13]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use QueueExtendLeaseRequest.ProtoReflect.Descriptor instead.
+func (*QueueExtendLeaseRequest) Descriptor() ([]byte, []int) {
+	return file_nitric_proto_queues_v1_queues_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *QueueExtendLeaseRequest) GetQueueName() string {
+	if x != nil {
+		return x.QueueName
+	}
+	return ""
+}
+
+func (x *QueueExtendLeaseRequest) GetLeaseId() string {
+	if x != nil {
+		return x.LeaseId
+	}
+	return ""
+}
+
+func (x *QueueExtendLeaseRequest) GetVisibilityTimeout() *durationpb.Duration {
+	if x != nil {
+		return x.VisibilityTimeout
+	}
+	return nil
+}
+
+type QueueExtendLeaseResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Lease id to use for further operations on the message, some providers issue a new lease id when it's extended
+	LeaseId string `protobuf:"bytes,1,opt,name=lease_id,json=leaseId,proto3" json:"lease_id,omitempty"`
+}
+
+func (x *QueueExtendLeaseResponse) Reset() {
+	*x = QueueExtendLeaseResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_nitric_proto_queues_v1_queues_proto_msgTypes[14]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *QueueExtendLeaseResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*QueueExtendLeaseResponse) ProtoMessage() {}
+
+func (x *QueueExtendLeaseResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_nitric_proto_queues_v1_queues_proto_msgTypes[14]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use QueueExtendLeaseResponse.ProtoReflect.Descriptor instead.
+func (*QueueExtendLeaseResponse) Descriptor() ([]byte, []int) {
+	return file_nitric_proto_queues_v1_queues_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *QueueExtendLeaseResponse) GetLeaseId() string {
+	if x != nil {
+		return x.LeaseId
+	}
+	return ""
+}
+
+type QueueReleaseRequest struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
@@ -243,27 +944,29 @@ type QueueCompleteRequest struct {
 	//
 	//	this will automatically be resolved to the provider specific queue identifier.
 	QueueName string `protobuf:"bytes,1,opt,name=queue_name,json=queueName,proto3" json:"queue_name,omitempty"`
-	// Lease id of the message to be completed
+	// Lease id of the message to release
 	LeaseId string `protobuf:"bytes,2,opt,name=lease_id,json=leaseId,proto3" json:"lease_id,omitempty"`
+	// How long to wait before the message is redelivered, it's redelivered immediately when not set
+	Delay *durationpb.Duration `protobuf:"bytes,3,opt,name=delay,proto3" json:"delay,omitempty"`
 }
 
-func (x *QueueCompleteRequest) Reset() {
-	*x = QueueCompleteRequest{}
+func (x *QueueReleaseRequest) Reset() {
+	*x = QueueReleaseRequest{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_nitric_proto_queues_v1_queues_proto_msgTypes[4]
+		mi := &file_nitric_proto_queues_v1_queues_proto_msgTypes[15]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *QueueCompleteRequest) String() string {
+func (x *QueueReleaseRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*QueueCompleteRequest) ProtoMessage() {}
+func (*QueueReleaseRequest) ProtoMessage() {}
 
-func (x *QueueCompleteRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_nitric_proto_queues_v1_queues_proto_msgTypes[4]
+func (x *QueueReleaseRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_nitric_proto_queues_v1_queues_proto_msgTypes[15]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -274,48 +977,55 @@ func (x *QueueCompleteRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use QueueCompleteRequest.ProtoReflect.Descriptor instead.
-func (*QueueCompleteRequest) Descriptor() ([]byte, []int) {
-	return file_nitric_proto_queues_v1_queues_proto_rawDescGZIP(), []int{4}
+// Deprecated: Use QueueReleaseRequest.ProtoReflect.Descriptor instead.
+func (*QueueReleaseRequest) Descriptor() ([]byte, []int) {
+	return file_nitric_proto_queues_v1_queues_proto_rawDescGZIP(), []int{15}
 }
 
-func (x *QueueCompleteRequest) GetQueueName() string {
+func (x *QueueReleaseRequest) GetQueueName() string {
 	if x != nil {
 		return x.QueueName
 	}
 	return ""
 }
 
-func (x *QueueCompleteRequest) GetLeaseId() string {
+func (x *QueueReleaseRequest) GetLeaseId() string {
 	if x != nil {
 		return x.LeaseId
 	}
 	return ""
 }
 
-type QueueCompleteResponse struct {
+func (x *QueueReleaseRequest) GetDelay() *durationpb.Duration {
+	if x != nil {
+		return x.Delay
+	}
+	return nil
+}
+
+type QueueReleaseResponse struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 }
 
-func (x *QueueCompleteResponse) Reset() {
-	*x = QueueCompleteResponse{}
+func (x *QueueReleaseResponse) Reset() {
+	*x = QueueReleaseResponse{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_nitric_proto_queues_v1_queues_proto_msgTypes[5]
+		mi := &file_nitric_proto_queues_v1_queues_proto_msgTypes[16]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *QueueCompleteResponse) String() string {
+func (x *QueueReleaseResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*QueueCompleteResponse) ProtoMessage() {}
+func (*QueueReleaseResponse) ProtoMessage() {}
 
-func (x *QueueCompleteResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_nitric_proto_queues_v1_queues_proto_msgTypes[5]
+func (x *QueueReleaseResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_nitric_proto_queues_v1_queues_proto_msgTypes[16]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -326,9 +1036,9 @@ func (x *QueueCompleteResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use QueueCompleteResponse.ProtoReflect.Descriptor instead.
-func (*QueueCompleteResponse) Descriptor() ([]byte, []int) {
-	return file_nitric_proto_queues_v1_queues_proto_rawDescGZIP(), []int{5}
+// Deprecated: Use QueueReleaseResponse.ProtoReflect.Descriptor instead.
+func (*QueueReleaseResponse) Descriptor() ([]byte, []int) {
+	return file_nitric_proto_queues_v1_queues_proto_rawDescGZIP(), []int{16}
 }
 
 // An message to be sent to a queue.
@@ -342,13 +1052,20 @@ type QueueMessage struct {
 	// Types that are assignable to Content:
 	//
 	//	*QueueMessage_StructPayload
+	//	*QueueMessage_BytesPayload
 	Content isQueueMessage_Content `protobuf_oneof:"content"`
+	// Key-value metadata delivered along with the message
+	Attributes map[string]string `protobuf:"bytes,2,rep,name=attributes,proto3" json:"attributes,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	// Messages with the same group key are delivered in the order they were enqueued, required by FIFO queues and not supported by other queues
+	GroupKey string `protobuf:"bytes,3,opt,name=group_key,json=groupKey,proto3" json:"group_key,omitempty"`
+	// Messages with the same deduplication id are only enqueued once within the provider's deduplication window, only supported by FIFO queues
+	DeduplicationId string `protobuf:"bytes,4,opt,name=deduplication_id,json=deduplicationId,proto3" json:"deduplication_id,omitempty"`
 }
 
 func (x *QueueMessage) Reset() {
 	*x = QueueMessage{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_nitric_proto_queues_v1_queues_proto_msgTypes[6]
+		mi := &file_nitric_proto_queues_v1_queues_proto_msgTypes[17]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -361,7 +1078,7 @@ func (x *QueueMessage) String() string {
 func (*QueueMessage) ProtoMessage() {}
 
 func (x *QueueMessage) ProtoReflect() protoreflect.Message {
-	mi := &file_nitric_proto_queues_v1_queues_proto_msgTypes[6]
+	mi := &file_nitric_proto_queues_v1_queues_proto_msgTypes[17]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -374,7 +1091,7 @@ func (x *QueueMessage) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use QueueMessage.ProtoReflect.Descriptor instead.
 func (*QueueMessage) Descriptor() ([]byte, []int) {
-	return file_nitric_proto_queues_v1_queues_proto_rawDescGZIP(), []int{6}
+	return file_nitric_proto_queues_v1_queues_proto_rawDescGZIP(), []int{17}
 }
 
 func (m *QueueMessage) GetContent() isQueueMessage_Content {
@@ -391,6 +1108,34 @@ func (x *QueueMessage) GetStructPayload() *structpb.Struct {
 	return nil
 }
 
+func (x *QueueMessage) GetBytesPayload() *BytesPayload {
+	if x, ok := x.GetContent().(*QueueMessage_BytesPayload); ok {
+		return x.BytesPayload
+	}
+	return nil
+}
+
+func (x *QueueMessage) GetAttributes() map[string]string {
+	if x != nil {
+		return x.Attributes
+	}
+	return nil
+}
+
+func (x *QueueMessage) GetGroupKey() string {
+	if x != nil {
+		return x.GroupKey
+	}
+	return ""
+}
+
+func (x *QueueMessage) GetDeduplicationId() string {
+	if x != nil {
+		return x.DeduplicationId
+	}
+	return ""
+}
+
 type isQueueMessage_Content interface {
 	isQueueMessage_Content()
 }
@@ -399,8 +1144,72 @@ type QueueMessage_StructPayload struct {
 	StructPayload *structpb.Struct `protobuf:"bytes,1,opt,name=struct_payload,json=structPayload,proto3,oneof"`
 }
 
+type QueueMessage_BytesPayload struct {
+	// field number matches nitric.proto.topics.v1.TopicMessage, so dead-lettered topic messages can be decoded as queue messages
+	BytesPayload *BytesPayload `protobuf:"bytes,5,opt,name=bytes_payload,json=bytesPayload,proto3,oneof"`
+}
+
 func (*QueueMessage_StructPayload) isQueueMessage_Content() {}
 
+func (*QueueMessage_BytesPayload) isQueueMessage_Content() {}
+
+// Raw message contents, for binary data or data that can't be represented as JSON without losing precision
+type BytesPayload struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Data []byte `protobuf:"bytes,1,opt,name=data,proto3" json:"data,omitempty"`
+	// The MIME type of the data, e.g. application/octet-stream
+	ContentType string `protobuf:"bytes,2,opt,name=content_type,json=contentType,proto3" json:"content_type,omitempty"`
+}
+
+func (x *BytesPayload) Reset() {
+	*x = BytesPayload{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_nitric_proto_queues_v1_queues_proto_msgTypes[18]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *BytesPayload) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BytesPayload) ProtoMessage() {}
+
+func (x *BytesPayload) ProtoReflect() protoreflect.Message {
+	mi := &file_nitric_proto_queues_v1_queues_proto_msgTypes[18]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BytesPayload.ProtoReflect.Descriptor instead.
+func (*BytesPayload) Descriptor() ([]byte, []int) {
+	return file_nitric_proto_queues_v1_queues_proto_rawDescGZIP(), []int{18}
+}
+
+func (x *BytesPayload) GetData() []byte {
+	if x != nil {
+		return x.Data
+	}
+	return nil
+}
+
+func (x *BytesPayload) GetContentType() string {
+	if x != nil {
+		return x.ContentType
+	}
+	return ""
+}
+
 type DequeuedMessage struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
@@ -408,12 +1217,14 @@ type DequeuedMessage struct {
 
 	LeaseId string        `protobuf:"bytes,1,opt,name=lease_id,json=leaseId,proto3" json:"lease_id,omitempty"`
 	Message *QueueMessage `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
+	// Number of times the message has been delivered, including this delivery, 0 when the provider can't determine it
+	DeliveryAttempt int32 `protobuf:"varint,3,opt,name=delivery_attempt,json=deliveryAttempt,proto3" json:"delivery_attempt,omitempty"`
 }
 
 func (x *DequeuedMessage) Reset() {
 	*x = DequeuedMessage{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_nitric_proto_queues_v1_queues_proto_msgTypes[7]
+		mi := &file_nitric_proto_queues_v1_queues_proto_msgTypes[19]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -426,7 +1237,7 @@ func (x *DequeuedMessage) String() string {
 func (*DequeuedMessage) ProtoMessage() {}
 
 func (x *DequeuedMessage) ProtoReflect() protoreflect.Message {
-	mi := &file_nitric_proto_queues_v1_queues_proto_msgTypes[7]
+	mi := &file_nitric_proto_queues_v1_queues_proto_msgTypes[19]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -439,7 +1250,7 @@ func (x *DequeuedMessage) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use DequeuedMessage.ProtoReflect.Descriptor instead.
 func (*DequeuedMessage) Descriptor() ([]byte, []int) {
-	return file_nitric_proto_queues_v1_queues_proto_rawDescGZIP(), []int{7}
+	return file_nitric_proto_queues_v1_queues_proto_rawDescGZIP(), []int{19}
 }
 
 func (x *DequeuedMessage) GetLeaseId() string {
@@ -456,6 +1267,13 @@ func (x *DequeuedMessage) GetMessage() *QueueMessage {
 	return nil
 }
 
+func (x *DequeuedMessage) GetDeliveryAttempt() int32 {
+	if x != nil {
+		return x.DeliveryAttempt
+	}
+	return 0
+}
+
 type FailedEnqueueMessage struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
@@ -470,7 +1288,7 @@ type FailedEnqueueMessage struct {
 func (x *FailedEnqueueMessage) Reset() {
 	*x = FailedEnqueueMessage{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_nitric_proto_queues_v1_queues_proto_msgTypes[8]
+		mi := &file_nitric_proto_queues_v1_queues_proto_msgTypes[20]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -483,7 +1301,7 @@ func (x *FailedEnqueueMessage) String() string {
 func (*FailedEnqueueMessage) ProtoMessage() {}
 
 func (x *FailedEnqueueMessage) ProtoReflect() protoreflect.Message {
-	mi := &file_nitric_proto_queues_v1_queues_proto_msgTypes[8]
+	mi := &file_nitric_proto_queues_v1_queues_proto_msgTypes[20]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -496,7 +1314,7 @@ func (x *FailedEnqueueMessage) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use FailedEnqueueMessage.ProtoReflect.Descriptor instead.
 func (*FailedEnqueueMessage) Descriptor() ([]byte, []int) {
-	return file_nitric_proto_queues_v1_queues_proto_rawDescGZIP(), []int{8}
+	return file_nitric_proto_queues_v1_queues_proto_rawDescGZIP(), []int{20}
 }
 
 func (x *FailedEnqueueMessage) GetMessage() *QueueMessage {
@@ -519,91 +1337,236 @@ var file_nitric_proto_queues_v1_queues_proto_rawDesc = []byte{
 	0x0a, 0x23, 0x6e, 0x69, 0x74, 0x72, 0x69, 0x63, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2f, 0x71,
 	0x75, 0x65, 0x75, 0x65, 0x73, 0x2f, 0x76, 0x31, 0x2f, 0x71, 0x75, 0x65, 0x75, 0x65, 0x73, 0x2e,
 	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x12, 0x16, 0x6e, 0x69, 0x74, 0x72, 0x69, 0x63, 0x2e, 0x70, 0x72,
-	0x6f, 0x74, 0x6f, 0x2e, 0x71, 0x75, 0x65, 0x75, 0x65, 0x73, 0x2e, 0x76, 0x31, 0x1a, 0x1c, 0x67,
+	0x6f, 0x74, 0x6f, 0x2e, 0x71, 0x75, 0x65, 0x75, 0x65, 0x73, 0x2e, 0x76, 0x31, 0x1a, 0x1e, 0x67,
+	0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2f, 0x64,
+	0x75, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x1a, 0x1c, 0x67,
 	0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2f, 0x73,
-	0x74, 0x72, 0x75, 0x63, 0x74, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x22, 0x76, 0x0a, 0x13, 0x51,
-	0x75, 0x65, 0x75, 0x65, 0x45, 0x6e, 0x71, 0x75, 0x65, 0x75, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65,
-	0x73, 0x74, 0x12, 0x1d, 0x0a, 0x0a, 0x71, 0x75, 0x65, 0x75, 0x65, 0x5f, 0x6e, 0x61, 0x6d, 0x65,
-	0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x71, 0x75, 0x65, 0x75, 0x65, 0x4e, 0x61, 0x6d,
-	0x65, 0x12, 0x40, 0x0a, 0x08, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x73, 0x18, 0x02, 0x20,
-	0x03, 0x28, 0x0b, 0x32, 0x24, 0x2e, 0x6e, 0x69, 0x74, 0x72, 0x69, 0x63, 0x2e, 0x70, 0x72, 0x6f,
-	0x74, 0x6f, 0x2e, 0x71, 0x75, 0x65, 0x75, 0x65, 0x73, 0x2e, 0x76, 0x31, 0x2e, 0x51, 0x75, 0x65,
-	0x75, 0x65, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x52, 0x08, 0x6d, 0x65, 0x73, 0x73, 0x61,
-	0x67, 0x65, 0x73, 0x22, 0x6d, 0x0a, 0x14, 0x51, 0x75, 0x65, 0x75, 0x65, 0x45, 0x6e, 0x71, 0x75,
-	0x65, 0x75, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x55, 0x0a, 0x0f, 0x66,
-	0x61, 0x69, 0x6c, 0x65, 0x64, 0x5f, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x73, 0x18, 0x01,
-	0x20, 0x03, 0x28, 0x0b, 0x32, 0x2c, 0x2e, 0x6e, 0x69, 0x74, 0x72, 0x69, 0x63, 0x2e, 0x70, 0x72,
-	0x6f, 0x74, 0x6f, 0x2e, 0x71, 0x75, 0x65, 0x75, 0x65, 0x73, 0x2e, 0x76, 0x31, 0x2e, 0x46, 0x61,
-	0x69, 0x6c, 0x65, 0x64, 0x45, 0x6e, 0x71, 0x75, 0x65, 0x75, 0x65, 0x4d, 0x65, 0x73, 0x73, 0x61,
-	0x67, 0x65, 0x52, 0x0e, 0x66, 0x61, 0x69, 0x6c, 0x65, 0x64, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67,
-	0x65, 0x73, 0x22, 0x4a, 0x0a, 0x13, 0x51, 0x75, 0x65, 0x75, 0x65, 0x44, 0x65, 0x71, 0x75, 0x65,
-	0x75, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x1d, 0x0a, 0x0a, 0x71, 0x75, 0x65,
-	0x75, 0x65, 0x5f, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x71,
-	0x75, 0x65, 0x75, 0x65, 0x4e, 0x61, 0x6d, 0x65, 0x12, 0x14, 0x0a, 0x05, 0x64, 0x65, 0x70, 0x74,
-	0x68, 0x18, 0x02, 0x20, 0x01, 0x28, 0x05, 0x52, 0x05, 0x64, 0x65, 0x70, 0x74, 0x68, 0x22, 0x5b,
-	0x0a, 0x14, 0x51, 0x75, 0x65, 0x75, 0x65, 0x44, 0x65, 0x71, 0x75, 0x65, 0x75, 0x65, 0x52, 0x65,
-	0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x43, 0x0a, 0x08, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67,
-	0x65, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x27, 0x2e, 0x6e, 0x69, 0x74, 0x72, 0x69,
+	0x74, 0x72, 0x75, 0x63, 0x74, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x22, 0xe2, 0x01, 0x0a, 0x0d,
+	0x43, 0x6c, 0x69, 0x65, 0x6e, 0x74, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x12, 0x0e, 0x0a,
+	0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x02, 0x69, 0x64, 0x12, 0x60, 0x0a,
+	0x14, 0x72, 0x65, 0x67, 0x69, 0x73, 0x74, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x5f, 0x72, 0x65,
+	0x71, 0x75, 0x65, 0x73, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x2b, 0x2e, 0x6e, 0x69,
+	0x74, 0x72, 0x69, 0x63, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x71, 0x75, 0x65, 0x75, 0x65,
+	0x73, 0x2e, 0x76, 0x31, 0x2e, 0x52, 0x65, 0x67, 0x69, 0x73, 0x74, 0x72, 0x61, 0x74, 0x69, 0x6f,
+	0x6e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x48, 0x00, 0x52, 0x13, 0x72, 0x65, 0x67, 0x69,
+	0x73, 0x74, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12,
+	0x54, 0x0a, 0x10, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x5f, 0x72, 0x65, 0x73, 0x70, 0x6f,
+	0x6e, 0x73, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x27, 0x2e, 0x6e, 0x69, 0x74, 0x72,
+	0x69, 0x63, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x71, 0x75, 0x65, 0x75, 0x65, 0x73, 0x2e,
+	0x76, 0x31, 0x2e, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e,
+	0x73, 0x65, 0x48, 0x00, 0x52, 0x0f, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x52, 0x65, 0x73,
+	0x70, 0x6f, 0x6e, 0x73, 0x65, 0x42, 0x09, 0x0a, 0x07, 0x63, 0x6f, 0x6e, 0x74, 0x65, 0x6e, 0x74,
+	0x22, 0xe3, 0x03, 0x0a, 0x0d, 0x53, 0x65, 0x72, 0x76, 0x65, 0x72, 0x4d, 0x65, 0x73, 0x73, 0x61,
+	0x67, 0x65, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x02,
+	0x69, 0x64, 0x12, 0x63, 0x0a, 0x15, 0x72, 0x65, 0x67, 0x69, 0x73, 0x74, 0x72, 0x61, 0x74, 0x69,
+	0x6f, 0x6e, 0x5f, 0x72, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28,
+	0x0b, 0x32, 0x2c, 0x2e, 0x6e, 0x69, 0x74, 0x72, 0x69, 0x63, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f,
+	0x2e, 0x71, 0x75, 0x65, 0x75, 0x65, 0x73, 0x2e, 0x76, 0x31, 0x2e, 0x52, 0x65, 0x67, 0x69, 0x73,
+	0x74, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x48,
+	0x00, 0x52, 0x14, 0x72, 0x65, 0x67, 0x69, 0x73, 0x74, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52,
+	0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x51, 0x0a, 0x0f, 0x6d, 0x65, 0x73, 0x73, 0x61,
+	0x67, 0x65, 0x5f, 0x72, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b,
+	0x32, 0x26, 0x2e, 0x6e, 0x69, 0x74, 0x72, 0x69, 0x63, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e,
+	0x71, 0x75, 0x65, 0x75, 0x65, 0x73, 0x2e, 0x76, 0x31, 0x2e, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67,
+	0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x48, 0x00, 0x52, 0x0e, 0x6d, 0x65, 0x73, 0x73,
+	0x61, 0x67, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x60, 0x0a, 0x14, 0x63, 0x61,
+	0x6e, 0x63, 0x65, 0x6c, 0x6c, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x5f, 0x72, 0x65, 0x71, 0x75, 0x65,
+	0x73, 0x74, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x2b, 0x2e, 0x6e, 0x69, 0x74, 0x72, 0x69,
 	0x63, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x71, 0x75, 0x65, 0x75, 0x65, 0x73, 0x2e, 0x76,
-	0x31, 0x2e, 0x44, 0x65, 0x71, 0x75, 0x65, 0x75, 0x65, 0x64, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67,
-	0x65, 0x52, 0x08, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x73, 0x22, 0x50, 0x0a, 0x14, 0x51,
-	0x75, 0x65, 0x75, 0x65, 0x43, 0x6f, 0x6d, 0x70, 0x6c, 0x65, 0x74, 0x65, 0x52, 0x65, 0x71, 0x75,
+	0x31, 0x2e, 0x43, 0x61, 0x6e, 0x63, 0x65, 0x6c, 0x6c, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x65,
+	0x71, 0x75, 0x65, 0x73, 0x74, 0x48, 0x00, 0x52, 0x13, 0x63, 0x61, 0x6e, 0x63, 0x65, 0x6c, 0x6c,
+	0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x5c, 0x0a, 0x0d,
+	0x74, 0x72, 0x61, 0x63, 0x65, 0x5f, 0x63, 0x6f, 0x6e, 0x74, 0x65, 0x78, 0x74, 0x18, 0x0a, 0x20,
+	0x03, 0x28, 0x0b, 0x32, 0x37, 0x2e, 0x6e, 0x69, 0x74, 0x72, 0x69, 0x63, 0x2e, 0x70, 0x72, 0x6f,
+	0x74, 0x6f, 0x2e, 0x71, 0x75, 0x65, 0x75, 0x65, 0x73, 0x2e, 0x76, 0x31, 0x2e, 0x53, 0x65, 0x72,
+	0x76, 0x65, 0x72, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x2e, 0x54, 0x72, 0x61, 0x63, 0x65,
+	0x43, 0x6f, 0x6e, 0x74, 0x65, 0x78, 0x74, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x52, 0x0c, 0x74, 0x72,
+	0x61, 0x63, 0x65, 0x43, 0x6f, 0x6e, 0x74, 0x65, 0x78, 0x74, 0x1a, 0x3f, 0x0a, 0x11, 0x54, 0x72,
+	0x61, 0x63, 0x65, 0x43, 0x6f, 0x6e, 0x74, 0x65, 0x78, 0x74, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12,
+	0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6b, 0x65,
+	0x79, 0x12, 0x14, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38, 0x01, 0x42, 0x09, 0x0a, 0x07, 0x63,
+	0x6f, 0x6e, 0x74, 0x65, 0x6e, 0x74, 0x22, 0x2d, 0x0a, 0x13, 0x43, 0x61, 0x6e, 0x63, 0x65, 0x6c,
+	0x6c, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x16, 0x0a,
+	0x06, 0x72, 0x65, 0x61, 0x73, 0x6f, 0x6e, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x72,
+	0x65, 0x61, 0x73, 0x6f, 0x6e, 0x22, 0x75, 0x0a, 0x13, 0x52, 0x65, 0x67, 0x69, 0x73, 0x74, 0x72,
+	0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x1d, 0x0a, 0x0a,
+	0x71, 0x75, 0x65, 0x75, 0x65, 0x5f, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x09, 0x71, 0x75, 0x65, 0x75, 0x65, 0x4e, 0x61, 0x6d, 0x65, 0x12, 0x1d, 0x0a, 0x0a, 0x62,
+	0x61, 0x74, 0x63, 0x68, 0x5f, 0x73, 0x69, 0x7a, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x05, 0x52,
+	0x09, 0x62, 0x61, 0x74, 0x63, 0x68, 0x53, 0x69, 0x7a, 0x65, 0x12, 0x20, 0x0a, 0x0b, 0x63, 0x6f,
+	0x6e, 0x63, 0x75, 0x72, 0x72, 0x65, 0x6e, 0x63, 0x79, 0x18, 0x03, 0x20, 0x01, 0x28, 0x05, 0x52,
+	0x0b, 0x63, 0x6f, 0x6e, 0x63, 0x75, 0x72, 0x72, 0x65, 0x6e, 0x63, 0x79, 0x22, 0x16, 0x0a, 0x14,
+	0x52, 0x65, 0x67, 0x69, 0x73, 0x74, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x65, 0x73, 0x70,
+	0x6f, 0x6e, 0x73, 0x65, 0x22, 0x9a, 0x01, 0x0a, 0x0e, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65,
+	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x1d, 0x0a, 0x0a, 0x71, 0x75, 0x65, 0x75, 0x65,
+	0x5f, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x71, 0x75, 0x65,
+	0x75, 0x65, 0x4e, 0x61, 0x6d, 0x65, 0x12, 0x3e, 0x0a, 0x07, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67,
+	0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x24, 0x2e, 0x6e, 0x69, 0x74, 0x72, 0x69, 0x63,
+	0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x71, 0x75, 0x65, 0x75, 0x65, 0x73, 0x2e, 0x76, 0x31,
+	0x2e, 0x51, 0x75, 0x65, 0x75, 0x65, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x52, 0x07, 0x6d,
+	0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x12, 0x29, 0x0a, 0x10, 0x64, 0x65, 0x6c, 0x69, 0x76, 0x65,
+	0x72, 0x79, 0x5f, 0x61, 0x74, 0x74, 0x65, 0x6d, 0x70, 0x74, 0x18, 0x03, 0x20, 0x01, 0x28, 0x05,
+	0x52, 0x0f, 0x64, 0x65, 0x6c, 0x69, 0x76, 0x65, 0x72, 0x79, 0x41, 0x74, 0x74, 0x65, 0x6d, 0x70,
+	0x74, 0x22, 0x2b, 0x0a, 0x0f, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x52, 0x65, 0x73, 0x70,
+	0x6f, 0x6e, 0x73, 0x65, 0x12, 0x18, 0x0a, 0x07, 0x73, 0x75, 0x63, 0x63, 0x65, 0x73, 0x73, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x08, 0x52, 0x07, 0x73, 0x75, 0x63, 0x63, 0x65, 0x73, 0x73, 0x22, 0x76,
+	0x0a, 0x13, 0x51, 0x75, 0x65, 0x75, 0x65, 0x45, 0x6e, 0x71, 0x75, 0x65, 0x75, 0x65, 0x52, 0x65,
+	0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x1d, 0x0a, 0x0a, 0x71, 0x75, 0x65, 0x75, 0x65, 0x5f, 0x6e,
+	0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x71, 0x75, 0x65, 0x75, 0x65,
+	0x4e, 0x61, 0x6d, 0x65, 0x12, 0x40, 0x0a, 0x08, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x73,
+	0x18, 0x02, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x24, 0x2e, 0x6e, 0x69, 0x74, 0x72, 0x69, 0x63, 0x2e,
+	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x71, 0x75, 0x65, 0x75, 0x65, 0x73, 0x2e, 0x76, 0x31, 0x2e,
+	0x51, 0x75, 0x65, 0x75, 0x65, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x52, 0x08, 0x6d, 0x65,
+	0x73, 0x73, 0x61, 0x67, 0x65, 0x73, 0x22, 0x6d, 0x0a, 0x14, 0x51, 0x75, 0x65, 0x75, 0x65, 0x45,
+	0x6e, 0x71, 0x75, 0x65, 0x75, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x55,
+	0x0a, 0x0f, 0x66, 0x61, 0x69, 0x6c, 0x65, 0x64, 0x5f, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65,
+	0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x2c, 0x2e, 0x6e, 0x69, 0x74, 0x72, 0x69, 0x63,
+	0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x71, 0x75, 0x65, 0x75, 0x65, 0x73, 0x2e, 0x76, 0x31,
+	0x2e, 0x46, 0x61, 0x69, 0x6c, 0x65, 0x64, 0x45, 0x6e, 0x71, 0x75, 0x65, 0x75, 0x65, 0x4d, 0x65,
+	0x73, 0x73, 0x61, 0x67, 0x65, 0x52, 0x0e, 0x66, 0x61, 0x69, 0x6c, 0x65, 0x64, 0x4d, 0x65, 0x73,
+	0x73, 0x61, 0x67, 0x65, 0x73, 0x22, 0x94, 0x01, 0x0a, 0x13, 0x51, 0x75, 0x65, 0x75, 0x65, 0x44,
+	0x65, 0x71, 0x75, 0x65, 0x75, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x1d, 0x0a,
+	0x0a, 0x71, 0x75, 0x65, 0x75, 0x65, 0x5f, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x09, 0x71, 0x75, 0x65, 0x75, 0x65, 0x4e, 0x61, 0x6d, 0x65, 0x12, 0x14, 0x0a, 0x05,
+	0x64, 0x65, 0x70, 0x74, 0x68, 0x18, 0x02, 0x20, 0x01, 0x28, 0x05, 0x52, 0x05, 0x64, 0x65, 0x70,
+	0x74, 0x68, 0x12, 0x48, 0x0a, 0x12, 0x76, 0x69, 0x73, 0x69, 0x62, 0x69, 0x6c, 0x69, 0x74, 0x79,
+	0x5f, 0x74, 0x69, 0x6d, 0x65, 0x6f, 0x75, 0x74, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x19,
+	0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66,
+	0x2e, 0x44, 0x75, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x11, 0x76, 0x69, 0x73, 0x69, 0x62,
+	0x69, 0x6c, 0x69, 0x74, 0x79, 0x54, 0x69, 0x6d, 0x65, 0x6f, 0x75, 0x74, 0x22, 0x5b, 0x0a, 0x14,
+	0x51, 0x75, 0x65, 0x75, 0x65, 0x44, 0x65, 0x71, 0x75, 0x65, 0x75, 0x65, 0x52, 0x65, 0x73, 0x70,
+	0x6f, 0x6e, 0x73, 0x65, 0x12, 0x43, 0x0a, 0x08, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x73,
+	0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x27, 0x2e, 0x6e, 0x69, 0x74, 0x72, 0x69, 0x63, 0x2e,
+	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x71, 0x75, 0x65, 0x75, 0x65, 0x73, 0x2e, 0x76, 0x31, 0x2e,
+	0x44, 0x65, 0x71, 0x75, 0x65, 0x75, 0x65, 0x64, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x52,
+	0x08, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x73, 0x22, 0x50, 0x0a, 0x14, 0x51, 0x75, 0x65,
+	0x75, 0x65, 0x43, 0x6f, 0x6d, 0x70, 0x6c, 0x65, 0x74, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
+	0x74, 0x12, 0x1d, 0x0a, 0x0a, 0x71, 0x75, 0x65, 0x75, 0x65, 0x5f, 0x6e, 0x61, 0x6d, 0x65, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x71, 0x75, 0x65, 0x75, 0x65, 0x4e, 0x61, 0x6d, 0x65,
+	0x12, 0x19, 0x0a, 0x08, 0x6c, 0x65, 0x61, 0x73, 0x65, 0x5f, 0x69, 0x64, 0x18, 0x02, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x07, 0x6c, 0x65, 0x61, 0x73, 0x65, 0x49, 0x64, 0x22, 0x17, 0x0a, 0x15, 0x51,
+	0x75, 0x65, 0x75, 0x65, 0x43, 0x6f, 0x6d, 0x70, 0x6c, 0x65, 0x74, 0x65, 0x52, 0x65, 0x73, 0x70,
+	0x6f, 0x6e, 0x73, 0x65, 0x22, 0x9d, 0x01, 0x0a, 0x17, 0x51, 0x75, 0x65, 0x75, 0x65, 0x45, 0x78,
+	0x74, 0x65, 0x6e, 0x64, 0x4c, 0x65, 0x61, 0x73, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
+	0x12, 0x1d, 0x0a, 0x0a, 0x71, 0x75, 0x65, 0x75, 0x65, 0x5f, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x71, 0x75, 0x65, 0x75, 0x65, 0x4e, 0x61, 0x6d, 0x65, 0x12,
+	0x19, 0x0a, 0x08, 0x6c, 0x65, 0x61, 0x73, 0x65, 0x5f, 0x69, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x07, 0x6c, 0x65, 0x61, 0x73, 0x65, 0x49, 0x64, 0x12, 0x48, 0x0a, 0x12, 0x76, 0x69,
+	0x73, 0x69, 0x62, 0x69, 0x6c, 0x69, 0x74, 0x79, 0x5f, 0x74, 0x69, 0x6d, 0x65, 0x6f, 0x75, 0x74,
+	0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x19, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e,
+	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x44, 0x75, 0x72, 0x61, 0x74, 0x69, 0x6f,
+	0x6e, 0x52, 0x11, 0x76, 0x69, 0x73, 0x69, 0x62, 0x69, 0x6c, 0x69, 0x74, 0x79, 0x54, 0x69, 0x6d,
+	0x65, 0x6f, 0x75, 0x74, 0x22, 0x35, 0x0a, 0x18, 0x51, 0x75, 0x65, 0x75, 0x65, 0x45, 0x78, 0x74,
+	0x65, 0x6e, 0x64, 0x4c, 0x65, 0x61, 0x73, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65,
+	0x12, 0x19, 0x0a, 0x08, 0x6c, 0x65, 0x61, 0x73, 0x65, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x07, 0x6c, 0x65, 0x61, 0x73, 0x65, 0x49, 0x64, 0x22, 0x80, 0x01, 0x0a, 0x13,
+	0x51, 0x75, 0x65, 0x75, 0x65, 0x52, 0x65, 0x6c, 0x65, 0x61, 0x73, 0x65, 0x52, 0x65, 0x71, 0x75,
 	0x65, 0x73, 0x74, 0x12, 0x1d, 0x0a, 0x0a, 0x71, 0x75, 0x65, 0x75, 0x65, 0x5f, 0x6e, 0x61, 0x6d,
 	0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x71, 0x75, 0x65, 0x75, 0x65, 0x4e, 0x61,
 	0x6d, 0x65, 0x12, 0x19, 0x0a, 0x08, 0x6c, 0x65, 0x61, 0x73, 0x65, 0x5f, 0x69, 0x64, 0x18, 0x02,
-	0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x6c, 0x65, 0x61, 0x73, 0x65, 0x49, 0x64, 0x22, 0x17, 0x0a,
-	0x15, 0x51, 0x75, 0x65, 0x75, 0x65, 0x43, 0x6f, 0x6d, 0x70, 0x6c, 0x65, 0x74, 0x65, 0x52, 0x65,
-	0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x5b, 0x0a, 0x0c, 0x51, 0x75, 0x65, 0x75, 0x65, 0x4d,
-	0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x12, 0x40, 0x0a, 0x0e, 0x73, 0x74, 0x72, 0x75, 0x63, 0x74,
-	0x5f, 0x70, 0x61, 0x79, 0x6c, 0x6f, 0x61, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x17,
-	0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66,
-	0x2e, 0x53, 0x74, 0x72, 0x75, 0x63, 0x74, 0x48, 0x00, 0x52, 0x0d, 0x73, 0x74, 0x72, 0x75, 0x63,
-	0x74, 0x50, 0x61, 0x79, 0x6c, 0x6f, 0x61, 0x64, 0x42, 0x09, 0x0a, 0x07, 0x63, 0x6f, 0x6e, 0x74,
-	0x65, 0x6e, 0x74, 0x22, 0x6c, 0x0a, 0x0f, 0x44, 0x65, 0x71, 0x75, 0x65, 0x75, 0x65, 0x64, 0x4d,
-	0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x12, 0x19, 0x0a, 0x08, 0x6c, 0x65, 0x61, 0x73, 0x65, 0x5f,
-	0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x6c, 0x65, 0x61, 0x73, 0x65, 0x49,
-	0x64, 0x12, 0x3e, 0x0a, 0x07, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x18, 0x02, 0x20, 0x01,
-	0x28, 0x0b, 0x32, 0x24, 0x2e, 0x6e, 0x69, 0x74, 0x72, 0x69, 0x63, 0x2e, 0x70, 0x72, 0x6f, 0x74,
-	0x6f, 0x2e, 0x71, 0x75, 0x65, 0x75, 0x65, 0x73, 0x2e, 0x76, 0x31, 0x2e, 0x51, 0x75, 0x65, 0x75,
-	0x65, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x52, 0x07, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67,
-	0x65, 0x22, 0x70, 0x0a, 0x14, 0x46, 0x61, 0x69, 0x6c, 0x65, 0x64, 0x45, 0x6e, 0x71, 0x75, 0x65,
-	0x75, 0x65, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x12, 0x3e, 0x0a, 0x07, 0x6d, 0x65, 0x73,
-	0x73, 0x61, 0x67, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x24, 0x2e, 0x6e, 0x69, 0x74,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x6c, 0x65, 0x61, 0x73, 0x65, 0x49, 0x64, 0x12, 0x2f, 0x0a,
+	0x05, 0x64, 0x65, 0x6c, 0x61, 0x79, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x19, 0x2e, 0x67,
+	0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x44,
+	0x75, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x05, 0x64, 0x65, 0x6c, 0x61, 0x79, 0x22, 0x16,
+	0x0a, 0x14, 0x51, 0x75, 0x65, 0x75, 0x65, 0x52, 0x65, 0x6c, 0x65, 0x61, 0x73, 0x65, 0x52, 0x65,
+	0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x85, 0x03, 0x0a, 0x0c, 0x51, 0x75, 0x65, 0x75, 0x65,
+	0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x12, 0x40, 0x0a, 0x0e, 0x73, 0x74, 0x72, 0x75, 0x63,
+	0x74, 0x5f, 0x70, 0x61, 0x79, 0x6c, 0x6f, 0x61, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32,
+	0x17, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75,
+	0x66, 0x2e, 0x53, 0x74, 0x72, 0x75, 0x63, 0x74, 0x48, 0x00, 0x52, 0x0d, 0x73, 0x74, 0x72, 0x75,
+	0x63, 0x74, 0x50, 0x61, 0x79, 0x6c, 0x6f, 0x61, 0x64, 0x12, 0x4b, 0x0a, 0x0d, 0x62, 0x79, 0x74,
+	0x65, 0x73, 0x5f, 0x70, 0x61, 0x79, 0x6c, 0x6f, 0x61, 0x64, 0x18, 0x05, 0x20, 0x01, 0x28, 0x0b,
+	0x32, 0x24, 0x2e, 0x6e, 0x69, 0x74, 0x72, 0x69, 0x63, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e,
+	0x71, 0x75, 0x65, 0x75, 0x65, 0x73, 0x2e, 0x76, 0x31, 0x2e, 0x42, 0x79, 0x74, 0x65, 0x73, 0x50,
+	0x61, 0x79, 0x6c, 0x6f, 0x61, 0x64, 0x48, 0x00, 0x52, 0x0c, 0x62, 0x79, 0x74, 0x65, 0x73, 0x50,
+	0x61, 0x79, 0x6c, 0x6f, 0x61, 0x64, 0x12, 0x54, 0x0a, 0x0a, 0x61, 0x74, 0x74, 0x72, 0x69, 0x62,
+	0x75, 0x74, 0x65, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x34, 0x2e, 0x6e, 0x69, 0x74,
 	0x72, 0x69, 0x63, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x71, 0x75, 0x65, 0x75, 0x65, 0x73,
 	0x2e, 0x76, 0x31, 0x2e, 0x51, 0x75, 0x65, 0x75, 0x65, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65,
-	0x52, 0x07, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x12, 0x18, 0x0a, 0x07, 0x64, 0x65, 0x74,
-	0x61, 0x69, 0x6c, 0x73, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x64, 0x65, 0x74, 0x61,
-	0x69, 0x6c, 0x73, 0x32, 0xbd, 0x02, 0x0a, 0x06, 0x51, 0x75, 0x65, 0x75, 0x65, 0x73, 0x12, 0x64,
-	0x0a, 0x07, 0x45, 0x6e, 0x71, 0x75, 0x65, 0x75, 0x65, 0x12, 0x2b, 0x2e, 0x6e, 0x69, 0x74, 0x72,
-	0x69, 0x63, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x71, 0x75, 0x65, 0x75, 0x65, 0x73, 0x2e,
-	0x76, 0x31, 0x2e, 0x51, 0x75, 0x65, 0x75, 0x65, 0x45, 0x6e, 0x71, 0x75, 0x65, 0x75, 0x65, 0x52,
-	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x2c, 0x2e, 0x6e, 0x69, 0x74, 0x72, 0x69, 0x63, 0x2e,
-	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x71, 0x75, 0x65, 0x75, 0x65, 0x73, 0x2e, 0x76, 0x31, 0x2e,
-	0x51, 0x75, 0x65, 0x75, 0x65, 0x45, 0x6e, 0x71, 0x75, 0x65, 0x75, 0x65, 0x52, 0x65, 0x73, 0x70,
-	0x6f, 0x6e, 0x73, 0x65, 0x12, 0x64, 0x0a, 0x07, 0x44, 0x65, 0x71, 0x75, 0x65, 0x75, 0x65, 0x12,
-	0x2b, 0x2e, 0x6e, 0x69, 0x74, 0x72, 0x69, 0x63, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x71,
-	0x75, 0x65, 0x75, 0x65, 0x73, 0x2e, 0x76, 0x31, 0x2e, 0x51, 0x75, 0x65, 0x75, 0x65, 0x44, 0x65,
-	0x71, 0x75, 0x65, 0x75, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x2c, 0x2e, 0x6e,
-	0x69, 0x74, 0x72, 0x69, 0x63, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x71, 0x75, 0x65, 0x75,
-	0x65, 0x73, 0x2e, 0x76, 0x31, 0x2e, 0x51, 0x75, 0x65, 0x75, 0x65, 0x44, 0x65, 0x71, 0x75, 0x65,
-	0x75, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x67, 0x0a, 0x08, 0x43, 0x6f,
-	0x6d, 0x70, 0x6c, 0x65, 0x74, 0x65, 0x12, 0x2c, 0x2e, 0x6e, 0x69, 0x74, 0x72, 0x69, 0x63, 0x2e,
-	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x71, 0x75, 0x65, 0x75, 0x65, 0x73, 0x2e, 0x76, 0x31, 0x2e,
-	0x51, 0x75, 0x65, 0x75, 0x65, 0x43, 0x6f, 0x6d, 0x70, 0x6c, 0x65, 0x74, 0x65, 0x52, 0x65, 0x71,
-	0x75, 0x65, 0x73, 0x74, 0x1a, 0x2d, 0x2e, 0x6e, 0x69, 0x74, 0x72, 0x69, 0x63, 0x2e, 0x70, 0x72,
-	0x6f, 0x74, 0x6f, 0x2e, 0x71, 0x75, 0x65, 0x75, 0x65, 0x73, 0x2e, 0x76, 0x31, 0x2e, 0x51, 0x75,
-	0x65, 0x75, 0x65, 0x43, 0x6f, 0x6d, 0x70, 0x6c, 0x65, 0x74, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f,
-	0x6e, 0x73, 0x65, 0x42, 0x9e, 0x01, 0x0a, 0x19, 0x69, 0x6f, 0x2e, 0x6e, 0x69, 0x74, 0x72, 0x69,
+	0x2e, 0x41, 0x74, 0x74, 0x72, 0x69, 0x62, 0x75, 0x74, 0x65, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79,
+	0x52, 0x0a, 0x61, 0x74, 0x74, 0x72, 0x69, 0x62, 0x75, 0x74, 0x65, 0x73, 0x12, 0x1b, 0x0a, 0x09,
+	0x67, 0x72, 0x6f, 0x75, 0x70, 0x5f, 0x6b, 0x65, 0x79, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x08, 0x67, 0x72, 0x6f, 0x75, 0x70, 0x4b, 0x65, 0x79, 0x12, 0x29, 0x0a, 0x10, 0x64, 0x65, 0x64,
+	0x75, 0x70, 0x6c, 0x69, 0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x5f, 0x69, 0x64, 0x18, 0x04, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x0f, 0x64, 0x65, 0x64, 0x75, 0x70, 0x6c, 0x69, 0x63, 0x61, 0x74, 0x69,
+	0x6f, 0x6e, 0x49, 0x64, 0x1a, 0x3d, 0x0a, 0x0f, 0x41, 0x74, 0x74, 0x72, 0x69, 0x62, 0x75, 0x74,
+	0x65, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x14, 0x0a, 0x05, 0x76, 0x61, 0x6c,
+	0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x3a,
+	0x02, 0x38, 0x01, 0x42, 0x09, 0x0a, 0x07, 0x63, 0x6f, 0x6e, 0x74, 0x65, 0x6e, 0x74, 0x22, 0x45,
+	0x0a, 0x0c, 0x42, 0x79, 0x74, 0x65, 0x73, 0x50, 0x61, 0x79, 0x6c, 0x6f, 0x61, 0x64, 0x12, 0x12,
+	0x0a, 0x04, 0x64, 0x61, 0x74, 0x61, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x04, 0x64, 0x61,
+	0x74, 0x61, 0x12, 0x21, 0x0a, 0x0c, 0x63, 0x6f, 0x6e, 0x74, 0x65, 0x6e, 0x74, 0x5f, 0x74, 0x79,
+	0x70, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0b, 0x63, 0x6f, 0x6e, 0x74, 0x65, 0x6e,
+	0x74, 0x54, 0x79, 0x70, 0x65, 0x22, 0x97, 0x01, 0x0a, 0x0f, 0x44, 0x65, 0x71, 0x75, 0x65, 0x75,
+	0x65, 0x64, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x12, 0x19, 0x0a, 0x08, 0x6c, 0x65, 0x61,
+	0x73, 0x65, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x6c, 0x65, 0x61,
+	0x73, 0x65, 0x49, 0x64, 0x12, 0x3e, 0x0a, 0x07, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x18,
+	0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x24, 0x2e, 0x6e, 0x69, 0x74, 0x72, 0x69, 0x63, 0x2e, 0x70,
+	0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x71, 0x75, 0x65, 0x75, 0x65, 0x73, 0x2e, 0x76, 0x31, 0x2e, 0x51,
+	0x75, 0x65, 0x75, 0x65, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x52, 0x07, 0x6d, 0x65, 0x73,
+	0x73, 0x61, 0x67, 0x65, 0x12, 0x29, 0x0a, 0x10, 0x64, 0x65, 0x6c, 0x69, 0x76, 0x65, 0x72, 0x79,
+	0x5f, 0x61, 0x74, 0x74, 0x65, 0x6d, 0x70, 0x74, 0x18, 0x03, 0x20, 0x01, 0x28, 0x05, 0x52, 0x0f,
+	0x64, 0x65, 0x6c, 0x69, 0x76, 0x65, 0x72, 0x79, 0x41, 0x74, 0x74, 0x65, 0x6d, 0x70, 0x74, 0x22,
+	0x70, 0x0a, 0x14, 0x46, 0x61, 0x69, 0x6c, 0x65, 0x64, 0x45, 0x6e, 0x71, 0x75, 0x65, 0x75, 0x65,
+	0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x12, 0x3e, 0x0a, 0x07, 0x6d, 0x65, 0x73, 0x73, 0x61,
+	0x67, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x24, 0x2e, 0x6e, 0x69, 0x74, 0x72, 0x69,
 	0x63, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x71, 0x75, 0x65, 0x75, 0x65, 0x73, 0x2e, 0x76,
-	0x31, 0x42, 0x0d, 0x51, 0x75, 0x65, 0x75, 0x65, 0x73, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65,
-	0x50, 0x01, 0x5a, 0x3e, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x6e,
-	0x69, 0x74, 0x72, 0x69, 0x63, 0x74, 0x65, 0x63, 0x68, 0x2f, 0x6e, 0x69, 0x74, 0x72, 0x69, 0x63,
-	0x2f, 0x63, 0x6f, 0x72, 0x65, 0x2f, 0x70, 0x6b, 0x67, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2f,
-	0x71, 0x75, 0x65, 0x75, 0x65, 0x73, 0x2f, 0x76, 0x31, 0x3b, 0x71, 0x75, 0x65, 0x75, 0x65, 0x73,
-	0x70, 0x62, 0xaa, 0x02, 0x16, 0x4e, 0x69, 0x74, 0x72, 0x69, 0x63, 0x2e, 0x50, 0x72, 0x6f, 0x74,
-	0x6f, 0x2e, 0x51, 0x75, 0x65, 0x75, 0x65, 0x73, 0x2e, 0x76, 0x31, 0xca, 0x02, 0x16, 0x4e, 0x69,
-	0x74, 0x72, 0x69, 0x63, 0x5c, 0x50, 0x72, 0x6f, 0x74, 0x6f, 0x5c, 0x51, 0x75, 0x65, 0x75, 0x65,
-	0x73, 0x5c, 0x56, 0x31, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+	0x31, 0x2e, 0x51, 0x75, 0x65, 0x75, 0x65, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x52, 0x07,
+	0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x12, 0x18, 0x0a, 0x07, 0x64, 0x65, 0x74, 0x61, 0x69,
+	0x6c, 0x73, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x64, 0x65, 0x74, 0x61, 0x69, 0x6c,
+	0x73, 0x32, 0x95, 0x04, 0x0a, 0x06, 0x51, 0x75, 0x65, 0x75, 0x65, 0x73, 0x12, 0x64, 0x0a, 0x07,
+	0x45, 0x6e, 0x71, 0x75, 0x65, 0x75, 0x65, 0x12, 0x2b, 0x2e, 0x6e, 0x69, 0x74, 0x72, 0x69, 0x63,
+	0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x71, 0x75, 0x65, 0x75, 0x65, 0x73, 0x2e, 0x76, 0x31,
+	0x2e, 0x51, 0x75, 0x65, 0x75, 0x65, 0x45, 0x6e, 0x71, 0x75, 0x65, 0x75, 0x65, 0x52, 0x65, 0x71,
+	0x75, 0x65, 0x73, 0x74, 0x1a, 0x2c, 0x2e, 0x6e, 0x69, 0x74, 0x72, 0x69, 0x63, 0x2e, 0x70, 0x72,
+	0x6f, 0x74, 0x6f, 0x2e, 0x71, 0x75, 0x65, 0x75, 0x65, 0x73, 0x2e, 0x76, 0x31, 0x2e, 0x51, 0x75,
+	0x65, 0x75, 0x65, 0x45, 0x6e, 0x71, 0x75, 0x65, 0x75, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e,
+	0x73, 0x65, 0x12, 0x64, 0x0a, 0x07, 0x44, 0x65, 0x71, 0x75, 0x65, 0x75, 0x65, 0x12, 0x2b, 0x2e,
+	0x6e, 0x69, 0x74, 0x72, 0x69, 0x63, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x71, 0x75, 0x65,
+	0x75, 0x65, 0x73, 0x2e, 0x76, 0x31, 0x2e, 0x51, 0x75, 0x65, 0x75, 0x65, 0x44, 0x65, 0x71, 0x75,
+	0x65, 0x75, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x2c, 0x2e, 0x6e, 0x69, 0x74,
+	0x72, 0x69, 0x63, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x71, 0x75, 0x65, 0x75, 0x65, 0x73,
+	0x2e, 0x76, 0x31, 0x2e, 0x51, 0x75, 0x65, 0x75, 0x65, 0x44, 0x65, 0x71, 0x75, 0x65, 0x75, 0x65,
+	0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x67, 0x0a, 0x08, 0x43, 0x6f, 0x6d, 0x70,
+	0x6c, 0x65, 0x74, 0x65, 0x12, 0x2c, 0x2e, 0x6e, 0x69, 0x74, 0x72, 0x69, 0x63, 0x2e, 0x70, 0x72,
+	0x6f, 0x74, 0x6f, 0x2e, 0x71, 0x75, 0x65, 0x75, 0x65, 0x73, 0x2e, 0x76, 0x31, 0x2e, 0x51, 0x75,
+	0x65, 0x75, 0x65, 0x43, 0x6f, 0x6d, 0x70, 0x6c, 0x65, 0x74, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65,
+	0x73, 0x74, 0x1a, 0x2d, 0x2e, 0x6e, 0x69, 0x74, 0x72, 0x69, 0x63, 0x2e, 0x70, 0x72, 0x6f, 0x74,
+	0x6f, 0x2e, 0x71, 0x75, 0x65, 0x75, 0x65, 0x73, 0x2e, 0x76, 0x31, 0x2e, 0x51, 0x75, 0x65, 0x75,
+	0x65, 0x43, 0x6f, 0x6d, 0x70, 0x6c, 0x65, 0x74, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73,
+	0x65, 0x12, 0x70, 0x0a, 0x0b, 0x45, 0x78, 0x74, 0x65, 0x6e, 0x64, 0x4c, 0x65, 0x61, 0x73, 0x65,
+	0x12, 0x2f, 0x2e, 0x6e, 0x69, 0x74, 0x72, 0x69, 0x63, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e,
+	0x71, 0x75, 0x65, 0x75, 0x65, 0x73, 0x2e, 0x76, 0x31, 0x2e, 0x51, 0x75, 0x65, 0x75, 0x65, 0x45,
+	0x78, 0x74, 0x65, 0x6e, 0x64, 0x4c, 0x65, 0x61, 0x73, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
+	0x74, 0x1a, 0x30, 0x2e, 0x6e, 0x69, 0x74, 0x72, 0x69, 0x63, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f,
+	0x2e, 0x71, 0x75, 0x65, 0x75, 0x65, 0x73, 0x2e, 0x76, 0x31, 0x2e, 0x51, 0x75, 0x65, 0x75, 0x65,
+	0x45, 0x78, 0x74, 0x65, 0x6e, 0x64, 0x4c, 0x65, 0x61, 0x73, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f,
+	0x6e, 0x73, 0x65, 0x12, 0x64, 0x0a, 0x07, 0x52, 0x65, 0x6c, 0x65, 0x61, 0x73, 0x65, 0x12, 0x2b,
+	0x2e, 0x6e, 0x69, 0x74, 0x72, 0x69, 0x63, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x71, 0x75,
+	0x65, 0x75, 0x65, 0x73, 0x2e, 0x76, 0x31, 0x2e, 0x51, 0x75, 0x65, 0x75, 0x65, 0x52, 0x65, 0x6c,
+	0x65, 0x61, 0x73, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x2c, 0x2e, 0x6e, 0x69,
+	0x74, 0x72, 0x69, 0x63, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x71, 0x75, 0x65, 0x75, 0x65,
+	0x73, 0x2e, 0x76, 0x31, 0x2e, 0x51, 0x75, 0x65, 0x75, 0x65, 0x52, 0x65, 0x6c, 0x65, 0x61, 0x73,
+	0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x32, 0x6b, 0x0a, 0x0d, 0x51, 0x75, 0x65,
+	0x75, 0x65, 0x4c, 0x69, 0x73, 0x74, 0x65, 0x6e, 0x65, 0x72, 0x12, 0x5a, 0x0a, 0x06, 0x4c, 0x69,
+	0x73, 0x74, 0x65, 0x6e, 0x12, 0x25, 0x2e, 0x6e, 0x69, 0x74, 0x72, 0x69, 0x63, 0x2e, 0x70, 0x72,
+	0x6f, 0x74, 0x6f, 0x2e, 0x71, 0x75, 0x65, 0x75, 0x65, 0x73, 0x2e, 0x76, 0x31, 0x2e, 0x43, 0x6c,
+	0x69, 0x65, 0x6e, 0x74, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x1a, 0x25, 0x2e, 0x6e, 0x69,
+	0x74, 0x72, 0x69, 0x63, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x71, 0x75, 0x65, 0x75, 0x65,
+	0x73, 0x2e, 0x76, 0x31, 0x2e, 0x53, 0x65, 0x72, 0x76, 0x65, 0x72, 0x4d, 0x65, 0x73, 0x73, 0x61,
+	0x67, 0x65, 0x28, 0x01, 0x30, 0x01, 0x42, 0x9e, 0x01, 0x0a, 0x19, 0x69, 0x6f, 0x2e, 0x6e, 0x69,
+	0x74, 0x72, 0x69, 0x63, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x71, 0x75, 0x65, 0x75, 0x65,
+	0x73, 0x2e, 0x76, 0x31, 0x42, 0x0d, 0x51, 0x75, 0x65, 0x75, 0x65, 0x73, 0x53, 0x65, 0x72, 0x76,
+	0x69, 0x63, 0x65, 0x50, 0x01, 0x5a, 0x3e, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f,
+	0x6d, 0x2f, 0x6e, 0x69, 0x74, 0x72, 0x69, 0x63, 0x74, 0x65, 0x63, 0x68, 0x2f, 0x6e, 0x69, 0x74,
+	0x72, 0x69, 0x63, 0x2f, 0x63, 0x6f, 0x72, 0x65, 0x2f, 0x70, 0x6b, 0x67, 0x2f, 0x70, 0x72, 0x6f,
+	0x74, 0x6f, 0x2f, 0x71, 0x75, 0x65, 0x75, 0x65, 0x73, 0x2f, 0x76, 0x31, 0x3b, 0x71, 0x75, 0x65,
+	0x75, 0x65, 0x73, 0x70, 0x62, 0xaa, 0x02, 0x16, 0x4e, 0x69, 0x74, 0x72, 0x69, 0x63, 0x2e, 0x50,
+	0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x51, 0x75, 0x65, 0x75, 0x65, 0x73, 0x2e, 0x76, 0x31, 0xca, 0x02,
+	0x16, 0x4e, 0x69, 0x74, 0x72, 0x69, 0x63, 0x5c, 0x50, 0x72, 0x6f, 0x74, 0x6f, 0x5c, 0x51, 0x75,
+	0x65, 0x75, 0x65, 0x73, 0x5c, 0x56, 0x31, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
 }
 
 var (
@@ -618,37 +1581,70 @@ func file_nitric_proto_queues_v1_queues_proto_rawDescGZIP() []byte {
 	return file_nitric_proto_queues_v1_queues_proto_rawDescData
 }
 
-var file_nitric_proto_queues_v1_queues_proto_msgTypes = make([]protoimpl.MessageInfo, 9)
+var file_nitric_proto_queues_v1_queues_proto_msgTypes = make([]protoimpl.MessageInfo, 23)
 var file_nitric_proto_queues_v1_queues_proto_goTypes = []interface{}{
-	(*QueueEnqueueRequest)(nil),   // 0: nitric.proto.queues.v1.QueueEnqueueRequest
-	(*QueueEnqueueResponse)(nil),  // 1: nitric.proto.queues.v1.QueueEnqueueResponse
-	(*QueueDequeueRequest)(nil),   // 2: nitric.proto.queues.v1.QueueDequeueRequest
-	(*QueueDequeueResponse)(nil),  // 3: nitric.proto.queues.v1.QueueDequeueResponse
-	(*QueueCompleteRequest)(nil),  // 4: nitric.proto.queues.v1.QueueCompleteRequest
-	(*QueueCompleteResponse)(nil), // 5: nitric.proto.queues.v1.QueueCompleteResponse
-	(*QueueMessage)(nil),          // 6: nitric.proto.queues.v1.QueueMessage
-	(*DequeuedMessage)(nil),       // 7: nitric.proto.queues.v1.DequeuedMessage
-	(*FailedEnqueueMessage)(nil),  // 8: nitric.proto.queues.v1.FailedEnqueueMessage
-	(*structpb.Struct)(nil),       // 9: google.protobuf.Struct
+	(*ClientMessage)(nil),            // 0: nitric.proto.queues.v1.ClientMessage
+	(*ServerMessage)(nil),            // 1: nitric.proto.queues.v1.ServerMessage
+	(*CancellationRequest)(nil),      // 2: nitric.proto.queues.v1.CancellationRequest
+	(*RegistrationRequest)(nil),      // 3: nitric.proto.queues.v1.RegistrationRequest
+	(*RegistrationResponse)(nil),     // 4: nitric.proto.queues.v1.RegistrationResponse
+	(*MessageRequest)(nil),           // 5: nitric.proto.queues.v1.MessageRequest
+	(*MessageResponse)(nil),          // 6: nitric.proto.queues.v1.MessageResponse
+	(*QueueEnqueueRequest)(nil),      // 7: nitric.proto.queues.v1.QueueEnqueueRequest
+	(*QueueEnqueueResponse)(nil),     // 8: nitric.proto.queues.v1.QueueEnqueueResponse
+	(*QueueDequeueRequest)(nil),      // 9: nitric.proto.queues.v1.QueueDequeueRequest
+	(*QueueDequeueResponse)(nil),     // 10: nitric.proto.queues.v1.QueueDequeueResponse
+	(*QueueCompleteRequest)(nil),     // 11: nitric.proto.queues.v1.QueueCompleteRequest
+	(*QueueCompleteResponse)(nil),    // 12: nitric.proto.queues.v1.QueueCompleteResponse
+	(*QueueExtendLeaseRequest)(nil),  // 13: nitric.proto.queues.v1.QueueExtendLeaseRequest
+	(*QueueExtendLeaseResponse)(nil), // 14: nitric.proto.queues.v1.QueueExtendLeaseResponse
+	(*QueueReleaseRequest)(nil),      // 15: nitric.proto.queues.v1.QueueReleaseRequest
+	(*QueueReleaseResponse)(nil),     // 16: nitric.proto.queues.v1.QueueReleaseResponse
+	(*QueueMessage)(nil),             // 17: nitric.proto.queues.v1.QueueMessage
+	(*BytesPayload)(nil),             // 18: nitric.proto.queues.v1.BytesPayload
+	(*DequeuedMessage)(nil),          // 19: nitric.proto.queues.v1.DequeuedMessage
+	(*FailedEnqueueMessage)(nil),     // 20: nitric.proto.queues.v1.FailedEnqueueMessage
+	nil,                              // 21: nitric.proto.queues.v1.ServerMessage.TraceContextEntry
+	nil,                              // 22: nitric.proto.queues.v1.QueueMessage.AttributesEntry
+	(*durationpb.Duration)(nil),      // 23: google.protobuf.Duration
+	(*structpb.Struct)(nil),          // 24: google.protobuf.Struct
 }
 var file_nitric_proto_queues_v1_queues_proto_depIdxs = []int32{
-	6, // 0: nitric.proto.queues.v1.QueueEnqueueRequest.messages:type_name -> nitric.proto.queues.v1.QueueMessage
-	8, // 1: nitric.proto.queues.v1.QueueEnqueueResponse.failed_messages:type_name -> nitric.proto.queues.v1.FailedEnqueueMessage
-	7, // 2: nitric.proto.queues.v1.QueueDequeueResponse.messages:type_name -> nitric.proto.queues.v1.DequeuedMessage
-	9, // 3: nitric.proto.queues.v1.QueueMessage.struct_payload:type_name -> google.protobuf.Struct
-	6, // 4: nitric.proto.queues.v1.DequeuedMessage.message:type_name -> nitric.proto.queues.v1.QueueMessage
-	6, // 5: nitric.proto.queues.v1.FailedEnqueueMessage.message:type_name -> nitric.proto.queues.v1.QueueMessage
-	0, // 6: nitric.proto.queues.v1.Queues.Enqueue:input_type -> nitric.proto.queues.v1.QueueEnqueueRequest
-	2, // 7: nitric.proto.queues.v1.Queues.Dequeue:input_type -> nitric.proto.queues.v1.QueueDequeueRequest
-	4, // 8: nitric.proto.queues.v1.Queues.Complete:input_type -> nitric.proto.queues.v1.QueueCompleteRequest
-	1, // 9: nitric.proto.queues.v1.Queues.Enqueue:output_type -> nitric.proto.queues.v1.QueueEnqueueResponse
-	3, // 10: nitric.proto.queues.v1.Queues.Dequeue:output_type -> nitric.proto.queues.v1.QueueDequeueResponse
-	5, // 11: nitric.proto.queues.v1.Queues.Complete:output_type -> nitric.proto.queues.v1.QueueCompleteResponse
-	9, // [9:12] is the sub-list for method output_type
-	6, // [6:9] is the sub-list for method input_type
-	6, // [6:6] is the sub-list for extension type_name
-	6, // [6:6] is the sub-list for extension extendee
-	0, // [0:6] is the sub-list for field type_name
+	3,  // 0: nitric.proto.queues.v1.ClientMessage.registration_request:type_name -> nitric.proto.queues.v1.RegistrationRequest
+	6,  // 1: nitric.proto.queues.v1.ClientMessage.message_response:type_name -> nitric.proto.queues.v1.MessageResponse
+	4,  // 2: nitric.proto.queues.v1.ServerMessage.registration_response:type_name -> nitric.proto.queues.v1.RegistrationResponse
+	5,  // 3: nitric.proto.queues.v1.ServerMessage.message_request:type_name -> nitric.proto.queues.v1.MessageRequest
+	2,  // 4: nitric.proto.queues.v1.ServerMessage.cancellation_request:type_name -> nitric.proto.queues.v1.CancellationRequest
+	21, // 5: nitric.proto.queues.v1.ServerMessage.trace_context:type_name -> nitric.proto.queues.v1.ServerMessage.TraceContextEntry
+	17, // 6: nitric.proto.queues.v1.MessageRequest.message:type_name -> nitric.proto.queues.v1.QueueMessage
+	17, // 7: nitric.proto.queues.v1.QueueEnqueueRequest.messages:type_name -> nitric.proto.queues.v1.QueueMessage
+	20, // 8: nitric.proto.queues.v1.QueueEnqueueResponse.failed_messages:type_name -> nitric.proto.queues.v1.FailedEnqueueMessage
+	23, // 9: nitric.proto.queues.v1.QueueDequeueRequest.visibility_timeout:type_name -> google.protobuf.Duration
+	19, // 10: nitric.proto.queues.v1.QueueDequeueResponse.messages:type_name -> nitric.proto.queues.v1.DequeuedMessage
+	23, // 11: nitric.proto.queues.v1.QueueExtendLeaseRequest.visibility_timeout:type_name -> google.protobuf.Duration
+	23, // 12: nitric.proto.queues.v1.QueueReleaseRequest.delay:type_name -> google.protobuf.Duration
+	24, // 13: nitric.proto.queues.v1.QueueMessage.struct_payload:type_name -> google.protobuf.Struct
+	18, // 14: nitric.proto.queues.v1.QueueMessage.bytes_payload:type_name -> nitric.proto.queues.v1.BytesPayload
+	22, // 15: nitric.proto.queues.v1.QueueMessage.attributes:type_name -> nitric.proto.queues.v1.QueueMessage.AttributesEntry
+	17, // 16: nitric.proto.queues.v1.DequeuedMessage.message:type_name -> nitric.proto.queues.v1.QueueMessage
+	17, // 17: nitric.proto.queues.v1.FailedEnqueueMessage.message:type_name -> nitric.proto.queues.v1.QueueMessage
+	7,  // 18: nitric.proto.queues.v1.Queues.Enqueue:input_type -> nitric.proto.queues.v1.QueueEnqueueRequest
+	9,  // 19: nitric.proto.queues.v1.Queues.Dequeue:input_type -> nitric.proto.queues.v1.QueueDequeueRequest
+	11, // 20: nitric.proto.queues.v1.Queues.Complete:input_type -> nitric.proto.queues.v1.QueueCompleteRequest
+	13, // 21: nitric.proto.queues.v1.Queues.ExtendLease:input_type -> nitric.proto.queues.v1.QueueExtendLeaseRequest
+	15, // 22: nitric.proto.queues.v1.Queues.Release:input_type -> nitric.proto.queues.v1.QueueReleaseRequest
+	0,  // 23: nitric.proto.queues.v1.QueueListener.Listen:input_type -> nitric.proto.queues.v1.ClientMessage
+	8,  // 24: nitric.proto.queues.v1.Queues.Enqueue:output_type -> nitric.proto.queues.v1.QueueEnqueueResponse
+	10, // 25: nitric.proto.queues.v1.Queues.Dequeue:output_type -> nitric.proto.queues.v1.QueueDequeueResponse
+	12, // 26: nitric.proto.queues.v1.Queues.Complete:output_type -> nitric.proto.queues.v1.QueueCompleteResponse
+	14, // 27: nitric.proto.queues.v1.Queues.ExtendLease:output_type -> nitric.proto.queues.v1.QueueExtendLeaseResponse
+	16, // 28: nitric.proto.queues.v1.Queues.Release:output_type -> nitric.proto.queues.v1.QueueReleaseResponse
+	1,  // 29: nitric.proto.queues.v1.QueueListener.Listen:output_type -> nitric.proto.queues.v1.ServerMessage
+	24, // [24:30] is the sub-list for method output_type
+	18, // [18:24] is the sub-list for method input_type
+	18, // [18:18] is the sub-list for extension type_name
+	18, // [18:18] is the sub-list for extension extendee
+	0,  // [0:18] is the sub-list for field type_name
 }
 
 func init() { file_nitric_proto_queues_v1_queues_proto_init() }
@@ -658,7 +1654,7 @@ func file_nitric_proto_queues_v1_queues_proto_init() {
 	}
 	if !protoimpl.UnsafeEnabled {
 		file_nitric_proto_queues_v1_queues_proto_msgTypes[0].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*QueueEnqueueRequest); i {
+			switch v := v.(*ClientMessage); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -670,7 +1666,7 @@ func file_nitric_proto_queues_v1_queues_proto_init() {
 			}
 		}
 		file_nitric_proto_queues_v1_queues_proto_msgTypes[1].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*QueueEnqueueResponse); i {
+			switch v := v.(*ServerMessage); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -682,7 +1678,7 @@ func file_nitric_proto_queues_v1_queues_proto_init() {
 			}
 		}
 		file_nitric_proto_queues_v1_queues_proto_msgTypes[2].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*QueueDequeueRequest); i {
+			switch v := v.(*CancellationRequest); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -694,7 +1690,7 @@ func file_nitric_proto_queues_v1_queues_proto_init() {
 			}
 		}
 		file_nitric_proto_queues_v1_queues_proto_msgTypes[3].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*QueueDequeueResponse); i {
+			switch v := v.(*RegistrationRequest); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -706,7 +1702,7 @@ func file_nitric_proto_queues_v1_queues_proto_init() {
 			}
 		}
 		file_nitric_proto_queues_v1_queues_proto_msgTypes[4].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*QueueCompleteRequest); i {
+			switch v := v.(*RegistrationResponse); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -718,7 +1714,7 @@ func file_nitric_proto_queues_v1_queues_proto_init() {
 			}
 		}
 		file_nitric_proto_queues_v1_queues_proto_msgTypes[5].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*QueueCompleteResponse); i {
+			switch v := v.(*MessageRequest); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -730,7 +1726,7 @@ func file_nitric_proto_queues_v1_queues_proto_init() {
 			}
 		}
 		file_nitric_proto_queues_v1_queues_proto_msgTypes[6].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*QueueMessage); i {
+			switch v := v.(*MessageResponse); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -742,7 +1738,7 @@ func file_nitric_proto_queues_v1_queues_proto_init() {
 			}
 		}
 		file_nitric_proto_queues_v1_queues_proto_msgTypes[7].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*DequeuedMessage); i {
+			switch v := v.(*QueueEnqueueRequest); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -754,6 +1750,150 @@ func file_nitric_proto_queues_v1_queues_proto_init() {
 			}
 		}
 		file_nitric_proto_queues_v1_queues_proto_msgTypes[8].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*QueueEnqueueResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_nitric_proto_queues_v1_queues_proto_msgTypes[9].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*QueueDequeueRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_nitric_proto_queues_v1_queues_proto_msgTypes[10].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*QueueDequeueResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_nitric_proto_queues_v1_queues_proto_msgTypes[11].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*QueueCompleteRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_nitric_proto_queues_v1_queues_proto_msgTypes[12].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*QueueCompleteResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_nitric_proto_queues_v1_queues_proto_msgTypes[13].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*QueueExtendLeaseRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_nitric_proto_queues_v1_queues_proto_msgTypes[14].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*QueueExtendLeaseResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_nitric_proto_queues_v1_queues_proto_msgTypes[15].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*QueueReleaseRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_nitric_proto_queues_v1_queues_proto_msgTypes[16].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*QueueReleaseResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_nitric_proto_queues_v1_queues_proto_msgTypes[17].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*QueueMessage); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_nitric_proto_queues_v1_queues_proto_msgTypes[18].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*BytesPayload); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_nitric_proto_queues_v1_queues_proto_msgTypes[19].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*DequeuedMessage); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_nitric_proto_queues_v1_queues_proto_msgTypes[20].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*FailedEnqueueMessage); i {
 			case 0:
 				return &v.state
@@ -766,8 +1906,18 @@ func file_nitric_proto_queues_v1_queues_proto_init() {
 			}
 		}
 	}
-	file_nitric_proto_queues_v1_queues_proto_msgTypes[6].OneofWrappers = []interface{}{
+	file_nitric_proto_queues_v1_queues_proto_msgTypes[0].OneofWrappers = []interface{}{
+		(*ClientMessage_RegistrationRequest)(nil),
+		(*ClientMessage_MessageResponse)(nil),
+	}
+	file_nitric_proto_queues_v1_queues_proto_msgTypes[1].OneofWrappers = []interface{}{
+		(*ServerMessage_RegistrationResponse)(nil),
+		(*ServerMessage_MessageRequest)(nil),
+		(*ServerMessage_CancellationRequest)(nil),
+	}
+	file_nitric_proto_queues_v1_queues_proto_msgTypes[17].OneofWrappers = []interface{}{
 		(*QueueMessage_StructPayload)(nil),
+		(*QueueMessage_BytesPayload)(nil),
 	}
 	type x struct{}
 	out := protoimpl.TypeBuilder{
@@ -775,9 +1925,9 @@ func file_nitric_proto_queues_v1_queues_proto_init() {
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: file_nitric_proto_queues_v1_queues_proto_rawDesc,
 			NumEnums:      0,
-			NumMessages:   9,
+			NumMessages:   23,
 			NumExtensions: 0,
-			NumServices:   1,
+			NumServices:   2,
 		},
 		GoTypes:           file_nitric_proto_queues_v1_queues_proto_goTypes,
 		DependencyIndexes: file_nitric_proto_queues_v1_queues_proto_depIdxs,
@@ -601,6 +601,11 @@ type QueueResource struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
+
+	// Moves messages that repeatedly fail processing to another queue, messages are retried indefinitely when not set
+	DeadLetterPolicy *QueueDeadLetterPolicy `protobuf:"bytes,1,opt,name=dead_letter_policy,json=deadLetterPolicy,proto3" json:"dead_letter_policy,omitempty"`
+	// Deliver messages in order within each message group and deduplicate them
+	Fifo bool `protobuf:"varint,2,opt,name=fifo,proto3" json:"fifo,omitempty"`
 }
 
 func (x *QueueResource) Reset() {
@@ -635,6 +640,77 @@ func (*QueueResource) Descriptor() ([]byte, []int) {
 	return file_nitric_proto_resources_v1_resources_proto_rawDescGZIP(), []int{5}
 }
 
+func (x *QueueResource) GetDeadLetterPolicy() *QueueDeadLetterPolicy {
+	if x != nil {
+		return x.DeadLetterPolicy
+	}
+	return nil
+}
+
+func (x *QueueResource) GetFifo() bool {
+	if x != nil {
+		return x.Fifo
+	}
+	return false
+}
+
+type QueueDeadLetterPolicy struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Name of the queue that failed messages are moved to, it must also be declared by the application
+	TargetQueue string `protobuf:"bytes,1,opt,name=target_queue,json=targetQueue,proto3" json:"target_queue,omitempty"`
+	// Number of times a message can be delivered before it's moved to the target queue
+	MaxReceiveCount int32 `protobuf:"varint,2,opt,name=max_receive_count,json=maxReceiveCount,proto3" json:"max_receive_count,omitempty"`
+}
+
+func (x *QueueDeadLetterPolicy) Reset() {
+	*x = QueueDeadLetterPolicy{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_nitric_proto_resources_v1_resources_proto_msgTypes[6]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *QueueDeadLetterPolicy) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*QueueDeadLetterPolicy) ProtoMessage() {}
+
+func (x *QueueDeadLetterPolicy) ProtoReflect() protoreflect.Message {
+	mi := &file_nitric_proto_resources_v1_resources_proto_msgTypes[6]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use QueueDeadLetterPolicy.ProtoReflect.Descriptor instead.
+func (*QueueDeadLetterPolicy) Descriptor() ([]byte, []int) {
+	return file_nitric_proto_resources_v1_resources_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *QueueDeadLetterPolicy) GetTargetQueue() string {
+	if x != nil {
+		return x.TargetQueue
+	}
+	return ""
+}
+
+func (x *QueueDeadLetterPolicy) GetMaxReceiveCount() int32 {
+	if x != nil {
+		return x.MaxReceiveCount
+	}
+	return 0
+}
+
 type KeyValueStoreResource struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
@@ -644,7 +720,7 @@ type KeyValueStoreResource struct {
 func (x *KeyValueStoreResource) Reset() {
 	*x = KeyValueStoreResource{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_nitric_proto_resources_v1_resources_proto_msgTypes[6]
+		mi := &file_nitric_proto_resources_v1_resources_proto_msgTypes[7]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -657,7 +733,7 @@ func (x *KeyValueStoreResource) String() string {
 func (*KeyValueStoreResource) ProtoMessage() {}
 
 func (x *KeyValueStoreResource) ProtoReflect() protoreflect.Message {
-	mi := &file_nitric_proto_resources_v1_resources_proto_msgTypes[6]
+	mi := &file_nitric_proto_resources_v1_resources_proto_msgTypes[7]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -670,7 +746,7 @@ func (x *KeyValueStoreResource) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use KeyValueStoreResource.ProtoReflect.Descriptor instead.
 func (*KeyValueStoreResource) Descriptor() ([]byte, []int) {
-	return file_nitric_proto_resources_v1_resources_proto_rawDescGZIP(), []int{6}
+	return file_nitric_proto_resources_v1_resources_proto_rawDescGZIP(), []int{7}
 }
 
 type SecretResource struct {
@@ -682,7 +758,7 @@ type SecretResource struct {
 func (x *SecretResource) Reset() {
 	*x = SecretResource{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_nitric_proto_resources_v1_resources_proto_msgTypes[7]
+		mi := &file_nitric_proto_resources_v1_resources_proto_msgTypes[8]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -695,7 +771,7 @@ func (x *SecretResource) String() string {
 func (*SecretResource) ProtoMessage() {}
 
 func (x *SecretResource) ProtoReflect() protoreflect.Message {
-	mi := &file_nitric_proto_resources_v1_resources_proto_msgTypes[7]
+	mi := &file_nitric_proto_resources_v1_resources_proto_msgTypes[8]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -708,7 +784,7 @@ func (x *SecretResource) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use SecretResource.ProtoReflect.Descriptor instead.
 func (*SecretResource) Descriptor() ([]byte, []int) {
-	return file_nitric_proto_resources_v1_resources_proto_rawDescGZIP(), []int{7}
+	return file_nitric_proto_resources_v1_resources_proto_rawDescGZIP(), []int{8}
 }
 
 type JobResource struct {
@@ -720,7 +796,7 @@ type JobResource struct {
 func (x *JobResource) Reset() {
 	*x = JobResource{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_nitric_proto_resources_v1_resources_proto_msgTypes[8]
+		mi := &file_nitric_proto_resources_v1_resources_proto_msgTypes[9]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -733,7 +809,7 @@ func (x *JobResource) String() string {
 func (*JobResource) ProtoMessage() {}
 
 func (x *JobResource) ProtoReflect() protoreflect.Message {
-	mi := &file_nitric_proto_resources_v1_resources_proto_msgTypes[8]
+	mi := &file_nitric_proto_resources_v1_resources_proto_msgTypes[9]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -746,7 +822,7 @@ func (x *JobResource) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use JobResource.ProtoReflect.Descriptor instead.
 func (*JobResource) Descriptor() ([]byte, []int) {
-	return file_nitric_proto_resources_v1_resources_proto_rawDescGZIP(), []int{8}
+	return file_nitric_proto_resources_v1_resources_proto_rawDescGZIP(), []int{9}
 }
 
 type SqlDatabaseMigrations struct {
@@ -763,7 +839,7 @@ type SqlDatabaseMigrations struct {
 func (x *SqlDatabaseMigrations) Reset() {
 	*x = SqlDatabaseMigrations{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_nitric_proto_resources_v1_resources_proto_msgTypes[9]
+		mi := &file_nitric_proto_resources_v1_resources_proto_msgTypes[10]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -776,7 +852,7 @@ func (x *SqlDatabaseMigrations) String() string {
 func (*SqlDatabaseMigrations) ProtoMessage() {}
 
 func (x *SqlDatabaseMigrations) ProtoReflect() protoreflect.Message {
-	mi := &file_nitric_proto_resources_v1_resources_proto_msgTypes[9]
+	mi := &file_nitric_proto_resources_v1_resources_proto_msgTypes[10]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -789,7 +865,7 @@ func (x *SqlDatabaseMigrations) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use SqlDatabaseMigrations.ProtoReflect.Descriptor instead.
 func (*SqlDatabaseMigrations) Descriptor() ([]byte, []int) {
-	return file_nitric_proto_resources_v1_resources_proto_rawDescGZIP(), []int{9}
+	return file_nitric_proto_resources_v1_resources_proto_rawDescGZIP(), []int{10}
 }
 
 func (m *SqlDatabaseMigrations) GetMigrations() isSqlDatabaseMigrations_Migrations {
@@ -830,7 +906,7 @@ type SqlDatabaseResource struct {
 func (x *SqlDatabaseResource) Reset() {
 	*x = SqlDatabaseResource{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_nitric_proto_resources_v1_resources_proto_msgTypes[10]
+		mi := &file_nitric_proto_resources_v1_resources_proto_msgTypes[11]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -843,7 +919,7 @@ func (x *SqlDatabaseResource) String() string {
 func (*SqlDatabaseResource) ProtoMessage() {}
 
 func (x *SqlDatabaseResource) ProtoReflect() protoreflect.Message {
-	mi := &file_nitric_proto_resources_v1_resources_proto_msgTypes[10]
+	mi := &file_nitric_proto_resources_v1_resources_proto_msgTypes[11]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -856,7 +932,7 @@ func (x *SqlDatabaseResource) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use SqlDatabaseResource.ProtoReflect.Descriptor instead.
 func (*SqlDatabaseResource) Descriptor() ([]byte, []int) {
-	return file_nitric_proto_resources_v1_resources_proto_rawDescGZIP(), []int{10}
+	return file_nitric_proto_resources_v1_resources_proto_rawDescGZIP(), []int{11}
 }
 
 func (x *SqlDatabaseResource) GetMigrations() *SqlDatabaseMigrations {
@@ -878,7 +954,7 @@ type ApiOpenIdConnectionDefinition struct {
 func (x *ApiOpenIdConnectionDefinition) Reset() {
 	*x = ApiOpenIdConnectionDefinition{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_nitric_proto_resources_v1_resources_proto_msgTypes[11]
+		mi := &file_nitric_proto_resources_v1_resources_proto_msgTypes[12]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -891,7 +967,7 @@ func (x *ApiOpenIdConnectionDefinition) String() string {
 func (*ApiOpenIdConnectionDefinition) ProtoMessage() {}
 
 func (x *ApiOpenIdConnectionDefinition) ProtoReflect() protoreflect.Message {
-	mi := &file_nitric_proto_resources_v1_resources_proto_msgTypes[11]
+	mi := &file_nitric_proto_resources_v1_resources_proto_msgTypes[12]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -904,7 +980,7 @@ func (x *ApiOpenIdConnectionDefinition) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ApiOpenIdConnectionDefinition.ProtoReflect.Descriptor instead.
 func (*ApiOpenIdConnectionDefinition) Descriptor() ([]byte, []int) {
-	return file_nitric_proto_resources_v1_resources_proto_rawDescGZIP(), []int{11}
+	return file_nitric_proto_resources_v1_resources_proto_rawDescGZIP(), []int{12}
 }
 
 func (x *ApiOpenIdConnectionDefinition) GetIssuer() string {
@@ -936,7 +1012,7 @@ type ApiSecurityDefinitionResource struct {
 func (x *ApiSecurityDefinitionResource) Reset() {
 	*x = ApiSecurityDefinitionResource{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_nitric_proto_resources_v1_resources_proto_msgTypes[12]
+		mi := &file_nitric_proto_resources_v1_resources_proto_msgTypes[13]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -949,7 +1025,7 @@ func (x *ApiSecurityDefinitionResource) String() string {
 func (*ApiSecurityDefinitionResource) ProtoMessage() {}
 
 func (x *ApiSecurityDefinitionResource) ProtoReflect() protoreflect.Message {
-	mi := &file_nitric_proto_resources_v1_resources_proto_msgTypes[12]
+	mi := &file_nitric_proto_resources_v1_resources_proto_msgTypes[13]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -962,7 +1038,7 @@ func (x *ApiSecurityDefinitionResource) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ApiSecurityDefinitionResource.ProtoReflect.Descriptor instead.
 func (*ApiSecurityDefinitionResource) Descriptor() ([]byte, []int) {
-	return file_nitric_proto_resources_v1_resources_proto_rawDescGZIP(), []int{12}
+	return file_nitric_proto_resources_v1_resources_proto_rawDescGZIP(), []int{13}
 }
 
 func (x *ApiSecurityDefinitionResource) GetApiName() string {
@@ -1007,7 +1083,7 @@ type ApiScopes struct {
 func (x *ApiScopes) Reset() {
 	*x = ApiScopes{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_nitric_proto_resources_v1_resources_proto_msgTypes[13]
+		mi := &file_nitric_proto_resources_v1_resources_proto_msgTypes[14]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -1020,7 +1096,7 @@ func (x *ApiScopes) String() string {
 func (*ApiScopes) ProtoMessage() {}
 
 func (x *ApiScopes) ProtoReflect() protoreflect.Message {
-	mi := &file_nitric_proto_resources_v1_resources_proto_msgTypes[13]
+	mi := &file_nitric_proto_resources_v1_resources_proto_msgTypes[14]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1033,7 +1109,7 @@ func (x *ApiScopes) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ApiScopes.ProtoReflect.Descriptor instead.
 func (*ApiScopes) Descriptor() ([]byte, []int) {
-	return file_nitric_proto_resources_v1_resources_proto_rawDescGZIP(), []int{13}
+	return file_nitric_proto_resources_v1_resources_proto_rawDescGZIP(), []int{14}
 }
 
 func (x *ApiScopes) GetScopes() []string {
@@ -1056,7 +1132,7 @@ type ApiResource struct {
 func (x *ApiResource) Reset() {
 	*x = ApiResource{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_nitric_proto_resources_v1_resources_proto_msgTypes[14]
+		mi := &file_nitric_proto_resources_v1_resources_proto_msgTypes[15]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -1069,7 +1145,7 @@ func (x *ApiResource) String() string {
 func (*ApiResource) ProtoMessage() {}
 
 func (x *ApiResource) ProtoReflect() protoreflect.Message {
-	mi := &file_nitric_proto_resources_v1_resources_proto_msgTypes[14]
+	mi := &file_nitric_proto_resources_v1_resources_proto_msgTypes[15]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1082,7 +1158,7 @@ func (x *ApiResource) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ApiResource.ProtoReflect.Descriptor instead.
 func (*ApiResource) Descriptor() ([]byte, []int) {
-	return file_nitric_proto_resources_v1_resources_proto_rawDescGZIP(), []int{14}
+	return file_nitric_proto_resources_v1_resources_proto_rawDescGZIP(), []int{15}
 }
 
 func (x *ApiResource) GetSecurity() map[string]*ApiScopes {
@@ -1101,7 +1177,7 @@ type ResourceDeclareResponse struct {
 func (x *ResourceDeclareResponse) Reset() {
 	*x = ResourceDeclareResponse{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_nitric_proto_resources_v1_resources_proto_msgTypes[15]
+		mi := &file_nitric_proto_resources_v1_resources_proto_msgTypes[16]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -1114,7 +1190,7 @@ func (x *ResourceDeclareResponse) String() string {
 func (*ResourceDeclareResponse) ProtoMessage() {}
 
 func (x *ResourceDeclareResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_nitric_proto_resources_v1_resources_proto_msgTypes[15]
+	mi := &file_nitric_proto_resources_v1_resources_proto_msgTypes[16]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1127,7 +1203,7 @@ func (x *ResourceDeclareResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ResourceDeclareResponse.ProtoReflect.Descriptor instead.
 func (*ResourceDeclareResponse) Descriptor() ([]byte, []int) {
-	return file_nitric_proto_resources_v1_resources_proto_rawDescGZIP(), []int{15}
+	return file_nitric_proto_resources_v1_resources_proto_rawDescGZIP(), []int{16}
 }
 
 var File_nitric_proto_resources_v1_resources_proto protoreflect.FileDescriptor
@@ -1214,108 +1290,122 @@ var file_nitric_proto_resources_v1_resources_proto_rawDesc = []byte{
 	0x6a, 0x6f, 0x62, 0x42, 0x08, 0x0a, 0x06, 0x63, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x22, 0x10, 0x0a,
 	0x0e, 0x42, 0x75, 0x63, 0x6b, 0x65, 0x74, 0x52, 0x65, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x22,
 	0x0f, 0x0a, 0x0d, 0x54, 0x6f, 0x70, 0x69, 0x63, 0x52, 0x65, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65,
-	0x22, 0x0f, 0x0a, 0x0d, 0x51, 0x75, 0x65, 0x75, 0x65, 0x52, 0x65, 0x73, 0x6f, 0x75, 0x72, 0x63,
-	0x65, 0x22, 0x17, 0x0a, 0x15, 0x4b, 0x65, 0x79, 0x56, 0x61, 0x6c, 0x75, 0x65, 0x53, 0x74, 0x6f,
-	0x72, 0x65, 0x52, 0x65, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x22, 0x10, 0x0a, 0x0e, 0x53, 0x65,
-	0x63, 0x72, 0x65, 0x74, 0x52, 0x65, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x22, 0x0d, 0x0a, 0x0b,
-	0x4a, 0x6f, 0x62, 0x52, 0x65, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x22, 0x50, 0x0a, 0x15, 0x53,
-	0x71, 0x6c, 0x44, 0x61, 0x74, 0x61, 0x62, 0x61, 0x73, 0x65, 0x4d, 0x69, 0x67, 0x72, 0x61, 0x74,
-	0x69, 0x6f, 0x6e, 0x73, 0x12, 0x29, 0x0a, 0x0f, 0x6d, 0x69, 0x67, 0x72, 0x61, 0x74, 0x69, 0x6f,
-	0x6e, 0x73, 0x5f, 0x70, 0x61, 0x74, 0x68, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x48, 0x00, 0x52,
-	0x0e, 0x6d, 0x69, 0x67, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x50, 0x61, 0x74, 0x68, 0x42,
-	0x0c, 0x0a, 0x0a, 0x6d, 0x69, 0x67, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x22, 0x67, 0x0a,
-	0x13, 0x53, 0x71, 0x6c, 0x44, 0x61, 0x74, 0x61, 0x62, 0x61, 0x73, 0x65, 0x52, 0x65, 0x73, 0x6f,
-	0x75, 0x72, 0x63, 0x65, 0x12, 0x50, 0x0a, 0x0a, 0x6d, 0x69, 0x67, 0x72, 0x61, 0x74, 0x69, 0x6f,
-	0x6e, 0x73, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x30, 0x2e, 0x6e, 0x69, 0x74, 0x72, 0x69,
-	0x63, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x72, 0x65, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65,
-	0x73, 0x2e, 0x76, 0x31, 0x2e, 0x53, 0x71, 0x6c, 0x44, 0x61, 0x74, 0x61, 0x62, 0x61, 0x73, 0x65,
-	0x4d, 0x69, 0x67, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x52, 0x0a, 0x6d, 0x69, 0x67, 0x72,
-	0x61, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x22, 0x55, 0x0a, 0x1d, 0x41, 0x70, 0x69, 0x4f, 0x70, 0x65,
-	0x6e, 0x49, 0x64, 0x43, 0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x44, 0x65, 0x66,
-	0x69, 0x6e, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x16, 0x0a, 0x06, 0x69, 0x73, 0x73, 0x75, 0x65,
-	0x72, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x69, 0x73, 0x73, 0x75, 0x65, 0x72, 0x12,
-	0x1c, 0x0a, 0x09, 0x61, 0x75, 0x64, 0x69, 0x65, 0x6e, 0x63, 0x65, 0x73, 0x18, 0x02, 0x20, 0x03,
-	0x28, 0x09, 0x52, 0x09, 0x61, 0x75, 0x64, 0x69, 0x65, 0x6e, 0x63, 0x65, 0x73, 0x22, 0x98, 0x01,
-	0x0a, 0x1d, 0x41, 0x70, 0x69, 0x53, 0x65, 0x63, 0x75, 0x72, 0x69, 0x74, 0x79, 0x44, 0x65, 0x66,
-	0x69, 0x6e, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x65, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x12,
-	0x19, 0x0a, 0x08, 0x61, 0x70, 0x69, 0x5f, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28,
-	0x09, 0x52, 0x07, 0x61, 0x70, 0x69, 0x4e, 0x61, 0x6d, 0x65, 0x12, 0x4e, 0x0a, 0x04, 0x6f, 0x69,
-	0x64, 0x63, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x38, 0x2e, 0x6e, 0x69, 0x74, 0x72, 0x69,
-	0x63, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x72, 0x65, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65,
-	0x73, 0x2e, 0x76, 0x31, 0x2e, 0x41, 0x70, 0x69, 0x4f, 0x70, 0x65, 0x6e, 0x49, 0x64, 0x43, 0x6f,
-	0x6e, 0x6e, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x44, 0x65, 0x66, 0x69, 0x6e, 0x69, 0x74, 0x69,
-	0x6f, 0x6e, 0x48, 0x00, 0x52, 0x04, 0x6f, 0x69, 0x64, 0x63, 0x42, 0x0c, 0x0a, 0x0a, 0x64, 0x65,
-	0x66, 0x69, 0x6e, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x22, 0x23, 0x0a, 0x09, 0x41, 0x70, 0x69, 0x53,
-	0x63, 0x6f, 0x70, 0x65, 0x73, 0x12, 0x16, 0x0a, 0x06, 0x73, 0x63, 0x6f, 0x70, 0x65, 0x73, 0x18,
-	0x01, 0x20, 0x03, 0x28, 0x09, 0x52, 0x06, 0x73, 0x63, 0x6f, 0x70, 0x65, 0x73, 0x22, 0xc2, 0x01,
-	0x0a, 0x0b, 0x41, 0x70, 0x69, 0x52, 0x65, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x12, 0x50, 0x0a,
-	0x08, 0x73, 0x65, 0x63, 0x75, 0x72, 0x69, 0x74, 0x79, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32,
-	0x34, 0x2e, 0x6e, 0x69, 0x74, 0x72, 0x69, 0x63, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x72,
-	0x65, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x73, 0x2e, 0x76, 0x31, 0x2e, 0x41, 0x70, 0x69, 0x52,
-	0x65, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x2e, 0x53, 0x65, 0x63, 0x75, 0x72, 0x69, 0x74, 0x79,
-	0x45, 0x6e, 0x74, 0x72, 0x79, 0x52, 0x08, 0x73, 0x65, 0x63, 0x75, 0x72, 0x69, 0x74, 0x79, 0x1a,
-	0x61, 0x0a, 0x0d, 0x53, 0x65, 0x63, 0x75, 0x72, 0x69, 0x74, 0x79, 0x45, 0x6e, 0x74, 0x72, 0x79,
-	0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6b,
-	0x65, 0x79, 0x12, 0x3a, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28,
-	0x0b, 0x32, 0x24, 0x2e, 0x6e, 0x69, 0x74, 0x72, 0x69, 0x63, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f,
-	0x2e, 0x72, 0x65, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x73, 0x2e, 0x76, 0x31, 0x2e, 0x41, 0x70,
-	0x69, 0x53, 0x63, 0x6f, 0x70, 0x65, 0x73, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02,
-	0x38, 0x01, 0x22, 0x19, 0x0a, 0x17, 0x52, 0x65, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x44, 0x65,
-	0x63, 0x6c, 0x61, 0x72, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x2a, 0xfe, 0x01,
-	0x0a, 0x0c, 0x52, 0x65, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x54, 0x79, 0x70, 0x65, 0x12, 0x07,
-	0x0a, 0x03, 0x41, 0x70, 0x69, 0x10, 0x00, 0x12, 0x0b, 0x0a, 0x07, 0x53, 0x65, 0x72, 0x76, 0x69,
-	0x63, 0x65, 0x10, 0x01, 0x12, 0x0a, 0x0a, 0x06, 0x42, 0x75, 0x63, 0x6b, 0x65, 0x74, 0x10, 0x02,
-	0x12, 0x09, 0x0a, 0x05, 0x54, 0x6f, 0x70, 0x69, 0x63, 0x10, 0x03, 0x12, 0x0c, 0x0a, 0x08, 0x53,
-	0x63, 0x68, 0x65, 0x64, 0x75, 0x6c, 0x65, 0x10, 0x04, 0x12, 0x10, 0x0a, 0x0c, 0x53, 0x75, 0x62,
-	0x73, 0x63, 0x72, 0x69, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x10, 0x05, 0x12, 0x11, 0x0a, 0x0d, 0x4b,
-	0x65, 0x79, 0x56, 0x61, 0x6c, 0x75, 0x65, 0x53, 0x74, 0x6f, 0x72, 0x65, 0x10, 0x06, 0x12, 0x0a,
-	0x0a, 0x06, 0x50, 0x6f, 0x6c, 0x69, 0x63, 0x79, 0x10, 0x07, 0x12, 0x0a, 0x0a, 0x06, 0x53, 0x65,
-	0x63, 0x72, 0x65, 0x74, 0x10, 0x08, 0x12, 0x12, 0x0a, 0x0e, 0x42, 0x75, 0x63, 0x6b, 0x65, 0x74,
-	0x4c, 0x69, 0x73, 0x74, 0x65, 0x6e, 0x65, 0x72, 0x10, 0x09, 0x12, 0x0d, 0x0a, 0x09, 0x57, 0x65,
-	0x62, 0x73, 0x6f, 0x63, 0x6b, 0x65, 0x74, 0x10, 0x0a, 0x12, 0x08, 0x0a, 0x04, 0x48, 0x74, 0x74,
-	0x70, 0x10, 0x0b, 0x12, 0x19, 0x0a, 0x15, 0x41, 0x70, 0x69, 0x53, 0x65, 0x63, 0x75, 0x72, 0x69,
-	0x74, 0x79, 0x44, 0x65, 0x66, 0x69, 0x6e, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x10, 0x0c, 0x12, 0x09,
-	0x0a, 0x05, 0x51, 0x75, 0x65, 0x75, 0x65, 0x10, 0x0d, 0x12, 0x0f, 0x0a, 0x0b, 0x53, 0x71, 0x6c,
-	0x44, 0x61, 0x74, 0x61, 0x62, 0x61, 0x73, 0x65, 0x10, 0x0e, 0x12, 0x09, 0x0a, 0x05, 0x42, 0x61,
-	0x74, 0x63, 0x68, 0x10, 0x0f, 0x12, 0x07, 0x0a, 0x03, 0x4a, 0x6f, 0x62, 0x10, 0x10, 0x2a, 0xa5,
-	0x02, 0x0a, 0x06, 0x41, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x12, 0x0a, 0x0e, 0x42, 0x75, 0x63,
-	0x6b, 0x65, 0x74, 0x46, 0x69, 0x6c, 0x65, 0x4c, 0x69, 0x73, 0x74, 0x10, 0x00, 0x12, 0x11, 0x0a,
-	0x0d, 0x42, 0x75, 0x63, 0x6b, 0x65, 0x74, 0x46, 0x69, 0x6c, 0x65, 0x47, 0x65, 0x74, 0x10, 0x01,
-	0x12, 0x11, 0x0a, 0x0d, 0x42, 0x75, 0x63, 0x6b, 0x65, 0x74, 0x46, 0x69, 0x6c, 0x65, 0x50, 0x75,
-	0x74, 0x10, 0x02, 0x12, 0x14, 0x0a, 0x10, 0x42, 0x75, 0x63, 0x6b, 0x65, 0x74, 0x46, 0x69, 0x6c,
-	0x65, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x10, 0x03, 0x12, 0x11, 0x0a, 0x0c, 0x54, 0x6f, 0x70,
-	0x69, 0x63, 0x50, 0x75, 0x62, 0x6c, 0x69, 0x73, 0x68, 0x10, 0xc8, 0x01, 0x12, 0x16, 0x0a, 0x11,
-	0x4b, 0x65, 0x79, 0x56, 0x61, 0x6c, 0x75, 0x65, 0x53, 0x74, 0x6f, 0x72, 0x65, 0x52, 0x65, 0x61,
-	0x64, 0x10, 0xac, 0x02, 0x12, 0x17, 0x0a, 0x12, 0x4b, 0x65, 0x79, 0x56, 0x61, 0x6c, 0x75, 0x65,
-	0x53, 0x74, 0x6f, 0x72, 0x65, 0x57, 0x72, 0x69, 0x74, 0x65, 0x10, 0xad, 0x02, 0x12, 0x18, 0x0a,
-	0x13, 0x4b, 0x65, 0x79, 0x56, 0x61, 0x6c, 0x75, 0x65, 0x53, 0x74, 0x6f, 0x72, 0x65, 0x44, 0x65,
-	0x6c, 0x65, 0x74, 0x65, 0x10, 0xae, 0x02, 0x12, 0x0e, 0x0a, 0x09, 0x53, 0x65, 0x63, 0x72, 0x65,
-	0x74, 0x50, 0x75, 0x74, 0x10, 0x90, 0x03, 0x12, 0x11, 0x0a, 0x0c, 0x53, 0x65, 0x63, 0x72, 0x65,
-	0x74, 0x41, 0x63, 0x63, 0x65, 0x73, 0x73, 0x10, 0x91, 0x03, 0x12, 0x14, 0x0a, 0x0f, 0x57, 0x65,
-	0x62, 0x73, 0x6f, 0x63, 0x6b, 0x65, 0x74, 0x4d, 0x61, 0x6e, 0x61, 0x67, 0x65, 0x10, 0xf4, 0x03,
-	0x12, 0x11, 0x0a, 0x0c, 0x51, 0x75, 0x65, 0x75, 0x65, 0x45, 0x6e, 0x71, 0x75, 0x65, 0x75, 0x65,
-	0x10, 0xd8, 0x04, 0x12, 0x11, 0x0a, 0x0c, 0x51, 0x75, 0x65, 0x75, 0x65, 0x44, 0x65, 0x71, 0x75,
-	0x65, 0x75, 0x65, 0x10, 0xd9, 0x04, 0x12, 0x0e, 0x0a, 0x09, 0x4a, 0x6f, 0x62, 0x53, 0x75, 0x62,
-	0x6d, 0x69, 0x74, 0x10, 0xbc, 0x05, 0x32, 0x7d, 0x0a, 0x09, 0x52, 0x65, 0x73, 0x6f, 0x75, 0x72,
-	0x63, 0x65, 0x73, 0x12, 0x70, 0x0a, 0x07, 0x44, 0x65, 0x63, 0x6c, 0x61, 0x72, 0x65, 0x12, 0x31,
+	0x22, 0x83, 0x01, 0x0a, 0x0d, 0x51, 0x75, 0x65, 0x75, 0x65, 0x52, 0x65, 0x73, 0x6f, 0x75, 0x72,
+	0x63, 0x65, 0x12, 0x5e, 0x0a, 0x12, 0x64, 0x65, 0x61, 0x64, 0x5f, 0x6c, 0x65, 0x74, 0x74, 0x65,
+	0x72, 0x5f, 0x70, 0x6f, 0x6c, 0x69, 0x63, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x30,
+	0x2e, 0x6e, 0x69, 0x74, 0x72, 0x69, 0x63, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x72, 0x65,
+	0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x73, 0x2e, 0x76, 0x31, 0x2e, 0x51, 0x75, 0x65, 0x75, 0x65,
+	0x44, 0x65, 0x61, 0x64, 0x4c, 0x65, 0x74, 0x74, 0x65, 0x72, 0x50, 0x6f, 0x6c, 0x69, 0x63, 0x79,
+	0x52, 0x10, 0x64, 0x65, 0x61, 0x64, 0x4c, 0x65, 0x74, 0x74, 0x65, 0x72, 0x50, 0x6f, 0x6c, 0x69,
+	0x63, 0x79, 0x12, 0x12, 0x0a, 0x04, 0x66, 0x69, 0x66, 0x6f, 0x18, 0x02, 0x20, 0x01, 0x28, 0x08,
+	0x52, 0x04, 0x66, 0x69, 0x66, 0x6f, 0x22, 0x66, 0x0a, 0x15, 0x51, 0x75, 0x65, 0x75, 0x65, 0x44,
+	0x65, 0x61, 0x64, 0x4c, 0x65, 0x74, 0x74, 0x65, 0x72, 0x50, 0x6f, 0x6c, 0x69, 0x63, 0x79, 0x12,
+	0x21, 0x0a, 0x0c, 0x74, 0x61, 0x72, 0x67, 0x65, 0x74, 0x5f, 0x71, 0x75, 0x65, 0x75, 0x65, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0b, 0x74, 0x61, 0x72, 0x67, 0x65, 0x74, 0x51, 0x75, 0x65,
+	0x75, 0x65, 0x12, 0x2a, 0x0a, 0x11, 0x6d, 0x61, 0x78, 0x5f, 0x72, 0x65, 0x63, 0x65, 0x69, 0x76,
+	0x65, 0x5f, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x05, 0x52, 0x0f, 0x6d,
+	0x61, 0x78, 0x52, 0x65, 0x63, 0x65, 0x69, 0x76, 0x65, 0x43, 0x6f, 0x75, 0x6e, 0x74, 0x22, 0x17,
+	0x0a, 0x15, 0x4b, 0x65, 0x79, 0x56, 0x61, 0x6c, 0x75, 0x65, 0x53, 0x74, 0x6f, 0x72, 0x65, 0x52,
+	0x65, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x22, 0x10, 0x0a, 0x0e, 0x53, 0x65, 0x63, 0x72, 0x65,
+	0x74, 0x52, 0x65, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x22, 0x0d, 0x0a, 0x0b, 0x4a, 0x6f, 0x62,
+	0x52, 0x65, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x22, 0x50, 0x0a, 0x15, 0x53, 0x71, 0x6c, 0x44,
+	0x61, 0x74, 0x61, 0x62, 0x61, 0x73, 0x65, 0x4d, 0x69, 0x67, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e,
+	0x73, 0x12, 0x29, 0x0a, 0x0f, 0x6d, 0x69, 0x67, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x5f,
+	0x70, 0x61, 0x74, 0x68, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x48, 0x00, 0x52, 0x0e, 0x6d, 0x69,
+	0x67, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x50, 0x61, 0x74, 0x68, 0x42, 0x0c, 0x0a, 0x0a,
+	0x6d, 0x69, 0x67, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x22, 0x67, 0x0a, 0x13, 0x53, 0x71,
+	0x6c, 0x44, 0x61, 0x74, 0x61, 0x62, 0x61, 0x73, 0x65, 0x52, 0x65, 0x73, 0x6f, 0x75, 0x72, 0x63,
+	0x65, 0x12, 0x50, 0x0a, 0x0a, 0x6d, 0x69, 0x67, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x30, 0x2e, 0x6e, 0x69, 0x74, 0x72, 0x69, 0x63, 0x2e, 0x70,
+	0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x72, 0x65, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x73, 0x2e, 0x76,
+	0x31, 0x2e, 0x53, 0x71, 0x6c, 0x44, 0x61, 0x74, 0x61, 0x62, 0x61, 0x73, 0x65, 0x4d, 0x69, 0x67,
+	0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x52, 0x0a, 0x6d, 0x69, 0x67, 0x72, 0x61, 0x74, 0x69,
+	0x6f, 0x6e, 0x73, 0x22, 0x55, 0x0a, 0x1d, 0x41, 0x70, 0x69, 0x4f, 0x70, 0x65, 0x6e, 0x49, 0x64,
+	0x43, 0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x44, 0x65, 0x66, 0x69, 0x6e, 0x69,
+	0x74, 0x69, 0x6f, 0x6e, 0x12, 0x16, 0x0a, 0x06, 0x69, 0x73, 0x73, 0x75, 0x65, 0x72, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x69, 0x73, 0x73, 0x75, 0x65, 0x72, 0x12, 0x1c, 0x0a, 0x09,
+	0x61, 0x75, 0x64, 0x69, 0x65, 0x6e, 0x63, 0x65, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x09, 0x52,
+	0x09, 0x61, 0x75, 0x64, 0x69, 0x65, 0x6e, 0x63, 0x65, 0x73, 0x22, 0x98, 0x01, 0x0a, 0x1d, 0x41,
+	0x70, 0x69, 0x53, 0x65, 0x63, 0x75, 0x72, 0x69, 0x74, 0x79, 0x44, 0x65, 0x66, 0x69, 0x6e, 0x69,
+	0x74, 0x69, 0x6f, 0x6e, 0x52, 0x65, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x12, 0x19, 0x0a, 0x08,
+	0x61, 0x70, 0x69, 0x5f, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07,
+	0x61, 0x70, 0x69, 0x4e, 0x61, 0x6d, 0x65, 0x12, 0x4e, 0x0a, 0x04, 0x6f, 0x69, 0x64, 0x63, 0x18,
+	0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x38, 0x2e, 0x6e, 0x69, 0x74, 0x72, 0x69, 0x63, 0x2e, 0x70,
+	0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x72, 0x65, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x73, 0x2e, 0x76,
+	0x31, 0x2e, 0x41, 0x70, 0x69, 0x4f, 0x70, 0x65, 0x6e, 0x49, 0x64, 0x43, 0x6f, 0x6e, 0x6e, 0x65,
+	0x63, 0x74, 0x69, 0x6f, 0x6e, 0x44, 0x65, 0x66, 0x69, 0x6e, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x48,
+	0x00, 0x52, 0x04, 0x6f, 0x69, 0x64, 0x63, 0x42, 0x0c, 0x0a, 0x0a, 0x64, 0x65, 0x66, 0x69, 0x6e,
+	0x69, 0x74, 0x69, 0x6f, 0x6e, 0x22, 0x23, 0x0a, 0x09, 0x41, 0x70, 0x69, 0x53, 0x63, 0x6f, 0x70,
+	0x65, 0x73, 0x12, 0x16, 0x0a, 0x06, 0x73, 0x63, 0x6f, 0x70, 0x65, 0x73, 0x18, 0x01, 0x20, 0x03,
+	0x28, 0x09, 0x52, 0x06, 0x73, 0x63, 0x6f, 0x70, 0x65, 0x73, 0x22, 0xc2, 0x01, 0x0a, 0x0b, 0x41,
+	0x70, 0x69, 0x52, 0x65, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x12, 0x50, 0x0a, 0x08, 0x73, 0x65,
+	0x63, 0x75, 0x72, 0x69, 0x74, 0x79, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x34, 0x2e, 0x6e,
+	0x69, 0x74, 0x72, 0x69, 0x63, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x72, 0x65, 0x73, 0x6f,
+	0x75, 0x72, 0x63, 0x65, 0x73, 0x2e, 0x76, 0x31, 0x2e, 0x41, 0x70, 0x69, 0x52, 0x65, 0x73, 0x6f,
+	0x75, 0x72, 0x63, 0x65, 0x2e, 0x53, 0x65, 0x63, 0x75, 0x72, 0x69, 0x74, 0x79, 0x45, 0x6e, 0x74,
+	0x72, 0x79, 0x52, 0x08, 0x73, 0x65, 0x63, 0x75, 0x72, 0x69, 0x74, 0x79, 0x1a, 0x61, 0x0a, 0x0d,
+	0x53, 0x65, 0x63, 0x75, 0x72, 0x69, 0x74, 0x79, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x10, 0x0a,
+	0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12,
+	0x3a, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x24,
+	0x2e, 0x6e, 0x69, 0x74, 0x72, 0x69, 0x63, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x72, 0x65,
+	0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x73, 0x2e, 0x76, 0x31, 0x2e, 0x41, 0x70, 0x69, 0x53, 0x63,
+	0x6f, 0x70, 0x65, 0x73, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38, 0x01, 0x22,
+	0x19, 0x0a, 0x17, 0x52, 0x65, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x44, 0x65, 0x63, 0x6c, 0x61,
+	0x72, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x2a, 0xfe, 0x01, 0x0a, 0x0c, 0x52,
+	0x65, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x54, 0x79, 0x70, 0x65, 0x12, 0x07, 0x0a, 0x03, 0x41,
+	0x70, 0x69, 0x10, 0x00, 0x12, 0x0b, 0x0a, 0x07, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x10,
+	0x01, 0x12, 0x0a, 0x0a, 0x06, 0x42, 0x75, 0x63, 0x6b, 0x65, 0x74, 0x10, 0x02, 0x12, 0x09, 0x0a,
+	0x05, 0x54, 0x6f, 0x70, 0x69, 0x63, 0x10, 0x03, 0x12, 0x0c, 0x0a, 0x08, 0x53, 0x63, 0x68, 0x65,
+	0x64, 0x75, 0x6c, 0x65, 0x10, 0x04, 0x12, 0x10, 0x0a, 0x0c, 0x53, 0x75, 0x62, 0x73, 0x63, 0x72,
+	0x69, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x10, 0x05, 0x12, 0x11, 0x0a, 0x0d, 0x4b, 0x65, 0x79, 0x56,
+	0x61, 0x6c, 0x75, 0x65, 0x53, 0x74, 0x6f, 0x72, 0x65, 0x10, 0x06, 0x12, 0x0a, 0x0a, 0x06, 0x50,
+	0x6f, 0x6c, 0x69, 0x63, 0x79, 0x10, 0x07, 0x12, 0x0a, 0x0a, 0x06, 0x53, 0x65, 0x63, 0x72, 0x65,
+	0x74, 0x10, 0x08, 0x12, 0x12, 0x0a, 0x0e, 0x42, 0x75, 0x63, 0x6b, 0x65, 0x74, 0x4c, 0x69, 0x73,
+	0x74, 0x65, 0x6e, 0x65, 0x72, 0x10, 0x09, 0x12, 0x0d, 0x0a, 0x09, 0x57, 0x65, 0x62, 0x73, 0x6f,
+	0x63, 0x6b, 0x65, 0x74, 0x10, 0x0a, 0x12, 0x08, 0x0a, 0x04, 0x48, 0x74, 0x74, 0x70, 0x10, 0x0b,
+	0x12, 0x19, 0x0a, 0x15, 0x41, 0x70, 0x69, 0x53, 0x65, 0x63, 0x75, 0x72, 0x69, 0x74, 0x79, 0x44,
+	0x65, 0x66, 0x69, 0x6e, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x10, 0x0c, 0x12, 0x09, 0x0a, 0x05, 0x51,
+	0x75, 0x65, 0x75, 0x65, 0x10, 0x0d, 0x12, 0x0f, 0x0a, 0x0b, 0x53, 0x71, 0x6c, 0x44, 0x61, 0x74,
+	0x61, 0x62, 0x61, 0x73, 0x65, 0x10, 0x0e, 0x12, 0x09, 0x0a, 0x05, 0x42, 0x61, 0x74, 0x63, 0x68,
+	0x10, 0x0f, 0x12, 0x07, 0x0a, 0x03, 0x4a, 0x6f, 0x62, 0x10, 0x10, 0x2a, 0xa5, 0x02, 0x0a, 0x06,
+	0x41, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x12, 0x0a, 0x0e, 0x42, 0x75, 0x63, 0x6b, 0x65, 0x74,
+	0x46, 0x69, 0x6c, 0x65, 0x4c, 0x69, 0x73, 0x74, 0x10, 0x00, 0x12, 0x11, 0x0a, 0x0d, 0x42, 0x75,
+	0x63, 0x6b, 0x65, 0x74, 0x46, 0x69, 0x6c, 0x65, 0x47, 0x65, 0x74, 0x10, 0x01, 0x12, 0x11, 0x0a,
+	0x0d, 0x42, 0x75, 0x63, 0x6b, 0x65, 0x74, 0x46, 0x69, 0x6c, 0x65, 0x50, 0x75, 0x74, 0x10, 0x02,
+	0x12, 0x14, 0x0a, 0x10, 0x42, 0x75, 0x63, 0x6b, 0x65, 0x74, 0x46, 0x69, 0x6c, 0x65, 0x44, 0x65,
+	0x6c, 0x65, 0x74, 0x65, 0x10, 0x03, 0x12, 0x11, 0x0a, 0x0c, 0x54, 0x6f, 0x70, 0x69, 0x63, 0x50,
+	0x75, 0x62, 0x6c, 0x69, 0x73, 0x68, 0x10, 0xc8, 0x01, 0x12, 0x16, 0x0a, 0x11, 0x4b, 0x65, 0x79,
+	0x56, 0x61, 0x6c, 0x75, 0x65, 0x53, 0x74, 0x6f, 0x72, 0x65, 0x52, 0x65, 0x61, 0x64, 0x10, 0xac,
+	0x02, 0x12, 0x17, 0x0a, 0x12, 0x4b, 0x65, 0x79, 0x56, 0x61, 0x6c, 0x75, 0x65, 0x53, 0x74, 0x6f,
+	0x72, 0x65, 0x57, 0x72, 0x69, 0x74, 0x65, 0x10, 0xad, 0x02, 0x12, 0x18, 0x0a, 0x13, 0x4b, 0x65,
+	0x79, 0x56, 0x61, 0x6c, 0x75, 0x65, 0x53, 0x74, 0x6f, 0x72, 0x65, 0x44, 0x65, 0x6c, 0x65, 0x74,
+	0x65, 0x10, 0xae, 0x02, 0x12, 0x0e, 0x0a, 0x09, 0x53, 0x65, 0x63, 0x72, 0x65, 0x74, 0x50, 0x75,
+	0x74, 0x10, 0x90, 0x03, 0x12, 0x11, 0x0a, 0x0c, 0x53, 0x65, 0x63, 0x72, 0x65, 0x74, 0x41, 0x63,
+	0x63, 0x65, 0x73, 0x73, 0x10, 0x91, 0x03, 0x12, 0x14, 0x0a, 0x0f, 0x57, 0x65, 0x62, 0x73, 0x6f,
+	0x63, 0x6b, 0x65, 0x74, 0x4d, 0x61, 0x6e, 0x61, 0x67, 0x65, 0x10, 0xf4, 0x03, 0x12, 0x11, 0x0a,
+	0x0c, 0x51, 0x75, 0x65, 0x75, 0x65, 0x45, 0x6e, 0x71, 0x75, 0x65, 0x75, 0x65, 0x10, 0xd8, 0x04,
+	0x12, 0x11, 0x0a, 0x0c, 0x51, 0x75, 0x65, 0x75, 0x65, 0x44, 0x65, 0x71, 0x75, 0x65, 0x75, 0x65,
+	0x10, 0xd9, 0x04, 0x12, 0x0e, 0x0a, 0x09, 0x4a, 0x6f, 0x62, 0x53, 0x75, 0x62, 0x6d, 0x69, 0x74,
+	0x10, 0xbc, 0x05, 0x32, 0x7d, 0x0a, 0x09, 0x52, 0x65, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x73,
+	0x12, 0x70, 0x0a, 0x07, 0x44, 0x65, 0x63, 0x6c, 0x61, 0x72, 0x65, 0x12, 0x31, 0x2e, 0x6e, 0x69,
+	0x74, 0x72, 0x69, 0x63, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x72, 0x65, 0x73, 0x6f, 0x75,
+	0x72, 0x63, 0x65, 0x73, 0x2e, 0x76, 0x31, 0x2e, 0x52, 0x65, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65,
+	0x44, 0x65, 0x63, 0x6c, 0x61, 0x72, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x32,
 	0x2e, 0x6e, 0x69, 0x74, 0x72, 0x69, 0x63, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x72, 0x65,
 	0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x73, 0x2e, 0x76, 0x31, 0x2e, 0x52, 0x65, 0x73, 0x6f, 0x75,
-	0x72, 0x63, 0x65, 0x44, 0x65, 0x63, 0x6c, 0x61, 0x72, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
-	0x74, 0x1a, 0x32, 0x2e, 0x6e, 0x69, 0x74, 0x72, 0x69, 0x63, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f,
-	0x2e, 0x72, 0x65, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x73, 0x2e, 0x76, 0x31, 0x2e, 0x52, 0x65,
-	0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x44, 0x65, 0x63, 0x6c, 0x61, 0x72, 0x65, 0x52, 0x65, 0x73,
-	0x70, 0x6f, 0x6e, 0x73, 0x65, 0x42, 0xb0, 0x01, 0x0a, 0x1c, 0x69, 0x6f, 0x2e, 0x6e, 0x69, 0x74,
-	0x72, 0x69, 0x63, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x72, 0x65, 0x73, 0x6f, 0x75, 0x72,
-	0x63, 0x65, 0x73, 0x2e, 0x76, 0x31, 0x42, 0x10, 0x52, 0x65, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65,
-	0x73, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x50, 0x01, 0x5a, 0x44, 0x67, 0x69, 0x74, 0x68,
-	0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x6e, 0x69, 0x74, 0x72, 0x69, 0x63, 0x74, 0x65, 0x63,
-	0x68, 0x2f, 0x6e, 0x69, 0x74, 0x72, 0x69, 0x63, 0x2f, 0x63, 0x6f, 0x72, 0x65, 0x2f, 0x70, 0x6b,
-	0x67, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2f, 0x72, 0x65, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65,
-	0x73, 0x2f, 0x76, 0x31, 0x3b, 0x72, 0x65, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x73, 0x70, 0x62,
-	0xaa, 0x02, 0x19, 0x4e, 0x69, 0x74, 0x72, 0x69, 0x63, 0x2e, 0x50, 0x72, 0x6f, 0x74, 0x6f, 0x2e,
-	0x52, 0x65, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x73, 0x2e, 0x76, 0x31, 0xca, 0x02, 0x19, 0x4e,
-	0x69, 0x74, 0x72, 0x69, 0x63, 0x5c, 0x50, 0x72, 0x6f, 0x74, 0x6f, 0x5c, 0x52, 0x65, 0x73, 0x6f,
-	0x75, 0x72, 0x63, 0x65, 0x73, 0x5c, 0x56, 0x31, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+	0x72, 0x63, 0x65, 0x44, 0x65, 0x63, 0x6c, 0x61, 0x72, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e,
+	0x73, 0x65, 0x42, 0xb0, 0x01, 0x0a, 0x1c, 0x69, 0x6f, 0x2e, 0x6e, 0x69, 0x74, 0x72, 0x69, 0x63,
+	0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x72, 0x65, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x73,
+	0x2e, 0x76, 0x31, 0x42, 0x10, 0x52, 0x65, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x73, 0x53, 0x65,
+	0x72, 0x76, 0x69, 0x63, 0x65, 0x50, 0x01, 0x5a, 0x44, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e,
+	0x63, 0x6f, 0x6d, 0x2f, 0x6e, 0x69, 0x74, 0x72, 0x69, 0x63, 0x74, 0x65, 0x63, 0x68, 0x2f, 0x6e,
+	0x69, 0x74, 0x72, 0x69, 0x63, 0x2f, 0x63, 0x6f, 0x72, 0x65, 0x2f, 0x70, 0x6b, 0x67, 0x2f, 0x70,
+	0x72, 0x6f, 0x74, 0x6f, 0x2f, 0x72, 0x65, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x73, 0x2f, 0x76,
+	0x31, 0x3b, 0x72, 0x65, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x73, 0x70, 0x62, 0xaa, 0x02, 0x19,
+	0x4e, 0x69, 0x74, 0x72, 0x69, 0x63, 0x2e, 0x50, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x52, 0x65, 0x73,
+	0x6f, 0x75, 0x72, 0x63, 0x65, 0x73, 0x2e, 0x76, 0x31, 0xca, 0x02, 0x19, 0x4e, 0x69, 0x74, 0x72,
+	0x69, 0x63, 0x5c, 0x50, 0x72, 0x6f, 0x74, 0x6f, 0x5c, 0x52, 0x65, 0x73, 0x6f, 0x75, 0x72, 0x63,
+	0x65, 0x73, 0x5c, 0x56, 0x31, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
 }
 
 var (
@@ -1331,7 +1421,7 @@ func file_nitric_proto_resources_v1_resources_proto_rawDescGZIP() []byte {
 }
 
 var file_nitric_proto_resources_v1_resources_proto_enumTypes = make([]protoimpl.EnumInfo, 2)
-var file_nitric_proto_resources_v1_resources_proto_msgTypes = make([]protoimpl.MessageInfo, 17)
+var file_nitric_proto_resources_v1_resources_proto_msgTypes = make([]protoimpl.MessageInfo, 18)
 var file_nitric_proto_resources_v1_resources_proto_goTypes = []interface{}{
 	(ResourceType)(0),                     // 0: nitric.proto.resources.v1.ResourceType
 	(Action)(0),                           // 1: nitric.proto.resources.v1.Action
@@ -1341,17 +1431,18 @@ var file_nitric_proto_resources_v1_resources_proto_goTypes = []interface{}{
 	(*BucketResource)(nil),                // 5: nitric.proto.resources.v1.BucketResource
 	(*TopicResource)(nil),                 // 6: nitric.proto.resources.v1.TopicResource
 	(*QueueResource)(nil),                 // 7: nitric.proto.resources.v1.QueueResource
-	(*KeyValueStoreResource)(nil),         // 8: nitric.proto.resources.v1.KeyValueStoreResource
-	(*SecretResource)(nil),                // 9: nitric.proto.resources.v1.SecretResource
-	(*JobResource)(nil),                   // 10: nitric.proto.resources.v1.JobResource
-	(*SqlDatabaseMigrations)(nil),         // 11: nitric.proto.resources.v1.SqlDatabaseMigrations
-	(*SqlDatabaseResource)(nil),           // 12: nitric.proto.resources.v1.SqlDatabaseResource
-	(*ApiOpenIdConnectionDefinition)(nil), // 13: nitric.proto.resources.v1.ApiOpenIdConnectionDefinition
-	(*ApiSecurityDefinitionResource)(nil), // 14: nitric.proto.resources.v1.ApiSecurityDefinitionResource
-	(*ApiScopes)(nil),                     // 15: nitric.proto.resources.v1.ApiScopes
-	(*ApiResource)(nil),                   // 16: nitric.proto.resources.v1.ApiResource
-	(*ResourceDeclareResponse)(nil),       // 17: nitric.proto.resources.v1.ResourceDeclareResponse
-	nil,                                   // 18: nitric.proto.resources.v1.ApiResource.SecurityEntry
+	(*QueueDeadLetterPolicy)(nil),         // 8: nitric.proto.resources.v1.QueueDeadLetterPolicy
+	(*KeyValueStoreResource)(nil),         // 9: nitric.proto.resources.v1.KeyValueStoreResource
+	(*SecretResource)(nil),                // 10: nitric.proto.resources.v1.SecretResource
+	(*JobResource)(nil),                   // 11: nitric.proto.resources.v1.JobResource
+	(*SqlDatabaseMigrations)(nil),         // 12: nitric.proto.resources.v1.SqlDatabaseMigrations
+	(*SqlDatabaseResource)(nil),           // 13: nitric.proto.resources.v1.SqlDatabaseResource
+	(*ApiOpenIdConnectionDefinition)(nil), // 14: nitric.proto.resources.v1.ApiOpenIdConnectionDefinition
+	(*ApiSecurityDefinitionResource)(nil), // 15: nitric.proto.resources.v1.ApiSecurityDefinitionResource
+	(*ApiScopes)(nil),                     // 16: nitric.proto.resources.v1.ApiScopes
+	(*ApiResource)(nil),                   // 17: nitric.proto.resources.v1.ApiResource
+	(*ResourceDeclareResponse)(nil),       // 18: nitric.proto.resources.v1.ResourceDeclareResponse
+	nil,                                   // 19: nitric.proto.resources.v1.ApiResource.SecurityEntry
 }
 var file_nitric_proto_resources_v1_resources_proto_depIdxs = []int32{
 	3,  // 0: nitric.proto.resources.v1.PolicyResource.principals:type_name -> nitric.proto.resources.v1.ResourceIdentifier
@@ -1362,24 +1453,25 @@ var file_nitric_proto_resources_v1_resources_proto_depIdxs = []int32{
 	2,  // 5: nitric.proto.resources.v1.ResourceDeclareRequest.policy:type_name -> nitric.proto.resources.v1.PolicyResource
 	5,  // 6: nitric.proto.resources.v1.ResourceDeclareRequest.bucket:type_name -> nitric.proto.resources.v1.BucketResource
 	6,  // 7: nitric.proto.resources.v1.ResourceDeclareRequest.topic:type_name -> nitric.proto.resources.v1.TopicResource
-	8,  // 8: nitric.proto.resources.v1.ResourceDeclareRequest.key_value_store:type_name -> nitric.proto.resources.v1.KeyValueStoreResource
-	9,  // 9: nitric.proto.resources.v1.ResourceDeclareRequest.secret:type_name -> nitric.proto.resources.v1.SecretResource
-	16, // 10: nitric.proto.resources.v1.ResourceDeclareRequest.api:type_name -> nitric.proto.resources.v1.ApiResource
-	14, // 11: nitric.proto.resources.v1.ResourceDeclareRequest.api_security_definition:type_name -> nitric.proto.resources.v1.ApiSecurityDefinitionResource
+	9,  // 8: nitric.proto.resources.v1.ResourceDeclareRequest.key_value_store:type_name -> nitric.proto.resources.v1.KeyValueStoreResource
+	10, // 9: nitric.proto.resources.v1.ResourceDeclareRequest.secret:type_name -> nitric.proto.resources.v1.SecretResource
+	17, // 10: nitric.proto.resources.v1.ResourceDeclareRequest.api:type_name -> nitric.proto.resources.v1.ApiResource
+	15, // 11: nitric.proto.resources.v1.ResourceDeclareRequest.api_security_definition:type_name -> nitric.proto.resources.v1.ApiSecurityDefinitionResource
 	7,  // 12: nitric.proto.resources.v1.ResourceDeclareRequest.queue:type_name -> nitric.proto.resources.v1.QueueResource
-	12, // 13: nitric.proto.resources.v1.ResourceDeclareRequest.sql_database:type_name -> nitric.proto.resources.v1.SqlDatabaseResource
-	10, // 14: nitric.proto.resources.v1.ResourceDeclareRequest.job:type_name -> nitric.proto.resources.v1.JobResource
-	11, // 15: nitric.proto.resources.v1.SqlDatabaseResource.migrations:type_name -> nitric.proto.resources.v1.SqlDatabaseMigrations
-	13, // 16: nitric.proto.resources.v1.ApiSecurityDefinitionResource.oidc:type_name -> nitric.proto.resources.v1.ApiOpenIdConnectionDefinition
-	18, // 17: nitric.proto.resources.v1.ApiResource.security:type_name -> nitric.proto.resources.v1.ApiResource.SecurityEntry
-	15, // 18: nitric.proto.resources.v1.ApiResource.SecurityEntry.value:type_name -> nitric.proto.resources.v1.ApiScopes
-	4,  // 19: nitric.proto.resources.v1.Resources.Declare:input_type -> nitric.proto.resources.v1.ResourceDeclareRequest
-	17, // 20: nitric.proto.resources.v1.Resources.Declare:output_type -> nitric.proto.resources.v1.ResourceDeclareResponse
-	20, // [20:21] is the sub-list for method output_type
-	19, // [19:20] is the sub-list for method input_type
-	19, // [19:19] is the sub-list for extension type_name
-	19, // [19:19] is the sub-list for extension extendee
-	0,  // [0:19] is the sub-list for field type_name
+	13, // 13: nitric.proto.resources.v1.ResourceDeclareRequest.sql_database:type_name -> nitric.proto.resources.v1.SqlDatabaseResource
+	11, // 14: nitric.proto.resources.v1.ResourceDeclareRequest.job:type_name -> nitric.proto.resources.v1.JobResource
+	8,  // 15: nitric.proto.resources.v1.QueueResource.dead_letter_policy:type_name -> nitric.proto.resources.v1.QueueDeadLetterPolicy
+	12, // 16: nitric.proto.resources.v1.SqlDatabaseResource.migrations:type_name -> nitric.proto.resources.v1.SqlDatabaseMigrations
+	14, // 17: nitric.proto.resources.v1.ApiSecurityDefinitionResource.oidc:type_name -> nitric.proto.resources.v1.ApiOpenIdConnectionDefinition
+	19, // 18: nitric.proto.resources.v1.ApiResource.security:type_name -> nitric.proto.resources.v1.ApiResource.SecurityEntry
+	16, // 19: nitric.proto.resources.v1.ApiResource.SecurityEntry.value:type_name -> nitric.proto.resources.v1.ApiScopes
+	4,  // 20: nitric.proto.resources.v1.Resources.Declare:input_type -> nitric.proto.resources.v1.ResourceDeclareRequest
+	18, // 21: nitric.proto.resources.v1.Resources.Declare:output_type -> nitric.proto.resources.v1.ResourceDeclareResponse
+	21, // [21:22] is the sub-list for method output_type
+	20, // [20:21] is the sub-list for method input_type
+	20, // [20:20] is the sub-list for extension type_name
+	20, // [20:20] is the sub-list for extension extendee
+	0,  // [0:20] is the sub-list for field type_name
 }
 
 func init() { file_nitric_proto_resources_v1_resources_proto_init() }
@@ -1461,7 +1553,7 @@ func file_nitric_proto_resources_v1_resources_proto_init() {
 			}
 		}
 		file_nitric_proto_resources_v1_resources_proto_msgTypes[6].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*KeyValueStoreResource); i {
+			switch v := v.(*QueueDeadLetterPolicy); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1473,7 +1565,7 @@ func file_nitric_proto_resources_v1_resources_proto_init() {
 			}
 		}
 		file_nitric_proto_resources_v1_resources_proto_msgTypes[7].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*SecretResource); i {
+			switch v := v.(*KeyValueStoreResource); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1485,7 +1577,7 @@ func file_nitric_proto_resources_v1_resources_proto_init() {
 			}
 		}
 		file_nitric_proto_resources_v1_resources_proto_msgTypes[8].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*JobResource); i {
+			switch v := v.(*SecretResource); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1497,7 +1589,7 @@ func file_nitric_proto_resources_v1_resources_proto_init() {
 			}
 		}
 		file_nitric_proto_resources_v1_resources_proto_msgTypes[9].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*SqlDatabaseMigrations); i {
+			switch v := v.(*JobResource); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1509,7 +1601,7 @@ func file_nitric_proto_resources_v1_resources_proto_init() {
 			}
 		}
 		file_nitric_proto_resources_v1_resources_proto_msgTypes[10].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*SqlDatabaseResource); i {
+			switch v := v.(*SqlDatabaseMigrations); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1521,7 +1613,7 @@ func file_nitric_proto_resources_v1_resources_proto_init() {
 			}
 		}
 		file_nitric_proto_resources_v1_resources_proto_msgTypes[11].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*ApiOpenIdConnectionDefinition); i {
+			switch v := v.(*SqlDatabaseResource); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1533,7 +1625,7 @@ func file_nitric_proto_resources_v1_resources_proto_init() {
 			}
 		}
 		file_nitric_proto_resources_v1_resources_proto_msgTypes[12].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*ApiSecurityDefinitionResource); i {
+			switch v := v.(*ApiOpenIdConnectionDefinition); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1545,7 +1637,7 @@ func file_nitric_proto_resources_v1_resources_proto_init() {
 			}
 		}
 		file_nitric_proto_resources_v1_resources_proto_msgTypes[13].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*ApiScopes); i {
+			switch v := v.(*ApiSecurityDefinitionResource); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1557,7 +1649,7 @@ func file_nitric_proto_resources_v1_resources_proto_init() {
 			}
 		}
 		file_nitric_proto_resources_v1_resources_proto_msgTypes[14].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*ApiResource); i {
+			switch v := v.(*ApiScopes); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1569,6 +1661,18 @@ func file_nitric_proto_resources_v1_resources_proto_init() {
 			}
 		}
 		file_nitric_proto_resources_v1_resources_proto_msgTypes[15].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ApiResource); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_nitric_proto_resources_v1_resources_proto_msgTypes[16].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*ResourceDeclareResponse); i {
 			case 0:
 				return &v.state
@@ -1593,10 +1697,10 @@ func file_nitric_proto_resources_v1_resources_proto_init() {
 		(*ResourceDeclareRequest_SqlDatabase)(nil),
 		(*ResourceDeclareRequest_Job)(nil),
 	}
-	file_nitric_proto_resources_v1_resources_proto_msgTypes[9].OneofWrappers = []interface{}{
+	file_nitric_proto_resources_v1_resources_proto_msgTypes[10].OneofWrappers = []interface{}{
 		(*SqlDatabaseMigrations_MigrationsPath)(nil),
 	}
-	file_nitric_proto_resources_v1_resources_proto_msgTypes[12].OneofWrappers = []interface{}{
+	file_nitric_proto_resources_v1_resources_proto_msgTypes[13].OneofWrappers = []interface{}{
 		(*ApiSecurityDefinitionResource_Oidc)(nil),
 	}
 	type x struct{}
@@ -1605,7 +1709,7 @@ func file_nitric_proto_resources_v1_resources_proto_init() {
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: file_nitric_proto_resources_v1_resources_proto_rawDesc,
 			NumEnums:      2,
-			NumMessages:   17,
+			NumMessages:   18,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
@@ -172,7 +172,10 @@ type ServerMessage struct {
 	//
 	//	*ServerMessage_RegistrationResponse
 	//	*ServerMessage_IntervalRequest
+	//	*ServerMessage_CancellationRequest
 	Content isServerMessage_Content `protobuf_oneof:"content"`
+	// W3C trace context (e.g. traceparent and tracestate) of the trigger, used to continue its trace in the service
+	TraceContext map[string]string `protobuf:"bytes,10,rep,name=trace_context,json=traceContext,proto3" json:"trace_context,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
 }
 
 func (x *ServerMessage) Reset() {
@@ -235,6 +238,20 @@ func (x *ServerMessage) GetIntervalRequest() *IntervalRequest {
 	return nil
 }
 
+func (x *ServerMessage) GetCancellationRequest() *CancellationRequest {
+	if x, ok := x.GetContent().(*ServerMessage_CancellationRequest); ok {
+		return x.CancellationRequest
+	}
+	return nil
+}
+
+func (x *ServerMessage) GetTraceContext() map[string]string {
+	if x != nil {
+		return x.TraceContext
+	}
+	return nil
+}
+
 type isServerMessage_Content interface {
 	isServerMessage_Content()
 }
@@ -249,10 +266,67 @@ type ServerMessage_IntervalRequest struct {
 	IntervalRequest *IntervalRequest `protobuf:"bytes,3,opt,name=interval_request,json=intervalRequest,proto3,oneof"`
 }
 
+type ServerMessage_CancellationRequest struct {
+	// Notifies the worker that a previous request with the same ID has been cancelled,
+	// typically because its deadline was exceeded. The worker should abort processing.
+	CancellationRequest *CancellationRequest `protobuf:"bytes,4,opt,name=cancellation_request,json=cancellationRequest,proto3,oneof"`
+}
+
 func (*ServerMessage_RegistrationResponse) isServerMessage_Content() {}
 
 func (*ServerMessage_IntervalRequest) isServerMessage_Content() {}
 
+func (*ServerMessage_CancellationRequest) isServerMessage_Content() {}
+
+// Notification that an in-flight request was cancelled by the server
+type CancellationRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// The reason the request was cancelled
+	Reason string `protobuf:"bytes,1,opt,name=reason,proto3" json:"reason,omitempty"`
+}
+
+func (x *CancellationRequest) Reset() {
+	*x = CancellationRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_nitric_proto_schedules_v1_schedules_proto_msgTypes[3]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *CancellationRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CancellationRequest) ProtoMessage() {}
+
+func (x *CancellationRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_nitric_proto_schedules_v1_schedules_proto_msgTypes[3]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CancellationRequest.ProtoReflect.Descriptor instead.
+func (*CancellationRequest) Descriptor() ([]byte, []int) {
+	return file_nitric_proto_schedules_v1_schedules_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *CancellationRequest) GetReason() string {
+	if x != nil {
+		return x.Reason
+	}
+	return ""
+}
+
 type RegistrationRequest struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
@@ -269,7 +343,7 @@ type RegistrationRequest struct {
 func (x *RegistrationRequest) Reset() {
 	*x = RegistrationRequest{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_nitric_proto_schedules_v1_schedules_proto_msgTypes[3]
+		mi := &file_nitric_proto_schedules_v1_schedules_proto_msgTypes[4]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -282,7 +356,7 @@ func (x *RegistrationRequest) String() string {
 func (*RegistrationRequest) ProtoMessage() {}
 
 func (x *RegistrationRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_nitric_proto_schedules_v1_schedules_proto_msgTypes[3]
+	mi := &file_nitric_proto_schedules_v1_schedules_proto_msgTypes[4]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -295,7 +369,7 @@ func (x *RegistrationRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use RegistrationRequest.ProtoReflect.Descriptor instead.
 func (*RegistrationRequest) Descriptor() ([]byte, []int) {
-	return file_nitric_proto_schedules_v1_schedules_proto_rawDescGZIP(), []int{3}
+	return file_nitric_proto_schedules_v1_schedules_proto_rawDescGZIP(), []int{4}
 }
 
 func (x *RegistrationRequest) GetScheduleName() string {
@@ -353,7 +427,7 @@ type ScheduleEvery struct {
 func (x *ScheduleEvery) Reset() {
 	*x = ScheduleEvery{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_nitric_proto_schedules_v1_schedules_proto_msgTypes[4]
+		mi := &file_nitric_proto_schedules_v1_schedules_proto_msgTypes[5]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -366,7 +440,7 @@ func (x *ScheduleEvery) String() string {
 func (*ScheduleEvery) ProtoMessage() {}
 
 func (x *ScheduleEvery) ProtoReflect() protoreflect.Message {
-	mi := &file_nitric_proto_schedules_v1_schedules_proto_msgTypes[4]
+	mi := &file_nitric_proto_schedules_v1_schedules_proto_msgTypes[5]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -379,7 +453,7 @@ func (x *ScheduleEvery) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ScheduleEvery.ProtoReflect.Descriptor instead.
 func (*ScheduleEvery) Descriptor() ([]byte, []int) {
-	return file_nitric_proto_schedules_v1_schedules_proto_rawDescGZIP(), []int{4}
+	return file_nitric_proto_schedules_v1_schedules_proto_rawDescGZIP(), []int{5}
 }
 
 func (x *ScheduleEvery) GetRate() string {
@@ -400,7 +474,7 @@ type ScheduleCron struct {
 func (x *ScheduleCron) Reset() {
 	*x = ScheduleCron{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_nitric_proto_schedules_v1_schedules_proto_msgTypes[5]
+		mi := &file_nitric_proto_schedules_v1_schedules_proto_msgTypes[6]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -413,7 +487,7 @@ func (x *ScheduleCron) String() string {
 func (*ScheduleCron) ProtoMessage() {}
 
 func (x *ScheduleCron) ProtoReflect() protoreflect.Message {
-	mi := &file_nitric_proto_schedules_v1_schedules_proto_msgTypes[5]
+	mi := &file_nitric_proto_schedules_v1_schedules_proto_msgTypes[6]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -426,7 +500,7 @@ func (x *ScheduleCron) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ScheduleCron.ProtoReflect.Descriptor instead.
 func (*ScheduleCron) Descriptor() ([]byte, []int) {
-	return file_nitric_proto_schedules_v1_schedules_proto_rawDescGZIP(), []int{5}
+	return file_nitric_proto_schedules_v1_schedules_proto_rawDescGZIP(), []int{6}
 }
 
 func (x *ScheduleCron) GetExpression() string {
@@ -445,7 +519,7 @@ type RegistrationResponse struct {
 func (x *RegistrationResponse) Reset() {
 	*x = RegistrationResponse{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_nitric_proto_schedules_v1_schedules_proto_msgTypes[6]
+		mi := &file_nitric_proto_schedules_v1_schedules_proto_msgTypes[7]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -458,7 +532,7 @@ func (x *RegistrationResponse) String() string {
 func (*RegistrationResponse) ProtoMessage() {}
 
 func (x *RegistrationResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_nitric_proto_schedules_v1_schedules_proto_msgTypes[6]
+	mi := &file_nitric_proto_schedules_v1_schedules_proto_msgTypes[7]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -471,7 +545,7 @@ func (x *RegistrationResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use RegistrationResponse.ProtoReflect.Descriptor instead.
 func (*RegistrationResponse) Descriptor() ([]byte, []int) {
-	return file_nitric_proto_schedules_v1_schedules_proto_rawDescGZIP(), []int{6}
+	return file_nitric_proto_schedules_v1_schedules_proto_rawDescGZIP(), []int{7}
 }
 
 type IntervalResponse struct {
@@ -483,7 +557,7 @@ type IntervalResponse struct {
 func (x *IntervalResponse) Reset() {
 	*x = IntervalResponse{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_nitric_proto_schedules_v1_schedules_proto_msgTypes[7]
+		mi := &file_nitric_proto_schedules_v1_schedules_proto_msgTypes[8]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -496,7 +570,7 @@ func (x *IntervalResponse) String() string {
 func (*IntervalResponse) ProtoMessage() {}
 
 func (x *IntervalResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_nitric_proto_schedules_v1_schedules_proto_msgTypes[7]
+	mi := &file_nitric_proto_schedules_v1_schedules_proto_msgTypes[8]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -509,7 +583,7 @@ func (x *IntervalResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use IntervalResponse.ProtoReflect.Descriptor instead.
 func (*IntervalResponse) Descriptor() ([]byte, []int) {
-	return file_nitric_proto_schedules_v1_schedules_proto_rawDescGZIP(), []int{7}
+	return file_nitric_proto_schedules_v1_schedules_proto_rawDescGZIP(), []int{8}
 }
 
 var File_nitric_proto_schedules_v1_schedules_proto protoreflect.FileDescriptor
@@ -537,7 +611,7 @@ var file_nitric_proto_schedules_v1_schedules_proto_rawDesc = []byte{
 	0x74, 0x65, 0x6e, 0x74, 0x22, 0x36, 0x0a, 0x0f, 0x49, 0x6e, 0x74, 0x65, 0x72, 0x76, 0x61, 0x6c,
 	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x23, 0x0a, 0x0d, 0x73, 0x63, 0x68, 0x65, 0x64,
 	0x75, 0x6c, 0x65, 0x5f, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0c,
-	0x73, 0x63, 0x68, 0x65, 0x64, 0x75, 0x6c, 0x65, 0x4e, 0x61, 0x6d, 0x65, 0x22, 0xeb, 0x01, 0x0a,
+	0x73, 0x63, 0x68, 0x65, 0x64, 0x75, 0x6c, 0x65, 0x4e, 0x61, 0x6d, 0x65, 0x22, 0xf2, 0x03, 0x0a,
 	0x0d, 0x53, 0x65, 0x72, 0x76, 0x65, 0x72, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x12, 0x0e,
 	0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x02, 0x69, 0x64, 0x12, 0x66,
 	0x0a, 0x15, 0x72, 0x65, 0x67, 0x69, 0x73, 0x74, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x5f, 0x72,
@@ -551,47 +625,67 @@ var file_nitric_proto_schedules_v1_schedules_proto_rawDesc = []byte{
 	0x32, 0x2a, 0x2e, 0x6e, 0x69, 0x74, 0x72, 0x69, 0x63, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e,
 	0x73, 0x63, 0x68, 0x65, 0x64, 0x75, 0x6c, 0x65, 0x73, 0x2e, 0x76, 0x31, 0x2e, 0x49, 0x6e, 0x74,
 	0x65, 0x72, 0x76, 0x61, 0x6c, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x48, 0x00, 0x52, 0x0f,
-	0x69, 0x6e, 0x74, 0x65, 0x72, 0x76, 0x61, 0x6c, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x42,
-	0x09, 0x0a, 0x07, 0x63, 0x6f, 0x6e, 0x74, 0x65, 0x6e, 0x74, 0x22, 0xc6, 0x01, 0x0a, 0x13, 0x52,
-	0x65, 0x67, 0x69, 0x73, 0x74, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x65, 0x71, 0x75, 0x65,
-	0x73, 0x74, 0x12, 0x23, 0x0a, 0x0d, 0x73, 0x63, 0x68, 0x65, 0x64, 0x75, 0x6c, 0x65, 0x5f, 0x6e,
-	0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0c, 0x73, 0x63, 0x68, 0x65, 0x64,
-	0x75, 0x6c, 0x65, 0x4e, 0x61, 0x6d, 0x65, 0x12, 0x40, 0x0a, 0x05, 0x65, 0x76, 0x65, 0x72, 0x79,
-	0x18, 0x0a, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x28, 0x2e, 0x6e, 0x69, 0x74, 0x72, 0x69, 0x63, 0x2e,
-	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x73, 0x63, 0x68, 0x65, 0x64, 0x75, 0x6c, 0x65, 0x73, 0x2e,
-	0x76, 0x31, 0x2e, 0x53, 0x63, 0x68, 0x65, 0x64, 0x75, 0x6c, 0x65, 0x45, 0x76, 0x65, 0x72, 0x79,
-	0x48, 0x00, 0x52, 0x05, 0x65, 0x76, 0x65, 0x72, 0x79, 0x12, 0x3d, 0x0a, 0x04, 0x63, 0x72, 0x6f,
-	0x6e, 0x18, 0x0b, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x27, 0x2e, 0x6e, 0x69, 0x74, 0x72, 0x69, 0x63,
-	0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x73, 0x63, 0x68, 0x65, 0x64, 0x75, 0x6c, 0x65, 0x73,
-	0x2e, 0x76, 0x31, 0x2e, 0x53, 0x63, 0x68, 0x65, 0x64, 0x75, 0x6c, 0x65, 0x43, 0x72, 0x6f, 0x6e,
-	0x48, 0x00, 0x52, 0x04, 0x63, 0x72, 0x6f, 0x6e, 0x42, 0x09, 0x0a, 0x07, 0x63, 0x61, 0x64, 0x65,
-	0x6e, 0x63, 0x65, 0x22, 0x23, 0x0a, 0x0d, 0x53, 0x63, 0x68, 0x65, 0x64, 0x75, 0x6c, 0x65, 0x45,
-	0x76, 0x65, 0x72, 0x79, 0x12, 0x12, 0x0a, 0x04, 0x72, 0x61, 0x74, 0x65, 0x18, 0x01, 0x20, 0x01,
-	0x28, 0x09, 0x52, 0x04, 0x72, 0x61, 0x74, 0x65, 0x22, 0x2e, 0x0a, 0x0c, 0x53, 0x63, 0x68, 0x65,
-	0x64, 0x75, 0x6c, 0x65, 0x43, 0x72, 0x6f, 0x6e, 0x12, 0x1e, 0x0a, 0x0a, 0x65, 0x78, 0x70, 0x72,
-	0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0a, 0x65, 0x78,
-	0x70, 0x72, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x22, 0x16, 0x0a, 0x14, 0x52, 0x65, 0x67, 0x69,
-	0x73, 0x74, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65,
-	0x22, 0x12, 0x0a, 0x10, 0x49, 0x6e, 0x74, 0x65, 0x72, 0x76, 0x61, 0x6c, 0x52, 0x65, 0x73, 0x70,
-	0x6f, 0x6e, 0x73, 0x65, 0x32, 0x6f, 0x0a, 0x09, 0x53, 0x63, 0x68, 0x65, 0x64, 0x75, 0x6c, 0x65,
-	0x73, 0x12, 0x62, 0x0a, 0x08, 0x53, 0x63, 0x68, 0x65, 0x64, 0x75, 0x6c, 0x65, 0x12, 0x28, 0x2e,
+	0x69, 0x6e, 0x74, 0x65, 0x72, 0x76, 0x61, 0x6c, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12,
+	0x63, 0x0a, 0x14, 0x63, 0x61, 0x6e, 0x63, 0x65, 0x6c, 0x6c, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x5f,
+	0x72, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x2e, 0x2e,
 	0x6e, 0x69, 0x74, 0x72, 0x69, 0x63, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x73, 0x63, 0x68,
-	0x65, 0x64, 0x75, 0x6c, 0x65, 0x73, 0x2e, 0x76, 0x31, 0x2e, 0x43, 0x6c, 0x69, 0x65, 0x6e, 0x74,
-	0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x1a, 0x28, 0x2e, 0x6e, 0x69, 0x74, 0x72, 0x69, 0x63,
-	0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x73, 0x63, 0x68, 0x65, 0x64, 0x75, 0x6c, 0x65, 0x73,
-	0x2e, 0x76, 0x31, 0x2e, 0x53, 0x65, 0x72, 0x76, 0x65, 0x72, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67,
-	0x65, 0x28, 0x01, 0x30, 0x01, 0x42, 0xb0, 0x01, 0x0a, 0x1c, 0x69, 0x6f, 0x2e, 0x6e, 0x69, 0x74,
-	0x72, 0x69, 0x63, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x73, 0x63, 0x68, 0x65, 0x64, 0x75,
-	0x6c, 0x65, 0x73, 0x2e, 0x76, 0x31, 0x42, 0x10, 0x53, 0x63, 0x68, 0x65, 0x64, 0x75, 0x6c, 0x65,
-	0x73, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x50, 0x01, 0x5a, 0x44, 0x67, 0x69, 0x74, 0x68,
-	0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x6e, 0x69, 0x74, 0x72, 0x69, 0x63, 0x74, 0x65, 0x63,
-	0x68, 0x2f, 0x6e, 0x69, 0x74, 0x72, 0x69, 0x63, 0x2f, 0x63, 0x6f, 0x72, 0x65, 0x2f, 0x70, 0x6b,
-	0x67, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2f, 0x73, 0x63, 0x68, 0x65, 0x64, 0x75, 0x6c, 0x65,
-	0x73, 0x2f, 0x76, 0x31, 0x3b, 0x73, 0x63, 0x68, 0x65, 0x64, 0x75, 0x6c, 0x65, 0x73, 0x70, 0x62,
-	0xaa, 0x02, 0x19, 0x4e, 0x69, 0x74, 0x72, 0x69, 0x63, 0x2e, 0x50, 0x72, 0x6f, 0x74, 0x6f, 0x2e,
-	0x53, 0x63, 0x68, 0x65, 0x64, 0x75, 0x6c, 0x65, 0x73, 0x2e, 0x76, 0x31, 0xca, 0x02, 0x19, 0x4e,
-	0x69, 0x74, 0x72, 0x69, 0x63, 0x5c, 0x50, 0x72, 0x6f, 0x74, 0x6f, 0x5c, 0x53, 0x63, 0x68, 0x65,
-	0x64, 0x75, 0x6c, 0x65, 0x73, 0x5c, 0x56, 0x31, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+	0x65, 0x64, 0x75, 0x6c, 0x65, 0x73, 0x2e, 0x76, 0x31, 0x2e, 0x43, 0x61, 0x6e, 0x63, 0x65, 0x6c,
+	0x6c, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x48, 0x00, 0x52,
+	0x13, 0x63, 0x61, 0x6e, 0x63, 0x65, 0x6c, 0x6c, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x65, 0x71,
+	0x75, 0x65, 0x73, 0x74, 0x12, 0x5f, 0x0a, 0x0d, 0x74, 0x72, 0x61, 0x63, 0x65, 0x5f, 0x63, 0x6f,
+	0x6e, 0x74, 0x65, 0x78, 0x74, 0x18, 0x0a, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x3a, 0x2e, 0x6e, 0x69,
+	0x74, 0x72, 0x69, 0x63, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x73, 0x63, 0x68, 0x65, 0x64,
+	0x75, 0x6c, 0x65, 0x73, 0x2e, 0x76, 0x31, 0x2e, 0x53, 0x65, 0x72, 0x76, 0x65, 0x72, 0x4d, 0x65,
+	0x73, 0x73, 0x61, 0x67, 0x65, 0x2e, 0x54, 0x72, 0x61, 0x63, 0x65, 0x43, 0x6f, 0x6e, 0x74, 0x65,
+	0x78, 0x74, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x52, 0x0c, 0x74, 0x72, 0x61, 0x63, 0x65, 0x43, 0x6f,
+	0x6e, 0x74, 0x65, 0x78, 0x74, 0x1a, 0x3f, 0x0a, 0x11, 0x54, 0x72, 0x61, 0x63, 0x65, 0x43, 0x6f,
+	0x6e, 0x74, 0x65, 0x78, 0x74, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65,
+	0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x14, 0x0a, 0x05,
+	0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x76, 0x61, 0x6c,
+	0x75, 0x65, 0x3a, 0x02, 0x38, 0x01, 0x42, 0x09, 0x0a, 0x07, 0x63, 0x6f, 0x6e, 0x74, 0x65, 0x6e,
+	0x74, 0x22, 0x2d, 0x0a, 0x13, 0x43, 0x61, 0x6e, 0x63, 0x65, 0x6c, 0x6c, 0x61, 0x74, 0x69, 0x6f,
+	0x6e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x16, 0x0a, 0x06, 0x72, 0x65, 0x61, 0x73,
+	0x6f, 0x6e, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x72, 0x65, 0x61, 0x73, 0x6f, 0x6e,
+	0x22, 0xc6, 0x01, 0x0a, 0x13, 0x52, 0x65, 0x67, 0x69, 0x73, 0x74, 0x72, 0x61, 0x74, 0x69, 0x6f,
+	0x6e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x23, 0x0a, 0x0d, 0x73, 0x63, 0x68, 0x65,
+	0x64, 0x75, 0x6c, 0x65, 0x5f, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x0c, 0x73, 0x63, 0x68, 0x65, 0x64, 0x75, 0x6c, 0x65, 0x4e, 0x61, 0x6d, 0x65, 0x12, 0x40, 0x0a,
+	0x05, 0x65, 0x76, 0x65, 0x72, 0x79, 0x18, 0x0a, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x28, 0x2e, 0x6e,
+	0x69, 0x74, 0x72, 0x69, 0x63, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x73, 0x63, 0x68, 0x65,
+	0x64, 0x75, 0x6c, 0x65, 0x73, 0x2e, 0x76, 0x31, 0x2e, 0x53, 0x63, 0x68, 0x65, 0x64, 0x75, 0x6c,
+	0x65, 0x45, 0x76, 0x65, 0x72, 0x79, 0x48, 0x00, 0x52, 0x05, 0x65, 0x76, 0x65, 0x72, 0x79, 0x12,
+	0x3d, 0x0a, 0x04, 0x63, 0x72, 0x6f, 0x6e, 0x18, 0x0b, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x27, 0x2e,
+	0x6e, 0x69, 0x74, 0x72, 0x69, 0x63, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x73, 0x63, 0x68,
+	0x65, 0x64, 0x75, 0x6c, 0x65, 0x73, 0x2e, 0x76, 0x31, 0x2e, 0x53, 0x63, 0x68, 0x65, 0x64, 0x75,
+	0x6c, 0x65, 0x43, 0x72, 0x6f, 0x6e, 0x48, 0x00, 0x52, 0x04, 0x63, 0x72, 0x6f, 0x6e, 0x42, 0x09,
+	0x0a, 0x07, 0x63, 0x61, 0x64, 0x65, 0x6e, 0x63, 0x65, 0x22, 0x23, 0x0a, 0x0d, 0x53, 0x63, 0x68,
+	0x65, 0x64, 0x75, 0x6c, 0x65, 0x45, 0x76, 0x65, 0x72, 0x79, 0x12, 0x12, 0x0a, 0x04, 0x72, 0x61,
+	0x74, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x72, 0x61, 0x74, 0x65, 0x22, 0x2e,
+	0x0a, 0x0c, 0x53, 0x63, 0x68, 0x65, 0x64, 0x75, 0x6c, 0x65, 0x43, 0x72, 0x6f, 0x6e, 0x12, 0x1e,
+	0x0a, 0x0a, 0x65, 0x78, 0x70, 0x72, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x0a, 0x65, 0x78, 0x70, 0x72, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x22, 0x16,
+	0x0a, 0x14, 0x52, 0x65, 0x67, 0x69, 0x73, 0x74, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x65,
+	0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x12, 0x0a, 0x10, 0x49, 0x6e, 0x74, 0x65, 0x72, 0x76,
+	0x61, 0x6c, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x32, 0x6f, 0x0a, 0x09, 0x53, 0x63,
+	0x68, 0x65, 0x64, 0x75, 0x6c, 0x65, 0x73, 0x12, 0x62, 0x0a, 0x08, 0x53, 0x63, 0x68, 0x65, 0x64,
+	0x75, 0x6c, 0x65, 0x12, 0x28, 0x2e, 0x6e, 0x69, 0x74, 0x72, 0x69, 0x63, 0x2e, 0x70, 0x72, 0x6f,
+	0x74, 0x6f, 0x2e, 0x73, 0x63, 0x68, 0x65, 0x64, 0x75, 0x6c, 0x65, 0x73, 0x2e, 0x76, 0x31, 0x2e,
+	0x43, 0x6c, 0x69, 0x65, 0x6e, 0x74, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x1a, 0x28, 0x2e,
+	0x6e, 0x69, 0x74, 0x72, 0x69, 0x63, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x73, 0x63, 0x68,
+	0x65, 0x64, 0x75, 0x6c, 0x65, 0x73, 0x2e, 0x76, 0x31, 0x2e, 0x53, 0x65, 0x72, 0x76, 0x65, 0x72,
+	0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x28, 0x01, 0x30, 0x01, 0x42, 0xb0, 0x01, 0x0a, 0x1c,
+	0x69, 0x6f, 0x2e, 0x6e, 0x69, 0x74, 0x72, 0x69, 0x63, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e,
+	0x73, 0x63, 0x68, 0x65, 0x64, 0x75, 0x6c, 0x65, 0x73, 0x2e, 0x76, 0x31, 0x42, 0x10, 0x53, 0x63,
+	0x68, 0x65, 0x64, 0x75, 0x6c, 0x65, 0x73, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x50, 0x01,
+	0x5a, 0x44, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x6e, 0x69, 0x74,
+	0x72, 0x69, 0x63, 0x74, 0x65, 0x63, 0x68, 0x2f, 0x6e, 0x69, 0x74, 0x72, 0x69, 0x63, 0x2f, 0x63,
+	0x6f, 0x72, 0x65, 0x2f, 0x70, 0x6b, 0x67, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2f, 0x73, 0x63,
+	0x68, 0x65, 0x64, 0x75, 0x6c, 0x65, 0x73, 0x2f, 0x76, 0x31, 0x3b, 0x73, 0x63, 0x68, 0x65, 0x64,
+	0x75, 0x6c, 0x65, 0x73, 0x70, 0x62, 0xaa, 0x02, 0x19, 0x4e, 0x69, 0x74, 0x72, 0x69, 0x63, 0x2e,
+	0x50, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x53, 0x63, 0x68, 0x65, 0x64, 0x75, 0x6c, 0x65, 0x73, 0x2e,
+	0x76, 0x31, 0xca, 0x02, 0x19, 0x4e, 0x69, 0x74, 0x72, 0x69, 0x63, 0x5c, 0x50, 0x72, 0x6f, 0x74,
+	0x6f, 0x5c, 0x53, 0x63, 0x68, 0x65, 0x64, 0x75, 0x6c, 0x65, 0x73, 0x5c, 0x56, 0x31, 0x62, 0x06,
+	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
 }
 
 var (
@@ -606,31 +700,35 @@ func file_nitric_proto_schedules_v1_schedules_proto_rawDescGZIP() []byte {
 	return file_nitric_proto_schedules_v1_schedules_proto_rawDescData
 }
 
-var file_nitric_proto_schedules_v1_schedules_proto_msgTypes = make([]protoimpl.MessageInfo, 8)
+var file_nitric_proto_schedules_v1_schedules_proto_msgTypes = make([]protoimpl.MessageInfo, 10)
 var file_nitric_proto_schedules_v1_schedules_proto_goTypes = []interface{}{
 	(*ClientMessage)(nil),        // 0: nitric.proto.schedules.v1.ClientMessage
 	(*IntervalRequest)(nil),      // 1: nitric.proto.schedules.v1.IntervalRequest
 	(*ServerMessage)(nil),        // 2: nitric.proto.schedules.v1.ServerMessage
-	(*RegistrationRequest)(nil),  // 3: nitric.proto.schedules.v1.RegistrationRequest
-	(*ScheduleEvery)(nil),        // 4: nitric.proto.schedules.v1.ScheduleEvery
-	(*ScheduleCron)(nil),         // 5: nitric.proto.schedules.v1.ScheduleCron
-	(*RegistrationResponse)(nil), // 6: nitric.proto.schedules.v1.RegistrationResponse
-	(*IntervalResponse)(nil),     // 7: nitric.proto.schedules.v1.IntervalResponse
+	(*CancellationRequest)(nil),  // 3: nitric.proto.schedules.v1.CancellationRequest
+	(*RegistrationRequest)(nil),  // 4: nitric.proto.schedules.v1.RegistrationRequest
+	(*ScheduleEvery)(nil),        // 5: nitric.proto.schedules.v1.ScheduleEvery
+	(*ScheduleCron)(nil),         // 6: nitric.proto.schedules.v1.ScheduleCron
+	(*RegistrationResponse)(nil), // 7: nitric.proto.schedules.v1.RegistrationResponse
+	(*IntervalResponse)(nil),     // 8: nitric.proto.schedules.v1.IntervalResponse
+	nil,                          // 9: nitric.proto.schedules.v1.ServerMessage.TraceContextEntry
 }
 var file_nitric_proto_schedules_v1_schedules_proto_depIdxs = []int32{
-	3, // 0: nitric.proto.schedules.v1.ClientMessage.registration_request:type_name -> nitric.proto.schedules.v1.RegistrationRequest
-	7, // 1: nitric.proto.schedules.v1.ClientMessage.interval_response:type_name -> nitric.proto.schedules.v1.IntervalResponse
-	6, // 2: nitric.proto.schedules.v1.ServerMessage.registration_response:type_name -> nitric.proto.schedules.v1.RegistrationResponse
+	4, // 0: nitric.proto.schedules.v1.ClientMessage.registration_request:type_name -> nitric.proto.schedules.v1.RegistrationRequest
+	8, // 1: nitric.proto.schedules.v1.ClientMessage.interval_response:type_name -> nitric.proto.schedules.v1.IntervalResponse
+	7, // 2: nitric.proto.schedules.v1.ServerMessage.registration_response:type_name -> nitric.proto.schedules.v1.RegistrationResponse
 	1, // 3: nitric.proto.schedules.v1.ServerMessage.interval_request:type_name -> nitric.proto.schedules.v1.IntervalRequest
-	4, // 4: nitric.proto.schedules.v1.RegistrationRequest.every:type_name -> nitric.proto.schedules.v1.ScheduleEvery
-	5, // 5: nitric.proto.schedules.v1.RegistrationRequest.cron:type_name -> nitric.proto.schedules.v1.ScheduleCron
-	0, // 6: nitric.proto.schedules.v1.Schedules.Schedule:input_type -> nitric.proto.schedules.v1.ClientMessage
-	2, // 7: nitric.proto.schedules.v1.Schedules.Schedule:output_type -> nitric.proto.schedules.v1.ServerMessage
-	7, // [7:8] is the sub-list for method output_type
-	6, // [6:7] is the sub-list for method input_type
-	6, // [6:6] is the sub-list for extension type_name
-	6, // [6:6] is the sub-list for extension extendee
-	0, // [0:6] is the sub-list for field type_name
+	3, // 4: nitric.proto.schedules.v1.ServerMessage.cancellation_request:type_name -> nitric.proto.schedules.v1.CancellationRequest
+	9, // 5: nitric.proto.schedules.v1.ServerMessage.trace_context:type_name -> nitric.proto.schedules.v1.ServerMessage.TraceContextEntry
+	5, // 6: nitric.proto.schedules.v1.RegistrationRequest.every:type_name -> nitric.proto.schedules.v1.ScheduleEvery
+	6, // 7: nitric.proto.schedules.v1.RegistrationRequest.cron:type_name -> nitric.proto.schedules.v1.ScheduleCron
+	0, // 8: nitric.proto.schedules.v1.Schedules.Schedule:input_type -> nitric.proto.schedules.v1.ClientMessage
+	2, // 9: nitric.proto.schedules.v1.Schedules.Schedule:output_type -> nitric.proto.schedules.v1.ServerMessage
+	9, // [9:10] is the sub-list for method output_type
+	8, // [8:9] is the sub-list for method input_type
+	8, // [8:8] is the sub-list for extension type_name
+	8, // [8:8] is the sub-list for extension extendee
+	0, // [0:8] is the sub-list for field type_name
 }
 
 func init() { file_nitric_proto_schedules_v1_schedules_proto_init() }
@@ -676,7 +774,7 @@ func file_nitric_proto_schedules_v1_schedules_proto_init() {
 			}
 		}
 		file_nitric_proto_schedules_v1_schedules_proto_msgTypes[3].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*RegistrationRequest); i {
+			switch v := v.(*CancellationRequest); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -688,7 +786,7 @@ func file_nitric_proto_schedules_v1_schedules_proto_init() {
 			}
 		}
 		file_nitric_proto_schedules_v1_schedules_proto_msgTypes[4].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*ScheduleEvery); i {
+			switch v := v.(*RegistrationRequest); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -700,7 +798,7 @@ func file_nitric_proto_schedules_v1_schedules_proto_init() {
 			}
 		}
 		file_nitric_proto_schedules_v1_schedules_proto_msgTypes[5].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*ScheduleCron); i {
+			switch v := v.(*ScheduleEvery); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -712,7 +810,7 @@ func file_nitric_proto_schedules_v1_schedules_proto_init() {
 			}
 		}
 		file_nitric_proto_schedules_v1_schedules_proto_msgTypes[6].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*RegistrationResponse); i {
+			switch v := v.(*ScheduleCron); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -724,6 +822,18 @@ func file_nitric_proto_schedules_v1_schedules_proto_init() {
 			}
 		}
 		file_nitric_proto_schedules_v1_schedules_proto_msgTypes[7].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*RegistrationResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_nitric_proto_schedules_v1_schedules_proto_msgTypes[8].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*IntervalResponse); i {
 			case 0:
 				return &v.state
@@ -743,8 +853,9 @@ func file_nitric_proto_schedules_v1_schedules_proto_init() {
 	file_nitric_proto_schedules_v1_schedules_proto_msgTypes[2].OneofWrappers = []interface{}{
 		(*ServerMessage_RegistrationResponse)(nil),
 		(*ServerMessage_IntervalRequest)(nil),
+		(*ServerMessage_CancellationRequest)(nil),
 	}
-	file_nitric_proto_schedules_v1_schedules_proto_msgTypes[3].OneofWrappers = []interface{}{
+	file_nitric_proto_schedules_v1_schedules_proto_msgTypes[4].OneofWrappers = []interface{}{
 		(*RegistrationRequest_Every)(nil),
 		(*RegistrationRequest_Cron)(nil),
 	}
@@ -754,7 +865,7 @@ func file_nitric_proto_schedules_v1_schedules_proto_init() {
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: file_nitric_proto_schedules_v1_schedules_proto_rawDesc,
 			NumEnums:      0,
-			NumMessages:   8,
+			NumMessages:   10,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
@@ -9,6 +9,7 @@ package secretspb
 import (
 	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
 	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
 	reflect "reflect"
 	sync "sync"
 )
@@ -20,6 +21,58 @@ const (
 	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
 )
 
+type SecretVersionState int32
+
+const (
+	// The version can be accessed
+	SecretVersionState_Enabled SecretVersionState = 0
+	// The version can't be accessed until it's enabled again
+	SecretVersionState_Disabled SecretVersionState = 1
+	// The version can't be accessed or enabled again
+	SecretVersionState_Destroyed SecretVersionState = 2
+)
+
+// Enum value maps for SecretVersionState.
+var (
+	SecretVersionState_name = map[int32]string{
+		0: "Enabled",
+		1: "Disabled",
+		2: "Destroyed",
+	}
+	SecretVersionState_value = map[string]int32{
+		"Enabled":   0,
+		"Disabled":  1,
+		"Destroyed": 2,
+	}
+)
+
+func (x SecretVersionState) Enum() *SecretVersionState {
+	p := new(SecretVersionState)
+	*p = x
+	return p
+}
+
+func (x SecretVersionState) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (SecretVersionState) Descriptor() protoreflect.EnumDescriptor {
+	return file_nitric_proto_secrets_v1_secrets_proto_enumTypes[0].Descriptor()
+}
+
+func (SecretVersionState) Type() protoreflect.EnumType {
+	return &file_nitric_proto_secrets_v1_secrets_proto_enumTypes[0]
+}
+
+func (x SecretVersionState) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use SecretVersionState.Descriptor instead.
+func (SecretVersionState) EnumDescriptor() ([]byte, []int) {
+	return file_nitric_proto_secrets_v1_secrets_proto_rawDescGZIP(), []int{0}
+}
+
 // Request to put a secret to a Secret Store
 type SecretPutRequest struct {
 	state         protoimpl.MessageState
@@ -341,6 +394,432 @@ func (x *SecretVersion) GetVersion() string {
 	return ""
 }
 
+// Request to list the versions of a secret
+type SecretListVersionsRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// The secret to list the versions of
+	Secret *Secret `protobuf:"bytes,1,opt,name=secret,proto3" json:"secret,omitempty"`
+}
+
+func (x *SecretListVersionsRequest) Reset() {
+	*x = SecretListVersionsRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_nitric_proto_secrets_v1_secrets_proto_msgTypes[6]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *SecretListVersionsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SecretListVersionsRequest) ProtoMessage() {}
+
+func (x *SecretListVersionsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_nitric_proto_secrets_v1_secrets_proto_msgTypes[6]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SecretListVersionsRequest.ProtoReflect.Descriptor instead.
+func (*SecretListVersionsRequest) Descriptor() ([]byte, []int) {
+	return file_nitric_proto_secrets_v1_secrets_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *SecretListVersionsRequest) GetSecret() *Secret {
+	if x != nil {
+		return x.Secret
+	}
+	return nil
+}
+
+// The versions of a secret
+type SecretListVersionsResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// The versions of the secret, newest first
+	Versions []*SecretVersionDetails `protobuf:"bytes,1,rep,name=versions,proto3" json:"versions,omitempty"`
+}
+
+func (x *SecretListVersionsResponse) Reset() {
+	*x = SecretListVersionsResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_nitric_proto_secrets_v1_secrets_proto_msgTypes[7]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *SecretListVersionsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SecretListVersionsResponse) ProtoMessage() {}
+
+func (x *SecretListVersionsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_nitric_proto_secrets_v1_secrets_proto_msgTypes[7]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SecretListVersionsResponse.ProtoReflect.Descriptor instead.
+func (*SecretListVersionsResponse) Descriptor() ([]byte, []int) {
+	return file_nitric_proto_secrets_v1_secrets_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *SecretListVersionsResponse) GetVersions() []*SecretVersionDetails {
+	if x != nil {
+		return x.Versions
+	}
+	return nil
+}
+
+// Request to disable a version of a secret
+type SecretDisableVersionRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// The version to disable, not every provider allows the latest version to be disabled
+	SecretVersion *SecretVersion `protobuf:"bytes,1,opt,name=secret_version,json=secretVersion,proto3" json:"secret_version,omitempty"`
+}
+
+func (x *SecretDisableVersionRequest) Reset() {
+	*x = SecretDisableVersionRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_nitric_proto_secrets_v1_secrets_proto_msgTypes[8]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *SecretDisableVersionRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SecretDisableVersionRequest) ProtoMessage() {}
+
+func (x *SecretDisableVersionRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_nitric_proto_secrets_v1_secrets_proto_msgTypes[8]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SecretDisableVersionRequest.ProtoReflect.Descriptor instead.
+func (*SecretDisableVersionRequest) Descriptor() ([]byte, []int) {
+	return file_nitric_proto_secrets_v1_secrets_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *SecretDisableVersionRequest) GetSecretVersion() *SecretVersion {
+	if x != nil {
+		return x.SecretVersion
+	}
+	return nil
+}
+
+type SecretDisableVersionResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+}
+
+func (x *SecretDisableVersionResponse) Reset() {
+	*x = SecretDisableVersionResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_nitric_proto_secrets_v1_secrets_proto_msgTypes[9]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *SecretDisableVersionResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SecretDisableVersionResponse) ProtoMessage() {}
+
+func (x *SecretDisableVersionResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_nitric_proto_secrets_v1_secrets_proto_msgTypes[9]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SecretDisableVersionResponse.ProtoReflect.Descriptor instead.
+func (*SecretDisableVersionResponse) Descriptor() ([]byte, []int) {
+	return file_nitric_proto_secrets_v1_secrets_proto_rawDescGZIP(), []int{9}
+}
+
+// Request to enable a disabled version of a secret
+type SecretEnableVersionRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// The version to enable
+	SecretVersion *SecretVersion `protobuf:"bytes,1,opt,name=secret_version,json=secretVersion,proto3" json:"secret_version,omitempty"`
+}
+
+func (x *SecretEnableVersionRequest) Reset() {
+	*x = SecretEnableVersionRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_nitric_proto_secrets_v1_secrets_proto_msgTypes[10]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *SecretEnableVersionRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SecretEnableVersionRequest) ProtoMessage() {}
+
+func (x *SecretEnableVersionRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_nitric_proto_secrets_v1_secrets_proto_msgTypes[10]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SecretEnableVersionRequest.ProtoReflect.Descriptor instead.
+func (*SecretEnableVersionRequest) Descriptor() ([]byte, []int) {
+	return file_nitric_proto_secrets_v1_secrets_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *SecretEnableVersionRequest) GetSecretVersion() *SecretVersion {
+	if x != nil {
+		return x.SecretVersion
+	}
+	return nil
+}
+
+type SecretEnableVersionResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+}
+
+func (x *SecretEnableVersionResponse) Reset() {
+	*x = SecretEnableVersionResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_nitric_proto_secrets_v1_secrets_proto_msgTypes[11]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *SecretEnableVersionResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SecretEnableVersionResponse) ProtoMessage() {}
+
+func (x *SecretEnableVersionResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_nitric_proto_secrets_v1_secrets_proto_msgTypes[11]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SecretEnableVersionResponse.ProtoReflect.Descriptor instead.
+func (*SecretEnableVersionResponse) Descriptor() ([]byte, []int) {
+	return file_nitric_proto_secrets_v1_secrets_proto_rawDescGZIP(), []int{11}
+}
+
+// Request to destroy a version of a secret
+type SecretDestroyVersionRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// The version to destroy, not every provider allows the latest version to be destroyed
+	SecretVersion *SecretVersion `protobuf:"bytes,1,opt,name=secret_version,json=secretVersion,proto3" json:"secret_version,omitempty"`
+}
+
+func (x *SecretDestroyVersionRequest) Reset() {
+	*x = SecretDestroyVersionRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_nitric_proto_secrets_v1_secrets_proto_msgTypes[12]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *SecretDestroyVersionRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SecretDestroyVersionRequest) ProtoMessage() {}
+
+func (x *SecretDestroyVersionRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_nitric_proto_secrets_v1_secrets_proto_msgTypes[12]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SecretDestroyVersionRequest.ProtoReflect.Descriptor instead.
+func (*SecretDestroyVersionRequest) Descriptor() ([]byte, []int) {
+	return file_nitric_proto_secrets_v1_secrets_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *SecretDestroyVersionRequest) GetSecretVersion() *SecretVersion {
+	if x != nil {
+		return x.SecretVersion
+	}
+	return nil
+}
+
+type SecretDestroyVersionResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+}
+
+func (x *SecretDestroyVersionResponse) Reset() {
+	*x = SecretDestroyVersionResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_nitric_proto_secrets_v1_secrets_proto_msgTypes[13]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *SecretDestroyVersionResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SecretDestroyVersionResponse) ProtoMessage() {}
+
+func (x *SecretDestroyVersionResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_nitric_proto_secrets_v1_secrets_proto_msgTypes[13]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SecretDestroyVersionResponse.ProtoReflect.Descriptor instead.
+func (*SecretDestroyVersionResponse) Descriptor() ([]byte, []int) {
+	return file_nitric_proto_secrets_v1_secrets_proto_rawDescGZIP(), []int{13}
+}
+
+// Metadata of a version of a secret
+type SecretVersionDetails struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// The secret version
+	SecretVersion *SecretVersion `protobuf:"bytes,1,opt,name=secret_version,json=secretVersion,proto3" json:"secret_version,omitempty"`
+	// When the version was created
+	CreateTime *timestamppb.Timestamp `protobuf:"bytes,2,opt,name=create_time,json=createTime,proto3" json:"create_time,omitempty"`
+	// Whether the version can be accessed
+	State SecretVersionState `protobuf:"varint,3,opt,name=state,proto3,enum=nitric.proto.secrets.v1.SecretVersionState" json:"state,omitempty"`
+}
+
+func (x *SecretVersionDetails) Reset() {
+	*x = SecretVersionDetails{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_nitric_proto_secrets_v1_secrets_proto_msgTypes[14]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *SecretVersionDetails) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SecretVersionDetails) ProtoMessage() {}
+
+func (x *SecretVersionDetails) ProtoReflect() protoreflect.Message {
+	mi := &file_nitric_proto_secrets_v1_secrets_proto_msgTypes[14]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SecretVersionDetails.ProtoReflect.Descriptor instead.
+func (*SecretVersionDetails) Descriptor() ([]byte, []int) {
+	return file_nitric_proto_secrets_v1_secrets_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *SecretVersionDetails) GetSecretVersion() *SecretVersion {
+	if x != nil {
+		return x.SecretVersion
+	}
+	return nil
+}
+
+func (x *SecretVersionDetails) GetCreateTime() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreateTime
+	}
+	return nil
+}
+
+func (x *SecretVersionDetails) GetState() SecretVersionState {
+	if x != nil {
+		return x.State
+	}
+	return SecretVersionState_Enabled
+}
+
 var File_nitric_proto_secrets_v1_secrets_proto protoreflect.FileDescriptor
 
 var file_nitric_proto_secrets_v1_secrets_proto_rawDesc = []byte{
@@ -348,41 +827,100 @@ var file_nitric_proto_secrets_v1_secrets_proto_rawDesc = []byte{
 	0x65, 0x63, 0x72, 0x65, 0x74, 0x73, 0x2f, 0x76, 0x31, 0x2f, 0x73, 0x65, 0x63, 0x72, 0x65, 0x74,
 	0x73, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x12, 0x17, 0x6e, 0x69, 0x74, 0x72, 0x69, 0x63, 0x2e,
 	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x73, 0x65, 0x63, 0x72, 0x65, 0x74, 0x73, 0x2e, 0x76, 0x31,
-	0x22, 0x61, 0x0a, 0x10, 0x53, 0x65, 0x63, 0x72, 0x65, 0x74, 0x50, 0x75, 0x74, 0x52, 0x65, 0x71,
-	0x75, 0x65, 0x73, 0x74, 0x12, 0x37, 0x0a, 0x06, 0x73, 0x65, 0x63, 0x72, 0x65, 0x74, 0x18, 0x01,
-	0x20, 0x01, 0x28, 0x0b, 0x32, 0x1f, 0x2e, 0x6e, 0x69, 0x74, 0x72, 0x69, 0x63, 0x2e, 0x70, 0x72,
-	0x6f, 0x74, 0x6f, 0x2e, 0x73, 0x65, 0x63, 0x72, 0x65, 0x74, 0x73, 0x2e, 0x76, 0x31, 0x2e, 0x53,
-	0x65, 0x63, 0x72, 0x65, 0x74, 0x52, 0x06, 0x73, 0x65, 0x63, 0x72, 0x65, 0x74, 0x12, 0x14, 0x0a,
-	0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x05, 0x76, 0x61,
-	0x6c, 0x75, 0x65, 0x22, 0x62, 0x0a, 0x11, 0x53, 0x65, 0x63, 0x72, 0x65, 0x74, 0x50, 0x75, 0x74,
-	0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x4d, 0x0a, 0x0e, 0x73, 0x65, 0x63, 0x72,
+	0x1a, 0x1f, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75,
+	0x66, 0x2f, 0x74, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x2e, 0x70, 0x72, 0x6f, 0x74,
+	0x6f, 0x22, 0x61, 0x0a, 0x10, 0x53, 0x65, 0x63, 0x72, 0x65, 0x74, 0x50, 0x75, 0x74, 0x52, 0x65,
+	0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x37, 0x0a, 0x06, 0x73, 0x65, 0x63, 0x72, 0x65, 0x74, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1f, 0x2e, 0x6e, 0x69, 0x74, 0x72, 0x69, 0x63, 0x2e, 0x70,
+	0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x73, 0x65, 0x63, 0x72, 0x65, 0x74, 0x73, 0x2e, 0x76, 0x31, 0x2e,
+	0x53, 0x65, 0x63, 0x72, 0x65, 0x74, 0x52, 0x06, 0x73, 0x65, 0x63, 0x72, 0x65, 0x74, 0x12, 0x14,
+	0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x05, 0x76,
+	0x61, 0x6c, 0x75, 0x65, 0x22, 0x62, 0x0a, 0x11, 0x53, 0x65, 0x63, 0x72, 0x65, 0x74, 0x50, 0x75,
+	0x74, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x4d, 0x0a, 0x0e, 0x73, 0x65, 0x63,
+	0x72, 0x65, 0x74, 0x5f, 0x76, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x0b, 0x32, 0x26, 0x2e, 0x6e, 0x69, 0x74, 0x72, 0x69, 0x63, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f,
+	0x2e, 0x73, 0x65, 0x63, 0x72, 0x65, 0x74, 0x73, 0x2e, 0x76, 0x31, 0x2e, 0x53, 0x65, 0x63, 0x72,
+	0x65, 0x74, 0x56, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x52, 0x0d, 0x73, 0x65, 0x63, 0x72, 0x65,
+	0x74, 0x56, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x22, 0x64, 0x0a, 0x13, 0x53, 0x65, 0x63, 0x72,
+	0x65, 0x74, 0x41, 0x63, 0x63, 0x65, 0x73, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12,
+	0x4d, 0x0a, 0x0e, 0x73, 0x65, 0x63, 0x72, 0x65, 0x74, 0x5f, 0x76, 0x65, 0x72, 0x73, 0x69, 0x6f,
+	0x6e, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x26, 0x2e, 0x6e, 0x69, 0x74, 0x72, 0x69, 0x63,
+	0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x73, 0x65, 0x63, 0x72, 0x65, 0x74, 0x73, 0x2e, 0x76,
+	0x31, 0x2e, 0x53, 0x65, 0x63, 0x72, 0x65, 0x74, 0x56, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x52,
+	0x0d, 0x73, 0x65, 0x63, 0x72, 0x65, 0x74, 0x56, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x22, 0x7b,
+	0x0a, 0x14, 0x53, 0x65, 0x63, 0x72, 0x65, 0x74, 0x41, 0x63, 0x63, 0x65, 0x73, 0x73, 0x52, 0x65,
+	0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x4d, 0x0a, 0x0e, 0x73, 0x65, 0x63, 0x72, 0x65, 0x74,
+	0x5f, 0x76, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x26,
+	0x2e, 0x6e, 0x69, 0x74, 0x72, 0x69, 0x63, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x73, 0x65,
+	0x63, 0x72, 0x65, 0x74, 0x73, 0x2e, 0x76, 0x31, 0x2e, 0x53, 0x65, 0x63, 0x72, 0x65, 0x74, 0x56,
+	0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x52, 0x0d, 0x73, 0x65, 0x63, 0x72, 0x65, 0x74, 0x56, 0x65,
+	0x72, 0x73, 0x69, 0x6f, 0x6e, 0x12, 0x14, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02,
+	0x20, 0x01, 0x28, 0x0c, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x22, 0x1c, 0x0a, 0x06, 0x53,
+	0x65, 0x63, 0x72, 0x65, 0x74, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x22, 0x62, 0x0a, 0x0d, 0x53, 0x65, 0x63,
+	0x72, 0x65, 0x74, 0x56, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x12, 0x37, 0x0a, 0x06, 0x73, 0x65,
+	0x63, 0x72, 0x65, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1f, 0x2e, 0x6e, 0x69, 0x74,
+	0x72, 0x69, 0x63, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x73, 0x65, 0x63, 0x72, 0x65, 0x74,
+	0x73, 0x2e, 0x76, 0x31, 0x2e, 0x53, 0x65, 0x63, 0x72, 0x65, 0x74, 0x52, 0x06, 0x73, 0x65, 0x63,
+	0x72, 0x65, 0x74, 0x12, 0x18, 0x0a, 0x07, 0x76, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x18, 0x02,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x76, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x22, 0x54, 0x0a,
+	0x19, 0x53, 0x65, 0x63, 0x72, 0x65, 0x74, 0x4c, 0x69, 0x73, 0x74, 0x56, 0x65, 0x72, 0x73, 0x69,
+	0x6f, 0x6e, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x37, 0x0a, 0x06, 0x73, 0x65,
+	0x63, 0x72, 0x65, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1f, 0x2e, 0x6e, 0x69, 0x74,
+	0x72, 0x69, 0x63, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x73, 0x65, 0x63, 0x72, 0x65, 0x74,
+	0x73, 0x2e, 0x76, 0x31, 0x2e, 0x53, 0x65, 0x63, 0x72, 0x65, 0x74, 0x52, 0x06, 0x73, 0x65, 0x63,
+	0x72, 0x65, 0x74, 0x22, 0x67, 0x0a, 0x1a, 0x53, 0x65, 0x63, 0x72, 0x65, 0x74, 0x4c, 0x69, 0x73,
+	0x74, 0x56, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73,
+	0x65, 0x12, 0x49, 0x0a, 0x08, 0x76, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x73, 0x18, 0x01, 0x20,
+	0x03, 0x28, 0x0b, 0x32, 0x2d, 0x2e, 0x6e, 0x69, 0x74, 0x72, 0x69, 0x63, 0x2e, 0x70, 0x72, 0x6f,
+	0x74, 0x6f, 0x2e, 0x73, 0x65, 0x63, 0x72, 0x65, 0x74, 0x73, 0x2e, 0x76, 0x31, 0x2e, 0x53, 0x65,
+	0x63, 0x72, 0x65, 0x74, 0x56, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x44, 0x65, 0x74, 0x61, 0x69,
+	0x6c, 0x73, 0x52, 0x08, 0x76, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x73, 0x22, 0x6c, 0x0a, 0x1b,
+	0x53, 0x65, 0x63, 0x72, 0x65, 0x74, 0x44, 0x69, 0x73, 0x61, 0x62, 0x6c, 0x65, 0x56, 0x65, 0x72,
+	0x73, 0x69, 0x6f, 0x6e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x4d, 0x0a, 0x0e, 0x73,
+	0x65, 0x63, 0x72, 0x65, 0x74, 0x5f, 0x76, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x0b, 0x32, 0x26, 0x2e, 0x6e, 0x69, 0x74, 0x72, 0x69, 0x63, 0x2e, 0x70, 0x72, 0x6f,
+	0x74, 0x6f, 0x2e, 0x73, 0x65, 0x63, 0x72, 0x65, 0x74, 0x73, 0x2e, 0x76, 0x31, 0x2e, 0x53, 0x65,
+	0x63, 0x72, 0x65, 0x74, 0x56, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x52, 0x0d, 0x73, 0x65, 0x63,
+	0x72, 0x65, 0x74, 0x56, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x22, 0x1e, 0x0a, 0x1c, 0x53, 0x65,
+	0x63, 0x72, 0x65, 0x74, 0x44, 0x69, 0x73, 0x61, 0x62, 0x6c, 0x65, 0x56, 0x65, 0x72, 0x73, 0x69,
+	0x6f, 0x6e, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x6b, 0x0a, 0x1a, 0x53, 0x65,
+	0x63, 0x72, 0x65, 0x74, 0x45, 0x6e, 0x61, 0x62, 0x6c, 0x65, 0x56, 0x65, 0x72, 0x73, 0x69, 0x6f,
+	0x6e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x4d, 0x0a, 0x0e, 0x73, 0x65, 0x63, 0x72,
 	0x65, 0x74, 0x5f, 0x76, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b,
 	0x32, 0x26, 0x2e, 0x6e, 0x69, 0x74, 0x72, 0x69, 0x63, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e,
 	0x73, 0x65, 0x63, 0x72, 0x65, 0x74, 0x73, 0x2e, 0x76, 0x31, 0x2e, 0x53, 0x65, 0x63, 0x72, 0x65,
 	0x74, 0x56, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x52, 0x0d, 0x73, 0x65, 0x63, 0x72, 0x65, 0x74,
-	0x56, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x22, 0x64, 0x0a, 0x13, 0x53, 0x65, 0x63, 0x72, 0x65,
-	0x74, 0x41, 0x63, 0x63, 0x65, 0x73, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x4d,
-	0x0a, 0x0e, 0x73, 0x65, 0x63, 0x72, 0x65, 0x74, 0x5f, 0x76, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e,
-	0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x26, 0x2e, 0x6e, 0x69, 0x74, 0x72, 0x69, 0x63, 0x2e,
-	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x73, 0x65, 0x63, 0x72, 0x65, 0x74, 0x73, 0x2e, 0x76, 0x31,
-	0x2e, 0x53, 0x65, 0x63, 0x72, 0x65, 0x74, 0x56, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x52, 0x0d,
-	0x73, 0x65, 0x63, 0x72, 0x65, 0x74, 0x56, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x22, 0x7b, 0x0a,
-	0x14, 0x53, 0x65, 0x63, 0x72, 0x65, 0x74, 0x41, 0x63, 0x63, 0x65, 0x73, 0x73, 0x52, 0x65, 0x73,
-	0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x4d, 0x0a, 0x0e, 0x73, 0x65, 0x63, 0x72, 0x65, 0x74, 0x5f,
+	0x56, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x22, 0x1d, 0x0a, 0x1b, 0x53, 0x65, 0x63, 0x72, 0x65,
+	0x74, 0x45, 0x6e, 0x61, 0x62, 0x6c, 0x65, 0x56, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x52, 0x65,
+	0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x6c, 0x0a, 0x1b, 0x53, 0x65, 0x63, 0x72, 0x65, 0x74,
+	0x44, 0x65, 0x73, 0x74, 0x72, 0x6f, 0x79, 0x56, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x52, 0x65,
+	0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x4d, 0x0a, 0x0e, 0x73, 0x65, 0x63, 0x72, 0x65, 0x74, 0x5f,
 	0x76, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x26, 0x2e,
 	0x6e, 0x69, 0x74, 0x72, 0x69, 0x63, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x73, 0x65, 0x63,
 	0x72, 0x65, 0x74, 0x73, 0x2e, 0x76, 0x31, 0x2e, 0x53, 0x65, 0x63, 0x72, 0x65, 0x74, 0x56, 0x65,
 	0x72, 0x73, 0x69, 0x6f, 0x6e, 0x52, 0x0d, 0x73, 0x65, 0x63, 0x72, 0x65, 0x74, 0x56, 0x65, 0x72,
-	0x73, 0x69, 0x6f, 0x6e, 0x12, 0x14, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20,
-	0x01, 0x28, 0x0c, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x22, 0x1c, 0x0a, 0x06, 0x53, 0x65,
-	0x63, 0x72, 0x65, 0x74, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01,
-	0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x22, 0x62, 0x0a, 0x0d, 0x53, 0x65, 0x63, 0x72,
-	0x65, 0x74, 0x56, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x12, 0x37, 0x0a, 0x06, 0x73, 0x65, 0x63,
-	0x72, 0x65, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1f, 0x2e, 0x6e, 0x69, 0x74, 0x72,
-	0x69, 0x63, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x73, 0x65, 0x63, 0x72, 0x65, 0x74, 0x73,
-	0x2e, 0x76, 0x31, 0x2e, 0x53, 0x65, 0x63, 0x72, 0x65, 0x74, 0x52, 0x06, 0x73, 0x65, 0x63, 0x72,
-	0x65, 0x74, 0x12, 0x18, 0x0a, 0x07, 0x76, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x18, 0x02, 0x20,
-	0x01, 0x28, 0x09, 0x52, 0x07, 0x76, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x32, 0xd4, 0x01, 0x0a,
+	0x73, 0x69, 0x6f, 0x6e, 0x22, 0x1e, 0x0a, 0x1c, 0x53, 0x65, 0x63, 0x72, 0x65, 0x74, 0x44, 0x65,
+	0x73, 0x74, 0x72, 0x6f, 0x79, 0x56, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x52, 0x65, 0x73, 0x70,
+	0x6f, 0x6e, 0x73, 0x65, 0x22, 0xe5, 0x01, 0x0a, 0x14, 0x53, 0x65, 0x63, 0x72, 0x65, 0x74, 0x56,
+	0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x44, 0x65, 0x74, 0x61, 0x69, 0x6c, 0x73, 0x12, 0x4d, 0x0a,
+	0x0e, 0x73, 0x65, 0x63, 0x72, 0x65, 0x74, 0x5f, 0x76, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x26, 0x2e, 0x6e, 0x69, 0x74, 0x72, 0x69, 0x63, 0x2e, 0x70,
+	0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x73, 0x65, 0x63, 0x72, 0x65, 0x74, 0x73, 0x2e, 0x76, 0x31, 0x2e,
+	0x53, 0x65, 0x63, 0x72, 0x65, 0x74, 0x56, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x52, 0x0d, 0x73,
+	0x65, 0x63, 0x72, 0x65, 0x74, 0x56, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x12, 0x3b, 0x0a, 0x0b,
+	0x63, 0x72, 0x65, 0x61, 0x74, 0x65, 0x5f, 0x74, 0x69, 0x6d, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28,
+	0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f,
+	0x62, 0x75, 0x66, 0x2e, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x52, 0x0a, 0x63,
+	0x72, 0x65, 0x61, 0x74, 0x65, 0x54, 0x69, 0x6d, 0x65, 0x12, 0x41, 0x0a, 0x05, 0x73, 0x74, 0x61,
+	0x74, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x2b, 0x2e, 0x6e, 0x69, 0x74, 0x72, 0x69,
+	0x63, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x73, 0x65, 0x63, 0x72, 0x65, 0x74, 0x73, 0x2e,
+	0x76, 0x31, 0x2e, 0x53, 0x65, 0x63, 0x72, 0x65, 0x74, 0x56, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e,
+	0x53, 0x74, 0x61, 0x74, 0x65, 0x52, 0x05, 0x73, 0x74, 0x61, 0x74, 0x65, 0x2a, 0x3e, 0x0a, 0x12,
+	0x53, 0x65, 0x63, 0x72, 0x65, 0x74, 0x56, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x53, 0x74, 0x61,
+	0x74, 0x65, 0x12, 0x0b, 0x0a, 0x07, 0x45, 0x6e, 0x61, 0x62, 0x6c, 0x65, 0x64, 0x10, 0x00, 0x12,
+	0x0c, 0x0a, 0x08, 0x44, 0x69, 0x73, 0x61, 0x62, 0x6c, 0x65, 0x64, 0x10, 0x01, 0x12, 0x0d, 0x0a,
+	0x09, 0x44, 0x65, 0x73, 0x74, 0x72, 0x6f, 0x79, 0x65, 0x64, 0x10, 0x02, 0x32, 0xc7, 0x05, 0x0a,
 	0x0d, 0x53, 0x65, 0x63, 0x72, 0x65, 0x74, 0x4d, 0x61, 0x6e, 0x61, 0x67, 0x65, 0x72, 0x12, 0x5c,
 	0x0a, 0x03, 0x50, 0x75, 0x74, 0x12, 0x29, 0x2e, 0x6e, 0x69, 0x74, 0x72, 0x69, 0x63, 0x2e, 0x70,
 	0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x73, 0x65, 0x63, 0x72, 0x65, 0x74, 0x73, 0x2e, 0x76, 0x31, 0x2e,
@@ -396,18 +934,49 @@ var file_nitric_proto_secrets_v1_secrets_proto_rawDesc = []byte{
 	0x75, 0x65, 0x73, 0x74, 0x1a, 0x2d, 0x2e, 0x6e, 0x69, 0x74, 0x72, 0x69, 0x63, 0x2e, 0x70, 0x72,
 	0x6f, 0x74, 0x6f, 0x2e, 0x73, 0x65, 0x63, 0x72, 0x65, 0x74, 0x73, 0x2e, 0x76, 0x31, 0x2e, 0x53,
 	0x65, 0x63, 0x72, 0x65, 0x74, 0x41, 0x63, 0x63, 0x65, 0x73, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f,
-	0x6e, 0x73, 0x65, 0x42, 0xa4, 0x01, 0x0a, 0x1a, 0x69, 0x6f, 0x2e, 0x6e, 0x69, 0x74, 0x72, 0x69,
-	0x63, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x73, 0x65, 0x63, 0x72, 0x65, 0x74, 0x73, 0x2e,
-	0x76, 0x31, 0x42, 0x0e, 0x53, 0x65, 0x63, 0x72, 0x65, 0x74, 0x73, 0x53, 0x65, 0x72, 0x76, 0x69,
-	0x63, 0x65, 0x50, 0x01, 0x5a, 0x40, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d,
-	0x2f, 0x6e, 0x69, 0x74, 0x72, 0x69, 0x63, 0x74, 0x65, 0x63, 0x68, 0x2f, 0x6e, 0x69, 0x74, 0x72,
-	0x69, 0x63, 0x2f, 0x63, 0x6f, 0x72, 0x65, 0x2f, 0x70, 0x6b, 0x67, 0x2f, 0x70, 0x72, 0x6f, 0x74,
-	0x6f, 0x2f, 0x73, 0x65, 0x63, 0x72, 0x65, 0x74, 0x73, 0x2f, 0x76, 0x31, 0x3b, 0x73, 0x65, 0x63,
-	0x72, 0x65, 0x74, 0x73, 0x70, 0x62, 0xaa, 0x02, 0x17, 0x4e, 0x69, 0x74, 0x72, 0x69, 0x63, 0x2e,
-	0x50, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x53, 0x65, 0x63, 0x72, 0x65, 0x74, 0x73, 0x2e, 0x76, 0x31,
-	0xca, 0x02, 0x17, 0x4e, 0x69, 0x74, 0x72, 0x69, 0x63, 0x5c, 0x50, 0x72, 0x6f, 0x74, 0x6f, 0x5c,
-	0x53, 0x65, 0x63, 0x72, 0x65, 0x74, 0x73, 0x5c, 0x56, 0x31, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74,
-	0x6f, 0x33,
+	0x6e, 0x73, 0x65, 0x12, 0x77, 0x0a, 0x0c, 0x4c, 0x69, 0x73, 0x74, 0x56, 0x65, 0x72, 0x73, 0x69,
+	0x6f, 0x6e, 0x73, 0x12, 0x32, 0x2e, 0x6e, 0x69, 0x74, 0x72, 0x69, 0x63, 0x2e, 0x70, 0x72, 0x6f,
+	0x74, 0x6f, 0x2e, 0x73, 0x65, 0x63, 0x72, 0x65, 0x74, 0x73, 0x2e, 0x76, 0x31, 0x2e, 0x53, 0x65,
+	0x63, 0x72, 0x65, 0x74, 0x4c, 0x69, 0x73, 0x74, 0x56, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x73,
+	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x33, 0x2e, 0x6e, 0x69, 0x74, 0x72, 0x69, 0x63,
+	0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x73, 0x65, 0x63, 0x72, 0x65, 0x74, 0x73, 0x2e, 0x76,
+	0x31, 0x2e, 0x53, 0x65, 0x63, 0x72, 0x65, 0x74, 0x4c, 0x69, 0x73, 0x74, 0x56, 0x65, 0x72, 0x73,
+	0x69, 0x6f, 0x6e, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x7d, 0x0a, 0x0e,
+	0x44, 0x69, 0x73, 0x61, 0x62, 0x6c, 0x65, 0x56, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x12, 0x34,
+	0x2e, 0x6e, 0x69, 0x74, 0x72, 0x69, 0x63, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x73, 0x65,
+	0x63, 0x72, 0x65, 0x74, 0x73, 0x2e, 0x76, 0x31, 0x2e, 0x53, 0x65, 0x63, 0x72, 0x65, 0x74, 0x44,
+	0x69, 0x73, 0x61, 0x62, 0x6c, 0x65, 0x56, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x52, 0x65, 0x71,
+	0x75, 0x65, 0x73, 0x74, 0x1a, 0x35, 0x2e, 0x6e, 0x69, 0x74, 0x72, 0x69, 0x63, 0x2e, 0x70, 0x72,
+	0x6f, 0x74, 0x6f, 0x2e, 0x73, 0x65, 0x63, 0x72, 0x65, 0x74, 0x73, 0x2e, 0x76, 0x31, 0x2e, 0x53,
+	0x65, 0x63, 0x72, 0x65, 0x74, 0x44, 0x69, 0x73, 0x61, 0x62, 0x6c, 0x65, 0x56, 0x65, 0x72, 0x73,
+	0x69, 0x6f, 0x6e, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x7a, 0x0a, 0x0d, 0x45,
+	0x6e, 0x61, 0x62, 0x6c, 0x65, 0x56, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x12, 0x33, 0x2e, 0x6e,
+	0x69, 0x74, 0x72, 0x69, 0x63, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x73, 0x65, 0x63, 0x72,
+	0x65, 0x74, 0x73, 0x2e, 0x76, 0x31, 0x2e, 0x53, 0x65, 0x63, 0x72, 0x65, 0x74, 0x45, 0x6e, 0x61,
+	0x62, 0x6c, 0x65, 0x56, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
+	0x74, 0x1a, 0x34, 0x2e, 0x6e, 0x69, 0x74, 0x72, 0x69, 0x63, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f,
+	0x2e, 0x73, 0x65, 0x63, 0x72, 0x65, 0x74, 0x73, 0x2e, 0x76, 0x31, 0x2e, 0x53, 0x65, 0x63, 0x72,
+	0x65, 0x74, 0x45, 0x6e, 0x61, 0x62, 0x6c, 0x65, 0x56, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x52,
+	0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x7d, 0x0a, 0x0e, 0x44, 0x65, 0x73, 0x74, 0x72,
+	0x6f, 0x79, 0x56, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x12, 0x34, 0x2e, 0x6e, 0x69, 0x74, 0x72,
+	0x69, 0x63, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x73, 0x65, 0x63, 0x72, 0x65, 0x74, 0x73,
+	0x2e, 0x76, 0x31, 0x2e, 0x53, 0x65, 0x63, 0x72, 0x65, 0x74, 0x44, 0x65, 0x73, 0x74, 0x72, 0x6f,
+	0x79, 0x56, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a,
+	0x35, 0x2e, 0x6e, 0x69, 0x74, 0x72, 0x69, 0x63, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x73,
+	0x65, 0x63, 0x72, 0x65, 0x74, 0x73, 0x2e, 0x76, 0x31, 0x2e, 0x53, 0x65, 0x63, 0x72, 0x65, 0x74,
+	0x44, 0x65, 0x73, 0x74, 0x72, 0x6f, 0x79, 0x56, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x52, 0x65,
+	0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x42, 0xa4, 0x01, 0x0a, 0x1a, 0x69, 0x6f, 0x2e, 0x6e, 0x69,
+	0x74, 0x72, 0x69, 0x63, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x73, 0x65, 0x63, 0x72, 0x65,
+	0x74, 0x73, 0x2e, 0x76, 0x31, 0x42, 0x0e, 0x53, 0x65, 0x63, 0x72, 0x65, 0x74, 0x73, 0x53, 0x65,
+	0x72, 0x76, 0x69, 0x63, 0x65, 0x50, 0x01, 0x5a, 0x40, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e,
+	0x63, 0x6f, 0x6d, 0x2f, 0x6e, 0x69, 0x74, 0x72, 0x69, 0x63, 0x74, 0x65, 0x63, 0x68, 0x2f, 0x6e,
+	0x69, 0x74, 0x72, 0x69, 0x63, 0x2f, 0x63, 0x6f, 0x72, 0x65, 0x2f, 0x70, 0x6b, 0x67, 0x2f, 0x70,
+	0x72, 0x6f, 0x74, 0x6f, 0x2f, 0x73, 0x65, 0x63, 0x72, 0x65, 0x74, 0x73, 0x2f, 0x76, 0x31, 0x3b,
+	0x73, 0x65, 0x63, 0x72, 0x65, 0x74, 0x73, 0x70, 0x62, 0xaa, 0x02, 0x17, 0x4e, 0x69, 0x74, 0x72,
+	0x69, 0x63, 0x2e, 0x50, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x53, 0x65, 0x63, 0x72, 0x65, 0x74, 0x73,
+	0x2e, 0x76, 0x31, 0xca, 0x02, 0x17, 0x4e, 0x69, 0x74, 0x72, 0x69, 0x63, 0x5c, 0x50, 0x72, 0x6f,
+	0x74, 0x6f, 0x5c, 0x53, 0x65, 0x63, 0x72, 0x65, 0x74, 0x73, 0x5c, 0x56, 0x31, 0x62, 0x06, 0x70,
+	0x72, 0x6f, 0x74, 0x6f, 0x33,
 }
 
 var (
@@ -422,30 +991,58 @@ func file_nitric_proto_secrets_v1_secrets_proto_rawDescGZIP() []byte {
 	return file_nitric_proto_secrets_v1_secrets_proto_rawDescData
 }
 
-var file_nitric_proto_secrets_v1_secrets_proto_msgTypes = make([]protoimpl.MessageInfo, 6)
+var file_nitric_proto_secrets_v1_secrets_proto_enumTypes = make([]protoimpl.EnumInfo, 1)
+var file_nitric_proto_secrets_v1_secrets_proto_msgTypes = make([]protoimpl.MessageInfo, 15)
 var file_nitric_proto_secrets_v1_secrets_proto_goTypes = []interface{}{
-	(*SecretPutRequest)(nil),     // 0: nitric.proto.secrets.v1.SecretPutRequest
-	(*SecretPutResponse)(nil),    // 1: nitric.proto.secrets.v1.SecretPutResponse
-	(*SecretAccessRequest)(nil),  // 2: nitric.proto.secrets.v1.SecretAccessRequest
-	(*SecretAccessResponse)(nil), // 3: nitric.proto.secrets.v1.SecretAccessResponse
-	(*Secret)(nil),               // 4: nitric.proto.secrets.v1.Secret
-	(*SecretVersion)(nil),        // 5: nitric.proto.secrets.v1.SecretVersion
+	(SecretVersionState)(0),              // 0: nitric.proto.secrets.v1.SecretVersionState
+	(*SecretPutRequest)(nil),             // 1: nitric.proto.secrets.v1.SecretPutRequest
+	(*SecretPutResponse)(nil),            // 2: nitric.proto.secrets.v1.SecretPutResponse
+	(*SecretAccessRequest)(nil),          // 3: nitric.proto.secrets.v1.SecretAccessRequest
+	(*SecretAccessResponse)(nil),         // 4: nitric.proto.secrets.v1.SecretAccessResponse
+	(*Secret)(nil),                       // 5: nitric.proto.secrets.v1.Secret
+	(*SecretVersion)(nil),                // 6: nitric.proto.secrets.v1.SecretVersion
+	(*SecretListVersionsRequest)(nil),    // 7: nitric.proto.secrets.v1.SecretListVersionsRequest
+	(*SecretListVersionsResponse)(nil),   // 8: nitric.proto.secrets.v1.SecretListVersionsResponse
+	(*SecretDisableVersionRequest)(nil),  // 9: nitric.proto.secrets.v1.SecretDisableVersionRequest
+	(*SecretDisableVersionResponse)(nil), // 10: nitric.proto.secrets.v1.SecretDisableVersionResponse
+	(*SecretEnableVersionRequest)(nil),   // 11: nitric.proto.secrets.v1.SecretEnableVersionRequest
+	(*SecretEnableVersionResponse)(nil),  // 12: nitric.proto.secrets.v1.SecretEnableVersionResponse
+	(*SecretDestroyVersionRequest)(nil),  // 13: nitric.proto.secrets.v1.SecretDestroyVersionRequest
+	(*SecretDestroyVersionResponse)(nil), // 14: nitric.proto.secrets.v1.SecretDestroyVersionResponse
+	(*SecretVersionDetails)(nil),         // 15: nitric.proto.secrets.v1.SecretVersionDetails
+	(*timestamppb.Timestamp)(nil),        // 16: google.protobuf.Timestamp
 }
 var file_nitric_proto_secrets_v1_secrets_proto_depIdxs = []int32{
-	4, // 0: nitric.proto.secrets.v1.SecretPutRequest.secret:type_name -> nitric.proto.secrets.v1.Secret
-	5, // 1: nitric.proto.secrets.v1.SecretPutResponse.secret_version:type_name -> nitric.proto.secrets.v1.SecretVersion
-	5, // 2: nitric.proto.secrets.v1.SecretAccessRequest.secret_version:type_name -> nitric.proto.secrets.v1.SecretVersion
-	5, // 3: nitric.proto.secrets.v1.SecretAccessResponse.secret_version:type_name -> nitric.proto.secrets.v1.SecretVersion
-	4, // 4: nitric.proto.secrets.v1.SecretVersion.secret:type_name -> nitric.proto.secrets.v1.Secret
-	0, // 5: nitric.proto.secrets.v1.SecretManager.Put:input_type -> nitric.proto.secrets.v1.SecretPutRequest
-	2, // 6: nitric.proto.secrets.v1.SecretManager.Access:input_type -> nitric.proto.secrets.v1.SecretAccessRequest
-	1, // 7: nitric.proto.secrets.v1.SecretManager.Put:output_type -> nitric.proto.secrets.v1.SecretPutResponse
-	3, // 8: nitric.proto.secrets.v1.SecretManager.Access:output_type -> nitric.proto.secrets.v1.SecretAccessResponse
-	7, // [7:9] is the sub-list for method output_type
-	5, // [5:7] is the sub-list for method input_type
-	5, // [5:5] is the sub-list for extension type_name
-	5, // [5:5] is the sub-list for extension extendee
-	0, // [0:5] is the sub-list for field type_name
+	5,  // 0: nitric.proto.secrets.v1.SecretPutRequest.secret:type_name -> nitric.proto.secrets.v1.Secret
+	6,  // 1: nitric.proto.secrets.v1.SecretPutResponse.secret_version:type_name -> nitric.proto.secrets.v1.SecretVersion
+	6,  // 2: nitric.proto.secrets.v1.SecretAccessRequest.secret_version:type_name -> nitric.proto.secrets.v1.SecretVersion
+	6,  // 3: nitric.proto.secrets.v1.SecretAccessResponse.secret_version:type_name -> nitric.proto.secrets.v1.SecretVersion
+	5,  // 4: nitric.proto.secrets.v1.SecretVersion.secret:type_name -> nitric.proto.secrets.v1.Secret
+	5,  // 5: nitric.proto.secrets.v1.SecretListVersionsRequest.secret:type_name -> nitric.proto.secrets.v1.Secret
+	15, // 6: nitric.proto.secrets.v1.SecretListVersionsResponse.versions:type_name -> nitric.proto.secrets.v1.SecretVersionDetails
+	6,  // 7: nitric.proto.secrets.v1.SecretDisableVersionRequest.secret_version:type_name -> nitric.proto.secrets.v1.SecretVersion
+	6,  // 8: nitric.proto.secrets.v1.SecretEnableVersionRequest.secret_version:type_name -> nitric.proto.secrets.v1.SecretVersion
+	6,  // 9: nitric.proto.secrets.v1.SecretDestroyVersionRequest.secret_version:type_name -> nitric.proto.secrets.v1.SecretVersion
+	6,  // 10: nitric.proto.secrets.v1.SecretVersionDetails.secret_version:type_name -> nitric.proto.secrets.v1.SecretVersion
+	16, // 11: nitric.proto.secrets.v1.SecretVersionDetails.create_time:type_name -> google.protobuf.Timestamp
+	0,  // 12: nitric.proto.secrets.v1.SecretVersionDetails.state:type_name -> nitric.proto.secrets.v1.SecretVersionState
+	1,  // 13: nitric.proto.secrets.v1.SecretManager.Put:input_type -> nitric.proto.secrets.v1.SecretPutRequest
+	3,  // 14: nitric.proto.secrets.v1.SecretManager.Access:input_type -> nitric.proto.secrets.v1.SecretAccessRequest
+	7,  // 15: nitric.proto.secrets.v1.SecretManager.ListVersions:input_type -> nitric.proto.secrets.v1.SecretListVersionsRequest
+	9,  // 16: nitric.proto.secrets.v1.SecretManager.DisableVersion:input_type -> nitric.proto.secrets.v1.SecretDisableVersionRequest
+	11, // 17: nitric.proto.secrets.v1.SecretManager.EnableVersion:input_type -> nitric.proto.secrets.v1.SecretEnableVersionRequest
+	13, // 18: nitric.proto.secrets.v1.SecretManager.DestroyVersion:input_type -> nitric.proto.secrets.v1.SecretDestroyVersionRequest
+	2,  // 19: nitric.proto.secrets.v1.SecretManager.Put:output_type -> nitric.proto.secrets.v1.SecretPutResponse
+	4,  // 20: nitric.proto.secrets.v1.SecretManager.Access:output_type -> nitric.proto.secrets.v1.SecretAccessResponse
+	8,  // 21: nitric.proto.secrets.v1.SecretManager.ListVersions:output_type -> nitric.proto.secrets.v1.SecretListVersionsResponse
+	10, // 22: nitric.proto.secrets.v1.SecretManager.DisableVersion:output_type -> nitric.proto.secrets.v1.SecretDisableVersionResponse
+	12, // 23: nitric.proto.secrets.v1.SecretManager.EnableVersion:output_type -> nitric.proto.secrets.v1.SecretEnableVersionResponse
+	14, // 24: nitric.proto.secrets.v1.SecretManager.DestroyVersion:output_type -> nitric.proto.secrets.v1.SecretDestroyVersionResponse
+	19, // [19:25] is the sub-list for method output_type
+	13, // [13:19] is the sub-list for method input_type
+	13, // [13:13] is the sub-list for extension type_name
+	13, // [13:13] is the sub-list for extension extendee
+	0,  // [0:13] is the sub-list for field type_name
 }
 
 func init() { file_nitric_proto_secrets_v1_secrets_proto_init() }
@@ -526,19 +1123,128 @@ func file_nitric_proto_secrets_v1_secrets_proto_init() {
 				return nil
 			}
 		}
+		file_nitric_proto_secrets_v1_secrets_proto_msgTypes[6].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*SecretListVersionsRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_nitric_proto_secrets_v1_secrets_proto_msgTypes[7].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*SecretListVersionsResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_nitric_proto_secrets_v1_secrets_proto_msgTypes[8].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*SecretDisableVersionRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_nitric_proto_secrets_v1_secrets_proto_msgTypes[9].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*SecretDisableVersionResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_nitric_proto_secrets_v1_secrets_proto_msgTypes[10].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*SecretEnableVersionRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_nitric_proto_secrets_v1_secrets_proto_msgTypes[11].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*SecretEnableVersionResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_nitric_proto_secrets_v1_secrets_proto_msgTypes[12].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*SecretDestroyVersionRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_nitric_proto_secrets_v1_secrets_proto_msgTypes[13].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*SecretDestroyVersionResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_nitric_proto_secrets_v1_secrets_proto_msgTypes[14].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*SecretVersionDetails); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
 	}
 	type x struct{}
 	out := protoimpl.TypeBuilder{
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: file_nitric_proto_secrets_v1_secrets_proto_rawDesc,
-			NumEnums:      0,
-			NumMessages:   6,
+			NumEnums:      1,
+			NumMessages:   15,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
 		GoTypes:           file_nitric_proto_secrets_v1_secrets_proto_goTypes,
 		DependencyIndexes: file_nitric_proto_secrets_v1_secrets_proto_depIdxs,
+		EnumInfos:         file_nitric_proto_secrets_v1_secrets_proto_enumTypes,
 		MessageInfos:      file_nitric_proto_secrets_v1_secrets_proto_msgTypes,
 	}.Build()
 	File_nitric_proto_secrets_v1_secrets_proto = out.File
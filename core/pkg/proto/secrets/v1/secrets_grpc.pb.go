@@ -26,6 +26,14 @@ type SecretManagerClient interface {
 	Put(ctx context.Context, in *SecretPutRequest, opts ...grpc.CallOption) (*SecretPutResponse, error)
 	// Gets a secret from a Secret Store
 	Access(ctx context.Context, in *SecretAccessRequest, opts ...grpc.CallOption) (*SecretAccessResponse, error)
+	// Lists the versions of a secret, including disabled and destroyed versions
+	ListVersions(ctx context.Context, in *SecretListVersionsRequest, opts ...grpc.CallOption) (*SecretListVersionsResponse, error)
+	// Disables a version of a secret, disabled versions can't be accessed until they're enabled again
+	DisableVersion(ctx context.Context, in *SecretDisableVersionRequest, opts ...grpc.CallOption) (*SecretDisableVersionResponse, error)
+	// Enables a disabled version of a secret
+	EnableVersion(ctx context.Context, in *SecretEnableVersionRequest, opts ...grpc.CallOption) (*SecretEnableVersionResponse, error)
+	// Destroys a version of a secret, destroyed versions can't be accessed or enabled again. Not every provider can destroy a single version
+	DestroyVersion(ctx context.Context, in *SecretDestroyVersionRequest, opts ...grpc.CallOption) (*SecretDestroyVersionResponse, error)
 }
 
 type secretManagerClient struct {
@@ -54,6 +62,42 @@ func (c *secretManagerClient) Access(ctx context.Context, in *SecretAccessReques
 	return out, nil
 }
 
+func (c *secretManagerClient) ListVersions(ctx context.Context, in *SecretListVersionsRequest, opts ...grpc.CallOption) (*SecretListVersionsResponse, error) {
+	out := new(SecretListVersionsResponse)
+	err := c.cc.Invoke(ctx, "/nitric.proto.secrets.v1.SecretManager/ListVersions", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *secretManagerClient) DisableVersion(ctx context.Context, in *SecretDisableVersionRequest, opts ...grpc.CallOption) (*SecretDisableVersionResponse, error) {
+	out := new(SecretDisableVersionResponse)
+	err := c.cc.Invoke(ctx, "/nitric.proto.secrets.v1.SecretManager/DisableVersion", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *secretManagerClient) EnableVersion(ctx context.Context, in *SecretEnableVersionRequest, opts ...grpc.CallOption) (*SecretEnableVersionResponse, error) {
+	out := new(SecretEnableVersionResponse)
+	err := c.cc.Invoke(ctx, "/nitric.proto.secrets.v1.SecretManager/EnableVersion", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *secretManagerClient) DestroyVersion(ctx context.Context, in *SecretDestroyVersionRequest, opts ...grpc.CallOption) (*SecretDestroyVersionResponse, error) {
+	out := new(SecretDestroyVersionResponse)
+	err := c.cc.Invoke(ctx, "/nitric.proto.secrets.v1.SecretManager/DestroyVersion", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // SecretManagerServer is the server API for SecretManager service.
 // All implementations should embed UnimplementedSecretManagerServer
 // for forward compatibility
@@ -62,6 +106,14 @@ type SecretManagerServer interface {
 	Put(context.Context, *SecretPutRequest) (*SecretPutResponse, error)
 	// Gets a secret from a Secret Store
 	Access(context.Context, *SecretAccessRequest) (*SecretAccessResponse, error)
+	// Lists the versions of a secret, including disabled and destroyed versions
+	ListVersions(context.Context, *SecretListVersionsRequest) (*SecretListVersionsResponse, error)
+	// Disables a version of a secret, disabled versions can't be accessed until they're enabled again
+	DisableVersion(context.Context, *SecretDisableVersionRequest) (*SecretDisableVersionResponse, error)
+	// Enables a disabled version of a secret
+	EnableVersion(context.Context, *SecretEnableVersionRequest) (*SecretEnableVersionResponse, error)
+	// Destroys a version of a secret, destroyed versions can't be accessed or enabled again. Not every provider can destroy a single version
+	DestroyVersion(context.Context, *SecretDestroyVersionRequest) (*SecretDestroyVersionResponse, error)
 }
 
 // UnimplementedSecretManagerServer should be embedded to have forward compatible implementations.
@@ -74,6 +126,18 @@ func (UnimplementedSecretManagerServer) Put(context.Context, *SecretPutRequest)
 func (UnimplementedSecretManagerServer) Access(context.Context, *SecretAccessRequest) (*SecretAccessResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method Access not implemented")
 }
+func (UnimplementedSecretManagerServer) ListVersions(context.Context, *SecretListVersionsRequest) (*SecretListVersionsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListVersions not implemented")
+}
+func (UnimplementedSecretManagerServer) DisableVersion(context.Context, *SecretDisableVersionRequest) (*SecretDisableVersionResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DisableVersion not implemented")
+}
+func (UnimplementedSecretManagerServer) EnableVersion(context.Context, *SecretEnableVersionRequest) (*SecretEnableVersionResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method EnableVersion not implemented")
+}
+func (UnimplementedSecretManagerServer) DestroyVersion(context.Context, *SecretDestroyVersionRequest) (*SecretDestroyVersionResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DestroyVersion not implemented")
+}
 
 // UnsafeSecretManagerServer may be embedded to opt out of forward compatibility for this service.
 // Use of this interface is not recommended, as added methods to SecretManagerServer will
@@ -122,6 +186,78 @@ func _SecretManager_Access_Handler(srv interface{}, ctx context.Context, dec fun
 	return interceptor(ctx, in, info, handler)
 }
 
+func _SecretManager_ListVersions_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SecretListVersionsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SecretManagerServer).ListVersions(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/nitric.proto.secrets.v1.SecretManager/ListVersions",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SecretManagerServer).ListVersions(ctx, req.(*SecretListVersionsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SecretManager_DisableVersion_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SecretDisableVersionRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SecretManagerServer).DisableVersion(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/nitric.proto.secrets.v1.SecretManager/DisableVersion",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SecretManagerServer).DisableVersion(ctx, req.(*SecretDisableVersionRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SecretManager_EnableVersion_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SecretEnableVersionRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SecretManagerServer).EnableVersion(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/nitric.proto.secrets.v1.SecretManager/EnableVersion",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SecretManagerServer).EnableVersion(ctx, req.(*SecretEnableVersionRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SecretManager_DestroyVersion_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SecretDestroyVersionRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SecretManagerServer).DestroyVersion(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/nitric.proto.secrets.v1.SecretManager/DestroyVersion",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SecretManagerServer).DestroyVersion(ctx, req.(*SecretDestroyVersionRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // SecretManager_ServiceDesc is the grpc.ServiceDesc for SecretManager service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -137,6 +273,22 @@ var SecretManager_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "Access",
 			Handler:    _SecretManager_Access_Handler,
 		},
+		{
+			MethodName: "ListVersions",
+			Handler:    _SecretManager_ListVersions_Handler,
+		},
+		{
+			MethodName: "DisableVersion",
+			Handler:    _SecretManager_DisableVersion_Handler,
+		},
+		{
+			MethodName: "EnableVersion",
+			Handler:    _SecretManager_EnableVersion_Handler,
+		},
+		{
+			MethodName: "DestroyVersion",
+			Handler:    _SecretManager_DestroyVersion_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "nitric/proto/secrets/v1/secrets.proto",
@@ -10,6 +10,7 @@ import (
 	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
 	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
 	durationpb "google.golang.org/protobuf/types/known/durationpb"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
 	reflect "reflect"
 	sync "sync"
 )
@@ -111,7 +112,7 @@ func (x StoragePreSignUrlRequest_Operation) Number() protoreflect.EnumNumber {
 
 // Deprecated: Use StoragePreSignUrlRequest_Operation.Descriptor instead.
 func (StoragePreSignUrlRequest_Operation) EnumDescriptor() ([]byte, []int) {
-	return file_nitric_proto_storage_v1_storage_proto_rawDescGZIP(), []int{13, 0}
+	return file_nitric_proto_storage_v1_storage_proto_rawDescGZIP(), []int{19, 0}
 }
 
 // ClientMessages are sent from the service to the nitric server
@@ -219,7 +220,10 @@ type ServerMessage struct {
 	//
 	//	*ServerMessage_RegistrationResponse
 	//	*ServerMessage_BlobEventRequest
+	//	*ServerMessage_CancellationRequest
 	Content isServerMessage_Content `protobuf_oneof:"content"`
+	// W3C trace context (e.g. traceparent and tracestate) of the trigger, used to continue its trace in the service
+	TraceContext map[string]string `protobuf:"bytes,10,rep,name=trace_context,json=traceContext,proto3" json:"trace_context,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
 }
 
 func (x *ServerMessage) Reset() {
@@ -282,6 +286,20 @@ func (x *ServerMessage) GetBlobEventRequest() *BlobEventRequest {
 	return nil
 }
 
+func (x *ServerMessage) GetCancellationRequest() *CancellationRequest {
+	if x, ok := x.GetContent().(*ServerMessage_CancellationRequest); ok {
+		return x.CancellationRequest
+	}
+	return nil
+}
+
+func (x *ServerMessage) GetTraceContext() map[string]string {
+	if x != nil {
+		return x.TraceContext
+	}
+	return nil
+}
+
 type isServerMessage_Content interface {
 	isServerMessage_Content()
 }
@@ -296,10 +314,67 @@ type ServerMessage_BlobEventRequest struct {
 	BlobEventRequest *BlobEventRequest `protobuf:"bytes,3,opt,name=blob_event_request,json=blobEventRequest,proto3,oneof"`
 }
 
+type ServerMessage_CancellationRequest struct {
+	// Notifies the worker that a previous request with the same ID has been cancelled,
+	// typically because its deadline was exceeded. The worker should abort processing.
+	CancellationRequest *CancellationRequest `protobuf:"bytes,4,opt,name=cancellation_request,json=cancellationRequest,proto3,oneof"`
+}
+
 func (*ServerMessage_RegistrationResponse) isServerMessage_Content() {}
 
 func (*ServerMessage_BlobEventRequest) isServerMessage_Content() {}
 
+func (*ServerMessage_CancellationRequest) isServerMessage_Content() {}
+
+// Notification that an in-flight request was cancelled by the server
+type CancellationRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// The reason the request was cancelled
+	Reason string `protobuf:"bytes,1,opt,name=reason,proto3" json:"reason,omitempty"`
+}
+
+func (x *CancellationRequest) Reset() {
+	*x = CancellationRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_nitric_proto_storage_v1_storage_proto_msgTypes[2]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *CancellationRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CancellationRequest) ProtoMessage() {}
+
+func (x *CancellationRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_nitric_proto_storage_v1_storage_proto_msgTypes[2]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CancellationRequest.ProtoReflect.Descriptor instead.
+func (*CancellationRequest) Descriptor() ([]byte, []int) {
+	return file_nitric_proto_storage_v1_storage_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *CancellationRequest) GetReason() string {
+	if x != nil {
+		return x.Reason
+	}
+	return ""
+}
+
 type BlobEventRequest struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
@@ -315,7 +390,7 @@ type BlobEventRequest struct {
 func (x *BlobEventRequest) Reset() {
 	*x = BlobEventRequest{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_nitric_proto_storage_v1_storage_proto_msgTypes[2]
+		mi := &file_nitric_proto_storage_v1_storage_proto_msgTypes[3]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -328,7 +403,7 @@ func (x *BlobEventRequest) String() string {
 func (*BlobEventRequest) ProtoMessage() {}
 
 func (x *BlobEventRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_nitric_proto_storage_v1_storage_proto_msgTypes[2]
+	mi := &file_nitric_proto_storage_v1_storage_proto_msgTypes[3]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -341,7 +416,7 @@ func (x *BlobEventRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use BlobEventRequest.ProtoReflect.Descriptor instead.
 func (*BlobEventRequest) Descriptor() ([]byte, []int) {
-	return file_nitric_proto_storage_v1_storage_proto_rawDescGZIP(), []int{2}
+	return file_nitric_proto_storage_v1_storage_proto_rawDescGZIP(), []int{3}
 }
 
 func (x *BlobEventRequest) GetBucketName() string {
@@ -389,7 +464,7 @@ type BlobEvent struct {
 func (x *BlobEvent) Reset() {
 	*x = BlobEvent{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_nitric_proto_storage_v1_storage_proto_msgTypes[3]
+		mi := &file_nitric_proto_storage_v1_storage_proto_msgTypes[4]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -402,7 +477,7 @@ func (x *BlobEvent) String() string {
 func (*BlobEvent) ProtoMessage() {}
 
 func (x *BlobEvent) ProtoReflect() protoreflect.Message {
-	mi := &file_nitric_proto_storage_v1_storage_proto_msgTypes[3]
+	mi := &file_nitric_proto_storage_v1_storage_proto_msgTypes[4]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -415,7 +490,7 @@ func (x *BlobEvent) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use BlobEvent.ProtoReflect.Descriptor instead.
 func (*BlobEvent) Descriptor() ([]byte, []int) {
-	return file_nitric_proto_storage_v1_storage_proto_rawDescGZIP(), []int{3}
+	return file_nitric_proto_storage_v1_storage_proto_rawDescGZIP(), []int{4}
 }
 
 func (x *BlobEvent) GetKey() string {
@@ -443,7 +518,7 @@ type BlobEventResponse struct {
 func (x *BlobEventResponse) Reset() {
 	*x = BlobEventResponse{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_nitric_proto_storage_v1_storage_proto_msgTypes[4]
+		mi := &file_nitric_proto_storage_v1_storage_proto_msgTypes[5]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -456,7 +531,7 @@ func (x *BlobEventResponse) String() string {
 func (*BlobEventResponse) ProtoMessage() {}
 
 func (x *BlobEventResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_nitric_proto_storage_v1_storage_proto_msgTypes[4]
+	mi := &file_nitric_proto_storage_v1_storage_proto_msgTypes[5]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -469,7 +544,7 @@ func (x *BlobEventResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use BlobEventResponse.ProtoReflect.Descriptor instead.
 func (*BlobEventResponse) Descriptor() ([]byte, []int) {
-	return file_nitric_proto_storage_v1_storage_proto_rawDescGZIP(), []int{4}
+	return file_nitric_proto_storage_v1_storage_proto_rawDescGZIP(), []int{5}
 }
 
 func (x *BlobEventResponse) GetSuccess() bool {
@@ -495,7 +570,7 @@ type RegistrationRequest struct {
 func (x *RegistrationRequest) Reset() {
 	*x = RegistrationRequest{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_nitric_proto_storage_v1_storage_proto_msgTypes[5]
+		mi := &file_nitric_proto_storage_v1_storage_proto_msgTypes[6]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -508,7 +583,7 @@ func (x *RegistrationRequest) String() string {
 func (*RegistrationRequest) ProtoMessage() {}
 
 func (x *RegistrationRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_nitric_proto_storage_v1_storage_proto_msgTypes[5]
+	mi := &file_nitric_proto_storage_v1_storage_proto_msgTypes[6]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -521,7 +596,7 @@ func (x *RegistrationRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use RegistrationRequest.ProtoReflect.Descriptor instead.
 func (*RegistrationRequest) Descriptor() ([]byte, []int) {
-	return file_nitric_proto_storage_v1_storage_proto_rawDescGZIP(), []int{5}
+	return file_nitric_proto_storage_v1_storage_proto_rawDescGZIP(), []int{6}
 }
 
 func (x *RegistrationRequest) GetBucketName() string {
@@ -557,7 +632,7 @@ type RegistrationResponse struct {
 func (x *RegistrationResponse) Reset() {
 	*x = RegistrationResponse{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_nitric_proto_storage_v1_storage_proto_msgTypes[6]
+		mi := &file_nitric_proto_storage_v1_storage_proto_msgTypes[7]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -570,7 +645,7 @@ func (x *RegistrationResponse) String() string {
 func (*RegistrationResponse) ProtoMessage() {}
 
 func (x *RegistrationResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_nitric_proto_storage_v1_storage_proto_msgTypes[6]
+	mi := &file_nitric_proto_storage_v1_storage_proto_msgTypes[7]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -583,7 +658,7 @@ func (x *RegistrationResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use RegistrationResponse.ProtoReflect.Descriptor instead.
 func (*RegistrationResponse) Descriptor() ([]byte, []int) {
-	return file_nitric_proto_storage_v1_storage_proto_rawDescGZIP(), []int{6}
+	return file_nitric_proto_storage_v1_storage_proto_rawDescGZIP(), []int{7}
 }
 
 func (x *RegistrationResponse) GetId() string {
@@ -607,12 +682,18 @@ type StorageWriteRequest struct {
 	Key string `protobuf:"bytes,2,opt,name=key,proto3" json:"key,omitempty"`
 	// bytes array to store
 	Body []byte `protobuf:"bytes,3,opt,name=body,proto3" json:"body,omitempty"`
+	// MIME type of the item, detected from the body when not provided
+	ContentType string `protobuf:"bytes,4,opt,name=content_type,json=contentType,proto3" json:"content_type,omitempty"`
+	// Cache-Control header returned when the item is served, e.g. through a pre-signed URL
+	CacheControl string `protobuf:"bytes,5,opt,name=cache_control,json=cacheControl,proto3" json:"cache_control,omitempty"`
+	// User defined metadata to store with the item
+	Metadata map[string]string `protobuf:"bytes,6,rep,name=metadata,proto3" json:"metadata,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
 }
 
 func (x *StorageWriteRequest) Reset() {
 	*x = StorageWriteRequest{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_nitric_proto_storage_v1_storage_proto_msgTypes[7]
+		mi := &file_nitric_proto_storage_v1_storage_proto_msgTypes[8]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -625,7 +706,7 @@ func (x *StorageWriteRequest) String() string {
 func (*StorageWriteRequest) ProtoMessage() {}
 
 func (x *StorageWriteRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_nitric_proto_storage_v1_storage_proto_msgTypes[7]
+	mi := &file_nitric_proto_storage_v1_storage_proto_msgTypes[8]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -638,7 +719,7 @@ func (x *StorageWriteRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use StorageWriteRequest.ProtoReflect.Descriptor instead.
 func (*StorageWriteRequest) Descriptor() ([]byte, []int) {
-	return file_nitric_proto_storage_v1_storage_proto_rawDescGZIP(), []int{7}
+	return file_nitric_proto_storage_v1_storage_proto_rawDescGZIP(), []int{8}
 }
 
 func (x *StorageWriteRequest) GetBucketName() string {
@@ -662,6 +743,27 @@ func (x *StorageWriteRequest) GetBody() []byte {
 	return nil
 }
 
+func (x *StorageWriteRequest) GetContentType() string {
+	if x != nil {
+		return x.ContentType
+	}
+	return ""
+}
+
+func (x *StorageWriteRequest) GetCacheControl() string {
+	if x != nil {
+		return x.CacheControl
+	}
+	return ""
+}
+
+func (x *StorageWriteRequest) GetMetadata() map[string]string {
+	if x != nil {
+		return x.Metadata
+	}
+	return nil
+}
+
 // Result of putting a storage item
 type StorageWriteResponse struct {
 	state         protoimpl.MessageState
@@ -672,7 +774,7 @@ type StorageWriteResponse struct {
 func (x *StorageWriteResponse) Reset() {
 	*x = StorageWriteResponse{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_nitric_proto_storage_v1_storage_proto_msgTypes[8]
+		mi := &file_nitric_proto_storage_v1_storage_proto_msgTypes[9]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -685,7 +787,7 @@ func (x *StorageWriteResponse) String() string {
 func (*StorageWriteResponse) ProtoMessage() {}
 
 func (x *StorageWriteResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_nitric_proto_storage_v1_storage_proto_msgTypes[8]
+	mi := &file_nitric_proto_storage_v1_storage_proto_msgTypes[9]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -698,7 +800,7 @@ func (x *StorageWriteResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use StorageWriteResponse.ProtoReflect.Descriptor instead.
 func (*StorageWriteResponse) Descriptor() ([]byte, []int) {
-	return file_nitric_proto_storage_v1_storage_proto_rawDescGZIP(), []int{8}
+	return file_nitric_proto_storage_v1_storage_proto_rawDescGZIP(), []int{9}
 }
 
 // Request to retrieve a storage item
@@ -718,7 +820,7 @@ type StorageReadRequest struct {
 func (x *StorageReadRequest) Reset() {
 	*x = StorageReadRequest{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_nitric_proto_storage_v1_storage_proto_msgTypes[9]
+		mi := &file_nitric_proto_storage_v1_storage_proto_msgTypes[10]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -731,7 +833,7 @@ func (x *StorageReadRequest) String() string {
 func (*StorageReadRequest) ProtoMessage() {}
 
 func (x *StorageReadRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_nitric_proto_storage_v1_storage_proto_msgTypes[9]
+	mi := &file_nitric_proto_storage_v1_storage_proto_msgTypes[10]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -744,7 +846,7 @@ func (x *StorageReadRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use StorageReadRequest.ProtoReflect.Descriptor instead.
 func (*StorageReadRequest) Descriptor() ([]byte, []int) {
-	return file_nitric_proto_storage_v1_storage_proto_rawDescGZIP(), []int{9}
+	return file_nitric_proto_storage_v1_storage_proto_rawDescGZIP(), []int{10}
 }
 
 func (x *StorageReadRequest) GetBucketName() string {
@@ -774,7 +876,7 @@ type StorageReadResponse struct {
 func (x *StorageReadResponse) Reset() {
 	*x = StorageReadResponse{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_nitric_proto_storage_v1_storage_proto_msgTypes[10]
+		mi := &file_nitric_proto_storage_v1_storage_proto_msgTypes[11]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -787,7 +889,7 @@ func (x *StorageReadResponse) String() string {
 func (*StorageReadResponse) ProtoMessage() {}
 
 func (x *StorageReadResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_nitric_proto_storage_v1_storage_proto_msgTypes[10]
+	mi := &file_nitric_proto_storage_v1_storage_proto_msgTypes[11]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -800,7 +902,7 @@ func (x *StorageReadResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use StorageReadResponse.ProtoReflect.Descriptor instead.
 func (*StorageReadResponse) Descriptor() ([]byte, []int) {
-	return file_nitric_proto_storage_v1_storage_proto_rawDescGZIP(), []int{10}
+	return file_nitric_proto_storage_v1_storage_proto_rawDescGZIP(), []int{11}
 }
 
 func (x *StorageReadResponse) GetBody() []byte {
@@ -810,35 +912,41 @@ func (x *StorageReadResponse) GetBody() []byte {
 	return nil
 }
 
-// Request to delete a storage item
-type StorageDeleteRequest struct {
+// Request to retrieve a storage item as a stream of chunks
+type StorageReadStreamRequest struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	// Name of the bucket to delete from
+	// Nitric name of the bucket to retrieve from
+	//
+	//	this will be automatically resolved to the provider specific bucket identifier.
 	BucketName string `protobuf:"bytes,1,opt,name=bucket_name,json=bucketName,proto3" json:"bucket_name,omitempty"`
-	// Key of item to delete
+	// Key of item to retrieve
 	Key string `protobuf:"bytes,2,opt,name=key,proto3" json:"key,omitempty"`
+	// Byte offset to start reading from
+	Offset int64 `protobuf:"varint,3,opt,name=offset,proto3" json:"offset,omitempty"`
+	// Maximum number of bytes to read, 0 reads to the end of the item
+	Length int64 `protobuf:"varint,4,opt,name=length,proto3" json:"length,omitempty"`
 }
 
-func (x *StorageDeleteRequest) Reset() {
-	*x = StorageDeleteRequest{}
+func (x *StorageReadStreamRequest) Reset() {
+	*x = StorageReadStreamRequest{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_nitric_proto_storage_v1_storage_proto_msgTypes[11]
+		mi := &file_nitric_proto_storage_v1_storage_proto_msgTypes[12]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *StorageDeleteRequest) String() string {
+func (x *StorageReadStreamRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*StorageDeleteRequest) ProtoMessage() {}
+func (*StorageReadStreamRequest) ProtoMessage() {}
 
-func (x *StorageDeleteRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_nitric_proto_storage_v1_storage_proto_msgTypes[11]
+func (x *StorageReadStreamRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_nitric_proto_storage_v1_storage_proto_msgTypes[12]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -849,49 +957,66 @@ func (x *StorageDeleteRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use StorageDeleteRequest.ProtoReflect.Descriptor instead.
-func (*StorageDeleteRequest) Descriptor() ([]byte, []int) {
-	return file_nitric_proto_storage_v1_storage_proto_rawDescGZIP(), []int{11}
+// Deprecated: Use StorageReadStreamRequest.ProtoReflect.Descriptor instead.
+func (*StorageReadStreamRequest) Descriptor() ([]byte, []int) {
+	return file_nitric_proto_storage_v1_storage_proto_rawDescGZIP(), []int{12}
 }
 
-func (x *StorageDeleteRequest) GetBucketName() string {
+func (x *StorageReadStreamRequest) GetBucketName() string {
 	if x != nil {
 		return x.BucketName
 	}
 	return ""
 }
 
-func (x *StorageDeleteRequest) GetKey() string {
+func (x *StorageReadStreamRequest) GetKey() string {
 	if x != nil {
 		return x.Key
 	}
 	return ""
 }
 
-// Result of deleting a storage item
-type StorageDeleteResponse struct {
+func (x *StorageReadStreamRequest) GetOffset() int64 {
+	if x != nil {
+		return x.Offset
+	}
+	return 0
+}
+
+func (x *StorageReadStreamRequest) GetLength() int64 {
+	if x != nil {
+		return x.Length
+	}
+	return 0
+}
+
+// A chunk of a streamed storage item
+type StorageReadStreamResponse struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
+
+	// The next bytes of the retrieved storage item
+	Chunk []byte `protobuf:"bytes,1,opt,name=chunk,proto3" json:"chunk,omitempty"`
 }
 
-func (x *StorageDeleteResponse) Reset() {
-	*x = StorageDeleteResponse{}
+func (x *StorageReadStreamResponse) Reset() {
+	*x = StorageReadStreamResponse{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_nitric_proto_storage_v1_storage_proto_msgTypes[12]
+		mi := &file_nitric_proto_storage_v1_storage_proto_msgTypes[13]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *StorageDeleteResponse) String() string {
+func (x *StorageReadStreamResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*StorageDeleteResponse) ProtoMessage() {}
+func (*StorageReadStreamResponse) ProtoMessage() {}
 
-func (x *StorageDeleteResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_nitric_proto_storage_v1_storage_proto_msgTypes[12]
+func (x *StorageReadStreamResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_nitric_proto_storage_v1_storage_proto_msgTypes[13]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -902,46 +1027,55 @@ func (x *StorageDeleteResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use StorageDeleteResponse.ProtoReflect.Descriptor instead.
-func (*StorageDeleteResponse) Descriptor() ([]byte, []int) {
-	return file_nitric_proto_storage_v1_storage_proto_rawDescGZIP(), []int{12}
+// Deprecated: Use StorageReadStreamResponse.ProtoReflect.Descriptor instead.
+func (*StorageReadStreamResponse) Descriptor() ([]byte, []int) {
+	return file_nitric_proto_storage_v1_storage_proto_rawDescGZIP(), []int{13}
 }
 
-// Request to generate a pre-signed URL for a blob to perform a specific operation, such as read or write.
-type StoragePreSignUrlRequest struct {
+func (x *StorageReadStreamResponse) GetChunk() []byte {
+	if x != nil {
+		return x.Chunk
+	}
+	return nil
+}
+
+// Identifies the storage item a stream of chunks will be written to
+type StorageWriteStreamHeader struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	// Nitric name of the bucket to retrieve from
+	// Nitric name of the bucket to store in
 	//
 	//	this will be automatically resolved to the provider specific bucket identifier.
 	BucketName string `protobuf:"bytes,1,opt,name=bucket_name,json=bucketName,proto3" json:"bucket_name,omitempty"`
-	// Key of item to generate the signed URL for.
-	// The URL and the token it contains will only be valid for operations on this resource specifically.
-	Key       string                             `protobuf:"bytes,2,opt,name=key,proto3" json:"key,omitempty"`
-	Operation StoragePreSignUrlRequest_Operation `protobuf:"varint,3,opt,name=operation,proto3,enum=nitric.proto.storage.v1.StoragePreSignUrlRequest_Operation" json:"operation,omitempty"`
-	// Expiry defined as as protobuf duration
-	Expiry *durationpb.Duration `protobuf:"bytes,4,opt,name=expiry,proto3" json:"expiry,omitempty"`
+	// Key to store the item under
+	Key string `protobuf:"bytes,2,opt,name=key,proto3" json:"key,omitempty"`
+	// MIME type of the item, detected from the first chunk when not provided
+	ContentType string `protobuf:"bytes,3,opt,name=content_type,json=contentType,proto3" json:"content_type,omitempty"`
+	// Cache-Control header returned when the item is served, e.g. through a pre-signed URL
+	CacheControl string `protobuf:"bytes,4,opt,name=cache_control,json=cacheControl,proto3" json:"cache_control,omitempty"`
+	// User defined metadata to store with the item
+	Metadata map[string]string `protobuf:"bytes,5,rep,name=metadata,proto3" json:"metadata,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
 }
 
-func (x *StoragePreSignUrlRequest) Reset() {
-	*x = StoragePreSignUrlRequest{}
+func (x *StorageWriteStreamHeader) Reset() {
+	*x = StorageWriteStreamHeader{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_nitric_proto_storage_v1_storage_proto_msgTypes[13]
+		mi := &file_nitric_proto_storage_v1_storage_proto_msgTypes[14]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *StoragePreSignUrlRequest) String() string {
+func (x *StorageWriteStreamHeader) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*StoragePreSignUrlRequest) ProtoMessage() {}
+func (*StorageWriteStreamHeader) ProtoMessage() {}
 
-func (x *StoragePreSignUrlRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_nitric_proto_storage_v1_storage_proto_msgTypes[13]
+func (x *StorageWriteStreamHeader) ProtoReflect() protoreflect.Message {
+	mi := &file_nitric_proto_storage_v1_storage_proto_msgTypes[14]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -952,65 +1086,76 @@ func (x *StoragePreSignUrlRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use StoragePreSignUrlRequest.ProtoReflect.Descriptor instead.
-func (*StoragePreSignUrlRequest) Descriptor() ([]byte, []int) {
-	return file_nitric_proto_storage_v1_storage_proto_rawDescGZIP(), []int{13}
+// Deprecated: Use StorageWriteStreamHeader.ProtoReflect.Descriptor instead.
+func (*StorageWriteStreamHeader) Descriptor() ([]byte, []int) {
+	return file_nitric_proto_storage_v1_storage_proto_rawDescGZIP(), []int{14}
 }
 
-func (x *StoragePreSignUrlRequest) GetBucketName() string {
+func (x *StorageWriteStreamHeader) GetBucketName() string {
 	if x != nil {
 		return x.BucketName
 	}
 	return ""
 }
 
-func (x *StoragePreSignUrlRequest) GetKey() string {
+func (x *StorageWriteStreamHeader) GetKey() string {
 	if x != nil {
 		return x.Key
 	}
 	return ""
 }
 
-func (x *StoragePreSignUrlRequest) GetOperation() StoragePreSignUrlRequest_Operation {
+func (x *StorageWriteStreamHeader) GetContentType() string {
 	if x != nil {
-		return x.Operation
+		return x.ContentType
 	}
-	return StoragePreSignUrlRequest_READ
+	return ""
 }
 
-func (x *StoragePreSignUrlRequest) GetExpiry() *durationpb.Duration {
+func (x *StorageWriteStreamHeader) GetCacheControl() string {
 	if x != nil {
-		return x.Expiry
+		return x.CacheControl
+	}
+	return ""
+}
+
+func (x *StorageWriteStreamHeader) GetMetadata() map[string]string {
+	if x != nil {
+		return x.Metadata
 	}
 	return nil
 }
 
-type StoragePreSignUrlResponse struct {
+// Request to put (create/update) a storage item from a stream of chunks
+type StorageWriteStreamRequest struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	// The pre-signed url, restricted to the operation, resource and expiry time specified in the request.
-	Url string `protobuf:"bytes,1,opt,name=url,proto3" json:"url,omitempty"`
+	// Types that are assignable to Content:
+	//
+	//	*StorageWriteStreamRequest_Header
+	//	*StorageWriteStreamRequest_Chunk
+	Content isStorageWriteStreamRequest_Content `protobuf_oneof:"content"`
 }
 
-func (x *StoragePreSignUrlResponse) Reset() {
-	*x = StoragePreSignUrlResponse{}
+func (x *StorageWriteStreamRequest) Reset() {
+	*x = StorageWriteStreamRequest{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_nitric_proto_storage_v1_storage_proto_msgTypes[14]
+		mi := &file_nitric_proto_storage_v1_storage_proto_msgTypes[15]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *StoragePreSignUrlResponse) String() string {
+func (x *StorageWriteStreamRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*StoragePreSignUrlResponse) ProtoMessage() {}
+func (*StorageWriteStreamRequest) ProtoMessage() {}
 
-func (x *StoragePreSignUrlResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_nitric_proto_storage_v1_storage_proto_msgTypes[14]
+func (x *StorageWriteStreamRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_nitric_proto_storage_v1_storage_proto_msgTypes[15]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1021,44 +1166,357 @@ func (x *StoragePreSignUrlResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use StoragePreSignUrlResponse.ProtoReflect.Descriptor instead.
-func (*StoragePreSignUrlResponse) Descriptor() ([]byte, []int) {
-	return file_nitric_proto_storage_v1_storage_proto_rawDescGZIP(), []int{14}
+// Deprecated: Use StorageWriteStreamRequest.ProtoReflect.Descriptor instead.
+func (*StorageWriteStreamRequest) Descriptor() ([]byte, []int) {
+	return file_nitric_proto_storage_v1_storage_proto_rawDescGZIP(), []int{15}
 }
 
-func (x *StoragePreSignUrlResponse) GetUrl() string {
-	if x != nil {
-		return x.Url
+func (m *StorageWriteStreamRequest) GetContent() isStorageWriteStreamRequest_Content {
+	if m != nil {
+		return m.Content
 	}
-	return ""
+	return nil
 }
 
-type StorageListBlobsRequest struct {
+func (x *StorageWriteStreamRequest) GetHeader() *StorageWriteStreamHeader {
+	if x, ok := x.GetContent().(*StorageWriteStreamRequest_Header); ok {
+		return x.Header
+	}
+	return nil
+}
+
+func (x *StorageWriteStreamRequest) GetChunk() []byte {
+	if x, ok := x.GetContent().(*StorageWriteStreamRequest_Chunk); ok {
+		return x.Chunk
+	}
+	return nil
+}
+
+type isStorageWriteStreamRequest_Content interface {
+	isStorageWriteStreamRequest_Content()
+}
+
+type StorageWriteStreamRequest_Header struct {
+	// The item to write, must be the first message on the stream
+	Header *StorageWriteStreamHeader `protobuf:"bytes,1,opt,name=header,proto3,oneof"`
+}
+
+type StorageWriteStreamRequest_Chunk struct {
+	// The next bytes of the item, in order
+	Chunk []byte `protobuf:"bytes,2,opt,name=chunk,proto3,oneof"`
+}
+
+func (*StorageWriteStreamRequest_Header) isStorageWriteStreamRequest_Content() {}
+
+func (*StorageWriteStreamRequest_Chunk) isStorageWriteStreamRequest_Content() {}
+
+// Result of putting a streamed storage item
+type StorageWriteStreamResponse struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	BucketName string `protobuf:"bytes,1,opt,name=bucket_name,json=bucketName,proto3" json:"bucket_name,omitempty"`
-	Prefix     string `protobuf:"bytes,2,opt,name=prefix,proto3" json:"prefix,omitempty"`
+	// Total number of bytes written
+	Size int64 `protobuf:"varint,1,opt,name=size,proto3" json:"size,omitempty"`
 }
 
-func (x *StorageListBlobsRequest) Reset() {
-	*x = StorageListBlobsRequest{}
+func (x *StorageWriteStreamResponse) Reset() {
+	*x = StorageWriteStreamResponse{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_nitric_proto_storage_v1_storage_proto_msgTypes[15]
+		mi := &file_nitric_proto_storage_v1_storage_proto_msgTypes[16]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *StorageListBlobsRequest) String() string {
+func (x *StorageWriteStreamResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StorageWriteStreamResponse) ProtoMessage() {}
+
+func (x *StorageWriteStreamResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_nitric_proto_storage_v1_storage_proto_msgTypes[16]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use StorageWriteStreamResponse.ProtoReflect.Descriptor instead.
+func (*StorageWriteStreamResponse) Descriptor() ([]byte, []int) {
+	return file_nitric_proto_storage_v1_storage_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *StorageWriteStreamResponse) GetSize() int64 {
+	if x != nil {
+		return x.Size
+	}
+	return 0
+}
+
+// Request to delete a storage item
+type StorageDeleteRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Name of the bucket to delete from
+	BucketName string `protobuf:"bytes,1,opt,name=bucket_name,json=bucketName,proto3" json:"bucket_name,omitempty"`
+	// Key of item to delete
+	Key string `protobuf:"bytes,2,opt,name=key,proto3" json:"key,omitempty"`
+}
+
+func (x *StorageDeleteRequest) Reset() {
+	*x = StorageDeleteRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_nitric_proto_storage_v1_storage_proto_msgTypes[17]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *StorageDeleteRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StorageDeleteRequest) ProtoMessage() {}
+
+func (x *StorageDeleteRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_nitric_proto_storage_v1_storage_proto_msgTypes[17]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use StorageDeleteRequest.ProtoReflect.Descriptor instead.
+func (*StorageDeleteRequest) Descriptor() ([]byte, []int) {
+	return file_nitric_proto_storage_v1_storage_proto_rawDescGZIP(), []int{17}
+}
+
+func (x *StorageDeleteRequest) GetBucketName() string {
+	if x != nil {
+		return x.BucketName
+	}
+	return ""
+}
+
+func (x *StorageDeleteRequest) GetKey() string {
+	if x != nil {
+		return x.Key
+	}
+	return ""
+}
+
+// Result of deleting a storage item
+type StorageDeleteResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+}
+
+func (x *StorageDeleteResponse) Reset() {
+	*x = StorageDeleteResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_nitric_proto_storage_v1_storage_proto_msgTypes[18]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *StorageDeleteResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StorageDeleteResponse) ProtoMessage() {}
+
+func (x *StorageDeleteResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_nitric_proto_storage_v1_storage_proto_msgTypes[18]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use StorageDeleteResponse.ProtoReflect.Descriptor instead.
+func (*StorageDeleteResponse) Descriptor() ([]byte, []int) {
+	return file_nitric_proto_storage_v1_storage_proto_rawDescGZIP(), []int{18}
+}
+
+// Request to generate a pre-signed URL for a blob to perform a specific operation, such as read or write.
+type StoragePreSignUrlRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Nitric name of the bucket to retrieve from
+	//
+	//	this will be automatically resolved to the provider specific bucket identifier.
+	BucketName string `protobuf:"bytes,1,opt,name=bucket_name,json=bucketName,proto3" json:"bucket_name,omitempty"`
+	// Key of item to generate the signed URL for.
+	// The URL and the token it contains will only be valid for operations on this resource specifically.
+	Key       string                             `protobuf:"bytes,2,opt,name=key,proto3" json:"key,omitempty"`
+	Operation StoragePreSignUrlRequest_Operation `protobuf:"varint,3,opt,name=operation,proto3,enum=nitric.proto.storage.v1.StoragePreSignUrlRequest_Operation" json:"operation,omitempty"`
+	// Expiry defined as as protobuf duration
+	Expiry *durationpb.Duration `protobuf:"bytes,4,opt,name=expiry,proto3" json:"expiry,omitempty"`
+}
+
+func (x *StoragePreSignUrlRequest) Reset() {
+	*x = StoragePreSignUrlRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_nitric_proto_storage_v1_storage_proto_msgTypes[19]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *StoragePreSignUrlRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StoragePreSignUrlRequest) ProtoMessage() {}
+
+func (x *StoragePreSignUrlRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_nitric_proto_storage_v1_storage_proto_msgTypes[19]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use StoragePreSignUrlRequest.ProtoReflect.Descriptor instead.
+func (*StoragePreSignUrlRequest) Descriptor() ([]byte, []int) {
+	return file_nitric_proto_storage_v1_storage_proto_rawDescGZIP(), []int{19}
+}
+
+func (x *StoragePreSignUrlRequest) GetBucketName() string {
+	if x != nil {
+		return x.BucketName
+	}
+	return ""
+}
+
+func (x *StoragePreSignUrlRequest) GetKey() string {
+	if x != nil {
+		return x.Key
+	}
+	return ""
+}
+
+func (x *StoragePreSignUrlRequest) GetOperation() StoragePreSignUrlRequest_Operation {
+	if x != nil {
+		return x.Operation
+	}
+	return StoragePreSignUrlRequest_READ
+}
+
+func (x *StoragePreSignUrlRequest) GetExpiry() *durationpb.Duration {
+	if x != nil {
+		return x.Expiry
+	}
+	return nil
+}
+
+type StoragePreSignUrlResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// The pre-signed url, restricted to the operation, resource and expiry time specified in the request.
+	Url string `protobuf:"bytes,1,opt,name=url,proto3" json:"url,omitempty"`
+}
+
+func (x *StoragePreSignUrlResponse) Reset() {
+	*x = StoragePreSignUrlResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_nitric_proto_storage_v1_storage_proto_msgTypes[20]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *StoragePreSignUrlResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StoragePreSignUrlResponse) ProtoMessage() {}
+
+func (x *StoragePreSignUrlResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_nitric_proto_storage_v1_storage_proto_msgTypes[20]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use StoragePreSignUrlResponse.ProtoReflect.Descriptor instead.
+func (*StoragePreSignUrlResponse) Descriptor() ([]byte, []int) {
+	return file_nitric_proto_storage_v1_storage_proto_rawDescGZIP(), []int{20}
+}
+
+func (x *StoragePreSignUrlResponse) GetUrl() string {
+	if x != nil {
+		return x.Url
+	}
+	return ""
+}
+
+type StorageListBlobsRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	BucketName string `protobuf:"bytes,1,opt,name=bucket_name,json=bucketName,proto3" json:"bucket_name,omitempty"`
+	Prefix     string `protobuf:"bytes,2,opt,name=prefix,proto3" json:"prefix,omitempty"`
+	// Groups keys containing the delimiter after the prefix into prefixes, like directories, instead of listing them
+	Delimiter string `protobuf:"bytes,3,opt,name=delimiter,proto3" json:"delimiter,omitempty"`
+	// Maximum number of blobs and prefixes to return in a page, 0 returns all of them
+	Limit int32 `protobuf:"varint,4,opt,name=limit,proto3" json:"limit,omitempty"`
+	// Token of the page to return, from a previous response's next_page_token
+	PageToken string `protobuf:"bytes,5,opt,name=page_token,json=pageToken,proto3" json:"page_token,omitempty"`
+	// Populate the content type and user metadata of each blob,
+	// some providers require an additional request per blob to do so
+	IncludeMetadata bool `protobuf:"varint,6,opt,name=include_metadata,json=includeMetadata,proto3" json:"include_metadata,omitempty"`
+}
+
+func (x *StorageListBlobsRequest) Reset() {
+	*x = StorageListBlobsRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_nitric_proto_storage_v1_storage_proto_msgTypes[21]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *StorageListBlobsRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
 func (*StorageListBlobsRequest) ProtoMessage() {}
 
 func (x *StorageListBlobsRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_nitric_proto_storage_v1_storage_proto_msgTypes[15]
+	mi := &file_nitric_proto_storage_v1_storage_proto_msgTypes[21]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1071,48 +1529,245 @@ func (x *StorageListBlobsRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use StorageListBlobsRequest.ProtoReflect.Descriptor instead.
 func (*StorageListBlobsRequest) Descriptor() ([]byte, []int) {
-	return file_nitric_proto_storage_v1_storage_proto_rawDescGZIP(), []int{15}
+	return file_nitric_proto_storage_v1_storage_proto_rawDescGZIP(), []int{21}
+}
+
+func (x *StorageListBlobsRequest) GetBucketName() string {
+	if x != nil {
+		return x.BucketName
+	}
+	return ""
+}
+
+func (x *StorageListBlobsRequest) GetPrefix() string {
+	if x != nil {
+		return x.Prefix
+	}
+	return ""
+}
+
+func (x *StorageListBlobsRequest) GetDelimiter() string {
+	if x != nil {
+		return x.Delimiter
+	}
+	return ""
+}
+
+func (x *StorageListBlobsRequest) GetLimit() int32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+func (x *StorageListBlobsRequest) GetPageToken() string {
+	if x != nil {
+		return x.PageToken
+	}
+	return ""
+}
+
+func (x *StorageListBlobsRequest) GetIncludeMetadata() bool {
+	if x != nil {
+		return x.IncludeMetadata
+	}
+	return false
+}
+
+type Blob struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Key string `protobuf:"bytes,1,opt,name=key,proto3" json:"key,omitempty"`
+	// Size of the blob in bytes
+	Size int64 `protobuf:"varint,2,opt,name=size,proto3" json:"size,omitempty"`
+	// Entity tag of the blob's current contents
+	Etag string `protobuf:"bytes,3,opt,name=etag,proto3" json:"etag,omitempty"`
+	// When the blob was last modified
+	LastModified *timestamppb.Timestamp `protobuf:"bytes,4,opt,name=last_modified,json=lastModified,proto3" json:"last_modified,omitempty"`
+	// MIME type of the blob
+	ContentType string `protobuf:"bytes,5,opt,name=content_type,json=contentType,proto3" json:"content_type,omitempty"`
+	// Cache-Control header returned when the blob is served
+	CacheControl string `protobuf:"bytes,6,opt,name=cache_control,json=cacheControl,proto3" json:"cache_control,omitempty"`
+	// User defined metadata stored with the blob
+	Metadata map[string]string `protobuf:"bytes,7,rep,name=metadata,proto3" json:"metadata,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+}
+
+func (x *Blob) Reset() {
+	*x = Blob{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_nitric_proto_storage_v1_storage_proto_msgTypes[22]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Blob) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Blob) ProtoMessage() {}
+
+func (x *Blob) ProtoReflect() protoreflect.Message {
+	mi := &file_nitric_proto_storage_v1_storage_proto_msgTypes[22]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Blob.ProtoReflect.Descriptor instead.
+func (*Blob) Descriptor() ([]byte, []int) {
+	return file_nitric_proto_storage_v1_storage_proto_rawDescGZIP(), []int{22}
+}
+
+func (x *Blob) GetKey() string {
+	if x != nil {
+		return x.Key
+	}
+	return ""
+}
+
+func (x *Blob) GetSize() int64 {
+	if x != nil {
+		return x.Size
+	}
+	return 0
+}
+
+func (x *Blob) GetEtag() string {
+	if x != nil {
+		return x.Etag
+	}
+	return ""
+}
+
+func (x *Blob) GetLastModified() *timestamppb.Timestamp {
+	if x != nil {
+		return x.LastModified
+	}
+	return nil
+}
+
+func (x *Blob) GetContentType() string {
+	if x != nil {
+		return x.ContentType
+	}
+	return ""
+}
+
+func (x *Blob) GetCacheControl() string {
+	if x != nil {
+		return x.CacheControl
+	}
+	return ""
+}
+
+func (x *Blob) GetMetadata() map[string]string {
+	if x != nil {
+		return x.Metadata
+	}
+	return nil
+}
+
+type StorageListBlobsResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// keys of the blobs in the bucket
+	Blobs []*Blob `protobuf:"bytes,1,rep,name=blobs,proto3" json:"blobs,omitempty"`
+	// Common prefixes of the keys grouped by the request's delimiter
+	Prefixes []string `protobuf:"bytes,2,rep,name=prefixes,proto3" json:"prefixes,omitempty"`
+	// Token for the next page of results, empty when there are no more
+	NextPageToken string `protobuf:"bytes,3,opt,name=next_page_token,json=nextPageToken,proto3" json:"next_page_token,omitempty"`
+}
+
+func (x *StorageListBlobsResponse) Reset() {
+	*x = StorageListBlobsResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_nitric_proto_storage_v1_storage_proto_msgTypes[23]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *StorageListBlobsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StorageListBlobsResponse) ProtoMessage() {}
+
+func (x *StorageListBlobsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_nitric_proto_storage_v1_storage_proto_msgTypes[23]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use StorageListBlobsResponse.ProtoReflect.Descriptor instead.
+func (*StorageListBlobsResponse) Descriptor() ([]byte, []int) {
+	return file_nitric_proto_storage_v1_storage_proto_rawDescGZIP(), []int{23}
+}
+
+func (x *StorageListBlobsResponse) GetBlobs() []*Blob {
+	if x != nil {
+		return x.Blobs
+	}
+	return nil
 }
 
-func (x *StorageListBlobsRequest) GetBucketName() string {
+func (x *StorageListBlobsResponse) GetPrefixes() []string {
 	if x != nil {
-		return x.BucketName
+		return x.Prefixes
 	}
-	return ""
+	return nil
 }
 
-func (x *StorageListBlobsRequest) GetPrefix() string {
+func (x *StorageListBlobsResponse) GetNextPageToken() string {
 	if x != nil {
-		return x.Prefix
+		return x.NextPageToken
 	}
 	return ""
 }
 
-type Blob struct {
+type StorageExistsRequest struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Key string `protobuf:"bytes,1,opt,name=key,proto3" json:"key,omitempty"`
+	BucketName string `protobuf:"bytes,1,opt,name=bucket_name,json=bucketName,proto3" json:"bucket_name,omitempty"`
+	// Key of item to retrieve
+	Key string `protobuf:"bytes,2,opt,name=key,proto3" json:"key,omitempty"`
 }
 
-func (x *Blob) Reset() {
-	*x = Blob{}
+func (x *StorageExistsRequest) Reset() {
+	*x = StorageExistsRequest{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_nitric_proto_storage_v1_storage_proto_msgTypes[16]
+		mi := &file_nitric_proto_storage_v1_storage_proto_msgTypes[24]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *Blob) String() string {
+func (x *StorageExistsRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*Blob) ProtoMessage() {}
+func (*StorageExistsRequest) ProtoMessage() {}
 
-func (x *Blob) ProtoReflect() protoreflect.Message {
-	mi := &file_nitric_proto_storage_v1_storage_proto_msgTypes[16]
+func (x *StorageExistsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_nitric_proto_storage_v1_storage_proto_msgTypes[24]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1123,44 +1778,50 @@ func (x *Blob) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use Blob.ProtoReflect.Descriptor instead.
-func (*Blob) Descriptor() ([]byte, []int) {
-	return file_nitric_proto_storage_v1_storage_proto_rawDescGZIP(), []int{16}
+// Deprecated: Use StorageExistsRequest.ProtoReflect.Descriptor instead.
+func (*StorageExistsRequest) Descriptor() ([]byte, []int) {
+	return file_nitric_proto_storage_v1_storage_proto_rawDescGZIP(), []int{24}
 }
 
-func (x *Blob) GetKey() string {
+func (x *StorageExistsRequest) GetBucketName() string {
+	if x != nil {
+		return x.BucketName
+	}
+	return ""
+}
+
+func (x *StorageExistsRequest) GetKey() string {
 	if x != nil {
 		return x.Key
 	}
 	return ""
 }
 
-type StorageListBlobsResponse struct {
+type StorageExistsResponse struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	// keys of the blobs in the bucket
-	Blobs []*Blob `protobuf:"bytes,1,rep,name=blobs,proto3" json:"blobs,omitempty"`
+	Exists bool `protobuf:"varint,1,opt,name=exists,proto3" json:"exists,omitempty"`
 }
 
-func (x *StorageListBlobsResponse) Reset() {
-	*x = StorageListBlobsResponse{}
+func (x *StorageExistsResponse) Reset() {
+	*x = StorageExistsResponse{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_nitric_proto_storage_v1_storage_proto_msgTypes[17]
+		mi := &file_nitric_proto_storage_v1_storage_proto_msgTypes[25]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *StorageListBlobsResponse) String() string {
+func (x *StorageExistsResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*StorageListBlobsResponse) ProtoMessage() {}
+func (*StorageExistsResponse) ProtoMessage() {}
 
-func (x *StorageListBlobsResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_nitric_proto_storage_v1_storage_proto_msgTypes[17]
+func (x *StorageExistsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_nitric_proto_storage_v1_storage_proto_msgTypes[25]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1171,45 +1832,46 @@ func (x *StorageListBlobsResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use StorageListBlobsResponse.ProtoReflect.Descriptor instead.
-func (*StorageListBlobsResponse) Descriptor() ([]byte, []int) {
-	return file_nitric_proto_storage_v1_storage_proto_rawDescGZIP(), []int{17}
+// Deprecated: Use StorageExistsResponse.ProtoReflect.Descriptor instead.
+func (*StorageExistsResponse) Descriptor() ([]byte, []int) {
+	return file_nitric_proto_storage_v1_storage_proto_rawDescGZIP(), []int{25}
 }
 
-func (x *StorageListBlobsResponse) GetBlobs() []*Blob {
+func (x *StorageExistsResponse) GetExists() bool {
 	if x != nil {
-		return x.Blobs
+		return x.Exists
 	}
-	return nil
+	return false
 }
 
-type StorageExistsRequest struct {
+// Request to retrieve the details of a storage item
+type StorageStatRequest struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
 	BucketName string `protobuf:"bytes,1,opt,name=bucket_name,json=bucketName,proto3" json:"bucket_name,omitempty"`
-	// Key of item to retrieve
+	// Key of item to retrieve the details of
 	Key string `protobuf:"bytes,2,opt,name=key,proto3" json:"key,omitempty"`
 }
 
-func (x *StorageExistsRequest) Reset() {
-	*x = StorageExistsRequest{}
+func (x *StorageStatRequest) Reset() {
+	*x = StorageStatRequest{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_nitric_proto_storage_v1_storage_proto_msgTypes[18]
+		mi := &file_nitric_proto_storage_v1_storage_proto_msgTypes[26]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *StorageExistsRequest) String() string {
+func (x *StorageStatRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*StorageExistsRequest) ProtoMessage() {}
+func (*StorageStatRequest) ProtoMessage() {}
 
-func (x *StorageExistsRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_nitric_proto_storage_v1_storage_proto_msgTypes[18]
+func (x *StorageStatRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_nitric_proto_storage_v1_storage_proto_msgTypes[26]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1220,50 +1882,51 @@ func (x *StorageExistsRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use StorageExistsRequest.ProtoReflect.Descriptor instead.
-func (*StorageExistsRequest) Descriptor() ([]byte, []int) {
-	return file_nitric_proto_storage_v1_storage_proto_rawDescGZIP(), []int{18}
+// Deprecated: Use StorageStatRequest.ProtoReflect.Descriptor instead.
+func (*StorageStatRequest) Descriptor() ([]byte, []int) {
+	return file_nitric_proto_storage_v1_storage_proto_rawDescGZIP(), []int{26}
 }
 
-func (x *StorageExistsRequest) GetBucketName() string {
+func (x *StorageStatRequest) GetBucketName() string {
 	if x != nil {
 		return x.BucketName
 	}
 	return ""
 }
 
-func (x *StorageExistsRequest) GetKey() string {
+func (x *StorageStatRequest) GetKey() string {
 	if x != nil {
 		return x.Key
 	}
 	return ""
 }
 
-type StorageExistsResponse struct {
+type StorageStatResponse struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Exists bool `protobuf:"varint,1,opt,name=exists,proto3" json:"exists,omitempty"`
+	// The details of the item, including its content type and user metadata
+	Blob *Blob `protobuf:"bytes,1,opt,name=blob,proto3" json:"blob,omitempty"`
 }
 
-func (x *StorageExistsResponse) Reset() {
-	*x = StorageExistsResponse{}
+func (x *StorageStatResponse) Reset() {
+	*x = StorageStatResponse{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_nitric_proto_storage_v1_storage_proto_msgTypes[19]
+		mi := &file_nitric_proto_storage_v1_storage_proto_msgTypes[27]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *StorageExistsResponse) String() string {
+func (x *StorageStatResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*StorageExistsResponse) ProtoMessage() {}
+func (*StorageStatResponse) ProtoMessage() {}
 
-func (x *StorageExistsResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_nitric_proto_storage_v1_storage_proto_msgTypes[19]
+func (x *StorageStatResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_nitric_proto_storage_v1_storage_proto_msgTypes[27]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1274,16 +1937,16 @@ func (x *StorageExistsResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use StorageExistsResponse.ProtoReflect.Descriptor instead.
-func (*StorageExistsResponse) Descriptor() ([]byte, []int) {
-	return file_nitric_proto_storage_v1_storage_proto_rawDescGZIP(), []int{19}
+// Deprecated: Use StorageStatResponse.ProtoReflect.Descriptor instead.
+func (*StorageStatResponse) Descriptor() ([]byte, []int) {
+	return file_nitric_proto_storage_v1_storage_proto_rawDescGZIP(), []int{27}
 }
 
-func (x *StorageExistsResponse) GetExists() bool {
+func (x *StorageStatResponse) GetBlob() *Blob {
 	if x != nil {
-		return x.Exists
+		return x.Blob
 	}
-	return false
+	return nil
 }
 
 var File_nitric_proto_storage_v1_storage_proto protoreflect.FileDescriptor
@@ -1295,188 +1958,327 @@ var file_nitric_proto_storage_v1_storage_proto_rawDesc = []byte{
 	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x73, 0x74, 0x6f, 0x72, 0x61, 0x67, 0x65, 0x2e, 0x76, 0x31,
 	0x1a, 0x1e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75,
 	0x66, 0x2f, 0x64, 0x75, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f,
-	0x22, 0xeb, 0x01, 0x0a, 0x0d, 0x43, 0x6c, 0x69, 0x65, 0x6e, 0x74, 0x4d, 0x65, 0x73, 0x73, 0x61,
-	0x67, 0x65, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x02,
-	0x69, 0x64, 0x12, 0x61, 0x0a, 0x14, 0x72, 0x65, 0x67, 0x69, 0x73, 0x74, 0x72, 0x61, 0x74, 0x69,
-	0x6f, 0x6e, 0x5f, 0x72, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b,
-	0x32, 0x2c, 0x2e, 0x6e, 0x69, 0x74, 0x72, 0x69, 0x63, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e,
+	0x1a, 0x1f, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75,
+	0x66, 0x2f, 0x74, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x2e, 0x70, 0x72, 0x6f, 0x74,
+	0x6f, 0x22, 0xeb, 0x01, 0x0a, 0x0d, 0x43, 0x6c, 0x69, 0x65, 0x6e, 0x74, 0x4d, 0x65, 0x73, 0x73,
+	0x61, 0x67, 0x65, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x02, 0x69, 0x64, 0x12, 0x61, 0x0a, 0x14, 0x72, 0x65, 0x67, 0x69, 0x73, 0x74, 0x72, 0x61, 0x74,
+	0x69, 0x6f, 0x6e, 0x5f, 0x72, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28,
+	0x0b, 0x32, 0x2c, 0x2e, 0x6e, 0x69, 0x74, 0x72, 0x69, 0x63, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f,
+	0x2e, 0x73, 0x74, 0x6f, 0x72, 0x61, 0x67, 0x65, 0x2e, 0x76, 0x31, 0x2e, 0x52, 0x65, 0x67, 0x69,
+	0x73, 0x74, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x48,
+	0x00, 0x52, 0x13, 0x72, 0x65, 0x67, 0x69, 0x73, 0x74, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52,
+	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x5c, 0x0a, 0x13, 0x62, 0x6c, 0x6f, 0x62, 0x5f, 0x65,
+	0x76, 0x65, 0x6e, 0x74, 0x5f, 0x72, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x18, 0x03, 0x20,
+	0x01, 0x28, 0x0b, 0x32, 0x2a, 0x2e, 0x6e, 0x69, 0x74, 0x72, 0x69, 0x63, 0x2e, 0x70, 0x72, 0x6f,
+	0x74, 0x6f, 0x2e, 0x73, 0x74, 0x6f, 0x72, 0x61, 0x67, 0x65, 0x2e, 0x76, 0x31, 0x2e, 0x42, 0x6c,
+	0x6f, 0x62, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x48,
+	0x00, 0x52, 0x11, 0x62, 0x6c, 0x6f, 0x62, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x52, 0x65, 0x73, 0x70,
+	0x6f, 0x6e, 0x73, 0x65, 0x42, 0x09, 0x0a, 0x07, 0x63, 0x6f, 0x6e, 0x74, 0x65, 0x6e, 0x74, 0x22,
+	0xee, 0x03, 0x0a, 0x0d, 0x53, 0x65, 0x72, 0x76, 0x65, 0x72, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67,
+	0x65, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x02, 0x69,
+	0x64, 0x12, 0x64, 0x0a, 0x15, 0x72, 0x65, 0x67, 0x69, 0x73, 0x74, 0x72, 0x61, 0x74, 0x69, 0x6f,
+	0x6e, 0x5f, 0x72, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b,
+	0x32, 0x2d, 0x2e, 0x6e, 0x69, 0x74, 0x72, 0x69, 0x63, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e,
 	0x73, 0x74, 0x6f, 0x72, 0x61, 0x67, 0x65, 0x2e, 0x76, 0x31, 0x2e, 0x52, 0x65, 0x67, 0x69, 0x73,
-	0x74, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x48, 0x00,
-	0x52, 0x13, 0x72, 0x65, 0x67, 0x69, 0x73, 0x74, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x65,
-	0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x5c, 0x0a, 0x13, 0x62, 0x6c, 0x6f, 0x62, 0x5f, 0x65, 0x76,
-	0x65, 0x6e, 0x74, 0x5f, 0x72, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x18, 0x03, 0x20, 0x01,
-	0x28, 0x0b, 0x32, 0x2a, 0x2e, 0x6e, 0x69, 0x74, 0x72, 0x69, 0x63, 0x2e, 0x70, 0x72, 0x6f, 0x74,
-	0x6f, 0x2e, 0x73, 0x74, 0x6f, 0x72, 0x61, 0x67, 0x65, 0x2e, 0x76, 0x31, 0x2e, 0x42, 0x6c, 0x6f,
-	0x62, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x48, 0x00,
-	0x52, 0x11, 0x62, 0x6c, 0x6f, 0x62, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x52, 0x65, 0x73, 0x70, 0x6f,
-	0x6e, 0x73, 0x65, 0x42, 0x09, 0x0a, 0x07, 0x63, 0x6f, 0x6e, 0x74, 0x65, 0x6e, 0x74, 0x22, 0xeb,
-	0x01, 0x0a, 0x0d, 0x53, 0x65, 0x72, 0x76, 0x65, 0x72, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65,
-	0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x02, 0x69, 0x64,
-	0x12, 0x64, 0x0a, 0x15, 0x72, 0x65, 0x67, 0x69, 0x73, 0x74, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e,
-	0x5f, 0x72, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32,
-	0x2d, 0x2e, 0x6e, 0x69, 0x74, 0x72, 0x69, 0x63, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x73,
-	0x74, 0x6f, 0x72, 0x61, 0x67, 0x65, 0x2e, 0x76, 0x31, 0x2e, 0x52, 0x65, 0x67, 0x69, 0x73, 0x74,
-	0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x48, 0x00,
-	0x52, 0x14, 0x72, 0x65, 0x67, 0x69, 0x73, 0x74, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x65,
-	0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x59, 0x0a, 0x12, 0x62, 0x6c, 0x6f, 0x62, 0x5f, 0x65,
-	0x76, 0x65, 0x6e, 0x74, 0x5f, 0x72, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x18, 0x03, 0x20, 0x01,
-	0x28, 0x0b, 0x32, 0x29, 0x2e, 0x6e, 0x69, 0x74, 0x72, 0x69, 0x63, 0x2e, 0x70, 0x72, 0x6f, 0x74,
-	0x6f, 0x2e, 0x73, 0x74, 0x6f, 0x72, 0x61, 0x67, 0x65, 0x2e, 0x76, 0x31, 0x2e, 0x42, 0x6c, 0x6f,
-	0x62, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x48, 0x00, 0x52,
-	0x10, 0x62, 0x6c, 0x6f, 0x62, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
-	0x74, 0x42, 0x09, 0x0a, 0x07, 0x63, 0x6f, 0x6e, 0x74, 0x65, 0x6e, 0x74, 0x22, 0x81, 0x01, 0x0a,
-	0x10, 0x42, 0x6c, 0x6f, 0x62, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
-	0x74, 0x12, 0x1f, 0x0a, 0x0b, 0x62, 0x75, 0x63, 0x6b, 0x65, 0x74, 0x5f, 0x6e, 0x61, 0x6d, 0x65,
-	0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0a, 0x62, 0x75, 0x63, 0x6b, 0x65, 0x74, 0x4e, 0x61,
-	0x6d, 0x65, 0x12, 0x43, 0x0a, 0x0a, 0x62, 0x6c, 0x6f, 0x62, 0x5f, 0x65, 0x76, 0x65, 0x6e, 0x74,
-	0x18, 0x0a, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x22, 0x2e, 0x6e, 0x69, 0x74, 0x72, 0x69, 0x63, 0x2e,
-	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x73, 0x74, 0x6f, 0x72, 0x61, 0x67, 0x65, 0x2e, 0x76, 0x31,
-	0x2e, 0x42, 0x6c, 0x6f, 0x62, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x48, 0x00, 0x52, 0x09, 0x62, 0x6c,
-	0x6f, 0x62, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x42, 0x07, 0x0a, 0x05, 0x65, 0x76, 0x65, 0x6e, 0x74,
-	0x22, 0x59, 0x0a, 0x09, 0x42, 0x6c, 0x6f, 0x62, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x12, 0x10, 0x0a,
-	0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12,
-	0x3a, 0x0a, 0x04, 0x74, 0x79, 0x70, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x26, 0x2e,
-	0x6e, 0x69, 0x74, 0x72, 0x69, 0x63, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x73, 0x74, 0x6f,
-	0x72, 0x61, 0x67, 0x65, 0x2e, 0x76, 0x31, 0x2e, 0x42, 0x6c, 0x6f, 0x62, 0x45, 0x76, 0x65, 0x6e,
-	0x74, 0x54, 0x79, 0x70, 0x65, 0x52, 0x04, 0x74, 0x79, 0x70, 0x65, 0x22, 0x2d, 0x0a, 0x11, 0x42,
-	0x6c, 0x6f, 0x62, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65,
-	0x12, 0x18, 0x0a, 0x07, 0x73, 0x75, 0x63, 0x63, 0x65, 0x73, 0x73, 0x18, 0x01, 0x20, 0x01, 0x28,
-	0x08, 0x52, 0x07, 0x73, 0x75, 0x63, 0x63, 0x65, 0x73, 0x73, 0x22, 0xb2, 0x01, 0x0a, 0x13, 0x52,
-	0x65, 0x67, 0x69, 0x73, 0x74, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x65, 0x71, 0x75, 0x65,
+	0x74, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x48,
+	0x00, 0x52, 0x14, 0x72, 0x65, 0x67, 0x69, 0x73, 0x74, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52,
+	0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x59, 0x0a, 0x12, 0x62, 0x6c, 0x6f, 0x62, 0x5f,
+	0x65, 0x76, 0x65, 0x6e, 0x74, 0x5f, 0x72, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x18, 0x03, 0x20,
+	0x01, 0x28, 0x0b, 0x32, 0x29, 0x2e, 0x6e, 0x69, 0x74, 0x72, 0x69, 0x63, 0x2e, 0x70, 0x72, 0x6f,
+	0x74, 0x6f, 0x2e, 0x73, 0x74, 0x6f, 0x72, 0x61, 0x67, 0x65, 0x2e, 0x76, 0x31, 0x2e, 0x42, 0x6c,
+	0x6f, 0x62, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x48, 0x00,
+	0x52, 0x10, 0x62, 0x6c, 0x6f, 0x62, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65,
+	0x73, 0x74, 0x12, 0x61, 0x0a, 0x14, 0x63, 0x61, 0x6e, 0x63, 0x65, 0x6c, 0x6c, 0x61, 0x74, 0x69,
+	0x6f, 0x6e, 0x5f, 0x72, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0b,
+	0x32, 0x2c, 0x2e, 0x6e, 0x69, 0x74, 0x72, 0x69, 0x63, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e,
+	0x73, 0x74, 0x6f, 0x72, 0x61, 0x67, 0x65, 0x2e, 0x76, 0x31, 0x2e, 0x43, 0x61, 0x6e, 0x63, 0x65,
+	0x6c, 0x6c, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x48, 0x00,
+	0x52, 0x13, 0x63, 0x61, 0x6e, 0x63, 0x65, 0x6c, 0x6c, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x65,
+	0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x5d, 0x0a, 0x0d, 0x74, 0x72, 0x61, 0x63, 0x65, 0x5f, 0x63,
+	0x6f, 0x6e, 0x74, 0x65, 0x78, 0x74, 0x18, 0x0a, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x38, 0x2e, 0x6e,
+	0x69, 0x74, 0x72, 0x69, 0x63, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x73, 0x74, 0x6f, 0x72,
+	0x61, 0x67, 0x65, 0x2e, 0x76, 0x31, 0x2e, 0x53, 0x65, 0x72, 0x76, 0x65, 0x72, 0x4d, 0x65, 0x73,
+	0x73, 0x61, 0x67, 0x65, 0x2e, 0x54, 0x72, 0x61, 0x63, 0x65, 0x43, 0x6f, 0x6e, 0x74, 0x65, 0x78,
+	0x74, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x52, 0x0c, 0x74, 0x72, 0x61, 0x63, 0x65, 0x43, 0x6f, 0x6e,
+	0x74, 0x65, 0x78, 0x74, 0x1a, 0x3f, 0x0a, 0x11, 0x54, 0x72, 0x61, 0x63, 0x65, 0x43, 0x6f, 0x6e,
+	0x74, 0x65, 0x78, 0x74, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x14, 0x0a, 0x05, 0x76,
+	0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75,
+	0x65, 0x3a, 0x02, 0x38, 0x01, 0x42, 0x09, 0x0a, 0x07, 0x63, 0x6f, 0x6e, 0x74, 0x65, 0x6e, 0x74,
+	0x22, 0x2d, 0x0a, 0x13, 0x43, 0x61, 0x6e, 0x63, 0x65, 0x6c, 0x6c, 0x61, 0x74, 0x69, 0x6f, 0x6e,
+	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x16, 0x0a, 0x06, 0x72, 0x65, 0x61, 0x73, 0x6f,
+	0x6e, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x72, 0x65, 0x61, 0x73, 0x6f, 0x6e, 0x22,
+	0x81, 0x01, 0x0a, 0x10, 0x42, 0x6c, 0x6f, 0x62, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x52, 0x65, 0x71,
+	0x75, 0x65, 0x73, 0x74, 0x12, 0x1f, 0x0a, 0x0b, 0x62, 0x75, 0x63, 0x6b, 0x65, 0x74, 0x5f, 0x6e,
+	0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0a, 0x62, 0x75, 0x63, 0x6b, 0x65,
+	0x74, 0x4e, 0x61, 0x6d, 0x65, 0x12, 0x43, 0x0a, 0x0a, 0x62, 0x6c, 0x6f, 0x62, 0x5f, 0x65, 0x76,
+	0x65, 0x6e, 0x74, 0x18, 0x0a, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x22, 0x2e, 0x6e, 0x69, 0x74, 0x72,
+	0x69, 0x63, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x73, 0x74, 0x6f, 0x72, 0x61, 0x67, 0x65,
+	0x2e, 0x76, 0x31, 0x2e, 0x42, 0x6c, 0x6f, 0x62, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x48, 0x00, 0x52,
+	0x09, 0x62, 0x6c, 0x6f, 0x62, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x42, 0x07, 0x0a, 0x05, 0x65, 0x76,
+	0x65, 0x6e, 0x74, 0x22, 0x59, 0x0a, 0x09, 0x42, 0x6c, 0x6f, 0x62, 0x45, 0x76, 0x65, 0x6e, 0x74,
+	0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6b,
+	0x65, 0x79, 0x12, 0x3a, 0x0a, 0x04, 0x74, 0x79, 0x70, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0e,
+	0x32, 0x26, 0x2e, 0x6e, 0x69, 0x74, 0x72, 0x69, 0x63, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e,
+	0x73, 0x74, 0x6f, 0x72, 0x61, 0x67, 0x65, 0x2e, 0x76, 0x31, 0x2e, 0x42, 0x6c, 0x6f, 0x62, 0x45,
+	0x76, 0x65, 0x6e, 0x74, 0x54, 0x79, 0x70, 0x65, 0x52, 0x04, 0x74, 0x79, 0x70, 0x65, 0x22, 0x2d,
+	0x0a, 0x11, 0x42, 0x6c, 0x6f, 0x62, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x52, 0x65, 0x73, 0x70, 0x6f,
+	0x6e, 0x73, 0x65, 0x12, 0x18, 0x0a, 0x07, 0x73, 0x75, 0x63, 0x63, 0x65, 0x73, 0x73, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x08, 0x52, 0x07, 0x73, 0x75, 0x63, 0x63, 0x65, 0x73, 0x73, 0x22, 0xb2, 0x01,
+	0x0a, 0x13, 0x52, 0x65, 0x67, 0x69, 0x73, 0x74, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x65,
+	0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x1f, 0x0a, 0x0b, 0x62, 0x75, 0x63, 0x6b, 0x65, 0x74, 0x5f,
+	0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0a, 0x62, 0x75, 0x63, 0x6b,
+	0x65, 0x74, 0x4e, 0x61, 0x6d, 0x65, 0x12, 0x4e, 0x0a, 0x0f, 0x62, 0x6c, 0x6f, 0x62, 0x5f, 0x65,
+	0x76, 0x65, 0x6e, 0x74, 0x5f, 0x74, 0x79, 0x70, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0e, 0x32,
+	0x26, 0x2e, 0x6e, 0x69, 0x74, 0x72, 0x69, 0x63, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x73,
+	0x74, 0x6f, 0x72, 0x61, 0x67, 0x65, 0x2e, 0x76, 0x31, 0x2e, 0x42, 0x6c, 0x6f, 0x62, 0x45, 0x76,
+	0x65, 0x6e, 0x74, 0x54, 0x79, 0x70, 0x65, 0x52, 0x0d, 0x62, 0x6c, 0x6f, 0x62, 0x45, 0x76, 0x65,
+	0x6e, 0x74, 0x54, 0x79, 0x70, 0x65, 0x12, 0x2a, 0x0a, 0x11, 0x6b, 0x65, 0x79, 0x5f, 0x70, 0x72,
+	0x65, 0x66, 0x69, 0x78, 0x5f, 0x66, 0x69, 0x6c, 0x74, 0x65, 0x72, 0x18, 0x03, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x0f, 0x6b, 0x65, 0x79, 0x50, 0x72, 0x65, 0x66, 0x69, 0x78, 0x46, 0x69, 0x6c, 0x74,
+	0x65, 0x72, 0x22, 0x26, 0x0a, 0x14, 0x52, 0x65, 0x67, 0x69, 0x73, 0x74, 0x72, 0x61, 0x74, 0x69,
+	0x6f, 0x6e, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x02, 0x69, 0x64, 0x22, 0xb9, 0x02, 0x0a, 0x13, 0x53,
+	0x74, 0x6f, 0x72, 0x61, 0x67, 0x65, 0x57, 0x72, 0x69, 0x74, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65,
 	0x73, 0x74, 0x12, 0x1f, 0x0a, 0x0b, 0x62, 0x75, 0x63, 0x6b, 0x65, 0x74, 0x5f, 0x6e, 0x61, 0x6d,
 	0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0a, 0x62, 0x75, 0x63, 0x6b, 0x65, 0x74, 0x4e,
-	0x61, 0x6d, 0x65, 0x12, 0x4e, 0x0a, 0x0f, 0x62, 0x6c, 0x6f, 0x62, 0x5f, 0x65, 0x76, 0x65, 0x6e,
-	0x74, 0x5f, 0x74, 0x79, 0x70, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x26, 0x2e, 0x6e,
-	0x69, 0x74, 0x72, 0x69, 0x63, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x73, 0x74, 0x6f, 0x72,
-	0x61, 0x67, 0x65, 0x2e, 0x76, 0x31, 0x2e, 0x42, 0x6c, 0x6f, 0x62, 0x45, 0x76, 0x65, 0x6e, 0x74,
-	0x54, 0x79, 0x70, 0x65, 0x52, 0x0d, 0x62, 0x6c, 0x6f, 0x62, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x54,
-	0x79, 0x70, 0x65, 0x12, 0x2a, 0x0a, 0x11, 0x6b, 0x65, 0x79, 0x5f, 0x70, 0x72, 0x65, 0x66, 0x69,
-	0x78, 0x5f, 0x66, 0x69, 0x6c, 0x74, 0x65, 0x72, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0f,
-	0x6b, 0x65, 0x79, 0x50, 0x72, 0x65, 0x66, 0x69, 0x78, 0x46, 0x69, 0x6c, 0x74, 0x65, 0x72, 0x22,
-	0x26, 0x0a, 0x14, 0x52, 0x65, 0x67, 0x69, 0x73, 0x74, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52,
-	0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20,
-	0x01, 0x28, 0x09, 0x52, 0x02, 0x69, 0x64, 0x22, 0x5c, 0x0a, 0x13, 0x53, 0x74, 0x6f, 0x72, 0x61,
-	0x67, 0x65, 0x57, 0x72, 0x69, 0x74, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x1f,
+	0x61, 0x6d, 0x65, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x12, 0x0a, 0x04, 0x62, 0x6f, 0x64, 0x79, 0x18, 0x03, 0x20,
+	0x01, 0x28, 0x0c, 0x52, 0x04, 0x62, 0x6f, 0x64, 0x79, 0x12, 0x21, 0x0a, 0x0c, 0x63, 0x6f, 0x6e,
+	0x74, 0x65, 0x6e, 0x74, 0x5f, 0x74, 0x79, 0x70, 0x65, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x0b, 0x63, 0x6f, 0x6e, 0x74, 0x65, 0x6e, 0x74, 0x54, 0x79, 0x70, 0x65, 0x12, 0x23, 0x0a, 0x0d,
+	0x63, 0x61, 0x63, 0x68, 0x65, 0x5f, 0x63, 0x6f, 0x6e, 0x74, 0x72, 0x6f, 0x6c, 0x18, 0x05, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x0c, 0x63, 0x61, 0x63, 0x68, 0x65, 0x43, 0x6f, 0x6e, 0x74, 0x72, 0x6f,
+	0x6c, 0x12, 0x56, 0x0a, 0x08, 0x6d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0x18, 0x06, 0x20,
+	0x03, 0x28, 0x0b, 0x32, 0x3a, 0x2e, 0x6e, 0x69, 0x74, 0x72, 0x69, 0x63, 0x2e, 0x70, 0x72, 0x6f,
+	0x74, 0x6f, 0x2e, 0x73, 0x74, 0x6f, 0x72, 0x61, 0x67, 0x65, 0x2e, 0x76, 0x31, 0x2e, 0x53, 0x74,
+	0x6f, 0x72, 0x61, 0x67, 0x65, 0x57, 0x72, 0x69, 0x74, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
+	0x74, 0x2e, 0x4d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x52,
+	0x08, 0x6d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0x1a, 0x3b, 0x0a, 0x0d, 0x4d, 0x65, 0x74,
+	0x61, 0x64, 0x61, 0x74, 0x61, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65,
+	0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x14, 0x0a, 0x05,
+	0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x76, 0x61, 0x6c,
+	0x75, 0x65, 0x3a, 0x02, 0x38, 0x01, 0x22, 0x16, 0x0a, 0x14, 0x53, 0x74, 0x6f, 0x72, 0x61, 0x67,
+	0x65, 0x57, 0x72, 0x69, 0x74, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x47,
+	0x0a, 0x12, 0x53, 0x74, 0x6f, 0x72, 0x61, 0x67, 0x65, 0x52, 0x65, 0x61, 0x64, 0x52, 0x65, 0x71,
+	0x75, 0x65, 0x73, 0x74, 0x12, 0x1f, 0x0a, 0x0b, 0x62, 0x75, 0x63, 0x6b, 0x65, 0x74, 0x5f, 0x6e,
+	0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0a, 0x62, 0x75, 0x63, 0x6b, 0x65,
+	0x74, 0x4e, 0x61, 0x6d, 0x65, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x02, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x22, 0x29, 0x0a, 0x13, 0x53, 0x74, 0x6f, 0x72, 0x61,
+	0x67, 0x65, 0x52, 0x65, 0x61, 0x64, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x12,
+	0x0a, 0x04, 0x62, 0x6f, 0x64, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x04, 0x62, 0x6f,
+	0x64, 0x79, 0x22, 0x7d, 0x0a, 0x18, 0x53, 0x74, 0x6f, 0x72, 0x61, 0x67, 0x65, 0x52, 0x65, 0x61,
+	0x64, 0x53, 0x74, 0x72, 0x65, 0x61, 0x6d, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x1f,
 	0x0a, 0x0b, 0x62, 0x75, 0x63, 0x6b, 0x65, 0x74, 0x5f, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20,
 	0x01, 0x28, 0x09, 0x52, 0x0a, 0x62, 0x75, 0x63, 0x6b, 0x65, 0x74, 0x4e, 0x61, 0x6d, 0x65, 0x12,
 	0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6b, 0x65,
-	0x79, 0x12, 0x12, 0x0a, 0x04, 0x62, 0x6f, 0x64, 0x79, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0c, 0x52,
-	0x04, 0x62, 0x6f, 0x64, 0x79, 0x22, 0x16, 0x0a, 0x14, 0x53, 0x74, 0x6f, 0x72, 0x61, 0x67, 0x65,
-	0x57, 0x72, 0x69, 0x74, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x47, 0x0a,
-	0x12, 0x53, 0x74, 0x6f, 0x72, 0x61, 0x67, 0x65, 0x52, 0x65, 0x61, 0x64, 0x52, 0x65, 0x71, 0x75,
-	0x65, 0x73, 0x74, 0x12, 0x1f, 0x0a, 0x0b, 0x62, 0x75, 0x63, 0x6b, 0x65, 0x74, 0x5f, 0x6e, 0x61,
-	0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0a, 0x62, 0x75, 0x63, 0x6b, 0x65, 0x74,
-	0x4e, 0x61, 0x6d, 0x65, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x02, 0x20, 0x01, 0x28,
-	0x09, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x22, 0x29, 0x0a, 0x13, 0x53, 0x74, 0x6f, 0x72, 0x61, 0x67,
-	0x65, 0x52, 0x65, 0x61, 0x64, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x12, 0x0a,
-	0x04, 0x62, 0x6f, 0x64, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x04, 0x62, 0x6f, 0x64,
-	0x79, 0x22, 0x49, 0x0a, 0x14, 0x53, 0x74, 0x6f, 0x72, 0x61, 0x67, 0x65, 0x44, 0x65, 0x6c, 0x65,
-	0x74, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x1f, 0x0a, 0x0b, 0x62, 0x75, 0x63,
-	0x6b, 0x65, 0x74, 0x5f, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0a,
-	0x62, 0x75, 0x63, 0x6b, 0x65, 0x74, 0x4e, 0x61, 0x6d, 0x65, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65,
-	0x79, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x22, 0x17, 0x0a, 0x15,
-	0x53, 0x74, 0x6f, 0x72, 0x61, 0x67, 0x65, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x52, 0x65, 0x73,
-	0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0xfd, 0x01, 0x0a, 0x18, 0x53, 0x74, 0x6f, 0x72, 0x61, 0x67,
-	0x65, 0x50, 0x72, 0x65, 0x53, 0x69, 0x67, 0x6e, 0x55, 0x72, 0x6c, 0x52, 0x65, 0x71, 0x75, 0x65,
-	0x73, 0x74, 0x12, 0x1f, 0x0a, 0x0b, 0x62, 0x75, 0x63, 0x6b, 0x65, 0x74, 0x5f, 0x6e, 0x61, 0x6d,
-	0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0a, 0x62, 0x75, 0x63, 0x6b, 0x65, 0x74, 0x4e,
-	0x61, 0x6d, 0x65, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09,
-	0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x59, 0x0a, 0x09, 0x6f, 0x70, 0x65, 0x72, 0x61, 0x74, 0x69,
-	0x6f, 0x6e, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x3b, 0x2e, 0x6e, 0x69, 0x74, 0x72, 0x69,
-	0x63, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x73, 0x74, 0x6f, 0x72, 0x61, 0x67, 0x65, 0x2e,
-	0x76, 0x31, 0x2e, 0x53, 0x74, 0x6f, 0x72, 0x61, 0x67, 0x65, 0x50, 0x72, 0x65, 0x53, 0x69, 0x67,
-	0x6e, 0x55, 0x72, 0x6c, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x2e, 0x4f, 0x70, 0x65, 0x72,
-	0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x09, 0x6f, 0x70, 0x65, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e,
-	0x12, 0x31, 0x0a, 0x06, 0x65, 0x78, 0x70, 0x69, 0x72, 0x79, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0b,
-	0x32, 0x19, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62,
-	0x75, 0x66, 0x2e, 0x44, 0x75, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x06, 0x65, 0x78, 0x70,
-	0x69, 0x72, 0x79, 0x22, 0x20, 0x0a, 0x09, 0x4f, 0x70, 0x65, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e,
-	0x12, 0x08, 0x0a, 0x04, 0x52, 0x45, 0x41, 0x44, 0x10, 0x00, 0x12, 0x09, 0x0a, 0x05, 0x57, 0x52,
-	0x49, 0x54, 0x45, 0x10, 0x01, 0x22, 0x2d, 0x0a, 0x19, 0x53, 0x74, 0x6f, 0x72, 0x61, 0x67, 0x65,
-	0x50, 0x72, 0x65, 0x53, 0x69, 0x67, 0x6e, 0x55, 0x72, 0x6c, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e,
-	0x73, 0x65, 0x12, 0x10, 0x0a, 0x03, 0x75, 0x72, 0x6c, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52,
-	0x03, 0x75, 0x72, 0x6c, 0x22, 0x52, 0x0a, 0x17, 0x53, 0x74, 0x6f, 0x72, 0x61, 0x67, 0x65, 0x4c,
-	0x69, 0x73, 0x74, 0x42, 0x6c, 0x6f, 0x62, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12,
-	0x1f, 0x0a, 0x0b, 0x62, 0x75, 0x63, 0x6b, 0x65, 0x74, 0x5f, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01,
-	0x20, 0x01, 0x28, 0x09, 0x52, 0x0a, 0x62, 0x75, 0x63, 0x6b, 0x65, 0x74, 0x4e, 0x61, 0x6d, 0x65,
-	0x12, 0x16, 0x0a, 0x06, 0x70, 0x72, 0x65, 0x66, 0x69, 0x78, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09,
-	0x52, 0x06, 0x70, 0x72, 0x65, 0x66, 0x69, 0x78, 0x22, 0x18, 0x0a, 0x04, 0x42, 0x6c, 0x6f, 0x62,
-	0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6b,
-	0x65, 0x79, 0x22, 0x4f, 0x0a, 0x18, 0x53, 0x74, 0x6f, 0x72, 0x61, 0x67, 0x65, 0x4c, 0x69, 0x73,
-	0x74, 0x42, 0x6c, 0x6f, 0x62, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x33,
-	0x0a, 0x05, 0x62, 0x6c, 0x6f, 0x62, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x1d, 0x2e,
-	0x6e, 0x69, 0x74, 0x72, 0x69, 0x63, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x73, 0x74, 0x6f,
-	0x72, 0x61, 0x67, 0x65, 0x2e, 0x76, 0x31, 0x2e, 0x42, 0x6c, 0x6f, 0x62, 0x52, 0x05, 0x62, 0x6c,
-	0x6f, 0x62, 0x73, 0x22, 0x49, 0x0a, 0x14, 0x53, 0x74, 0x6f, 0x72, 0x61, 0x67, 0x65, 0x45, 0x78,
-	0x69, 0x73, 0x74, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x1f, 0x0a, 0x0b, 0x62,
-	0x75, 0x63, 0x6b, 0x65, 0x74, 0x5f, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09,
-	0x52, 0x0a, 0x62, 0x75, 0x63, 0x6b, 0x65, 0x74, 0x4e, 0x61, 0x6d, 0x65, 0x12, 0x10, 0x0a, 0x03,
-	0x6b, 0x65, 0x79, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x22, 0x2f,
-	0x0a, 0x15, 0x53, 0x74, 0x6f, 0x72, 0x61, 0x67, 0x65, 0x45, 0x78, 0x69, 0x73, 0x74, 0x73, 0x52,
-	0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x16, 0x0a, 0x06, 0x65, 0x78, 0x69, 0x73, 0x74,
-	0x73, 0x18, 0x01, 0x20, 0x01, 0x28, 0x08, 0x52, 0x06, 0x65, 0x78, 0x69, 0x73, 0x74, 0x73, 0x2a,
-	0x29, 0x0a, 0x0d, 0x42, 0x6c, 0x6f, 0x62, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x54, 0x79, 0x70, 0x65,
-	0x12, 0x0b, 0x0a, 0x07, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x64, 0x10, 0x00, 0x12, 0x0b, 0x0a,
-	0x07, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x64, 0x10, 0x01, 0x32, 0x8b, 0x05, 0x0a, 0x07, 0x53,
-	0x74, 0x6f, 0x72, 0x61, 0x67, 0x65, 0x12, 0x61, 0x0a, 0x04, 0x52, 0x65, 0x61, 0x64, 0x12, 0x2b,
+	0x79, 0x12, 0x16, 0x0a, 0x06, 0x6f, 0x66, 0x66, 0x73, 0x65, 0x74, 0x18, 0x03, 0x20, 0x01, 0x28,
+	0x03, 0x52, 0x06, 0x6f, 0x66, 0x66, 0x73, 0x65, 0x74, 0x12, 0x16, 0x0a, 0x06, 0x6c, 0x65, 0x6e,
+	0x67, 0x74, 0x68, 0x18, 0x04, 0x20, 0x01, 0x28, 0x03, 0x52, 0x06, 0x6c, 0x65, 0x6e, 0x67, 0x74,
+	0x68, 0x22, 0x31, 0x0a, 0x19, 0x53, 0x74, 0x6f, 0x72, 0x61, 0x67, 0x65, 0x52, 0x65, 0x61, 0x64,
+	0x53, 0x74, 0x72, 0x65, 0x61, 0x6d, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x14,
+	0x0a, 0x05, 0x63, 0x68, 0x75, 0x6e, 0x6b, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x05, 0x63,
+	0x68, 0x75, 0x6e, 0x6b, 0x22, 0xaf, 0x02, 0x0a, 0x18, 0x53, 0x74, 0x6f, 0x72, 0x61, 0x67, 0x65,
+	0x57, 0x72, 0x69, 0x74, 0x65, 0x53, 0x74, 0x72, 0x65, 0x61, 0x6d, 0x48, 0x65, 0x61, 0x64, 0x65,
+	0x72, 0x12, 0x1f, 0x0a, 0x0b, 0x62, 0x75, 0x63, 0x6b, 0x65, 0x74, 0x5f, 0x6e, 0x61, 0x6d, 0x65,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0a, 0x62, 0x75, 0x63, 0x6b, 0x65, 0x74, 0x4e, 0x61,
+	0x6d, 0x65, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x03, 0x6b, 0x65, 0x79, 0x12, 0x21, 0x0a, 0x0c, 0x63, 0x6f, 0x6e, 0x74, 0x65, 0x6e, 0x74, 0x5f,
+	0x74, 0x79, 0x70, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0b, 0x63, 0x6f, 0x6e, 0x74,
+	0x65, 0x6e, 0x74, 0x54, 0x79, 0x70, 0x65, 0x12, 0x23, 0x0a, 0x0d, 0x63, 0x61, 0x63, 0x68, 0x65,
+	0x5f, 0x63, 0x6f, 0x6e, 0x74, 0x72, 0x6f, 0x6c, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0c,
+	0x63, 0x61, 0x63, 0x68, 0x65, 0x43, 0x6f, 0x6e, 0x74, 0x72, 0x6f, 0x6c, 0x12, 0x5b, 0x0a, 0x08,
+	0x6d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0x18, 0x05, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x3f,
 	0x2e, 0x6e, 0x69, 0x74, 0x72, 0x69, 0x63, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x73, 0x74,
 	0x6f, 0x72, 0x61, 0x67, 0x65, 0x2e, 0x76, 0x31, 0x2e, 0x53, 0x74, 0x6f, 0x72, 0x61, 0x67, 0x65,
-	0x52, 0x65, 0x61, 0x64, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x2c, 0x2e, 0x6e, 0x69,
-	0x74, 0x72, 0x69, 0x63, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x73, 0x74, 0x6f, 0x72, 0x61,
-	0x67, 0x65, 0x2e, 0x76, 0x31, 0x2e, 0x53, 0x74, 0x6f, 0x72, 0x61, 0x67, 0x65, 0x52, 0x65, 0x61,
-	0x64, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x64, 0x0a, 0x05, 0x57, 0x72, 0x69,
-	0x74, 0x65, 0x12, 0x2c, 0x2e, 0x6e, 0x69, 0x74, 0x72, 0x69, 0x63, 0x2e, 0x70, 0x72, 0x6f, 0x74,
+	0x57, 0x72, 0x69, 0x74, 0x65, 0x53, 0x74, 0x72, 0x65, 0x61, 0x6d, 0x48, 0x65, 0x61, 0x64, 0x65,
+	0x72, 0x2e, 0x4d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x52,
+	0x08, 0x6d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0x1a, 0x3b, 0x0a, 0x0d, 0x4d, 0x65, 0x74,
+	0x61, 0x64, 0x61, 0x74, 0x61, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65,
+	0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x14, 0x0a, 0x05,
+	0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x76, 0x61, 0x6c,
+	0x75, 0x65, 0x3a, 0x02, 0x38, 0x01, 0x22, 0x8b, 0x01, 0x0a, 0x19, 0x53, 0x74, 0x6f, 0x72, 0x61,
+	0x67, 0x65, 0x57, 0x72, 0x69, 0x74, 0x65, 0x53, 0x74, 0x72, 0x65, 0x61, 0x6d, 0x52, 0x65, 0x71,
+	0x75, 0x65, 0x73, 0x74, 0x12, 0x4b, 0x0a, 0x06, 0x68, 0x65, 0x61, 0x64, 0x65, 0x72, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x0b, 0x32, 0x31, 0x2e, 0x6e, 0x69, 0x74, 0x72, 0x69, 0x63, 0x2e, 0x70, 0x72,
+	0x6f, 0x74, 0x6f, 0x2e, 0x73, 0x74, 0x6f, 0x72, 0x61, 0x67, 0x65, 0x2e, 0x76, 0x31, 0x2e, 0x53,
+	0x74, 0x6f, 0x72, 0x61, 0x67, 0x65, 0x57, 0x72, 0x69, 0x74, 0x65, 0x53, 0x74, 0x72, 0x65, 0x61,
+	0x6d, 0x48, 0x65, 0x61, 0x64, 0x65, 0x72, 0x48, 0x00, 0x52, 0x06, 0x68, 0x65, 0x61, 0x64, 0x65,
+	0x72, 0x12, 0x16, 0x0a, 0x05, 0x63, 0x68, 0x75, 0x6e, 0x6b, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0c,
+	0x48, 0x00, 0x52, 0x05, 0x63, 0x68, 0x75, 0x6e, 0x6b, 0x42, 0x09, 0x0a, 0x07, 0x63, 0x6f, 0x6e,
+	0x74, 0x65, 0x6e, 0x74, 0x22, 0x30, 0x0a, 0x1a, 0x53, 0x74, 0x6f, 0x72, 0x61, 0x67, 0x65, 0x57,
+	0x72, 0x69, 0x74, 0x65, 0x53, 0x74, 0x72, 0x65, 0x61, 0x6d, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e,
+	0x73, 0x65, 0x12, 0x12, 0x0a, 0x04, 0x73, 0x69, 0x7a, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x03,
+	0x52, 0x04, 0x73, 0x69, 0x7a, 0x65, 0x22, 0x49, 0x0a, 0x14, 0x53, 0x74, 0x6f, 0x72, 0x61, 0x67,
+	0x65, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x1f,
+	0x0a, 0x0b, 0x62, 0x75, 0x63, 0x6b, 0x65, 0x74, 0x5f, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x0a, 0x62, 0x75, 0x63, 0x6b, 0x65, 0x74, 0x4e, 0x61, 0x6d, 0x65, 0x12,
+	0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6b, 0x65,
+	0x79, 0x22, 0x17, 0x0a, 0x15, 0x53, 0x74, 0x6f, 0x72, 0x61, 0x67, 0x65, 0x44, 0x65, 0x6c, 0x65,
+	0x74, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0xfd, 0x01, 0x0a, 0x18, 0x53,
+	0x74, 0x6f, 0x72, 0x61, 0x67, 0x65, 0x50, 0x72, 0x65, 0x53, 0x69, 0x67, 0x6e, 0x55, 0x72, 0x6c,
+	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x1f, 0x0a, 0x0b, 0x62, 0x75, 0x63, 0x6b, 0x65,
+	0x74, 0x5f, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0a, 0x62, 0x75,
+	0x63, 0x6b, 0x65, 0x74, 0x4e, 0x61, 0x6d, 0x65, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18,
+	0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x59, 0x0a, 0x09, 0x6f, 0x70,
+	0x65, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x3b, 0x2e,
+	0x6e, 0x69, 0x74, 0x72, 0x69, 0x63, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x73, 0x74, 0x6f,
+	0x72, 0x61, 0x67, 0x65, 0x2e, 0x76, 0x31, 0x2e, 0x53, 0x74, 0x6f, 0x72, 0x61, 0x67, 0x65, 0x50,
+	0x72, 0x65, 0x53, 0x69, 0x67, 0x6e, 0x55, 0x72, 0x6c, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
+	0x2e, 0x4f, 0x70, 0x65, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x09, 0x6f, 0x70, 0x65, 0x72,
+	0x61, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x31, 0x0a, 0x06, 0x65, 0x78, 0x70, 0x69, 0x72, 0x79, 0x18,
+	0x04, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x19, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70,
+	0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x44, 0x75, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e,
+	0x52, 0x06, 0x65, 0x78, 0x70, 0x69, 0x72, 0x79, 0x22, 0x20, 0x0a, 0x09, 0x4f, 0x70, 0x65, 0x72,
+	0x61, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x08, 0x0a, 0x04, 0x52, 0x45, 0x41, 0x44, 0x10, 0x00, 0x12,
+	0x09, 0x0a, 0x05, 0x57, 0x52, 0x49, 0x54, 0x45, 0x10, 0x01, 0x22, 0x2d, 0x0a, 0x19, 0x53, 0x74,
+	0x6f, 0x72, 0x61, 0x67, 0x65, 0x50, 0x72, 0x65, 0x53, 0x69, 0x67, 0x6e, 0x55, 0x72, 0x6c, 0x52,
+	0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x10, 0x0a, 0x03, 0x75, 0x72, 0x6c, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x75, 0x72, 0x6c, 0x22, 0xd0, 0x01, 0x0a, 0x17, 0x53, 0x74,
+	0x6f, 0x72, 0x61, 0x67, 0x65, 0x4c, 0x69, 0x73, 0x74, 0x42, 0x6c, 0x6f, 0x62, 0x73, 0x52, 0x65,
+	0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x1f, 0x0a, 0x0b, 0x62, 0x75, 0x63, 0x6b, 0x65, 0x74, 0x5f,
+	0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0a, 0x62, 0x75, 0x63, 0x6b,
+	0x65, 0x74, 0x4e, 0x61, 0x6d, 0x65, 0x12, 0x16, 0x0a, 0x06, 0x70, 0x72, 0x65, 0x66, 0x69, 0x78,
+	0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x70, 0x72, 0x65, 0x66, 0x69, 0x78, 0x12, 0x1c,
+	0x0a, 0x09, 0x64, 0x65, 0x6c, 0x69, 0x6d, 0x69, 0x74, 0x65, 0x72, 0x18, 0x03, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x09, 0x64, 0x65, 0x6c, 0x69, 0x6d, 0x69, 0x74, 0x65, 0x72, 0x12, 0x14, 0x0a, 0x05,
+	0x6c, 0x69, 0x6d, 0x69, 0x74, 0x18, 0x04, 0x20, 0x01, 0x28, 0x05, 0x52, 0x05, 0x6c, 0x69, 0x6d,
+	0x69, 0x74, 0x12, 0x1d, 0x0a, 0x0a, 0x70, 0x61, 0x67, 0x65, 0x5f, 0x74, 0x6f, 0x6b, 0x65, 0x6e,
+	0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x70, 0x61, 0x67, 0x65, 0x54, 0x6f, 0x6b, 0x65,
+	0x6e, 0x12, 0x29, 0x0a, 0x10, 0x69, 0x6e, 0x63, 0x6c, 0x75, 0x64, 0x65, 0x5f, 0x6d, 0x65, 0x74,
+	0x61, 0x64, 0x61, 0x74, 0x61, 0x18, 0x06, 0x20, 0x01, 0x28, 0x08, 0x52, 0x0f, 0x69, 0x6e, 0x63,
+	0x6c, 0x75, 0x64, 0x65, 0x4d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0x22, 0xcf, 0x02, 0x0a,
+	0x04, 0x42, 0x6c, 0x6f, 0x62, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x12, 0x0a, 0x04, 0x73, 0x69, 0x7a, 0x65, 0x18,
+	0x02, 0x20, 0x01, 0x28, 0x03, 0x52, 0x04, 0x73, 0x69, 0x7a, 0x65, 0x12, 0x12, 0x0a, 0x04, 0x65,
+	0x74, 0x61, 0x67, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x65, 0x74, 0x61, 0x67, 0x12,
+	0x3f, 0x0a, 0x0d, 0x6c, 0x61, 0x73, 0x74, 0x5f, 0x6d, 0x6f, 0x64, 0x69, 0x66, 0x69, 0x65, 0x64,
+	0x18, 0x04, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e,
+	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61,
+	0x6d, 0x70, 0x52, 0x0c, 0x6c, 0x61, 0x73, 0x74, 0x4d, 0x6f, 0x64, 0x69, 0x66, 0x69, 0x65, 0x64,
+	0x12, 0x21, 0x0a, 0x0c, 0x63, 0x6f, 0x6e, 0x74, 0x65, 0x6e, 0x74, 0x5f, 0x74, 0x79, 0x70, 0x65,
+	0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0b, 0x63, 0x6f, 0x6e, 0x74, 0x65, 0x6e, 0x74, 0x54,
+	0x79, 0x70, 0x65, 0x12, 0x23, 0x0a, 0x0d, 0x63, 0x61, 0x63, 0x68, 0x65, 0x5f, 0x63, 0x6f, 0x6e,
+	0x74, 0x72, 0x6f, 0x6c, 0x18, 0x06, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0c, 0x63, 0x61, 0x63, 0x68,
+	0x65, 0x43, 0x6f, 0x6e, 0x74, 0x72, 0x6f, 0x6c, 0x12, 0x47, 0x0a, 0x08, 0x6d, 0x65, 0x74, 0x61,
+	0x64, 0x61, 0x74, 0x61, 0x18, 0x07, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x2b, 0x2e, 0x6e, 0x69, 0x74,
+	0x72, 0x69, 0x63, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x73, 0x74, 0x6f, 0x72, 0x61, 0x67,
+	0x65, 0x2e, 0x76, 0x31, 0x2e, 0x42, 0x6c, 0x6f, 0x62, 0x2e, 0x4d, 0x65, 0x74, 0x61, 0x64, 0x61,
+	0x74, 0x61, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x52, 0x08, 0x6d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74,
+	0x61, 0x1a, 0x3b, 0x0a, 0x0d, 0x4d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0x45, 0x6e, 0x74,
+	0x72, 0x79, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x03, 0x6b, 0x65, 0x79, 0x12, 0x14, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38, 0x01, 0x22, 0x93,
+	0x01, 0x0a, 0x18, 0x53, 0x74, 0x6f, 0x72, 0x61, 0x67, 0x65, 0x4c, 0x69, 0x73, 0x74, 0x42, 0x6c,
+	0x6f, 0x62, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x33, 0x0a, 0x05, 0x62,
+	0x6c, 0x6f, 0x62, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x1d, 0x2e, 0x6e, 0x69, 0x74,
+	0x72, 0x69, 0x63, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x73, 0x74, 0x6f, 0x72, 0x61, 0x67,
+	0x65, 0x2e, 0x76, 0x31, 0x2e, 0x42, 0x6c, 0x6f, 0x62, 0x52, 0x05, 0x62, 0x6c, 0x6f, 0x62, 0x73,
+	0x12, 0x1a, 0x0a, 0x08, 0x70, 0x72, 0x65, 0x66, 0x69, 0x78, 0x65, 0x73, 0x18, 0x02, 0x20, 0x03,
+	0x28, 0x09, 0x52, 0x08, 0x70, 0x72, 0x65, 0x66, 0x69, 0x78, 0x65, 0x73, 0x12, 0x26, 0x0a, 0x0f,
+	0x6e, 0x65, 0x78, 0x74, 0x5f, 0x70, 0x61, 0x67, 0x65, 0x5f, 0x74, 0x6f, 0x6b, 0x65, 0x6e, 0x18,
+	0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0d, 0x6e, 0x65, 0x78, 0x74, 0x50, 0x61, 0x67, 0x65, 0x54,
+	0x6f, 0x6b, 0x65, 0x6e, 0x22, 0x49, 0x0a, 0x14, 0x53, 0x74, 0x6f, 0x72, 0x61, 0x67, 0x65, 0x45,
+	0x78, 0x69, 0x73, 0x74, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x1f, 0x0a, 0x0b,
+	0x62, 0x75, 0x63, 0x6b, 0x65, 0x74, 0x5f, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x0a, 0x62, 0x75, 0x63, 0x6b, 0x65, 0x74, 0x4e, 0x61, 0x6d, 0x65, 0x12, 0x10, 0x0a,
+	0x03, 0x6b, 0x65, 0x79, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x22,
+	0x2f, 0x0a, 0x15, 0x53, 0x74, 0x6f, 0x72, 0x61, 0x67, 0x65, 0x45, 0x78, 0x69, 0x73, 0x74, 0x73,
+	0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x16, 0x0a, 0x06, 0x65, 0x78, 0x69, 0x73,
+	0x74, 0x73, 0x18, 0x01, 0x20, 0x01, 0x28, 0x08, 0x52, 0x06, 0x65, 0x78, 0x69, 0x73, 0x74, 0x73,
+	0x22, 0x47, 0x0a, 0x12, 0x53, 0x74, 0x6f, 0x72, 0x61, 0x67, 0x65, 0x53, 0x74, 0x61, 0x74, 0x52,
+	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x1f, 0x0a, 0x0b, 0x62, 0x75, 0x63, 0x6b, 0x65, 0x74,
+	0x5f, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0a, 0x62, 0x75, 0x63,
+	0x6b, 0x65, 0x74, 0x4e, 0x61, 0x6d, 0x65, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x02,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x22, 0x48, 0x0a, 0x13, 0x53, 0x74, 0x6f,
+	0x72, 0x61, 0x67, 0x65, 0x53, 0x74, 0x61, 0x74, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65,
+	0x12, 0x31, 0x0a, 0x04, 0x62, 0x6c, 0x6f, 0x62, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1d,
+	0x2e, 0x6e, 0x69, 0x74, 0x72, 0x69, 0x63, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x73, 0x74,
+	0x6f, 0x72, 0x61, 0x67, 0x65, 0x2e, 0x76, 0x31, 0x2e, 0x42, 0x6c, 0x6f, 0x62, 0x52, 0x04, 0x62,
+	0x6c, 0x6f, 0x62, 0x2a, 0x29, 0x0a, 0x0d, 0x42, 0x6c, 0x6f, 0x62, 0x45, 0x76, 0x65, 0x6e, 0x74,
+	0x54, 0x79, 0x70, 0x65, 0x12, 0x0b, 0x0a, 0x07, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x64, 0x10,
+	0x00, 0x12, 0x0b, 0x0a, 0x07, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x64, 0x10, 0x01, 0x32, 0xdf,
+	0x07, 0x0a, 0x07, 0x53, 0x74, 0x6f, 0x72, 0x61, 0x67, 0x65, 0x12, 0x61, 0x0a, 0x04, 0x52, 0x65,
+	0x61, 0x64, 0x12, 0x2b, 0x2e, 0x6e, 0x69, 0x74, 0x72, 0x69, 0x63, 0x2e, 0x70, 0x72, 0x6f, 0x74,
 	0x6f, 0x2e, 0x73, 0x74, 0x6f, 0x72, 0x61, 0x67, 0x65, 0x2e, 0x76, 0x31, 0x2e, 0x53, 0x74, 0x6f,
-	0x72, 0x61, 0x67, 0x65, 0x57, 0x72, 0x69, 0x74, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
-	0x1a, 0x2d, 0x2e, 0x6e, 0x69, 0x74, 0x72, 0x69, 0x63, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e,
-	0x73, 0x74, 0x6f, 0x72, 0x61, 0x67, 0x65, 0x2e, 0x76, 0x31, 0x2e, 0x53, 0x74, 0x6f, 0x72, 0x61,
-	0x67, 0x65, 0x57, 0x72, 0x69, 0x74, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12,
-	0x67, 0x0a, 0x06, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x12, 0x2d, 0x2e, 0x6e, 0x69, 0x74, 0x72,
-	0x69, 0x63, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x73, 0x74, 0x6f, 0x72, 0x61, 0x67, 0x65,
-	0x2e, 0x76, 0x31, 0x2e, 0x53, 0x74, 0x6f, 0x72, 0x61, 0x67, 0x65, 0x44, 0x65, 0x6c, 0x65, 0x74,
-	0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x2e, 0x2e, 0x6e, 0x69, 0x74, 0x72, 0x69,
-	0x63, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x73, 0x74, 0x6f, 0x72, 0x61, 0x67, 0x65, 0x2e,
-	0x76, 0x31, 0x2e, 0x53, 0x74, 0x6f, 0x72, 0x61, 0x67, 0x65, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65,
-	0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x73, 0x0a, 0x0a, 0x50, 0x72, 0x65, 0x53,
-	0x69, 0x67, 0x6e, 0x55, 0x72, 0x6c, 0x12, 0x31, 0x2e, 0x6e, 0x69, 0x74, 0x72, 0x69, 0x63, 0x2e,
+	0x72, 0x61, 0x67, 0x65, 0x52, 0x65, 0x61, 0x64, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a,
+	0x2c, 0x2e, 0x6e, 0x69, 0x74, 0x72, 0x69, 0x63, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x73,
+	0x74, 0x6f, 0x72, 0x61, 0x67, 0x65, 0x2e, 0x76, 0x31, 0x2e, 0x53, 0x74, 0x6f, 0x72, 0x61, 0x67,
+	0x65, 0x52, 0x65, 0x61, 0x64, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x64, 0x0a,
+	0x05, 0x57, 0x72, 0x69, 0x74, 0x65, 0x12, 0x2c, 0x2e, 0x6e, 0x69, 0x74, 0x72, 0x69, 0x63, 0x2e,
 	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x73, 0x74, 0x6f, 0x72, 0x61, 0x67, 0x65, 0x2e, 0x76, 0x31,
-	0x2e, 0x53, 0x74, 0x6f, 0x72, 0x61, 0x67, 0x65, 0x50, 0x72, 0x65, 0x53, 0x69, 0x67, 0x6e, 0x55,
-	0x72, 0x6c, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x32, 0x2e, 0x6e, 0x69, 0x74, 0x72,
-	0x69, 0x63, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x73, 0x74, 0x6f, 0x72, 0x61, 0x67, 0x65,
-	0x2e, 0x76, 0x31, 0x2e, 0x53, 0x74, 0x6f, 0x72, 0x61, 0x67, 0x65, 0x50, 0x72, 0x65, 0x53, 0x69,
-	0x67, 0x6e, 0x55, 0x72, 0x6c, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x70, 0x0a,
-	0x09, 0x4c, 0x69, 0x73, 0x74, 0x42, 0x6c, 0x6f, 0x62, 0x73, 0x12, 0x30, 0x2e, 0x6e, 0x69, 0x74,
+	0x2e, 0x53, 0x74, 0x6f, 0x72, 0x61, 0x67, 0x65, 0x57, 0x72, 0x69, 0x74, 0x65, 0x52, 0x65, 0x71,
+	0x75, 0x65, 0x73, 0x74, 0x1a, 0x2d, 0x2e, 0x6e, 0x69, 0x74, 0x72, 0x69, 0x63, 0x2e, 0x70, 0x72,
+	0x6f, 0x74, 0x6f, 0x2e, 0x73, 0x74, 0x6f, 0x72, 0x61, 0x67, 0x65, 0x2e, 0x76, 0x31, 0x2e, 0x53,
+	0x74, 0x6f, 0x72, 0x61, 0x67, 0x65, 0x57, 0x72, 0x69, 0x74, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f,
+	0x6e, 0x73, 0x65, 0x12, 0x67, 0x0a, 0x06, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x12, 0x2d, 0x2e,
+	0x6e, 0x69, 0x74, 0x72, 0x69, 0x63, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x73, 0x74, 0x6f,
+	0x72, 0x61, 0x67, 0x65, 0x2e, 0x76, 0x31, 0x2e, 0x53, 0x74, 0x6f, 0x72, 0x61, 0x67, 0x65, 0x44,
+	0x65, 0x6c, 0x65, 0x74, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x2e, 0x2e, 0x6e,
+	0x69, 0x74, 0x72, 0x69, 0x63, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x73, 0x74, 0x6f, 0x72,
+	0x61, 0x67, 0x65, 0x2e, 0x76, 0x31, 0x2e, 0x53, 0x74, 0x6f, 0x72, 0x61, 0x67, 0x65, 0x44, 0x65,
+	0x6c, 0x65, 0x74, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x73, 0x0a, 0x0a,
+	0x50, 0x72, 0x65, 0x53, 0x69, 0x67, 0x6e, 0x55, 0x72, 0x6c, 0x12, 0x31, 0x2e, 0x6e, 0x69, 0x74,
 	0x72, 0x69, 0x63, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x73, 0x74, 0x6f, 0x72, 0x61, 0x67,
-	0x65, 0x2e, 0x76, 0x31, 0x2e, 0x53, 0x74, 0x6f, 0x72, 0x61, 0x67, 0x65, 0x4c, 0x69, 0x73, 0x74,
-	0x42, 0x6c, 0x6f, 0x62, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x31, 0x2e, 0x6e,
+	0x65, 0x2e, 0x76, 0x31, 0x2e, 0x53, 0x74, 0x6f, 0x72, 0x61, 0x67, 0x65, 0x50, 0x72, 0x65, 0x53,
+	0x69, 0x67, 0x6e, 0x55, 0x72, 0x6c, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x32, 0x2e,
+	0x6e, 0x69, 0x74, 0x72, 0x69, 0x63, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x73, 0x74, 0x6f,
+	0x72, 0x61, 0x67, 0x65, 0x2e, 0x76, 0x31, 0x2e, 0x53, 0x74, 0x6f, 0x72, 0x61, 0x67, 0x65, 0x50,
+	0x72, 0x65, 0x53, 0x69, 0x67, 0x6e, 0x55, 0x72, 0x6c, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73,
+	0x65, 0x12, 0x70, 0x0a, 0x09, 0x4c, 0x69, 0x73, 0x74, 0x42, 0x6c, 0x6f, 0x62, 0x73, 0x12, 0x30,
+	0x2e, 0x6e, 0x69, 0x74, 0x72, 0x69, 0x63, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x73, 0x74,
+	0x6f, 0x72, 0x61, 0x67, 0x65, 0x2e, 0x76, 0x31, 0x2e, 0x53, 0x74, 0x6f, 0x72, 0x61, 0x67, 0x65,
+	0x4c, 0x69, 0x73, 0x74, 0x42, 0x6c, 0x6f, 0x62, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
+	0x1a, 0x31, 0x2e, 0x6e, 0x69, 0x74, 0x72, 0x69, 0x63, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e,
+	0x73, 0x74, 0x6f, 0x72, 0x61, 0x67, 0x65, 0x2e, 0x76, 0x31, 0x2e, 0x53, 0x74, 0x6f, 0x72, 0x61,
+	0x67, 0x65, 0x4c, 0x69, 0x73, 0x74, 0x42, 0x6c, 0x6f, 0x62, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f,
+	0x6e, 0x73, 0x65, 0x12, 0x67, 0x0a, 0x06, 0x45, 0x78, 0x69, 0x73, 0x74, 0x73, 0x12, 0x2d, 0x2e,
+	0x6e, 0x69, 0x74, 0x72, 0x69, 0x63, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x73, 0x74, 0x6f,
+	0x72, 0x61, 0x67, 0x65, 0x2e, 0x76, 0x31, 0x2e, 0x53, 0x74, 0x6f, 0x72, 0x61, 0x67, 0x65, 0x45,
+	0x78, 0x69, 0x73, 0x74, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x2e, 0x2e, 0x6e,
 	0x69, 0x74, 0x72, 0x69, 0x63, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x73, 0x74, 0x6f, 0x72,
-	0x61, 0x67, 0x65, 0x2e, 0x76, 0x31, 0x2e, 0x53, 0x74, 0x6f, 0x72, 0x61, 0x67, 0x65, 0x4c, 0x69,
-	0x73, 0x74, 0x42, 0x6c, 0x6f, 0x62, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12,
-	0x67, 0x0a, 0x06, 0x45, 0x78, 0x69, 0x73, 0x74, 0x73, 0x12, 0x2d, 0x2e, 0x6e, 0x69, 0x74, 0x72,
-	0x69, 0x63, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x73, 0x74, 0x6f, 0x72, 0x61, 0x67, 0x65,
-	0x2e, 0x76, 0x31, 0x2e, 0x53, 0x74, 0x6f, 0x72, 0x61, 0x67, 0x65, 0x45, 0x78, 0x69, 0x73, 0x74,
-	0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x2e, 0x2e, 0x6e, 0x69, 0x74, 0x72, 0x69,
-	0x63, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x73, 0x74, 0x6f, 0x72, 0x61, 0x67, 0x65, 0x2e,
-	0x76, 0x31, 0x2e, 0x53, 0x74, 0x6f, 0x72, 0x61, 0x67, 0x65, 0x45, 0x78, 0x69, 0x73, 0x74, 0x73,
-	0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x32, 0x6f, 0x0a, 0x0f, 0x53, 0x74, 0x6f, 0x72,
-	0x61, 0x67, 0x65, 0x4c, 0x69, 0x73, 0x74, 0x65, 0x6e, 0x65, 0x72, 0x12, 0x5c, 0x0a, 0x06, 0x4c,
-	0x69, 0x73, 0x74, 0x65, 0x6e, 0x12, 0x26, 0x2e, 0x6e, 0x69, 0x74, 0x72, 0x69, 0x63, 0x2e, 0x70,
+	0x61, 0x67, 0x65, 0x2e, 0x76, 0x31, 0x2e, 0x53, 0x74, 0x6f, 0x72, 0x61, 0x67, 0x65, 0x45, 0x78,
+	0x69, 0x73, 0x74, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x61, 0x0a, 0x04,
+	0x53, 0x74, 0x61, 0x74, 0x12, 0x2b, 0x2e, 0x6e, 0x69, 0x74, 0x72, 0x69, 0x63, 0x2e, 0x70, 0x72,
+	0x6f, 0x74, 0x6f, 0x2e, 0x73, 0x74, 0x6f, 0x72, 0x61, 0x67, 0x65, 0x2e, 0x76, 0x31, 0x2e, 0x53,
+	0x74, 0x6f, 0x72, 0x61, 0x67, 0x65, 0x53, 0x74, 0x61, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
+	0x74, 0x1a, 0x2c, 0x2e, 0x6e, 0x69, 0x74, 0x72, 0x69, 0x63, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f,
+	0x2e, 0x73, 0x74, 0x6f, 0x72, 0x61, 0x67, 0x65, 0x2e, 0x76, 0x31, 0x2e, 0x53, 0x74, 0x6f, 0x72,
+	0x61, 0x67, 0x65, 0x53, 0x74, 0x61, 0x74, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12,
+	0x75, 0x0a, 0x0a, 0x52, 0x65, 0x61, 0x64, 0x53, 0x74, 0x72, 0x65, 0x61, 0x6d, 0x12, 0x31, 0x2e,
+	0x6e, 0x69, 0x74, 0x72, 0x69, 0x63, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x73, 0x74, 0x6f,
+	0x72, 0x61, 0x67, 0x65, 0x2e, 0x76, 0x31, 0x2e, 0x53, 0x74, 0x6f, 0x72, 0x61, 0x67, 0x65, 0x52,
+	0x65, 0x61, 0x64, 0x53, 0x74, 0x72, 0x65, 0x61, 0x6d, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
+	0x1a, 0x32, 0x2e, 0x6e, 0x69, 0x74, 0x72, 0x69, 0x63, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e,
+	0x73, 0x74, 0x6f, 0x72, 0x61, 0x67, 0x65, 0x2e, 0x76, 0x31, 0x2e, 0x53, 0x74, 0x6f, 0x72, 0x61,
+	0x67, 0x65, 0x52, 0x65, 0x61, 0x64, 0x53, 0x74, 0x72, 0x65, 0x61, 0x6d, 0x52, 0x65, 0x73, 0x70,
+	0x6f, 0x6e, 0x73, 0x65, 0x30, 0x01, 0x12, 0x78, 0x0a, 0x0b, 0x57, 0x72, 0x69, 0x74, 0x65, 0x53,
+	0x74, 0x72, 0x65, 0x61, 0x6d, 0x12, 0x32, 0x2e, 0x6e, 0x69, 0x74, 0x72, 0x69, 0x63, 0x2e, 0x70,
 	0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x73, 0x74, 0x6f, 0x72, 0x61, 0x67, 0x65, 0x2e, 0x76, 0x31, 0x2e,
-	0x43, 0x6c, 0x69, 0x65, 0x6e, 0x74, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x1a, 0x26, 0x2e,
+	0x53, 0x74, 0x6f, 0x72, 0x61, 0x67, 0x65, 0x57, 0x72, 0x69, 0x74, 0x65, 0x53, 0x74, 0x72, 0x65,
+	0x61, 0x6d, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x33, 0x2e, 0x6e, 0x69, 0x74, 0x72,
+	0x69, 0x63, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x73, 0x74, 0x6f, 0x72, 0x61, 0x67, 0x65,
+	0x2e, 0x76, 0x31, 0x2e, 0x53, 0x74, 0x6f, 0x72, 0x61, 0x67, 0x65, 0x57, 0x72, 0x69, 0x74, 0x65,
+	0x53, 0x74, 0x72, 0x65, 0x61, 0x6d, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x28, 0x01,
+	0x32, 0x6f, 0x0a, 0x0f, 0x53, 0x74, 0x6f, 0x72, 0x61, 0x67, 0x65, 0x4c, 0x69, 0x73, 0x74, 0x65,
+	0x6e, 0x65, 0x72, 0x12, 0x5c, 0x0a, 0x06, 0x4c, 0x69, 0x73, 0x74, 0x65, 0x6e, 0x12, 0x26, 0x2e,
 	0x6e, 0x69, 0x74, 0x72, 0x69, 0x63, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x73, 0x74, 0x6f,
-	0x72, 0x61, 0x67, 0x65, 0x2e, 0x76, 0x31, 0x2e, 0x53, 0x65, 0x72, 0x76, 0x65, 0x72, 0x4d, 0x65,
-	0x73, 0x73, 0x61, 0x67, 0x65, 0x28, 0x01, 0x30, 0x01, 0x42, 0xa4, 0x01, 0x0a, 0x1a, 0x69, 0x6f,
-	0x2e, 0x6e, 0x69, 0x74, 0x72, 0x69, 0x63, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x73, 0x74,
-	0x6f, 0x72, 0x61, 0x67, 0x65, 0x2e, 0x76, 0x31, 0x42, 0x0e, 0x53, 0x74, 0x6f, 0x72, 0x61, 0x67,
-	0x65, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x50, 0x01, 0x5a, 0x40, 0x67, 0x69, 0x74, 0x68,
-	0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x6e, 0x69, 0x74, 0x72, 0x69, 0x63, 0x74, 0x65, 0x63,
-	0x68, 0x2f, 0x6e, 0x69, 0x74, 0x72, 0x69, 0x63, 0x2f, 0x63, 0x6f, 0x72, 0x65, 0x2f, 0x70, 0x6b,
-	0x67, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2f, 0x73, 0x74, 0x6f, 0x72, 0x61, 0x67, 0x65, 0x2f,
-	0x76, 0x31, 0x3b, 0x73, 0x74, 0x6f, 0x72, 0x61, 0x67, 0x65, 0x70, 0x62, 0xaa, 0x02, 0x17, 0x4e,
-	0x69, 0x74, 0x72, 0x69, 0x63, 0x2e, 0x50, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x53, 0x74, 0x6f, 0x72,
-	0x61, 0x67, 0x65, 0x2e, 0x76, 0x31, 0xca, 0x02, 0x17, 0x4e, 0x69, 0x74, 0x72, 0x69, 0x63, 0x5c,
-	0x50, 0x72, 0x6f, 0x74, 0x6f, 0x5c, 0x53, 0x74, 0x6f, 0x72, 0x61, 0x67, 0x65, 0x5c, 0x56, 0x31,
-	0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+	0x72, 0x61, 0x67, 0x65, 0x2e, 0x76, 0x31, 0x2e, 0x43, 0x6c, 0x69, 0x65, 0x6e, 0x74, 0x4d, 0x65,
+	0x73, 0x73, 0x61, 0x67, 0x65, 0x1a, 0x26, 0x2e, 0x6e, 0x69, 0x74, 0x72, 0x69, 0x63, 0x2e, 0x70,
+	0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x73, 0x74, 0x6f, 0x72, 0x61, 0x67, 0x65, 0x2e, 0x76, 0x31, 0x2e,
+	0x53, 0x65, 0x72, 0x76, 0x65, 0x72, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x28, 0x01, 0x30,
+	0x01, 0x42, 0xa4, 0x01, 0x0a, 0x1a, 0x69, 0x6f, 0x2e, 0x6e, 0x69, 0x74, 0x72, 0x69, 0x63, 0x2e,
+	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x73, 0x74, 0x6f, 0x72, 0x61, 0x67, 0x65, 0x2e, 0x76, 0x31,
+	0x42, 0x0e, 0x53, 0x74, 0x6f, 0x72, 0x61, 0x67, 0x65, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65,
+	0x50, 0x01, 0x5a, 0x40, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x6e,
+	0x69, 0x74, 0x72, 0x69, 0x63, 0x74, 0x65, 0x63, 0x68, 0x2f, 0x6e, 0x69, 0x74, 0x72, 0x69, 0x63,
+	0x2f, 0x63, 0x6f, 0x72, 0x65, 0x2f, 0x70, 0x6b, 0x67, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2f,
+	0x73, 0x74, 0x6f, 0x72, 0x61, 0x67, 0x65, 0x2f, 0x76, 0x31, 0x3b, 0x73, 0x74, 0x6f, 0x72, 0x61,
+	0x67, 0x65, 0x70, 0x62, 0xaa, 0x02, 0x17, 0x4e, 0x69, 0x74, 0x72, 0x69, 0x63, 0x2e, 0x50, 0x72,
+	0x6f, 0x74, 0x6f, 0x2e, 0x53, 0x74, 0x6f, 0x72, 0x61, 0x67, 0x65, 0x2e, 0x76, 0x31, 0xca, 0x02,
+	0x17, 0x4e, 0x69, 0x74, 0x72, 0x69, 0x63, 0x5c, 0x50, 0x72, 0x6f, 0x74, 0x6f, 0x5c, 0x53, 0x74,
+	0x6f, 0x72, 0x61, 0x67, 0x65, 0x5c, 0x56, 0x31, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
 }
 
 var (
@@ -1492,62 +2294,89 @@ func file_nitric_proto_storage_v1_storage_proto_rawDescGZIP() []byte {
 }
 
 var file_nitric_proto_storage_v1_storage_proto_enumTypes = make([]protoimpl.EnumInfo, 2)
-var file_nitric_proto_storage_v1_storage_proto_msgTypes = make([]protoimpl.MessageInfo, 20)
+var file_nitric_proto_storage_v1_storage_proto_msgTypes = make([]protoimpl.MessageInfo, 32)
 var file_nitric_proto_storage_v1_storage_proto_goTypes = []interface{}{
 	(BlobEventType)(0),                      // 0: nitric.proto.storage.v1.BlobEventType
 	(StoragePreSignUrlRequest_Operation)(0), // 1: nitric.proto.storage.v1.StoragePreSignUrlRequest.Operation
 	(*ClientMessage)(nil),                   // 2: nitric.proto.storage.v1.ClientMessage
 	(*ServerMessage)(nil),                   // 3: nitric.proto.storage.v1.ServerMessage
-	(*BlobEventRequest)(nil),                // 4: nitric.proto.storage.v1.BlobEventRequest
-	(*BlobEvent)(nil),                       // 5: nitric.proto.storage.v1.BlobEvent
-	(*BlobEventResponse)(nil),               // 6: nitric.proto.storage.v1.BlobEventResponse
-	(*RegistrationRequest)(nil),             // 7: nitric.proto.storage.v1.RegistrationRequest
-	(*RegistrationResponse)(nil),            // 8: nitric.proto.storage.v1.RegistrationResponse
-	(*StorageWriteRequest)(nil),             // 9: nitric.proto.storage.v1.StorageWriteRequest
-	(*StorageWriteResponse)(nil),            // 10: nitric.proto.storage.v1.StorageWriteResponse
-	(*StorageReadRequest)(nil),              // 11: nitric.proto.storage.v1.StorageReadRequest
-	(*StorageReadResponse)(nil),             // 12: nitric.proto.storage.v1.StorageReadResponse
-	(*StorageDeleteRequest)(nil),            // 13: nitric.proto.storage.v1.StorageDeleteRequest
-	(*StorageDeleteResponse)(nil),           // 14: nitric.proto.storage.v1.StorageDeleteResponse
-	(*StoragePreSignUrlRequest)(nil),        // 15: nitric.proto.storage.v1.StoragePreSignUrlRequest
-	(*StoragePreSignUrlResponse)(nil),       // 16: nitric.proto.storage.v1.StoragePreSignUrlResponse
-	(*StorageListBlobsRequest)(nil),         // 17: nitric.proto.storage.v1.StorageListBlobsRequest
-	(*Blob)(nil),                            // 18: nitric.proto.storage.v1.Blob
-	(*StorageListBlobsResponse)(nil),        // 19: nitric.proto.storage.v1.StorageListBlobsResponse
-	(*StorageExistsRequest)(nil),            // 20: nitric.proto.storage.v1.StorageExistsRequest
-	(*StorageExistsResponse)(nil),           // 21: nitric.proto.storage.v1.StorageExistsResponse
-	(*durationpb.Duration)(nil),             // 22: google.protobuf.Duration
+	(*CancellationRequest)(nil),             // 4: nitric.proto.storage.v1.CancellationRequest
+	(*BlobEventRequest)(nil),                // 5: nitric.proto.storage.v1.BlobEventRequest
+	(*BlobEvent)(nil),                       // 6: nitric.proto.storage.v1.BlobEvent
+	(*BlobEventResponse)(nil),               // 7: nitric.proto.storage.v1.BlobEventResponse
+	(*RegistrationRequest)(nil),             // 8: nitric.proto.storage.v1.RegistrationRequest
+	(*RegistrationResponse)(nil),            // 9: nitric.proto.storage.v1.RegistrationResponse
+	(*StorageWriteRequest)(nil),             // 10: nitric.proto.storage.v1.StorageWriteRequest
+	(*StorageWriteResponse)(nil),            // 11: nitric.proto.storage.v1.StorageWriteResponse
+	(*StorageReadRequest)(nil),              // 12: nitric.proto.storage.v1.StorageReadRequest
+	(*StorageReadResponse)(nil),             // 13: nitric.proto.storage.v1.StorageReadResponse
+	(*StorageReadStreamRequest)(nil),        // 14: nitric.proto.storage.v1.StorageReadStreamRequest
+	(*StorageReadStreamResponse)(nil),       // 15: nitric.proto.storage.v1.StorageReadStreamResponse
+	(*StorageWriteStreamHeader)(nil),        // 16: nitric.proto.storage.v1.StorageWriteStreamHeader
+	(*StorageWriteStreamRequest)(nil),       // 17: nitric.proto.storage.v1.StorageWriteStreamRequest
+	(*StorageWriteStreamResponse)(nil),      // 18: nitric.proto.storage.v1.StorageWriteStreamResponse
+	(*StorageDeleteRequest)(nil),            // 19: nitric.proto.storage.v1.StorageDeleteRequest
+	(*StorageDeleteResponse)(nil),           // 20: nitric.proto.storage.v1.StorageDeleteResponse
+	(*StoragePreSignUrlRequest)(nil),        // 21: nitric.proto.storage.v1.StoragePreSignUrlRequest
+	(*StoragePreSignUrlResponse)(nil),       // 22: nitric.proto.storage.v1.StoragePreSignUrlResponse
+	(*StorageListBlobsRequest)(nil),         // 23: nitric.proto.storage.v1.StorageListBlobsRequest
+	(*Blob)(nil),                            // 24: nitric.proto.storage.v1.Blob
+	(*StorageListBlobsResponse)(nil),        // 25: nitric.proto.storage.v1.StorageListBlobsResponse
+	(*StorageExistsRequest)(nil),            // 26: nitric.proto.storage.v1.StorageExistsRequest
+	(*StorageExistsResponse)(nil),           // 27: nitric.proto.storage.v1.StorageExistsResponse
+	(*StorageStatRequest)(nil),              // 28: nitric.proto.storage.v1.StorageStatRequest
+	(*StorageStatResponse)(nil),             // 29: nitric.proto.storage.v1.StorageStatResponse
+	nil,                                     // 30: nitric.proto.storage.v1.ServerMessage.TraceContextEntry
+	nil,                                     // 31: nitric.proto.storage.v1.StorageWriteRequest.MetadataEntry
+	nil,                                     // 32: nitric.proto.storage.v1.StorageWriteStreamHeader.MetadataEntry
+	nil,                                     // 33: nitric.proto.storage.v1.Blob.MetadataEntry
+	(*durationpb.Duration)(nil),             // 34: google.protobuf.Duration
+	(*timestamppb.Timestamp)(nil),           // 35: google.protobuf.Timestamp
 }
 var file_nitric_proto_storage_v1_storage_proto_depIdxs = []int32{
-	7,  // 0: nitric.proto.storage.v1.ClientMessage.registration_request:type_name -> nitric.proto.storage.v1.RegistrationRequest
-	6,  // 1: nitric.proto.storage.v1.ClientMessage.blob_event_response:type_name -> nitric.proto.storage.v1.BlobEventResponse
-	8,  // 2: nitric.proto.storage.v1.ServerMessage.registration_response:type_name -> nitric.proto.storage.v1.RegistrationResponse
-	4,  // 3: nitric.proto.storage.v1.ServerMessage.blob_event_request:type_name -> nitric.proto.storage.v1.BlobEventRequest
-	5,  // 4: nitric.proto.storage.v1.BlobEventRequest.blob_event:type_name -> nitric.proto.storage.v1.BlobEvent
-	0,  // 5: nitric.proto.storage.v1.BlobEvent.type:type_name -> nitric.proto.storage.v1.BlobEventType
-	0,  // 6: nitric.proto.storage.v1.RegistrationRequest.blob_event_type:type_name -> nitric.proto.storage.v1.BlobEventType
-	1,  // 7: nitric.proto.storage.v1.StoragePreSignUrlRequest.operation:type_name -> nitric.proto.storage.v1.StoragePreSignUrlRequest.Operation
-	22, // 8: nitric.proto.storage.v1.StoragePreSignUrlRequest.expiry:type_name -> google.protobuf.Duration
-	18, // 9: nitric.proto.storage.v1.StorageListBlobsResponse.blobs:type_name -> nitric.proto.storage.v1.Blob
-	11, // 10: nitric.proto.storage.v1.Storage.Read:input_type -> nitric.proto.storage.v1.StorageReadRequest
-	9,  // 11: nitric.proto.storage.v1.Storage.Write:input_type -> nitric.proto.storage.v1.StorageWriteRequest
-	13, // 12: nitric.proto.storage.v1.Storage.Delete:input_type -> nitric.proto.storage.v1.StorageDeleteRequest
-	15, // 13: nitric.proto.storage.v1.Storage.PreSignUrl:input_type -> nitric.proto.storage.v1.StoragePreSignUrlRequest
-	17, // 14: nitric.proto.storage.v1.Storage.ListBlobs:input_type -> nitric.proto.storage.v1.StorageListBlobsRequest
-	20, // 15: nitric.proto.storage.v1.Storage.Exists:input_type -> nitric.proto.storage.v1.StorageExistsRequest
-	2,  // 16: nitric.proto.storage.v1.StorageListener.Listen:input_type -> nitric.proto.storage.v1.ClientMessage
-	12, // 17: nitric.proto.storage.v1.Storage.Read:output_type -> nitric.proto.storage.v1.StorageReadResponse
-	10, // 18: nitric.proto.storage.v1.Storage.Write:output_type -> nitric.proto.storage.v1.StorageWriteResponse
-	14, // 19: nitric.proto.storage.v1.Storage.Delete:output_type -> nitric.proto.storage.v1.StorageDeleteResponse
-	16, // 20: nitric.proto.storage.v1.Storage.PreSignUrl:output_type -> nitric.proto.storage.v1.StoragePreSignUrlResponse
-	19, // 21: nitric.proto.storage.v1.Storage.ListBlobs:output_type -> nitric.proto.storage.v1.StorageListBlobsResponse
-	21, // 22: nitric.proto.storage.v1.Storage.Exists:output_type -> nitric.proto.storage.v1.StorageExistsResponse
-	3,  // 23: nitric.proto.storage.v1.StorageListener.Listen:output_type -> nitric.proto.storage.v1.ServerMessage
-	17, // [17:24] is the sub-list for method output_type
-	10, // [10:17] is the sub-list for method input_type
-	10, // [10:10] is the sub-list for extension type_name
-	10, // [10:10] is the sub-list for extension extendee
-	0,  // [0:10] is the sub-list for field type_name
+	8,  // 0: nitric.proto.storage.v1.ClientMessage.registration_request:type_name -> nitric.proto.storage.v1.RegistrationRequest
+	7,  // 1: nitric.proto.storage.v1.ClientMessage.blob_event_response:type_name -> nitric.proto.storage.v1.BlobEventResponse
+	9,  // 2: nitric.proto.storage.v1.ServerMessage.registration_response:type_name -> nitric.proto.storage.v1.RegistrationResponse
+	5,  // 3: nitric.proto.storage.v1.ServerMessage.blob_event_request:type_name -> nitric.proto.storage.v1.BlobEventRequest
+	4,  // 4: nitric.proto.storage.v1.ServerMessage.cancellation_request:type_name -> nitric.proto.storage.v1.CancellationRequest
+	30, // 5: nitric.proto.storage.v1.ServerMessage.trace_context:type_name -> nitric.proto.storage.v1.ServerMessage.TraceContextEntry
+	6,  // 6: nitric.proto.storage.v1.BlobEventRequest.blob_event:type_name -> nitric.proto.storage.v1.BlobEvent
+	0,  // 7: nitric.proto.storage.v1.BlobEvent.type:type_name -> nitric.proto.storage.v1.BlobEventType
+	0,  // 8: nitric.proto.storage.v1.RegistrationRequest.blob_event_type:type_name -> nitric.proto.storage.v1.BlobEventType
+	31, // 9: nitric.proto.storage.v1.StorageWriteRequest.metadata:type_name -> nitric.proto.storage.v1.StorageWriteRequest.MetadataEntry
+	32, // 10: nitric.proto.storage.v1.StorageWriteStreamHeader.metadata:type_name -> nitric.proto.storage.v1.StorageWriteStreamHeader.MetadataEntry
+	16, // 11: nitric.proto.storage.v1.StorageWriteStreamRequest.header:type_name -> nitric.proto.storage.v1.StorageWriteStreamHeader
+	1,  // 12: nitric.proto.storage.v1.StoragePreSignUrlRequest.operation:type_name -> nitric.proto.storage.v1.StoragePreSignUrlRequest.Operation
+	34, // 13: nitric.proto.storage.v1.StoragePreSignUrlRequest.expiry:type_name -> google.protobuf.Duration
+	35, // 14: nitric.proto.storage.v1.Blob.last_modified:type_name -> google.protobuf.Timestamp
+	33, // 15: nitric.proto.storage.v1.Blob.metadata:type_name -> nitric.proto.storage.v1.Blob.MetadataEntry
+	24, // 16: nitric.proto.storage.v1.StorageListBlobsResponse.blobs:type_name -> nitric.proto.storage.v1.Blob
+	24, // 17: nitric.proto.storage.v1.StorageStatResponse.blob:type_name -> nitric.proto.storage.v1.Blob
+	12, // 18: nitric.proto.storage.v1.Storage.Read:input_type -> nitric.proto.storage.v1.StorageReadRequest
+	10, // 19: nitric.proto.storage.v1.Storage.Write:input_type -> nitric.proto.storage.v1.StorageWriteRequest
+	19, // 20: nitric.proto.storage.v1.Storage.Delete:input_type -> nitric.proto.storage.v1.StorageDeleteRequest
+	21, // 21: nitric.proto.storage.v1.Storage.PreSignUrl:input_type -> nitric.proto.storage.v1.StoragePreSignUrlRequest
+	23, // 22: nitric.proto.storage.v1.Storage.ListBlobs:input_type -> nitric.proto.storage.v1.StorageListBlobsRequest
+	26, // 23: nitric.proto.storage.v1.Storage.Exists:input_type -> nitric.proto.storage.v1.StorageExistsRequest
+	28, // 24: nitric.proto.storage.v1.Storage.Stat:input_type -> nitric.proto.storage.v1.StorageStatRequest
+	14, // 25: nitric.proto.storage.v1.Storage.ReadStream:input_type -> nitric.proto.storage.v1.StorageReadStreamRequest
+	17, // 26: nitric.proto.storage.v1.Storage.WriteStream:input_type -> nitric.proto.storage.v1.StorageWriteStreamRequest
+	2,  // 27: nitric.proto.storage.v1.StorageListener.Listen:input_type -> nitric.proto.storage.v1.ClientMessage
+	13, // 28: nitric.proto.storage.v1.Storage.Read:output_type -> nitric.proto.storage.v1.StorageReadResponse
+	11, // 29: nitric.proto.storage.v1.Storage.Write:output_type -> nitric.proto.storage.v1.StorageWriteResponse
+	20, // 30: nitric.proto.storage.v1.Storage.Delete:output_type -> nitric.proto.storage.v1.StorageDeleteResponse
+	22, // 31: nitric.proto.storage.v1.Storage.PreSignUrl:output_type -> nitric.proto.storage.v1.StoragePreSignUrlResponse
+	25, // 32: nitric.proto.storage.v1.Storage.ListBlobs:output_type -> nitric.proto.storage.v1.StorageListBlobsResponse
+	27, // 33: nitric.proto.storage.v1.Storage.Exists:output_type -> nitric.proto.storage.v1.StorageExistsResponse
+	29, // 34: nitric.proto.storage.v1.Storage.Stat:output_type -> nitric.proto.storage.v1.StorageStatResponse
+	15, // 35: nitric.proto.storage.v1.Storage.ReadStream:output_type -> nitric.proto.storage.v1.StorageReadStreamResponse
+	18, // 36: nitric.proto.storage.v1.Storage.WriteStream:output_type -> nitric.proto.storage.v1.StorageWriteStreamResponse
+	3,  // 37: nitric.proto.storage.v1.StorageListener.Listen:output_type -> nitric.proto.storage.v1.ServerMessage
+	28, // [28:38] is the sub-list for method output_type
+	18, // [18:28] is the sub-list for method input_type
+	18, // [18:18] is the sub-list for extension type_name
+	18, // [18:18] is the sub-list for extension extendee
+	0,  // [0:18] is the sub-list for field type_name
 }
 
 func init() { file_nitric_proto_storage_v1_storage_proto_init() }
@@ -1581,7 +2410,7 @@ func file_nitric_proto_storage_v1_storage_proto_init() {
 			}
 		}
 		file_nitric_proto_storage_v1_storage_proto_msgTypes[2].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*BlobEventRequest); i {
+			switch v := v.(*CancellationRequest); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1593,7 +2422,7 @@ func file_nitric_proto_storage_v1_storage_proto_init() {
 			}
 		}
 		file_nitric_proto_storage_v1_storage_proto_msgTypes[3].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*BlobEvent); i {
+			switch v := v.(*BlobEventRequest); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1605,7 +2434,7 @@ func file_nitric_proto_storage_v1_storage_proto_init() {
 			}
 		}
 		file_nitric_proto_storage_v1_storage_proto_msgTypes[4].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*BlobEventResponse); i {
+			switch v := v.(*BlobEvent); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1617,7 +2446,7 @@ func file_nitric_proto_storage_v1_storage_proto_init() {
 			}
 		}
 		file_nitric_proto_storage_v1_storage_proto_msgTypes[5].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*RegistrationRequest); i {
+			switch v := v.(*BlobEventResponse); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1629,7 +2458,7 @@ func file_nitric_proto_storage_v1_storage_proto_init() {
 			}
 		}
 		file_nitric_proto_storage_v1_storage_proto_msgTypes[6].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*RegistrationResponse); i {
+			switch v := v.(*RegistrationRequest); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1641,7 +2470,7 @@ func file_nitric_proto_storage_v1_storage_proto_init() {
 			}
 		}
 		file_nitric_proto_storage_v1_storage_proto_msgTypes[7].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*StorageWriteRequest); i {
+			switch v := v.(*RegistrationResponse); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1653,7 +2482,7 @@ func file_nitric_proto_storage_v1_storage_proto_init() {
 			}
 		}
 		file_nitric_proto_storage_v1_storage_proto_msgTypes[8].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*StorageWriteResponse); i {
+			switch v := v.(*StorageWriteRequest); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1665,7 +2494,7 @@ func file_nitric_proto_storage_v1_storage_proto_init() {
 			}
 		}
 		file_nitric_proto_storage_v1_storage_proto_msgTypes[9].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*StorageReadRequest); i {
+			switch v := v.(*StorageWriteResponse); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1677,7 +2506,7 @@ func file_nitric_proto_storage_v1_storage_proto_init() {
 			}
 		}
 		file_nitric_proto_storage_v1_storage_proto_msgTypes[10].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*StorageReadResponse); i {
+			switch v := v.(*StorageReadRequest); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1689,7 +2518,7 @@ func file_nitric_proto_storage_v1_storage_proto_init() {
 			}
 		}
 		file_nitric_proto_storage_v1_storage_proto_msgTypes[11].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*StorageDeleteRequest); i {
+			switch v := v.(*StorageReadResponse); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1701,7 +2530,7 @@ func file_nitric_proto_storage_v1_storage_proto_init() {
 			}
 		}
 		file_nitric_proto_storage_v1_storage_proto_msgTypes[12].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*StorageDeleteResponse); i {
+			switch v := v.(*StorageReadStreamRequest); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1713,7 +2542,7 @@ func file_nitric_proto_storage_v1_storage_proto_init() {
 			}
 		}
 		file_nitric_proto_storage_v1_storage_proto_msgTypes[13].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*StoragePreSignUrlRequest); i {
+			switch v := v.(*StorageReadStreamResponse); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1725,7 +2554,7 @@ func file_nitric_proto_storage_v1_storage_proto_init() {
 			}
 		}
 		file_nitric_proto_storage_v1_storage_proto_msgTypes[14].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*StoragePreSignUrlResponse); i {
+			switch v := v.(*StorageWriteStreamHeader); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1737,7 +2566,7 @@ func file_nitric_proto_storage_v1_storage_proto_init() {
 			}
 		}
 		file_nitric_proto_storage_v1_storage_proto_msgTypes[15].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*StorageListBlobsRequest); i {
+			switch v := v.(*StorageWriteStreamRequest); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1749,7 +2578,7 @@ func file_nitric_proto_storage_v1_storage_proto_init() {
 			}
 		}
 		file_nitric_proto_storage_v1_storage_proto_msgTypes[16].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*Blob); i {
+			switch v := v.(*StorageWriteStreamResponse); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1761,7 +2590,7 @@ func file_nitric_proto_storage_v1_storage_proto_init() {
 			}
 		}
 		file_nitric_proto_storage_v1_storage_proto_msgTypes[17].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*StorageListBlobsResponse); i {
+			switch v := v.(*StorageDeleteRequest); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1773,7 +2602,7 @@ func file_nitric_proto_storage_v1_storage_proto_init() {
 			}
 		}
 		file_nitric_proto_storage_v1_storage_proto_msgTypes[18].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*StorageExistsRequest); i {
+			switch v := v.(*StorageDeleteResponse); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1785,6 +2614,78 @@ func file_nitric_proto_storage_v1_storage_proto_init() {
 			}
 		}
 		file_nitric_proto_storage_v1_storage_proto_msgTypes[19].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*StoragePreSignUrlRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_nitric_proto_storage_v1_storage_proto_msgTypes[20].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*StoragePreSignUrlResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_nitric_proto_storage_v1_storage_proto_msgTypes[21].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*StorageListBlobsRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_nitric_proto_storage_v1_storage_proto_msgTypes[22].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Blob); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_nitric_proto_storage_v1_storage_proto_msgTypes[23].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*StorageListBlobsResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_nitric_proto_storage_v1_storage_proto_msgTypes[24].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*StorageExistsRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_nitric_proto_storage_v1_storage_proto_msgTypes[25].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*StorageExistsResponse); i {
 			case 0:
 				return &v.state
@@ -1796,6 +2697,30 @@ func file_nitric_proto_storage_v1_storage_proto_init() {
 				return nil
 			}
 		}
+		file_nitric_proto_storage_v1_storage_proto_msgTypes[26].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*StorageStatRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_nitric_proto_storage_v1_storage_proto_msgTypes[27].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*StorageStatResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
 	}
 	file_nitric_proto_storage_v1_storage_proto_msgTypes[0].OneofWrappers = []interface{}{
 		(*ClientMessage_RegistrationRequest)(nil),
@@ -1804,17 +2729,22 @@ func file_nitric_proto_storage_v1_storage_proto_init() {
 	file_nitric_proto_storage_v1_storage_proto_msgTypes[1].OneofWrappers = []interface{}{
 		(*ServerMessage_RegistrationResponse)(nil),
 		(*ServerMessage_BlobEventRequest)(nil),
+		(*ServerMessage_CancellationRequest)(nil),
 	}
-	file_nitric_proto_storage_v1_storage_proto_msgTypes[2].OneofWrappers = []interface{}{
+	file_nitric_proto_storage_v1_storage_proto_msgTypes[3].OneofWrappers = []interface{}{
 		(*BlobEventRequest_BlobEvent)(nil),
 	}
+	file_nitric_proto_storage_v1_storage_proto_msgTypes[15].OneofWrappers = []interface{}{
+		(*StorageWriteStreamRequest_Header)(nil),
+		(*StorageWriteStreamRequest_Chunk)(nil),
+	}
 	type x struct{}
 	out := protoimpl.TypeBuilder{
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: file_nitric_proto_storage_v1_storage_proto_rawDesc,
 			NumEnums:      2,
-			NumMessages:   20,
+			NumMessages:   32,
 			NumExtensions: 0,
 			NumServices:   2,
 		},
@@ -34,6 +34,12 @@ type StorageClient interface {
 	ListBlobs(ctx context.Context, in *StorageListBlobsRequest, opts ...grpc.CallOption) (*StorageListBlobsResponse, error)
 	// Determine is an object exists in a bucket
 	Exists(ctx context.Context, in *StorageExistsRequest, opts ...grpc.CallOption) (*StorageExistsResponse, error)
+	// Retrieve the details of an item in a bucket, without its contents
+	Stat(ctx context.Context, in *StorageStatRequest, opts ...grpc.CallOption) (*StorageStatResponse, error)
+	// Retrieve an item from a bucket as a stream of chunks, optionally limited to a byte range
+	ReadStream(ctx context.Context, in *StorageReadStreamRequest, opts ...grpc.CallOption) (Storage_ReadStreamClient, error)
+	// Store an item to a bucket from a stream of chunks, the first message must be a header
+	WriteStream(ctx context.Context, opts ...grpc.CallOption) (Storage_WriteStreamClient, error)
 }
 
 type storageClient struct {
@@ -98,6 +104,81 @@ func (c *storageClient) Exists(ctx context.Context, in *StorageExistsRequest, op
 	return out, nil
 }
 
+func (c *storageClient) Stat(ctx context.Context, in *StorageStatRequest, opts ...grpc.CallOption) (*StorageStatResponse, error) {
+	out := new(StorageStatResponse)
+	err := c.cc.Invoke(ctx, "/nitric.proto.storage.v1.Storage/Stat", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *storageClient) ReadStream(ctx context.Context, in *StorageReadStreamRequest, opts ...grpc.CallOption) (Storage_ReadStreamClient, error) {
+	stream, err := c.cc.NewStream(ctx, &Storage_ServiceDesc.Streams[0], "/nitric.proto.storage.v1.Storage/ReadStream", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &storageReadStreamClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type Storage_ReadStreamClient interface {
+	Recv() (*StorageReadStreamResponse, error)
+	grpc.ClientStream
+}
+
+type storageReadStreamClient struct {
+	grpc.ClientStream
+}
+
+func (x *storageReadStreamClient) Recv() (*StorageReadStreamResponse, error) {
+	m := new(StorageReadStreamResponse)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *storageClient) WriteStream(ctx context.Context, opts ...grpc.CallOption) (Storage_WriteStreamClient, error) {
+	stream, err := c.cc.NewStream(ctx, &Storage_ServiceDesc.Streams[1], "/nitric.proto.storage.v1.Storage/WriteStream", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &storageWriteStreamClient{stream}
+	return x, nil
+}
+
+type Storage_WriteStreamClient interface {
+	Send(*StorageWriteStreamRequest) error
+	CloseAndRecv() (*StorageWriteStreamResponse, error)
+	grpc.ClientStream
+}
+
+type storageWriteStreamClient struct {
+	grpc.ClientStream
+}
+
+func (x *storageWriteStreamClient) Send(m *StorageWriteStreamRequest) error {
+	return x.ClientStream.SendMsg(m)
+}
+
+func (x *storageWriteStreamClient) CloseAndRecv() (*StorageWriteStreamResponse, error) {
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	m := new(StorageWriteStreamResponse)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
 // StorageServer is the server API for Storage service.
 // All implementations should embed UnimplementedStorageServer
 // for forward compatibility
@@ -114,6 +195,12 @@ type StorageServer interface {
 	ListBlobs(context.Context, *StorageListBlobsRequest) (*StorageListBlobsResponse, error)
 	// Determine is an object exists in a bucket
 	Exists(context.Context, *StorageExistsRequest) (*StorageExistsResponse, error)
+	// Retrieve the details of an item in a bucket, without its contents
+	Stat(context.Context, *StorageStatRequest) (*StorageStatResponse, error)
+	// Retrieve an item from a bucket as a stream of chunks, optionally limited to a byte range
+	ReadStream(*StorageReadStreamRequest, Storage_ReadStreamServer) error
+	// Store an item to a bucket from a stream of chunks, the first message must be a header
+	WriteStream(Storage_WriteStreamServer) error
 }
 
 // UnimplementedStorageServer should be embedded to have forward compatible implementations.
@@ -138,6 +225,15 @@ func (UnimplementedStorageServer) ListBlobs(context.Context, *StorageListBlobsRe
 func (UnimplementedStorageServer) Exists(context.Context, *StorageExistsRequest) (*StorageExistsResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method Exists not implemented")
 }
+func (UnimplementedStorageServer) Stat(context.Context, *StorageStatRequest) (*StorageStatResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Stat not implemented")
+}
+func (UnimplementedStorageServer) ReadStream(*StorageReadStreamRequest, Storage_ReadStreamServer) error {
+	return status.Errorf(codes.Unimplemented, "method ReadStream not implemented")
+}
+func (UnimplementedStorageServer) WriteStream(Storage_WriteStreamServer) error {
+	return status.Errorf(codes.Unimplemented, "method WriteStream not implemented")
+}
 
 // UnsafeStorageServer may be embedded to opt out of forward compatibility for this service.
 // Use of this interface is not recommended, as added methods to StorageServer will
@@ -258,6 +354,71 @@ func _Storage_Exists_Handler(srv interface{}, ctx context.Context, dec func(inte
 	return interceptor(ctx, in, info, handler)
 }
 
+func _Storage_Stat_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(StorageStatRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(StorageServer).Stat(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/nitric.proto.storage.v1.Storage/Stat",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(StorageServer).Stat(ctx, req.(*StorageStatRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Storage_ReadStream_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(StorageReadStreamRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(StorageServer).ReadStream(m, &storageReadStreamServer{stream})
+}
+
+type Storage_ReadStreamServer interface {
+	Send(*StorageReadStreamResponse) error
+	grpc.ServerStream
+}
+
+type storageReadStreamServer struct {
+	grpc.ServerStream
+}
+
+func (x *storageReadStreamServer) Send(m *StorageReadStreamResponse) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _Storage_WriteStream_Handler(srv interface{}, stream grpc.ServerStream) error {
+	return srv.(StorageServer).WriteStream(&storageWriteStreamServer{stream})
+}
+
+type Storage_WriteStreamServer interface {
+	SendAndClose(*StorageWriteStreamResponse) error
+	Recv() (*StorageWriteStreamRequest, error)
+	grpc.ServerStream
+}
+
+type storageWriteStreamServer struct {
+	grpc.ServerStream
+}
+
+func (x *storageWriteStreamServer) SendAndClose(m *StorageWriteStreamResponse) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func (x *storageWriteStreamServer) Recv() (*StorageWriteStreamRequest, error) {
+	m := new(StorageWriteStreamRequest)
+	if err := x.ServerStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
 // Storage_ServiceDesc is the grpc.ServiceDesc for Storage service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -289,8 +450,23 @@ var Storage_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "Exists",
 			Handler:    _Storage_Exists_Handler,
 		},
+		{
+			MethodName: "Stat",
+			Handler:    _Storage_Stat_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "ReadStream",
+			Handler:       _Storage_ReadStream_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "WriteStream",
+			Handler:       _Storage_WriteStream_Handler,
+			ClientStreams: true,
+		},
 	},
-	Streams:  []grpc.StreamDesc{},
 	Metadata: "nitric/proto/storage/v1/storage.proto",
 }
 
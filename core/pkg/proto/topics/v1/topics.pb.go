@@ -123,6 +123,8 @@ type MessageRequest struct {
 	TopicName string `protobuf:"bytes,1,opt,name=topic_name,json=topicName,proto3" json:"topic_name,omitempty"`
 	// Message Type
 	Message *TopicMessage `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
+	// Number of times the message has been delivered, including this delivery, 0 when the provider can't determine it
+	DeliveryAttempt int32 `protobuf:"varint,3,opt,name=delivery_attempt,json=deliveryAttempt,proto3" json:"delivery_attempt,omitempty"`
 }
 
 func (x *MessageRequest) Reset() {
@@ -171,6 +173,13 @@ func (x *MessageRequest) GetMessage() *TopicMessage {
 	return nil
 }
 
+func (x *MessageRequest) GetDeliveryAttempt() int32 {
+	if x != nil {
+		return x.DeliveryAttempt
+	}
+	return 0
+}
+
 type MessageResponse struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
@@ -230,7 +239,10 @@ type ServerMessage struct {
 	//
 	//	*ServerMessage_RegistrationResponse
 	//	*ServerMessage_MessageRequest
+	//	*ServerMessage_CancellationRequest
 	Content isServerMessage_Content `protobuf_oneof:"content"`
+	// W3C trace context (e.g. traceparent and tracestate) of the trigger, used to continue its trace in the service
+	TraceContext map[string]string `protobuf:"bytes,10,rep,name=trace_context,json=traceContext,proto3" json:"trace_context,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
 }
 
 func (x *ServerMessage) Reset() {
@@ -293,6 +305,20 @@ func (x *ServerMessage) GetMessageRequest() *MessageRequest {
 	return nil
 }
 
+func (x *ServerMessage) GetCancellationRequest() *CancellationRequest {
+	if x, ok := x.GetContent().(*ServerMessage_CancellationRequest); ok {
+		return x.CancellationRequest
+	}
+	return nil
+}
+
+func (x *ServerMessage) GetTraceContext() map[string]string {
+	if x != nil {
+		return x.TraceContext
+	}
+	return nil
+}
+
 type isServerMessage_Content interface {
 	isServerMessage_Content()
 }
@@ -307,22 +333,83 @@ type ServerMessage_MessageRequest struct {
 	MessageRequest *MessageRequest `protobuf:"bytes,3,opt,name=message_request,json=messageRequest,proto3,oneof"`
 }
 
+type ServerMessage_CancellationRequest struct {
+	// Notifies the worker that a previous request with the same ID has been cancelled,
+	// typically because its deadline was exceeded. The worker should abort processing.
+	CancellationRequest *CancellationRequest `protobuf:"bytes,4,opt,name=cancellation_request,json=cancellationRequest,proto3,oneof"`
+}
+
 func (*ServerMessage_RegistrationResponse) isServerMessage_Content() {}
 
 func (*ServerMessage_MessageRequest) isServerMessage_Content() {}
 
+func (*ServerMessage_CancellationRequest) isServerMessage_Content() {}
+
+// Notification that an in-flight request was cancelled by the server
+type CancellationRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// The reason the request was cancelled
+	Reason string `protobuf:"bytes,1,opt,name=reason,proto3" json:"reason,omitempty"`
+}
+
+func (x *CancellationRequest) Reset() {
+	*x = CancellationRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_nitric_proto_topics_v1_topics_proto_msgTypes[4]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *CancellationRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CancellationRequest) ProtoMessage() {}
+
+func (x *CancellationRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_nitric_proto_topics_v1_topics_proto_msgTypes[4]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CancellationRequest.ProtoReflect.Descriptor instead.
+func (*CancellationRequest) Descriptor() ([]byte, []int) {
+	return file_nitric_proto_topics_v1_topics_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *CancellationRequest) GetReason() string {
+	if x != nil {
+		return x.Reason
+	}
+	return ""
+}
+
 type RegistrationRequest struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
 	TopicName string `protobuf:"bytes,1,opt,name=topic_name,json=topicName,proto3" json:"topic_name,omitempty"`
+	// Only messages with attributes matching the filter are delivered to the subscriber, all messages are delivered when not set
+	Filter *AttributeFilter `protobuf:"bytes,2,opt,name=filter,proto3" json:"filter,omitempty"`
+	// Controls redelivery of messages the subscriber fails to process, the provider defaults apply when not set
+	RetryPolicy *SubscriptionRetryPolicy `protobuf:"bytes,3,opt,name=retry_policy,json=retryPolicy,proto3" json:"retry_policy,omitempty"`
 }
 
 func (x *RegistrationRequest) Reset() {
 	*x = RegistrationRequest{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_nitric_proto_topics_v1_topics_proto_msgTypes[4]
+		mi := &file_nitric_proto_topics_v1_topics_proto_msgTypes[5]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -335,7 +422,7 @@ func (x *RegistrationRequest) String() string {
 func (*RegistrationRequest) ProtoMessage() {}
 
 func (x *RegistrationRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_nitric_proto_topics_v1_topics_proto_msgTypes[4]
+	mi := &file_nitric_proto_topics_v1_topics_proto_msgTypes[5]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -348,7 +435,7 @@ func (x *RegistrationRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use RegistrationRequest.ProtoReflect.Descriptor instead.
 func (*RegistrationRequest) Descriptor() ([]byte, []int) {
-	return file_nitric_proto_topics_v1_topics_proto_rawDescGZIP(), []int{4}
+	return file_nitric_proto_topics_v1_topics_proto_rawDescGZIP(), []int{5}
 }
 
 func (x *RegistrationRequest) GetTopicName() string {
@@ -358,6 +445,20 @@ func (x *RegistrationRequest) GetTopicName() string {
 	return ""
 }
 
+func (x *RegistrationRequest) GetFilter() *AttributeFilter {
+	if x != nil {
+		return x.Filter
+	}
+	return nil
+}
+
+func (x *RegistrationRequest) GetRetryPolicy() *SubscriptionRetryPolicy {
+	if x != nil {
+		return x.RetryPolicy
+	}
+	return nil
+}
+
 type RegistrationResponse struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
@@ -367,7 +468,7 @@ type RegistrationResponse struct {
 func (x *RegistrationResponse) Reset() {
 	*x = RegistrationResponse{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_nitric_proto_topics_v1_topics_proto_msgTypes[5]
+		mi := &file_nitric_proto_topics_v1_topics_proto_msgTypes[6]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -380,7 +481,7 @@ func (x *RegistrationResponse) String() string {
 func (*RegistrationResponse) ProtoMessage() {}
 
 func (x *RegistrationResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_nitric_proto_topics_v1_topics_proto_msgTypes[5]
+	mi := &file_nitric_proto_topics_v1_topics_proto_msgTypes[6]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -393,7 +494,7 @@ func (x *RegistrationResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use RegistrationResponse.ProtoReflect.Descriptor instead.
 func (*RegistrationResponse) Descriptor() ([]byte, []int) {
-	return file_nitric_proto_topics_v1_topics_proto_rawDescGZIP(), []int{5}
+	return file_nitric_proto_topics_v1_topics_proto_rawDescGZIP(), []int{6}
 }
 
 type TopicMessage struct {
@@ -406,13 +507,16 @@ type TopicMessage struct {
 	// Types that are assignable to Content:
 	//
 	//	*TopicMessage_StructPayload
+	//	*TopicMessage_BytesPayload
 	Content isTopicMessage_Content `protobuf_oneof:"content"`
+	// Key-value metadata delivered along with the message, subscriptions can filter messages by their attributes
+	Attributes map[string]string `protobuf:"bytes,2,rep,name=attributes,proto3" json:"attributes,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
 }
 
 func (x *TopicMessage) Reset() {
 	*x = TopicMessage{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_nitric_proto_topics_v1_topics_proto_msgTypes[6]
+		mi := &file_nitric_proto_topics_v1_topics_proto_msgTypes[7]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -425,7 +529,7 @@ func (x *TopicMessage) String() string {
 func (*TopicMessage) ProtoMessage() {}
 
 func (x *TopicMessage) ProtoReflect() protoreflect.Message {
-	mi := &file_nitric_proto_topics_v1_topics_proto_msgTypes[6]
+	mi := &file_nitric_proto_topics_v1_topics_proto_msgTypes[7]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -438,7 +542,7 @@ func (x *TopicMessage) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use TopicMessage.ProtoReflect.Descriptor instead.
 func (*TopicMessage) Descriptor() ([]byte, []int) {
-	return file_nitric_proto_topics_v1_topics_proto_rawDescGZIP(), []int{6}
+	return file_nitric_proto_topics_v1_topics_proto_rawDescGZIP(), []int{7}
 }
 
 func (m *TopicMessage) GetContent() isTopicMessage_Content {
@@ -455,6 +559,20 @@ func (x *TopicMessage) GetStructPayload() *structpb.Struct {
 	return nil
 }
 
+func (x *TopicMessage) GetBytesPayload() *BytesPayload {
+	if x, ok := x.GetContent().(*TopicMessage_BytesPayload); ok {
+		return x.BytesPayload
+	}
+	return nil
+}
+
+func (x *TopicMessage) GetAttributes() map[string]string {
+	if x != nil {
+		return x.Attributes
+	}
+	return nil
+}
+
 type isTopicMessage_Content interface {
 	isTopicMessage_Content()
 }
@@ -463,39 +581,43 @@ type TopicMessage_StructPayload struct {
 	StructPayload *structpb.Struct `protobuf:"bytes,1,opt,name=struct_payload,json=structPayload,proto3,oneof"`
 }
 
+type TopicMessage_BytesPayload struct {
+	// field number matches nitric.proto.queues.v1.QueueMessage, so dead-lettered topic messages can be decoded as queue messages
+	BytesPayload *BytesPayload `protobuf:"bytes,5,opt,name=bytes_payload,json=bytesPayload,proto3,oneof"`
+}
+
 func (*TopicMessage_StructPayload) isTopicMessage_Content() {}
 
-// Request to publish a message to a topic
-type TopicPublishRequest struct {
+func (*TopicMessage_BytesPayload) isTopicMessage_Content() {}
+
+// Raw message contents, for binary data or data that can't be represented as JSON without losing precision
+type BytesPayload struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	// The name of the topic to publish the topic to
-	TopicName string `protobuf:"bytes,1,opt,name=topic_name,json=topicName,proto3" json:"topic_name,omitempty"`
-	// The message to be published
-	Message *TopicMessage `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
-	// An optional delay specified in seconds (minimum 10 seconds)
-	Delay *durationpb.Duration `protobuf:"bytes,3,opt,name=delay,proto3" json:"delay,omitempty"`
+	Data []byte `protobuf:"bytes,1,opt,name=data,proto3" json:"data,omitempty"`
+	// The MIME type of the data, e.g. application/octet-stream
+	ContentType string `protobuf:"bytes,2,opt,name=content_type,json=contentType,proto3" json:"content_type,omitempty"`
 }
 
-func (x *TopicPublishRequest) Reset() {
-	*x = TopicPublishRequest{}
+func (x *BytesPayload) Reset() {
+	*x = BytesPayload{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_nitric_proto_topics_v1_topics_proto_msgTypes[7]
+		mi := &file_nitric_proto_topics_v1_topics_proto_msgTypes[8]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *TopicPublishRequest) String() string {
+func (x *BytesPayload) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*TopicPublishRequest) ProtoMessage() {}
+func (*BytesPayload) ProtoMessage() {}
 
-func (x *TopicPublishRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_nitric_proto_topics_v1_topics_proto_msgTypes[7]
+func (x *BytesPayload) ProtoReflect() protoreflect.Message {
+	mi := &file_nitric_proto_topics_v1_topics_proto_msgTypes[8]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -506,56 +628,107 @@ func (x *TopicPublishRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use TopicPublishRequest.ProtoReflect.Descriptor instead.
-func (*TopicPublishRequest) Descriptor() ([]byte, []int) {
-	return file_nitric_proto_topics_v1_topics_proto_rawDescGZIP(), []int{7}
+// Deprecated: Use BytesPayload.ProtoReflect.Descriptor instead.
+func (*BytesPayload) Descriptor() ([]byte, []int) {
+	return file_nitric_proto_topics_v1_topics_proto_rawDescGZIP(), []int{8}
 }
 
-func (x *TopicPublishRequest) GetTopicName() string {
+func (x *BytesPayload) GetData() []byte {
 	if x != nil {
-		return x.TopicName
+		return x.Data
 	}
-	return ""
+	return nil
 }
 
-func (x *TopicPublishRequest) GetMessage() *TopicMessage {
+func (x *BytesPayload) GetContentType() string {
 	if x != nil {
-		return x.Message
+		return x.ContentType
 	}
-	return nil
+	return ""
 }
 
-func (x *TopicPublishRequest) GetDelay() *durationpb.Duration {
+// Filter expression matching messages by their attributes, a message matches when it satisfies every condition
+type AttributeFilter struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Conditions []*AttributeCondition `protobuf:"bytes,1,rep,name=conditions,proto3" json:"conditions,omitempty"`
+}
+
+func (x *AttributeFilter) Reset() {
+	*x = AttributeFilter{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_nitric_proto_topics_v1_topics_proto_msgTypes[9]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *AttributeFilter) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AttributeFilter) ProtoMessage() {}
+
+func (x *AttributeFilter) ProtoReflect() protoreflect.Message {
+	mi := &file_nitric_proto_topics_v1_topics_proto_msgTypes[9]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AttributeFilter.ProtoReflect.Descriptor instead.
+func (*AttributeFilter) Descriptor() ([]byte, []int) {
+	return file_nitric_proto_topics_v1_topics_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *AttributeFilter) GetConditions() []*AttributeCondition {
 	if x != nil {
-		return x.Delay
+		return x.Conditions
 	}
 	return nil
 }
 
-// Result of publishing an topic
-type TopicPublishResponse struct {
+// A condition on the value of a single message attribute
+type AttributeCondition struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
+
+	// The key of the attribute, each key may only appear in one condition of a filter
+	Key string `protobuf:"bytes,1,opt,name=key,proto3" json:"key,omitempty"`
+	// Types that are assignable to Match:
+	//
+	//	*AttributeCondition_AnyOf
+	//	*AttributeCondition_NoneOf
+	//	*AttributeCondition_Prefix
+	//	*AttributeCondition_Exists
+	Match isAttributeCondition_Match `protobuf_oneof:"match"`
 }
 
-func (x *TopicPublishResponse) Reset() {
-	*x = TopicPublishResponse{}
+func (x *AttributeCondition) Reset() {
+	*x = AttributeCondition{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_nitric_proto_topics_v1_topics_proto_msgTypes[8]
+		mi := &file_nitric_proto_topics_v1_topics_proto_msgTypes[10]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *TopicPublishResponse) String() string {
+func (x *AttributeCondition) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*TopicPublishResponse) ProtoMessage() {}
+func (*AttributeCondition) ProtoMessage() {}
 
-func (x *TopicPublishResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_nitric_proto_topics_v1_topics_proto_msgTypes[8]
+func (x *AttributeCondition) ProtoReflect() protoreflect.Message {
+	mi := &file_nitric_proto_topics_v1_topics_proto_msgTypes[10]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -566,164 +739,812 @@ func (x *TopicPublishResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use TopicPublishResponse.ProtoReflect.Descriptor instead.
-func (*TopicPublishResponse) Descriptor() ([]byte, []int) {
-	return file_nitric_proto_topics_v1_topics_proto_rawDescGZIP(), []int{8}
+// Deprecated: Use AttributeCondition.ProtoReflect.Descriptor instead.
+func (*AttributeCondition) Descriptor() ([]byte, []int) {
+	return file_nitric_proto_topics_v1_topics_proto_rawDescGZIP(), []int{10}
 }
 
-var File_nitric_proto_topics_v1_topics_proto protoreflect.FileDescriptor
+func (x *AttributeCondition) GetKey() string {
+	if x != nil {
+		return x.Key
+	}
+	return ""
+}
 
-var file_nitric_proto_topics_v1_topics_proto_rawDesc = []byte{
-	0x0a, 0x23, 0x6e, 0x69, 0x74, 0x72, 0x69, 0x63, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2f, 0x74,
-	0x6f, 0x70, 0x69, 0x63, 0x73, 0x2f, 0x76, 0x31, 0x2f, 0x74, 0x6f, 0x70, 0x69, 0x63, 0x73, 0x2e,
-	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x12, 0x16, 0x6e, 0x69, 0x74, 0x72, 0x69, 0x63, 0x2e, 0x70, 0x72,
-	0x6f, 0x74, 0x6f, 0x2e, 0x74, 0x6f, 0x70, 0x69, 0x63, 0x73, 0x2e, 0x76, 0x31, 0x1a, 0x1e, 0x67,
-	0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2f, 0x64,
-	0x75, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x1a, 0x1c, 0x67,
-	0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2f, 0x73,
-	0x74, 0x72, 0x75, 0x63, 0x74, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x22, 0xe2, 0x01, 0x0a, 0x0d,
-	0x43, 0x6c, 0x69, 0x65, 0x6e, 0x74, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x12, 0x0e, 0x0a,
-	0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x02, 0x69, 0x64, 0x12, 0x60, 0x0a,
-	0x14, 0x72, 0x65, 0x67, 0x69, 0x73, 0x74, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x5f, 0x72, 0x65,
-	0x71, 0x75, 0x65, 0x73, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x2b, 0x2e, 0x6e, 0x69,
-	0x74, 0x72, 0x69, 0x63, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x74, 0x6f, 0x70, 0x69, 0x63,
-	0x73, 0x2e, 0x76, 0x31, 0x2e, 0x52, 0x65, 0x67, 0x69, 0x73, 0x74, 0x72, 0x61, 0x74, 0x69, 0x6f,
-	0x6e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x48, 0x00, 0x52, 0x13, 0x72, 0x65, 0x67, 0x69,
-	0x73, 0x74, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12,
-	0x54, 0x0a, 0x10, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x5f, 0x72, 0x65, 0x73, 0x70, 0x6f,
-	0x6e, 0x73, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x27, 0x2e, 0x6e, 0x69, 0x74, 0x72,
-	0x69, 0x63, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x74, 0x6f, 0x70, 0x69, 0x63, 0x73, 0x2e,
-	0x76, 0x31, 0x2e, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e,
-	0x73, 0x65, 0x48, 0x00, 0x52, 0x0f, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x52, 0x65, 0x73,
-	0x70, 0x6f, 0x6e, 0x73, 0x65, 0x42, 0x09, 0x0a, 0x07, 0x63, 0x6f, 0x6e, 0x74, 0x65, 0x6e, 0x74,
-	0x22, 0x6f, 0x0a, 0x0e, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65,
-	0x73, 0x74, 0x12, 0x1d, 0x0a, 0x0a, 0x74, 0x6f, 0x70, 0x69, 0x63, 0x5f, 0x6e, 0x61, 0x6d, 0x65,
-	0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x74, 0x6f, 0x70, 0x69, 0x63, 0x4e, 0x61, 0x6d,
-	0x65, 0x12, 0x3e, 0x0a, 0x07, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x18, 0x02, 0x20, 0x01,
-	0x28, 0x0b, 0x32, 0x24, 0x2e, 0x6e, 0x69, 0x74, 0x72, 0x69, 0x63, 0x2e, 0x70, 0x72, 0x6f, 0x74,
-	0x6f, 0x2e, 0x74, 0x6f, 0x70, 0x69, 0x63, 0x73, 0x2e, 0x76, 0x31, 0x2e, 0x54, 0x6f, 0x70, 0x69,
-	0x63, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x52, 0x07, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67,
-	0x65, 0x22, 0x2b, 0x0a, 0x0f, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x52, 0x65, 0x73, 0x70,
-	0x6f, 0x6e, 0x73, 0x65, 0x12, 0x18, 0x0a, 0x07, 0x73, 0x75, 0x63, 0x63, 0x65, 0x73, 0x73, 0x18,
-	0x01, 0x20, 0x01, 0x28, 0x08, 0x52, 0x07, 0x73, 0x75, 0x63, 0x63, 0x65, 0x73, 0x73, 0x22, 0xe2,
-	0x01, 0x0a, 0x0d, 0x53, 0x65, 0x72, 0x76, 0x65, 0x72, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65,
-	0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x02, 0x69, 0x64,
-	0x12, 0x63, 0x0a, 0x15, 0x72, 0x65, 0x67, 0x69, 0x73, 0x74, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e,
-	0x5f, 0x72, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32,
-	0x2c, 0x2e, 0x6e, 0x69, 0x74, 0x72, 0x69, 0x63, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x74,
-	0x6f, 0x70, 0x69, 0x63, 0x73, 0x2e, 0x76, 0x31, 0x2e, 0x52, 0x65, 0x67, 0x69, 0x73, 0x74, 0x72,
-	0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x48, 0x00, 0x52,
-	0x14, 0x72, 0x65, 0x67, 0x69, 0x73, 0x74, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x65, 0x73,
-	0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x51, 0x0a, 0x0f, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65,
-	0x5f, 0x72, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x26,
-	0x2e, 0x6e, 0x69, 0x74, 0x72, 0x69, 0x63, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x74, 0x6f,
-	0x70, 0x69, 0x63, 0x73, 0x2e, 0x76, 0x31, 0x2e, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x52,
-	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x48, 0x00, 0x52, 0x0e, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67,
-	0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x42, 0x09, 0x0a, 0x07, 0x63, 0x6f, 0x6e, 0x74,
-	0x65, 0x6e, 0x74, 0x22, 0x34, 0x0a, 0x13, 0x52, 0x65, 0x67, 0x69, 0x73, 0x74, 0x72, 0x61, 0x74,
-	0x69, 0x6f, 0x6e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x1d, 0x0a, 0x0a, 0x74, 0x6f,
-	0x70, 0x69, 0x63, 0x5f, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09,
-	0x74, 0x6f, 0x70, 0x69, 0x63, 0x4e, 0x61, 0x6d, 0x65, 0x22, 0x16, 0x0a, 0x14, 0x52, 0x65, 0x67,
-	0x69, 0x73, 0x74, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73,
-	0x65, 0x22, 0x5b, 0x0a, 0x0c, 0x54, 0x6f, 0x70, 0x69, 0x63, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67,
-	0x65, 0x12, 0x40, 0x0a, 0x0e, 0x73, 0x74, 0x72, 0x75, 0x63, 0x74, 0x5f, 0x70, 0x61, 0x79, 0x6c,
-	0x6f, 0x61, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x17, 0x2e, 0x67, 0x6f, 0x6f, 0x67,
-	0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x53, 0x74, 0x72, 0x75,
-	0x63, 0x74, 0x48, 0x00, 0x52, 0x0d, 0x73, 0x74, 0x72, 0x75, 0x63, 0x74, 0x50, 0x61, 0x79, 0x6c,
-	0x6f, 0x61, 0x64, 0x42, 0x09, 0x0a, 0x07, 0x63, 0x6f, 0x6e, 0x74, 0x65, 0x6e, 0x74, 0x22, 0xa5,
-	0x01, 0x0a, 0x13, 0x54, 0x6f, 0x70, 0x69, 0x63, 0x50, 0x75, 0x62, 0x6c, 0x69, 0x73, 0x68, 0x52,
-	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x1d, 0x0a, 0x0a, 0x74, 0x6f, 0x70, 0x69, 0x63, 0x5f,
-	0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x74, 0x6f, 0x70, 0x69,
-	0x63, 0x4e, 0x61, 0x6d, 0x65, 0x12, 0x3e, 0x0a, 0x07, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65,
-	0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x24, 0x2e, 0x6e, 0x69, 0x74, 0x72, 0x69, 0x63, 0x2e,
-	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x74, 0x6f, 0x70, 0x69, 0x63, 0x73, 0x2e, 0x76, 0x31, 0x2e,
-	0x54, 0x6f, 0x70, 0x69, 0x63, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x52, 0x07, 0x6d, 0x65,
-	0x73, 0x73, 0x61, 0x67, 0x65, 0x12, 0x2f, 0x0a, 0x05, 0x64, 0x65, 0x6c, 0x61, 0x79, 0x18, 0x03,
-	0x20, 0x01, 0x28, 0x0b, 0x32, 0x19, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72,
-	0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x44, 0x75, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52,
-	0x05, 0x64, 0x65, 0x6c, 0x61, 0x79, 0x22, 0x16, 0x0a, 0x14, 0x54, 0x6f, 0x70, 0x69, 0x63, 0x50,
-	0x75, 0x62, 0x6c, 0x69, 0x73, 0x68, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x32, 0x6e,
-	0x0a, 0x06, 0x54, 0x6f, 0x70, 0x69, 0x63, 0x73, 0x12, 0x64, 0x0a, 0x07, 0x50, 0x75, 0x62, 0x6c,
-	0x69, 0x73, 0x68, 0x12, 0x2b, 0x2e, 0x6e, 0x69, 0x74, 0x72, 0x69, 0x63, 0x2e, 0x70, 0x72, 0x6f,
-	0x74, 0x6f, 0x2e, 0x74, 0x6f, 0x70, 0x69, 0x63, 0x73, 0x2e, 0x76, 0x31, 0x2e, 0x54, 0x6f, 0x70,
-	0x69, 0x63, 0x50, 0x75, 0x62, 0x6c, 0x69, 0x73, 0x68, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
-	0x1a, 0x2c, 0x2e, 0x6e, 0x69, 0x74, 0x72, 0x69, 0x63, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e,
-	0x74, 0x6f, 0x70, 0x69, 0x63, 0x73, 0x2e, 0x76, 0x31, 0x2e, 0x54, 0x6f, 0x70, 0x69, 0x63, 0x50,
-	0x75, 0x62, 0x6c, 0x69, 0x73, 0x68, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x32, 0x6b,
-	0x0a, 0x0a, 0x53, 0x75, 0x62, 0x73, 0x63, 0x72, 0x69, 0x62, 0x65, 0x72, 0x12, 0x5d, 0x0a, 0x09,
-	0x53, 0x75, 0x62, 0x73, 0x63, 0x72, 0x69, 0x62, 0x65, 0x12, 0x25, 0x2e, 0x6e, 0x69, 0x74, 0x72,
-	0x69, 0x63, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x74, 0x6f, 0x70, 0x69, 0x63, 0x73, 0x2e,
-	0x76, 0x31, 0x2e, 0x43, 0x6c, 0x69, 0x65, 0x6e, 0x74, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65,
-	0x1a, 0x25, 0x2e, 0x6e, 0x69, 0x74, 0x72, 0x69, 0x63, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e,
-	0x74, 0x6f, 0x70, 0x69, 0x63, 0x73, 0x2e, 0x76, 0x31, 0x2e, 0x53, 0x65, 0x72, 0x76, 0x65, 0x72,
-	0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x28, 0x01, 0x30, 0x01, 0x42, 0x9e, 0x01, 0x0a, 0x19,
-	0x69, 0x6f, 0x2e, 0x6e, 0x69, 0x74, 0x72, 0x69, 0x63, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e,
-	0x74, 0x6f, 0x70, 0x69, 0x63, 0x73, 0x2e, 0x76, 0x31, 0x42, 0x0d, 0x54, 0x6f, 0x70, 0x69, 0x63,
-	0x73, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x50, 0x01, 0x5a, 0x3e, 0x67, 0x69, 0x74, 0x68,
-	0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x6e, 0x69, 0x74, 0x72, 0x69, 0x63, 0x74, 0x65, 0x63,
-	0x68, 0x2f, 0x6e, 0x69, 0x74, 0x72, 0x69, 0x63, 0x2f, 0x63, 0x6f, 0x72, 0x65, 0x2f, 0x70, 0x6b,
-	0x67, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2f, 0x74, 0x6f, 0x70, 0x69, 0x63, 0x73, 0x2f, 0x76,
-	0x31, 0x3b, 0x74, 0x6f, 0x70, 0x69, 0x63, 0x73, 0x70, 0x62, 0xaa, 0x02, 0x16, 0x4e, 0x69, 0x74,
-	0x72, 0x69, 0x63, 0x2e, 0x50, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x54, 0x6f, 0x70, 0x69, 0x63, 0x73,
-	0x2e, 0x76, 0x31, 0xca, 0x02, 0x16, 0x4e, 0x69, 0x74, 0x72, 0x69, 0x63, 0x5c, 0x50, 0x72, 0x6f,
-	0x74, 0x6f, 0x5c, 0x54, 0x6f, 0x70, 0x69, 0x63, 0x73, 0x5c, 0x56, 0x31, 0x62, 0x06, 0x70, 0x72,
-	0x6f, 0x74, 0x6f, 0x33,
+func (m *AttributeCondition) GetMatch() isAttributeCondition_Match {
+	if m != nil {
+		return m.Match
+	}
+	return nil
 }
 
-var (
-	file_nitric_proto_topics_v1_topics_proto_rawDescOnce sync.Once
-	file_nitric_proto_topics_v1_topics_proto_rawDescData = file_nitric_proto_topics_v1_topics_proto_rawDesc
-)
+func (x *AttributeCondition) GetAnyOf() *AttributeValues {
+	if x, ok := x.GetMatch().(*AttributeCondition_AnyOf); ok {
+		return x.AnyOf
+	}
+	return nil
+}
 
-func file_nitric_proto_topics_v1_topics_proto_rawDescGZIP() []byte {
-	file_nitric_proto_topics_v1_topics_proto_rawDescOnce.Do(func() {
-		file_nitric_proto_topics_v1_topics_proto_rawDescData = protoimpl.X.CompressGZIP(file_nitric_proto_topics_v1_topics_proto_rawDescData)
-	})
-	return file_nitric_proto_topics_v1_topics_proto_rawDescData
+func (x *AttributeCondition) GetNoneOf() *AttributeValues {
+	if x, ok := x.GetMatch().(*AttributeCondition_NoneOf); ok {
+		return x.NoneOf
+	}
+	return nil
 }
 
-var file_nitric_proto_topics_v1_topics_proto_msgTypes = make([]protoimpl.MessageInfo, 9)
-var file_nitric_proto_topics_v1_topics_proto_goTypes = []interface{}{
-	(*ClientMessage)(nil),        // 0: nitric.proto.topics.v1.ClientMessage
-	(*MessageRequest)(nil),       // 1: nitric.proto.topics.v1.MessageRequest
-	(*MessageResponse)(nil),      // 2: nitric.proto.topics.v1.MessageResponse
-	(*ServerMessage)(nil),        // 3: nitric.proto.topics.v1.ServerMessage
-	(*RegistrationRequest)(nil),  // 4: nitric.proto.topics.v1.RegistrationRequest
-	(*RegistrationResponse)(nil), // 5: nitric.proto.topics.v1.RegistrationResponse
-	(*TopicMessage)(nil),         // 6: nitric.proto.topics.v1.TopicMessage
-	(*TopicPublishRequest)(nil),  // 7: nitric.proto.topics.v1.TopicPublishRequest
-	(*TopicPublishResponse)(nil), // 8: nitric.proto.topics.v1.TopicPublishResponse
-	(*structpb.Struct)(nil),      // 9: google.protobuf.Struct
-	(*durationpb.Duration)(nil),  // 10: google.protobuf.Duration
+func (x *AttributeCondition) GetPrefix() string {
+	if x, ok := x.GetMatch().(*AttributeCondition_Prefix); ok {
+		return x.Prefix
+	}
+	return ""
 }
-var file_nitric_proto_topics_v1_topics_proto_depIdxs = []int32{
-	4,  // 0: nitric.proto.topics.v1.ClientMessage.registration_request:type_name -> nitric.proto.topics.v1.RegistrationRequest
-	2,  // 1: nitric.proto.topics.v1.ClientMessage.message_response:type_name -> nitric.proto.topics.v1.MessageResponse
-	6,  // 2: nitric.proto.topics.v1.MessageRequest.message:type_name -> nitric.proto.topics.v1.TopicMessage
-	5,  // 3: nitric.proto.topics.v1.ServerMessage.registration_response:type_name -> nitric.proto.topics.v1.RegistrationResponse
-	1,  // 4: nitric.proto.topics.v1.ServerMessage.message_request:type_name -> nitric.proto.topics.v1.MessageRequest
-	9,  // 5: nitric.proto.topics.v1.TopicMessage.struct_payload:type_name -> google.protobuf.Struct
-	6,  // 6: nitric.proto.topics.v1.TopicPublishRequest.message:type_name -> nitric.proto.topics.v1.TopicMessage
-	10, // 7: nitric.proto.topics.v1.TopicPublishRequest.delay:type_name -> google.protobuf.Duration
-	7,  // 8: nitric.proto.topics.v1.Topics.Publish:input_type -> nitric.proto.topics.v1.TopicPublishRequest
-	0,  // 9: nitric.proto.topics.v1.Subscriber.Subscribe:input_type -> nitric.proto.topics.v1.ClientMessage
-	8,  // 10: nitric.proto.topics.v1.Topics.Publish:output_type -> nitric.proto.topics.v1.TopicPublishResponse
-	3,  // 11: nitric.proto.topics.v1.Subscriber.Subscribe:output_type -> nitric.proto.topics.v1.ServerMessage
-	10, // [10:12] is the sub-list for method output_type
-	8,  // [8:10] is the sub-list for method input_type
-	8,  // [8:8] is the sub-list for extension type_name
-	8,  // [8:8] is the sub-list for extension extendee
-	0,  // [0:8] is the sub-list for field type_name
+
+func (x *AttributeCondition) GetExists() bool {
+	if x, ok := x.GetMatch().(*AttributeCondition_Exists); ok {
+		return x.Exists
+	}
+	return false
 }
 
-func init() { file_nitric_proto_topics_v1_topics_proto_init() }
-func file_nitric_proto_topics_v1_topics_proto_init() {
-	if File_nitric_proto_topics_v1_topics_proto != nil {
-		return
+type isAttributeCondition_Match interface {
+	isAttributeCondition_Match()
+}
+
+type AttributeCondition_AnyOf struct {
+	// The attribute is present and its value is one of the values
+	AnyOf *AttributeValues `protobuf:"bytes,2,opt,name=any_of,json=anyOf,proto3,oneof"`
+}
+
+type AttributeCondition_NoneOf struct {
+	// The attribute is present and its value is none of the values
+	NoneOf *AttributeValues `protobuf:"bytes,3,opt,name=none_of,json=noneOf,proto3,oneof"`
+}
+
+type AttributeCondition_Prefix struct {
+	// The attribute is present and its value starts with the prefix
+	Prefix string `protobuf:"bytes,4,opt,name=prefix,proto3,oneof"`
+}
+
+type AttributeCondition_Exists struct {
+	// The attribute is present when true, or absent when false
+	Exists bool `protobuf:"varint,5,opt,name=exists,proto3,oneof"`
+}
+
+func (*AttributeCondition_AnyOf) isAttributeCondition_Match() {}
+
+func (*AttributeCondition_NoneOf) isAttributeCondition_Match() {}
+
+func (*AttributeCondition_Prefix) isAttributeCondition_Match() {}
+
+func (*AttributeCondition_Exists) isAttributeCondition_Match() {}
+
+type AttributeValues struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Values []string `protobuf:"bytes,1,rep,name=values,proto3" json:"values,omitempty"`
+}
+
+func (x *AttributeValues) Reset() {
+	*x = AttributeValues{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_nitric_proto_topics_v1_topics_proto_msgTypes[11]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
 	}
-	if !protoimpl.UnsafeEnabled {
-		file_nitric_proto_topics_v1_topics_proto_msgTypes[0].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*ClientMessage); i {
+}
+
+func (x *AttributeValues) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AttributeValues) ProtoMessage() {}
+
+func (x *AttributeValues) ProtoReflect() protoreflect.Message {
+	mi := &file_nitric_proto_topics_v1_topics_proto_msgTypes[11]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AttributeValues.ProtoReflect.Descriptor instead.
+func (*AttributeValues) Descriptor() ([]byte, []int) {
+	return file_nitric_proto_topics_v1_topics_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *AttributeValues) GetValues() []string {
+	if x != nil {
+		return x.Values
+	}
+	return nil
+}
+
+// Redelivery settings for messages a subscriber fails to process
+type SubscriptionRetryPolicy struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Maximum number of times a message is delivered, including the first delivery, the provider default applies when 0
+	MaxAttempts int32 `protobuf:"varint,1,opt,name=max_attempts,json=maxAttempts,proto3" json:"max_attempts,omitempty"`
+	// Lower bound of the delay between redeliveries, not supported by every provider
+	MinBackoff *durationpb.Duration `protobuf:"bytes,2,opt,name=min_backoff,json=minBackoff,proto3" json:"min_backoff,omitempty"`
+	// Upper bound of the delay between redeliveries, not supported by every provider
+	MaxBackoff *durationpb.Duration `protobuf:"bytes,3,opt,name=max_backoff,json=maxBackoff,proto3" json:"max_backoff,omitempty"`
+	// Name of a queue declared by the application that messages are dead-lettered to once max attempts are exhausted.
+	// On aws and gcp messages are moved to the queue itself, Event Grid can only dead-letter to blob storage,
+	// so on azure messages are written to a blob container named after the queue instead
+	DeadLetterQueue string `protobuf:"bytes,4,opt,name=dead_letter_queue,json=deadLetterQueue,proto3" json:"dead_letter_queue,omitempty"`
+}
+
+func (x *SubscriptionRetryPolicy) Reset() {
+	*x = SubscriptionRetryPolicy{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_nitric_proto_topics_v1_topics_proto_msgTypes[12]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *SubscriptionRetryPolicy) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SubscriptionRetryPolicy) ProtoMessage() {}
+
+func (x *SubscriptionRetryPolicy) ProtoReflect() protoreflect.Message {
+	mi := &file_nitric_proto_topics_v1_topics_proto_msgTypes[12]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SubscriptionRetryPolicy.ProtoReflect.Descriptor instead.
+func (*SubscriptionRetryPolicy) Descriptor() ([]byte, []int) {
+	return file_nitric_proto_topics_v1_topics_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *SubscriptionRetryPolicy) GetMaxAttempts() int32 {
+	if x != nil {
+		return x.MaxAttempts
+	}
+	return 0
+}
+
+func (x *SubscriptionRetryPolicy) GetMinBackoff() *durationpb.Duration {
+	if x != nil {
+		return x.MinBackoff
+	}
+	return nil
+}
+
+func (x *SubscriptionRetryPolicy) GetMaxBackoff() *durationpb.Duration {
+	if x != nil {
+		return x.MaxBackoff
+	}
+	return nil
+}
+
+func (x *SubscriptionRetryPolicy) GetDeadLetterQueue() string {
+	if x != nil {
+		return x.DeadLetterQueue
+	}
+	return ""
+}
+
+// Request to publish a message to a topic
+type TopicPublishRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// The name of the topic to publish the topic to
+	TopicName string `protobuf:"bytes,1,opt,name=topic_name,json=topicName,proto3" json:"topic_name,omitempty"`
+	// The message to be published
+	Message *TopicMessage `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
+	// An optional delay specified in seconds (minimum 10 seconds)
+	Delay *durationpb.Duration `protobuf:"bytes,3,opt,name=delay,proto3" json:"delay,omitempty"`
+}
+
+func (x *TopicPublishRequest) Reset() {
+	*x = TopicPublishRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_nitric_proto_topics_v1_topics_proto_msgTypes[13]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *TopicPublishRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TopicPublishRequest) ProtoMessage() {}
+
+func (x *TopicPublishRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_nitric_proto_topics_v1_topics_proto_msgTypes[13]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TopicPublishRequest.ProtoReflect.Descriptor instead.
+func (*TopicPublishRequest) Descriptor() ([]byte, []int) {
+	return file_nitric_proto_topics_v1_topics_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *TopicPublishRequest) GetTopicName() string {
+	if x != nil {
+		return x.TopicName
+	}
+	return ""
+}
+
+func (x *TopicPublishRequest) GetMessage() *TopicMessage {
+	if x != nil {
+		return x.Message
+	}
+	return nil
+}
+
+func (x *TopicPublishRequest) GetDelay() *durationpb.Duration {
+	if x != nil {
+		return x.Delay
+	}
+	return nil
+}
+
+// Result of publishing an topic
+type TopicPublishResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// The provider assigned ID of the published message, empty for delayed messages that are assigned an ID once they're published
+	MessageId string `protobuf:"bytes,1,opt,name=message_id,json=messageId,proto3" json:"message_id,omitempty"`
+}
+
+func (x *TopicPublishResponse) Reset() {
+	*x = TopicPublishResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_nitric_proto_topics_v1_topics_proto_msgTypes[14]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *TopicPublishResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TopicPublishResponse) ProtoMessage() {}
+
+func (x *TopicPublishResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_nitric_proto_topics_v1_topics_proto_msgTypes[14]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TopicPublishResponse.ProtoReflect.Descriptor instead.
+func (*TopicPublishResponse) Descriptor() ([]byte, []int) {
+	return file_nitric_proto_topics_v1_topics_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *TopicPublishResponse) GetMessageId() string {
+	if x != nil {
+		return x.MessageId
+	}
+	return ""
+}
+
+// Request to publish a batch of messages to a topic
+type TopicPublishBatchRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// The name of the topic to publish the messages to
+	TopicName string `protobuf:"bytes,1,opt,name=topic_name,json=topicName,proto3" json:"topic_name,omitempty"`
+	// The messages to be published
+	Messages []*TopicMessage `protobuf:"bytes,2,rep,name=messages,proto3" json:"messages,omitempty"`
+}
+
+func (x *TopicPublishBatchRequest) Reset() {
+	*x = TopicPublishBatchRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_nitric_proto_topics_v1_topics_proto_msgTypes[15]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *TopicPublishBatchRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TopicPublishBatchRequest) ProtoMessage() {}
+
+func (x *TopicPublishBatchRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_nitric_proto_topics_v1_topics_proto_msgTypes[15]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TopicPublishBatchRequest.ProtoReflect.Descriptor instead.
+func (*TopicPublishBatchRequest) Descriptor() ([]byte, []int) {
+	return file_nitric_proto_topics_v1_topics_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *TopicPublishBatchRequest) GetTopicName() string {
+	if x != nil {
+		return x.TopicName
+	}
+	return ""
+}
+
+func (x *TopicPublishBatchRequest) GetMessages() []*TopicMessage {
+	if x != nil {
+		return x.Messages
+	}
+	return nil
+}
+
+// Result of publishing a batch of messages to a topic
+type TopicPublishBatchResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// The result of publishing each message, in the same order as the requested messages
+	Results []*TopicPublishResult `protobuf:"bytes,1,rep,name=results,proto3" json:"results,omitempty"`
+}
+
+func (x *TopicPublishBatchResponse) Reset() {
+	*x = TopicPublishBatchResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_nitric_proto_topics_v1_topics_proto_msgTypes[16]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *TopicPublishBatchResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TopicPublishBatchResponse) ProtoMessage() {}
+
+func (x *TopicPublishBatchResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_nitric_proto_topics_v1_topics_proto_msgTypes[16]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TopicPublishBatchResponse.ProtoReflect.Descriptor instead.
+func (*TopicPublishBatchResponse) Descriptor() ([]byte, []int) {
+	return file_nitric_proto_topics_v1_topics_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *TopicPublishBatchResponse) GetResults() []*TopicPublishResult {
+	if x != nil {
+		return x.Results
+	}
+	return nil
+}
+
+// Result of publishing a single message of a batch
+type TopicPublishResult struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Types that are assignable to Result:
+	//
+	//	*TopicPublishResult_MessageId
+	//	*TopicPublishResult_Error
+	Result isTopicPublishResult_Result `protobuf_oneof:"result"`
+}
+
+func (x *TopicPublishResult) Reset() {
+	*x = TopicPublishResult{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_nitric_proto_topics_v1_topics_proto_msgTypes[17]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *TopicPublishResult) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TopicPublishResult) ProtoMessage() {}
+
+func (x *TopicPublishResult) ProtoReflect() protoreflect.Message {
+	mi := &file_nitric_proto_topics_v1_topics_proto_msgTypes[17]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TopicPublishResult.ProtoReflect.Descriptor instead.
+func (*TopicPublishResult) Descriptor() ([]byte, []int) {
+	return file_nitric_proto_topics_v1_topics_proto_rawDescGZIP(), []int{17}
+}
+
+func (m *TopicPublishResult) GetResult() isTopicPublishResult_Result {
+	if m != nil {
+		return m.Result
+	}
+	return nil
+}
+
+func (x *TopicPublishResult) GetMessageId() string {
+	if x, ok := x.GetResult().(*TopicPublishResult_MessageId); ok {
+		return x.MessageId
+	}
+	return ""
+}
+
+func (x *TopicPublishResult) GetError() string {
+	if x, ok := x.GetResult().(*TopicPublishResult_Error); ok {
+		return x.Error
+	}
+	return ""
+}
+
+type isTopicPublishResult_Result interface {
+	isTopicPublishResult_Result()
+}
+
+type TopicPublishResult_MessageId struct {
+	// The provider assigned ID of the published message
+	MessageId string `protobuf:"bytes,1,opt,name=message_id,json=messageId,proto3,oneof"`
+}
+
+type TopicPublishResult_Error struct {
+	// A description of why the message failed to be published
+	Error string `protobuf:"bytes,2,opt,name=error,proto3,oneof"`
+}
+
+func (*TopicPublishResult_MessageId) isTopicPublishResult_Result() {}
+
+func (*TopicPublishResult_Error) isTopicPublishResult_Result() {}
+
+var File_nitric_proto_topics_v1_topics_proto protoreflect.FileDescriptor
+
+var file_nitric_proto_topics_v1_topics_proto_rawDesc = []byte{
+	0x0a, 0x23, 0x6e, 0x69, 0x74, 0x72, 0x69, 0x63, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2f, 0x74,
+	0x6f, 0x70, 0x69, 0x63, 0x73, 0x2f, 0x76, 0x31, 0x2f, 0x74, 0x6f, 0x70, 0x69, 0x63, 0x73, 0x2e,
+	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x12, 0x16, 0x6e, 0x69, 0x74, 0x72, 0x69, 0x63, 0x2e, 0x70, 0x72,
+	0x6f, 0x74, 0x6f, 0x2e, 0x74, 0x6f, 0x70, 0x69, 0x63, 0x73, 0x2e, 0x76, 0x31, 0x1a, 0x1e, 0x67,
+	0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2f, 0x64,
+	0x75, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x1a, 0x1c, 0x67,
+	0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2f, 0x73,
+	0x74, 0x72, 0x75, 0x63, 0x74, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x22, 0xe2, 0x01, 0x0a, 0x0d,
+	0x43, 0x6c, 0x69, 0x65, 0x6e, 0x74, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x12, 0x0e, 0x0a,
+	0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x02, 0x69, 0x64, 0x12, 0x60, 0x0a,
+	0x14, 0x72, 0x65, 0x67, 0x69, 0x73, 0x74, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x5f, 0x72, 0x65,
+	0x71, 0x75, 0x65, 0x73, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x2b, 0x2e, 0x6e, 0x69,
+	0x74, 0x72, 0x69, 0x63, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x74, 0x6f, 0x70, 0x69, 0x63,
+	0x73, 0x2e, 0x76, 0x31, 0x2e, 0x52, 0x65, 0x67, 0x69, 0x73, 0x74, 0x72, 0x61, 0x74, 0x69, 0x6f,
+	0x6e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x48, 0x00, 0x52, 0x13, 0x72, 0x65, 0x67, 0x69,
+	0x73, 0x74, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12,
+	0x54, 0x0a, 0x10, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x5f, 0x72, 0x65, 0x73, 0x70, 0x6f,
+	0x6e, 0x73, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x27, 0x2e, 0x6e, 0x69, 0x74, 0x72,
+	0x69, 0x63, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x74, 0x6f, 0x70, 0x69, 0x63, 0x73, 0x2e,
+	0x76, 0x31, 0x2e, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e,
+	0x73, 0x65, 0x48, 0x00, 0x52, 0x0f, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x52, 0x65, 0x73,
+	0x70, 0x6f, 0x6e, 0x73, 0x65, 0x42, 0x09, 0x0a, 0x07, 0x63, 0x6f, 0x6e, 0x74, 0x65, 0x6e, 0x74,
+	0x22, 0x9a, 0x01, 0x0a, 0x0e, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x52, 0x65, 0x71, 0x75,
+	0x65, 0x73, 0x74, 0x12, 0x1d, 0x0a, 0x0a, 0x74, 0x6f, 0x70, 0x69, 0x63, 0x5f, 0x6e, 0x61, 0x6d,
+	0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x74, 0x6f, 0x70, 0x69, 0x63, 0x4e, 0x61,
+	0x6d, 0x65, 0x12, 0x3e, 0x0a, 0x07, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x18, 0x02, 0x20,
+	0x01, 0x28, 0x0b, 0x32, 0x24, 0x2e, 0x6e, 0x69, 0x74, 0x72, 0x69, 0x63, 0x2e, 0x70, 0x72, 0x6f,
+	0x74, 0x6f, 0x2e, 0x74, 0x6f, 0x70, 0x69, 0x63, 0x73, 0x2e, 0x76, 0x31, 0x2e, 0x54, 0x6f, 0x70,
+	0x69, 0x63, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x52, 0x07, 0x6d, 0x65, 0x73, 0x73, 0x61,
+	0x67, 0x65, 0x12, 0x29, 0x0a, 0x10, 0x64, 0x65, 0x6c, 0x69, 0x76, 0x65, 0x72, 0x79, 0x5f, 0x61,
+	0x74, 0x74, 0x65, 0x6d, 0x70, 0x74, 0x18, 0x03, 0x20, 0x01, 0x28, 0x05, 0x52, 0x0f, 0x64, 0x65,
+	0x6c, 0x69, 0x76, 0x65, 0x72, 0x79, 0x41, 0x74, 0x74, 0x65, 0x6d, 0x70, 0x74, 0x22, 0x2b, 0x0a,
+	0x0f, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65,
+	0x12, 0x18, 0x0a, 0x07, 0x73, 0x75, 0x63, 0x63, 0x65, 0x73, 0x73, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x08, 0x52, 0x07, 0x73, 0x75, 0x63, 0x63, 0x65, 0x73, 0x73, 0x22, 0xe3, 0x03, 0x0a, 0x0d, 0x53,
+	0x65, 0x72, 0x76, 0x65, 0x72, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x12, 0x0e, 0x0a, 0x02,
+	0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x02, 0x69, 0x64, 0x12, 0x63, 0x0a, 0x15,
+	0x72, 0x65, 0x67, 0x69, 0x73, 0x74, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x5f, 0x72, 0x65, 0x73,
+	0x70, 0x6f, 0x6e, 0x73, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x2c, 0x2e, 0x6e, 0x69,
+	0x74, 0x72, 0x69, 0x63, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x74, 0x6f, 0x70, 0x69, 0x63,
+	0x73, 0x2e, 0x76, 0x31, 0x2e, 0x52, 0x65, 0x67, 0x69, 0x73, 0x74, 0x72, 0x61, 0x74, 0x69, 0x6f,
+	0x6e, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x48, 0x00, 0x52, 0x14, 0x72, 0x65, 0x67,
+	0x69, 0x73, 0x74, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73,
+	0x65, 0x12, 0x51, 0x0a, 0x0f, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x5f, 0x72, 0x65, 0x71,
+	0x75, 0x65, 0x73, 0x74, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x26, 0x2e, 0x6e, 0x69, 0x74,
+	0x72, 0x69, 0x63, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x74, 0x6f, 0x70, 0x69, 0x63, 0x73,
+	0x2e, 0x76, 0x31, 0x2e, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65,
+	0x73, 0x74, 0x48, 0x00, 0x52, 0x0e, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x52, 0x65, 0x71,
+	0x75, 0x65, 0x73, 0x74, 0x12, 0x60, 0x0a, 0x14, 0x63, 0x61, 0x6e, 0x63, 0x65, 0x6c, 0x6c, 0x61,
+	0x74, 0x69, 0x6f, 0x6e, 0x5f, 0x72, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x18, 0x04, 0x20, 0x01,
+	0x28, 0x0b, 0x32, 0x2b, 0x2e, 0x6e, 0x69, 0x74, 0x72, 0x69, 0x63, 0x2e, 0x70, 0x72, 0x6f, 0x74,
+	0x6f, 0x2e, 0x74, 0x6f, 0x70, 0x69, 0x63, 0x73, 0x2e, 0x76, 0x31, 0x2e, 0x43, 0x61, 0x6e, 0x63,
+	0x65, 0x6c, 0x6c, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x48,
+	0x00, 0x52, 0x13, 0x63, 0x61, 0x6e, 0x63, 0x65, 0x6c, 0x6c, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52,
+	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x5c, 0x0a, 0x0d, 0x74, 0x72, 0x61, 0x63, 0x65, 0x5f,
+	0x63, 0x6f, 0x6e, 0x74, 0x65, 0x78, 0x74, 0x18, 0x0a, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x37, 0x2e,
+	0x6e, 0x69, 0x74, 0x72, 0x69, 0x63, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x74, 0x6f, 0x70,
+	0x69, 0x63, 0x73, 0x2e, 0x76, 0x31, 0x2e, 0x53, 0x65, 0x72, 0x76, 0x65, 0x72, 0x4d, 0x65, 0x73,
+	0x73, 0x61, 0x67, 0x65, 0x2e, 0x54, 0x72, 0x61, 0x63, 0x65, 0x43, 0x6f, 0x6e, 0x74, 0x65, 0x78,
+	0x74, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x52, 0x0c, 0x74, 0x72, 0x61, 0x63, 0x65, 0x43, 0x6f, 0x6e,
+	0x74, 0x65, 0x78, 0x74, 0x1a, 0x3f, 0x0a, 0x11, 0x54, 0x72, 0x61, 0x63, 0x65, 0x43, 0x6f, 0x6e,
+	0x74, 0x65, 0x78, 0x74, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x14, 0x0a, 0x05, 0x76,
+	0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75,
+	0x65, 0x3a, 0x02, 0x38, 0x01, 0x42, 0x09, 0x0a, 0x07, 0x63, 0x6f, 0x6e, 0x74, 0x65, 0x6e, 0x74,
+	0x22, 0x2d, 0x0a, 0x13, 0x43, 0x61, 0x6e, 0x63, 0x65, 0x6c, 0x6c, 0x61, 0x74, 0x69, 0x6f, 0x6e,
+	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x16, 0x0a, 0x06, 0x72, 0x65, 0x61, 0x73, 0x6f,
+	0x6e, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x72, 0x65, 0x61, 0x73, 0x6f, 0x6e, 0x22,
+	0xc9, 0x01, 0x0a, 0x13, 0x52, 0x65, 0x67, 0x69, 0x73, 0x74, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e,
+	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x1d, 0x0a, 0x0a, 0x74, 0x6f, 0x70, 0x69, 0x63,
+	0x5f, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x74, 0x6f, 0x70,
+	0x69, 0x63, 0x4e, 0x61, 0x6d, 0x65, 0x12, 0x3f, 0x0a, 0x06, 0x66, 0x69, 0x6c, 0x74, 0x65, 0x72,
+	0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x27, 0x2e, 0x6e, 0x69, 0x74, 0x72, 0x69, 0x63, 0x2e,
+	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x74, 0x6f, 0x70, 0x69, 0x63, 0x73, 0x2e, 0x76, 0x31, 0x2e,
+	0x41, 0x74, 0x74, 0x72, 0x69, 0x62, 0x75, 0x74, 0x65, 0x46, 0x69, 0x6c, 0x74, 0x65, 0x72, 0x52,
+	0x06, 0x66, 0x69, 0x6c, 0x74, 0x65, 0x72, 0x12, 0x52, 0x0a, 0x0c, 0x72, 0x65, 0x74, 0x72, 0x79,
+	0x5f, 0x70, 0x6f, 0x6c, 0x69, 0x63, 0x79, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x2f, 0x2e,
+	0x6e, 0x69, 0x74, 0x72, 0x69, 0x63, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x74, 0x6f, 0x70,
+	0x69, 0x63, 0x73, 0x2e, 0x76, 0x31, 0x2e, 0x53, 0x75, 0x62, 0x73, 0x63, 0x72, 0x69, 0x70, 0x74,
+	0x69, 0x6f, 0x6e, 0x52, 0x65, 0x74, 0x72, 0x79, 0x50, 0x6f, 0x6c, 0x69, 0x63, 0x79, 0x52, 0x0b,
+	0x72, 0x65, 0x74, 0x72, 0x79, 0x50, 0x6f, 0x6c, 0x69, 0x63, 0x79, 0x22, 0x16, 0x0a, 0x14, 0x52,
+	0x65, 0x67, 0x69, 0x73, 0x74, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x65, 0x73, 0x70, 0x6f,
+	0x6e, 0x73, 0x65, 0x22, 0xbd, 0x02, 0x0a, 0x0c, 0x54, 0x6f, 0x70, 0x69, 0x63, 0x4d, 0x65, 0x73,
+	0x73, 0x61, 0x67, 0x65, 0x12, 0x40, 0x0a, 0x0e, 0x73, 0x74, 0x72, 0x75, 0x63, 0x74, 0x5f, 0x70,
+	0x61, 0x79, 0x6c, 0x6f, 0x61, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x17, 0x2e, 0x67,
+	0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x53,
+	0x74, 0x72, 0x75, 0x63, 0x74, 0x48, 0x00, 0x52, 0x0d, 0x73, 0x74, 0x72, 0x75, 0x63, 0x74, 0x50,
+	0x61, 0x79, 0x6c, 0x6f, 0x61, 0x64, 0x12, 0x4b, 0x0a, 0x0d, 0x62, 0x79, 0x74, 0x65, 0x73, 0x5f,
+	0x70, 0x61, 0x79, 0x6c, 0x6f, 0x61, 0x64, 0x18, 0x05, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x24, 0x2e,
+	0x6e, 0x69, 0x74, 0x72, 0x69, 0x63, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x74, 0x6f, 0x70,
+	0x69, 0x63, 0x73, 0x2e, 0x76, 0x31, 0x2e, 0x42, 0x79, 0x74, 0x65, 0x73, 0x50, 0x61, 0x79, 0x6c,
+	0x6f, 0x61, 0x64, 0x48, 0x00, 0x52, 0x0c, 0x62, 0x79, 0x74, 0x65, 0x73, 0x50, 0x61, 0x79, 0x6c,
+	0x6f, 0x61, 0x64, 0x12, 0x54, 0x0a, 0x0a, 0x61, 0x74, 0x74, 0x72, 0x69, 0x62, 0x75, 0x74, 0x65,
+	0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x34, 0x2e, 0x6e, 0x69, 0x74, 0x72, 0x69, 0x63,
+	0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x74, 0x6f, 0x70, 0x69, 0x63, 0x73, 0x2e, 0x76, 0x31,
+	0x2e, 0x54, 0x6f, 0x70, 0x69, 0x63, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x2e, 0x41, 0x74,
+	0x74, 0x72, 0x69, 0x62, 0x75, 0x74, 0x65, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x52, 0x0a, 0x61,
+	0x74, 0x74, 0x72, 0x69, 0x62, 0x75, 0x74, 0x65, 0x73, 0x1a, 0x3d, 0x0a, 0x0f, 0x41, 0x74, 0x74,
+	0x72, 0x69, 0x62, 0x75, 0x74, 0x65, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x10, 0x0a, 0x03,
+	0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x14,
+	0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x76,
+	0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38, 0x01, 0x42, 0x09, 0x0a, 0x07, 0x63, 0x6f, 0x6e, 0x74,
+	0x65, 0x6e, 0x74, 0x22, 0x45, 0x0a, 0x0c, 0x42, 0x79, 0x74, 0x65, 0x73, 0x50, 0x61, 0x79, 0x6c,
+	0x6f, 0x61, 0x64, 0x12, 0x12, 0x0a, 0x04, 0x64, 0x61, 0x74, 0x61, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x0c, 0x52, 0x04, 0x64, 0x61, 0x74, 0x61, 0x12, 0x21, 0x0a, 0x0c, 0x63, 0x6f, 0x6e, 0x74, 0x65,
+	0x6e, 0x74, 0x5f, 0x74, 0x79, 0x70, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0b, 0x63,
+	0x6f, 0x6e, 0x74, 0x65, 0x6e, 0x74, 0x54, 0x79, 0x70, 0x65, 0x22, 0x5d, 0x0a, 0x0f, 0x41, 0x74,
+	0x74, 0x72, 0x69, 0x62, 0x75, 0x74, 0x65, 0x46, 0x69, 0x6c, 0x74, 0x65, 0x72, 0x12, 0x4a, 0x0a,
+	0x0a, 0x63, 0x6f, 0x6e, 0x64, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28,
+	0x0b, 0x32, 0x2a, 0x2e, 0x6e, 0x69, 0x74, 0x72, 0x69, 0x63, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f,
+	0x2e, 0x74, 0x6f, 0x70, 0x69, 0x63, 0x73, 0x2e, 0x76, 0x31, 0x2e, 0x41, 0x74, 0x74, 0x72, 0x69,
+	0x62, 0x75, 0x74, 0x65, 0x43, 0x6f, 0x6e, 0x64, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x0a, 0x63,
+	0x6f, 0x6e, 0x64, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x22, 0xe9, 0x01, 0x0a, 0x12, 0x41, 0x74,
+	0x74, 0x72, 0x69, 0x62, 0x75, 0x74, 0x65, 0x43, 0x6f, 0x6e, 0x64, 0x69, 0x74, 0x69, 0x6f, 0x6e,
+	0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6b,
+	0x65, 0x79, 0x12, 0x40, 0x0a, 0x06, 0x61, 0x6e, 0x79, 0x5f, 0x6f, 0x66, 0x18, 0x02, 0x20, 0x01,
+	0x28, 0x0b, 0x32, 0x27, 0x2e, 0x6e, 0x69, 0x74, 0x72, 0x69, 0x63, 0x2e, 0x70, 0x72, 0x6f, 0x74,
+	0x6f, 0x2e, 0x74, 0x6f, 0x70, 0x69, 0x63, 0x73, 0x2e, 0x76, 0x31, 0x2e, 0x41, 0x74, 0x74, 0x72,
+	0x69, 0x62, 0x75, 0x74, 0x65, 0x56, 0x61, 0x6c, 0x75, 0x65, 0x73, 0x48, 0x00, 0x52, 0x05, 0x61,
+	0x6e, 0x79, 0x4f, 0x66, 0x12, 0x42, 0x0a, 0x07, 0x6e, 0x6f, 0x6e, 0x65, 0x5f, 0x6f, 0x66, 0x18,
+	0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x27, 0x2e, 0x6e, 0x69, 0x74, 0x72, 0x69, 0x63, 0x2e, 0x70,
+	0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x74, 0x6f, 0x70, 0x69, 0x63, 0x73, 0x2e, 0x76, 0x31, 0x2e, 0x41,
+	0x74, 0x74, 0x72, 0x69, 0x62, 0x75, 0x74, 0x65, 0x56, 0x61, 0x6c, 0x75, 0x65, 0x73, 0x48, 0x00,
+	0x52, 0x06, 0x6e, 0x6f, 0x6e, 0x65, 0x4f, 0x66, 0x12, 0x18, 0x0a, 0x06, 0x70, 0x72, 0x65, 0x66,
+	0x69, 0x78, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x48, 0x00, 0x52, 0x06, 0x70, 0x72, 0x65, 0x66,
+	0x69, 0x78, 0x12, 0x18, 0x0a, 0x06, 0x65, 0x78, 0x69, 0x73, 0x74, 0x73, 0x18, 0x05, 0x20, 0x01,
+	0x28, 0x08, 0x48, 0x00, 0x52, 0x06, 0x65, 0x78, 0x69, 0x73, 0x74, 0x73, 0x42, 0x07, 0x0a, 0x05,
+	0x6d, 0x61, 0x74, 0x63, 0x68, 0x22, 0x29, 0x0a, 0x0f, 0x41, 0x74, 0x74, 0x72, 0x69, 0x62, 0x75,
+	0x74, 0x65, 0x56, 0x61, 0x6c, 0x75, 0x65, 0x73, 0x12, 0x16, 0x0a, 0x06, 0x76, 0x61, 0x6c, 0x75,
+	0x65, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x09, 0x52, 0x06, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x73,
+	0x22, 0xe0, 0x01, 0x0a, 0x17, 0x53, 0x75, 0x62, 0x73, 0x63, 0x72, 0x69, 0x70, 0x74, 0x69, 0x6f,
+	0x6e, 0x52, 0x65, 0x74, 0x72, 0x79, 0x50, 0x6f, 0x6c, 0x69, 0x63, 0x79, 0x12, 0x21, 0x0a, 0x0c,
+	0x6d, 0x61, 0x78, 0x5f, 0x61, 0x74, 0x74, 0x65, 0x6d, 0x70, 0x74, 0x73, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x05, 0x52, 0x0b, 0x6d, 0x61, 0x78, 0x41, 0x74, 0x74, 0x65, 0x6d, 0x70, 0x74, 0x73, 0x12,
+	0x3a, 0x0a, 0x0b, 0x6d, 0x69, 0x6e, 0x5f, 0x62, 0x61, 0x63, 0x6b, 0x6f, 0x66, 0x66, 0x18, 0x02,
+	0x20, 0x01, 0x28, 0x0b, 0x32, 0x19, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72,
+	0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x44, 0x75, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52,
+	0x0a, 0x6d, 0x69, 0x6e, 0x42, 0x61, 0x63, 0x6b, 0x6f, 0x66, 0x66, 0x12, 0x3a, 0x0a, 0x0b, 0x6d,
+	0x61, 0x78, 0x5f, 0x62, 0x61, 0x63, 0x6b, 0x6f, 0x66, 0x66, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b,
+	0x32, 0x19, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62,
+	0x75, 0x66, 0x2e, 0x44, 0x75, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x0a, 0x6d, 0x61, 0x78,
+	0x42, 0x61, 0x63, 0x6b, 0x6f, 0x66, 0x66, 0x12, 0x2a, 0x0a, 0x11, 0x64, 0x65, 0x61, 0x64, 0x5f,
+	0x6c, 0x65, 0x74, 0x74, 0x65, 0x72, 0x5f, 0x71, 0x75, 0x65, 0x75, 0x65, 0x18, 0x04, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x0f, 0x64, 0x65, 0x61, 0x64, 0x4c, 0x65, 0x74, 0x74, 0x65, 0x72, 0x51, 0x75,
+	0x65, 0x75, 0x65, 0x22, 0xa5, 0x01, 0x0a, 0x13, 0x54, 0x6f, 0x70, 0x69, 0x63, 0x50, 0x75, 0x62,
+	0x6c, 0x69, 0x73, 0x68, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x1d, 0x0a, 0x0a, 0x74,
+	0x6f, 0x70, 0x69, 0x63, 0x5f, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x09, 0x74, 0x6f, 0x70, 0x69, 0x63, 0x4e, 0x61, 0x6d, 0x65, 0x12, 0x3e, 0x0a, 0x07, 0x6d, 0x65,
+	0x73, 0x73, 0x61, 0x67, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x24, 0x2e, 0x6e, 0x69,
+	0x74, 0x72, 0x69, 0x63, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x74, 0x6f, 0x70, 0x69, 0x63,
+	0x73, 0x2e, 0x76, 0x31, 0x2e, 0x54, 0x6f, 0x70, 0x69, 0x63, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67,
+	0x65, 0x52, 0x07, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x12, 0x2f, 0x0a, 0x05, 0x64, 0x65,
+	0x6c, 0x61, 0x79, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x19, 0x2e, 0x67, 0x6f, 0x6f, 0x67,
+	0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x44, 0x75, 0x72, 0x61,
+	0x74, 0x69, 0x6f, 0x6e, 0x52, 0x05, 0x64, 0x65, 0x6c, 0x61, 0x79, 0x22, 0x35, 0x0a, 0x14, 0x54,
+	0x6f, 0x70, 0x69, 0x63, 0x50, 0x75, 0x62, 0x6c, 0x69, 0x73, 0x68, 0x52, 0x65, 0x73, 0x70, 0x6f,
+	0x6e, 0x73, 0x65, 0x12, 0x1d, 0x0a, 0x0a, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x5f, 0x69,
+	0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65,
+	0x49, 0x64, 0x22, 0x7b, 0x0a, 0x18, 0x54, 0x6f, 0x70, 0x69, 0x63, 0x50, 0x75, 0x62, 0x6c, 0x69,
+	0x73, 0x68, 0x42, 0x61, 0x74, 0x63, 0x68, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x1d,
+	0x0a, 0x0a, 0x74, 0x6f, 0x70, 0x69, 0x63, 0x5f, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x09, 0x74, 0x6f, 0x70, 0x69, 0x63, 0x4e, 0x61, 0x6d, 0x65, 0x12, 0x40, 0x0a,
+	0x08, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x0b, 0x32,
+	0x24, 0x2e, 0x6e, 0x69, 0x74, 0x72, 0x69, 0x63, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x74,
+	0x6f, 0x70, 0x69, 0x63, 0x73, 0x2e, 0x76, 0x31, 0x2e, 0x54, 0x6f, 0x70, 0x69, 0x63, 0x4d, 0x65,
+	0x73, 0x73, 0x61, 0x67, 0x65, 0x52, 0x08, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x73, 0x22,
+	0x61, 0x0a, 0x19, 0x54, 0x6f, 0x70, 0x69, 0x63, 0x50, 0x75, 0x62, 0x6c, 0x69, 0x73, 0x68, 0x42,
+	0x61, 0x74, 0x63, 0x68, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x44, 0x0a, 0x07,
+	0x72, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x2a, 0x2e,
+	0x6e, 0x69, 0x74, 0x72, 0x69, 0x63, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x74, 0x6f, 0x70,
+	0x69, 0x63, 0x73, 0x2e, 0x76, 0x31, 0x2e, 0x54, 0x6f, 0x70, 0x69, 0x63, 0x50, 0x75, 0x62, 0x6c,
+	0x69, 0x73, 0x68, 0x52, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x52, 0x07, 0x72, 0x65, 0x73, 0x75, 0x6c,
+	0x74, 0x73, 0x22, 0x57, 0x0a, 0x12, 0x54, 0x6f, 0x70, 0x69, 0x63, 0x50, 0x75, 0x62, 0x6c, 0x69,
+	0x73, 0x68, 0x52, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x12, 0x1f, 0x0a, 0x0a, 0x6d, 0x65, 0x73, 0x73,
+	0x61, 0x67, 0x65, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x48, 0x00, 0x52, 0x09,
+	0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x49, 0x64, 0x12, 0x16, 0x0a, 0x05, 0x65, 0x72, 0x72,
+	0x6f, 0x72, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x48, 0x00, 0x52, 0x05, 0x65, 0x72, 0x72, 0x6f,
+	0x72, 0x42, 0x08, 0x0a, 0x06, 0x72, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x32, 0xe3, 0x01, 0x0a, 0x06,
+	0x54, 0x6f, 0x70, 0x69, 0x63, 0x73, 0x12, 0x64, 0x0a, 0x07, 0x50, 0x75, 0x62, 0x6c, 0x69, 0x73,
+	0x68, 0x12, 0x2b, 0x2e, 0x6e, 0x69, 0x74, 0x72, 0x69, 0x63, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f,
+	0x2e, 0x74, 0x6f, 0x70, 0x69, 0x63, 0x73, 0x2e, 0x76, 0x31, 0x2e, 0x54, 0x6f, 0x70, 0x69, 0x63,
+	0x50, 0x75, 0x62, 0x6c, 0x69, 0x73, 0x68, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x2c,
+	0x2e, 0x6e, 0x69, 0x74, 0x72, 0x69, 0x63, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x74, 0x6f,
+	0x70, 0x69, 0x63, 0x73, 0x2e, 0x76, 0x31, 0x2e, 0x54, 0x6f, 0x70, 0x69, 0x63, 0x50, 0x75, 0x62,
+	0x6c, 0x69, 0x73, 0x68, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x73, 0x0a, 0x0c,
+	0x50, 0x75, 0x62, 0x6c, 0x69, 0x73, 0x68, 0x42, 0x61, 0x74, 0x63, 0x68, 0x12, 0x30, 0x2e, 0x6e,
+	0x69, 0x74, 0x72, 0x69, 0x63, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x74, 0x6f, 0x70, 0x69,
+	0x63, 0x73, 0x2e, 0x76, 0x31, 0x2e, 0x54, 0x6f, 0x70, 0x69, 0x63, 0x50, 0x75, 0x62, 0x6c, 0x69,
+	0x73, 0x68, 0x42, 0x61, 0x74, 0x63, 0x68, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x31,
+	0x2e, 0x6e, 0x69, 0x74, 0x72, 0x69, 0x63, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x74, 0x6f,
+	0x70, 0x69, 0x63, 0x73, 0x2e, 0x76, 0x31, 0x2e, 0x54, 0x6f, 0x70, 0x69, 0x63, 0x50, 0x75, 0x62,
+	0x6c, 0x69, 0x73, 0x68, 0x42, 0x61, 0x74, 0x63, 0x68, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73,
+	0x65, 0x32, 0x6b, 0x0a, 0x0a, 0x53, 0x75, 0x62, 0x73, 0x63, 0x72, 0x69, 0x62, 0x65, 0x72, 0x12,
+	0x5d, 0x0a, 0x09, 0x53, 0x75, 0x62, 0x73, 0x63, 0x72, 0x69, 0x62, 0x65, 0x12, 0x25, 0x2e, 0x6e,
+	0x69, 0x74, 0x72, 0x69, 0x63, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x74, 0x6f, 0x70, 0x69,
+	0x63, 0x73, 0x2e, 0x76, 0x31, 0x2e, 0x43, 0x6c, 0x69, 0x65, 0x6e, 0x74, 0x4d, 0x65, 0x73, 0x73,
+	0x61, 0x67, 0x65, 0x1a, 0x25, 0x2e, 0x6e, 0x69, 0x74, 0x72, 0x69, 0x63, 0x2e, 0x70, 0x72, 0x6f,
+	0x74, 0x6f, 0x2e, 0x74, 0x6f, 0x70, 0x69, 0x63, 0x73, 0x2e, 0x76, 0x31, 0x2e, 0x53, 0x65, 0x72,
+	0x76, 0x65, 0x72, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x28, 0x01, 0x30, 0x01, 0x42, 0x9e,
+	0x01, 0x0a, 0x19, 0x69, 0x6f, 0x2e, 0x6e, 0x69, 0x74, 0x72, 0x69, 0x63, 0x2e, 0x70, 0x72, 0x6f,
+	0x74, 0x6f, 0x2e, 0x74, 0x6f, 0x70, 0x69, 0x63, 0x73, 0x2e, 0x76, 0x31, 0x42, 0x0d, 0x54, 0x6f,
+	0x70, 0x69, 0x63, 0x73, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x50, 0x01, 0x5a, 0x3e, 0x67,
+	0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x6e, 0x69, 0x74, 0x72, 0x69, 0x63,
+	0x74, 0x65, 0x63, 0x68, 0x2f, 0x6e, 0x69, 0x74, 0x72, 0x69, 0x63, 0x2f, 0x63, 0x6f, 0x72, 0x65,
+	0x2f, 0x70, 0x6b, 0x67, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2f, 0x74, 0x6f, 0x70, 0x69, 0x63,
+	0x73, 0x2f, 0x76, 0x31, 0x3b, 0x74, 0x6f, 0x70, 0x69, 0x63, 0x73, 0x70, 0x62, 0xaa, 0x02, 0x16,
+	0x4e, 0x69, 0x74, 0x72, 0x69, 0x63, 0x2e, 0x50, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x54, 0x6f, 0x70,
+	0x69, 0x63, 0x73, 0x2e, 0x76, 0x31, 0xca, 0x02, 0x16, 0x4e, 0x69, 0x74, 0x72, 0x69, 0x63, 0x5c,
+	0x50, 0x72, 0x6f, 0x74, 0x6f, 0x5c, 0x54, 0x6f, 0x70, 0x69, 0x63, 0x73, 0x5c, 0x56, 0x31, 0x62,
+	0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+}
+
+var (
+	file_nitric_proto_topics_v1_topics_proto_rawDescOnce sync.Once
+	file_nitric_proto_topics_v1_topics_proto_rawDescData = file_nitric_proto_topics_v1_topics_proto_rawDesc
+)
+
+func file_nitric_proto_topics_v1_topics_proto_rawDescGZIP() []byte {
+	file_nitric_proto_topics_v1_topics_proto_rawDescOnce.Do(func() {
+		file_nitric_proto_topics_v1_topics_proto_rawDescData = protoimpl.X.CompressGZIP(file_nitric_proto_topics_v1_topics_proto_rawDescData)
+	})
+	return file_nitric_proto_topics_v1_topics_proto_rawDescData
+}
+
+var file_nitric_proto_topics_v1_topics_proto_msgTypes = make([]protoimpl.MessageInfo, 20)
+var file_nitric_proto_topics_v1_topics_proto_goTypes = []interface{}{
+	(*ClientMessage)(nil),             // 0: nitric.proto.topics.v1.ClientMessage
+	(*MessageRequest)(nil),            // 1: nitric.proto.topics.v1.MessageRequest
+	(*MessageResponse)(nil),           // 2: nitric.proto.topics.v1.MessageResponse
+	(*ServerMessage)(nil),             // 3: nitric.proto.topics.v1.ServerMessage
+	(*CancellationRequest)(nil),       // 4: nitric.proto.topics.v1.CancellationRequest
+	(*RegistrationRequest)(nil),       // 5: nitric.proto.topics.v1.RegistrationRequest
+	(*RegistrationResponse)(nil),      // 6: nitric.proto.topics.v1.RegistrationResponse
+	(*TopicMessage)(nil),              // 7: nitric.proto.topics.v1.TopicMessage
+	(*BytesPayload)(nil),              // 8: nitric.proto.topics.v1.BytesPayload
+	(*AttributeFilter)(nil),           // 9: nitric.proto.topics.v1.AttributeFilter
+	(*AttributeCondition)(nil),        // 10: nitric.proto.topics.v1.AttributeCondition
+	(*AttributeValues)(nil),           // 11: nitric.proto.topics.v1.AttributeValues
+	(*SubscriptionRetryPolicy)(nil),   // 12: nitric.proto.topics.v1.SubscriptionRetryPolicy
+	(*TopicPublishRequest)(nil),       // 13: nitric.proto.topics.v1.TopicPublishRequest
+	(*TopicPublishResponse)(nil),      // 14: nitric.proto.topics.v1.TopicPublishResponse
+	(*TopicPublishBatchRequest)(nil),  // 15: nitric.proto.topics.v1.TopicPublishBatchRequest
+	(*TopicPublishBatchResponse)(nil), // 16: nitric.proto.topics.v1.TopicPublishBatchResponse
+	(*TopicPublishResult)(nil),        // 17: nitric.proto.topics.v1.TopicPublishResult
+	nil,                               // 18: nitric.proto.topics.v1.ServerMessage.TraceContextEntry
+	nil,                               // 19: nitric.proto.topics.v1.TopicMessage.AttributesEntry
+	(*structpb.Struct)(nil),           // 20: google.protobuf.Struct
+	(*durationpb.Duration)(nil),       // 21: google.protobuf.Duration
+}
+var file_nitric_proto_topics_v1_topics_proto_depIdxs = []int32{
+	5,  // 0: nitric.proto.topics.v1.ClientMessage.registration_request:type_name -> nitric.proto.topics.v1.RegistrationRequest
+	2,  // 1: nitric.proto.topics.v1.ClientMessage.message_response:type_name -> nitric.proto.topics.v1.MessageResponse
+	7,  // 2: nitric.proto.topics.v1.MessageRequest.message:type_name -> nitric.proto.topics.v1.TopicMessage
+	6,  // 3: nitric.proto.topics.v1.ServerMessage.registration_response:type_name -> nitric.proto.topics.v1.RegistrationResponse
+	1,  // 4: nitric.proto.topics.v1.ServerMessage.message_request:type_name -> nitric.proto.topics.v1.MessageRequest
+	4,  // 5: nitric.proto.topics.v1.ServerMessage.cancellation_request:type_name -> nitric.proto.topics.v1.CancellationRequest
+	18, // 6: nitric.proto.topics.v1.ServerMessage.trace_context:type_name -> nitric.proto.topics.v1.ServerMessage.TraceContextEntry
+	9,  // 7: nitric.proto.topics.v1.RegistrationRequest.filter:type_name -> nitric.proto.topics.v1.AttributeFilter
+	12, // 8: nitric.proto.topics.v1.RegistrationRequest.retry_policy:type_name -> nitric.proto.topics.v1.SubscriptionRetryPolicy
+	20, // 9: nitric.proto.topics.v1.TopicMessage.struct_payload:type_name -> google.protobuf.Struct
+	8,  // 10: nitric.proto.topics.v1.TopicMessage.bytes_payload:type_name -> nitric.proto.topics.v1.BytesPayload
+	19, // 11: nitric.proto.topics.v1.TopicMessage.attributes:type_name -> nitric.proto.topics.v1.TopicMessage.AttributesEntry
+	10, // 12: nitric.proto.topics.v1.AttributeFilter.conditions:type_name -> nitric.proto.topics.v1.AttributeCondition
+	11, // 13: nitric.proto.topics.v1.AttributeCondition.any_of:type_name -> nitric.proto.topics.v1.AttributeValues
+	11, // 14: nitric.proto.topics.v1.AttributeCondition.none_of:type_name -> nitric.proto.topics.v1.AttributeValues
+	21, // 15: nitric.proto.topics.v1.SubscriptionRetryPolicy.min_backoff:type_name -> google.protobuf.Duration
+	21, // 16: nitric.proto.topics.v1.SubscriptionRetryPolicy.max_backoff:type_name -> google.protobuf.Duration
+	7,  // 17: nitric.proto.topics.v1.TopicPublishRequest.message:type_name -> nitric.proto.topics.v1.TopicMessage
+	21, // 18: nitric.proto.topics.v1.TopicPublishRequest.delay:type_name -> google.protobuf.Duration
+	7,  // 19: nitric.proto.topics.v1.TopicPublishBatchRequest.messages:type_name -> nitric.proto.topics.v1.TopicMessage
+	17, // 20: nitric.proto.topics.v1.TopicPublishBatchResponse.results:type_name -> nitric.proto.topics.v1.TopicPublishResult
+	13, // 21: nitric.proto.topics.v1.Topics.Publish:input_type -> nitric.proto.topics.v1.TopicPublishRequest
+	15, // 22: nitric.proto.topics.v1.Topics.PublishBatch:input_type -> nitric.proto.topics.v1.TopicPublishBatchRequest
+	0,  // 23: nitric.proto.topics.v1.Subscriber.Subscribe:input_type -> nitric.proto.topics.v1.ClientMessage
+	14, // 24: nitric.proto.topics.v1.Topics.Publish:output_type -> nitric.proto.topics.v1.TopicPublishResponse
+	16, // 25: nitric.proto.topics.v1.Topics.PublishBatch:output_type -> nitric.proto.topics.v1.TopicPublishBatchResponse
+	3,  // 26: nitric.proto.topics.v1.Subscriber.Subscribe:output_type -> nitric.proto.topics.v1.ServerMessage
+	24, // [24:27] is the sub-list for method output_type
+	21, // [21:24] is the sub-list for method input_type
+	21, // [21:21] is the sub-list for extension type_name
+	21, // [21:21] is the sub-list for extension extendee
+	0,  // [0:21] is the sub-list for field type_name
+}
+
+func init() { file_nitric_proto_topics_v1_topics_proto_init() }
+func file_nitric_proto_topics_v1_topics_proto_init() {
+	if File_nitric_proto_topics_v1_topics_proto != nil {
+		return
+	}
+	if !protoimpl.UnsafeEnabled {
+		file_nitric_proto_topics_v1_topics_proto_msgTypes[0].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ClientMessage); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -771,7 +1592,7 @@ func file_nitric_proto_topics_v1_topics_proto_init() {
 			}
 		}
 		file_nitric_proto_topics_v1_topics_proto_msgTypes[4].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*RegistrationRequest); i {
+			switch v := v.(*CancellationRequest); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -783,7 +1604,7 @@ func file_nitric_proto_topics_v1_topics_proto_init() {
 			}
 		}
 		file_nitric_proto_topics_v1_topics_proto_msgTypes[5].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*RegistrationResponse); i {
+			switch v := v.(*RegistrationRequest); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -795,7 +1616,7 @@ func file_nitric_proto_topics_v1_topics_proto_init() {
 			}
 		}
 		file_nitric_proto_topics_v1_topics_proto_msgTypes[6].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*TopicMessage); i {
+			switch v := v.(*RegistrationResponse); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -807,7 +1628,7 @@ func file_nitric_proto_topics_v1_topics_proto_init() {
 			}
 		}
 		file_nitric_proto_topics_v1_topics_proto_msgTypes[7].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*TopicPublishRequest); i {
+			switch v := v.(*TopicMessage); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -819,6 +1640,78 @@ func file_nitric_proto_topics_v1_topics_proto_init() {
 			}
 		}
 		file_nitric_proto_topics_v1_topics_proto_msgTypes[8].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*BytesPayload); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_nitric_proto_topics_v1_topics_proto_msgTypes[9].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*AttributeFilter); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_nitric_proto_topics_v1_topics_proto_msgTypes[10].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*AttributeCondition); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_nitric_proto_topics_v1_topics_proto_msgTypes[11].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*AttributeValues); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_nitric_proto_topics_v1_topics_proto_msgTypes[12].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*SubscriptionRetryPolicy); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_nitric_proto_topics_v1_topics_proto_msgTypes[13].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*TopicPublishRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_nitric_proto_topics_v1_topics_proto_msgTypes[14].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*TopicPublishResponse); i {
 			case 0:
 				return &v.state
@@ -830,6 +1723,42 @@ func file_nitric_proto_topics_v1_topics_proto_init() {
 				return nil
 			}
 		}
+		file_nitric_proto_topics_v1_topics_proto_msgTypes[15].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*TopicPublishBatchRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_nitric_proto_topics_v1_topics_proto_msgTypes[16].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*TopicPublishBatchResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_nitric_proto_topics_v1_topics_proto_msgTypes[17].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*TopicPublishResult); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
 	}
 	file_nitric_proto_topics_v1_topics_proto_msgTypes[0].OneofWrappers = []interface{}{
 		(*ClientMessage_RegistrationRequest)(nil),
@@ -838,9 +1767,21 @@ func file_nitric_proto_topics_v1_topics_proto_init() {
 	file_nitric_proto_topics_v1_topics_proto_msgTypes[3].OneofWrappers = []interface{}{
 		(*ServerMessage_RegistrationResponse)(nil),
 		(*ServerMessage_MessageRequest)(nil),
+		(*ServerMessage_CancellationRequest)(nil),
 	}
-	file_nitric_proto_topics_v1_topics_proto_msgTypes[6].OneofWrappers = []interface{}{
+	file_nitric_proto_topics_v1_topics_proto_msgTypes[7].OneofWrappers = []interface{}{
 		(*TopicMessage_StructPayload)(nil),
+		(*TopicMessage_BytesPayload)(nil),
+	}
+	file_nitric_proto_topics_v1_topics_proto_msgTypes[10].OneofWrappers = []interface{}{
+		(*AttributeCondition_AnyOf)(nil),
+		(*AttributeCondition_NoneOf)(nil),
+		(*AttributeCondition_Prefix)(nil),
+		(*AttributeCondition_Exists)(nil),
+	}
+	file_nitric_proto_topics_v1_topics_proto_msgTypes[17].OneofWrappers = []interface{}{
+		(*TopicPublishResult_MessageId)(nil),
+		(*TopicPublishResult_Error)(nil),
 	}
 	type x struct{}
 	out := protoimpl.TypeBuilder{
@@ -848,7 +1789,7 @@ func file_nitric_proto_topics_v1_topics_proto_init() {
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: file_nitric_proto_topics_v1_topics_proto_rawDesc,
 			NumEnums:      0,
-			NumMessages:   9,
+			NumMessages:   20,
 			NumExtensions: 0,
 			NumServices:   2,
 		},
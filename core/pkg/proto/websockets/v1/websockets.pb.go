@@ -728,7 +728,10 @@ type ServerMessage struct {
 	//
 	//	*ServerMessage_RegistrationResponse
 	//	*ServerMessage_WebsocketEventRequest
+	//	*ServerMessage_CancellationRequest
 	Content isServerMessage_Content `protobuf_oneof:"content"`
+	// W3C trace context (e.g. traceparent and tracestate) of the trigger, used to continue its trace in the service
+	TraceContext map[string]string `protobuf:"bytes,10,rep,name=trace_context,json=traceContext,proto3" json:"trace_context,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
 }
 
 func (x *ServerMessage) Reset() {
@@ -791,6 +794,20 @@ func (x *ServerMessage) GetWebsocketEventRequest() *WebsocketEventRequest {
 	return nil
 }
 
+func (x *ServerMessage) GetCancellationRequest() *CancellationRequest {
+	if x, ok := x.GetContent().(*ServerMessage_CancellationRequest); ok {
+		return x.CancellationRequest
+	}
+	return nil
+}
+
+func (x *ServerMessage) GetTraceContext() map[string]string {
+	if x != nil {
+		return x.TraceContext
+	}
+	return nil
+}
+
 type isServerMessage_Content interface {
 	isServerMessage_Content()
 }
@@ -807,10 +824,67 @@ type ServerMessage_WebsocketEventRequest struct {
 	WebsocketEventRequest *WebsocketEventRequest `protobuf:"bytes,3,opt,name=websocket_event_request,json=websocketEventRequest,proto3,oneof"`
 }
 
+type ServerMessage_CancellationRequest struct {
+	// Notifies the worker that a previous request with the same ID has been cancelled,
+	// typically because its deadline was exceeded. The worker should abort processing.
+	CancellationRequest *CancellationRequest `protobuf:"bytes,4,opt,name=cancellation_request,json=cancellationRequest,proto3,oneof"`
+}
+
 func (*ServerMessage_RegistrationResponse) isServerMessage_Content() {}
 
 func (*ServerMessage_WebsocketEventRequest) isServerMessage_Content() {}
 
+func (*ServerMessage_CancellationRequest) isServerMessage_Content() {}
+
+// Notification that an in-flight request was cancelled by the server
+type CancellationRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// The reason the request was cancelled
+	Reason string `protobuf:"bytes,1,opt,name=reason,proto3" json:"reason,omitempty"`
+}
+
+func (x *CancellationRequest) Reset() {
+	*x = CancellationRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_nitric_proto_websockets_v1_websockets_proto_msgTypes[12]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *CancellationRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CancellationRequest) ProtoMessage() {}
+
+func (x *CancellationRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_nitric_proto_websockets_v1_websockets_proto_msgTypes[12]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CancellationRequest.ProtoReflect.Descriptor instead.
+func (*CancellationRequest) Descriptor() ([]byte, []int) {
+	return file_nitric_proto_websockets_v1_websockets_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *CancellationRequest) GetReason() string {
+	if x != nil {
+		return x.Reason
+	}
+	return ""
+}
+
 type WebsocketEventResponse struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
@@ -825,7 +899,7 @@ type WebsocketEventResponse struct {
 func (x *WebsocketEventResponse) Reset() {
 	*x = WebsocketEventResponse{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_nitric_proto_websockets_v1_websockets_proto_msgTypes[12]
+		mi := &file_nitric_proto_websockets_v1_websockets_proto_msgTypes[13]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -838,7 +912,7 @@ func (x *WebsocketEventResponse) String() string {
 func (*WebsocketEventResponse) ProtoMessage() {}
 
 func (x *WebsocketEventResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_nitric_proto_websockets_v1_websockets_proto_msgTypes[12]
+	mi := &file_nitric_proto_websockets_v1_websockets_proto_msgTypes[13]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -851,7 +925,7 @@ func (x *WebsocketEventResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use WebsocketEventResponse.ProtoReflect.Descriptor instead.
 func (*WebsocketEventResponse) Descriptor() ([]byte, []int) {
-	return file_nitric_proto_websockets_v1_websockets_proto_rawDescGZIP(), []int{12}
+	return file_nitric_proto_websockets_v1_websockets_proto_rawDescGZIP(), []int{13}
 }
 
 func (m *WebsocketEventResponse) GetWebsocketResponse() isWebsocketEventResponse_WebsocketResponse {
@@ -890,7 +964,7 @@ type WebsocketConnectionEvent struct {
 func (x *WebsocketConnectionEvent) Reset() {
 	*x = WebsocketConnectionEvent{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_nitric_proto_websockets_v1_websockets_proto_msgTypes[13]
+		mi := &file_nitric_proto_websockets_v1_websockets_proto_msgTypes[14]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -903,7 +977,7 @@ func (x *WebsocketConnectionEvent) String() string {
 func (*WebsocketConnectionEvent) ProtoMessage() {}
 
 func (x *WebsocketConnectionEvent) ProtoReflect() protoreflect.Message {
-	mi := &file_nitric_proto_websockets_v1_websockets_proto_msgTypes[13]
+	mi := &file_nitric_proto_websockets_v1_websockets_proto_msgTypes[14]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -916,7 +990,7 @@ func (x *WebsocketConnectionEvent) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use WebsocketConnectionEvent.ProtoReflect.Descriptor instead.
 func (*WebsocketConnectionEvent) Descriptor() ([]byte, []int) {
-	return file_nitric_proto_websockets_v1_websockets_proto_rawDescGZIP(), []int{13}
+	return file_nitric_proto_websockets_v1_websockets_proto_rawDescGZIP(), []int{14}
 }
 
 func (x *WebsocketConnectionEvent) GetQueryParams() map[string]*QueryValue {
@@ -937,7 +1011,7 @@ type WebsocketConnectionResponse struct {
 func (x *WebsocketConnectionResponse) Reset() {
 	*x = WebsocketConnectionResponse{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_nitric_proto_websockets_v1_websockets_proto_msgTypes[14]
+		mi := &file_nitric_proto_websockets_v1_websockets_proto_msgTypes[15]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -950,7 +1024,7 @@ func (x *WebsocketConnectionResponse) String() string {
 func (*WebsocketConnectionResponse) ProtoMessage() {}
 
 func (x *WebsocketConnectionResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_nitric_proto_websockets_v1_websockets_proto_msgTypes[14]
+	mi := &file_nitric_proto_websockets_v1_websockets_proto_msgTypes[15]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -963,7 +1037,7 @@ func (x *WebsocketConnectionResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use WebsocketConnectionResponse.ProtoReflect.Descriptor instead.
 func (*WebsocketConnectionResponse) Descriptor() ([]byte, []int) {
-	return file_nitric_proto_websockets_v1_websockets_proto_rawDescGZIP(), []int{14}
+	return file_nitric_proto_websockets_v1_websockets_proto_rawDescGZIP(), []int{15}
 }
 
 func (x *WebsocketConnectionResponse) GetReject() bool {
@@ -982,7 +1056,7 @@ type WebsocketDisconnectionEvent struct {
 func (x *WebsocketDisconnectionEvent) Reset() {
 	*x = WebsocketDisconnectionEvent{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_nitric_proto_websockets_v1_websockets_proto_msgTypes[15]
+		mi := &file_nitric_proto_websockets_v1_websockets_proto_msgTypes[16]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -995,7 +1069,7 @@ func (x *WebsocketDisconnectionEvent) String() string {
 func (*WebsocketDisconnectionEvent) ProtoMessage() {}
 
 func (x *WebsocketDisconnectionEvent) ProtoReflect() protoreflect.Message {
-	mi := &file_nitric_proto_websockets_v1_websockets_proto_msgTypes[15]
+	mi := &file_nitric_proto_websockets_v1_websockets_proto_msgTypes[16]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1008,7 +1082,7 @@ func (x *WebsocketDisconnectionEvent) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use WebsocketDisconnectionEvent.ProtoReflect.Descriptor instead.
 func (*WebsocketDisconnectionEvent) Descriptor() ([]byte, []int) {
-	return file_nitric_proto_websockets_v1_websockets_proto_rawDescGZIP(), []int{15}
+	return file_nitric_proto_websockets_v1_websockets_proto_rawDescGZIP(), []int{16}
 }
 
 type WebsocketMessageEvent struct {
@@ -1023,7 +1097,7 @@ type WebsocketMessageEvent struct {
 func (x *WebsocketMessageEvent) Reset() {
 	*x = WebsocketMessageEvent{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_nitric_proto_websockets_v1_websockets_proto_msgTypes[16]
+		mi := &file_nitric_proto_websockets_v1_websockets_proto_msgTypes[17]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -1036,7 +1110,7 @@ func (x *WebsocketMessageEvent) String() string {
 func (*WebsocketMessageEvent) ProtoMessage() {}
 
 func (x *WebsocketMessageEvent) ProtoReflect() protoreflect.Message {
-	mi := &file_nitric_proto_websockets_v1_websockets_proto_msgTypes[16]
+	mi := &file_nitric_proto_websockets_v1_websockets_proto_msgTypes[17]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1049,7 +1123,7 @@ func (x *WebsocketMessageEvent) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use WebsocketMessageEvent.ProtoReflect.Descriptor instead.
 func (*WebsocketMessageEvent) Descriptor() ([]byte, []int) {
-	return file_nitric_proto_websockets_v1_websockets_proto_rawDescGZIP(), []int{16}
+	return file_nitric_proto_websockets_v1_websockets_proto_rawDescGZIP(), []int{17}
 }
 
 func (x *WebsocketMessageEvent) GetBody() []byte {
@@ -1142,7 +1216,7 @@ var file_nitric_proto_websockets_v1_websockets_proto_rawDesc = []byte{
 	0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x42, 0x11, 0x0a, 0x0f, 0x77, 0x65, 0x62, 0x73, 0x6f, 0x63,
 	0x6b, 0x65, 0x74, 0x5f, 0x65, 0x76, 0x65, 0x6e, 0x74, 0x22, 0x22, 0x0a, 0x0a, 0x51, 0x75, 0x65,
 	0x72, 0x79, 0x56, 0x61, 0x6c, 0x75, 0x65, 0x12, 0x14, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65,
-	0x18, 0x01, 0x20, 0x03, 0x28, 0x09, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x22, 0x80, 0x02,
+	0x18, 0x01, 0x20, 0x03, 0x28, 0x09, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x22, 0x89, 0x04,
 	0x0a, 0x0d, 0x53, 0x65, 0x72, 0x76, 0x65, 0x72, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x12,
 	0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x02, 0x69, 0x64, 0x12,
 	0x67, 0x0a, 0x15, 0x72, 0x65, 0x67, 0x69, 0x73, 0x74, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x5f,
@@ -1158,89 +1232,109 @@ var file_nitric_proto_websockets_v1_websockets_proto_rawDesc = []byte{
 	0x65, 0x74, 0x73, 0x2e, 0x76, 0x31, 0x2e, 0x57, 0x65, 0x62, 0x73, 0x6f, 0x63, 0x6b, 0x65, 0x74,
 	0x45, 0x76, 0x65, 0x6e, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x48, 0x00, 0x52, 0x15,
 	0x77, 0x65, 0x62, 0x73, 0x6f, 0x63, 0x6b, 0x65, 0x74, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x52, 0x65,
-	0x71, 0x75, 0x65, 0x73, 0x74, 0x42, 0x09, 0x0a, 0x07, 0x63, 0x6f, 0x6e, 0x74, 0x65, 0x6e, 0x74,
-	0x22, 0x9a, 0x01, 0x0a, 0x16, 0x57, 0x65, 0x62, 0x73, 0x6f, 0x63, 0x6b, 0x65, 0x74, 0x45, 0x76,
-	0x65, 0x6e, 0x74, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x6a, 0x0a, 0x13, 0x63,
-	0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x5f, 0x72, 0x65, 0x73, 0x70, 0x6f, 0x6e,
-	0x73, 0x65, 0x18, 0x0a, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x37, 0x2e, 0x6e, 0x69, 0x74, 0x72, 0x69,
-	0x63, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x77, 0x65, 0x62, 0x73, 0x6f, 0x63, 0x6b, 0x65,
-	0x74, 0x73, 0x2e, 0x76, 0x31, 0x2e, 0x57, 0x65, 0x62, 0x73, 0x6f, 0x63, 0x6b, 0x65, 0x74, 0x43,
-	0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73,
-	0x65, 0x48, 0x00, 0x52, 0x12, 0x63, 0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x52,
-	0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x42, 0x14, 0x0a, 0x12, 0x77, 0x65, 0x62, 0x73, 0x6f,
-	0x63, 0x6b, 0x65, 0x74, 0x5f, 0x72, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0xec, 0x01,
-	0x0a, 0x18, 0x57, 0x65, 0x62, 0x73, 0x6f, 0x63, 0x6b, 0x65, 0x74, 0x43, 0x6f, 0x6e, 0x6e, 0x65,
-	0x63, 0x74, 0x69, 0x6f, 0x6e, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x12, 0x68, 0x0a, 0x0c, 0x71, 0x75,
-	0x65, 0x72, 0x79, 0x5f, 0x70, 0x61, 0x72, 0x61, 0x6d, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b,
-	0x32, 0x45, 0x2e, 0x6e, 0x69, 0x74, 0x72, 0x69, 0x63, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e,
+	0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x64, 0x0a, 0x14, 0x63, 0x61, 0x6e, 0x63, 0x65, 0x6c, 0x6c,
+	0x61, 0x74, 0x69, 0x6f, 0x6e, 0x5f, 0x72, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x18, 0x04, 0x20,
+	0x01, 0x28, 0x0b, 0x32, 0x2f, 0x2e, 0x6e, 0x69, 0x74, 0x72, 0x69, 0x63, 0x2e, 0x70, 0x72, 0x6f,
+	0x74, 0x6f, 0x2e, 0x77, 0x65, 0x62, 0x73, 0x6f, 0x63, 0x6b, 0x65, 0x74, 0x73, 0x2e, 0x76, 0x31,
+	0x2e, 0x43, 0x61, 0x6e, 0x63, 0x65, 0x6c, 0x6c, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x65, 0x71,
+	0x75, 0x65, 0x73, 0x74, 0x48, 0x00, 0x52, 0x13, 0x63, 0x61, 0x6e, 0x63, 0x65, 0x6c, 0x6c, 0x61,
+	0x74, 0x69, 0x6f, 0x6e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x60, 0x0a, 0x0d, 0x74,
+	0x72, 0x61, 0x63, 0x65, 0x5f, 0x63, 0x6f, 0x6e, 0x74, 0x65, 0x78, 0x74, 0x18, 0x0a, 0x20, 0x03,
+	0x28, 0x0b, 0x32, 0x3b, 0x2e, 0x6e, 0x69, 0x74, 0x72, 0x69, 0x63, 0x2e, 0x70, 0x72, 0x6f, 0x74,
+	0x6f, 0x2e, 0x77, 0x65, 0x62, 0x73, 0x6f, 0x63, 0x6b, 0x65, 0x74, 0x73, 0x2e, 0x76, 0x31, 0x2e,
+	0x53, 0x65, 0x72, 0x76, 0x65, 0x72, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x2e, 0x54, 0x72,
+	0x61, 0x63, 0x65, 0x43, 0x6f, 0x6e, 0x74, 0x65, 0x78, 0x74, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x52,
+	0x0c, 0x74, 0x72, 0x61, 0x63, 0x65, 0x43, 0x6f, 0x6e, 0x74, 0x65, 0x78, 0x74, 0x1a, 0x3f, 0x0a,
+	0x11, 0x54, 0x72, 0x61, 0x63, 0x65, 0x43, 0x6f, 0x6e, 0x74, 0x65, 0x78, 0x74, 0x45, 0x6e, 0x74,
+	0x72, 0x79, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x03, 0x6b, 0x65, 0x79, 0x12, 0x14, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38, 0x01, 0x42, 0x09,
+	0x0a, 0x07, 0x63, 0x6f, 0x6e, 0x74, 0x65, 0x6e, 0x74, 0x22, 0x2d, 0x0a, 0x13, 0x43, 0x61, 0x6e,
+	0x63, 0x65, 0x6c, 0x6c, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
+	0x12, 0x16, 0x0a, 0x06, 0x72, 0x65, 0x61, 0x73, 0x6f, 0x6e, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x06, 0x72, 0x65, 0x61, 0x73, 0x6f, 0x6e, 0x22, 0x9a, 0x01, 0x0a, 0x16, 0x57, 0x65, 0x62,
+	0x73, 0x6f, 0x63, 0x6b, 0x65, 0x74, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x52, 0x65, 0x73, 0x70, 0x6f,
+	0x6e, 0x73, 0x65, 0x12, 0x6a, 0x0a, 0x13, 0x63, 0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74, 0x69, 0x6f,
+	0x6e, 0x5f, 0x72, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x18, 0x0a, 0x20, 0x01, 0x28, 0x0b,
+	0x32, 0x37, 0x2e, 0x6e, 0x69, 0x74, 0x72, 0x69, 0x63, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e,
 	0x77, 0x65, 0x62, 0x73, 0x6f, 0x63, 0x6b, 0x65, 0x74, 0x73, 0x2e, 0x76, 0x31, 0x2e, 0x57, 0x65,
 	0x62, 0x73, 0x6f, 0x63, 0x6b, 0x65, 0x74, 0x43, 0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74, 0x69, 0x6f,
-	0x6e, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x2e, 0x51, 0x75, 0x65, 0x72, 0x79, 0x50, 0x61, 0x72, 0x61,
-	0x6d, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x52, 0x0b, 0x71, 0x75, 0x65, 0x72, 0x79, 0x50, 0x61,
-	0x72, 0x61, 0x6d, 0x73, 0x1a, 0x66, 0x0a, 0x10, 0x51, 0x75, 0x65, 0x72, 0x79, 0x50, 0x61, 0x72,
-	0x61, 0x6d, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18,
-	0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x3c, 0x0a, 0x05, 0x76, 0x61,
-	0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x26, 0x2e, 0x6e, 0x69, 0x74, 0x72,
+	0x6e, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x48, 0x00, 0x52, 0x12, 0x63, 0x6f, 0x6e,
+	0x6e, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x42,
+	0x14, 0x0a, 0x12, 0x77, 0x65, 0x62, 0x73, 0x6f, 0x63, 0x6b, 0x65, 0x74, 0x5f, 0x72, 0x65, 0x73,
+	0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0xec, 0x01, 0x0a, 0x18, 0x57, 0x65, 0x62, 0x73, 0x6f, 0x63,
+	0x6b, 0x65, 0x74, 0x43, 0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x45, 0x76, 0x65,
+	0x6e, 0x74, 0x12, 0x68, 0x0a, 0x0c, 0x71, 0x75, 0x65, 0x72, 0x79, 0x5f, 0x70, 0x61, 0x72, 0x61,
+	0x6d, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x45, 0x2e, 0x6e, 0x69, 0x74, 0x72, 0x69,
+	0x63, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x77, 0x65, 0x62, 0x73, 0x6f, 0x63, 0x6b, 0x65,
+	0x74, 0x73, 0x2e, 0x76, 0x31, 0x2e, 0x57, 0x65, 0x62, 0x73, 0x6f, 0x63, 0x6b, 0x65, 0x74, 0x43,
+	0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x2e, 0x51,
+	0x75, 0x65, 0x72, 0x79, 0x50, 0x61, 0x72, 0x61, 0x6d, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x52,
+	0x0b, 0x71, 0x75, 0x65, 0x72, 0x79, 0x50, 0x61, 0x72, 0x61, 0x6d, 0x73, 0x1a, 0x66, 0x0a, 0x10,
+	0x51, 0x75, 0x65, 0x72, 0x79, 0x50, 0x61, 0x72, 0x61, 0x6d, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79,
+	0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6b,
+	0x65, 0x79, 0x12, 0x3c, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28,
+	0x0b, 0x32, 0x26, 0x2e, 0x6e, 0x69, 0x74, 0x72, 0x69, 0x63, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f,
+	0x2e, 0x77, 0x65, 0x62, 0x73, 0x6f, 0x63, 0x6b, 0x65, 0x74, 0x73, 0x2e, 0x76, 0x31, 0x2e, 0x51,
+	0x75, 0x65, 0x72, 0x79, 0x56, 0x61, 0x6c, 0x75, 0x65, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65,
+	0x3a, 0x02, 0x38, 0x01, 0x22, 0x35, 0x0a, 0x1b, 0x57, 0x65, 0x62, 0x73, 0x6f, 0x63, 0x6b, 0x65,
+	0x74, 0x43, 0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x65, 0x73, 0x70, 0x6f,
+	0x6e, 0x73, 0x65, 0x12, 0x16, 0x0a, 0x06, 0x72, 0x65, 0x6a, 0x65, 0x63, 0x74, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x08, 0x52, 0x06, 0x72, 0x65, 0x6a, 0x65, 0x63, 0x74, 0x22, 0x1d, 0x0a, 0x1b, 0x57,
+	0x65, 0x62, 0x73, 0x6f, 0x63, 0x6b, 0x65, 0x74, 0x44, 0x69, 0x73, 0x63, 0x6f, 0x6e, 0x6e, 0x65,
+	0x63, 0x74, 0x69, 0x6f, 0x6e, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x22, 0x2b, 0x0a, 0x15, 0x57, 0x65,
+	0x62, 0x73, 0x6f, 0x63, 0x6b, 0x65, 0x74, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x45, 0x76,
+	0x65, 0x6e, 0x74, 0x12, 0x12, 0x0a, 0x04, 0x62, 0x6f, 0x64, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x0c, 0x52, 0x04, 0x62, 0x6f, 0x64, 0x79, 0x2a, 0x3e, 0x0a, 0x12, 0x57, 0x65, 0x62, 0x73, 0x6f,
+	0x63, 0x6b, 0x65, 0x74, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x54, 0x79, 0x70, 0x65, 0x12, 0x0b, 0x0a,
+	0x07, 0x43, 0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74, 0x10, 0x00, 0x12, 0x0e, 0x0a, 0x0a, 0x44, 0x69,
+	0x73, 0x63, 0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74, 0x10, 0x01, 0x12, 0x0b, 0x0a, 0x07, 0x4d, 0x65,
+	0x73, 0x73, 0x61, 0x67, 0x65, 0x10, 0x02, 0x32, 0x8a, 0x03, 0x0a, 0x09, 0x57, 0x65, 0x62, 0x73,
+	0x6f, 0x63, 0x6b, 0x65, 0x74, 0x12, 0x72, 0x0a, 0x0b, 0x53, 0x65, 0x6e, 0x64, 0x4d, 0x65, 0x73,
+	0x73, 0x61, 0x67, 0x65, 0x12, 0x30, 0x2e, 0x6e, 0x69, 0x74, 0x72, 0x69, 0x63, 0x2e, 0x70, 0x72,
+	0x6f, 0x74, 0x6f, 0x2e, 0x77, 0x65, 0x62, 0x73, 0x6f, 0x63, 0x6b, 0x65, 0x74, 0x73, 0x2e, 0x76,
+	0x31, 0x2e, 0x57, 0x65, 0x62, 0x73, 0x6f, 0x63, 0x6b, 0x65, 0x74, 0x53, 0x65, 0x6e, 0x64, 0x52,
+	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x31, 0x2e, 0x6e, 0x69, 0x74, 0x72, 0x69, 0x63, 0x2e,
+	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x77, 0x65, 0x62, 0x73, 0x6f, 0x63, 0x6b, 0x65, 0x74, 0x73,
+	0x2e, 0x76, 0x31, 0x2e, 0x57, 0x65, 0x62, 0x73, 0x6f, 0x63, 0x6b, 0x65, 0x74, 0x53, 0x65, 0x6e,
+	0x64, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x8c, 0x01, 0x0a, 0x0f, 0x43, 0x6c,
+	0x6f, 0x73, 0x65, 0x43, 0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x3b, 0x2e,
+	0x6e, 0x69, 0x74, 0x72, 0x69, 0x63, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x77, 0x65, 0x62,
+	0x73, 0x6f, 0x63, 0x6b, 0x65, 0x74, 0x73, 0x2e, 0x76, 0x31, 0x2e, 0x57, 0x65, 0x62, 0x73, 0x6f,
+	0x63, 0x6b, 0x65, 0x74, 0x43, 0x6c, 0x6f, 0x73, 0x65, 0x43, 0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74,
+	0x69, 0x6f, 0x6e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x3c, 0x2e, 0x6e, 0x69, 0x74,
+	0x72, 0x69, 0x63, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x77, 0x65, 0x62, 0x73, 0x6f, 0x63,
+	0x6b, 0x65, 0x74, 0x73, 0x2e, 0x76, 0x31, 0x2e, 0x57, 0x65, 0x62, 0x73, 0x6f, 0x63, 0x6b, 0x65,
+	0x74, 0x43, 0x6c, 0x6f, 0x73, 0x65, 0x43, 0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e,
+	0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x7a, 0x0a, 0x0d, 0x53, 0x6f, 0x63, 0x6b,
+	0x65, 0x74, 0x44, 0x65, 0x74, 0x61, 0x69, 0x6c, 0x73, 0x12, 0x33, 0x2e, 0x6e, 0x69, 0x74, 0x72,
 	0x69, 0x63, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x77, 0x65, 0x62, 0x73, 0x6f, 0x63, 0x6b,
-	0x65, 0x74, 0x73, 0x2e, 0x76, 0x31, 0x2e, 0x51, 0x75, 0x65, 0x72, 0x79, 0x56, 0x61, 0x6c, 0x75,
-	0x65, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38, 0x01, 0x22, 0x35, 0x0a, 0x1b,
-	0x57, 0x65, 0x62, 0x73, 0x6f, 0x63, 0x6b, 0x65, 0x74, 0x43, 0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74,
-	0x69, 0x6f, 0x6e, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x16, 0x0a, 0x06, 0x72,
-	0x65, 0x6a, 0x65, 0x63, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x08, 0x52, 0x06, 0x72, 0x65, 0x6a,
-	0x65, 0x63, 0x74, 0x22, 0x1d, 0x0a, 0x1b, 0x57, 0x65, 0x62, 0x73, 0x6f, 0x63, 0x6b, 0x65, 0x74,
-	0x44, 0x69, 0x73, 0x63, 0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x45, 0x76, 0x65,
-	0x6e, 0x74, 0x22, 0x2b, 0x0a, 0x15, 0x57, 0x65, 0x62, 0x73, 0x6f, 0x63, 0x6b, 0x65, 0x74, 0x4d,
-	0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x12, 0x12, 0x0a, 0x04, 0x62,
-	0x6f, 0x64, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x04, 0x62, 0x6f, 0x64, 0x79, 0x2a,
-	0x3e, 0x0a, 0x12, 0x57, 0x65, 0x62, 0x73, 0x6f, 0x63, 0x6b, 0x65, 0x74, 0x45, 0x76, 0x65, 0x6e,
-	0x74, 0x54, 0x79, 0x70, 0x65, 0x12, 0x0b, 0x0a, 0x07, 0x43, 0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74,
-	0x10, 0x00, 0x12, 0x0e, 0x0a, 0x0a, 0x44, 0x69, 0x73, 0x63, 0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74,
-	0x10, 0x01, 0x12, 0x0b, 0x0a, 0x07, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x10, 0x02, 0x32,
-	0x8a, 0x03, 0x0a, 0x09, 0x57, 0x65, 0x62, 0x73, 0x6f, 0x63, 0x6b, 0x65, 0x74, 0x12, 0x72, 0x0a,
-	0x0b, 0x53, 0x65, 0x6e, 0x64, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x12, 0x30, 0x2e, 0x6e,
-	0x69, 0x74, 0x72, 0x69, 0x63, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x77, 0x65, 0x62, 0x73,
-	0x6f, 0x63, 0x6b, 0x65, 0x74, 0x73, 0x2e, 0x76, 0x31, 0x2e, 0x57, 0x65, 0x62, 0x73, 0x6f, 0x63,
-	0x6b, 0x65, 0x74, 0x53, 0x65, 0x6e, 0x64, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x31,
+	0x65, 0x74, 0x73, 0x2e, 0x76, 0x31, 0x2e, 0x57, 0x65, 0x62, 0x73, 0x6f, 0x63, 0x6b, 0x65, 0x74,
+	0x44, 0x65, 0x74, 0x61, 0x69, 0x6c, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x34,
 	0x2e, 0x6e, 0x69, 0x74, 0x72, 0x69, 0x63, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x77, 0x65,
 	0x62, 0x73, 0x6f, 0x63, 0x6b, 0x65, 0x74, 0x73, 0x2e, 0x76, 0x31, 0x2e, 0x57, 0x65, 0x62, 0x73,
-	0x6f, 0x63, 0x6b, 0x65, 0x74, 0x53, 0x65, 0x6e, 0x64, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73,
-	0x65, 0x12, 0x8c, 0x01, 0x0a, 0x0f, 0x43, 0x6c, 0x6f, 0x73, 0x65, 0x43, 0x6f, 0x6e, 0x6e, 0x65,
-	0x63, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x3b, 0x2e, 0x6e, 0x69, 0x74, 0x72, 0x69, 0x63, 0x2e, 0x70,
-	0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x77, 0x65, 0x62, 0x73, 0x6f, 0x63, 0x6b, 0x65, 0x74, 0x73, 0x2e,
-	0x76, 0x31, 0x2e, 0x57, 0x65, 0x62, 0x73, 0x6f, 0x63, 0x6b, 0x65, 0x74, 0x43, 0x6c, 0x6f, 0x73,
-	0x65, 0x43, 0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x65, 0x71, 0x75, 0x65,
-	0x73, 0x74, 0x1a, 0x3c, 0x2e, 0x6e, 0x69, 0x74, 0x72, 0x69, 0x63, 0x2e, 0x70, 0x72, 0x6f, 0x74,
-	0x6f, 0x2e, 0x77, 0x65, 0x62, 0x73, 0x6f, 0x63, 0x6b, 0x65, 0x74, 0x73, 0x2e, 0x76, 0x31, 0x2e,
-	0x57, 0x65, 0x62, 0x73, 0x6f, 0x63, 0x6b, 0x65, 0x74, 0x43, 0x6c, 0x6f, 0x73, 0x65, 0x43, 0x6f,
-	0x6e, 0x6e, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65,
-	0x12, 0x7a, 0x0a, 0x0d, 0x53, 0x6f, 0x63, 0x6b, 0x65, 0x74, 0x44, 0x65, 0x74, 0x61, 0x69, 0x6c,
-	0x73, 0x12, 0x33, 0x2e, 0x6e, 0x69, 0x74, 0x72, 0x69, 0x63, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f,
-	0x2e, 0x77, 0x65, 0x62, 0x73, 0x6f, 0x63, 0x6b, 0x65, 0x74, 0x73, 0x2e, 0x76, 0x31, 0x2e, 0x57,
-	0x65, 0x62, 0x73, 0x6f, 0x63, 0x6b, 0x65, 0x74, 0x44, 0x65, 0x74, 0x61, 0x69, 0x6c, 0x73, 0x52,
-	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x34, 0x2e, 0x6e, 0x69, 0x74, 0x72, 0x69, 0x63, 0x2e,
-	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x77, 0x65, 0x62, 0x73, 0x6f, 0x63, 0x6b, 0x65, 0x74, 0x73,
-	0x2e, 0x76, 0x31, 0x2e, 0x57, 0x65, 0x62, 0x73, 0x6f, 0x63, 0x6b, 0x65, 0x74, 0x44, 0x65, 0x74,
-	0x61, 0x69, 0x6c, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x32, 0x7c, 0x0a, 0x10,
-	0x57, 0x65, 0x62, 0x73, 0x6f, 0x63, 0x6b, 0x65, 0x74, 0x48, 0x61, 0x6e, 0x64, 0x6c, 0x65, 0x72,
-	0x12, 0x68, 0x0a, 0x0c, 0x48, 0x61, 0x6e, 0x64, 0x6c, 0x65, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x73,
-	0x12, 0x29, 0x2e, 0x6e, 0x69, 0x74, 0x72, 0x69, 0x63, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e,
-	0x77, 0x65, 0x62, 0x73, 0x6f, 0x63, 0x6b, 0x65, 0x74, 0x73, 0x2e, 0x76, 0x31, 0x2e, 0x43, 0x6c,
-	0x69, 0x65, 0x6e, 0x74, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x1a, 0x29, 0x2e, 0x6e, 0x69,
-	0x74, 0x72, 0x69, 0x63, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x77, 0x65, 0x62, 0x73, 0x6f,
-	0x63, 0x6b, 0x65, 0x74, 0x73, 0x2e, 0x76, 0x31, 0x2e, 0x53, 0x65, 0x72, 0x76, 0x65, 0x72, 0x4d,
-	0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x28, 0x01, 0x30, 0x01, 0x42, 0xb6, 0x01, 0x0a, 0x1d, 0x69,
-	0x6f, 0x2e, 0x6e, 0x69, 0x74, 0x72, 0x69, 0x63, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x77,
-	0x65, 0x62, 0x73, 0x6f, 0x63, 0x6b, 0x65, 0x74, 0x73, 0x2e, 0x76, 0x31, 0x42, 0x11, 0x57, 0x65,
-	0x62, 0x73, 0x6f, 0x63, 0x6b, 0x65, 0x74, 0x73, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x50,
-	0x01, 0x5a, 0x46, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x6e, 0x69,
-	0x74, 0x72, 0x69, 0x63, 0x74, 0x65, 0x63, 0x68, 0x2f, 0x6e, 0x69, 0x74, 0x72, 0x69, 0x63, 0x2f,
-	0x63, 0x6f, 0x72, 0x65, 0x2f, 0x70, 0x6b, 0x67, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2f, 0x77,
-	0x65, 0x62, 0x73, 0x6f, 0x63, 0x6b, 0x65, 0x74, 0x73, 0x2f, 0x76, 0x31, 0x3b, 0x77, 0x65, 0x62,
-	0x73, 0x6f, 0x63, 0x6b, 0x65, 0x74, 0x73, 0x70, 0x62, 0xaa, 0x02, 0x1a, 0x4e, 0x69, 0x74, 0x72,
-	0x69, 0x63, 0x2e, 0x50, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x57, 0x65, 0x62, 0x73, 0x6f, 0x63, 0x6b,
-	0x65, 0x74, 0x73, 0x2e, 0x76, 0x31, 0xca, 0x02, 0x1a, 0x4e, 0x69, 0x74, 0x72, 0x69, 0x63, 0x5c,
-	0x50, 0x72, 0x6f, 0x74, 0x6f, 0x5c, 0x57, 0x65, 0x62, 0x73, 0x6f, 0x63, 0x6b, 0x65, 0x74, 0x73,
-	0x5c, 0x56, 0x31, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+	0x6f, 0x63, 0x6b, 0x65, 0x74, 0x44, 0x65, 0x74, 0x61, 0x69, 0x6c, 0x73, 0x52, 0x65, 0x73, 0x70,
+	0x6f, 0x6e, 0x73, 0x65, 0x32, 0x7c, 0x0a, 0x10, 0x57, 0x65, 0x62, 0x73, 0x6f, 0x63, 0x6b, 0x65,
+	0x74, 0x48, 0x61, 0x6e, 0x64, 0x6c, 0x65, 0x72, 0x12, 0x68, 0x0a, 0x0c, 0x48, 0x61, 0x6e, 0x64,
+	0x6c, 0x65, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x73, 0x12, 0x29, 0x2e, 0x6e, 0x69, 0x74, 0x72, 0x69,
+	0x63, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x77, 0x65, 0x62, 0x73, 0x6f, 0x63, 0x6b, 0x65,
+	0x74, 0x73, 0x2e, 0x76, 0x31, 0x2e, 0x43, 0x6c, 0x69, 0x65, 0x6e, 0x74, 0x4d, 0x65, 0x73, 0x73,
+	0x61, 0x67, 0x65, 0x1a, 0x29, 0x2e, 0x6e, 0x69, 0x74, 0x72, 0x69, 0x63, 0x2e, 0x70, 0x72, 0x6f,
+	0x74, 0x6f, 0x2e, 0x77, 0x65, 0x62, 0x73, 0x6f, 0x63, 0x6b, 0x65, 0x74, 0x73, 0x2e, 0x76, 0x31,
+	0x2e, 0x53, 0x65, 0x72, 0x76, 0x65, 0x72, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x28, 0x01,
+	0x30, 0x01, 0x42, 0xb6, 0x01, 0x0a, 0x1d, 0x69, 0x6f, 0x2e, 0x6e, 0x69, 0x74, 0x72, 0x69, 0x63,
+	0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x77, 0x65, 0x62, 0x73, 0x6f, 0x63, 0x6b, 0x65, 0x74,
+	0x73, 0x2e, 0x76, 0x31, 0x42, 0x11, 0x57, 0x65, 0x62, 0x73, 0x6f, 0x63, 0x6b, 0x65, 0x74, 0x73,
+	0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x50, 0x01, 0x5a, 0x46, 0x67, 0x69, 0x74, 0x68, 0x75,
+	0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x6e, 0x69, 0x74, 0x72, 0x69, 0x63, 0x74, 0x65, 0x63, 0x68,
+	0x2f, 0x6e, 0x69, 0x74, 0x72, 0x69, 0x63, 0x2f, 0x63, 0x6f, 0x72, 0x65, 0x2f, 0x70, 0x6b, 0x67,
+	0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2f, 0x77, 0x65, 0x62, 0x73, 0x6f, 0x63, 0x6b, 0x65, 0x74,
+	0x73, 0x2f, 0x76, 0x31, 0x3b, 0x77, 0x65, 0x62, 0x73, 0x6f, 0x63, 0x6b, 0x65, 0x74, 0x73, 0x70,
+	0x62, 0xaa, 0x02, 0x1a, 0x4e, 0x69, 0x74, 0x72, 0x69, 0x63, 0x2e, 0x50, 0x72, 0x6f, 0x74, 0x6f,
+	0x2e, 0x57, 0x65, 0x62, 0x73, 0x6f, 0x63, 0x6b, 0x65, 0x74, 0x73, 0x2e, 0x76, 0x31, 0xca, 0x02,
+	0x1a, 0x4e, 0x69, 0x74, 0x72, 0x69, 0x63, 0x5c, 0x50, 0x72, 0x6f, 0x74, 0x6f, 0x5c, 0x57, 0x65,
+	0x62, 0x73, 0x6f, 0x63, 0x6b, 0x65, 0x74, 0x73, 0x5c, 0x56, 0x31, 0x62, 0x06, 0x70, 0x72, 0x6f,
+	0x74, 0x6f, 0x33,
 }
 
 var (
@@ -1256,7 +1350,7 @@ func file_nitric_proto_websockets_v1_websockets_proto_rawDescGZIP() []byte {
 }
 
 var file_nitric_proto_websockets_v1_websockets_proto_enumTypes = make([]protoimpl.EnumInfo, 1)
-var file_nitric_proto_websockets_v1_websockets_proto_msgTypes = make([]protoimpl.MessageInfo, 18)
+var file_nitric_proto_websockets_v1_websockets_proto_msgTypes = make([]protoimpl.MessageInfo, 20)
 var file_nitric_proto_websockets_v1_websockets_proto_goTypes = []interface{}{
 	(WebsocketEventType)(0),                  // 0: nitric.proto.websockets.v1.WebsocketEventType
 	(*WebsocketDetailsRequest)(nil),          // 1: nitric.proto.websockets.v1.WebsocketDetailsRequest
@@ -1271,38 +1365,42 @@ var file_nitric_proto_websockets_v1_websockets_proto_goTypes = []interface{}{
 	(*WebsocketEventRequest)(nil),            // 10: nitric.proto.websockets.v1.WebsocketEventRequest
 	(*QueryValue)(nil),                       // 11: nitric.proto.websockets.v1.QueryValue
 	(*ServerMessage)(nil),                    // 12: nitric.proto.websockets.v1.ServerMessage
-	(*WebsocketEventResponse)(nil),           // 13: nitric.proto.websockets.v1.WebsocketEventResponse
-	(*WebsocketConnectionEvent)(nil),         // 14: nitric.proto.websockets.v1.WebsocketConnectionEvent
-	(*WebsocketConnectionResponse)(nil),      // 15: nitric.proto.websockets.v1.WebsocketConnectionResponse
-	(*WebsocketDisconnectionEvent)(nil),      // 16: nitric.proto.websockets.v1.WebsocketDisconnectionEvent
-	(*WebsocketMessageEvent)(nil),            // 17: nitric.proto.websockets.v1.WebsocketMessageEvent
-	nil,                                      // 18: nitric.proto.websockets.v1.WebsocketConnectionEvent.QueryParamsEntry
+	(*CancellationRequest)(nil),              // 13: nitric.proto.websockets.v1.CancellationRequest
+	(*WebsocketEventResponse)(nil),           // 14: nitric.proto.websockets.v1.WebsocketEventResponse
+	(*WebsocketConnectionEvent)(nil),         // 15: nitric.proto.websockets.v1.WebsocketConnectionEvent
+	(*WebsocketConnectionResponse)(nil),      // 16: nitric.proto.websockets.v1.WebsocketConnectionResponse
+	(*WebsocketDisconnectionEvent)(nil),      // 17: nitric.proto.websockets.v1.WebsocketDisconnectionEvent
+	(*WebsocketMessageEvent)(nil),            // 18: nitric.proto.websockets.v1.WebsocketMessageEvent
+	nil,                                      // 19: nitric.proto.websockets.v1.ServerMessage.TraceContextEntry
+	nil,                                      // 20: nitric.proto.websockets.v1.WebsocketConnectionEvent.QueryParamsEntry
 }
 var file_nitric_proto_websockets_v1_websockets_proto_depIdxs = []int32{
 	9,  // 0: nitric.proto.websockets.v1.ClientMessage.registration_request:type_name -> nitric.proto.websockets.v1.RegistrationRequest
-	13, // 1: nitric.proto.websockets.v1.ClientMessage.websocket_event_response:type_name -> nitric.proto.websockets.v1.WebsocketEventResponse
+	14, // 1: nitric.proto.websockets.v1.ClientMessage.websocket_event_response:type_name -> nitric.proto.websockets.v1.WebsocketEventResponse
 	0,  // 2: nitric.proto.websockets.v1.RegistrationRequest.event_type:type_name -> nitric.proto.websockets.v1.WebsocketEventType
-	14, // 3: nitric.proto.websockets.v1.WebsocketEventRequest.connection:type_name -> nitric.proto.websockets.v1.WebsocketConnectionEvent
-	16, // 4: nitric.proto.websockets.v1.WebsocketEventRequest.disconnection:type_name -> nitric.proto.websockets.v1.WebsocketDisconnectionEvent
-	17, // 5: nitric.proto.websockets.v1.WebsocketEventRequest.message:type_name -> nitric.proto.websockets.v1.WebsocketMessageEvent
+	15, // 3: nitric.proto.websockets.v1.WebsocketEventRequest.connection:type_name -> nitric.proto.websockets.v1.WebsocketConnectionEvent
+	17, // 4: nitric.proto.websockets.v1.WebsocketEventRequest.disconnection:type_name -> nitric.proto.websockets.v1.WebsocketDisconnectionEvent
+	18, // 5: nitric.proto.websockets.v1.WebsocketEventRequest.message:type_name -> nitric.proto.websockets.v1.WebsocketMessageEvent
 	8,  // 6: nitric.proto.websockets.v1.ServerMessage.registration_response:type_name -> nitric.proto.websockets.v1.RegistrationResponse
 	10, // 7: nitric.proto.websockets.v1.ServerMessage.websocket_event_request:type_name -> nitric.proto.websockets.v1.WebsocketEventRequest
-	15, // 8: nitric.proto.websockets.v1.WebsocketEventResponse.connection_response:type_name -> nitric.proto.websockets.v1.WebsocketConnectionResponse
-	18, // 9: nitric.proto.websockets.v1.WebsocketConnectionEvent.query_params:type_name -> nitric.proto.websockets.v1.WebsocketConnectionEvent.QueryParamsEntry
-	11, // 10: nitric.proto.websockets.v1.WebsocketConnectionEvent.QueryParamsEntry.value:type_name -> nitric.proto.websockets.v1.QueryValue
-	3,  // 11: nitric.proto.websockets.v1.Websocket.SendMessage:input_type -> nitric.proto.websockets.v1.WebsocketSendRequest
-	5,  // 12: nitric.proto.websockets.v1.Websocket.CloseConnection:input_type -> nitric.proto.websockets.v1.WebsocketCloseConnectionRequest
-	1,  // 13: nitric.proto.websockets.v1.Websocket.SocketDetails:input_type -> nitric.proto.websockets.v1.WebsocketDetailsRequest
-	7,  // 14: nitric.proto.websockets.v1.WebsocketHandler.HandleEvents:input_type -> nitric.proto.websockets.v1.ClientMessage
-	4,  // 15: nitric.proto.websockets.v1.Websocket.SendMessage:output_type -> nitric.proto.websockets.v1.WebsocketSendResponse
-	6,  // 16: nitric.proto.websockets.v1.Websocket.CloseConnection:output_type -> nitric.proto.websockets.v1.WebsocketCloseConnectionResponse
-	2,  // 17: nitric.proto.websockets.v1.Websocket.SocketDetails:output_type -> nitric.proto.websockets.v1.WebsocketDetailsResponse
-	12, // 18: nitric.proto.websockets.v1.WebsocketHandler.HandleEvents:output_type -> nitric.proto.websockets.v1.ServerMessage
-	15, // [15:19] is the sub-list for method output_type
-	11, // [11:15] is the sub-list for method input_type
-	11, // [11:11] is the sub-list for extension type_name
-	11, // [11:11] is the sub-list for extension extendee
-	0,  // [0:11] is the sub-list for field type_name
+	13, // 8: nitric.proto.websockets.v1.ServerMessage.cancellation_request:type_name -> nitric.proto.websockets.v1.CancellationRequest
+	19, // 9: nitric.proto.websockets.v1.ServerMessage.trace_context:type_name -> nitric.proto.websockets.v1.ServerMessage.TraceContextEntry
+	16, // 10: nitric.proto.websockets.v1.WebsocketEventResponse.connection_response:type_name -> nitric.proto.websockets.v1.WebsocketConnectionResponse
+	20, // 11: nitric.proto.websockets.v1.WebsocketConnectionEvent.query_params:type_name -> nitric.proto.websockets.v1.WebsocketConnectionEvent.QueryParamsEntry
+	11, // 12: nitric.proto.websockets.v1.WebsocketConnectionEvent.QueryParamsEntry.value:type_name -> nitric.proto.websockets.v1.QueryValue
+	3,  // 13: nitric.proto.websockets.v1.Websocket.SendMessage:input_type -> nitric.proto.websockets.v1.WebsocketSendRequest
+	5,  // 14: nitric.proto.websockets.v1.Websocket.CloseConnection:input_type -> nitric.proto.websockets.v1.WebsocketCloseConnectionRequest
+	1,  // 15: nitric.proto.websockets.v1.Websocket.SocketDetails:input_type -> nitric.proto.websockets.v1.WebsocketDetailsRequest
+	7,  // 16: nitric.proto.websockets.v1.WebsocketHandler.HandleEvents:input_type -> nitric.proto.websockets.v1.ClientMessage
+	4,  // 17: nitric.proto.websockets.v1.Websocket.SendMessage:output_type -> nitric.proto.websockets.v1.WebsocketSendResponse
+	6,  // 18: nitric.proto.websockets.v1.Websocket.CloseConnection:output_type -> nitric.proto.websockets.v1.WebsocketCloseConnectionResponse
+	2,  // 19: nitric.proto.websockets.v1.Websocket.SocketDetails:output_type -> nitric.proto.websockets.v1.WebsocketDetailsResponse
+	12, // 20: nitric.proto.websockets.v1.WebsocketHandler.HandleEvents:output_type -> nitric.proto.websockets.v1.ServerMessage
+	17, // [17:21] is the sub-list for method output_type
+	13, // [13:17] is the sub-list for method input_type
+	13, // [13:13] is the sub-list for extension type_name
+	13, // [13:13] is the sub-list for extension extendee
+	0,  // [0:13] is the sub-list for field type_name
 }
 
 func init() { file_nitric_proto_websockets_v1_websockets_proto_init() }
@@ -1456,7 +1554,7 @@ func file_nitric_proto_websockets_v1_websockets_proto_init() {
 			}
 		}
 		file_nitric_proto_websockets_v1_websockets_proto_msgTypes[12].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*WebsocketEventResponse); i {
+			switch v := v.(*CancellationRequest); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1468,7 +1566,7 @@ func file_nitric_proto_websockets_v1_websockets_proto_init() {
 			}
 		}
 		file_nitric_proto_websockets_v1_websockets_proto_msgTypes[13].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*WebsocketConnectionEvent); i {
+			switch v := v.(*WebsocketEventResponse); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1480,7 +1578,7 @@ func file_nitric_proto_websockets_v1_websockets_proto_init() {
 			}
 		}
 		file_nitric_proto_websockets_v1_websockets_proto_msgTypes[14].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*WebsocketConnectionResponse); i {
+			switch v := v.(*WebsocketConnectionEvent); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1492,7 +1590,7 @@ func file_nitric_proto_websockets_v1_websockets_proto_init() {
 			}
 		}
 		file_nitric_proto_websockets_v1_websockets_proto_msgTypes[15].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*WebsocketDisconnectionEvent); i {
+			switch v := v.(*WebsocketConnectionResponse); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1504,6 +1602,18 @@ func file_nitric_proto_websockets_v1_websockets_proto_init() {
 			}
 		}
 		file_nitric_proto_websockets_v1_websockets_proto_msgTypes[16].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*WebsocketDisconnectionEvent); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_nitric_proto_websockets_v1_websockets_proto_msgTypes[17].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*WebsocketMessageEvent); i {
 			case 0:
 				return &v.state
@@ -1528,8 +1638,9 @@ func file_nitric_proto_websockets_v1_websockets_proto_init() {
 	file_nitric_proto_websockets_v1_websockets_proto_msgTypes[11].OneofWrappers = []interface{}{
 		(*ServerMessage_RegistrationResponse)(nil),
 		(*ServerMessage_WebsocketEventRequest)(nil),
+		(*ServerMessage_CancellationRequest)(nil),
 	}
-	file_nitric_proto_websockets_v1_websockets_proto_msgTypes[12].OneofWrappers = []interface{}{
+	file_nitric_proto_websockets_v1_websockets_proto_msgTypes[13].OneofWrappers = []interface{}{
 		(*WebsocketEventResponse_ConnectionResponse)(nil),
 	}
 	type x struct{}
@@ -1538,7 +1649,7 @@ func file_nitric_proto_websockets_v1_websockets_proto_init() {
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: file_nitric_proto_websockets_v1_websockets_proto_rawDesc,
 			NumEnums:      1,
-			NumMessages:   18,
+			NumMessages:   20,
 			NumExtensions: 0,
 			NumServices:   2,
 		},
@@ -15,6 +15,8 @@
 package server
 
 import (
+	"time"
+
 	"github.com/nitrictech/nitric/core/pkg/gateway"
 	batchpb "github.com/nitrictech/nitric/core/pkg/proto/batch/v1"
 	kvstorepb "github.com/nitrictech/nitric/core/pkg/proto/kvstore/v1"
@@ -25,9 +27,12 @@ import (
 	storagepb "github.com/nitrictech/nitric/core/pkg/proto/storage/v1"
 	topicspb "github.com/nitrictech/nitric/core/pkg/proto/topics/v1"
 	websocketspb "github.com/nitrictech/nitric/core/pkg/proto/websockets/v1"
+	"github.com/nitrictech/nitric/core/pkg/workers"
 	"github.com/nitrictech/nitric/core/pkg/workers/apis"
 	"github.com/nitrictech/nitric/core/pkg/workers/http"
 	"github.com/nitrictech/nitric/core/pkg/workers/jobs"
+	"github.com/nitrictech/nitric/core/pkg/workers/keyvalue"
+	"github.com/nitrictech/nitric/core/pkg/workers/queues"
 	"github.com/nitrictech/nitric/core/pkg/workers/schedules"
 	"github.com/nitrictech/nitric/core/pkg/workers/storage"
 	"github.com/nitrictech/nitric/core/pkg/workers/topics"
@@ -126,6 +131,18 @@ func WithStorageListenerPlugin(storage storage.BucketRequestHandler) ServerOptio
 	}
 }
 
+func WithKeyValueListenerPlugin(keyvalue keyvalue.StoreRequestHandler) ServerOption {
+	return func(opts *NitricServer) {
+		opts.KeyValueListenerPlugin = keyvalue
+	}
+}
+
+func WithQueueListenerPlugin(queues queues.QueueRequestHandler) ServerOption {
+	return func(opts *NitricServer) {
+		opts.QueueListenerPlugin = queues
+	}
+}
+
 func WithJobHandlerPlugin(jobs jobs.JobRequestHandler) ServerOption {
 	return func(opts *NitricServer) {
 		opts.JobHandlerPlugin = jobs
@@ -138,6 +155,38 @@ func WithWebsocketListenerPlugin(websockets websockets.WebsocketRequestHandler)
 	}
 }
 
+// WithLoadBalancer - Set the strategy used to balance requests between workers registered for the same trigger.
+// this option takes precedence over the WORKER_LOAD_BALANCER environment variable
+func WithLoadBalancer(lb workers.LoadBalancer) ServerOption {
+	return func(opts *NitricServer) {
+		opts.LoadBalancer = lb
+	}
+}
+
+// WithShutdownGracePeriod - Set how long to wait for in-flight requests to finish when the server stops.
+// this option takes precedence over the SHUTDOWN_GRACE_PERIOD environment variable
+func WithShutdownGracePeriod(gracePeriod time.Duration) ServerOption {
+	return func(opts *NitricServer) {
+		opts.ShutdownGracePeriod = gracePeriod
+	}
+}
+
+// WithProcessStopTimeout - Set how long to wait for the user process to exit once in-flight requests have finished.
+// this option takes precedence over the PROCESS_STOP_TIMEOUT environment variable
+func WithProcessStopTimeout(timeout time.Duration) ServerOption {
+	return func(opts *NitricServer) {
+		opts.ProcessStopTimeout = timeout
+	}
+}
+
+// WithSecretCacheTTL - Cache secret values, with the latest version of a secret cached for the ttl.
+// this option takes precedence over the SECRET_CACHE_TTL environment variable
+func WithSecretCacheTTL(ttl time.Duration) ServerOption {
+	return func(opts *NitricServer) {
+		opts.SecretCacheTTL = ttl
+	}
+}
+
 func WithServiceAddress(address string) ServerOption {
 	return func(opts *NitricServer) {
 		opts.ServiceAddress = address
@@ -15,14 +15,19 @@
 package server
 
 import (
+	"context"
 	"errors"
 	"fmt"
 	"math"
 	"net"
 	"os"
+	"sync/atomic"
 	"time"
 
+	"go.opentelemetry.io/otel/metric"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
 
 	"github.com/nitrictech/nitric/core/pkg/decorators"
 	"github.com/nitrictech/nitric/core/pkg/env"
@@ -43,9 +48,12 @@ import (
 	topicspb "github.com/nitrictech/nitric/core/pkg/proto/topics/v1"
 	websocketspb "github.com/nitrictech/nitric/core/pkg/proto/websockets/v1"
 	"github.com/nitrictech/nitric/core/pkg/server/runtime"
+	"github.com/nitrictech/nitric/core/pkg/workers"
 	"github.com/nitrictech/nitric/core/pkg/workers/apis"
 	"github.com/nitrictech/nitric/core/pkg/workers/http"
 	"github.com/nitrictech/nitric/core/pkg/workers/jobs"
+	"github.com/nitrictech/nitric/core/pkg/workers/keyvalue"
+	"github.com/nitrictech/nitric/core/pkg/workers/queues"
 	"github.com/nitrictech/nitric/core/pkg/workers/schedules"
 	"github.com/nitrictech/nitric/core/pkg/workers/storage"
 	"github.com/nitrictech/nitric/core/pkg/workers/topics"
@@ -55,6 +63,13 @@ import (
 type NitricServer struct {
 	processManager pm.ProcessManager
 	grpcServer     *grpc.Server
+	healthServer   *health.Server
+	// stops the health status of the grpc server being updated
+	stopHealthReporting context.CancelFunc
+	// set once the server is shutting down
+	stopping atomic.Bool
+	// reports worker gauges to the meter provider
+	workerMetrics metric.Registration
 
 	// Options
 	ServiceAddress string
@@ -69,6 +84,18 @@ type NitricServer struct {
 	// The minimum number of workers that need to be available
 	MinWorkers int
 
+	// The strategy used to balance requests between workers registered for the same trigger
+	LoadBalancer workers.LoadBalancer
+
+	// How long to wait for in-flight requests to finish when stopping
+	ShutdownGracePeriod time.Duration
+
+	// How long to wait for the user process to exit once in-flight requests have finished
+	ProcessStopTimeout time.Duration
+
+	// How long to cache the latest version of a secret for, secret values aren't cached when 0
+	SecretCacheTTL time.Duration
+
 	// The provider adapter gateway
 	GatewayPlugin gateway.GatewayService
 
@@ -92,6 +119,8 @@ type NitricServer struct {
 	StorageListenerPlugin   storage.BucketRequestHandler
 	WebsocketListenerPlugin websockets.WebsocketRequestHandler
 	JobHandlerPlugin        jobs.JobRequestHandler
+	KeyValueListenerPlugin  keyvalue.StoreRequestHandler
+	QueueListenerPlugin     queues.QueueRequestHandler
 }
 
 func (s *NitricServer) WorkerCount() int {
@@ -101,7 +130,9 @@ func (s *NitricServer) WorkerCount() int {
 		s.TopicsListenerPlugin.WorkerCount() +
 		s.StorageListenerPlugin.WorkerCount() +
 		s.WebsocketListenerPlugin.WorkerCount() +
-		s.JobHandlerPlugin.WorkerCount()
+		s.JobHandlerPlugin.WorkerCount() +
+		s.KeyValueListenerPlugin.WorkerCount() +
+		s.QueueListenerPlugin.WorkerCount()
 }
 
 func (s *NitricServer) waitForMinimumWorkers(timeout int) error {
@@ -127,6 +158,75 @@ func (s *NitricServer) waitForMinimumWorkers(timeout int) error {
 	return nil
 }
 
+// Ready returns nil if the server is ready to handle triggers, otherwise an error describing why it isn't
+func (s *NitricServer) Ready() error {
+	if s.stopping.Load() {
+		return errors.New("server is shutting down")
+	}
+
+	if !s.processManager.UserProcessAlive() {
+		return errors.New("user process is not running")
+	}
+
+	if s.healthServer == nil {
+		// worker plugins are defaulted when the server starts
+		return errors.New("server has not started")
+	}
+
+	if s.WorkerCount() < s.MinWorkers {
+		return fmt.Errorf("available workers below required minimum of %d, %d available", s.MinWorkers, s.WorkerCount())
+	}
+
+	return nil
+}
+
+// Workers lists the workers registered with the server by the type of trigger they handle
+func (s *NitricServer) Workers() map[string][]workers.WorkerDescription {
+	plugins := map[string]any{
+		workers.TriggerApi:       s.ApiPlugin,
+		workers.TriggerHttp:      s.HttpPlugin,
+		workers.TriggerSchedule:  s.SchedulesPlugin,
+		workers.TriggerTopic:     s.TopicsListenerPlugin,
+		workers.TriggerBucket:    s.StorageListenerPlugin,
+		workers.TriggerWebsocket: s.WebsocketListenerPlugin,
+		workers.TriggerJob:       s.JobHandlerPlugin,
+		workers.TriggerKeyValue:  s.KeyValueListenerPlugin,
+		workers.TriggerQueue:     s.QueueListenerPlugin,
+	}
+
+	described := map[string][]workers.WorkerDescription{}
+	for triggerType, plugin := range plugins {
+		if describable, ok := plugin.(workers.Describable); ok {
+			described[triggerType] = describable.DescribeWorkers()
+		}
+	}
+
+	return described
+}
+
+// healthCheckInterval is how often the grpc health status is updated to reflect the server's readiness
+const healthCheckInterval = time.Second
+
+// reportHealth keeps the grpc health status in sync with the server's readiness until the context is done
+func (s *NitricServer) reportHealth(ctx context.Context) {
+	ticker := time.NewTicker(healthCheckInterval)
+	defer ticker.Stop()
+
+	for {
+		status := healthpb.HealthCheckResponse_SERVING
+		if s.Ready() != nil {
+			status = healthpb.HealthCheckResponse_NOT_SERVING
+		}
+		s.healthServer.SetServingStatus("", status)
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
 type ServerStartOptions func(m *NitricServer)
 
 func WithGrpcServer(s *grpc.Server) ServerStartOptions {
@@ -198,8 +298,35 @@ func (s *NitricServer) Start(startOpts ...ServerStartOptions) error {
 	}
 	batchpb.RegisterJobServer(s.grpcServer, s.JobHandlerPlugin)
 
+	if s.KeyValueListenerPlugin == nil {
+		s.KeyValueListenerPlugin = keyvalue.New()
+	}
+	kvstorepb.RegisterKeyValueListenerServer(s.grpcServer, s.KeyValueListenerPlugin)
+
+	if s.QueueListenerPlugin == nil {
+		s.QueueListenerPlugin = queues.New()
+	}
+	queuespb.RegisterQueueListenerServer(s.grpcServer, s.QueueListenerPlugin)
+
+	// Allow orchestrators to check the server's readiness with the standard grpc health service
+	s.healthServer = health.NewServer()
+	s.healthServer.SetServingStatus("", healthpb.HealthCheckResponse_NOT_SERVING)
+	healthpb.RegisterHealthServer(s.grpcServer, s.healthServer)
+
+	// Apply the load balancing strategy to the worker managers that support it
+	for _, plugin := range []any{s.ApiPlugin, s.SchedulesPlugin, s.WebsocketListenerPlugin, s.JobHandlerPlugin, s.QueueListenerPlugin} {
+		if balanced, ok := plugin.(workers.LoadBalanced); ok && s.LoadBalancer != nil {
+			balanced.SetLoadBalancer(s.LoadBalancer)
+		}
+	}
+
 	// Load & Register the service plugins
-	secretsServerWithValidation := decorators.SecretsServerWithValidation(s.SecretManagerPlugin)
+	secretsServer := s.SecretManagerPlugin
+	if s.SecretCacheTTL > 0 {
+		secretsServer = decorators.SecretsServerWithCache(secretsServer, s.SecretCacheTTL)
+	}
+
+	secretsServerWithValidation := decorators.SecretsServerWithValidation(secretsServer)
 	keyvalueServerWithCompat := decorators.KeyValueServerWithCompat(s.KeyValuePlugin)
 
 	kvstorepb.RegisterKvStoreServer(s.grpcServer, keyvalueServerWithCompat)
@@ -220,6 +347,15 @@ func (s *NitricServer) Start(startOpts ...ServerStartOptions) error {
 
 	logger.Debug("Registered Gateway Plugin")
 
+	s.workerMetrics, err = workers.ObserveWorkers(s.Workers)
+	if err != nil {
+		logger.Warnf("unable to report worker metrics: %v", err)
+	}
+
+	healthCtx, stopHealthReporting := context.WithCancel(context.Background())
+	s.stopHealthReporting = stopHealthReporting
+	go s.reportHealth(healthCtx)
+
 	// Start the gRPC server
 	go (func() {
 		logger.Debugf("Services listening on: %s", s.ServiceAddress)
@@ -257,6 +393,9 @@ func (s *NitricServer) Start(startOpts ...ServerStartOptions) error {
 			StorageListenerPlugin:   s.StorageListenerPlugin,
 			WebsocketListenerPlugin: s.WebsocketListenerPlugin,
 			JobHandlerPlugin:        s.JobHandlerPlugin,
+			KeyValueListenerPlugin:  s.KeyValueListenerPlugin,
+			QueueListenerPlugin:     s.QueueListenerPlugin,
+			Status:                  s,
 		})
 	}(gatewayErrchan)
 
@@ -283,10 +422,86 @@ func (s *NitricServer) Start(startOpts ...ServerStartOptions) error {
 	return exitErr
 }
 
+// drainWorkers waits for requests already sent to workers to complete, workers stop accepting new requests
+func (s *NitricServer) drainWorkers(ctx context.Context) error {
+	var firstErr error
+
+	for _, plugin := range []any{s.ApiPlugin, s.HttpPlugin, s.SchedulesPlugin, s.TopicsListenerPlugin, s.StorageListenerPlugin, s.WebsocketListenerPlugin, s.JobHandlerPlugin, s.KeyValueListenerPlugin, s.QueueListenerPlugin} {
+		if drainable, ok := plugin.(workers.Drainable); ok {
+			if err := drainable.Drain(ctx); err != nil && firstErr == nil {
+				firstErr = err
+			}
+		}
+	}
+
+	return firstErr
+}
+
+// Stop the server gracefully.
+//
+//	The gateway stops accepting new triggers, then in-flight requests are given the shutdown grace period to complete
+//	before the user process is sent SIGTERM. The user process is given the process stop timeout to exit before it's killed.
 func (s *NitricServer) Stop() {
-	_ = s.GatewayPlugin.Stop()
-	s.grpcServer.Stop()
-	s.processManager.StopAll()
+	s.stopping.Store(true)
+
+	if s.stopHealthReporting != nil {
+		s.stopHealthReporting()
+		s.healthServer.Shutdown()
+	}
+
+	if s.workerMetrics != nil {
+		_ = s.workerMetrics.Unregister()
+	}
+
+	// stop new triggers first, so they aren't refused by workers that have started draining
+	if stopper, ok := s.GatewayPlugin.(gateway.AcceptStopper); ok {
+		if err := stopper.StopAccepting(); err != nil {
+			logger.Errorf("error stopping gateway from accepting new requests: %v", err)
+		}
+	}
+
+	drainCtx, cancelDrain := context.WithTimeout(context.Background(), s.ShutdownGracePeriod)
+	defer cancelDrain()
+
+	if err := s.drainWorkers(drainCtx); err != nil {
+		logger.Warnf("in-flight requests did not complete within the shutdown grace period: %v", err)
+	}
+
+	// the gateway is only stopped once draining is done, stopping it may cancel the requests it's still serving
+	gatewayStopped := make(chan struct{})
+	go func() {
+		defer close(gatewayStopped)
+
+		if err := s.GatewayPlugin.Stop(); err != nil {
+			logger.Errorf("error stopping gateway: %v", err)
+		}
+	}()
+
+	// the user process gets its own timeout, so it isn't killed immediately when draining uses the whole grace period
+	ctx, cancel := context.WithTimeout(context.Background(), s.ProcessStopTimeout)
+	defer cancel()
+
+	s.processManager.StopAll(ctx)
+
+	if s.grpcServer != nil {
+		grpcStopped := make(chan struct{})
+		go func() {
+			defer close(grpcStopped)
+			s.grpcServer.GracefulStop()
+		}()
+
+		select {
+		case <-grpcStopped:
+		case <-ctx.Done():
+			s.grpcServer.Stop()
+		}
+	}
+
+	select {
+	case <-gatewayStopped:
+	case <-ctx.Done():
+		logger.Warnf("gateway did not stop within the process stop timeout")
+	}
 }
 
 // New - Create a new nitric server
@@ -319,6 +534,40 @@ func New(opts ...ServerOption) (*NitricServer, error) {
 		return nil, fmt.Errorf("invalid WORKER_TIMEOUT: %w", err)
 	}
 
+	if m.ShutdownGracePeriod <= 0 {
+		gracePeriod, err := env.SHUTDOWN_GRACE_PERIOD.Int()
+		if err != nil {
+			return nil, fmt.Errorf("invalid SHUTDOWN_GRACE_PERIOD: %w", err)
+		}
+
+		m.ShutdownGracePeriod = time.Duration(gracePeriod) * time.Second
+	}
+
+	if m.ProcessStopTimeout <= 0 {
+		stopTimeout, err := env.PROCESS_STOP_TIMEOUT.Int()
+		if err != nil {
+			return nil, fmt.Errorf("invalid PROCESS_STOP_TIMEOUT: %w", err)
+		}
+
+		m.ProcessStopTimeout = time.Duration(stopTimeout) * time.Second
+	}
+
+	if m.SecretCacheTTL <= 0 {
+		secretCacheTTL, err := env.SECRET_CACHE_TTL.Int()
+		if err != nil {
+			return nil, fmt.Errorf("invalid SECRET_CACHE_TTL: %w", err)
+		}
+
+		m.SecretCacheTTL = time.Duration(secretCacheTTL) * time.Second
+	}
+
+	if m.LoadBalancer == nil {
+		m.LoadBalancer, err = workers.LoadBalancerFromString(env.WORKER_LOAD_BALANCER.String())
+		if err != nil {
+			return nil, fmt.Errorf("invalid WORKER_LOAD_BALANCER: %w", err)
+		}
+	}
+
 	if m.ChildCommand == nil {
 		if len(os.Args) > 1 {
 			m.ChildCommand = os.Args[1:]
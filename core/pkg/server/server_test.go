@@ -15,18 +15,57 @@
 package server_test
 
 import (
+	"context"
 	"fmt"
 	"net"
 	"net/http"
 	"os"
+	"sync/atomic"
+	"time"
 
 	"github.com/golang/mock/gomock"
 	mock_gateway "github.com/nitrictech/nitric/core/mocks/gateway"
 	server "github.com/nitrictech/nitric/core/pkg/server"
+	httpworkers "github.com/nitrictech/nitric/core/pkg/workers/http"
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/gomega"
 )
 
+// acceptStoppingGateway records when the server stops it accepting new requests
+type acceptStoppingGateway struct {
+	*mock_gateway.MockGatewayService
+	calls []string
+}
+
+func (a *acceptStoppingGateway) StopAccepting() error {
+	a.calls = append(a.calls, "StopAccepting")
+	return nil
+}
+
+// drainingHttpPlugin has a request in flight until it's drained
+type drainingHttpPlugin struct {
+	httpworkers.HttpRequestHandler
+	inFlight  chan struct{}
+	completed atomic.Bool
+}
+
+func (d *drainingHttpPlugin) Drain(ctx context.Context) error {
+	select {
+	case <-d.inFlight:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+func (d *drainingHttpPlugin) serve(duration time.Duration) {
+	go func() {
+		defer close(d.inFlight)
+		time.Sleep(duration)
+		d.completed.Store(true)
+	}()
+}
+
 var _ = Describe("Nitric Server", func() {
 	Context("Starting the server", func() {
 		When("The Gateway plugin is available and working", func() {
@@ -125,4 +164,112 @@ var _ = Describe("Nitric Server", func() {
 			})
 		})
 	})
+
+	Context("Checking readiness", func() {
+		When("The server has not started", func() {
+			ctrl := gomock.NewController(GinkgoT())
+			mockGateway := mock_gateway.NewMockGatewayService(ctrl)
+
+			os.Args = []string{}
+			mb, _ := server.New(
+				server.WithMinWorkers(0),
+				server.WithGatewayPlugin(mockGateway),
+			)
+
+			It("Should not be ready", func() {
+				err := mb.Ready()
+				Expect(err).Should(HaveOccurred())
+				Expect(err.Error()).To(ContainSubstring("not started"))
+			})
+		})
+
+		When("The server has stopped", func() {
+			ctrl := gomock.NewController(GinkgoT())
+			mockGateway := mock_gateway.NewMockGatewayService(ctrl)
+			mockGateway.EXPECT().Stop().AnyTimes().Return(nil)
+
+			os.Args = []string{}
+			mb, _ := server.New(
+				server.WithMinWorkers(0),
+				server.WithGatewayPlugin(mockGateway),
+			)
+
+			It("Should not be ready", func() {
+				mb.Stop()
+
+				err := mb.Ready()
+				Expect(err).Should(HaveOccurred())
+				Expect(err.Error()).To(ContainSubstring("shutting down"))
+			})
+		})
+	})
+
+	Context("Stopping the server", func() {
+		When("The gateway can stop accepting new requests", func() {
+			It("Should stop accepting new requests before stopping the gateway", func() {
+				ctrl := gomock.NewController(GinkgoT())
+				gw := &acceptStoppingGateway{MockGatewayService: mock_gateway.NewMockGatewayService(ctrl)}
+				gw.EXPECT().Stop().Times(1).DoAndReturn(func() error {
+					gw.calls = append(gw.calls, "Stop")
+					return nil
+				})
+
+				os.Args = []string{}
+				mb, _ := server.New(
+					server.WithMinWorkers(0),
+					server.WithGatewayPlugin(gw),
+				)
+
+				mb.Stop()
+
+				Expect(gw.calls).To(Equal([]string{"StopAccepting", "Stop"}))
+			})
+		})
+
+		When("A request is in flight", func() {
+			It("Should let the request complete before stopping the gateway", func() {
+				ctrl := gomock.NewController(GinkgoT())
+				mockGateway := mock_gateway.NewMockGatewayService(ctrl)
+				httpPlugin := &drainingHttpPlugin{inFlight: make(chan struct{})}
+
+				completedBeforeStop := false
+				mockGateway.EXPECT().Stop().Times(1).DoAndReturn(func() error {
+					completedBeforeStop = httpPlugin.completed.Load()
+					return nil
+				})
+
+				os.Args = []string{}
+				mb, _ := server.New(
+					server.WithMinWorkers(0),
+					server.WithGatewayPlugin(mockGateway),
+					server.WithHttpPlugin(httpPlugin),
+					server.WithShutdownGracePeriod(5*time.Second),
+				)
+
+				httpPlugin.serve(100 * time.Millisecond)
+				mb.Stop()
+
+				Expect(httpPlugin.completed.Load()).To(BeTrue())
+				Expect(completedBeforeStop).To(BeTrue())
+			})
+		})
+
+		When("The shutdown timeouts are configured", func() {
+			It("Should keep each phase's timeout", func() {
+				ctrl := gomock.NewController(GinkgoT())
+				mockGateway := mock_gateway.NewMockGatewayService(ctrl)
+
+				os.Args = []string{}
+				mb, err := server.New(
+					server.WithGatewayPlugin(mockGateway),
+					server.WithShutdownGracePeriod(time.Minute),
+					server.WithProcessStopTimeout(5*time.Second),
+				)
+				Expect(err).ShouldNot(HaveOccurred())
+
+				Expect(mb.ShutdownGracePeriod).To(Equal(time.Minute))
+				Expect(mb.ProcessStopTimeout).To(Equal(5 * time.Second))
+			})
+		})
+	})
 })
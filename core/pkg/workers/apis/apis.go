@@ -17,6 +17,7 @@ package apis
 import (
 	"context"
 	"fmt"
+	"io"
 	"slices"
 	"strings"
 	"sync"
@@ -33,6 +34,8 @@ type RouteWorker struct {
 	routeMatcher string
 	methods      []string
 	connection   *WorkerConnection
+	// the worker accepts request bodies streamed as chunks
+	streamRequestBody bool
 }
 
 // slashSplitter - used to split strings, with the same output regardless of leading or trailing slashes
@@ -43,7 +46,11 @@ func slashSplitter(c rune) bool {
 
 type ApiRequestHandler interface {
 	apispb.ApiServer
-	HandleRequest(apiName string, request *apispb.ServerMessage) (*apispb.ClientMessage, error)
+	HandleRequest(ctx context.Context, apiName string, request *apispb.ServerMessage) (*apispb.ClientMessage, error)
+	// HandleStreamingRequest forwards a request to a worker, streaming the request body from body if it's not nil.
+	// The returned reader provides the response body, streamed from the worker if it responded with a streamed body,
+	// and must be closed by the caller.
+	HandleStreamingRequest(ctx context.Context, apiName string, request *apispb.ServerMessage, body io.Reader) (*apispb.HttpResponse, io.ReadCloser, error)
 	WorkerCount() int
 }
 
@@ -53,44 +60,35 @@ func splitPath(p string) []string {
 	return strings.FieldsFunc(p, slashSplitter)
 }
 
-func extractPathParams(route string, requestPath string) (map[string]string, error) {
-	requestPathSegments := splitPath(requestPath)
-	pathSegments := splitPath(route)
-	params := make(map[string]string)
-
-	if len(requestPathSegments) != len(pathSegments) {
-		return nil, fmt.Errorf("path template mismatch")
-	}
-
-	for i, p := range pathSegments {
-		if !strings.HasPrefix(p, ":") && p != requestPathSegments[i] {
-			return nil, fmt.Errorf("path template mismatch")
-		} else if strings.HasPrefix(p, ":") {
-			params[strings.Replace(p, ":", "", 1)] = requestPathSegments[i]
-		}
-	}
-
-	return params, nil
-}
-
-func (r *RouteWorker) isSupportedRequest(httpRequest *apispb.HttpRequest) bool {
-	if !slices.Contains[[]string](r.methods, httpRequest.GetMethod()) {
-		return false
-	}
-
-	_, err := extractPathParams(r.routeMatcher, httpRequest.GetPath())
-
-	return err == nil
+// InFlight returns the number of requests the route's worker is currently handling
+func (r *RouteWorker) InFlight() int {
+	return r.connection.InFlight()
 }
 
 type ApiName = string
 
 type RouteWorkerManager struct {
 	routeWorkerMap map[ApiName][]*RouteWorker
-	lock           sync.RWMutex
+	// route tries for each api, used to match requests to workers
+	routers      map[ApiName]*routeNode
+	loadBalancer workers.LoadBalancer
+	lock         sync.RWMutex
 }
 
-var _ apispb.ApiServer = &RouteWorkerManager{}
+var (
+	_ apispb.ApiServer     = &RouteWorkerManager{}
+	_ workers.LoadBalanced = &RouteWorkerManager{}
+	_ workers.Drainable    = &RouteWorkerManager{}
+	_ workers.Describable  = &RouteWorkerManager{}
+)
+
+// SetLoadBalancer sets the strategy used to choose between workers registered for the same route
+func (s *RouteWorkerManager) SetLoadBalancer(lb workers.LoadBalancer) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	s.loadBalancer = lb
+}
 
 func (s *RouteWorkerManager) WorkerCount() int {
 	s.lock.RLock()
@@ -104,24 +102,66 @@ func (s *RouteWorkerManager) WorkerCount() int {
 	return total
 }
 
+// Drain waits for requests in flight on all route workers to complete, workers stop accepting new requests
+func (s *RouteWorkerManager) Drain(ctx context.Context) error {
+	s.lock.RLock()
+	connections := []*WorkerConnection{}
+	for _, routeWorkers := range s.routeWorkerMap {
+		for _, rw := range routeWorkers {
+			connections = append(connections, rw.connection)
+		}
+	}
+	s.lock.RUnlock()
+
+	return workers.DrainAll(ctx, connections)
+}
+
+// DescribeWorkers lists the route workers registered for each api
+func (s *RouteWorkerManager) DescribeWorkers() []workers.WorkerDescription {
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+
+	descriptions := []workers.WorkerDescription{}
+	for apiName, routeWorkers := range s.routeWorkerMap {
+		for _, rw := range routeWorkers {
+			descriptions = append(descriptions, workers.WorkerDescription{
+				Name:     apiName,
+				Trigger:  fmt.Sprintf("%s %s", strings.Join(rw.methods, ","), rw.routeMatcher),
+				InFlight: rw.InFlight(),
+			})
+		}
+	}
+
+	return descriptions
+}
+
 func (a *RouteWorkerManager) ApiDetails(ctx context.Context, req *apispb.ApiDetailsRequest) (*apispb.ApiDetailsResponse, error) {
 	return nil, status.Error(codes.Unimplemented, "Not Implemented")
 }
 
 // registerRouteHandler registers a worker by the routes and methods it handles.
-func (s *RouteWorkerManager) registerRouteHandler(apiName string, path string, methods []string, worker *WorkerConnection) (*RouteWorker, error) {
+func (s *RouteWorkerManager) registerRouteHandler(apiName string, registration *apispb.RegistrationRequest, worker *WorkerConnection) (*RouteWorker, error) {
 	s.lock.Lock()
 	defer s.lock.Unlock()
 
-	if _, exists := s.routeWorkerMap[apiName]; !exists {
-		s.routeWorkerMap[apiName] = []*RouteWorker{}
+	router, exists := s.routers[apiName]
+	if !exists {
+		router = newRouteNode()
 	}
 
 	rw := &RouteWorker{
-		routeMatcher: path,
-		methods:      methods,
-		connection:   worker,
+		routeMatcher:      registration.GetPath(),
+		methods:           registration.GetMethods(),
+		connection:        worker,
+		streamRequestBody: registration.GetStreamRequestBody(),
+	}
+
+	// workers registering the same route are replicas, requests are balanced between them
+	if err := router.insert(rw); err != nil {
+		return nil, fmt.Errorf("unable to register route for api %s: %w", apiName, err)
 	}
+
+	s.routers[apiName] = router
 	s.routeWorkerMap[apiName] = append(s.routeWorkerMap[apiName], rw)
 
 	return rw, nil
@@ -137,7 +177,17 @@ func (s *RouteWorkerManager) unregisterRouteHandler(apiName string, worker *Rout
 
 	if len(s.routeWorkerMap[apiName]) == 0 {
 		delete(s.routeWorkerMap, apiName)
+		delete(s.routers, apiName)
+		return
+	}
+
+	// rebuild the trie, so routes that no longer have workers can't conflict with new registrations
+	router := newRouteNode()
+	for _, rw := range s.routeWorkerMap[apiName] {
+		// the remaining routes were all previously accepted, so they can't conflict with each other
+		_ = router.insert(rw)
 	}
+	s.routers[apiName] = router
 }
 
 func (s *RouteWorkerManager) Serve(stream apispb.Api_ServeServer) error {
@@ -150,14 +200,12 @@ func (s *RouteWorkerManager) Serve(stream apispb.Api_ServeServer) error {
 		return fmt.Errorf("first request must be an init request")
 	}
 
-	wrkr := workers.NewWorkerRequestBroker[*apispb.ServerMessage, *apispb.ClientMessage](stream)
+	wrkr := workers.NewWorkerRequestBroker[*apispb.ServerMessage, *apispb.ClientMessage](stream, newCancellationRequest)
 
 	// Get routing info
 	apiName := initRequest.GetRegistrationRequest().Api
-	path := initRequest.GetRegistrationRequest().Path
-	methods := initRequest.GetRegistrationRequest().Methods
 
-	routeWorker, err := s.registerRouteHandler(apiName, path, methods, wrkr)
+	routeWorker, err := s.registerRouteHandler(apiName, initRequest.GetRegistrationRequest(), wrkr)
 	if err != nil {
 		return err
 	}
@@ -177,52 +225,68 @@ func (s *RouteWorkerManager) Serve(stream apispb.Api_ServeServer) error {
 	return wrkr.Run()
 }
 
-func (s *RouteWorkerManager) HandleRequest(apiName string, request *apispb.ServerMessage) (*apispb.ClientMessage, error) {
+// findRouteWorker selects the worker that should handle a request, balancing requests across workers registered for the same route
+func (s *RouteWorkerManager) findRouteWorker(apiName string, httpRequest *apispb.HttpRequest) (*RouteWorker, map[string]string, error) {
 	s.lock.RLock()
 	defer s.lock.RUnlock()
 
-	if request.Id == "" {
-		request.Id = workers.GenerateUniqueId()
-	}
-
-	possibleHandlers, ok := s.routeWorkerMap[apiName]
+	router, ok := s.routers[apiName]
 	if !ok {
-		return nil, fmt.Errorf("no routes registered for api %s", apiName)
+		return nil, nil, fmt.Errorf("no routes registered for api %s", apiName)
 	}
 
-	// Handlers are applied using Highlander rules (THERE CAN BE ONLY ONE!!!)
-	var theOneTrueHandler *RouteWorker = nil
-	for _, handler := range possibleHandlers {
-		if handler.isSupportedRequest(request.GetHttpRequest()) {
-			// Praise the one true handler 🙌
-			theOneTrueHandler = handler
-			break
-		}
+	params := map[string]string{}
+	candidates := router.match(splitPath(httpRequest.GetPath()), httpRequest.GetMethod(), params)
+	if len(candidates) == 0 {
+		return nil, nil, fmt.Errorf("no worker registered for Api %s on route: %s - %s", apiName, httpRequest.GetMethod(), httpRequest.GetPath())
 	}
 
-	if theOneTrueHandler == nil {
-		return nil, fmt.Errorf("no worker registered for Api %s on route: %s - %s", apiName, request.GetHttpRequest().GetMethod(), request.GetHttpRequest().GetPath())
+	balanceKey := fmt.Sprintf("%s %s %s", apiName, httpRequest.GetMethod(), candidates[0].routeMatcher)
+
+	return workers.SelectWorker(s.loadBalancer, balanceKey, candidates), params, nil
+}
+
+// HandleRequest forwards a request to a worker, buffering the response body if the worker streams it
+func (s *RouteWorkerManager) HandleRequest(ctx context.Context, apiName string, request *apispb.ServerMessage) (*apispb.ClientMessage, error) {
+	response, body, err := s.HandleStreamingRequest(ctx, apiName, request, nil)
+	if err != nil {
+		return nil, err
 	}
+	defer body.Close()
 
-	if request.GetHttpRequest().GetPathParams() == nil || len(request.GetHttpRequest().GetPathParams()) < 1 {
-		pathParams, err := extractPathParams(theOneTrueHandler.routeMatcher, request.GetHttpRequest().GetPath())
+	if response.StreamedBody {
+		response.Body, err = io.ReadAll(body)
 		if err != nil {
-			return nil, err
+			return nil, fmt.Errorf("error reading streamed response body: %w", err)
 		}
-		request.GetHttpRequest().PathParams = pathParams
+		response.StreamedBody = false
 	}
 
-	resp, err := theOneTrueHandler.connection.Send(request)
-	if err != nil {
-		return nil, err
-	}
+	return &apispb.ClientMessage{
+		Id: request.Id,
+		Content: &apispb.ClientMessage_HttpResponse{
+			HttpResponse: response,
+		},
+	}, nil
+}
 
-	return *resp, nil
+// newCancellationRequest notifies a worker that an in-flight request has been cancelled
+func newCancellationRequest(id workers.RequestIdentifier, reason string) *apispb.ServerMessage {
+	return &apispb.ServerMessage{
+		Id: id,
+		Content: &apispb.ServerMessage_CancellationRequest{
+			CancellationRequest: &apispb.CancellationRequest{
+				Reason: reason,
+			},
+		},
+	}
 }
 
 func New() *RouteWorkerManager {
 	return &RouteWorkerManager{
 		routeWorkerMap: map[string][]*RouteWorker{},
+		routers:        map[string]*routeNode{},
+		loadBalancer:   workers.RoundRobin(),
 		lock:           sync.RWMutex{},
 	}
 }
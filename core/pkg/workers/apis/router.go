@@ -0,0 +1,231 @@
+// Copyright 2021 Nitric Technologies Pty Ltd.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package apis
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+)
+
+type segmentKind int
+
+const (
+	staticSegment segmentKind = iota
+	paramSegment
+	wildcardSegment
+)
+
+const (
+	paramPrefix    = ":"
+	wildcardPrefix = "*"
+)
+
+type routeSegment struct {
+	kind segmentKind
+	// the literal value of static segments, or the name of param and wildcard segments
+	value string
+}
+
+// parseRoute - splits a route into its segments, validating param and wildcard usage.
+//
+//	static segments must match exactly, e.g. /users/me
+//	param segments match any single segment, e.g. /users/:id
+//	a trailing wildcard segment matches one or more remaining segments, e.g. /files/*path
+func parseRoute(route string) ([]routeSegment, error) {
+	parts := splitPath(route)
+	segments := make([]routeSegment, 0, len(parts))
+
+	for i, part := range parts {
+		switch {
+		case strings.HasPrefix(part, paramPrefix):
+			name := strings.TrimPrefix(part, paramPrefix)
+			if name == "" {
+				return nil, fmt.Errorf("invalid route %s, path params must be named", route)
+			}
+
+			segments = append(segments, routeSegment{kind: paramSegment, value: name})
+		case strings.HasPrefix(part, wildcardPrefix):
+			name := strings.TrimPrefix(part, wildcardPrefix)
+			if name == "" {
+				return nil, fmt.Errorf("invalid route %s, wildcard params must be named", route)
+			}
+
+			if i != len(parts)-1 {
+				return nil, fmt.Errorf("invalid route %s, wildcard params are only supported as the last segment", route)
+			}
+
+			segments = append(segments, routeSegment{kind: wildcardSegment, value: name})
+		default:
+			segments = append(segments, routeSegment{kind: staticSegment, value: part})
+		}
+	}
+
+	return segments, nil
+}
+
+// routeNode - a node in a route trie, each node represents a single path segment
+type routeNode struct {
+	static map[string]*routeNode
+
+	// param and wildcard children keyed by name, routes may only use different names in the same position
+	// when they don't share any methods, so a request is never ambiguous
+	params    map[string]*routeNode
+	wildcards map[string]*routeNode
+
+	// the workers registered for the route ending at this node
+	workers []*RouteWorker
+}
+
+func newRouteNode() *routeNode {
+	return &routeNode{
+		static:    map[string]*routeNode{},
+		params:    map[string]*routeNode{},
+		wildcards: map[string]*routeNode{},
+	}
+}
+
+// sharedMethod - returns a method that both the given methods and a route in this subtree support
+func (n *routeNode) sharedMethod(methods []string) (string, bool) {
+	for _, worker := range n.workers {
+		for _, method := range methods {
+			if slices.Contains(worker.methods, method) {
+				return method, true
+			}
+		}
+	}
+
+	children := []map[string]*routeNode{n.static, n.params, n.wildcards}
+	for _, nodes := range children {
+		for _, child := range nodes {
+			if method, ok := child.sharedMethod(methods); ok {
+				return method, true
+			}
+		}
+	}
+
+	return "", false
+}
+
+// conflict - check whether a route is ambiguous with the routes already in the trie that share one of its methods
+func (n *routeNode) conflict(route string, segments []routeSegment, methods []string) error {
+	node := n
+	for _, segment := range segments {
+		switch segment.kind {
+		case staticSegment:
+			node = node.static[segment.value]
+		case paramSegment:
+			for name, child := range node.params {
+				if method, ok := child.sharedMethod(methods); ok && name != segment.value {
+					return fmt.Errorf("%s route %s conflicts with an existing route using param :%s in the same position, params in the same position must have the same name", method, route, name)
+				}
+			}
+			node = node.params[segment.value]
+		case wildcardSegment:
+			for name, child := range node.wildcards {
+				if method, ok := child.sharedMethod(methods); ok && name != segment.value {
+					return fmt.Errorf("%s route %s conflicts with an existing route using wildcard *%s in the same position, wildcards in the same position must have the same name", method, route, name)
+				}
+			}
+			node = node.wildcards[segment.value]
+		}
+
+		// no existing routes share this prefix
+		if node == nil {
+			return nil
+		}
+	}
+
+	return nil
+}
+
+// insert - add a worker to the trie, rejecting routes that are ambiguous with an existing route
+func (n *routeNode) insert(worker *RouteWorker) error {
+	segments, err := parseRoute(worker.routeMatcher)
+	if err != nil {
+		return err
+	}
+
+	if err := n.conflict(worker.routeMatcher, segments, worker.methods); err != nil {
+		return err
+	}
+
+	node := n
+	for _, segment := range segments {
+		children := node.static
+		switch segment.kind {
+		case paramSegment:
+			children = node.params
+		case wildcardSegment:
+			children = node.wildcards
+		}
+
+		child, ok := children[segment.value]
+		if !ok {
+			child = newRouteNode()
+			children[segment.value] = child
+		}
+		node = child
+	}
+
+	node.workers = append(node.workers, worker)
+
+	return nil
+}
+
+// workersFor - the workers at this node that support the given method
+func (n *routeNode) workersFor(method string) []*RouteWorker {
+	supported := []*RouteWorker{}
+
+	for _, worker := range n.workers {
+		if slices.Contains(worker.methods, method) {
+			supported = append(supported, worker)
+		}
+	}
+
+	return supported
+}
+
+// match - find the workers for the most specific route matching the path segments and method.
+//
+//	static segments take precedence over params, which take precedence over wildcards.
+func (n *routeNode) match(segments []string, method string, params map[string]string) []*RouteWorker {
+	if len(segments) == 0 {
+		return n.workersFor(method)
+	}
+
+	if child, ok := n.static[segments[0]]; ok {
+		if matched := child.match(segments[1:], method, params); len(matched) > 0 {
+			return matched
+		}
+	}
+
+	// at most one param or wildcard name is used in this position for each method, see conflict
+	for name, child := range n.params {
+		if matched := child.match(segments[1:], method, params); len(matched) > 0 {
+			params[name] = segments[0]
+			return matched
+		}
+	}
+
+	for name, child := range n.wildcards {
+		if matched := child.workersFor(method); len(matched) > 0 {
+			params[name] = strings.Join(segments, "/")
+			return matched
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,120 @@
+// Copyright 2021 Nitric Technologies Pty Ltd.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package apis
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/ginkgo/extensions/table"
+	. "github.com/onsi/gomega"
+)
+
+func newTestRouteWorker(route string, methods ...string) *RouteWorker {
+	return &RouteWorker{
+		routeMatcher: route,
+		methods:      methods,
+	}
+}
+
+var _ = Describe("Route trie", func() {
+	When("parsing routes", func() {
+		DescribeTable("should reject invalid routes",
+			func(route string, message string) {
+				_, err := parseRoute(route)
+				Expect(err).To(MatchError(ContainSubstring(message)))
+			},
+			Entry("unnamed param", "/users/:", "path params must be named"),
+			Entry("unnamed wildcard", "/files/*", "wildcard params must be named"),
+			Entry("wildcard before the last segment", "/files/*path/meta", "only supported as the last segment"),
+		)
+	})
+
+	When("inserting routes", func() {
+		var router *routeNode
+
+		BeforeEach(func() {
+			router = newRouteNode()
+		})
+
+		It("should reject routes using different param names in the same position for the same method", func() {
+			Expect(router.insert(newTestRouteWorker("/users/:id", "GET"))).To(Succeed())
+
+			err := router.insert(newTestRouteWorker("/users/:name/posts", "GET", "POST"))
+			Expect(err).To(MatchError(ContainSubstring("GET route /users/:name/posts conflicts with an existing route using param :id")))
+		})
+
+		It("should reject routes using different wildcard names in the same position for the same method", func() {
+			Expect(router.insert(newTestRouteWorker("/files/*path", "GET"))).To(Succeed())
+
+			err := router.insert(newTestRouteWorker("/files/*key", "GET"))
+			Expect(err).To(MatchError(ContainSubstring("conflicts with an existing route using wildcard *path")))
+		})
+
+		It("should accept routes using different param names in the same position for different methods", func() {
+			Expect(router.insert(newTestRouteWorker("/a/:x", "GET"))).To(Succeed())
+			Expect(router.insert(newTestRouteWorker("/a/:y", "POST"))).To(Succeed())
+		})
+
+		It("should accept replicas of the same route", func() {
+			Expect(router.insert(newTestRouteWorker("/users/:id", "GET"))).To(Succeed())
+			Expect(router.insert(newTestRouteWorker("/users/:id", "GET"))).To(Succeed())
+
+			Expect(router.match([]string{"users", "1"}, "GET", map[string]string{})).To(HaveLen(2))
+		})
+	})
+
+	When("matching requests", func() {
+		var (
+			router   *routeNode
+			me       = newTestRouteWorker("/users/me", "GET")
+			user     = newTestRouteWorker("/users/:id", "GET")
+			update   = newTestRouteWorker("/users/:userId", "PUT")
+			posts    = newTestRouteWorker("/users/:id/posts", "GET")
+			files    = newTestRouteWorker("/files/*path", "GET")
+			filesTop = newTestRouteWorker("/files/top", "GET")
+		)
+
+		BeforeEach(func() {
+			router = newRouteNode()
+			for _, worker := range []*RouteWorker{me, user, update, posts, files, filesTop} {
+				Expect(router.insert(worker)).To(Succeed())
+			}
+		})
+
+		DescribeTable("should match the most specific route for the method",
+			func(path string, method string, expected **RouteWorker, expectedParams map[string]string) {
+				params := map[string]string{}
+
+				matched := router.match(splitPath(path), method, params)
+
+				if expected == nil {
+					Expect(matched).To(BeEmpty())
+					return
+				}
+
+				Expect(matched).To(ConsistOf(*expected))
+				Expect(params).To(Equal(expectedParams))
+			},
+			Entry("static over param", "/users/me", "GET", &me, map[string]string{}),
+			Entry("param", "/users/1", "GET", &user, map[string]string{"id": "1"}),
+			Entry("param with a different name for another method", "/users/1", "PUT", &update, map[string]string{"userId": "1"}),
+			Entry("nested param", "/users/1/posts", "GET", &posts, map[string]string{"id": "1"}),
+			Entry("wildcard", "/files/a/b/c", "GET", &files, map[string]string{"path": "a/b/c"}),
+			Entry("static over wildcard", "/files/top", "GET", &filesTop, map[string]string{}),
+			Entry("unsupported method", "/users/1", "DELETE", nil, nil),
+			Entry("unknown path", "/unknown", "GET", nil, nil),
+			Entry("wildcards require at least one segment", "/files", "GET", nil, nil),
+		)
+	})
+})
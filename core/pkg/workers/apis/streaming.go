@@ -0,0 +1,208 @@
+// Copyright 2021 Nitric Technologies Pty Ltd.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package apis
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+
+	"github.com/nitrictech/nitric/core/pkg/logger"
+	apispb "github.com/nitrictech/nitric/core/pkg/proto/apis/v1"
+	"github.com/nitrictech/nitric/core/pkg/workers"
+)
+
+// the maximum size of each request body chunk streamed to workers
+const requestBodyChunkSize = 64 * 1024
+
+// isFinalResponse reports whether a message from a worker completes its response
+func isFinalResponse(msg *apispb.ClientMessage) bool {
+	if chunk := msg.GetHttpResponseBodyChunk(); chunk != nil {
+		return chunk.Last
+	}
+
+	// the response is complete, unless it's followed by a streamed body
+	return !msg.GetHttpResponse().GetStreamedBody()
+}
+
+func newRequestBodyChunk(id workers.RequestIdentifier, data []byte, last bool) *apispb.ServerMessage {
+	return &apispb.ServerMessage{
+		Id: id,
+		Content: &apispb.ServerMessage_HttpRequestBodyChunk{
+			HttpRequestBodyChunk: &apispb.HttpBodyChunk{
+				Data: data,
+				Last: last,
+			},
+		},
+	}
+}
+
+// streamRequestBody sends a request body to a worker as a series of chunks
+func streamRequestBody(ctx context.Context, connection *WorkerConnection, id workers.RequestIdentifier, body io.Reader) error {
+	buf := make([]byte, requestBodyChunkSize)
+
+	for {
+		// stop streaming once the request has completed or been cancelled
+		if ctx.Err() != nil {
+			return nil
+		}
+
+		n, err := body.Read(buf)
+		if n > 0 {
+			// the message is serialized before SendMessage returns, so the buffer can be reused
+			if sendErr := connection.SendMessage(newRequestBodyChunk(id, buf[:n], false)); sendErr != nil {
+				return sendErr
+			}
+		}
+
+		if err == io.EOF {
+			return connection.SendMessage(newRequestBodyChunk(id, nil, true))
+		}
+
+		if err != nil {
+			return err
+		}
+	}
+}
+
+// responseBodyReader reads a response body streamed from a worker
+type responseBodyReader struct {
+	ctx    context.Context
+	cancel context.CancelFunc
+	chunks <-chan *apispb.ClientMessage
+	buffer []byte
+	last   bool
+}
+
+func (r *responseBodyReader) Read(p []byte) (int, error) {
+	for len(r.buffer) == 0 {
+		if r.last {
+			return 0, io.EOF
+		}
+
+		select {
+		case msg, ok := <-r.chunks:
+			if !ok {
+				return 0, fmt.Errorf("worker disconnected before completing the response body: %w", io.ErrUnexpectedEOF)
+			}
+
+			chunk := msg.GetHttpResponseBodyChunk()
+			if chunk == nil {
+				return 0, fmt.Errorf("received invalid response type from worker, expected a response body chunk")
+			}
+
+			r.buffer = chunk.Data
+			r.last = chunk.Last
+		case <-r.ctx.Done():
+			return 0, r.ctx.Err()
+		}
+	}
+
+	n := copy(p, r.buffer)
+	r.buffer = r.buffer[n:]
+
+	return n, nil
+}
+
+// Close stops streaming the response, cancelling the request if the worker hasn't finished sending the body
+func (r *responseBodyReader) Close() error {
+	r.cancel()
+	return nil
+}
+
+func (s *RouteWorkerManager) HandleStreamingRequest(ctx context.Context, apiName string, request *apispb.ServerMessage, body io.Reader) (_ *apispb.HttpResponse, _ io.ReadCloser, err error) {
+	if request.Id == "" {
+		request.Id = workers.GenerateUniqueId()
+	}
+
+	// the request is measured until the worker starts responding, streamed response bodies aren't included
+	requestCtx, end := workers.StartRequest(ctx, workers.TriggerApi, apiName)
+	defer func() { end(err) }()
+
+	ctx = requestCtx
+	request.TraceContext = workers.InjectTraceContext(ctx)
+
+	routeWorker, pathParams, err := s.findRouteWorker(apiName, request.GetHttpRequest())
+	if err != nil {
+		return nil, nil, err
+	}
+
+	httpRequest := request.GetHttpRequest()
+	if httpRequest.GetPathParams() == nil || len(httpRequest.GetPathParams()) < 1 {
+		httpRequest.PathParams = pathParams
+	}
+
+	if body != nil && !routeWorker.streamRequestBody {
+		// the worker only accepts complete request bodies
+		httpRequest.Body, err = io.ReadAll(body)
+		if err != nil {
+			return nil, nil, fmt.Errorf("unable to read request body: %w", err)
+		}
+
+		body = nil
+	}
+
+	httpRequest.StreamedBody = body != nil
+
+	// cancelled when the response body is closed, notifying the worker if it's still streaming
+	ctx, cancel := context.WithCancel(ctx)
+
+	responses, err := routeWorker.connection.Stream(ctx, request, isFinalResponse)
+	if err != nil {
+		cancel()
+		return nil, nil, err
+	}
+
+	if body != nil {
+		go func() {
+			if err := streamRequestBody(ctx, routeWorker.connection, request.Id, body); err != nil {
+				logger.Errorf("error streaming request body to worker: %v", err)
+				cancel()
+			}
+		}()
+	}
+
+	var msg *apispb.ClientMessage
+	select {
+	case resp, ok := <-responses:
+		if !ok {
+			cancel()
+			return nil, nil, fmt.Errorf("worker disconnected before responding")
+		}
+
+		msg = resp
+	case <-ctx.Done():
+		cancel()
+		return nil, nil, ctx.Err()
+	}
+
+	httpResponse := msg.GetHttpResponse()
+	if httpResponse == nil {
+		cancel()
+		return nil, nil, fmt.Errorf("received invalid response type from worker, expected an http response")
+	}
+
+	if !httpResponse.StreamedBody {
+		cancel()
+		return httpResponse, io.NopCloser(bytes.NewReader(httpResponse.Body)), nil
+	}
+
+	return httpResponse, &responseBodyReader{
+		ctx:    ctx,
+		cancel: cancel,
+		chunks: responses,
+	}, nil
+}
@@ -0,0 +1,60 @@
+// Copyright 2021 Nitric Technologies Pty Ltd.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package workers
+
+import (
+	"context"
+	"time"
+)
+
+// Drainable is implemented by worker managers that can wait for their in-flight requests to complete before shutting down
+type Drainable interface {
+	// Drain stops new requests being sent to workers and waits until in-flight requests complete or the context is done
+	Drain(ctx context.Context) error
+}
+
+// drainPollInterval is how often in-flight requests are checked while draining
+const drainPollInterval = 5 * time.Millisecond
+
+// WaitForIdle blocks until the reporter has no requests in flight, returning the context error if it's done first
+func WaitForIdle(ctx context.Context, reporter InFlightReporter) error {
+	ticker := time.NewTicker(drainPollInterval)
+	defer ticker.Stop()
+
+	for reporter.InFlight() > 0 {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ticker.C:
+		}
+	}
+
+	return nil
+}
+
+// DrainAll drains each of the given workers, all workers share the deadline of the context.
+//
+//	Every worker is drained even if the deadline passes, so none of them accept new requests, the first error is returned.
+func DrainAll[D Drainable](ctx context.Context, drainables []D) error {
+	var firstErr error
+
+	for _, d := range drainables {
+		if err := d.Drain(ctx); err != nil && firstErr == nil {
+			firstErr = err
+		}
+	}
+
+	return firstErr
+}
@@ -15,12 +15,15 @@
 package http
 
 import (
+	"context"
 	"fmt"
 	"net"
 	"sync"
+	"sync/atomic"
 	"time"
 
 	httppb "github.com/nitrictech/nitric/core/pkg/proto/http/v1"
+	"github.com/nitrictech/nitric/core/pkg/workers"
 	"github.com/valyala/fasthttp"
 )
 
@@ -28,11 +31,20 @@ type HttpServer struct {
 	Output chan error
 	host   string
 	lock   sync.Mutex
+	// the number of requests currently being proxied
+	inFlight atomic.Int64
+	draining atomic.Bool
 }
 
+var (
+	_ workers.InFlightReporter = &HttpServer{}
+	_ workers.Drainable        = &HttpServer{}
+	_ workers.Describable      = &HttpServer{}
+)
+
 type HttpRequestHandler interface {
 	httppb.HttpServer
-	HandleRequest(request *fasthttp.Request) (*fasthttp.Response, error)
+	HandleRequest(ctx context.Context, request *fasthttp.Request) (*fasthttp.Response, error)
 	WorkerCount() int
 }
 
@@ -69,6 +81,30 @@ func IsPortOpenWithRetry(host string, connectionTimeout time.Duration, maxRetrie
 	return <-done
 }
 
+// InFlight returns the number of requests currently being proxied to the HTTP server
+func (h *HttpServer) InFlight() int {
+	return int(h.inFlight.Load())
+}
+
+// Drain stops new requests being proxied and waits for in-flight requests to complete
+func (h *HttpServer) Drain(ctx context.Context) error {
+	h.draining.Store(true)
+
+	return workers.WaitForIdle(ctx, h)
+}
+
+// DescribeWorkers describes the proxied HTTP server, if one is registered
+func (h *HttpServer) DescribeWorkers() []workers.WorkerDescription {
+	if h.host == "" {
+		return []workers.WorkerDescription{}
+	}
+
+	return []workers.WorkerDescription{{
+		Name:     h.host,
+		InFlight: h.InFlight(),
+	}}
+}
+
 func (h *HttpServer) WorkerCount() int {
 	if h.host != "" {
 		return 1
@@ -127,19 +163,38 @@ func (h *HttpServer) Proxy(stream httppb.Http_ProxyServer) error {
 }
 
 // HandleRequest forwards proxy request to the underlying HTTP server
-func (srv *HttpServer) HandleRequest(request *fasthttp.Request) (*fasthttp.Response, error) {
+func (srv *HttpServer) HandleRequest(ctx context.Context, request *fasthttp.Request) (_ *fasthttp.Response, err error) {
 	if srv.host == "" {
 		return nil, fmt.Errorf("http server not registered")
 	}
 
+	if srv.draining.Load() {
+		return nil, fmt.Errorf("http server is draining, no new requests are accepted")
+	}
+
+	srv.inFlight.Add(1)
+	defer srv.inFlight.Add(-1)
+
 	requestCopy := &fasthttp.Request{}
 	var response fasthttp.Response
 
+	ctx, end := workers.StartRequest(ctx, workers.TriggerHttp, srv.host)
+	defer func() { end(err) }()
+
 	request.CopyTo(requestCopy)
 	requestCopy.URI().SetHost(srv.host)
 	requestCopy.URI().SetScheme("http")
 
-	if err := fasthttp.Do(requestCopy, &response); err != nil {
+	// continue the trace in the proxied server
+	for key, value := range workers.InjectTraceContext(ctx) {
+		requestCopy.Header.Set(key, value)
+	}
+
+	if deadline, ok := ctx.Deadline(); ok {
+		if err := fasthttp.DoDeadline(requestCopy, &response, deadline); err != nil {
+			return nil, err
+		}
+	} else if err := fasthttp.Do(requestCopy, &response); err != nil {
 		return nil, err
 	}
 
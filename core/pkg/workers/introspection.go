@@ -0,0 +1,30 @@
+// Copyright 2021 Nitric Technologies Pty Ltd.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package workers
+
+// WorkerDescription describes a registered worker, used to introspect the membrane
+type WorkerDescription struct {
+	// The resource the worker handles triggers for, e.g. the api, topic or bucket name
+	Name string `json:"name"`
+	// The triggers the worker handles for the resource, e.g. the route or event type
+	Trigger string `json:"trigger,omitempty"`
+	// The number of requests the worker is currently handling
+	InFlight int `json:"inFlight"`
+}
+
+// Describable is implemented by worker managers that can list their registered workers
+type Describable interface {
+	DescribeWorkers() []WorkerDescription
+}
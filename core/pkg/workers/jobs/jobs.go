@@ -15,7 +15,9 @@
 package jobs
 
 import (
+	"context"
 	"fmt"
+	"slices"
 	"sync"
 
 	"github.com/nitrictech/nitric/core/pkg/help"
@@ -29,15 +31,27 @@ type WorkerConnection = workers.WorkerRequestBroker[*batchpb.ServerMessage, *bat
 
 type JobRequestHandler interface {
 	batchpb.JobServer
-	HandleJobRequest(request *batchpb.ServerMessage) (*batchpb.ClientMessage, error)
+	HandleJobRequest(ctx context.Context, request *batchpb.ServerMessage) (*batchpb.ClientMessage, error)
 	WorkerCount() int
 }
 
-var _ JobRequestHandler = (*JobManager)(nil)
+var (
+	_ JobRequestHandler    = (*JobManager)(nil)
+	_ workers.LoadBalanced = (*JobManager)(nil)
+)
 
 type JobManager struct {
-	handlers map[JobName]*WorkerConnection
-	lock     sync.RWMutex
+	handlers     map[JobName][]*WorkerConnection
+	loadBalancer workers.LoadBalancer
+	lock         sync.RWMutex
+}
+
+// SetLoadBalancer sets the strategy used to choose between handlers registered for the same job
+func (s *JobManager) SetLoadBalancer(lb workers.LoadBalancer) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	s.loadBalancer = lb
 }
 
 func (s *JobManager) registerHandler(handler *WorkerConnection, registrationRequest *batchpb.RegistrationRequest) error {
@@ -46,28 +60,70 @@ func (s *JobManager) registerHandler(handler *WorkerConnection, registrationRequ
 
 	jobName := registrationRequest.GetJobName()
 
-	if _, exists := s.handlers[jobName]; exists {
-		// Only one job handler allowed per job
-		return fmt.Errorf("job handler already registered for job: %s", jobName)
-	}
-
-	s.handlers[jobName] = handler
+	// multiple handlers may register for the same job, requests are balanced between them
+	s.handlers[jobName] = append(s.handlers[jobName], handler)
 
 	return nil
 }
 
-func (s *JobManager) unregisterHandler(jobName string) {
+func (s *JobManager) unregisterHandler(jobName string, handler *WorkerConnection) {
 	s.lock.Lock()
 	defer s.lock.Unlock()
 
-	delete(s.handlers, jobName)
+	s.handlers[jobName] = slices.DeleteFunc(s.handlers[jobName], func(h *WorkerConnection) bool {
+		return h == handler
+	})
+
+	if len(s.handlers[jobName]) == 0 {
+		delete(s.handlers, jobName)
+	}
+}
+
+var (
+	_ workers.Drainable   = &JobManager{}
+	_ workers.Describable = &JobManager{}
+)
+
+// DescribeWorkers lists the handlers registered for each job
+func (s *JobManager) DescribeWorkers() []workers.WorkerDescription {
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+
+	descriptions := []workers.WorkerDescription{}
+	for jobName, handlers := range s.handlers {
+		for _, handler := range handlers {
+			descriptions = append(descriptions, workers.WorkerDescription{
+				Name:     jobName,
+				InFlight: handler.InFlight(),
+			})
+		}
+	}
+
+	return descriptions
+}
+
+// Drain waits for in-flight job runs to complete, handlers stop accepting new jobs
+func (s *JobManager) Drain(ctx context.Context) error {
+	s.lock.RLock()
+	connections := []*WorkerConnection{}
+	for _, handlers := range s.handlers {
+		connections = append(connections, handlers...)
+	}
+	s.lock.RUnlock()
+
+	return workers.DrainAll(ctx, connections)
 }
 
 func (s *JobManager) WorkerCount() int {
 	s.lock.RLock()
 	defer s.lock.RUnlock()
 
-	return len(s.handlers)
+	total := 0
+	for _, jobHandlers := range s.handlers {
+		total += len(jobHandlers)
+	}
+
+	return total
 }
 
 // Subscribe allows the local nitric server to register new subscribers
@@ -86,12 +142,12 @@ func (s *JobManager) HandleJob(handlerConnectionStream batchpb.Job_HandleJobServ
 		return fmt.Errorf("request received from unregistered subscriber, initial request must be a registration request. %s", help.BugInNitricHelpText())
 	}
 
-	handler := workers.NewWorkerRequestBroker[*batchpb.ServerMessage, *batchpb.ClientMessage](handlerConnectionStream)
+	handler := workers.NewWorkerRequestBroker[*batchpb.ServerMessage, *batchpb.ClientMessage](handlerConnectionStream, newCancellationRequest)
 	if err := s.registerHandler(handler, registrationRequest); err != nil {
 		return err
 	}
 
-	defer s.unregisterHandler(registrationRequest.GetJobName())
+	defer s.unregisterHandler(registrationRequest.GetJobName(), handler)
 
 	// send acknowledgement of registration
 	err = handlerConnectionStream.Send(&batchpb.ServerMessage{
@@ -111,19 +167,19 @@ func (s *JobManager) HandleJob(handlerConnectionStream batchpb.Job_HandleJobServ
 	return nil
 }
 
-// findMatchingSubscriber returns the subscribers for a given topic, or an error if none are found
+// findMatchingHandler selects the handler for a given job, or returns an error if none are found
 func (s *JobManager) findMatchingHandler(jobName string) (*WorkerConnection, error) {
 	s.lock.RLock()
 	defer s.lock.RUnlock()
-	worker, ok := s.handlers[jobName]
-	if !ok {
+	candidates, ok := s.handlers[jobName]
+	if !ok || len(candidates) == 0 {
 		return nil, fmt.Errorf("no worker registered for job: %s", jobName)
 	}
 
-	return worker, nil
+	return workers.SelectWorker(s.loadBalancer, jobName, candidates), nil
 }
 
-func (s *JobManager) HandleJobRequest(request *batchpb.ServerMessage) (*batchpb.ClientMessage, error) {
+func (s *JobManager) HandleJobRequest(ctx context.Context, request *batchpb.ServerMessage) (_ *batchpb.ClientMessage, err error) {
 	if request.Id == "" {
 		request.Id = workers.GenerateUniqueId()
 	}
@@ -134,21 +190,39 @@ func (s *JobManager) HandleJobRequest(request *batchpb.ServerMessage) (*batchpb.
 	}
 	jobName := messageRequest.GetJobName()
 
+	ctx, end := workers.StartRequest(ctx, workers.TriggerJob, jobName)
+	defer func() { end(err) }()
+
+	request.TraceContext = workers.InjectTraceContext(ctx)
+
 	handler, err := s.findMatchingHandler(jobName)
 	if err != nil {
 		return nil, err
 	}
 
-	resp, err := handler.Send(request)
+	resp, err := handler.Send(ctx, request)
 	if err != nil {
 		return nil, err
 	}
 	return *resp, nil
 }
 
+// newCancellationRequest notifies a worker that an in-flight request has been cancelled
+func newCancellationRequest(id workers.RequestIdentifier, reason string) *batchpb.ServerMessage {
+	return &batchpb.ServerMessage{
+		Id: id,
+		Content: &batchpb.ServerMessage_CancellationRequest{
+			CancellationRequest: &batchpb.CancellationRequest{
+				Reason: reason,
+			},
+		},
+	}
+}
+
 func New() *JobManager {
 	return &JobManager{
-		handlers: make(map[string]*WorkerConnection),
-		lock:     sync.RWMutex{},
+		handlers:     make(map[string][]*WorkerConnection),
+		loadBalancer: workers.RoundRobin(),
+		lock:         sync.RWMutex{},
 	}
 }
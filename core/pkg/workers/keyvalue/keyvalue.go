@@ -0,0 +1,262 @@
+// Copyright 2021 Nitric Technologies Pty Ltd.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package keyvalue
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+
+	"github.com/nitrictech/nitric/core/pkg/help"
+	kvstorepb "github.com/nitrictech/nitric/core/pkg/proto/kvstore/v1"
+	"github.com/nitrictech/nitric/core/pkg/workers"
+)
+
+// StoreName uniquely identifies a key/value store
+type StoreName = string
+
+// ErrNoListener is returned when no listener is registered for an event's store, event type and key
+var ErrNoListener = errors.New("no listener registered")
+
+// StoreListenerManager manages key/value listeners for different stores
+type StoreListenerManager struct {
+	listenerMap map[StoreName][]*StoreEventListener
+	mutex       sync.RWMutex
+}
+
+type StoreRequestHandler interface {
+	kvstorepb.KeyValueListenerServer
+	HandleRequest(ctx context.Context, request *kvstorepb.ServerMessage) (*kvstorepb.ClientMessage, error)
+	WorkerCount() int
+}
+
+// WorkerConnection handles communication between key/value stores and worker
+type WorkerConnection = workers.WorkerRequestBroker[*kvstorepb.ServerMessage, *kvstorepb.ClientMessage]
+
+// StoreEventListener listens for specific events on a key/value store
+type StoreEventListener struct {
+	connection     *WorkerConnection
+	storeName      StoreName
+	eventType      kvstorepb.KeyValueEventType
+	keyPrefixMatch string
+}
+
+// WorkerCount returns the total number of workers across all listeners
+func (s *StoreListenerManager) WorkerCount() int {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+
+	total := 0
+	for _, listeners := range s.listenerMap {
+		total += len(listeners)
+	}
+
+	return total
+}
+
+var (
+	_ workers.Drainable   = &StoreListenerManager{}
+	_ workers.Describable = &StoreListenerManager{}
+)
+
+// DescribeWorkers lists the listeners registered for each store
+func (s *StoreListenerManager) DescribeWorkers() []workers.WorkerDescription {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+
+	descriptions := []workers.WorkerDescription{}
+	for storeName, listeners := range s.listenerMap {
+		for _, listener := range listeners {
+			descriptions = append(descriptions, workers.WorkerDescription{
+				Name:     storeName,
+				Trigger:  fmt.Sprintf("%s %s*", listener.eventType.String(), listener.keyPrefixMatch),
+				InFlight: listener.connection.InFlight(),
+			})
+		}
+	}
+
+	return descriptions
+}
+
+// Drain waits for in-flight value events to complete, listeners stop accepting new events
+func (s *StoreListenerManager) Drain(ctx context.Context) error {
+	s.mutex.RLock()
+	connections := []*WorkerConnection{}
+	for _, listeners := range s.listenerMap {
+		for _, listener := range listeners {
+			connections = append(connections, listener.connection)
+		}
+	}
+	s.mutex.RUnlock()
+
+	return workers.DrainAll(ctx, connections)
+}
+
+// findMatchingListener or error if not found, for specific store, event type, and key prefix
+func (s *StoreListenerManager) findMatchingListener(storeName StoreName, eventType kvstorepb.KeyValueEventType, key string) (*StoreEventListener, error) {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+
+	for _, listener := range s.listenerMap[storeName] {
+		if listener.eventType == eventType && strings.HasPrefix(key, listener.keyPrefixMatch) {
+			return listener, nil
+		}
+	}
+
+	return nil, fmt.Errorf("%w for store %s and eventType %s with prefix matcher that matches key %s", ErrNoListener, storeName, eventType, key)
+}
+
+// mutualPrefixCheck returns true if either string starts with the other
+func mutualPrefixCheck(prefix, other string) bool {
+	return strings.HasPrefix(prefix, other) || strings.HasPrefix(other, prefix)
+}
+
+var _ kvstorepb.KeyValueListenerServer = &StoreListenerManager{}
+
+// registerListener adds a new listener for a given registration request
+func (s *StoreListenerManager) registerListener(registration *kvstorepb.RegistrationRequest, stream kvstorepb.KeyValueListener_ListenServer) (*StoreEventListener, error) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	storeName := registration.GetStoreName()
+	eventType := registration.GetEventType()
+	prefixFilter := registration.GetKeyPrefixFilter()
+	if prefixFilter == "*" {
+		prefixFilter = ""
+	}
+
+	// Prevent overlapping prefixes for the same store and event type
+	for _, existingListener := range s.listenerMap[storeName] {
+		if existingListener.eventType != eventType {
+			continue
+		}
+		if mutualPrefixCheck(existingListener.keyPrefixMatch, prefixFilter) {
+			return nil, fmt.Errorf("overlapping listener key prefixes %s and %s for store '%s'", existingListener.keyPrefixMatch, prefixFilter, storeName)
+		}
+	}
+
+	listener := &StoreEventListener{
+		connection:     workers.NewWorkerRequestBroker[*kvstorepb.ServerMessage, *kvstorepb.ClientMessage](stream, newCancellationRequest),
+		storeName:      storeName,
+		eventType:      eventType,
+		keyPrefixMatch: prefixFilter,
+	}
+	s.listenerMap[storeName] = append(s.listenerMap[storeName], listener)
+
+	return listener, nil
+}
+
+func (s *StoreListenerManager) unregisterListener(listener *StoreEventListener) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	listeners := s.listenerMap[listener.storeName]
+	for i, l := range listeners {
+		if l == listener {
+			s.listenerMap[listener.storeName] = append(listeners[:i:i], listeners[i+1:]...)
+			break
+		}
+	}
+
+	if len(s.listenerMap[listener.storeName]) == 0 {
+		delete(s.listenerMap, listener.storeName)
+	}
+}
+
+// Listen handles incoming stream connections for key/value events
+func (s *StoreListenerManager) Listen(stream kvstorepb.KeyValueListener_ListenServer) error {
+	initialMessage, err := stream.Recv()
+	if err != nil {
+		return err
+	}
+
+	registration := initialMessage.GetRegistrationRequest()
+	if registration == nil {
+		return fmt.Errorf("request received from unregistered key/value listener, initial request must be a registration request. %s", help.BugInNitricHelpText())
+	}
+
+	listener, err := s.registerListener(registration, stream)
+	if err != nil {
+		return err
+	}
+
+	defer s.unregisterListener(listener)
+
+	// Send acknowledgement of registration
+	err = stream.Send(&kvstorepb.ServerMessage{
+		Content: &kvstorepb.ServerMessage_RegistrationResponse{
+			RegistrationResponse: &kvstorepb.RegistrationResponse{},
+		},
+	})
+	if err != nil {
+		return err
+	}
+
+	return listener.connection.Run()
+}
+
+// HandleRequest processes incoming requests and directs them to the appropriate listener
+func (s *StoreListenerManager) HandleRequest(ctx context.Context, request *kvstorepb.ServerMessage) (_ *kvstorepb.ClientMessage, err error) {
+	if request.Id == "" {
+		request.Id = workers.GenerateUniqueId()
+	}
+
+	eventRequest := request.GetKeyValueEventRequest()
+	if eventRequest == nil {
+		return nil, fmt.Errorf("received unhandled request message type %T", request.Content)
+	}
+
+	storeName := eventRequest.GetStoreName()
+	eventType := eventRequest.GetKeyValueEvent().GetType()
+	key := eventRequest.GetKeyValueEvent().GetKey()
+
+	ctx, end := workers.StartRequest(ctx, workers.TriggerKeyValue, storeName)
+	defer func() { end(err) }()
+
+	request.TraceContext = workers.InjectTraceContext(ctx)
+
+	listener, err := s.findMatchingListener(storeName, eventType, key)
+	if err != nil {
+		return nil, err
+	}
+
+	response, err := listener.connection.Send(ctx, request)
+	if err != nil {
+		return nil, err
+	}
+
+	return *response, nil
+}
+
+// newCancellationRequest notifies a worker that an in-flight request has been cancelled
+func newCancellationRequest(id workers.RequestIdentifier, reason string) *kvstorepb.ServerMessage {
+	return &kvstorepb.ServerMessage{
+		Id: id,
+		Content: &kvstorepb.ServerMessage_CancellationRequest{
+			CancellationRequest: &kvstorepb.CancellationRequest{
+				Reason: reason,
+			},
+		},
+	}
+}
+
+func New() *StoreListenerManager {
+	return &StoreListenerManager{
+		listenerMap: make(map[StoreName][]*StoreEventListener),
+		mutex:       sync.RWMutex{},
+	}
+}
@@ -0,0 +1,245 @@
+// Copyright 2021 Nitric Technologies Pty Ltd.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package keyvalue_test
+
+import (
+	"context"
+	"errors"
+	"io"
+	"sync"
+
+	"google.golang.org/grpc"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	kvstorepb "github.com/nitrictech/nitric/core/pkg/proto/kvstore/v1"
+	"github.com/nitrictech/nitric/core/pkg/workers/keyvalue"
+)
+
+// listenerStream is a listener's stream that registers with the first message it receives and
+// answers every event request with its configured result
+type listenerStream struct {
+	grpc.ServerStream
+
+	registration *kvstorepb.ClientMessage
+	success      bool
+
+	sent      chan *kvstorepb.ServerMessage
+	responses chan *kvstorepb.ClientMessage
+	// closed when the listener starts waiting for event responses
+	receiving chan struct{}
+
+	lock     sync.Mutex
+	received int
+}
+
+func (l *listenerStream) Send(msg *kvstorepb.ServerMessage) error {
+	l.sent <- msg
+
+	if msg.GetKeyValueEventRequest() != nil {
+		l.responses <- &kvstorepb.ClientMessage{
+			Id: msg.Id,
+			Content: &kvstorepb.ClientMessage_KeyValueEventResponse{
+				KeyValueEventResponse: &kvstorepb.KeyValueEventResponse{
+					Success: l.success,
+				},
+			},
+		}
+	}
+
+	return nil
+}
+
+func (l *listenerStream) Recv() (*kvstorepb.ClientMessage, error) {
+	l.lock.Lock()
+	l.received++
+	received := l.received
+	l.lock.Unlock()
+
+	if received == 1 {
+		return l.registration, nil
+	}
+
+	if received == 2 {
+		close(l.receiving)
+	}
+
+	msg, ok := <-l.responses
+	if !ok {
+		return nil, io.EOF
+	}
+
+	return msg, nil
+}
+
+// disconnect ends the listener's stream
+func (l *listenerStream) disconnect() {
+	close(l.responses)
+}
+
+func newListenerStream(storeName string, eventType kvstorepb.KeyValueEventType, prefix string) *listenerStream {
+	return &listenerStream{
+		registration: &kvstorepb.ClientMessage{
+			Content: &kvstorepb.ClientMessage_RegistrationRequest{
+				RegistrationRequest: &kvstorepb.RegistrationRequest{
+					StoreName:       storeName,
+					EventType:       eventType,
+					KeyPrefixFilter: prefix,
+				},
+			},
+		},
+		success:   true,
+		sent:      make(chan *kvstorepb.ServerMessage, 10),
+		responses: make(chan *kvstorepb.ClientMessage, 10),
+		receiving: make(chan struct{}),
+	}
+}
+
+func newEventRequest(storeName string, eventType kvstorepb.KeyValueEventType, key string) *kvstorepb.ServerMessage {
+	return &kvstorepb.ServerMessage{
+		Content: &kvstorepb.ServerMessage_KeyValueEventRequest{
+			KeyValueEventRequest: &kvstorepb.KeyValueEventRequest{
+				StoreName: storeName,
+				Event: &kvstorepb.KeyValueEventRequest_KeyValueEvent{
+					KeyValueEvent: &kvstorepb.KeyValueEvent{
+						Key:  key,
+						Type: eventType,
+					},
+				},
+			},
+		},
+	}
+}
+
+var _ = Describe("StoreListenerManager", func() {
+	var manager *keyvalue.StoreListenerManager
+
+	// listen connects a listener to the manager, returning once it's ready for events
+	listen := func(stream *listenerStream) <-chan error {
+		done := make(chan error, 1)
+		go func() {
+			done <- manager.Listen(stream)
+		}()
+
+		Eventually(stream.receiving).Should(BeClosed())
+
+		return done
+	}
+
+	BeforeEach(func() {
+		manager = keyvalue.New()
+	})
+
+	When("the first message isn't a registration request", func() {
+		It("should reject the listener", func() {
+			stream := newListenerStream("store", kvstorepb.KeyValueEventType_Set, "")
+			stream.registration = &kvstorepb.ClientMessage{}
+
+			err := manager.Listen(stream)
+
+			Expect(err).Should(HaveOccurred())
+			Expect(manager.WorkerCount()).To(Equal(0))
+		})
+	})
+
+	When("a listener registers", func() {
+		var stream *listenerStream
+
+		BeforeEach(func() {
+			stream = newListenerStream("store", kvstorepb.KeyValueEventType_Set, "users/")
+			listen(stream)
+		})
+
+		AfterEach(func() {
+			stream.disconnect()
+		})
+
+		It("should acknowledge the registration", func() {
+			var ack *kvstorepb.ServerMessage
+			Eventually(stream.sent).Should(Receive(&ack))
+			Expect(ack.GetRegistrationResponse()).ToNot(BeNil())
+			Expect(manager.WorkerCount()).To(Equal(1))
+		})
+
+		It("should deliver events for keys matching its prefix", func() {
+			resp, err := manager.HandleRequest(context.TODO(), newEventRequest("store", kvstorepb.KeyValueEventType_Set, "users/1"))
+
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(resp.GetKeyValueEventResponse().GetSuccess()).To(BeTrue())
+		})
+
+		It("should return ErrNoListener for keys outside its prefix", func() {
+			_, err := manager.HandleRequest(context.TODO(), newEventRequest("store", kvstorepb.KeyValueEventType_Set, "orders/1"))
+
+			Expect(errors.Is(err, keyvalue.ErrNoListener)).To(BeTrue())
+		})
+
+		It("should return ErrNoListener for other event types", func() {
+			_, err := manager.HandleRequest(context.TODO(), newEventRequest("store", kvstorepb.KeyValueEventType_Delete, "users/1"))
+
+			Expect(errors.Is(err, keyvalue.ErrNoListener)).To(BeTrue())
+		})
+
+		It("should return ErrNoListener for other stores", func() {
+			_, err := manager.HandleRequest(context.TODO(), newEventRequest("other", kvstorepb.KeyValueEventType_Set, "users/1"))
+
+			Expect(errors.Is(err, keyvalue.ErrNoListener)).To(BeTrue())
+		})
+
+		It("should reject listeners with overlapping prefixes for the same event type", func() {
+			err := manager.Listen(newListenerStream("store", kvstorepb.KeyValueEventType_Set, "users/admins/"))
+
+			Expect(err).Should(HaveOccurred())
+			Expect(manager.WorkerCount()).To(Equal(1))
+		})
+
+		It("should accept listeners with overlapping prefixes for other event types", func() {
+			other := newListenerStream("store", kvstorepb.KeyValueEventType_Delete, "users/")
+			listen(other)
+			defer other.disconnect()
+
+			Expect(manager.WorkerCount()).To(Equal(2))
+		})
+	})
+
+	When("a listener's handler fails", func() {
+		It("should return the unsuccessful response", func() {
+			stream := newListenerStream("store", kvstorepb.KeyValueEventType_Set, "*")
+			stream.success = false
+			listen(stream)
+			defer stream.disconnect()
+
+			resp, err := manager.HandleRequest(context.TODO(), newEventRequest("store", kvstorepb.KeyValueEventType_Set, "any"))
+
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(resp.GetKeyValueEventResponse().GetSuccess()).To(BeFalse())
+		})
+	})
+
+	When("a listener disconnects", func() {
+		It("should no longer deliver it events", func() {
+			stream := newListenerStream("store", kvstorepb.KeyValueEventType_Set, "*")
+			done := listen(stream)
+
+			stream.disconnect()
+			Eventually(done).Should(Receive())
+
+			Expect(manager.WorkerCount()).To(Equal(0))
+			_, err := manager.HandleRequest(context.TODO(), newEventRequest("store", kvstorepb.KeyValueEventType_Set, "any"))
+			Expect(errors.Is(err, keyvalue.ErrNoListener)).To(BeTrue())
+		})
+	})
+})
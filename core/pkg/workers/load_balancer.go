@@ -0,0 +1,138 @@
+// Copyright 2021 Nitric Technologies Pty Ltd.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package workers
+
+import (
+	"fmt"
+	"math/rand/v2"
+	"sync"
+)
+
+// InFlightReporter is a worker that can report how many requests it is currently handling
+type InFlightReporter interface {
+	InFlight() int
+}
+
+// LoadBalancer selects which of several equivalent workers should handle a request
+type LoadBalancer interface {
+	// Select returns the index of the worker that should handle the next request for the given key.
+	//	inFlight holds the number of requests each candidate is currently handling and is never empty.
+	Select(key string, inFlight []int) int
+}
+
+// LoadBalanced is implemented by worker managers that support a configurable LoadBalancer
+type LoadBalanced interface {
+	SetLoadBalancer(lb LoadBalancer)
+}
+
+type roundRobinLoadBalancer struct {
+	lock     sync.Mutex
+	counters map[string]uint64
+}
+
+func (r *roundRobinLoadBalancer) next(key string, size int) int {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	count := r.counters[key]
+	r.counters[key] = count + 1
+
+	return int(count % uint64(size)) //#nosec G115 -- result is always less than size
+}
+
+func (r *roundRobinLoadBalancer) Select(key string, inFlight []int) int {
+	return r.next(key, len(inFlight))
+}
+
+// RoundRobin distributes requests evenly across workers, in turn
+func RoundRobin() LoadBalancer {
+	return &roundRobinLoadBalancer{
+		counters: map[string]uint64{},
+	}
+}
+
+type leastInFlightLoadBalancer struct {
+	tieBreaker *roundRobinLoadBalancer
+}
+
+func (l *leastInFlightLoadBalancer) Select(key string, inFlight []int) int {
+	least := []int{}
+
+	for i, count := range inFlight {
+		if len(least) == 0 || count < inFlight[least[0]] {
+			least = []int{i}
+		} else if count == inFlight[least[0]] {
+			least = append(least, i)
+		}
+	}
+
+	// spread requests across idle workers, rather than always favouring the first
+	return least[l.tieBreaker.next(key, len(least))]
+}
+
+// LeastInFlight sends requests to the worker currently handling the fewest requests
+func LeastInFlight() LoadBalancer {
+	return &leastInFlightLoadBalancer{
+		tieBreaker: &roundRobinLoadBalancer{
+			counters: map[string]uint64{},
+		},
+	}
+}
+
+type randomLoadBalancer struct{}
+
+func (randomLoadBalancer) Select(key string, inFlight []int) int {
+	return rand.IntN(len(inFlight)) //#nosec G404 -- load balancing doesn't require a secure random source
+}
+
+// Random sends each request to a randomly selected worker
+func Random() LoadBalancer {
+	return randomLoadBalancer{}
+}
+
+// LoadBalancerFromString returns the load balancer with the given name,
+// one of "round-robin", "least-in-flight" or "random".
+func LoadBalancerFromString(name string) (LoadBalancer, error) {
+	switch name {
+	case "round-robin":
+		return RoundRobin(), nil
+	case "least-in-flight":
+		return LeastInFlight(), nil
+	case "random":
+		return Random(), nil
+	default:
+		return nil, fmt.Errorf("unknown load balancer %q, expected one of round-robin, least-in-flight or random", name)
+	}
+}
+
+// SelectWorker picks one of the candidate workers using the given load balancer,
+// the first candidate is always selected if no load balancer is provided and the zero value is returned if there are no candidates.
+func SelectWorker[W InFlightReporter](lb LoadBalancer, key string, candidates []W) W {
+	if len(candidates) == 0 {
+		var none W
+		return none
+	}
+
+	if lb == nil || len(candidates) == 1 {
+		return candidates[0]
+	}
+
+	inFlight := make([]int, len(candidates))
+	for i, candidate := range candidates {
+		inFlight[i] = candidate.InFlight()
+	}
+
+	return candidates[lb.Select(key, inFlight)]
+}
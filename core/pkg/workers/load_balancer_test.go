@@ -0,0 +1,116 @@
+// Copyright 2021 Nitric Technologies Pty Ltd.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package workers
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/ginkgo/extensions/table"
+	. "github.com/onsi/gomega"
+)
+
+// testWorker reports a fixed number of in-flight requests
+type testWorker struct {
+	name     string
+	inFlight int
+}
+
+func (t *testWorker) InFlight() int {
+	return t.inFlight
+}
+
+// selections returns the indexes selected by consecutive calls to the load balancer
+func selections(lb LoadBalancer, key string, inFlight []int, count int) []int {
+	selected := []int{}
+	for i := 0; i < count; i++ {
+		selected = append(selected, lb.Select(key, inFlight))
+	}
+
+	return selected
+}
+
+var _ = Describe("LoadBalancer", func() {
+	DescribeTable("RoundRobin",
+		func(inFlight []int, expected []int) {
+			Expect(selections(RoundRobin(), "key", inFlight, len(expected))).To(Equal(expected))
+		},
+		Entry("a single worker", []int{0}, []int{0, 0, 0}),
+		Entry("several idle workers", []int{0, 0, 0}, []int{0, 1, 2, 0, 1, 2}),
+		Entry("ignoring in-flight requests", []int{5, 0}, []int{0, 1, 0, 1}),
+	)
+
+	It("RoundRobin should take turns separately for each key", func() {
+		lb := RoundRobin()
+
+		Expect(lb.Select("a", []int{0, 0})).To(Equal(0))
+		Expect(lb.Select("b", []int{0, 0})).To(Equal(0))
+		Expect(lb.Select("a", []int{0, 0})).To(Equal(1))
+	})
+
+	DescribeTable("LeastInFlight",
+		func(inFlight []int, expected []int) {
+			Expect(selections(LeastInFlight(), "key", inFlight, len(expected))).To(Equal(expected))
+		},
+		Entry("a single worker", []int{3}, []int{0, 0}),
+		Entry("a single least busy worker", []int{2, 0, 1}, []int{1, 1, 1}),
+		Entry("taking turns between tied workers", []int{1, 0, 2, 0}, []int{1, 3, 1, 3}),
+		Entry("taking turns between all idle workers", []int{0, 0, 0}, []int{0, 1, 2, 0}),
+	)
+
+	DescribeTable("Random",
+		func(inFlight []int) {
+			for _, selected := range selections(Random(), "key", inFlight, 100) {
+				Expect(selected).To(BeNumerically(">=", 0))
+				Expect(selected).To(BeNumerically("<", len(inFlight)))
+			}
+		},
+		Entry("a single worker", []int{0}),
+		Entry("several workers", []int{0, 3, 1}),
+	)
+
+	DescribeTable("LoadBalancerFromString",
+		func(name string, expected LoadBalancer) {
+			lb, err := LoadBalancerFromString(name)
+
+			if expected == nil {
+				Expect(err).To(HaveOccurred())
+				Expect(lb).To(BeNil())
+				return
+			}
+
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(lb).To(BeAssignableToTypeOf(expected))
+		},
+		Entry("round-robin", "round-robin", RoundRobin()),
+		Entry("least-in-flight", "least-in-flight", LeastInFlight()),
+		Entry("random", "random", Random()),
+		Entry("an unknown name", "fastest", nil),
+		Entry("a blank name", "", nil),
+		Entry("a name in the wrong case", "Round-Robin", nil),
+	)
+
+	Context("SelectWorker", func() {
+		workers := []*testWorker{{name: "busy", inFlight: 2}, {name: "idle", inFlight: 0}}
+
+		DescribeTable("selecting a worker",
+			func(lb LoadBalancer, candidates []*testWorker, expected *testWorker) {
+				Expect(SelectWorker(lb, "key", candidates)).To(Equal(expected))
+			},
+			Entry("no candidates", LeastInFlight(), []*testWorker{}, nil),
+			Entry("no load balancer", nil, workers, workers[0]),
+			Entry("a single candidate", LeastInFlight(), workers[:1], workers[0]),
+			Entry("the least busy candidate", LeastInFlight(), workers, workers[1]),
+		)
+	})
+})
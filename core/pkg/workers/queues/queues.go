@@ -0,0 +1,496 @@
+// Copyright 2021 Nitric Technologies Pty Ltd.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package queues
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/nitrictech/nitric/core/pkg/help"
+	"github.com/nitrictech/nitric/core/pkg/logger"
+	queuespb "github.com/nitrictech/nitric/core/pkg/proto/queues/v1"
+	"github.com/nitrictech/nitric/core/pkg/workers"
+	"google.golang.org/protobuf/types/known/durationpb"
+)
+
+type QueueName = string
+
+// The number of messages dequeued or delivered together when the listener doesn't set a batch size
+const defaultBatchSize = 10
+
+// How long a polling listener waits before dequeueing again after finding its queue empty
+const defaultPollInterval = time.Second
+
+// How long dequeued messages are leased for, leases are extended while listeners are still handling their messages
+const defaultLeaseDuration = 30 * time.Second
+
+// The longest a listener may handle a dequeued message before it's cancelled and the message is released for redelivery
+const defaultHandleTimeout = 15 * time.Minute
+
+type WorkerConnection = workers.WorkerRequestBroker[*queuespb.ServerMessage, *queuespb.ClientMessage]
+
+type QueueRequestHandler interface {
+	queuespb.QueueListenerServer
+	HandleRequest(ctx context.Context, request *queuespb.ServerMessage) (*queuespb.ClientMessage, error)
+	WorkerCount() int
+}
+
+// QueueListener is a worker registered to handle the messages of a queue
+type QueueListener struct {
+	connection *WorkerConnection
+	queueName  QueueName
+	batchSize  int
+	// holds a slot for each message the worker is handling, limiting its concurrency
+	slots chan struct{}
+}
+
+// InFlight returns the number of messages the listener is handling
+func (l *QueueListener) InFlight() int {
+	return l.connection.InFlight()
+}
+
+// acquire waits for the listener to have capacity to handle another message
+func (l *QueueListener) acquire(ctx context.Context) error {
+	select {
+	case l.slots <- struct{}{}:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+func (l *QueueListener) release() {
+	<-l.slots
+}
+
+// QueueListenerManager dispatches queue messages to the listeners registered for each queue.
+//
+//	When created with a queues plugin the manager dequeues messages for its listeners, completing them when they're handled
+//	successfully and releasing them otherwise. Without one, the provider delivers messages through HandleRequest, e.g. from an event source.
+type QueueListenerManager struct {
+	listenerMap  map[QueueName][]*QueueListener
+	loadBalancer workers.LoadBalancer
+	mutex        sync.RWMutex
+
+	queues        queuespb.QueuesServer
+	pollInterval  time.Duration
+	leaseDuration time.Duration
+	handleTimeout time.Duration
+	// stops listeners polling for new messages, e.g. while draining
+	pollCtx     context.Context
+	stopPolling context.CancelFunc
+}
+
+var (
+	_ queuespb.QueueListenerServer = &QueueListenerManager{}
+	_ workers.LoadBalanced         = &QueueListenerManager{}
+	_ workers.Drainable            = &QueueListenerManager{}
+	_ workers.Describable          = &QueueListenerManager{}
+)
+
+// SetLoadBalancer sets the strategy used to choose between listeners registered for the same queue
+func (s *QueueListenerManager) SetLoadBalancer(lb workers.LoadBalancer) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	s.loadBalancer = lb
+}
+
+func (s *QueueListenerManager) registerListener(stream queuespb.QueueListener_ListenServer, registration *queuespb.RegistrationRequest) (*QueueListener, error) {
+	if registration.GetQueueName() == "" {
+		return nil, fmt.Errorf("queue listeners must provide a queue name")
+	}
+
+	if registration.GetBatchSize() < 0 || registration.GetConcurrency() < 0 {
+		return nil, fmt.Errorf("batch size and concurrency of queue listeners must not be negative")
+	}
+
+	batchSize := int(registration.GetBatchSize())
+	if batchSize == 0 {
+		batchSize = defaultBatchSize
+	}
+
+	concurrency := int(registration.GetConcurrency())
+	if concurrency == 0 {
+		concurrency = batchSize
+	}
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	// multiple listeners may register for the same queue, they compete for its messages
+	listener := &QueueListener{
+		connection: workers.NewWorkerRequestBroker[*queuespb.ServerMessage, *queuespb.ClientMessage](stream, newCancellationRequest),
+		queueName:  registration.GetQueueName(),
+		batchSize:  batchSize,
+		slots:      make(chan struct{}, concurrency),
+	}
+	s.listenerMap[listener.queueName] = append(s.listenerMap[listener.queueName], listener)
+
+	return listener, nil
+}
+
+func (s *QueueListenerManager) unregisterListener(listener *QueueListener) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	s.listenerMap[listener.queueName] = slices.DeleteFunc(s.listenerMap[listener.queueName], func(l *QueueListener) bool {
+		return l == listener
+	})
+
+	if len(s.listenerMap[listener.queueName]) == 0 {
+		delete(s.listenerMap, listener.queueName)
+	}
+}
+
+// Drain stops listeners polling for new messages and waits for in-flight messages to be handled
+func (s *QueueListenerManager) Drain(ctx context.Context) error {
+	s.stopPolling()
+
+	s.mutex.RLock()
+	connections := []*WorkerConnection{}
+	for _, listeners := range s.listenerMap {
+		for _, listener := range listeners {
+			connections = append(connections, listener.connection)
+		}
+	}
+	s.mutex.RUnlock()
+
+	return workers.DrainAll(ctx, connections)
+}
+
+// DescribeWorkers lists the listeners registered for each queue
+func (s *QueueListenerManager) DescribeWorkers() []workers.WorkerDescription {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+
+	descriptions := []workers.WorkerDescription{}
+	for queueName, listeners := range s.listenerMap {
+		for _, listener := range listeners {
+			descriptions = append(descriptions, workers.WorkerDescription{
+				Name:     queueName,
+				Trigger:  fmt.Sprintf("batch size %d, concurrency %d", listener.batchSize, cap(listener.slots)),
+				InFlight: listener.InFlight(),
+			})
+		}
+	}
+
+	return descriptions
+}
+
+func (s *QueueListenerManager) WorkerCount() int {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+
+	count := 0
+	for _, listeners := range s.listenerMap {
+		count += len(listeners)
+	}
+
+	return count
+}
+
+// Listen allows the local nitric server to register new queue listeners
+//
+//	called by Nitric applications wishing to have the messages of a queue pushed to them.
+//	the stream establishes communication between that listener and this server
+func (s *QueueListenerManager) Listen(stream queuespb.QueueListener_ListenServer) error {
+	// The client MUST send registration request on the stream as its first message
+	initialRequest, err := stream.Recv()
+	if err != nil {
+		return err
+	}
+
+	registrationRequest := initialRequest.GetRegistrationRequest()
+	if registrationRequest == nil {
+		return fmt.Errorf("request received from unregistered queue listener, initial request must be a registration request. %s", help.BugInNitricHelpText())
+	}
+
+	listener, err := s.registerListener(stream, registrationRequest)
+	if err != nil {
+		return err
+	}
+
+	defer s.unregisterListener(listener)
+
+	// send acknowledgement of registration
+	err = stream.Send(&queuespb.ServerMessage{
+		Content: &queuespb.ServerMessage_RegistrationResponse{
+			RegistrationResponse: &queuespb.RegistrationResponse{},
+		},
+	})
+	if err != nil {
+		return err
+	}
+
+	if s.queues != nil {
+		ctx, cancel := context.WithCancel(s.pollCtx)
+		defer cancel()
+
+		go s.poll(ctx, listener)
+	}
+
+	err = listener.connection.Run()
+	if err != nil {
+		return fmt.Errorf("queue listener connection broker encountered an error: %w", err)
+	}
+
+	return nil
+}
+
+// poll dequeues messages for the listener until the context is done, as long as the listener has capacity to handle them
+func (s *QueueListenerManager) poll(ctx context.Context, listener *QueueListener) {
+	for {
+		// wait for capacity before dequeueing, so messages aren't leased while they can't be handled
+		if err := listener.acquire(ctx); err != nil {
+			return
+		}
+
+		// dequeue no more messages than the listener has capacity for, including the slot just acquired
+		depth := min(listener.batchSize, cap(listener.slots)-len(listener.slots)+1)
+
+		resp, err := s.queues.Dequeue(ctx, &queuespb.QueueDequeueRequest{
+			QueueName:         listener.queueName,
+			Depth:             int32(depth), //#nosec G115 -- depth is bounded by the batch size
+			VisibilityTimeout: durationpb.New(s.leaseDuration),
+		})
+		if err != nil || len(resp.Messages) == 0 {
+			listener.release()
+
+			if ctx.Err() != nil {
+				return
+			}
+
+			if err != nil {
+				logger.Errorf("error dequeueing messages for listener on queue %s: %v", listener.queueName, err)
+			}
+
+			select {
+			case <-ctx.Done():
+				return
+			case <-time.After(s.pollInterval):
+			}
+
+			continue
+		}
+
+		for i, message := range resp.Messages {
+			// the first message uses the slot acquired before dequeueing
+			if i > 0 {
+				if err := listener.acquire(ctx); err != nil {
+					// the remaining messages are redelivered once their leases expire
+					return
+				}
+			}
+
+			go func(message *queuespb.DequeuedMessage) {
+				defer listener.release()
+
+				s.handleDequeuedMessage(ctx, listener, message)
+			}(message)
+		}
+	}
+}
+
+// handleDequeuedMessage dispatches a dequeued message to the listener, completing it on success and releasing it otherwise.
+//
+//	the message's lease is extended while the listener handles it, for up to the handle timeout.
+//	the message is completed or released even if polling stops while it's being handled.
+func (s *QueueListenerManager) handleDequeuedMessage(ctx context.Context, listener *QueueListener, message *queuespb.DequeuedMessage) {
+	handleCtx, cancel := context.WithTimeout(context.WithoutCancel(ctx), s.handleTimeout)
+	defer cancel()
+
+	releaseLease := s.holdLease(handleCtx, listener.queueName, message.LeaseId)
+
+	resp, err := s.dispatch(handleCtx, listener, &queuespb.ServerMessage{
+		Id: workers.GenerateUniqueId(),
+		Content: &queuespb.ServerMessage_MessageRequest{
+			MessageRequest: &queuespb.MessageRequest{
+				QueueName:       listener.queueName,
+				Message:         message.Message,
+				DeliveryAttempt: message.DeliveryAttempt,
+			},
+		},
+	})
+
+	leaseId := releaseLease()
+
+	// the message is redelivered once its lease expires anyway, so there's no use settling it after that
+	settleCtx, cancelSettle := context.WithTimeout(context.WithoutCancel(ctx), s.leaseDuration)
+	defer cancelSettle()
+
+	if err == nil && resp.GetMessageResponse().GetSuccess() {
+		_, err = s.queues.Complete(settleCtx, &queuespb.QueueCompleteRequest{
+			QueueName: listener.queueName,
+			LeaseId:   leaseId,
+		})
+		if err != nil {
+			logger.Errorf("error completing message on queue %s: %v", listener.queueName, err)
+		}
+
+		return
+	}
+
+	if err != nil {
+		logger.Errorf("error handling message on queue %s: %v", listener.queueName, err)
+	}
+
+	_, err = s.queues.Release(settleCtx, &queuespb.QueueReleaseRequest{
+		QueueName: listener.queueName,
+		LeaseId:   leaseId,
+	})
+	if err != nil {
+		logger.Errorf("error releasing message on queue %s: %v", listener.queueName, err)
+	}
+}
+
+// holdLease extends a message's lease until the returned function is called or the context is done.
+//
+//	the returned function stops extending the lease and returns the message's current lease id,
+//	since some providers issue a new lease id each time a lease is extended, e.g. Azure Storage Queue pop receipts.
+func (s *QueueListenerManager) holdLease(ctx context.Context, queueName QueueName, leaseId string) func() string {
+	stop := make(chan struct{})
+	current := make(chan string, 1)
+
+	go func() {
+		// extend the lease halfway through, leaving time for the extension to complete before it expires
+		ticker := time.NewTicker(s.leaseDuration / 2)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-stop:
+				current <- leaseId
+				return
+			case <-ticker.C:
+				if ctx.Err() != nil {
+					continue
+				}
+
+				resp, err := s.queues.ExtendLease(ctx, &queuespb.QueueExtendLeaseRequest{
+					QueueName:         queueName,
+					LeaseId:           leaseId,
+					VisibilityTimeout: durationpb.New(s.leaseDuration),
+				})
+				if err != nil {
+					logger.Errorf("error extending lease of message on queue %s: %v", queueName, err)
+					continue
+				}
+
+				if resp.GetLeaseId() != "" {
+					leaseId = resp.GetLeaseId()
+				}
+			}
+		}
+	}()
+
+	return func() string {
+		close(stop)
+		return <-current
+	}
+}
+
+// dispatch sends a message request to a specific listener
+func (s *QueueListenerManager) dispatch(ctx context.Context, listener *QueueListener, request *queuespb.ServerMessage) (_ *queuespb.ClientMessage, err error) {
+	ctx, end := workers.StartRequest(ctx, workers.TriggerQueue, listener.queueName)
+	defer func() { end(err) }()
+
+	request.TraceContext = workers.InjectTraceContext(ctx)
+
+	response, err := listener.connection.Send(ctx, request)
+	if err != nil {
+		return nil, err
+	}
+
+	return *response, nil
+}
+
+// findMatchingListener returns a listener for the given queue, or an error if none are found
+func (s *QueueListenerManager) findMatchingListener(queueName QueueName) (*QueueListener, error) {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+
+	candidates, ok := s.listenerMap[queueName]
+	if !ok || len(candidates) == 0 {
+		return nil, fmt.Errorf("no listeners registered for queue: %s", queueName)
+	}
+
+	return workers.SelectWorker(s.loadBalancer, queueName, candidates), nil
+}
+
+// HandleRequest delivers a message pushed by the provider to a listener for its queue, waiting for the listener to have capacity
+func (s *QueueListenerManager) HandleRequest(ctx context.Context, request *queuespb.ServerMessage) (*queuespb.ClientMessage, error) {
+	if request.Id == "" {
+		request.Id = workers.GenerateUniqueId()
+	}
+
+	messageRequest := request.GetMessageRequest()
+	if messageRequest == nil {
+		return nil, fmt.Errorf("invalid request, expected message request. %s", help.BugInNitricHelpText())
+	}
+
+	listener, err := s.findMatchingListener(messageRequest.GetQueueName())
+	if err != nil {
+		return nil, err
+	}
+
+	if err := listener.acquire(ctx); err != nil {
+		return nil, err
+	}
+	defer listener.release()
+
+	return s.dispatch(ctx, listener, request)
+}
+
+// newCancellationRequest notifies a worker that an in-flight request has been cancelled
+func newCancellationRequest(id workers.RequestIdentifier, reason string) *queuespb.ServerMessage {
+	return &queuespb.ServerMessage{
+		Id: id,
+		Content: &queuespb.ServerMessage_CancellationRequest{
+			CancellationRequest: &queuespb.CancellationRequest{
+				Reason: reason,
+			},
+		},
+	}
+}
+
+func newManager(queues queuespb.QueuesServer, pollInterval time.Duration) *QueueListenerManager {
+	pollCtx, stopPolling := context.WithCancel(context.Background())
+
+	return &QueueListenerManager{
+		listenerMap:   make(map[QueueName][]*QueueListener),
+		loadBalancer:  workers.RoundRobin(),
+		mutex:         sync.RWMutex{},
+		queues:        queues,
+		pollInterval:  pollInterval,
+		leaseDuration: defaultLeaseDuration,
+		handleTimeout: defaultHandleTimeout,
+		pollCtx:       pollCtx,
+		stopPolling:   stopPolling,
+	}
+}
+
+// New creates a queue listener manager for providers that push messages to the membrane through HandleRequest
+func New() *QueueListenerManager {
+	return newManager(nil, 0)
+}
+
+// NewPolling creates a queue listener manager that dequeues messages for its listeners from the given queues plugin
+func NewPolling(queues queuespb.QueuesServer) *QueueListenerManager {
+	return newManager(queues, defaultPollInterval)
+}
@@ -0,0 +1,430 @@
+// Copyright 2021 Nitric Technologies Pty Ltd.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package queues
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"sync"
+	"time"
+
+	"google.golang.org/grpc"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	queuespb "github.com/nitrictech/nitric/core/pkg/proto/queues/v1"
+)
+
+// fakeQueues is a queues plugin holding messages in memory, each lease extension issues a new lease id
+type fakeQueues struct {
+	queuespb.UnimplementedQueuesServer
+
+	lock      sync.Mutex
+	available []*queuespb.DequeuedMessage
+	dequeues  []*queuespb.QueueDequeueRequest
+	extended  []string
+	completed []string
+	released  []string
+}
+
+func (f *fakeQueues) Dequeue(ctx context.Context, req *queuespb.QueueDequeueRequest) (*queuespb.QueueDequeueResponse, error) {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	f.dequeues = append(f.dequeues, req)
+
+	depth := min(int(req.Depth), len(f.available))
+	messages := f.available[:depth]
+	f.available = f.available[depth:]
+
+	return &queuespb.QueueDequeueResponse{Messages: messages}, nil
+}
+
+func (f *fakeQueues) ExtendLease(ctx context.Context, req *queuespb.QueueExtendLeaseRequest) (*queuespb.QueueExtendLeaseResponse, error) {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+
+	f.extended = append(f.extended, req.LeaseId)
+
+	return &queuespb.QueueExtendLeaseResponse{LeaseId: fmt.Sprintf("%s-extended", req.LeaseId)}, nil
+}
+
+func (f *fakeQueues) Complete(ctx context.Context, req *queuespb.QueueCompleteRequest) (*queuespb.QueueCompleteResponse, error) {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+
+	f.completed = append(f.completed, req.LeaseId)
+
+	return &queuespb.QueueCompleteResponse{}, nil
+}
+
+func (f *fakeQueues) Release(ctx context.Context, req *queuespb.QueueReleaseRequest) (*queuespb.QueueReleaseResponse, error) {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+
+	f.released = append(f.released, req.LeaseId)
+
+	return &queuespb.QueueReleaseResponse{}, nil
+}
+
+func (f *fakeQueues) enqueue(leaseIds ...string) {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+
+	for _, leaseId := range leaseIds {
+		f.available = append(f.available, &queuespb.DequeuedMessage{
+			LeaseId: leaseId,
+			Message: &queuespb.QueueMessage{},
+		})
+	}
+}
+
+func (f *fakeQueues) completedLeases() []string {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+
+	return append([]string{}, f.completed...)
+}
+
+func (f *fakeQueues) releasedLeases() []string {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+
+	return append([]string{}, f.released...)
+}
+
+func (f *fakeQueues) extendedLeases() []string {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+
+	return append([]string{}, f.extended...)
+}
+
+func (f *fakeQueues) dequeueRequests() []*queuespb.QueueDequeueRequest {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+
+	return append([]*queuespb.QueueDequeueRequest{}, f.dequeues...)
+}
+
+// listenerStream is a queue listener's stream, it registers with its first message and responds to message requests with respond
+type listenerStream struct {
+	grpc.ServerStream
+
+	registration *queuespb.RegistrationRequest
+	// returns the listener's response to a message request, or nil if the listener never responds
+	respond func(*queuespb.MessageRequest) *queuespb.ClientMessage
+
+	responses  chan *queuespb.ClientMessage
+	closed     chan struct{}
+	closedOnce sync.Once
+	// closed when the listener starts waiting for message responses
+	receiving     chan struct{}
+	registered    bool
+	lock          sync.Mutex
+	inFlight      int
+	maxInFlight   int
+	cancellations int
+}
+
+func (l *listenerStream) Send(msg *queuespb.ServerMessage) error {
+	if msg.GetCancellationRequest() != nil {
+		l.lock.Lock()
+		l.cancellations++
+		l.lock.Unlock()
+
+		return nil
+	}
+
+	request := msg.GetMessageRequest()
+	if request == nil {
+		return nil
+	}
+
+	l.lock.Lock()
+	l.inFlight++
+	l.maxInFlight = max(l.maxInFlight, l.inFlight)
+	l.lock.Unlock()
+
+	go func() {
+		response := l.respond(request)
+
+		l.lock.Lock()
+		l.inFlight--
+		l.lock.Unlock()
+
+		if response == nil {
+			return
+		}
+
+		response.Id = msg.Id
+		select {
+		case l.responses <- response:
+		case <-l.closed:
+		}
+	}()
+
+	return nil
+}
+
+func (l *listenerStream) Recv() (*queuespb.ClientMessage, error) {
+	if !l.registered {
+		l.registered = true
+
+		return &queuespb.ClientMessage{
+			Content: &queuespb.ClientMessage_RegistrationRequest{
+				RegistrationRequest: l.registration,
+			},
+		}, nil
+	}
+
+	select {
+	case <-l.receiving:
+	default:
+		close(l.receiving)
+	}
+
+	select {
+	case msg := <-l.responses:
+		return msg, nil
+	case <-l.closed:
+		return nil, io.EOF
+	}
+}
+
+func (l *listenerStream) disconnect() {
+	l.closedOnce.Do(func() {
+		close(l.closed)
+	})
+}
+
+func (l *listenerStream) stats() (maxInFlight int, cancellations int) {
+	l.lock.Lock()
+	defer l.lock.Unlock()
+
+	return l.maxInFlight, l.cancellations
+}
+
+func messageResponse(success bool) *queuespb.ClientMessage {
+	return &queuespb.ClientMessage{
+		Content: &queuespb.ClientMessage_MessageResponse{
+			MessageResponse: &queuespb.MessageResponse{
+				Success: success,
+			},
+		},
+	}
+}
+
+func newListenerStream(registration *queuespb.RegistrationRequest, respond func(*queuespb.MessageRequest) *queuespb.ClientMessage) *listenerStream {
+	return &listenerStream{
+		registration: registration,
+		respond:      respond,
+		responses:    make(chan *queuespb.ClientMessage),
+		closed:       make(chan struct{}),
+		receiving:    make(chan struct{}),
+	}
+}
+
+var _ = Describe("QueueListenerManager", func() {
+	var (
+		queues  *fakeQueues
+		manager *QueueListenerManager
+		streams []*listenerStream
+	)
+
+	listen := func(stream *listenerStream) {
+		streams = append(streams, stream)
+
+		go func() {
+			defer GinkgoRecover()
+			_ = manager.Listen(stream)
+		}()
+
+		Eventually(stream.receiving).Should(BeClosed())
+	}
+
+	BeforeEach(func() {
+		queues = &fakeQueues{}
+		manager = NewPolling(queues)
+		manager.pollInterval = 5 * time.Millisecond
+		manager.leaseDuration = 40 * time.Millisecond
+		streams = nil
+	})
+
+	AfterEach(func() {
+		manager.stopPolling()
+
+		for _, stream := range streams {
+			stream.disconnect()
+		}
+	})
+
+	Context("registering listeners", func() {
+		It("should reject listeners without a queue name", func() {
+			err := manager.Listen(newListenerStream(&queuespb.RegistrationRequest{}, nil))
+
+			Expect(err).Should(HaveOccurred())
+		})
+
+		It("should reject listeners with a negative batch size or concurrency", func() {
+			err := manager.Listen(newListenerStream(&queuespb.RegistrationRequest{QueueName: "queue", BatchSize: -1}, nil))
+			Expect(err).Should(HaveOccurred())
+
+			err = manager.Listen(newListenerStream(&queuespb.RegistrationRequest{QueueName: "queue", Concurrency: -1}, nil))
+			Expect(err).Should(HaveOccurred())
+
+			Expect(manager.WorkerCount()).To(Equal(0))
+		})
+
+		It("should unregister listeners when they disconnect", func() {
+			stream := newListenerStream(&queuespb.RegistrationRequest{QueueName: "queue"}, nil)
+			listen(stream)
+			Expect(manager.WorkerCount()).To(Equal(1))
+
+			stream.disconnect()
+
+			Eventually(manager.WorkerCount).Should(Equal(0))
+		})
+	})
+
+	Context("polling for messages", func() {
+		It("should lease dequeued messages for the lease duration", func() {
+			listen(newListenerStream(&queuespb.RegistrationRequest{QueueName: "queue"}, nil))
+
+			Eventually(queues.dequeueRequests).ShouldNot(BeEmpty())
+
+			request := queues.dequeueRequests()[0]
+			Expect(request.QueueName).To(Equal("queue"))
+			Expect(request.VisibilityTimeout.AsDuration()).To(Equal(manager.leaseDuration))
+		})
+
+		It("should complete messages that are handled successfully", func() {
+			queues.enqueue("lease-1", "lease-2")
+
+			listen(newListenerStream(&queuespb.RegistrationRequest{QueueName: "queue"}, func(*queuespb.MessageRequest) *queuespb.ClientMessage {
+				return messageResponse(true)
+			}))
+
+			Eventually(queues.completedLeases).Should(ConsistOf("lease-1", "lease-2"))
+			Expect(queues.releasedLeases()).To(BeEmpty())
+		})
+
+		It("should release messages that fail to be handled", func() {
+			queues.enqueue("lease-1")
+
+			listen(newListenerStream(&queuespb.RegistrationRequest{QueueName: "queue"}, func(*queuespb.MessageRequest) *queuespb.ClientMessage {
+				return messageResponse(false)
+			}))
+
+			Eventually(queues.releasedLeases).Should(ConsistOf("lease-1"))
+			Expect(queues.completedLeases()).To(BeEmpty())
+		})
+
+		It("should not dequeue more messages than the listener can handle at once", func() {
+			queues.enqueue("lease-1", "lease-2", "lease-3", "lease-4")
+
+			listen(newListenerStream(&queuespb.RegistrationRequest{QueueName: "queue", BatchSize: 4, Concurrency: 2}, func(*queuespb.MessageRequest) *queuespb.ClientMessage {
+				time.Sleep(10 * time.Millisecond)
+				return messageResponse(true)
+			}))
+
+			Eventually(queues.completedLeases).Should(HaveLen(4))
+
+			maxInFlight, _ := streams[0].stats()
+			Expect(maxInFlight).To(BeNumerically("<=", 2))
+			for _, request := range queues.dequeueRequests() {
+				Expect(request.Depth).To(BeNumerically("<=", 2))
+			}
+		})
+
+		It("should stop polling when draining", func() {
+			listen(newListenerStream(&queuespb.RegistrationRequest{QueueName: "queue"}, nil))
+			Eventually(queues.dequeueRequests).ShouldNot(BeEmpty())
+
+			Expect(manager.Drain(context.TODO())).To(Succeed())
+
+			polled := len(queues.dequeueRequests())
+			Consistently(func() int { return len(queues.dequeueRequests()) }, 50*time.Millisecond).Should(Equal(polled))
+		})
+	})
+
+	Context("handling messages for longer than their lease", func() {
+		It("should extend the lease and complete the message with its latest lease id", func() {
+			queues.enqueue("lease-1")
+
+			listen(newListenerStream(&queuespb.RegistrationRequest{QueueName: "queue"}, func(*queuespb.MessageRequest) *queuespb.ClientMessage {
+				time.Sleep(3 * manager.leaseDuration)
+				return messageResponse(true)
+			}))
+
+			Eventually(queues.completedLeases, time.Second).Should(HaveLen(1))
+
+			extended := queues.extendedLeases()
+			Expect(len(extended)).To(BeNumerically(">=", 2))
+			Expect(extended[0]).To(Equal("lease-1"))
+			Expect(extended[1]).To(Equal("lease-1-extended"))
+			Expect(queues.completedLeases()[0]).To(Equal(fmt.Sprintf("%s-extended", extended[len(extended)-1])))
+		})
+
+		It("should cancel the listener and release the message once the handle timeout passes", func() {
+			manager.handleTimeout = 50 * time.Millisecond
+			queues.enqueue("lease-1")
+
+			listen(newListenerStream(&queuespb.RegistrationRequest{QueueName: "queue"}, func(*queuespb.MessageRequest) *queuespb.ClientMessage {
+				return nil
+			}))
+
+			Eventually(queues.releasedLeases, time.Second).Should(HaveLen(1))
+			Expect(queues.completedLeases()).To(BeEmpty())
+
+			_, cancellations := streams[0].stats()
+			Expect(cancellations).To(Equal(1))
+		})
+	})
+
+	Context("HandleRequest", func() {
+		It("should return an error without a listener for the queue", func() {
+			_, err := manager.HandleRequest(context.TODO(), &queuespb.ServerMessage{
+				Content: &queuespb.ServerMessage_MessageRequest{
+					MessageRequest: &queuespb.MessageRequest{QueueName: "queue"},
+				},
+			})
+
+			Expect(err).Should(HaveOccurred())
+		})
+
+		It("should deliver pushed messages to a listener for the queue", func() {
+			manager = New()
+			listen(newListenerStream(&queuespb.RegistrationRequest{QueueName: "queue"}, func(*queuespb.MessageRequest) *queuespb.ClientMessage {
+				return messageResponse(true)
+			}))
+
+			resp, err := manager.HandleRequest(context.TODO(), &queuespb.ServerMessage{
+				Content: &queuespb.ServerMessage_MessageRequest{
+					MessageRequest: &queuespb.MessageRequest{QueueName: "queue"},
+				},
+			})
+
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(resp.GetMessageResponse().GetSuccess()).To(BeTrue())
+		})
+	})
+})
@@ -15,8 +15,11 @@
 package workers
 
 import (
+	"context"
 	"fmt"
 	"sync"
+	"sync/atomic"
+	"time"
 
 	"github.com/nitrictech/nitric/core/pkg/help"
 	"github.com/nitrictech/nitric/core/pkg/logger"
@@ -36,81 +39,380 @@ type IdentifiableMessage interface {
 	GetId() RequestIdentifier
 }
 
+// cancelledRequestRetention is how long a cancelled request is remembered, so late responses from the worker can be discarded
+const cancelledRequestRetention = time.Minute
+
+// CancellationFactory builds the message sent to a worker to notify it that the request with the given ID was cancelled
+type CancellationFactory[Request IdentifiableMessage] func(id RequestIdentifier, reason string) Request
+
+// pendingRequest tracks a request that is awaiting one or more responses from the worker
+type pendingRequest[Response IdentifiableMessage] struct {
+	responses chan Response
+	// reports whether a response is the final response for the request, nil if the first response is final
+	isLast func(Response) bool
+	// closed when the requester stops waiting for responses, e.g. on cancellation
+	abandoned   chan struct{}
+	abandonOnce sync.Once
+	// closed once the final response has been delivered or the worker disconnected
+	finished chan struct{}
+
+	// responses received from the worker that haven't been read by the requester yet,
+	// queued so a slow requester doesn't block responses to other requests
+	queueLock sync.Mutex
+	queue     []Response
+	// set once no further responses will be queued
+	closed bool
+	queued chan struct{}
+}
+
+func newPendingRequest[Response IdentifiableMessage](isLast func(Response) bool) *pendingRequest[Response] {
+	return &pendingRequest[Response]{
+		responses: make(chan Response),
+		isLast:    isLast,
+		abandoned: make(chan struct{}),
+		finished:  make(chan struct{}),
+		queued:    make(chan struct{}, 1),
+	}
+}
+
+func (p *pendingRequest[Response]) abandon() {
+	p.abandonOnce.Do(func() {
+		close(p.abandoned)
+	})
+}
+
+// enqueue queues a response for the requester without waiting for it to be read
+func (p *pendingRequest[Response]) enqueue(response Response) {
+	p.queueLock.Lock()
+	p.queue = append(p.queue, response)
+	p.queueLock.Unlock()
+
+	p.notify()
+}
+
+// close stops the pending request once all queued responses have been read
+func (p *pendingRequest[Response]) close() {
+	p.queueLock.Lock()
+	p.closed = true
+	p.queueLock.Unlock()
+
+	p.notify()
+}
+
+func (p *pendingRequest[Response]) notify() {
+	select {
+	case p.queued <- struct{}{}:
+	default:
+		// the forwarder has already been notified
+	}
+}
+
+// forward passes queued responses to the requester until the request is closed or abandoned
+func (p *pendingRequest[Response]) forward() {
+	for {
+		p.queueLock.Lock()
+		if len(p.queue) == 0 {
+			closed := p.closed
+			p.queueLock.Unlock()
+
+			if closed {
+				close(p.responses)
+				close(p.finished)
+				return
+			}
+
+			select {
+			case <-p.queued:
+				continue
+			case <-p.abandoned:
+				return
+			}
+		}
+
+		response := p.queue[0]
+		p.queue = p.queue[1:]
+		p.queueLock.Unlock()
+
+		select {
+		case p.responses <- response:
+		case <-p.abandoned:
+			return
+		}
+	}
+}
+
+// cancelledRequest tracks a cancelled request until its final response is received or it's forgotten
+type cancelledRequest[Response IdentifiableMessage] struct {
+	isLast func(Response) bool
+	// forgets the request if the worker never sends its final response
+	expiry *time.Timer
+}
+
 // WorkerRequestBroker helps manage the async bidirectional stream between the worker (typically a client SDK) and the Nitric server.
 //
 //	the broker facilitates sending requests to a worker and awaits responses, then matches them with the corresponding request.
 //	This enables users of this brokers to treat the request/response lifecycle with the worker as if they were synchronous.
 type WorkerRequestBroker[Request IdentifiableMessage, Response IdentifiableMessage] struct {
 	workerConnectionStream GrpcBidiStreamServer[Request, Response]
-	responseChannelLock    sync.RWMutex
-	responseChannels       map[RequestIdentifier]chan Response
-	running                bool
+	newCancellation        CancellationFactory[Request]
+	// grpc streams don't support concurrent calls to Send
+	sendLock        sync.Mutex
+	pendingLock     sync.RWMutex
+	pendingRequests map[RequestIdentifier]*pendingRequest[Response]
+	// requests that were cancelled, so late responses from the worker can be discarded
+	cancelledRequests map[RequestIdentifier]*cancelledRequest[Response]
+	// how long cancelled requests are remembered
+	cancelledRetention time.Duration
+	// the number of requests awaiting a response from the worker
+	inFlight atomic.Int64
+	// set once the broker is shutting down, new requests are rejected
+	draining atomic.Bool
+	running  atomic.Bool
+}
+
+var (
+	_ InFlightReporter = (*WorkerRequestBroker[IdentifiableMessage, IdentifiableMessage])(nil)
+	_ Drainable        = (*WorkerRequestBroker[IdentifiableMessage, IdentifiableMessage])(nil)
+)
+
+// InFlight returns the number of requests sent to the worker that are still awaiting a response
+func (w *WorkerRequestBroker[Request, Response]) InFlight() int {
+	return int(w.inFlight.Load())
+}
+
+func (w *WorkerRequestBroker[Request, Response]) send(req Request) error {
+	w.sendLock.Lock()
+	defer w.sendLock.Unlock()
+
+	return w.workerConnectionStream.Send(req)
+}
+
+// cancel removes a pending request and notifies the worker that it should stop processing it
+func (w *WorkerRequestBroker[Request, Response]) cancel(id RequestIdentifier, reason string) {
+	w.pendingLock.Lock()
+	pending, ok := w.pendingRequests[id]
+	if !ok {
+		// the request has already completed
+		w.pendingLock.Unlock()
+		return
+	}
+
+	delete(w.pendingRequests, id)
+	w.cancelledRequests[id] = &cancelledRequest[Response]{
+		isLast: pending.isLast,
+		expiry: time.AfterFunc(w.cancelledRetention, func() {
+			w.forgetCancelled(id)
+		}),
+	}
+	w.pendingLock.Unlock()
+
+	if w.newCancellation == nil {
+		return
+	}
+
+	if err := w.send(w.newCancellation(id, reason)); err != nil {
+		logger.Debugf("unable to notify worker of cancelled request %s: %v", id, err)
+	}
+}
+
+// forgetCancelled stops discarding responses for a cancelled request, any later responses are treated as unknown
+func (w *WorkerRequestBroker[Request, Response]) forgetCancelled(id RequestIdentifier) {
+	w.pendingLock.Lock()
+	defer w.pendingLock.Unlock()
+
+	if cancelled, ok := w.cancelledRequests[id]; ok {
+		cancelled.expiry.Stop()
+		delete(w.cancelledRequests, id)
+	}
 }
 
-func (w *WorkerRequestBroker[Request, Response]) Send(req Request) (*Response, error) {
-	if !w.running {
+// Send a request to the worker and wait for its response.
+//
+//	If the context is cancelled or its deadline passes before the worker responds, the worker is notified of the cancellation
+//	and the context error is returned.
+func (w *WorkerRequestBroker[Request, Response]) Send(ctx context.Context, req Request) (*Response, error) {
+	responses, err := w.Stream(ctx, req, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	// wait for the response
+	select {
+	case response, ok := <-responses:
+		if !ok {
+			return nil, fmt.Errorf("error receiving response")
+		}
+
+		return &response, nil
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+}
+
+// Stream sends a request to the worker and returns a channel that receives each of the worker's responses to that request.
+//
+//	The channel is closed after the response that satisfies isLast is received, or if the worker disconnects.
+//	If isLast is nil the first response is the last. If the context is done before the last response is received,
+//	the worker is notified of the cancellation and no further responses are delivered.
+func (w *WorkerRequestBroker[Request, Response]) Stream(ctx context.Context, req Request, isLast func(Response) bool) (<-chan Response, error) {
+	if !w.running.Load() {
 		return nil, fmt.Errorf("worker server not running, call Start() before sending requests")
 	}
 
-	if _, exists := w.responseChannels[req.GetId()]; exists {
+	// counted before checking for draining, so a drain that starts after this check still waits for the request
+	w.inFlight.Add(1)
+	forwarding := false
+	defer func() {
+		if !forwarding {
+			w.inFlight.Add(-1)
+		}
+	}()
+
+	if w.draining.Load() {
+		return nil, fmt.Errorf("worker is draining, no new requests are accepted")
+	}
+
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	pending := newPendingRequest(isLast)
+
+	w.pendingLock.Lock()
+	if _, exists := w.pendingRequests[req.GetId()]; exists {
+		w.pendingLock.Unlock()
 		return nil, fmt.Errorf("request with ID %s already exists", req.GetId())
 	}
+	w.pendingRequests[req.GetId()] = pending
+	w.pendingLock.Unlock()
 
-	w.responseChannelLock.Lock()
-	responseChannel := make(chan Response)
-	w.responseChannels[req.GetId()] = responseChannel
-	w.responseChannelLock.Unlock()
+	if err := w.send(req); err != nil {
+		w.pendingLock.Lock()
+		delete(w.pendingRequests, req.GetId())
+		w.pendingLock.Unlock()
 
-	if err := w.workerConnectionStream.Send(req); err != nil {
 		return nil, err
 	}
 
-	// wait for the response
-	response, ok := <-responseChannel
+	forwarding = true
+
+	go pending.forward()
+
+	go func() {
+		defer w.inFlight.Add(-1)
+
+		select {
+		case <-pending.finished:
+		case <-ctx.Done():
+			pending.abandon()
+			w.cancel(req.GetId(), ctx.Err().Error())
+		}
+	}()
+
+	return pending.responses, nil
+}
+
+// Drain stops the broker accepting new requests and waits for responses to requests already sent to the worker.
+//
+//	The context error is returned if it's done before all responses are received.
+func (w *WorkerRequestBroker[Request, Response]) Drain(ctx context.Context) error {
+	w.draining.Store(true)
+
+	return WaitForIdle(ctx, w)
+}
+
+// SendMessage sends a message to the worker without waiting for a response, e.g. part of an in-flight request.
+func (w *WorkerRequestBroker[Request, Response]) SendMessage(msg Request) error {
+	if !w.running.Load() {
+		return fmt.Errorf("worker server not running, call Start() before sending requests")
+	}
+
+	return w.send(msg)
+}
+
+// deliver passes a response to the pending request it belongs to
+func (w *WorkerRequestBroker[Request, Response]) deliver(response Response) {
+	w.pendingLock.Lock()
+	pending, ok := w.pendingRequests[response.GetId()]
+	last := ok && (pending.isLast == nil || pending.isLast(response))
+	if last {
+		// remove the request so duplicate responses are treated as unknown
+		delete(w.pendingRequests, response.GetId())
+	}
+	cancelledRequest, cancelled := w.cancelledRequests[response.GetId()]
+	if cancelled && (cancelledRequest.isLast == nil || cancelledRequest.isLast(response)) {
+		// no further responses are expected for the cancelled request
+		cancelledRequest.expiry.Stop()
+		delete(w.cancelledRequests, response.GetId())
+	}
+	w.pendingLock.Unlock()
+
+	if cancelled {
+		// the request was cancelled before the worker responded, there's no one waiting for this response
+		return
+	}
+
 	if !ok {
-		return nil, fmt.Errorf("error receiving response")
+		// This would indicate a critical bug, it means that the client (SDK) did not return a response with an ID that matches a request that was sent to it
+		// OR there may have been a network error that resulted in duplicate responses
+		logger.Errorf("nitric received a response for an unknown request, response could not be returned: %s", help.BugInNitricHelpText())
+		return
 	}
 
-	// clean up the map reference
-	w.responseChannelLock.Lock()
-	delete(w.responseChannels, req.GetId())
-	w.responseChannelLock.Unlock()
+	// never block here, deliver is called by the receive loop which serves every request to the worker
+	pending.enqueue(response)
 
-	return &response, nil
+	if last {
+		pending.close()
+	}
 }
 
 // Run the connection broker, allowing async communication with the worker (i.e.
 func (w *WorkerRequestBroker[Request, Response]) Run() error {
-	if w.running {
+	if !w.running.CompareAndSwap(false, true) {
 		return fmt.Errorf("worker already running")
 	}
+
+	defer w.closePendingRequests()
+
 	// Read responses on the client connection stream and match them with the corresponding response channel.
 	for {
-		w.running = true
 		response, err := w.workerConnectionStream.Recv()
 		if err != nil {
 			// Most likely the client closed the connection
 			return err
 		}
 
-		w.responseChannelLock.RLock()
-		responseChannel, ok := w.responseChannels[response.GetId()]
-		w.responseChannelLock.RUnlock()
-		if !ok {
-			// This would indicate a critical bug, it means that the client (SDK) did not return a response with an ID that matches a request that was sent to it
-			// OR there may have been a network error that resulted in duplicate responses
-			logger.Errorf("nitric received a response for an unknown request, response could not be returned: %s", help.BugInNitricHelpText())
-		}
+		w.deliver(response)
+	}
+}
+
+// closePendingRequests unblocks any requests still waiting on a worker that is no longer connected
+func (w *WorkerRequestBroker[Request, Response]) closePendingRequests() {
+	w.pendingLock.Lock()
+	defer w.pendingLock.Unlock()
 
-		responseChannel <- response
+	for id, pending := range w.pendingRequests {
+		pending.close()
+		delete(w.pendingRequests, id)
+	}
+
+	for id, cancelled := range w.cancelledRequests {
+		cancelled.expiry.Stop()
+		delete(w.cancelledRequests, id)
 	}
 }
 
-func NewWorkerRequestBroker[Request IdentifiableMessage, Response IdentifiableMessage](workerConnectionStream GrpcBidiStreamServer[Request, Response]) *WorkerRequestBroker[Request, Response] {
+// NewWorkerRequestBroker creates a broker for the given worker stream.
+//
+//	newCancellation is used to notify the worker when a request is cancelled, it may be nil if the worker doesn't support cancellation.
+func NewWorkerRequestBroker[Request IdentifiableMessage, Response IdentifiableMessage](workerConnectionStream GrpcBidiStreamServer[Request, Response], newCancellation CancellationFactory[Request]) *WorkerRequestBroker[Request, Response] {
 	return &WorkerRequestBroker[Request, Response]{
 		workerConnectionStream: workerConnectionStream,
-		responseChannelLock:    sync.RWMutex{},
-		responseChannels:       make(map[string]chan Response),
-		running:                false,
+		newCancellation:        newCancellation,
+		pendingLock:            sync.RWMutex{},
+		pendingRequests:        make(map[string]*pendingRequest[Response]),
+		cancelledRequests:      make(map[string]*cancelledRequest[Response]),
+		cancelledRetention:     cancelledRequestRetention,
 	}
 }
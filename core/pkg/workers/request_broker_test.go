@@ -0,0 +1,227 @@
+// Copyright 2021 Nitric Technologies Pty Ltd.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package workers
+
+import (
+	"context"
+	"io"
+	"sync"
+	"time"
+
+	"google.golang.org/grpc"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+type testMessage struct {
+	id        string
+	cancelled bool
+	last      bool
+}
+
+func (m *testMessage) GetId() string {
+	return m.id
+}
+
+func newTestCancellation(id RequestIdentifier, reason string) *testMessage {
+	return &testMessage{id: id, cancelled: true}
+}
+
+// testStream is a worker stream that records the messages sent to the worker and receives responses written to it
+type testStream struct {
+	grpc.ServerStream
+
+	sent      chan *testMessage
+	responses chan *testMessage
+	// closed when the broker first waits for a response
+	receiving     chan struct{}
+	receivingOnce sync.Once
+}
+
+func (t *testStream) Send(msg *testMessage) error {
+	t.sent <- msg
+	return nil
+}
+
+func (t *testStream) Recv() (*testMessage, error) {
+	t.receivingOnce.Do(func() {
+		close(t.receiving)
+	})
+
+	msg, ok := <-t.responses
+	if !ok {
+		return nil, io.EOF
+	}
+
+	return msg, nil
+}
+
+func newTestStream() *testStream {
+	return &testStream{
+		sent:      make(chan *testMessage, 10),
+		responses: make(chan *testMessage),
+		receiving: make(chan struct{}),
+	}
+}
+
+func (w *WorkerRequestBroker[Request, Response]) cancelledCount() int {
+	w.pendingLock.RLock()
+	defer w.pendingLock.RUnlock()
+
+	return len(w.cancelledRequests)
+}
+
+var _ = Describe("WorkerRequestBroker", func() {
+	var (
+		stream  *testStream
+		broker  *WorkerRequestBroker[*testMessage, *testMessage]
+		stopped chan error
+	)
+
+	BeforeEach(func() {
+		stream = newTestStream()
+		broker = NewWorkerRequestBroker[*testMessage, *testMessage](stream, newTestCancellation)
+		stopped = make(chan error, 1)
+
+		go func() {
+			stopped <- broker.Run()
+		}()
+
+		Eventually(stream.receiving).Should(BeClosed())
+	})
+
+	AfterEach(func() {
+		close(stream.responses)
+		Eventually(stopped).Should(Receive())
+	})
+
+	When("the request context is cancelled before the worker responds", func() {
+		var err error
+
+		BeforeEach(func() {
+			ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+			defer cancel()
+
+			_, err = broker.Send(ctx, &testMessage{id: "1"})
+		})
+
+		It("should return the context error", func() {
+			Expect(err).To(Equal(context.DeadlineExceeded))
+		})
+
+		It("should notify the worker of the cancellation", func() {
+			Eventually(stream.sent).Should(Receive(Equal(&testMessage{id: "1"})))
+			Eventually(stream.sent).Should(Receive(Equal(&testMessage{id: "1", cancelled: true})))
+		})
+
+		It("should no longer count the request as in-flight", func() {
+			Eventually(broker.InFlight).Should(Equal(0))
+		})
+
+		It("should discard the late response and forget the request", func() {
+			Eventually(broker.cancelledCount).Should(Equal(1))
+
+			stream.responses <- &testMessage{id: "1"}
+
+			Eventually(broker.cancelledCount).Should(Equal(0))
+		})
+	})
+
+	When("a streamed request is cancelled", func() {
+		It("should discard responses until the last one is received", func() {
+			ctx, cancel := context.WithCancel(context.Background())
+
+			_, err := broker.Stream(ctx, &testMessage{id: "1"}, func(m *testMessage) bool { return m.last })
+			Expect(err).ToNot(HaveOccurred())
+
+			cancel()
+			Eventually(broker.cancelledCount).Should(Equal(1))
+
+			stream.responses <- &testMessage{id: "1"}
+			Consistently(broker.cancelledCount, 50*time.Millisecond).Should(Equal(1))
+
+			stream.responses <- &testMessage{id: "1", last: true}
+			Eventually(broker.cancelledCount).Should(Equal(0))
+		})
+	})
+
+	When("the worker never responds to a cancelled request", func() {
+		It("should forget the request after the retention period", func() {
+			broker.cancelledRetention = 50 * time.Millisecond
+
+			_, err := broker.Stream(context.Background(), &testMessage{id: "1"}, nil)
+			Expect(err).ToNot(HaveOccurred())
+			broker.cancel("1", "cancelled")
+
+			Expect(broker.cancelledCount()).To(Equal(1))
+			Eventually(broker.cancelledCount).Should(Equal(0))
+		})
+	})
+
+	When("the requester of a streamed request isn't reading its responses", func() {
+		It("should still deliver responses to other requests", func() {
+			slow, err := broker.Stream(context.Background(), &testMessage{id: "slow"}, func(m *testMessage) bool { return m.last })
+			Expect(err).ToNot(HaveOccurred())
+
+			fast, err := broker.Stream(context.Background(), &testMessage{id: "fast"}, nil)
+			Expect(err).ToNot(HaveOccurred())
+
+			for i := 0; i < 5; i++ {
+				stream.responses <- &testMessage{id: "slow"}
+			}
+			stream.responses <- &testMessage{id: "slow", last: true}
+			stream.responses <- &testMessage{id: "fast"}
+
+			Eventually(fast).Should(Receive(Equal(&testMessage{id: "fast"})))
+
+			// the slow requester still receives every response in order
+			for i := 0; i < 5; i++ {
+				Eventually(slow).Should(Receive(Equal(&testMessage{id: "slow"})))
+			}
+			Eventually(slow).Should(Receive(Equal(&testMessage{id: "slow", last: true})))
+			Eventually(slow).Should(BeClosed())
+			Eventually(broker.InFlight).Should(Equal(0))
+		})
+	})
+
+	When("the worker responds before the request is cancelled", func() {
+		It("should return the response and not remember the request", func() {
+			go func() {
+				defer GinkgoRecover()
+
+				Eventually(stream.sent).Should(Receive())
+				stream.responses <- &testMessage{id: "1"}
+			}()
+
+			resp, err := broker.Send(context.Background(), &testMessage{id: "1"})
+			Expect(err).ToNot(HaveOccurred())
+			Expect((*resp).GetId()).To(Equal("1"))
+
+			Consistently(broker.cancelledCount, 50*time.Millisecond).Should(Equal(0))
+		})
+	})
+
+	When("the broker is draining", func() {
+		It("should refuse new requests without counting them as in-flight", func() {
+			Expect(broker.Drain(context.Background())).To(Succeed())
+
+			_, err := broker.Send(context.Background(), &testMessage{id: "1"})
+			Expect(err).To(HaveOccurred())
+			Expect(broker.InFlight()).To(Equal(0))
+			Expect(stream.sent).ToNot(Receive())
+		})
+	})
+})
@@ -15,7 +15,9 @@
 package schedules
 
 import (
+	"context"
 	"fmt"
+	"slices"
 	"sync"
 
 	schedulespb "github.com/nitrictech/nitric/core/pkg/proto/schedules/v1"
@@ -28,16 +30,30 @@ type WorkerConnection = workers.WorkerRequestBroker[*schedulespb.ServerMessage,
 
 type ScheduleRequestHandler interface {
 	schedulespb.SchedulesServer
-	HandleRequest(request *schedulespb.ServerMessage) (*schedulespb.ClientMessage, error)
+	HandleRequest(ctx context.Context, request *schedulespb.ServerMessage) (*schedulespb.ClientMessage, error)
 	WorkerCount() int
 }
 
 type ScheduleWorkerManager struct {
-	workerMap map[ScheduleName]*WorkerConnection
-	mutex     sync.RWMutex
+	workerMap    map[ScheduleName][]*WorkerConnection
+	loadBalancer workers.LoadBalancer
+	mutex        sync.RWMutex
 }
 
-var _ schedulespb.SchedulesServer = &ScheduleWorkerManager{}
+var (
+	_ schedulespb.SchedulesServer = &ScheduleWorkerManager{}
+	_ workers.LoadBalanced        = &ScheduleWorkerManager{}
+	_ workers.Drainable           = &ScheduleWorkerManager{}
+	_ workers.Describable         = &ScheduleWorkerManager{}
+)
+
+// SetLoadBalancer sets the strategy used to choose between workers registered for the same schedule
+func (s *ScheduleWorkerManager) SetLoadBalancer(lb workers.LoadBalancer) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	s.loadBalancer = lb
+}
 
 func (s *ScheduleWorkerManager) registerSchedule(scheduleWorker *WorkerConnection, request *schedulespb.RegistrationRequest) error {
 	s.mutex.Lock()
@@ -45,29 +61,23 @@ func (s *ScheduleWorkerManager) registerSchedule(scheduleWorker *WorkerConnectio
 
 	scheduleName := request.GetScheduleName()
 
-	if _, exists := s.workerMap[scheduleName]; exists {
-		return fmt.Errorf("schedule already registered with name: %s", scheduleName)
-	}
-
-	s.workerMap[scheduleName] = scheduleWorker
+	// multiple workers may register for the same schedule, triggers are balanced between them
+	s.workerMap[scheduleName] = append(s.workerMap[scheduleName], scheduleWorker)
 
 	return nil
 }
 
-func (s *ScheduleWorkerManager) unregisterSchedule(scheduleWorker *WorkerConnection) {
+func (s *ScheduleWorkerManager) unregisterSchedule(scheduleName ScheduleName, scheduleWorker *WorkerConnection) {
 	s.mutex.Lock()
 	defer s.mutex.Unlock()
 
-	var resultKey string
+	s.workerMap[scheduleName] = slices.DeleteFunc(s.workerMap[scheduleName], func(wrb *WorkerConnection) bool {
+		return wrb == scheduleWorker
+	})
 
-	for k, wrb := range s.workerMap {
-		if wrb == scheduleWorker {
-			resultKey = k
-			break
-		}
+	if len(s.workerMap[scheduleName]) == 0 {
+		delete(s.workerMap, scheduleName)
 	}
-
-	delete(s.workerMap, resultKey)
 }
 
 func (s *ScheduleWorkerManager) Schedule(stream schedulespb.Schedules_ScheduleServer) error {
@@ -80,12 +90,12 @@ func (s *ScheduleWorkerManager) Schedule(stream schedulespb.Schedules_ScheduleSe
 		return fmt.Errorf("first request must be an init request")
 	}
 
-	worker := workers.NewWorkerRequestBroker[*schedulespb.ServerMessage, *schedulespb.ClientMessage](stream)
+	worker := workers.NewWorkerRequestBroker[*schedulespb.ServerMessage, *schedulespb.ClientMessage](stream, newCancellationRequest)
 	if err := s.registerSchedule(worker, initRequest.GetRegistrationRequest()); err != nil {
 		return err
 	}
 
-	defer s.unregisterSchedule(worker)
+	defer s.unregisterSchedule(initRequest.GetRegistrationRequest().GetScheduleName(), worker)
 
 	// send acknowledgement of registration
 	err = stream.Send(&schedulespb.ServerMessage{
@@ -100,32 +110,100 @@ func (s *ScheduleWorkerManager) Schedule(stream schedulespb.Schedules_ScheduleSe
 	return worker.Run()
 }
 
-func (s *ScheduleWorkerManager) HandleRequest(request *schedulespb.ServerMessage) (*schedulespb.ClientMessage, error) {
+// findMatchingWorker selects the worker that should handle a schedule trigger
+func (s *ScheduleWorkerManager) findMatchingWorker(scheduleName ScheduleName) (*WorkerConnection, error) {
 	s.mutex.RLock()
 	defer s.mutex.RUnlock()
 
+	candidates, ok := s.workerMap[scheduleName]
+	if !ok || len(candidates) == 0 {
+		return nil, fmt.Errorf("no worker registered for schedule: %s", scheduleName)
+	}
+
+	return workers.SelectWorker(s.loadBalancer, scheduleName, candidates), nil
+}
+
+func (s *ScheduleWorkerManager) HandleRequest(ctx context.Context, request *schedulespb.ServerMessage) (_ *schedulespb.ClientMessage, err error) {
 	if request.Id == "" {
 		request.Id = workers.GenerateUniqueId()
 	}
 
-	worker, ok := s.workerMap[request.GetIntervalRequest().GetScheduleName()]
+	ctx, end := workers.StartRequest(ctx, workers.TriggerSchedule, request.GetIntervalRequest().GetScheduleName())
+	defer func() { end(err) }()
+
+	request.TraceContext = workers.InjectTraceContext(ctx)
 
-	if !ok {
-		return nil, fmt.Errorf("no worker registered for schedule: %s", request.GetIntervalRequest().GetScheduleName())
+	worker, err := s.findMatchingWorker(request.GetIntervalRequest().GetScheduleName())
+	if err != nil {
+		return nil, err
 	}
 
-	resp, err := worker.Send(request)
+	resp, err := worker.Send(ctx, request)
+	if err != nil {
+		return nil, err
+	}
 
-	return *resp, err
+	return *resp, nil
+}
+
+// Drain waits for in-flight schedule requests to complete, workers stop accepting new requests
+func (s *ScheduleWorkerManager) Drain(ctx context.Context) error {
+	s.mutex.RLock()
+	connections := []*WorkerConnection{}
+	for _, scheduleWorkers := range s.workerMap {
+		connections = append(connections, scheduleWorkers...)
+	}
+	s.mutex.RUnlock()
+
+	return workers.DrainAll(ctx, connections)
+}
+
+// DescribeWorkers lists the workers registered for each schedule
+func (s *ScheduleWorkerManager) DescribeWorkers() []workers.WorkerDescription {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+
+	descriptions := []workers.WorkerDescription{}
+	for scheduleName, scheduleWorkers := range s.workerMap {
+		for _, worker := range scheduleWorkers {
+			descriptions = append(descriptions, workers.WorkerDescription{
+				Name:     scheduleName,
+				InFlight: worker.InFlight(),
+			})
+		}
+	}
+
+	return descriptions
 }
 
 func (s *ScheduleWorkerManager) WorkerCount() int {
-	return len(s.workerMap)
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+
+	total := 0
+	for _, scheduleWorkers := range s.workerMap {
+		total += len(scheduleWorkers)
+	}
+
+	return total
+}
+
+// newCancellationRequest notifies a worker that an in-flight request has been cancelled
+func newCancellationRequest(id workers.RequestIdentifier, reason string) *schedulespb.ServerMessage {
+	return &schedulespb.ServerMessage{
+		Id: id,
+		Content: &schedulespb.ServerMessage_CancellationRequest{
+			CancellationRequest: &schedulespb.CancellationRequest{
+				Reason: reason,
+			},
+		},
+	}
 }
 
 func New() *ScheduleWorkerManager {
 	return &ScheduleWorkerManager{
-		workerMap: make(map[string]*WorkerConnection),
-		mutex:     sync.RWMutex{},
+		workerMap:    make(map[string][]*WorkerConnection),
+		loadBalancer: workers.RoundRobin(),
+		mutex:        sync.RWMutex{},
 	}
 }
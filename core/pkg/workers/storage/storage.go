@@ -15,6 +15,7 @@
 package storage
 
 import (
+	"context"
 	"fmt"
 	"strings"
 	"sync"
@@ -35,7 +36,7 @@ type BucketListenerManager struct {
 
 type BucketRequestHandler interface {
 	storagepb.StorageListenerServer
-	HandleRequest(request *storagepb.ServerMessage) (*storagepb.ClientMessage, error)
+	HandleRequest(ctx context.Context, request *storagepb.ServerMessage) (*storagepb.ClientMessage, error)
 	WorkerCount() int
 }
 
@@ -60,6 +61,44 @@ func (b *BucketListenerManager) WorkerCount() int {
 	return total
 }
 
+var (
+	_ workers.Drainable   = &BucketListenerManager{}
+	_ workers.Describable = &BucketListenerManager{}
+)
+
+// DescribeWorkers lists the listeners registered for each bucket
+func (b *BucketListenerManager) DescribeWorkers() []workers.WorkerDescription {
+	b.mutex.RLock()
+	defer b.mutex.RUnlock()
+
+	descriptions := []workers.WorkerDescription{}
+	for bucketName, listeners := range b.listenerMap {
+		for _, listener := range listeners {
+			descriptions = append(descriptions, workers.WorkerDescription{
+				Name:     bucketName,
+				Trigger:  fmt.Sprintf("%s %s*", listener.eventType.String(), listener.keyPrefixMatch),
+				InFlight: listener.connection.InFlight(),
+			})
+		}
+	}
+
+	return descriptions
+}
+
+// Drain waits for in-flight bucket notifications to complete, listeners stop accepting new notifications
+func (b *BucketListenerManager) Drain(ctx context.Context) error {
+	b.mutex.RLock()
+	connections := []*WorkerConnection{}
+	for _, listeners := range b.listenerMap {
+		for _, listener := range listeners {
+			connections = append(connections, listener.connection)
+		}
+	}
+	b.mutex.RUnlock()
+
+	return workers.DrainAll(ctx, connections)
+}
+
 // findMatchingListener or error if not found, for specific bucket, event type, and key prefix
 func (b *BucketListenerManager) findMatchingListener(bucketName BucketName, eventType storagepb.BlobEventType, key string) (*BucketEventListener, error) {
 	b.mutex.RLock()
@@ -105,7 +144,7 @@ func (b *BucketListenerManager) RegisterNewListener(registration *storagepb.Regi
 		prefixFilter = ""
 	}
 
-	workerConn := workers.NewWorkerRequestBroker[*storagepb.ServerMessage, *storagepb.ClientMessage](stream)
+	workerConn := workers.NewWorkerRequestBroker[*storagepb.ServerMessage, *storagepb.ClientMessage](stream, newCancellationRequest)
 
 	if b.listenerMap[bucketName] == nil {
 		b.listenerMap[bucketName] = make([]*BucketEventListener, 0, 1)
@@ -163,7 +202,7 @@ func (b *BucketListenerManager) Listen(stream storagepb.StorageListener_ListenSe
 }
 
 // HandleRequest processes incoming requests and directs them to the appropriate listener
-func (b *BucketListenerManager) HandleRequest(request *storagepb.ServerMessage) (*storagepb.ClientMessage, error) {
+func (b *BucketListenerManager) HandleRequest(ctx context.Context, request *storagepb.ServerMessage) (_ *storagepb.ClientMessage, err error) {
 	b.mutex.RLock()
 	defer b.mutex.RUnlock()
 
@@ -180,14 +219,34 @@ func (b *BucketListenerManager) HandleRequest(request *storagepb.ServerMessage)
 	eventType := blobEventRequest.GetBlobEvent().GetType()
 	key := blobEventRequest.GetBlobEvent().GetKey()
 
+	ctx, end := workers.StartRequest(ctx, workers.TriggerBucket, bucketID)
+	defer func() { end(err) }()
+
+	request.TraceContext = workers.InjectTraceContext(ctx)
+
 	listener, err := b.findMatchingListener(bucketID, eventType, key)
 	if err != nil {
 		return nil, err
 	}
 
-	response, err := listener.connection.Send(request)
+	response, err := listener.connection.Send(ctx, request)
+	if err != nil {
+		return nil, err
+	}
+
+	return *response, nil
+}
 
-	return *response, err
+// newCancellationRequest notifies a worker that an in-flight request has been cancelled
+func newCancellationRequest(id workers.RequestIdentifier, reason string) *storagepb.ServerMessage {
+	return &storagepb.ServerMessage{
+		Id: id,
+		Content: &storagepb.ServerMessage_CancellationRequest{
+			CancellationRequest: &storagepb.CancellationRequest{
+				Reason: reason,
+			},
+		},
+	}
 }
 
 func New() *BucketListenerManager {
@@ -0,0 +1,188 @@
+// Copyright 2021 Nitric Technologies Pty Ltd.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package workers
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/metric"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/trace"
+)
+
+const instrumentationName = "github.com/nitrictech/nitric/core/pkg/workers"
+
+// The types of trigger handled by workers, used to label telemetry
+const (
+	TriggerApi       = "api"
+	TriggerHttp      = "http"
+	TriggerSchedule  = "schedule"
+	TriggerTopic     = "topic"
+	TriggerBucket    = "bucket"
+	TriggerWebsocket = "websocket"
+	TriggerJob       = "job"
+	TriggerKeyValue  = "keyvalue"
+	TriggerQueue     = "queue"
+)
+
+var (
+	triggerTypeKey = attribute.Key("nitric.trigger.type")
+	triggerNameKey = attribute.Key("nitric.trigger.name")
+)
+
+// traceContextPropagator propagates W3C trace context between triggers, the server and workers
+var traceContextPropagator = propagation.TraceContext{}
+
+type requestInstruments struct {
+	requests metric.Int64Counter
+	errors   metric.Int64Counter
+	duration metric.Float64Histogram
+}
+
+var (
+	instrumentsOnce sync.Once
+	instruments     requestInstruments
+)
+
+// getInstruments lazily creates the request instruments, so they're created with the meter provider configured by the runtime
+func getInstruments() requestInstruments {
+	instrumentsOnce.Do(func() {
+		meter := otel.Meter(instrumentationName)
+
+		// instruments are no-ops if they fail to be created, so errors are reported to the otel error handler rather than returned
+		var err error
+		instruments.requests, err = meter.Int64Counter("nitric.worker.requests",
+			metric.WithDescription("Number of requests sent to workers"),
+			metric.WithUnit("{request}"))
+		if err != nil {
+			otel.Handle(err)
+		}
+
+		instruments.errors, err = meter.Int64Counter("nitric.worker.errors",
+			metric.WithDescription("Number of requests sent to workers that failed"),
+			metric.WithUnit("{request}"))
+		if err != nil {
+			otel.Handle(err)
+		}
+
+		instruments.duration, err = meter.Float64Histogram("nitric.worker.duration",
+			metric.WithDescription("Time taken for workers to respond to requests"),
+			metric.WithUnit("s"))
+		if err != nil {
+			otel.Handle(err)
+		}
+	})
+
+	return instruments
+}
+
+// StartRequest starts a span for a request sent to a worker and counts the request.
+//
+//	The returned function must be called with the request's error once the worker has responded, it ends the span and records
+//	the request's duration and whether it failed.
+func StartRequest(ctx context.Context, triggerType string, name string) (context.Context, func(err error)) {
+	attributes := []attribute.KeyValue{triggerTypeKey.String(triggerType), triggerNameKey.String(name)}
+	measurementOpts := metric.WithAttributes(attributes...)
+
+	ctx, span := otel.Tracer(instrumentationName).Start(ctx, fmt.Sprintf("%s %s", triggerType, name),
+		trace.WithSpanKind(trace.SpanKindServer),
+		trace.WithAttributes(attributes...),
+	)
+
+	start := time.Now()
+	recorders := getInstruments()
+	recorders.requests.Add(ctx, 1, measurementOpts)
+
+	return ctx, func(err error) {
+		recorders.duration.Record(ctx, time.Since(start).Seconds(), measurementOpts)
+
+		if err != nil {
+			recorders.errors.Add(ctx, 1, measurementOpts)
+			span.RecordError(err)
+			span.SetStatus(codes.Error, err.Error())
+		}
+
+		span.End()
+	}
+}
+
+// InjectTraceContext returns the W3C trace context of ctx, sent to workers so they can continue the trace
+func InjectTraceContext(ctx context.Context) map[string]string {
+	carrier := propagation.MapCarrier{}
+	traceContextPropagator.Inject(ctx, carrier)
+
+	return carrier
+}
+
+// ExtractTraceContext returns a copy of ctx with the W3C trace context read from the carrier, e.g. the attributes of a message.
+// ctx is returned unchanged if the carrier has no valid trace context.
+func ExtractTraceContext(ctx context.Context, carrier propagation.TextMapCarrier) context.Context {
+	return traceContextPropagator.Extract(ctx, carrier)
+}
+
+// ObserveWorkers reports the number of registered workers and their in-flight requests as gauges, labelled by trigger type and name.
+//
+//	describe is called on each collection and returns the registered workers by trigger type, unregister the returned
+//	registration to stop observing.
+func ObserveWorkers(describe func() map[string][]WorkerDescription) (metric.Registration, error) {
+	meter := otel.Meter(instrumentationName)
+
+	registered, err := meter.Int64ObservableGauge("nitric.worker.registered",
+		metric.WithDescription("Number of workers registered to handle a trigger"),
+		metric.WithUnit("{worker}"))
+	if err != nil {
+		return nil, err
+	}
+
+	inFlight, err := meter.Int64ObservableGauge("nitric.worker.in_flight",
+		metric.WithDescription("Number of requests workers are currently handling"),
+		metric.WithUnit("{request}"))
+	if err != nil {
+		return nil, err
+	}
+
+	return meter.RegisterCallback(func(_ context.Context, observer metric.Observer) error {
+		type counts struct {
+			workers  int64
+			inFlight int64
+		}
+
+		for triggerType, descriptions := range describe() {
+			byName := map[string]*counts{}
+			for _, description := range descriptions {
+				if byName[description.Name] == nil {
+					byName[description.Name] = &counts{}
+				}
+
+				byName[description.Name].workers++
+				byName[description.Name].inFlight += int64(description.InFlight)
+			}
+
+			for name, c := range byName {
+				attributes := metric.WithAttributes(triggerTypeKey.String(triggerType), triggerNameKey.String(name))
+				observer.ObserveInt64(registered, c.workers, attributes)
+				observer.ObserveInt64(inFlight, c.inFlight, attributes)
+			}
+		}
+
+		return nil
+	}, registered, inFlight)
+}
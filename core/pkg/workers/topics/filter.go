@@ -0,0 +1,88 @@
+// Copyright 2021 Nitric Technologies Pty Ltd.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package topics
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	topicspb "github.com/nitrictech/nitric/core/pkg/proto/topics/v1"
+)
+
+// ValidateFilter returns an error if the attribute filter can't be applied consistently by every provider
+func ValidateFilter(filter *topicspb.AttributeFilter) error {
+	keys := map[string]bool{}
+
+	for _, condition := range filter.GetConditions() {
+		if condition.GetKey() == "" {
+			return fmt.Errorf("attribute filter conditions must provide an attribute key")
+		}
+
+		// providers combine the conditions on a key with OR, so a key can't be constrained by more than one condition
+		if keys[condition.GetKey()] {
+			return fmt.Errorf("attribute %s appears in more than one filter condition", condition.GetKey())
+		}
+		keys[condition.GetKey()] = true
+
+		switch match := condition.GetMatch().(type) {
+		case *topicspb.AttributeCondition_AnyOf:
+			if len(match.AnyOf.GetValues()) == 0 {
+				return fmt.Errorf("filter condition on attribute %s must provide at least one value", condition.GetKey())
+			}
+		case *topicspb.AttributeCondition_NoneOf:
+			if len(match.NoneOf.GetValues()) == 0 {
+				return fmt.Errorf("filter condition on attribute %s must provide at least one value", condition.GetKey())
+			}
+		case *topicspb.AttributeCondition_Prefix:
+			if match.Prefix == "" {
+				return fmt.Errorf("filter condition on attribute %s must provide a prefix", condition.GetKey())
+			}
+		case *topicspb.AttributeCondition_Exists:
+		default:
+			return fmt.Errorf("filter condition on attribute %s must provide a match", condition.GetKey())
+		}
+	}
+
+	return nil
+}
+
+// MatchesFilter returns true if the attributes satisfy every condition of the filter, a nil filter matches all attributes
+func MatchesFilter(filter *topicspb.AttributeFilter, attributes map[string]string) bool {
+	for _, condition := range filter.GetConditions() {
+		value, present := attributes[condition.GetKey()]
+
+		switch match := condition.GetMatch().(type) {
+		case *topicspb.AttributeCondition_AnyOf:
+			if !present || !slices.Contains(match.AnyOf.GetValues(), value) {
+				return false
+			}
+		case *topicspb.AttributeCondition_NoneOf:
+			if !present || slices.Contains(match.NoneOf.GetValues(), value) {
+				return false
+			}
+		case *topicspb.AttributeCondition_Prefix:
+			if !present || !strings.HasPrefix(value, match.Prefix) {
+				return false
+			}
+		case *topicspb.AttributeCondition_Exists:
+			if present != match.Exists {
+				return false
+			}
+		}
+	}
+
+	return true
+}
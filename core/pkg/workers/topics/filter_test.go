@@ -0,0 +1,113 @@
+// Copyright 2021 Nitric Technologies Pty Ltd.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package topics_test
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/ginkgo/extensions/table"
+	. "github.com/onsi/gomega"
+
+	topicspb "github.com/nitrictech/nitric/core/pkg/proto/topics/v1"
+	"github.com/nitrictech/nitric/core/pkg/workers/topics"
+)
+
+func filterOf(conditions ...*topicspb.AttributeCondition) *topicspb.AttributeFilter {
+	return &topicspb.AttributeFilter{Conditions: conditions}
+}
+
+func anyOf(key string, values ...string) *topicspb.AttributeCondition {
+	return &topicspb.AttributeCondition{
+		Key:   key,
+		Match: &topicspb.AttributeCondition_AnyOf{AnyOf: &topicspb.AttributeValues{Values: values}},
+	}
+}
+
+func noneOf(key string, values ...string) *topicspb.AttributeCondition {
+	return &topicspb.AttributeCondition{
+		Key:   key,
+		Match: &topicspb.AttributeCondition_NoneOf{NoneOf: &topicspb.AttributeValues{Values: values}},
+	}
+}
+
+func prefix(key string, prefix string) *topicspb.AttributeCondition {
+	return &topicspb.AttributeCondition{
+		Key:   key,
+		Match: &topicspb.AttributeCondition_Prefix{Prefix: prefix},
+	}
+}
+
+func exists(key string, exists bool) *topicspb.AttributeCondition {
+	return &topicspb.AttributeCondition{
+		Key:   key,
+		Match: &topicspb.AttributeCondition_Exists{Exists: exists},
+	}
+}
+
+var _ = Describe("Attribute filters", func() {
+	DescribeTable("MatchesFilter",
+		func(filter *topicspb.AttributeFilter, attributes map[string]string, matches bool) {
+			Expect(topics.MatchesFilter(filter, attributes)).To(Equal(matches))
+		},
+		Entry("no filter", nil, map[string]string{}, true),
+		Entry("no conditions", filterOf(), map[string]string{"type": "order"}, true),
+
+		Entry("exact match with the value", filterOf(anyOf("type", "order")), map[string]string{"type": "order"}, true),
+		Entry("exact match with another value", filterOf(anyOf("type", "order")), map[string]string{"type": "refund"}, false),
+		Entry("exact match with the attribute absent", filterOf(anyOf("type", "order")), map[string]string{}, false),
+		Entry("exact match is case sensitive", filterOf(anyOf("type", "order")), map[string]string{"type": "Order"}, false),
+
+		Entry("multiple values with the first value", filterOf(anyOf("type", "order", "refund")), map[string]string{"type": "order"}, true),
+		Entry("multiple values with the second value", filterOf(anyOf("type", "order", "refund")), map[string]string{"type": "refund"}, true),
+		Entry("multiple values with another value", filterOf(anyOf("type", "order", "refund")), map[string]string{"type": "invoice"}, false),
+
+		Entry("none of with another value", filterOf(noneOf("type", "test", "debug")), map[string]string{"type": "order"}, true),
+		Entry("none of with an excluded value", filterOf(noneOf("type", "test", "debug")), map[string]string{"type": "debug"}, false),
+		Entry("none of with the attribute absent", filterOf(noneOf("type", "test", "debug")), map[string]string{}, false),
+
+		Entry("prefix match with the prefix", filterOf(prefix("region", "eu-")), map[string]string{"region": "eu-west"}, true),
+		Entry("prefix match with the whole value", filterOf(prefix("region", "eu-")), map[string]string{"region": "eu-"}, true),
+		Entry("prefix match with another prefix", filterOf(prefix("region", "eu-")), map[string]string{"region": "us-east"}, false),
+		Entry("prefix match with the attribute absent", filterOf(prefix("region", "eu-")), map[string]string{}, false),
+
+		Entry("exists with the attribute present", filterOf(exists("priority", true)), map[string]string{"priority": ""}, true),
+		Entry("exists with the attribute absent", filterOf(exists("priority", true)), map[string]string{}, false),
+		Entry("not exists with the attribute absent", filterOf(exists("priority", false)), map[string]string{}, true),
+		Entry("not exists with the attribute present", filterOf(exists("priority", false)), map[string]string{"priority": "high"}, false),
+
+		Entry("multiple conditions all satisfied", filterOf(anyOf("type", "order"), prefix("region", "eu-")), map[string]string{"type": "order", "region": "eu-west"}, true),
+		Entry("multiple conditions partly satisfied", filterOf(anyOf("type", "order"), prefix("region", "eu-")), map[string]string{"type": "order", "region": "us-east"}, false),
+		Entry("multiple conditions with an attribute absent", filterOf(anyOf("type", "order"), prefix("region", "eu-")), map[string]string{"type": "order"}, false),
+	)
+
+	DescribeTable("ValidateFilter should reject filters providers can't apply consistently",
+		func(filter *topicspb.AttributeFilter, message string) {
+			err := topics.ValidateFilter(filter)
+
+			Expect(err).Should(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring(message))
+		},
+		Entry("missing key", filterOf(anyOf("", "order")), "must provide an attribute key"),
+		Entry("repeated key", filterOf(anyOf("type", "order"), prefix("type", "ord")), "more than one filter condition"),
+		Entry("any of without values", filterOf(anyOf("type")), "at least one value"),
+		Entry("none of without values", filterOf(noneOf("type")), "at least one value"),
+		Entry("empty prefix", filterOf(prefix("type", "")), "must provide a prefix"),
+		Entry("missing match", filterOf(&topicspb.AttributeCondition{Key: "type"}), "must provide a match"),
+	)
+
+	It("ValidateFilter should accept valid filters", func() {
+		Expect(topics.ValidateFilter(nil)).To(Succeed())
+		Expect(topics.ValidateFilter(filterOf(anyOf("type", "order"), noneOf("env", "test"), prefix("region", "eu-"), exists("priority", false)))).To(Succeed())
+	})
+})
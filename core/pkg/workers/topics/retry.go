@@ -0,0 +1,42 @@
+// Copyright 2021 Nitric Technologies Pty Ltd.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package topics
+
+import (
+	"fmt"
+
+	topicspb "github.com/nitrictech/nitric/core/pkg/proto/topics/v1"
+)
+
+// ValidateRetryPolicy returns an error if the retry policy is inconsistent, provider specific limits are checked when deploying
+func ValidateRetryPolicy(policy *topicspb.SubscriptionRetryPolicy) error {
+	if policy == nil {
+		return nil
+	}
+
+	if policy.GetMaxAttempts() < 0 {
+		return fmt.Errorf("max attempts must not be negative")
+	}
+
+	if policy.GetMinBackoff().AsDuration() < 0 || policy.GetMaxBackoff().AsDuration() < 0 {
+		return fmt.Errorf("backoff must not be negative")
+	}
+
+	if policy.GetMinBackoff() != nil && policy.GetMaxBackoff() != nil && policy.GetMinBackoff().AsDuration() > policy.GetMaxBackoff().AsDuration() {
+		return fmt.Errorf("min backoff must not be greater than max backoff")
+	}
+
+	return nil
+}
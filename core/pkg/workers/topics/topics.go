@@ -32,26 +32,43 @@ type WorkerConnection = workers.WorkerRequestBroker[*topicspb.ServerMessage, *to
 
 type SubscriptionRequestHandler interface {
 	topicspb.SubscriberServer
-	HandleRequest(request *topicspb.ServerMessage) (*topicspb.ClientMessage, error)
+	HandleRequest(ctx context.Context, request *topicspb.ServerMessage) (*topicspb.ClientMessage, error)
 	WorkerCount() int
 }
 
+// Subscriber is a worker subscribed to the messages of a topic that match its filter
+type Subscriber struct {
+	connection *WorkerConnection
+	filter     *topicspb.AttributeFilter
+}
+
 type SubscriberManager struct {
-	subscriberMap map[TopicName][]*WorkerConnection
+	subscriberMap map[TopicName][]*Subscriber
 	lock          sync.RWMutex
 }
 
 func (s *SubscriberManager) registerSubscriber(subscriber *WorkerConnection, registrationRequest *topicspb.RegistrationRequest) error {
+	if err := ValidateFilter(registrationRequest.GetFilter()); err != nil {
+		return fmt.Errorf("invalid subscription filter: %w", err)
+	}
+
+	if err := ValidateRetryPolicy(registrationRequest.GetRetryPolicy()); err != nil {
+		return fmt.Errorf("invalid subscription retry policy: %w", err)
+	}
+
 	s.lock.Lock()
 	defer s.lock.Unlock()
 
 	topicName := registrationRequest.GetTopicName()
 
 	if _, exists := s.subscriberMap[topicName]; !exists {
-		s.subscriberMap[topicName] = make([]*WorkerConnection, 0, 1)
+		s.subscriberMap[topicName] = make([]*Subscriber, 0, 1)
 	}
 
-	s.subscriberMap[topicName] = append(s.subscriberMap[topicName], subscriber)
+	s.subscriberMap[topicName] = append(s.subscriberMap[topicName], &Subscriber{
+		connection: subscriber,
+		filter:     registrationRequest.GetFilter(),
+	})
 
 	return nil
 }
@@ -60,8 +77,8 @@ func (s *SubscriberManager) unregisterSubscriber(topicName string, subscriber *W
 	s.lock.Lock()
 	defer s.lock.Unlock()
 
-	s.subscriberMap[topicName] = slices.DeleteFunc[[]*WorkerConnection](s.subscriberMap[topicName], func(wc *WorkerConnection) bool {
-		return wc == subscriber
+	s.subscriberMap[topicName] = slices.DeleteFunc(s.subscriberMap[topicName], func(sub *Subscriber) bool {
+		return sub.connection == subscriber
 	})
 
 	if len(s.subscriberMap[topicName]) == 0 {
@@ -69,6 +86,43 @@ func (s *SubscriberManager) unregisterSubscriber(topicName string, subscriber *W
 	}
 }
 
+var (
+	_ workers.Drainable   = &SubscriberManager{}
+	_ workers.Describable = &SubscriberManager{}
+)
+
+// Drain waits for in-flight topic deliveries to complete, subscribers stop accepting new messages
+func (s *SubscriberManager) Drain(ctx context.Context) error {
+	s.lock.RLock()
+	connections := []*WorkerConnection{}
+	for _, subscribers := range s.subscriberMap {
+		for _, subscriber := range subscribers {
+			connections = append(connections, subscriber.connection)
+		}
+	}
+	s.lock.RUnlock()
+
+	return workers.DrainAll(ctx, connections)
+}
+
+// DescribeWorkers lists the subscribers registered for each topic
+func (s *SubscriberManager) DescribeWorkers() []workers.WorkerDescription {
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+
+	descriptions := []workers.WorkerDescription{}
+	for topicName, subscribers := range s.subscriberMap {
+		for _, subscriber := range subscribers {
+			descriptions = append(descriptions, workers.WorkerDescription{
+				Name:     topicName,
+				InFlight: subscriber.connection.InFlight(),
+			})
+		}
+	}
+
+	return descriptions
+}
+
 func (s *SubscriberManager) WorkerCount() int {
 	s.lock.RLock()
 	defer s.lock.RUnlock()
@@ -97,7 +151,7 @@ func (s *SubscriberManager) Subscribe(subscriberConnectionStream topicspb.Subscr
 		return fmt.Errorf("request received from unregistered subscriber, initial request must be a registration request. %s", help.BugInNitricHelpText())
 	}
 
-	subscriber := workers.NewWorkerRequestBroker[*topicspb.ServerMessage, *topicspb.ClientMessage](subscriberConnectionStream)
+	subscriber := workers.NewWorkerRequestBroker[*topicspb.ServerMessage, *topicspb.ClientMessage](subscriberConnectionStream, newCancellationRequest)
 	if err := s.registerSubscriber(subscriber, registrationRequest); err != nil {
 		return err
 	}
@@ -122,28 +176,37 @@ func (s *SubscriberManager) Subscribe(subscriberConnectionStream topicspb.Subscr
 	return nil
 }
 
-// findMatchingSubscriber returns the subscribers for a given topic, or an error if none are found
-func (s *SubscriberManager) findMatchingSubscriber(topicName string) ([]*WorkerConnection, error) {
+// findMatchingSubscriber returns the subscribers for a given topic whose filters match the message, or an error if none are registered
+func (s *SubscriberManager) findMatchingSubscriber(topicName string, message *topicspb.TopicMessage) ([]*WorkerConnection, error) {
 	s.lock.RLock()
 	defer s.lock.RUnlock()
-	workers, ok := s.subscriberMap[topicName]
-	if !ok || len(workers) == 0 {
+
+	subscribers, ok := s.subscriberMap[topicName]
+	if !ok || len(subscribers) == 0 {
 		return nil, fmt.Errorf("no workers registered for topic subscription: %s", topicName)
 	}
 
-	return workers, nil
+	// providers filter messages before delivering them too, filtering again keeps delivery consistent where their matching differs (e.g. case-insensitive values)
+	matching := []*WorkerConnection{}
+	for _, subscriber := range subscribers {
+		if MatchesFilter(subscriber.filter, message.GetAttributes()) {
+			matching = append(matching, subscriber.connection)
+		}
+	}
+
+	return matching, nil
 }
 
 // ForwardRequestToSubscribers forwards an event to all subscribers for a given topic
 // returns a slice of errors encountered while forwarding the event
-func ForwardRequestToSubscribers(subscribers []*WorkerConnection, request *topicspb.ServerMessage) (bool, error) {
+func ForwardRequestToSubscribers(ctx context.Context, subscribers []*WorkerConnection, request *topicspb.ServerMessage) (bool, error) {
 	success := true
-	errs, _ := errgroup.WithContext(context.Background())
+	errs := errgroup.Group{}
 
 	for _, subscriber := range subscribers {
 		footLongSub := subscriber
 		errs.Go(func() error {
-			resp, err := footLongSub.Send(request)
+			resp, err := footLongSub.Send(ctx, request)
 			if err != nil {
 				return err
 			} else if !(*resp).GetMessageResponse().GetSuccess() {
@@ -155,13 +218,13 @@ func ForwardRequestToSubscribers(subscribers []*WorkerConnection, request *topic
 
 	err := errs.Wait()
 	if err != nil {
-		return false, fmt.Errorf("errors occurred handling subscription %v", errs)
+		return false, fmt.Errorf("errors occurred handling subscription: %w", err)
 	}
 
 	return success, nil
 }
 
-func (s *SubscriberManager) HandleRequest(request *topicspb.ServerMessage) (*topicspb.ClientMessage, error) {
+func (s *SubscriberManager) HandleRequest(ctx context.Context, request *topicspb.ServerMessage) (_ *topicspb.ClientMessage, err error) {
 	if request.Id == "" {
 		request.Id = workers.GenerateUniqueId()
 	}
@@ -172,12 +235,18 @@ func (s *SubscriberManager) HandleRequest(request *topicspb.ServerMessage) (*top
 	}
 	topicName := messageRequest.GetTopicName()
 
-	subscribers, err := s.findMatchingSubscriber(topicName)
+	ctx, end := workers.StartRequest(ctx, workers.TriggerTopic, topicName)
+	defer func() { end(err) }()
+
+	// every subscriber receives the same trace context, continuing the publisher's trace if it was propagated with the message
+	request.TraceContext = workers.InjectTraceContext(ctx)
+
+	subscribers, err := s.findMatchingSubscriber(topicName, messageRequest.GetMessage())
 	if err != nil {
 		return nil, err
 	}
 
-	success, err := ForwardRequestToSubscribers(subscribers, request)
+	success, err := ForwardRequestToSubscribers(ctx, subscribers, request)
 	if err != nil {
 		return nil, err
 	}
@@ -198,9 +267,21 @@ func (s *SubscriberManager) HandleRequest(request *topicspb.ServerMessage) (*top
 	}, nil
 }
 
+// newCancellationRequest notifies a worker that an in-flight request has been cancelled
+func newCancellationRequest(id workers.RequestIdentifier, reason string) *topicspb.ServerMessage {
+	return &topicspb.ServerMessage{
+		Id: id,
+		Content: &topicspb.ServerMessage_CancellationRequest{
+			CancellationRequest: &topicspb.CancellationRequest{
+				Reason: reason,
+			},
+		},
+	}
+}
+
 func New() *SubscriberManager {
 	return &SubscriberManager{
-		subscriberMap: make(map[string][]*WorkerConnection),
+		subscriberMap: make(map[string][]*Subscriber),
 		lock:          sync.RWMutex{},
 	}
 }
@@ -15,7 +15,9 @@
 package websockets
 
 import (
+	"context"
 	"fmt"
+	"slices"
 	"strings"
 	"sync"
 
@@ -29,14 +31,29 @@ type WorkerConnection = workers.WorkerRequestBroker[*websocketspb.ServerMessage,
 
 type WebsocketRequestHandler interface {
 	websocketspb.WebsocketHandlerServer
-	HandleRequest(request *websocketspb.ServerMessage) (*websocketspb.ClientMessage, error)
+	HandleRequest(ctx context.Context, request *websocketspb.ServerMessage) (*websocketspb.ClientMessage, error)
 	WorkerCount() int
 }
 
 // WebsocketManager manages connections and event handlers for websockets
 type WebsocketManager struct {
-	handlers map[string]*WorkerConnection
-	mutex    sync.RWMutex
+	handlers     map[string][]*WorkerConnection
+	loadBalancer workers.LoadBalancer
+	mutex        sync.RWMutex
+}
+
+var (
+	_ workers.LoadBalanced = (*WebsocketManager)(nil)
+	_ workers.Drainable    = (*WebsocketManager)(nil)
+	_ workers.Describable  = (*WebsocketManager)(nil)
+)
+
+// SetLoadBalancer sets the strategy used to choose between handlers registered for the same socket event
+func (wm *WebsocketManager) SetLoadBalancer(lb workers.LoadBalancer) {
+	wm.mutex.Lock()
+	defer wm.mutex.Unlock()
+
+	wm.loadBalancer = lb
 }
 
 // generateHandlerKey creates a unique identifier for a websocket event handler
@@ -44,9 +61,51 @@ func generateHandlerKey(socketName string, eventType websocketspb.WebsocketEvent
 	return strings.ToLower(fmt.Sprintf("%s-%s", socketName, eventType.String()))
 }
 
+// Drain waits for in-flight websocket events to complete, handlers stop accepting new events
+func (wm *WebsocketManager) Drain(ctx context.Context) error {
+	wm.mutex.RLock()
+	connections := []*WorkerConnection{}
+	for _, handlers := range wm.handlers {
+		connections = append(connections, handlers...)
+	}
+	wm.mutex.RUnlock()
+
+	return workers.DrainAll(ctx, connections)
+}
+
+// DescribeWorkers lists the handlers registered for each socket event
+func (wm *WebsocketManager) DescribeWorkers() []workers.WorkerDescription {
+	wm.mutex.RLock()
+	defer wm.mutex.RUnlock()
+
+	descriptions := []workers.WorkerDescription{}
+	for key, handlers := range wm.handlers {
+		// handler keys are the socket name followed by the event type, which never contains a dash
+		separator := strings.LastIndex(key, "-")
+
+		for _, handler := range handlers {
+			descriptions = append(descriptions, workers.WorkerDescription{
+				Name:     key[:separator],
+				Trigger:  key[separator+1:],
+				InFlight: handler.InFlight(),
+			})
+		}
+	}
+
+	return descriptions
+}
+
 // WorkerCount returns the total number of websocket handlers
 func (wm *WebsocketManager) WorkerCount() int {
-	return len(wm.handlers)
+	wm.mutex.RLock()
+	defer wm.mutex.RUnlock()
+
+	total := 0
+	for _, eventHandlers := range wm.handlers {
+		total += len(eventHandlers)
+	}
+
+	return total
 }
 
 // registerHandler adds a new handler to the manager
@@ -59,28 +118,24 @@ func (wm *WebsocketManager) registerHandler(handler *WorkerConnection, registrat
 
 	handlerKey := generateHandlerKey(socketName, eventType)
 
-	if _, exists := wm.handlers[handlerKey]; exists {
-		return fmt.Errorf("websocket handler already registered, socket: %s eventType: %s", socketName, eventType.String())
-	}
-
-	wm.handlers[handlerKey] = handler
+	// multiple handlers may register for the same socket event, events are balanced between them
+	wm.handlers[handlerKey] = append(wm.handlers[handlerKey], handler)
 	return nil
 }
 
-func (wm *WebsocketManager) unregisterHandler(handler *WorkerConnection) {
+func (wm *WebsocketManager) unregisterHandler(registrationRequest *websocketspb.RegistrationRequest, handler *WorkerConnection) {
 	wm.mutex.Lock()
 	defer wm.mutex.Unlock()
 
-	var resultKey string
+	handlerKey := generateHandlerKey(registrationRequest.SocketName, registrationRequest.EventType)
 
-	for k, wrb := range wm.handlers {
-		if wrb == handler {
-			resultKey = k
-			break
-		}
-	}
+	wm.handlers[handlerKey] = slices.DeleteFunc(wm.handlers[handlerKey], func(wrb *WorkerConnection) bool {
+		return wrb == handler
+	})
 
-	delete(wm.handlers, resultKey)
+	if len(wm.handlers[handlerKey]) == 0 {
+		delete(wm.handlers, handlerKey)
+	}
 }
 
 // ManageEventHandlers handles the registration of new websocket event handlers
@@ -95,12 +150,12 @@ func (wm *WebsocketManager) HandleEvents(stream websocketspb.WebsocketHandler_Ha
 		return fmt.Errorf("unregistered websocket handler, expected a registration request. %s", help.BugInNitricHelpText())
 	}
 
-	handler := workers.NewWorkerRequestBroker[*websocketspb.ServerMessage, *websocketspb.ClientMessage](stream)
+	handler := workers.NewWorkerRequestBroker[*websocketspb.ServerMessage, *websocketspb.ClientMessage](stream, newCancellationRequest)
 	if err := wm.registerHandler(handler, registration); err != nil {
 		return err
 	}
 
-	defer wm.unregisterHandler(handler)
+	defer wm.unregisterHandler(registration, handler)
 
 	err = stream.Send(&websocketspb.ServerMessage{
 		Content: &websocketspb.ServerMessage_RegistrationResponse{
@@ -121,16 +176,16 @@ func (wm *WebsocketManager) FindMatchingHandler(socketName string, eventType web
 
 	handlerKey := generateHandlerKey(socketName, eventType)
 
-	handler, exists := wm.handlers[handlerKey]
-	if !exists {
+	candidates, exists := wm.handlers[handlerKey]
+	if !exists || len(candidates) == 0 {
 		return nil, fmt.Errorf("no handlers for socket: %s and eventType: %s", socketName, eventType.String())
 	}
 
-	return handler, nil
+	return workers.SelectWorker(wm.loadBalancer, handlerKey, candidates), nil
 }
 
 // HandleRequest handles incoming requests and forwards them to the appropriate handler
-func (wm *WebsocketManager) HandleRequest(request *websocketspb.ServerMessage) (*websocketspb.ClientMessage, error) {
+func (wm *WebsocketManager) HandleRequest(ctx context.Context, request *websocketspb.ServerMessage) (_ *websocketspb.ClientMessage, err error) {
 	eventRequest := request.GetWebsocketEventRequest()
 	if eventRequest == nil {
 		return nil, fmt.Errorf("invalid request, expected a websocket event request. %s", help.BugInNitricHelpText())
@@ -143,12 +198,17 @@ func (wm *WebsocketManager) HandleRequest(request *websocketspb.ServerMessage) (
 	socketName := eventRequest.SocketName
 	eventType := determineEventType(eventRequest)
 
+	ctx, end := workers.StartRequest(ctx, workers.TriggerWebsocket, socketName)
+	defer func() { end(err) }()
+
+	request.TraceContext = workers.InjectTraceContext(ctx)
+
 	handler, err := wm.FindMatchingHandler(socketName, eventType)
 	if err != nil {
 		return nil, err
 	}
 
-	response, err := handler.Send(request)
+	response, err := handler.Send(ctx, request)
 	if err != nil {
 		return nil, err
 	}
@@ -167,9 +227,22 @@ func determineEventType(eventRequest *websocketspb.WebsocketEventRequest) websoc
 }
 
 // NewWebsocketManager creates a new instance of WebsocketManager
+// newCancellationRequest notifies a worker that an in-flight request has been cancelled
+func newCancellationRequest(id workers.RequestIdentifier, reason string) *websocketspb.ServerMessage {
+	return &websocketspb.ServerMessage{
+		Id: id,
+		Content: &websocketspb.ServerMessage_CancellationRequest{
+			CancellationRequest: &websocketspb.CancellationRequest{
+				Reason: reason,
+			},
+		},
+	}
+}
+
 func NewWebsocketManager() *WebsocketManager {
 	return &WebsocketManager{
-		handlers: make(map[string]*WorkerConnection),
-		mutex:    sync.RWMutex{},
+		handlers:     make(map[string][]*WorkerConnection),
+		loadBalancer: workers.RoundRobin(),
+		mutex:        sync.RWMutex{},
 	}
 }